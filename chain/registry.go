@@ -0,0 +1,165 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ChainSafe/gossamer/chain/kusama"
+	"github.com/ChainSafe/gossamer/chain/paseo"
+	"github.com/ChainSafe/gossamer/chain/polkadot"
+	"github.com/ChainSafe/gossamer/chain/westend"
+	westenddev "github.com/ChainSafe/gossamer/chain/westend-dev"
+	westendlocal "github.com/ChainSafe/gossamer/chain/westend-local"
+	cfg "github.com/ChainSafe/gossamer/config"
+)
+
+// specBaseURL is where the raw chain-specs shipped with the source tree can be downloaded from
+const specBaseURL = "https://raw.githubusercontent.com/ChainSafe/gossamer/development/chain/"
+
+const defaultDownloadTimeout = 5 * time.Minute
+
+var (
+	// ErrUnknownChain is returned when a chain name is not in the registry
+	ErrUnknownChain = errors.New("unknown chain")
+	// ErrChainSpecDownload is returned when a chain-spec cannot be downloaded
+	ErrChainSpecDownload = errors.New("failed to download chain-spec")
+)
+
+// Network is a chain known to gossamer
+type Network struct {
+	Name cfg.Chain
+	// DefaultConfig returns the default node configuration for the chain, including
+	// its default base path and the path of its chain-spec in the source tree
+	DefaultConfig func() *cfg.Config
+	// SpecURL is where the raw chain-spec is downloaded from when it is not available locally
+	SpecURL string
+}
+
+var registry = map[cfg.Chain]Network{
+	cfg.PolkadotChain: {
+		Name:          cfg.PolkadotChain,
+		DefaultConfig: polkadot.DefaultConfig,
+		SpecURL:       specBaseURL + "polkadot/chain-spec-raw.json",
+	},
+	cfg.KusamaChain: {
+		Name:          cfg.KusamaChain,
+		DefaultConfig: kusama.DefaultConfig,
+		SpecURL:       specBaseURL + "kusama/chain-spec-raw.json",
+	},
+	cfg.WestendChain: {
+		Name:          cfg.WestendChain,
+		DefaultConfig: westend.DefaultConfig,
+		SpecURL:       specBaseURL + "westend/chain-spec-raw.json",
+	},
+	cfg.WestendDevChain: {
+		Name:          cfg.WestendDevChain,
+		DefaultConfig: westenddev.DefaultConfig,
+		SpecURL:       specBaseURL + "westend-dev/westend-dev-spec-raw.json",
+	},
+	cfg.WestendLocalChain: {
+		Name:          cfg.WestendLocalChain,
+		DefaultConfig: westendlocal.DefaultConfig,
+		SpecURL:       specBaseURL + "westend-local/westend-local-spec-raw.json",
+	},
+	cfg.PaseoChain: {
+		Name:          cfg.PaseoChain,
+		DefaultConfig: paseo.DefaultConfig,
+		SpecURL:       specBaseURL + "paseo/chain-spec-raw.json",
+	},
+}
+
+// Lookup returns the registered network with the given name
+func Lookup(name string) (Network, error) {
+	network, ok := registry[cfg.Chain(name)]
+	if !ok {
+		return Network{}, fmt.Errorf("%w: %s, expected a chain-spec path or one of %v", ErrUnknownChain, name, Names())
+	}
+	return network, nil
+}
+
+// Names returns the sorted names of all registered networks
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveChainSpec returns the path of the network's raw chain-spec. The given local path is used
+// if it exists, then a chain-spec previously stored in the base path. Otherwise the chain-spec
+// is downloaded from the network's SpecURL into the base path.
+func (n Network) ResolveChainSpec(ctx context.Context, localPath, basePath string) (string, error) {
+	if _, err := os.Stat(localPath); err == nil {
+		return localPath, nil
+	}
+
+	destination := cfg.GetChainSpec(basePath)
+	if _, err := os.Stat(destination); err == nil {
+		return destination, nil
+	}
+
+	if n.SpecURL == "" {
+		return "", fmt.Errorf("%w: no chain-spec found for %s", ErrChainSpecDownload, n.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultDownloadTimeout)
+	defer cancel()
+	if err := download(ctx, n.SpecURL, destination); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrChainSpecDownload, err)
+	}
+	return destination, nil
+}
+
+// download writes the content at url to destination, leaving no file behind on failure
+func download(ctx context.Context, url, destination string) (err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(destination), filepath.Base(destination)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing chain-spec: %w", err)
+	}
+
+	return os.Rename(file.Name(), destination)
+}
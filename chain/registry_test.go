@@ -0,0 +1,96 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range Names() {
+		network, err := Lookup(name)
+		require.NoError(t, err)
+		assert.Equal(t, cfg.Chain(name), network.Name)
+
+		config := network.DefaultConfig()
+		assert.NotEmpty(t, config.BasePath)
+		assert.NotEmpty(t, config.ChainSpec)
+		assert.Equal(t, filepath.Base(config.ChainSpec), filepath.Base(network.SpecURL))
+	}
+
+	_, err := Lookup("rococo")
+	assert.ErrorIs(t, err, ErrUnknownChain)
+}
+
+func TestNetwork_ResolveChainSpec(t *testing.T) {
+	t.Parallel()
+
+	const spec = `{"name":"Test"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chain-spec-raw.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(spec))
+	}))
+	t.Cleanup(server.Close)
+
+	localPath := filepath.Join(t.TempDir(), "local.json")
+	require.NoError(t, os.WriteFile(localPath, []byte(spec), 0600))
+
+	testCases := map[string]struct {
+		specURL      string
+		localPath    string
+		expectedPath func(basePath string) string
+		errIs        error
+	}{
+		"local_chain_spec": {
+			localPath:    localPath,
+			expectedPath: func(string) string { return localPath },
+		},
+		"download": {
+			specURL:      server.URL + "/chain-spec-raw.json",
+			localPath:    "./missing.json",
+			expectedPath: cfg.GetChainSpec,
+		},
+		"download_not_found": {
+			specURL:   server.URL + "/missing.json",
+			localPath: "./missing.json",
+			errIs:     ErrChainSpecDownload,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			basePath := t.TempDir()
+			network := Network{Name: "test", SpecURL: testCase.specURL}
+			path, err := network.ResolveChainSpec(context.Background(), testCase.localPath, basePath)
+			if testCase.errIs != nil {
+				assert.ErrorIs(t, err, testCase.errIs)
+				assert.NoFileExists(t, cfg.GetChainSpec(basePath))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPath(basePath), path)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, spec, string(data))
+		})
+	}
+}
@@ -16,7 +16,9 @@ func init() {
 	AccountCmd.Flags().String("keystore-path", "", "path to keystore")
 	AccountCmd.Flags().String("keystore-file", "", "name of keystore file to import")
 	AccountCmd.Flags().String("password", "", "password used to encrypt the keystore. Used with --generate or --unlock")
-	AccountCmd.Flags().String("scheme", crypto.Sr25519Type, "keyring scheme (sr25519, ed25519, secp256k1)")
+	AccountCmd.Flags().String("scheme", crypto.Sr25519Type, "keyring scheme (sr25519, ed25519, secp256k1, bls381)")
+	AccountCmd.Flags().String("suri", "",
+		"secret URI of the key, a BIP39 mnemonic or 0x prefixed seed with an optional derivation path, eg. '<phrase>//stash'")
 }
 
 // AccountCmd is the command to manage the gossamer keystore
@@ -34,7 +36,13 @@ func init() {
 	gossamer account import --keystore-path=path/to/location --keystore-file=keystore.json
 To import a raw key:
 	gossamer account import-raw --keystore-path=path/to/location --keystore-file=keystore.json
-To list keys: gossamer account list --keystore-path=path/to/location`,
+To list keys: gossamer account list --keystore-path=path/to/location
+To generate a new BIP39 mnemonic:
+	gossamer account generate-mnemonic
+To inspect a key derived from a mnemonic:
+	gossamer account inspect --suri="<mnemonic>//stash" --scheme=sr25519
+To insert a key derived from a mnemonic into the keystore:
+	gossamer account insert --keystore-path=path/to/location --suri="<mnemonic>//controller" --scheme=ed25519`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			logger.Errorf("account command cannot be empty")
@@ -58,6 +66,18 @@ func init() {
 			if err := listKeys(cmd); err != nil {
 				return err
 			}
+		case "generate-mnemonic":
+			if err := generateMnemonic(cmd); err != nil {
+				return err
+			}
+		case "inspect":
+			if err := inspectKey(cmd); err != nil {
+				return err
+			}
+		case "insert":
+			if err := insertKey(cmd); err != nil {
+				return err
+			}
 		default:
 			logger.Errorf("invalid account command: %s", args[0])
 			return fmt.Errorf("invalid account command: %s", args[0])
@@ -81,7 +101,7 @@ func generateKeyPair(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to get scheme: %s", err)
 	}
-	if !(scheme == crypto.Ed25519Type || scheme == crypto.Sr25519Type || scheme == crypto.Secp256k1Type) {
+	if !isValidScheme(scheme) {
 		return fmt.Errorf("invalid scheme: %s", scheme)
 	}
 
@@ -152,7 +172,7 @@ func importRawKey(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("failed to get scheme: %s", err)
 	}
-	if !(scheme == crypto.Ed25519Type || scheme == crypto.Sr25519Type || scheme == crypto.Secp256k1Type) {
+	if !isValidScheme(scheme) {
 		return fmt.Errorf("invalid scheme: %s", scheme)
 	}
 
@@ -190,3 +210,96 @@ func listKeys(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// generateMnemonic prints a new BIP39 mnemonic
+func generateMnemonic(cmd *cobra.Command) error {
+	mnemonic, err := crypto.NewBIP39Mnemonic()
+	if err != nil {
+		return fmt.Errorf("failed to generate mnemonic: %s", err)
+	}
+
+	cmd.Println(mnemonic)
+	return nil
+}
+
+// keypairFromFlags returns the keypair described by the suri and scheme flags
+func keypairFromFlags(cmd *cobra.Command) (keystore.PublicPrivater, error) {
+	suri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suri: %s", err)
+	}
+	if suri == "" {
+		return nil, fmt.Errorf("suri cannot be empty")
+	}
+
+	scheme, err := cmd.Flags().GetString("scheme")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheme: %s", err)
+	}
+	if !isValidScheme(scheme) {
+		return nil, fmt.Errorf("invalid scheme: %s", scheme)
+	}
+
+	kp, err := keystore.DecodeKeyPairFromSecretURI(suri, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive keypair: %w", err)
+	}
+	return kp, nil
+}
+
+// inspectKey prints the public key and address of the key described by the suri flag
+func inspectKey(cmd *cobra.Command) error {
+	kp, err := keypairFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Public key (hex): %s\n", kp.Public().Hex())
+	cmd.Printf("SS58 Address:     %s\n", kp.Public().Address())
+	return nil
+}
+
+// insertKey derives the key described by the suri flag and saves it to the keystore
+func insertKey(cmd *cobra.Command) error {
+	keystorePath, err := cmd.Flags().GetString("keystore-path")
+	if err != nil {
+		return fmt.Errorf("failed to get keystore-path: %s", err)
+	}
+	if keystorePath == "" {
+		return fmt.Errorf("keystore-path cannot be empty")
+	}
+
+	password, err := cmd.Flags().GetString("password")
+	if err != nil {
+		return fmt.Errorf("failed to get password: %s", err)
+	}
+
+	kp, err := keypairFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := cmd.Flags().GetString("scheme")
+	if err != nil {
+		return fmt.Errorf("failed to get scheme: %s", err)
+	}
+
+	file, err := keystore.GenerateKeypair(scheme, kp, keystorePath, []byte(password))
+	if err != nil {
+		logger.Errorf("failed to insert keypair: %s", err)
+		return err
+	}
+
+	logger.Infof("keypair %s inserted and saved to %s", kp.Public().Hex(), file)
+	return nil
+}
+
+// isValidScheme returns true if the keyring scheme given is supported
+func isValidScheme(scheme string) bool {
+	switch scheme {
+	case crypto.Ed25519Type, crypto.Sr25519Type, crypto.Secp256k1Type, crypto.Bls381Type:
+		return true
+	default:
+		return false
+	}
+}
@@ -4,7 +4,9 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -132,3 +134,36 @@ func TestAccountList(t *testing.T) {
 	err = rootCmd.Execute()
 	require.NoError(t, err)
 }
+
+// TestAccountInspect test "gossamer account inspect --suri=//Alice"
+func TestAccountInspect(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	out := bytes.NewBuffer(nil)
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs([]string{"account", "inspect", "--suri=//Alice", "--scheme=sr25519"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+}
+
+// TestAccountInsert test "gossamer account insert --suri=//Alice//stash"
+func TestAccountInsert(t *testing.T) {
+	testDir := t.TempDir()
+	directory := fmt.Sprintf("--keystore-path=%s", testDir)
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	rootCmd.SetArgs([]string{"account", "insert", directory, "--suri=//Alice//stash", "--scheme=sr25519"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(testDir, "keystore", "be5ddb1579b72e84524fc29e78609e3caf42e85aa118ebfe0b0ad404b5bdd25f.key")
+	require.FileExists(t, keyFile)
+}
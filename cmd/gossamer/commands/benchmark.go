@@ -0,0 +1,202 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/tryruntime"
+	"github.com/ChainSafe/gossamer/internal/sysinfo"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	BenchmarkRuntimeCmd.Flags().String("wasm", "", "path to the runtime wasm to benchmark")
+	BenchmarkRuntimeCmd.Flags().String("method", "", "runtime entrypoint to call, for example Core_version")
+	BenchmarkRuntimeCmd.Flags().Int("runs", 100, "number of calls of the runtime entrypoint")
+	BenchmarkRuntimeCmd.Flags().String("input", "",
+		"hex encoded input given to every call, defaults to random inputs of --input-size bytes")
+	BenchmarkRuntimeCmd.Flags().Int("input-size", 0, "size in bytes of the random inputs generated for each call")
+	BenchmarkMachineCmd.Flags().Duration("duration", 5*time.Second, "duration of the benchmark of each metric")
+	BenchmarkMachineCmd.Flags().Bool("allow-fail", false, "do not return an error if the requirements are not met")
+	BenchmarkCmd.AddCommand(BenchmarkRuntimeCmd, BenchmarkMachineCmd)
+}
+
+// BenchmarkCmd is the command grouping the benchmark commands
+var BenchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Benchmark the node components",
+}
+
+// BenchmarkRuntimeCmd is the command to benchmark a runtime entrypoint
+var BenchmarkRuntimeCmd = &cobra.Command{
+	Use:   "runtime",
+	Short: "Measure the execution time and host function calls of a runtime entrypoint",
+	Long: `runtime calls the runtime entrypoint given repeatedly on top of an empty state
+and prints the execution times and the profile of the host functions called.
+Usage:
+	gossamer benchmark runtime --wasm runtime.wasm --method Core_version --runs 1000
+	gossamer benchmark runtime --wasm runtime.wasm --method TaggedTransactionQueue_validate_transaction --input-size 128`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execBenchmarkRuntime(cmd)
+	},
+}
+
+// BenchmarkMachineCmd is the command to benchmark the hardware against the reference hardware
+var BenchmarkMachineCmd = &cobra.Command{
+	Use:   "machine",
+	Short: "Measure the hardware performance against the reference hardware requirements",
+	Long: `machine measures the hashing, signature verification, memory copy and disk write
+throughputs of the machine and compares them to the Polkadot reference hardware.
+The disk is benchmarked by writing files in --base-path.
+Usage:
+	gossamer benchmark machine --base-path ~/.gossamer/westend
+	gossamer benchmark machine --duration 10s --allow-fail`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execBenchmarkMachine(cmd)
+	},
+}
+
+// execBenchmarkMachine executes the benchmark machine command
+func execBenchmarkMachine(cmd *cobra.Command) error {
+	duration, err := cmd.Flags().GetDuration("duration")
+	if err != nil {
+		return fmt.Errorf("failed to get duration: %s", err)
+	}
+	allowFail, err := cmd.Flags().GetBool("allow-fail")
+	if err != nil {
+		return fmt.Errorf("failed to get allow-fail: %s", err)
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	scores, err := sysinfo.Benchmark(utils.ExpandDir(basePath), duration, sysinfo.ReferenceHardware)
+	if err != nil {
+		return err
+	}
+
+	passed := printMachineScores(cmd.OutOrStdout(), scores)
+	if !passed && !allowFail {
+		return fmt.Errorf("the hardware does not meet the reference hardware requirements")
+	}
+	return nil
+}
+
+// printMachineScores prints the scores given and returns true if all of them passed.
+func printMachineScores(w io.Writer, scores []sysinfo.Score) (passed bool) {
+	var passes int
+	for _, score := range scores {
+		fmt.Fprintln(w, score)
+		if score.Passed() {
+			passes++
+		}
+	}
+
+	fmt.Fprintf(w, "score: %d/%d requirements met\n", passes, len(scores))
+	return passes == len(scores)
+}
+
+// execBenchmarkRuntime executes the benchmark runtime command
+func execBenchmarkRuntime(cmd *cobra.Command) error {
+	wasmFile, err := cmd.Flags().GetString("wasm")
+	if err != nil {
+		return fmt.Errorf("failed to get wasm: %s", err)
+	}
+	if wasmFile == "" {
+		return fmt.Errorf("wasm must be specified")
+	}
+	code, err := os.ReadFile(filepath.Clean(wasmFile))
+	if err != nil {
+		return fmt.Errorf("reading wasm file: %w", err)
+	}
+
+	method, err := cmd.Flags().GetString("method")
+	if err != nil {
+		return fmt.Errorf("failed to get method: %s", err)
+	}
+	if method == "" {
+		return fmt.Errorf("method must be specified")
+	}
+
+	runs, err := cmd.Flags().GetInt("runs")
+	if err != nil {
+		return fmt.Errorf("failed to get runs: %s", err)
+	}
+	if runs < 1 {
+		return fmt.Errorf("runs must be at least 1")
+	}
+
+	inputFlag, err := cmd.Flags().GetString("input")
+	if err != nil {
+		return fmt.Errorf("failed to get input: %s", err)
+	}
+	inputSize, err := cmd.Flags().GetInt("input-size")
+	if err != nil {
+		return fmt.Errorf("failed to get input-size: %s", err)
+	}
+	input, err := newBenchmarkInput(inputFlag, inputSize)
+	if err != nil {
+		return err
+	}
+
+	result, err := tryruntime.BenchmarkRuntime(code, method, runs, input)
+	if err != nil {
+		return err
+	}
+
+	printBenchmarkResult(cmd.OutOrStdout(), result)
+	return nil
+}
+
+// newBenchmarkInput returns the function generating the input of each benchmark run,
+// returning the hex decoded input given, or random bytes of the size given.
+func newBenchmarkInput(inputHex string, size int) (input func(run int) []byte, err error) {
+	if inputHex != "" {
+		data, err := common.HexToBytes(inputHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		return func(int) []byte { return data }, nil
+	}
+
+	if size < 0 {
+		return nil, fmt.Errorf("input-size cannot be negative")
+	}
+
+	return func(int) []byte {
+		data := make([]byte, size)
+		_, err := rand.Read(data)
+		if err != nil {
+			panic(fmt.Sprintf("generating random input: %s", err))
+		}
+		return data
+	}, nil
+}
+
+func printBenchmarkResult(w io.Writer, result *tryruntime.BenchmarkResult) {
+	fmt.Fprintf(w, "%s: %d runs, %d failures\n", result.Method, result.Runs, result.Failures)
+	fmt.Fprintf(w, "total %s, mean %s, min %s, max %s\n",
+		result.Total, result.Mean(), result.Min, result.Max)
+
+	if len(result.HostFunctions) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "host function calls:")
+	for _, profile := range result.HostFunctions {
+		fmt.Fprintf(w, "  %s: %d calls, total %s\n", profile.Name, profile.Calls, profile.Total)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/tryruntime"
+	"github.com/ChainSafe/gossamer/internal/sysinfo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newBenchmarkInput(t *testing.T) {
+	t.Parallel()
+
+	input, err := newBenchmarkInput("0x0102", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, input(0))
+
+	input, err = newBenchmarkInput("", 10)
+	require.NoError(t, err)
+	assert.Len(t, input(0), 10)
+
+	_, err = newBenchmarkInput("0xzz", 0)
+	assert.Error(t, err)
+
+	_, err = newBenchmarkInput("", -1)
+	assert.EqualError(t, err, "input-size cannot be negative")
+}
+
+func Test_printBenchmarkResult(t *testing.T) {
+	t.Parallel()
+
+	result := &tryruntime.BenchmarkResult{
+		Method:   "Core_version",
+		Runs:     2,
+		Failures: 1,
+		Total:    3 * time.Millisecond,
+		Min:      time.Millisecond,
+		Max:      2 * time.Millisecond,
+		HostFunctions: []tryruntime.HostFunctionProfile{
+			{Name: "ext_allocator_malloc_version_1", Calls: 4, Total: time.Microsecond},
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	printBenchmarkResult(buffer, result)
+
+	expected := "Core_version: 2 runs, 1 failures\n" +
+		"total 3ms, mean 1.5ms, min 1ms, max 2ms\n" +
+		"host function calls:\n" +
+		"  ext_allocator_malloc_version_1: 4 calls, total 1µs\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func Test_printMachineScores(t *testing.T) {
+	t.Parallel()
+
+	scores := []sysinfo.Score{
+		{Metric: sysinfo.Blake2256, Throughput: 1000, Minimum: 783.27},
+		{Metric: sysinfo.DiskRndWrite, Throughput: 100, Minimum: 420},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	passed := printMachineScores(buffer, scores)
+
+	assert.False(t, passed)
+	expected := "BLAKE2-256         1000.00 MiB/s (minimum 783.27 MiB/s) ✅ pass\n" +
+		"Rnd Write           100.00 MiB/s (minimum 420.00 MiB/s) ❌ fail\n" +
+		"score: 1/2 requirements met\n"
+	assert.Equal(t, expected, buffer.String())
+}
@@ -5,6 +5,8 @@
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/ChainSafe/gossamer/dot"
 	"github.com/spf13/cobra"
@@ -14,6 +16,11 @@ func init() {
 	BuildSpecCmd.Flags().Bool("raw", false, "print raw genesis json")
 	BuildSpecCmd.Flags().
 		String("output-path", "", "path to output the recently created chain-spec JSON file")
+	BuildSpecCmd.Flags().String("runtime", "", "path to the runtime WASM used to build the genesis")
+	BuildSpecCmd.Flags().
+		String("genesis-patch", "", "path to a JSON patch applied to the runtime default genesis config")
+	BuildSpecCmd.Flags().String("chain-name", "Custom", "name of the chain-spec built from a runtime")
+	BuildSpecCmd.Flags().String("chain-id", "custom", "identifier of the chain-spec built from a runtime")
 }
 
 // BuildSpecCmd is the command to generate genesis JSON
@@ -25,7 +32,9 @@ func init() {
 To generate raw chain-spec file from default:
 	gossamer build-spec --raw --output chain-spec.json
 To generate raw chain-spec file from specific chain-spec file:
-	gossamer build-spec --raw --chain chain-spec.json --output-path chain-spec-raw.json`,
+	gossamer build-spec --raw --chain chain-spec.json --output-path chain-spec-raw.json
+To generate a chain-spec file from a runtime and a genesis config patch:
+	gossamer build-spec --runtime runtime.wasm --genesis-patch patch.json --chain-id my_chain --output-path chain-spec.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return execBuildSpec(cmd)
 	},
@@ -49,8 +58,13 @@ func execBuildSpec(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get base-path value: %s", err)
 	}
 
-	if chainSpec == "" && basePath == "" {
-		return fmt.Errorf("one of chain or base-path must be specified")
+	runtimePath, err := cmd.Flags().GetString("runtime")
+	if err != nil {
+		return fmt.Errorf("failed to get runtime value: %s", err)
+	}
+
+	if chainSpec == "" && basePath == "" && runtimePath == "" {
+		return fmt.Errorf("one of chain, base-path or runtime must be specified")
 	}
 
 	outputPath, err := cmd.Flags().GetString("output-path")
@@ -60,7 +74,12 @@ func execBuildSpec(cmd *cobra.Command) error {
 
 	var bs *dot.BuildSpec
 
-	if chainSpec != "" {
+	if runtimePath != "" {
+		bs, err = buildSpecFromRuntime(cmd, runtimePath)
+		if err != nil {
+			return err
+		}
+	} else if chainSpec != "" {
 		bs, err = dot.BuildFromGenesis(chainSpec, 0)
 		if err != nil {
 			return err
@@ -101,3 +120,36 @@ func execBuildSpec(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// buildSpecFromRuntime builds a spec from the runtime at runtimePath and the genesis-patch flag
+func buildSpecFromRuntime(cmd *cobra.Command, runtimePath string) (*dot.BuildSpec, error) {
+	patchPath, err := cmd.Flags().GetString("genesis-patch")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genesis-patch value: %s", err)
+	}
+
+	name, err := cmd.Flags().GetString("chain-name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain-name value: %s", err)
+	}
+
+	id, err := cmd.Flags().GetString("chain-id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain-id value: %s", err)
+	}
+
+	code, err := os.ReadFile(filepath.Clean(runtimePath))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read runtime: %w", err)
+	}
+
+	var patch []byte
+	if patchPath != "" {
+		patch, err = os.ReadFile(filepath.Clean(patchPath))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read genesis patch: %w", err)
+		}
+	}
+
+	return dot.BuildFromRuntime(name, id, code, patch)
+}
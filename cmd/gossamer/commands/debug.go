@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/tryruntime"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	DebugExecuteBlockCmd.Flags().Bool("no-trace", false, "do not log the storage host function calls")
+	DebugCmd.AddCommand(DebugExecuteBlockCmd)
+}
+
+// DebugCmd is the command grouping the node debugging commands
+var DebugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Debug the chain data of the node",
+}
+
+// DebugExecuteBlockCmd is the command to re-execute an imported block with storage tracing
+var DebugExecuteBlockCmd = &cobra.Command{
+	Use:   "execute-block <hash>",
+	Short: "Re-execute an imported block, tracing its storage accesses",
+	Long: `execute-block re-executes an already imported block on top of the state of its
+parent with the runtime of the parent state. Every ext_storage_* host function call is
+logged with its key and value, followed by the resulting state diff and state root.
+The node must not be running.
+Usage:
+	gossamer debug execute-block --base-path ~/.gossamer/westend <hash>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execDebugExecuteBlock(cmd, args[0])
+	},
+}
+
+// execDebugExecuteBlock executes the debug execute-block command
+func execDebugExecuteBlock(cmd *cobra.Command, hash string) error {
+	blockHash, err := common.HexToHash(hash)
+	if err != nil {
+		return fmt.Errorf("invalid block hash: %w", err)
+	}
+
+	noTrace, err := cmd.Flags().GetBool("no-trace")
+	if err != nil {
+		return fmt.Errorf("failed to get no-trace: %s", err)
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	source, err := tryruntime.NewLocalSource(utils.ExpandDir(basePath))
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	var tracer runtime.StorageTracer
+	if !noTrace {
+		tracer = tryruntime.NewTraceWriter(cmd.OutOrStdout())
+	}
+
+	result, err := executeBlockFromSource(source, blockHash, nil, tracer)
+	if result != nil {
+		printExecutionResult(cmd.OutOrStdout(), result)
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/tryruntime"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ForkOffCmd.Flags().String("rpc", "", "HTTP RPC endpoint of a live node to fetch the state from")
+	ForkOffCmd.Flags().String("block", "", "hash of the block to fork off from, defaults to the finalised head")
+	ForkOffCmd.Flags().StringSlice("replace", dot.DefaultForkOffReplaced,
+		"storage prefixes taken from the base chain-spec instead of the live chain, "+
+			"as pallet names, Pallet.Item names or hex encoded key prefixes")
+	ForkOffCmd.Flags().StringSlice("merge", dot.DefaultForkOffMerged,
+		"storage prefixes added from the base chain-spec to the state of the live chain")
+	ForkOffCmd.Flags().
+		String("output-path", "", "path to output the raw chain-spec JSON file of the fork")
+}
+
+// ForkOffCmd is the command to create the chain-spec of a local fork of a live chain
+var ForkOffCmd = &cobra.Command{
+	Use:   "fork-off",
+	Short: "Create the raw chain-spec of a local fork of a live chain",
+	Long: `The fork-off command downloads the state of a live chain over RPC and outputs a raw
+chain-spec launching a local fork of the chain with this state. The authorities, the sudo key
+and the system storage of the last blocks are taken from the base chain-spec given with --chain,
+so the fork is run by the local validators of the base chain-spec, and the accounts of the
+base chain-spec are added to the state. The runtime code of the live chain is kept.
+Usage:
+	gossamer fork-off --rpc http://localhost:8545 --chain westend-dev-raw.json --output-path fork-raw.json
+	gossamer fork-off --rpc http://localhost:8545 --chain westend-dev-raw.json --block <hash> --replace Babe,Grandpa,Session`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execForkOff(cmd)
+	},
+}
+
+// execForkOff executes the fork-off command
+func execForkOff(cmd *cobra.Command) error {
+	rpc, err := cmd.Flags().GetString("rpc")
+	if err != nil {
+		return fmt.Errorf("failed to get rpc: %s", err)
+	}
+	if rpc == "" {
+		return fmt.Errorf("rpc must be specified")
+	}
+
+	chainSpec, err := cmd.Flags().GetString("chain")
+	if err != nil {
+		return fmt.Errorf("failed to get chain: %s", err)
+	}
+	if chainSpec == "" {
+		return fmt.Errorf("chain must be specified")
+	}
+
+	blockFlag, err := cmd.Flags().GetString("block")
+	if err != nil {
+		return fmt.Errorf("failed to get block: %s", err)
+	}
+
+	replaced, err := cmd.Flags().GetStringSlice("replace")
+	if err != nil {
+		return fmt.Errorf("failed to get replace: %s", err)
+	}
+
+	merged, err := cmd.Flags().GetStringSlice("merge")
+	if err != nil {
+		return fmt.Errorf("failed to get merge: %s", err)
+	}
+
+	outputPath, err := cmd.Flags().GetString("output-path")
+	if err != nil {
+		return fmt.Errorf("failed to get output-path: %s", err)
+	}
+
+	bs, err := dot.BuildFromGenesis(chainSpec, 0)
+	if err != nil {
+		return fmt.Errorf("loading base chain-spec: %w", err)
+	}
+
+	source := tryruntime.NewRemoteSource(rpc)
+	var blockHash common.Hash
+	if blockFlag != "" {
+		blockHash, err = common.HexToHash(blockFlag)
+		if err != nil {
+			return fmt.Errorf("invalid block hash: %w", err)
+		}
+	} else {
+		blockHash, err = source.FinalisedHead()
+		if err != nil {
+			return fmt.Errorf("getting finalised head: %w", err)
+		}
+	}
+
+	logger.Infof("fetching state at block %s from %s", blockHash, rpc)
+	state, err := source.Entries(blockHash)
+	if err != nil {
+		return fmt.Errorf("getting state at block %s: %w", blockHash, err)
+	}
+
+	err = bs.ForkOff(state, replaced, merged)
+	if err != nil {
+		return fmt.Errorf("forking off state: %w", err)
+	}
+
+	res, err := bs.ToJSONRaw()
+	if err != nil {
+		return err
+	}
+
+	if outputPath != "" {
+		err = dot.WriteGenesisSpecFile(res, outputPath)
+		if err != nil {
+			return fmt.Errorf("cannot write genesis spec file: %w", err)
+		}
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", res)
+	}
+
+	return nil
+}
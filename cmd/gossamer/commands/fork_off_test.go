@@ -0,0 +1,54 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForkOff test "gossamer fork-off --rpc <url> --chain=chain-spec-raw.json --output-path fork.json"
+func TestForkOff(t *testing.T) {
+	results := map[string]string{
+		"chain_getFinalizedHead": `"0x0100000000000000000000000000000000000000000000000000000000000000"`,
+		"state_getKeysPaged":     `["0x3a636f6465","0x0102"]`,
+		"state_queryStorageAt":   `[{"block":null,"changes":[["0x3a636f6465","0x01"],["0x0102","0x02"]]}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, request.ID, results[request.Method])
+	}))
+	t.Cleanup(server.Close)
+
+	outputPath := filepath.Join(t.TempDir(), "fork.json")
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(ForkOffCmd)
+
+	rootCmd.SetArgs([]string{ForkOffCmd.Name(), "--rpc", server.URL, "--chain", testChainSpec,
+		"--output-path", outputPath})
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+
+	fork, err := genesis.NewGenesisFromJSONRaw(outputPath)
+	require.NoError(t, err)
+	top := fork.Genesis.Raw["top"]
+	assert.Equal(t, "0x01", top["0x3a636f6465"])
+	assert.Equal(t, "0x02", top["0x0102"])
+	assert.Equal(t, "Local", fork.ChainType)
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	KeyGenerateNodeKeyCmd.Flags().String("file", "",
+		"file to write the secret node key to, printed to the standard output if empty")
+	KeyInspectNodeKeyCmd.Flags().String("file", "", "file to read the secret node key from")
+	KeyCmd.AddCommand(KeyGenerateNodeKeyCmd, KeyInspectNodeKeyCmd)
+}
+
+// KeyCmd is the command grouping the node key management commands
+var KeyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Manage the libp2p node keys",
+}
+
+// KeyGenerateNodeKeyCmd is the command to generate a libp2p node key
+var KeyGenerateNodeKeyCmd = &cobra.Command{
+	Use:   "generate-node-key",
+	Short: "Generate a random libp2p node key",
+	Long: `generate-node-key generates a random Ed25519 node key and writes its hex encoded secret
+to the file given, only readable by its owner, or to the standard output. The peer ID of the
+key is printed to the standard error. The key file is used with --node-key-file.
+Usage:
+	gossamer key generate-node-key --file ~/.gossamer/node.key`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyGenerateNodeKey(cmd)
+	},
+}
+
+// KeyInspectNodeKeyCmd is the command to print the peer ID of a libp2p node key
+var KeyInspectNodeKeyCmd = &cobra.Command{
+	Use:   "inspect-node-key",
+	Short: "Print the peer ID of a libp2p node key",
+	Long: `inspect-node-key prints the peer ID of the node key in the file given.
+Usage:
+	gossamer key inspect-node-key --file ~/.gossamer/node.key`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyInspectNodeKey(cmd)
+	},
+}
+
+// execKeyGenerateNodeKey executes the key generate-node-key command
+func execKeyGenerateNodeKey(cmd *cobra.Command) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %s", err)
+	}
+
+	key, err := network.GenerateNodeKey()
+	if err != nil {
+		return err
+	}
+
+	peerID, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("getting peer ID: %w", err)
+	}
+
+	if file != "" {
+		err = network.SaveNodeKey(key, file)
+		if err != nil {
+			return err
+		}
+	} else {
+		raw, err := key.Raw()
+		if err != nil {
+			return fmt.Errorf("getting raw node key: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), hex.EncodeToString(ed25519.PrivateKey(raw).Seed()))
+	}
+
+	fmt.Fprintln(cmd.ErrOrStderr(), peerID)
+	return nil
+}
+
+// execKeyInspectNodeKey executes the key inspect-node-key command
+func execKeyInspectNodeKey(cmd *cobra.Command) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %s", err)
+	}
+	if file == "" {
+		return fmt.Errorf("file must be specified")
+	}
+
+	key, err := network.LoadNodeKey(file)
+	if err != nil {
+		return err
+	} else if key == nil {
+		return fmt.Errorf("node key file %s does not exist", file)
+	}
+
+	peerID, err := peer.IDFromPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("getting peer ID: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), peerID)
+	return nil
+}
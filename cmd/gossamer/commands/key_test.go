@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestKeyNodeKey test "gossamer key generate-node-key --file node.key" and
+// "gossamer key inspect-node-key --file node.key"
+func TestKeyNodeKey(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "node.key")
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(KeyCmd)
+	generateOutput, generateErrOutput := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	rootCmd.SetOut(generateOutput)
+	rootCmd.SetErr(generateErrOutput)
+
+	rootCmd.SetArgs([]string{KeyCmd.Name(), KeyGenerateNodeKeyCmd.Name(), "--file", file})
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	assert.Empty(t, generateOutput.String())
+	peerID := strings.TrimSpace(generateErrOutput.String())
+	assert.NotEmpty(t, peerID)
+
+	// an existing node key file is not overwritten
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "node key file already exists")
+
+	inspectOutput := bytes.NewBuffer(nil)
+	rootCmd.SetOut(inspectOutput)
+	rootCmd.SetArgs([]string{KeyCmd.Name(), KeyInspectNodeKeyCmd.Name(), "--file", file})
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	assert.Equal(t, peerID+"\n", inspectOutput.String())
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	MigrateDatabaseCmd.Flags().String("to", "", "database backend to migrate to, one of 'pebble' or 'rocksdb'")
+}
+
+// MigrateDatabaseCmd is the command to migrate the node database to another backend
+var MigrateDatabaseCmd = &cobra.Command{
+	Use:   "migrate-database",
+	Short: "Migrate the node database to another backend",
+	Long: `migrate-database --to <backend> copies the node database into a database using
+the given backend and replaces it. The original database is kept next to the new one.
+Set state.database-backend to the new backend in the node config afterwards.
+Usage: gossamer migrate-database --base-path ~/.gossamer/westend --to rocksdb`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execMigrateDatabase(cmd)
+	},
+}
+
+// execMigrateDatabase executes the migrate-database command
+func execMigrateDatabase(cmd *cobra.Command) error {
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return fmt.Errorf("failed to get to: %s", err)
+	}
+	if to == "" {
+		return fmt.Errorf("to must be specified")
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	return database.MigrateBackend(basePath, database.Backend(to))
+}
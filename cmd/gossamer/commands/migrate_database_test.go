@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMigrateDatabase test "gossamer migrate-database --to <backend>"
+func TestMigrateDatabase(t *testing.T) {
+	const copyBackend database.Backend = "pebble-copy"
+	database.RegisterBackend(copyBackend, func(path string, inMemory bool) (database.Database, error) {
+		return database.NewPebble(path, inMemory)
+	})
+
+	basepath := t.TempDir()
+	db, err := database.LoadDatabase(basepath, false)
+	require.NoError(t, err)
+	err = db.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	migrate := func(args ...string) error {
+		rootCmd, err := NewRootCommand()
+		require.NoError(t, err)
+		rootCmd.AddCommand(MigrateDatabaseCmd)
+		rootCmd.SetArgs(append([]string{MigrateDatabaseCmd.Name(), "--base-path", basepath}, args...))
+		return rootCmd.Execute()
+	}
+
+	err = migrate()
+	assert.EqualError(t, err, "to must be specified")
+
+	err = migrate("--to", string(database.PebbleBackend))
+	assert.ErrorIs(t, err, database.ErrSameBackend)
+
+	err = migrate("--to", "unknown")
+	assert.ErrorIs(t, err, database.ErrBackendUnavailable)
+
+	err = migrate("--to", string(copyBackend))
+	require.NoError(t, err)
+
+	db, err = database.LoadDatabase(basepath, false)
+	require.NoError(t, err)
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	err = db.Close()
+	require.NoError(t, err)
+}
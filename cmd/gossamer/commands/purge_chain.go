@@ -0,0 +1,143 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	PurgeChainCmd.Flags().Bool("state", false, "only purge the state tries")
+	PurgeChainCmd.Flags().Bool("blocks", false, "only purge the block headers, bodies and justifications")
+	PurgeChainCmd.Flags().Bool("offchain", false, "only purge the offchain storage and other ancillary data")
+	PurgeChainCmd.Flags().Bool("keep-keystore", false, "keep the keystore when purging the whole base path")
+	PurgeChainCmd.Flags().Bool("force", false, "disable the confirmation prompt")
+}
+
+// PurgeChainCmd is the command to remove the chain data of the node
+var PurgeChainCmd = &cobra.Command{
+	Use:   "purge-chain",
+	Short: "Remove the chain data of the node",
+	Long: `purge-chain removes the whole base path of the node, or only the parts of the
+database selected with --state, --blocks and --offchain. Purging the state or the blocks also removes the chain head
+pointers, and the node must be initialised again afterwards. The keystore is never
+touched by the selective purges, and is kept when purging the whole base path
+with --keep-keystore.
+Usage: gossamer purge-chain --base-path ~/.gossamer/westend --state --offchain`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execPurgeChain(cmd)
+	},
+}
+
+// execPurgeChain executes the purge-chain command
+func execPurgeChain(cmd *cobra.Command) error {
+	var targets []state.PurgeTarget
+	for _, flagTarget := range []struct {
+		flag   string
+		target state.PurgeTarget
+	}{
+		{flag: "state", target: state.PurgeState},
+		{flag: "blocks", target: state.PurgeBlocks},
+		{flag: "offchain", target: state.PurgeAncillary},
+	} {
+		selected, err := cmd.Flags().GetBool(flagTarget.flag)
+		if err != nil {
+			return fmt.Errorf("failed to get --%s: %s", flagTarget.flag, err)
+		}
+		if selected {
+			targets = append(targets, flagTarget.target)
+		}
+	}
+
+	keepKeystore, err := cmd.Flags().GetBool("keep-keystore")
+	if err != nil {
+		return fmt.Errorf("failed to get --keep-keystore: %s", err)
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return fmt.Errorf("failed to get --force: %s", err)
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	what := "the whole base path " + basePath
+	switch {
+	case len(targets) > 0:
+		names := make([]string, len(targets))
+		for i, target := range targets {
+			names[i] = string(target)
+		}
+		what = "the " + strings.Join(names, ", ") + " data of " + basePath
+	case keepKeystore:
+		what += " except its keystore"
+	}
+
+	if !force && !confirmMessage("Are you sure you want to purge "+what+"? [Y/n]") {
+		logger.Warn("exiting without purging " + what)
+		return nil
+	}
+
+	if len(targets) > 0 {
+		return state.Purge(basePath, targets...)
+	}
+
+	err = purgeBasePath(basePath, keepKeystore)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("purged " + what)
+	return nil
+}
+
+// purgeBasePath removes the content of the base path, except the keystore if keepKeystore is true
+func purgeBasePath(basepath string, keepKeystore bool) error {
+	if !keepKeystore {
+		return os.RemoveAll(basepath)
+	}
+
+	entries, err := os.ReadDir(basepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading base path: %w", err)
+	}
+
+	keystorePath, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return fmt.Errorf("getting keystore directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		entryPath, err := filepath.Abs(filepath.Join(utils.ExpandDir(basepath), entry.Name()))
+		if err != nil {
+			return fmt.Errorf("getting absolute path of %s: %w", entry.Name(), err)
+		}
+		if entryPath == keystorePath {
+			continue
+		}
+
+		err = os.RemoveAll(entryPath)
+		if err != nil {
+			return fmt.Errorf("removing %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_purgeBasePath(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		keepKeystore bool
+		remaining    []string
+	}{
+		"everything": {},
+		"keep_keystore": {
+			keepKeystore: true,
+			remaining:    []string{"keystore"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			basepath := filepath.Join(t.TempDir(), "node")
+			for _, dir := range []string{"db", "keystore"} {
+				err := os.MkdirAll(filepath.Join(basepath, dir), os.ModePerm)
+				require.NoError(t, err)
+			}
+			err := os.WriteFile(filepath.Join(basepath, "config.toml"), nil, 0o600)
+			require.NoError(t, err)
+
+			err = purgeBasePath(basepath, testCase.keepKeystore)
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(basepath)
+			if testCase.remaining == nil {
+				assert.ErrorIs(t, err, os.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+			remaining := make([]string, len(entries))
+			for i, entry := range entries {
+				remaining[i] = entry.Name()
+			}
+			assert.Equal(t, testCase.remaining, remaining)
+		})
+	}
+}
@@ -39,6 +39,10 @@
 	role string
 	// validator when set, the node will be an authority
 	validator bool
+	// light when set, the node will be a light client
+	light bool
+	// dev when set, the node runs a single authority development chain with instant seal
+	dev bool
 
 	// Account Config
 	// key to use for the node
@@ -75,6 +79,7 @@ func NewRootCommand() (*cobra.Command, error) {
 		Long: `Gossamer is a Golang implementation of the Polkadot Host.
 Usage:
 	gossamer --chain westend-local --alice
+	gossamer --dev
 	gossamer --chain westend-dev --key alice --port 7002
 	gossamer --chain westend --key bob --port 7003
 	gossamer --chain paseo --key bob --port 7003
@@ -88,6 +93,10 @@ func NewRootCommand() (*cobra.Command, error) {
 				return nil
 			}
 
+			if err := parseDev(cmd); err != nil {
+				return fmt.Errorf("failed to parse dev mode: %s", err)
+			}
+
 			if err := parseChainSpec(chain); err != nil {
 				return fmt.Errorf("failed to parse chain-spec: %s", err)
 			}
@@ -96,6 +105,10 @@ func NewRootCommand() (*cobra.Command, error) {
 				return fmt.Errorf("failed to parse base path: %s", err)
 			}
 
+			if err := resolveChainSpec(cmd.Context(), chain); err != nil {
+				return fmt.Errorf("failed to resolve chain-spec: %s", err)
+			}
+
 			parseAccount()
 
 			if err := parseRole(); err != nil {
@@ -162,7 +175,7 @@ func addRootFlags(cmd *cobra.Command) error {
 	cmd.PersistentFlags().StringVar(&chain,
 		"chain",
 		"",
-		"The default chain configuration to load. Example: --chain kusama")
+		"The chain to load, either a chain-spec path or a known chain name. Example: --chain kusama")
 
 	// Base Config
 	if err := addBaseConfigFlags(cmd); err != nil {
@@ -204,9 +217,17 @@ func addRootFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add rpc flags: %s", err)
 	}
 
+	// Sync Config
+	if err := addSyncFlags(cmd); err != nil {
+		return fmt.Errorf("failed to add sync flags: %s", err)
+	}
+
 	// pprof Config
 	addPprofFlags(cmd)
 
+	// Substrate compatibility
+	addCompatibilityFlags(cmd)
+
 	return nil
 }
 
@@ -236,6 +257,21 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 		"retain-blocks"); err != nil {
 		return fmt.Errorf("failed to add --retain-blocks flag: %s", err)
 	}
+	if err := addStringFlagBindViper(cmd,
+		"log-format",
+		config.BaseConfig.LogFormat,
+		"Format of the log lines, either console or json for structured logs with a target field",
+		"log-format"); err != nil {
+		return fmt.Errorf("failed to add --log-format flag: %s", err)
+	}
+	if err := addUint32FlagBindViper(cmd,
+		"memory-budget",
+		config.BaseConfig.MemoryBudget,
+		"Memory budget in MiB, the caches and buffers being shrunk under memory pressure to stay under it. "+
+			"0 for no budget",
+		"memory-budget"); err != nil {
+		return fmt.Errorf("failed to add --memory-budget flag: %s", err)
+	}
 	cmd.Flags().StringVar(&pruning,
 		"state-pruning",
 		string(config.BaseConfig.Pruning),
@@ -293,6 +329,31 @@ func addAccountFlags(cmd *cobra.Command) error {
 		"",
 		"Password used to encrypt the keystore")
 
+	if err := addStringFlagBindViper(cmd,
+		"remote-signer-url",
+		config.Account.RemoteSignerURL,
+		"URL of an external signing service holding the node's private keys",
+		"account.remote-signer-url"); err != nil {
+		return fmt.Errorf("failed to add --remote-signer-url flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"remote-signer-token-file",
+		config.Account.RemoteSignerTokenFile,
+		"File holding the bearer token used to authenticate to the remote signer. "+
+			"Alternatively set "+cfg.RemoteSignerTokenEnv,
+		"account.remote-signer-token-file"); err != nil {
+		return fmt.Errorf("failed to add --remote-signer-token-file flag: %s", err)
+	}
+
+	if err := addStringSliceFlagBindViper(cmd,
+		"remote-signer-key-types",
+		config.Account.RemoteSignerKeyTypes,
+		"Comma separated list of keystores served by the remote signer, eg. imon,audi",
+		"account.remote-signer-key-types"); err != nil {
+		return fmt.Errorf("failed to add --remote-signer-key-types flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -393,6 +454,15 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --node-key flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"node-key-file",
+		config.Network.NodeKeyFile,
+		"File the secret Ed25519 key to use for libp2p networking is read from, "+
+			"or generated and written to if it does not exist. Defaults to node.key in the base path",
+		"network.node-key-file"); err != nil {
+		return fmt.Errorf("failed to add --node-key-file flag: %s", err)
+	}
+
 	if err := addStringFlagBindViper(cmd,
 		"listen-addr",
 		config.Network.ListenAddress,
@@ -401,6 +471,38 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --listen-addr flag: %s", err)
 	}
 
+	if err := addStringSliceFlagBindViper(cmd,
+		"listen-addrs",
+		config.Network.ListenAddresses,
+		"Multiaddresses to listen on along the listen address, eg. to listen on both IPv4 and IPv6",
+		"network.listen-addrs"); err != nil {
+		return fmt.Errorf("failed to add --listen-addrs flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"tip-request-racers",
+		config.Network.TipRequestRacers,
+		"Number of peers a single block request is raced to during tip sync, disabled if lower than 2",
+		"network.tip-request-racers"); err != nil {
+		return fmt.Errorf("failed to add --tip-request-racers flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"verify-ancient-blocks",
+		config.Network.VerifyAncientBlocks,
+		"Verify the BABE authorship of the bootstrap synced blocks committed to a justified block",
+		"network.verify-ancient-blocks"); err != nil {
+		return fmt.Errorf("failed to add --verify-ancient-blocks flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"validator-peers",
+		config.Network.ValidatorPeers,
+		"Number of peer slots reserved to the validator peers, in addition to the incoming and outgoing slots",
+		"network.validator-peers"); err != nil {
+		return fmt.Errorf("failed to add --validator-peers flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -475,10 +577,46 @@ func addRPCFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --ws-unsafe-external flag: %s", err)
 	}
 
-	// dummy flag to conform with the substrate cli
-	cmd.Flags().String("rpc-cors",
-		"",
-		"dummy flag to conform with the substrate cli")
+	if err := addStringSliceFlagBindViper(cmd,
+		"rpc-cors",
+		config.RPC.CORS,
+		"Browser origins allowed to access the HTTP-RPC and websocket servers, 'all' allows any origin",
+		"rpc.cors"); err != nil {
+		return fmt.Errorf("failed to add --rpc-cors flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"rpc-tls-cert-file",
+		config.RPC.TLSCertFile,
+		"Certificate file the HTTP-RPC and websocket servers serve TLS with",
+		"rpc.tls-cert-file"); err != nil {
+		return fmt.Errorf("failed to add --rpc-tls-cert-file flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"rpc-tls-key-file",
+		config.RPC.TLSKeyFile,
+		"Private key file the HTTP-RPC and websocket servers serve TLS with",
+		"rpc.tls-key-file"); err != nil {
+		return fmt.Errorf("failed to add --rpc-tls-key-file flag: %s", err)
+	}
+
+	if err := addStringSliceFlagBindViper(cmd,
+		"rpc-acme-domains",
+		config.RPC.ACMEDomains,
+		"Domains the TLS certificates of the HTTP-RPC and websocket servers are obtained for with ACME",
+		"rpc.acme-domains"); err != nil {
+		return fmt.Errorf("failed to add --rpc-acme-domains flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"rpc-auth-token-file",
+		config.RPC.AuthTokenFile,
+		"File holding the token the unsafe RPC calls must be authenticated with, "+
+			"the token may instead be given by the "+cfg.RPCAuthTokenEnv+" environment variable",
+		"rpc.auth-token-file"); err != nil {
+		return fmt.Errorf("failed to add --rpc-auth-token-file flag: %s", err)
+	}
 
 	return nil
 }
@@ -488,13 +626,24 @@ func addCoreFlags(cmd *cobra.Command) error {
 	cmd.Flags().StringVar(&role,
 		"role",
 		cfg.FullNode.String(),
-		"Role of the node. One of 'full', 'light', or 'authority'.")
+		"Role of the node. One of 'full', 'light', 'authority' or 'rpc'.")
 
 	cmd.Flags().BoolVar(&validator,
 		"validator",
 		false,
 		"Run as a validator node")
 
+	cmd.Flags().BoolVar(&light,
+		"light",
+		false,
+		"Run as a light client, syncing and verifying headers only and reading storage from full node peers")
+
+	cmd.Flags().BoolVar(&dev,
+		"dev",
+		false,
+		"Run a development chain: westend-dev with Alice's key, an in-memory database, "+
+			"a block sealed on every submitted extrinsic and the engine RPC module enabled")
+
 	if err := addBoolFlagBindViper(cmd,
 		"babe-authority",
 		config.Core.BabeAuthority,
@@ -527,6 +676,54 @@ func addCoreFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --grandpa-interval flag: %s", err)
 	}
 
+	if err := addUintFlagBindViper(cmd,
+		"pool-limit",
+		config.Core.PoolLimit,
+		"Maximum number of transactions in the transaction pool",
+		"core.pool-limit"); err != nil {
+		return fmt.Errorf("failed to add --pool-limit flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"pool-kbytes",
+		config.Core.PoolKBytes,
+		"Maximum size of the transactions in the transaction pool in kilobytes",
+		"core.pool-kbytes"); err != nil {
+		return fmt.Errorf("failed to add --pool-kbytes flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"pool-sender-limit",
+		config.Core.PoolSenderLimit,
+		"Maximum number of transactions of a single sender in the transaction pool, 0 for no limit",
+		"core.pool-sender-limit"); err != nil {
+		return fmt.Errorf("failed to add --pool-sender-limit flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"tx-ban-duration",
+		config.Core.TxBanDuration,
+		"Duration invalid transactions are banned from the transaction pool for",
+		"core.tx-ban-duration"); err != nil {
+		return fmt.Errorf("failed to add --tx-ban-duration flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-unfinalised-depth",
+		config.Core.MaxUnfinalisedDepth,
+		"Number of unfinalised blocks above which BABE backs off the block authoring, 0 for no backoff",
+		"core.max-unfinalised-depth"); err != nil {
+		return fmt.Errorf("failed to add --max-unfinalised-depth flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"runtime-tracing",
+		config.Core.RuntimeTracing,
+		"Trace the host function calls made by the runtime, with their argument sizes and durations",
+		"core.runtime-tracing"); err != nil {
+		return fmt.Errorf("failed to add --runtime-tracing flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -539,6 +736,88 @@ func addStateFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --rewind flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"database", config.State.DatabaseBackend,
+		"Database backend. One of 'pebble', 'rocksdb' or 'memory' for an ephemeral node",
+		"state.database-backend"); err != nil {
+		return fmt.Errorf("failed to add --database flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-state-version", config.State.MaxStateVersion,
+		"Latest state trie version the runtime of the followed chain can require",
+		"state.max-state-version"); err != nil {
+		return fmt.Errorf("failed to add --max-state-version flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"storage-changes-index", config.State.StorageChangesIndex,
+		"Index the storage keys modified by each block imported",
+		"state.storage-changes-index"); err != nil {
+		return fmt.Errorf("failed to add --storage-changes-index flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"storage-changes-retained-blocks", config.State.StorageChangesRetainedBlocks,
+		"Number of finalised blocks the storage changes index retains the modified keys of, 0 for all",
+		"state.storage-changes-retained-blocks"); err != nil {
+		return fmt.Errorf("failed to add --storage-changes-retained-blocks flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"snapshot-dir", config.State.SnapshotDir,
+		"Directory the database is snapshotted to at each snapshot interval, disabled if empty",
+		"state.snapshot-dir"); err != nil {
+		return fmt.Errorf("failed to add --snapshot-dir flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"snapshot-interval", config.State.SnapshotInterval,
+		"Interval between two database snapshots",
+		"state.snapshot-interval"); err != nil {
+		return fmt.Errorf("failed to add --snapshot-interval flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"snapshot-retained", config.State.SnapshotRetained,
+		"Number of most recent database snapshots retained, 0 for all",
+		"state.snapshot-retained"); err != nil {
+		return fmt.Errorf("failed to add --snapshot-retained flag: %s", err)
+	}
+
+	return nil
+}
+
+// addSyncFlags adds sync flags and binds to viper
+func addSyncFlags(cmd *cobra.Command) error {
+	if err := addUintFlagBindViper(cmd,
+		"sync-max-requests", config.Sync.MaxRequests,
+		"Maximum number of block requests in flight at once during bootstrap sync",
+		"sync.max-requests"); err != nil {
+		return fmt.Errorf("failed to add --sync-max-requests flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"sync-max-requests-per-peer", config.Sync.MaxRequestsPerPeer,
+		"Maximum number of block requests in flight to a single peer",
+		"sync.max-requests-per-peer"); err != nil {
+		return fmt.Errorf("failed to add --sync-max-requests-per-peer flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"sync-max-workers", config.Sync.MaxWorkers,
+		"Maximum number of peers blocks are requested from, 0 for no limit",
+		"sync.max-workers"); err != nil {
+		return fmt.Errorf("failed to add --sync-max-workers flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"sync-telemetry", config.Sync.Telemetry,
+		"Send telemetry messages summarising the batches of blocks synced",
+		"sync.telemetry"); err != nil {
+		return fmt.Errorf("failed to add --sync-telemetry flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -556,6 +835,10 @@ func addPprofFlags(cmd *cobra.Command) {
 	cmd.Flags().Int("pprof.mutex-profile-rate",
 		config.Pprof.MutexProfileRate,
 		"The frequency at which the Go runtime samples the state of mutexes to generate mutex profile information.")
+	cmd.Flags().String("pprof.auth-token-file",
+		config.Pprof.AuthTokenFile,
+		"File holding the bearer token the pprof requests must be authenticated with, "+
+			"the token may instead be given by the "+cfg.PprofAuthTokenEnv+" environment variable")
 }
 
 // execRoot executes the root command
@@ -589,6 +872,10 @@ func execRoot(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to validate config: %s", err)
 	}
 
+	if err := loadRemoteKeystores(ks, config.Account); err != nil {
+		return fmt.Errorf("failed to load remote keystores: %s", err)
+	}
+
 	// Write the config to the base path
 	if err := cfg.WriteConfigFile(config.BasePath, config); err != nil {
 		return fmt.Errorf("failed to ensure root: %s", err)
@@ -620,3 +907,16 @@ func execRoot(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// addCompatibilityFlags adds the flags of the Substrate node command line which test network
+// orchestrators such as zombienet pass to every node, and which have no effect on gossamer.
+func addCompatibilityFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-hardware-benchmarks", false,
+		"Accepted for compatibility with Substrate, gossamer does not benchmark the hardware on startup")
+	cmd.Flags().Bool("unsafe-force-node-key-generation", false,
+		"Accepted for compatibility with Substrate, "+
+			"gossamer generates the node key if the node key file is missing")
+	cmd.Flags().Bool("insecure-validator-i-know-what-i-do", false,
+		"Accepted for compatibility with Substrate, "+
+			"gossamer does not restrict the RPC methods of authority nodes")
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot/tryruntime"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	TryRuntimeExecuteBlockCmd.Flags().String("block", "", "hash of the block to execute")
+	TryRuntimeExecuteBlockCmd.Flags().String("wasm", "",
+		"path to the runtime wasm to execute the block with, defaults to the runtime of the parent state")
+	TryRuntimeExecuteBlockCmd.Flags().String("uri", "",
+		"HTTP RPC endpoint of a live node to fetch the block and state from, "+
+			"defaults to reading the database at --base-path")
+	TryRuntimeCmd.AddCommand(TryRuntimeExecuteBlockCmd)
+}
+
+// TryRuntimeCmd is the command grouping the runtime testing commands
+var TryRuntimeCmd = &cobra.Command{
+	Use:   "try-runtime",
+	Short: "Test a runtime against an existing chain state",
+}
+
+// TryRuntimeExecuteBlockCmd is the command to replay a block with a given runtime
+var TryRuntimeExecuteBlockCmd = &cobra.Command{
+	Use:   "execute-block",
+	Short: "Replay a block with a given runtime and report the storage changes",
+	Long: `execute-block replays a block on top of the state of its parent with the given
+runtime wasm and prints the storage changes made by the block.
+The block and the state are read from the node database, or fetched from a live node with --uri.
+Usage:
+	gossamer try-runtime execute-block --base-path ~/.gossamer/westend --block <hash> --wasm runtime.wasm
+	gossamer try-runtime execute-block --uri http://localhost:8545 --block <hash> --wasm runtime.wasm`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execTryRuntimeExecuteBlock(cmd)
+	},
+}
+
+// execTryRuntimeExecuteBlock executes the try-runtime execute-block command
+func execTryRuntimeExecuteBlock(cmd *cobra.Command) error {
+	blockFlag, err := cmd.Flags().GetString("block")
+	if err != nil {
+		return fmt.Errorf("failed to get block: %s", err)
+	}
+	if blockFlag == "" {
+		return fmt.Errorf("block must be specified")
+	}
+	blockHash, err := common.HexToHash(blockFlag)
+	if err != nil {
+		return fmt.Errorf("invalid block hash: %w", err)
+	}
+
+	wasmFile, err := cmd.Flags().GetString("wasm")
+	if err != nil {
+		return fmt.Errorf("failed to get wasm: %s", err)
+	}
+	var code []byte
+	if wasmFile != "" {
+		code, err = os.ReadFile(filepath.Clean(wasmFile))
+		if err != nil {
+			return fmt.Errorf("reading wasm file: %w", err)
+		}
+	}
+
+	uri, err := cmd.Flags().GetString("uri")
+	if err != nil {
+		return fmt.Errorf("failed to get uri: %s", err)
+	}
+
+	var source tryruntime.Source
+	if uri != "" {
+		source = tryruntime.NewRemoteSource(uri)
+	} else {
+		if basePath == "" {
+			basePath = config.BasePath
+		}
+		if basePath == "" {
+			return fmt.Errorf("basepath must be specified")
+		}
+
+		localSource, err := tryruntime.NewLocalSource(utils.ExpandDir(basePath))
+		if err != nil {
+			return err
+		}
+		defer localSource.Close()
+		source = localSource
+	}
+
+	result, err := executeBlockFromSource(source, blockHash, code, nil)
+	if result != nil {
+		printExecutionResult(cmd.OutOrStdout(), result)
+	}
+	return err
+}
+
+// executeBlockFromSource executes the block with the given hash on top of the state
+// of its parent, both read from the given source
+func executeBlockFromSource(source tryruntime.Source, blockHash common.Hash,
+	code []byte, tracer runtime.StorageTracer) (*tryruntime.Result, error) {
+	block, err := source.Block(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting block %s: %w", blockHash, err)
+	}
+
+	parentState, err := source.State(block.Header.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting state of parent block %s: %w", block.Header.ParentHash, err)
+	}
+
+	return tryruntime.ExecuteBlock(block, parentState, code, tracer)
+}
+
+func printExecutionResult(w io.Writer, result *tryruntime.Result) {
+	for _, diff := range result.Diff {
+		switch {
+		case diff.Before == nil:
+			fmt.Fprintf(w, "+ %s: %s\n", common.BytesToHex(diff.Key), common.BytesToHex(diff.After))
+		case diff.After == nil:
+			fmt.Fprintf(w, "- %s: %s\n", common.BytesToHex(diff.Key), common.BytesToHex(diff.Before))
+		default:
+			fmt.Fprintf(w, "~ %s: %s -> %s\n", common.BytesToHex(diff.Key),
+				common.BytesToHex(diff.Before), common.BytesToHex(diff.After))
+		}
+	}
+
+	fmt.Fprintf(w, "%d storage changes\n", len(result.Diff))
+	fmt.Fprintf(w, "state root: %s\n", result.StateRoot)
+	if result.StateRoot != result.ExpectedStateRoot {
+		fmt.Fprintf(w, "state root mismatch, block header has %s\n", result.ExpectedStateRoot)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/tryruntime"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_printExecutionResult(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		result   *tryruntime.Result
+		expected string
+	}{
+		"matching_state_root": {
+			result: &tryruntime.Result{
+				StateRoot:         common.Hash{1},
+				ExpectedStateRoot: common.Hash{1},
+			},
+			expected: "0 storage changes\n" +
+				"state root: 0x0100000000000000000000000000000000000000000000000000000000000000\n",
+		},
+		"diff_and_state_root_mismatch": {
+			result: &tryruntime.Result{
+				StateRoot:         common.Hash{1},
+				ExpectedStateRoot: common.Hash{2},
+				Diff: []tryruntime.StorageDiff{
+					{Key: []byte{1}, After: []byte{2}},
+					{Key: []byte{3}, Before: []byte{4}},
+					{Key: []byte{5}, Before: []byte{6}, After: []byte{7}},
+				},
+			},
+			expected: "+ 0x01: 0x02\n" +
+				"- 0x03: 0x04\n" +
+				"~ 0x05: 0x06 -> 0x07\n" +
+				"3 storage changes\n" +
+				"state root: 0x0100000000000000000000000000000000000000000000000000000000000000\n" +
+				"state root mismatch, block header has 0x0200000000000000000000000000000000000000000000000000000000000000\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+			printExecutionResult(buffer, testCase.result)
+
+			assert.Equal(t, testCase.expected, buffer.String())
+		})
+	}
+}
@@ -4,24 +4,21 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
-	"github.com/ChainSafe/gossamer/chain/paseo"
-
 	"github.com/spf13/cobra"
 
-	"github.com/ChainSafe/gossamer/chain/kusama"
-	"github.com/ChainSafe/gossamer/chain/polkadot"
-	"github.com/ChainSafe/gossamer/chain/westend"
-	westenddev "github.com/ChainSafe/gossamer/chain/westend-dev"
+	gssmrchain "github.com/ChainSafe/gossamer/chain"
 	westendlocal "github.com/ChainSafe/gossamer/chain/westend-local"
 	gssmros "github.com/ChainSafe/gossamer/lib/os"
 
@@ -31,6 +28,7 @@
 
 	cfg "github.com/ChainSafe/gossamer/config"
 
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/utils"
@@ -163,6 +161,56 @@ func loadBuiltInTestKeys(accountKey string, ks keystore.GlobalKeystore) (err err
 	return nil
 }
 
+// loadRemoteKeystores replaces the keystores listed in the account config
+// remote signer key types with keystores backed by the remote signer.
+func loadRemoteKeystores(ks *keystore.GlobalKeystore, accountConfig *cfg.AccountConfig) error {
+	if len(accountConfig.RemoteSignerKeyTypes) == 0 {
+		return nil
+	}
+
+	token, err := remoteSignerToken(accountConfig.RemoteSignerTokenFile)
+	if err != nil {
+		return fmt.Errorf("reading remote signer token: %w", err)
+	}
+
+	signer := keystore.NewHTTPRemoteSigner(accountConfig.RemoteSignerURL, token)
+	for _, name := range accountConfig.RemoteSignerKeyTypes {
+		keyType := keystore.DetermineKeyType(name)
+		remote := keystore.NewRemoteKeystore(keystore.Name(name), keyType, signer)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := remote.Refresh(ctx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("loading %s keys from remote signer: %w", name, err)
+		}
+
+		if err := ks.SetKeystore(remote); err != nil {
+			return fmt.Errorf("setting %s keystore: %w", name, err)
+		}
+		logger.Infof("loaded %d %s key(s) from remote signer", remote.Size(), name)
+	}
+
+	return nil
+}
+
+// remoteSignerToken returns the remote signer token from the environment,
+// falling back to reading it from the given token file if set.
+func remoteSignerToken(tokenFile string) (string, error) {
+	if token := os.Getenv(cfg.RemoteSignerTokenEnv); token != "" {
+		return token, nil
+	}
+
+	if tokenFile == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 // KeypairInserter inserts a keypair.
 type KeypairInserter interface {
 	Insert(kp keystore.KeyPair) error
@@ -227,8 +275,13 @@ func parseIdentity() {
 }
 
 // parseChainSpec parses the chain spec from the given chain
-// and sets the default config
+// and sets the default config. The chain is either a path to a chain spec
+// or the name of a network from the chain registry.
 func parseChainSpec(chain string) error {
+	if chain == "" {
+		return nil
+	}
+
 	// check if the chain is a path to a chain spec
 	if _, err := os.Stat(chain); err == nil {
 		spec, err := genesis.NewGenesisFromJSONRaw(chain)
@@ -237,34 +290,48 @@ func parseChainSpec(chain string) error {
 		}
 		config = cfg.DefaultConfigFromSpec(spec)
 		config.ChainSpec = chain
-	} else {
-		switch cfg.Chain(chain) {
-		case cfg.PolkadotChain:
-			config = polkadot.DefaultConfig()
-		case cfg.KusamaChain:
-			config = kusama.DefaultConfig()
-		case cfg.WestendChain:
-			config = westend.DefaultConfig()
-		case cfg.WestendDevChain:
-			config = westenddev.DefaultConfig()
-		case cfg.PaseoChain:
-			config = paseo.DefaultConfig()
-		case cfg.WestendLocalChain:
-			if alice || key == "alice" {
-				config = westendlocal.DefaultAliceConfig()
-			} else if bob || key == "bob" {
-				config = westendlocal.DefaultBobConfig()
-			} else if charlie || key == "charlie" {
-				config = westendlocal.DefaultCharlieConfig()
-			} else {
-				config = westendlocal.DefaultConfig()
-			}
-		default:
-			return nil
+		return nil
+	}
+
+	network, err := gssmrchain.Lookup(chain)
+	if err != nil {
+		return err
+	}
+
+	config = network.DefaultConfig()
+	if network.Name == cfg.WestendLocalChain {
+		if alice || key == "alice" {
+			config = westendlocal.DefaultAliceConfig()
+		} else if bob || key == "bob" {
+			config = westendlocal.DefaultBobConfig()
+		} else if charlie || key == "charlie" {
+			config = westendlocal.DefaultCharlieConfig()
 		}
 	}
+	return nil
+}
+
+// resolveChainSpec makes sure the chain spec of a network from the chain registry
+// is available, downloading it into the base path if needed, and sets the network
+// fields of the config from the chain spec.
+// It must be called after the base path has been parsed.
+func resolveChainSpec(ctx context.Context, chain string) error {
+	if chain == "" || config.ChainSpec == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(chain); err != nil {
+		network, err := gssmrchain.Lookup(chain)
+		if err != nil {
+			return err
+		}
+		chainSpec, err := network.ResolveChainSpec(ctx, config.ChainSpec, config.BasePath)
+		if err != nil {
+			return err
+		}
+		config.ChainSpec = chainSpec
+	}
 
-	// parse chain spec and set config fields
 	spec, err := genesis.NewGenesisFromJSONRaw(config.ChainSpec)
 	if err != nil {
 		return fmt.Errorf("failed to load chain spec: %s", err)
@@ -361,20 +428,65 @@ func parseAccount() {
 func parseRPC() {
 	// if rpc modules is not set, set it to the default
 	// if rpc modules is set to unsafe, set it to all modules
+	// light clients only serve the light client modules
 	//TODO: refactor this to follow the same pattern as substrate
 	// Substrate accepts `unsafe`,`safe` and `auto` for --rpc-methods
-	if rpcModules == "unsafe" || rpcModules == "" {
+	switch {
+	case config.Core.Role == common.LightClientRole && (rpcModules == "unsafe" || rpcModules == ""):
+		config.RPC.Modules = cfg.LightRPCModules
+	case rpcModules == "unsafe" || rpcModules == "":
 		config.RPC.Modules = cfg.DefaultRPCModules
-	} else {
+	default:
 		config.RPC.Modules = strings.Split(rpcModules, ",")
 	}
 
+	if config.Core.Dev && !slices.Contains(config.RPC.Modules, "engine") {
+		config.RPC.Modules = append(config.RPC.Modules, "engine")
+	}
+
 	// bind it to viper so that it can be used during the config parsing
 	viper.Set("rpc.modules", config.RPC.Modules)
 }
 
+// parseDev applies the development chain defaults when --dev is set.
+// Flags set explicitly on the command line take precedence.
+func parseDev(cmd *cobra.Command) error {
+	if !dev {
+		return nil
+	}
+	if light {
+		return fmt.Errorf("--dev and --light cannot be used together")
+	}
+
+	if chain == "" {
+		chain = cfg.WestendDevChain.String()
+	}
+	if key == "" && !alice && !bob && !charlie {
+		alice = true
+	}
+	role = cfg.AuthorityNode.String()
+
+	config.Core.Dev = true
+	config.Core.BabeAuthority = true
+	config.Core.GrandpaAuthority = false
+	viper.Set("core.dev", true)
+	viper.Set("core.babe-authority", true)
+	viper.Set("core.grandpa-authority", false)
+
+	if !cmd.Flags().Changed("database") {
+		config.State.DatabaseBackend = string(database.MemoryBackend)
+		viper.Set("state.database-backend", config.State.DatabaseBackend)
+	}
+	return nil
+}
+
 // copyChainSpec copies the chain-spec file to the base path
 func copyChainSpec(source, destination string) error {
+	if filepath.Clean(source) == filepath.Clean(destination) {
+		config.ChainSpec = destination
+		viper.Set("chain-spec", destination)
+		return nil
+	}
 	if err := gssmros.CopyFile(source, destination); err != nil {
 		return fmt.Errorf("failed to copy genesis file: %s", err)
 	}
@@ -387,9 +499,14 @@ func copyChainSpec(source, destination string) error {
 // parseRole parses the role from the command line flags
 func parseRole() error {
 	var selectedRole common.NetworkRole
-	if validator {
+	switch {
+	case validator && light:
+		return fmt.Errorf("--validator and --light cannot be used together")
+	case validator:
 		selectedRole = common.AuthorityRole
-	} else {
+	case light:
+		selectedRole = common.LightClientRole
+	default:
 		switch role {
 		case cfg.FullNode.String():
 			selectedRole = common.FullNodeRole
@@ -397,6 +514,8 @@ func parseRole() error {
 			selectedRole = common.LightClientRole
 		case cfg.AuthorityNode.String():
 			selectedRole = common.AuthorityRole
+		case cfg.RPCNode.String():
+			selectedRole = common.RPCNodeRole
 		default:
 			return fmt.Errorf("invalid role: %s", role)
 		}
@@ -404,6 +523,13 @@ func parseRole() error {
 
 	config.Core.Role = selectedRole
 	viper.Set("core.role", config.Core.Role)
+
+	if selectedRole == common.RPCNodeRole {
+		config.Core.BabeAuthority = false
+		config.Core.GrandpaAuthority = false
+		viper.Set("core.babe-authority", false)
+		viper.Set("core.grandpa-authority", false)
+	}
 	return nil
 }
 
@@ -13,6 +13,7 @@
 
 	"github.com/spf13/viper"
 
+	gssmrchain "github.com/ChainSafe/gossamer/chain"
 	"github.com/ChainSafe/gossamer/chain/westend"
 )
 
@@ -268,3 +269,8 @@ func TestBindToViper(t *testing.T) {
 		})
 	}
 }
+
+func TestParseChainSpec_unknownChain(t *testing.T) {
+	err := parseChainSpec("rococo")
+	require.ErrorIs(t, err, gssmrchain.ErrUnknownChain)
+}
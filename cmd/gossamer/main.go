@@ -21,10 +21,17 @@ func main() {
 	rootCmd.AddCommand(
 		commands.InitCmd,
 		commands.AccountCmd,
+		commands.KeyCmd,
 		commands.ImportRuntimeCmd,
 		commands.BuildSpecCmd,
 		commands.PruneStateCmd,
+		commands.MigrateDatabaseCmd,
+		commands.PurgeChainCmd,
 		commands.ImportStateCmd,
+		commands.TryRuntimeCmd,
+		commands.ForkOffCmd,
+		commands.BenchmarkCmd,
+		commands.DebugCmd,
 		commands.VersionCmd,
 	)
 	configureCobraCmd("GSSMR")
@@ -6,13 +6,19 @@
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/os"
 	wazero "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/adrg/xdg"
 )
 
@@ -23,6 +29,8 @@
 	defaultChainSpecFile = "chain-spec-raw.json"
 	// DefaultLogLevel is the default log level
 	DefaultLogLevel = "info"
+	// DefaultLogFormat is the default log format
+	DefaultLogFormat = "console"
 	// DefaultPrometheusPort is the default prometheus port
 	DefaultPrometheusPort = uint32(9876)
 	// DefaultRetainBlocks is the default number of blocks to retain
@@ -37,6 +45,16 @@
 	DefaultRole = common.AuthorityRole
 	// DefaultWasmInterpreter is the default wasm interpreter
 	DefaultWasmInterpreter = wazero.Name
+	// DefaultPoolLimit is the default maximum number of transactions in the pool
+	DefaultPoolLimit = uint(8192)
+	// DefaultPoolKBytes is the default maximum size of the transactions in the pool in kilobytes
+	DefaultPoolKBytes = uint(20480)
+	// DefaultSnapshotInterval is the default interval between two database snapshots
+	DefaultSnapshotInterval = 6 * time.Hour
+	// DefaultSnapshotRetained is the default number of database snapshots retained
+	DefaultSnapshotRetained = uint32(3)
+	// DefaultTxBanDuration is the default duration invalid transactions are banned for
+	DefaultTxBanDuration = 30 * time.Minute
 
 	// DefaultNetworkPort is the default network port
 	DefaultNetworkPort = uint16(7001)
@@ -46,6 +64,15 @@
 	DefaultMinPeers = 0
 	// DefaultMaxPeers is the default maximum number of peers
 	DefaultMaxPeers = 50
+	// DefaultRPCNodeMaxPeers is the minimum maximum number of peers of an RPC node
+	DefaultRPCNodeMaxPeers = 100
+	// MaxTipRequestRacers is the maximum number of peers a tip sync block request can be raced to
+	MaxTipRequestRacers = uint(3)
+
+	// DefaultDatabaseBackend is the default database backend
+	DefaultDatabaseBackend = string(database.DefaultBackend)
+	// DefaultMaxStateVersion is the default latest state trie version a followed chain can require
+	DefaultMaxStateVersion = uint(trie.V1)
 
 	// DefaultRPCPort is the default RPC port
 	DefaultRPCPort = uint32(8545)
@@ -54,6 +81,13 @@
 	// DefaultWSPort is the default WS port
 	DefaultWSPort = uint32(8546)
 
+	// DefaultSyncMaxRequests is the default maximum number of block requests in flight during bootstrap
+	DefaultSyncMaxRequests = uint(60)
+	// DefaultSyncMaxRequestsPerPeer is the default maximum number of block requests in flight to a peer
+	DefaultSyncMaxRequestsPerPeer = uint(1)
+	// DefaultSyncMaxWorkers is the default maximum number of peers to sync from, zero for no limit
+	DefaultSyncMaxWorkers = uint(0)
+
 	// DefaultPprofListenAddress is the default pprof listen address
 	DefaultPprofListenAddress = "localhost:6060"
 
@@ -61,6 +95,13 @@
 	DefaultSystemName = "Gossamer"
 	// DefaultSystemVersion is the default system version
 	DefaultSystemVersion = "0.0.0"
+
+	// RemoteSignerTokenEnv is the environment variable holding the remote signer bearer token
+	RemoteSignerTokenEnv = "GSSMR_REMOTE_SIGNER_TOKEN"
+	// PprofAuthTokenEnv is the environment variable holding the pprof server bearer token
+	PprofAuthTokenEnv = "GSSMR_PPROF_TOKEN"
+	// RPCAuthTokenEnv is the environment variable holding the token of the unsafe RPC methods
+	RPCAuthTokenEnv = "GSSMR_RPC_TOKEN"
 )
 
 // DefaultRPCModules the default RPC modules
@@ -75,6 +116,18 @@
 	"childstate",
 	"syncstate",
 	"payment",
+	"admin",
+	"chainHead",
+}
+
+// LightRPCModules are the RPC modules served by light clients, which neither execute the
+// runtime nor hold the storage of the chain
+var LightRPCModules = []string{
+	"system",
+	"chain",
+	"chainHead",
+	"grandpa",
+	"rpc",
 }
 
 // Config defines the configuration for the gossamer node
@@ -86,6 +139,7 @@ type Config struct {
 	Network    *NetworkConfig `mapstructure:"network"`
 	State      *StateConfig   `mapstructure:"state"`
 	RPC        *RPCConfig     `mapstructure:"rpc"`
+	Sync       *SyncConfig    `mapstructure:"sync"`
 	Pprof      *PprofConfig   `mapstructure:"pprof"`
 
 	// System holds the system information
@@ -117,6 +171,16 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.RPC.ValidateBasic(); err != nil {
 		return fmt.Errorf("rpc config: %w", err)
 	}
+	if cfg.Core.Role == common.LightClientRole {
+		for _, module := range cfg.RPC.Modules {
+			if !slices.Contains(LightRPCModules, module) {
+				return fmt.Errorf("rpc config: module %s is not served by light clients", module)
+			}
+		}
+	}
+	if err := cfg.Sync.ValidateBasic(); err != nil {
+		return fmt.Errorf("sync config: %w", err)
+	}
 	if err := cfg.Pprof.ValidateBasic(); err != nil {
 		return fmt.Errorf("pprof config: %w", err)
 	}
@@ -136,6 +200,11 @@ type BaseConfig struct {
 	PrometheusExternal bool                        `mapstructure:"prometheus-external,omitempty"`
 	NoTelemetry        bool                        `mapstructure:"no-telemetry"`
 	TelemetryURLs      []genesis.TelemetryEndpoint `mapstructure:"telemetry-urls,omitempty"`
+	// MemoryBudget is the number of mebibytes of memory the node shrinks its caches
+	// and buffers to stay under, zero for no budget.
+	MemoryBudget uint32 `mapstructure:"memory-budget,omitempty"`
+	// LogFormat is the format of the log lines, either console or json.
+	LogFormat string `mapstructure:"log-format,omitempty"`
 }
 
 // SystemConfig represents the system configuration
@@ -162,6 +231,14 @@ type LogConfig struct {
 type AccountConfig struct {
 	Key    string `mapstructure:"key,omitempty"`
 	Unlock string `mapstructure:"unlock,omitempty"`
+
+	// RemoteSignerURL is the url of an external signing service
+	RemoteSignerURL string `mapstructure:"remote-signer-url,omitempty"`
+	// RemoteSignerTokenFile is the path to a file holding the bearer token used to authenticate
+	// to the remote signer. The token may instead be given by the RemoteSignerTokenEnv variable.
+	RemoteSignerTokenFile string `mapstructure:"remote-signer-token-file,omitempty"`
+	// RemoteSignerKeyTypes are the keystore names (eg. imon, audi) served by the remote signer
+	RemoteSignerKeyTypes []string `mapstructure:"remote-signer-key-types,omitempty"`
 }
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
@@ -179,6 +256,21 @@ type NetworkConfig struct {
 	PublicDNS         string        `mapstructure:"public-dns"`
 	NodeKey           string        `mapstructure:"node-key"`
 	ListenAddress     string        `mapstructure:"listen-addr"`
+	// ListenAddresses are the multiaddresses to listen on along the ListenAddress, which
+	// default to the IPv4 and IPv6 unspecified addresses with the port when both are empty.
+	ListenAddresses []string `mapstructure:"listen-addrs"`
+	// TipRequestRacers is the number of peers a single block request is sent to during tip sync,
+	// to use the first valid response received. Racing is disabled if lower than 2.
+	TipRequestRacers uint `mapstructure:"tip-request-racers"`
+	// VerifyAncientBlocks verifies the BABE authorship of the blocks synced during the
+	// bootstrap sync which are committed to a justified block of the syncing chain.
+	VerifyAncientBlocks bool `mapstructure:"verify-ancient-blocks"`
+	// ValidatorPeers is the number of peer slots reserved to the validator peers, in
+	// addition to the incoming and outgoing slots.
+	ValidatorPeers uint `mapstructure:"validator-peers"`
+	// NodeKeyFile is the file the secret Ed25519 key of the libp2p identity is read from,
+	// or generated and written to if it does not exist, defaulting to node.key in the base path.
+	NodeKeyFile string `mapstructure:"node-key-file"`
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
@@ -188,11 +280,47 @@ type CoreConfig struct {
 	GrandpaAuthority bool               `mapstructure:"grandpa-authority"`
 	WasmInterpreter  string             `mapstructure:"wasm-interpreter,omitempty"`
 	GrandpaInterval  time.Duration      `mapstructure:"grandpa-interval,omitempty"`
+	// Dev enables instant and manual block sealing for local development
+	Dev bool `mapstructure:"dev,omitempty"`
+	// PoolLimit is the maximum number of transactions in the transaction pool
+	PoolLimit uint `mapstructure:"pool-limit"`
+	// PoolKBytes is the maximum size of the transactions in the pool in kilobytes
+	PoolKBytes uint `mapstructure:"pool-kbytes"`
+	// PoolSenderLimit is the maximum number of transactions of a sender in the pool,
+	// zero for no limit
+	PoolSenderLimit uint `mapstructure:"pool-sender-limit"`
+	// TxBanDuration is the duration invalid transactions are banned from the pool for
+	TxBanDuration time.Duration `mapstructure:"tx-ban-duration"`
+	// MaxUnfinalisedDepth is the number of unfinalised blocks above which the BABE block
+	// authoring is backed off, blocks being authored at growing slot intervals until the
+	// finality catches up, zero for no backoff
+	MaxUnfinalisedDepth uint `mapstructure:"max-unfinalised-depth"`
+	// RuntimeTracing traces the host function calls made by the runtime during each
+	// runtime call, logging them at the trace level and on runtime call failures.
+	RuntimeTracing bool `mapstructure:"runtime-tracing"`
 }
 
 // StateConfig contains the configuration for the state.
 type StateConfig struct {
-	Rewind uint `mapstructure:"rewind,omitempty"`
+	Rewind          uint   `mapstructure:"rewind,omitempty"`
+	DatabaseBackend string `mapstructure:"database-backend,omitempty"`
+	// MaxStateVersion is the latest state trie version the runtime of the
+	// followed chain can require, the node refusing to start otherwise
+	MaxStateVersion uint `mapstructure:"max-state-version"`
+	// StorageChangesIndex indexes the storage keys modified by each block imported, so
+	// state_queryStorage and state_getStorageDiff do not compare the state of the blocks
+	StorageChangesIndex bool `mapstructure:"storage-changes-index"`
+	// StorageChangesRetainedBlocks is the number of finalised blocks the storage
+	// changes index retains the modified keys of, zero to retain all of them
+	StorageChangesRetainedBlocks uint32 `mapstructure:"storage-changes-retained-blocks"`
+	// SnapshotDir is the directory the database is snapshotted to at each snapshot
+	// interval, the database not being snapshotted if it is empty
+	SnapshotDir string `mapstructure:"snapshot-dir"`
+	// SnapshotInterval is the interval between two database snapshots
+	SnapshotInterval time.Duration `mapstructure:"snapshot-interval"`
+	// SnapshotRetained is the number of most recent database snapshots
+	// retained, zero to retain all of them
+	SnapshotRetained uint32 `mapstructure:"snapshot-retained"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -206,6 +334,34 @@ type RPCConfig struct {
 	WSPort            uint32   `mapstructure:"ws-port,omitempty"`
 	WSExternal        bool     `mapstructure:"ws-external,omitempty"`
 	UnsafeWSExternal  bool     `mapstructure:"unsafe-ws-external,omitempty"`
+	// CORS are the browser origins allowed to access the HTTP and websocket servers,
+	// "all" allows any origin
+	CORS []string `mapstructure:"cors,omitempty"`
+	// TLSCertFile and TLSKeyFile are the paths to the certificate and private key files
+	// the HTTP and websocket servers serve TLS with.
+	TLSCertFile string `mapstructure:"tls-cert-file,omitempty"`
+	TLSKeyFile  string `mapstructure:"tls-key-file,omitempty"`
+	// ACMEDomains are the domains the TLS certificates of the HTTP and websocket servers
+	// are obtained for with the ACME protocol, instead of being read from files.
+	ACMEDomains []string `mapstructure:"acme-domains,omitempty"`
+	// AuthTokenFile is the path to a file holding the token the calls to the unsafe methods
+	// must be authenticated with, as a bearer token or as the password of a basic authentication.
+	// The token may instead be given by the RPCAuthTokenEnv variable, and the calls are not
+	// authenticated if there is no token.
+	AuthTokenFile string `mapstructure:"auth-token-file,omitempty"`
+}
+
+// SyncConfig contains the configuration of the block synchronisation.
+type SyncConfig struct {
+	// MaxRequests is the maximum number of block requests in flight at once during bootstrap.
+	MaxRequests uint `mapstructure:"max-requests"`
+	// MaxRequestsPerPeer is the maximum number of block requests in flight to a single peer.
+	MaxRequestsPerPeer uint `mapstructure:"max-requests-per-peer"`
+	// MaxWorkers is the maximum number of peers blocks are requested from, zero for no limit.
+	MaxWorkers uint `mapstructure:"max-workers"`
+	// Telemetry enables the telemetry messages summarising the batches of blocks
+	// synced, sent at most once a minute to the telemetry endpoints.
+	Telemetry bool `mapstructure:"telemetry"`
 }
 
 // PprofConfig contains the configuration for Pprof.
@@ -214,6 +370,10 @@ type PprofConfig struct {
 	ListeningAddress string `mapstructure:"listening-address,omitempty"`
 	BlockProfileRate int    `mapstructure:"block-profile-rate,omitempty"`
 	MutexProfileRate int    `mapstructure:"mutex-profile-rate,omitempty"`
+	// AuthTokenFile is the path to a file holding the bearer token the requests to the
+	// pprof server must be authenticated with. The token may instead be given by the
+	// PprofAuthTokenEnv variable, and the requests are not authenticated if there is no token.
+	AuthTokenFile string `mapstructure:"auth-token-file,omitempty"`
 }
 
 // ValidateBasic does the basic validation on BaseConfig
@@ -233,6 +393,11 @@ func (b *BaseConfig) ValidateBasic() error {
 	if b.PrometheusPort == 0 {
 		return fmt.Errorf("prometheus port cannot be empty")
 	}
+	if b.LogFormat != "" {
+		if _, err := log.ParseFormat(b.LogFormat); err != nil {
+			return fmt.Errorf("log-format: %w", err)
+		}
+	}
 	if uint32Max < b.RetainBlocks {
 		return fmt.Errorf(
 			"retain-blocks value overflows uint32 boundaries, must be less than or equal to: %d",
@@ -253,6 +418,20 @@ func (a *AccountConfig) ValidateBasic() error {
 	if a.Key == "" {
 		return fmt.Errorf("key cannot be empty")
 	}
+	if len(a.RemoteSignerKeyTypes) > 0 && a.RemoteSignerURL == "" {
+		return fmt.Errorf("remote-signer-url cannot be empty when remote-signer-key-types is set")
+	}
+	for _, name := range a.RemoteSignerKeyTypes {
+		switch keystore.Name(name) {
+		case keystore.BabeName, keystore.GranName:
+			// BABE needs VRF outputs and GRANDPA a local ed25519 keypair, neither
+			// of which the remote signer provides.
+			return fmt.Errorf("remote-signer-key-types: %s keys cannot be held by a remote signer", name)
+		}
+		if keystore.DetermineKeyType(name) == crypto.UnknownType {
+			return fmt.Errorf("remote-signer-key-types: invalid key type %s", name)
+		}
+	}
 
 	return nil
 }
@@ -268,12 +447,21 @@ func (n *NetworkConfig) ValidateBasic() error {
 	if n.DiscoveryInterval == 0 {
 		return fmt.Errorf("discovery-interval cannot be empty")
 	}
+	if n.TipRequestRacers > MaxTipRequestRacers {
+		return fmt.Errorf("tip-request-racers cannot be greater than %d", MaxTipRequestRacers)
+	}
+	if n.NodeKey != "" && n.NodeKeyFile != "" {
+		return fmt.Errorf("node-key and node-key-file cannot both be set")
+	}
 
 	return nil
 }
 
 // ValidateBasic does the basic validation on CoreConfig
 func (c *CoreConfig) ValidateBasic() error {
+	if c.Role == common.RPCNodeRole && (c.BabeAuthority || c.GrandpaAuthority) {
+		return fmt.Errorf("rpc role cannot run as a BABE or GRANDPA authority")
+	}
 	if c.WasmInterpreter == "" {
 		return fmt.Errorf("wasm-interpreter cannot be empty")
 	}
@@ -286,7 +474,22 @@ func (c *CoreConfig) ValidateBasic() error {
 
 // ValidateBasic does the basic validation on StateConfig
 func (s *StateConfig) ValidateBasic() error {
-	return nil
+	if s.MaxStateVersion > uint(trie.V1) {
+		return fmt.Errorf("max-state-version %d is not supported, the latest state version is %d",
+			s.MaxStateVersion, trie.V1)
+	}
+	if s.SnapshotDir != "" && s.SnapshotInterval <= 0 {
+		return fmt.Errorf("snapshot-interval must be positive, got %s", s.SnapshotInterval)
+	}
+	if s.DatabaseBackend == "" {
+		return nil
+	}
+	for _, backend := range database.Backends() {
+		if s.DatabaseBackend == string(backend) {
+			return nil
+		}
+	}
+	return fmt.Errorf("database-backend %s is not available", s.DatabaseBackend)
 }
 
 // ValidateBasic does the basic validation on RPCConfig
@@ -302,6 +505,33 @@ func (r *RPCConfig) ValidateBasic() error {
 	if r.IsWSEnabled() && r.WSPort == 0 {
 		return fmt.Errorf("ws port cannot be empty")
 	}
+	if (r.TLSCertFile == "") != (r.TLSKeyFile == "") {
+		return fmt.Errorf("tls cert file and tls key file must be set together")
+	}
+	if r.TLSCertFile != "" && len(r.ACMEDomains) > 0 {
+		return fmt.Errorf("tls cert file and acme domains cannot be set together")
+	}
+
+	return nil
+}
+
+// IsTLSEnabled returns true if the HTTP and websocket servers serve TLS.
+func (r *RPCConfig) IsTLSEnabled() bool {
+	return r.TLSCertFile != "" || len(r.ACMEDomains) > 0
+}
+
+// ValidateBasic does the basic validation on SyncConfig
+func (s *SyncConfig) ValidateBasic() error {
+	if s.MaxRequests == 0 {
+		return fmt.Errorf("max-requests cannot be zero")
+	}
+	if s.MaxRequestsPerPeer == 0 {
+		return fmt.Errorf("max-requests-per-peer cannot be zero")
+	}
+	if s.MaxRequestsPerPeer > s.MaxRequests {
+		return fmt.Errorf("max-requests-per-peer %d cannot be greater than max-requests %d",
+			s.MaxRequestsPerPeer, s.MaxRequests)
+	}
 
 	return nil
 }
@@ -334,6 +564,7 @@ func DefaultConfig() *Config {
 			BasePath:           xdg.DataHome + "gossamer",
 			ChainSpec:          "",
 			LogLevel:           DefaultLogLevel,
+			LogFormat:          DefaultLogFormat,
 			PrometheusPort:     DefaultPrometheusPort,
 			RetainBlocks:       DefaultRetainBlocks,
 			Pruning:            DefaultPruning,
@@ -363,6 +594,9 @@ func DefaultConfig() *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+			PoolLimit:        DefaultPoolLimit,
+			PoolKBytes:       DefaultPoolKBytes,
+			TxBanDuration:    DefaultTxBanDuration,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -380,7 +614,13 @@ func DefaultConfig() *Config {
 			ListenAddress:     "",
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:                       0,
+			DatabaseBackend:              DefaultDatabaseBackend,
+			MaxStateVersion:              DefaultMaxStateVersion,
+			StorageChangesIndex:          false,
+			StorageChangesRetainedBlocks: 0,
+			SnapshotInterval:             DefaultSnapshotInterval,
+			SnapshotRetained:             DefaultSnapshotRetained,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -393,6 +633,11 @@ func DefaultConfig() *Config {
 			WSExternal:        false,
 			UnsafeWSExternal:  false,
 		},
+		Sync: &SyncConfig{
+			MaxRequests:        DefaultSyncMaxRequests,
+			MaxRequestsPerPeer: DefaultSyncMaxRequestsPerPeer,
+			MaxWorkers:         DefaultSyncMaxWorkers,
+		},
 		Pprof: &PprofConfig{
 			Enabled:          false,
 			ListeningAddress: DefaultPprofListenAddress,
@@ -415,6 +660,7 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			BasePath:           xdg.DataHome + "gossamer",
 			ChainSpec:          "",
 			LogLevel:           DefaultLogLevel,
+			LogFormat:          DefaultLogFormat,
 			PrometheusPort:     uint32(9876),
 			RetainBlocks:       DefaultRetainBlocks,
 			Pruning:            DefaultPruning,
@@ -444,6 +690,9 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+			PoolLimit:        DefaultPoolLimit,
+			PoolKBytes:       DefaultPoolKBytes,
+			TxBanDuration:    DefaultTxBanDuration,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -461,7 +710,13 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			ListenAddress:     "",
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:                       0,
+			DatabaseBackend:              DefaultDatabaseBackend,
+			MaxStateVersion:              DefaultMaxStateVersion,
+			StorageChangesIndex:          false,
+			StorageChangesRetainedBlocks: 0,
+			SnapshotInterval:             DefaultSnapshotInterval,
+			SnapshotRetained:             DefaultSnapshotRetained,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -474,6 +729,11 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			WSExternal:        false,
 			UnsafeWSExternal:  false,
 		},
+		Sync: &SyncConfig{
+			MaxRequests:        DefaultSyncMaxRequests,
+			MaxRequestsPerPeer: DefaultSyncMaxRequestsPerPeer,
+			MaxWorkers:         DefaultSyncMaxWorkers,
+		},
 		Pprof: &PprofConfig{
 			Enabled:          false,
 			ListeningAddress: DefaultPprofListenAddress,
@@ -496,12 +756,14 @@ func Copy(c *Config) Config {
 			BasePath:           c.BaseConfig.BasePath,
 			ChainSpec:          c.BaseConfig.ChainSpec,
 			LogLevel:           c.BaseConfig.LogLevel,
+			LogFormat:          c.BaseConfig.LogFormat,
 			PrometheusPort:     c.PrometheusPort,
 			RetainBlocks:       c.RetainBlocks,
 			Pruning:            c.Pruning,
 			PrometheusExternal: c.PrometheusExternal,
 			NoTelemetry:        c.NoTelemetry,
-			TelemetryURLs:      c.TelemetryURLs,
+			TelemetryURLs:      append([]genesis.TelemetryEndpoint(nil), c.TelemetryURLs...),
+			MemoryBudget:       c.MemoryBudget,
 		},
 		Log: &LogConfig{
 			Core:    c.Log.Core,
@@ -516,33 +778,55 @@ func Copy(c *Config) Config {
 			Wasmer:  c.Log.Wasmer,
 		},
 		Account: &AccountConfig{
-			Key:    c.Account.Key,
-			Unlock: c.Account.Unlock,
+			Key:                   c.Account.Key,
+			Unlock:                c.Account.Unlock,
+			RemoteSignerURL:       c.Account.RemoteSignerURL,
+			RemoteSignerTokenFile: c.Account.RemoteSignerTokenFile,
+			RemoteSignerKeyTypes:  append([]string(nil), c.Account.RemoteSignerKeyTypes...),
 		},
 		Core: &CoreConfig{
-			Role:             c.Core.Role,
-			BabeAuthority:    c.Core.BabeAuthority,
-			GrandpaAuthority: c.Core.GrandpaAuthority,
-			WasmInterpreter:  c.Core.WasmInterpreter,
-			GrandpaInterval:  c.Core.GrandpaInterval,
+			Role:                c.Core.Role,
+			BabeAuthority:       c.Core.BabeAuthority,
+			GrandpaAuthority:    c.Core.GrandpaAuthority,
+			WasmInterpreter:     c.Core.WasmInterpreter,
+			GrandpaInterval:     c.Core.GrandpaInterval,
+			Dev:                 c.Core.Dev,
+			PoolLimit:           c.Core.PoolLimit,
+			PoolKBytes:          c.Core.PoolKBytes,
+			PoolSenderLimit:     c.Core.PoolSenderLimit,
+			TxBanDuration:       c.Core.TxBanDuration,
+			MaxUnfinalisedDepth: c.Core.MaxUnfinalisedDepth,
+			RuntimeTracing:      c.Core.RuntimeTracing,
 		},
 		Network: &NetworkConfig{
-			Port:              c.Network.Port,
-			Bootnodes:         c.Network.Bootnodes,
-			ProtocolID:        c.Network.ProtocolID,
-			NoBootstrap:       c.Network.NoBootstrap,
-			NoMDNS:            c.Network.NoMDNS,
-			MinPeers:          c.Network.MinPeers,
-			MaxPeers:          c.Network.MaxPeers,
-			PersistentPeers:   c.Network.PersistentPeers,
-			DiscoveryInterval: c.Network.DiscoveryInterval,
-			PublicIP:          c.Network.PublicIP,
-			PublicDNS:         c.Network.PublicDNS,
-			NodeKey:           c.Network.NodeKey,
-			ListenAddress:     c.Network.ListenAddress,
+			Port:                c.Network.Port,
+			Bootnodes:           append([]string(nil), c.Network.Bootnodes...),
+			ProtocolID:          c.Network.ProtocolID,
+			NoBootstrap:         c.Network.NoBootstrap,
+			NoMDNS:              c.Network.NoMDNS,
+			MinPeers:            c.Network.MinPeers,
+			MaxPeers:            c.Network.MaxPeers,
+			PersistentPeers:     append([]string(nil), c.Network.PersistentPeers...),
+			DiscoveryInterval:   c.Network.DiscoveryInterval,
+			PublicIP:            c.Network.PublicIP,
+			PublicDNS:           c.Network.PublicDNS,
+			NodeKey:             c.Network.NodeKey,
+			ListenAddress:       c.Network.ListenAddress,
+			ListenAddresses:     append([]string(nil), c.Network.ListenAddresses...),
+			TipRequestRacers:    c.Network.TipRequestRacers,
+			VerifyAncientBlocks: c.Network.VerifyAncientBlocks,
+			ValidatorPeers:      c.Network.ValidatorPeers,
+			NodeKeyFile:         c.Network.NodeKeyFile,
 		},
 		State: &StateConfig{
-			Rewind: c.State.Rewind,
+			Rewind:                       c.State.Rewind,
+			DatabaseBackend:              c.State.DatabaseBackend,
+			MaxStateVersion:              c.State.MaxStateVersion,
+			StorageChangesIndex:          c.State.StorageChangesIndex,
+			StorageChangesRetainedBlocks: c.State.StorageChangesRetainedBlocks,
+			SnapshotDir:                  c.State.SnapshotDir,
+			SnapshotInterval:             c.State.SnapshotInterval,
+			SnapshotRetained:             c.State.SnapshotRetained,
 		},
 		RPC: &RPCConfig{
 			UnsafeRPC:         c.RPC.UnsafeRPC,
@@ -550,16 +834,28 @@ func Copy(c *Config) Config {
 			RPCExternal:       c.RPC.RPCExternal,
 			Port:              c.RPC.Port,
 			Host:              c.RPC.Host,
-			Modules:           c.RPC.Modules,
+			Modules:           append([]string(nil), c.RPC.Modules...),
 			WSPort:            c.RPC.WSPort,
 			WSExternal:        c.RPC.WSExternal,
 			UnsafeWSExternal:  c.RPC.UnsafeWSExternal,
+			CORS:              append([]string(nil), c.RPC.CORS...),
+			TLSCertFile:       c.RPC.TLSCertFile,
+			TLSKeyFile:        c.RPC.TLSKeyFile,
+			ACMEDomains:       append([]string(nil), c.RPC.ACMEDomains...),
+			AuthTokenFile:     c.RPC.AuthTokenFile,
+		},
+		Sync: &SyncConfig{
+			MaxRequests:        c.Sync.MaxRequests,
+			MaxRequestsPerPeer: c.Sync.MaxRequestsPerPeer,
+			MaxWorkers:         c.Sync.MaxWorkers,
+			Telemetry:          c.Sync.Telemetry,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          c.Pprof.Enabled,
 			ListeningAddress: c.Pprof.ListeningAddress,
 			BlockProfileRate: c.Pprof.BlockProfileRate,
 			MutexProfileRate: c.Pprof.MutexProfileRate,
+			AuthTokenFile:    c.Pprof.AuthTokenFile,
 		},
 		System: &SystemConfig{
 			SystemName:    c.System.SystemName,
@@ -619,6 +915,9 @@ func (c Chain) String() string {
 
 	// AuthorityNode is an authority node
 	AuthorityNode NetworkRole = "authority"
+
+	// RPCNode is a full node serving RPC requests which neither authors nor votes
+	RPCNode NetworkRole = "rpc"
 )
 
 // String returns the string representation of the network role
@@ -0,0 +1,436 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	baseConfig := func(logFormat string) BaseConfig {
+		return BaseConfig{
+			Name:           "Gossamer",
+			ID:             "gssmr",
+			BasePath:       "basepath",
+			ChainSpec:      "chain-spec-raw.json",
+			PrometheusPort: DefaultPrometheusPort,
+			LogFormat:      logFormat,
+		}
+	}
+
+	testCases := map[string]struct {
+		config BaseConfig
+		errMsg string
+	}{
+		"default_log_format": {
+			config: baseConfig(DefaultLogFormat),
+		},
+		"no_log_format": {
+			config: baseConfig(""),
+		},
+		"json_log_format": {
+			config: baseConfig("json"),
+		},
+		"unknown_log_format": {
+			config: baseConfig("xml"),
+			errMsg: "log-format: format is not recognised: xml",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestAccountConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config AccountConfig
+		errMsg string
+	}{
+		"no_remote_signer": {
+			config: AccountConfig{Key: "alice"},
+		},
+		"remote_signer": {
+			config: AccountConfig{
+				Key:                  "alice",
+				RemoteSignerURL:      "http://localhost:9000",
+				RemoteSignerKeyTypes: []string{"imon", "audi", "beef"},
+			},
+		},
+		"missing_remote_signer_url": {
+			config: AccountConfig{
+				Key:                  "alice",
+				RemoteSignerKeyTypes: []string{"imon"},
+			},
+			errMsg: "remote-signer-url cannot be empty when remote-signer-key-types is set",
+		},
+		"remote_babe_keys": {
+			config: AccountConfig{
+				Key:                  "alice",
+				RemoteSignerURL:      "http://localhost:9000",
+				RemoteSignerKeyTypes: []string{"babe"},
+			},
+			errMsg: "remote-signer-key-types: babe keys cannot be held by a remote signer",
+		},
+		"remote_gran_keys": {
+			config: AccountConfig{
+				Key:                  "alice",
+				RemoteSignerURL:      "http://localhost:9000",
+				RemoteSignerKeyTypes: []string{"gran"},
+			},
+			errMsg: "remote-signer-key-types: gran keys cannot be held by a remote signer",
+		},
+		"unknown_key_type": {
+			config: AccountConfig{
+				Key:                  "alice",
+				RemoteSignerURL:      "http://localhost:9000",
+				RemoteSignerKeyTypes: []string{"nope"},
+			},
+			errMsg: "remote-signer-key-types: invalid key type nope",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestCoreConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config CoreConfig
+		errMsg string
+	}{
+		"authority": {
+			config: CoreConfig{
+				Role:             common.AuthorityRole,
+				BabeAuthority:    true,
+				GrandpaAuthority: true,
+				WasmInterpreter:  DefaultWasmInterpreter,
+			},
+		},
+		"rpc": {
+			config: CoreConfig{
+				Role:            common.RPCNodeRole,
+				WasmInterpreter: DefaultWasmInterpreter,
+			},
+		},
+		"rpc_babe_authority": {
+			config: CoreConfig{
+				Role:            common.RPCNodeRole,
+				BabeAuthority:   true,
+				WasmInterpreter: DefaultWasmInterpreter,
+			},
+			errMsg: "rpc role cannot run as a BABE or GRANDPA authority",
+		},
+		"rpc_grandpa_authority": {
+			config: CoreConfig{
+				Role:             common.RPCNodeRole,
+				GrandpaAuthority: true,
+				WasmInterpreter:  DefaultWasmInterpreter,
+			},
+			errMsg: "rpc role cannot run as a BABE or GRANDPA authority",
+		},
+		"empty_wasm_interpreter": {
+			config: CoreConfig{Role: common.FullNodeRole},
+			errMsg: "wasm-interpreter cannot be empty",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestStateConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config StateConfig
+		errMsg string
+	}{
+		"default": {
+			config: StateConfig{
+				DatabaseBackend: DefaultDatabaseBackend,
+				MaxStateVersion: DefaultMaxStateVersion,
+			},
+		},
+		"max_state_version_0": {
+			config: StateConfig{MaxStateVersion: 0},
+		},
+		"unknown_max_state_version": {
+			config: StateConfig{MaxStateVersion: 2},
+			errMsg: "max-state-version 2 is not supported, the latest state version is 1",
+		},
+		"unknown_database_backend": {
+			config: StateConfig{DatabaseBackend: "leveldb"},
+			errMsg: "database-backend leveldb is not available",
+		},
+		"snapshots": {
+			config: StateConfig{SnapshotDir: "snapshots", SnapshotInterval: DefaultSnapshotInterval},
+		},
+		"zero_snapshot_interval": {
+			config: StateConfig{SnapshotDir: "snapshots"},
+			errMsg: "snapshot-interval must be positive, got 0s",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestNetworkConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config NetworkConfig
+		errMsg string
+	}{
+		"default": {
+			config: NetworkConfig{
+				Port:              DefaultNetworkPort,
+				ProtocolID:        "/gossamer/gssmr/0",
+				DiscoveryInterval: DefaultDiscoveryInterval,
+			},
+		},
+		"max_tip_request_racers": {
+			config: NetworkConfig{
+				Port:              DefaultNetworkPort,
+				ProtocolID:        "/gossamer/gssmr/0",
+				DiscoveryInterval: DefaultDiscoveryInterval,
+				TipRequestRacers:  MaxTipRequestRacers,
+			},
+		},
+		"too_many_tip_request_racers": {
+			config: NetworkConfig{
+				Port:              DefaultNetworkPort,
+				ProtocolID:        "/gossamer/gssmr/0",
+				DiscoveryInterval: DefaultDiscoveryInterval,
+				TipRequestRacers:  MaxTipRequestRacers + 1,
+			},
+			errMsg: "tip-request-racers cannot be greater than 3",
+		},
+		"node_key_and_node_key_file": {
+			config: NetworkConfig{
+				Port:              DefaultNetworkPort,
+				ProtocolID:        "/gossamer/gssmr/0",
+				DiscoveryInterval: DefaultDiscoveryInterval,
+				NodeKey:           "0102",
+				NodeKeyFile:       "node.key",
+			},
+			errMsg: "node-key and node-key-file cannot both be set",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestSyncConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config SyncConfig
+		errMsg string
+	}{
+		"default": {
+			config: SyncConfig{
+				MaxRequests:        DefaultSyncMaxRequests,
+				MaxRequestsPerPeer: DefaultSyncMaxRequestsPerPeer,
+				MaxWorkers:         DefaultSyncMaxWorkers,
+			},
+		},
+		"max_requests_per_peer_equal_to_max_requests": {
+			config: SyncConfig{MaxRequests: 4, MaxRequestsPerPeer: 4, MaxWorkers: 1},
+		},
+		"max_requests_0": {
+			config: SyncConfig{MaxRequestsPerPeer: 1},
+			errMsg: "max-requests cannot be zero",
+		},
+		"max_requests_per_peer_0": {
+			config: SyncConfig{MaxRequests: 1},
+			errMsg: "max-requests-per-peer cannot be zero",
+		},
+		"max_requests_per_peer_greater_than_max_requests": {
+			config: SyncConfig{MaxRequests: 2, MaxRequestsPerPeer: 3},
+			errMsg: "max-requests-per-peer 3 cannot be greater than max-requests 2",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestRPCConfig_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config RPCConfig
+		errMsg string
+	}{
+		"default": {
+			config: RPCConfig{Port: DefaultRPCPort, Host: DefaultRPCHost, WSPort: DefaultWSPort},
+		},
+		"tls_files": {
+			config: RPCConfig{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"},
+		},
+		"acme_domains": {
+			config: RPCConfig{ACMEDomains: []string{"rpc.example.com"}},
+		},
+		"tls_key_file_missing": {
+			config: RPCConfig{TLSCertFile: "cert.pem"},
+			errMsg: "tls cert file and tls key file must be set together",
+		},
+		"tls_files_and_acme_domains": {
+			config: RPCConfig{
+				TLSCertFile: "cert.pem",
+				TLSKeyFile:  "key.pem",
+				ACMEDomains: []string{"rpc.example.com"},
+			},
+			errMsg: "tls cert file and acme domains cannot be set together",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.config.ValidateBasic()
+			if testCase.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
+
+func TestCopy_remoteSignerKeyTypes(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig()
+	config.Account.RemoteSignerKeyTypes = []string{"imon", "audi"}
+
+	copied := Copy(config)
+	copied.Account.RemoteSignerKeyTypes[0] = "para"
+
+	assert.Equal(t, []string{"imon", "audi"}, config.Account.RemoteSignerKeyTypes)
+}
+
+func TestWriteConfigFile_remoteSigner(t *testing.T) {
+	t.Parallel()
+
+	basePath := t.TempDir()
+	require.NoError(t, EnsureRoot(basePath))
+
+	config := DefaultConfig()
+	config.Account.RemoteSignerURL = "http://localhost:9000"
+	config.Account.RemoteSignerTokenFile = "/etc/gossamer/signer-token"
+	config.Account.RemoteSignerKeyTypes = []string{"imon", "audi"}
+
+	err := WriteConfigFile(basePath, config)
+	require.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(basePath, defaultConfigFilePath))
+	err = v.ReadInConfig()
+	require.NoError(t, err)
+
+	parsed := DefaultConfig()
+	err = v.Unmarshal(parsed)
+	require.NoError(t, err)
+
+	assert.Equal(t, config.Account.RemoteSignerURL, parsed.Account.RemoteSignerURL)
+	assert.Equal(t, config.Account.RemoteSignerTokenFile, parsed.Account.RemoteSignerTokenFile)
+	assert.Equal(t, config.Account.RemoteSignerKeyTypes, parsed.Account.RemoteSignerKeyTypes)
+}
+
+func TestConfig_ValidateBasic_lightRPCModules(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig()
+	config.ChainSpec = "chain-spec-raw.json"
+	config.Core.Role = common.LightClientRole
+	config.RPC.Modules = LightRPCModules
+	require.NoError(t, config.ValidateBasic())
+
+	config.RPC.Modules = append([]string{"author"}, LightRPCModules...)
+	err := config.ValidateBasic()
+	assert.EqualError(t, err, "rpc config: module author is not served by light clients")
+}
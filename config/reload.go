@@ -0,0 +1,90 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// ErrImmutableField is returned when reloading a config changing a field which requires a node restart
+var ErrImmutableField = errors.New("field cannot be changed without restarting the node")
+
+// ReadConfigFile reads the config file in the base path, using the given config for the
+// values missing from the file, except the telemetry urls which are omitted from the file
+// when there is none. The given config is not modified.
+func ReadConfigFile(basePath string, base *Config) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(filepath.Join(basePath, defaultConfigFilePath))
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	config := Copy(base)
+	config.TelemetryURLs = nil
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("decoding config file: %w", err)
+	}
+	return &config, nil
+}
+
+// ValidateReload checks that the updated config only differs from the current one by fields
+// which can be reloaded at runtime: the log levels, the rpc cors origins, the telemetry
+// endpoints, the persistent peers and the pruning retain-blocks target.
+func ValidateReload(current, updated *Config) error {
+	if err := updated.ValidateBasic(); err != nil {
+		return err
+	}
+
+	a, b := Copy(current), Copy(updated)
+	for _, c := range []*Config{&a, &b} {
+		c.LogLevel = ""
+		c.Log = &LogConfig{}
+		c.RPC.CORS = nil
+		c.TelemetryURLs = nil
+		c.Network.PersistentPeers = nil
+		c.RetainBlocks = 0
+		c.System = &SystemConfig{}
+	}
+
+	changed := changedFields("", reflect.ValueOf(a), reflect.ValueOf(b))
+	if len(changed) > 0 {
+		return fmt.Errorf("%w: %s", ErrImmutableField, strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// changedFields returns the mapstructure names of the fields differing between the structs a and b
+func changedFields(prefix string, a, b reflect.Value) (changed []string) {
+	if a.Kind() == reflect.Ptr {
+		a, b = a.Elem(), b.Elem()
+	}
+
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		name := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		fieldA, fieldB := a.Field(i), b.Field(i)
+
+		isStruct := field.Type.Kind() == reflect.Struct ||
+			field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct
+		if isStruct {
+			subPrefix := prefix
+			if name != "" {
+				subPrefix = prefix + name + "."
+			}
+			changed = append(changed, changedFields(subPrefix, fieldA, fieldB)...)
+			continue
+		}
+
+		if !reflect.DeepEqual(fieldA.Interface(), fieldB.Interface()) {
+			changed = append(changed, prefix+name)
+		}
+	}
+	return changed
+}
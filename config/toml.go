@@ -82,6 +82,11 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to "info"
 log-level = "{{ .BaseConfig.LogLevel }}"
 
+# Format of the log lines
+# One of: console, json
+# Defaults to "console"
+log-format = "{{ .BaseConfig.LogFormat }}"
+
 # Listen address for the prometheus server
 # Defaults to "localhost:9876"
 prometheus-port = {{ .BaseConfig.PrometheusPort }}
@@ -94,6 +99,10 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to "archive"
 pruning = "{{ .BaseConfig.Pruning }}"
 
+# Memory budget in MiB the caches and buffers are shrunk to stay under
+# Defaults to 0 for no budget
+memory-budget = {{ .BaseConfig.MemoryBudget }}
+
 # Disable connecting to the Substrate telemetry server
 # Defaults to false
 no-telemetry = {{ .BaseConfig.NoTelemetry }}
@@ -167,6 +176,17 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Unlock an account. eg. --unlock=0 to unlock account 0
 unlock = "{{ .Account.Unlock }}"
 
+# URL of an external signing service holding the private keys
+remote-signer-url = "{{ .Account.RemoteSignerURL }}"
+
+# File holding the bearer token used to authenticate to the remote signer
+# The token may instead be given by the GSSMR_REMOTE_SIGNER_TOKEN environment variable
+remote-signer-token-file = "{{ .Account.RemoteSignerTokenFile }}"
+
+# Keystores whose keys are held by the remote signer, eg. ["imon", "audi"]
+# babe and gran keys cannot be held by a remote signer
+remote-signer-key-types = [{{ range .Account.RemoteSignerKeyTypes }}"{{ . }}", {{ end }}]
+
 #######################################################
 ###          Network Configuration Options          ###
 #######################################################
@@ -199,7 +219,7 @@ func WriteConfigFile(basePath string, config *Config) error {
 max-peers = {{ .Network.MaxPeers }}
 
 # Comma separated list of peers to always keep connected to
-persistent-peers = "{{ StringsJoin .Network.PersistentPeers ", " }}"
+persistent-peers = "{{ StringsJoin .Network.PersistentPeers "," }}"
 
 # Interval to perform peer discovery in duration
 # Format: "10s", "1m", "1h"
@@ -214,9 +234,32 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Overrides the secret Ed25519 key to use for libp2p networking
 node-key = "{{ .Network.NodeKey }}"
 
+# File the secret Ed25519 key to use for libp2p networking is read from, or generated and written to
+# Defaults to node.key in the base path
+node-key-file = "{{ .Network.NodeKeyFile }}"
+
 # Multiaddress to listen on
 listen-addr = "{{ .Network.ListenAddress }}"
 
+# Multiaddresses to listen on along listen-addr, eg. to listen on both IPv4 and IPv6
+# Defaults to listening on all the IPv4 and IPv6 interfaces with the port when listen-addr is empty
+listen-addrs = [{{ range .Network.ListenAddresses }}"{{ . }}", {{ end }}]
+
+# Number of peers a single block request is sent to during tip sync,
+# to use the first valid response received. Disabled if lower than 2.
+# Defaults to 0
+tip-request-racers = {{ .Network.TipRequestRacers }}
+
+# Verify the BABE authorship of the blocks synced during the bootstrap sync
+# which are committed to a justified block of the syncing chain.
+# Defaults to false
+verify-ancient-blocks = {{ .Network.VerifyAncientBlocks }}
+
+# Number of peer slots reserved to the validator peers, in addition to the
+# incoming and outgoing slots.
+# Defaults to 0
+validator-peers = {{ .Network.ValidatorPeers }}
+
 #######################################################
 ###             Core Configuration Options          ###
 #######################################################
@@ -224,7 +267,7 @@ func WriteConfigFile(basePath string, config *Config) error {
 
 # Role of the gossamer node
 # Represented as an integer
-# One of: 1 (Full), 2 (Light), 4 (Authority)
+# One of: 1 (Full), 2 (Light), 4 (Authority), 8 (RPC)
 role = {{ .Core.Role }}
 
 # Enable BABE authoring
@@ -242,6 +285,36 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Grandpa interval
 grandpa-interval = "{{ .Core.GrandpaInterval }}"
 
+# Development mode: seal a block as soon as an extrinsic is submitted
+# and expose the engine_createBlock and engine_finalizeBlock RPCs
+# Defaults to false
+dev = {{ .Core.Dev }}
+
+# Maximum number of transactions in the transaction pool
+# Defaults to 8192
+pool-limit = {{ .Core.PoolLimit }}
+
+# Maximum size of the transactions in the transaction pool in kilobytes
+# Defaults to 20480
+pool-kbytes = {{ .Core.PoolKBytes }}
+
+# Maximum number of transactions of a single sender in the transaction pool
+# Defaults to 0, no limit
+pool-sender-limit = {{ .Core.PoolSenderLimit }}
+
+# Duration invalid transactions are banned from the transaction pool for
+# Defaults to "30m0s"
+tx-ban-duration = "{{ .Core.TxBanDuration }}"
+
+# Number of unfinalised blocks of the best chain above which BABE backs off the block
+# authoring, authoring blocks at growing slot intervals until the finality catches up
+# Defaults to 0, no backoff
+max-unfinalised-depth = {{ .Core.MaxUnfinalisedDepth }}
+
+# Trace the host function calls made by the runtime during each runtime call
+# Defaults to false
+runtime-tracing = {{ .Core.RuntimeTracing }}
+
 #######################################################
 ###            State Configuration Options          ###
 #######################################################
@@ -250,6 +323,40 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to 0
 rewind = {{ .State.Rewind }}
 
+# Database backend, one of "pebble", "rocksdb" or "memory"
+# RocksDB is only available in binaries built with the rocksdb build tag
+# Memory databases are lost when the node stops
+# Defaults to "pebble"
+database-backend = "{{ .State.DatabaseBackend }}"
+
+# Latest state trie version the runtime of the followed chain can require.
+# The node refuses to start if the chain requires a later state version.
+# Defaults to 1
+max-state-version = {{ .State.MaxStateVersion }}
+
+# Index the storage keys modified by each block imported, so the storage
+# changes are queried without comparing the state of the blocks
+# Defaults to false
+storage-changes-index = {{ .State.StorageChangesIndex }}
+
+# Number of finalised blocks the storage changes index retains the
+# modified keys of, 0 to retain the modified keys of all the blocks
+# Defaults to 0
+storage-changes-retained-blocks = {{ .State.StorageChangesRetainedBlocks }}
+
+# Directory the database is snapshotted to at each snapshot interval,
+# the snapshots being hard linked to the database files where supported
+# Defaults to "", the database not being snapshotted
+snapshot-dir = "{{ .State.SnapshotDir }}"
+
+# Interval between two database snapshots
+# Defaults to "6h0m0s"
+snapshot-interval = "{{ .State.SnapshotInterval }}"
+
+# Number of most recent database snapshots retained, 0 to retain all of them
+# Defaults to 3
+snapshot-retained = {{ .State.SnapshotRetained }}
+
 #######################################################
 ###              RPC Configuration Options          ###
 #######################################################
@@ -276,7 +383,7 @@ func WriteConfigFile(basePath string, config *Config) error {
 host = "{{ .RPC.Host }}"
 
 # API modules to enable via HTTP-RPC, comma separated list
-# Defaults to "system, author, chain, state, rpc, grandpa, offchain, childstate, syncstate, payment"
+# Defaults to "system, author, chain, state, rpc, grandpa, offchain, childstate, syncstate, payment, admin, chainHead"
 modules = [{{ range .RPC.Modules }}"{{ . }}", {{ end }}]
 
 # Websockets server listening port
@@ -291,6 +398,49 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to false
 unsafe-ws-external = {{ .RPC.UnsafeWSExternal }}
 
+# Browser origins allowed to access the HTTP-RPC and websocket servers, eg. ["https://polkadot.js.org"]
+# Use ["all"] to allow any origin
+# Defaults to no cross origin headers being sent
+cors = [{{ range .RPC.CORS }}"{{ . }}", {{ end }}]
+
+# Paths to the certificate and private key files the HTTP-RPC and websocket servers serve TLS with
+# Defaults to "", TLS not being served
+tls-cert-file = "{{ .RPC.TLSCertFile }}"
+tls-key-file = "{{ .RPC.TLSKeyFile }}"
+
+# Domains the TLS certificates are obtained for from Let's Encrypt, instead of the certificate files
+# The HTTP-01 challenges are answered on port 80, which must be reachable and not be used by another server
+# Defaults to no domain
+acme-domains = [{{ range .RPC.ACMEDomains }}"{{ . }}", {{ end }}]
+
+# Path to a file holding the token the calls to the unsafe methods must be authenticated with,
+# as a bearer token or as the password of a basic authentication.
+# The token may instead be set with the GSSMR_RPC_TOKEN environment variable.
+# Defaults to "", the calls not being authenticated
+auth-token-file = "{{ .RPC.AuthTokenFile }}"
+
+#######################################################
+###            SYNC Configuration Options           ###
+#######################################################
+[sync]
+
+# Maximum number of block requests in flight at once during bootstrap
+# Defaults to 60
+max-requests = {{ .Sync.MaxRequests }}
+
+# Maximum number of block requests in flight to a single peer, at most max-requests
+# Defaults to 1
+max-requests-per-peer = {{ .Sync.MaxRequestsPerPeer }}
+
+# Maximum number of peers blocks are requested from
+# Defaults to 0, no limit
+max-workers = {{ .Sync.MaxWorkers }}
+
+# Send telemetry messages summarising the batches of blocks synced,
+# at most once a minute
+# Defaults to false
+telemetry = {{ .Sync.Telemetry }}
+
 #######################################################
 ###            PPROF Configuration Options          ###
 #######################################################
@@ -311,4 +461,9 @@ func WriteConfigFile(basePath string, config *Config) error {
 # The frequency at which the Go runtime samples the state of mutexes to generate mutex profile information.
 # Defaults to 0
 mutex-profile-rate = {{ .Pprof.MutexProfileRate }}
+
+# Path to a file holding the bearer token the pprof server requests must be authenticated with.
+# The token may instead be given by the GSSMR_PPROF_TOKEN environment variable.
+# Defaults to no authentication
+auth-token-file = "{{ .Pprof.AuthTokenFile }}"
 `
@@ -0,0 +1,551 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/events"
+	"github.com/ChainSafe/gossamer/dot/light"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/rpc"
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
+	dotsync "github.com/ChainSafe/gossamer/dot/sync"
+	"github.com/ChainSafe/gossamer/dot/system"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/memory"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+)
+
+var (
+	// errUnknownComponent is returned when a component depends on a component missing from the assembly
+	errUnknownComponent = errors.New("unknown component")
+	// errComponentCycle is returned when components of an assembly depend on each other
+	errComponentCycle = errors.New("component dependency cycle")
+)
+
+// componentName identifies a component of a node assembly
+type componentName string
+
+const (
+	pprofComponent    componentName = "pprof"
+	stateComponent    componentName = "state"
+	snapshotComponent componentName = "snapshot"
+	systemComponent   componentName = "system"
+	networkComponent  componentName = "network"
+	runtimeComponent  componentName = "runtime"
+	verifierComponent componentName = "verifier"
+	digestComponent   componentName = "digest"
+	coreComponent     componentName = "core"
+	grandpaComponent  componentName = "grandpa"
+	syncComponent     componentName = "sync"
+	babeComponent     componentName = "babe"
+	reloadComponent   componentName = "reload"
+	rpcComponent      componentName = "rpc"
+)
+
+// component describes how to build a part of a node from the components it depends on.
+type component struct {
+	name      componentName
+	dependsOn []componentName
+	// build creates the component and stores it in the assembly.
+	// It registers the node services the component provides using assembly.addService.
+	build func(a *assembly) error
+	// onAssembled is an optional hook called once all the components are built,
+	// in the build order, to wire components depending on each other.
+	onAssembled func(a *assembly)
+}
+
+// fullNodeComponents are the components of a full or authority node.
+// The archive node uses the same components, with the state pruner in archive mode.
+var fullNodeComponents = []component{
+	{name: pprofComponent, build: buildPprof},
+	{name: stateComponent, build: buildState},
+	{name: snapshotComponent, dependsOn: []componentName{stateComponent}, build: buildSnapshot},
+	{name: systemComponent, dependsOn: []componentName{stateComponent}, build: buildSystem},
+	{
+		name:      networkComponent,
+		dependsOn: []componentName{stateComponent, systemComponent},
+		build:     buildNetwork,
+	},
+	{
+		name:      runtimeComponent,
+		dependsOn: []componentName{stateComponent, networkComponent},
+		build:     buildRuntime,
+	},
+	{name: verifierComponent, dependsOn: []componentName{runtimeComponent}, build: buildVerifier},
+	{name: digestComponent, dependsOn: []componentName{runtimeComponent}, build: buildDigest},
+	{
+		name:      coreComponent,
+		dependsOn: []componentName{stateComponent, networkComponent, runtimeComponent},
+		build:     buildCore,
+	},
+	{
+		name:      grandpaComponent,
+		dependsOn: []componentName{stateComponent, networkComponent, runtimeComponent},
+		build:     buildGrandpa,
+	},
+	{
+		name:        syncComponent,
+		dependsOn:   []componentName{grandpaComponent, verifierComponent, coreComponent, networkComponent},
+		build:       buildSync,
+		onAssembled: wireNetworkHandlers,
+	},
+	{name: babeComponent, dependsOn: []componentName{stateComponent, coreComponent}, build: buildBabe},
+	{
+		name:      reloadComponent,
+		dependsOn: []componentName{stateComponent, networkComponent},
+		build:     buildReloader,
+	},
+	{
+		name: rpcComponent,
+		dependsOn: []componentName{stateComponent, runtimeComponent, coreComponent, networkComponent,
+			babeComponent, systemComponent, grandpaComponent, syncComponent, reloadComponent},
+		build: buildRPC,
+	},
+}
+
+// lightNodeComponents are the components of a light client. Light clients only sync and
+// verify block headers, and read the storage of blocks from full node peers.
+var lightNodeComponents = []component{
+	{name: pprofComponent, build: buildPprof},
+	{name: stateComponent, build: buildState},
+	{name: snapshotComponent, dependsOn: []componentName{stateComponent}, build: buildSnapshot},
+	{name: systemComponent, dependsOn: []componentName{stateComponent}, build: buildSystem},
+	{
+		name:      networkComponent,
+		dependsOn: []componentName{stateComponent, systemComponent},
+		build:     buildNetwork,
+	},
+	{name: digestComponent, dependsOn: []componentName{stateComponent}, build: buildDigest},
+	{
+		name:      grandpaComponent,
+		dependsOn: []componentName{stateComponent, networkComponent},
+		build:     buildLightGrandpa,
+	},
+	{
+		name:        syncComponent,
+		dependsOn:   []componentName{stateComponent, grandpaComponent, networkComponent},
+		build:       buildHeaderSync,
+		onAssembled: wireLightNetworkHandlers,
+	},
+	{
+		name:      reloadComponent,
+		dependsOn: []componentName{stateComponent, networkComponent},
+		build:     buildReloader,
+	},
+	{
+		name: rpcComponent,
+		dependsOn: []componentName{stateComponent, networkComponent, systemComponent, grandpaComponent,
+			syncComponent, reloadComponent},
+		build: buildRPC,
+	},
+}
+
+// nodeComponents returns the components of the node with the configured role
+func nodeComponents(config *cfg.Config) []component {
+	if config.Core.Role == common.LightClientRole {
+		return lightNodeComponents
+	}
+	return fullNodeComponents
+}
+
+// assembly holds the components of a node while it is being built
+type assembly struct {
+	config *cfg.Config
+	ks     *keystore.GlobalKeystore
+
+	stateBuilder     stateBuilder
+	networkBuilder   networkBuilder
+	consensusBuilder consensusBuilder
+	syncBuilder      syncBuilder
+	rpcBuilder       rpcBuilder
+
+	services []service
+	// supervisor restarts the failed goroutines of the node services and tracks their health.
+	supervisor *supervisor.Supervisor
+	// budget shrinks the caches and buffers of the node services under memory pressure.
+	budget *memory.Budget
+	// events is the bus the node services publish and subscribe to their events on.
+	events *events.Bus
+
+	telemetry   Telemetry
+	state       *state.Service
+	system      *system.Service
+	network     *network.Service
+	nodeStorage *runtime.NodeStorage
+	verifier    *babe.VerificationManager
+	core        *core.Service
+	grandpa     *grandpa.Service
+	sync        *dotsync.Service
+	babe        *babe.Service
+	reloader    *configReloader
+	headerSync  *light.HeaderSync
+	chainHead   modules.ChainHeadAPI
+}
+
+func newAssembly(config *cfg.Config, ks *keystore.GlobalKeystore, builder nodeBuilderIface) *assembly {
+	return &assembly{
+		config:           config,
+		ks:               ks,
+		stateBuilder:     builder,
+		networkBuilder:   builder,
+		consensusBuilder: builder,
+		syncBuilder:      builder,
+		rpcBuilder:       builder,
+		supervisor:       supervisor.New(),
+		budget:           memory.NewBudget(uint64(config.MemoryBudget) << 20),
+		events:           events.NewBus(),
+	}
+}
+
+// addService registers a service of the node being assembled
+func (a *assembly) addService(srvc service) {
+	a.services = append(a.services, srvc)
+}
+
+// build builds the components in their dependency order and then runs their onAssembled hooks.
+func (a *assembly) build(components []component) error {
+	ordered, err := orderComponents(components)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range ordered {
+		err := c.build(a)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range ordered {
+		if c.onAssembled != nil {
+			c.onAssembled(a)
+		}
+	}
+	return nil
+}
+
+// orderComponents returns the components sorted so each is after its dependencies,
+// keeping the given order between independent components.
+func orderComponents(components []component) ([]component, error) {
+	byName := make(map[componentName]component, len(components))
+	for _, c := range components {
+		byName[c.name] = c
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	marks := make(map[componentName]int, len(components))
+	ordered := make([]component, 0, len(components))
+
+	var visit func(c component) error
+	visit = func(c component) error {
+		switch marks[c.name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", errComponentCycle, c.name)
+		}
+
+		marks[c.name] = visiting
+		for _, name := range c.dependsOn {
+			dependency, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("%w: %s depends on %s", errUnknownComponent, c.name, name)
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		marks[c.name] = visited
+		ordered = append(ordered, c)
+		return nil
+	}
+
+	for _, c := range components {
+		if err := visit(c); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+func buildPprof(a *assembly) error {
+	if !a.config.Pprof.Enabled {
+		return nil
+	}
+
+	pprofService, err := createPprofService(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create pprof service: %w", err)
+	}
+	a.addService(pprofService)
+	return nil
+}
+
+// buildState creates and starts the state service. The state service is not registered
+// with the other services since it must be stopped last.
+func buildState(a *assembly) (err error) {
+	a.state, err = a.stateBuilder.createStateService(a.config)
+	if err != nil {
+		return fmt.Errorf("failed to create state service: %s", err)
+	}
+
+	gd, err := a.state.Base.LoadGenesisData()
+	if err != nil {
+		return fmt.Errorf("cannot load genesis data: %w", err)
+	}
+
+	a.telemetry, err = setupTelemetry(a.config, gd)
+	if err != nil {
+		return fmt.Errorf("cannot setup telemetry mailer: %w", err)
+	}
+
+	a.state.Telemetry = a.telemetry
+
+	err = startStateService(*a.config.State, a.state)
+	if err != nil {
+		return fmt.Errorf("cannot start state service: %w", err)
+	}
+
+	a.state.SetMemoryBudget(a.budget)
+	a.state.SetEventBus(a.events)
+	a.addService(a.budget)
+	return nil
+}
+
+// buildSnapshot creates the service snapshotting the database at regular intervals, if enabled.
+func buildSnapshot(a *assembly) error {
+	if a.config.State.SnapshotDir == "" {
+		return nil
+	}
+
+	a.addService(database.NewSnapshotter(a.state.DB(), database.SnapshotConfig{
+		Dir:      a.config.State.SnapshotDir,
+		Interval: a.config.State.SnapshotInterval,
+		Retained: a.config.State.SnapshotRetained,
+	}))
+	return nil
+}
+
+func buildSystem(a *assembly) (err error) {
+	systemInfo := &types.SystemInfo{
+		SystemName:    a.config.System.SystemName,
+		SystemVersion: a.config.System.SystemVersion,
+		Role:          a.config.Core.Role,
+	}
+
+	a.system, err = a.rpcBuilder.createSystemService(systemInfo, a.state)
+	if err != nil {
+		return fmt.Errorf("failed to create system service: %s", err)
+	}
+	a.addService(a.system)
+	return nil
+}
+
+func buildNetwork(a *assembly) (err error) {
+	if !networkServiceEnabled(a.config) {
+		// do not create or append network service if network service is not enabled
+		logger.Debugf("network service disabled, role is %d", a.config.Core.Role)
+		return nil
+	}
+
+	a.network, err = a.networkBuilder.createNetworkService(a.config, a.state, a.telemetry)
+	if err != nil {
+		return fmt.Errorf("failed to create network service: %s", err)
+	}
+	a.network.SetSupervisor(a.supervisor)
+	a.network.SetEventBus(a.events)
+	a.addService(a.network)
+
+	startupTime := fmt.Sprint(time.Now().UnixNano())
+	genesisHash := a.state.Block.GenesisHash()
+	netstate := a.network.NetworkState()
+
+	//sent NewSystemConnectedTM only if networkServiceEnabled
+	connectedMsg := telemetry.NewSystemConnected(
+		a.config.Core.GrandpaAuthority,
+		a.system.ChainName(),
+		&genesisHash,
+		a.system.SystemName(),
+		a.config.BaseConfig.Name,
+		netstate.PeerID,
+		startupTime,
+		a.system.SystemVersion())
+
+	a.telemetry.SendMessage(connectedMsg)
+	return nil
+}
+
+func buildRuntime(a *assembly) (err error) {
+	a.nodeStorage, err = a.stateBuilder.createRuntimeStorage(a.state)
+	if err != nil {
+		return err
+	}
+
+	return a.stateBuilder.loadRuntime(a.config, a.nodeStorage, a.state, a.ks, a.network)
+}
+
+func buildVerifier(a *assembly) error {
+	a.verifier = a.consensusBuilder.createBlockVerifier(a.state)
+	return nil
+}
+
+func buildDigest(a *assembly) error {
+	dh, err := a.consensusBuilder.createDigestHandler(a.state)
+	if err != nil {
+		return err
+	}
+	dh.SetEventBus(a.events)
+	a.addService(dh)
+	return nil
+}
+
+func buildCore(a *assembly) (err error) {
+	a.core, err = a.syncBuilder.createCoreService(a.config, a.ks, a.state, a.network)
+	if err != nil {
+		return fmt.Errorf("failed to create core service: %s", err)
+	}
+	a.core.SetEventBus(a.events)
+	a.addService(a.core)
+	return nil
+}
+
+func buildGrandpa(a *assembly) (err error) {
+	a.grandpa, err = a.consensusBuilder.createGRANDPAService(a.config, a.state, a.ks.Gran, a.network, a.telemetry)
+	if err != nil {
+		return err
+	}
+	a.grandpa.SetSupervisor(a.supervisor)
+	a.addService(a.grandpa)
+	return nil
+}
+
+func buildSync(a *assembly) (err error) {
+	a.sync, err = a.syncBuilder.newSyncService(a.config, a.state, a.grandpa, a.verifier, a.core, a.network, a.telemetry)
+	if err != nil {
+		return err
+	}
+	a.sync.SetSupervisor(a.supervisor)
+	a.sync.SetMemoryBudget(a.budget)
+	a.sync.SetEventBus(a.events)
+	a.addService(a.sync)
+	return nil
+}
+
+// wireNetworkHandlers sets the handlers of the messages received by the network service
+func wireNetworkHandlers(a *assembly) {
+	if a.network == nil {
+		return
+	}
+	a.network.SetSyncer(a.sync)
+	a.network.SetTransactionHandler(a.core)
+	a.network.SetReadProofProvider(a.core)
+}
+
+// buildLightGrandpa creates the GRANDPA service verifying the justifications of the synced headers.
+// It is not registered as a node service since light clients do not take part in finality voting.
+func buildLightGrandpa(a *assembly) (err error) {
+	a.grandpa, err = createLightGRANDPAService(a.config, a.state, a.network, a.telemetry)
+	return err
+}
+
+func buildHeaderSync(a *assembly) (err error) {
+	a.headerSync, err = createHeaderSync(a.config, a.state, a.grandpa, a.network)
+	if err != nil {
+		return err
+	}
+	a.addService(a.headerSync)
+	a.chainHead = light.NewRemoteStorage(a.state.Block, a.network)
+	return nil
+}
+
+// wireLightNetworkHandlers sets the header sync as the handler of the block announces
+// and transactions received by the network service of light clients.
+func wireLightNetworkHandlers(a *assembly) {
+	a.network.SetSyncer(a.headerSync)
+	a.network.SetTransactionHandler(a.headerSync)
+}
+
+func buildBabe(a *assembly) (err error) {
+	a.babe, err = a.consensusBuilder.createBABEService(a.config, a.state, a.ks.Babe, a.core, a.telemetry)
+	if err != nil {
+		return err
+	}
+	a.babe.SetSupervisor(a.supervisor)
+	a.babe.SetEventBus(a.events)
+	a.addService(a.babe)
+	return nil
+}
+
+func buildReloader(a *assembly) error {
+	gd, err := a.state.Base.LoadGenesisData()
+	if err != nil {
+		return fmt.Errorf("cannot load genesis data: %w", err)
+	}
+
+	a.reloader = newConfigReloader(a.config)
+	a.reloader.genesisTelemetry = gd.TelemetryEndpoints
+	a.reloader.state = a.state
+	if a.network != nil {
+		a.reloader.network = a.network
+	}
+	if mailer, ok := a.telemetry.(telemetryEndpointsSetter); ok {
+		a.reloader.telemetry = mailer
+	}
+	return nil
+}
+
+func buildRPC(a *assembly) error {
+	// check if rpc service is enabled
+	if !a.config.RPC.IsRPCEnabled() && !a.config.RPC.IsWSEnabled() {
+		logger.Debug("rpc service disabled by default")
+		return nil
+	}
+
+	var rpcSrvc *rpc.HTTPServer
+	cRPCParams := rpcServiceSettings{
+		config:        a.config,
+		nodeStorage:   a.nodeStorage,
+		state:         a.state,
+		core:          a.core,
+		network:       a.network,
+		blockProducer: a.babe,
+		system:        a.system,
+		blockFinality: a.grandpa,
+		syncer:        a.sync,
+		headerSync:    a.headerSync,
+		reloader:      a.reloader,
+		chainHead:     a.chainHead,
+		supervisor:    a.supervisor,
+	}
+	if a.config.Core.Dev && a.babe != nil {
+		cRPCParams.manualSeal = a.babe
+	}
+	rpcSrvc, err := a.rpcBuilder.createRPCService(cRPCParams)
+	if err != nil {
+		return fmt.Errorf("failed to create rpc service: %s", err)
+	}
+	a.addService(rpcSrvc)
+
+	if a.reloader != nil {
+		a.reloader.rpc = rpcSrvc
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_orderComponents(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		components []component
+		order      []componentName
+		errWrapped error
+		errMessage string
+	}{
+		"declared_order_kept": {
+			components: []component{
+				{name: "a"},
+				{name: "b", dependsOn: []componentName{"a"}},
+				{name: "c"},
+			},
+			order: []componentName{"a", "b", "c"},
+		},
+		"dependency_moved_first": {
+			components: []component{
+				{name: "a", dependsOn: []componentName{"c"}},
+				{name: "b"},
+				{name: "c"},
+			},
+			order: []componentName{"c", "a", "b"},
+		},
+		"unknown_dependency": {
+			components: []component{
+				{name: "a", dependsOn: []componentName{"b"}},
+			},
+			errWrapped: errUnknownComponent,
+			errMessage: "unknown component: a depends on b",
+		},
+		"cycle": {
+			components: []component{
+				{name: "a", dependsOn: []componentName{"b"}},
+				{name: "b", dependsOn: []componentName{"a"}},
+			},
+			errWrapped: errComponentCycle,
+			errMessage: "component dependency cycle: a",
+		},
+		"full_node": {
+			components: fullNodeComponents,
+			order: []componentName{pprofComponent, stateComponent, snapshotComponent, systemComponent, networkComponent,
+				runtimeComponent, verifierComponent, digestComponent, coreComponent, grandpaComponent,
+				syncComponent, babeComponent, reloadComponent, rpcComponent},
+		},
+		"light_node": {
+			components: lightNodeComponents,
+			order: []componentName{pprofComponent, stateComponent, snapshotComponent, systemComponent, networkComponent,
+				digestComponent, grandpaComponent, syncComponent, reloadComponent, rpcComponent},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ordered, err := orderComponents(testCase.components)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+
+			names := make([]componentName, len(ordered))
+			for i, c := range ordered {
+				names[i] = c.name
+			}
+			assert.Equal(t, testCase.order, names)
+		})
+	}
+}
+
+func Test_assembly_build(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	errTest := errors.New("test error")
+	components := []component{
+		{
+			name:        "a",
+			build:       func(*assembly) error { calls = append(calls, "build a"); return nil },
+			onAssembled: func(*assembly) { calls = append(calls, "assembled a") },
+		},
+		{
+			name:      "b",
+			dependsOn: []componentName{"a"},
+			build:     func(*assembly) error { calls = append(calls, "build b"); return nil },
+		},
+	}
+
+	err := (&assembly{}).build(components)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build a", "build b", "assembled a"}, calls)
+
+	calls = nil
+	components[1].build = func(*assembly) error { return errTest }
+	err = (&assembly{}).build(components)
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []string{"build a"}, calls)
+}
@@ -5,6 +5,7 @@
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,58 +16,220 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
 	"github.com/ChainSafe/gossamer/lib/utils"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
 
+// defaultBuildSpecChainType is the chain type of chain specs built from a runtime
+const defaultBuildSpecChainType = "Local"
+
+// ErrInvalidGenesisPatch is returned when the genesis patch given to the genesis builder is not valid JSON
+var ErrInvalidGenesisPatch = errors.New("invalid genesis patch")
+
 // BuildSpec object for working with building genesis JSON files
 type BuildSpec struct {
 	genesis *genesis.Genesis
 }
 
-// ToJSON outputs genesis JSON in human-readable form
+// ToJSON outputs genesis JSON in human-readable form.
+// Specs without a human-readable genesis are output with their raw genesis.
 func (b *BuildSpec) ToJSON() ([]byte, error) {
-	tmpGen := &genesis.Genesis{
-		Name:       b.genesis.Name,
-		ID:         b.genesis.ID,
-		ChainType:  b.genesis.ChainType,
-		Bootnodes:  b.genesis.Bootnodes,
-		ProtocolID: b.genesis.ProtocolID,
-		Properties: b.genesis.Properties,
-		Genesis: genesis.Fields{
-			Runtime: b.genesis.GenesisFields().Runtime,
-		},
+	fields := b.genesis.GenesisFields()
+	tmpGen := b.specWithoutGenesis()
+	if fields.Runtime != nil || fields.RuntimeGenesis != nil {
+		tmpGen.Genesis = genesis.Fields{
+			Runtime:        fields.Runtime,
+			RuntimeGenesis: fields.RuntimeGenesis,
+		}
+	} else {
+		tmpGen.Genesis = genesis.Fields{
+			Raw: fields.Raw,
+		}
 	}
 	return json.MarshalIndent(tmpGen, "", "    ")
 }
 
 // ToJSONRaw outputs genesis JSON in raw form
 func (b *BuildSpec) ToJSONRaw() ([]byte, error) {
-	tmpGen := &genesis.Genesis{
-		Name:       b.genesis.Name,
-		ID:         b.genesis.ID,
-		ChainType:  b.genesis.ChainType,
-		Bootnodes:  b.genesis.Bootnodes,
-		ProtocolID: b.genesis.ProtocolID,
-		Properties: b.genesis.Properties,
-		Genesis: genesis.Fields{
-			Raw: b.genesis.GenesisFields().Raw,
-		},
+	tmpGen := b.specWithoutGenesis()
+	tmpGen.Genesis = genesis.Fields{
+		Raw: b.genesis.GenesisFields().Raw,
 	}
 	return json.MarshalIndent(tmpGen, "", "    ")
 }
 
+func (b *BuildSpec) specWithoutGenesis() *genesis.Genesis {
+	return &genesis.Genesis{
+		Name:               b.genesis.Name,
+		ID:                 b.genesis.ID,
+		ChainType:          b.genesis.ChainType,
+		Bootnodes:          b.genesis.Bootnodes,
+		TelemetryEndpoints: b.genesis.TelemetryEndpoints,
+		ProtocolID:         b.genesis.ProtocolID,
+		Properties:         b.genesis.Properties,
+		ForkBlocks:         b.genesis.ForkBlocks,
+		BadBlocks:          b.genesis.BadBlocks,
+		ConsensusEngine:    b.genesis.ConsensusEngine,
+		CodeSubstitutes:    b.genesis.CodeSubstitutes,
+		Checkpoint:         b.genesis.Checkpoint,
+	}
+}
+
 // BuildFromGenesis builds a BuildSpec based on the human-readable genesis file at path
 func BuildFromGenesis(path string, authCount int) (*BuildSpec, error) {
 	gen, err := genesis.NewGenesisFromJSON(path, authCount)
 	if err != nil {
 		return nil, err
 	}
+
+	if gen.Genesis.Raw == nil && gen.Genesis.RuntimeGenesis != nil {
+		err = buildRuntimeGenesis(gen)
+		if err != nil {
+			return nil, fmt.Errorf("building runtime genesis: %w", err)
+		}
+	}
+
 	bs := &BuildSpec{
 		genesis: gen,
 	}
 	return bs, nil
 }
 
+// BuildFromRuntime builds a BuildSpec named name with identifier id, whose genesis is built
+// by the runtime genesis builder from the runtime default genesis config with the JSON patch applied.
+func BuildFromRuntime(name, id string, code, patch []byte) (*BuildSpec, error) {
+	if len(patch) == 0 {
+		patch = []byte("{}")
+	}
+	if !json.Valid(patch) {
+		return nil, fmt.Errorf("%w: genesis patch is not valid JSON", ErrInvalidGenesisPatch)
+	}
+
+	gen := &genesis.Genesis{
+		Name:      name,
+		ID:        id,
+		ChainType: defaultBuildSpecChainType,
+		Genesis: genesis.Fields{
+			RuntimeGenesis: &genesis.RuntimeGenesis{
+				Code:  common.BytesToHex(code),
+				Patch: patch,
+			},
+		},
+	}
+
+	err := buildRuntimeGenesis(gen)
+	if err != nil {
+		return nil, fmt.Errorf("building runtime genesis: %w", err)
+	}
+
+	return &BuildSpec{genesis: gen}, nil
+}
+
+// genesisBuilder is the runtime API building the genesis storage of a chain.
+type genesisBuilder interface {
+	GenesisBuilderCreateDefaultConfig() ([]byte, error)
+	GenesisBuilderBuildConfig(config []byte) error
+}
+
+// buildRuntimeGenesis executes the genesis builder of the runtime genesis code
+// and sets the resulting storage as the raw genesis.
+func buildRuntimeGenesis(gen *genesis.Genesis) error {
+	runtimeGenesis := gen.Genesis.RuntimeGenesis
+	code, err := common.HexToBytes(runtimeGenesis.Code)
+	if err != nil {
+		return fmt.Errorf("decoding runtime code: %w", err)
+	}
+
+	tr := inmemory_trie.NewEmptyTrie()
+	err = tr.Put(common.CodeKey, code)
+	if err != nil {
+		return fmt.Errorf("setting runtime code: %w", err)
+	}
+	trieState := rtstorage.NewTrieState(tr)
+
+	instance, err := wazero_runtime.NewInstance(code, wazero_runtime.Config{
+		Storage:  trieState,
+		Keystore: keystore.NewGlobalKeystore(),
+		LogLvl:   log.Error,
+	})
+	if err != nil {
+		return fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	return buildGenesisStorage(gen, instance, trieState)
+}
+
+// buildGenesisStorage builds the genesis storage with the genesis builder given, from its
+// default genesis config with the runtime genesis patch applied, into the trie state given
+// and sets the resulting storage as the raw genesis.
+func buildGenesisStorage(gen *genesis.Genesis, builder genesisBuilder, trieState *rtstorage.TrieState) error {
+	defaultConfig, err := builder.GenesisBuilderCreateDefaultConfig()
+	if err != nil {
+		return fmt.Errorf("getting default genesis config: %w", err)
+	}
+
+	config, err := mergeJSONPatch(defaultConfig, gen.Genesis.RuntimeGenesis.Patch)
+	if err != nil {
+		return err
+	}
+
+	err = builder.GenesisBuilderBuildConfig(config)
+	if err != nil {
+		return err
+	}
+
+	top := make(map[string]string)
+	for key, value := range trieState.TrieEntries() {
+		top[common.BytesToHex([]byte(key))] = common.BytesToHex(value)
+	}
+	gen.Genesis.Raw = map[string]map[string]string{"top": top}
+	return nil
+}
+
+// mergeJSONPatch applies the JSON merge patch (RFC 7396) to target
+func mergeJSONPatch(target, patch []byte) ([]byte, error) {
+	if len(patch) == 0 {
+		return target, nil
+	}
+
+	var targetValue, patchValue interface{}
+	err := json.Unmarshal(target, &targetValue)
+	if err != nil {
+		return nil, fmt.Errorf("decoding genesis config: %w", err)
+	}
+	err = json.Unmarshal(patch, &patchValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidGenesisPatch, err)
+	}
+
+	return json.Marshal(mergePatchValue(targetValue, patchValue))
+}
+
+func mergePatchValue(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatchValue(targetObject[key], value)
+	}
+	return targetObject
+}
+
 // WriteGenesisSpecFile writes the build-spec in the output filepath
 func WriteGenesisSpecFile(data []byte, fp string) error {
 	// if file already exists then dont apply any written on it
@@ -4,16 +4,22 @@
 package dot
 
 import (
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
 	"github.com/ChainSafe/gossamer/lib/utils"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestBuildSpec_ToJSON(t *testing.T) {
@@ -67,7 +73,7 @@ func TestBuildSpec_ToJSON(t *testing.T) {
     "properties": null,
     "forkBlocks": null,
     "badBlocks": null,
-    "consensusEngine": "",
+    "consensusEngine": "babe",
     "codeSubstitutes": null
 }`,
 		},
@@ -98,16 +104,26 @@ func TestBuildSpec_ToJSON(t *testing.T) {
         "node1",
         "node2"
     ],
-    "telemetryEndpoints": null,
+    "telemetryEndpoints": [
+        "endpoint"
+    ],
     "protocolId": "protocol",
     "genesis": {},
     "properties": {
         "key": "value"
     },
-    "forkBlocks": null,
-    "badBlocks": null,
-    "consensusEngine": "",
-    "codeSubstitutes": null
+    "forkBlocks": [
+        "1",
+        "2"
+    ],
+    "badBlocks": [
+        "3",
+        "4"
+    ],
+    "consensusEngine": "babe",
+    "codeSubstitutes": {
+        "key": "value"
+    }
 }`,
 		},
 	}
@@ -313,3 +329,201 @@ type args struct {
 		})
 	}
 }
+
+func TestBuildFromGenesis_rawRoundTrip(t *testing.T) {
+	rawGenesis := genesis.Genesis{
+		Name:            "test",
+		ConsensusEngine: "babe",
+		Genesis: genesis.Fields{
+			Raw: map[string]map[string]string{"top": {"0x3a636f6465": "0x01"}},
+		},
+	}
+	testGenesisPath := writeGenesisToTestJSON(t, rawGenesis)
+
+	bs, err := BuildFromGenesis(testGenesisPath, 0)
+	require.NoError(t, err)
+
+	for _, toJSON := range []func() ([]byte, error){bs.ToJSON, bs.ToJSONRaw} {
+		data, err := toJSON()
+		require.NoError(t, err)
+
+		got := genesis.Genesis{}
+		err = json.Unmarshal(data, &got)
+		require.NoError(t, err)
+		assert.Equal(t, rawGenesis.Name, got.Name)
+		assert.Equal(t, rawGenesis.ConsensusEngine, got.ConsensusEngine)
+		assert.Equal(t, rawGenesis.Genesis, got.Genesis)
+	}
+}
+
+func TestBuildFromRuntime(t *testing.T) {
+	westendDevGenesis, err := genesis.NewGenesisFromJSONRaw(utils.GetWestendDevRawGenesisPath(t))
+	require.NoError(t, err)
+	code := common.MustHexToBytes(westendDevGenesis.Genesis.Raw["top"]["0x3a636f6465"])
+
+	_, err = BuildFromRuntime("test", "test", code, []byte("{"))
+	assert.ErrorIs(t, err, ErrInvalidGenesisPatch)
+
+	// the westend v0.9.29 runtime predates the genesis builder API
+	_, err = BuildFromRuntime("test", "test", code, nil)
+	assert.ErrorIs(t, err, wazero_runtime.ErrExportFunctionNotFound)
+}
+
+func Test_buildGenesisStorage(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	const (
+		defaultConfig = `{"balances":{"balances":[]},"sudo":{"key":"alice"}}`
+		patch         = `{"sudo":{"key":"bob"}}`
+		mergedConfig  = `{"balances":{"balances":[]},"sudo":{"key":"bob"}}`
+	)
+	code := []byte{1, 2, 3}
+	sudoKey := []byte("sudo_key")
+
+	testCases := map[string]struct {
+		setupBuilder func(ctrl *gomock.Controller, trieState *rtstorage.TrieState) genesisBuilder
+		expectedRaw  map[string]map[string]string
+		errWrapped   error
+		errMessage   string
+	}{
+		"create_default_config_error": {
+			setupBuilder: func(ctrl *gomock.Controller, _ *rtstorage.TrieState) genesisBuilder {
+				builder := NewMockgenesisBuilder(ctrl)
+				builder.EXPECT().GenesisBuilderCreateDefaultConfig().Return(nil, errTest)
+				return builder
+			},
+			errWrapped: errTest,
+			errMessage: "getting default genesis config: test error",
+		},
+		"build_config_error": {
+			setupBuilder: func(ctrl *gomock.Controller, _ *rtstorage.TrieState) genesisBuilder {
+				builder := NewMockgenesisBuilder(ctrl)
+				builder.EXPECT().GenesisBuilderCreateDefaultConfig().Return([]byte(defaultConfig), nil)
+				builder.EXPECT().GenesisBuilderBuildConfig([]byte(mergedConfig)).Return(errTest)
+				return builder
+			},
+			errWrapped: errTest,
+			errMessage: "test error",
+		},
+		"patched_config_built": {
+			setupBuilder: func(ctrl *gomock.Controller, trieState *rtstorage.TrieState) genesisBuilder {
+				builder := NewMockgenesisBuilder(ctrl)
+				builder.EXPECT().GenesisBuilderCreateDefaultConfig().Return([]byte(defaultConfig), nil)
+				builder.EXPECT().GenesisBuilderBuildConfig([]byte(mergedConfig)).
+					DoAndReturn(func(_ []byte) error {
+						return trieState.Put(sudoKey, []byte("bob"))
+					})
+				return builder
+			},
+			expectedRaw: map[string]map[string]string{"top": {
+				common.BytesToHex(common.CodeKey): common.BytesToHex(code),
+				common.BytesToHex(sudoKey):        common.BytesToHex([]byte("bob")),
+			}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			tr := inmemory_trie.NewEmptyTrie()
+			err := tr.Put(common.CodeKey, code)
+			require.NoError(t, err)
+			trieState := rtstorage.NewTrieState(tr)
+
+			gen := &genesis.Genesis{
+				Name: "test",
+				ID:   "test",
+				Genesis: genesis.Fields{
+					RuntimeGenesis: &genesis.RuntimeGenesis{
+						Code:  common.BytesToHex(code),
+						Patch: json.RawMessage(patch),
+					},
+				},
+			}
+			builder := testCase.setupBuilder(ctrl, trieState)
+
+			err = buildGenesisStorage(gen, builder, trieState)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			assert.Equal(t, testCase.expectedRaw, gen.Genesis.Raw)
+
+			// the spec keeps the runtime genesis, and its raw form the storage built
+			bs := &BuildSpec{genesis: gen}
+			data, err := bs.ToJSON()
+			require.NoError(t, err)
+			spec := genesis.Genesis{}
+			err = json.Unmarshal(data, &spec)
+			require.NoError(t, err)
+			require.NotNil(t, spec.Genesis.RuntimeGenesis)
+			assert.Equal(t, common.BytesToHex(code), spec.Genesis.RuntimeGenesis.Code)
+			assert.JSONEq(t, patch, string(spec.Genesis.RuntimeGenesis.Patch))
+			assert.Nil(t, spec.Genesis.Raw)
+
+			data, err = bs.ToJSONRaw()
+			require.NoError(t, err)
+			spec = genesis.Genesis{}
+			err = json.Unmarshal(data, &spec)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedRaw, spec.Genesis.Raw)
+			assert.Nil(t, spec.Genesis.RuntimeGenesis)
+		})
+	}
+}
+
+func TestMergeJSONPatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		target   string
+		patch    string
+		expected string
+		errMsg   string
+	}{
+		"empty_patch": {
+			target:   `{"a":1}`,
+			expected: `{"a":1}`,
+		},
+		"nested_merge": {
+			target:   `{"balances":{"balances":[]},"sudo":{"key":"alice"}}`,
+			patch:    `{"sudo":{"key":"bob"}}`,
+			expected: `{"balances":{"balances":[]},"sudo":{"key":"bob"}}`,
+		},
+		"remove_field": {
+			target:   `{"a":1,"b":2}`,
+			patch:    `{"b":null}`,
+			expected: `{"a":1}`,
+		},
+		"replace_array": {
+			target:   `{"a":[1,2]}`,
+			patch:    `{"a":[3]}`,
+			expected: `{"a":[3]}`,
+		},
+		"invalid_patch": {
+			target: `{}`,
+			patch:  `{`,
+			errMsg: "invalid genesis patch: unexpected end of JSON input",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, err := mergeJSONPatch([]byte(testCase.target), []byte(testCase.patch))
+			if testCase.errMsg != "" {
+				assert.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.expected, string(merged))
+		})
+	}
+}
@@ -126,6 +126,7 @@ func Test_networkServiceEnabled(t *testing.T) {
 				Network:    &cfg.NetworkConfig{},
 				State:      &cfg.StateConfig{},
 				RPC:        &cfg.RPCConfig{},
+				Sync:       &cfg.SyncConfig{},
 				Pprof:      &cfg.PprofConfig{},
 				System:     &cfg.SystemConfig{},
 			},
@@ -8,6 +8,7 @@
 	"path/filepath"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -20,11 +21,13 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -148,6 +151,10 @@ func createTestService(t *testing.T, genesisFilePath string,
 	service, err = NewService(cfg)
 	require.NoError(t, err)
 
+	bus := events.NewBus()
+	stateSrvc.SetEventBus(bus)
+	service.SetEventBus(bus)
+
 	return service, encodedExtrinsic
 }
 
@@ -258,6 +265,12 @@ func NewTestService(t *testing.T, cfg *Config) *Service {
 	s, err := NewService(cfg)
 	require.NoError(t, err)
 
+	if stateSrvc != nil {
+		bus := events.NewBus()
+		stateSrvc.SetEventBus(bus)
+		s.SetEventBus(bus)
+	}
+
 	return s
 }
 
@@ -298,3 +311,13 @@ func getWestendDevRuntimeCode(t *testing.T) (code []byte) {
 
 	return trieState.LoadCode()
 }
+
+// assertValidTransaction asserts the valid transaction has the extrinsic, validity
+// and dispatch class of the expected transaction. Its dispatch class is queried,
+// since the query is deferred until the class is needed by the pool.
+func assertValidTransaction(t *testing.T, expected, actual *transaction.ValidTransaction) {
+	t.Helper()
+	assert.Equal(t, expected.Extrinsic, actual.Extrinsic)
+	assert.Equal(t, expected.Validity, actual.Validity)
+	assert.Equal(t, expected.Class, actual.DispatchClass())
+}
@@ -28,10 +28,12 @@ type BlockState interface {
 	BestBlockHash() common.Hash
 	BestBlockHeader() (*types.Header, error)
 	AddBlock(*types.Block) error
+	GetHeader(hash common.Hash) (*types.Header, error)
 	GetBlockStateRoot(bhash common.Hash) (common.Hash, error)
 	RangeInMemory(start, end common.Hash) ([]common.Hash, error)
 	GetBlockBody(hash common.Hash) (*types.Body, error)
 	HandleRuntimeChanges(newState *rtstorage.TrieState, in runtime.Instance, bHash common.Hash) error
+	AcquireRuntime(blockHash common.Hash) (instance runtime.Instance, release func(), err error)
 	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
 	StoreRuntime(blockHash common.Hash, runtime runtime.Instance)
 	LowestCommonAncestor(a, b common.Hash) (common.Hash, error)
@@ -56,9 +58,12 @@ type TransactionState interface {
 	Push(vt *transaction.ValidTransaction) (common.Hash, error)
 	AddToPool(vt *transaction.ValidTransaction) common.Hash
 	RemoveExtrinsic(ext types.Extrinsic)
-	RemoveExtrinsicFromPool(ext types.Extrinsic)
-	PendingInPool() []*transaction.ValidTransaction
+	Pending() []*transaction.ValidTransaction
 	Exists(ext types.Extrinsic) bool
+	Revalidate(validate func(ext types.Extrinsic) (*transaction.ValidTransaction, error))
+	PruneExpired(bestNumber uint)
+	Ban(ext types.Extrinsic)
+	IsBanned(ext types.Extrinsic) bool
 }
 
 // Network is the interface for the network service
@@ -40,6 +40,7 @@ func (s *Service) validateTransaction(head *types.Header, rt runtime.Instance,
 	}
 
 	vtx := transaction.NewValidTransaction(tx, validity)
+	vtx.SetClassQuery(dispatchClassQuery(rt, ts, tx))
 
 	// push to the transaction queue of BABE session
 	hash := s.transactionState.AddToPool(vtx)
@@ -76,11 +77,17 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 
 	allTxnsAreValid := true
 	for _, tx := range txs {
+		if s.transactionState.IsBanned(tx) {
+			logger.Debugf("ignoring banned transaction %s", tx)
+			continue
+		}
+
 		validity, err := s.validateTransaction(head, rt, tx)
 		if err != nil {
 			allTxnsAreValid = false
 			switch err.(type) {
 			case runtime.InvalidTransaction:
+				s.transactionState.Ban(tx)
 				s.net.ReportPeer(peerset.ReputationChange{
 					Value:  peerset.BadTransactionValue,
 					Reason: peerset.BadTransactionReason,
@@ -111,5 +118,5 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 
 // TransactionsCount returns number for pending transactions in pool
 func (s *Service) TransactionsCount() int {
-	return len(s.transactionState.PendingInPool())
+	return len(s.transactionState.Pending())
 }
@@ -21,6 +21,7 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -157,7 +158,7 @@ func TestService_HandleTransactionMessage(t *testing.T) {
 
 	cfg := &Config{
 		Keystore:         ks,
-		TransactionState: state.NewTransactionState(telemetryMock),
+		TransactionState: state.NewTransactionState(telemetryMock, transaction.PoolLimits{}),
 		Network:          net,
 	}
 
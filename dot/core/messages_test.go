@@ -57,8 +57,11 @@ type mockStorageState struct {
 }
 
 type mockTxnState struct {
-	input *transaction.ValidTransaction
-	hash  common.Hash
+	ext    types.Extrinsic
+	banned bool
+	ban    bool
+	input  *transaction.ValidTransaction
+	hash   common.Hash
 }
 
 type mockSetContextStorage struct {
@@ -75,6 +78,7 @@ type mockRuntime struct {
 	runtime           *MockInstance
 	setContextStorage *mockSetContextStorage
 	validateTxn       *mockValidateTxn
+	dispatchInfo      *types.RuntimeDispatchInfo
 }
 
 func TestService_TransactionsCount(t *testing.T) {
@@ -84,8 +88,8 @@ func TestService_TransactionsCount(t *testing.T) {
 
 	txs := []*transaction.ValidTransaction{nil, nil}
 
-	mockTxnStateEmpty.EXPECT().PendingInPool().Return([]*transaction.ValidTransaction{})
-	mockTxnState.EXPECT().PendingInPool().Return(txs)
+	mockTxnStateEmpty.EXPECT().Pending().Return([]*transaction.ValidTransaction{})
+	mockTxnState.EXPECT().Pending().Return(txs)
 
 	tests := []struct {
 		name    string
@@ -225,6 +229,9 @@ type args struct {
 				input: &common.Hash{},
 				err:   errDummyErr,
 			},
+			mockTxnState: &mockTxnState{
+				ext: types.Extrinsic{1, 2, 3},
+			},
 			args: args{
 				peerID: peer.ID("jimbo"),
 				msg: &network.TransactionMessage{
@@ -260,6 +267,10 @@ type args struct {
 				input:     &common.Hash{},
 				trieState: &storage.TrieState{},
 			},
+			mockTxnState: &mockTxnState{
+				ext: types.Extrinsic{1, 2, 3},
+				ban: true,
+			},
 			mockRuntime: &mockRuntime{
 				runtime:           runtimeMock2,
 				setContextStorage: &mockSetContextStorage{trieState: &storage.TrieState{}},
@@ -305,11 +316,12 @@ type args struct {
 				trieState: &storage.TrieState{},
 			},
 			mockTxnState: &mockTxnState{
-				input: transaction.NewValidTransaction(
-					types.Extrinsic{1, 2, 3},
-					&transaction.Validity{
-						Propagate: true,
-					}),
+				ext: types.Extrinsic{1, 2, 3},
+				input: &transaction.ValidTransaction{
+					Extrinsic: types.Extrinsic{1, 2, 3},
+					Validity:  &transaction.Validity{Propagate: true},
+					Class:     transaction.Operational,
+				},
 				hash: common.Hash{},
 			},
 			mockRuntime: &mockRuntime{
@@ -323,6 +335,7 @@ type args struct {
 					}, nil)),
 					validity: &transaction.Validity{Propagate: true},
 				},
+				dispatchInfo: &types.RuntimeDispatchInfo{Class: int(transaction.Operational)},
 			},
 			args: args{
 				peerID: peer.ID("jimbo"),
@@ -332,6 +345,37 @@ type args struct {
 			},
 			exp: true,
 		},
+		{
+			name: "banned_transaction",
+			mockNetwork: &mockNetwork{
+				IsSynced: true,
+				ReportPeer: &mockReportPeer{
+					change: peerset.ReputationChange{
+						Value:  peerset.GoodTransactionValue,
+						Reason: peerset.GoodTransactionReason,
+					},
+					id: peer.ID("jimbo"),
+				},
+			},
+			mockBlockState: &mockBlockState{
+				bestHeader: &mockBestHeader{
+					header: testEmptyHeader,
+				},
+				getRuntime: &mockGetRuntime{
+					runtime: runtimeMock,
+				},
+			},
+			mockTxnState: &mockTxnState{
+				ext:    types.Extrinsic{1, 2, 3},
+				banned: true,
+			},
+			args: args{
+				peerID: peer.ID("jimbo"),
+				msg: &network.TransactionMessage{
+					Extrinsics: []types.Extrinsic{{1, 2, 3}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -372,7 +416,17 @@ type args struct {
 			}
 			if tt.mockTxnState != nil {
 				txnState := NewMockTransactionState(ctrl)
-				txnState.EXPECT().AddToPool(tt.mockTxnState.input).Return(tt.mockTxnState.hash)
+				txnState.EXPECT().IsBanned(tt.mockTxnState.ext).Return(tt.mockTxnState.banned)
+				if tt.mockTxnState.ban {
+					txnState.EXPECT().Ban(tt.mockTxnState.ext)
+				}
+				if tt.mockTxnState.input != nil {
+					txnState.EXPECT().AddToPool(gomock.Any()).DoAndReturn(
+						func(vt *transaction.ValidTransaction) common.Hash {
+							assertValidTransaction(t, tt.mockTxnState.input, vt)
+							return tt.mockTxnState.hash
+						})
+				}
 				s.transactionState = txnState
 			}
 			if tt.mockRuntime != nil {
@@ -380,6 +434,11 @@ type args struct {
 				rt.EXPECT().SetContextStorage(tt.mockRuntime.setContextStorage.trieState)
 				rt.EXPECT().ValidateTransaction(tt.mockRuntime.validateTxn.input).
 					Return(tt.mockRuntime.validateTxn.validity, tt.mockRuntime.validateTxn.err)
+				if tt.mockRuntime.dispatchInfo != nil {
+					// the dispatch class is queried when the transaction is added to the pool
+					rt.EXPECT().SetContextStorage(tt.mockRuntime.setContextStorage.trieState)
+					rt.EXPECT().PaymentQueryInfo(gomock.Any()).Return(tt.mockRuntime.dispatchInfo, nil)
+				}
 				rt.EXPECT().Version().Return(runtime.Version{
 					SpecName:         []byte("polkadot"),
 					ImplName:         []byte("parity-polkadot"),
@@ -10,7 +10,7 @@
 package core
 
 import (
-	json "encoding/json"
+	"encoding/json"
 	reflect "reflect"
 
 	network "github.com/ChainSafe/gossamer/dot/network"
@@ -47,6 +47,22 @@ func (m *MockBlockState) EXPECT() *MockBlockStateMockRecorder {
 	return m.recorder
 }
 
+// AcquireRuntime mocks base method.
+func (m *MockBlockState) AcquireRuntime(arg0 common.Hash) (runtime.Instance, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireRuntime", arg0)
+	ret0, _ := ret[0].(runtime.Instance)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AcquireRuntime indicates an expected call of AcquireRuntime.
+func (mr *MockBlockStateMockRecorder) AcquireRuntime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireRuntime", reflect.TypeOf((*MockBlockState)(nil).AcquireRuntime), arg0)
+}
+
 // AddBlock mocks base method.
 func (m *MockBlockState) AddBlock(arg0 *types.Block) error {
 	m.ctrl.T.Helper()
@@ -120,6 +136,21 @@ func (mr *MockBlockStateMockRecorder) GetBlockStateRoot(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockStateRoot", reflect.TypeOf((*MockBlockState)(nil).GetBlockStateRoot), arg0)
 }
 
+// GetHeader mocks base method.
+func (m *MockBlockState) GetHeader(arg0 common.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", arg0)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockBlockStateMockRecorder) GetHeader(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockBlockState)(nil).GetHeader), arg0)
+}
+
 // GetRuntime mocks base method.
 func (m *MockBlockState) GetRuntime(arg0 common.Hash) (runtime.Instance, error) {
 	m.ctrl.T.Helper()
@@ -334,6 +365,18 @@ func (mr *MockTransactionStateMockRecorder) AddToPool(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToPool", reflect.TypeOf((*MockTransactionState)(nil).AddToPool), arg0)
 }
 
+// Ban mocks base method.
+func (m *MockTransactionState) Ban(arg0 types.Extrinsic) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Ban", arg0)
+}
+
+// Ban indicates an expected call of Ban.
+func (mr *MockTransactionStateMockRecorder) Ban(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ban", reflect.TypeOf((*MockTransactionState)(nil).Ban), arg0)
+}
+
 // Exists mocks base method.
 func (m *MockTransactionState) Exists(arg0 types.Extrinsic) bool {
 	m.ctrl.T.Helper()
@@ -348,18 +391,44 @@ func (mr *MockTransactionStateMockRecorder) Exists(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockTransactionState)(nil).Exists), arg0)
 }
 
-// PendingInPool mocks base method.
-func (m *MockTransactionState) PendingInPool() []*transaction.ValidTransaction {
+// IsBanned mocks base method.
+func (m *MockTransactionState) IsBanned(arg0 types.Extrinsic) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockTransactionStateMockRecorder) IsBanned(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockTransactionState)(nil).IsBanned), arg0)
+}
+
+// Pending mocks base method.
+func (m *MockTransactionState) Pending() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PendingInPool")
+	ret := m.ctrl.Call(m, "Pending")
 	ret0, _ := ret[0].([]*transaction.ValidTransaction)
 	return ret0
 }
 
-// PendingInPool indicates an expected call of PendingInPool.
-func (mr *MockTransactionStateMockRecorder) PendingInPool() *gomock.Call {
+// Pending indicates an expected call of Pending.
+func (mr *MockTransactionStateMockRecorder) Pending() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionState)(nil).Pending))
+}
+
+// PruneExpired mocks base method.
+func (m *MockTransactionState) PruneExpired(arg0 uint) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PruneExpired", arg0)
+}
+
+// PruneExpired indicates an expected call of PruneExpired.
+func (mr *MockTransactionStateMockRecorder) PruneExpired(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingInPool", reflect.TypeOf((*MockTransactionState)(nil).PendingInPool))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneExpired", reflect.TypeOf((*MockTransactionState)(nil).PruneExpired), arg0)
 }
 
 // Push mocks base method.
@@ -389,16 +458,16 @@ func (mr *MockTransactionStateMockRecorder) RemoveExtrinsic(arg0 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsic", reflect.TypeOf((*MockTransactionState)(nil).RemoveExtrinsic), arg0)
 }
 
-// RemoveExtrinsicFromPool mocks base method.
-func (m *MockTransactionState) RemoveExtrinsicFromPool(arg0 types.Extrinsic) {
+// Revalidate mocks base method.
+func (m *MockTransactionState) Revalidate(arg0 func(types.Extrinsic) (*transaction.ValidTransaction, error)) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RemoveExtrinsicFromPool", arg0)
+	m.ctrl.Call(m, "Revalidate", arg0)
 }
 
-// RemoveExtrinsicFromPool indicates an expected call of RemoveExtrinsicFromPool.
-func (mr *MockTransactionStateMockRecorder) RemoveExtrinsicFromPool(arg0 any) *gomock.Call {
+// Revalidate indicates an expected call of Revalidate.
+func (mr *MockTransactionStateMockRecorder) Revalidate(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsicFromPool", reflect.TypeOf((*MockTransactionState)(nil).RemoveExtrinsicFromPool), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revalidate", reflect.TypeOf((*MockTransactionState)(nil).Revalidate), arg0)
 }
 
 // MockNetwork is a mock of Network interface.
@@ -8,8 +8,10 @@
 	"context"
 	"errors"
 	"fmt"
-	"sync"
+	"slices"
+	"sort"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -30,6 +32,10 @@
 	logger = log.NewFromGlobal(log.AddContext("pkg", "core"))
 )
 
+// poolBlocksDepth is the number of blocks below the last block imported
+// the blocks are kept for the maintenance of the transaction pool.
+const poolBlocksDepth = 256
+
 // QueryKeyValueChanges represents the key-value data inside a block storage
 type QueryKeyValueChanges map[string]string
 
@@ -37,10 +43,8 @@
 // BABE session, and network service. It deals with the validation of transactions
 // and blocks by calling their respective validation functions in the runtime.
 type Service struct {
-	ctx        context.Context
-	cancel     context.CancelFunc
-	blockAddCh chan *types.Block // for asynchronous block handling
-	lock       sync.Mutex        // lock for channel
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	// Service interfaces
 	blockState       BlockState
@@ -56,6 +60,22 @@ type Service struct {
 	// Keystore
 	keys          *keystore.GlobalKeystore
 	onBlockImport BlockImportDigestHandler
+
+	// poolBestBlockHash is the best block hash the transaction pool was last
+	// maintained for, only accessed by the handleBlocksAsync goroutine.
+	poolBestBlockHash common.Hash
+	// poolBlocks are the unfinalised blocks imported, kept to resubmit the extrinsics
+	// of the blocks retracted by the finalisation of another fork, which prunes them
+	// from the block state. Only accessed by the handleBlocksAsync goroutine.
+	poolBlocks map[common.Hash]*types.Block
+
+	eventBus *events.Bus
+	// imported notifies the blocks imported, handled asynchronously.
+	// It is blocking since a missed block is not tracked for the transaction pool.
+	imported *events.Subscription[events.BlockImported]
+	// finalised notifies the blocks finalised, which can change the best block.
+	// It is blocking since a missed finalisation leaves retracted blocks tracked.
+	finalised *events.Subscription[events.BlockFinalised]
 }
 
 // Config holds the configuration for the core Service.
@@ -80,8 +100,6 @@ type Config struct {
 func NewService(cfg *Config) (*Service, error) {
 	logger.Patch(log.SetLevel(cfg.LogLvl))
 
-	blockAddCh := make(chan *types.Block, 256)
-
 	ctx, cancel := context.WithCancel(context.Background())
 	srv := &Service{
 		ctx:                  ctx,
@@ -92,28 +110,34 @@ func NewService(cfg *Config) (*Service, error) {
 		transactionState:     cfg.TransactionState,
 		grandpaState:         cfg.GrandpaState,
 		net:                  cfg.Network,
-		blockAddCh:           blockAddCh,
 		codeSubstitute:       cfg.CodeSubstitutes,
 		codeSubstitutedState: cfg.CodeSubstitutedState,
 		onBlockImport:        cfg.OnBlockImport,
+		poolBlocks:           make(map[common.Hash]*types.Block),
 	}
 
 	return srv, nil
 }
 
+// SetEventBus sets the event bus the blocks imported are published on and the blocks
+// imported and finalised are received from, before the service is started.
+func (s *Service) SetEventBus(bus *events.Bus) {
+	s.eventBus = bus
+}
+
 // Start starts the core service
 func (s *Service) Start() error {
+	s.imported = events.SubscribeBlocking[events.BlockImported](s.eventBus)
+	s.finalised = events.SubscribeBlocking[events.BlockFinalised](s.eventBus)
 	go s.handleBlocksAsync()
 	return nil
 }
 
 // Stop stops the core service
 func (s *Service) Stop() error {
-	s.lock.Lock()
-	defer s.lock.Unlock()
-
 	s.cancel()
-	close(s.blockAddCh)
+	s.imported.Unsubscribe()
+	s.finalised.Unsubscribe()
 	return nil
 }
 
@@ -268,15 +292,9 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		return err
 	}
 
-	go func() {
-		s.lock.Lock()
-		defer s.lock.Unlock()
-		if s.ctx.Err() != nil {
-			return
-		}
-
-		s.blockAddCh <- block
-	}()
+	// the block import does not wait for the block to be received, since the
+	// subscribers may need the storage state locked by the block producer.
+	go events.Publish(s.eventBus, events.BlockImported{Block: block})
 
 	return nil
 }
@@ -312,6 +330,10 @@ func (s *Service) handleCodeSubstitution(hash common.Hash,
 		cfg.Role = 4
 	}
 
+	if tracer, ok := rt.(runtime.HostFunctionTracer); ok {
+		cfg.HostFunctionTracing = tracer.HostFunctionTracing()
+	}
+
 	next, err := wazero_runtime.NewInstance(code, cfg)
 	if err != nil {
 		return fmt.Errorf("creating new runtime instance: %w", err)
@@ -331,166 +353,330 @@ func (s *Service) handleCodeSubstitution(hash common.Hash,
 func (s *Service) handleBlocksAsync() {
 	for {
 		select {
-		case block, ok := <-s.blockAddCh:
+		case event, ok := <-s.imported.Events():
 			if !ok {
 				return
 			}
 
-			if block == nil {
-				continue
-			}
-
-			bestBlockHash := s.blockState.BestBlockHash()
-			if err := s.handleChainReorg(bestBlockHash, block.Header.Hash()); err != nil {
-				// TODO remove once gossamer is in stable state
-				panic(fmt.Errorf("failed to re-add transactions to chain upon re-org: %s", err))
+			s.trackPoolBlock(event.Block)
+			s.handleBestBlockChange(nil)
+		case event, ok := <-s.finalised.Events():
+			if !ok {
+				return
 			}
 
-			if err := s.maintainTransactionPool(block, bestBlockHash); err != nil {
-				// TODO remove once gossamer is in stable state
-				panic(fmt.Errorf("failed to maintain txn pool after re-org: %s", err))
-			}
+			// the finalisation prunes the forks of the finalised block, which
+			// changes the best block if it was on one of them.
+			s.handleBestBlockChange(event.Info.Retracted)
+			s.untrackPoolBlocks(event.Info)
 		case <-s.ctx.Done():
 			return
 		}
 	}
 }
 
-// handleChainReorg checks if there is a chain re-org (ie. new chain head is on a different chain than the
-// previous chain head). If there is a re-org, it moves the transactions that were included on the previous
-// chain back into the transaction pool.
-func (s *Service) handleChainReorg(best, curr common.Hash) error {
-	ancestor, err := s.blockState.LowestCommonAncestor(best, curr)
+// handleBestBlockChange maintains the transaction pool if the best block changed
+// since it was last maintained. The pruned block hashes given are the blocks
+// pruned from the block state since.
+func (s *Service) handleBestBlockChange(pruned []common.Hash) {
+	// the transaction pool is only maintained when the best block changes
+	bestHeader, err := s.blockState.BestBlockHeader()
 	if err != nil {
-		return err
+		logger.Errorf("failed to get best block header: %s", err)
+		return
 	}
 
-	// if the highest common ancestor of the previous chain head and current chain head is the previous chain head,
-	// then the current chain head is the descendant of the previous and thus are on the same chain
-	if ancestor == best || ancestor == curr {
-		return nil
+	bestBlockHash := bestHeader.Hash()
+	if bestBlockHash == s.poolBestBlockHash {
+		return
 	}
 
-	subchain, err := s.blockState.RangeInMemory(ancestor, best)
+	if slices.Contains(pruned, s.poolBestBlockHash) {
+		err = s.maintainTransactionPoolAfterPruning(s.poolBestBlockHash, bestHeader)
+	} else {
+		err = s.maintainTransactionPool(s.poolBestBlockHash, bestHeader)
+	}
 	if err != nil {
-		return err
+		// TODO remove once gossamer is in stable state
+		panic(fmt.Errorf("failed to maintain txn pool after best block change: %s", err))
 	}
+	s.poolBestBlockHash = bestBlockHash
+}
 
-	// subchain contains the ancestor as well so we need to remove it.
-	if len(subchain) > 0 {
-		subchain = subchain[1:]
-	} else {
-		return nil
+// trackPoolBlock keeps the block imported given for the maintenance of the transaction
+// pool, and drops the blocks kept too far below it, if the finality lags or is disabled.
+func (s *Service) trackPoolBlock(block *types.Block) {
+	if s.poolBlocks == nil {
+		s.poolBlocks = make(map[common.Hash]*types.Block)
 	}
 
-	// Check transaction validation on the best block.
-	bestBlockHash := s.blockState.BestBlockHash()
-	rt, err := s.blockState.GetRuntime(bestBlockHash)
-	if err != nil {
-		return err
+	for hash, poolBlock := range s.poolBlocks {
+		if poolBlock.Header.Number+poolBlocksDepth <= block.Header.Number {
+			delete(s.poolBlocks, hash)
+		}
 	}
 
-	if rt == nil {
-		return ErrNilRuntime
+	s.poolBlocks[block.Header.Hash()] = block
+}
+
+// untrackPoolBlocks drops the blocks kept for the maintenance of the transaction
+// pool which are finalised or pruned by the finalisation given.
+func (s *Service) untrackPoolBlocks(info *types.FinalisationInfo) {
+	for hash, poolBlock := range s.poolBlocks {
+		if poolBlock.Header.Number <= info.Header.Number {
+			delete(s.poolBlocks, hash)
+		}
+	}
+
+	for _, hash := range info.Retracted {
+		delete(s.poolBlocks, hash)
 	}
+}
 
-	// for each block in the previous chain, re-add its extrinsics back into the pool
-	for _, hash := range subchain {
+// maintainTransactionPool updates the transaction pool when the best block changes
+// from the previous best block to the new best block. It removes the extrinsics
+// included in the enacted blocks, revalidates the transactions in the pool against
+// the new best block state, moving them between the ready and future queues or
+// dropping them, and resubmits the extrinsics of the blocks retracted by a re-org.
+// Transactions past their longevity are dropped before being revalidated.
+// See https://github.com/paritytech/polkadot-sdk/blob/b0741d4f78ebc424c7544e1d2d5db7968132e577/substrate/client/transaction-pool/src/lib.rs#L582
+func (s *Service) maintainTransactionPool(previousBest common.Hash, bestHeader *types.Header) error {
+	retracted, enacted := s.treeRoute(previousBest, bestHeader.Hash())
+	return s.updateTransactionPool(retracted, enacted, bestHeader)
+}
+
+// maintainTransactionPoolAfterPruning updates the transaction pool when the best block
+// changes from the previous best block pruned from the block state, with its fork, by the
+// finalisation of another fork. Since the route between both blocks can no longer be found
+// in the block tree, it is found from the blocks kept for the maintenance of the pool.
+func (s *Service) maintainTransactionPoolAfterPruning(previousBest common.Hash, bestHeader *types.Header) error {
+	retracted, enacted, err := s.prunedTreeRoute(previousBest, bestHeader)
+	if err != nil {
+		logger.Debugf("failed to find route from pruned block %s to best block %s: %s",
+			previousBest, bestHeader.Hash(), err)
+		retracted, enacted = nil, []common.Hash{bestHeader.Hash()}
+	}
+
+	return s.updateTransactionPool(retracted, enacted, bestHeader)
+}
+
+// updateTransactionPool updates the transaction pool for the blocks retracted from
+// and the blocks enacted on the canonical chain, both ordered by ascending number.
+func (s *Service) updateTransactionPool(retracted, enacted []common.Hash, bestHeader *types.Header) error {
+	best := bestHeader.Hash()
+
+	for _, hash := range enacted {
 		body, err := s.blockState.GetBlockBody(hash)
-		if err != nil || body == nil {
+		if err != nil {
+			logger.Debugf("failed to get body of enacted block %s: %s", hash, err)
 			continue
 		}
 
 		for _, ext := range *body {
-			logger.Tracef("validating transaction on re-org chain for extrinsic %s", ext)
-			decExt := &ctypes.Extrinsic{}
-			decoder := cscale.NewDecoder(bytes.NewReader(ext))
-			if err = decoder.Decode(&decExt); err != nil {
-				return fmt.Errorf("decoding extrinsic: %s", err)
-			}
+			s.transactionState.RemoveExtrinsic(ext)
+		}
+	}
 
-			// Inherent are not signed.
-			if !decExt.IsSigned() {
-				continue
-			}
+	s.transactionState.PruneExpired(bestHeader.Number)
 
-			externalExt, err := s.buildExternalTransaction(rt, ext)
-			if err != nil {
-				return fmt.Errorf("building external transaction: %s", err)
-			}
+	validate, err := s.transactionValidator(best)
+	if err != nil {
+		return fmt.Errorf("creating transaction validator: %w", err)
+	}
 
-			transactionValidity, err := rt.ValidateTransaction(externalExt)
-			if err != nil {
-				logger.Debugf("failed to validate transaction for extrinsic %s: %s skipping in chain reorg", ext, err)
-				s.transactionState.RemoveExtrinsic(ext)
-				continue
-			}
-			vtx := transaction.NewValidTransaction(ext, transactionValidity)
-			s.transactionState.AddToPool(vtx)
+	s.transactionState.Revalidate(validate)
+
+	return s.resubmitRetractedExtrinsics(retracted, validate)
+}
+
+// treeRoute returns the blocks retracted from and the blocks enacted on the canonical
+// chain when the best block changes from the previous best block to the new one,
+// both ordered by ascending number. If the route cannot be found in the block tree,
+// only the new best block is enacted.
+func (s *Service) treeRoute(previousBest, best common.Hash) (retracted, enacted []common.Hash) {
+	enacted = []common.Hash{best}
+	if previousBest.IsEmpty() {
+		return nil, enacted
+	}
+
+	ancestor, err := s.blockState.LowestCommonAncestor(previousBest, best)
+	if err != nil {
+		logger.Debugf("failed to get lowest common ancestor of %s and %s: %s", previousBest, best, err)
+		return nil, enacted
+	}
+
+	// both ranges contain the ancestor which is neither retracted nor enacted
+	retractedRange, err := s.blockState.RangeInMemory(ancestor, previousBest)
+	if err != nil {
+		logger.Debugf("failed to get retracted blocks from %s to %s: %s", ancestor, previousBest, err)
+		return nil, enacted
+	}
+
+	enactedRange, err := s.blockState.RangeInMemory(ancestor, best)
+	if err != nil {
+		logger.Debugf("failed to get enacted blocks from %s to %s: %s", ancestor, best, err)
+		return retractedRange[1:], enacted
+	}
+
+	return retractedRange[1:], enactedRange[1:]
+}
+
+// prunedTreeRoute returns the blocks retracted from and the blocks enacted on the canonical
+// chain when the best block changes from the previous best block pruned from the block state
+// to the new best block, both ordered by ascending number. The pruned blocks are found in the
+// blocks kept for the maintenance of the transaction pool.
+func (s *Service) prunedTreeRoute(previousBest common.Hash, bestHeader *types.Header) (
+	retracted, enacted []common.Hash, err error) {
+	retractedHeader, err := s.poolBlockHeader(previousBest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enactedHeader := bestHeader
+	for retractedHeader.Hash() != enactedHeader.Hash() {
+		if retractedHeader.Number >= enactedHeader.Number {
+			retracted = append(retracted, retractedHeader.Hash())
+			retractedHeader, err = s.poolBlockHeader(retractedHeader.ParentHash)
+		} else {
+			enacted = append(enacted, enactedHeader.Hash())
+			enactedHeader, err = s.poolBlockHeader(enactedHeader.ParentHash)
+		}
+		if err != nil {
+			return nil, nil, err
 		}
 	}
 
-	return nil
+	slices.Reverse(retracted)
+	slices.Reverse(enacted)
+	return retracted, enacted, nil
 }
 
-// maintainTransactionPool removes any transactions that were included in
-// the new block, revalidates the transactions in the pool, and moves
-// them to the queue if valid.
-// See https://github.com/paritytech/substrate/blob/74804b5649eccfb83c90aec87bdca58e5d5c8789/client/transaction-pool/src/lib.rs#L545
-func (s *Service) maintainTransactionPool(block *types.Block, bestBlockHash common.Hash) error {
-	// remove extrinsics included in a block
-	for _, ext := range block.Body {
-		s.transactionState.RemoveExtrinsic(ext)
+// poolBlockHeader returns the header of the block kept for the maintenance
+// of the transaction pool, or the header from the block state otherwise.
+func (s *Service) poolBlockHeader(hash common.Hash) (*types.Header, error) {
+	block, ok := s.poolBlocks[hash]
+	if ok {
+		return &block.Header, nil
 	}
 
-	stateRoot, err := s.storageState.GetStateRootFromBlock(&bestBlockHash)
+	header, err := s.blockState.GetHeader(hash)
 	if err != nil {
-		logger.Errorf("could not get state root from block %s: %w", bestBlockHash, err)
-		return err
+		return nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+	return header, nil
+}
+
+// poolBlockBody returns the body of the block from the block state, or the body of the
+// block kept for the maintenance of the transaction pool if it was pruned.
+func (s *Service) poolBlockBody(hash common.Hash) (*types.Body, error) {
+	body, err := s.blockState.GetBlockBody(hash)
+	if err == nil {
+		return body, nil
+	}
+
+	block, ok := s.poolBlocks[hash]
+	if !ok {
+		return nil, err
+	}
+	return &block.Body, nil
+}
+
+// transactionValidator returns a function validating transactions against the state
+// of the given block with the TaggedTransactionQueue runtime API.
+func (s *Service) transactionValidator(blockHash common.Hash) (
+	validate func(ext types.Extrinsic) (*transaction.ValidTransaction, error), err error) {
+	stateRoot, err := s.storageState.GetStateRootFromBlock(&blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root from block %s: %w", blockHash, err)
 	}
 
 	ts, err := s.storageState.TrieState(stateRoot)
 	if err != nil {
-		logger.Errorf(err.Error())
-		return err
+		return nil, fmt.Errorf("getting trie state: %w", err)
 	}
 
-	// re-validate transactions in the pool and move them to the queue
-	txs := s.transactionState.PendingInPool()
-	for _, tx := range txs {
-		bestBlockHash := s.blockState.BestBlockHash()
-		rt, err := s.blockState.GetRuntime(bestBlockHash)
+	rt, err := s.blockState.GetRuntime(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	if rt == nil {
+		return nil, ErrNilRuntime
+	}
+
+	return func(ext types.Extrinsic) (*transaction.ValidTransaction, error) {
+		rt.SetContextStorage(ts)
+		externalExt, err := s.buildExternalTransaction(rt, ext)
 		if err != nil {
-			return fmt.Errorf("failed to get runtime to re-validate transactions in pool: %s", err)
+			return nil, fmt.Errorf("building external transaction: %w", err)
 		}
 
-		rt.SetContextStorage(ts)
-		externalExt, err := s.buildExternalTransaction(rt, tx.Extrinsic)
+		validity, err := rt.ValidateTransaction(externalExt)
 		if err != nil {
-			return fmt.Errorf("building external transaction: %s", err)
+			return nil, err
 		}
 
-		txnValidity, err := rt.ValidateTransaction(externalExt)
+		vtx := transaction.NewValidTransaction(ext, validity)
+		vtx.SetClassQuery(dispatchClassQuery(rt, ts, ext))
+		return vtx, nil
+	}, nil
+}
+
+// dispatchClassQuery returns the query of the dispatch class of the extrinsic from
+// the runtime at the given state, which returns the normal class if it cannot be
+// queried. The transaction pool only runs the query when it needs the class.
+func dispatchClassQuery(rt runtime.Instance, ts *rtstorage.TrieState,
+	ext types.Extrinsic) func() transaction.DispatchClass {
+	return func() transaction.DispatchClass {
+		rt.SetContextStorage(ts)
+		dispatchInfo, err := rt.PaymentQueryInfo(ext)
 		if err != nil {
-			logger.Debugf("failed to validate transaction for extrinsic %s: %s", tx.Extrinsic, err)
-			s.transactionState.RemoveExtrinsic(tx.Extrinsic)
+			logger.Debugf("failed to query dispatch info of extrinsic %s: %s", ext, err)
+			return transaction.Normal
+		}
+		return transaction.DispatchClass(dispatchInfo.Class)
+	}
+}
+
+// resubmitRetractedExtrinsics moves the signed extrinsics included in the retracted
+// blocks back into the transaction pool, if they are valid on the new best block.
+func (s *Service) resubmitRetractedExtrinsics(retracted []common.Hash,
+	validate func(ext types.Extrinsic) (*transaction.ValidTransaction, error)) error {
+	for _, hash := range retracted {
+		body, err := s.poolBlockBody(hash)
+		if err != nil || body == nil {
 			continue
 		}
 
-		tx = transaction.NewValidTransaction(tx.Extrinsic, txnValidity)
+		for _, ext := range *body {
+			logger.Tracef("validating transaction on re-org chain for extrinsic %s", ext)
+			decExt := &ctypes.Extrinsic{}
+			decoder := cscale.NewDecoder(bytes.NewReader(ext))
+			if err = decoder.Decode(&decExt); err != nil {
+				return fmt.Errorf("decoding extrinsic: %s", err)
+			}
 
-		// Err is only thrown if tx is already in pool, in which case it still gets removed
-		h, _ := s.transactionState.Push(tx)
+			// Inherent are not signed.
+			if !decExt.IsSigned() || s.transactionState.Exists(ext) {
+				continue
+			}
 
-		s.transactionState.RemoveExtrinsicFromPool(tx.Extrinsic)
-		logger.Tracef("moved transaction %s to queue", h)
+			vtx, err := validate(ext)
+			if err != nil {
+				logger.Debugf("failed to validate transaction for extrinsic %s: %s skipping in chain reorg", ext, err)
+				s.banIfInvalid(ext, err)
+				continue
+			}
+			s.transactionState.AddToPool(vtx)
+		}
 	}
+
 	return nil
 }
 
 // InsertKey inserts keypair into the account keystore
 func (s *Service) InsertKey(kp KeyPair, keystoreType string) error {
-	ks, err := s.keys.GetKeystore([]byte(keystoreType))
+	ks, err := s.keys.GetKeystoreOfType([]byte(keystoreType), kp.Type())
 	if err != nil {
 		return err
 	}
@@ -501,7 +687,13 @@ func (s *Service) InsertKey(kp KeyPair, keystoreType string) error {
 // HasKey returns true if given hex encoded public key string is found in keystore, false otherwise, error if there
 // are issues decoding string
 func (s *Service) HasKey(pubKeyStr, keystoreType string) (bool, error) {
-	ks, err := s.keys.GetKeystore([]byte(keystoreType))
+	pubKey, err := common.HexToBytes(pubKeyStr)
+	if err != nil {
+		return false, err
+	}
+
+	keyType := keystore.DeterminePublicKeyType(keystoreType, pubKey)
+	ks, err := s.keys.GetKeystoreOfType([]byte(keystoreType), keyType)
 	if err != nil {
 		return false, err
 	}
@@ -509,6 +701,25 @@ func (s *Service) HasKey(pubKeyStr, keystoreType string) (bool, error) {
 	return keystore.HasKey(pubKeyStr, keystoreType, ks)
 }
 
+// ListKeys returns the hex encoded public keys held by each keystore, keyed by keystore name
+func (s *Service) ListKeys() map[string][]string {
+	keys := make(map[string][]string)
+	for _, ks := range s.keys.Keystores() {
+		pubKeys := ks.PublicKeys()
+		encoded := make([]string, len(pubKeys))
+		for i, pub := range pubKeys {
+			encoded[i] = pub.Hex()
+		}
+		// keystores of different crypto types may share a name, such as the beef ones
+		if shared, ok := keys[string(ks.Name())]; ok {
+			encoded = append(shared, encoded...)
+		}
+		sort.Strings(encoded)
+		keys[string(ks.Name())] = encoded
+	}
+	return keys
+}
+
 // DecodeSessionKeys executes the runtime DecodeSessionKeys and return the scale encoded keys
 func (s *Service) DecodeSessionKeys(encodedSessionKeys []byte) ([]byte, error) {
 	bestBlockHash := s.blockState.BestBlockHash()
@@ -523,10 +734,11 @@ func (s *Service) DecodeSessionKeys(encodedSessionKeys []byte) ([]byte, error) {
 // GetRuntimeVersion gets the current RuntimeVersion
 func (s *Service) GetRuntimeVersion(bhash *common.Hash) (
 	version runtime.Version, err error) {
-	rt, err := prepareRuntime(bhash, s.storageState, s.blockState)
+	rt, release, err := prepareRuntime(bhash, s.storageState, s.blockState)
 	if err != nil {
 		return version, fmt.Errorf("setting up runtime: %w", err)
 	}
+	defer release()
 	return rt.Version()
 }
 
@@ -540,6 +752,10 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return nil
 	}
 
+	if s.transactionState.IsBanned(ext) {
+		return transaction.ErrTransactionBanned
+	}
+
 	bestBlockHash := s.blockState.BestBlockHash()
 
 	stateRoot, err := s.storageState.GetStateRootFromBlock(&bestBlockHash)
@@ -567,12 +783,17 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 
 	transactionValidity, err := rt.ValidateTransaction(externalExt)
 	if err != nil {
+		s.banIfInvalid(ext, err)
 		return err
 	}
 
 	// add transaction to pool
 	vtx := transaction.NewValidTransaction(ext, transactionValidity)
-	s.transactionState.AddToPool(vtx)
+	vtx.SetClassQuery(dispatchClassQuery(rt, ts, ext))
+	_, err = s.transactionState.Push(vtx)
+	if err != nil {
+		return fmt.Errorf("adding transaction to pool: %w", err)
+	}
 
 	// broadcast transaction
 	msg := &network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}}
@@ -580,12 +801,22 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 	return nil
 }
 
+// banIfInvalid temporarily bans the extrinsic if the validation error is an
+// invalid transaction error, so it is not validated again when resubmitted.
+func (s *Service) banIfInvalid(ext types.Extrinsic, err error) {
+	var invalidTransaction runtime.InvalidTransaction
+	if errors.As(err, &invalidTransaction) {
+		s.transactionState.Ban(ext)
+	}
+}
+
 // GetMetadata calls runtime Metadata_metadata function
 func (s *Service) GetMetadata(bhash *common.Hash) (metadata []byte, err error) {
-	rt, err := prepareRuntime(bhash, s.storageState, s.blockState)
+	rt, release, err := prepareRuntime(bhash, s.storageState, s.blockState)
 	if err != nil {
 		return nil, fmt.Errorf("setting up runtime: %w", err)
 	}
+	defer release()
 	return rt.Metadata()
 }
 
@@ -633,19 +864,22 @@ func (s *Service) buildExternalTransaction(rt runtime.Instance, ext types.Extrin
 	return types.Extrinsic(bytes.Join(extrinsicParts, nil)), nil
 }
 
+// prepareRuntime acquires the runtime of the block and sets its storage context
+// to the state of the block. The release function must be called once the
+// runtime is no longer used.
 func prepareRuntime(blockHash *common.Hash, storageState StorageState,
-	blockState BlockState) (instance runtime.Instance, err error) {
+	blockState BlockState) (instance runtime.Instance, release func(), err error) {
 	var stateRootHash *common.Hash
 	if blockHash != nil {
 		stateRootHash, err = storageState.GetStateRootFromBlock(blockHash)
 		if err != nil {
-			return nil, fmt.Errorf("getting state root from block hash: %w", err)
+			return nil, nil, fmt.Errorf("getting state root from block hash: %w", err)
 		}
 	}
 
 	trieState, err := storageState.TrieState(stateRootHash)
 	if err != nil {
-		return nil, fmt.Errorf("getting trie state: %w", err)
+		return nil, nil, fmt.Errorf("getting trie state: %w", err)
 	}
 
 	var blockHashValue common.Hash
@@ -654,11 +888,11 @@ func prepareRuntime(blockHash *common.Hash, storageState StorageState,
 	} else {
 		blockHashValue = blockState.BestBlockHash()
 	}
-	instance, err = blockState.GetRuntime(blockHashValue)
+	instance, release, err = blockState.AcquireRuntime(blockHashValue)
 	if err != nil {
-		return nil, fmt.Errorf("getting runtime: %w", err)
+		return nil, nil, fmt.Errorf("getting runtime: %w", err)
 	}
 
 	instance.SetContextStorage(trieState)
-	return instance, nil
+	return instance, release, nil
 }
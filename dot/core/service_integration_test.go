@@ -206,12 +206,12 @@ func TestHandleChainReorg_NoReorg(t *testing.T) {
 	head, err := s.blockState.BestBlockHeader()
 	require.NoError(t, err)
 
-	err = s.handleChainReorg(head.ParentHash, head.Hash())
+	err = s.maintainTransactionPool(head.ParentHash, head)
 	require.NoError(t, err)
 }
 
 func TestHandleChainReorg_WithReorg_Trans(t *testing.T) {
-	t.Skip() // TODO: tx fails to validate in handleChainReorg() with "Invalid transaction" (#1026)
+	t.Skip() // TODO: tx fails to validate in maintainTransactionPool() with "Invalid transaction" (#1026)
 	s := NewTestService(t, nil)
 	bs := s.blockState
 
@@ -262,7 +262,7 @@ func TestHandleChainReorg_WithReorg_Trans(t *testing.T) {
 	err = bs.AddBlock(block41)
 	require.NoError(t, err)
 
-	err = s.handleChainReorg(block41.Header.Hash(), block5.Header.Hash())
+	err = s.maintainTransactionPool(block41.Header.Hash(), &block5.Header)
 	require.NoError(t, err)
 
 	pending := s.transactionState.(*state.TransactionState).Pending()
@@ -279,15 +279,16 @@ func TestHandleChainReorg_WithReorg_NoTransactions(t *testing.T) {
 	leaves := s.blockState.(*state.BlockState).Leaves()
 	require.Equal(t, 2, len(leaves))
 
-	head := s.blockState.BestBlockHash()
+	head, err := s.blockState.BestBlockHeader()
+	require.NoError(t, err)
 	var other common.Hash
-	if leaves[0] == head {
+	if leaves[0] == head.Hash() {
 		other = leaves[1]
 	} else {
 		other = leaves[0]
 	}
 
-	err := s.handleChainReorg(other, head)
+	err = s.maintainTransactionPool(other, head)
 	require.NoError(t, err)
 }
 
@@ -339,15 +340,16 @@ func TestHandleChainReorg_WithReorg_Transactions(t *testing.T) {
 	leaves := s.blockState.(*state.BlockState).Leaves()
 	require.Equal(t, 2, len(leaves))
 
-	head := s.blockState.BestBlockHash()
+	head, err := s.blockState.BestBlockHeader()
+	require.NoError(t, err)
 	var other common.Hash
-	if leaves[0] == head {
+	if leaves[0] == head.Hash() {
 		other = leaves[1]
 	} else {
 		other = leaves[0]
 	}
 
-	err = s.handleChainReorg(other, head)
+	err = s.maintainTransactionPool(other, head)
 	require.NoError(t, err)
 
 	pending := s.transactionState.(*state.TransactionState).Pending()
@@ -391,10 +393,9 @@ func TestMaintainTransactionPoolLatestTxnQueue_EmptyBlock(t *testing.T) {
 
 	expectedTx := transaction.NewValidTransaction(tx.Extrinsic, txnValidity)
 
-	bestBlockHash := service.blockState.BestBlockHash()
-	err = service.maintainTransactionPool(&types.Block{
-		Body: *types.NewBody([]types.Extrinsic{}),
-	}, bestBlockHash)
+	bestBlockHeader, err := service.blockState.BestBlockHeader()
+	require.NoError(t, err)
+	err = service.maintainTransactionPool(bestBlockHeader.Hash(), bestBlockHeader)
 	require.NoError(t, err)
 
 	resultTx := service.transactionState.(*state.TransactionState).Pop()
@@ -429,10 +430,31 @@ func TestMaintainTransactionPoolLatestTxnQueue_BlockWithExtrinsics(t *testing.T)
 	}
 	_ = service.transactionState.AddToPool(tx)
 
-	bestBlockHash := service.blockState.BestBlockHash()
-	err = service.maintainTransactionPool(&types.Block{
+	genesisHeader, err := service.blockState.BestBlockHeader()
+	require.NoError(t, err)
+	rt, err := service.blockState.GetRuntime(genesisHeader.Hash())
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	prd, err := types.NewBabeSecondaryPlainPreDigest(0, 1).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	err = digest.Add(*prd)
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header: types.Header{
+			ParentHash: genesisHeader.Hash(),
+			Number:     1,
+			StateRoot:  genesisHeader.StateRoot,
+			Digest:     digest,
+		},
 		Body: types.Body([]types.Extrinsic{encodedExtrinsic}),
-	}, bestBlockHash)
+	}
+	service.blockState.StoreRuntime(block.Header.Hash(), rt)
+	err = service.blockState.AddBlock(block)
+	require.NoError(t, err)
+
+	err = service.maintainTransactionPool(genesisHeader.Hash(), &block.Header)
 	require.NoError(t, err)
 
 	res := []*transaction.ValidTransaction{}
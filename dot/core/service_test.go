@@ -11,12 +11,14 @@
 	"io"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network"
 	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/bls381"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -597,384 +599,493 @@ func Test_Service_HandleBlockProduced(t *testing.T) {
 
 func Test_Service_maintainTransactionPool(t *testing.T) {
 	t.Parallel()
-	t.Run("Validate_Transaction_err", func(t *testing.T) {
-		t.Parallel()
-		testHeader := types.NewEmptyHeader()
-		block := types.NewBlock(*testHeader, *types.NewBody([]types.Extrinsic{[]byte{21}}))
-		block.Header.Number = 21
 
-		validity := &transaction.Validity{
-			Priority: 0x3e8,
-			Requires: [][]byte{{0xb5, 0x47, 0xb1, 0x90, 0x37, 0x10, 0x7e, 0x1f, 0x79,
-				0x4c, 0xa8, 0x69, 0x0, 0xa1, 0xb5, 0x98}},
-			Provides: [][]byte{{0xe4, 0x80, 0x7d, 0x1b, 0x67, 0x49, 0x37, 0xbf, 0xc7,
-				0x89, 0xbb, 0xdd, 0x88, 0x6a, 0xdd, 0xd6}},
-			Longevity: 0x40,
-			Propagate: true,
-		}
+	testPrevHash := common.MustHexToHash("0x01")
+	testBestHeader := &types.Header{Number: 2}
+	testBestHash := testBestHeader.Hash()
+	testAncestorHash := common.MustHexToHash("0x03")
+
+	// A valid extrinsic is needed since retracted extrinsics are decoded
+	ext, externExt, body := generateExtrinsic(t)
+	testValidity := &transaction.Validity{Propagate: true}
+	vtx := transaction.NewValidTransaction(ext, testValidity)
 
-		extrinsic := types.Extrinsic{21}
-		externalExt := types.Extrinsic(bytes.Join([][]byte{
-			{byte(types.TxnExternal)},
-			extrinsic,
-			testHeader.StateRoot.ToBytes(),
-		}, nil))
-		vt := transaction.NewValidTransaction(extrinsic, validity)
+	runtimeVersion := runtime.Version{
+		SpecName:         []byte("polkadot"),
+		ImplName:         []byte("parity-polkadot"),
+		AuthoringVersion: authoringVersion,
+		SpecVersion:      specVersion,
+		ImplVersion:      implVersion,
+		APIItems: []runtime.APIItem{{
+			Name: common.MustBlake2b8([]byte("TaggedTransactionQueue")),
+			Ver:  3,
+		}},
+		TransactionVersion: transactionVersion,
+		StateVersion:       stateVersion,
+	}
 
+	t.Run("get_state_root_error", func(t *testing.T) {
+		t.Parallel()
 		ctrl := gomock.NewController(t)
-		runtimeMock := NewMockInstance(ctrl)
-		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(nil, errTestDummyError)
-		runtimeMock.EXPECT().Version().Return(runtime.Version{
-			SpecName:         []byte("polkadot"),
-			ImplName:         []byte("parity-polkadot"),
-			AuthoringVersion: authoringVersion,
-			SpecVersion:      specVersion,
-			ImplVersion:      implVersion,
-			APIItems: []runtime.APIItem{{
-				Name: common.MustBlake2b8([]byte("TaggedTransactionQueue")),
-				Ver:  3,
-			}},
-			TransactionVersion: transactionVersion,
-			StateVersion:       stateVersion,
-		}, nil)
-		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
 
-		mockTxnState := NewMockTransactionState(ctrl)
-		mockTxnState.EXPECT().RemoveExtrinsic(types.Extrinsic{21}).Times(2)
-		mockTxnState.EXPECT().PendingInPool().Return([]*transaction.ValidTransaction{vt})
 		mockBlockState := NewMockBlockState(ctrl)
-		runtimeBlockHashCall := mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).
-			Return(runtimeMock, nil).After(runtimeBlockHashCall)
-		mockBlockState.EXPECT().BestBlockHash().
-			Return(common.Hash{}).After(runtimeBlockHashCall)
-
+		mockBlockState.EXPECT().GetBlockBody(testBestHash).Return(body, nil)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().RemoveExtrinsic(ext)
+		mockTxnState.EXPECT().PruneExpired(uint(2))
 		mockStorageState := NewMockStorageState(ctrl)
-		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(&rtstorage.TrieState{}, nil)
-		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{1}, nil)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&testBestHash).Return(nil, errTestDummyError)
+
 		service := &Service{
 			transactionState: mockTxnState,
 			blockState:       mockBlockState,
 			storageState:     mockStorageState,
 		}
-		err := service.maintainTransactionPool(&block, common.Hash{1})
-		require.NoError(t, err)
+		err := service.maintainTransactionPool(common.Hash{}, testBestHeader)
+		assert.ErrorIs(t, err, errTestDummyError)
+		assert.EqualError(t, err, "creating transaction validator: "+
+			"getting state root from block "+testBestHash.String()+": test dummy error")
 	})
 
-	t.Run("Validate_Transaction_ok", func(t *testing.T) {
+	t.Run("revalidate", func(t *testing.T) {
 		t.Parallel()
-		testHeader := types.NewEmptyHeader()
-		block := types.NewBlock(*testHeader, *types.NewBody([]types.Extrinsic{[]byte{21}}))
-		block.Header.Number = 21
-
-		validity := &transaction.Validity{
-			Priority: 0x3e8,
-			Requires: [][]byte{{0xb5, 0x47, 0xb1, 0x90, 0x37, 0x10, 0x7e, 0x1f, 0x79, 0x4c,
-				0xa8, 0x69, 0x0, 0xa1, 0xb5, 0x98}},
-			Provides: [][]byte{{0xe4, 0x80, 0x7d, 0x1b, 0x67, 0x49, 0x37, 0xbf, 0xc7, 0x89,
-				0xbb, 0xdd, 0x88, 0x6a, 0xdd, 0xd6}},
-			Longevity: 0x40,
-			Propagate: true,
-		}
-
-		ext := types.Extrinsic{21}
-		externalExt := types.Extrinsic(bytes.Join([][]byte{
-			{byte(types.TxnExternal)},
-			ext,
-			testHeader.StateRoot.ToBytes(),
-		}, nil))
-		vt := transaction.NewValidTransaction(ext, validity)
-		tx := transaction.NewValidTransaction(ext, &transaction.Validity{Propagate: true})
-
 		ctrl := gomock.NewController(t)
+
 		runtimeMock := NewMockInstance(ctrl)
-		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(&transaction.Validity{Propagate: true}, nil)
-		runtimeMock.EXPECT().Version().Return(runtime.Version{
-			SpecName:         []byte("polkadot"),
-			ImplName:         []byte("parity-polkadot"),
-			AuthoringVersion: authoringVersion,
-			SpecVersion:      specVersion,
-			ImplVersion:      implVersion,
-			APIItems: []runtime.APIItem{{
-				Name: common.MustBlake2b8([]byte("TaggedTransactionQueue")),
-				Ver:  3,
-			}},
-			TransactionVersion: transactionVersion,
-			StateVersion:       stateVersion,
-		}, nil)
-		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
-		mockTxnState := NewMockTransactionState(ctrl)
-		mockTxnState.EXPECT().RemoveExtrinsic(types.Extrinsic{21})
-		mockTxnState.EXPECT().PendingInPool().Return([]*transaction.ValidTransaction{vt})
-		mockTxnState.EXPECT().Push(tx).Return(common.Hash{}, nil)
-		mockTxnState.EXPECT().RemoveExtrinsicFromPool(types.Extrinsic{21})
-
-		mockBlockStateOk := NewMockBlockState(ctrl)
-		runtimeBlockHashCall := mockBlockStateOk.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockStateOk.EXPECT().GetRuntime(common.Hash{1}).
-			Return(runtimeMock, nil).After(runtimeBlockHashCall)
-		mockBlockStateOk.EXPECT().BestBlockHash().
-			Return(common.Hash{}).After(runtimeBlockHashCall)
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{}).Times(2)
+		runtimeMock.EXPECT().Version().Return(runtimeVersion, nil)
+		runtimeMock.EXPECT().ValidateTransaction(externExt).Return(testValidity, nil)
+		runtimeMock.EXPECT().PaymentQueryInfo(ext).Return(&types.RuntimeDispatchInfo{}, nil)
 
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetBlockBody(testBestHash).Return(nil, errTestDummyError)
+		mockBlockState.EXPECT().GetRuntime(testBestHash).Return(runtimeMock, nil)
+		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&testBestHash).Return(&common.Hash{1}, nil)
 		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(&rtstorage.TrieState{}, nil)
-		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{1}, nil)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().PruneExpired(uint(2))
+		mockTxnState.EXPECT().Revalidate(gomock.Any()).
+			Do(func(validate func(types.Extrinsic) (*transaction.ValidTransaction, error)) {
+				vt, err := validate(ext)
+				assert.NoError(t, err)
+				assertValidTransaction(t, vtx, vt)
+			})
+
 		service := &Service{
 			transactionState: mockTxnState,
-			blockState:       mockBlockStateOk,
+			blockState:       mockBlockState,
 			storageState:     mockStorageState,
 		}
-		err := service.maintainTransactionPool(&block, common.Hash{1})
+		err := service.maintainTransactionPool(common.Hash{}, testBestHeader)
 		require.NoError(t, err)
 	})
-}
 
-func Test_Service_handleBlocksAsync(t *testing.T) {
-	t.Parallel()
-	t.Run("cancelled_context", func(t *testing.T) {
+	t.Run("reorg", func(t *testing.T) {
 		t.Parallel()
-		blockAddChan := make(chan *types.Block)
-		ctx, cancel := context.WithCancel(context.Background())
-		cancel()
-		service := &Service{
-			blockAddCh: blockAddChan,
-			ctx:        ctx,
-		}
-		service.handleBlocksAsync()
-	})
+		ctrl := gomock.NewController(t)
 
-	t.Run("channel_not_ok", func(t *testing.T) {
-		t.Parallel()
-		blockAddChan := make(chan *types.Block)
-		close(blockAddChan)
-		service := &Service{
-			blockAddCh: blockAddChan,
-			ctx:        context.Background(),
-		}
-		service.handleBlocksAsync()
-	})
+		runtimeMock := NewMockInstance(ctrl)
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{}).Times(2)
+		runtimeMock.EXPECT().Version().Return(runtimeVersion, nil)
+		runtimeMock.EXPECT().ValidateTransaction(externExt).Return(testValidity, nil)
+		runtimeMock.EXPECT().PaymentQueryInfo(ext).Return(&types.RuntimeDispatchInfo{}, nil)
+
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+			Return(testAncestorHash, nil)
+		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).
+			Return([]common.Hash{testAncestorHash, testPrevHash}, nil)
+		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testBestHash).
+			Return([]common.Hash{testAncestorHash, testBestHash}, nil)
+		mockBlockState.EXPECT().GetBlockBody(testBestHash).Return(types.NewBody(nil), nil)
+		mockBlockState.EXPECT().GetBlockBody(testPrevHash).Return(body, nil)
+		mockBlockState.EXPECT().GetRuntime(testBestHash).Return(runtimeMock, nil)
+		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&testBestHash).Return(&common.Hash{1}, nil)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(&rtstorage.TrieState{}, nil)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().PruneExpired(uint(2))
+		mockTxnState.EXPECT().Revalidate(gomock.Any())
+		mockTxnState.EXPECT().Exists(ext).Return(false)
+		mockTxnState.EXPECT().AddToPool(gomock.Any()).DoAndReturn(
+			func(vt *transaction.ValidTransaction) common.Hash {
+				assertValidTransaction(t, vtx, vt)
+				return common.Hash{}
+			})
 
-	t.Run("nil_block", func(t *testing.T) {
-		t.Parallel()
-		blockAddChan := make(chan *types.Block)
-		go func() {
-			blockAddChan <- nil
-			close(blockAddChan)
-		}()
 		service := &Service{
-			blockAddCh: blockAddChan,
-			ctx:        context.Background(),
+			transactionState: mockTxnState,
+			blockState:       mockBlockState,
+			storageState:     mockStorageState,
 		}
-		service.handleBlocksAsync()
+		err := service.maintainTransactionPool(testPrevHash, testBestHeader)
+		require.NoError(t, err)
 	})
 
-	t.Run("handleChainReorg_error", func(t *testing.T) {
+	t.Run("reorg_invalid_transaction", func(t *testing.T) {
 		t.Parallel()
+		ctrl := gomock.NewController(t)
 
-		testHeader := types.NewEmptyHeader()
-		block := types.NewBlock(*testHeader, *types.NewBody([]types.Extrinsic{[]byte{21}}))
-		block.Header.Number = 21
+		runtimeMock := NewMockInstance(ctrl)
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+		runtimeMock.EXPECT().Version().Return(runtimeVersion, nil)
+		runtimeMock.EXPECT().ValidateTransaction(externExt).Return(nil, runtime.NewInvalidTransaction())
 
-		ctrl := gomock.NewController(t)
 		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+			Return(testAncestorHash, nil)
+		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).
+			Return([]common.Hash{testAncestorHash, testPrevHash}, nil)
+		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testBestHash).
+			Return([]common.Hash{testAncestorHash, testBestHash}, nil)
+		mockBlockState.EXPECT().GetBlockBody(testBestHash).Return(types.NewBody(nil), nil)
+		mockBlockState.EXPECT().GetBlockBody(testPrevHash).Return(body, nil)
+		mockBlockState.EXPECT().GetRuntime(testBestHash).Return(runtimeMock, nil)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
-		mockBlockState.EXPECT().LowestCommonAncestor(common.Hash{}, block.Header.Hash()).
-			Return(common.Hash{}, errTestDummyError)
-
-		blockAddChan := make(chan *types.Block)
-		go func() {
-			blockAddChan <- &block
-			close(blockAddChan)
-		}()
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&testBestHash).Return(&common.Hash{1}, nil)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(&rtstorage.TrieState{}, nil)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().PruneExpired(uint(2))
+		mockTxnState.EXPECT().Revalidate(gomock.Any())
+		mockTxnState.EXPECT().Exists(ext).Return(false)
+		mockTxnState.EXPECT().Ban(ext)
+
 		service := &Service{
-			blockState: mockBlockState,
-			blockAddCh: blockAddChan,
-			ctx:        context.Background(),
+			transactionState: mockTxnState,
+			blockState:       mockBlockState,
+			storageState:     mockStorageState,
 		}
-
-		assert.PanicsWithError(t, "failed to re-add transactions to chain upon re-org: test dummy error",
-			service.handleBlocksAsync)
+		err := service.maintainTransactionPool(testPrevHash, testBestHeader)
+		require.NoError(t, err)
 	})
 }
 
-func TestService_handleChainReorg(t *testing.T) {
+func Test_Service_treeRoute(t *testing.T) {
 	t.Parallel()
-	execTest := func(t *testing.T, s *Service, prevHash common.Hash, currHash common.Hash, expErr error) {
-		err := s.handleChainReorg(prevHash, currHash)
-		if expErr != nil {
-			assert.EqualError(t, err, expErr.Error())
-		}
-	}
 
 	testPrevHash := common.MustHexToHash("0x01")
-	testCurrentHash := common.MustHexToHash("0x02")
+	testBestHash := common.MustHexToHash("0x02")
 	testAncestorHash := common.MustHexToHash("0x03")
-	testSubChain := []common.Hash{testPrevHash, testCurrentHash, testAncestorHash}
 
-	// A valid extrinsic is needed since it will be validated in handleChainReorg
-	ext, externExt, body := generateExtrinsic(t)
-	testValidity := &transaction.Validity{Propagate: true}
-	vtx := transaction.NewValidTransaction(ext, testValidity)
+	testCases := map[string]struct {
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		previousBest      common.Hash
+		retracted         []common.Hash
+		enacted           []common.Hash
+	}{
+		"no_previous_best": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				return nil
+			},
+			enacted: []common.Hash{testBestHash},
+		},
+		"lowest_common_ancestor_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+					Return(common.Hash{}, errTestDummyError)
+				return mockBlockState
+			},
+			previousBest: testPrevHash,
+			enacted:      []common.Hash{testBestHash},
+		},
+		"retracted_range_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+					Return(testAncestorHash, nil)
+				mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).
+					Return(nil, errTestDummyError)
+				return mockBlockState
+			},
+			previousBest: testPrevHash,
+			enacted:      []common.Hash{testBestHash},
+		},
+		"enacted_range_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+					Return(testAncestorHash, nil)
+				mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).
+					Return([]common.Hash{testAncestorHash, testPrevHash}, nil)
+				mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testBestHash).
+					Return(nil, errTestDummyError)
+				return mockBlockState
+			},
+			previousBest: testPrevHash,
+			retracted:    []common.Hash{testPrevHash},
+			enacted:      []common.Hash{testBestHash},
+		},
+		"descendant": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+					Return(testPrevHash, nil)
+				mockBlockState.EXPECT().RangeInMemory(testPrevHash, testPrevHash).
+					Return([]common.Hash{testPrevHash}, nil)
+				mockBlockState.EXPECT().RangeInMemory(testPrevHash, testBestHash).
+					Return([]common.Hash{testPrevHash, testAncestorHash, testBestHash}, nil)
+				return mockBlockState
+			},
+			previousBest: testPrevHash,
+			retracted:    []common.Hash{},
+			enacted:      []common.Hash{testAncestorHash, testBestHash},
+		},
+		"reorg": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testBestHash).
+					Return(testAncestorHash, nil)
+				mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).
+					Return([]common.Hash{testAncestorHash, testPrevHash}, nil)
+				mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testBestHash).
+					Return([]common.Hash{testAncestorHash, testBestHash}, nil)
+				return mockBlockState
+			},
+			previousBest: testPrevHash,
+			retracted:    []common.Hash{testPrevHash},
+			enacted:      []common.Hash{testBestHash},
+		},
+	}
 
-	t.Run("highest_common_ancestor_err", func(t *testing.T) {
-		t.Parallel()
-		ctrl := gomock.NewController(t)
-		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(common.Hash{}, errDummyErr)
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
 
-		service := &Service{
-			blockState: mockBlockState,
-		}
-		execTest(t, service, testPrevHash, testCurrentHash, errDummyErr)
-	})
+			service := &Service{
+				blockState: testCase.blockStateBuilder(ctrl),
+			}
+			retracted, enacted := service.treeRoute(testCase.previousBest, testBestHash)
+			assert.Equal(t, testCase.retracted, retracted)
+			assert.Equal(t, testCase.enacted, enacted)
+		})
+	}
+}
 
-	t.Run("highest_common_ancestor_err", func(t *testing.T) {
-		t.Parallel()
-		ctrl := gomock.NewController(t)
-		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(common.Hash{}, errDummyErr)
+func Test_Service_prunedTreeRoute(t *testing.T) {
+	t.Parallel()
 
-		service := &Service{
-			blockState: mockBlockState,
-		}
-		execTest(t, service, testPrevHash, testCurrentHash, errDummyErr)
-	})
+	ancestor := &types.Header{Number: 5}
+	pruned1 := &types.Header{Number: 6, ParentHash: ancestor.Hash(), StateRoot: common.Hash{1}}
+	pruned2 := &types.Header{Number: 7, ParentHash: pruned1.Hash()}
+	canonical1 := &types.Header{Number: 6, ParentHash: ancestor.Hash(), StateRoot: common.Hash{2}}
+	canonical2 := &types.Header{Number: 7, ParentHash: canonical1.Hash()}
+	best := &types.Header{Number: 8, ParentHash: canonical2.Hash()}
 
-	t.Run("ancestor_eq_priv", func(t *testing.T) {
-		t.Parallel()
-		ctrl := gomock.NewController(t)
-		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(testPrevHash, nil)
+	testCases := map[string]struct {
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		poolBlocks        map[common.Hash]*types.Block
+		retracted         []common.Hash
+		enacted           []common.Hash
+		errWrapped        error
+		errMessage        string
+	}{
+		"previous_best_not_found": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(pruned2.Hash()).Return(nil, errTestDummyError)
+				return mockBlockState
+			},
+			errWrapped: errTestDummyError,
+			errMessage: "getting header of block " + pruned2.Hash().String() + ": test dummy error",
+		},
+		"pruned_fork": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(canonical2.Hash()).Return(canonical2, nil)
+				mockBlockState.EXPECT().GetHeader(canonical1.Hash()).Return(canonical1, nil)
+				mockBlockState.EXPECT().GetHeader(ancestor.Hash()).Return(ancestor, nil).Times(2)
+				return mockBlockState
+			},
+			poolBlocks: map[common.Hash]*types.Block{
+				pruned1.Hash(): {Header: *pruned1},
+				pruned2.Hash(): {Header: *pruned2},
+			},
+			retracted: []common.Hash{pruned1.Hash(), pruned2.Hash()},
+			enacted:   []common.Hash{canonical1.Hash(), canonical2.Hash(), best.Hash()},
+		},
+	}
 
-		service := &Service{
-			blockState: mockBlockState,
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := &Service{
+				blockState: testCase.blockStateBuilder(ctrl),
+				poolBlocks: testCase.poolBlocks,
+			}
+			retracted, enacted, err := service.prunedTreeRoute(pruned2.Hash(), best)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.retracted, retracted)
+			assert.Equal(t, testCase.enacted, enacted)
+		})
+	}
+}
+
+func Test_Service_poolBlocks(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{}
+
+	fork := &types.Block{Header: types.Header{Number: 3, StateRoot: common.Hash{1}}}
+	blocks := make([]*types.Block, 0, poolBlocksDepth+2)
+	for number := uint(1); number <= poolBlocksDepth+2; number++ {
+		block := &types.Block{Header: types.Header{Number: number}}
+		blocks = append(blocks, block)
+		service.trackPoolBlock(block)
+		if number == fork.Header.Number {
+			service.trackPoolBlock(fork)
 		}
-		execTest(t, service, testPrevHash, testCurrentHash, nil)
-	})
+	}
 
-	t.Run("subchain_err", func(t *testing.T) {
-		t.Parallel()
-		ctrl := gomock.NewController(t)
-		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(testAncestorHash, nil)
-		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).Return([]common.Hash{}, errDummyErr)
+	// the blocks too far below the last block imported are dropped
+	assert.Len(t, service.poolBlocks, poolBlocksDepth+1)
+	assert.NotContains(t, service.poolBlocks, blocks[1].Header.Hash())
+	assert.Contains(t, service.poolBlocks, blocks[2].Header.Hash())
+	assert.Contains(t, service.poolBlocks, fork.Header.Hash())
+
+	info := &types.FinalisationInfo{
+		Header:    blocks[9].Header,
+		Retracted: []common.Hash{fork.Header.Hash(), blocks[20].Header.Hash()},
+	}
+	service.untrackPoolBlocks(info)
 
+	assert.Len(t, service.poolBlocks, poolBlocksDepth-9)
+	assert.NotContains(t, service.poolBlocks, blocks[9].Header.Hash())
+	assert.NotContains(t, service.poolBlocks, fork.Header.Hash())
+	assert.NotContains(t, service.poolBlocks, blocks[20].Header.Hash())
+	assert.Contains(t, service.poolBlocks, blocks[10].Header.Hash())
+}
+
+func Test_Service_handleBlocksAsync(t *testing.T) {
+	t.Parallel()
+	t.Run("cancelled_context", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 		service := &Service{
-			blockState: mockBlockState,
+			imported: events.SubscribeBlocking[events.BlockImported](events.NewBus()),
+			ctx:      ctx,
 		}
-		execTest(t, service, testPrevHash, testCurrentHash, errDummyErr)
+		service.handleBlocksAsync()
 	})
 
-	t.Run("empty_subchain", func(t *testing.T) {
+	t.Run("unsubscribed", func(t *testing.T) {
 		t.Parallel()
-		ctrl := gomock.NewController(t)
-		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(testAncestorHash, nil)
-		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).Return([]common.Hash{}, nil)
-
+		imported := events.SubscribeBlocking[events.BlockImported](events.NewBus())
+		imported.Unsubscribe()
 		service := &Service{
-			blockState: mockBlockState,
+			imported: imported,
+			ctx:      context.Background(),
 		}
-		execTest(t, service, testPrevHash, testCurrentHash, nil)
+		service.handleBlocksAsync()
 	})
 
-	t.Run("get_runtime_err", func(t *testing.T) {
+	t.Run("best_block_unchanged", func(t *testing.T) {
 		t.Parallel()
+
+		testHeader := types.NewEmptyHeader()
+		block := types.NewBlock(*testHeader, *types.NewBody([]types.Extrinsic{[]byte{21}}))
+
 		ctrl := gomock.NewController(t)
 		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(testAncestorHash, nil)
-		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).Return(testSubChain, nil)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(nil, errDummyErr)
+		mockBlockState.EXPECT().BestBlockHeader().Return(&block.Header, nil)
 
+		bus := events.NewBus()
+		imported := events.SubscribeBlocking[events.BlockImported](bus)
+		events.Publish(bus, events.BlockImported{Block: &block})
+		imported.Unsubscribe()
 		service := &Service{
-			blockState: mockBlockState,
+			blockState:        mockBlockState,
+			imported:          imported,
+			ctx:               context.Background(),
+			poolBestBlockHash: block.Header.Hash(),
 		}
-		execTest(t, service, testPrevHash, testCurrentHash, errDummyErr)
+		service.handleBlocksAsync()
 	})
 
-	t.Run("invalid_transaction", func(t *testing.T) {
+	t.Run("maintainTransactionPool_error", func(t *testing.T) {
 		t.Parallel()
-		ctrl := gomock.NewController(t)
 
-		runtimeMockErr := NewMockInstance(ctrl)
-		runtimeMockErr.EXPECT().ValidateTransaction(externExt).Return(nil, errTestDummyError)
-		runtimeMockErr.EXPECT().Version().Return(runtime.Version{
-			SpecName:         []byte("polkadot"),
-			ImplName:         []byte("parity-polkadot"),
-			AuthoringVersion: authoringVersion,
-			SpecVersion:      specVersion,
-			ImplVersion:      implVersion,
-			APIItems: []runtime.APIItem{{
-				Name: common.MustBlake2b8([]byte("TaggedTransactionQueue")),
-				Ver:  3,
-			}},
-			TransactionVersion: transactionVersion,
-			StateVersion:       stateVersion,
-		}, nil)
+		testHeader := types.NewEmptyHeader()
+		block := types.NewBlock(*testHeader, *types.NewBody([]types.Extrinsic{[]byte{21}}))
+		block.Header.Number = 21
 
+		ctrl := gomock.NewController(t)
+		bestHash := block.Header.Hash()
 		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(testAncestorHash, nil)
-		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).Return(testSubChain, nil)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(runtimeMockErr, nil)
-		mockBlockState.EXPECT().GetBlockBody(testCurrentHash).Return(nil, errDummyErr)
-		mockBlockState.EXPECT().GetBlockBody(testAncestorHash).Return(body, nil)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
+		mockBlockState.EXPECT().BestBlockHeader().Return(&block.Header, nil)
+		mockBlockState.EXPECT().GetBlockBody(bestHash).Return(nil, errTestDummyError)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&bestHash).Return(nil, errTestDummyError)
 		mockTxnState := NewMockTransactionState(ctrl)
-		mockTxnState.EXPECT().RemoveExtrinsic(ext)
+		mockTxnState.EXPECT().PruneExpired(uint(21))
 
+		bus := events.NewBus()
+		imported := events.SubscribeBlocking[events.BlockImported](bus)
+		events.Publish(bus, events.BlockImported{Block: &block})
 		service := &Service{
 			blockState:       mockBlockState,
+			storageState:     mockStorageState,
 			transactionState: mockTxnState,
+			imported:         imported,
+			ctx:              context.Background(),
 		}
 
-		execTest(t, service, testPrevHash, testCurrentHash, nil)
+		assert.PanicsWithError(t, "failed to maintain txn pool after best block change: "+
+			"creating transaction validator: getting state root from block "+
+			bestHash.String()+": test dummy error",
+			service.handleBlocksAsync)
 	})
 
-	t.Run("happy_path", func(t *testing.T) {
+	t.Run("best_block_pruned_by_finalisation", func(t *testing.T) {
 		t.Parallel()
-		ctrl := gomock.NewController(t)
-		runtimeMockOk := NewMockInstance(ctrl)
-		runtimeMockOk.EXPECT().ValidateTransaction(externExt).Return(testValidity, nil)
-		runtimeMockOk.EXPECT().Version().Return(runtime.Version{
-			SpecName:         []byte("polkadot"),
-			ImplName:         []byte("parity-polkadot"),
-			AuthoringVersion: authoringVersion,
-			SpecVersion:      specVersion,
-			ImplVersion:      implVersion,
-			APIItems: []runtime.APIItem{{
-				Name: common.MustBlake2b8([]byte("TaggedTransactionQueue")),
-				Ver:  3,
-			}},
-			TransactionVersion: transactionVersion,
-			StateVersion:       stateVersion,
-		}, nil)
 
-		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().LowestCommonAncestor(testPrevHash, testCurrentHash).
-			Return(testAncestorHash, nil)
-		mockBlockState.EXPECT().RangeInMemory(testAncestorHash, testPrevHash).Return(testSubChain, nil)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(runtimeMockOk, nil)
-		mockBlockState.EXPECT().GetBlockBody(testCurrentHash).Return(nil, errDummyErr)
-		mockBlockState.EXPECT().GetBlockBody(testAncestorHash).Return(body, nil)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
-		mockTxnStateOk := NewMockTransactionState(ctrl)
-		mockTxnStateOk.EXPECT().AddToPool(vtx).Return(common.Hash{})
+		bestHeader := &types.Header{Number: 21}
+		bestHash := bestHeader.Hash()
+		prunedHash := common.Hash{1}
 
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().BestBlockHeader().Return(bestHeader, nil)
+		mockBlockState.EXPECT().GetHeader(prunedHash).Return(nil, errTestDummyError)
+		mockBlockState.EXPECT().GetBlockBody(bestHash).Return(nil, errTestDummyError)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&bestHash).Return(nil, errTestDummyError)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().PruneExpired(uint(21))
+
+		bus := events.NewBus()
+		finalised := events.Subscribe[events.BlockFinalised](bus)
+		events.Publish(bus, events.BlockFinalised{Info: &types.FinalisationInfo{
+			Header:    types.Header{Number: 20},
+			Retracted: []common.Hash{prunedHash},
+		}})
 		service := &Service{
-			blockState:       mockBlockState,
-			transactionState: mockTxnStateOk,
+			blockState:        mockBlockState,
+			storageState:      mockStorageState,
+			transactionState:  mockTxnState,
+			finalised:         finalised,
+			ctx:               context.Background(),
+			poolBestBlockHash: prunedHash,
 		}
-		execTest(t, service, testPrevHash, testCurrentHash, nil)
+
+		assert.PanicsWithError(t, "failed to maintain txn pool after best block change: "+
+			"creating transaction validator: getting state root from block "+
+			bestHash.String()+": test dummy error",
+			service.handleBlocksAsync)
 	})
 }
 
@@ -1092,6 +1203,38 @@ type args struct {
 	}
 }
 
+func TestService_ListKeys(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	keyStore := keystore.GlobalKeystore{
+		Babe:    keystore.NewBasicKeystore(keystore.BabeName, crypto.Sr25519Type),
+		Imon:    keystore.NewBasicKeystore(keystore.ImonName, crypto.Sr25519Type),
+		Beef:    keystore.NewBasicKeystore(keystore.BeefName, crypto.Secp256k1Type),
+		BeefBls: keystore.NewBasicKeystore(keystore.BeefName, crypto.Bls381Type),
+	}
+	err = keyStore.Babe.Insert(keyring.Alice())
+	require.NoError(t, err)
+
+	service := &Service{keys: &keyStore}
+
+	blsKeypair, err := bls381.GenerateKeypair()
+	require.NoError(t, err)
+	err = service.InsertKey(blsKeypair, string(keystore.BeefName))
+	require.NoError(t, err)
+	has, err := service.HasKey(blsKeypair.Public().Hex(), string(keystore.BeefName))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	expected := map[string][]string{
+		"babe": {keyring.Alice().Public().Hex()},
+		"imon": {},
+		"beef": {blsKeypair.Public().Hex()},
+	}
+	assert.Equal(t, expected, service.ListKeys())
+}
+
 func TestService_DecodeSessionKeys(t *testing.T) {
 	t.Parallel()
 	testEncKeys := []byte{1, 2, 3, 4}
@@ -1191,7 +1334,7 @@ func TestServiceGetRuntimeVersion(t *testing.T) {
 		mockStorageState.EXPECT().TrieState(&common.Hash{}).Return(ts, nil).MaxTimes(2)
 
 		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().GetRuntime(common.Hash{}).Return(nil, errDummyErr)
+		mockBlockState.EXPECT().AcquireRuntime(common.Hash{}).Return(nil, nil, errDummyErr)
 		service := &Service{
 			storageState: mockStorageState,
 			blockState:   mockBlockState,
@@ -1209,7 +1352,9 @@ func TestServiceGetRuntimeVersion(t *testing.T) {
 
 		runtimeMock := NewMockInstance(ctrl)
 		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().GetRuntime(common.Hash{}).Return(runtimeMock, nil)
+		released := false
+		release := func() { released = true }
+		mockBlockState.EXPECT().AcquireRuntime(common.Hash{}).Return(runtimeMock, release, nil)
 		runtimeMock.EXPECT().SetContextStorage(ts)
 		runtimeMock.EXPECT().Version().Return(rv, nil)
 		service := &Service{
@@ -1217,6 +1362,7 @@ func TestServiceGetRuntimeVersion(t *testing.T) {
 			blockState:   mockBlockState,
 		}
 		execTest(t, service, &common.Hash{}, rv, nil, "")
+		assert.True(t, released)
 	})
 }
 
@@ -1243,6 +1389,19 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		execTest(t, service, nil, nil)
 	})
 
+	t.Run("banned", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(nil)
+		mockTxnState.EXPECT().IsBanned(nil).Return(true)
+		service := &Service{
+			transactionState: mockTxnState,
+			net:              NewMockNetwork(ctrl),
+		}
+		execTest(t, service, nil, transaction.ErrTransactionBanned)
+	})
+
 	t.Run("trie_state_err", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -1254,6 +1413,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(nil)
+		mockTxnState.EXPECT().IsBanned(nil)
 		service := &Service{
 			blockState:       mockBlockState,
 			storageState:     mockStorageState,
@@ -1277,6 +1437,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(nil).MaxTimes(2)
+		mockTxnState.EXPECT().IsBanned(nil)
 		service := &Service{
 			storageState:     mockStorageState,
 			transactionState: mockTxnState,
@@ -1301,6 +1462,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().IsBanned(types.Extrinsic{})
 
 		runtimeMockErr.EXPECT().ValidateTransaction(externalExt).Return(nil, errDummyErr)
 		runtimeMockErr.EXPECT().Version().Return(runtime.Version{
@@ -1337,6 +1499,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 
 		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(&transaction.Validity{Propagate: true}, nil)
+		runtimeMock.EXPECT().PaymentQueryInfo(ext).Return(nil, errDummyErr)
 		runtimeMock.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -1350,7 +1513,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 			TransactionVersion: transactionVersion,
 			StateVersion:       stateVersion,
 		}, nil)
-		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{}).Times(2)
 
 		mockStorageState := NewMockStorageState(ctrl)
 		mockStorageState.EXPECT().TrieState(&common.Hash{}).Return(&rtstorage.TrieState{}, nil)
@@ -1358,7 +1521,12 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(types.Extrinsic{}).MaxTimes(2)
-		mockTxnState.EXPECT().AddToPool(transaction.NewValidTransaction(ext, &transaction.Validity{Propagate: true}))
+		mockTxnState.EXPECT().IsBanned(types.Extrinsic{})
+		mockTxnState.EXPECT().Push(gomock.Any()).DoAndReturn(
+			func(vt *transaction.ValidTransaction) (common.Hash, error) {
+				assertValidTransaction(t, transaction.NewValidTransaction(ext, &transaction.Validity{Propagate: true}), vt)
+				return common.Hash{}, nil
+			})
 		mockNetState := NewMockNetwork(ctrl)
 		mockNetState.EXPECT().GossipMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}})
 		service := &Service{
@@ -1414,7 +1582,7 @@ func TestServiceGetMetadata(t *testing.T) {
 		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(nil, errDummyErr)
+		mockBlockState.EXPECT().AcquireRuntime(common.Hash{1}).Return(nil, nil, errDummyErr)
 		service := &Service{
 			storageState: mockStorageState,
 			blockState:   mockBlockState,
@@ -1431,7 +1599,9 @@ func TestServiceGetMetadata(t *testing.T) {
 		runtimeMockOk := NewMockInstance(ctrl)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
-		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(runtimeMockOk, nil)
+		released := false
+		release := func() { released = true }
+		mockBlockState.EXPECT().AcquireRuntime(common.Hash{1}).Return(runtimeMockOk, release, nil)
 		runtimeMockOk.EXPECT().SetContextStorage(&rtstorage.TrieState{})
 		runtimeMockOk.EXPECT().Metadata().Return([]byte{1, 2, 3}, nil)
 		service := &Service{
@@ -1440,6 +1610,7 @@ func TestServiceGetMetadata(t *testing.T) {
 		}
 		const expectedErrMessage = "setting up runtime: getting state root from block hash: dummy error for testing"
 		execTest(t, service, nil, []byte{1, 2, 3}, nil, expectedErrMessage)
+		assert.True(t, released)
 	})
 }
 
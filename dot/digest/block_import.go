@@ -5,21 +5,43 @@
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+// ConsensusDigestHandler handles the consensus digests of imported blocks
+// for the consensus engine it is registered for.
+type ConsensusDigestHandler interface {
+	HandleConsensusDigest(header *types.Header, digest types.ConsensusDigest) error
+}
+
+// BlockImportHandler dispatches the consensus digests of imported blocks
+// to the handlers registered for their consensus engine ID.
 type BlockImportHandler struct {
-	epochState   EpochState
-	grandpaState GrandpaState
+	handlersLock sync.RWMutex
+	handlers     map[types.ConsensusEngineID][]ConsensusDigestHandler
 }
 
+// NewBlockImportHandler returns a new BlockImportHandler with the
+// BABE and GRANDPA consensus digest handlers registered.
 func NewBlockImportHandler(epochState EpochState, grandpaState GrandpaState) *BlockImportHandler {
-	return &BlockImportHandler{
-		epochState:   epochState,
-		grandpaState: grandpaState,
+	h := &BlockImportHandler{
+		handlers: make(map[types.ConsensusEngineID][]ConsensusDigestHandler),
 	}
+	h.RegisterHandler(types.BabeEngineID, &babeDigestHandler{epochState: epochState})
+	h.RegisterHandler(types.GrandpaEngineID, &grandpaDigestHandler{grandpaState: grandpaState})
+	return h
+}
+
+// RegisterHandler registers a handler for the consensus digests of the
+// consensus engine ID given, such as the BEEFY one. Handlers registered
+// for the same engine ID are called in their registration order.
+func (h *BlockImportHandler) RegisterHandler(engineID types.ConsensusEngineID, handler ConsensusDigestHandler) {
+	h.handlersLock.Lock()
+	defer h.handlersLock.Unlock()
+	h.handlers[engineID] = append(h.handlers[engineID], handler)
 }
 
 // HandleDigests handles consensus digests for an imported block
@@ -44,34 +66,67 @@ func (h *BlockImportHandler) HandleDigests(header *types.Header) error {
 	return nil
 }
 
+// handleConsensusDigest dispatches the consensus digest given to the handlers
+// registered for its consensus engine ID. Digests of consensus engines without
+// any registered handler are ignored.
 func (h *BlockImportHandler) handleConsensusDigest(d *types.ConsensusDigest, header *types.Header) error {
-	switch d.ConsensusEngineID {
-	case types.GrandpaEngineID:
-		data := types.NewGrandpaConsensusDigest()
-		err := scale.Unmarshal(d.Data, &data)
-		if err != nil {
-			return fmt.Errorf("unmarshaling grandpa consensus digest: %w", err)
-		}
+	h.handlersLock.RLock()
+	handlers := h.handlers[d.ConsensusEngineID]
+	h.handlersLock.RUnlock()
+
+	if len(handlers) == 0 {
+		logger.Debugf("ignoring consensus digest of block %s: %s",
+			header.Hash(), fmt.Errorf("%w: %s", ErrUnknownConsensusEngineID, d.ConsensusEngineID))
+		return nil
+	}
 
-		err = h.grandpaState.HandleGRANDPADigest(header, data)
+	for _, handler := range handlers {
+		err := handler.HandleConsensusDigest(header, *d)
 		if err != nil {
-			return fmt.Errorf("handling grandpa digest: %w", err)
-		}
-	case types.BabeEngineID:
-		data := types.NewBabeConsensusDigest()
-		err := scale.Unmarshal(d.Data, &data)
-		if err != nil {
-			return fmt.Errorf("unmarshaling babe consensus digest: %w", err)
+			return err
 		}
+	}
 
-		err = h.epochState.HandleBABEDigest(header, data)
-		if err != nil {
-			return fmt.Errorf("handling babe digest: %w", err)
-		}
-	default:
-		return fmt.Errorf("%w: 0x%x", ErrUnknownConsensusEngineID, d.ConsensusEngineID.ToBytes())
+	return nil
+}
+
+// babeDigestHandler handles the BABE consensus digests.
+type babeDigestHandler struct {
+	epochState EpochState
+}
+
+// HandleConsensusDigest handles a BABE consensus digest.
+func (b *babeDigestHandler) HandleConsensusDigest(header *types.Header, d types.ConsensusDigest) error {
+	data := types.NewBabeConsensusDigest()
+	err := scale.Unmarshal(d.Data, &data)
+	if err != nil {
+		return fmt.Errorf("unmarshaling babe consensus digest: %w", err)
 	}
 
+	err = b.epochState.HandleBABEDigest(header, data)
+	if err != nil {
+		return fmt.Errorf("handling babe digest: %w", err)
+	}
+	return nil
+}
+
+// grandpaDigestHandler handles the GRANDPA consensus digests.
+type grandpaDigestHandler struct {
+	grandpaState GrandpaState
+}
+
+// HandleConsensusDigest handles a GRANDPA consensus digest.
+func (g *grandpaDigestHandler) HandleConsensusDigest(header *types.Header, d types.ConsensusDigest) error {
+	data := types.NewGrandpaConsensusDigest()
+	err := scale.Unmarshal(d.Data, &data)
+	if err != nil {
+		return fmt.Errorf("unmarshaling grandpa consensus digest: %w", err)
+	}
+
+	err = g.grandpaState.HandleGRANDPADigest(header, data)
+	if err != nil {
+		return fmt.Errorf("handling grandpa digest: %w", err)
+	}
 	return nil
 }
 
@@ -90,10 +145,7 @@ func toConsensusDigests(scaleVaryingTypes types.Digest) []types.ConsensusDigest
 			continue
 		}
 
-		switch digest.ConsensusEngineID {
-		case types.GrandpaEngineID, types.BabeEngineID:
-			consensusDigests = append(consensusDigests, digest)
-		}
+		consensusDigests = append(consensusDigests, digest)
 	}
 
 	return consensusDigests
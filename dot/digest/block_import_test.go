@@ -288,6 +288,64 @@ func TestBlockImportHandle(t *testing.T) {
 	}
 }
 
+func TestBlockImportHandler_RegisterHandler(t *testing.T) {
+	t.Parallel()
+
+	beefyDigest := types.ConsensusDigest{
+		ConsensusEngineID: types.BeefyEngineID,
+		Data:              []byte{1, 2, 3},
+	}
+	customDigest := types.ConsensusDigest{
+		ConsensusEngineID: types.ConsensusEngineID{'C', 'U', 'S', 'T'},
+		Data:              []byte{4, 5},
+	}
+	unknownDigest := types.ConsensusDigest{
+		ConsensusEngineID: types.ConsensusEngineID{0, 0, 0, 0},
+		Data:              []byte{6},
+	}
+	header := createBlockWithDigests(t, &types.Header{}, beefyDigest, customDigest, unknownDigest)
+
+	mockedError := errors.New("mock error")
+	testCases := map[string]struct {
+		customErr error
+		errString string
+	}{
+		"dispatched_to_registered_handlers": {},
+		"handler_fails": {
+			customErr: mockedError,
+			errString: "consensus digests: mock error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			firstBeefyHandler := NewMockConsensusDigestHandler(ctrl)
+			secondBeefyHandler := NewMockConsensusDigestHandler(ctrl)
+			customHandler := NewMockConsensusDigestHandler(ctrl)
+			gomock.InOrder(
+				firstBeefyHandler.EXPECT().HandleConsensusDigest(header, beefyDigest).Return(nil),
+				secondBeefyHandler.EXPECT().HandleConsensusDigest(header, beefyDigest).Return(nil),
+				customHandler.EXPECT().HandleConsensusDigest(header, customDigest).Return(testCase.customErr),
+			)
+
+			handler := NewBlockImportHandler(nil, nil)
+			handler.RegisterHandler(types.BeefyEngineID, firstBeefyHandler)
+			handler.RegisterHandler(types.BeefyEngineID, secondBeefyHandler)
+			handler.RegisterHandler(customDigest.ConsensusEngineID, customHandler)
+
+			err := handler.HandleDigests(header)
+			require.ErrorIs(t, err, testCase.customErr)
+			if testCase.errString != "" {
+				require.EqualError(t, err, testCase.errString)
+			}
+		})
+	}
+}
+
 func createBABEConsensusDigest(t *testing.T, digestData any) types.ConsensusDigest {
 	t.Helper()
 
@@ -7,7 +7,7 @@
 	"context"
 	"errors"
 
-	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/internal/log"
 )
 
@@ -23,34 +23,34 @@ type Handler struct {
 	cancel context.CancelFunc
 
 	// interfaces
-	blockState   BlockState
 	epochState   EpochState
 	grandpaState GrandpaState
 
-	// block notification channels
-	imported  chan *types.Block
-	finalised chan *types.FinalisationInfo
+	eventBus *events.Bus
+	// finalised notifies the blocks finalised. It is blocking since the
+	// scheduled changes of a missed finalisation would never be applied.
+	finalised *events.Subscription[events.BlockFinalised]
 }
 
 // NewHandler returns a new Handler
-func NewHandler(blockState BlockState, epochState EpochState, grandpaState GrandpaState) (*Handler, error) {
-	imported := blockState.GetImportedBlockNotifierChannel()
-	finalised := blockState.GetFinalisedNotifierChannel()
-
+func NewHandler(epochState EpochState, grandpaState GrandpaState) (*Handler, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Handler{
 		ctx:          ctx,
 		cancel:       cancel,
-		blockState:   blockState,
 		epochState:   epochState,
 		grandpaState: grandpaState,
-		imported:     imported,
-		finalised:    finalised,
 	}, nil
 }
 
+// SetEventBus sets the event bus the blocks finalised are received from, before the handler is started.
+func (h *Handler) SetEventBus(bus *events.Bus) {
+	h.eventBus = bus
+}
+
 // Start starts the Handler
 func (h *Handler) Start() error {
+	h.finalised = events.SubscribeBlocking[events.BlockFinalised](h.eventBus)
 	go h.handleBlockFinalisation(h.ctx)
 	return nil
 }
@@ -58,19 +58,19 @@ func (h *Handler) Start() error {
 // Stop stops the Handler
 func (h *Handler) Stop() error {
 	h.cancel()
-	h.blockState.FreeImportedBlockNotifierChannel(h.imported)
-	h.blockState.FreeFinalisedNotifierChannel(h.finalised)
+	h.finalised.Unsubscribe()
 	return nil
 }
 
 func (h *Handler) handleBlockFinalisation(ctx context.Context) {
 	for {
 		select {
-		case info := <-h.finalised:
-			if info == nil {
-				continue
+		case event, ok := <-h.finalised.Events():
+			if !ok {
+				return
 			}
 
+			info := event.Info
 			err := h.epochState.FinalizeBABENextEpochData(&info.Header)
 			if err != nil {
 				logger.Errorf("failed to persist babe next epoch data: %s", err)
@@ -11,6 +11,7 @@
 
 	"context"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -49,22 +50,26 @@ func newTestHandler(t *testing.T) (*Handler, *BlockImportHandler, *state.Service
 	err = stateSrvc.Start()
 	require.NoError(t, err)
 
-	dh, err := NewHandler(stateSrvc.Block, stateSrvc.Epoch, stateSrvc.Grandpa)
+	bus := events.NewBus()
+	stateSrvc.SetEventBus(bus)
+
+	dh, err := NewHandler(stateSrvc.Epoch, stateSrvc.Grandpa)
 	require.NoError(t, err)
+	dh.SetEventBus(bus)
 
 	blockImportHandler := NewBlockImportHandler(stateSrvc.Epoch, stateSrvc.Grandpa)
 	return dh, blockImportHandler, stateSrvc
 }
 
 func TestHandler_GrandpaScheduledChange(t *testing.T) {
-	handler, blockImportHandler, _ := newTestHandler(t)
+	handler, blockImportHandler, stateSrvc := newTestHandler(t)
 	handler.Start()
 	defer handler.Stop()
 
 	// create 4 blocks and finalize only blocks 0, 1, 2
-	headers, _ := state.AddBlocksToState(t, handler.blockState.(*state.BlockState), 4, false)
+	headers, _ := state.AddBlocksToState(t, stateSrvc.Block, 4, false)
 	for i, h := range headers[:3] {
-		err := handler.blockState.(*state.BlockState).SetFinalisedHash(h.Hash(), uint64(i), 0)
+		err := stateSrvc.Block.SetFinalisedHash(h.Hash(), uint64(i), 0)
 		require.NoError(t, err)
 	}
 
@@ -95,7 +100,7 @@ func TestHandler_GrandpaScheduledChange(t *testing.T) {
 	require.NoError(t, err)
 
 	// finalize block of number 3
-	err = handler.blockState.(*state.BlockState).SetFinalisedHash(headers[3].Hash(), 3, 0)
+	err = stateSrvc.Block.SetFinalisedHash(headers[3].Hash(), 3, 0)
 	require.NoError(t, err)
 
 	time.Sleep(time.Millisecond * 500)
@@ -186,7 +191,6 @@ func TestMultipleGRANDPADigests_ShouldIncludeJustForcedChanges(t *testing.T) {
 				Digest: digests,
 			}
 
-			_, blockImportHandler, _ := newTestHandler(t)
 			ctrl := gomock.NewController(t)
 			grandpaState := NewMockGrandpaState(ctrl)
 
@@ -203,7 +207,7 @@ func TestMultipleGRANDPADigests_ShouldIncludeJustForcedChanges(t *testing.T) {
 				grandpaState.EXPECT().HandleGRANDPADigest(header, expected).Return(nil)
 			}
 
-			blockImportHandler.grandpaState = grandpaState
+			blockImportHandler := NewBlockImportHandler(nil, grandpaState)
 			err := blockImportHandler.HandleDigests(header)
 			require.NoError(t, err)
 		})
@@ -304,17 +308,18 @@ func TestHandler_HandleNextEpochData(t *testing.T) {
 	err = stateSrv.DB().Put(blockHeaderKey, []byte{})
 	require.NoError(t, err)
 
-	handler.finalised = make(chan *types.FinalisationInfo, 1)
+	bus := events.NewBus()
+	handler.finalised = events.SubscribeBlocking[events.BlockFinalised](bus)
 
 	const timeout = time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	handler.finalised <- &types.FinalisationInfo{
+	events.Publish(bus, events.BlockFinalised{Info: &types.FinalisationInfo{
 		Header: *header,
 		Round:  1,
 		SetID:  1,
-	}
+	}})
 
 	handler.handleBlockFinalisation(ctx)
 
@@ -368,17 +373,18 @@ func TestHandler_HandleNextConfigData(t *testing.T) {
 	err = stateSrv.DB().Put(blockHeaderKey, []byte{})
 	require.NoError(t, err)
 
-	handler.finalised = make(chan *types.FinalisationInfo, 1)
+	bus := events.NewBus()
+	handler.finalised = events.SubscribeBlocking[events.BlockFinalised](bus)
 
 	const timeout = time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	handler.finalised <- &types.FinalisationInfo{
+	events.Publish(bus, events.BlockFinalised{Info: &types.FinalisationInfo{
 		Header: *header,
 		Round:  1,
 		SetID:  1,
-	}
+	}})
 
 	handler.handleBlockFinalisation(ctx)
 
@@ -6,3 +6,4 @@
 //go:generate mockgen -destination=mock_telemetry_test.go -package $GOPACKAGE . Telemetry
 //go:generate mockgen -destination=mock_grandpa_test.go -package $GOPACKAGE . GrandpaState
 //go:generate mockgen -destination=mock_epoch_state_test.go -package $GOPACKAGE . EpochState
+//go:generate mockgen -destination=mock_consensus_digest_handler_test.go -package $GOPACKAGE . ConsensusDigestHandler
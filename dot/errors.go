@@ -14,3 +14,7 @@
 var ErrInvalidKeystoreType = errors.New("invalid keystore type")
 
 var ErrWasmInterpreterName = errors.New("unknown wasm interpreter name")
+
+// ErrStateVersionNotSupported is returned when the runtime requires a state trie
+// version later than the maximum state version configured
+var ErrStateVersionNotSupported = errors.New("state version not supported")
@@ -0,0 +1,270 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package events implements the typed event bus the node services use to notify
+// each other of the blocks imported and finalised, the transactions made ready and
+// the peers connected and disconnected, without holding channels to each other.
+package events
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	publishedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_events",
+		Name:      "published_total",
+		Help:      "total number of events published on the event bus",
+	}, []string{"event"})
+	droppedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_events",
+		Name:      "dropped_total",
+		Help:      "total number of events dropped since the buffer of a subscriber was full",
+	}, []string{"event"})
+	subscribersGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_events",
+		Name:      "subscribers",
+		Help:      "number of subscribers of each event",
+	}, []string{"event"})
+)
+
+// subscriptionBufferSize is the number of events buffered for a subscriber,
+// the events published once the buffer is full being dropped for it unless
+// it subscribed with SubscribeBlocking.
+const subscriptionBufferSize = 256
+
+// BlockImported is published by the core service once a block, received from
+// a peer or produced locally, is imported and its runtime changes are handled,
+// and received by the core service to maintain the transaction pool.
+type BlockImported struct {
+	Block *types.Block
+}
+
+func (BlockImported) name() string { return "block_imported" }
+
+// BlockFinalised is published by the block state once a block is finalised,
+// and received by the core service, the chain sync and the digest handler.
+type BlockFinalised struct {
+	Info *types.FinalisationInfo
+}
+
+func (BlockFinalised) name() string { return "block_finalised" }
+
+// TransactionReady is published by the transaction state once transactions,
+// received from peers or submitted locally, are ready to be included in a block,
+// and received by the BABE instant seal.
+type TransactionReady struct{}
+
+func (TransactionReady) name() string { return "transaction_ready" }
+
+// PeerConnected is published by the network service once a connection to
+// a peer is opened.
+type PeerConnected struct {
+	ID peer.ID
+}
+
+func (PeerConnected) name() string { return "peer_connected" }
+
+// PeerDisconnected is published by the network service once the connection to
+// a peer is closed, and received by the chain sync to stop requesting blocks from it.
+type PeerDisconnected struct {
+	ID peer.ID
+}
+
+func (PeerDisconnected) name() string { return "peer_disconnected" }
+
+// Event is the constraint of the events published on the bus.
+type Event interface {
+	BlockImported | BlockFinalised | TransactionReady | PeerConnected | PeerDisconnected
+	name() string
+}
+
+// subscriber receives the events of a subscription, and
+// returns false if the event was dropped.
+type subscriber func(event any) (delivered bool)
+
+// Bus delivers the events published to their subscribers.
+// A nil bus publishes nothing and its subscriptions receive no event.
+type Bus struct {
+	mutex  sync.RWMutex
+	nextID uint64
+	// topics are the subscribers of each event, by subscription id.
+	topics map[string]map[uint64]subscriber
+}
+
+// NewBus creates an event bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{
+		topics: make(map[string]map[uint64]subscriber),
+	}
+}
+
+// Subscription receives the events of a type published on the bus.
+// A nil subscription receives no event.
+type Subscription[E Event] struct {
+	bus      *Bus
+	id       uint64
+	blocking bool
+
+	// mutex is held to send events to the channel, which is closed once
+	// no publisher is sending to it anymore.
+	mutex  sync.RWMutex
+	ch     chan E
+	closed bool
+	// done is closed once unsubscribing, to release the publishers
+	// waiting for a blocking subscription to receive their event.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// Subscribe subscribes to the events of type E published on the bus given.
+// The events are dropped for the subscription while its buffer is full,
+// so the subscriber must keep up with the events or tolerate missing some.
+func Subscribe[E Event](bus *Bus) *Subscription[E] {
+	return subscribe[E](bus, false)
+}
+
+// SubscribeBlocking subscribes to the events of type E published on the bus given,
+// the publishers waiting for the subscription to receive each event once its buffer
+// is full instead of dropping it. It is meant for the subscribers which cannot miss
+// an event, and which must not wait on the publishers of the events they subscribed to.
+func SubscribeBlocking[E Event](bus *Bus) *Subscription[E] {
+	return subscribe[E](bus, true)
+}
+
+func subscribe[E Event](bus *Bus, blocking bool) *Subscription[E] {
+	if bus == nil {
+		return &Subscription[E]{}
+	}
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	subscription := &Subscription[E]{
+		bus:      bus,
+		id:       bus.nextID,
+		blocking: blocking,
+		ch:       make(chan E, subscriptionBufferSize),
+		done:     make(chan struct{}),
+	}
+	bus.nextID++
+
+	var event E
+	topic, ok := bus.topics[event.name()]
+	if !ok {
+		topic = make(map[uint64]subscriber)
+		bus.topics[event.name()] = topic
+	}
+	topic[subscription.id] = func(event any) (delivered bool) {
+		return subscription.deliver(event.(E))
+	}
+	subscribersGauge.WithLabelValues(event.name()).Set(float64(len(topic)))
+	return subscription
+}
+
+// deliver sends the event given to the subscription, waiting for it to be
+// received if the subscription is blocking, and returns false if it was dropped.
+func (s *Subscription[E]) deliver(event E) (delivered bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return false
+	}
+
+	if s.blocking {
+		select {
+		case s.ch <- event:
+			return true
+		case <-s.done:
+			return false
+		}
+	}
+
+	select {
+	case s.ch <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// Events returns the channel the events are received on,
+// which is closed once unsubscribed.
+func (s *Subscription[E]) Events() <-chan E {
+	if s == nil {
+		return nil
+	}
+	return s.ch
+}
+
+// Unsubscribe stops the delivery of the events and closes the events channel.
+// It can be called more than once.
+func (s *Subscription[E]) Unsubscribe() {
+	if s == nil || s.bus == nil {
+		return
+	}
+
+	// release the publishers waiting for the subscription to receive their event
+	s.doneOnce.Do(func() { close(s.done) })
+
+	var event E
+	s.bus.mutex.Lock()
+	topic := s.bus.topics[event.name()]
+	delete(topic, s.id)
+	subscribersGauge.WithLabelValues(event.name()).Set(float64(len(topic)))
+	s.bus.mutex.Unlock()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.ch)
+}
+
+// Publish delivers the event given to the subscribers of its type. It waits for the
+// blocking subscribers to receive it and drops it for the other subscribers whose
+// buffer is full. The bus is not locked while delivering, so the subscribers can
+// publish or unsubscribe meanwhile.
+func Publish[E Event](bus *Bus, event E) {
+	if bus == nil {
+		return
+	}
+
+	name := event.name()
+	bus.mutex.RLock()
+	subscribers := make([]subscriber, 0, len(bus.topics[name]))
+	for _, deliver := range bus.topics[name] {
+		subscribers = append(subscribers, deliver)
+	}
+	bus.mutex.RUnlock()
+
+	publishedCounter.WithLabelValues(name).Inc()
+	for _, deliver := range subscribers {
+		if !deliver(event) {
+			droppedCounter.WithLabelValues(name).Inc()
+		}
+	}
+}
+
+// HasSubscribers returns true if the events of type E have at least one subscriber,
+// for the publishers to skip preparing events nobody receives.
+func HasSubscribers[E Event](bus *Bus) bool {
+	if bus == nil {
+		return false
+	}
+
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	var event E
+	return len(bus.topics[event.name()]) > 0
+}
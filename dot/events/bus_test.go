@@ -0,0 +1,118 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package events
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	assert.False(t, HasSubscribers[PeerDisconnected](bus))
+
+	disconnected := Subscribe[PeerDisconnected](bus)
+	otherDisconnected := Subscribe[PeerDisconnected](bus)
+	finalised := Subscribe[BlockFinalised](bus)
+	connected := Subscribe[PeerConnected](bus)
+	assert.True(t, HasSubscribers[PeerDisconnected](bus))
+
+	Publish(bus, PeerDisconnected{ID: peer.ID("a")})
+	assert.Equal(t, PeerDisconnected{ID: peer.ID("a")}, <-disconnected.Events())
+	assert.Equal(t, PeerDisconnected{ID: peer.ID("a")}, <-otherDisconnected.Events())
+	assert.Empty(t, finalised.Events())
+	assert.Empty(t, connected.Events())
+
+	disconnected.Unsubscribe()
+	disconnected.Unsubscribe()
+	_, ok := <-disconnected.Events()
+	assert.False(t, ok)
+
+	Publish(bus, PeerDisconnected{ID: peer.ID("b")})
+	assert.Equal(t, PeerDisconnected{ID: peer.ID("b")}, <-otherDisconnected.Events())
+
+	otherDisconnected.Unsubscribe()
+	assert.False(t, HasSubscribers[PeerDisconnected](bus))
+}
+
+func TestPublish_fullBuffer(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	subscription := Subscribe[BlockFinalised](bus)
+
+	for i := uint(0); i <= subscriptionBufferSize; i++ {
+		Publish(bus, BlockFinalised{Info: &types.FinalisationInfo{Header: types.Header{Number: i}}})
+	}
+
+	// the events published once the buffer is full are dropped
+	assert.Len(t, subscription.Events(), subscriptionBufferSize)
+	event := <-subscription.Events()
+	assert.Equal(t, uint(0), event.Info.Header.Number)
+}
+
+func TestPublish_blockingSubscription(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	subscription := SubscribeBlocking[BlockFinalised](bus)
+
+	const events = 2 * subscriptionBufferSize
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := uint(0); i < events; i++ {
+			Publish(bus, BlockFinalised{Info: &types.FinalisationInfo{Header: types.Header{Number: i}}})
+		}
+	}()
+
+	// no event is dropped once the buffer is full
+	for i := uint(0); i < events; i++ {
+		event := <-subscription.Events()
+		assert.Equal(t, i, event.Info.Header.Number)
+	}
+	<-published
+}
+
+func TestPublish_blockingSubscriptionUnsubscribed(t *testing.T) {
+	t.Parallel()
+
+	bus := NewBus()
+	subscription := SubscribeBlocking[BlockFinalised](bus)
+
+	for i := uint(0); i < subscriptionBufferSize; i++ {
+		Publish(bus, BlockFinalised{Info: &types.FinalisationInfo{Header: types.Header{Number: i}}})
+	}
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		Publish(bus, BlockFinalised{Info: &types.FinalisationInfo{}})
+	}()
+
+	// unsubscribing releases the publisher waiting for the full buffer
+	subscription.Unsubscribe()
+	<-published
+	assert.False(t, HasSubscribers[BlockFinalised](bus))
+}
+
+func TestBus_nil(t *testing.T) {
+	t.Parallel()
+
+	var bus *Bus
+	subscription := SubscribeBlocking[BlockFinalised](bus)
+	Publish(bus, BlockFinalised{})
+	assert.False(t, HasSubscribers[BlockFinalised](bus))
+	assert.Nil(t, subscription.Events())
+	subscription.Unsubscribe()
+
+	subscription = nil
+	assert.Nil(t, subscription.Events())
+	subscription.Unsubscribe()
+}
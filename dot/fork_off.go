@@ -0,0 +1,157 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// forkOffChainType is the chain type of the chain specs of a forked off chain
+const forkOffChainType = "Local"
+
+// ErrInvalidStoragePrefix is returned when a storage prefix given to fork off a chain is not valid
+var ErrInvalidStoragePrefix = errors.New("invalid storage prefix")
+
+var (
+	// DefaultForkOffReplaced are the storage prefixes taken from the base chain-spec when forking off
+	// a chain: the authorities of the block production and finality, the sudo key and the system
+	// storage of the last blocks, so the fork starts from a fresh genesis authored by local validators.
+	DefaultForkOffReplaced = []string{
+		"Babe",
+		"Grandpa",
+		"Session",
+		"Authorship",
+		"Sudo",
+		"System.Number",
+		"System.ParentHash",
+		"System.BlockHash",
+		"System.Digest",
+		"System.Events",
+		"System.EventCount",
+		"System.LastRuntimeUpgrade",
+	}
+	// DefaultForkOffMerged are the storage prefixes added from the base chain-spec to the state of
+	// the forked off chain, so the development accounts of the base chain-spec have funds.
+	DefaultForkOffMerged = []string{"System.Account"}
+)
+
+// ForkOff replaces the genesis storage of the build spec with the state of a live chain given, as
+// a map of hex encoded keys to hex encoded values, to launch a local fork of the live chain.
+// The entries under the replaced storage prefixes are taken from the build spec instead of the
+// live chain state, and the entries under the merged storage prefixes are taken from both, the
+// build spec entries taking precedence. A storage prefix is either the name of a pallet, the
+// name of a pallet storage item as Pallet.Item, or a hex encoded key prefix.
+// The child tries and the network settings of the build spec are dropped, and so are the
+// child trie roots stored under the default child storage prefix, since the child tries
+// of the live chain are not fetched.
+func (b *BuildSpec) ForkOff(state map[string]string, replaced, merged []string) error {
+	if !b.genesis.IsRaw() || b.genesis.Genesis.Raw == nil {
+		return fmt.Errorf("base chain-spec has no raw genesis")
+	}
+
+	replacedPrefixes, err := storagePrefixes(replaced)
+	if err != nil {
+		return fmt.Errorf("replaced storage: %w", err)
+	}
+	mergedPrefixes, err := storagePrefixes(merged)
+	if err != nil {
+		return fmt.Errorf("merged storage: %w", err)
+	}
+
+	childStoragePrefix := common.BytesToHex(inmemory_trie.ChildStorageKeyPrefix)
+	var childTrieRoots uint
+
+	top := make(map[string]string, len(state))
+	for key, value := range state {
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, childStoragePrefix) {
+			childTrieRoots++
+			continue
+		}
+		if hasAnyPrefix(key, replacedPrefixes) {
+			continue
+		}
+		top[key] = value
+	}
+
+	for key, value := range b.genesis.Genesis.Raw["top"] {
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, childStoragePrefix) {
+			continue
+		}
+		if hasAnyPrefix(key, replacedPrefixes) || hasAnyPrefix(key, mergedPrefixes) {
+			top[key] = value
+		}
+	}
+
+	if childTrieRoots > 0 {
+		logger.Warnf("dropped %d child trie roots from the live chain state, "+
+			"the child storage of the live chain is not forked off", childTrieRoots)
+	}
+
+	b.genesis.ChainType = forkOffChainType
+	b.genesis.Bootnodes = nil
+	b.genesis.ForkBlocks = nil
+	b.genesis.BadBlocks = nil
+	b.genesis.CodeSubstitutes = nil
+	b.genesis.Checkpoint = nil
+	b.genesis.Genesis.Raw = map[string]map[string]string{"top": top}
+	return nil
+}
+
+// storagePrefixes returns the hex encoded key prefixes of the storage prefixes given.
+func storagePrefixes(names []string) (prefixes []string, err error) {
+	prefixes = make([]string, len(names))
+	for i, name := range names {
+		prefixes[i], err = storagePrefix(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return prefixes, nil
+}
+
+// storagePrefix returns the hex encoded key prefix of the pallet, the pallet storage
+// item as Pallet.Item, or the hex encoded key prefix given.
+func storagePrefix(name string) (string, error) {
+	if strings.HasPrefix(name, "0x") {
+		prefix, err := common.HexToBytes(name)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %s", ErrInvalidStoragePrefix, name, err)
+		} else if len(prefix) == 0 {
+			return "", fmt.Errorf("%w: %s", ErrInvalidStoragePrefix, name)
+		}
+		return common.BytesToHex(prefix), nil
+	}
+
+	parts := strings.Split(name, ".")
+	if len(parts) > 2 || slices.Contains(parts, "") {
+		return "", fmt.Errorf("%w: %s", ErrInvalidStoragePrefix, name)
+	}
+
+	var prefix []byte
+	for _, part := range parts {
+		hash, err := common.Twox128Hash([]byte(part))
+		if err != nil {
+			return "", fmt.Errorf("hashing %s: %w", part, err)
+		}
+		prefix = append(prefix, hash...)
+	}
+	return common.BytesToHex(prefix), nil
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
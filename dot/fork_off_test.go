@@ -0,0 +1,127 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_storagePrefix(t *testing.T) {
+	t.Parallel()
+
+	sudo, err := common.Twox128Hash([]byte("Sudo"))
+	require.NoError(t, err)
+	key, err := common.Twox128Hash([]byte("Key"))
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		name       string
+		prefix     string
+		errWrapped error
+		errMessage string
+	}{
+		"pallet": {
+			name:   "Sudo",
+			prefix: common.BytesToHex(sudo),
+		},
+		"storage_item": {
+			name:   "Sudo.Key",
+			prefix: common.BytesToHex(append(sudo, key...)),
+		},
+		"hex_prefix": {
+			name:   "0x3A63",
+			prefix: "0x3a63",
+		},
+		"empty_hex_prefix": {
+			name:       "0x",
+			errWrapped: ErrInvalidStoragePrefix,
+			errMessage: "invalid storage prefix: 0x",
+		},
+		"empty_storage_item": {
+			name:       "Sudo.",
+			errWrapped: ErrInvalidStoragePrefix,
+			errMessage: "invalid storage prefix: Sudo.",
+		},
+		"nested_storage_item": {
+			name:       "Sudo.Key.Value",
+			errWrapped: ErrInvalidStoragePrefix,
+			errMessage: "invalid storage prefix: Sudo.Key.Value",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			prefix, err := storagePrefix(testCase.name)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.prefix, prefix)
+		})
+	}
+}
+
+func TestBuildSpec_ForkOff(t *testing.T) {
+	t.Parallel()
+
+	sudoPrefix, err := storagePrefix("Sudo")
+	require.NoError(t, err)
+	accountPrefix, err := storagePrefix("System.Account")
+	require.NoError(t, err)
+
+	bs := &BuildSpec{genesis: &genesis.Genesis{
+		Name:      "Development",
+		ChainType: "Development",
+		Bootnodes: []string{"/ip4/127.0.0.1/tcp/7001"},
+		Genesis: genesis.Fields{Raw: map[string]map[string]string{
+			"top": {
+				sudoPrefix + "01":    "0xaa",
+				accountPrefix + "01": "0xbb",
+				accountPrefix + "02": "0xcc",
+				"0x3a636f6465":       "0xdd",
+			},
+			"childrenDefault": {},
+		}},
+	}}
+
+	childStorageKey := common.BytesToHex([]byte(string(inmemory_trie.ChildStorageKeyPrefix) + "child"))
+	state := map[string]string{
+		sudoPrefix + "02":    "0x01",
+		accountPrefix + "02": "0x02",
+		accountPrefix + "03": "0x03",
+		"0x3A636F6465":       "0x04",
+		childStorageKey:      "0x05",
+	}
+
+	err = bs.ForkOff(state, []string{"Sudo"}, []string{"System.Account"})
+	require.NoError(t, err)
+
+	expected := map[string]map[string]string{
+		"top": {
+			sudoPrefix + "01":    "0xaa",
+			accountPrefix + "01": "0xbb",
+			accountPrefix + "02": "0xcc",
+			accountPrefix + "03": "0x03",
+			"0x3a636f6465":       "0x04",
+		},
+	}
+	assert.Equal(t, expected, bs.genesis.Genesis.Raw)
+	assert.Equal(t, "Development", bs.genesis.Name)
+	assert.Equal(t, "Local", bs.genesis.ChainType)
+	assert.Nil(t, bs.genesis.Bootnodes)
+
+	err = bs.ForkOff(state, []string{"Sudo.Key.Value"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidStoragePrefix)
+	assert.EqualError(t, err, "replaced storage: invalid storage prefix: Sudo.Key.Value")
+}
@@ -25,9 +25,11 @@ type ServiceRegisterer interface {
 	Get(srvc interface{}) services.Service
 }
 
-// BlockJustificationVerifier has a verification method for block justifications.
+// BlockJustificationVerifier has check and verification methods for block justifications.
 type BlockJustificationVerifier interface {
+	CheckJustification([]byte) error
 	VerifyBlockJustification(common.Hash, []byte) error
+	VerifyUnimportedJustification(hash common.Hash, number uint, justification []byte) error
 }
 
 // Telemetry is the telemetry client to send telemetry messages.
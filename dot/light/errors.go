@@ -0,0 +1,16 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import "errors"
+
+var (
+	// ErrBlocksNotServed is returned when a peer requests blocks from a light client
+	ErrBlocksNotServed = errors.New("light clients do not serve blocks")
+	// ErrNoPeerServedRead is returned when no connected peer answered a remote read with a valid proof
+	ErrNoPeerServedRead = errors.New("no peer served the remote read")
+
+	errNilHeader       = errors.New("block data has no header")
+	errNotChildOfBlock = errors.New("header is not a child of the previous block")
+)
@@ -0,0 +1,288 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "light"))
+
+const (
+	// maxHeadersPerRequest is the maximum number of headers requested at once
+	maxHeadersPerRequest = 128
+	defaultSyncInterval  = 2 * time.Second
+
+	// headerRequestData requests the headers and justifications, without the block bodies
+	headerRequestData = network.RequestedDataHeader + network.RequestedDataJustification
+)
+
+// Config is the configuration of the header sync service
+type Config struct {
+	LogLvl         log.Level
+	BlockState     BlockState
+	FinalityGadget FinalityGadget
+	Network        Network
+	RequestMaker   network.RequestMaker
+	// Interval is the delay between two header requests once the node caught up
+	// with its peers, it defaults to 2 seconds.
+	Interval time.Duration
+}
+
+// HeaderSync syncs the block headers of the chain from full node peers, verifying that they
+// form a chain and verifying their finality justifications. Block bodies are not requested
+// and blocks are not executed.
+type HeaderSync struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	blockState     BlockState
+	finalityGadget FinalityGadget
+	network        Network
+	requestMaker   network.RequestMaker
+	interval       time.Duration
+
+	// peersBest is the best block number announced by each peer
+	peersBest   map[peer.ID]uint
+	peersBestMu sync.RWMutex
+
+	synced atomic.Bool
+}
+
+// NewHeaderSync creates a new header sync service
+func NewHeaderSync(cfg *Config) *HeaderSync {
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultSyncInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HeaderSync{
+		ctx:            ctx,
+		cancel:         cancel,
+		done:           make(chan struct{}),
+		blockState:     cfg.BlockState,
+		finalityGadget: cfg.FinalityGadget,
+		network:        cfg.Network,
+		requestMaker:   cfg.RequestMaker,
+		interval:       interval,
+		peersBest:      make(map[peer.ID]uint),
+	}
+}
+
+// Start starts syncing headers in the background
+func (s *HeaderSync) Start() error {
+	go s.run()
+	return nil
+}
+
+// Stop stops syncing headers
+func (s *HeaderSync) Stop() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+func (s *HeaderSync) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// keep requesting headers without waiting while there are headers to sync
+		for s.ctx.Err() == nil {
+			imported, err := s.syncHeaders()
+			if err != nil {
+				logger.Debugf("syncing headers: %s", err)
+				break
+			}
+			if imported == 0 {
+				break
+			}
+		}
+	}
+}
+
+// syncHeaders requests the headers following our best block from the peer with the highest
+// best block, and returns the number of headers imported.
+func (s *HeaderSync) syncHeaders() (imported int, err error) {
+	best, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		return 0, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	who, target := s.highestPeer()
+	if target <= best.Number {
+		s.synced.Store(true)
+		return 0, nil
+	}
+	s.synced.Store(false)
+
+	start, err := variadic.NewUint32OrHash(uint32(best.Number + 1))
+	if err != nil {
+		return 0, err
+	}
+	count := uint32(min(target-best.Number, maxHeadersPerRequest))
+	request := &network.BlockRequestMessage{
+		RequestedData: headerRequestData,
+		StartingBlock: *start,
+		Direction:     network.Ascending,
+		Max:           &count,
+	}
+
+	response := new(network.BlockResponseMessage)
+	err = s.requestMaker.Do(who, request, response)
+	if err != nil {
+		s.removePeer(who)
+		return 0, fmt.Errorf("requesting headers from peer %s: %w", who, err)
+	}
+
+	imported, err = s.importHeaders(best, response.BlockData)
+	if err != nil {
+		s.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, who)
+		s.removePeer(who)
+		return imported, fmt.Errorf("importing headers from peer %s: %w", who, err)
+	}
+
+	logger.Debugf("imported %d headers from peer %s, target is block number %d", imported, who, target)
+	return imported, nil
+}
+
+// importHeaders adds the headers of the block data to the block state, checking each header is
+// the child of the previous one, starting from the given parent header. The justifications
+// included are verified and the blocks they justify are finalised.
+func (s *HeaderSync) importHeaders(parent *types.Header, blockData []*types.BlockData) (imported int, err error) {
+	for _, bd := range blockData {
+		if bd == nil || bd.Header == nil {
+			return imported, errNilHeader
+		}
+
+		header := bd.Header
+		if header.ParentHash != parent.Hash() || header.Number != parent.Number+1 {
+			return imported, fmt.Errorf("%w: block number %d with parent hash %s",
+				errNotChildOfBlock, header.Number, header.ParentHash)
+		}
+
+		hash := header.Hash()
+		has, err := s.blockState.HasHeader(hash)
+		if err != nil {
+			return imported, fmt.Errorf("checking header is known: %w", err)
+		}
+
+		if !has {
+			err = s.blockState.AddBlock(&types.Block{Header: *header, Body: types.Body{}})
+			if err != nil {
+				return imported, fmt.Errorf("adding header number %d: %w", header.Number, err)
+			}
+		}
+
+		if bd.Justification != nil && len(*bd.Justification) > 0 {
+			err = s.finalityGadget.VerifyBlockJustification(hash, *bd.Justification)
+			if err != nil {
+				return imported, fmt.Errorf("verifying block number %d justification: %w", header.Number, err)
+			}
+
+			err = s.blockState.SetJustification(hash, *bd.Justification)
+			if err != nil {
+				return imported, fmt.Errorf("setting block number %d justification: %w", header.Number, err)
+			}
+		}
+
+		parent = header
+		imported++
+	}
+
+	return imported, nil
+}
+
+func (s *HeaderSync) highestPeer() (who peer.ID, number uint) {
+	s.peersBestMu.RLock()
+	defer s.peersBestMu.RUnlock()
+
+	for id, best := range s.peersBest {
+		if best > number {
+			who, number = id, best
+		}
+	}
+	return who, number
+}
+
+func (s *HeaderSync) setPeerBest(who peer.ID, number uint) {
+	s.peersBestMu.Lock()
+	defer s.peersBestMu.Unlock()
+
+	if number > s.peersBest[who] {
+		s.peersBest[who] = number
+	}
+}
+
+func (s *HeaderSync) removePeer(who peer.ID) {
+	s.peersBestMu.Lock()
+	defer s.peersBestMu.Unlock()
+	delete(s.peersBest, who)
+}
+
+// HandleBlockAnnounceHandshake records the best block number of the peer
+func (s *HeaderSync) HandleBlockAnnounceHandshake(from peer.ID, msg *network.BlockAnnounceHandshake) error {
+	s.setPeerBest(from, uint(msg.BestBlockNumber))
+	return nil
+}
+
+// HandleBlockAnnounce records the number of the block announced by the peer,
+// its header is requested with the next headers to sync.
+func (s *HeaderSync) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnounceMessage) error {
+	s.setPeerBest(from, msg.Number)
+	return nil
+}
+
+// HighestBlock returns the highest block number announced by the peers
+func (s *HeaderSync) HighestBlock() uint {
+	_, number := s.highestPeer()
+	return number
+}
+
+// IsSynced returns true if our best block is the highest best block announced by our peers
+func (s *HeaderSync) IsSynced() bool {
+	return s.synced.Load()
+}
+
+// CreateBlockResponse returns an error since light clients do not store block bodies
+func (*HeaderSync) CreateBlockResponse(*network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
+	return nil, ErrBlocksNotServed
+}
+
+// HandleTransactionMessage ignores the transactions gossiped by peers,
+// since light clients have no transaction pool.
+func (*HeaderSync) HandleTransactionMessage(peer.ID, *network.TransactionMessage) (bool, error) {
+	return false, nil
+}
+
+// TransactionsCount returns 0 since light clients have no transaction pool
+func (*HeaderSync) TransactionsCount() int {
+	return 0
+}
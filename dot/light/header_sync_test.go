@@ -0,0 +1,194 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestChain(length int) (headers []*types.Header) {
+	parent := &types.Header{Digest: types.NewDigest()}
+	headers = append(headers, parent)
+	for i := 1; i < length; i++ {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     parent.Number + 1,
+			Digest:     types.NewDigest(),
+		}
+		headers = append(headers, header)
+		parent = header
+	}
+	return headers
+}
+
+func Test_HeaderSync_importHeaders(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(3)
+	justification := []byte{1, 2}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockStateBuilder     func(ctrl *gomock.Controller) BlockState
+		finalityGadgetBuilder func(ctrl *gomock.Controller) FinalityGadget
+		blockData             []*types.BlockData
+		imported              int
+		errWrapped            error
+		errMessage            string
+	}{
+		"nil_header": {
+			blockStateBuilder:     func(ctrl *gomock.Controller) BlockState { return nil },
+			finalityGadgetBuilder: func(ctrl *gomock.Controller) FinalityGadget { return nil },
+			blockData:             []*types.BlockData{{Hash: chain[1].Hash()}},
+			errWrapped:            errNilHeader,
+			errMessage:            "block data has no header",
+		},
+		"not_a_chain": {
+			blockStateBuilder:     func(ctrl *gomock.Controller) BlockState { return nil },
+			finalityGadgetBuilder: func(ctrl *gomock.Controller) FinalityGadget { return nil },
+			blockData:             []*types.BlockData{{Header: chain[2]}},
+			errWrapped:            errNotChildOfBlock,
+			errMessage: "header is not a child of the previous block: block number 2 with parent hash " +
+				chain[1].Hash().String(),
+		},
+		"headers_imported": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().HasHeader(chain[1].Hash()).Return(false, nil)
+				blockState.EXPECT().AddBlock(&types.Block{Header: *chain[1], Body: types.Body{}}).Return(nil)
+				blockState.EXPECT().HasHeader(chain[2].Hash()).Return(true, nil)
+				blockState.EXPECT().SetJustification(chain[2].Hash(), justification).Return(nil)
+				return blockState
+			},
+			finalityGadgetBuilder: func(ctrl *gomock.Controller) FinalityGadget {
+				finalityGadget := NewMockFinalityGadget(ctrl)
+				finalityGadget.EXPECT().VerifyBlockJustification(chain[2].Hash(), justification).Return(nil)
+				return finalityGadget
+			},
+			blockData: []*types.BlockData{
+				{Header: chain[1]},
+				{Header: chain[2], Justification: &justification},
+			},
+			imported: 2,
+		},
+		"invalid_justification": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().HasHeader(chain[1].Hash()).Return(false, nil)
+				blockState.EXPECT().AddBlock(&types.Block{Header: *chain[1], Body: types.Body{}}).Return(nil)
+				return blockState
+			},
+			finalityGadgetBuilder: func(ctrl *gomock.Controller) FinalityGadget {
+				finalityGadget := NewMockFinalityGadget(ctrl)
+				finalityGadget.EXPECT().VerifyBlockJustification(chain[1].Hash(), justification).Return(errTest)
+				return finalityGadget
+			},
+			blockData:  []*types.BlockData{{Header: chain[1], Justification: &justification}},
+			errWrapped: errTest,
+			errMessage: "verifying block number 1 justification: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			s := &HeaderSync{
+				blockState:     testCase.blockStateBuilder(ctrl),
+				finalityGadget: testCase.finalityGadgetBuilder(ctrl),
+			}
+
+			imported, err := s.importHeaders(chain[0], testCase.blockData)
+			assert.Equal(t, testCase.imported, imported)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_HeaderSync_syncHeaders(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(2)
+	who := peer.ID("peer")
+
+	t.Run("synced", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().BestBlockHeader().Return(chain[1], nil)
+
+		s := NewHeaderSync(&Config{BlockState: blockState})
+		err := s.HandleBlockAnnounceHandshake(who, &network.BlockAnnounceHandshake{BestBlockNumber: 1})
+		require.NoError(t, err)
+
+		imported, err := s.syncHeaders()
+		require.NoError(t, err)
+		assert.Zero(t, imported)
+		assert.True(t, s.IsSynced())
+	})
+
+	t.Run("invalid_response", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().BestBlockHeader().Return(chain[0], nil)
+
+		start, err := variadic.NewUint32OrHash(uint32(1))
+		require.NoError(t, err)
+		count := uint32(1)
+		requestMaker := NewMockRequestMaker(ctrl)
+		requestMaker.EXPECT().Do(who, &network.BlockRequestMessage{
+			RequestedData: headerRequestData,
+			StartingBlock: *start,
+			Direction:     network.Ascending,
+			Max:           &count,
+		}, gomock.AssignableToTypeOf(&network.BlockResponseMessage{})).
+			DoAndReturn(func(_ peer.ID, _ network.Message, res network.ResponseMessage) error {
+				response := res.(*network.BlockResponseMessage)
+				response.BlockData = []*types.BlockData{{Hash: common.Hash{1}}}
+				return nil
+			})
+
+		net := NewMockNetwork(ctrl)
+		net.EXPECT().ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, who)
+
+		s := NewHeaderSync(&Config{
+			BlockState:   blockState,
+			Network:      net,
+			RequestMaker: requestMaker,
+		})
+		err = s.HandleBlockAnnounce(who, &network.BlockAnnounceMessage{Number: 1})
+		require.NoError(t, err)
+
+		imported, err := s.syncHeaders()
+		assert.ErrorIs(t, err, errNilHeader)
+		assert.Zero(t, imported)
+		assert.False(t, s.IsSynced())
+
+		// the peer is no longer used to sync
+		_, number := s.highestPeer()
+		assert.Zero(t, number)
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// BlockState is the interface for the block state
+type BlockState interface {
+	BestBlockHeader() (*types.Header, error)
+	GetHeader(hash common.Hash) (*types.Header, error)
+	HasHeader(hash common.Hash) (bool, error)
+	AddBlock(block *types.Block) error
+	SetJustification(hash common.Hash, data []byte) error
+}
+
+// FinalityGadget verifies the finality justifications of blocks
+type FinalityGadget interface {
+	VerifyBlockJustification(hash common.Hash, justification []byte) error
+}
+
+// Network is the interface for the network service
+type Network interface {
+	AllConnectedPeersIDs() []peer.ID
+	ReportPeer(change peerset.ReputationChange, p peer.ID)
+	RemoteRead(to peer.ID, block common.Hash, keys [][]byte) (proof [][]byte, err error)
+}
@@ -0,0 +1,7 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+//go:generate mockgen -destination=mocks_test.go -package=$GOPACKAGE . BlockState,FinalityGadget,Network
+//go:generate mockgen -destination=mock_request_maker_test.go -package=$GOPACKAGE github.com/ChainSafe/gossamer/dot/network RequestMaker
@@ -0,0 +1,59 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/proof"
+)
+
+// RemoteStorage reads the storage of blocks from full node peers. The storage proofs
+// returned by peers are verified against the state root of the synced block headers.
+// It does not implement modules.ChainHeadCallAPI: runtime calls would need the execution
+// proofs of remote call requests, which are neither served nor requested by gossamer nodes.
+type RemoteStorage struct {
+	blockState BlockState
+	network    Network
+}
+
+// NewRemoteStorage creates a new remote storage reader
+func NewRemoteStorage(blockState BlockState, net Network) *RemoteStorage {
+	return &RemoteStorage{
+		blockState: blockState,
+		network:    net,
+	}
+}
+
+// Storage returns the values of the keys at the given block, trying each connected
+// peer until one of them returns a valid proof.
+func (r *RemoteStorage) Storage(block common.Hash, keys [][]byte) (values [][]byte, err error) {
+	header, err := r.blockState.GetHeader(block)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	for _, who := range r.network.AllConnectedPeersIDs() {
+		encodedProof, err := r.network.RemoteRead(who, block, keys)
+		if err != nil {
+			logger.Debugf("remote read from peer %s failed: %s", who, err)
+			continue
+		}
+
+		values, err = proof.Values(encodedProof, header.StateRoot, keys)
+		if err != nil {
+			logger.Debugf("invalid remote read proof from peer %s: %s", who, err)
+			r.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, who)
+			continue
+		}
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("%w: for block %s", ErrNoPeerServedRead, block)
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_RemoteStorage_Storage(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory.NewEmptyTrie()
+	tr.Put([]byte("key"), []byte("value"))
+	tr.Put([]byte("other"), []byte("other value"))
+	stateRoot, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("key")}
+	validProof, err := proof.Generate(stateRoot.ToBytes(), keys, db)
+	require.NoError(t, err)
+
+	otherTrie := inmemory.NewEmptyTrie()
+	otherTrie.Put([]byte("key"), []byte("forged value"))
+	otherRoot, err := trie.V0.Hash(otherTrie)
+	require.NoError(t, err)
+	err = otherTrie.WriteDirty(db)
+	require.NoError(t, err)
+	forgedProof, err := proof.Generate(otherRoot.ToBytes(), keys, db)
+	require.NoError(t, err)
+
+	header := &types.Header{StateRoot: stateRoot, Digest: types.NewDigest()}
+	block := header.Hash()
+	peerA, peerB, peerC := peer.ID("a"), peer.ID("b"), peer.ID("c")
+
+	ctrl := gomock.NewController(t)
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(block).Return(header, nil)
+
+	net := NewMockNetwork(ctrl)
+	net.EXPECT().AllConnectedPeersIDs().Return([]peer.ID{peerA, peerB, peerC})
+	net.EXPECT().RemoteRead(peerA, block, keys).Return(nil, errors.New("timeout"))
+	// the proof of peer b is for another state root
+	net.EXPECT().RemoteRead(peerB, block, keys).Return(forgedProof, nil)
+	net.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.BadMessageValue,
+		Reason: peerset.BadMessageReason,
+	}, peerB)
+	net.EXPECT().RemoteRead(peerC, block, keys).Return(validProof, nil)
+
+	remoteStorage := NewRemoteStorage(blockState, net)
+	values, err := remoteStorage.Storage(block, keys)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value")}, values)
+}
+
+func Test_RemoteStorage_Storage_noPeer(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Digest: types.NewDigest()}
+	block := header.Hash()
+
+	ctrl := gomock.NewController(t)
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(block).Return(header, nil)
+	net := NewMockNetwork(ctrl)
+	net.EXPECT().AllConnectedPeersIDs().Return(nil)
+
+	remoteStorage := NewRemoteStorage(blockState, net)
+	_, err := remoteStorage.Storage(block, [][]byte{{1}})
+	assert.ErrorIs(t, err, ErrNoPeerServedRead)
+}
@@ -28,6 +28,313 @@
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockstateBuilder is a mock of stateBuilder interface.
+type MockstateBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockstateBuilderMockRecorder
+}
+
+// MockstateBuilderMockRecorder is the mock recorder for MockstateBuilder.
+type MockstateBuilderMockRecorder struct {
+	mock *MockstateBuilder
+}
+
+// NewMockstateBuilder creates a new mock instance.
+func NewMockstateBuilder(ctrl *gomock.Controller) *MockstateBuilder {
+	mock := &MockstateBuilder{ctrl: ctrl}
+	mock.recorder = &MockstateBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstateBuilder) EXPECT() *MockstateBuilderMockRecorder {
+	return m.recorder
+}
+
+// createRuntimeStorage mocks base method.
+func (m *MockstateBuilder) createRuntimeStorage(st *state.Service) (*runtime.NodeStorage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createRuntimeStorage", st)
+	ret0, _ := ret[0].(*runtime.NodeStorage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createRuntimeStorage indicates an expected call of createRuntimeStorage.
+func (mr *MockstateBuilderMockRecorder) createRuntimeStorage(st any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createRuntimeStorage", reflect.TypeOf((*MockstateBuilder)(nil).createRuntimeStorage), st)
+}
+
+// createStateService mocks base method.
+func (m *MockstateBuilder) createStateService(config *config.Config) (*state.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createStateService", config)
+	ret0, _ := ret[0].(*state.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createStateService indicates an expected call of createStateService.
+func (mr *MockstateBuilderMockRecorder) createStateService(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createStateService", reflect.TypeOf((*MockstateBuilder)(nil).createStateService), config)
+}
+
+// initNode mocks base method.
+func (m *MockstateBuilder) initNode(config *config.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initNode", config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// initNode indicates an expected call of initNode.
+func (mr *MockstateBuilderMockRecorder) initNode(config any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initNode", reflect.TypeOf((*MockstateBuilder)(nil).initNode), config)
+}
+
+// loadRuntime mocks base method.
+func (m *MockstateBuilder) loadRuntime(config *config.Config, ns *runtime.NodeStorage, stateSrvc *state.Service, ks *keystore.GlobalKeystore, net *network.Service) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "loadRuntime", config, ns, stateSrvc, ks, net)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// loadRuntime indicates an expected call of loadRuntime.
+func (mr *MockstateBuilderMockRecorder) loadRuntime(config, ns, stateSrvc, ks, net any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "loadRuntime", reflect.TypeOf((*MockstateBuilder)(nil).loadRuntime), config, ns, stateSrvc, ks, net)
+}
+
+// MocknetworkBuilder is a mock of networkBuilder interface.
+type MocknetworkBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MocknetworkBuilderMockRecorder
+}
+
+// MocknetworkBuilderMockRecorder is the mock recorder for MocknetworkBuilder.
+type MocknetworkBuilderMockRecorder struct {
+	mock *MocknetworkBuilder
+}
+
+// NewMocknetworkBuilder creates a new mock instance.
+func NewMocknetworkBuilder(ctrl *gomock.Controller) *MocknetworkBuilder {
+	mock := &MocknetworkBuilder{ctrl: ctrl}
+	mock.recorder = &MocknetworkBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknetworkBuilder) EXPECT() *MocknetworkBuilderMockRecorder {
+	return m.recorder
+}
+
+// createNetworkService mocks base method.
+func (m *MocknetworkBuilder) createNetworkService(config *config.Config, stateSrvc *state.Service, telemetryMailer Telemetry) (*network.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createNetworkService", config, stateSrvc, telemetryMailer)
+	ret0, _ := ret[0].(*network.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createNetworkService indicates an expected call of createNetworkService.
+func (mr *MocknetworkBuilderMockRecorder) createNetworkService(config, stateSrvc, telemetryMailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createNetworkService", reflect.TypeOf((*MocknetworkBuilder)(nil).createNetworkService), config, stateSrvc, telemetryMailer)
+}
+
+// MockconsensusBuilder is a mock of consensusBuilder interface.
+type MockconsensusBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockconsensusBuilderMockRecorder
+}
+
+// MockconsensusBuilderMockRecorder is the mock recorder for MockconsensusBuilder.
+type MockconsensusBuilderMockRecorder struct {
+	mock *MockconsensusBuilder
+}
+
+// NewMockconsensusBuilder creates a new mock instance.
+func NewMockconsensusBuilder(ctrl *gomock.Controller) *MockconsensusBuilder {
+	mock := &MockconsensusBuilder{ctrl: ctrl}
+	mock.recorder = &MockconsensusBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockconsensusBuilder) EXPECT() *MockconsensusBuilderMockRecorder {
+	return m.recorder
+}
+
+// createBABEService mocks base method.
+func (m *MockconsensusBuilder) createBABEService(config *config.Config, st *state.Service, ks KeyStore, cs *core.Service, telemetryMailer Telemetry) (*babe.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createBABEService", config, st, ks, cs, telemetryMailer)
+	ret0, _ := ret[0].(*babe.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createBABEService indicates an expected call of createBABEService.
+func (mr *MockconsensusBuilderMockRecorder) createBABEService(config, st, ks, cs, telemetryMailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createBABEService", reflect.TypeOf((*MockconsensusBuilder)(nil).createBABEService), config, st, ks, cs, telemetryMailer)
+}
+
+// createBlockVerifier mocks base method.
+func (m *MockconsensusBuilder) createBlockVerifier(st *state.Service) *babe.VerificationManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createBlockVerifier", st)
+	ret0, _ := ret[0].(*babe.VerificationManager)
+	return ret0
+}
+
+// createBlockVerifier indicates an expected call of createBlockVerifier.
+func (mr *MockconsensusBuilderMockRecorder) createBlockVerifier(st any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createBlockVerifier", reflect.TypeOf((*MockconsensusBuilder)(nil).createBlockVerifier), st)
+}
+
+// createDigestHandler mocks base method.
+func (m *MockconsensusBuilder) createDigestHandler(st *state.Service) (*digest.Handler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createDigestHandler", st)
+	ret0, _ := ret[0].(*digest.Handler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createDigestHandler indicates an expected call of createDigestHandler.
+func (mr *MockconsensusBuilderMockRecorder) createDigestHandler(st any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createDigestHandler", reflect.TypeOf((*MockconsensusBuilder)(nil).createDigestHandler), st)
+}
+
+// createGRANDPAService mocks base method.
+func (m *MockconsensusBuilder) createGRANDPAService(config *config.Config, st *state.Service, ks KeyStore, net *network.Service, telemetryMailer Telemetry) (*grandpa.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createGRANDPAService", config, st, ks, net, telemetryMailer)
+	ret0, _ := ret[0].(*grandpa.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createGRANDPAService indicates an expected call of createGRANDPAService.
+func (mr *MockconsensusBuilderMockRecorder) createGRANDPAService(config, st, ks, net, telemetryMailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createGRANDPAService", reflect.TypeOf((*MockconsensusBuilder)(nil).createGRANDPAService), config, st, ks, net, telemetryMailer)
+}
+
+// MocksyncBuilder is a mock of syncBuilder interface.
+type MocksyncBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MocksyncBuilderMockRecorder
+}
+
+// MocksyncBuilderMockRecorder is the mock recorder for MocksyncBuilder.
+type MocksyncBuilderMockRecorder struct {
+	mock *MocksyncBuilder
+}
+
+// NewMocksyncBuilder creates a new mock instance.
+func NewMocksyncBuilder(ctrl *gomock.Controller) *MocksyncBuilder {
+	mock := &MocksyncBuilder{ctrl: ctrl}
+	mock.recorder = &MocksyncBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksyncBuilder) EXPECT() *MocksyncBuilderMockRecorder {
+	return m.recorder
+}
+
+// createCoreService mocks base method.
+func (m *MocksyncBuilder) createCoreService(config *config.Config, ks *keystore.GlobalKeystore, st *state.Service, net *network.Service) (*core.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createCoreService", config, ks, st, net)
+	ret0, _ := ret[0].(*core.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createCoreService indicates an expected call of createCoreService.
+func (mr *MocksyncBuilderMockRecorder) createCoreService(config, ks, st, net any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createCoreService", reflect.TypeOf((*MocksyncBuilder)(nil).createCoreService), config, ks, st, net)
+}
+
+// newSyncService mocks base method.
+func (m *MocksyncBuilder) newSyncService(config *config.Config, st *state.Service, finalityGadget BlockJustificationVerifier, verifier *babe.VerificationManager, cs *core.Service, net *network.Service, telemetryMailer Telemetry) (*sync.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "newSyncService", config, st, finalityGadget, verifier, cs, net, telemetryMailer)
+	ret0, _ := ret[0].(*sync.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// newSyncService indicates an expected call of newSyncService.
+func (mr *MocksyncBuilderMockRecorder) newSyncService(config, st, finalityGadget, verifier, cs, net, telemetryMailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "newSyncService", reflect.TypeOf((*MocksyncBuilder)(nil).newSyncService), config, st, finalityGadget, verifier, cs, net, telemetryMailer)
+}
+
+// MockrpcBuilder is a mock of rpcBuilder interface.
+type MockrpcBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockrpcBuilderMockRecorder
+}
+
+// MockrpcBuilderMockRecorder is the mock recorder for MockrpcBuilder.
+type MockrpcBuilderMockRecorder struct {
+	mock *MockrpcBuilder
+}
+
+// NewMockrpcBuilder creates a new mock instance.
+func NewMockrpcBuilder(ctrl *gomock.Controller) *MockrpcBuilder {
+	mock := &MockrpcBuilder{ctrl: ctrl}
+	mock.recorder = &MockrpcBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrpcBuilder) EXPECT() *MockrpcBuilderMockRecorder {
+	return m.recorder
+}
+
+// createRPCService mocks base method.
+func (m *MockrpcBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createRPCService", params)
+	ret0, _ := ret[0].(*rpc.HTTPServer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createRPCService indicates an expected call of createRPCService.
+func (mr *MockrpcBuilderMockRecorder) createRPCService(params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createRPCService", reflect.TypeOf((*MockrpcBuilder)(nil).createRPCService), params)
+}
+
+// createSystemService mocks base method.
+func (m *MockrpcBuilder) createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*system.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createSystemService", cfg, stateSrvc)
+	ret0, _ := ret[0].(*system.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createSystemService indicates an expected call of createSystemService.
+func (mr *MockrpcBuilderMockRecorder) createSystemService(cfg, stateSrvc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createSystemService", reflect.TypeOf((*MockrpcBuilder)(nil).createSystemService), cfg, stateSrvc)
+}
+
 // MocknodeBuilderIface is a mock of nodeBuilderIface interface.
 type MocknodeBuilderIface struct {
 	ctrl     *gomock.Controller
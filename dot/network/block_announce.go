@@ -57,9 +57,9 @@ func (bm *BlockAnnounceMessage) Encode() ([]byte, error) {
 
 // Decode the message into a BlockAnnounceMessage
 func (bm *BlockAnnounceMessage) Decode(in []byte) error {
-	err := scale.Unmarshal(in, bm)
+	err := UnmarshalMessage(in, bm)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %s", ErrMalformedHeader, err)
 	}
 	return nil
 }
@@ -77,7 +77,7 @@ func (bm *BlockAnnounceMessage) Hash() (common.Hash, error) {
 
 func decodeBlockAnnounceHandshake(in []byte) (Handshake, error) {
 	hs := BlockAnnounceHandshake{}
-	err := scale.Unmarshal(in, &hs)
+	err := UnmarshalMessage(in, &hs)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +121,7 @@ func (hs *BlockAnnounceHandshake) Encode() ([]byte, error) {
 
 // Decode the message into a BlockAnnounceHandshake
 func (hs *BlockAnnounceHandshake) Decode(in []byte) error {
-	err := scale.Unmarshal(in, hs)
+	err := UnmarshalMessage(in, hs)
 	if err != nil {
 		return err
 	}
@@ -172,6 +172,8 @@ func (s *Service) validateBlockAnnounceHandshake(from peer.ID, hs Handshake) err
 		return errors.New("genesis hash mismatch")
 	}
 
+	s.blockAnnouncer.markKnown(from, bhs.BestBlockHash)
+
 	np, ok := s.notificationsProtocols[blockAnnounceMsgType]
 	if !ok {
 		// this should never happen.
@@ -200,6 +202,30 @@ func (s *Service) validateBlockAnnounceHandshake(from peer.ID, hs Handshake) err
 	return s.syncer.HandleBlockAnnounceHandshake(from, bhs)
 }
 
+// sendBlockAnnounces sends the block announces given to the peer given, in order.
+func (s *Service) sendBlockAnnounces(to peer.ID, messages []*BlockAnnounceMessage) {
+	s.notificationsMu.RLock()
+	info, has := s.notificationsProtocols[blockAnnounceMsgType]
+	s.notificationsMu.RUnlock()
+	if !has || info == nil {
+		logger.Errorf("block announce notifications protocol is not registered")
+		return
+	}
+
+	hs, err := info.getHandshake()
+	if err != nil {
+		logger.Errorf("failed to get handshake using protocol %s: %s", info.protocolID, err)
+		return
+	}
+
+	info.peersData.setMutex(to)
+	go func() {
+		for _, message := range messages {
+			s.sendData(to, hs, info, message)
+		}
+	}()
+}
+
 // handleBlockAnnounceMessage handles BlockAnnounce messages
 // if some more blocks are required to sync the announced block, the node will open a sync stream
 // with its peer and send a BlockRequest message
@@ -209,6 +235,8 @@ func (s *Service) handleBlockAnnounceMessage(from peer.ID, msg NotificationsMess
 		return false, errors.New("invalid message")
 	}
 
+	s.blockAnnouncer.markKnown(from, blockAnnounceHash(bam))
+
 	err = s.syncer.HandleBlockAnnounce(from, bam)
 	if errors.Is(err, blocktree.ErrBlockExists) {
 		return true, nil
@@ -0,0 +1,214 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// blockAnnounceBatchWindow is the duration the outgoing block announces are batched for
+	// before being sent, so blocks authored or imported in quick succession are announced together.
+	blockAnnounceBatchWindow = 50 * time.Millisecond
+	// maxKnownBlocks is the number of block hashes remembered as known to a peer.
+	maxKnownBlocks = 1024
+)
+
+// knownBlocks is a bounded set of the hashes of the blocks known to a peer,
+// the oldest hashes being forgotten first once the set is full.
+type knownBlocks struct {
+	hashes map[common.Hash]struct{}
+	// order is a ring buffer of the hashes in the order they were added.
+	order []common.Hash
+	next  int
+}
+
+func newKnownBlocks() *knownBlocks {
+	return &knownBlocks{
+		hashes: make(map[common.Hash]struct{}),
+	}
+}
+
+func (k *knownBlocks) has(hash common.Hash) bool {
+	_, has := k.hashes[hash]
+	return has
+}
+
+func (k *knownBlocks) add(hash common.Hash) {
+	if k.has(hash) {
+		return
+	}
+
+	k.hashes[hash] = struct{}{}
+	if len(k.order) < maxKnownBlocks {
+		k.order = append(k.order, hash)
+		return
+	}
+
+	delete(k.hashes, k.order[k.next])
+	k.order[k.next] = hash
+	k.next = (k.next + 1) % maxKnownBlocks
+}
+
+// blockAnnounce is a block announce waiting to be sent, with the hash of the block announced.
+type blockAnnounce struct {
+	hash    common.Hash
+	message *BlockAnnounceMessage
+}
+
+// blockAnnouncer batches the outgoing block announces and sends them to each
+// peer without the blocks the peer is already known to have.
+type blockAnnouncer struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending []blockAnnounce
+	timer   *time.Timer
+	stopped bool
+	// known are the blocks known to each peer, either
+	// announced by the peer or announced to the peer.
+	known map[peer.ID]*knownBlocks
+
+	// peers returns the peers the announces are sent to.
+	peers func() []peer.ID
+	// send sends the block announces given to the peer given, in order.
+	send func(to peer.ID, messages []*BlockAnnounceMessage)
+}
+
+func newBlockAnnouncer(window time.Duration, peers func() []peer.ID,
+	send func(to peer.ID, messages []*BlockAnnounceMessage)) *blockAnnouncer {
+	return &blockAnnouncer{
+		window: window,
+		known:  make(map[peer.ID]*knownBlocks),
+		peers:  peers,
+		send:   send,
+	}
+}
+
+// announce adds the block announce given to the current batch, which is
+// sent once the batch window started by its first announce is over.
+func (a *blockAnnouncer) announce(message *BlockAnnounceMessage) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.stopped {
+		return
+	}
+
+	a.pending = append(a.pending, blockAnnounce{
+		hash:    blockAnnounceHash(message),
+		message: message,
+	})
+	if a.timer == nil {
+		a.timer = time.AfterFunc(a.window, a.flush)
+	}
+}
+
+// markKnown records the block given as known to the peer given.
+func (a *blockAnnouncer) markKnown(who peer.ID, hash common.Hash) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	known, has := a.known[who]
+	if !has {
+		known = newKnownBlocks()
+		a.known[who] = known
+	}
+	known.add(hash)
+}
+
+// flush sends the batched block announces to each peer, skipping
+// the announces of the blocks the peer is known to have.
+func (a *blockAnnouncer) flush() {
+	a.mu.Lock()
+	announces := coalesceBlockAnnounces(a.pending)
+	a.pending = nil
+	a.timer = nil
+	if a.stopped || len(announces) == 0 {
+		a.mu.Unlock()
+		return
+	}
+
+	peers := a.peers()
+	connected := make(map[peer.ID]struct{}, len(peers))
+	toSend := make(map[peer.ID][]*BlockAnnounceMessage, len(peers))
+	for _, who := range peers {
+		connected[who] = struct{}{}
+
+		known, has := a.known[who]
+		if !has {
+			known = newKnownBlocks()
+			a.known[who] = known
+		}
+
+		for _, announce := range announces {
+			if known.has(announce.hash) {
+				continue
+			}
+			known.add(announce.hash)
+			toSend[who] = append(toSend[who], announce.message)
+		}
+	}
+
+	// forget the blocks known to the disconnected peers
+	for who := range a.known {
+		if _, isConnected := connected[who]; !isConnected {
+			delete(a.known, who)
+		}
+	}
+	a.mu.Unlock()
+
+	for who, messages := range toSend {
+		a.send(who, messages)
+	}
+}
+
+// stop stops the batching, dropping the block announces not sent yet.
+func (a *blockAnnouncer) stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.stopped = true
+	a.pending = nil
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+}
+
+// coalesceBlockAnnounces returns the block announces given without the announces
+// of the same block made before, and without the announces of the blocks which are
+// the parent of another block announced, since announcing a block is enough for
+// a peer to sync its ancestors. The announces are kept in their original order.
+func coalesceBlockAnnounces(announces []blockAnnounce) []blockAnnounce {
+	latest := make(map[common.Hash]int, len(announces))
+	parents := make(map[common.Hash]struct{}, len(announces))
+	for i, announce := range announces {
+		latest[announce.hash] = i
+		parents[announce.message.ParentHash] = struct{}{}
+	}
+
+	coalesced := make([]blockAnnounce, 0, len(latest))
+	for i, announce := range announces {
+		if latest[announce.hash] != i {
+			continue
+		}
+		if _, isParent := parents[announce.hash]; isParent {
+			continue
+		}
+		coalesced = append(coalesced, announce)
+	}
+	return coalesced
+}
+
+// blockAnnounceHash returns the hash of the block announced.
+func blockAnnounceHash(message *BlockAnnounceMessage) common.Hash {
+	header := types.NewHeader(message.ParentHash, message.StateRoot,
+		message.ExtrinsicsRoot, message.Number, message.Digest)
+	return header.Hash()
+}
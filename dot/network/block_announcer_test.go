@@ -0,0 +1,172 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlockAnnounce(parentHash common.Hash, number uint) *BlockAnnounceMessage {
+	return &BlockAnnounceMessage{
+		ParentHash: parentHash,
+		Number:     number,
+		Digest:     types.NewDigest(),
+		BestBlock:  true,
+	}
+}
+
+func Test_coalesceBlockAnnounces(t *testing.T) {
+	t.Parallel()
+
+	block1 := newTestBlockAnnounce(common.Hash{1}, 1)
+	block2 := newTestBlockAnnounce(blockAnnounceHash(block1), 2)
+	fork2 := newTestBlockAnnounce(blockAnnounceHash(block1), 2)
+	fork2.StateRoot = common.Hash{2}
+	block2NotBest := *block2
+	block2NotBest.BestBlock = false
+
+	newAnnounces := func(messages ...*BlockAnnounceMessage) []blockAnnounce {
+		announces := make([]blockAnnounce, len(messages))
+		for i, message := range messages {
+			announces[i] = blockAnnounce{hash: blockAnnounceHash(message), message: message}
+		}
+		return announces
+	}
+
+	testCases := map[string]struct {
+		announces []blockAnnounce
+		expected  []blockAnnounce
+	}{
+		"single_announce": {
+			announces: newAnnounces(block1),
+			expected:  newAnnounces(block1),
+		},
+		"parent_dropped": {
+			announces: newAnnounces(block1, block2),
+			expected:  newAnnounces(block2),
+		},
+		"forks_kept": {
+			announces: newAnnounces(block1, block2, fork2),
+			expected:  newAnnounces(block2, fork2),
+		},
+		"latest_announce_of_same_block_kept": {
+			announces: newAnnounces(block2, fork2, &block2NotBest),
+			expected:  newAnnounces(fork2, &block2NotBest),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			coalesced := coalesceBlockAnnounces(testCase.announces)
+			assert.Equal(t, testCase.expected, coalesced)
+		})
+	}
+}
+
+func Test_blockAnnouncer_flush(t *testing.T) {
+	t.Parallel()
+
+	peerA, peerB := peer.ID("a"), peer.ID("b")
+	sent := make(map[peer.ID][]*BlockAnnounceMessage)
+	var sentMu sync.Mutex
+	send := func(to peer.ID, messages []*BlockAnnounceMessage) {
+		sentMu.Lock()
+		defer sentMu.Unlock()
+		sent[to] = append(sent[to], messages...)
+	}
+	peers := []peer.ID{peerA, peerB}
+	getPeers := func() []peer.ID { return peers }
+
+	// a long window so the batches are only sent when flushed by the test
+	announcer := newBlockAnnouncer(time.Hour, getPeers, send)
+	defer announcer.stop()
+
+	block1 := newTestBlockAnnounce(common.Hash{1}, 1)
+	block2 := newTestBlockAnnounce(blockAnnounceHash(block1), 2)
+	block3 := newTestBlockAnnounce(blockAnnounceHash(block2), 3)
+
+	// peer b announced the block 2 to us
+	announcer.markKnown(peerB, blockAnnounceHash(block2))
+
+	announcer.announce(block1)
+	announcer.announce(block2)
+	announcer.flush()
+
+	expected := map[peer.ID][]*BlockAnnounceMessage{
+		peerA: {block2},
+	}
+	assert.Equal(t, expected, sent)
+
+	// the block 2 is not announced twice
+	announcer.announce(block2)
+	announcer.announce(block3)
+	announcer.flush()
+
+	expected = map[peer.ID][]*BlockAnnounceMessage{
+		peerA: {block2, block3},
+		peerB: {block3},
+	}
+	assert.Equal(t, expected, sent)
+
+	// the blocks known to the disconnected peers are forgotten
+	peers = []peer.ID{peerA}
+	announcer.announce(block3)
+	announcer.flush()
+	assert.NotContains(t, announcer.known, peerB)
+}
+
+func Test_blockAnnouncer_announce(t *testing.T) {
+	t.Parallel()
+
+	sent := make(chan []*BlockAnnounceMessage, 1)
+	send := func(_ peer.ID, messages []*BlockAnnounceMessage) {
+		sent <- messages
+	}
+	getPeers := func() []peer.ID { return []peer.ID{"a"} }
+
+	announcer := newBlockAnnouncer(10*time.Millisecond, getPeers, send)
+	defer announcer.stop()
+
+	block1 := newTestBlockAnnounce(common.Hash{1}, 1)
+	fork1 := newTestBlockAnnounce(common.Hash{2}, 1)
+	announcer.announce(block1)
+	announcer.announce(fork1)
+
+	select {
+	case messages := <-sent:
+		assert.Equal(t, []*BlockAnnounceMessage{block1, fork1}, messages)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for the block announces to be sent")
+	}
+}
+
+func Test_knownBlocks_add(t *testing.T) {
+	t.Parallel()
+
+	hash := func(i int) common.Hash {
+		return common.Hash{byte(i), byte(i >> 8)}
+	}
+
+	known := newKnownBlocks()
+	for i := 0; i <= maxKnownBlocks; i++ {
+		known.add(hash(i))
+	}
+
+	// the oldest hash is forgotten first
+	assert.False(t, known.has(hash(0)))
+	assert.True(t, known.has(hash(1)))
+	assert.True(t, known.has(hash(maxKnownBlocks)))
+	assert.Len(t, known.hashes, maxKnownBlocks)
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// maxSameBlockRequests is the number of times a peer can send the same block request
+	// within blockRequestsWindow, the peer being punished for the following ones.
+	maxSameBlockRequests = 2
+	// smallBlockRequestMax is the maximum number of blocks below which a block request is small.
+	smallBlockRequestMax = 8
+	// maxSmallBlockRequests is the number of small block requests a peer can send within
+	// blockRequestsWindow, the peer being punished for the following ones.
+	maxSmallBlockRequests = 64
+	// blockRequestsWindow is the duration the block requests of a peer are remembered.
+	blockRequestsWindow = 30 * time.Second
+)
+
+// peerBlockRequests are the block requests received recently from a peer.
+type peerBlockRequests struct {
+	// same are the times the requests were received, by encoded request.
+	same map[string][]time.Time
+	// small are the times the small requests were received.
+	small []time.Time
+}
+
+// blockRequestLimiter tracks the inbound block requests of the peers, to punish the peers
+// sending the same request over and over, or flooding the node with small requests.
+type blockRequestLimiter struct {
+	mu    sync.Mutex
+	now   func() time.Time
+	peers map[peer.ID]*peerBlockRequests
+	// lastPrune is the last time the requests older than the window were forgotten.
+	lastPrune time.Time
+}
+
+func newBlockRequestLimiter() *blockRequestLimiter {
+	return &blockRequestLimiter{
+		now:   time.Now,
+		peers: make(map[peer.ID]*peerBlockRequests),
+	}
+}
+
+// check records the block request received from the peer given, and returns the reputation
+// change of the peer and false if the request is abusive and should not be served.
+func (l *blockRequestLimiter) check(who peer.ID, req *BlockRequestMessage) (
+	change peerset.ReputationChange, ok bool, err error) {
+	encoded, err := req.Encode()
+	if err != nil {
+		return change, false, fmt.Errorf("encoding block request: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	windowStart := now.Add(-blockRequestsWindow)
+	if now.Sub(l.lastPrune) > blockRequestsWindow {
+		l.prune(windowStart)
+		l.lastPrune = now
+	}
+
+	requests, has := l.peers[who]
+	if !has {
+		requests = &peerBlockRequests{same: make(map[string][]time.Time)}
+		l.peers[who] = requests
+	}
+
+	key := string(encoded)
+	same := append(recentTimes(requests.same[key], windowStart), now)
+	requests.same[key] = same
+	if len(same) > maxSameBlockRequests {
+		return peerset.ReputationChange{
+			Value:  peerset.SameBlockRequestValue,
+			Reason: peerset.SameBlockRequestReason,
+		}, false, nil
+	}
+
+	if req.Max != nil && *req.Max < smallBlockRequestMax {
+		requests.small = append(recentTimes(requests.small, windowStart), now)
+		if len(requests.small) > maxSmallBlockRequests {
+			return peerset.ReputationChange{
+				Value:  peerset.BlockRequestFloodValue,
+				Reason: peerset.BlockRequestFloodReason,
+			}, false, nil
+		}
+	}
+
+	return change, true, nil
+}
+
+// prune forgets the requests received before the window start given.
+func (l *blockRequestLimiter) prune(windowStart time.Time) {
+	for who, requests := range l.peers {
+		for key, times := range requests.same {
+			times = recentTimes(times, windowStart)
+			if len(times) == 0 {
+				delete(requests.same, key)
+				continue
+			}
+			requests.same[key] = times
+		}
+
+		requests.small = recentTimes(requests.small, windowStart)
+		if len(requests.same) == 0 && len(requests.small) == 0 {
+			delete(l.peers, who)
+		}
+	}
+}
+
+// recentTimes returns the times given, in increasing order, which are after the window start.
+func recentTimes(times []time.Time, windowStart time.Time) []time.Time {
+	for i, t := range times {
+		if t.After(windowStart) {
+			return times[i:]
+		}
+	}
+	return times[:0]
+}
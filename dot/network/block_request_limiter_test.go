@@ -0,0 +1,167 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockRequestMessage_validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		request  *BlockRequestMessage
+		errorMsg string
+	}{
+		"valid_by_number": {
+			request: &BlockRequestMessage{
+				RequestedData: BootstrapRequestData,
+				StartingBlock: *variadic.MustNewUint32OrHash(uint32(1)),
+				Direction:     Ascending,
+			},
+		},
+		"valid_by_hash": {
+			request: &BlockRequestMessage{
+				RequestedData: RequestedDataHeader,
+				StartingBlock: *variadic.MustNewUint32OrHash(common.Hash{1}),
+				Direction:     Descending,
+			},
+		},
+		"valid_with_indexed_body": {
+			request: &BlockRequestMessage{
+				RequestedData: RequestedDataHeader | RequestedDataBody | RequestedDataIndexedBody,
+				StartingBlock: *variadic.MustNewUint32OrHash(uint32(1)),
+				Direction:     Ascending,
+			},
+		},
+		"unknown_direction": {
+			request: &BlockRequestMessage{
+				StartingBlock: *variadic.MustNewUint32OrHash(uint32(1)),
+				Direction:     SyncDirection(2),
+			},
+			errorMsg: "invalid block request: unknown direction 2",
+		},
+		"unknown_requested_data": {
+			request: &BlockRequestMessage{
+				RequestedData: RequestedDataHeader | 64,
+				StartingBlock: *variadic.MustNewUint32OrHash(uint32(1)),
+				Direction:     Ascending,
+			},
+			errorMsg: "invalid block request: unknown requested data 01000001",
+		},
+		"no_starting_block": {
+			request: &BlockRequestMessage{
+				Direction: Ascending,
+			},
+			errorMsg: "invalid block request: invalid starting block",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.request.validate()
+			if testCase.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidBlockRequest)
+			assert.EqualError(t, err, testCase.errorMsg)
+		})
+	}
+}
+
+func Test_blockRequestLimiter_check_sameRequest(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	limiter := newBlockRequestLimiter()
+	limiter.now = func() time.Time { return now }
+
+	request := &BlockRequestMessage{
+		RequestedData: BootstrapRequestData,
+		StartingBlock: *variadic.MustNewUint32OrHash(uint32(1)),
+		Direction:     Ascending,
+	}
+	who := peer.ID("peer")
+
+	for i := 0; i < maxSameBlockRequests; i++ {
+		_, ok, err := limiter.check(who, request)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	// another peer can send the same request
+	_, ok, err := limiter.check(peer.ID("other"), request)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	change, ok, err := limiter.check(who, request)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	expectedChange := peerset.ReputationChange{
+		Value:  peerset.SameBlockRequestValue,
+		Reason: peerset.SameBlockRequestReason,
+	}
+	assert.Equal(t, expectedChange, change)
+
+	// the requests are forgotten once out of the window
+	now = now.Add(blockRequestsWindow + time.Second)
+	_, ok, err = limiter.check(who, request)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NotContains(t, limiter.peers, peer.ID("other"))
+}
+
+func Test_blockRequestLimiter_check_smallRequests(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	limiter := newBlockRequestLimiter()
+	limiter.now = func() time.Time { return now }
+
+	who := peer.ID("peer")
+	max := uint32(1)
+	newRequest := func(number uint32) *BlockRequestMessage {
+		return &BlockRequestMessage{
+			RequestedData: BootstrapRequestData,
+			StartingBlock: *variadic.MustNewUint32OrHash(number),
+			Direction:     Ascending,
+			Max:           &max,
+		}
+	}
+
+	for i := uint32(0); i < maxSmallBlockRequests; i++ {
+		_, ok, err := limiter.check(who, newRequest(i))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	change, ok, err := limiter.check(who, newRequest(maxSmallBlockRequests))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	expectedChange := peerset.ReputationChange{
+		Value:  peerset.BlockRequestFloodValue,
+		Reason: peerset.BlockRequestFloodReason,
+	}
+	assert.Equal(t, expectedChange, change)
+
+	// requests for more blocks are not limited
+	largeMax := uint32(MaxBlocksInResponse)
+	request := newRequest(0)
+	request.Max = &largeMax
+	_, ok, err = limiter.check(who, request)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
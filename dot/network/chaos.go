@@ -0,0 +1,42 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// InjectFaults sets the faults injected in the requests made by the service, replacing
+// the faults previously set, and nil stops injecting faults. The faults are only
+// injected in binaries built with the chaos build tag.
+func (s *Service) InjectFaults(faults *chaos.Faults) {
+	s.host.faults.Store(faults)
+}
+
+// injectRequestFaults disconnects from the peer given, and returns an error
+// wrapping chaos.ErrFaultInjected, if the peer is to be dropped.
+func (h *host) injectRequestFaults(to peer.ID) error {
+	if !h.faults.Load().DropsPeer(to) {
+		return nil
+	}
+
+	err := h.closePeer(to)
+	if err != nil {
+		logger.Debugf("failed to close connection with peer %s: %s", to, err)
+	}
+	return fmt.Errorf("%w: dropped peer %s", chaos.ErrFaultInjected, to)
+}
+
+// injectResponseFaults returns an error wrapping chaos.ErrFaultInjected
+// if the response received from the peer given is to be dropped.
+func (h *host) injectResponseFaults(from peer.ID) error {
+	if !h.faults.Load().DropsResponse(from) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: dropped response from peer %s", chaos.ErrFaultInjected, from)
+}
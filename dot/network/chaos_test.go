@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_host_injectResponseFaults(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		faults     *chaos.Faults
+		errWrapped error
+		errMessage string
+	}{
+		"no_faults": {},
+		"response_not_dropped": {
+			faults: &chaos.Faults{DropResponse: chaos.Peers("peerB")},
+		},
+		"response_dropped": {
+			faults:     &chaos.Faults{DropResponse: chaos.Always},
+			errWrapped: chaos.ErrFaultInjected,
+			errMessage: "fault injected: dropped response from peer DgUrnn4",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &host{}
+			h.faults.Store(testCase.faults)
+
+			err := h.injectResponseFaults("peerA")
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -4,10 +4,9 @@
 package network
 
 import (
-	"crypto/ed25519"
 	"errors"
 	"fmt"
-	"path"
+	"path/filepath"
 	"time"
 
 	"github.com/adrg/xdg"
@@ -85,11 +84,15 @@ type Config struct {
 	NoBootstrap bool
 	// NoMDNS disables MDNS discovery
 	NoMDNS bool
-	// ListenAddress is the multiaddress to listen on
-	ListenAddress string
+	// ListenAddresses are the multiaddresses to listen on, which default to the
+	// IPv4 and IPv6 unspecified addresses with the Port to listen on both stacks
+	ListenAddresses []string
 
 	MinPeers int
 	MaxPeers int
+	// ValidatorPeers is the number of peer slots reserved to the validator peers,
+	// in addition to the incoming and outgoing slots.
+	ValidatorPeers uint32
 
 	DiscoveryInterval time.Duration
 
@@ -98,6 +101,9 @@ type Config struct {
 
 	// NodeKey is the private hex encoded Ed25519 key to build the p2p identity
 	NodeKey string
+	// NodeKeyFile is the file the Ed25519 key of the p2p identity is read from, or
+	// generated and written to if it does not exist. Defaults to node.key in the BasePath.
+	NodeKeyFile string
 
 	// privateKey the private key for the network p2p identity
 	privateKey crypto.PrivKey
@@ -170,23 +176,23 @@ func (c *Config) checkState() (err error) {
 // using the random seed (if random seed is not set, creates new random key)
 func (c *Config) buildIdentity() error {
 	if c.NodeKey != "" {
-		privateKeySeed, err := common.HexToBytes("0x" + c.NodeKey)
+		privateKey, err := ParseNodeKey(c.NodeKey)
 		if err != nil {
-			return fmt.Errorf("parsing hex encoding of ed25519 private key: %w", err)
-		}
-		key := ed25519.NewKeyFromSeed(privateKeySeed)
-		privateKey, err := crypto.UnmarshalEd25519PrivateKey(key)
-		if err != nil {
-			return fmt.Errorf("decoding ed25519 bytes: %w", err)
+			return fmt.Errorf("parsing node key: %w", err)
 		}
 		c.privateKey = privateKey
 		return nil
 	}
 
+	keyFile := c.NodeKeyFile
+	if keyFile == "" {
+		keyFile = filepath.Join(c.BasePath, DefaultKeyFile)
+	}
+
 	if c.RandSeed == 0 {
 
 		// attempt to load existing key
-		key, err := loadKey(c.BasePath)
+		key, err := LoadNodeKey(keyFile)
 		if err != nil {
 			return err
 		}
@@ -195,10 +201,10 @@ func (c *Config) buildIdentity() error {
 		if key == nil {
 			c.logger.Infof(
 				"Generating p2p identity with seed %d and key file %s",
-				c.RandSeed, path.Join(c.BasePath, DefaultKeyFile))
+				c.RandSeed, keyFile)
 
 			// generate key
-			key, err = generateKey(c.RandSeed, c.BasePath)
+			key, err = generateKey(c.RandSeed, keyFile)
 			if err != nil {
 				return err
 			}
@@ -209,10 +215,10 @@ func (c *Config) buildIdentity() error {
 	} else {
 		c.logger.Infof(
 			"Generating p2p identity with seed %d and key file %s",
-			c.RandSeed, path.Join(c.BasePath, DefaultKeyFile))
+			c.RandSeed, keyFile)
 
 		// generate temporary deterministic key
-		key, err := generateKey(c.RandSeed, c.BasePath)
+		key, err := generateKey(c.RandSeed, keyFile)
 		if err != nil {
 			return err
 		}
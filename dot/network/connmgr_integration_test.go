@@ -102,7 +102,7 @@ func TestProtectUnprotectPeer(t *testing.T) {
 		slotAllocationTime = time.Second * 2
 	)
 
-	peerCfgSet := peerset.NewConfigSet(uint32(max-min), uint32(max), false, slotAllocationTime)
+	peerCfgSet := peerset.NewConfigSet(uint32(max-min), uint32(max), 0, false, slotAllocationTime)
 	cm, err := newConnManager(max, peerCfgSet)
 	require.NoError(t, err)
 
@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+const (
+	// maxMessageDecodeDepth is the maximum nesting depth of the values
+	// decoded from the SCALE encoded messages received from peers.
+	maxMessageDecodeDepth = 32
+	// messageAllocationPerByte is the number of bytes the decoding of each byte of a
+	// SCALE encoded message received from a peer can allocate. Each element of a
+	// collection is encoded in at least a byte, so the length prefixes of a malformed
+	// message cannot make its decoding allocate more than this bound.
+	messageAllocationPerByte = 64
+)
+
+// UnmarshalMessage decodes the SCALE encoded message received from a peer into dst,
+// bounding the nesting depth of the values decoded and the memory allocated to the
+// length of the message. A panic decoding the message is returned as an error wrapping
+// ErrMessageDecodePanic, so malformed peer input cannot crash the node.
+func UnmarshalMessage(in []byte, dst any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrMessageDecodePanic, r)
+		}
+	}()
+
+	maxAllocation := uint(len(in)) * messageAllocationPerByte
+	if maxAllocation == 0 {
+		// still bound the allocations of an empty message
+		maxAllocation = messageAllocationPerByte
+	}
+
+	decoder := scale.NewDecoder(bytes.NewReader(in),
+		scale.WithMaxDepth(maxMessageDecodeDepth),
+		scale.WithMaxAllocation(maxAllocation))
+	return decoder.Decode(dst)
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"fmt"
+	"testing"
+
+	pb "github.com/ChainSafe/gossamer/dot/network/proto"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnmarshalMessage(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in          []byte
+		dst         any
+		expectedDst any
+		errWrapped  error
+	}{
+		"extrinsics": {
+			in:          []byte{8, 4, 1, 0},
+			dst:         &[]types.Extrinsic{},
+			expectedDst: &[]types.Extrinsic{{1}, nil},
+		},
+		"bytes_length_prefix_exceeding_allocation": {
+			// compact encoded length of 2^30 bytes
+			in:          []byte{0x03, 0x00, 0x00, 0x00, 0x40},
+			dst:         &[]byte{},
+			expectedDst: &[]byte{},
+			errWrapped:  scale.ErrMaxAllocationExceeded,
+		},
+		"extrinsics_length_prefix_exceeding_allocation": {
+			// compact encoded length of 2^16 extrinsics
+			in:          []byte{0x02, 0x00, 0x04, 0x00},
+			dst:         &[]types.Extrinsic{},
+			expectedDst: &[]types.Extrinsic{},
+			errWrapped:  scale.ErrMaxAllocationExceeded,
+		},
+		"digest_length_prefix_exceeding_allocation": {
+			// compact encoded length of 2^16 digest items
+			in:          []byte{0x02, 0x00, 0x04, 0x00},
+			dst:         &types.Digest{},
+			expectedDst: &types.Digest{},
+			errWrapped:  scale.ErrMaxAllocationExceeded,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := UnmarshalMessage(testCase.in, testCase.dst)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.expectedDst, testCase.dst)
+		})
+	}
+}
+
+func Test_protobufToBlockData_malformedHeader(t *testing.T) {
+	t.Parallel()
+
+	blockData, err := protobufToBlockData(&pb.BlockData{
+		Hash:   []byte{1},
+		Header: []byte{1, 2, 3},
+	})
+
+	assert.ErrorIs(t, err, ErrMalformedHeader)
+	assert.Nil(t, blockData)
+}
+
+func Test_decodeErrorReputation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err        error
+		reputation peerset.ReputationChange
+	}{
+		"malformed_header": {
+			err: fmt.Errorf("%w: %s", ErrMalformedHeader, ErrMessageDecodePanic),
+			reputation: peerset.ReputationChange{
+				Value:  peerset.BadBlockAnnouncementValue,
+				Reason: peerset.BadBlockAnnouncementReason,
+			},
+		},
+		"decode_panic": {
+			err: ErrMessageDecodePanic,
+			reputation: peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reputation := decodeErrorReputation(testCase.err)
+
+			assert.Equal(t, testCase.reputation, reputation)
+		})
+	}
+}
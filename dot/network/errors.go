@@ -24,4 +24,9 @@
 	ErrInvalidLEB128EncodedData      = errors.New("invalid LEB128 encoded data")
 	ErrGreaterThanMaxSize            = errors.New("greater than maximum size")
 	ErrStreamReset                   = errors.New("stream reset")
+	ErrNoReadProofProvider           = errors.New("remote reads are not served by this node")
+	ErrInvalidBlockHash              = errors.New("invalid block hash")
+	ErrMessageDecodePanic            = errors.New("panic decoding message")
+	ErrMalformedHeader               = errors.New("malformed header")
+	ErrInvalidBlockRequest           = errors.New("invalid block request")
 )
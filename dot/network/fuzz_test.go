@@ -0,0 +1,128 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/require"
+)
+
+func Fuzz_BlockRequestMessage_Decode(f *testing.F) {
+	max := uint32(128)
+	request := &BlockRequestMessage{
+		RequestedData: BootstrapRequestData,
+		StartingBlock: *variadic.MustNewUint32OrHash(uint32(1)),
+		Direction:     Ascending,
+		Max:           &max,
+	}
+	encoded, err := request.Encode()
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_ = new(BlockRequestMessage).Decode(in)
+	})
+}
+
+func Fuzz_BlockResponseMessage_Decode(f *testing.F) {
+	header := types.NewEmptyHeader()
+	header.Number = 1
+	justification := []byte{1, 2}
+	response := &BlockResponseMessage{
+		BlockData: []*types.BlockData{{
+			Hash:          header.Hash(),
+			Header:        header,
+			Body:          types.NewBody([]types.Extrinsic{{1, 2, 3}}),
+			Justification: &justification,
+		}},
+	}
+	encoded, err := response.Encode()
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_ = new(BlockResponseMessage).Decode(in)
+	})
+}
+
+func Fuzz_decodeBlockAnnounceMessage(f *testing.F) {
+	announce := &BlockAnnounceMessage{
+		Number:    1,
+		Digest:    types.NewDigest(),
+		BestBlock: true,
+	}
+	encoded, err := announce.Encode()
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_, _ = decodeBlockAnnounceMessage(in)
+	})
+}
+
+func Fuzz_decodeBlockAnnounceHandshake(f *testing.F) {
+	handshake := &BlockAnnounceHandshake{
+		Roles:           common.FullNodeRole,
+		BestBlockNumber: 1,
+	}
+	encoded, err := handshake.Encode()
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_, _ = decodeBlockAnnounceHandshake(in)
+	})
+}
+
+func Fuzz_decodeTransactionMessage(f *testing.F) {
+	message := &TransactionMessage{Extrinsics: []types.Extrinsic{{1, 2}, {3}}}
+	encoded, err := message.Encode()
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_, _ = decodeTransactionMessage(in)
+	})
+}
+
+func Fuzz_UnmarshalMessage_Header(f *testing.F) {
+	digest := types.NewDigest()
+	err := digest.Add(
+		*types.NewBABEPreRuntimeDigest([]byte{1, 2, 3}),
+		types.ConsensusDigest{ConsensusEngineID: types.GrandpaEngineID, Data: []byte{4}},
+		types.SealDigest{ConsensusEngineID: types.BabeEngineID, Data: []byte{5, 6}},
+	)
+	require.NoError(f, err)
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 4, digest)
+	encoded, err := scale.Marshal(*header)
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_ = UnmarshalMessage(in, types.NewEmptyHeader())
+	})
+}
+
+func Fuzz_UnmarshalMessage_Digest(f *testing.F) {
+	digest := types.NewDigest()
+	err := digest.Add(
+		*types.NewBABEPreRuntimeDigest([]byte{1, 2, 3}),
+		types.SealDigest{ConsensusEngineID: types.BabeEngineID, Data: []byte{4, 5}},
+		types.RuntimeEnvironmentUpdated{},
+	)
+	require.NoError(f, err)
+	encoded, err := scale.Marshal(digest)
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		decoded := types.NewDigest()
+		_ = UnmarshalMessage(in, &decoded)
+	})
+}
@@ -12,9 +12,12 @@
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
+	"github.com/ChainSafe/gossamer/internal/chaos"
 	"github.com/ChainSafe/gossamer/internal/pubip"
 	"github.com/dgraph-io/ristretto"
 	badger "github.com/ipfs/go-ds-badger2"
@@ -39,6 +42,8 @@ func newPrivateIPFilters() (privateIPs *ma.Filters, err error) {
 		"198.18.0.0/15",
 		"192.168.0.0/16",
 		"169.254.0.0/16",
+		"fc00::/7",
+		"fe80::/10",
 	}
 	privateIPs = ma.NewFilters()
 	for _, cidr := range privateCIDRs {
@@ -82,28 +87,18 @@ type host struct {
 	bwc             *metrics.BandwidthCounter
 	closeSync       sync.Once
 	externalAddr    ma.Multiaddr
+	// faults are the faults injected in the requests, in chaos builds only.
+	faults atomic.Pointer[chaos.Faults]
+	// supervisor records the panics recovered from the stream handlers.
+	supervisor *supervisor.Supervisor
 }
 
 func newHost(ctx context.Context, cfg *Config) (*host, error) {
-	// create multiaddress (without p2p identity)
-	listenAddress := fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", cfg.Port)
-	if cfg.ListenAddress != "" {
-		listenAddress = cfg.ListenAddress
-	}
-	addr, err := ma.NewMultiaddr(listenAddress)
+	listenAddrs, err := parseListenAddresses(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	portString, err := addr.ValueForProtocol(ma.P_TCP)
-	if err != nil {
-		return nil, err
-	}
-
-	port, err := strconv.ParseUint(portString, 10, 64)
-	if err != nil {
-		return nil, err
-	}
 	var externalAddr ma.Multiaddr
 
 	switch {
@@ -113,12 +108,16 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 			return nil, fmt.Errorf("invalid public ip: %s", cfg.PublicIP)
 		}
 		logger.Debugf("using config PublicIP: %s", ip)
-		externalAddr, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, port))
+		externalAddr, err = ipMultiaddr(ip, listenAddrs)
 		if err != nil {
 			return nil, err
 		}
 	case strings.TrimSpace(cfg.PublicDNS) != "":
 		logger.Debugf("using config PublicDNS: %s", cfg.PublicDNS)
+		port, err := listenPort(listenAddrs, ma.P_IP4)
+		if err != nil {
+			return nil, err
+		}
 		externalAddr, err = ma.NewMultiaddr(fmt.Sprintf("/dns/%s/tcp/%d", cfg.PublicDNS, port))
 		if err != nil {
 			return nil, err
@@ -129,7 +128,7 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 			logger.Errorf("failed to get public IP error: %v", err)
 		} else {
 			logger.Debugf("got public IP address %s", ip)
-			externalAddr, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, port))
+			externalAddr, err = ipMultiaddr(ip, listenAddrs)
 			if err != nil {
 				return nil, err
 			}
@@ -159,6 +158,8 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		uint32(cfg.MaxPeers-cfg.MinPeers),
 		// maxOutPeers is later used in peerstate only and defines available Outgoing connection slots
 		uint32(cfg.MaxPeers/2),
+		// maxValidatorPeers defines the slots reserved to the validator peers
+		cfg.ValidatorPeers,
 		reservedOnly,
 		peerSetSlotAllocTime,
 	)
@@ -207,7 +208,7 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 	// set libp2p host options
 	opts := []libp2p.Option{
 		libp2p.ResourceManager(manager),
-		libp2p.ListenAddrs(addr),
+		libp2p.ListenAddrs(listenAddrs...),
 		libp2p.DisableRelay(),
 		libp2p.Identity(cfg.privateKey),
 		libp2p.NATPortMap(),
@@ -268,6 +269,65 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 	return host, nil
 }
 
+// parseListenAddresses returns the multiaddresses to listen on, which default to the
+// IPv4 and IPv6 unspecified addresses with the configured port to listen on both stacks.
+func parseListenAddresses(cfg *Config) (listenAddrs []ma.Multiaddr, err error) {
+	listenAddresses := cfg.ListenAddresses
+	if len(listenAddresses) == 0 {
+		listenAddresses = []string{
+			fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", cfg.Port),
+			fmt.Sprintf("/ip6/::/tcp/%d", cfg.Port),
+		}
+	}
+
+	listenAddrs = make([]ma.Multiaddr, len(listenAddresses))
+	for i, listenAddress := range listenAddresses {
+		listenAddrs[i], err = ma.NewMultiaddr(listenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("parsing listen address %s: %w", listenAddress, err)
+		}
+
+		_, err = listenAddrs[i].ValueForProtocol(ma.P_TCP)
+		if err != nil {
+			return nil, fmt.Errorf("listen address %s has no tcp port: %w", listenAddress, err)
+		}
+	}
+	return listenAddrs, nil
+}
+
+// listenPort returns the tcp port of the first listen address of the IP protocol given,
+// falling back to the port of the first listen address.
+func listenPort(listenAddrs []ma.Multiaddr, ipProtocol int) (port uint64, err error) {
+	listenAddr := listenAddrs[0]
+	for _, addr := range listenAddrs {
+		if _, err := addr.ValueForProtocol(ipProtocol); err == nil {
+			listenAddr = addr
+			break
+		}
+	}
+
+	portString, err := listenAddr.ValueForProtocol(ma.P_TCP)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(portString, 10, 16)
+}
+
+// ipMultiaddr returns the multiaddress of the public ip given, with the port
+// of the listen address of the same IP stack.
+func ipMultiaddr(ip net.IP, listenAddrs []ma.Multiaddr) (ma.Multiaddr, error) {
+	ipProtocol, ipName := ma.P_IP6, "ip6"
+	if ip.To4() != nil {
+		ipProtocol, ipName = ma.P_IP4, "ip4"
+	}
+
+	port, err := listenPort(listenAddrs, ipProtocol)
+	if err != nil {
+		return nil, err
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/%d", ipName, ip, port))
+}
+
 // close closes host services and the libp2p host (host services first)
 func (h *host) close() error {
 	// close DHT service
@@ -301,8 +361,12 @@ func (h *host) close() error {
 }
 
 // registerStreamHandler registers the stream handler for the given protocol id.
+// The panics of the handler are recovered and recorded as failures of the network service.
 func (h *host) registerStreamHandler(pid protocol.ID, handler func(network.Stream)) {
-	h.p2pHost.SetStreamHandler(pid, handler)
+	h.p2pHost.SetStreamHandler(pid, func(stream network.Stream) {
+		defer h.supervisor.Recover("network")
+		handler(stream)
+	})
 }
 
 // connect connects the host to a specific peer address
@@ -447,6 +511,24 @@ func (h *host) multiaddrs() (multiaddrs []ma.Multiaddr) {
 	return multiaddrs
 }
 
+// listenMultiaddrs returns the multiaddresses the host is listening on, the unspecified
+// addresses being expanded to the addresses of the network interfaces.
+func (h *host) listenMultiaddrs() (multiaddrs []ma.Multiaddr, err error) {
+	addrs, err := h.p2pHost.Network().InterfaceListenAddresses()
+	if err != nil {
+		return nil, fmt.Errorf("getting interface listen addresses: %w", err)
+	}
+
+	for _, addr := range addrs {
+		multiaddr, err := ma.NewMultiaddr(fmt.Sprintf("%s/p2p/%s", addr, h.id()))
+		if err != nil {
+			continue
+		}
+		multiaddrs = append(multiaddrs, multiaddr)
+	}
+	return multiaddrs, nil
+}
+
 // protocols returns all protocols currently supported by the node as strings.
 func (h *host) protocols() []string {
 	protocolIDs := h.p2pHost.Mux().Protocols()
@@ -11,7 +11,9 @@
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
 	"github.com/ChainSafe/gossamer/lib/common"
+	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	ma "github.com/multiformats/go-multiaddr"
@@ -79,6 +81,7 @@ func TestExternalAddrsPublicIP(t *testing.T) {
 
 	expected := []ma.Multiaddr{
 		mustNewMultiAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)),
+		mustNewMultiAddr(fmt.Sprintf("/ip6/::1/tcp/%d", port)),
 		mustNewMultiAddr(fmt.Sprintf("/ip4/10.0.5.2/tcp/%d", port)),
 	}
 	assert.Equal(t, addrInfo.Addrs, expected)
@@ -98,6 +101,7 @@ func TestExternalAddrsPublicDNS(t *testing.T) {
 
 	expected := []ma.Multiaddr{
 		mustNewMultiAddr("/ip4/127.0.0.1/tcp/7001"),
+		mustNewMultiAddr("/ip6/::1/tcp/7001"),
 		mustNewMultiAddr("/dns/alice/tcp/7001"),
 	}
 	assert.Equal(t, addrInfo.Addrs, expected)
@@ -105,6 +109,32 @@ func TestExternalAddrsPublicDNS(t *testing.T) {
 }
 
 // test host connect method
+func TestExternalAddrsPublicIPv6(t *testing.T) {
+	t.Parallel()
+
+	ipv4Port, ipv6Port := availablePort(t), availablePort(t)
+	config := &Config{
+		BasePath: t.TempDir(),
+		PublicIP: "2001:db8::1",
+		ListenAddresses: []string{
+			fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", ipv4Port),
+			fmt.Sprintf("/ip6/::1/tcp/%d", ipv6Port),
+		},
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	node := createTestService(t, config)
+	addrInfo := addrInfo(node.host)
+
+	expected := []ma.Multiaddr{
+		mustNewMultiAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", ipv4Port)),
+		mustNewMultiAddr(fmt.Sprintf("/ip6/::1/tcp/%d", ipv6Port)),
+		mustNewMultiAddr(fmt.Sprintf("/ip6/2001:db8::1/tcp/%d", ipv6Port)),
+	}
+	assert.Equal(t, expected, addrInfo.Addrs)
+}
+
 func TestConnect(t *testing.T) {
 	t.Parallel()
 
@@ -230,6 +260,61 @@ func TestSend(t *testing.T) {
 	require.Equal(t, testBlockReqMessage, msg[0])
 }
 
+func TestStreamHandlerPanicRecovered(t *testing.T) {
+	t.Parallel()
+
+	configA := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+	nodeB.SetSupervisor(supervisor.New())
+	handled := make(chan struct{})
+	nodeB.host.registerStreamHandler(nodeB.host.protocolID, func(libp2pnetwork.Stream) {
+		defer close(handled)
+		panic("test panic")
+	})
+
+	addrInfoB := addrInfo(nodeB.host)
+	err := nodeA.host.connect(addrInfoB)
+	// retry connect if "failed to dial" error
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(addrInfoB)
+	}
+	require.NoError(t, err)
+
+	_, err = nodeA.host.send(addrInfoB.ID, nodeB.host.protocolID, newTestBlockRequestMessage(t))
+	require.NoError(t, err)
+
+	<-handled
+	require.Eventually(t, func() bool {
+		return len(nodeB.host.supervisor.Health()) == 1
+	}, TestMessageTimeout, 10*time.Millisecond)
+
+	expected := []common.ServiceHealth{{
+		Name:      "network",
+		State:     string(supervisor.Running),
+		Failures:  1,
+		LastError: "service panicked: test panic",
+	}}
+	assert.Equal(t, expected, nodeB.host.supervisor.Health())
+}
+
 // test host send method with existing stream
 func TestExistingStream(t *testing.T) {
 	t.Parallel()
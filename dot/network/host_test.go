@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseListenAddresses(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config      *Config
+		listenAddrs []string
+		errMessage  string
+	}{
+		"default_dual_stack": {
+			config:      &Config{Port: 7001},
+			listenAddrs: []string{"/ip4/0.0.0.0/tcp/7001", "/ip6/::/tcp/7001"},
+		},
+		"configured": {
+			config: &Config{
+				Port:            7001,
+				ListenAddresses: []string{"/ip6/::1/tcp/7002", "/ip4/127.0.0.1/tcp/7003"},
+			},
+			listenAddrs: []string{"/ip6/::1/tcp/7002", "/ip4/127.0.0.1/tcp/7003"},
+		},
+		"invalid_address": {
+			config:     &Config{ListenAddresses: []string{"/ip6/invalid/tcp/7002"}},
+			errMessage: "parsing listen address /ip6/invalid/tcp/7002: ",
+		},
+		"no_tcp_port": {
+			config:     &Config{ListenAddresses: []string{"/ip6/::1/udp/7002"}},
+			errMessage: "listen address /ip6/::1/udp/7002 has no tcp port: ",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			listenAddrs, err := parseListenAddresses(testCase.config)
+			if testCase.errMessage != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+
+			addresses := make([]string, len(listenAddrs))
+			for i, listenAddr := range listenAddrs {
+				addresses[i] = listenAddr.String()
+			}
+			assert.Equal(t, testCase.listenAddrs, addresses)
+		})
+	}
+}
+
+func Test_ipMultiaddr(t *testing.T) {
+	t.Parallel()
+
+	listenAddrs := []ma.Multiaddr{
+		ma.StringCast("/ip4/0.0.0.0/tcp/7001"),
+		ma.StringCast("/ip6/::/tcp/7002"),
+	}
+
+	testCases := map[string]struct {
+		ip          net.IP
+		listenAddrs []ma.Multiaddr
+		multiaddr   string
+	}{
+		"ipv4": {
+			ip:          net.ParseIP("198.51.100.19"),
+			listenAddrs: listenAddrs,
+			multiaddr:   "/ip4/198.51.100.19/tcp/7001",
+		},
+		"ipv6": {
+			ip:          net.ParseIP("2001:db8::1"),
+			listenAddrs: listenAddrs,
+			multiaddr:   "/ip6/2001:db8::1/tcp/7002",
+		},
+		"ipv6_without_ipv6_listen_address": {
+			ip:          net.ParseIP("2001:db8::1"),
+			listenAddrs: listenAddrs[:1],
+			multiaddr:   "/ip6/2001:db8::1/tcp/7001",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			multiaddr, err := ipMultiaddr(testCase.ip, testCase.listenAddrs)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.multiaddr, multiaddr.String())
+		})
+	}
+}
@@ -4,9 +4,28 @@
 package network
 
 import (
+	"errors"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 )
 
+// decodeErrorReputation returns the reputation change of a peer sending
+// an inbound message failing to decode with the error given.
+func decodeErrorReputation(err error) peerset.ReputationChange {
+	if errors.Is(err, ErrMalformedHeader) {
+		return peerset.ReputationChange{
+			Value:  peerset.BadBlockAnnouncementValue,
+			Reason: peerset.BadBlockAnnouncementReason,
+		}
+	}
+
+	return peerset.ReputationChange{
+		Value:  peerset.BadMessageValue,
+		Reason: peerset.BadMessageReason,
+	}
+}
+
 func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder, handler messageHandler,
 	maxSize uint64) {
 	// we NEED to reset the stream if we ever return from this function, as if we return,
@@ -37,6 +56,7 @@ func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder
 		if err != nil {
 			logger.Tracef("failed to decode message from stream id %s using protocol %s: %s",
 				stream.ID(), stream.Protocol(), err)
+			s.host.cm.peerSetHandler.ReportPeer(decodeErrorReputation(err), peer)
 			continue
 		}
 
@@ -6,6 +6,8 @@
 import (
 	"encoding/json"
 	"io"
+
+	"github.com/ChainSafe/gossamer/lib/common"
 )
 
 // Telemetry is the telemetry client to send telemetry messages.
@@ -27,3 +29,8 @@ type MDNS interface {
 	Start() error
 	io.Closer
 }
+
+// ReadProofProvider generates the storage proofs sent in response to light client remote read requests.
+type ReadProofProvider interface {
+	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+}
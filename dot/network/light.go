@@ -48,6 +48,10 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) (err
 
 	resp := NewLightResponse()
 	switch {
+	// requests decoded from the wire carry all the request kinds, so remote
+	// reads are recognised by their keys before the other kinds are checked.
+	case lr.RemoteReadRequest != nil && len(lr.RemoteReadRequest.Keys) > 0:
+		resp.RemoteReadResponse, err = s.remoteReadResp(lr.RemoteReadRequest)
 	case lr.RemoteCallRequest != nil:
 		resp.RemoteCallResponse, err = remoteCallResp(lr.RemoteCallRequest)
 	case lr.RemoteHeaderRequest != nil:
@@ -55,7 +59,7 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) (err
 	case lr.RemoteChangesRequest != nil:
 		resp.RemoteChangesResponse, err = remoteChangeResp(lr.RemoteChangesRequest)
 	case lr.RemoteReadRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadResp(lr.RemoteReadRequest)
+		resp.RemoteReadResponse, err = s.remoteReadResp(lr.RemoteReadRequest)
 	case lr.RemoteReadChildRequest != nil:
 		resp.RemoteReadResponse, err = remoteReadChildResp(lr.RemoteReadChildRequest)
 	default:
@@ -458,6 +462,50 @@ func remoteHeaderResp(_ *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
 func remoteReadChildResp(_ *RemoteReadChildRequest) (*RemoteReadResponse, error) {
 	return &RemoteReadResponse{}, nil
 }
-func remoteReadResp(_ *RemoteReadRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+// remoteReadResp returns the scale encoded storage proof of the requested keys at the requested block
+func (s *Service) remoteReadResp(req *RemoteReadRequest) (*RemoteReadResponse, error) {
+	if s.readProofProvider == nil {
+		return nil, ErrNoReadProofProvider
+	}
+
+	var block common.Hash
+	if len(req.Block) != len(block) {
+		return nil, fmt.Errorf("%w: %d bytes", ErrInvalidBlockHash, len(req.Block))
+	}
+	copy(block[:], req.Block)
+
+	_, proof, err := s.readProofProvider.GetReadProofAt(block, req.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("getting read proof: %w", err)
+	}
+
+	encodedProof, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding read proof: %w", err)
+	}
+
+	return &RemoteReadResponse{Proof: encodedProof}, nil
+}
+
+// RemoteRead requests from the given peer the storage proof of the keys at the given block.
+// The proof is returned as its encoded trie nodes and must be verified by the caller.
+func (s *Service) RemoteRead(to peer.ID, block common.Hash, keys [][]byte) (proof [][]byte, err error) {
+	req := NewLightRequest()
+	req.RemoteReadRequest = &RemoteReadRequest{
+		Block: block.ToBytes(),
+		Keys:  keys,
+	}
+
+	resp := NewLightResponse()
+	err = s.lightRequester.Do(to, req, resp)
+	if err != nil {
+		return nil, fmt.Errorf("sending remote read request: %w", err)
+	}
+
+	err = scale.Unmarshal(resp.RemoteReadResponse.Proof, &proof)
+	if err != nil {
+		return nil, fmt.Errorf("decoding remote read proof: %w", err)
+	}
+	return proof, nil
 }
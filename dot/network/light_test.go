@@ -0,0 +1,88 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_remoteReadResp(t *testing.T) {
+	t.Parallel()
+
+	block := common.Hash{1}
+	keys := [][]byte{{2}}
+	proof := [][]byte{{3}, {4}}
+	encodedProof, err := scale.Marshal(proof)
+	require.NoError(t, err)
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		providerBuilder func(ctrl *gomock.Controller) ReadProofProvider
+		request         *RemoteReadRequest
+		response        *RemoteReadResponse
+		errWrapped      error
+		errMessage      string
+	}{
+		"no_provider": {
+			providerBuilder: func(ctrl *gomock.Controller) ReadProofProvider { return nil },
+			request:         &RemoteReadRequest{Block: block.ToBytes(), Keys: keys},
+			errWrapped:      ErrNoReadProofProvider,
+			errMessage:      "remote reads are not served by this node",
+		},
+		"invalid_block_hash": {
+			providerBuilder: func(ctrl *gomock.Controller) ReadProofProvider {
+				return NewMockReadProofProvider(ctrl)
+			},
+			request:    &RemoteReadRequest{Block: []byte{1}, Keys: keys},
+			errWrapped: ErrInvalidBlockHash,
+			errMessage: "invalid block hash: 1 bytes",
+		},
+		"proof_error": {
+			providerBuilder: func(ctrl *gomock.Controller) ReadProofProvider {
+				provider := NewMockReadProofProvider(ctrl)
+				provider.EXPECT().GetReadProofAt(block, keys).Return(common.Hash{}, nil, errTest)
+				return provider
+			},
+			request:    &RemoteReadRequest{Block: block.ToBytes(), Keys: keys},
+			errWrapped: errTest,
+			errMessage: "getting read proof: test error",
+		},
+		"success": {
+			providerBuilder: func(ctrl *gomock.Controller) ReadProofProvider {
+				provider := NewMockReadProofProvider(ctrl)
+				provider.EXPECT().GetReadProofAt(block, keys).Return(block, proof, nil)
+				return provider
+			},
+			request:  &RemoteReadRequest{Block: block.ToBytes(), Keys: keys},
+			response: &RemoteReadResponse{Proof: encodedProof},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			s := &Service{}
+			if provider := testCase.providerBuilder(ctrl); provider != nil {
+				s.SetReadProofProvider(provider)
+			}
+
+			response, err := s.remoteReadResp(testCase.request)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/ChainSafe/gossamer/dot/network/proto"
@@ -47,6 +48,7 @@ type NotificationsMessage interface {
 	RequestedDataReceipt       = byte(4)
 	RequestedDataMessageQueue  = byte(8)
 	RequestedDataJustification = byte(16)
+	RequestedDataIndexedBody   = byte(32) // indexed transactions are not stored, so never sent
 	BootstrapRequestData       = RequestedDataHeader +
 		RequestedDataBody +
 		RequestedDataJustification
@@ -173,6 +175,29 @@ func (bm *BlockRequestMessage) Decode(in []byte) error {
 	return nil
 }
 
+// knownBlockRequestDataMask has the bits of the block data a block request can ask for.
+const knownBlockRequestDataMask = RequestedDataHeader | RequestedDataBody | RequestedDataReceipt |
+	RequestedDataMessageQueue | RequestedDataJustification | RequestedDataIndexedBody
+
+// validate returns an error wrapping ErrInvalidBlockRequest if the block request cannot be
+// served whatever the chain of the node is.
+func (bm *BlockRequestMessage) validate() error {
+	if bm.Direction != Ascending && bm.Direction != Descending {
+		return fmt.Errorf("%w: unknown direction %d", ErrInvalidBlockRequest, bm.Direction)
+	}
+
+	if bm.RequestedData&^knownBlockRequestDataMask != 0 {
+		return fmt.Errorf("%w: unknown requested data %08b", ErrInvalidBlockRequest, bm.RequestedData)
+	}
+
+	switch bm.StartingBlock.Value().(type) {
+	case uint32, common.Hash:
+	default:
+		return fmt.Errorf("%w: invalid starting block", ErrInvalidBlockRequest)
+	}
+	return nil
+}
+
 var _ ResponseMessage = (*BlockResponseMessage)(nil)
 
 // BlockResponseMessage is sent in response to a BlockRequestMessage
@@ -209,6 +234,18 @@ func (bm *BlockResponseMessage) Encode() ([]byte, error) {
 	return proto.Marshal(msg)
 }
 
+// BlockDataEncodedSize returns the number of bytes the block data given takes in an encoded
+// BlockResponseMessage.
+func BlockDataEncodedSize(bd *types.BlockData) (uint64, error) {
+	msg, err := blockDataToProtobuf(bd)
+	if err != nil {
+		return 0, err
+	}
+
+	// the block data are the repeated length delimited field 1 of the block response
+	return uint64(protowire.SizeTag(1) + protowire.SizeBytes(proto.Size(msg))), nil
+}
+
 // Decode decodes the protobuf encoded input to a BlockResponseMessage
 func (bm *BlockResponseMessage) Decode(in []byte) (err error) {
 	msg := &pb.BlockResponse{}
@@ -279,21 +316,24 @@ func protobufToBlockData(pbd *pb.BlockData) (*types.BlockData, error) {
 
 	if pbd.Header != nil {
 		header := types.NewEmptyHeader()
-		err := scale.Unmarshal(pbd.Header, header)
+		err := UnmarshalMessage(pbd.Header, header)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %s", ErrMalformedHeader, err)
 		}
 
 		bd.Header = header
 	}
 
 	if pbd.Body != nil {
-		body, err := types.NewBodyFromEncodedBytes(pbd.Body)
-		if err != nil {
-			return nil, err
+		body := make(types.Body, len(pbd.Body))
+		for i, encodedExtrinsic := range pbd.Body {
+			err := UnmarshalMessage(encodedExtrinsic, &body[i])
+			if err != nil {
+				return nil, fmt.Errorf("decoding extrinsic %d: %w", i, err)
+			}
 		}
 
-		bd.Body = body
+		bd.Body = &body
 	} else {
 		bd.Body = nil
 	}
@@ -155,6 +155,32 @@ func TestEncodeBlockResponseMessage_Empty(t *testing.T) {
 	require.Equal(t, bm, act)
 }
 
+func TestBlockDataEncodedSize(t *testing.T) {
+	t.Parallel()
+
+	justification := []byte{1, 2}
+	blockData := []*types.BlockData{
+		{Hash: common.Hash{1}},
+		{
+			Hash:          common.Hash{2},
+			Header:        &types.Header{Number: 2, Digest: types.NewDigest()},
+			Body:          types.NewBody([]types.Extrinsic{{4, 5}, make([]byte, 300)}),
+			Justification: &justification,
+		},
+	}
+
+	var size uint64
+	for _, bd := range blockData {
+		bdSize, err := BlockDataEncodedSize(bd)
+		require.NoError(t, err)
+		size += bdSize
+	}
+
+	encoded, err := (&BlockResponseMessage{BlockData: blockData}).Encode()
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(encoded)), size)
+}
+
 func TestEncodeBlockResponseMessage_WithBody(t *testing.T) {
 	t.Parallel()
 
@@ -320,6 +346,17 @@ func TestDecode_BlockAnnounceMessage(t *testing.T) {
 	require.Equal(t, expected, bhm)
 }
 
+func TestDecode_BlockAnnounceMessage_MalformedHeader(t *testing.T) {
+	t.Parallel()
+
+	// parent hash and a truncated block number
+	announceMessage := common.MustHexToBytes("0x454545454545454545454545454545454545454545454545454545454545454503")
+
+	bhm := BlockAnnounceMessage{}
+	err := bhm.Decode(announceMessage)
+	require.ErrorIs(t, err, ErrMalformedHeader)
+}
+
 func TestEncodeTransactionMessageSingleExtrinsic(t *testing.T) {
 	/* expected:
 	 * 0x04 - Scale encoded count of Extrinsic array(count = 1)
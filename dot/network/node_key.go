@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"crypto/ed25519"
+	crand "crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// nodeKeyFileMode is the mode of the node key file, only readable and writable by its owner
+const nodeKeyFileMode os.FileMode = 0o600
+
+var (
+	// ErrInvalidNodeKey is returned when a node key is not a hex encoded ed25519 key
+	ErrInvalidNodeKey = errors.New("invalid node key")
+	// ErrNodeKeyFileExists is returned when saving a node key to a file that already exists
+	ErrNodeKeyFileExists = errors.New("node key file already exists")
+)
+
+// GenerateNodeKey generates a random ed25519 node key
+func GenerateNodeKey() (crypto.PrivKey, error) {
+	key, _, err := crypto.GenerateEd25519Key(crand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating ed25519 key: %w", err)
+	}
+	return key, nil
+}
+
+// ParseNodeKey parses the hex encoded ed25519 node key given, either as the 32 bytes secret
+// seed of the key, as written by Substrate nodes, or as the 64 bytes private key, as written
+// by previous gossamer versions. The 0x prefix and the surrounding whitespaces are optional.
+func ParseNodeKey(hexKey string) (crypto.PrivKey, error) {
+	hexKey = strings.TrimPrefix(strings.TrimSpace(hexKey), "0x")
+
+	// the decoding error does not contain the secret key
+	decoded, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNodeKey, err)
+	}
+
+	switch len(decoded) {
+	case ed25519.SeedSize:
+		decoded = ed25519.NewKeyFromSeed(decoded)
+	case ed25519.PrivateKeySize:
+	default:
+		return nil, fmt.Errorf("%w: %d bytes instead of %d or %d bytes",
+			ErrInvalidNodeKey, len(decoded), ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+
+	key, err := crypto.UnmarshalEd25519PrivateKey(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNodeKey, err)
+	}
+	return key, nil
+}
+
+// LoadNodeKey reads the node key from the file given, as parsed by ParseNodeKey.
+// It returns a nil key if the file does not exist. The permissions of the file are
+// restricted to its owner if the file is readable by other users.
+func LoadNodeKey(file string) (crypto.PrivKey, error) {
+	file = filepath.Clean(file)
+	info, err := os.Stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting node key file information: %w", err)
+	}
+
+	if info.Mode().Perm()&^nodeKeyFileMode != 0 {
+		err = os.Chmod(file, nodeKeyFileMode)
+		if err != nil {
+			return nil, fmt.Errorf("restricting node key file permissions: %w", err)
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading node key file: %w", err)
+	}
+
+	key, err := ParseNodeKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parsing node key file %s: %w", file, err)
+	}
+	return key, nil
+}
+
+// SaveNodeKey writes the hex encoded secret seed of the node key given to the file given,
+// only readable and writable by its owner, creating its parent directories if needed.
+// An existing file is not overwritten.
+func SaveNodeKey(key crypto.PrivKey, file string) error {
+	raw, err := key.Raw()
+	if err != nil {
+		return fmt.Errorf("getting raw node key: %w", err)
+	} else if len(raw) != ed25519.PrivateKeySize {
+		return fmt.Errorf("%w: %d bytes instead of %d bytes",
+			ErrInvalidNodeKey, len(raw), ed25519.PrivateKeySize)
+	}
+
+	file = filepath.Clean(file)
+	err = os.MkdirAll(filepath.Dir(file), 0o700)
+	if err != nil {
+		return fmt.Errorf("creating node key directory: %w", err)
+	}
+
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, nodeKeyFileMode)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s", ErrNodeKeyFileExists, file)
+	} else if err != nil {
+		return fmt.Errorf("creating node key file: %w", err)
+	}
+
+	seed := ed25519.PrivateKey(raw).Seed()
+	_, err = f.WriteString(hex.EncodeToString(seed))
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing node key file: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("closing node key file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeKey(t *testing.T) {
+	t.Parallel()
+
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	expectedKey, err := crypto.UnmarshalEd25519PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		hexKey     string
+		key        crypto.PrivKey
+		errWrapped error
+		errMessage string
+	}{
+		"seed": {
+			hexKey: hex.EncodeToString(seed),
+			key:    expectedKey,
+		},
+		"prefixed_seed_with_newline": {
+			hexKey: "0x" + hex.EncodeToString(seed) + "\n",
+			key:    expectedKey,
+		},
+		"private_key": {
+			hexKey: hex.EncodeToString(privateKey),
+			key:    expectedKey,
+		},
+		"invalid_hex": {
+			hexKey:     "zz",
+			errWrapped: ErrInvalidNodeKey,
+			errMessage: "invalid node key: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		"invalid_length": {
+			hexKey:     "0102",
+			errWrapped: ErrInvalidNodeKey,
+			errMessage: "invalid node key: 2 bytes instead of 32 or 64 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := ParseNodeKey(testCase.hexKey)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			if testCase.key == nil {
+				assert.Nil(t, key)
+			} else {
+				assert.True(t, testCase.key.Equals(key))
+			}
+		})
+	}
+}
+
+func TestSaveNodeKey_LoadNodeKey(t *testing.T) {
+	t.Parallel()
+
+	file := filepath.Join(t.TempDir(), "keys", DefaultKeyFile)
+
+	key, err := LoadNodeKey(file)
+	require.NoError(t, err)
+	assert.Nil(t, key)
+
+	generated, err := GenerateNodeKey()
+	require.NoError(t, err)
+	err = SaveNodeKey(generated, file)
+	require.NoError(t, err)
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.Equal(t, nodeKeyFileMode, info.Mode().Perm())
+	}
+
+	key, err = LoadNodeKey(file)
+	require.NoError(t, err)
+	assert.True(t, generated.Equals(key))
+
+	// an existing node key file is not overwritten
+	other, err := GenerateNodeKey()
+	require.NoError(t, err)
+	err = SaveNodeKey(other, file)
+	assert.ErrorIs(t, err, ErrNodeKeyFileExists)
+
+	// the permissions of a node key file readable by other users are restricted
+	if runtime.GOOS != "windows" {
+		err = os.Chmod(file, 0o644)
+		require.NoError(t, err)
+		_, err = LoadNodeKey(file)
+		require.NoError(t, err)
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.Equal(t, nodeKeyFileMode, info.Mode().Perm())
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// notificationsQueueWorkers is the number of goroutines handling the queued notifications.
+const notificationsQueueWorkers = 4
+
+var droppedNotificationsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gossamer_network_notifications_queue",
+	Name:      "dropped_total",
+	Help:      "total number of inbound notifications dropped as their queue is full, by protocol",
+}, []string{"protocol"})
+
+// dropPolicy is the notification dropped when a notifications buffer is full.
+type dropPolicy uint8
+
+const (
+	// dropOldest drops the oldest notification queued, for the notifications superseded
+	// by the newer ones such as the votes and the block announces.
+	dropOldest dropPolicy = iota
+	// dropNewest drops the notification received, for the notifications whose flood should
+	// not evict the ones already queued such as the transactions.
+	dropNewest
+)
+
+// notificationsBufferConfig is the configuration of the buffer of a notifications protocol.
+type notificationsBufferConfig struct {
+	name        string
+	messageType MessageType
+	capacity    int
+	policy      dropPolicy
+}
+
+// notificationsBufferConfigs are the configurations of the buffers of the notifications
+// protocols, ordered by decreasing priority. The notifications of the protocols not listed
+// are handled as they are read.
+var notificationsBufferConfigs = []notificationsBufferConfig{
+	{name: "grandpa", messageType: ConsensusMsgType, capacity: 4096, policy: dropOldest},
+	{name: "block_announces", messageType: blockAnnounceMsgType, capacity: 1024, policy: dropOldest},
+	{name: "transactions", messageType: transactionMsgType, capacity: 1024, policy: dropNewest},
+}
+
+// queuedNotification is an inbound notification waiting to be handled.
+type queuedNotification struct {
+	stream  network.Stream
+	message Message
+	handler messageHandler
+}
+
+// notificationsBuffer is the bounded FIFO buffer of the notifications of a protocol.
+type notificationsBuffer struct {
+	notificationsBufferConfig
+	notifications []queuedNotification
+	dropped       prometheus.Counter
+}
+
+func (b *notificationsBuffer) push(notification queuedNotification) {
+	if len(b.notifications) >= b.capacity {
+		b.dropped.Inc()
+		if b.policy == dropNewest {
+			return
+		}
+		b.notifications[0] = queuedNotification{}
+		b.notifications = b.notifications[1:]
+	}
+	b.notifications = append(b.notifications, notification)
+}
+
+func (b *notificationsBuffer) pop() (notification queuedNotification) {
+	notification = b.notifications[0]
+	b.notifications[0] = queuedNotification{}
+	b.notifications = b.notifications[1:]
+	return notification
+}
+
+// notificationsQueue queues the inbound notifications in bounded buffers per protocol,
+// and handles them by protocol priority so that a flood of low priority notifications,
+// such as transactions, does not delay the handling of the finality votes.
+type notificationsQueue struct {
+	mu sync.Mutex
+	// buffers are the buffers of the protocols, ordered by decreasing priority.
+	buffers []*notificationsBuffer
+	// signal is sent to when a notification is queued.
+	signal chan struct{}
+	// resetStream resets an inbound stream whose notification failed to be handled.
+	resetStream func(network.Stream)
+}
+
+func newNotificationsQueue(configs []notificationsBufferConfig,
+	resetStream func(network.Stream)) *notificationsQueue {
+	buffers := make([]*notificationsBuffer, len(configs))
+	for i, config := range configs {
+		buffers[i] = &notificationsBuffer{
+			notificationsBufferConfig: config,
+			dropped:                   droppedNotificationsCounter.WithLabelValues(config.name),
+		}
+	}
+
+	return &notificationsQueue{
+		buffers:     buffers,
+		signal:      make(chan struct{}, 1),
+		resetStream: resetStream,
+	}
+}
+
+// handler returns the message handler queueing the notifications of the message type
+// given, the handshakes and the notifications of the protocols without buffer being
+// handled as they are read.
+func (q *notificationsQueue) handler(messageType MessageType, handler messageHandler) messageHandler {
+	var buffer *notificationsBuffer
+	if q != nil {
+		for _, b := range q.buffers {
+			if b.messageType == messageType {
+				buffer = b
+				break
+			}
+		}
+	}
+
+	if buffer == nil {
+		return handler
+	}
+
+	return func(stream network.Stream, msg Message) error {
+		if _, isHandshake := msg.(Handshake); isHandshake {
+			return handler(stream, msg)
+		}
+
+		q.mu.Lock()
+		buffer.push(queuedNotification{stream: stream, message: msg, handler: handler})
+		q.mu.Unlock()
+
+		q.notify()
+		return nil
+	}
+}
+
+func (q *notificationsQueue) notify() {
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the oldest notification of the highest priority buffer which is not empty,
+// and whether more notifications are queued.
+func (q *notificationsQueue) pop() (notification queuedNotification, ok, more bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, buffer := range q.buffers {
+		if len(buffer.notifications) == 0 {
+			continue
+		}
+
+		if ok {
+			return notification, true, true
+		}
+
+		notification, ok = buffer.pop(), true
+		if len(buffer.notifications) > 0 {
+			return notification, true, true
+		}
+	}
+	return notification, ok, false
+}
+
+// start starts the workers handling the queued notifications until the context is done.
+func (q *notificationsQueue) start(ctx context.Context) {
+	if q == nil {
+		return
+	}
+
+	for i := 0; i < notificationsQueueWorkers; i++ {
+		go q.work(ctx)
+	}
+}
+
+func (q *notificationsQueue) work(ctx context.Context) {
+	for {
+		notification, ok, more := q.pop()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.signal:
+				continue
+			}
+		}
+
+		if more {
+			// wake up another worker to handle the remaining notifications
+			q.notify()
+		}
+
+		err := notification.handler(notification.stream, notification.message)
+		if err != nil {
+			logger.Tracef("failed to handle message %s from stream id %s: %s",
+				notification.message, notification.stream.ID(), err)
+			q.resetStream(notification.stream)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_notificationsQueue_pop(t *testing.T) {
+	t.Parallel()
+
+	queue := newNotificationsQueue(notificationsBufferConfigs, nil)
+	var handled []Message
+	handler := func(_ network.Stream, msg Message) error {
+		handled = append(handled, msg)
+		return nil
+	}
+
+	transaction := &TransactionMessage{}
+	blockAnnounce := &BlockAnnounceMessage{Number: 1}
+	vote := &ConsensusMessage{Data: []byte{1}}
+
+	transactionHandler := queue.handler(transactionMsgType, handler)
+	require.NoError(t, transactionHandler(nil, transaction))
+	require.NoError(t, queue.handler(blockAnnounceMsgType, handler)(nil, blockAnnounce))
+	require.NoError(t, queue.handler(ConsensusMsgType, handler)(nil, vote))
+	assert.Empty(t, handled)
+
+	// handshakes are handled as they are read
+	handshake := &BlockAnnounceHandshake{}
+	require.NoError(t, transactionHandler(nil, handshake))
+	assert.Equal(t, []Message{handshake}, handled)
+
+	var popped []Message
+	for {
+		notification, ok, more := queue.pop()
+		if !ok {
+			break
+		}
+		popped = append(popped, notification.message)
+		assert.Equal(t, len(popped) < 3, more)
+	}
+	assert.Equal(t, []Message{vote, blockAnnounce, transaction}, popped)
+}
+
+func Test_notificationsBuffer_push(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name     string
+		policy   dropPolicy
+		expected []Message
+	}{
+		"drop_oldest": {
+			name:     "test_drop_oldest",
+			policy:   dropOldest,
+			expected: []Message{&ConsensusMessage{Data: []byte{2}}, &ConsensusMessage{Data: []byte{3}}},
+		},
+		"drop_newest": {
+			name:     "test_drop_newest",
+			policy:   dropNewest,
+			expected: []Message{&ConsensusMessage{Data: []byte{1}}, &ConsensusMessage{Data: []byte{2}}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := notificationsBufferConfig{
+				name:        testCase.name,
+				messageType: ConsensusMsgType,
+				capacity:    2,
+				policy:      testCase.policy,
+			}
+			queue := newNotificationsQueue([]notificationsBufferConfig{config}, nil)
+			handler := queue.handler(ConsensusMsgType, func(network.Stream, Message) error { return nil })
+			for i := byte(1); i <= 3; i++ {
+				require.NoError(t, handler(nil, &ConsensusMessage{Data: []byte{i}}))
+			}
+
+			messages := make([]Message, len(queue.buffers[0].notifications))
+			for i, notification := range queue.buffers[0].notifications {
+				messages[i] = notification.message
+			}
+			assert.Equal(t, testCase.expected, messages)
+		})
+	}
+}
+
+func Test_notificationsQueue_unqueuedProtocol(t *testing.T) {
+	t.Parallel()
+
+	queue := newNotificationsQueue(notificationsBufferConfigs, nil)
+	errTest := errors.New("test error")
+	handler := queue.handler(MessageType(0), func(network.Stream, Message) error { return errTest })
+
+	err := handler(nil, &ConsensusMessage{})
+	assert.ErrorIs(t, err, errTest)
+}
+
+func Test_notificationsQueue_work(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	stream := NewMockStream(ctrl)
+	stream.EXPECT().ID().Return("stream")
+
+	reset := make(chan network.Stream, 1)
+	queue := newNotificationsQueue(notificationsBufferConfigs, func(stream network.Stream) {
+		reset <- stream
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queue.start(ctx)
+
+	handler := queue.handler(ConsensusMsgType, func(network.Stream, Message) error {
+		return errors.New("test error")
+	})
+	err := handler(stream, &ConsensusMessage{})
+	require.NoError(t, err)
+
+	// the stream of a notification failing to be handled is reset
+	select {
+	case resetStream := <-reset:
+		assert.Equal(t, network.Stream(stream), resetStream)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the stream to be reset")
+	}
+}
@@ -101,3 +101,12 @@ func (p *peersData) countOutboundStreams() (count int64) {
 	}
 	return count
 }
+
+// isFullDuplex returns true if both the inbound and outbound handshakes with
+// the peer are validated, meaning notifications are exchanged both ways.
+func (p *peersData) isFullDuplex(peerID peer.ID) bool {
+	inbound := p.getInboundHandshakeData(peerID)
+	outbound := p.getOutboundHandshakeData(peerID)
+	return inbound != nil && inbound.validated &&
+		outbound != nil && outbound.validated
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peersData_isFullDuplex(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		inbound    *handshakeData
+		outbound   *handshakeData
+		fullDuplex bool
+	}{
+		"no_handshake": {},
+		"inbound_only": {
+			inbound: &handshakeData{validated: true},
+		},
+		"outbound_only": {
+			outbound: &handshakeData{validated: true},
+		},
+		"outbound_not_validated": {
+			inbound:  &handshakeData{validated: true},
+			outbound: &handshakeData{received: true},
+		},
+		"both_validated": {
+			inbound:    &handshakeData{validated: true},
+			outbound:   &handshakeData{validated: true},
+			fullDuplex: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			const peerID = peer.ID("a")
+			data := newPeersData()
+			if testCase.inbound != nil {
+				data.setInboundHandshakeData(peerID, testCase.inbound)
+			}
+			if testCase.outbound != nil {
+				data.setOutboundHandshakeData(peerID, testCase.outbound)
+			}
+
+			assert.Equal(t, testCase.fullDuplex, data.isFullDuplex(peerID))
+		})
+	}
+}
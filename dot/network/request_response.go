@@ -4,15 +4,38 @@
 package network
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/klauspost/compress/zstd"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// zstdMagic is the magic number starting a zstd frame.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+var (
+	responseReceivedBytesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_request_response",
+		Name:      "received_bytes_total",
+		Help:      "total number of response bytes received, before decompression",
+	}, []string{"protocol"})
+	responseRejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_request_response",
+		Name:      "rejected_total",
+		Help:      "total number of responses rejected, by reason",
+	}, []string{"protocol", "reason"})
 )
 
 type RequestMaker interface {
@@ -27,9 +50,23 @@ type RequestResponseProtocol struct {
 	protocolID      protocol.ID
 	responseBufMu   sync.Mutex
 	responseBuf     []byte
+	// compressedResponses is true if the protocol allows
+	// the responses to be sent as zstd frames.
+	compressedResponses bool
+	// decoder and decompressedBuf are lazily created on the
+	// first compressed response, and guarded by responseBufMu.
+	decoder         *zstd.Decoder
+	decompressedBuf []byte
 }
 
 func (rrp *RequestResponseProtocol) Do(to peer.ID, req Message, res ResponseMessage) error {
+	if chaos.Enabled {
+		err := rrp.host.injectRequestFaults(to)
+		if err != nil {
+			return err
+		}
+	}
+
 	rrp.host.p2pHost.ConnManager().Protect(to, "")
 	defer rrp.host.p2pHost.ConnManager().Unprotect(to, "")
 
@@ -52,7 +89,16 @@ func (rrp *RequestResponseProtocol) Do(to peer.ID, req Message, res ResponseMess
 		return err
 	}
 
-	return rrp.receiveResponse(stream, res)
+	err = rrp.receiveResponse(stream, res)
+	if err != nil {
+		return err
+	}
+
+	if chaos.Enabled {
+		return rrp.host.injectResponseFaults(to)
+	}
+
+	return nil
 }
 
 func (rrp *RequestResponseProtocol) receiveResponse(stream libp2pnetwork.Stream, msg ResponseMessage) error {
@@ -63,9 +109,17 @@ func (rrp *RequestResponseProtocol) receiveResponse(stream libp2pnetwork.Stream,
 	defer rrp.responseBufMu.Unlock()
 
 	buf := rrp.responseBuf
+	who := stream.Conn().RemotePeer()
 
 	n, err := readStream(stream, &buf, rrp.maxResponseSize)
+	responseReceivedBytesCounter.WithLabelValues(string(rrp.protocolID)).Add(float64(n))
 	if err != nil {
+		switch {
+		case errors.Is(err, ErrGreaterThanMaxSize):
+			rrp.rejectResponse(who, peerset.OversizedResponseValue, peerset.OversizedResponseReason, "oversized")
+		case errors.Is(err, ErrFailedToReadEntireMessage) && errors.Is(err, io.EOF):
+			rrp.rejectResponse(who, peerset.TruncatedResponseValue, peerset.TruncatedResponseReason, "truncated")
+		}
 		return fmt.Errorf("read stream error: %w", err)
 	}
 
@@ -73,18 +127,67 @@ func (rrp *RequestResponseProtocol) receiveResponse(stream libp2pnetwork.Stream,
 		return ErrReceivedEmptyMessage
 	}
 
-	err = msg.Decode(buf[:n])
-	if err != nil {
-		rrp.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
-			Value:  peerset.BadMessageValue,
-			Reason: peerset.BadMessageReason,
-		}, stream.Conn().RemotePeer())
+	payload := buf[:n]
+	if rrp.compressedResponses {
+		payload, err = rrp.decompressResponse(payload)
+		if errors.Is(err, ErrGreaterThanMaxSize) {
+			rrp.rejectResponse(who, peerset.OversizedResponseValue, peerset.OversizedResponseReason, "oversized")
+			return err
+		} else if err != nil {
+			rrp.rejectResponse(who, peerset.BadMessageValue, peerset.BadMessageReason, "bad_compression")
+			return err
+		}
+	}
+
+	err = msg.Decode(payload)
+	if errors.Is(err, ErrMalformedHeader) {
+		rrp.rejectResponse(who, peerset.IncompleteHeaderValue, peerset.IncompleteHeaderReason, "malformed_header")
+		return fmt.Errorf("failed to decode block response: %w", err)
+	} else if err != nil {
+		rrp.rejectResponse(who, peerset.BadMessageValue, peerset.BadMessageReason, "bad_message")
 		return fmt.Errorf("failed to decode block response: %w", err)
 	}
 
 	return nil
 }
 
+// decompressResponse decompresses the payload given if it is a zstd frame, and returns it
+// unchanged otherwise. The decompressed payload cannot be greater than the maximum response size.
+func (rrp *RequestResponseProtocol) decompressResponse(payload []byte) ([]byte, error) {
+	if !bytes.HasPrefix(payload, zstdMagic) {
+		return payload, nil
+	}
+
+	if rrp.decoder == nil {
+		decoder, err := zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxMemory(rrp.maxResponseSize))
+		if err != nil {
+			return nil, fmt.Errorf("creating zstd decoder: %w", err)
+		}
+		rrp.decoder = decoder
+	}
+
+	decompressed, err := rrp.decoder.DecodeAll(payload, rrp.decompressedBuf[:0])
+	if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+		return nil, fmt.Errorf("decompressing response: %w: max %d", ErrGreaterThanMaxSize, rrp.maxResponseSize)
+	} else if err != nil {
+		return nil, fmt.Errorf("decompressing response: %w", err)
+	}
+
+	rrp.decompressedBuf = decompressed
+	return decompressed, nil
+}
+
+func (rrp *RequestResponseProtocol) rejectResponse(who peer.ID, value peerset.Reputation,
+	reason, metricReason string) {
+	responseRejectedCounter.WithLabelValues(string(rrp.protocolID), metricReason).Inc()
+	rrp.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+		Value:  value,
+		Reason: reason,
+	}, who)
+}
+
 type ResponseMessage interface {
 	String() string
 	Encode() ([]byte, error)
@@ -0,0 +1,83 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestResponseProtocol_decompressResponse(t *testing.T) {
+	t.Parallel()
+
+	compress := func(t *testing.T, payload []byte) []byte {
+		t.Helper()
+		encoder, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		return encoder.EncodeAll(payload, nil)
+	}
+
+	testCases := map[string]struct {
+		payload    func(t *testing.T) []byte
+		maxSize    uint64
+		expected   []byte
+		errWrapped error
+		errMessage string
+	}{
+		"uncompressed": {
+			payload:  func(*testing.T) []byte { return []byte{1, 2, 3} },
+			maxSize:  3,
+			expected: []byte{1, 2, 3},
+		},
+		"compressed": {
+			payload: func(t *testing.T) []byte {
+				return compress(t, bytes.Repeat([]byte{1}, 2048))
+			},
+			maxSize:  2048,
+			expected: bytes.Repeat([]byte{1}, 2048),
+		},
+		"decompressed_greater_than_max_size": {
+			payload: func(t *testing.T) []byte {
+				return compress(t, bytes.Repeat([]byte{1}, 2049))
+			},
+			maxSize:    2048,
+			errWrapped: ErrGreaterThanMaxSize,
+			errMessage: "decompressing response: greater than maximum size: max 2048",
+		},
+		"corrupted": {
+			payload: func(*testing.T) []byte {
+				return append(append([]byte{}, zstdMagic...), 0xff, 0xff)
+			},
+			maxSize:    2048,
+			errMessage: "decompressing response: reserved bit set on frame header",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rrp := &RequestResponseProtocol{
+				maxResponseSize:     testCase.maxSize,
+				compressedResponses: true,
+			}
+
+			decompressed, err := rrp.decompressResponse(testCase.payload(t))
+			if testCase.errMessage != "" {
+				if testCase.errWrapped != nil {
+					assert.ErrorIs(t, err, testCase.errWrapped)
+				}
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, decompressed)
+		})
+	}
+}
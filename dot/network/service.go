@@ -12,7 +12,9 @@
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -22,6 +24,7 @@
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -38,6 +41,7 @@
 
 	maxMessageSize       = 1024 * 64 // 64kb for now
 	findPeerQueryTimeout = 10 * time.Second
+	lightRequestTimeout  = 20 * time.Second
 )
 
 var (
@@ -88,6 +92,16 @@
 		Name:      "outbound_total",
 		Help:      "total number of outbound streams",
 	})
+	peerSlotsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_peerset",
+		Name:      "slots_occupied",
+		Help:      "number of peers occupying a slot, by slot category",
+	}, []string{"category"})
+	peerSlotsLimitGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_peerset",
+		Name:      "slots_limit",
+		Help:      "maximum number of peers occupying a slot, by slot category",
+	}, []string{"category"})
 	processStartTimeGauge = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "substrate", // Note: this is using substrate namespace because that is what zombienet uses
 		//  to confirm nodes have started TODO: consider other ways to handle this, see issue #3205
@@ -95,6 +109,12 @@
 		Help: "gossamer process start seconds unix timestamp, " +
 			"using substrate namespace so zombienet detects node start",
 	})
+	substratePeerCountGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "sub_libp2p_peers_count",
+		Help: "number of connected peers, " +
+			"using substrate name so zombienet peer count assertions apply to gossamer nodes",
+	})
 )
 
 type (
@@ -120,14 +140,20 @@ type Service struct {
 
 	notificationsProtocols map[MessageType]*notificationsProtocol // map of sub-protocol msg ID to protocol info
 	notificationsMu        sync.RWMutex
+	notificationsQueue     *notificationsQueue
+	blockRequestLimiter    *blockRequestLimiter
+	blockAnnouncer         *blockAnnouncer
+	eventBus               *events.Bus
 
 	lightRequest   map[peer.ID]struct{} // set if we have sent a light request message to the given peer
 	lightRequestMu sync.RWMutex
+	lightRequester *RequestResponseProtocol
 
 	// Service interfaces
 	blockState         BlockState
 	syncer             Syncer
 	transactionHandler TransactionHandler
+	readProofProvider  ReadProofProvider
 
 	// Configuration options
 	noBootstrap bool
@@ -220,9 +246,13 @@ func NewService(cfg *Config) (*Service, error) {
 		closeCh:                make(chan struct{}),
 		bufPool:                bufPool,
 		streamManager:          newStreamManager(ctx),
+		blockRequestLimiter:    newBlockRequestLimiter(),
 		telemetry:              cfg.Telemetry,
 		Metrics:                cfg.Metrics,
 	}
+	network.notificationsQueue = newNotificationsQueue(notificationsBufferConfigs, network.resetInboundStream)
+	network.blockAnnouncer = newBlockAnnouncer(blockAnnounceBatchWindow, host.peers, network.sendBlockAnnounces)
+	network.lightRequester = network.GetRequestResponseProtocol(lightID, lightRequestTimeout, MaxBlockResponseSize)
 
 	return network, nil
 }
@@ -237,6 +267,23 @@ func (s *Service) SetTransactionHandler(handler TransactionHandler) {
 	s.transactionHandler = handler
 }
 
+// SetReadProofProvider sets the ReadProofProvider used to answer the remote read requests of light clients
+func (s *Service) SetReadProofProvider(provider ReadProofProvider) {
+	s.readProofProvider = provider
+}
+
+// SetSupervisor sets the supervisor recording the panics of the stream handlers,
+// before the service is started.
+func (s *Service) SetSupervisor(sv *supervisor.Supervisor) {
+	s.host.supervisor = sv
+}
+
+// SetEventBus sets the event bus the peers connected and disconnected are published on,
+// before the service is started.
+func (s *Service) SetEventBus(bus *events.Bus) {
+	s.eventBus = bus
+}
+
 // Start starts the network service
 func (s *Service) Start() error {
 	if s.syncer == nil {
@@ -251,6 +298,8 @@ func (s *Service) Start() error {
 		s.ctx, s.cancel = context.WithCancel(context.Background())
 	}
 
+	s.notificationsQueue.start(s.ctx)
+
 	s.host.registerStreamHandler(s.host.protocolID+SyncID, s.handleSyncStream)
 	s.host.registerStreamHandler(s.host.protocolID+lightID, s.handleLightStream)
 
@@ -300,6 +349,7 @@ func (s *Service) Start() error {
 		}
 		const setID = 0
 		s.host.cm.peerSetHandler.Incoming(setID, peerID)
+		events.Publish(s.eventBus, events.PeerConnected{ID: peerID})
 	}
 
 	// when a peer gets disconnected, we should clear all handshake data we have for it.
@@ -309,6 +359,7 @@ func (s *Service) Start() error {
 			prtl.peersData.deleteInboundHandshakeData(peerID)
 			prtl.peersData.deleteOutboundHandshakeData(peerID)
 		}
+		events.Publish(s.eventBus, events.PeerDisconnected{ID: peerID})
 	}
 
 	// log listening addresses to console
@@ -360,7 +411,9 @@ func (s *Service) updateMetrics() {
 		case <-s.ctx.Done():
 			return
 		case <-ticker.C:
-			peerCountGauge.Set(float64(s.host.peerCount()))
+			peerCount := float64(s.host.peerCount())
+			peerCountGauge.Set(peerCount)
+			substratePeerCountGauge.Set(peerCount)
 			connectionsGauge.Set(float64(len(s.host.p2pHost.Network().Conns())))
 			nodeLatencyGauge.Set(float64(
 				s.host.p2pHost.Peerstore().LatencyEWMA(s.host.id()).Milliseconds()))
@@ -372,10 +425,23 @@ func (s *Service) updateMetrics() {
 			outboundGrandpaStreamsGauge.Set(float64(s.getNumStreams(ConsensusMsgType, false)))
 			inboundStreamsGauge.Set(float64(s.getTotalStreams(true)))
 			outboundStreamsGauge.Set(float64(s.getTotalStreams(false)))
+			s.updateSlotMetrics()
 		}
 	}
 }
 
+// updateSlotMetrics sets the numbers of peers occupying the peer set slots, by category.
+func (s *Service) updateSlotMetrics() {
+	stats := s.host.cm.peerSetHandler.SlotStats(0)
+	peerSlotsGauge.WithLabelValues("inbound").Set(float64(stats.Inbound))
+	peerSlotsGauge.WithLabelValues("outbound").Set(float64(stats.Outbound))
+	peerSlotsGauge.WithLabelValues("validator").Set(float64(stats.Validators))
+	peerSlotsGauge.WithLabelValues("reserved").Set(float64(stats.Reserved))
+	peerSlotsLimitGauge.WithLabelValues("inbound").Set(float64(stats.MaxInbound))
+	peerSlotsLimitGauge.WithLabelValues("outbound").Set(float64(stats.MaxOutbound))
+	peerSlotsLimitGauge.WithLabelValues("validator").Set(float64(stats.MaxValidators))
+}
+
 func (s *Service) getTotalStreams(inbound bool) (count int64) {
 	for _, conn := range s.host.p2pHost.Network().Conns() {
 		for _, stream := range conn.GetStreams() {
@@ -468,6 +534,7 @@ func (s *Service) sentBlockIntervalTelemetry() {
 // are dependent on the host instance should be closed first)
 func (s *Service) Stop() error {
 	s.cancel()
+	s.blockAnnouncer.stop()
 
 	// close mDNS discovery service
 	err := s.mdns.Close()
@@ -521,10 +588,11 @@ func (s *Service) RegisterNotificationsProtocol(
 	s.notificationsProtocols[messageID] = np
 	decoder := createDecoder(np, handshakeDecoder, messageDecoder)
 	handlerWithValidate := s.createNotificationsMessageHandler(np, messageHandler, batchHandler)
+	queuedHandler := s.notificationsQueue.handler(messageID, handlerWithValidate)
 
 	s.host.registerStreamHandler(protocolID, func(stream libp2pnetwork.Stream) {
 		logger.Tracef("received stream using sub-protocol %s", protocolID)
-		s.readStream(stream, decoder, handlerWithValidate, maxSize)
+		s.readStream(stream, decoder, queuedHandler, maxSize)
 	})
 
 	logger.Infof("registered notifications sub-protocol %s", protocolID)
@@ -545,6 +613,12 @@ func (s *Service) GossipMessage(msg NotificationsMessage) {
 	logger.Debugf("gossiping from host %s message of type %d: %s",
 		s.host.id(), msg.Type(), msg)
 
+	// block announces are batched and only sent to the peers not knowing the blocks
+	if blockAnnounce, ok := msg.(*BlockAnnounceMessage); ok {
+		s.blockAnnouncer.announce(blockAnnounce)
+		return
+	}
+
 	// check if the message is part of a notifications protocol
 	s.notificationsMu.Lock()
 	defer s.notificationsMu.Unlock()
@@ -595,6 +669,8 @@ func (s *Service) GetRequestResponseProtocol(subprotocol string, requestTimeout
 		protocolID:      protocolID,
 		responseBuf:     make([]byte, maxResponseSize),
 		responseBufMu:   sync.Mutex{},
+		// block responses may be compressed
+		compressedResponses: subprotocol == SyncID,
 	}
 }
 
@@ -650,6 +726,56 @@ func (s *Service) Peers() []common.PeerInfo {
 	return peers
 }
 
+// ReleaseSyncOnlyPeers disconnects the connected peers not exchanging block announces
+// both ways with the node, keeping only as many of them as needed to stay at minPeers
+// connected peers. The peerset then reallocates the slots released to peers able to
+// gossip. Protected and persistent peers are never released.
+// It returns the number of peers released.
+func (s *Service) ReleaseSyncOnlyPeers(minPeers int) (released int) {
+	s.notificationsMu.RLock()
+	np := s.notificationsProtocols[blockAnnounceMsgType]
+	s.notificationsMu.RUnlock()
+
+	var gossipPeers int
+	var syncOnlyPeers []peer.ID
+	for _, p := range s.host.peers() {
+		if np.peersData.isFullDuplex(p) {
+			gossipPeers++
+			continue
+		}
+
+		_, persistent := s.host.cm.persistentPeers.Load(p)
+		if persistent || s.host.cm.IsProtected(p, "") {
+			continue
+		}
+
+		syncOnlyPeers = append(syncOnlyPeers, p)
+	}
+
+	toRelease := syncOnlyPeersToRelease(syncOnlyPeers, gossipPeers, minPeers)
+	if len(toRelease) == 0 {
+		return 0
+	}
+
+	s.host.cm.peerSetHandler.DisconnectPeer(0, toRelease...)
+	return len(toRelease)
+}
+
+// syncOnlyPeersToRelease returns the sync only peers to disconnect, keeping the
+// first ones given to make up for the gossip peers missing to reach minPeers.
+func syncOnlyPeersToRelease(syncOnlyPeers []peer.ID, gossipPeers, minPeers int) []peer.ID {
+	keep := minPeers - gossipPeers
+	if keep < 0 {
+		keep = 0
+	}
+
+	if keep >= len(syncOnlyPeers) {
+		return nil
+	}
+
+	return syncOnlyPeers[keep:]
+}
+
 // AddReservedPeers insert new peers to the peerstore with PermanentAddrTTL
 func (s *Service) AddReservedPeers(addrs ...string) error {
 	return s.host.addReservedPeers(addrs...)
@@ -660,6 +786,28 @@ func (s *Service) RemoveReservedPeers(addrs ...string) error {
 	return s.host.removeReservedPeers(addrs...)
 }
 
+// ReservedPeers returns the ids of the reserved peers.
+func (s *Service) ReservedPeers() []string {
+	reserved := s.host.cm.peerSetHandler.ReservedPeers()
+	peers := make([]string, len(reserved))
+	for i, peerID := range reserved {
+		peers[i] = peerID.String()
+	}
+	return peers
+}
+
+// SetValidatorPeers replaces the validator peers, set by the GRANDPA service to the peers
+// the votes of the authorities are received from, which occupy the peer slots reserved
+// to the validators.
+func (s *Service) SetValidatorPeers(peers peer.IDSlice) {
+	s.host.cm.peerSetHandler.SetValidatorPeers(0, peers...)
+}
+
+// ListenAddresses returns the multiaddresses the node is listening on.
+func (s *Service) ListenAddresses() ([]ma.Multiaddr, error) {
+	return s.host.listenMultiaddrs()
+}
+
 // NodeRoles Returns the roles the node is running as.
 func (s *Service) NodeRoles() common.NetworkRole {
 	return s.cfg.Roles
@@ -160,13 +160,23 @@ func TestBroadcastDuplicateMessage(t *testing.T) {
 
 	nodeA.host.messageCache = nil
 
-	// All 5 message will be sent since cache is disabled.
+	// The block is still not announced again although the cache is disabled,
+	// since the block announcer remembers the blocks known to the peer.
 	for i := 0; i < 5; i++ {
 		nodeA.GossipMessage(announceMessage)
 		time.Sleep(time.Millisecond * 10)
 	}
 
-	require.Equal(t, 6, len(handler.messages[nodeA.host.id()]))
+	time.Sleep(time.Millisecond * 500)
+	require.Equal(t, 1, len(handler.messages[nodeA.host.id()]))
+
+	nodeA.GossipMessage(&BlockAnnounceMessage{
+		Number: announceMessage.Number + 1,
+		Digest: types.NewDigest(),
+	})
+
+	time.Sleep(time.Millisecond * 500)
+	require.Equal(t, 2, len(handler.messages[nodeA.host.id()]))
 }
 
 func TestService_NodeRoles(t *testing.T) {
@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_syncOnlyPeersToRelease(t *testing.T) {
+	t.Parallel()
+
+	syncOnlyPeers := []peer.ID{"a", "b", "c"}
+
+	testCases := map[string]struct {
+		gossipPeers int
+		minPeers    int
+		released    []peer.ID
+	}{
+		"enough_gossip_peers": {
+			gossipPeers: 5,
+			minPeers:    5,
+			released:    []peer.ID{"a", "b", "c"},
+		},
+		"keep_missing_peers": {
+			gossipPeers: 3,
+			minPeers:    5,
+			released:    []peer.ID{"c"},
+		},
+		"keep_all_peers": {
+			gossipPeers: 1,
+			minPeers:    5,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			released := syncOnlyPeersToRelease(syncOnlyPeers, testCase.gossipPeers, testCase.minPeers)
+			assert.Equal(t, testCase.released, released)
+		})
+	}
+}
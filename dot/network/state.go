@@ -55,15 +55,19 @@ type PeerAdd interface {
 	Incoming(int, ...peer.ID)
 	AddReservedPeer(int, ...peer.ID)
 	AddPeer(int, ...peer.ID)
+	SetValidatorPeers(int, ...peer.ID)
 }
 
 // PeerRemove is the interface used by the PeerSetHandler to remove peers from peerSet.
 type PeerRemove interface {
 	RemoveReservedPeer(int, ...peer.ID)
+	DisconnectPeer(int, ...peer.ID)
 }
 
 // Peer is the interface used by the PeerSetHandler to get the peer data from peerSet.
 type Peer interface {
 	SortedPeers(idx int) chan peer.IDSlice
+	ReservedPeers() peer.IDSlice
+	SlotStats(setID int) peerset.SlotStats
 	Messages() chan peerset.Message
 }
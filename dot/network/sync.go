@@ -4,8 +4,33 @@
 package network
 
 import (
+	"errors"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	blockRequestsServedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_sync",
+		Name:      "block_requests_served_total",
+		Help:      "total number of inbound block requests handled, by direction and outcome",
+	}, []string{"direction", "outcome"})
+	blocksServedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_network_sync",
+		Name:      "blocks_served_total",
+		Help:      "total number of blocks sent in the responses to the inbound block requests",
+	})
+	blockRequestDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "gossamer_network_sync",
+		Name:      "block_request_duration_seconds",
+		Help:      "duration of the handling of the inbound block requests answered",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+	})
 )
 
 // handleSyncStream handles streams with the <protocol-id>/sync/2 protocol ID
@@ -38,16 +63,55 @@ func (s *Service) handleSyncMessage(stream libp2pnetwork.Stream, msg Message) er
 	}()
 
 	if req, ok := msg.(*BlockRequestMessage); ok {
-		resp, err := s.syncer.CreateBlockResponse(req)
+		start := time.Now()
+		direction := req.Direction.String()
+		who := stream.Conn().RemotePeer()
+
+		err := req.validate()
+		if err != nil {
+			blockRequestsServedCounter.WithLabelValues(direction, "invalid").Inc()
+			logger.Debugf("invalid block request from peer %s: %s", who, err)
+			s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, who)
+			return nil
+		}
+
+		change, ok, err := s.blockRequestLimiter.check(who, req)
 		if err != nil {
+			return err
+		} else if !ok {
+			blockRequestsServedCounter.WithLabelValues(direction, "refused").Inc()
+			logger.Debugf("refusing block request from peer %s: %s", who, change.Reason)
+			s.host.cm.peerSetHandler.ReportPeer(change, who)
+			return nil
+		}
+
+		resp, err := s.syncer.CreateBlockResponse(req)
+		if errors.Is(err, ErrInvalidBlockRequest) {
+			blockRequestsServedCounter.WithLabelValues(direction, "invalid").Inc()
+			logger.Debugf("invalid block request from peer %s: %s", who, err)
+			s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, who)
+			return nil
+		} else if err != nil {
+			blockRequestsServedCounter.WithLabelValues(direction, "failed").Inc()
 			logger.Debugf("cannot create response for request: %s", err)
 			return nil
 		}
 
 		if err = s.host.writeToStream(stream, resp); err != nil {
-			logger.Debugf("failed to send BlockResponse message to peer %s: %s", stream.Conn().RemotePeer(), err)
+			blockRequestsServedCounter.WithLabelValues(direction, "write_failed").Inc()
+			logger.Debugf("failed to send BlockResponse message to peer %s: %s", who, err)
 			return err
 		}
+
+		blockRequestsServedCounter.WithLabelValues(direction, "success").Inc()
+		blocksServedCounter.Add(float64(len(resp.BlockData)))
+		blockRequestDuration.Observe(time.Since(start).Seconds())
 	}
 
 	return nil
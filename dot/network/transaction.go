@@ -45,7 +45,7 @@ func (tm *TransactionMessage) Encode() ([]byte, error) {
 
 // Decode the message into a TransactionMessage
 func (tm *TransactionMessage) Decode(in []byte) error {
-	return scale.Unmarshal(in, &tm.Extrinsics)
+	return UnmarshalMessage(in, &tm.Extrinsics)
 }
 
 // Hash returns the hash of the TransactionMessage
@@ -4,14 +4,9 @@
 package network
 
 import (
-	crand "crypto/rand"
-	"encoding/hex"
 	"fmt"
 	"io"
 	mrand "math/rand"
-	"os"
-	"path"
-	"path/filepath"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
@@ -59,78 +54,24 @@ func stringsToAddrInfos(peers []string) ([]peer.AddrInfo, error) {
 	return pinfos, nil
 }
 
-// generateKey generates an ed25519 private key and writes it to the data directory
+// generateKey generates an ed25519 private key and writes it to the node key file given.
 // If the seed is zero, we use real cryptographic randomness. Otherwise, we use a
 // deterministic randomness source to make keys the same across multiple runs.
-func generateKey(seed int64, fp string) (crypto.PrivKey, error) {
-	var r io.Reader
-	if seed == 0 {
-		r = crand.Reader
-	} else {
-		r = mrand.New(mrand.NewSource(seed)) //nolint:gosec
+func generateKey(seed int64, file string) (crypto.PrivKey, error) {
+	if seed != 0 {
+		r := mrand.New(mrand.NewSource(seed)) //nolint:gosec
+		key, _, err := crypto.GenerateEd25519Key(r)
+		return key, err
 	}
-	key, _, err := crypto.GenerateEd25519Key(r)
-	if err != nil {
-		return nil, err
-	}
-	if seed == 0 {
-		if err = makeDir(fp); err != nil {
-			return nil, err
-		}
-		if err = saveKey(key, fp); err != nil {
-			return nil, err
-		}
-	}
-	return key, nil
-}
 
-// loadKey attempts to load a private key from the provided filepath
-func loadKey(fp string) (crypto.PrivKey, error) {
-	pth := path.Join(filepath.Clean(fp), DefaultKeyFile)
-	if _, err := os.Stat(pth); os.IsNotExist(err) {
-		return nil, nil
-	}
-	keyData, err := os.ReadFile(filepath.Clean(pth))
+	key, err := GenerateNodeKey()
 	if err != nil {
 		return nil, err
 	}
-	dec := make([]byte, hex.DecodedLen(len(keyData)))
-	_, err = hex.Decode(dec, keyData)
-	if err != nil {
+	if err = SaveNodeKey(key, file); err != nil {
 		return nil, err
 	}
-	return crypto.UnmarshalEd25519PrivateKey(dec)
-}
-
-// makeDir makes directory if directory does not already exist
-func makeDir(fp string) error {
-	_, e := os.Stat(fp)
-	if os.IsNotExist(e) {
-		e = os.Mkdir(fp, os.ModePerm)
-		if e != nil {
-			return e
-		}
-	}
-	return e
-}
-
-// saveKey attempts to save a private key to the provided filepath
-func saveKey(priv crypto.PrivKey, fp string) (err error) {
-	pth := path.Join(filepath.Clean(fp), DefaultKeyFile)
-	f, err := os.Create(filepath.Clean(pth))
-	if err != nil {
-		return err
-	}
-	raw, err := priv.Raw()
-	if err != nil {
-		return err
-	}
-	enc := make([]byte, hex.EncodedLen(len(raw)))
-	hex.Encode(enc, raw)
-	if _, err = f.Write(enc); err != nil {
-		return err
-	}
-	return f.Close()
+	return key, nil
 }
 
 func uint64ToLEB128(in uint64) []byte {
@@ -197,6 +138,13 @@ func readStream(stream libp2pnetwork.Stream, bufPointer *[]byte, maxSize uint64)
 		return 0, nil // msg length of 0 is allowed, for example transactions handshake
 	}
 
+	// the length is checked before growing the buffer, so a peer
+	// cannot make us allocate more than the maximum size.
+	if length > maxSize {
+		logger.Warnf("received message with size %d greater than max size %d, closing stream", length, maxSize)
+		return 0, fmt.Errorf("%w: max %d, got %d", ErrGreaterThanMaxSize, maxSize, length)
+	}
+
 	buf := *bufPointer
 	if length > uint64(len(buf)) {
 		logger.Warnf("received message with size %d greater than allocated message buffer size %d", length, len(buf))
@@ -205,15 +153,11 @@ func readStream(stream libp2pnetwork.Stream, bufPointer *[]byte, maxSize uint64)
 		buf = *bufPointer
 	}
 
-	if length > maxSize {
-		logger.Warnf("received message with size %d greater than max size %d, closing stream", length, maxSize)
-		return 0, fmt.Errorf("%w: max %d, got %d", ErrGreaterThanMaxSize, maxSize, length)
-	}
-
 	for tot < int(length) {
 		n, err := stream.Read(buf[tot:])
 		if err != nil {
-			return n + tot, err
+			return n + tot, fmt.Errorf("%w: expected %d bytes, received %d bytes: %w",
+				ErrFailedToReadEntireMessage, length, n+tot, err)
 		}
 
 		tot += n
@@ -5,6 +5,8 @@
 
 import (
 	"bytes"
+	"io"
+	"path/filepath"
 	"testing"
 
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
@@ -81,17 +83,17 @@ func TestStringsToAddrInfos(t *testing.T) {
 func TestGenerateKey(t *testing.T) {
 	testDir := t.TempDir()
 
-	keyA, err := generateKey(0, testDir)
+	keyA, err := generateKey(0, filepath.Join(testDir, "a.key"))
 	require.NoError(t, err)
 
-	keyB, err := generateKey(0, testDir)
+	keyB, err := generateKey(0, filepath.Join(testDir, "b.key"))
 	require.NoError(t, err)
 	require.NotEqual(t, keyA, keyB)
 
-	keyC, err := generateKey(1, testDir)
+	keyC, err := generateKey(1, filepath.Join(testDir, "c.key"))
 	require.NoError(t, err)
 
-	keyD, err := generateKey(1, testDir)
+	keyD, err := generateKey(1, filepath.Join(testDir, "c.key"))
 	require.NoError(t, err)
 	require.Equal(t, keyC, keyD)
 }
@@ -274,6 +276,32 @@ func TestReadStream(t *testing.T) {
 			errString:  "greater than maximum size: max 9, got 10",
 			maxSize:    9,
 		},
+
+		"stream_closed_before_entire_message": {
+			buildStreamMock: func(ctrl *gomock.Controller) libp2pnetwork.Stream {
+				input := []byte{0xa, //size 0xa == 10
+					0x01, 0x01, 0x01, // truncated data
+				}
+
+				streamBuf := new(bytes.Buffer)
+				_, err := streamBuf.Write(input)
+				require.NoError(t, err)
+
+				streamMock := NewMockStream(ctrl)
+
+				streamMock.EXPECT().Read(gomock.Any()).
+					DoAndReturn(func(buf any) (n, err any) {
+						return streamBuf.Read(buf.([]byte))
+					}).Times(3)
+
+				return streamMock
+			},
+			bufPointer:     &[]byte{0}, // a buffer with size 1
+			wantErr:        io.EOF,
+			errString:      "failed to read entire message: expected 10 bytes, received 3 bytes: EOF",
+			expectedOutput: 3,
+			maxSize:        11,
+		},
 	}
 
 	for tname, tt := range cases {
@@ -12,7 +12,6 @@
 	"runtime/debug"
 	"sync"
 	"syscall"
-	"time"
 
 	cfg "github.com/ChainSafe/gossamer/config"
 	"github.com/ChainSafe/gossamer/dot/core"
@@ -46,31 +45,57 @@ type Node struct {
 	wg              sync.WaitGroup
 	started         chan struct{}
 	metricsServer   *metrics.Server
+	reloader        *configReloader
 }
 
-type nodeBuilderIface interface {
+// stateBuilder creates the state of the node and the runtime stored in it
+type stateBuilder interface {
 	initNode(config *cfg.Config) error
 	createStateService(config *cfg.Config) (*state.Service, error)
-	createNetworkService(config *cfg.Config, stateSrvc *state.Service, telemetryMailer Telemetry) (*network.Service,
-		error)
 	createRuntimeStorage(st *state.Service) (*runtime.NodeStorage, error)
 	loadRuntime(config *cfg.Config, ns *runtime.NodeStorage, stateSrvc *state.Service, ks *keystore.GlobalKeystore,
 		net *network.Service) error
+}
+
+// networkBuilder creates the p2p network service
+type networkBuilder interface {
+	createNetworkService(config *cfg.Config, stateSrvc *state.Service, telemetryMailer Telemetry) (*network.Service,
+		error)
+}
+
+// consensusBuilder creates the block production and finality services
+type consensusBuilder interface {
 	createBlockVerifier(st *state.Service) *babe.VerificationManager
 	createDigestHandler(st *state.Service) (*digest.Handler, error)
-	createCoreService(config *cfg.Config, ks *keystore.GlobalKeystore, st *state.Service, net *network.Service,
-	) (*core.Service, error)
 	createGRANDPAService(config *cfg.Config, st *state.Service, ks KeyStore,
 		net *network.Service, telemetryMailer Telemetry) (*grandpa.Service, error)
+	createBABEService(config *cfg.Config, st *state.Service, ks KeyStore, cs *core.Service,
+		telemetryMailer Telemetry) (service *babe.Service, err error)
+}
+
+// syncBuilder creates the block import and sync services
+type syncBuilder interface {
+	createCoreService(config *cfg.Config, ks *keystore.GlobalKeystore, st *state.Service, net *network.Service,
+	) (*core.Service, error)
 	newSyncService(config *cfg.Config, st *state.Service, finalityGadget BlockJustificationVerifier,
 		verifier *babe.VerificationManager, cs *core.Service, net *network.Service,
 		telemetryMailer Telemetry) (*dotsync.Service, error)
-	createBABEService(config *cfg.Config, st *state.Service, ks KeyStore, cs *core.Service,
-		telemetryMailer Telemetry) (service *babe.Service, err error)
+}
+
+// rpcBuilder creates the services exposed to the node users
+type rpcBuilder interface {
 	createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*system.Service, error)
 	createRPCService(params rpcServiceSettings) (*rpc.HTTPServer, error)
 }
 
+type nodeBuilderIface interface {
+	stateBuilder
+	networkBuilder
+	consensusBuilder
+	syncBuilder
+	rpcBuilder
+}
+
 var _ nodeBuilderIface = (*nodeBuilder)(nil)
 
 type nodeBuilder struct{}
@@ -78,23 +103,14 @@ type nodeBuilder struct{
 // IsNodeInitialised returns true if, within the configured data directory for the
 // node, the state database has been created and the genesis data can been loaded
 func IsNodeInitialised(basepath string) (bool, error) {
-	// check if key registry exists
+	// check if a database exists, on disk or in memory
 	nodeDatabaseDir := filepath.Join(basepath, database.DefaultDatabaseDir)
-
-	_, err := os.Stat(nodeDatabaseDir)
+	backend, err := database.DetectBackend(nodeDatabaseDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
-		}
 		return false, err
 	}
 
-	entries, err := os.ReadDir(nodeDatabaseDir)
-	if err != nil {
-		return false, fmt.Errorf("failed to read dir %s: %w", nodeDatabaseDir, err)
-	}
-
-	if len(entries) == 0 {
+	if backend == "" {
 		return false, nil
 	}
 
@@ -132,6 +148,10 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 		return fmt.Errorf("failed to parse log level: %w", err)
 	}
 	logger.Patch(log.SetLevel(globalLogLevel))
+	err = setLogFormat(config.LogFormat)
+	if err != nil {
+		return err
+	}
 	logger.Infof(
 		"🕸️ initialising node with name %s, id %s, base path %s and chain-spec %s...",
 		config.Name, config.ID, config.BasePath, config.ChainSpec)
@@ -144,7 +164,11 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 
 	if !gen.IsRaw() {
 		// genesis is human-readable, convert to raw
-		err = gen.ToRaw()
+		if gen.Genesis.Runtime == nil && gen.Genesis.RuntimeGenesis != nil {
+			err = buildRuntimeGenesis(gen)
+		} else {
+			err = gen.ToRaw()
+		}
 		if err != nil {
 			return fmt.Errorf("failed to convert genesis-spec to raw genesis: %w", err)
 		}
@@ -179,8 +203,9 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 			Mode:           config.Pruning,
 			RetainedBlocks: config.RetainBlocks,
 		},
-		Telemetry: telemetryMailer,
-		Metrics:   metrics.NewIntervalConfig(config.PrometheusExternal),
+		Telemetry:       telemetryMailer,
+		Metrics:         metrics.NewIntervalConfig(config.PrometheusExternal),
+		DatabaseBackend: database.Backend(config.State.DatabaseBackend),
 	}
 
 	// create new state service
@@ -265,167 +290,35 @@ func newNode(config *cfg.Config,
 	}
 
 	logger.Patch(log.SetLevel(globalLogLevel))
-
-	logger.Infof(
-		"🕸️ initialising node services with global configuration name %s, id %s and base path %s...",
-		config.Name, config.ID, config.BasePath)
-
-	var (
-		nodeSrvcs   []service
-		networkSrvc *network.Service
-	)
-
-	if config.Pprof.Enabled {
-		nodeSrvcs = append(nodeSrvcs, createPprofService(*config.Pprof))
-	}
-
-	stateSrvc, err := builder.createStateService(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create state service: %s", err)
-	}
-
-	gd, err := stateSrvc.Base.LoadGenesisData()
-	if err != nil {
-		return nil, fmt.Errorf("cannot load genesis data: %w", err)
-	}
-
-	telemetryMailer, err := setupTelemetry(config, gd)
-	if err != nil {
-		return nil, fmt.Errorf("cannot setup telemetry mailer: %w", err)
-	}
-
-	stateSrvc.Telemetry = telemetryMailer
-
-	err = startStateService(*config.State, stateSrvc)
-	if err != nil {
-		return nil, fmt.Errorf("cannot start state service: %w", err)
-	}
-
-	systemInfo := &types.SystemInfo{
-		SystemName:    config.System.SystemName,
-		SystemVersion: config.System.SystemVersion,
-	}
-
-	sysSrvc, err := builder.createSystemService(systemInfo, stateSrvc)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create system service: %s", err)
-	}
-
-	nodeSrvcs = append(nodeSrvcs, sysSrvc)
-
-	// check if network service is enabled
-	if enabled := networkServiceEnabled(config); enabled {
-		// create network service and append network service to node services
-		networkSrvc, err = builder.createNetworkService(config, stateSrvc, telemetryMailer)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create network service: %s", err)
-		}
-		nodeSrvcs = append(nodeSrvcs, networkSrvc)
-		startupTime := fmt.Sprint(time.Now().UnixNano())
-		genesisHash := stateSrvc.Block.GenesisHash()
-		netstate := networkSrvc.NetworkState()
-
-		//sent NewSystemConnectedTM only if networkServiceEnabled
-		connectedMsg := telemetry.NewSystemConnected(
-			config.Core.GrandpaAuthority,
-			sysSrvc.ChainName(),
-			&genesisHash,
-			sysSrvc.SystemName(),
-			config.BaseConfig.Name,
-			netstate.PeerID,
-			startupTime,
-			sysSrvc.SystemVersion())
-
-		telemetryMailer.SendMessage(connectedMsg)
-	} else {
-		// do not create or append network service if network service is not enabled
-		logger.Debugf("network service disabled, role is %d", config.Core.Role)
-	}
-
-	// create runtime
-	ns, err := builder.createRuntimeStorage(stateSrvc)
+	err = setLogFormat(config.LogFormat)
 	if err != nil {
 		return nil, err
 	}
 
-	err = builder.loadRuntime(config, ns, stateSrvc, ks, networkSrvc)
-	if err != nil {
-		return nil, err
-	}
-
-	ver := builder.createBlockVerifier(stateSrvc)
-
-	dh, err := builder.createDigestHandler(stateSrvc)
-	if err != nil {
-		return nil, err
-	}
-	nodeSrvcs = append(nodeSrvcs, dh)
-
-	coreSrvc, err := builder.createCoreService(config, ks, stateSrvc, networkSrvc)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create core service: %s", err)
-	}
-	nodeSrvcs = append(nodeSrvcs, coreSrvc)
-
-	fg, err := builder.createGRANDPAService(config, stateSrvc, ks.Gran, networkSrvc, telemetryMailer)
-	if err != nil {
-		return nil, err
-	}
-	nodeSrvcs = append(nodeSrvcs, fg)
-
-	syncer, err := builder.newSyncService(config, stateSrvc, fg, ver, coreSrvc, networkSrvc, telemetryMailer)
-	if err != nil {
-		return nil, err
-	}
-
-	if networkSrvc != nil {
-		networkSrvc.SetSyncer(syncer)
-		networkSrvc.SetTransactionHandler(coreSrvc)
-	}
-	nodeSrvcs = append(nodeSrvcs, syncer)
+	logger.Infof(
+		"🕸️ initialising node services with global configuration name %s, id %s and base path %s...",
+		config.Name, config.ID, config.BasePath)
 
-	bp, err := builder.createBABEService(config, stateSrvc, ks.Babe, coreSrvc, telemetryMailer)
+	nodeAssembly := newAssembly(config, ks, builder)
+	err = nodeAssembly.build(nodeComponents(config))
 	if err != nil {
 		return nil, err
 	}
-	nodeSrvcs = append(nodeSrvcs, bp)
-
-	// check if rpc service is enabled
-	if enabled := config.RPC.IsRPCEnabled() || config.RPC.IsWSEnabled(); enabled {
-		var rpcSrvc *rpc.HTTPServer
-		cRPCParams := rpcServiceSettings{
-			config:        config,
-			nodeStorage:   ns,
-			state:         stateSrvc,
-			core:          coreSrvc,
-			network:       networkSrvc,
-			blockProducer: bp,
-			system:        sysSrvc,
-			blockFinality: fg,
-			syncer:        syncer,
-		}
-		rpcSrvc, err = builder.createRPCService(cRPCParams)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create rpc service: %s", err)
-		}
-		nodeSrvcs = append(nodeSrvcs, rpcSrvc)
-	} else {
-		logger.Debug("rpc service disabled by default")
-	}
-
-	// close state service last
-	nodeSrvcs = append(nodeSrvcs, stateSrvc)
 
 	node := &Node{
 		Name:            config.Name,
 		ServiceRegistry: serviceRegistry,
 		started:         make(chan struct{}),
+		reloader:        nodeAssembly.reloader,
 	}
 
-	for _, srvc := range nodeSrvcs {
+	for _, srvc := range nodeAssembly.services {
 		node.ServiceRegistry.RegisterService(srvc)
 	}
 
+	// close state service last
+	node.ServiceRegistry.RegisterService(nodeAssembly.state)
+
 	if config.PrometheusExternal {
 		address := fmt.Sprintf(":%d", config.PrometheusPort)
 		node.metricsServer = metrics.NewServer(address)
@@ -438,6 +331,21 @@ func newNode(config *cfg.Config,
 	return node, nil
 }
 
+// setLogFormat sets the format of the lines of all the loggers, if a format is given.
+func setLogFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+
+	logFormat, err := log.ParseFormat(format)
+	if err != nil {
+		return fmt.Errorf("cannot parse log format: %w", err)
+	}
+
+	log.Patch(log.SetFormat(logFormat))
+	return nil
+}
+
 func setupTelemetry(config *cfg.Config, genesisData *genesis.Data) (mailer Telemetry, err error) {
 	if config.NoTelemetry {
 		return telemetry.NewNoopMailer(), nil
@@ -500,11 +408,37 @@ func (n *Node) Start() error {
 		n.Stop()
 	}()
 
+	stopReload := make(chan struct{})
+	if n.reloader != nil {
+		go n.reloadOnHangup(stopReload)
+	}
+
 	close(n.started)
 	n.wg.Wait()
+	close(stopReload)
 	return nil
 }
 
+// reloadOnHangup reloads the node configuration each time a SIGHUP is received,
+// until stop is closed.
+func (n *Node) reloadOnHangup(stop <-chan struct{}) {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGHUP)
+	defer signal.Stop(sigc)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-sigc:
+			logger.Info("hangup signal, reloading configuration...")
+			if err := n.reloader.ReloadConfig(); err != nil {
+				logger.Errorf("cannot reload configuration: %s", err)
+			}
+		}
+	}
+}
+
 // Stop stops all dot node services
 func (n *Node) Stop() {
 	// stop all node services
@@ -540,7 +474,7 @@ func (nodeBuilder) loadRuntime(config *cfg.Config, ns *runtime.NodeStorage,
 			continue
 		}
 
-		rt, err := createRuntime(config, *ns, stateSrvc, ks, net, code)
+		rt, err := createRuntime(config, *ns, stateSrvc, ks, net, *hash, code)
 		if err != nil {
 			return err
 		}
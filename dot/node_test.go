@@ -172,6 +172,31 @@ func TestNodeInitialized(t *testing.T) {
 	}
 }
 
+func TestNodeInitialized_memoryDatabase(t *testing.T) {
+	config := DefaultTestWestendDevConfig(t)
+	config.ChainSpec = NewTestGenesisRawFile(t, config)
+	config.State.DatabaseBackend = string(database.MemoryBackend)
+	t.Cleanup(func() {
+		err := database.ClearDatabase(config.BasePath)
+		require.NoError(t, err)
+	})
+
+	nodeInstance := nodeBuilder{}
+	err := nodeInstance.initNode(config)
+	require.NoError(t, err)
+
+	initialised, err := IsNodeInitialised(config.BasePath)
+	require.NoError(t, err)
+	assert.True(t, initialised)
+
+	_, err = os.Stat(filepath.Join(config.BasePath, database.DefaultDatabaseDir))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	nodeName, err := LoadGlobalNodeName(config.BasePath)
+	require.NoError(t, err)
+	assert.Equal(t, config.Name, nodeName)
+}
+
 func initKeystore(t *testing.T, cfg *cfg.Config) (
 	globalKeyStore *keystore.GlobalKeystore, err error) {
 	ks := keystore.NewGlobalKeystore()
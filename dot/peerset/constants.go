@@ -63,6 +63,26 @@
 	// BadJustificationReason is used when peer send invalid justification.
 	BadJustificationReason = "Bad justification"
 
+	// OversizedResponseValue is used when peer sends a response greater than the maximum size.
+	OversizedResponseValue Reputation = -(1 << 16)
+	// OversizedResponseReason is used when peer sends a response greater than the maximum size.
+	OversizedResponseReason = "Oversized response"
+
+	// TruncatedResponseValue is used when peer closes the stream before sending the entire response.
+	TruncatedResponseValue Reputation = -(1 << 12)
+	// TruncatedResponseReason is used when peer closes the stream before sending the entire response.
+	TruncatedResponseReason = "Truncated response"
+
+	// SameBlockRequestValue is used when peer sends the same block request multiple times.
+	SameBlockRequestValue Reputation = math.MinInt32
+	// SameBlockRequestReason is used when peer sends the same block request multiple times.
+	SameBlockRequestReason = "Same block request multiple times"
+
+	// BlockRequestFloodValue is used when peer floods us with block requests for a few blocks.
+	BlockRequestFloodValue Reputation = -(1 << 12)
+	// BlockRequestFloodReason is used when peer floods us with block requests for a few blocks.
+	BlockRequestFloodReason = "Block request flood"
+
 	// GenesisMismatch is used when peer has a different genesis
 	GenesisMismatch Reputation = math.MinInt32
 	// GenesisMismatchReason used when a peer has a different genesis
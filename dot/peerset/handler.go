@@ -57,6 +57,26 @@ func (h *Handler) SetReservedPeer(setID int, peers ...peer.ID) {
 	}
 }
 
+// ReservedPeers returns the reserved peers of the peerSet.
+func (h *Handler) ReservedPeers() peer.IDSlice {
+	return h.peerSet.reservedPeers()
+}
+
+// SetValidatorPeers replaces the validator peers of the peerSet, which occupy the
+// slots reserved to the validators.
+func (h *Handler) SetValidatorPeers(setID int, peers ...peer.ID) {
+	h.actionQueue <- action{
+		actionCall: setValidatorPeers,
+		setID:      setID,
+		peers:      peers,
+	}
+}
+
+// SlotStats returns the numbers of peers occupying the slots of the set, by category.
+func (h *Handler) SlotStats(setID int) SlotStats {
+	return h.peerSet.peerState.slotStats(setID)
+}
+
 // AddPeer adds peer to peerSet.
 func (h *Handler) AddPeer(setID int, peers ...peer.ID) {
 	h.actionQueue <- action{
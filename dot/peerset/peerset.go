@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -55,6 +56,8 @@
 	sortedPeers
 	// disconnect peer
 	disconnect
+	// setValidatorPeers is for setting the validator peers of the peerSet
+	setValidatorPeers
 )
 
 func (a ActionReceiver) String() string {
@@ -79,6 +82,8 @@ func (a ActionReceiver) String() string {
 		return "sortedPeers"
 	case disconnect:
 		return "disconnect"
+	case setValidatorPeers:
+		return "setValidatorPeers"
 	default:
 		return "invalid action"
 	}
@@ -168,6 +173,24 @@ func newReputationChange(value Reputation, reason string) ReputationChange {
 	return ReputationChange{value, reason}
 }
 
+// SlotStats are the numbers of peers occupying the slots of a set, by category.
+type SlotStats struct {
+	// Inbound and MaxInbound are the number and the maximum number of peers
+	// occupying an incoming slot.
+	Inbound    uint32
+	MaxInbound uint32
+	// Outbound and MaxOutbound are the number and the maximum number of peers
+	// occupying an outgoing slot.
+	Outbound    uint32
+	MaxOutbound uint32
+	// Validators and MaxValidators are the number and the maximum number of validator
+	// peers occupying a validator slot.
+	Validators    uint32
+	MaxValidators uint32
+	// Reserved is the number of connected reserved peers, which don't occupy any slot.
+	Reserved uint32
+}
+
 // PeerSet is a container for all the components of a peerSet.
 type PeerSet struct {
 	sync.Mutex
@@ -198,6 +221,9 @@ type config struct {
 	maxInPeers uint32
 	// maximum number of slot occupying nodes for outgoing connections.
 	maxOutPeers uint32
+	// maximum number of validator nodes occupying the slots reserved to the validators,
+	// in addition to the incoming and outgoing slots.
+	maxValidatorPeers uint32
 
 	// TODO Use in future for reserved only peers
 	// if true, we only accept reservedNodes (#1888).
@@ -213,10 +239,12 @@ type ConfigSet struct {
 }
 
 // NewConfigSet creates a new config set for the peerSet
-func NewConfigSet(maxInPeers, maxOutPeers uint32, reservedOnly bool, allocTime time.Duration) *ConfigSet {
+func NewConfigSet(maxInPeers, maxOutPeers, maxValidatorPeers uint32, reservedOnly bool,
+	allocTime time.Duration) *ConfigSet {
 	set := &config{
 		maxInPeers:        maxInPeers,
 		maxOutPeers:       maxOutPeers,
+		maxValidatorPeers: maxValidatorPeers,
 		reservedOnly:      reservedOnly,
 		periodicAllocTime: allocTime,
 	}
@@ -415,6 +443,34 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 		return nil
 	}
 
+	for peerState.hasFreeValidatorSlot(setIdx) {
+		peerID := peerState.highestNotConnectedValidator(setIdx)
+		if peerID == "" {
+			break
+		}
+
+		node, err := peerState.getNode(peerID)
+		if err != nil {
+			return fmt.Errorf("cannot get node: %w", err)
+		}
+
+		if node.reputation < BannedThresholdValue {
+			logger.Debugf("highest rated validator peer %s is below bannedThresholdValue", peerID)
+			break
+		}
+
+		if err = peerState.tryOutgoing(setIdx, peerID); err != nil {
+			logger.Errorf("could not set validator peer %s as outgoing connection: %s", peerID, err)
+			break
+		}
+
+		ps.resultMsgCh <- Message{
+			Status: Connect,
+			setID:  uint64(setIdx),
+			PeerID: peerID,
+		}
+	}
+
 	for peerState.hasFreeOutgoingSlot(setIdx) {
 		peerID := peerState.highestNotConnectedPeer(setIdx)
 		if peerID == "" {
@@ -506,6 +562,26 @@ func (ps *PeerSet) removeReservedPeers(setID int, peers ...peer.ID) error {
 	return nil
 }
 
+// reservedPeers returns the reserved peers, sorted.
+func (ps *PeerSet) reservedPeers() peer.IDSlice {
+	ps.reservedLock.RLock()
+	defer ps.reservedLock.RUnlock()
+
+	peers := make(peer.IDSlice, 0, len(ps.reservedNode))
+	for peerID := range ps.reservedNode {
+		peers = append(peers, peerID)
+	}
+	sort.Sort(peers)
+	return peers
+}
+
+// setValidatorPeers replaces the validator peers, which occupy the validator slots, and
+// allocates the slots freed.
+func (ps *PeerSet) setValidatorPeers(setID int, peers ...peer.ID) error {
+	ps.peerState.setValidatorNodes(setID, peers...)
+	return ps.allocSlots(setID)
+}
+
 func (ps *PeerSet) setReservedPeer(setID int, peers ...peer.ID) error {
 	toInsert := make([]peer.ID, 0, len(peers))
 	toRemove := make([]peer.ID, 0, len(peers))
@@ -768,6 +844,8 @@ func (ps *PeerSet) listenActionAllocSlots(ctx context.Context) {
 				act.resultPeersCh <- ps.peerState.sortedPeers(act.setID)
 			case disconnect:
 				err = ps.disconnect(act.setID, UnknownDrop, act.peers...)
+			case setValidatorPeers:
+				err = ps.setValidatorPeers(act.setID, act.peers...)
 			}
 
 			if err != nil {
@@ -4,6 +4,8 @@
 package peerset
 
 import (
+	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -94,6 +96,10 @@ func TestAddReservedPeers(t *testing.T) {
 		checkPeerStateSetNumOut(t, ps.peerState, testSetID, 1)
 	}
 
+	expectedReserved := peer.IDSlice{reservedPeer, reservedPeer2}
+	sort.Sort(expectedReserved)
+	require.Equal(t, expectedReserved, handler.ReservedPeers())
+
 	expectedMsgs := []Message{
 		{Status: Connect, setID: 0, PeerID: bootNode},
 		{Status: Connect, setID: 0, PeerID: reservedPeer},
@@ -406,3 +412,27 @@ func checkPeerSetReservedNodeCount(t *testing.T, ps *PeerSet, expectedCount int)
 
 	require.Equal(t, expectedCount, len(ps.reservedNode))
 }
+
+func TestSetValidatorPeers(t *testing.T) {
+	const testSetID = 0
+
+	t.Parallel()
+
+	handler, err := NewPeerSetHandler(NewConfigSet(0, 0, 1, false, allocTimeDuration))
+	require.NoError(t, err)
+	handler.Start(context.Background())
+	t.Cleanup(handler.Stop)
+
+	ps := handler.peerSet
+	handler.SetValidatorPeers(testSetID, peer1)
+	time.Sleep(100 * time.Millisecond)
+
+	// the validator peer is connected to although there is no outgoing slot.
+	require.Len(t, ps.resultMsgCh, 1)
+	msg := <-ps.resultMsgCh
+	require.Equal(t, Connect, msg.Status)
+	require.Equal(t, peer1, msg.PeerID)
+
+	expected := SlotStats{Validators: 1, MaxValidators: 1}
+	require.Equal(t, expected, handler.SlotStats(testSetID))
+}
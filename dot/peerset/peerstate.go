@@ -51,6 +51,17 @@ type Info struct {
 	// maximum allowed number of slot occupying nodes for which the MembershipState is outgoing.
 	maxOut uint32
 
+	// maximum allowed number of validator nodes occupying a validator slot, the validator
+	// slots being reserved to the validators in addition to the incoming and outgoing slots.
+	maxValidators uint32
+
+	// list of node identities of the validators, as known from the votes of the GRANDPA authorities.
+	validatorNodes map[peer.ID]struct{}
+
+	// list of connected validator node identities occupying a validator slot instead of
+	// an incoming or outgoing slot.
+	validatorSlotNodes map[peer.ID]struct{}
+
 	// list of node identities (discovered or not) that don't occupy slots.
 	// Note for future readers: this module is purely dedicated to managing slots.
 	// If you are considering adding more features, please consider doing so outside this module rather
@@ -130,6 +141,10 @@ func NewPeerState(cfgs []*config) (*PeersState, error) {
 			maxIn:       cfg.maxInPeers,
 			maxOut:      cfg.maxOutPeers,
 			noSlotNodes: make(map[peer.ID]struct{}),
+
+			maxValidators:      cfg.maxValidatorPeers,
+			validatorNodes:     make(map[peer.ID]struct{}),
+			validatorSlotNodes: make(map[peer.ID]struct{}),
 		}
 
 		infoSet = append(infoSet, info)
@@ -251,6 +266,16 @@ func (ps *PeersState) addReputation(peerID peer.ID, change ReputationChange) (
 
 // highestNotConnectedPeer returns the peer with the highest Reputation and that we are not connected to.
 func (ps *PeersState) highestNotConnectedPeer(set int) (highestPeerID peer.ID) {
+	return ps.highestNotConnected(set, false)
+}
+
+// highestNotConnectedValidator returns the validator peer with the highest Reputation
+// and that we are not connected to.
+func (ps *PeersState) highestNotConnectedValidator(set int) (highestPeerID peer.ID) {
+	return ps.highestNotConnected(set, true)
+}
+
+func (ps *PeersState) highestNotConnected(set int, validatorsOnly bool) (highestPeerID peer.ID) {
 	ps.RLock()
 	defer ps.RUnlock()
 
@@ -260,6 +285,10 @@ func (ps *PeersState) highestNotConnectedPeer(set int) (highestPeerID peer.ID) {
 			continue
 		}
 
+		if _, isValidator := ps.sets[set].validatorNodes[peerID]; validatorsOnly && !isValidator {
+			continue
+		}
+
 		val := int(node.reputation)
 		if val >= maxRep {
 			maxRep = val
@@ -283,6 +312,78 @@ func (ps *PeersState) hasFreeIncomingSlot(set int) bool {
 	return ps.sets[set].numIn < ps.sets[set].maxIn
 }
 
+// hasFreeValidatorSlot checks if the number of validators occupying a validator slot
+// is less than the maximum number of validator slots.
+func (ps *PeersState) hasFreeValidatorSlot(set int) bool {
+	return uint32(len(ps.sets[set].validatorSlotNodes)) < ps.sets[set].maxValidators
+}
+
+// takesValidatorSlot returns true if the peer is a validator and a validator slot is free,
+// in which case it occupies a validator slot rather than an incoming or outgoing slot.
+func (ps *PeersState) takesValidatorSlot(set int, peerID peer.ID) bool {
+	_, isValidator := ps.sets[set].validatorNodes[peerID]
+	return isValidator && ps.hasFreeValidatorSlot(set)
+}
+
+// setValidatorNodes replaces the validator nodes of the set, the unknown ones being inserted.
+// The connected nodes which are no longer validators move from their validator slot to an
+// incoming or outgoing slot, whose number can then exceed the maximum number of slots.
+func (ps *PeersState) setValidatorNodes(idx int, peers ...peer.ID) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	info := &ps.sets[idx]
+	validatorNodes := make(map[peer.ID]struct{}, len(peers))
+	for _, peerID := range peers {
+		validatorNodes[peerID] = struct{}{}
+		if _, has := ps.nodes[peerID]; !has {
+			n := newNode(len(ps.sets))
+			n.state[idx] = notConnected
+			ps.nodes[peerID] = n
+		}
+	}
+
+	for peerID := range info.validatorSlotNodes {
+		if _, ok := validatorNodes[peerID]; ok {
+			continue
+		}
+
+		delete(info.validatorSlotNodes, peerID)
+		switch ps.nodes[peerID].state[idx] {
+		case ingoing:
+			info.numIn++
+		case outgoing:
+			info.numOut++
+		}
+	}
+
+	info.validatorNodes = validatorNodes
+}
+
+// slotStats returns the numbers of peers occupying the slots of the set, by category.
+func (ps *PeersState) slotStats(idx int) SlotStats {
+	ps.RLock()
+	defer ps.RUnlock()
+
+	info := ps.sets[idx]
+	stats := SlotStats{
+		Inbound:       info.numIn,
+		MaxInbound:    info.maxIn,
+		Outbound:      info.numOut,
+		MaxOutbound:   info.maxOut,
+		Validators:    uint32(len(info.validatorSlotNodes)),
+		MaxValidators: info.maxValidators,
+	}
+
+	for peerID := range info.noSlotNodes {
+		node, has := ps.nodes[peerID]
+		if has && isPeerConnected(node.state[idx]) {
+			stats.Reserved++
+		}
+	}
+	return stats
+}
+
 // addNoSlotNode adds a node to the list of nodes that don't occupy slots.
 // has no effect if the node was already in the group.
 func (ps *PeersState) addNoSlotNode(idx int, peerID peer.ID) error {
@@ -302,6 +403,11 @@ func (ps *PeersState) addNoSlotNode(idx int, peerID peer.ID) error {
 		return fmt.Errorf("%w: for peer id %s", ErrPeerDoesNotExist, peerID)
 	}
 
+	if _, ok := ps.sets[idx].validatorSlotNodes[peerID]; ok {
+		delete(ps.sets[idx].validatorSlotNodes, peerID)
+		return nil
+	}
+
 	switch node.state[idx] {
 	case ingoing:
 		ps.sets[idx].numIn--
@@ -350,8 +456,12 @@ func (ps *PeersState) disconnect(idx int, peerID peer.ID) error {
 		return fmt.Errorf("%w: for peer id %s", ErrPeerDoesNotExist, peerID)
 	}
 
-	_, has = info.noSlotNodes[peerID]
-	if !has {
+	_, isNoSlotNode := info.noSlotNodes[peerID]
+	_, hasValidatorSlot := info.validatorSlotNodes[peerID]
+	switch {
+	case hasValidatorSlot:
+		delete(info.validatorSlotNodes, peerID)
+	case !isNoSlotNode:
 		switch node.state[idx] {
 		case ingoing:
 			info.numIn--
@@ -437,14 +547,16 @@ func (ps *PeersState) forgetPeer(set int, peerID peer.ID) error {
 // tryOutgoing tries to set the peer as connected as an outgoing connection.
 // If there are enough slots available, switches the node to Connected and returns nil.
 // If the slots are full, the node stays "not connected" and we return the error ErrOutgoingSlotsUnavailable.
-// non slot occupying nodes don't count towards the number of slots.
+// non slot occupying nodes don't count towards the number of slots, and validator nodes
+// occupy a validator slot if one is free.
 func (ps *PeersState) tryOutgoing(setID int, peerID peer.ID) error {
 	ps.Lock()
 	defer ps.Unlock()
 
 	_, isNoSlotNode := ps.sets[setID].noSlotNodes[peerID]
+	takesValidatorSlot := !isNoSlotNode && ps.takesValidatorSlot(setID, peerID)
 
-	if !ps.hasFreeOutgoingSlot(setID) && !isNoSlotNode {
+	if !ps.hasFreeOutgoingSlot(setID) && !isNoSlotNode && !takesValidatorSlot {
 		return ErrOutgoingSlotsUnavailable
 	}
 
@@ -455,7 +567,10 @@ func (ps *PeersState) tryOutgoing(setID int, peerID peer.ID) error {
 
 	node.state[setID] = outgoing
 
-	if !isNoSlotNode {
+	switch {
+	case takesValidatorSlot:
+		ps.sets[setID].validatorSlotNodes[peerID] = struct{}{}
+	case !isNoSlotNode:
 		ps.sets[setID].numOut++
 	}
 
@@ -465,15 +580,17 @@ func (ps *PeersState) tryOutgoing(setID int, peerID peer.ID) error {
 // tryAcceptIncoming tries to accept the peer as an incoming connection.
 // if there are enough slots available, switches the node to Connected and returns nil.
 // If the slots are full, the node stays "not connected" and we return Err.
-// non slot occupying nodes don't count towards the number of slots.
+// non slot occupying nodes don't count towards the number of slots, and validator nodes
+// occupy a validator slot if one is free.
 func (ps *PeersState) tryAcceptIncoming(setID int, peerID peer.ID) error {
 	ps.Lock()
 	defer ps.Unlock()
 
 	_, isNoSlotOccupied := ps.sets[setID].noSlotNodes[peerID]
+	takesValidatorSlot := !isNoSlotOccupied && ps.takesValidatorSlot(setID, peerID)
 
 	// if slot is not available and the node is not a reserved node then error
-	if !ps.hasFreeIncomingSlot(setID) && !isNoSlotOccupied {
+	if !ps.hasFreeIncomingSlot(setID) && !isNoSlotOccupied && !takesValidatorSlot {
 		return ErrIncomingSlotsUnavailable
 	}
 
@@ -484,7 +601,10 @@ func (ps *PeersState) tryAcceptIncoming(setID int, peerID peer.ID) error {
 	}
 
 	node.state[setID] = ingoing
-	if !isNoSlotOccupied {
+	switch {
+	case takesValidatorSlot:
+		ps.sets[setID].validatorSlotNodes[peerID] = struct{}{}
+	case !isNoSlotOccupied:
 		// this need to be added as incoming connection allocate slot.
 		ps.sets[setID].numIn++
 	}
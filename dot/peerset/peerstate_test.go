@@ -197,3 +197,50 @@ func TestHighestNotConnectedPeer(t *testing.T) {
 
 	require.Equal(t, peer1, state.highestNotConnectedPeer(0))
 }
+
+func TestValidatorSlots(t *testing.T) {
+	t.Parallel()
+
+	state, err := NewPeerState([]*config{
+		{
+			maxInPeers:        1,
+			maxOutPeers:       1,
+			maxValidatorPeers: 1,
+		},
+	})
+	require.NoError(t, err)
+
+	state.setValidatorNodes(0, peer1, peer2)
+	require.Equal(t, notConnectedPeer, state.peerStatus(0, peer1))
+	require.Contains(t, []peer.ID{peer1, peer2}, state.highestNotConnectedValidator(0))
+
+	// peer1 occupies the validator slot, and peer2 falls back to the incoming slot.
+	err = state.tryAcceptIncoming(0, peer1)
+	require.NoError(t, err)
+	err = state.tryAcceptIncoming(0, peer2)
+	require.NoError(t, err)
+
+	state.insertPeer(0, incomingPeer)
+	err = state.tryAcceptIncoming(0, incomingPeer)
+	require.ErrorIs(t, err, ErrIncomingSlotsUnavailable)
+
+	expected := SlotStats{
+		Inbound:       1,
+		MaxInbound:    1,
+		MaxOutbound:   1,
+		Validators:    1,
+		MaxValidators: 1,
+	}
+	require.Equal(t, expected, state.slotStats(0))
+
+	// peer1 is no longer a validator and moves to an incoming slot.
+	state.setValidatorNodes(0, peer2)
+	expected.Inbound = 2
+	expected.Validators = 0
+	require.Equal(t, expected, state.slotStats(0))
+
+	err = state.disconnect(0, peer1)
+	require.NoError(t, err)
+	expected.Inbound = 1
+	require.Equal(t, expected, state.slotStats(0))
+}
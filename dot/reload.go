@@ -0,0 +1,320 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const telemetryReloadTimeout = 30 * time.Second
+
+// reservedPeersSetter is implemented by the network service
+type reservedPeersSetter interface {
+	AddReservedPeers(addrs ...string) error
+	RemoveReservedPeers(addrs ...string) error
+}
+
+// telemetryEndpointsSetter is implemented by the telemetry mailer
+type telemetryEndpointsSetter interface {
+	SetEndpoints(ctx context.Context, conns []*genesis.TelemetryEndpoint) error
+}
+
+// corsSetter is implemented by the rpc server
+type corsSetter interface {
+	SetCORS(origins []string)
+}
+
+// retainedBlocksSetter is implemented by the state service
+type retainedBlocksSetter interface {
+	SetPrunerRetainedBlocks(retainedBlocks uint32) error
+}
+
+// configReloader applies the reloadable fields of the node config file to the running services.
+// The other fields of the config file cannot be changed without restarting the node.
+type configReloader struct {
+	mutex sync.Mutex
+	// config is the running node configuration
+	config *cfg.Config
+	// fileConfig is the config file content when the node started or was last reloaded,
+	// nil if the base path has no config file.
+	fileConfig *cfg.Config
+	// genesisTelemetry are the telemetry endpoints of the chain-spec,
+	// used when the config file has no telemetry urls.
+	genesisTelemetry []*genesis.TelemetryEndpoint
+
+	network   reservedPeersSetter
+	telemetry telemetryEndpointsSetter
+	rpc       corsSetter
+	state     retainedBlocksSetter
+}
+
+func newConfigReloader(config *cfg.Config) *configReloader {
+	fileConfig, err := cfg.ReadConfigFile(config.BasePath, config)
+	if err != nil {
+		logger.Debugf("config reload disabled: %s", err)
+		fileConfig = nil
+	}
+
+	return &configReloader{
+		config:     config,
+		fileConfig: fileConfig,
+	}
+}
+
+// persistentPeersChange is the change of the persistent peers of a reload.
+type persistentPeersChange struct {
+	// added are the multiaddresses of the persistent peers added.
+	added []string
+	// addedIDs are the peer IDs of the persistent peers added.
+	addedIDs []string
+	// removed are the multiaddresses of the persistent peers removed.
+	removed []string
+	// removedIDs are the peer IDs of the persistent peers removed.
+	removedIDs []string
+}
+
+// ReloadConfig reads the config file in the node base path and applies its log levels,
+// rpc cors origins, telemetry endpoints, persistent peers and pruning target.
+// All the changes are validated before any is applied, and the changes applied are
+// reverted if a following change fails, so no change is applied if an error is returned.
+// No change is applied either if the config file changes any other field.
+func (r *configReloader) ReloadConfig() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.fileConfig == nil {
+		return fmt.Errorf("no config file found in base path %s", r.config.BasePath)
+	}
+
+	updated, err := cfg.ReadConfigFile(r.config.BasePath, r.fileConfig)
+	if err != nil {
+		return err
+	}
+
+	err = cfg.ValidateReload(r.fileConfig, updated)
+	if err != nil {
+		return err
+	}
+
+	logLevels, err := parseLogLevels(updated)
+	if err != nil {
+		return err
+	}
+
+	peersChange, err := diffPersistentPeers(r.config.Network.PersistentPeers, updated.Network.PersistentPeers)
+	if err != nil {
+		return fmt.Errorf("reloading persistent peers: %w", err)
+	}
+
+	endpoints, endpointsChanged := r.telemetryEndpoints(updated.TelemetryURLs)
+
+	err = r.applyRetainedBlocks(updated.RetainBlocks)
+	if err != nil {
+		return fmt.Errorf("reloading pruner: %w", err)
+	}
+
+	err = r.applyPersistentPeers(peersChange)
+	if err != nil {
+		r.revertRetainedBlocks(updated.RetainBlocks)
+		return fmt.Errorf("reloading persistent peers: %w", err)
+	}
+
+	if endpointsChanged {
+		err = r.applyTelemetry(endpoints)
+		if err != nil {
+			r.revertPersistentPeers(peersChange)
+			r.revertRetainedBlocks(updated.RetainBlocks)
+			return fmt.Errorf("reloading telemetry endpoints: %w", err)
+		}
+	}
+
+	log.Patch(log.SetLevel(logLevels.global))
+	logger.Patch(log.SetLevel(logLevels.global))
+	for pkg, level := range logLevels.packages {
+		log.PatchContext("pkg", pkg, log.SetLevel(level))
+	}
+
+	if r.rpc != nil {
+		r.rpc.SetCORS(updated.RPC.CORS)
+	}
+
+	r.config.LogLevel = updated.LogLevel
+	r.config.Log = updated.Log
+	r.config.RPC.CORS = updated.RPC.CORS
+	r.config.RetainBlocks = updated.RetainBlocks
+	r.config.Network.PersistentPeers = updated.Network.PersistentPeers
+	r.config.TelemetryURLs = updated.TelemetryURLs
+	r.fileConfig = updated
+	logger.Info("configuration reloaded")
+	return nil
+}
+
+// diffPersistentPeers returns the change from the current persistent peers to the updated
+// ones, after parsing the multiaddresses of the persistent peers added and removed.
+func diffPersistentPeers(current, updated []string) (change persistentPeersChange, err error) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, addr := range current {
+		currentSet[addr] = struct{}{}
+	}
+	updatedSet := make(map[string]struct{}, len(updated))
+	for _, addr := range updated {
+		updatedSet[addr] = struct{}{}
+	}
+
+	for _, addr := range updated {
+		if _, ok := currentSet[addr]; ok {
+			continue
+		}
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return change, fmt.Errorf("parsing persistent peer %s: %w", addr, err)
+		}
+		change.added = append(change.added, addr)
+		change.addedIDs = append(change.addedIDs, info.ID.String())
+	}
+
+	for _, addr := range current {
+		if _, ok := updatedSet[addr]; ok {
+			continue
+		}
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return change, fmt.Errorf("parsing persistent peer %s: %w", addr, err)
+		}
+		change.removed = append(change.removed, addr)
+		change.removedIDs = append(change.removedIDs, info.ID.String())
+	}
+	return change, nil
+}
+
+func (r *configReloader) applyPersistentPeers(change persistentPeersChange) error {
+	if r.network == nil {
+		return nil
+	}
+
+	err := r.network.AddReservedPeers(change.added...)
+	if err != nil {
+		return err
+	}
+
+	err = r.network.RemoveReservedPeers(change.removedIDs...)
+	if err != nil {
+		r.revertAddedPeers(change)
+		return err
+	}
+	return nil
+}
+
+// revertPersistentPeers reverts the persistent peers change applied.
+func (r *configReloader) revertPersistentPeers(change persistentPeersChange) {
+	if r.network == nil {
+		return
+	}
+
+	r.revertAddedPeers(change)
+	err := r.network.AddReservedPeers(change.removed...)
+	if err != nil {
+		logger.Errorf("restoring the persistent peers removed: %s", err)
+	}
+}
+
+func (r *configReloader) revertAddedPeers(change persistentPeersChange) {
+	err := r.network.RemoveReservedPeers(change.addedIDs...)
+	if err != nil {
+		logger.Errorf("removing the persistent peers added: %s", err)
+	}
+}
+
+// telemetryEndpoints returns the telemetry endpoints of the telemetry urls given, or
+// of the chain-spec if there is none, and whether they differ from the running ones.
+func (r *configReloader) telemetryEndpoints(urls []genesis.TelemetryEndpoint) (
+	endpoints []*genesis.TelemetryEndpoint, changed bool) {
+	unchanged := len(urls) == 0 && len(r.config.TelemetryURLs) == 0 ||
+		reflect.DeepEqual(urls, r.config.TelemetryURLs)
+	if r.telemetry == nil || unchanged {
+		return nil, false
+	}
+
+	if len(urls) == 0 {
+		// with no telemetry urls configured, the chain-spec endpoints are used
+		return r.genesisTelemetry, true
+	}
+
+	endpoints = make([]*genesis.TelemetryEndpoint, len(urls))
+	for i := range urls {
+		endpoints[i] = &urls[i]
+	}
+	return endpoints, true
+}
+
+func (r *configReloader) applyTelemetry(endpoints []*genesis.TelemetryEndpoint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryReloadTimeout)
+	defer cancel()
+	return r.telemetry.SetEndpoints(ctx, endpoints)
+}
+
+func (r *configReloader) applyRetainedBlocks(retainedBlocks uint32) error {
+	if r.state == nil || retainedBlocks == r.config.RetainBlocks {
+		return nil
+	}
+	return r.state.SetPrunerRetainedBlocks(retainedBlocks)
+}
+
+// revertRetainedBlocks restores the pruning target of the running configuration,
+// if it was changed to the retained blocks given.
+func (r *configReloader) revertRetainedBlocks(retainedBlocks uint32) {
+	if r.state == nil || retainedBlocks == r.config.RetainBlocks {
+		return
+	}
+
+	err := r.state.SetPrunerRetainedBlocks(r.config.RetainBlocks)
+	if err != nil {
+		logger.Errorf("restoring the pruner retained blocks: %s", err)
+	}
+}
+
+type logLevels struct {
+	global   log.Level
+	packages map[string]log.Level
+}
+
+// parseLogLevels parses the log levels of the config, keyed by the
+// context value of the package loggers they apply to.
+func parseLogLevels(config *cfg.Config) (levels logLevels, err error) {
+	levels.global, err = log.ParseLevel(config.LogLevel)
+	if err != nil {
+		return levels, fmt.Errorf("parsing global log level: %w", err)
+	}
+
+	packageLevels := map[string]string{
+		"core":    config.Log.Core,
+		"digest":  config.Log.Digest,
+		"sync":    config.Log.Sync,
+		"network": config.Log.Network,
+		"rpc":     config.Log.RPC,
+		"state":   config.Log.State,
+		"runtime": config.Log.Wasmer,
+		"babe":    config.Log.Babe,
+		"grandpa": config.Log.Grandpa,
+	}
+
+	levels.packages = make(map[string]log.Level, len(packageLevels))
+	for pkg, value := range packageLevels {
+		level, err := log.ParseLevel(value)
+		if err != nil {
+			return levels, fmt.Errorf("parsing %s log level: %w", pkg, err)
+		}
+		levels.packages[pkg] = level
+	}
+	return levels, nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPeerA = "/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp"
+	testPeerB = "/ip4/127.0.0.1/tcp/7002/p2p/12D3KooWHdiAxVd8uMQR1hGWXccidmfCwLqcMpGwR6QcTP6QRMuD"
+)
+
+type fakeReloadTargets struct {
+	addedPeers     []string
+	removedPeers   []string
+	endpoints      []*genesis.TelemetryEndpoint
+	cors           []string
+	retainedBlocks uint32
+	endpointsErr   error
+}
+
+func (f *fakeReloadTargets) AddReservedPeers(addrs ...string) error {
+	f.addedPeers = append(f.addedPeers, addrs...)
+	return nil
+}
+
+func (f *fakeReloadTargets) RemoveReservedPeers(ids ...string) error {
+	f.removedPeers = append(f.removedPeers, ids...)
+	return nil
+}
+
+func (f *fakeReloadTargets) SetEndpoints(_ context.Context, conns []*genesis.TelemetryEndpoint) error {
+	if f.endpointsErr != nil {
+		return f.endpointsErr
+	}
+	f.endpoints = conns
+	return nil
+}
+
+func (f *fakeReloadTargets) SetCORS(origins []string) {
+	f.cors = origins
+}
+
+func (f *fakeReloadTargets) SetPrunerRetainedBlocks(retainedBlocks uint32) error {
+	f.retainedBlocks = retainedBlocks
+	return nil
+}
+
+func newTestConfigReloader(t *testing.T) (*configReloader, *cfg.Config, *fakeReloadTargets) {
+	t.Helper()
+
+	config := cfg.DefaultConfig()
+	config.BasePath = t.TempDir()
+	config.ChainSpec = cfg.GetChainSpec(config.BasePath)
+	config.Network.PersistentPeers = []string{testPeerA}
+	require.NoError(t, cfg.EnsureRoot(config.BasePath))
+	require.NoError(t, cfg.WriteConfigFile(config.BasePath, config))
+
+	targets := &fakeReloadTargets{}
+	reloader := newConfigReloader(config)
+	reloader.network = targets
+	reloader.telemetry = targets
+	reloader.rpc = targets
+	reloader.state = targets
+
+	updated := cfg.Copy(config)
+	return reloader, &updated, targets
+}
+
+func TestConfigReloader_ReloadConfig(t *testing.T) {
+	reloader, updated, targets := newTestConfigReloader(t)
+
+	updated.RetainBlocks = 1024
+	updated.RPC.CORS = []string{"https://polkadot.js.org"}
+	updated.Network.PersistentPeers = []string{testPeerB}
+	updated.TelemetryURLs = []genesis.TelemetryEndpoint{{Endpoint: "wss://telemetry.example.com/submit/"}}
+	require.NoError(t, cfg.WriteConfigFile(updated.BasePath, updated))
+
+	err := reloader.ReloadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{testPeerB}, targets.addedPeers)
+	assert.Equal(t, []string{"12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp"}, targets.removedPeers)
+	require.Len(t, targets.endpoints, 1)
+	assert.Equal(t, "wss://telemetry.example.com/submit/", targets.endpoints[0].Endpoint)
+	assert.Equal(t, []string{"https://polkadot.js.org"}, targets.cors)
+	assert.Equal(t, uint32(1024), targets.retainedBlocks)
+
+	assert.Equal(t, uint32(1024), reloader.config.RetainBlocks)
+	assert.Equal(t, []string{testPeerB}, reloader.config.Network.PersistentPeers)
+}
+
+func TestConfigReloader_ReloadConfig_telemetryError(t *testing.T) {
+	reloader, updated, targets := newTestConfigReloader(t)
+	errTest := errors.New("test error")
+	targets.endpointsErr = errTest
+
+	updated.RetainBlocks = 1024
+	updated.RPC.CORS = []string{"https://polkadot.js.org"}
+	updated.Network.PersistentPeers = []string{testPeerB}
+	updated.TelemetryURLs = []genesis.TelemetryEndpoint{{Endpoint: "wss://telemetry.example.com/submit/"}}
+	require.NoError(t, cfg.WriteConfigFile(updated.BasePath, updated))
+
+	err := reloader.ReloadConfig()
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "reloading telemetry endpoints: test error")
+
+	// the persistent peers and the pruner changes are reverted
+	const (
+		peerAID = "12D3KooWEyoppNCUx8Yx66oV9fJnriXwCcXwDDUA2kj6vnc6iDEp"
+		peerBID = "12D3KooWHdiAxVd8uMQR1hGWXccidmfCwLqcMpGwR6QcTP6QRMuD"
+	)
+	assert.Equal(t, []string{testPeerB, testPeerA}, targets.addedPeers)
+	assert.Equal(t, []string{peerAID, peerBID}, targets.removedPeers)
+	assert.Equal(t, cfg.DefaultRetainBlocks, targets.retainedBlocks)
+	assert.Nil(t, targets.cors)
+
+	assert.Equal(t, cfg.DefaultRetainBlocks, reloader.config.RetainBlocks)
+	assert.Equal(t, []string{testPeerA}, reloader.config.Network.PersistentPeers)
+	assert.Equal(t, []string{testPeerA}, reloader.fileConfig.Network.PersistentPeers)
+
+	// the same changes are applied once the telemetry endpoints can be dialled
+	targets.endpointsErr = nil
+	err = reloader.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPeerB}, reloader.config.Network.PersistentPeers)
+	assert.Equal(t, uint32(1024), targets.retainedBlocks)
+}
+
+func TestConfigReloader_ReloadConfig_telemetryRemoved(t *testing.T) {
+	reloader, updated, targets := newTestConfigReloader(t)
+	genesisTelemetry := []*genesis.TelemetryEndpoint{{Endpoint: "wss://chain-spec.example.com/submit/"}}
+	reloader.genesisTelemetry = genesisTelemetry
+
+	updated.TelemetryURLs = []genesis.TelemetryEndpoint{{Endpoint: "wss://telemetry.example.com/submit/"}}
+	require.NoError(t, cfg.WriteConfigFile(updated.BasePath, updated))
+	err := reloader.ReloadConfig()
+	require.NoError(t, err)
+	require.Len(t, targets.endpoints, 1)
+	assert.Equal(t, "wss://telemetry.example.com/submit/", targets.endpoints[0].Endpoint)
+
+	// removing the telemetry urls reverts to the chain-spec endpoints
+	updated.TelemetryURLs = nil
+	require.NoError(t, cfg.WriteConfigFile(updated.BasePath, updated))
+	err = reloader.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, genesisTelemetry, targets.endpoints)
+	assert.Empty(t, reloader.config.TelemetryURLs)
+}
+
+func TestConfigReloader_ReloadConfig_immutableField(t *testing.T) {
+	reloader, updated, targets := newTestConfigReloader(t)
+
+	updated.RetainBlocks = 1024
+	updated.Network.Port = 7100
+	updated.RPC.WSPort = 9000
+	require.NoError(t, cfg.WriteConfigFile(updated.BasePath, updated))
+
+	err := reloader.ReloadConfig()
+	assert.ErrorIs(t, err, cfg.ErrImmutableField)
+	assert.EqualError(t, err, "field cannot be changed without restarting the node: network.port, rpc.ws-port")
+
+	assert.Equal(t, &fakeReloadTargets{}, targets)
+	assert.Equal(t, cfg.DefaultRetainBlocks, reloader.config.RetainBlocks)
+}
+
+func TestConfigReloader_ReloadConfig_noConfigFile(t *testing.T) {
+	t.Parallel()
+
+	config := cfg.DefaultConfig()
+	config.BasePath = t.TempDir()
+
+	err := newConfigReloader(config).ReloadConfig()
+	assert.EqualError(t, err, "no config file found in base path "+config.BasePath)
+}
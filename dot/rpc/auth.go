@@ -0,0 +1,30 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// authorised returns true if the request is authenticated with the token given,
+// either as a bearer token or as the password of the basic authentication.
+// All requests are authorised when the token is empty.
+func authorised(r *http.Request, token string) bool {
+	if token == "" {
+		return true
+	}
+
+	var given string
+	if _, password, ok := r.BasicAuth(); ok {
+		given = password
+	} else if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		given = bearer
+	} else {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_authorised(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		token         string
+		authorization string
+		basicPassword string
+		authorised    bool
+	}{
+		"no_token": {
+			authorised: true,
+		},
+		"missing_authorization": {
+			token: "secret",
+		},
+		"bearer_token": {
+			token:         "secret",
+			authorization: "Bearer secret",
+			authorised:    true,
+		},
+		"wrong_bearer_token": {
+			token:         "secret",
+			authorization: "Bearer wrong",
+		},
+		"basic_auth": {
+			token:         "secret",
+			basicPassword: "secret",
+			authorised:    true,
+		},
+		"wrong_basic_auth": {
+			token:         "secret",
+			basicPassword: "wrong",
+		},
+		"unknown_scheme": {
+			token:         "secret",
+			authorization: "secret",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodPost, "/", nil)
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+			if testCase.basicPassword != "" {
+				request.SetBasicAuth("gossamer", testCase.basicPassword)
+			}
+
+			assert.Equal(t, testCase.authorised, authorised(request, testCase.token))
+		})
+	}
+}
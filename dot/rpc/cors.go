@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+)
+
+// allowAllOrigins is the cors origin allowing requests from any origin
+const allowAllOrigins = "all"
+
+// SetCORS replaces the browser origins allowed to access the HTTP and websocket servers
+func (h *HTTPServer) SetCORS(origins []string) {
+	h.corsLock.Lock()
+	defer h.corsLock.Unlock()
+	h.cors = origins
+}
+
+// corsAllowed returns true if a request from the given origin is allowed.
+// Requests without origin, ie. not coming from a browser, are always allowed,
+// as are all requests when no cors origin is configured.
+func (h *HTTPServer) corsAllowed(origin string) bool {
+	h.corsLock.RLock()
+	defer h.corsLock.RUnlock()
+
+	if origin == "" || len(h.cors) == 0 {
+		return true
+	}
+
+	for _, allowed := range h.cors {
+		if allowed == allowAllOrigins || allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// corsHandler rejects browser requests from origins which are not allowed and sets
+// the cross origin headers of requests from allowed origins.
+func (h *HTTPServer) corsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if !h.corsAllowed(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+
+		h.corsLock.RLock()
+		corsEnabled := len(h.cors) > 0
+		h.corsLock.RUnlock()
+
+		if origin != "" && corsEnabled {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			w.Header().Add("Vary", "Origin")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
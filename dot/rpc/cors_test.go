@@ -0,0 +1,85 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPServer_corsHandler(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		cors           []string
+		method         string
+		origin         string
+		expectedStatus int
+		expectedOrigin string
+	}{
+		"no_cors_configured": {
+			method:         http.MethodPost,
+			origin:         "https://example.com",
+			expectedStatus: http.StatusOK,
+		},
+		"no_origin": {
+			cors:           []string{"https://polkadot.js.org"},
+			method:         http.MethodPost,
+			expectedStatus: http.StatusOK,
+		},
+		"origin_allowed": {
+			cors:           []string{"https://polkadot.js.org"},
+			method:         http.MethodPost,
+			origin:         "https://polkadot.js.org",
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "https://polkadot.js.org",
+		},
+		"all_origins_allowed": {
+			cors:           []string{"all"},
+			method:         http.MethodPost,
+			origin:         "https://example.com",
+			expectedStatus: http.StatusOK,
+			expectedOrigin: "https://example.com",
+		},
+		"preflight": {
+			cors:           []string{"https://polkadot.js.org"},
+			method:         http.MethodOptions,
+			origin:         "https://polkadot.js.org",
+			expectedStatus: http.StatusNoContent,
+			expectedOrigin: "https://polkadot.js.org",
+		},
+		"origin_refused": {
+			cors:           []string{"https://polkadot.js.org"},
+			method:         http.MethodPost,
+			origin:         "https://example.com",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := &HTTPServer{}
+			server.SetCORS(testCase.cors)
+			handler := server.corsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			request := httptest.NewRequest(testCase.method, "/", nil)
+			if testCase.origin != "" {
+				request.Header.Set("Origin", testCase.origin)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			assert.Equal(t, testCase.expectedOrigin, recorder.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
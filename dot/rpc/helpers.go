@@ -64,6 +64,10 @@ func rpcValidator(cfg *HTTPServerConfig, validate *validator.Validate) func(r *r
 			return fmt.Errorf("unsafe rpc method %s cannot be reachable", rpcmethod)
 		}
 
+		if isUnsafe && !authorised(r.Request, cfg.AuthToken) {
+			return fmt.Errorf("unauthorised call to unsafe rpc method %s", rpcmethod)
+		}
+
 		if err = validate.Struct(v); err != nil {
 			return err
 		}
@@ -4,9 +4,11 @@
 package rpc
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
@@ -26,6 +28,12 @@ type HTTPServer struct {
 	rpcServer    *rpc.Server // Actual RPC call handler
 	serverConfig *HTTPServerConfig
 	wsConns      []*subscription.WSConn
+	// localRPCHost is the address of the plain text HTTP server listening on the loopback
+	// interface the websocket calls are forwarded to when the servers serve TLS.
+	localRPCHost string
+
+	cors     []string
+	corsLock sync.RWMutex
 }
 
 // HTTPServerConfig configures the HTTPServer
@@ -52,6 +60,22 @@ type HTTPServerConfig struct {
 	WSUnsafeExternal    bool
 	WSPort              uint32
 	Modules             []string
+	CORS                []string
+	ConfigReloaderAPI   modules.ConfigReloaderAPI
+	BadBlocksAPI        modules.BadBlocksAPI
+	ChainHeadAPI        modules.ChainHeadAPI
+	ManualSealAPI       modules.ManualSealAPI
+	SupervisorAPI       modules.SupervisorAPI
+	GrandpaStateAPI     modules.GrandpaStateAPI
+	// TLSConfig is the TLS configuration the HTTP and websocket servers serve TLS with,
+	// they serve plain text connections if it is nil.
+	TLSConfig *tls.Config
+	// ACMEHandler answers the HTTP-01 challenges of the ACME certificate authority on port 80,
+	// it is nil if the certificates are not obtained with ACME.
+	ACMEHandler http.Handler
+	// AuthToken is the token the calls to the unsafe methods must be authenticated with,
+	// they are not authenticated if it is empty.
+	AuthToken string
 }
 
 func (h *HTTPServerConfig) rpcUnsafeEnabled() bool {
@@ -81,6 +105,7 @@ func NewHTTPServer(cfg *HTTPServerConfig) *HTTPServer {
 		logger:       logger,
 		rpcServer:    rpc.NewServer(),
 		serverConfig: cfg,
+		cors:         cfg.CORS,
 	}
 
 	server.RegisterModules(cfg.Modules)
@@ -96,13 +121,14 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "system":
 			srvc = modules.NewSystemModule(h.serverConfig.NetworkAPI, h.serverConfig.SystemAPI,
 				h.serverConfig.CoreAPI, h.serverConfig.StorageAPI, h.serverConfig.TransactionQueueAPI,
-				h.serverConfig.BlockAPI, h.serverConfig.SyncAPI)
+				h.serverConfig.BlockAPI, h.serverConfig.SyncAPI, h.serverConfig.SupervisorAPI)
 		case "author":
 			srvc = modules.NewAuthorModule(h.logger, h.serverConfig.CoreAPI, h.serverConfig.TransactionQueueAPI)
 		case "chain":
 			srvc = modules.NewChainModule(h.serverConfig.BlockAPI)
 		case "grandpa":
-			srvc = modules.NewGrandpaModule(h.serverConfig.BlockAPI, h.serverConfig.BlockFinalityAPI)
+			srvc = modules.NewGrandpaModule(h.serverConfig.BlockAPI, h.serverConfig.GrandpaStateAPI,
+				h.serverConfig.BlockFinalityAPI)
 		case "state":
 			srvc = modules.NewStateModule(h.serverConfig.NetworkAPI, h.serverConfig.StorageAPI,
 				h.serverConfig.CoreAPI, h.serverConfig.BlockAPI)
@@ -118,6 +144,13 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 			srvc = modules.NewSyncStateModule(h.serverConfig.SyncStateAPI)
 		case "payment":
 			srvc = modules.NewPaymentModule(h.serverConfig.BlockAPI)
+		case "chainHead":
+			srvc = modules.NewChainHeadModule(h.serverConfig.BlockAPI, h.serverConfig.StorageAPI,
+				h.serverConfig.ChainHeadAPI)
+		case "engine":
+			srvc = modules.NewEngineModule(h.serverConfig.BlockAPI, h.serverConfig.ManualSealAPI)
+		case "admin":
+			srvc = modules.NewAdminModule(h.serverConfig.ConfigReloaderAPI, h.serverConfig.BadBlocksAPI)
 		default:
 			h.logger.Warn("Unrecognised module: " + mod)
 			continue
@@ -142,7 +175,7 @@ func (h *HTTPServer) Start() error {
 
 	h.logger.Infof("Starting HTTP Server on host %s and port %d...", h.serverConfig.Host, h.serverConfig.RPCPort)
 	r := mux.NewRouter()
-	r.Handle("/", h.rpcServer)
+	r.Handle("/", h.corsHandler(h.rpcServer))
 
 	validate := validator.New()
 	// Add custom validator for `common.Hash`
@@ -155,18 +188,56 @@ func (h *HTTPServer) Start() error {
 			Addr:              fmt.Sprintf(":%d", h.serverConfig.RPCPort),
 			ReadHeaderTimeout: 5 * time.Second,
 			Handler:           r,
+			TLSConfig:         h.serverConfig.TLSConfig,
 		}
 
-		err := server.ListenAndServe()
+		err := listenAndServe(server)
 		if err != nil {
 			h.logger.Errorf("http error: %s", err)
 		}
 	}()
 
+	if h.serverConfig.ACMEHandler != nil {
+		go func() {
+			acmeServer := &http.Server{
+				Addr:              ":80",
+				ReadHeaderTimeout: 5 * time.Second,
+				Handler:           h.serverConfig.ACMEHandler,
+			}
+
+			err := acmeServer.ListenAndServe()
+			if err != nil {
+				h.logger.Errorf("acme http error: %s", err)
+			}
+		}()
+	}
+
 	if !h.serverConfig.exposeWS() {
 		return nil
 	}
 
+	if h.serverConfig.TLSConfig != nil {
+		// the websocket calls are forwarded in plain text over the loopback interface,
+		// since the certificate is not issued for the host the node reaches itself with.
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return fmt.Errorf("listening on loopback interface: %w", err)
+		}
+		h.localRPCHost = fmt.Sprintf("http://%s/", listener.Addr())
+
+		go func() {
+			localServer := &http.Server{
+				ReadHeaderTimeout: 5 * time.Second,
+				Handler:           r,
+			}
+
+			err := localServer.Serve(listener)
+			if err != nil {
+				h.logger.Errorf("local http error: %s", err)
+			}
+		}()
+	}
+
 	h.logger.Infof("Starting WebSocket Server on host %s and port %d...",
 		h.serverConfig.Host, h.serverConfig.WSPort)
 	ws := mux.NewRouter()
@@ -176,9 +247,10 @@ func (h *HTTPServer) Start() error {
 			Addr:              fmt.Sprintf(":%d", h.serverConfig.WSPort),
 			ReadHeaderTimeout: 5 * time.Second,
 			Handler:           ws,
+			TLSConfig:         h.serverConfig.TLSConfig,
 		}
 
-		err := wsServer.ListenAndServe()
+		err := listenAndServe(wsServer)
 		if err != nil {
 			h.logger.Errorf("http error: %s", err)
 		}
@@ -187,6 +259,15 @@ func (h *HTTPServer) Start() error {
 	return nil
 }
 
+// listenAndServe serves TLS connections if the server has a TLS configuration,
+// and plain text connections otherwise.
+func listenAndServe(server *http.Server) error {
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
+
 // Stop stops the server
 func (h *HTTPServer) Stop() error {
 	if h.serverConfig.exposeWS() {
@@ -214,6 +295,11 @@ func (h *HTTPServer) Stop() error {
 func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var upg = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
+			if !h.corsAllowed(r.Header.Get("Origin")) {
+				logger.Debugf("websocket request from origin %s refused", r.Header.Get("Origin"))
+				return false
+			}
+
 			if !h.serverConfig.exposeWS() {
 				ip, _, err := net.SplitHostPort(r.RemoteAddr)
 				if err != nil {
@@ -241,6 +327,10 @@ func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	// create wsConn
 	wsc := NewWSConn(ws, h.serverConfig)
+	if h.localRPCHost != "" {
+		wsc.RPCHost = h.localRPCHost
+	}
+	wsc.Authorization = r.Header.Get("Authorization")
 	h.wsConns = append(h.wsConns, wsc)
 
 	go wsc.HandleConn()
@@ -58,7 +58,8 @@ func TestRegisterModules(t *testing.T) {
 
 func TestUnsafeRPCProtection(t *testing.T) {
 	cfg := &HTTPServerConfig{
-		Modules:           []string{"system", "author", "chain", "state", "rpc", "grandpa", "dev", "syncstate"},
+		Modules: []string{"system", "author", "chain", "state", "rpc", "grandpa", "dev", "syncstate", "admin",
+			"offchain"},
 		RPCPort:           7878,
 		RPCAPI:            NewService(),
 		RPCUnsafeExternal: false,
@@ -238,6 +239,59 @@ func TestRPCExternalEnable_UnsafeExternalNotEnabled(t *testing.T) {
 	require.Equal(t, expected, string(resBody))
 }
 
+func TestUnsafeRPCAuthToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	data := []byte(fmt.Sprintf(
+		`{"jsonrpc":"2.0","method":"%s","params":["%s"],"id":1}`,
+		"system_addReservedPeer",
+		"/ip4/198.51.100.19/tcp/30333/p2p/QmSk5HQbn6LhUwDiNMseVUjuRYhEtYj4aUZ6WfWoGURpdV"))
+
+	netmock := mocks.NewMockNetworkAPI(ctrl)
+	netmock.EXPECT().AddReservedPeers(gomock.Any()).Return(nil)
+
+	cfg := &HTTPServerConfig{
+		Modules:    []string{"system"},
+		RPCPort:    7881,
+		RPCAPI:     NewService(),
+		RPCUnsafe:  true,
+		NetworkAPI: netmock,
+		AuthToken:  "secret",
+	}
+
+	s := NewHTTPServer(cfg)
+	err := s.Start()
+	require.NoError(t, err)
+
+	time.Sleep(time.Second)
+	defer s.Stop()
+
+	url := fmt.Sprintf("http://localhost:%d/", cfg.RPCPort)
+	_, resBody := PostRequest(t, url, bytes.NewReader(data))
+	expected := `{` +
+		`"jsonrpc":"2.0",` +
+		`"error":{` +
+		`"code":-32000,` +
+		`"message":"unauthorised call to unsafe rpc method system_addReservedPeer",` +
+		`"data":null` +
+		`},` +
+		`"id":1` +
+		`}` + "\n"
+	require.Equal(t, expected, string(resBody))
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret")
+	res, err := new(http.Client).Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	resBody, err = io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"jsonrpc":"2.0","result":null,"id":1}`+"\n", string(resBody))
+}
+
 func PostRequest(t *testing.T, url string, data io.Reader) (int, []byte) {
 	t.Helper()
 
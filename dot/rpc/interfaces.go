@@ -16,6 +16,7 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 // StorageAPI is the interface for the storage state
@@ -27,8 +28,10 @@ type StorageAPI interface {
 	Entries(root *common.Hash) (map[string][]byte, error)
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
+	GetKeysWithPrefixPaged(root *common.Hash, prefix, startAfter []byte, limit uint) ([][]byte, error)
 	RegisterStorageObserver(observer state.Observer)
 	UnregisterStorageObserver(observer state.Observer)
+	StorageDiff(from, to common.Hash) (map[string][]byte, error)
 }
 
 // BlockAPI is the interface for the block state
@@ -48,7 +51,12 @@ type BlockAPI interface {
 	RangeInMemory(start, end common.Hash) ([]common.Hash, error)
 	RegisterRuntimeUpdatedChannel(ch chan<- runtime.Version) (uint32, error)
 	UnregisterRuntimeUpdatedChannel(id uint32) bool
+	AcquireRuntime(blockHash common.Hash) (instance runtime.Instance, release func(), err error)
 	GetRuntime(blockHash common.Hash) (runtime runtime.Instance, err error)
+	GetRuntimeUpgrades() ([]state.RuntimeUpgrade, error)
+	PinBlock(hash common.Hash) error
+	UnpinBlock(hash common.Hash) (unpinned bool)
+	GetChangedKeys(hash common.Hash) (keys [][]byte, indexed bool, err error)
 }
 
 // NetworkAPI interface for network state methods
@@ -62,6 +70,8 @@ type NetworkAPI interface {
 	StartingBlock() int64
 	AddReservedPeers(addrs ...string) error
 	RemoveReservedPeers(addrs ...string) error
+	ReservedPeers() []string
+	ListenAddresses() ([]ma.Multiaddr, error)
 }
 
 // BlockProducerAPI is the interface for BlockProducer methods
@@ -76,6 +86,7 @@ type BlockProducerAPI interface {
 type TransactionStateAPI interface {
 	AddToPool(*transaction.ValidTransaction) common.Hash
 	Pending() []*transaction.ValidTransaction
+	RemoveInvalid(hashes ...common.Hash) (removed []common.Hash)
 	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status
 	FreeStatusNotifierChannel(ch chan transaction.Status)
 }
@@ -84,6 +95,7 @@ type TransactionStateAPI interface {
 type CoreAPI interface {
 	InsertKey(kp core.KeyPair, keystoreType string) error
 	HasKey(pubKeyStr string, keyType string) (bool, error)
+	ListKeys() map[string][]string
 	GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error)
 	HandleSubmittedExtrinsic(types.Extrinsic) error
 	GetMetadata(bhash *common.Hash) ([]byte, error)
@@ -104,6 +116,7 @@ type SystemAPI interface {
 	Properties() map[string]interface{}
 	ChainType() string
 	ChainName() string
+	NodeRole() common.NetworkRole
 }
 
 // BlockFinalityAPI is the interface for handling block finalisation methods
@@ -143,3 +143,21 @@ func (mr *MockTransactionStateAPIMockRecorder) Pending() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionStateAPI)(nil).Pending))
 }
+
+// RemoveInvalid mocks base method.
+func (m *MockTransactionStateAPI) RemoveInvalid(arg0 ...common.Hash) []common.Hash {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveInvalid", varargs...)
+	ret0, _ := ret[0].([]common.Hash)
+	return ret0
+}
+
+// RemoveInvalid indicates an expected call of RemoveInvalid.
+func (mr *MockTransactionStateAPIMockRecorder) RemoveInvalid(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveInvalid", reflect.TypeOf((*MockTransactionStateAPI)(nil).RemoveInvalid), arg0...)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var (
+	// ErrConfigReloadUnavailable is returned when the node does not support reloading its configuration
+	ErrConfigReloadUnavailable = errors.New("config reload is not available")
+	// ErrBadBlocksUnavailable is returned when the node does not support updating its bad blocks
+	ErrBadBlocksUnavailable = errors.New("bad blocks update is not available")
+)
+
+// AdminModule is an RPC module providing node administration methods
+type AdminModule struct {
+	configReloader ConfigReloaderAPI
+	badBlocks      BadBlocksAPI
+}
+
+// NewAdminModule creates a new admin module
+func NewAdminModule(configReloader ConfigReloaderAPI, badBlocks BadBlocksAPI) *AdminModule {
+	return &AdminModule{
+		configReloader: configReloader,
+		badBlocks:      badBlocks,
+	}
+}
+
+// ReloadConfig reloads the log levels, rpc cors origins, telemetry endpoints, persistent peers
+// and pruning target from the node config file. It fails without applying any change if the
+// config file changes a field requiring a node restart.
+func (am *AdminModule) ReloadConfig(r *http.Request, req *EmptyRequest, res *[]byte) error {
+	if am.configReloader == nil {
+		return ErrConfigReloadUnavailable
+	}
+	return am.configReloader.ReloadConfig()
+}
+
+// AddBadBlock adds the block hash given to the bad blocks, so the block and its
+// descendants are rejected when received from peers. It is kept after a restart.
+func (am *AdminModule) AddBadBlock(r *http.Request, req *StringRequest, res *[]byte) error {
+	if am.badBlocks == nil {
+		return ErrBadBlocksUnavailable
+	}
+
+	hash, err := common.HexToHash(req.String)
+	if err != nil {
+		return fmt.Errorf("parsing block hash: %w", err)
+	}
+
+	return am.badBlocks.AddBadBlock(hash)
+}
+
+// RemoveBadBlock removes the block hash given from the bad blocks.
+func (am *AdminModule) RemoveBadBlock(r *http.Request, req *StringRequest, res *[]byte) error {
+	if am.badBlocks == nil {
+		return ErrBadBlocksUnavailable
+	}
+
+	hash, err := common.HexToHash(req.String)
+	if err != nil {
+		return fmt.Errorf("parsing block hash: %w", err)
+	}
+
+	return am.badBlocks.RemoveBadBlock(hash)
+}
+
+// BadBlocks returns the hashes of the bad blocks from the genesis or added at runtime.
+func (am *AdminModule) BadBlocks(r *http.Request, req *EmptyRequest, res *[]string) error {
+	if am.badBlocks == nil {
+		return ErrBadBlocksUnavailable
+	}
+
+	hashes := am.badBlocks.BadBlocks()
+	*res = make([]string, len(hashes))
+	for i, hash := range hashes {
+		(*res)[i] = hash.String()
+	}
+	return nil
+}
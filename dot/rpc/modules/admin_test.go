@@ -0,0 +1,113 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type configReloaderFunc func() error
+
+func (f configReloaderFunc) ReloadConfig() error {
+	return f()
+}
+
+func TestAdminModule_ReloadConfig(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		configReloader ConfigReloaderAPI
+		errIs          error
+	}{
+		"no_config_reloader": {
+			errIs: ErrConfigReloadUnavailable,
+		},
+		"reload_error": {
+			configReloader: configReloaderFunc(func() error { return errTest }),
+			errIs:          errTest,
+		},
+		"reloaded": {
+			configReloader: configReloaderFunc(func() error { return nil }),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			module := NewAdminModule(testCase.configReloader, nil)
+			err := module.ReloadConfig(nil, &EmptyRequest{}, nil)
+			assert.ErrorIs(t, err, testCase.errIs)
+		})
+	}
+}
+
+type badBlocksFake struct {
+	hashes []common.Hash
+	err    error
+}
+
+func (f *badBlocksFake) AddBadBlock(hash common.Hash) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.hashes = append(f.hashes, hash)
+	return nil
+}
+
+func (f *badBlocksFake) RemoveBadBlock(hash common.Hash) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.hashes = slices.DeleteFunc(f.hashes, func(h common.Hash) bool { return h == hash })
+	return nil
+}
+
+func (f *badBlocksFake) BadBlocks() []common.Hash {
+	return f.hashes
+}
+
+func TestAdminModule_BadBlocks(t *testing.T) {
+	t.Parallel()
+
+	module := NewAdminModule(nil, nil)
+	err := module.AddBadBlock(nil, &StringRequest{String: common.Hash{1}.String()}, nil)
+	assert.ErrorIs(t, err, ErrBadBlocksUnavailable)
+	err = module.RemoveBadBlock(nil, &StringRequest{String: common.Hash{1}.String()}, nil)
+	assert.ErrorIs(t, err, ErrBadBlocksUnavailable)
+	err = module.BadBlocks(nil, &EmptyRequest{}, nil)
+	assert.ErrorIs(t, err, ErrBadBlocksUnavailable)
+
+	badBlocks := &badBlocksFake{}
+	module = NewAdminModule(nil, badBlocks)
+
+	err = module.AddBadBlock(nil, &StringRequest{String: "0xinvalid"}, nil)
+	assert.ErrorContains(t, err, "parsing block hash")
+
+	err = module.AddBadBlock(nil, &StringRequest{String: common.Hash{1}.String()}, nil)
+	require.NoError(t, err)
+	err = module.AddBadBlock(nil, &StringRequest{String: common.Hash{2}.String()}, nil)
+	require.NoError(t, err)
+	err = module.RemoveBadBlock(nil, &StringRequest{String: common.Hash{1}.String()}, nil)
+	require.NoError(t, err)
+
+	var res []string
+	err = module.BadBlocks(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, []string{common.Hash{2}.String()}, res)
+
+	errTest := errors.New("test error")
+	badBlocks.err = errTest
+	err = module.AddBadBlock(nil, &StringRequest{String: common.Hash{3}.String()}, nil)
+	assert.ErrorIs(t, err, errTest)
+}
@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	ma "github.com/multiformats/go-multiaddr"
 )
 
 // StorageAPI is the interface for the storage state
@@ -25,8 +26,10 @@ type StorageAPI interface {
 	Entries(root *common.Hash) (map[string][]byte, error)
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
+	GetKeysWithPrefixPaged(root *common.Hash, prefix, startAfter []byte, limit uint) ([][]byte, error)
 	RegisterStorageObserver(observer state.Observer)
 	UnregisterStorageObserver(observer state.Observer)
+	StorageDiff(from, to common.Hash) (map[string][]byte, error)
 }
 
 // BlockAPI is the interface for the block state
@@ -46,7 +49,12 @@ type BlockAPI interface {
 	RangeInMemory(start, end common.Hash) ([]common.Hash, error)
 	RegisterRuntimeUpdatedChannel(ch chan<- runtime.Version) (uint32, error)
 	UnregisterRuntimeUpdatedChannel(id uint32) bool
+	AcquireRuntime(blockHash common.Hash) (instance runtime.Instance, release func(), err error)
 	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+	GetRuntimeUpgrades() ([]state.RuntimeUpgrade, error)
+	PinBlock(hash common.Hash) error
+	UnpinBlock(hash common.Hash) (unpinned bool)
+	GetChangedKeys(hash common.Hash) (keys [][]byte, indexed bool, err error)
 }
 
 // NetworkAPI interface for network state methods
@@ -60,6 +68,32 @@ type NetworkAPI interface {
 	StartingBlock() int64
 	AddReservedPeers(addrs ...string) error
 	RemoveReservedPeers(addrs ...string) error
+	ReservedPeers() []string
+	ListenAddresses() ([]ma.Multiaddr, error)
+}
+
+// ChainHeadAPI reads the storage of blocks for the chainHead methods
+type ChainHeadAPI interface {
+	Storage(block common.Hash, keys [][]byte) (values [][]byte, err error)
+}
+
+// ChainHeadCallAPI is a ChainHeadAPI also calling the runtime of blocks. Light clients
+// only implement ChainHeadAPI, since they do not execute the runtime.
+type ChainHeadCallAPI interface {
+	ChainHeadAPI
+	Call(block common.Hash, function string, params []byte) ([]byte, error)
+}
+
+// ConfigReloaderAPI is the interface for reloading the node configuration
+type ConfigReloaderAPI interface {
+	ReloadConfig() error
+}
+
+// BadBlocksAPI is the interface to update the blocks rejected when received from peers
+type BadBlocksAPI interface {
+	AddBadBlock(hash common.Hash) error
+	RemoveBadBlock(hash common.Hash) error
+	BadBlocks() []common.Hash
 }
 
 // BlockProducerAPI is the interface for BlockProducer methods
@@ -70,15 +104,34 @@ type BlockProducerAPI interface {
 	SlotDuration() uint64
 }
 
+// SupervisorAPI is the interface to get the health of the node services run by the supervisor
+type SupervisorAPI interface {
+	Health() []common.ServiceHealth
+}
+
+// GrandpaStateAPI is the interface to get the GRANDPA authority sets of the finalised blocks
+type GrandpaStateAPI interface {
+	GetSetIDByBlockNumber(num uint) (uint64, error)
+	GetSetEndBlockNumber(setID uint64) (blockNumber uint, err error)
+}
+
+// ManualSealAPI is the interface to create and finalise blocks on demand in dev mode
+type ManualSealAPI interface {
+	CreateBlock(parentHash *common.Hash, createEmpty, finalise bool) (common.Hash, error)
+	FinalizeBlock(hash common.Hash) error
+}
+
 // TransactionStateAPI ...
 type TransactionStateAPI interface {
 	Pending() []*transaction.ValidTransaction
+	RemoveInvalid(hashes ...common.Hash) (removed []common.Hash)
 }
 
 // CoreAPI is the interface for the core methods
 type CoreAPI interface {
 	InsertKey(kp core.KeyPair, keystoreType string) error
 	HasKey(pubKeyStr string, keyType string) (bool, error)
+	ListKeys() map[string][]string
 	GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error)
 	HandleSubmittedExtrinsic(types.Extrinsic) error
 	GetMetadata(bhash *common.Hash) ([]byte, error)
@@ -98,6 +151,7 @@ type SystemAPI interface {
 	Properties() map[string]interface{}
 	ChainType() string
 	ChainName() string
+	NodeRole() common.NetworkRole
 }
 
 // BlockFinalityAPI is the interface for handling block finalisation methods
@@ -5,17 +5,54 @@
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/gorilla/rpc/v2/json2"
 )
 
-var ErrProvidedKeyDoesNotMatch = errors.New("generated public key does not equal provided public key")
+// Substrate compatible author RPC error codes
+const (
+	authorErrorBase = 1000
+	// unsupportedKeyTypeErrorCode is the code of the error returned when the crypto of a key type is unknown
+	unsupportedKeyTypeErrorCode json2.ErrorCode = authorErrorBase + 5
+	// otherAuthorErrorCode is the code of the other author errors
+	otherAuthorErrorCode json2.ErrorCode = authorErrorBase + 40
+)
+
+// keyTypeIDLength is the length of a key type ID, such as babe or gran
+const keyTypeIDLength = 4
+
+var (
+	ErrProvidedKeyDoesNotMatch = errors.New("generated public key does not equal provided public key")
+	ErrHasKeyInvalidParams     = errors.New("expected public key and key type parameters")
+
+	errInvalidKeyType = &json2.Error{
+		Code:    otherAuthorErrorCode,
+		Message: "Invalid key type ID format (should be of length four)",
+	}
+	errUnsupportedKeyType = &json2.Error{
+		Code:    unsupportedKeyTypeErrorCode,
+		Message: "Unknown key type crypto",
+		Data: "The crypto for the given key type is unknown, " +
+			"please add the public key to the request to insert the key successfully.",
+	}
+	errInvalidSecretURI = &json2.Error{
+		Code:    otherAuthorErrorCode,
+		Message: "Invalid seed phrase/SURI",
+	}
+	errKeyDoesNotMatch = &json2.Error{
+		Code:    otherAuthorErrorCode,
+		Message: ErrProvidedKeyDoesNotMatch.Error(),
+	}
+)
 
 // AuthorModule holds a pointer to the API
 type AuthorModule struct {
@@ -41,10 +78,10 @@ type Extrinsic struct {
 	Data string
 }
 
-// ExtrinsicOrHash is a type for Hash and Extrinsic array of bytes
+// ExtrinsicOrHash identifies an extrinsic either by its hash or by its hex-encoded bytes
 type ExtrinsicOrHash struct {
 	Hash      common.Hash
-	Extrinsic []byte
+	Extrinsic string
 }
 
 // ExtrinsicOrHashRequest is a array of ExtrinsicOrHash
@@ -65,6 +102,9 @@ type ExtrinsicOrHash struct {
 // HasSessionKeyResponse is the response to the RPC call author_hasSessionKeys
 type HasSessionKeyResponse bool
 
+// ListKeysResponse maps keystore names to the hex encoded public keys they hold
+type ListKeysResponse map[string][]string
+
 // KeyTypeID represents the key type of a session key
 type keyTypeID [4]uint8
 
@@ -143,28 +183,49 @@ func (am *AuthorModule) HasSessionKeys(r *http.Request, req *HasSessionKeyReques
 	return nil
 }
 
-// InsertKey inserts a key into the keystore
+// InsertKey inserts a key into the keystore of the given key type. The seed is a secret URI
+// decoded with the crypto scheme of the key type, and its public key must match the public
+// key given. Errors are returned with the codes used by Substrate nodes.
 func (am *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, _ *KeyInsertResponse) error {
 	keyReq := *req
 
-	keyBytes, err := common.HexToBytes(req.Seed)
-	if err != nil {
-		return err
+	if len(keyReq.Type) != keyTypeIDLength {
+		return errInvalidKeyType
 	}
 
-	keyPair, err := keystore.DecodeKeyPairFromHex(keyBytes, keystore.DetermineKeyType(keyReq.Type))
+	// an invalid public key is reported once compared with the one of the seed
+	pubKey, _ := common.HexToBytes(keyReq.PublicKey)
+	keyType := keystore.DeterminePublicKeyType(keyReq.Type, pubKey)
+	if keyType == crypto.UnknownType {
+		return errUnsupportedKeyType
+	}
+
+	// the decoding error is not returned since it may contain the secret
+	decoded, err := keystore.DecodeKeyPairFromSecretURI(keyReq.Seed, keyType)
 	if err != nil {
-		return err
+		return errInvalidSecretURI
+	}
+
+	keyPair, ok := decoded.(keystore.KeyPair)
+	if !ok {
+		return fmt.Errorf("%w: %T", keystore.ErrKeyTypeNotSupported, decoded)
 	}
 
 	//strings.EqualFold compare using case-insensitivity.
 	if !strings.EqualFold(keyPair.Public().Hex(), keyReq.PublicKey) {
-		return ErrProvidedKeyDoesNotMatch
+		return errKeyDoesNotMatch
 	}
 
 	err = am.coreAPI.InsertKey(keyPair, keyReq.Type)
-	if err != nil {
-		return err
+	if errors.Is(err, keystore.ErrInvalidKeystoreName) {
+		// the key type has no keystore of the node
+		return errUnsupportedKeyType
+	} else if err != nil {
+		return &json2.Error{
+			Code:    otherAuthorErrorCode,
+			Message: "The key store is unavailable",
+			Data:    err.Error(),
+		}
 	}
 
 	am.logger.Info("inserted key " + keyPair.Public().Hex() + " into keystore")
@@ -174,11 +235,21 @@ func (am *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, _ *Key
 // HasKey Checks if the keystore has private keys for the given public key and key type.
 func (am *AuthorModule) HasKey(r *http.Request, req *[]string, res *bool) error {
 	reqKey := *req
+	if len(reqKey) != 2 {
+		return ErrHasKeyInvalidParams
+	}
+
 	var err error
 	*res, err = am.coreAPI.HasKey(reqKey[0], reqKey[1])
 	return err
 }
 
+// ListKeys returns the public keys held by each keystore of the node
+func (am *AuthorModule) ListKeys(r *http.Request, _ *EmptyRequest, res *ListKeysResponse) error {
+	*res = am.coreAPI.ListKeys()
+	return nil
+}
+
 // PendingExtrinsics Returns all pending extrinsics
 func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, res *PendingExtrinsicsResponse) error {
 	pending := am.txStateAPI.Pending()
@@ -191,8 +262,26 @@ func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, re
 	return nil
 }
 
-// RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting
-func (am *AuthorModule) RemoveExtrinsic(r *http.Request, _ *ExtrinsicOrHashRequest, _ *RemoveExtrinsicsResponse) error {
+// RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting.
+// The extrinsics depending on the given extrinsics are removed as well, and the hashes of all
+// the removed extrinsics are returned.
+func (am *AuthorModule) RemoveExtrinsic(r *http.Request, req *ExtrinsicOrHashRequest,
+	res *RemoveExtrinsicsResponse) error {
+	hashes := make([]common.Hash, len(*req))
+	for i, extrinsicOrHash := range *req {
+		if extrinsicOrHash.Extrinsic == "" {
+			hashes[i] = extrinsicOrHash.Hash
+			continue
+		}
+
+		extBytes, err := common.HexToBytes(extrinsicOrHash.Extrinsic)
+		if err != nil {
+			return fmt.Errorf("decoding extrinsic: %w", err)
+		}
+		hashes[i] = types.Extrinsic(extBytes).Hash()
+	}
+
+	*res = append(RemoveExtrinsicsResponse{}, am.txStateAPI.RemoveInvalid(hashes...)...)
 	return nil
 }
 
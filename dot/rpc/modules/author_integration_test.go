@@ -137,6 +137,15 @@ func TestAuthorModule_Pending_Integration(t *testing.T) {
 	t.Parallel()
 	integrationTestController := setupStateAndRuntime(t, t.TempDir(), nil)
 
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().
+		SendMessage(
+			telemetry.NewTxpoolImport(1, 0),
+		)
+
+	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
+
 	auth := newAuthorModule(t, integrationTestController)
 	res := new(PendingExtrinsicsResponse)
 	err := auth.PendingExtrinsics(nil, nil, res)
@@ -167,10 +176,10 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	telemetryMock := NewMockTelemetry(ctrl)
 	telemetryMock.EXPECT().
 		SendMessage(
-			telemetry.NewTxpoolImport(0, 1),
+			telemetry.NewTxpoolImport(1, 0),
 		)
 
-	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock)
+	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
 	genesisHash := integrationTestController.genesisHeader.Hash()
 
@@ -207,7 +216,7 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	}
 
 	expectedHash := ExtrinsicHashResponse(expectedExtrinsic.Hash().String())
-	txOnPool := integrationTestController.stateSrv.Transaction.PendingInPool()
+	txOnPool := integrationTestController.stateSrv.Transaction.Pending()
 
 	// compare results
 	require.Len(t, txOnPool, 1)
@@ -247,7 +256,7 @@ func TestAuthorModule_SubmitExtrinsic_bad_proof(t *testing.T) {
 	err := auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
 	require.EqualError(t, err, "bad proof")
 
-	txOnPool := integrationTestController.stateSrv.Transaction.PendingInPool()
+	txOnPool := integrationTestController.stateSrv.Transaction.Pending()
 	require.Len(t, txOnPool, 0)
 }
 
@@ -272,10 +281,10 @@ func TestAuthorModule_SubmitExtrinsic_AlreadyInPool(t *testing.T) {
 	telemetryMock := NewMockTelemetry(ctrl)
 	telemetryMock.EXPECT().
 		SendMessage(
-			telemetry.NewTxpoolImport(0, 1),
+			telemetry.NewTxpoolImport(1, 0),
 		)
 
-	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock)
+	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
 	genesisHash := integrationTestController.genesisHeader.Hash()
 
@@ -349,11 +358,18 @@ func TestAuthorModule_InsertKey_Integration(t *testing.T) {
 			waitErr: ErrProvidedKeyDoesNotMatch,
 		},
 
-		"unknown_key_type": {
+		"invalid_key_type_length": {
 			ksType:  "someothertype",
 			seed:    seed,
 			kp:      grandKp,
-			waitErr: errors.New("cannot decode key: invalid key type"),
+			waitErr: errInvalidKeyType,
+		},
+
+		"unknown_key_type_crypto": {
+			ksType:  "mack",
+			seed:    seed,
+			kp:      grandKp,
+			waitErr: errUnsupportedKeyType,
 		},
 	}
 
@@ -579,10 +595,10 @@ func TestAuthorModule_SubmitExtrinsic_WithVersion_V0929(t *testing.T) {
 	telemetryMock := NewMockTelemetry(ctrl)
 	telemetryMock.EXPECT().
 		SendMessage(
-			telemetry.NewTxpoolImport(0, 1),
+			telemetry.NewTxpoolImport(1, 0),
 		)
 
-	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock)
+	integrationTestController.stateSrv.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
 	genesisHash := integrationTestController.genesisHeader.Hash()
 	extrinsic := createExtrinsic(t, integrationTestController.runtime, genesisHash, 0)
@@ -613,7 +629,7 @@ func TestAuthorModule_SubmitExtrinsic_WithVersion_V0929(t *testing.T) {
 	}
 
 	expectedHash := ExtrinsicHashResponse(expectedExtrinsic.Hash().String())
-	txOnPool := integrationTestController.stateSrv.Transaction.PendingInPool()
+	txOnPool := integrationTestController.stateSrv.Transaction.Pending()
 
 	// compare results
 	require.Len(t, txOnPool, 1)
@@ -669,7 +685,7 @@ func setupStateAndRuntime(t *testing.T, basepath string, useInstance useRuntimeI
 	})
 	state2test.UseMemDB()
 
-	state2test.Transaction = state.NewTransactionState(telemetryMock)
+	state2test.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
 	err := state2test.Initialise(&gen, &genesisHeader, genesisTrie)
 	require.NoError(t, err)
 
@@ -730,7 +746,7 @@ func setupStateAndPopulateTrieState(t *testing.T, basepath string,
 	})
 	state2test.UseMemDB()
 
-	state2test.Transaction = state.NewTransactionState(telemetryMock)
+	state2test.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
 	err := state2test.Initialise(&gen, &genesisHeader, genesisTrie)
 	require.NoError(t, err)
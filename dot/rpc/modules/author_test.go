@@ -14,10 +14,12 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/gorilla/rpc/v2/json2"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
@@ -299,6 +301,72 @@ type args struct {
 	}
 }
 
+func TestAuthorModule_RemoveExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	extrinsic := types.Extrinsic{1, 2, 3}
+	hash := common.Hash{1}
+	dependentHash := common.Hash{2}
+
+	testCases := map[string]struct {
+		txStateAPIBuilder func(ctrl *gomock.Controller) TransactionStateAPI
+		req               ExtrinsicOrHashRequest
+		errMessage        string
+		res               RemoveExtrinsicsResponse
+	}{
+		"invalid_extrinsic": {
+			txStateAPIBuilder: func(ctrl *gomock.Controller) TransactionStateAPI {
+				return nil
+			},
+			req:        ExtrinsicOrHashRequest{{Extrinsic: "0xzz"}},
+			errMessage: "decoding extrinsic: encoding/hex: invalid byte: U+007A 'z': 0xzz",
+		},
+		"nothing_removed": {
+			txStateAPIBuilder: func(ctrl *gomock.Controller) TransactionStateAPI {
+				txStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+				txStateAPI.EXPECT().RemoveInvalid(hash).Return(nil)
+				return txStateAPI
+			},
+			req: ExtrinsicOrHashRequest{{Hash: hash}},
+			res: RemoveExtrinsicsResponse{},
+		},
+		"hash_and_extrinsic": {
+			txStateAPIBuilder: func(ctrl *gomock.Controller) TransactionStateAPI {
+				txStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+				txStateAPI.EXPECT().RemoveInvalid(hash, extrinsic.Hash()).
+					Return([]common.Hash{hash, dependentHash, extrinsic.Hash()})
+				return txStateAPI
+			},
+			req: ExtrinsicOrHashRequest{
+				{Hash: hash},
+				{Extrinsic: common.BytesToHex(extrinsic)},
+			},
+			res: RemoveExtrinsicsResponse{hash, dependentHash, extrinsic.Hash()},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			authorModule := &AuthorModule{
+				txStateAPI: testCase.txStateAPIBuilder(ctrl),
+			}
+
+			var res RemoveExtrinsicsResponse
+			err := authorModule.RemoveExtrinsic(nil, &testCase.req, &res)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.res, res)
+		})
+	}
+}
+
 func TestAuthorModule_InsertKey(t *testing.T) {
 	kp1, err := sr25519.NewKeypairFromSeed(
 		common.MustHexToBytes("0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a"))
@@ -316,14 +384,49 @@ func TestAuthorModule_InsertKey(t *testing.T) {
 	require.NoError(t, err)
 	_ = kp3.Public().Hex()
 
+	kp4, err := keystore.DecodeKeyPairFromHex(
+		common.MustHexToBytes("0xcb6df9de1efca7a3998a8ead4e02159d5fa99c3e0d4fd6432667390bb4726854"),
+		crypto.Secp256k1Type)
+	require.NoError(t, err)
+
+	aliceKp, err := keystore.DecodeKeyPairFromSecretURI("//Alice", crypto.Sr25519Type)
+	require.NoError(t, err)
+
+	const blsSeed = "0xcb6df9de1efca7a3998a8ead4e02159d5fa99c3e0d4fd6432667390bb4726854"
+	blsKp, err := keystore.DecodeKeyPairFromSecretURI(blsSeed, crypto.Bls381Type)
+	require.NoError(t, err)
+
 	ctrl := gomock.NewController(t)
 
 	mockCoreAPIHappyBabe := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPIHappyBabe.EXPECT().InsertKey(kp1, "babe").Return(nil)
 
+	mockCoreAPIHappyBeef := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPIHappyBeef.EXPECT().InsertKey(kp4, "beef").Return(nil)
+
+	mockCoreAPIHappyBeefBls := mocks.NewMockCoreAPI(ctrl)
+	isBlsKp := gomock.Cond(func(x any) bool {
+		kp, ok := x.(keystore.KeyPair)
+		return ok && kp.Type() == crypto.Bls381Type && kp.Public().Hex() == blsKp.Public().Hex()
+	})
+	mockCoreAPIHappyBeefBls.EXPECT().InsertKey(isBlsKp, "beef").Return(nil)
+
 	mockCoreAPIHappyGran := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPIHappyGran.EXPECT().InsertKey(kp2, "gran").Return(nil)
 
+	mockCoreAPIHappyAlice := mocks.NewMockCoreAPI(ctrl)
+	isAliceKp := gomock.Cond(func(x any) bool {
+		kp, ok := x.(keystore.KeyPair)
+		return ok && kp.Public().Hex() == aliceKp.Public().Hex()
+	})
+	mockCoreAPIHappyAlice.EXPECT().InsertKey(isAliceKp, "babe").Return(nil)
+
+	mockCoreAPIErr := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPIErr.EXPECT().InsertKey(kp1, "babe").Return(errors.New("test error"))
+
+	mockCoreAPINoKeystore := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPINoKeystore.EXPECT().InsertKey(kp1, "babe").Return(keystore.ErrInvalidKeystoreName)
+
 	type fields struct {
 		logger     Infoer
 		coreAPI    CoreAPI
@@ -367,6 +470,30 @@ type args struct {
 				},
 			},
 		},
+		{
+			name: "happy_path,_beef_keytype",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPIHappyBeef,
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"beef",
+					"0xcb6df9de1efca7a3998a8ead4e02159d5fa99c3e0d4fd6432667390bb4726854",
+					"0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1",
+				},
+			},
+		},
+		{
+			name: "happy_path,_beef_keytype_bls381",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPIHappyBeefBls,
+			},
+			args: args{
+				req: &KeyInsertRequest{"beef", blsSeed, blsKp.Public().Hex()},
+			},
+		},
 		{
 			name: "invalid_key",
 			fields: fields{
@@ -378,7 +505,7 @@ type args struct {
 					"0x0000000000000000000000000000000000000000000000000000000000000000",
 				},
 			},
-			expErr: ErrProvidedKeyDoesNotMatch,
+			expErr: errKeyDoesNotMatch,
 		},
 		{
 			name: "unknown_key",
@@ -392,7 +519,83 @@ type args struct {
 					"0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a",
 				},
 			},
-			expErr: errors.New("cannot decode key: invalid key type"),
+			expErr: errUnsupportedKeyType,
+		},
+		{
+			name: "happy_path,_secret_uri",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPIHappyAlice,
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babe",
+					"//Alice",
+					"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+				},
+			},
+		},
+		{
+			name: "invalid_key_type_length",
+			fields: fields{
+				logger: log.New(log.SetWriter(io.Discard)),
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babes",
+					"0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a",
+					"0xdad5131003242c37c227f744f82118dd59a24b949ae264a93d949100738c196c",
+				},
+			},
+			expErr: errInvalidKeyType,
+		},
+		{
+			name: "invalid_secret_uri",
+			fields: fields{
+				logger: log.New(log.SetWriter(io.Discard)),
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babe",
+					"0x6246ddf254",
+					"0xdad5131003242c37c227f744f82118dd59a24b949ae264a93d949100738c196c",
+				},
+			},
+			expErr: errInvalidSecretURI,
+		},
+		{
+			name: "keystore_error",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPIErr,
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babe",
+					"0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a",
+					"0xdad5131003242c37c227f744f82118dd59a24b949ae264a93d949100738c196c",
+				},
+			},
+			expErr: &json2.Error{
+				Code:    otherAuthorErrorCode,
+				Message: "The key store is unavailable",
+				Data:    "test error",
+			},
+		},
+		{
+			name: "no_keystore_for_key_type",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPINoKeystore,
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babe",
+					"0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a",
+					"0xdad5131003242c37c227f744f82118dd59a24b949ae264a93d949100738c196c",
+				},
+			},
+			expErr: errUnsupportedKeyType,
 		},
 	}
 	for _, tt := range tests {
@@ -405,7 +608,7 @@ type args struct {
 			var res KeyInsertResponse
 			err := am.InsertKey(tt.args.r, tt.args.req, &res)
 			if tt.expErr != nil {
-				assert.EqualError(t, err, tt.expErr.Error())
+				assert.Equal(t, tt.expErr, err)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -464,6 +667,14 @@ type args struct {
 			},
 			wantRes: false,
 		},
+		{
+			name: "HasKey_missing_key_type",
+			args: args{
+				req: &[]string{kr.Alice().Public().Hex()},
+			},
+			wantRes: false,
+			expErr:  ErrHasKeyInvalidParams,
+		},
 		{
 			name: "HasKey_error",
 			fields: fields{
@@ -494,3 +705,20 @@ type args struct {
 		})
 	}
 }
+
+func TestAuthorModule_ListKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	keys := map[string][]string{
+		"babe": {"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"},
+		"gran": {},
+	}
+	mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPI.EXPECT().ListKeys().Return(keys)
+
+	am := &AuthorModule{coreAPI: mockCoreAPI}
+	var res ListKeysResponse
+	err := am.ListKeys(nil, nil, &res)
+	require.NoError(t, err)
+	assert.Equal(t, ListKeysResponse(keys), res)
+}
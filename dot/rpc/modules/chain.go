@@ -4,6 +4,7 @@
 package modules
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -52,7 +53,54 @@ type ChainBlock struct {
 
 // ChainBlockResponse struct
 type ChainBlockResponse struct {
-	Block ChainBlock `json:"block"`
+	Block          ChainBlock                `json:"block"`
+	Justifications []ChainBlockJustification `json:"justifications"`
+}
+
+// ChainBlockJustification is the justification of a block for a consensus engine. It is encoded
+// in JSON as the tuple of the consensus engine id and of the encoded justification, both as arrays
+// of bytes, to be compatible with the Substrate format.
+type ChainBlockJustification struct {
+	EngineID      types.ConsensusEngineID
+	Justification []byte
+}
+
+// MarshalJSON encodes the justification as the tuple of its consensus engine id and its bytes.
+func (j ChainBlockJustification) MarshalJSON() ([]byte, error) {
+	justification := make([]uint16, len(j.Justification))
+	for i, b := range j.Justification {
+		justification[i] = uint16(b)
+	}
+	return json.Marshal([2]any{j.EngineID, justification})
+}
+
+// UnmarshalJSON decodes the tuple of a consensus engine id and of the justification bytes.
+func (j *ChainBlockJustification) UnmarshalJSON(data []byte) error {
+	var tuple [2]json.RawMessage
+	err := json.Unmarshal(data, &tuple)
+	if err != nil {
+		return fmt.Errorf("decoding justification tuple: %w", err)
+	}
+
+	err = json.Unmarshal(tuple[0], &j.EngineID)
+	if err != nil {
+		return fmt.Errorf("decoding consensus engine id: %w", err)
+	}
+
+	var justification []uint16
+	err = json.Unmarshal(tuple[1], &justification)
+	if err != nil {
+		return fmt.Errorf("decoding justification: %w", err)
+	}
+
+	j.Justification = make([]byte, len(justification))
+	for i, b := range justification {
+		if b > 0xff {
+			return fmt.Errorf("decoding justification: byte %d at index %d overflows", b, i)
+		}
+		j.Justification[i] = byte(b)
+	}
+	return nil
 }
 
 // ChainHashResponse interface to handle response
@@ -70,8 +118,8 @@ func NewChainModule(api BlockAPI) *ChainModule {
 	}
 }
 
-// GetBlock Get header and body of a relay chain block. If no block hash is provided,
-// the latest block body will be returned.
+// GetBlock Get header and body of a relay chain block, along with its justifications.
+// If no block hash is provided, the latest block body will be returned.
 func (cm *ChainModule) GetBlock(r *http.Request, req *ChainHashRequest, res *ChainBlockResponse) error {
 	hash := cm.hashLookup(req)
 	block, err := cm.blockAPI.GetBlockByHash(hash)
@@ -93,7 +141,30 @@ func (cm *ChainModule) GetBlock(r *http.Request, req *ChainHashRequest, res *Cha
 			res.Block.Body = append(res.Block.Body, e.String())
 		}
 	}
-	return nil
+
+	res.Justifications, err = cm.justifications(hash)
+	return err
+}
+
+// justifications returns the justifications stored for the block hash given, only GRANDPA
+// justifications being stored, or nil if none is stored.
+func (cm *ChainModule) justifications(hash common.Hash) ([]ChainBlockJustification, error) {
+	hasJustification, err := cm.blockAPI.HasJustification(hash)
+	if err != nil {
+		return nil, fmt.Errorf("checking for justification: %w", err)
+	} else if !hasJustification {
+		return nil, nil
+	}
+
+	justification, err := cm.blockAPI.GetJustification(hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting justification: %w", err)
+	}
+
+	return []ChainBlockJustification{{
+		EngineID:      types.GrandpaEngineID,
+		Justification: justification,
+	}}, nil
 }
 
 // GetBlockHash Get hash of the 'n-th' block in the canon chain. If no parameters are provided,
@@ -0,0 +1,189 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// chainHeadStorageValue is the only storage query type supported by chainHead_v1_storage
+const chainHeadStorageValue = "value"
+
+var (
+	errUnsupportedStorageQuery = errors.New("unsupported storage query type")
+	errRuntimeCallsNotServed   = errors.New("runtime calls are not served by this node")
+)
+
+// ChainHeadHeaderRequest is the request of chainHead_v1_header
+type ChainHeadHeaderRequest struct {
+	FollowSubscription string
+	Hash               common.Hash
+}
+
+// ChainHeadStorageItem is a storage query of chainHead_v1_storage
+type ChainHeadStorageItem struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+// ChainHeadStorageRequest is the request of chainHead_v1_storage
+type ChainHeadStorageRequest struct {
+	FollowSubscription string
+	Hash               common.Hash
+	Items              []ChainHeadStorageItem
+}
+
+// ChainHeadStorageResult is a storage value returned by chainHead_v1_storage
+type ChainHeadStorageResult struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ChainHeadCallRequest is the request of chainHead_v1_call
+type ChainHeadCallRequest struct {
+	FollowSubscription string
+	Hash               common.Hash
+	Function           string
+	CallParameters     string
+}
+
+// ChainHeadModule is an RPC module providing the chainHead methods. The results are
+// returned directly rather than through the events of a follow subscription.
+type ChainHeadModule struct {
+	blockAPI     BlockAPI
+	chainHeadAPI ChainHeadAPI
+}
+
+// NewChainHeadModule creates a new chainHead module. The storage and runtime calls are
+// served by the given ChainHeadAPI, or from the local state if it is nil.
+func NewChainHeadModule(blockAPI BlockAPI, storageAPI StorageAPI, chainHeadAPI ChainHeadAPI) *ChainHeadModule {
+	if chainHeadAPI == nil {
+		chainHeadAPI = &localChainHead{blockAPI: blockAPI, storageAPI: storageAPI}
+	}
+
+	return &ChainHeadModule{
+		blockAPI:     blockAPI,
+		chainHeadAPI: chainHeadAPI,
+	}
+}
+
+// Header returns the hex encoded SCALE header of the block
+func (cm *ChainHeadModule) Header(_ *http.Request, req *ChainHeadHeaderRequest, res *string) error {
+	header, err := cm.blockAPI.GetHeader(req.Hash)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := scale.Marshal(*header)
+	if err != nil {
+		return err
+	}
+
+	*res = common.BytesToHex(encoded)
+	return nil
+}
+
+// Storage returns the storage values of the requested keys at the block. Keys
+// without a value are omitted from the results.
+func (cm *ChainHeadModule) Storage(_ *http.Request, req *ChainHeadStorageRequest,
+	res *[]ChainHeadStorageResult) error {
+	keys := make([][]byte, len(req.Items))
+	for i, item := range req.Items {
+		if item.Type != chainHeadStorageValue {
+			return fmt.Errorf("%w: %s", errUnsupportedStorageQuery, item.Type)
+		}
+
+		key, err := common.HexToBytes(item.Key)
+		if err != nil {
+			return fmt.Errorf("decoding key %s: %w", item.Key, err)
+		}
+		keys[i] = key
+	}
+
+	values, err := cm.chainHeadAPI.Storage(req.Hash, keys)
+	if err != nil {
+		return err
+	}
+
+	results := make([]ChainHeadStorageResult, 0, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		results = append(results, ChainHeadStorageResult{
+			Key:   req.Items[i].Key,
+			Value: common.BytesToHex(value),
+		})
+	}
+
+	*res = results
+	return nil
+}
+
+// Call calls the runtime function at the block and returns its hex encoded result.
+// It returns errRuntimeCallsNotServed if the ChainHeadAPI is not a ChainHeadCallAPI.
+func (cm *ChainHeadModule) Call(_ *http.Request, req *ChainHeadCallRequest, res *string) error {
+	chainHeadCallAPI, ok := cm.chainHeadAPI.(ChainHeadCallAPI)
+	if !ok {
+		return errRuntimeCallsNotServed
+	}
+
+	params, err := common.HexToBytes(req.CallParameters)
+	if err != nil {
+		return fmt.Errorf("decoding call parameters: %w", err)
+	}
+
+	result, err := chainHeadCallAPI.Call(req.Hash, req.Function, params)
+	if err != nil {
+		return err
+	}
+
+	*res = common.BytesToHex(result)
+	return nil
+}
+
+// localChainHead serves the chainHead storage and runtime calls from the node state,
+// pinning the block queried so its state is not pruned during the query.
+type localChainHead struct {
+	blockAPI   BlockAPI
+	storageAPI StorageAPI
+}
+
+func (l *localChainHead) Storage(block common.Hash, keys [][]byte) (values [][]byte, err error) {
+	err = l.blockAPI.PinBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("pinning block: %w", err)
+	}
+	defer l.blockAPI.UnpinBlock(block)
+
+	values = make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i], err = l.storageAPI.GetStorageByBlockHash(&block, key)
+		if err != nil {
+			return nil, fmt.Errorf("getting storage for key 0x%x: %w", key, err)
+		}
+	}
+	return values, nil
+}
+
+func (l *localChainHead) Call(block common.Hash, function string, params []byte) ([]byte, error) {
+	err := l.blockAPI.PinBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("pinning block: %w", err)
+	}
+	defer l.blockAPI.UnpinBlock(block)
+
+	rt, release, err := l.blockAPI.AcquireRuntime(block)
+	if err != nil {
+		return nil, fmt.Errorf("get runtime: %w", err)
+	}
+	defer release()
+
+	return rt.Exec(function, params)
+}
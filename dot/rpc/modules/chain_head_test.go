@@ -0,0 +1,195 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChainHeadModule_Header(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 4, nil)
+	encoded, err := scale.Marshal(*header)
+	require.NoError(t, err)
+
+	blockAPI := NewMockBlockAPI(ctrl)
+	blockAPI.EXPECT().GetHeader(common.Hash{9}).Return(header, nil)
+
+	module := NewChainHeadModule(blockAPI, nil, nil)
+	var res string
+	err = module.Header(nil, &ChainHeadHeaderRequest{Hash: common.Hash{9}}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, common.BytesToHex(encoded), res)
+}
+
+func TestChainHeadModule_Storage(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	block := common.Hash{1}
+
+	testCases := map[string]struct {
+		chainHeadAPIBuilder func(ctrl *gomock.Controller) ChainHeadAPI
+		items               []ChainHeadStorageItem
+		results             []ChainHeadStorageResult
+		errWrapped          error
+		errMessage          string
+	}{
+		"unsupported_query_type": {
+			chainHeadAPIBuilder: func(ctrl *gomock.Controller) ChainHeadAPI { return NewMockChainHeadAPI(ctrl) },
+			items:               []ChainHeadStorageItem{{Key: "0x01", Type: "hash"}},
+			errWrapped:          errUnsupportedStorageQuery,
+			errMessage:          "unsupported storage query type: hash",
+		},
+		"storage_error": {
+			chainHeadAPIBuilder: func(ctrl *gomock.Controller) ChainHeadAPI {
+				chainHeadAPI := NewMockChainHeadAPI(ctrl)
+				chainHeadAPI.EXPECT().Storage(block, [][]byte{{1}}).Return(nil, errTest)
+				return chainHeadAPI
+			},
+			items:      []ChainHeadStorageItem{{Key: "0x01", Type: "value"}},
+			errWrapped: errTest,
+			errMessage: "test error",
+		},
+		"missing_values_omitted": {
+			chainHeadAPIBuilder: func(ctrl *gomock.Controller) ChainHeadAPI {
+				chainHeadAPI := NewMockChainHeadAPI(ctrl)
+				chainHeadAPI.EXPECT().Storage(block, [][]byte{{1}, {2}}).Return([][]byte{nil, {3}}, nil)
+				return chainHeadAPI
+			},
+			items: []ChainHeadStorageItem{
+				{Key: "0x01", Type: "value"},
+				{Key: "0x02", Type: "value"},
+			},
+			results: []ChainHeadStorageResult{{Key: "0x02", Value: "0x03"}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			module := NewChainHeadModule(nil, nil, testCase.chainHeadAPIBuilder(ctrl))
+			var res []ChainHeadStorageResult
+			err := module.Storage(nil, &ChainHeadStorageRequest{Hash: block, Items: testCase.items}, &res)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.results, res)
+		})
+	}
+}
+
+func TestChainHeadModule_Call(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		chainHeadAPIBuilder func(ctrl *gomock.Controller) ChainHeadAPI
+		result              string
+		errWrapped          error
+	}{
+		"runtime_calls_not_served": {
+			chainHeadAPIBuilder: func(ctrl *gomock.Controller) ChainHeadAPI { return NewMockChainHeadAPI(ctrl) },
+			errWrapped:          errRuntimeCallsNotServed,
+		},
+		"success": {
+			chainHeadAPIBuilder: func(ctrl *gomock.Controller) ChainHeadAPI {
+				chainHeadAPI := NewMockChainHeadCallAPI(ctrl)
+				chainHeadAPI.EXPECT().Call(common.Hash{1}, "Core_version", []byte{2}).Return([]byte{3}, nil)
+				return chainHeadAPI
+			},
+			result: "0x03",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			module := NewChainHeadModule(nil, nil, testCase.chainHeadAPIBuilder(ctrl))
+			var res string
+			err := module.Call(nil, &ChainHeadCallRequest{
+				Hash:           common.Hash{1},
+				Function:       "Core_version",
+				CallParameters: "0x02",
+			}, &res)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.result, res)
+		})
+	}
+}
+
+func TestLocalChainHead_Storage(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	block := common.Hash{1}
+
+	testCases := map[string]struct {
+		blockAPIBuilder   func(ctrl *gomock.Controller) BlockAPI
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		values            [][]byte
+		errWrapped        error
+		errMessage        string
+	}{
+		"pin_error": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().PinBlock(block).Return(errTest)
+				return blockAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI { return NewMockStorageAPI(ctrl) },
+			errWrapped:        errTest,
+			errMessage:        "pinning block: test error",
+		},
+		"block_pinned_during_query": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				pin := blockAPI.EXPECT().PinBlock(block).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(block).Return(true).After(pin)
+				return blockAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorageByBlockHash(&block, []byte{1}).Return([]byte{2}, nil)
+				return storageAPI
+			},
+			values: [][]byte{{2}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			chainHead := &localChainHead{
+				blockAPI:   testCase.blockAPIBuilder(ctrl),
+				storageAPI: testCase.storageAPIBuilder(ctrl),
+			}
+			values, err := chainHead.Storage(block, [][]byte{{1}})
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.values, values)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package modules
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
@@ -27,6 +28,7 @@ func TestChainModule_GetBlock(t *testing.T) {
 	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
 	mockBlockAPI.EXPECT().GetBlockByHash(inputHash).Return(&emptyBlock, nil)
 	mockBlockAPI.EXPECT().BestBlockHash().Return(testHash)
+	mockBlockAPI.EXPECT().HasJustification(inputHash).Return(false, nil)
 
 	mockBlockAPIGetHashErr := mocks.NewMockBlockAPI(ctrl)
 	mockBlockAPIGetHashErr.EXPECT().GetBlockByHash(inputHash).Return(nil, errors.New("GetJustification error"))
@@ -35,6 +37,17 @@ func TestChainModule_GetBlock(t *testing.T) {
 	bodyBlock.Body = types.BytesArrayToExtrinsics([][]byte{{1}})
 	mockBlockAPIWithBody := mocks.NewMockBlockAPI(ctrl)
 	mockBlockAPIWithBody.EXPECT().GetBlockByHash(inputHash).Return(&bodyBlock, nil)
+	mockBlockAPIWithBody.EXPECT().HasJustification(inputHash).Return(false, nil)
+
+	mockBlockAPIWithJustification := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPIWithJustification.EXPECT().GetBlockByHash(inputHash).Return(&emptyBlock, nil)
+	mockBlockAPIWithJustification.EXPECT().HasJustification(inputHash).Return(true, nil)
+	mockBlockAPIWithJustification.EXPECT().GetJustification(inputHash).Return([]byte{1, 2}, nil)
+
+	mockBlockAPIJustificationErr := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPIJustificationErr.EXPECT().GetBlockByHash(inputHash).Return(&emptyBlock, nil)
+	mockBlockAPIJustificationErr.EXPECT().HasJustification(inputHash).Return(true, nil)
+	mockBlockAPIJustificationErr.EXPECT().GetJustification(inputHash).Return(nil, errors.New("test error"))
 
 	chainModule := NewChainModule(mockBlockAPI)
 	type fields struct {
@@ -99,6 +112,49 @@ type args struct {
 				Body: []string{"0x0401"},
 			}},
 		},
+		{
+			name: "GetBlock_with_justification_OK",
+			fields: fields{
+				mockBlockAPIWithJustification,
+			},
+			args: args{
+				req: &ChainHashRequest{&testHash},
+			},
+			exp: ChainBlockResponse{
+				Block: ChainBlock{
+					Header: ChainBlockHeaderResponse{
+						ParentHash:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+						Number:         "0x00",
+						StateRoot:      "0x0000000000000000000000000000000000000000000000000000000000000000",
+						ExtrinsicsRoot: "0x0000000000000000000000000000000000000000000000000000000000000000",
+						Digest:         ChainBlockHeaderDigest{},
+					},
+				},
+				Justifications: []ChainBlockJustification{{
+					EngineID:      types.GrandpaEngineID,
+					Justification: []byte{1, 2},
+				}},
+			},
+		},
+		{
+			name: "GetJustification_Err",
+			fields: fields{
+				mockBlockAPIJustificationErr,
+			},
+			args: args{
+				req: &ChainHashRequest{&testHash},
+			},
+			exp: ChainBlockResponse{Block: ChainBlock{
+				Header: ChainBlockHeaderResponse{
+					ParentHash:     "0x0000000000000000000000000000000000000000000000000000000000000000",
+					Number:         "0x00",
+					StateRoot:      "0x0000000000000000000000000000000000000000000000000000000000000000",
+					ExtrinsicsRoot: "0x0000000000000000000000000000000000000000000000000000000000000000",
+					Digest:         ChainBlockHeaderDigest{},
+				},
+			}},
+			expErr: errors.New("getting justification: test error"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -117,6 +173,27 @@ type args struct {
 	}
 }
 
+func TestChainBlockJustification_JSON(t *testing.T) {
+	t.Parallel()
+
+	justifications := []ChainBlockJustification{{
+		EngineID:      types.GrandpaEngineID,
+		Justification: []byte{0, 1, 255},
+	}}
+
+	data, err := json.Marshal(justifications)
+	require.NoError(t, err)
+	assert.Equal(t, `[[[70,82,78,75],[0,1,255]]]`, string(data))
+
+	var decoded []ChainBlockJustification
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, justifications, decoded)
+
+	err = json.Unmarshal([]byte(`[[[70,82,78,75],[256]]]`), &decoded)
+	assert.EqualError(t, err, "decoding justification: byte 256 at index 0 overflows")
+}
+
 func TestChainModule_GetBlockHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
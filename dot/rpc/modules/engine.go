@@ -0,0 +1,88 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var errManualSealDisabled = errors.New("manual seal is only available in dev mode")
+
+// EngineCreateBlockRequest is the request of engine_createBlock
+type EngineCreateBlockRequest struct {
+	CreateEmpty bool
+	Finalize    bool
+	ParentHash  *common.Hash
+}
+
+// ImportedAux describes the import of a created block
+type ImportedAux struct {
+	HeaderOnly                 bool `json:"header_only"`
+	ClearJustificationRequests bool `json:"clear_justification_requests"`
+	NeedsJustification         bool `json:"needs_justification"`
+	BadJustification           bool `json:"bad_justification"`
+	IsNewBest                  bool `json:"is_new_best"`
+}
+
+// CreatedBlock is the response of engine_createBlock
+type CreatedBlock struct {
+	Hash common.Hash `json:"hash"`
+	Aux  ImportedAux `json:"aux"`
+}
+
+// EngineFinalizeBlockRequest is the request of engine_finalizeBlock
+type EngineFinalizeBlockRequest struct {
+	Hash          common.Hash
+	Justification *string
+}
+
+// EngineModule is an RPC module providing the manual seal methods of the dev mode
+type EngineModule struct {
+	blockAPI      BlockAPI
+	manualSealAPI ManualSealAPI
+}
+
+// NewEngineModule creates a new engine module.
+func NewEngineModule(blockAPI BlockAPI, manualSealAPI ManualSealAPI) *EngineModule {
+	return &EngineModule{
+		blockAPI:      blockAPI,
+		manualSealAPI: manualSealAPI,
+	}
+}
+
+// CreateBlock builds and imports a block, on top of the best block unless a parent hash is given
+func (em *EngineModule) CreateBlock(_ *http.Request, req *EngineCreateBlockRequest, res *CreatedBlock) error {
+	if em.manualSealAPI == nil {
+		return errManualSealDisabled
+	}
+
+	hash, err := em.manualSealAPI.CreateBlock(req.ParentHash, req.CreateEmpty, req.Finalize)
+	if err != nil {
+		return err
+	}
+
+	*res = CreatedBlock{
+		Hash: hash,
+		Aux:  ImportedAux{IsNewBest: em.blockAPI.BestBlockHash() == hash},
+	}
+	return nil
+}
+
+// FinalizeBlock finalises the block with the given hash. Justifications are ignored.
+func (em *EngineModule) FinalizeBlock(_ *http.Request, req *EngineFinalizeBlockRequest, res *bool) error {
+	if em.manualSealAPI == nil {
+		return errManualSealDisabled
+	}
+
+	err := em.manualSealAPI.FinalizeBlock(req.Hash)
+	if err != nil {
+		return err
+	}
+
+	*res = true
+	return nil
+}
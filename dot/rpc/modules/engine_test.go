@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEngineModule_CreateBlock(t *testing.T) {
+	t.Parallel()
+
+	parentHash := common.Hash{1}
+	blockHash := common.Hash{2}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		engineModule func(ctrl *gomock.Controller) *EngineModule
+		request      *EngineCreateBlockRequest
+		expected     CreatedBlock
+		errWrapped   error
+	}{
+		"manual_seal_disabled": {
+			engineModule: func(ctrl *gomock.Controller) *EngineModule {
+				return NewEngineModule(NewMockBlockAPI(ctrl), nil)
+			},
+			request:    &EngineCreateBlockRequest{},
+			errWrapped: errManualSealDisabled,
+		},
+		"create_block_error": {
+			engineModule: func(ctrl *gomock.Controller) *EngineModule {
+				manualSeal := NewMockManualSealAPI(ctrl)
+				manualSeal.EXPECT().CreateBlock(nil, true, false).Return(common.Hash{}, errTest)
+				return NewEngineModule(NewMockBlockAPI(ctrl), manualSeal)
+			},
+			request:    &EngineCreateBlockRequest{CreateEmpty: true},
+			errWrapped: errTest,
+		},
+		"new_best_block": {
+			engineModule: func(ctrl *gomock.Controller) *EngineModule {
+				manualSeal := NewMockManualSealAPI(ctrl)
+				manualSeal.EXPECT().CreateBlock(nil, true, true).Return(blockHash, nil)
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(blockHash)
+				return NewEngineModule(blockAPI, manualSeal)
+			},
+			request: &EngineCreateBlockRequest{CreateEmpty: true, Finalize: true},
+			expected: CreatedBlock{
+				Hash: blockHash,
+				Aux:  ImportedAux{IsNewBest: true},
+			},
+		},
+		"fork_block": {
+			engineModule: func(ctrl *gomock.Controller) *EngineModule {
+				manualSeal := NewMockManualSealAPI(ctrl)
+				manualSeal.EXPECT().CreateBlock(&parentHash, false, false).Return(blockHash, nil)
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(common.Hash{3})
+				return NewEngineModule(blockAPI, manualSeal)
+			},
+			request:  &EngineCreateBlockRequest{ParentHash: &parentHash},
+			expected: CreatedBlock{Hash: blockHash},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			var res CreatedBlock
+			err := testCase.engineModule(ctrl).CreateBlock(nil, testCase.request, &res)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.expected, res)
+		})
+	}
+}
+
+func TestEngineModule_FinalizeBlock(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.Hash{1}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		manualSeal func(ctrl *gomock.Controller) ManualSealAPI
+		expected   bool
+		errWrapped error
+	}{
+		"manual_seal_disabled": {
+			manualSeal: func(*gomock.Controller) ManualSealAPI { return nil },
+			errWrapped: errManualSealDisabled,
+		},
+		"finalise_error": {
+			manualSeal: func(ctrl *gomock.Controller) ManualSealAPI {
+				manualSeal := NewMockManualSealAPI(ctrl)
+				manualSeal.EXPECT().FinalizeBlock(blockHash).Return(errTest)
+				return manualSeal
+			},
+			errWrapped: errTest,
+		},
+		"success": {
+			manualSeal: func(ctrl *gomock.Controller) ManualSealAPI {
+				manualSeal := NewMockManualSealAPI(ctrl)
+				manualSeal.EXPECT().FinalizeBlock(blockHash).Return(nil)
+				return manualSeal
+			},
+			expected: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			engineModule := NewEngineModule(NewMockBlockAPI(ctrl), testCase.manualSeal(ctrl))
+
+			var res bool
+			err := engineModule.FinalizeBlock(nil, &EngineFinalizeBlockRequest{Hash: blockHash}, &res)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.expected, res)
+		})
+	}
+}
@@ -4,9 +4,11 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 )
@@ -14,13 +16,15 @@
 // GrandpaModule init parameters
 type GrandpaModule struct {
 	blockAPI         BlockAPI
+	grandpaStateAPI  GrandpaStateAPI
 	blockFinalityAPI BlockFinalityAPI
 }
 
 // NewGrandpaModule creates a new Grandpa rpc module.
-func NewGrandpaModule(api BlockAPI, finalityAPI BlockFinalityAPI) *GrandpaModule {
+func NewGrandpaModule(api BlockAPI, grandpaStateAPI GrandpaStateAPI, finalityAPI BlockFinalityAPI) *GrandpaModule {
 	return &GrandpaModule{
 		blockAPI:         api,
+		grandpaStateAPI:  grandpaStateAPI,
 		blockFinalityAPI: finalityAPI,
 	}
 }
@@ -58,9 +62,16 @@ type ProveFinalityRequest struct {
 // ProveFinality for the provided block number, the Justification for the last block in the set is written to the
 // response. The response is a SCALE encoded proof array.  The proof array is empty if the block number is
 // not finalized.
+// The last block of the set is found in the set id changes stored, and the Justification of the block
+// itself is written if its set is not yet ended.
 // Returns error which are included in the response if they occur.
 func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityRequest, res *ProveFinalityResponse) error {
-	blockHash, err := gm.blockAPI.GetHashByNumber(uint(req.BlockNumber))
+	justifiedNumber, err := gm.setEndBlockNumber(uint(req.BlockNumber))
+	if err != nil {
+		return err
+	}
+
+	blockHash, err := gm.blockAPI.GetHashByNumber(justifiedNumber)
 	if err != nil {
 		return err
 	}
@@ -82,6 +93,23 @@ func (gm *GrandpaModule) ProveFinality(r *http.Request, req *ProveFinalityReques
 	return nil
 }
 
+// setEndBlockNumber returns the number of the last block of the authority set of the block number
+// given, or the block number given if its set is not yet ended.
+func (gm *GrandpaModule) setEndBlockNumber(blockNumber uint) (uint, error) {
+	setID, err := gm.grandpaStateAPI.GetSetIDByBlockNumber(blockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("getting set id of block %d: %w", blockNumber, err)
+	}
+
+	endNumber, err := gm.grandpaStateAPI.GetSetEndBlockNumber(setID)
+	if errors.Is(err, state.ErrSetNotEnded) {
+		return blockNumber, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("getting last block of set id %d: %w", setID, err)
+	}
+	return endNumber, nil
+}
+
 // RoundState returns the state of the current best round state as well as the ongoing background rounds.
 func (gm *GrandpaModule) RoundState(r *http.Request, req *EmptyRequest, res *RoundStateResponse) error {
 	voters := gm.blockFinalityAPI.GetVoters()
@@ -32,7 +32,7 @@ func TestGrandpaProveFinality(t *testing.T) {
 		t.Errorf("Fail: bestblock failed")
 	}
 
-	gmSvc := NewGrandpaModule(testStateService.Block, nil)
+	gmSvc := NewGrandpaModule(testStateService.Block, testStateService.Grandpa, nil)
 
 	testStateService.Block.SetJustification(bestBlock.Header.ParentHash, make([]byte, 10))
 	testStateService.Block.SetJustification(bestBlock.Header.Hash(), make([]byte, 11))
@@ -80,7 +80,7 @@ func TestRoundState(t *testing.T) {
 		kr.Bob().Public().(*ed25519.PublicKey).AsBytes(),
 	})
 
-	mod := NewGrandpaModule(nil, grandpamock)
+	mod := NewGrandpaModule(nil, nil, grandpamock)
 
 	res := new(RoundStateResponse)
 	err := mod.RoundState(nil, nil, res)
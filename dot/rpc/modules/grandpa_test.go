@@ -9,6 +9,7 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
@@ -23,18 +24,59 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 
 	mockError := errors.New("test mock error")
 
+	currentSetBuilder := func(blockNumber uint) func(ctrl *gomock.Controller) GrandpaStateAPI {
+		return func(ctrl *gomock.Controller) GrandpaStateAPI {
+			mockGrandpaStateAPI := NewMockGrandpaStateAPI(ctrl)
+			mockGrandpaStateAPI.EXPECT().GetSetIDByBlockNumber(blockNumber).Return(uint64(1), nil)
+			mockGrandpaStateAPI.EXPECT().GetSetEndBlockNumber(uint64(1)).
+				Return(uint(0), state.ErrSetNotEnded)
+			return mockGrandpaStateAPI
+		}
+	}
+
 	tests := map[string]struct {
-		blockAPIBuilder func(ctrl *gomock.Controller) BlockAPI
-		request         *ProveFinalityRequest
-		expErr          error
-		exp             ProveFinalityResponse
+		blockAPIBuilder        func(ctrl *gomock.Controller) BlockAPI
+		grandpaStateAPIBuilder func(ctrl *gomock.Controller) GrandpaStateAPI
+		request                *ProveFinalityRequest
+		expErr                 error
+		exp                    ProveFinalityResponse
 	}{
+		"error_during_get_set_id": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				return NewMockBlockAPI(ctrl)
+			},
+			grandpaStateAPIBuilder: func(ctrl *gomock.Controller) GrandpaStateAPI {
+				mockGrandpaStateAPI := NewMockGrandpaStateAPI(ctrl)
+				mockGrandpaStateAPI.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), mockError)
+				return mockGrandpaStateAPI
+			},
+			request: &ProveFinalityRequest{
+				BlockNumber: 1,
+			},
+			expErr: mockError,
+		},
+		"error_during_get_set_end_block_number": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				return NewMockBlockAPI(ctrl)
+			},
+			grandpaStateAPIBuilder: func(ctrl *gomock.Controller) GrandpaStateAPI {
+				mockGrandpaStateAPI := NewMockGrandpaStateAPI(ctrl)
+				mockGrandpaStateAPI.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(1), nil)
+				mockGrandpaStateAPI.EXPECT().GetSetEndBlockNumber(uint64(1)).Return(uint(0), mockError)
+				return mockGrandpaStateAPI
+			},
+			request: &ProveFinalityRequest{
+				BlockNumber: 1,
+			},
+			expErr: mockError,
+		},
 		"error_during_get_hash_by_number": {
 			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
 				mockBlockAPI := NewMockBlockAPI(ctrl)
 				mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{}, mockError)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: currentSetBuilder(1),
 			request: &ProveFinalityRequest{
 				BlockNumber: 1,
 			},
@@ -47,6 +89,7 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 				mockBlockAPI.EXPECT().HasJustification(common.Hash{2}).Return(false, mockError)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: currentSetBuilder(2),
 			request: &ProveFinalityRequest{
 				BlockNumber: 2,
 			},
@@ -59,6 +102,7 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 				mockBlockAPI.EXPECT().HasJustification(common.Hash{2}).Return(false, nil)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: currentSetBuilder(2),
 			request: &ProveFinalityRequest{
 				BlockNumber: 2,
 			},
@@ -72,6 +116,7 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 				mockBlockAPI.EXPECT().GetJustification(common.Hash{3}).Return(nil, mockError)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: currentSetBuilder(3),
 			request: &ProveFinalityRequest{
 				BlockNumber: 3,
 			},
@@ -85,11 +130,31 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 				mockBlockAPI.EXPECT().GetJustification(common.Hash{4}).Return([]byte(`justification`), nil)
 				return mockBlockAPI
 			},
+			grandpaStateAPIBuilder: currentSetBuilder(4),
 			request: &ProveFinalityRequest{
 				BlockNumber: 4,
 			},
 			exp: ProveFinalityResponse{common.BytesToHex([]byte(`justification`))},
 		},
+		"block_of_ended_set": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				mockBlockAPI.EXPECT().GetHashByNumber(uint(8)).Return(common.Hash{8}, nil)
+				mockBlockAPI.EXPECT().HasJustification(common.Hash{8}).Return(true, nil)
+				mockBlockAPI.EXPECT().GetJustification(common.Hash{8}).Return([]byte(`justification`), nil)
+				return mockBlockAPI
+			},
+			grandpaStateAPIBuilder: func(ctrl *gomock.Controller) GrandpaStateAPI {
+				mockGrandpaStateAPI := NewMockGrandpaStateAPI(ctrl)
+				mockGrandpaStateAPI.EXPECT().GetSetIDByBlockNumber(uint(5)).Return(uint64(1), nil)
+				mockGrandpaStateAPI.EXPECT().GetSetEndBlockNumber(uint64(1)).Return(uint(8), nil)
+				return mockGrandpaStateAPI
+			},
+			request: &ProveFinalityRequest{
+				BlockNumber: 5,
+			},
+			exp: ProveFinalityResponse{common.BytesToHex([]byte(`justification`))},
+		},
 	}
 	for name, tt := range tests {
 		tt := tt
@@ -97,7 +162,8 @@ func TestGrandpaModule_ProveFinality(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 			gm := &GrandpaModule{
-				blockAPI: tt.blockAPIBuilder(ctrl),
+				blockAPI:        tt.blockAPIBuilder(ctrl),
+				grandpaStateAPI: tt.grandpaStateAPIBuilder(ctrl),
 			}
 			res := ProveFinalityResponse(nil)
 			err := gm.ProveFinality(nil, tt.request, &res)
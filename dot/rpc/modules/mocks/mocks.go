@@ -21,6 +21,7 @@
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
 	trie "github.com/ChainSafe/gossamer/pkg/trie"
+	multiaddr "github.com/multiformats/go-multiaddr"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -77,6 +78,21 @@ func (mr *MockStorageAPIMockRecorder) GetKeysWithPrefix(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysWithPrefix", reflect.TypeOf((*MockStorageAPI)(nil).GetKeysWithPrefix), arg0, arg1)
 }
 
+// GetKeysWithPrefixPaged mocks base method.
+func (m *MockStorageAPI) GetKeysWithPrefixPaged(arg0 *common.Hash, arg1, arg2 []byte, arg3 uint) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysWithPrefixPaged", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeysWithPrefixPaged indicates an expected call of GetKeysWithPrefixPaged.
+func (mr *MockStorageAPIMockRecorder) GetKeysWithPrefixPaged(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysWithPrefixPaged", reflect.TypeOf((*MockStorageAPI)(nil).GetKeysWithPrefixPaged), arg0, arg1, arg2, arg3)
+}
+
 // GetStateRootFromBlock mocks base method.
 func (m *MockStorageAPI) GetStateRootFromBlock(arg0 *common.Hash) (*common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -164,6 +180,21 @@ func (mr *MockStorageAPIMockRecorder) RegisterStorageObserver(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterStorageObserver", reflect.TypeOf((*MockStorageAPI)(nil).RegisterStorageObserver), arg0)
 }
 
+// StorageDiff mocks base method.
+func (m *MockStorageAPI) StorageDiff(arg0, arg1 common.Hash) (map[string][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageDiff", arg0, arg1)
+	ret0, _ := ret[0].(map[string][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageDiff indicates an expected call of StorageDiff.
+func (mr *MockStorageAPIMockRecorder) StorageDiff(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageDiff", reflect.TypeOf((*MockStorageAPI)(nil).StorageDiff), arg0, arg1)
+}
+
 // UnregisterStorageObserver mocks base method.
 func (m *MockStorageAPI) UnregisterStorageObserver(arg0 state.Observer) {
 	m.ctrl.T.Helper()
@@ -199,6 +230,22 @@ func (m *MockBlockAPI) EXPECT() *MockBlockAPIMockRecorder {
 	return m.recorder
 }
 
+// AcquireRuntime mocks base method.
+func (m *MockBlockAPI) AcquireRuntime(arg0 common.Hash) (runtime.Instance, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireRuntime", arg0)
+	ret0, _ := ret[0].(runtime.Instance)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AcquireRuntime indicates an expected call of AcquireRuntime.
+func (mr *MockBlockAPIMockRecorder) AcquireRuntime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireRuntime", reflect.TypeOf((*MockBlockAPI)(nil).AcquireRuntime), arg0)
+}
+
 // BestBlockHash mocks base method.
 func (m *MockBlockAPI) BestBlockHash() common.Hash {
 	m.ctrl.T.Helper()
@@ -252,6 +299,22 @@ func (mr *MockBlockAPIMockRecorder) GetBlockByHash(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockAPI)(nil).GetBlockByHash), arg0)
 }
 
+// GetChangedKeys mocks base method.
+func (m *MockBlockAPI) GetChangedKeys(arg0 common.Hash) ([][]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedKeys", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChangedKeys indicates an expected call of GetChangedKeys.
+func (mr *MockBlockAPIMockRecorder) GetChangedKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedKeys", reflect.TypeOf((*MockBlockAPI)(nil).GetChangedKeys), arg0)
+}
+
 // GetFinalisedHash mocks base method.
 func (m *MockBlockAPI) GetFinalisedHash(arg0, arg1 uint64) (common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -370,6 +433,21 @@ func (mr *MockBlockAPIMockRecorder) GetRuntime(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockAPI)(nil).GetRuntime), arg0)
 }
 
+// GetRuntimeUpgrades mocks base method.
+func (m *MockBlockAPI) GetRuntimeUpgrades() ([]state.RuntimeUpgrade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntimeUpgrades")
+	ret0, _ := ret[0].([]state.RuntimeUpgrade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntimeUpgrades indicates an expected call of GetRuntimeUpgrades.
+func (mr *MockBlockAPIMockRecorder) GetRuntimeUpgrades() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntimeUpgrades", reflect.TypeOf((*MockBlockAPI)(nil).GetRuntimeUpgrades))
+}
+
 // HasJustification mocks base method.
 func (m *MockBlockAPI) HasJustification(arg0 common.Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -385,6 +463,20 @@ func (mr *MockBlockAPIMockRecorder) HasJustification(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasJustification", reflect.TypeOf((*MockBlockAPI)(nil).HasJustification), arg0)
 }
 
+// PinBlock mocks base method.
+func (m *MockBlockAPI) PinBlock(arg0 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinBlock indicates an expected call of PinBlock.
+func (mr *MockBlockAPIMockRecorder) PinBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinBlock", reflect.TypeOf((*MockBlockAPI)(nil).PinBlock), arg0)
+}
+
 // RangeInMemory mocks base method.
 func (m *MockBlockAPI) RangeInMemory(arg0, arg1 common.Hash) ([]common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -415,6 +507,20 @@ func (mr *MockBlockAPIMockRecorder) RegisterRuntimeUpdatedChannel(arg0 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRuntimeUpdatedChannel", reflect.TypeOf((*MockBlockAPI)(nil).RegisterRuntimeUpdatedChannel), arg0)
 }
 
+// UnpinBlock mocks base method.
+func (m *MockBlockAPI) UnpinBlock(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinBlock", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// UnpinBlock indicates an expected call of UnpinBlock.
+func (mr *MockBlockAPIMockRecorder) UnpinBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinBlock", reflect.TypeOf((*MockBlockAPI)(nil).UnpinBlock), arg0)
+}
+
 // UnregisterRuntimeUpdatedChannel mocks base method.
 func (m *MockBlockAPI) UnregisterRuntimeUpdatedChannel(arg0 uint32) bool {
 	m.ctrl.T.Helper()
@@ -484,6 +590,21 @@ func (mr *MockNetworkAPIMockRecorder) Health() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockNetworkAPI)(nil).Health))
 }
 
+// ListenAddresses mocks base method.
+func (m *MockNetworkAPI) ListenAddresses() ([]multiaddr.Multiaddr, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenAddresses")
+	ret0, _ := ret[0].([]multiaddr.Multiaddr)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListenAddresses indicates an expected call of ListenAddresses.
+func (mr *MockNetworkAPIMockRecorder) ListenAddresses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenAddresses", reflect.TypeOf((*MockNetworkAPI)(nil).ListenAddresses))
+}
+
 // NetworkState mocks base method.
 func (m *MockNetworkAPI) NetworkState() common.NetworkState {
 	m.ctrl.T.Helper()
@@ -544,6 +665,20 @@ func (mr *MockNetworkAPIMockRecorder) RemoveReservedPeers(arg0 ...any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReservedPeers", reflect.TypeOf((*MockNetworkAPI)(nil).RemoveReservedPeers), arg0...)
 }
 
+// ReservedPeers mocks base method.
+func (m *MockNetworkAPI) ReservedPeers() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReservedPeers")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// ReservedPeers indicates an expected call of ReservedPeers.
+func (mr *MockNetworkAPIMockRecorder) ReservedPeers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReservedPeers", reflect.TypeOf((*MockNetworkAPI)(nil).ReservedPeers))
+}
+
 // Start mocks base method.
 func (m *MockNetworkAPI) Start() error {
 	m.ctrl.T.Helper()
@@ -702,6 +837,24 @@ func (mr *MockTransactionStateAPIMockRecorder) Pending() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionStateAPI)(nil).Pending))
 }
 
+// RemoveInvalid mocks base method.
+func (m *MockTransactionStateAPI) RemoveInvalid(arg0 ...common.Hash) []common.Hash {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveInvalid", varargs...)
+	ret0, _ := ret[0].([]common.Hash)
+	return ret0
+}
+
+// RemoveInvalid indicates an expected call of RemoveInvalid.
+func (mr *MockTransactionStateAPIMockRecorder) RemoveInvalid(arg0 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveInvalid", reflect.TypeOf((*MockTransactionStateAPI)(nil).RemoveInvalid), arg0...)
+}
+
 // MockCoreAPI is a mock of CoreAPI interface.
 type MockCoreAPI struct {
 	ctrl     *gomock.Controller
@@ -829,6 +982,20 @@ func (mr *MockCoreAPIMockRecorder) InsertKey(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertKey", reflect.TypeOf((*MockCoreAPI)(nil).InsertKey), arg0, arg1)
 }
 
+// ListKeys mocks base method.
+func (m *MockCoreAPI) ListKeys() map[string][]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys")
+	ret0, _ := ret[0].(map[string][]string)
+	return ret0
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockCoreAPIMockRecorder) ListKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockCoreAPI)(nil).ListKeys))
+}
+
 // MockSystemAPI is a mock of SystemAPI interface.
 type MockSystemAPI struct {
 	ctrl     *gomock.Controller
@@ -880,6 +1047,20 @@ func (mr *MockSystemAPIMockRecorder) ChainType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainType", reflect.TypeOf((*MockSystemAPI)(nil).ChainType))
 }
 
+// NodeRole mocks base method.
+func (m *MockSystemAPI) NodeRole() common.NetworkRole {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeRole")
+	ret0, _ := ret[0].(common.NetworkRole)
+	return ret0
+}
+
+// NodeRole indicates an expected call of NodeRole.
+func (mr *MockSystemAPIMockRecorder) NodeRole() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeRole", reflect.TypeOf((*MockSystemAPI)(nil).NodeRole))
+}
+
 // Properties mocks base method.
 func (m *MockSystemAPI) Properties() map[string]any {
 	m.ctrl.T.Helper()
@@ -1,16 +1,16 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,Telemetry)
+// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,Telemetry,ChainHeadAPI,ChainHeadCallAPI,ManualSealAPI,SupervisorAPI,GrandpaStateAPI)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks_test.go -package=modules . StorageAPI,BlockAPI,Telemetry
+//	mockgen -destination=mocks_test.go -package modules . StorageAPI,BlockAPI,Telemetry,ChainHeadAPI,ChainHeadCallAPI,ManualSealAPI,SupervisorAPI,GrandpaStateAPI
 //
 
 // Package modules is a generated GoMock package.
 package modules
 
 import (
-	json "encoding/json"
+	"encoding/json"
 	reflect "reflect"
 
 	state "github.com/ChainSafe/gossamer/dot/state"
@@ -74,6 +74,21 @@ func (mr *MockStorageAPIMockRecorder) GetKeysWithPrefix(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysWithPrefix", reflect.TypeOf((*MockStorageAPI)(nil).GetKeysWithPrefix), arg0, arg1)
 }
 
+// GetKeysWithPrefixPaged mocks base method.
+func (m *MockStorageAPI) GetKeysWithPrefixPaged(arg0 *common.Hash, arg1, arg2 []byte, arg3 uint) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysWithPrefixPaged", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeysWithPrefixPaged indicates an expected call of GetKeysWithPrefixPaged.
+func (mr *MockStorageAPIMockRecorder) GetKeysWithPrefixPaged(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysWithPrefixPaged", reflect.TypeOf((*MockStorageAPI)(nil).GetKeysWithPrefixPaged), arg0, arg1, arg2, arg3)
+}
+
 // GetStateRootFromBlock mocks base method.
 func (m *MockStorageAPI) GetStateRootFromBlock(arg0 *common.Hash) (*common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +176,21 @@ func (mr *MockStorageAPIMockRecorder) RegisterStorageObserver(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterStorageObserver", reflect.TypeOf((*MockStorageAPI)(nil).RegisterStorageObserver), arg0)
 }
 
+// StorageDiff mocks base method.
+func (m *MockStorageAPI) StorageDiff(arg0, arg1 common.Hash) (map[string][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorageDiff", arg0, arg1)
+	ret0, _ := ret[0].(map[string][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StorageDiff indicates an expected call of StorageDiff.
+func (mr *MockStorageAPIMockRecorder) StorageDiff(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorageDiff", reflect.TypeOf((*MockStorageAPI)(nil).StorageDiff), arg0, arg1)
+}
+
 // UnregisterStorageObserver mocks base method.
 func (m *MockStorageAPI) UnregisterStorageObserver(arg0 state.Observer) {
 	m.ctrl.T.Helper()
@@ -196,6 +226,22 @@ func (m *MockBlockAPI) EXPECT() *MockBlockAPIMockRecorder {
 	return m.recorder
 }
 
+// AcquireRuntime mocks base method.
+func (m *MockBlockAPI) AcquireRuntime(arg0 common.Hash) (runtime.Instance, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireRuntime", arg0)
+	ret0, _ := ret[0].(runtime.Instance)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AcquireRuntime indicates an expected call of AcquireRuntime.
+func (mr *MockBlockAPIMockRecorder) AcquireRuntime(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireRuntime", reflect.TypeOf((*MockBlockAPI)(nil).AcquireRuntime), arg0)
+}
+
 // BestBlockHash mocks base method.
 func (m *MockBlockAPI) BestBlockHash() common.Hash {
 	m.ctrl.T.Helper()
@@ -249,6 +295,22 @@ func (mr *MockBlockAPIMockRecorder) GetBlockByHash(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockAPI)(nil).GetBlockByHash), arg0)
 }
 
+// GetChangedKeys mocks base method.
+func (m *MockBlockAPI) GetChangedKeys(arg0 common.Hash) ([][]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedKeys", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChangedKeys indicates an expected call of GetChangedKeys.
+func (mr *MockBlockAPIMockRecorder) GetChangedKeys(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedKeys", reflect.TypeOf((*MockBlockAPI)(nil).GetChangedKeys), arg0)
+}
+
 // GetFinalisedHash mocks base method.
 func (m *MockBlockAPI) GetFinalisedHash(arg0, arg1 uint64) (common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -367,6 +429,21 @@ func (mr *MockBlockAPIMockRecorder) GetRuntime(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockAPI)(nil).GetRuntime), arg0)
 }
 
+// GetRuntimeUpgrades mocks base method.
+func (m *MockBlockAPI) GetRuntimeUpgrades() ([]state.RuntimeUpgrade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntimeUpgrades")
+	ret0, _ := ret[0].([]state.RuntimeUpgrade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntimeUpgrades indicates an expected call of GetRuntimeUpgrades.
+func (mr *MockBlockAPIMockRecorder) GetRuntimeUpgrades() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntimeUpgrades", reflect.TypeOf((*MockBlockAPI)(nil).GetRuntimeUpgrades))
+}
+
 // HasJustification mocks base method.
 func (m *MockBlockAPI) HasJustification(arg0 common.Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -382,6 +459,20 @@ func (mr *MockBlockAPIMockRecorder) HasJustification(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasJustification", reflect.TypeOf((*MockBlockAPI)(nil).HasJustification), arg0)
 }
 
+// PinBlock mocks base method.
+func (m *MockBlockAPI) PinBlock(arg0 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinBlock indicates an expected call of PinBlock.
+func (mr *MockBlockAPIMockRecorder) PinBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinBlock", reflect.TypeOf((*MockBlockAPI)(nil).PinBlock), arg0)
+}
+
 // RangeInMemory mocks base method.
 func (m *MockBlockAPI) RangeInMemory(arg0, arg1 common.Hash) ([]common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -412,6 +503,20 @@ func (mr *MockBlockAPIMockRecorder) RegisterRuntimeUpdatedChannel(arg0 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRuntimeUpdatedChannel", reflect.TypeOf((*MockBlockAPI)(nil).RegisterRuntimeUpdatedChannel), arg0)
 }
 
+// UnpinBlock mocks base method.
+func (m *MockBlockAPI) UnpinBlock(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinBlock", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// UnpinBlock indicates an expected call of UnpinBlock.
+func (mr *MockBlockAPIMockRecorder) UnpinBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinBlock", reflect.TypeOf((*MockBlockAPI)(nil).UnpinBlock), arg0)
+}
+
 // UnregisterRuntimeUpdatedChannel mocks base method.
 func (m *MockBlockAPI) UnregisterRuntimeUpdatedChannel(arg0 uint32) bool {
 	m.ctrl.T.Helper()
@@ -460,3 +565,236 @@ func (mr *MockTelemetryMockRecorder) SendMessage(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockTelemetry)(nil).SendMessage), arg0)
 }
+
+// MockChainHeadAPI is a mock of ChainHeadAPI interface.
+type MockChainHeadAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainHeadAPIMockRecorder
+}
+
+// MockChainHeadAPIMockRecorder is the mock recorder for MockChainHeadAPI.
+type MockChainHeadAPIMockRecorder struct {
+	mock *MockChainHeadAPI
+}
+
+// NewMockChainHeadAPI creates a new mock instance.
+func NewMockChainHeadAPI(ctrl *gomock.Controller) *MockChainHeadAPI {
+	mock := &MockChainHeadAPI{ctrl: ctrl}
+	mock.recorder = &MockChainHeadAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainHeadAPI) EXPECT() *MockChainHeadAPIMockRecorder {
+	return m.recorder
+}
+
+// Storage mocks base method.
+func (m *MockChainHeadAPI) Storage(arg0 common.Hash, arg1 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Storage", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Storage indicates an expected call of Storage.
+func (mr *MockChainHeadAPIMockRecorder) Storage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Storage", reflect.TypeOf((*MockChainHeadAPI)(nil).Storage), arg0, arg1)
+}
+
+// MockChainHeadCallAPI is a mock of ChainHeadCallAPI interface.
+type MockChainHeadCallAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainHeadCallAPIMockRecorder
+}
+
+// MockChainHeadCallAPIMockRecorder is the mock recorder for MockChainHeadCallAPI.
+type MockChainHeadCallAPIMockRecorder struct {
+	mock *MockChainHeadCallAPI
+}
+
+// NewMockChainHeadCallAPI creates a new mock instance.
+func NewMockChainHeadCallAPI(ctrl *gomock.Controller) *MockChainHeadCallAPI {
+	mock := &MockChainHeadCallAPI{ctrl: ctrl}
+	mock.recorder = &MockChainHeadCallAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainHeadCallAPI) EXPECT() *MockChainHeadCallAPIMockRecorder {
+	return m.recorder
+}
+
+// Call mocks base method.
+func (m *MockChainHeadCallAPI) Call(arg0 common.Hash, arg1 string, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Call", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Call indicates an expected call of Call.
+func (mr *MockChainHeadCallAPIMockRecorder) Call(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockChainHeadCallAPI)(nil).Call), arg0, arg1, arg2)
+}
+
+// Storage mocks base method.
+func (m *MockChainHeadCallAPI) Storage(arg0 common.Hash, arg1 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Storage", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Storage indicates an expected call of Storage.
+func (mr *MockChainHeadCallAPIMockRecorder) Storage(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Storage", reflect.TypeOf((*MockChainHeadCallAPI)(nil).Storage), arg0, arg1)
+}
+
+// MockManualSealAPI is a mock of ManualSealAPI interface.
+type MockManualSealAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockManualSealAPIMockRecorder
+}
+
+// MockManualSealAPIMockRecorder is the mock recorder for MockManualSealAPI.
+type MockManualSealAPIMockRecorder struct {
+	mock *MockManualSealAPI
+}
+
+// NewMockManualSealAPI creates a new mock instance.
+func NewMockManualSealAPI(ctrl *gomock.Controller) *MockManualSealAPI {
+	mock := &MockManualSealAPI{ctrl: ctrl}
+	mock.recorder = &MockManualSealAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManualSealAPI) EXPECT() *MockManualSealAPIMockRecorder {
+	return m.recorder
+}
+
+// CreateBlock mocks base method.
+func (m *MockManualSealAPI) CreateBlock(arg0 *common.Hash, arg1, arg2 bool) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlock indicates an expected call of CreateBlock.
+func (mr *MockManualSealAPIMockRecorder) CreateBlock(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlock", reflect.TypeOf((*MockManualSealAPI)(nil).CreateBlock), arg0, arg1, arg2)
+}
+
+// FinalizeBlock mocks base method.
+func (m *MockManualSealAPI) FinalizeBlock(arg0 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizeBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinalizeBlock indicates an expected call of FinalizeBlock.
+func (mr *MockManualSealAPIMockRecorder) FinalizeBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeBlock", reflect.TypeOf((*MockManualSealAPI)(nil).FinalizeBlock), arg0)
+}
+
+// MockSupervisorAPI is a mock of SupervisorAPI interface.
+type MockSupervisorAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupervisorAPIMockRecorder
+}
+
+// MockSupervisorAPIMockRecorder is the mock recorder for MockSupervisorAPI.
+type MockSupervisorAPIMockRecorder struct {
+	mock *MockSupervisorAPI
+}
+
+// NewMockSupervisorAPI creates a new mock instance.
+func NewMockSupervisorAPI(ctrl *gomock.Controller) *MockSupervisorAPI {
+	mock := &MockSupervisorAPI{ctrl: ctrl}
+	mock.recorder = &MockSupervisorAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupervisorAPI) EXPECT() *MockSupervisorAPIMockRecorder {
+	return m.recorder
+}
+
+// Health mocks base method.
+func (m *MockSupervisorAPI) Health() []common.ServiceHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Health")
+	ret0, _ := ret[0].([]common.ServiceHealth)
+	return ret0
+}
+
+// Health indicates an expected call of Health.
+func (mr *MockSupervisorAPIMockRecorder) Health() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Health", reflect.TypeOf((*MockSupervisorAPI)(nil).Health))
+}
+
+// MockGrandpaStateAPI is a mock of GrandpaStateAPI interface.
+type MockGrandpaStateAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrandpaStateAPIMockRecorder
+}
+
+// MockGrandpaStateAPIMockRecorder is the mock recorder for MockGrandpaStateAPI.
+type MockGrandpaStateAPIMockRecorder struct {
+	mock *MockGrandpaStateAPI
+}
+
+// NewMockGrandpaStateAPI creates a new mock instance.
+func NewMockGrandpaStateAPI(ctrl *gomock.Controller) *MockGrandpaStateAPI {
+	mock := &MockGrandpaStateAPI{ctrl: ctrl}
+	mock.recorder = &MockGrandpaStateAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrandpaStateAPI) EXPECT() *MockGrandpaStateAPIMockRecorder {
+	return m.recorder
+}
+
+// GetSetEndBlockNumber mocks base method.
+func (m *MockGrandpaStateAPI) GetSetEndBlockNumber(arg0 uint64) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetEndBlockNumber", arg0)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetEndBlockNumber indicates an expected call of GetSetEndBlockNumber.
+func (mr *MockGrandpaStateAPIMockRecorder) GetSetEndBlockNumber(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetEndBlockNumber", reflect.TypeOf((*MockGrandpaStateAPI)(nil).GetSetEndBlockNumber), arg0)
+}
+
+// GetSetIDByBlockNumber mocks base method.
+func (m *MockGrandpaStateAPI) GetSetIDByBlockNumber(arg0 uint) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetIDByBlockNumber", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetIDByBlockNumber indicates an expected call of GetSetIDByBlockNumber.
+func (mr *MockGrandpaStateAPIMockRecorder) GetSetIDByBlockNumber(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDByBlockNumber", reflect.TypeOf((*MockGrandpaStateAPI)(nil).GetSetIDByBlockNumber), arg0)
+}
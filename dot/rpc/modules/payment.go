@@ -45,10 +45,11 @@ func (p *PaymentModule) QueryInfo(_ *http.Request, req *PaymentQueryInfoRequest,
 		hash = *req.Hash
 	}
 
-	r, err := p.blockAPI.GetRuntime(hash)
+	r, release, err := p.blockAPI.AcquireRuntime(hash)
 	if err != nil {
 		return err
 	}
+	defer release()
 
 	ext, err := common.HexToBytes(req.Ext)
 	if err != nil {
@@ -44,7 +44,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 		blockAPIMock := mocks.NewMockBlockAPI(ctrl)
 		blockAPIMock.EXPECT().BestBlockHash().Return(bestBlockHash)
 
-		blockAPIMock.EXPECT().GetRuntime(bestBlockHash).Return(runtimeMock, nil)
+		blockAPIMock.EXPECT().AcquireRuntime(bestBlockHash).Return(runtimeMock, func() {}, nil)
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -67,8 +67,8 @@ func TestPaymentQueryInfo(t *testing.T) {
 		blockAPIMock := mocks.NewMockBlockAPI(ctrl)
 		blockAPIMock.EXPECT().BestBlockHash().Return(bestBlockHash)
 
-		blockAPIMock.EXPECT().GetRuntime(bestBlockHash).
-			Return(nil, errors.New("mocked problems"))
+		blockAPIMock.EXPECT().AcquireRuntime(bestBlockHash).
+			Return(nil, nil, errors.New("mocked problems"))
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -92,7 +92,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 		runtimeMock.EXPECT().PaymentQueryInfo(gomock.Any()).Return(nil, errors.New("mocked error"))
 
 		blockAPIMock := mocks.NewMockBlockAPI(ctrl)
-		blockAPIMock.EXPECT().GetRuntime(common.Hash{1, 2}).Return(runtimeMock, nil)
+		blockAPIMock.EXPECT().AcquireRuntime(common.Hash{1, 2}).Return(runtimeMock, func() {}, nil)
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -117,7 +117,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 		runtimeMock.EXPECT().PaymentQueryInfo(gomock.Any()).Return(nil, nil)
 
 		blockAPIMock := mocks.NewMockBlockAPI(ctrl)
-		blockAPIMock.EXPECT().GetRuntime(common.Hash{1, 2}).Return(runtimeMock, nil)
+		blockAPIMock.EXPECT().AcquireRuntime(common.Hash{1, 2}).Return(runtimeMock, func() {}, nil)
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -37,13 +37,13 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 	blockErrorAPIMock2 := mocks.NewMockBlockAPI(ctrl)
 
 	blockAPIMock.EXPECT().BestBlockHash().Return(testHash).Times(2)
-	blockAPIMock.EXPECT().GetRuntime(testHash).Return(runtimeMock, nil).Times(3)
+	blockAPIMock.EXPECT().AcquireRuntime(testHash).Return(runtimeMock, func() {}, nil).Times(3)
 
-	blockAPIMock2.EXPECT().GetRuntime(testHash).Return(runtimeMock2, nil)
+	blockAPIMock2.EXPECT().AcquireRuntime(testHash).Return(runtimeMock2, func() {}, nil)
 
-	blockErrorAPIMock1.EXPECT().GetRuntime(testHash).Return(runtimeErrorMock, nil)
+	blockErrorAPIMock1.EXPECT().AcquireRuntime(testHash).Return(runtimeErrorMock, func() {}, nil)
 
-	blockErrorAPIMock2.EXPECT().GetRuntime(testHash).Return(nil, errors.New("GetRuntime error"))
+	blockErrorAPIMock2.EXPECT().AcquireRuntime(testHash).Return(nil, nil, errors.New("AcquireRuntime error"))
 
 	runtimeMock.EXPECT().PaymentQueryInfo(common.MustHexToBytes("0x0000")).Return(nil, nil).Times(2)
 	runtimeMock2.EXPECT().PaymentQueryInfo(common.MustHexToBytes("0x0000")).Return(&types.RuntimeDispatchInfo{
@@ -139,7 +139,7 @@ type args struct {
 			expErr: errors.New("PaymentQueryInfo error"),
 		},
 		{
-			name: "GetRuntime_error",
+			name: "AcquireRuntime_error",
 			fields: fields{
 				blockErrorAPIMock2,
 			},
@@ -149,7 +149,7 @@ type args struct {
 					Hash: &testHash,
 				},
 			},
-			expErr: errors.New("GetRuntime error"),
+			expErr: errors.New("AcquireRuntime error"),
 		},
 	}
 	for _, tt := range tests {
@@ -16,10 +16,17 @@
 		"author_removeExtrinsic",
 		"author_insertKey",
 		"author_rotateKeys",
+		"author_listKeys",
 		"state_getPairs",
 		"state_getKeysPaged",
 		"state_queryStorage",
+		"state_getStorageDiff",
 		"state_trie",
+		"admin_reloadConfig",
+		"admin_addBadBlock",
+		"admin_removeBadBlock",
+		"offchain_localStorageGet",
+		"offchain_localStorageSet",
 	}
 
 	// AliasesMethods is a map that links the original methods to their aliases
@@ -27,6 +34,9 @@
 		"chain_getHead":          "chain_getBlockHash",
 		"account_nextIndex":      "system_accountNextIndex",
 		"chain_getFinalisedHead": "chain_getFinalizedHead",
+		"chainHead_v1_header":    "chainHead_header",
+		"chainHead_v1_storage":   "chainHead_storage",
+		"chainHead_v1_call":      "chainHead_call",
 	}
 )
 
@@ -4,10 +4,11 @@
 package modules
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"net/http"
-	"strings"
+	"slices"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -77,6 +78,13 @@ type StateStorageQueryRangeRequest struct {
 	EndBlock   common.Hash `json:"block"`
 }
 
+// StateStorageDiffRequest holds json fields
+type StateStorageDiffRequest struct {
+	From   common.Hash `json:"from" validate:"required"`
+	To     common.Hash `json:"to"`
+	Prefix *string     `json:"prefix"`
+}
+
 // StateStorageQueryAtRequest holds json fields
 type StateStorageQueryAtRequest struct {
 	Keys []string    `json:"keys" validate:"required"`
@@ -140,6 +148,15 @@ type StorageChangeSetResponse struct {
 	Changes [][2]*string `json:"changes"`
 }
 
+// StorageDiffResponse holds the storage changes between two blocks
+type StorageDiffResponse struct {
+	From common.Hash `json:"from"`
+	To   common.Hash `json:"to"`
+	// Changes are the keys changed and their value at the block To, ordered by key,
+	// the value of the keys deleted being nil.
+	Changes [][2]*string `json:"changes"`
+}
+
 // KeyValueOption struct holds json fields
 type KeyValueOption []byte
 
@@ -181,6 +198,13 @@ func NewStateRuntimeVersionResponse(runtimeVersion runtime.Version) (
 	}
 }
 
+// StateRuntimeUpgradeResponse is a runtime upgrade enacted by a block
+type StateRuntimeUpgradeResponse struct {
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint        `json:"blockNumber"`
+	SpecVersion uint32      `json:"specVersion"`
+}
+
 // StateModule is an RPC module providing access to storage API points.
 type StateModule struct {
 	networkAPI NetworkAPI
@@ -199,12 +223,28 @@ func NewStateModule(net NetworkAPI, storage StorageAPI, core CoreAPI, blockAPI B
 	}
 }
 
+// pinBlock pins the block with the hash given, if any, so its state is kept in memory
+// while it is read, and returns the function unpinning it.
+func (sm *StateModule) pinBlock(hash *common.Hash) (unpin func(), err error) {
+	if hash == nil {
+		return func() {}, nil
+	}
+
+	err = sm.blockAPI.PinBlock(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("pinning block: %w", err)
+	}
+	return func() { sm.blockAPI.UnpinBlock(*hash) }, nil
+}
+
 // GetPairs returns the keys with prefix, leave empty to get all the keys.
 func (sm *StateModule) GetPairs(_ *http.Request, req *StatePairRequest, res *StatePairResponse) error {
-	var (
-		stateRootHash *common.Hash
-		err           error
-	)
+	var stateRootHash *common.Hash
+	unpin, err := sm.pinBlock(req.Bhash)
+	if err != nil {
+		return err
+	}
+	defer unpin()
 
 	if req.Bhash != nil {
 		stateRootHash, err = sm.storageAPI.GetStateRootFromBlock(req.Bhash)
@@ -263,6 +303,12 @@ func (sm *StateModule) Trie(_ *http.Request, req *StateTrieAtRequest, res *State
 		blockHash = sm.blockAPI.BestBlockHash()
 	}
 
+	unpin, err := sm.pinBlock(&blockHash)
+	if err != nil {
+		return err
+	}
+	defer unpin()
+
 	blockHeader, err := sm.blockAPI.GetHeader(blockHash)
 	if err != nil {
 		return fmt.Errorf("getting header: %w", err)
@@ -301,10 +347,17 @@ func (sm *StateModule) Call(_ *http.Request, req *StateCallRequest, res *StateCa
 		blockHash = *req.Block
 	}
 
-	rt, err := sm.blockAPI.GetRuntime(blockHash)
+	unpin, err := sm.pinBlock(&blockHash)
+	if err != nil {
+		return err
+	}
+	defer unpin()
+
+	rt, release, err := sm.blockAPI.AcquireRuntime(blockHash)
 	if err != nil {
 		return fmt.Errorf("get runtime: %w", err)
 	}
+	defer release()
 
 	request, err := common.HexToBytes(req.Params)
 	if err != nil {
@@ -329,24 +382,28 @@ func (sm *StateModule) GetKeysPaged(_ *http.Request, req *StateStorageKeyRequest
 	if err != nil {
 		return err
 	}
-	keys, err := sm.storageAPI.GetKeysWithPrefix(req.Block, hPrefix)
+	var afterKey []byte
+	if req.AfterKey != "" {
+		afterKey, err = common.HexToBytes(req.AfterKey)
+		if err != nil {
+			return fmt.Errorf("cannot convert after key: %w", err)
+		}
+	}
+
+	unpin, err := sm.pinBlock(req.Block)
+	if err != nil {
+		return err
+	}
+	defer unpin()
+
+	keys, err := sm.storageAPI.GetKeysWithPrefixPaged(req.Block, hPrefix, afterKey, uint(req.Qty))
 	if err != nil {
 		return fmt.Errorf("cannot get keys with prefix %s: %w", hPrefix, err)
 	}
-	resCount := uint32(0)
 	for _, k := range keys {
-		fKey := fmt.Sprintf("0x%x", k)
-		if strings.Compare(fKey, req.AfterKey) == 1 {
-			// sm.storageAPI.Keys sorts keys in lexicographical order, so we know that keys where strings.Compare = 1
-			//  are after the requested after key.
-			if resCount >= req.Qty {
-				break
-			}
-			*res = append(*res, fKey)
-			resCount++
-		}
+		*res = append(*res, common.BytesToHex(k))
 	}
-	return err
+	return nil
 }
 
 // GetMetadata calls runtime Metadata_metadata function
@@ -375,6 +432,14 @@ func (sm *StateModule) GetReadProof(
 		keys[i] = bKey
 	}
 
+	if !req.Hash.IsEmpty() {
+		unpin, err := sm.pinBlock(&req.Hash)
+		if err != nil {
+			return err
+		}
+		defer unpin()
+	}
+
 	block, proofs, err := sm.coreAPI.GetReadProofAt(req.Hash, keys)
 	if err != nil {
 		return err
@@ -406,17 +471,40 @@ func (sm *StateModule) GetRuntimeVersion(
 	return nil
 }
 
+// GetRuntimeUpgrades returns the runtime upgrades enacted by the
+// blocks of the best chain, ordered by block number.
+func (sm *StateModule) GetRuntimeUpgrades(
+	_ *http.Request, _ *EmptyRequest, res *[]StateRuntimeUpgradeResponse) error {
+	upgrades, err := sm.blockAPI.GetRuntimeUpgrades()
+	if err != nil {
+		return err
+	}
+
+	*res = make([]StateRuntimeUpgradeResponse, len(upgrades))
+	for i, upgrade := range upgrades {
+		(*res)[i] = StateRuntimeUpgradeResponse{
+			BlockHash:   upgrade.BlockHash,
+			BlockNumber: upgrade.BlockNumber,
+			SpecVersion: upgrade.SpecVersion,
+		}
+	}
+	return nil
+}
+
 // GetStorage Returns a storage entry at a specific block's state.
 // If not block hash is provided, the latest value is returned.
 func (sm *StateModule) GetStorage(
 	_ *http.Request, req *StateStorageRequest, res *StateStorageResponse) error {
-	var (
-		item []byte
-		err  error
-	)
+	var item []byte
 
 	reqBytes, _ := common.HexToBytes(req.Key) // no need to catch error here
 
+	unpin, err := sm.pinBlock(req.Bhash)
+	if err != nil {
+		return err
+	}
+	defer unpin()
+
 	if req.Bhash != nil {
 		item, err = sm.storageAPI.GetStorageByBlockHash(req.Bhash, reqBytes)
 		if err != nil {
@@ -440,13 +528,16 @@ func (sm *StateModule) GetStorage(
 // If no block hash is provided, the latest value is returned.
 func (sm *StateModule) GetStorageHash(
 	_ *http.Request, req *StateStorageHashRequest, res *StateStorageHashResponse) error {
-	var (
-		item []byte
-		err  error
-	)
+	var item []byte
 
 	reqBytes, _ := common.HexToBytes(req.Key)
 
+	unpin, err := sm.pinBlock(req.Bhash)
+	if err != nil {
+		return err
+	}
+	defer unpin()
+
 	if req.Bhash != nil {
 		item, err = sm.storageAPI.GetStorageByBlockHash(req.Bhash, reqBytes)
 		if err != nil {
@@ -472,13 +563,16 @@ func (sm *StateModule) GetStorageHash(
 // If no block hash is provided, the latest value is used.
 func (sm *StateModule) GetStorageSize(
 	_ *http.Request, req *StateStorageSizeRequest, res *StateStorageSizeResponse) error {
-	var (
-		item []byte
-		err  error
-	)
+	var item []byte
 
 	reqBytes, _ := common.HexToBytes(req.Key)
 
+	unpin, err := sm.pinBlock(req.Bhash)
+	if err != nil {
+		return err
+	}
+	defer unpin()
+
 	if req.Bhash != nil {
 		item, err = sm.storageAPI.GetStorageByBlockHash(req.Bhash, reqBytes)
 		if err != nil {
@@ -525,6 +619,10 @@ func (sm *StateModule) QueryStorage(
 
 	response := make([]StorageChangeSetResponse, 0, endBlockNumber-startBlockNumber)
 	lastValue := make([]*string, len(req.Keys))
+	keys := make([][]byte, len(req.Keys))
+	for i, key := range req.Keys {
+		keys[i] = common.MustHexToBytes(key)
+	}
 
 	for i := startBlockNumber; i <= endBlockNumber; i++ {
 		blockHash, err := sm.blockAPI.GetHashByNumber(i)
@@ -533,11 +631,29 @@ func (sm *StateModule) QueryStorage(
 		}
 		changes := make([][2]*string, 0, len(req.Keys))
 
-		for j, key := range req.Keys {
-			value, err := sm.storageAPI.GetStorageByBlockHash(&blockHash, common.MustHexToBytes(key))
+		if i > startBlockNumber {
+			// the values are not read if the storage changes index
+			// shows the block does not modify any of the keys.
+			changedKeys, indexed, err := sm.blockAPI.GetChangedKeys(blockHash)
 			if err != nil {
-				return fmt.Errorf("getting value by block hash: %w", err)
+				return fmt.Errorf("getting changed keys: %w", err)
+			}
+			if indexed && !modifiesAny(changedKeys, keys) {
+				response = append(response, StorageChangeSetResponse{
+					Block:   &blockHash,
+					Changes: changes,
+				})
+				continue
 			}
+		}
+
+		values, err := sm.getStorageValues(blockHash, keys)
+		if err != nil {
+			return err
+		}
+
+		for j, key := range req.Keys {
+			value := values[j]
 			var hexValue *string
 			if len(value) > 0 {
 				hexValue = stringPtr(common.BytesToHex(value))
@@ -566,6 +682,79 @@ func (sm *StateModule) QueryStorage(
 	return nil
 }
 
+// GetStorageDiff returns the storage entries changed between the block From and the block To,
+// or the best block if To is empty, optionally restricted to the keys with the given prefix.
+// The blocks do not need to be on the same chain, which allows comparing the state of a block
+// before and after a runtime upgrade, or the state of two forks. If the block From is an ancestor
+// of the block To and the storage changes index covers the blocks in between, only the values
+// of the keys they modify are compared, otherwise the state tries of both blocks are compared.
+func (sm *StateModule) GetStorageDiff(
+	_ *http.Request, req *StateStorageDiffRequest, res *StorageDiffResponse) error {
+	if req.From.IsEmpty() {
+		return ErrStartBlockHashEmpty
+	}
+
+	to := req.To
+	if to.IsEmpty() {
+		to = sm.blockAPI.BestBlockHash()
+	}
+
+	var prefix []byte
+	if req.Prefix != nil {
+		var err error
+		prefix, err = common.HexToBytes(*req.Prefix)
+		if err != nil {
+			return fmt.Errorf("decoding prefix: %w", err)
+		}
+	}
+
+	unpinFrom, err := sm.pinBlock(&req.From)
+	if err != nil {
+		return err
+	}
+	defer unpinFrom()
+
+	unpinTo, err := sm.pinBlock(&to)
+	if err != nil {
+		return err
+	}
+	defer unpinTo()
+
+	diff, indexed, err := sm.indexedStorageDiff(req.From, to, prefix)
+	if err != nil {
+		return err
+	}
+	if !indexed {
+		diff, err = sm.trieStorageDiff(req.From, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	keys := make([]string, 0, len(diff))
+	for key := range diff {
+		if bytes.HasPrefix([]byte(key), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	changes := make([][2]*string, len(keys))
+	for i, key := range keys {
+		changes[i][0] = stringPtr(common.BytesToHex([]byte(key)))
+		if value := diff[key]; value != nil {
+			changes[i][1] = stringPtr(common.BytesToHex(value))
+		}
+	}
+
+	*res = StorageDiffResponse{
+		From:    req.From,
+		To:      to,
+		Changes: changes,
+	}
+	return nil
+}
+
 // QueryStorageAt queries historical storage entries (by key) at the block hash given or
 // the best block if the given block hash is nil
 func (sm *StateModule) QueryStorageAt(
@@ -575,13 +764,19 @@ func (sm *StateModule) QueryStorageAt(
 		atBlockHash = sm.blockAPI.BestBlockHash()
 	}
 
-	changes := make([][2]*string, len(request.Keys))
+	keys := make([][]byte, len(request.Keys))
+	for i, key := range request.Keys {
+		keys[i] = common.MustHexToBytes(key)
+	}
 
+	values, err := sm.getStorageValues(atBlockHash, keys)
+	if err != nil {
+		return err
+	}
+
+	changes := make([][2]*string, len(request.Keys))
 	for i, key := range request.Keys {
-		value, err := sm.storageAPI.GetStorageByBlockHash(&atBlockHash, common.MustHexToBytes(key))
-		if err != nil {
-			return fmt.Errorf("getting value by block hash: %w", err)
-		}
+		value := values[i]
 		var hexValue *string
 		if len(value) > 0 {
 			hexValue = stringPtr(common.BytesToHex(value))
@@ -600,8 +795,134 @@ func (sm *StateModule) QueryStorageAt(
 	return nil
 }
 
+// indexedStorageDiff returns the storage entries with the given prefix changed between the
+// block from and the block to, reading only the values of the keys the storage changes index
+// records as modified by the blocks in between. It returns false if the block from is not an
+// ancestor of the block to, or if the storage changes of a block in between are not indexed.
+func (sm *StateModule) indexedStorageDiff(from, to common.Hash, prefix []byte) (
+	diff map[string][]byte, indexed bool, err error) {
+	changedKeys, indexed, err := sm.changedKeysSince(from, to)
+	if err != nil || !indexed {
+		return nil, false, err
+	}
+
+	keys := make([][]byte, 0, len(changedKeys))
+	for key := range changedKeys {
+		if bytes.HasPrefix([]byte(key), prefix) {
+			keys = append(keys, []byte(key))
+		}
+	}
+
+	fromValues, err := sm.readStorageValues(from, keys)
+	if err != nil {
+		return nil, false, err
+	}
+	toValues, err := sm.readStorageValues(to, keys)
+	if err != nil {
+		return nil, false, err
+	}
+
+	diff = make(map[string][]byte)
+	for i, key := range keys {
+		// a key modified by the blocks in between can be set back to its value at the block from.
+		if !bytes.Equal(fromValues[i], toValues[i]) || (fromValues[i] == nil) != (toValues[i] == nil) {
+			diff[string(key)] = toValues[i]
+		}
+	}
+	return diff, true, nil
+}
+
+// changedKeysSince returns the set of the storage keys modified by the blocks after the block
+// from up to the block to, walking back from the block to, and false if the block from is not
+// an ancestor of the block to or if the storage changes of a block walked are not indexed.
+func (sm *StateModule) changedKeysSince(from, to common.Hash) (
+	keys map[string]struct{}, indexed bool, err error) {
+	fromHeader, err := sm.blockAPI.GetHeader(from)
+	if err != nil {
+		return nil, false, fmt.Errorf("getting header of block %s: %w", from, err)
+	}
+
+	keys = make(map[string]struct{})
+	for hash := to; hash != from; {
+		header, err := sm.blockAPI.GetHeader(hash)
+		if err != nil {
+			return nil, false, fmt.Errorf("getting header of block %s: %w", hash, err)
+		}
+		if header.Number <= fromHeader.Number {
+			return nil, false, nil
+		}
+
+		changedKeys, indexed, err := sm.blockAPI.GetChangedKeys(hash)
+		if err != nil {
+			return nil, false, fmt.Errorf("getting changed keys: %w", err)
+		} else if !indexed {
+			return nil, false, nil
+		}
+		for _, key := range changedKeys {
+			keys[string(key)] = struct{}{}
+		}
+		hash = header.ParentHash
+	}
+	return keys, true, nil
+}
+
+// trieStorageDiff returns the storage entries changed between the block from and the
+// block to by comparing their state tries.
+func (sm *StateModule) trieStorageDiff(from, to common.Hash) (diff map[string][]byte, err error) {
+	fromRoot, err := sm.storageAPI.GetStateRootFromBlock(&from)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root of block %s: %w", from, err)
+	}
+
+	toRoot, err := sm.storageAPI.GetStateRootFromBlock(&to)
+	if err != nil {
+		return nil, fmt.Errorf("getting state root of block %s: %w", to, err)
+	}
+
+	diff, err = sm.storageAPI.StorageDiff(*fromRoot, *toRoot)
+	if err != nil {
+		return nil, fmt.Errorf("comparing storage: %w", err)
+	}
+	return diff, nil
+}
+
+// getStorageValues returns the values of the keys given in the state of the block given,
+// which is pinned while the values are read.
+func (sm *StateModule) getStorageValues(blockHash common.Hash, keys [][]byte) (values [][]byte, err error) {
+	unpin, err := sm.pinBlock(&blockHash)
+	if err != nil {
+		return nil, err
+	}
+	defer unpin()
+
+	return sm.readStorageValues(blockHash, keys)
+}
+
+// readStorageValues returns the values of the keys given in the state of the block given.
+func (sm *StateModule) readStorageValues(blockHash common.Hash, keys [][]byte) (values [][]byte, err error) {
+	values = make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i], err = sm.storageAPI.GetStorageByBlockHash(&blockHash, key)
+		if err != nil {
+			return nil, fmt.Errorf("getting value by block hash: %w", err)
+		}
+	}
+	return values, nil
+}
+
 func stringPtr(s string) *string { return &s }
 
+// modifiesAny returns true if any of the keys given is in the sorted changed keys given.
+func modifiesAny(changedKeys, keys [][]byte) bool {
+	for _, key := range keys {
+		_, found := slices.BinarySearchFunc(changedKeys, key, bytes.Compare)
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
 // SubscribeRuntimeVersion initialised a runtime version subscription and returns the current version
 // See dot/rpc/subscription
 func (sm *StateModule) SubscribeRuntimeVersion(
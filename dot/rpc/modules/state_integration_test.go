@@ -377,6 +377,11 @@ func TestStateModule_QueryStorage(t *testing.T) {
 		}, nil)
 		mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{1, 2}, nil)
 		mockBlockAPI.EXPECT().GetHashByNumber(uint(4)).Return(common.Hash{3, 4}, nil)
+		mockBlockAPI.EXPECT().PinBlock(common.Hash{1, 2}).Return(nil)
+		mockBlockAPI.EXPECT().UnpinBlock(common.Hash{1, 2}).Return(true)
+		mockBlockAPI.EXPECT().PinBlock(common.Hash{3, 4}).Return(nil)
+		mockBlockAPI.EXPECT().UnpinBlock(common.Hash{3, 4}).Return(true)
+		mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{3, 4}).Return(nil, false, nil)
 
 		mockStorageAPI := NewMockStorageAPI(ctrl)
 		mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{1, 2}, []byte{144}).Return([]byte(`value`), nil)
@@ -26,6 +26,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -41,6 +42,10 @@ func TestStateModuleGetPairs(t *testing.T) {
 
 	str := "0x01"
 	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().PinBlock(hash).Return(nil).Times(7)
+	mockBlockAPI.EXPECT().UnpinBlock(hash).Return(true).Times(7)
 	m := make(map[string][]byte)
 	m["a"] = []byte{21, 22}
 	m["b"] = []byte{23, 24}
@@ -179,6 +184,7 @@ type args struct {
 				networkAPI: tt.fields.networkAPI,
 				storageAPI: tt.fields.storageAPI,
 				coreAPI:    tt.fields.coreAPI,
+				blockAPI:   mockBlockAPI,
 			}
 			res := StatePairResponse{}
 			err := sm.GetPairs(tt.args.in0, tt.args.req, &res)
@@ -196,16 +202,16 @@ func TestStateModuleGetKeysPaged(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
-	mockStorageAPI.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
-		Return([][]byte{{1}, {2}}, nil)
+	mockStorageAPI.EXPECT().GetKeysWithPrefixPaged((*common.Hash)(nil), common.MustHexToBytes("0x"),
+		[]byte{1}, uint(0)).Return(nil, nil)
 
 	mockStorageAPI2 := mocks.NewMockStorageAPI(ctrl)
-	mockStorageAPI2.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
-		Return([][]byte{{1, 1, 1}, {1, 1, 1}}, nil)
+	mockStorageAPI2.EXPECT().GetKeysWithPrefixPaged((*common.Hash)(nil), common.MustHexToBytes("0x"),
+		[]byte{1}, uint(1)).Return([][]byte{{1, 1, 1}}, nil)
 
 	mockStorageAPIErr := mocks.NewMockStorageAPI(ctrl)
-	mockStorageAPIErr.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
-		Return(nil, errors.New("GetKeysWithPrefix Err"))
+	mockStorageAPIErr.EXPECT().GetKeysWithPrefixPaged((*common.Hash)(nil), common.MustHexToBytes("0x"),
+		[]byte{1}, uint(0)).Return(nil, errors.New("GetKeysWithPrefix Err"))
 
 	type fields struct {
 		networkAPI NetworkAPI
@@ -244,6 +250,16 @@ type args struct {
 			},
 			exp: StateStorageKeysResponse{"0x010101"},
 		},
+		{
+			name:   "Request AfterKey Error",
+			fields: fields{nil, mockStorageAPI, nil},
+			args: args{
+				req: &StateStorageKeyRequest{
+					AfterKey: "01",
+				},
+			},
+			expErr: errors.New("cannot convert after key: could not byteify non 0x prefixed string: 01"),
+		},
 		{
 			name:   "GetKeysWithPrefix Error",
 			fields: fields{nil, mockStorageAPIErr, nil},
@@ -297,7 +313,9 @@ func TestCall(t *testing.T) {
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
 	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
 	mockBlockAPI.EXPECT().BestBlockHash().Return(testHash)
-	mockBlockAPI.EXPECT().GetRuntime(testHash).Return(rt, nil)
+	mockBlockAPI.EXPECT().PinBlock(testHash).Return(nil)
+	mockBlockAPI.EXPECT().AcquireRuntime(testHash).Return(rt, func() {}, nil)
+	mockBlockAPI.EXPECT().UnpinBlock(testHash).Return(true)
 
 	sm := NewStateModule(mockNetworkAPI, mockStorageAPI, nil, mockBlockAPI)
 
@@ -342,7 +360,9 @@ func TestStateTrie(t *testing.T) {
 				fakeBlockHeader := types.NewHeader(common.EmptyHash, fakeStateRoot,
 					common.EmptyHash, 1, nil)
 
+				blockAPIMock.EXPECT().PinBlock(bestBlockHash).Return(nil)
 				blockAPIMock.EXPECT().GetHeader(bestBlockHash).Return(fakeBlockHeader, nil)
+				blockAPIMock.EXPECT().UnpinBlock(bestBlockHash).Return(true)
 
 				fakeEntries := map[string][]byte{
 					"entry-1": {0, 1, 2, 3},
@@ -367,8 +387,10 @@ func TestStateTrie(t *testing.T) {
 				fakeBlockHeader := types.NewHeader(common.EmptyHash, fakeStateRoot,
 					common.EmptyHash, 1, nil)
 
+				blockAPIMock.EXPECT().PinBlock(expecificBlockHash).Return(nil)
 				blockAPIMock.EXPECT().GetHeader(expecificBlockHash).
 					Return(fakeBlockHeader, nil)
+				blockAPIMock.EXPECT().UnpinBlock(expecificBlockHash).Return(true)
 
 				fakeEntries := map[string][]byte{
 					"entry-1": {0, 1, 2, 3},
@@ -473,6 +495,10 @@ func TestStateModuleGetReadProof(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().PinBlock(hash).Return(nil).Times(3)
+	mockBlockAPI.EXPECT().UnpinBlock(hash).Return(true).Times(3)
 	keys := []string{"0x1111", "0x2222"}
 	expKeys := make([][]byte, len(keys))
 	for i, hexKey := range keys {
@@ -550,6 +576,7 @@ type args struct {
 				networkAPI: tt.fields.networkAPI,
 				storageAPI: tt.fields.storageAPI,
 				coreAPI:    tt.fields.coreAPI,
+				blockAPI:   mockBlockAPI,
 			}
 			res := StateGetReadProofResponse{}
 			err := sm.GetReadProof(tt.args.in0, tt.args.req, &res)
@@ -646,10 +673,66 @@ type args struct {
 	}
 }
 
+func TestStateModuleGetRuntimeUpgrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+	upgrades := []state.RuntimeUpgrade{{
+		BlockHash:   hash,
+		BlockNumber: 10,
+		SpecVersion: 25,
+	}}
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().GetRuntimeUpgrades().Return(upgrades, nil)
+
+	mockBlockAPIErr := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPIErr.EXPECT().GetRuntimeUpgrades().
+		Return(nil, errors.New("GetRuntimeUpgrades Error"))
+
+	tests := map[string]struct {
+		blockAPI BlockAPI
+		expErr   error
+		exp      []StateRuntimeUpgradeResponse
+	}{
+		"OK Case": {
+			blockAPI: mockBlockAPI,
+			exp: []StateRuntimeUpgradeResponse{{
+				BlockHash:   hash,
+				BlockNumber: 10,
+				SpecVersion: 25,
+			}},
+		},
+		"GetRuntimeUpgrades Error": {
+			blockAPI: mockBlockAPIErr,
+			expErr:   errors.New("GetRuntimeUpgrades Error"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			sm := &StateModule{
+				blockAPI: tt.blockAPI,
+			}
+			var res []StateRuntimeUpgradeResponse
+			err := sm.GetRuntimeUpgrades(nil, nil, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
 func TestStateModuleGetStorage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().PinBlock(hash).Return(nil).Times(2)
+	mockBlockAPI.EXPECT().UnpinBlock(hash).Return(true).Times(2)
 	reqBytes := common.MustHexToBytes("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
 
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
@@ -727,6 +810,7 @@ type args struct {
 				networkAPI: tt.fields.networkAPI,
 				storageAPI: tt.fields.storageAPI,
 				coreAPI:    tt.fields.coreAPI,
+				blockAPI:   mockBlockAPI,
 			}
 			res := StateStorageResponse("")
 			err := sm.GetStorage(tt.args.in0, tt.args.req, &res)
@@ -744,6 +828,10 @@ func TestStateModuleGetStorageHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().PinBlock(hash).Return(nil).Times(2)
+	mockBlockAPI.EXPECT().UnpinBlock(hash).Return(true).Times(2)
 	reqBytes := common.MustHexToBytes("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
 
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
@@ -822,6 +910,7 @@ type args struct {
 				networkAPI: tt.fields.networkAPI,
 				storageAPI: tt.fields.storageAPI,
 				coreAPI:    tt.fields.coreAPI,
+				blockAPI:   mockBlockAPI,
 			}
 			res := StateStorageHashResponse("")
 			err := sm.GetStorageHash(tt.args.in0, tt.args.req, &res)
@@ -839,6 +928,10 @@ func TestStateModuleGetStorageSize(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+
+	mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+	mockBlockAPI.EXPECT().PinBlock(hash).Return(nil).Times(2)
+	mockBlockAPI.EXPECT().UnpinBlock(hash).Return(true).Times(2)
 	reqBytes := common.MustHexToBytes("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
 
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
@@ -916,6 +1009,7 @@ type args struct {
 				networkAPI: tt.fields.networkAPI,
 				storageAPI: tt.fields.storageAPI,
 				coreAPI:    tt.fields.coreAPI,
+				blockAPI:   mockBlockAPI,
 			}
 			res := StateStorageSizeResponse(0)
 			err := sm.GetStorageSize(tt.args.in0, tt.args.req, &res)
@@ -1009,7 +1103,15 @@ type args struct {
 						Return(&types.Block{Header: types.Header{Number: 3}}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{3}).Return(nil, false, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{4}).Return(nil, false, nil)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{3}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{3}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{4}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{4}).Return(true)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -1063,8 +1165,19 @@ type args struct {
 						Return(&types.Block{Header: types.Header{Number: 3}}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(0)).Return(common.Hash{1}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{2}).Return(nil, false, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{3}).Return(nil, false, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{4}).Return(nil, false, nil)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{1}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{1}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{3}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{3}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{4}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{4}).Return(true)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -1118,6 +1231,11 @@ type args struct {
 						Return(&types.Block{Header: types.Header{Number: 2}}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{3}).Return(nil, false, nil)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{3}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{3}).Return(true)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -1140,6 +1258,92 @@ type args struct {
 				},
 			},
 		},
+		"start_block/no_end_block/indexed_changes": {
+			fields: fields{
+				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
+						Return([]byte{1, 1, 1}, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{4}, []byte{1, 2, 4}).
+						Return([]byte{3, 3, 3}, nil)
+					return mockStorageAPI
+				},
+				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{2}).
+						Return(&types.Block{Header: types.Header{Number: 1}}, nil)
+					mockBlockAPI.EXPECT().BestBlockHash().Return(common.Hash{4})
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{4}).
+						Return(&types.Block{Header: types.Header{Number: 3}}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{3}).
+						Return([][]byte{{1, 2, 3}, {1, 2, 5}}, true, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{4}).
+						Return([][]byte{{1, 2, 3}, {1, 2, 4}}, true, nil)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{4}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{4}).Return(true)
+					return mockBlockAPI
+				}},
+			args: args{
+				req: &StateStorageQueryRangeRequest{
+					Keys:       []string{"0x010204"},
+					StartBlock: common.Hash{2},
+				},
+			},
+			exp: []StorageChangeSetResponse{
+				{
+					Block: &common.Hash{2},
+					Changes: [][2]*string{
+						makeChange("0x010204", "0x010101"),
+					},
+				},
+				{
+					Block:   &common.Hash{3},
+					Changes: [][2]*string{},
+				},
+				{
+					Block: &common.Hash{4},
+					Changes: [][2]*string{
+						makeChange("0x010204", "0x030303"),
+					},
+				},
+			},
+		},
+		"start_block/end_block/error_get_changed_keys": {
+			fields: fields{
+				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
+						Return([]byte{1, 1, 1}, nil)
+					return mockStorageAPI
+				},
+				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{2}).
+						Return(&types.Block{Header: types.Header{Number: 1}}, nil)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{3}).
+						Return(&types.Block{Header: types.Header{Number: 2}}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().GetChangedKeys(common.Hash{3}).Return(nil, false, errTest)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
+					return mockBlockAPI
+				}},
+			args: args{
+				req: &StateStorageQueryRangeRequest{
+					Keys:       []string{"0x010204"},
+					StartBlock: common.Hash{2},
+					EndBlock:   common.Hash{3},
+				},
+			},
+			exp:       []StorageChangeSetResponse{},
+			errRegexp: "getting changed keys: test error",
+		},
 		"start_block/end_block/error_end_hash": {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
@@ -1205,6 +1409,8 @@ func(ctrl *gomock.Controller) BlockAPI {
 						Header: types.Header{Number: 2},
 					}, nil)
 					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
 					return mockBlockAPI
 				}},
 			args: args{
@@ -1264,6 +1470,8 @@ type fields struct {
 				blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
 					mockBlockAPI := NewMockBlockAPI(ctrl)
 					mockBlockAPI.EXPECT().BestBlockHash().Return(common.Hash{2})
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
 					return mockBlockAPI
 				}},
 			request: &StateStorageQueryAtRequest{
@@ -1286,7 +1494,10 @@ type fields struct {
 					return mockStorageAPI
 				},
 				blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
-					return NewMockBlockAPI(ctrl)
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{1}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{1}).Return(true)
+					return mockBlockAPI
 				}},
 			request: &StateStorageQueryAtRequest{
 				Keys: []string{"0x010203"},
@@ -1308,7 +1519,10 @@ type fields struct {
 					return mockStorageAPI
 				},
 				blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
-					return NewMockBlockAPI(ctrl)
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
+					return mockBlockAPI
 				}},
 			request: &StateStorageQueryAtRequest{
 				Keys: []string{"0x080808", "0x010204", "0x090909"},
@@ -1338,6 +1552,8 @@ type fields struct {
 				blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
 					mockBlockAPI := NewMockBlockAPI(ctrl)
 					mockBlockAPI.EXPECT().BestBlockHash().Return(common.Hash{2})
+					mockBlockAPI.EXPECT().PinBlock(common.Hash{2}).Return(nil)
+					mockBlockAPI.EXPECT().UnpinBlock(common.Hash{2}).Return(true)
 					return mockBlockAPI
 				}},
 			request: &StateStorageQueryAtRequest{
@@ -1374,3 +1590,214 @@ type fields struct {
 		})
 	}
 }
+
+func TestStateModule_pinBlock(t *testing.T) {
+	t.Parallel()
+	errTest := errors.New("test error")
+	hash := common.Hash{1}
+
+	tests := map[string]struct {
+		hash            *common.Hash
+		blockAPIBuilder func(ctrl *gomock.Controller) *MockBlockAPI
+		errWrapped      error
+		errMessage      string
+	}{
+		"nil_hash": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
+				return NewMockBlockAPI(ctrl)
+			},
+		},
+		"pin_error": {
+			hash: &hash,
+			blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				mockBlockAPI.EXPECT().PinBlock(hash).Return(errTest)
+				return mockBlockAPI
+			},
+			errWrapped: errTest,
+			errMessage: "pinning block: test error",
+		},
+		"pinned": {
+			hash: &hash,
+			blockAPIBuilder: func(ctrl *gomock.Controller) *MockBlockAPI {
+				mockBlockAPI := NewMockBlockAPI(ctrl)
+				mockBlockAPI.EXPECT().PinBlock(hash).Return(nil)
+				mockBlockAPI.EXPECT().UnpinBlock(hash).Return(true)
+				return mockBlockAPI
+			},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			sm := &StateModule{
+				blockAPI: tt.blockAPIBuilder(ctrl),
+			}
+
+			unpin, err := sm.pinBlock(tt.hash)
+			assert.ErrorIs(t, err, tt.errWrapped)
+			if tt.errWrapped != nil {
+				assert.EqualError(t, err, tt.errMessage)
+				return
+			}
+			unpin()
+		})
+	}
+}
+
+func TestStateModule_GetStorageDiff(t *testing.T) {
+	t.Parallel()
+	errTest := errors.New("test error")
+	from, to := common.Hash{1}, common.Hash{2}
+	fromRoot, toRoot := common.Hash{3}, common.Hash{4}
+
+	testCases := map[string]struct {
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		blockAPIBuilder   func(ctrl *gomock.Controller) BlockAPI
+		request           *StateStorageDiffRequest
+		expectedResponse  StorageDiffResponse
+		errWrapped        error
+		errMessage        string
+	}{
+		"missing_from_block": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI { return NewMockStorageAPI(ctrl) },
+			blockAPIBuilder:   func(ctrl *gomock.Controller) BlockAPI { return NewMockBlockAPI(ctrl) },
+			request:           &StateStorageDiffRequest{To: to},
+			errWrapped:        ErrStartBlockHashEmpty,
+			errMessage:        "the start block hash cannot be an empty value",
+		},
+		"diff_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&from).Return(&fromRoot, nil)
+				storageAPI.EXPECT().GetStateRootFromBlock(&to).Return(&toRoot, nil)
+				storageAPI.EXPECT().StorageDiff(fromRoot, toRoot).Return(nil, errTest)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().PinBlock(from).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(from).Return(true)
+				blockAPI.EXPECT().PinBlock(to).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(to).Return(true)
+				blockAPI.EXPECT().GetHeader(from).Return(&types.Header{Number: 1}, nil)
+				blockAPI.EXPECT().GetHeader(to).Return(&types.Header{Number: 2, ParentHash: from}, nil)
+				blockAPI.EXPECT().GetChangedKeys(to).Return(nil, false, nil)
+				return blockAPI
+			},
+			request:    &StateStorageDiffRequest{From: from, To: to},
+			errWrapped: errTest,
+			errMessage: "comparing storage: test error",
+		},
+		"changed_keys_error": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI { return NewMockStorageAPI(ctrl) },
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().PinBlock(from).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(from).Return(true)
+				blockAPI.EXPECT().PinBlock(to).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(to).Return(true)
+				blockAPI.EXPECT().GetHeader(from).Return(&types.Header{Number: 1}, nil)
+				blockAPI.EXPECT().GetHeader(to).Return(&types.Header{Number: 2, ParentHash: from}, nil)
+				blockAPI.EXPECT().GetChangedKeys(to).Return(nil, false, errTest)
+				return blockAPI
+			},
+			request:    &StateStorageDiffRequest{From: from, To: to},
+			errWrapped: errTest,
+			errMessage: "getting changed keys: test error",
+		},
+		"indexed_changes": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStorageByBlockHash(&from, []byte{1, 1}).Return([]byte{1}, nil)
+				storageAPI.EXPECT().GetStorageByBlockHash(&to, []byte{1, 1}).Return([]byte{9}, nil)
+				storageAPI.EXPECT().GetStorageByBlockHash(&from, []byte{1, 2}).Return([]byte{1}, nil)
+				storageAPI.EXPECT().GetStorageByBlockHash(&to, []byte{1, 2}).Return(nil, nil)
+				storageAPI.EXPECT().GetStorageByBlockHash(&from, []byte{1, 3}).Return([]byte{3}, nil)
+				storageAPI.EXPECT().GetStorageByBlockHash(&to, []byte{1, 3}).Return([]byte{3}, nil)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().PinBlock(from).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(from).Return(true)
+				blockAPI.EXPECT().PinBlock(to).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(to).Return(true)
+				middle := common.Hash{5}
+				blockAPI.EXPECT().GetHeader(from).Return(&types.Header{Number: 1}, nil)
+				blockAPI.EXPECT().GetHeader(to).Return(&types.Header{Number: 3, ParentHash: middle}, nil)
+				blockAPI.EXPECT().GetChangedKeys(to).Return([][]byte{{1, 1}, {1, 3}}, true, nil)
+				blockAPI.EXPECT().GetHeader(middle).Return(&types.Header{Number: 2, ParentHash: from}, nil)
+				blockAPI.EXPECT().GetChangedKeys(middle).Return([][]byte{{1, 2}, {1, 3}, {2, 1}}, true, nil)
+				return blockAPI
+			},
+			request: &StateStorageDiffRequest{From: from, To: to, Prefix: stringPtr("0x01")},
+			expectedResponse: StorageDiffResponse{
+				From: from,
+				To:   to,
+				Changes: [][2]*string{
+					{stringPtr("0x0101"), stringPtr("0x09")},
+					{stringPtr("0x0102"), nil},
+				},
+			},
+		},
+		"best_block_with_prefix": {
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				storageAPI := NewMockStorageAPI(ctrl)
+				storageAPI.EXPECT().GetStateRootFromBlock(&from).Return(&fromRoot, nil)
+				storageAPI.EXPECT().GetStateRootFromBlock(&to).Return(&toRoot, nil)
+				storageAPI.EXPECT().StorageDiff(fromRoot, toRoot).Return(map[string][]byte{
+					"\x01\x03": {},
+					"\x01\x02": nil,
+					"\x01\x01": {9},
+					"\x02\x01": {9},
+				}, nil)
+				return storageAPI
+			},
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				blockAPI := NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(to)
+				blockAPI.EXPECT().PinBlock(from).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(from).Return(true)
+				blockAPI.EXPECT().PinBlock(to).Return(nil)
+				blockAPI.EXPECT().UnpinBlock(to).Return(true)
+				// the block from is not an ancestor of the block to.
+				blockAPI.EXPECT().GetHeader(from).Return(&types.Header{Number: 1}, nil)
+				blockAPI.EXPECT().GetHeader(to).Return(&types.Header{Number: 1}, nil)
+				return blockAPI
+			},
+			request: &StateStorageDiffRequest{From: from, Prefix: stringPtr("0x01")},
+			expectedResponse: StorageDiffResponse{
+				From: from,
+				To:   to,
+				Changes: [][2]*string{
+					{stringPtr("0x0101"), stringPtr("0x09")},
+					{stringPtr("0x0102"), nil},
+					{stringPtr("0x0103"), stringPtr("0x")},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			sm := &StateModule{
+				storageAPI: testCase.storageAPIBuilder(ctrl),
+				blockAPI:   testCase.blockAPIBuilder(ctrl),
+			}
+			var response StorageDiffResponse
+			err := sm.GetStorageDiff(nil, testCase.request, &response)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expectedResponse, response)
+		})
+	}
+}
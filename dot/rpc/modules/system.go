@@ -6,6 +6,7 @@
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
@@ -27,6 +28,8 @@ type SystemModule struct {
 	txStateAPI TransactionStateAPI
 	blockAPI   BlockAPI
 	syncAPI    SyncAPI
+	// supervisorAPI is optional, the health of the node services is not returned if it is nil.
+	supervisorAPI SupervisorAPI
 }
 
 // EmptyRequest represents an RPC request with no fields
@@ -70,15 +73,16 @@ type SyncStateResponse struct {
 // NewSystemModule creates a new API instance
 func NewSystemModule(net NetworkAPI, sys SystemAPI, core CoreAPI,
 	storage StorageAPI, txAPI TransactionStateAPI, blockAPI BlockAPI,
-	syncAPI SyncAPI) *SystemModule {
+	syncAPI SyncAPI, supervisorAPI SupervisorAPI) *SystemModule {
 	return &SystemModule{
-		networkAPI: net,
-		systemAPI:  sys,
-		coreAPI:    core,
-		storageAPI: storage,
-		txStateAPI: txAPI,
-		blockAPI:   blockAPI,
-		syncAPI:    syncAPI,
+		networkAPI:    net,
+		systemAPI:     sys,
+		coreAPI:       core,
+		storageAPI:    storage,
+		txStateAPI:    txAPI,
+		blockAPI:      blockAPI,
+		syncAPI:       syncAPI,
+		supervisorAPI: supervisorAPI,
 	}
 }
 
@@ -112,9 +116,13 @@ func (sm *SystemModule) Version(r *http.Request, req *EmptyRequest, res *string)
 	return nil
 }
 
-// Health returns the information about the health of the network
+// Health returns the information about the health of the network,
+// extended with the health of the node services run by the supervisor.
 func (sm *SystemModule) Health(r *http.Request, req *EmptyRequest, res *SystemHealthResponse) error {
 	health := sm.networkAPI.Health()
+	if sm.supervisorAPI != nil {
+		health.Services = sm.supervisorAPI.Health()
+	}
 	*res = SystemHealthResponse(health)
 	return nil
 }
@@ -136,13 +144,13 @@ func (sm *SystemModule) Peers(r *http.Request, req *EmptyRequest, res *SystemPee
 	return nil
 }
 
-// NodeRoles Returns the roles the node is running as.
+// NodeRoles Returns the roles the node is configured to run as.
 func (sm *SystemModule) NodeRoles(r *http.Request, req *EmptyRequest, res *[]interface{}) error {
 	resultArray := []interface{}{}
 
-	role := sm.networkAPI.NodeRoles()
+	role := sm.systemAPI.NodeRole()
 	switch role {
-	case common.FullNodeRole:
+	case common.FullNodeRole, common.RPCNodeRole:
 		resultArray = append(resultArray, "Full")
 	case common.LightClientRole:
 		resultArray = append(resultArray, "LightClient")
@@ -194,6 +202,10 @@ func (sm *SystemModule) AccountNextIndex(r *http.Request, req *StringRequest, re
 
 	// no extrinsic signed by request found in pending transactions, so look in storage
 	// get metadata to build storage storageKey
+	if sm.coreAPI == nil {
+		// light clients neither execute the runtime nor hold the storage
+		return errRuntimeCallsNotServed
+	}
 	rawMeta, err := sm.coreAPI.GetMetadata(nil)
 	if err != nil {
 		return err
@@ -245,15 +257,18 @@ func (sm *SystemModule) SyncState(r *http.Request, req *EmptyRequest, res *SyncS
 
 // LocalListenAddresses Returns the libp2p multiaddresses that the local node is listening on
 func (sm *SystemModule) LocalListenAddresses(r *http.Request, req *EmptyRequest, res *[]string) error {
-	netstate := sm.networkAPI.NetworkState()
+	multiaddrs, err := sm.networkAPI.ListenAddresses()
+	if err != nil {
+		return fmt.Errorf("getting listen addresses: %w", err)
+	}
 
-	if len(netstate.Multiaddrs) < 1 {
+	if len(multiaddrs) < 1 {
 		return errors.New("multiaddress list is empty")
 	}
 
-	addrs := make([]string, len(netstate.Multiaddrs))
+	addrs := make([]string, len(multiaddrs))
 
-	for i, ma := range netstate.Multiaddrs {
+	for i, ma := range multiaddrs {
 		addrs[i] = ma.String()
 	}
 
@@ -289,3 +304,14 @@ func (sm *SystemModule) RemoveReservedPeer(r *http.Request, req *StringRequest,
 
 	return sm.networkAPI.RemoveReservedPeers(req.String)
 }
+
+// ReservedPeers Returns the base58-encoded PeerIds of the reserved peers of the node.
+func (sm *SystemModule) ReservedPeers(r *http.Request, req *EmptyRequest, res *[]string) error {
+	peers := sm.networkAPI.ReservedPeers()
+	if peers == nil {
+		peers = []string{}
+	}
+
+	*res = peers
+	return nil
+}
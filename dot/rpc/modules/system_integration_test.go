@@ -96,7 +96,7 @@ func TestSystemModule_Health(t *testing.T) {
 	networkMock := mocks.NewMockNetworkAPI(ctrl)
 	networkMock.EXPECT().Health().Return(testHealth)
 
-	sys := NewSystemModule(networkMock, nil, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(networkMock, nil, nil, nil, nil, nil, nil, nil)
 
 	res := &SystemHealthResponse{}
 	err := sys.Health(nil, nil, res)
@@ -107,7 +107,7 @@ func TestSystemModule_Health(t *testing.T) {
 // Test RPC's System.NetworkState() response
 func TestSystemModule_NetworkState(t *testing.T) {
 	net := newNetworkService(t)
-	sys := NewSystemModule(net, nil, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(net, nil, nil, nil, nil, nil, nil, nil)
 
 	res := &SystemNetworkStateResponse{}
 	err := sys.NetworkState(nil, nil, res)
@@ -124,7 +124,7 @@ func TestSystemModule_NetworkState(t *testing.T) {
 func TestSystemModule_Peers(t *testing.T) {
 	net := newNetworkService(t)
 	net.Stop()
-	sys := NewSystemModule(net, nil, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(net, nil, nil, nil, nil, nil, nil, nil)
 
 	res := &SystemPeersResponse{}
 	err := sys.Peers(nil, nil, res)
@@ -136,8 +136,11 @@ func TestSystemModule_Peers(t *testing.T) {
 }
 
 func TestSystemModule_NodeRoles(t *testing.T) {
-	net := newNetworkService(t)
-	sys := NewSystemModule(net, nil, nil, nil, nil, nil, nil)
+	ctrl := gomock.NewController(t)
+
+	api := mocks.NewMockSystemAPI(ctrl)
+	api.EXPECT().NodeRole().Return(common.FullNodeRole)
+	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil, nil)
 	expected := []interface{}{"Full"}
 
 	var res []interface{}
@@ -162,7 +165,7 @@ func TestSystemModule_Chain(t *testing.T) {
 
 	api := mocks.NewMockSystemAPI(ctrl)
 	api.EXPECT().ChainName().Return(testGenesisData.Name)
-	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil, nil)
 
 	res := new(string)
 	err := sys.Chain(nil, nil, res)
@@ -176,7 +179,7 @@ func TestSystemModule_ChainType(t *testing.T) {
 	api := mocks.NewMockSystemAPI(ctrl)
 	api.EXPECT().ChainType().Return(testGenesisData.ChainType)
 
-	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil, nil)
 
 	res := new(string)
 	sys.ChainType(nil, nil, res)
@@ -187,7 +190,7 @@ func TestSystemModule_Name(t *testing.T) {
 
 	api := mocks.NewMockSystemAPI(ctrl)
 	api.EXPECT().SystemName().Return(testSystemInfo.SystemName)
-	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil, nil)
 
 	res := new(string)
 	err := sys.Name(nil, nil, res)
@@ -201,7 +204,7 @@ func TestSystemModule_Version(t *testing.T) {
 	api := mocks.NewMockSystemAPI(ctrl)
 	api.EXPECT().SystemVersion().Return(testSystemInfo.SystemVersion)
 
-	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil, nil)
 
 	res := new(string)
 	err := sys.Version(nil, nil, res)
@@ -215,7 +218,7 @@ func TestSystemModule_Properties(t *testing.T) {
 	api := mocks.NewMockSystemAPI(ctrl)
 	api.EXPECT().Properties().Return(nil)
 
-	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil)
+	sys := NewSystemModule(nil, api, nil, nil, nil, nil, nil, nil)
 
 	expected := map[string]interface{}(nil)
 
@@ -347,8 +350,8 @@ func setupSystemModule(t *testing.T) *SystemModule {
 		SendMessage(gomock.Any()).
 		AnyTimes()
 
-	txQueue := state.NewTransactionState(telemetryMock)
-	return NewSystemModule(net, nil, core, chain.Storage, txQueue, nil, nil)
+	txQueue := state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
+	return NewSystemModule(net, nil, core, chain.Storage, txQueue, nil, nil, nil)
 }
 
 func newCoreService(t *testing.T, srvc *state.Service) *core.Service {
@@ -443,13 +446,8 @@ func TestLocalListenAddresses(t *testing.T) {
 	ma, err := multiaddr.NewMultiaddr("/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWCYyh5xoAc5oRyiGU4d9ktcqFQ23JjitNFR6bEcbw7YdN")
 	require.NoError(t, err)
 
-	mockedNetState := common.NetworkState{
-		PeerID:     "fake-peer-id",
-		Multiaddrs: []multiaddr.Multiaddr{ma},
-	}
-
 	mockNetAPI := mocks.NewMockNetworkAPI(ctrl)
-	mockNetAPI.EXPECT().NetworkState().Return(mockedNetState)
+	mockNetAPI.EXPECT().ListenAddresses().Return([]multiaddr.Multiaddr{ma}, nil)
 
 	res := make([]string, 0)
 
@@ -462,7 +460,7 @@ func TestLocalListenAddresses(t *testing.T) {
 	require.Len(t, res, 1)
 	require.Equal(t, res[0], ma.String())
 
-	mockNetAPI.EXPECT().NetworkState().Return(common.NetworkState{Multiaddrs: []multiaddr.Multiaddr{}})
+	mockNetAPI.EXPECT().ListenAddresses().Return([]multiaddr.Multiaddr{}, nil)
 	err = sysmodule.LocalListenAddresses(nil, nil, &res)
 	require.Error(t, err, "multiaddress list is empty")
 }
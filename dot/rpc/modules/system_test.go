@@ -13,6 +13,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/multiformats/go-multiaddr"
 	"go.uber.org/mock/gomock"
 
@@ -121,6 +122,31 @@ func TestSystemModule_HealthTest(t *testing.T) {
 	require.Equal(t, SystemHealthResponse(common.Health{}), sysHealthRes)
 }
 
+func TestSystemModule_Health_services(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
+	mockNetworkAPI.EXPECT().Health().Return(common.Health{Peers: 1, ShouldHavePeers: true})
+	services := []common.ServiceHealth{
+		{Name: "babe", State: "running"},
+		{Name: "sync", State: "restarting", Failures: 1, LastError: "test error"},
+	}
+	mockSupervisorAPI := NewMockSupervisorAPI(ctrl)
+	mockSupervisorAPI.EXPECT().Health().Return(services)
+	sm := NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, mockSupervisorAPI)
+
+	var sysHealthRes SystemHealthResponse
+	err := sm.Health(nil, &EmptyRequest{}, &sysHealthRes)
+	require.NoError(t, err)
+
+	expected := SystemHealthResponse{
+		Peers:           1,
+		ShouldHavePeers: true,
+		Services:        services,
+	}
+	require.Equal(t, expected, sysHealthRes)
+}
+
 func TestSystemModule_NetworkStateTest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -156,17 +182,20 @@ func TestSystemModule_PeersTest(t *testing.T) {
 func TestSystemModule_NodeRolesTest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	mockNetworkAPI1 := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPI1.EXPECT().NodeRoles().Return(common.FullNodeRole)
+	mockSystemAPI1 := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI1.EXPECT().NodeRole().Return(common.FullNodeRole)
+
+	mockSystemAPI2 := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI2.EXPECT().NodeRole().Return(common.LightClientRole)
 
-	mockNetworkAPI2 := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPI2.EXPECT().NodeRoles().Return(common.LightClientRole)
+	mockSystemAPI3 := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI3.EXPECT().NodeRole().Return(common.AuthorityRole)
 
-	mockNetworkAPI3 := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPI3.EXPECT().NodeRoles().Return(common.AuthorityRole)
+	mockSystemAPI4 := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI4.EXPECT().NodeRole().Return(common.NetworkRole(21))
 
-	mockNetworkAPI4 := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPI4.EXPECT().NodeRoles().Return(common.NetworkRole(21))
+	mockSystemAPI5 := mocks.NewMockSystemAPI(ctrl)
+	mockSystemAPI5.EXPECT().NodeRole().Return(common.RPCNodeRole)
 
 	type args struct {
 		r   *http.Request
@@ -181,7 +210,7 @@ type args struct {
 	}{
 		{
 			name:      "Full",
-			sysModule: NewSystemModule(mockNetworkAPI1, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(nil, mockSystemAPI1, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -189,7 +218,7 @@ type args struct {
 		},
 		{
 			name:      "LightClient",
-			sysModule: NewSystemModule(mockNetworkAPI2, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(nil, mockSystemAPI2, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -197,7 +226,7 @@ type args struct {
 		},
 		{
 			name:      "Authority",
-			sysModule: NewSystemModule(mockNetworkAPI3, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(nil, mockSystemAPI3, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -205,12 +234,20 @@ type args struct {
 		},
 		{
 			name:      "UnknownRole",
-			sysModule: NewSystemModule(mockNetworkAPI4, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(nil, mockSystemAPI4, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
 			exp: []interface{}{"UnknownRole", []interface{}{common.NetworkRole(21)}},
 		},
+		{
+			name:      "RPC",
+			sysModule: NewSystemModule(nil, mockSystemAPI5, nil, nil, nil, nil, nil, nil),
+			args: args{
+				req: &EmptyRequest{},
+			},
+			exp: []interface{}{"Full"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -242,7 +279,7 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 	}
 
 	mockTxStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
-	mockTxStateAPI.EXPECT().Pending().Return(v).Times(5)
+	mockTxStateAPI.EXPECT().Pending().Return(v).Times(6)
 
 	mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPI.EXPECT().GetMetadata((*common.Hash)(nil)).
@@ -254,7 +291,8 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 
 	// Magic number mismatch
 	mockCoreAPIMagicNumMismatch := mocks.NewMockCoreAPI(ctrl)
-	mockCoreAPIMagicNumMismatch.EXPECT().GetMetadata((*common.Hash)(nil)).Return(storageKeyHex, nil)
+	mockCoreAPIMagicNumMismatch.EXPECT().GetMetadata((*common.Hash)(nil)).
+		Return(scale.MustMarshal(storageKeyHex), nil)
 
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
 	mockStorageAPI.EXPECT().GetStorage((*common.Hash)(nil), storageKeyHex).
@@ -277,13 +315,13 @@ type args struct {
 	}{
 		{
 			name:      "Nil Request",
-			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil, nil),
 			args:      args{},
 			expErr:    errors.New("account address must be valid"),
 		},
 		{
 			name:      "found_in_pending_transactions",
-			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil, nil),
 			args: args{
 				req: &StringRequest{String: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
 			},
@@ -291,7 +329,7 @@ type args struct {
 		},
 		{
 			name:      "not_found_in_pending_transactions",
-			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil),
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil, nil, nil),
 			args: args{
 				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
 			},
@@ -299,7 +337,7 @@ type args struct {
 		},
 		{
 			name:      "GetMetadata Err",
-			sysModule: NewSystemModule(nil, nil, mockCoreAPIErr, mockStorageAPI, mockTxStateAPI, nil, nil),
+			sysModule: NewSystemModule(nil, nil, mockCoreAPIErr, mockStorageAPI, mockTxStateAPI, nil, nil, nil),
 			args: args{
 				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
 			},
@@ -307,20 +345,28 @@ type args struct {
 		},
 		{
 			name:      "Magic Number Mismatch",
-			sysModule: NewSystemModule(nil, nil, mockCoreAPIMagicNumMismatch, mockStorageAPI, mockTxStateAPI, nil, nil),
+			sysModule: NewSystemModule(nil, nil, mockCoreAPIMagicNumMismatch, mockStorageAPI, mockTxStateAPI, nil, nil, nil),
 			args: args{
 				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
 			},
-			expErr: errors.New("magic number mismatch: expected 0x6174656d, found 0xe03056ea"),
+			expErr: errors.New("magic number mismatch: expected 0x6174656d, found 0x4e39aa26"),
 		},
 		{
 			name:      "GetStorage Err",
-			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPIErr, mockTxStateAPI, nil, nil),
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPIErr, mockTxStateAPI, nil, nil, nil),
 			args: args{
 				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
 			},
 			expErr: errors.New("getStorage error"),
 		},
+		{
+			name:      "light_client_without_core",
+			sysModule: NewSystemModule(nil, nil, nil, mockStorageAPI, mockTxStateAPI, nil, nil, nil),
+			args: args{
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+			},
+			expErr: errRuntimeCallsNotServed,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -369,7 +415,7 @@ type args struct {
 	}{
 		{
 			name:      "OK",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, mockBlockAPI, mockSyncAPI),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, mockBlockAPI, mockSyncAPI, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -381,7 +427,7 @@ type args struct {
 		},
 		{
 			name:      "Err",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, mockBlockAPIErr, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, mockBlockAPIErr, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -407,19 +453,18 @@ func TestSystemModule_LocalListenAddresses(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockNetworkAPIEmpty := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPIEmpty.EXPECT().NetworkState().Return(common.NetworkState{})
+	mockNetworkAPIEmpty.EXPECT().ListenAddresses().Return(nil, nil)
+
+	mockNetworkAPIErr := mocks.NewMockNetworkAPI(ctrl)
+	mockNetworkAPIErr.EXPECT().ListenAddresses().Return(nil, errors.New("test error"))
 
 	addr, err := multiaddr.NewMultiaddr("/ip4/1.2.3.4/tcp/80")
 	require.NoError(t, err)
 	multiAddy := make([]multiaddr.Multiaddr, 1)
 	multiAddy[0] = addr
-	ns := common.NetworkState{
-		PeerID:     "jimbo",
-		Multiaddrs: multiAddy,
-	}
 
 	mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPI.EXPECT().NetworkState().Return(ns)
+	mockNetworkAPI.EXPECT().ListenAddresses().Return(multiAddy, nil)
 
 	type args struct {
 		r   *http.Request
@@ -434,7 +479,7 @@ type args struct {
 	}{
 		{
 			name:      "OK",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -442,12 +487,20 @@ type args struct {
 		},
 		{
 			name:      "Empty multiaddress list",
-			sysModule: NewSystemModule(mockNetworkAPIEmpty, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPIEmpty, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
 			expErr: errors.New("multiaddress list is empty"),
 		},
+		{
+			name:      "Listen addresses error",
+			sysModule: NewSystemModule(mockNetworkAPIErr, nil, nil, nil, nil, nil, nil, nil),
+			args: args{
+				req: &EmptyRequest{},
+			},
+			expErr: errors.New("getting listen addresses: test error"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -495,7 +548,7 @@ type args struct {
 	}{
 		{
 			name:      "OK",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -503,7 +556,7 @@ type args struct {
 		},
 		{
 			name:      "Empty peerId",
-			sysModule: NewSystemModule(mockNetworkAPIEmpty, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPIEmpty, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &EmptyRequest{},
 			},
@@ -547,7 +600,7 @@ type args struct {
 	}{
 		{
 			name:      "OK",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &StringRequest{"jimbo"},
 			},
@@ -555,7 +608,7 @@ type args struct {
 		},
 		{
 			name:      "AddReservedPeer Error",
-			sysModule: NewSystemModule(mockNetworkAPIErr, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPIErr, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &StringRequest{"jimbo"},
 			},
@@ -563,7 +616,7 @@ type args struct {
 		},
 		{
 			name:      "Empty StringRequest Error",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &StringRequest{""},
 			},
@@ -607,7 +660,7 @@ type args struct {
 	}{
 		{
 			name:      "OK",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &StringRequest{"jimbo"},
 			},
@@ -615,7 +668,7 @@ type args struct {
 		},
 		{
 			name:      "RemoveReservedPeer Error",
-			sysModule: NewSystemModule(mockNetworkAPIErr, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPIErr, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &StringRequest{"jimbo"},
 			},
@@ -623,7 +676,7 @@ type args struct {
 		},
 		{
 			name:      "Empty StringRequest Error",
-			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil),
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
 			args: args{
 				req: &StringRequest{""},
 			},
@@ -644,3 +697,38 @@ type args struct {
 		})
 	}
 }
+
+func TestSystemModule_ReservedPeers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
+	mockNetworkAPI.EXPECT().ReservedPeers().Return([]string{"12D3KooWDpJ7As7BWAwRMfu1VU2WCqNjvq387JEYKDBj4kx6nXTN"})
+
+	mockNetworkAPIEmpty := mocks.NewMockNetworkAPI(ctrl)
+	mockNetworkAPIEmpty.EXPECT().ReservedPeers().Return(nil)
+
+	tests := []struct {
+		name      string
+		sysModule *SystemModule
+		exp       []string
+	}{
+		{
+			name:      "OK",
+			sysModule: NewSystemModule(mockNetworkAPI, nil, nil, nil, nil, nil, nil, nil),
+			exp:       []string{"12D3KooWDpJ7As7BWAwRMfu1VU2WCqNjvq387JEYKDBj4kx6nXTN"},
+		},
+		{
+			name:      "No reserved peers",
+			sysModule: NewSystemModule(mockNetworkAPIEmpty, nil, nil, nil, nil, nil, nil, nil),
+			exp:       []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res []string
+			err := tt.sysModule.ReservedPeers(nil, &EmptyRequest{}, &res)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
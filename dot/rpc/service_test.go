@@ -23,12 +23,12 @@ func TestNewService(t *testing.T) {
 }
 
 func TestService_Methods(t *testing.T) {
-	qtySystemMethods := 15
+	qtySystemMethods := 16
 	qtyRPCMethods := 1
-	qtyAuthorMethods := 8
+	qtyAuthorMethods := 9
 
 	rpcService := NewService()
-	sysMod := modules.NewSystemModule(nil, nil, nil, nil, nil, nil, nil)
+	sysMod := modules.NewSystemModule(nil, nil, nil, nil, nil, nil, nil, nil)
 	rpcService.BuildMethodNames(sysMod, "system")
 	m := rpcService.Methods()
 	require.Equal(t, qtySystemMethods, len(m)) // check to confirm quantity for methods is correct
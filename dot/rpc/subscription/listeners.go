@@ -6,7 +6,6 @@
 import (
 	"errors"
 	"fmt"
-	"math/big"
 	"reflect"
 	"time"
 
@@ -57,9 +56,10 @@ type ChangeResult struct {
 
 // StorageObserver struct to hold data for observer (Observer Design Pattern)
 type StorageObserver struct {
-	id     uint32
-	filter map[string][]byte
-	wsconn *WSConn
+	id       uint32
+	filter   map[string][]byte
+	prefixes [][]byte
+	wsconn   *WSConn
 }
 
 // Update is called to notify observer of new value
@@ -93,6 +93,11 @@ func (s *StorageObserver) GetFilter() map[string][]byte {
 	return s.filter
 }
 
+// GetPrefixes returns the prefixes of the keys the Observer is notified of the changes
+func (s *StorageObserver) GetPrefixes() [][]byte {
+	return s.prefixes
+}
+
 // Listen to satisfy Listener interface (but is no longer used by StorageObserver)
 func (*StorageObserver) Listen() {}
 
@@ -300,8 +305,7 @@ type ExtrinsicSubmitListener struct {
 	finalisedChan chan *types.FinalisationInfo
 	// txStatusChan is used to know when transaction/extrinsic becomes part of the
 	// ready queue or future queue.
-	// we are using transaction.PriorityQueue for ready queue and transaction.Pool
-	// for future queue.
+	// both queues are part of the transaction.TaggedPool.
 	txStatusChan  chan transaction.Status
 	done          chan struct{}
 	cancel        chan struct{}
@@ -395,6 +399,7 @@ type RuntimeVersionListener struct {
 	runtimeUpdate chan runtime.Version
 	channelID     uint32
 	coreAPI       CoreAPI
+	blockAPI      BlockAPI
 }
 
 // Listen implementation of Listen interface to listen for runtime version changes
@@ -410,14 +415,22 @@ func (l *RuntimeVersionListener) Listen() {
 		stateRuntimeVersionMethod, l.subID, versionResponse)
 	go l.wsconn.safeSend(subscriptionResponse)
 
-	// listen for runtime updates
+	// listen for runtime updates, until the runtime updated channel is unregistered
 	go func() {
+		lastVersion := rtVersion
 		for {
 			info, ok := <-l.runtimeUpdate
 			if !ok {
 				return
 			}
 
+			// the runtime upgrades enacted by blocks on different forks
+			// are notified, even if they upgrade to the same version
+			if reflect.DeepEqual(info, lastVersion) {
+				continue
+			}
+			lastVersion = info
+
 			versionResponse := modules.NewStateRuntimeVersionResponse(info)
 			subscriptionResponse := newSubscriptionResponse(
 				stateRuntimeVersionMethod, l.subID, versionResponse)
@@ -431,9 +444,16 @@ func (l *RuntimeVersionListener) GetChannelID() uint32 {
 	return l.channelID
 }
 
-// Stop to runtimeVersionListener not implemented yet because the listener
-// does not need to be stoped
-func (*RuntimeVersionListener) Stop() error { return nil }
+// Stop unregisters the runtime updated channel of the listener,
+// which stops the goroutine listening for the runtime updates
+func (l *RuntimeVersionListener) Stop() error {
+	if l.blockAPI == nil {
+		return nil
+	}
+
+	l.blockAPI.UnregisterRuntimeUpdatedChannel(l.channelID)
+	return nil
+}
 
 // GrandpaJustificationListener struct has the finalisedCh and the context to stop the goroutines
 type GrandpaJustificationListener struct {
@@ -464,13 +484,13 @@ func (g *GrandpaJustificationListener) Listen() {
 					return
 				}
 
-				just, err := g.wsconn.BlockAPI.GetJustification(info.Header.Hash())
-				if err != nil {
-					g.wsconn.safeSendError(float64(g.subID), big.NewInt(InvalidRequestCode),
-						fmt.Sprintf("failed to retrieve justification: %v", err))
+				if info.Justification == nil {
+					// the block was finalised without a justification
+					continue
 				}
 
-				g.wsconn.safeSend(newSubscriptionResponse(grandpaJustificationsMethod, g.subID, common.BytesToHex(just)))
+				g.wsconn.safeSend(newSubscriptionResponse(grandpaJustificationsMethod, g.subID,
+					common.BytesToHex(info.Justification)))
 			}
 		}
 	}()
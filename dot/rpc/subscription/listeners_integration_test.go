@@ -261,7 +261,6 @@ func TestGrandpaJustification_Listen(t *testing.T) {
 		require.NoError(t, err)
 
 		blockStateMock := mocks.NewMockBlockAPI(ctrl)
-		blockStateMock.EXPECT().GetJustification(gomock.Any()).Return(mockedJustBytes, nil)
 		blockStateMock.EXPECT().FreeFinalisedNotifierChannel(gomock.Any())
 		wsconn.BlockAPI = blockStateMock
 
@@ -276,9 +275,14 @@ func TestGrandpaJustification_Listen(t *testing.T) {
 		}
 
 		sub.Listen()
+		// a block finalised without justification is not notified
 		finchannel <- &types.FinalisationInfo{
 			Header: *types.NewEmptyHeader(),
 		}
+		finchannel <- &types.FinalisationInfo{
+			Header:        *types.NewEmptyHeader(),
+			Justification: mockedJustBytes,
+		}
 
 		time.Sleep(time.Second * 3)
 
@@ -299,11 +303,14 @@ func TestRuntimeChannelListener_Listen(t *testing.T) {
 
 	notifyChan := make(chan runtime.Version)
 	mockConnection := &mockWSConnAPI{}
+	blockAPI := mocks.NewMockBlockAPI(ctrl)
 	rvl := RuntimeVersionListener{
 		wsconn:        mockConnection,
 		subID:         0,
 		runtimeUpdate: notifyChan,
+		channelID:     1,
 		coreAPI:       modules.NewMockAnyAPI(ctrl),
+		blockAPI:      blockAPI,
 	}
 
 	expectedInitialVersion := modules.StateRuntimeVersionResponse{
@@ -363,4 +370,17 @@ func TestRuntimeChannelListener_Listen(t *testing.T) {
 	notifyChan <- version
 	time.Sleep(time.Millisecond * 10)
 	require.Equal(t, expectedUpdateResponse, mockConnection.lastMessage)
+
+	// the same version is not notified again
+	mockConnection.lastMessage = BaseResponseJSON{}
+	notifyChan <- version
+	time.Sleep(time.Millisecond * 10)
+	require.Equal(t, BaseResponseJSON{}, mockConnection.lastMessage)
+
+	blockAPI.EXPECT().UnregisterRuntimeUpdatedChannel(uint32(1)).
+		DoAndReturn(func(uint32) bool {
+			close(notifyChan)
+			return true
+		})
+	require.NoError(t, rvl.Stop())
 }
@@ -18,6 +18,7 @@
 	chainSubscribeAllHeads         string = "chain_subscribeAllHeads"
 	stateSubscribeStorage          string = "state_subscribeStorage"
 	stateSubscribeRuntimeVersion   string = "state_subscribeRuntimeVersion"
+	chainSubscribeRuntimeVersion   string = "chain_subscribeRuntimeVersion"
 	grandpaSubscribeJustifications string = "grandpa_subscribeJustifications"
 )
 
@@ -42,7 +43,7 @@ func (c *WSConn) getSetupListener(method string) setupListener {
 		return c.initBlockFinalizedListener
 	case chainSubscribeAllHeads:
 		return c.initAllBlocksListerner
-	case stateSubscribeRuntimeVersion:
+	case stateSubscribeRuntimeVersion, chainSubscribeRuntimeVersion:
 		return c.initRuntimeVersionListener
 	case grandpaSubscribeJustifications:
 		return c.initGrandpaJustificationListener
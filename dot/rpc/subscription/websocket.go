@@ -55,6 +55,9 @@ type WSConn struct {
 	TxStateAPI    TransactionStateAPI
 	RPCHost       string
 	HTTP          httpclient
+	// Authorization is the authorization header of the websocket upgrade request,
+	// forwarded with the calls to the HTTP server.
+	Authorization string
 }
 
 // readWebsocketMessage will read and parse the message data to a string->interface{} data
@@ -169,7 +172,9 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (L
 	// the following type checking/casting is needed in order to satisfy some
 	// websocket request field params eg.:
 	// "params": ["0x..."] or
-	// "params": [["0x...", "0x..."]]
+	// "params": [["0x...", "0x..."]] or, to be notified of the changes
+	// of the keys having a prefix,
+	// "params": [["0x..."], {"prefixes": ["0x..."]}]
 	switch filters := params.(type) {
 	case []interface{}:
 		for _, interfaceKey := range filters {
@@ -189,8 +194,15 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (L
 
 					stgobs.filter[k] = []byte{}
 				}
+			case map[string]interface{}:
+				prefixes, err := parseStoragePrefixes(key["prefixes"])
+				if err != nil {
+					return nil, err
+				}
+				stgobs.prefixes = append(stgobs.prefixes, prefixes...)
 			default:
-				return nil, fmt.Errorf("%w: %T, expected type string, []string, []interface{}", errUnexpectedType, interfaceKey)
+				return nil, fmt.Errorf("%w: %T, expected type string, []string, []interface{}, map[string]interface{}",
+					errUnexpectedType, interfaceKey)
 			}
 		}
 	default:
@@ -211,6 +223,32 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (L
 	return stgobs, nil
 }
 
+// parseStoragePrefixes parses the hex encoded storage key prefixes given.
+func parseStoragePrefixes(params interface{}) (prefixes [][]byte, err error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	hexPrefixes, ok := params.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: %T, expected type []interface{}", errUnexpectedType, params)
+	}
+
+	prefixes = make([][]byte, len(hexPrefixes))
+	for i, param := range hexPrefixes {
+		hexPrefix, ok := param.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T, expected type string", errUnexpectedType, param)
+		}
+
+		prefixes[i], err = common.HexToBytes(hexPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("decoding storage key prefix: %w", err)
+		}
+	}
+	return prefixes, nil
+}
+
 func (c *WSConn) initBlockListener(reqID float64, _ interface{}) (Listener, error) {
 	bl := NewBlockListener(c)
 
@@ -360,6 +398,7 @@ func (c *WSConn) initRuntimeVersionListener(reqID float64, _ interface{}) (Liste
 		wsconn:        c,
 		runtimeUpdate: make(chan runtime.Version),
 		coreAPI:       c.CoreAPI,
+		blockAPI:      c.BlockAPI,
 	}
 
 	chanID, err := c.BlockAPI.RegisterRuntimeUpdatedChannel(rvl.runtimeUpdate)
@@ -448,6 +487,9 @@ func (c *WSConn) prepareRequest(b []byte) (*http.Request, error) {
 	}
 
 	req.Header.Set("Content-Type", "application/json;")
+	if c.Authorization != "" {
+		req.Header.Set("Authorization", c.Authorization)
+	}
 	return req, nil
 }
 
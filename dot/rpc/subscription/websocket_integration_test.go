@@ -84,7 +84,7 @@ func TestWSConn_initStorageChangeListener(t *testing.T) {
 			msg:           nil,
 			subscriptions: 0,
 			initErr:       errUnexpectedType,
-			initErrMsg:    "unexpected type: []int, expected type string, []string, []interface{}",
+			initErrMsg:    "unexpected type: []int, expected type string, []string, []interface{}, map[string]interface{}",
 			storageAPIset: true,
 			reqID:         1,
 			params:        []interface{}{[]int{123}},
@@ -94,11 +94,34 @@ func TestWSConn_initStorageChangeListener(t *testing.T) {
 			msg:           nil,
 			subscriptions: 0,
 			initErr:       errUnexpectedType,
-			initErrMsg:    "unexpected type: int, expected type string, []string, []interface{}",
+			initErrMsg:    "unexpected type: int, expected type string, []string, []interface{}, map[string]interface{}",
 			storageAPIset: true,
 			reqID:         1,
 			params:        []interface{}{123},
 		},
+		"req_4_prefixes": {
+			badRequest:    false,
+			msg:           []byte(`{"jsonrpc":"2.0","result":1,"id":4}` + "\n"),
+			subscriptions: 1,
+			storageAPIset: true,
+			reqID:         4,
+			params: []interface{}{
+				[]interface{}{"0x26aa"},
+				map[string]interface{}{"prefixes": []interface{}{"0x26aa394e"}},
+			},
+		},
+		"req_1_unexpected_type_prefix": {
+			badRequest:    true,
+			msg:           nil,
+			subscriptions: 0,
+			initErr:       errUnexpectedType,
+			initErrMsg:    "unexpected type: float64, expected type string",
+			storageAPIset: true,
+			reqID:         1,
+			params: []interface{}{
+				map[string]interface{}{"prefixes": []interface{}{float64(1)}},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		testCase := testCase
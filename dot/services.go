@@ -4,8 +4,12 @@
 package dot
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,6 +17,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/digest"
+	"github.com/ChainSafe/gossamer/dot/light"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
@@ -35,6 +40,9 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"golang.org/x/crypto/acme/autocert"
 )
 
 // BlockProducer to produce blocks
@@ -55,6 +63,11 @@ type rpcServiceSettings struct {
 	system        *system.Service
 	blockFinality *grandpa.Service
 	syncer        *sync.Service
+	headerSync    *light.HeaderSync
+	reloader      modules.ConfigReloaderAPI
+	chainHead     modules.ChainHeadAPI
+	manualSeal    modules.ManualSealAPI
+	supervisor    modules.SupervisorAPI
 }
 
 func newInMemoryDB() (database.Database, error) {
@@ -106,6 +119,17 @@ func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error
 		LogLevel:          stateLogLevel,
 		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
 		GenesisBABEConfig: babeCfg,
+		DatabaseBackend:   database.Backend(config.State.DatabaseBackend),
+		TransactionPoolLimits: transaction.PoolLimits{
+			MaxCount:     int(config.Core.PoolLimit),
+			MaxBytes:     int(config.Core.PoolKBytes) * 1024,
+			MaxPerSender: int(config.Core.PoolSenderLimit),
+			BanDuration:  config.Core.TxBanDuration,
+		},
+		StorageChanges: state.StorageChangesConfig{
+			Enabled:        config.State.StorageChangesIndex,
+			RetainedBlocks: config.State.StorageChangesRetainedBlocks,
+		},
 	}
 
 	stateSrvc := state.NewService(stateConfig)
@@ -144,16 +168,25 @@ func (nodeBuilder) createRuntimeStorage(st *state.Service) (*runtime.NodeStorage
 
 	return &runtime.NodeStorage{
 		LocalStorage:      localStorage,
-		PersistentStorage: database.NewTable(st.DB(), "offlinestorage"),
+		PersistentStorage: database.NewTable(st.DB(), state.OffchainStoragePrefix),
 		BaseDB:            st.Base,
 	}, nil
 }
 
+// createRuntime creates a runtime instance of the code given and stores it for the block hash given,
+// the code being the runtime code in the state of this block.
 func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service,
-	ks *keystore.GlobalKeystore, net *network.Service, code []byte) (
+	ks *keystore.GlobalKeystore, net *network.Service, blockHash common.Hash, code []byte) (
 	rt runtime.Instance, err error) {
 	logger.Info("creating runtime with interpreter " + config.Core.WasmInterpreter + "...")
 
+	// the code hash is the hash of the code of the block, even if the code is substituted,
+	// so the runtime is upgraded once the code of a descendant block changes.
+	codeHash, err := common.Blake2bHash(code)
+	if err != nil {
+		return nil, fmt.Errorf("hashing runtime code: %w", err)
+	}
+
 	// check if code substitute is in use, if so replace code
 	codeSubHash := st.Base.LoadCodeSubstitutedBlockHash()
 
@@ -173,11 +206,6 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 		return nil, err
 	}
 
-	codeHash, err := st.Storage.LoadCodeHash(nil)
-	if err != nil {
-		return nil, err
-	}
-
 	wasmerLogLevel, err := log.ParseLevel(config.Log.Wasmer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse wasmer log level: %w", err)
@@ -185,13 +213,14 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 	switch config.Core.WasmInterpreter {
 	case wazero_runtime.Name:
 		rtCfg := wazero_runtime.Config{
-			Storage:     ts,
-			Keystore:    ks,
-			LogLvl:      wasmerLogLevel,
-			NodeStorage: ns,
-			Network:     net,
-			Role:        config.Core.Role,
-			CodeHash:    codeHash,
+			Storage:             ts,
+			Keystore:            ks,
+			LogLvl:              wasmerLogLevel,
+			NodeStorage:         ns,
+			Network:             net,
+			Role:                config.Core.Role,
+			CodeHash:            codeHash,
+			HostFunctionTracing: config.Core.RuntimeTracing,
 		}
 
 		// create runtime executor
@@ -203,10 +232,31 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 		return nil, fmt.Errorf("%w: %s", ErrWasmInterpreterName, config.Core.WasmInterpreter)
 	}
 
-	st.Block.StoreRuntime(st.Block.BestBlockHash(), rt)
+	err = checkStateVersion(rt, config.State.MaxStateVersion)
+	if err != nil {
+		rt.Stop()
+		return nil, err
+	}
+
+	st.Block.StoreRuntime(blockHash, rt)
 	return rt, nil
 }
 
+// checkStateVersion returns an error if the runtime requires a state trie
+// version later than the maximum state version given.
+func checkStateVersion(rt runtime.Instance, maxStateVersion uint) error {
+	version, err := rt.Version()
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	if uint(version.StateVersion) > maxStateVersion {
+		return fmt.Errorf("%w: runtime %s requires state version %d but max-state-version is %d",
+			ErrStateVersionNotSupported, version.SpecName, version.StateVersion, maxStateVersion)
+	}
+	return nil
+}
+
 func asAuthority(authority bool) string {
 	if authority {
 		return " as authority"
@@ -254,19 +304,25 @@ func (nodeBuilder) createBABEServiceWithBuilder(config *cfg.Config, st *state.Se
 		return nil, fmt.Errorf("failed to parse babe log level: %w", err)
 	}
 	bcfg := &babe.ServiceConfig{
-		LogLvl:             babeLogLevel,
-		BlockState:         st.Block,
-		StorageState:       st.Storage,
-		TransactionState:   st.Transaction,
-		EpochState:         st.Epoch,
-		BlockImportHandler: cs,
-		Authority:          config.Core.BabeAuthority,
-		IsDev:              config.ID == "dev",
-		Telemetry:          telemetryMailer,
+		LogLvl:              babeLogLevel,
+		BlockState:          st.Block,
+		StorageState:        st.Storage,
+		TransactionState:    st.Transaction,
+		EpochState:          st.Epoch,
+		BlockImportHandler:  cs,
+		Authority:           config.Core.BabeAuthority,
+		IsDev:               config.ID == "dev",
+		Telemetry:           telemetryMailer,
+		InstantSeal:         config.Core.Dev,
+		MaxUnfinalisedDepth: config.Core.MaxUnfinalisedDepth,
 	}
 
 	if config.Core.BabeAuthority {
-		bcfg.Keypair = kps[0].(*sr25519.Keypair)
+		kp, ok := kps[0].(*sr25519.Keypair)
+		if !ok {
+			return nil, fmt.Errorf("%w: babe authoring requires a local sr25519 keypair", ErrInvalidKeystoreType)
+		}
+		bcfg.Keypair = kp
 	}
 
 	bs, err := newBabeService.NewServiceIFace(bcfg)
@@ -344,19 +400,25 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse network log level: %w", err)
 	}
+	// rpc nodes relax their peer slots to keep up with the chain while serving requests
+	maxPeers := config.Network.MaxPeers
+	if config.Core.Role == common.RPCNodeRole {
+		maxPeers = max(maxPeers, cfg.DefaultRPCNodeMaxPeers)
+	}
+
 	// network service configuation
 	networkConfig := network.Config{
 		LogLvl:            networkLogLevel,
 		BlockState:        stateSrvc.Block,
 		BasePath:          config.BasePath,
-		Roles:             config.Core.Role,
+		Roles:             config.Core.Role.Announced(),
 		Port:              config.Network.Port,
 		Bootnodes:         config.Network.Bootnodes,
 		ProtocolID:        config.Network.ProtocolID,
 		NoBootstrap:       config.Network.NoBootstrap,
 		NoMDNS:            config.Network.NoMDNS,
 		MinPeers:          config.Network.MinPeers,
-		MaxPeers:          config.Network.MaxPeers,
+		MaxPeers:          maxPeers,
 		PersistentPeers:   config.Network.PersistentPeers,
 		DiscoveryInterval: config.Network.DiscoveryInterval,
 		SlotDuration:      slotDuration,
@@ -365,7 +427,9 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 		PublicDNS:         config.Network.PublicDNS,
 		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
 		NodeKey:           config.Network.NodeKey,
-		ListenAddress:     config.Network.ListenAddress,
+		NodeKeyFile:       config.Network.NodeKeyFile,
+		ListenAddresses:   listenAddresses(config.Network),
+		ValidatorPeers:    uint32(config.Network.ValidatorPeers),
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -377,6 +441,14 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 	return networkSrvc, nil
 }
 
+// listenAddresses returns the listen address and the listen addresses configured.
+func listenAddresses(config *cfg.NetworkConfig) []string {
+	if config.ListenAddress == "" {
+		return config.ListenAddresses
+	}
+	return append([]string{config.ListenAddress}, config.ListenAddresses...)
+}
+
 // RPC Service
 
 // createRPCService creates the RPC service from the provided core configuration
@@ -406,19 +478,49 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse rpc log level: %w", err)
 	}
+
+	// avoid non nil interfaces holding the nil services of light clients
+	var badBlocksAPI modules.BadBlocksAPI
+	var syncAPI modules.SyncAPI
+	switch {
+	case params.syncer != nil:
+		badBlocksAPI = params.syncer
+		syncAPI = params.syncer
+	case params.headerSync != nil:
+		syncAPI = params.headerSync
+	}
+	var coreAPI modules.CoreAPI
+	if params.core != nil {
+		coreAPI = params.core
+	}
+
+	rpcAuthToken, err := authToken(cfg.RPCAuthTokenEnv, params.config.RPC.AuthTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading rpc auth token: %w", err)
+	}
+
+	if rpcAuthToken == "" && (params.config.RPC.UnsafeRPCExternal || params.config.RPC.UnsafeWSExternal) {
+		logger.Warn("unsafe rpc methods are exposed externally without authentication")
+	}
+
+	tlsConfig, acmeHandler, err := rpcTLSConfig(params.config)
+	if err != nil {
+		return nil, fmt.Errorf("creating rpc tls config: %w", err)
+	}
+
 	rpcConfig := &rpc.HTTPServerConfig{
 		LogLvl:              rpcLogLevel,
 		BlockAPI:            params.state.Block,
 		StorageAPI:          params.state.Storage,
 		NetworkAPI:          params.network,
-		CoreAPI:             params.core,
+		CoreAPI:             coreAPI,
 		NodeStorage:         params.nodeStorage,
 		BlockProducerAPI:    params.blockProducer,
 		BlockFinalityAPI:    params.blockFinality,
 		TransactionQueueAPI: params.state.Transaction,
 		RPCAPI:              rpcService,
 		SyncStateAPI:        syncStateSrvc,
-		SyncAPI:             params.syncer,
+		SyncAPI:             syncAPI,
 		SystemAPI:           params.system,
 		RPCUnsafe:           params.config.RPC.UnsafeRPC,
 		RPCExternal:         params.config.RPC.RPCExternal,
@@ -429,11 +531,48 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		WSUnsafeExternal:    params.config.RPC.UnsafeWSExternal,
 		WSPort:              params.config.RPC.WSPort,
 		Modules:             params.config.RPC.Modules,
+		CORS:                params.config.RPC.CORS,
+		ConfigReloaderAPI:   params.reloader,
+		BadBlocksAPI:        badBlocksAPI,
+		ChainHeadAPI:        params.chainHead,
+		ManualSealAPI:       params.manualSeal,
+		SupervisorAPI:       params.supervisor,
+		GrandpaStateAPI:     params.state.Grandpa,
+		TLSConfig:           tlsConfig,
+		ACMEHandler:         acmeHandler,
+		AuthToken:           rpcAuthToken,
 	}
 
 	return rpc.NewHTTPServer(rpcConfig), nil
 }
 
+// rpcTLSConfig returns the TLS configuration of the RPC servers, which is nil if TLS is
+// not enabled. The certificates obtained with ACME are cached in the base path, and the
+// handler returned answers the HTTP-01 challenges of the ACME certificate authority.
+func rpcTLSConfig(config *cfg.Config) (tlsConfig *tls.Config, acmeHandler http.Handler, err error) {
+	if !config.RPC.IsTLSEnabled() {
+		return nil, nil, nil
+	}
+
+	if len(config.RPC.ACMEDomains) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(config.RPC.ACMEDomains...),
+			Cache:      autocert.DirCache(filepath.Join(config.BasePath, "acme")),
+		}
+		return manager.TLSConfig(), manager.HTTPHandler(nil), nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(config.RPC.TLSCertFile, config.RPC.TLSKeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading tls key pair: %w", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}, nil, nil
+}
+
 // createSystemService creates a systemService for providing system related information
 func (nodeBuilder) createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*system.Service, error) {
 	genesisData, err := stateSrvc.Base.LoadGenesisData()
@@ -485,12 +624,69 @@ func (nodeBuilder) createGRANDPAService(config *cfg.Config, st *state.Service, k
 	}
 
 	if config.Core.GrandpaAuthority {
-		gsCfg.Keypair = keys[0].(*ed25519.Keypair)
+		kp, ok := keys[0].(*ed25519.Keypair)
+		if !ok {
+			return nil, fmt.Errorf("%w: grandpa voting requires a local ed25519 keypair", ErrInvalidKeystoreType)
+		}
+		gsCfg.Keypair = kp
 	}
 
 	return grandpa.NewService(gsCfg)
 }
 
+// createLightGRANDPAService creates a GRANDPA service used by light clients to verify finality
+// justifications. The voters are read from the grandpa state since light clients have no runtime.
+func createLightGRANDPAService(config *cfg.Config, st *state.Service, net *network.Service,
+	telemetryMailer Telemetry) (*grandpa.Service, error) {
+	setID, err := st.Grandpa.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	voters, err := st.Grandpa.GetAuthorities(setID)
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities for set id %d: %w", setID, err)
+	}
+
+	grandpaLogLevel, err := log.ParseLevel(config.Log.Grandpa)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse grandpa log level: %w", err)
+	}
+
+	return grandpa.NewService(&grandpa.Config{
+		LogLvl:       grandpaLogLevel,
+		BlockState:   st.Block,
+		GrandpaState: st.Grandpa,
+		Voters:       voters,
+		Network:      net,
+		Interval:     config.Core.GrandpaInterval,
+		Telemetry:    telemetryMailer,
+	})
+}
+
+// createHeaderSync creates the header sync service of light clients
+func createHeaderSync(config *cfg.Config, st *state.Service, fg light.FinalityGadget,
+	net *network.Service) (*light.HeaderSync, error) {
+	syncLogLevel, err := log.ParseLevel(config.Log.Sync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sync log level: %w", err)
+	}
+
+	const headerRequestTimeout = time.Second * 20
+	requestMaker := net.GetRequestResponseProtocol(
+		network.SyncID,
+		headerRequestTimeout,
+		network.MaxBlockResponseSize)
+
+	return light.NewHeaderSync(&light.Config{
+		LogLvl:         syncLogLevel,
+		BlockState:     st.Block,
+		FinalityGadget: fg,
+		Network:        net,
+		RequestMaker:   requestMaker,
+	}), nil
+}
+
 func (nodeBuilder) createBlockVerifier(st *state.Service) *babe.VerificationManager {
 	return babe.NewVerificationManager(st.Block, st.Slot, st.Epoch)
 }
@@ -513,6 +709,11 @@ func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg Bloc
 		return nil, fmt.Errorf("failed to parse sync log level: %w", err)
 	}
 
+	checkpoint, err := newSyncCheckpoint(genesisData.Checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creating sync checkpoint: %w", err)
+	}
+
 	const blockRequestTimeout = time.Second * 20
 	requestMaker := net.GetRequestResponseProtocol(
 		network.SyncID,
@@ -520,30 +721,121 @@ func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg Bloc
 		network.MaxBlockResponseSize)
 
 	syncCfg := &sync.Config{
-		LogLvl:             syncLogLevel,
-		Network:            net,
-		BlockState:         st.Block,
-		StorageState:       st.Storage,
-		TransactionState:   st.Transaction,
-		FinalityGadget:     fg,
-		BabeVerifier:       verifier,
-		BlockImportHandler: cs,
-		MinPeers:           config.Network.MinPeers,
-		MaxPeers:           config.Network.MaxPeers,
-		SlotDuration:       slotDuration,
-		Telemetry:          telemetryMailer,
-		BadBlocks:          genesisData.BadBlocks,
-		RequestMaker:       requestMaker,
+		LogLvl:              syncLogLevel,
+		Network:             net,
+		BlockState:          st.Block,
+		StorageState:        st.Storage,
+		TransactionState:    st.Transaction,
+		FinalityGadget:      fg,
+		BabeVerifier:        verifier,
+		BlockImportHandler:  cs,
+		MinPeers:            config.Network.MinPeers,
+		MaxPeers:            config.Network.MaxPeers,
+		SlotDuration:        slotDuration,
+		Telemetry:           telemetryMailer,
+		BadBlocks:           genesisData.BadBlocks,
+		RequestMaker:        requestMaker,
+		TipRequestRacers:    config.Network.TipRequestRacers,
+		VerifyAncientBlocks: config.Network.VerifyAncientBlocks,
+		Checkpoint:          checkpoint,
+		MaxRequests:         config.Sync.MaxRequests,
+		MaxRequestsPerPeer:  config.Sync.MaxRequestsPerPeer,
+		MaxWorkers:          config.Sync.MaxWorkers,
+		BatchTelemetry:      config.Sync.Telemetry,
 	}
 
 	return sync.NewService(syncCfg)
 }
 
+// newSyncCheckpoint decodes the checkpoint of the chain-spec and verifies its justification
+// against its authority set, so the checkpoint block can be trusted by the chain sync.
+// It returns a nil checkpoint if the chain-spec has no checkpoint.
+func newSyncCheckpoint(checkpoint *genesis.Checkpoint) (*sync.Checkpoint, error) {
+	if checkpoint == nil {
+		return nil, nil
+	}
+
+	hash, err := common.HexToHash(checkpoint.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("decoding hash: %w", err)
+	}
+
+	encodedHeader, err := common.HexToBytes(checkpoint.Header)
+	if err != nil {
+		return nil, fmt.Errorf("decoding header hex: %w", err)
+	}
+
+	header := types.NewEmptyHeader()
+	err = scale.Unmarshal(encodedHeader, header)
+	if err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+
+	if header.Hash() != hash {
+		return nil, fmt.Errorf("header hash %s does not match checkpoint hash %s", header.Hash(), hash)
+	}
+
+	voters := make([]types.GrandpaVoter, len(checkpoint.Authorities))
+	for i, authority := range checkpoint.Authorities {
+		key, err := ed25519.NewPublicKey(crypto.PublicAddressToByteArray(authority.Address))
+		if err != nil {
+			return nil, fmt.Errorf("decoding authority %s: %w", authority.Address, err)
+		}
+		voters[i] = types.GrandpaVoter{Key: *key, ID: uint64(i)}
+	}
+
+	justification, err := common.HexToBytes(checkpoint.Justification)
+	if err != nil {
+		return nil, fmt.Errorf("decoding justification hex: %w", err)
+	}
+
+	round, err := grandpa.VerifyCheckpointJustification(hash, header.Number, justification,
+		checkpoint.SetID, voters)
+	if err != nil {
+		return nil, fmt.Errorf("verifying justification: %w", err)
+	}
+
+	return &sync.Checkpoint{
+		Header:        header,
+		Justification: justification,
+		Round:         round,
+		SetID:         checkpoint.SetID,
+	}, nil
+}
+
 func (nodeBuilder) createDigestHandler(st *state.Service) (*digest.Handler, error) {
-	return digest.NewHandler(st.Block, st.Epoch, st.Grandpa)
+	return digest.NewHandler(st.Epoch, st.Grandpa)
 }
 
-func createPprofService(config cfg.PprofConfig) (service *pprof.Service) {
+func createPprofService(config *cfg.Config) (service *pprof.Service, err error) {
+	pprofAuthToken, err := authToken(cfg.PprofAuthTokenEnv, config.Pprof.AuthTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading pprof auth token: %w", err)
+	}
+
+	if pprofAuthToken == "" {
+		logger.Warnf("pprof server listening on %s without authentication", config.Pprof.ListeningAddress)
+	}
+
+	snapshotDir := filepath.Join(config.BasePath, "debug")
 	pprofLogger := log.NewFromGlobal(log.AddContext("pkg", "pprof"))
-	return pprof.NewService(config, pprofLogger)
+	return pprof.NewService(*config.Pprof, pprofAuthToken, snapshotDir, pprofLogger), nil
+}
+
+// authToken returns the authentication token from the given environment variable,
+// falling back to reading it from the given token file if set.
+func authToken(envVariable, tokenFile string) (string, error) {
+	if token := os.Getenv(envVariable); token != "" {
+		return token, nil
+	}
+
+	if tokenFile == "" {
+		return "", nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
 }
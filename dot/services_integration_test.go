@@ -6,7 +6,10 @@
 package dot
 
 import (
+	"context"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -22,6 +25,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	babe "github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -33,6 +37,18 @@
 	gomock "go.uber.org/mock/gomock"
 )
 
+// testRemoteSigner is a keystore.RemoteSigner holding the given public keys
+// and refusing to sign.
+type testRemoteSigner [][]byte
+
+func (s testRemoteSigner) PublicKeys(context.Context, keystore.Name) ([][]byte, error) {
+	return s, nil
+}
+
+func (testRemoteSigner) Sign(context.Context, keystore.Name, []byte, []byte) ([]byte, error) {
+	return nil, keystore.ErrRemoteSignerRequest
+}
+
 func Test_nodeBuilder_createBABEService(t *testing.T) {
 	t.Parallel()
 
@@ -46,6 +62,11 @@ func Test_nodeBuilder_createBABEService(t *testing.T) {
 	require.NoError(t, err)
 	ks2.Babe.Insert(kr.Alice())
 
+	remoteBabe := keystore.NewRemoteKeystore(keystore.BabeName, crypto.Sr25519Type,
+		testRemoteSigner{kr.Alice().Public().Encode()})
+	err = remoteBabe.Refresh(context.Background())
+	require.NoError(t, err)
+
 	type args struct {
 		cfg              *cfg.Config
 		initStateService bool
@@ -79,6 +100,16 @@ type args struct {
 			expected: nil,
 			err:      ErrNoKeysProvided,
 		},
+		{
+			name: "remote_keystore",
+			args: args{
+				cfg:              config,
+				initStateService: true,
+				ks:               remoteBabe,
+			},
+			expected: nil,
+			err:      ErrInvalidKeystoreType,
+		},
 		{
 			name: "base_case",
 			args: args{
@@ -262,7 +293,11 @@ func Test_nodeBuilder_createGRANDPAService(t *testing.T) {
 	require.NoError(t, err)
 	ks.Gran.Insert(kr.Alice())
 
+	remoteGran := keystore.NewRemoteKeystore(keystore.GranName, crypto.Ed25519Type,
+		testRemoteSigner{kr.Alice().Public().Encode()})
+	err = remoteGran.Refresh(context.Background())
 	require.NoError(t, err)
+
 	tests := []struct {
 		name      string
 		ks        KeyStore
@@ -275,6 +310,12 @@ func Test_nodeBuilder_createGRANDPAService(t *testing.T) {
 			expectNil: true,
 			err:       ErrInvalidKeystoreType,
 		},
+		{
+			name:      "remote keystore",
+			ks:        remoteGran,
+			expectNil: true,
+			err:       ErrInvalidKeystoreType,
+		},
 		{
 			name:      "base case",
 			ks:        ks.Gran,
@@ -346,7 +387,8 @@ type args struct {
 			code, err := stateSrvc.Storage.LoadCode(nil)
 			require.NoError(t, err)
 
-			got, err := createRuntime(tt.args.config, tt.args.ns, stateSrvc, nil, nil, code)
+			got, err := createRuntime(tt.args.config, tt.args.ns, stateSrvc, nil, nil,
+				stateSrvc.Block.BestBlockHash(), code)
 			assert.ErrorIs(t, err, tt.err)
 			if tt.expectedType == nil {
 				assert.Nil(t, got)
@@ -797,19 +839,43 @@ func TestNewWebSocketServer(t *testing.T) {
 }
 
 func Test_createPprofService(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	err := os.WriteFile(tokenFile, []byte("token\n"), 0o600)
+	require.NoError(t, err)
+
 	tests := []struct {
-		name     string
-		settings cfg.PprofConfig
-		notNil   bool
+		name       string
+		settings   cfg.PprofConfig
+		notNil     bool
+		errMessage string
 	}{
 		{
 			name:   "base case",
 			notNil: true,
 		},
+		{
+			name:     "auth token file",
+			settings: cfg.PprofConfig{AuthTokenFile: tokenFile},
+			notNil:   true,
+		},
+		{
+			name:       "auth token file not found",
+			settings:   cfg.PprofConfig{AuthTokenFile: filepath.Join(t.TempDir(), "missing")},
+			errMessage: "reading pprof auth token: open ",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := createPprofService(tt.settings)
+			config := &cfg.Config{
+				BaseConfig: cfg.BaseConfig{BasePath: t.TempDir()},
+				Pprof:      &tt.settings,
+			}
+			got, err := createPprofService(config)
+			if tt.errMessage != "" {
+				assert.ErrorContains(t, err, tt.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
 			if tt.notNil {
 				assert.NotNil(t, got)
 			} else {
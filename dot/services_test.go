@@ -7,11 +7,16 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/mocks"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -123,6 +128,50 @@ func Test_newInMemoryDB(t *testing.T) {
 	}
 }
 
+func Test_checkStateVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		stateVersion    uint8
+		maxStateVersion uint
+		errWrapped      error
+		errMessage      string
+	}{
+		"state_version_supported": {
+			stateVersion:    1,
+			maxStateVersion: 1,
+		},
+		"state_version_not_supported": {
+			stateVersion:    1,
+			maxStateVersion: 0,
+			errWrapped:      ErrStateVersionNotSupported,
+			errMessage: "state version not supported: runtime westend requires " +
+				"state version 1 but max-state-version is 0",
+		},
+	}
+
+	for name, testCase := range tests {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			instance := mocks.NewMockInstance(ctrl)
+			instance.EXPECT().Version().Return(runtime.Version{
+				SpecName:     []byte("westend"),
+				StateVersion: testCase.stateVersion,
+			}, nil)
+
+			err := checkStateVersion(instance, testCase.maxStateVersion)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
 func newStateService(t *testing.T, ctrl *gomock.Controller) *state.Service {
 	t.Helper()
 
@@ -165,3 +214,62 @@ func newStateService(t *testing.T, ctrl *gomock.Controller) *state.Service {
 
 	return stateSrvc
 }
+
+func Test_newSyncCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 10, types.NewDigest())
+	encodedHeader, err := scale.Marshal(*header)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		checkpoint *genesis.Checkpoint
+		expected   *sync.Checkpoint
+		errMessage string
+	}{
+		"no_checkpoint": {},
+		"invalid_hash": {
+			checkpoint: &genesis.Checkpoint{Hash: "0xzz"},
+			errMessage: "decoding hash: encoding/hex: invalid byte: U+007A 'z'",
+		},
+		"invalid_header": {
+			checkpoint: &genesis.Checkpoint{
+				Hash:   header.Hash().String(),
+				Header: "0x01",
+			},
+			errMessage: "decoding header: decoding struct: unmarshalling field at index 0: EOF",
+		},
+		"header_hash_mismatch": {
+			checkpoint: &genesis.Checkpoint{
+				Hash:   common.Hash{9}.String(),
+				Header: common.BytesToHex(encodedHeader),
+			},
+			errMessage: "header hash " + header.Hash().String() +
+				" does not match checkpoint hash " + common.Hash{9}.String(),
+		},
+		"invalid_justification": {
+			checkpoint: &genesis.Checkpoint{
+				Hash:          header.Hash().String(),
+				Header:        common.BytesToHex(encodedHeader),
+				Justification: "0x00",
+			},
+			errMessage: "verifying justification: malformed justification: " +
+				"decoding struct: unmarshalling field at index 0: unexpected EOF",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			checkpoint, err := newSyncCheckpoint(testCase.checkpoint)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expected, checkpoint)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var badBlocksKey = []byte("badblocks") // badBlocksKey -> scale encoded []common.Hash
+
+// GetBadBlocks returns the hashes of the blocks added as bad blocks at runtime.
+func (bs *BlockState) GetBadBlocks() ([]common.Hash, error) {
+	bs.badBlocksLock.Lock()
+	defer bs.badBlocksLock.Unlock()
+
+	return bs.loadBadBlocks()
+}
+
+// AddBadBlock persists the hash given as a bad block.
+func (bs *BlockState) AddBadBlock(hash common.Hash) error {
+	bs.badBlocksLock.Lock()
+	defer bs.badBlocksLock.Unlock()
+
+	badBlocks, err := bs.loadBadBlocks()
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(badBlocks, hash) {
+		return nil
+	}
+
+	return bs.storeBadBlocks(append(badBlocks, hash))
+}
+
+// RemoveBadBlock removes the hash given from the persisted bad blocks.
+func (bs *BlockState) RemoveBadBlock(hash common.Hash) error {
+	bs.badBlocksLock.Lock()
+	defer bs.badBlocksLock.Unlock()
+
+	badBlocks, err := bs.loadBadBlocks()
+	if err != nil {
+		return err
+	}
+
+	index := slices.Index(badBlocks, hash)
+	if index == -1 {
+		return nil
+	}
+
+	return bs.storeBadBlocks(slices.Delete(badBlocks, index, index+1))
+}
+
+func (bs *BlockState) loadBadBlocks() (badBlocks []common.Hash, err error) {
+	encoded, err := bs.db.Get(badBlocksKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting bad blocks: %w", err)
+	}
+
+	err = scale.Unmarshal(encoded, &badBlocks)
+	if err != nil {
+		return nil, fmt.Errorf("decoding bad blocks: %w", err)
+	}
+	return badBlocks, nil
+}
+
+func (bs *BlockState) storeBadBlocks(badBlocks []common.Hash) error {
+	encoded, err := scale.Marshal(badBlocks)
+	if err != nil {
+		return fmt.Errorf("encoding bad blocks: %w", err)
+	}
+
+	err = bs.db.Put(badBlocksKey, encoded)
+	if err != nil {
+		return fmt.Errorf("storing bad blocks: %w", err)
+	}
+	return nil
+}
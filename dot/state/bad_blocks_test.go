@@ -0,0 +1,43 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_BadBlocks(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	badBlocks, err := bs.GetBadBlocks()
+	require.NoError(t, err)
+	assert.Empty(t, badBlocks)
+
+	err = bs.AddBadBlock(common.Hash{1})
+	require.NoError(t, err)
+	err = bs.AddBadBlock(common.Hash{2})
+	require.NoError(t, err)
+	// a bad block added twice is only kept once
+	err = bs.AddBadBlock(common.Hash{1})
+	require.NoError(t, err)
+
+	badBlocks, err = bs.GetBadBlocks()
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{{1}, {2}}, badBlocks)
+
+	err = bs.RemoveBadBlock(common.Hash{1})
+	require.NoError(t, err)
+	err = bs.RemoveBadBlock(common.Hash{3})
+	require.NoError(t, err)
+
+	badBlocks, err = bs.GetBadBlocks()
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{{2}}, badBlocks)
+}
@@ -11,6 +11,7 @@
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
@@ -36,6 +37,7 @@
 	receiptPrefix       = []byte("rcp") // receiptPrefix + hash -> receipt
 	messageQueuePrefix  = []byte("mqp") // messageQueuePrefix + hash -> message queue
 	justificationPrefix = []byte("jcp") // justificationPrefix + hash -> justification
+	availabilityPrefix  = []byte("avl") // availabilityPrefix + hash -> block availability flags
 
 	errNilBlockTree = errors.New("blocktree is nil")
 	errNilBlockBody = errors.New("block body is nil")
@@ -61,6 +63,12 @@ type BlockState struct {
 	lastSetID         uint64
 	unfinalisedBlocks *hashToBlockMap
 	tries             *Tries
+	// pins are the references held on the blocks pinned by their readers
+	pins pinnedBlocks
+	// runtimeCache caches the runtime instances of the finalised blocks no longer in the tree
+	runtimeCache runtimeCache
+	// storageState is the storage state the runtime code of the finalised blocks is loaded from
+	storageState runtimeStorage
 
 	// State variables
 	pausedLock sync.RWMutex
@@ -68,11 +76,31 @@ type BlockState struct {
 
 	// block notifiers
 	imported                       map[chan *types.Block]struct{}
+	importSubscriptions            map[*ImportNotifications]struct{}
 	finalised                      map[chan *types.FinalisationInfo]struct{}
 	finalisedLock                  sync.RWMutex
 	importedLock                   sync.RWMutex
 	runtimeUpdateSubscriptionsLock sync.RWMutex
 	runtimeUpdateSubscriptions     map[uint32]chan<- runtime.Version
+	// eventBus is the bus the blocks finalised are published on, if any
+	eventBus *events.Bus
+	// forkChoice selects the best chain, BABEForkChoice if nil
+	forkChoice ForkChoice
+
+	// storage changes of the blocks stored, kept until their import is notified
+	storageChangesLock sync.Mutex
+	storageChanges     map[common.Hash]blockStorageChanges
+	// storageChangesIndex indexes the storage keys modified by each block, if enabled
+	storageChangesIndex *storageChangesIndex
+
+	// runtime upgrades
+	runtimeUpgradesLock sync.Mutex
+	pendingRuntimes     map[common.Hash]*pendingRuntime
+
+	badBlocksLock sync.Mutex
+
+	// availabilityLock serialises the updates of the block availability index
+	availabilityLock sync.Mutex
 
 	telemetry Telemetry
 }
@@ -86,8 +114,11 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
 		imported:                   make(map[chan *types.Block]struct{}),
+		importSubscriptions:        make(map[*ImportNotifications]struct{}),
+		storageChanges:             make(map[common.Hash]blockStorageChanges),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		pendingRuntimes:            make(map[common.Hash]*pendingRuntime),
 		telemetry:                  telemetry,
 		pause:                      make(chan struct{}),
 	}
@@ -105,7 +136,7 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 
 	bs.genesisHash = genesisHash
 	bs.lastFinalised = header.Hash()
-	bs.bt = blocktree.NewBlockTreeFromRoot(header)
+	bs.bt = bs.newBlockTree(header)
 	return bs, nil
 }
 
@@ -114,19 +145,22 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 func NewBlockStateFromGenesis(db database.Database, trs *Tries, header *types.Header,
 	telemetryMailer Telemetry) (*BlockState, error) {
 	bs := &BlockState{
-		bt:                         blocktree.NewBlockTreeFromRoot(header),
 		baseState:                  NewBaseState(db),
 		db:                         database.NewTable(db, blockPrefix),
 		unfinalisedBlocks:          newHashToBlockMap(),
 		tries:                      trs,
 		imported:                   make(map[chan *types.Block]struct{}),
+		importSubscriptions:        make(map[*ImportNotifications]struct{}),
+		storageChanges:             make(map[common.Hash]blockStorageChanges),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		pendingRuntimes:            make(map[common.Hash]*pendingRuntime),
 		genesisHash:                header.Hash(),
 		lastFinalised:              header.Hash(),
 		telemetry:                  telemetryMailer,
 		pause:                      make(chan struct{}),
 	}
+	bs.bt = bs.newBlockTree(header)
 
 	if err := bs.setArrivalTime(header.Hash(), time.Now()); err != nil {
 		return nil, err
@@ -217,11 +251,11 @@ func (bs *BlockState) GenesisHash() common.Hash {
 // HasHeader returns true if the hash is part of the unfinalised blocks in-memory or
 // persisted in the database.
 func (bs *BlockState) HasHeader(hash common.Hash) (bool, error) {
-	if bs.unfinalisedBlocks.getBlock(hash) != nil {
-		return true, nil
+	availability, err := bs.GetBlockAvailability(hash)
+	if err != nil {
+		return false, err
 	}
-
-	return bs.db.Has(headerKey(hash))
+	return availability.Has(HeaderAvailable), nil
 }
 
 // HasHeaderInDatabase returns true if the database contains a header with the given hash
@@ -439,7 +473,13 @@ func (bs *BlockState) SetHeader(header *types.Header) error {
 		return err
 	}
 
-	return bs.db.Put(headerKey(header.Hash()), bh)
+	hash := header.Hash()
+	err = bs.db.Put(headerKey(hash), bh)
+	if err != nil {
+		return err
+	}
+
+	return bs.setAvailable(hash, HeaderAvailable)
 }
 
 // HasBlockBody returns true if the db contains the block body
@@ -447,11 +487,11 @@ func (bs *BlockState) HasBlockBody(hash common.Hash) (bool, error) {
 	bs.lock.RLock()
 	defer bs.lock.RUnlock()
 
-	if bs.unfinalisedBlocks.getBlock(hash) != nil {
-		return true, nil
+	availability, err := bs.GetBlockAvailability(hash)
+	if err != nil {
+		return false, err
 	}
-
-	return bs.db.Has(blockBodyKey(hash))
+	return availability.Has(BodyAvailable), nil
 }
 
 // GetBlockBody will return Body for a given hash
@@ -476,7 +516,12 @@ func (bs *BlockState) SetBlockBody(hash common.Hash, body *types.Body) error {
 		return err
 	}
 
-	return bs.db.Put(blockBodyKey(hash), encodedBody)
+	err = bs.db.Put(blockBodyKey(hash), encodedBody)
+	if err != nil {
+		return err
+	}
+
+	return bs.setAvailable(hash, BodyAvailable)
 }
 
 // CompareAndSetBlockData will compare empty fields and set all elements in a block data to db
@@ -551,7 +596,7 @@ func (bs *BlockState) isBlockOnCurrentChain(header *types.Header) (bool, error)
 	return true, nil
 }
 
-// BestBlockHash returns the hash of the head of the current chain
+// BestBlockHash returns the hash of the head of the best chain selected by the fork choice
 func (bs *BlockState) BestBlockHash() common.Hash {
 	if bs.bt == nil {
 		return common.Hash{}
@@ -875,42 +920,55 @@ func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 		logger.Infof(
 			"🔄 detected runtime code change, upgrading with block %s from previous code hash %s and spec %d to new code hash %s and spec %d...", //nolint:lll
 			bHash, parentCodeHash, previousVersion.SpecVersion, currCodeHash, newVersion.SpecVersion)
-	}
-
-	rtCfg := wazero_runtime.Config{
-		Storage:     newState,
-		Keystore:    parentRuntimeInstance.Keystore(),
-		NodeStorage: parentRuntimeInstance.NodeStorage(),
-		Network:     parentRuntimeInstance.NetworkService(),
-		CodeHash:    currCodeHash,
-	}
-
-	if parentRuntimeInstance.Validator() {
-		rtCfg.Role = 4
-	}
-
-	instance, err := wazero_runtime.NewInstance(code, rtCfg)
-	if err != nil {
-		return err
-	}
-
-	bs.StoreRuntime(bHash, instance)
-
+	} else if instance := bs.bt.GetRuntimeByCodeHash(currCodeHash); instance != nil {
+		// the same code is already enacted by a block of another fork,
+		// so its runtime instance is shared rather than compiled again.
+		return bs.shareRuntime(bHash, instance)
+	} else if instance := bs.runtimeCache.remove(currCodeHash); instance != nil {
+		// the code is instantiated for a finalised block, so the instance
+		// is moved from the runtime cache to the block tree.
+		return bs.shareRuntime(bHash, instance)
+	}
+
+	// the code substitution ends with the new code, which is recorded before the
+	// runtime is compiled so a substitution for a later block is not overwritten.
 	err = bs.baseState.StoreCodeSubstitutedBlockHash(common.Hash{})
 	if err != nil {
 		return fmt.Errorf("failed to update code substituted block hash: %w", err)
 	}
 
-	newVersion, err := instance.Version()
-	if err != nil {
-		return err
-	}
-	go bs.notifyRuntimeUpdated(newVersion)
+	rtCfg := runtimeConfig(parentRuntimeInstance, newState, currCodeHash)
+
+	// the new runtime is only needed to build or import the children
+	// of this block, so it is compiled while they are awaited
+	bs.precompileRuntime(bHash, code, rtCfg)
 	return nil
 }
 
 // GetRuntime gets the runtime instance pointer for the block hash given.
+// The runtime of a finalised block no longer in the block tree can be stopped once
+// evicted from the runtime cache, so AcquireRuntime is used to run it.
 func (bs *BlockState) GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error) {
+	instance, release, err := bs.AcquireRuntime(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	release()
+	return instance, nil
+}
+
+// AcquireRuntime gets the runtime instance for the block hash given and the function releasing
+// it, which must be called once the instance is no longer used. The instance is not stopped
+// until it is released, even if it is evicted from the runtime cache in the meantime.
+func (bs *BlockState) AcquireRuntime(blockHash common.Hash) (
+	instance runtime.Instance, release func(), err error) {
+	// a runtime upgraded by the block or one of its ancestors might
+	// still be compiling, in which case we wait for it to be stored
+	err = bs.waitPendingRuntime(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// we search primarily in the blocktree so we ensure the
 	// fork aware property while searching for a runtime, however
 	// if there is no runtimes in that fork then we look for the
@@ -921,17 +979,25 @@ func (bs *BlockState) GetRuntime(blockHash common.Hash) (instance runtime.Instan
 		// in this case the node is not in the blocktree which mean
 		// it is a finalized node already persisted in database
 		if errors.Is(err, blocktree.ErrNodeNotFound) {
-			panic(err.Error() + " see https://github.com/ChainSafe/gossamer/issues/3066")
+			return bs.finalisedRuntime(blockHash)
 		}
 
-		return nil, fmt.Errorf("while getting runtime: %w", err)
+		return nil, nil, fmt.Errorf("while getting runtime: %w", err)
 	}
 
-	return runtimeInstance, nil
+	return runtimeInstance, bs.runtimeCache.acquire(runtimeInstance), nil
 }
 
 // StoreRuntime stores the runtime for corresponding block hash.
+// A runtime still compiling for the block is discarded once compiled.
 func (bs *BlockState) StoreRuntime(hash common.Hash, rt runtime.Instance) {
+	bs.runtimeUpgradesLock.Lock()
+	defer bs.runtimeUpgradesLock.Unlock()
+
+	if pending, ok := bs.pendingRuntimes[hash]; ok {
+		pending.superseded = true
+		delete(bs.pendingRuntimes, hash)
+	}
 	bs.bt.StoreRuntime(hash, rt)
 }
 
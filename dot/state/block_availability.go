@@ -0,0 +1,186 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// BlockAvailability are the flags of the data of a block stored in the database.
+type BlockAvailability byte
+
+const (
+	// HeaderAvailable is set if the header of the block is stored.
+	HeaderAvailable BlockAvailability = 1 << iota
+	// BodyAvailable is set if the body of the block is stored.
+	BodyAvailable
+	// JustificationAvailable is set if the justification of the block is stored.
+	JustificationAvailable
+
+	// FullBlockAvailable is set if the header and the body of the block are stored.
+	FullBlockAvailable = HeaderAvailable | BodyAvailable
+)
+
+// Has returns true if all the flags given are set.
+func (a BlockAvailability) Has(flags BlockAvailability) bool {
+	return a&flags == flags
+}
+
+// availabilityKey = availabilityPrefix + hash
+func availabilityKey(hash common.Hash) []byte {
+	return append(availabilityPrefix, hash.ToBytes()...)
+}
+
+// GetBlockAvailability returns the flags of the data stored for the block hash given.
+// The header and the body of the unfinalised blocks are always available.
+func (bs *BlockState) GetBlockAvailability(hash common.Hash) (availability BlockAvailability, err error) {
+	if bs.unfinalisedBlocks.getBlock(hash) != nil {
+		availability = FullBlockAvailable
+	}
+
+	stored, _, err := bs.storedAvailability(hash)
+	if err != nil {
+		return 0, err
+	}
+	return availability | stored, nil
+}
+
+// BlockAvailabilityRange returns the flags of the data stored for the blocks of the canonical
+// chain numbered from start to end included, the flags of the blocks not known being zero.
+// It determines the data missing for a range of blocks without reading the data.
+func (bs *BlockState) BlockAvailabilityRange(start, end uint) ([]BlockAvailability, error) {
+	if start > end {
+		return nil, fmt.Errorf("%w: start %d is greater than end %d", ErrStartGreaterThanEnd, start, end)
+	}
+
+	availabilities := make([]BlockAvailability, end-start+1)
+	for number := start; number <= end; number++ {
+		hash, err := bs.GetHashByNumber(number)
+		switch {
+		case errors.Is(err, blocktree.ErrNumGreaterThanHighest):
+			return availabilities, nil
+		case errors.Is(err, database.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("getting hash of block %d: %w", number, err)
+		}
+
+		availabilities[number-start], err = bs.GetBlockAvailability(hash)
+		if err != nil {
+			return nil, fmt.Errorf("getting availability of block %d: %w", number, err)
+		}
+	}
+	return availabilities, nil
+}
+
+// storedAvailability returns the flags of the data of the block stored in the database,
+// and whether they are indexed. The flags of the blocks not indexed, which are the blocks
+// stored before the availability index was introduced, are determined from their data.
+func (bs *BlockState) storedAvailability(hash common.Hash) (
+	availability BlockAvailability, indexed bool, err error) {
+	data, err := bs.db.Get(availabilityKey(hash))
+	switch {
+	case err == nil && len(data) == 1:
+		return BlockAvailability(data[0]), true, nil
+	case err != nil && !errors.Is(err, database.ErrNotFound):
+		return 0, false, fmt.Errorf("getting block availability: %w", err)
+	}
+
+	availability, err = unindexedAvailability(bs.db, hash)
+	return availability, false, err
+}
+
+// unindexedAvailability determines the flags of the data of a block stored in the block table
+// given from the presence of the data, their body and justification being only stored along
+// their header.
+func unindexedAvailability(blocks database.Reader, hash common.Hash) (availability BlockAvailability, err error) {
+	hasHeader, err := blocks.Has(headerKey(hash))
+	if err != nil || !hasHeader {
+		return 0, err
+	}
+
+	availability = HeaderAvailable
+	for flag, key := range map[BlockAvailability][]byte{
+		BodyAvailable:          blockBodyKey(hash),
+		JustificationAvailable: prefixKey(hash, justificationPrefix),
+	} {
+		has, err := blocks.Has(key)
+		if err != nil {
+			return 0, err
+		}
+		if has {
+			availability |= flag
+		}
+	}
+	return availability, nil
+}
+
+// indexBlockAvailability is the migration indexing the availability of
+// the blocks stored before the availability index was introduced. The index
+// is flushed in chunks, the blocks already indexed being skipped when retried.
+func indexBlockAvailability(db database.Database, batch database.Batch, progress func(migrated uint64)) error {
+	blocks := database.NewTable(db, blockPrefix)
+	iter, err := blocks.NewPrefixIterator(headerPrefix)
+	if err != nil {
+		return fmt.Errorf("creating headers iterator: %w", err)
+	}
+	defer iter.Release()
+
+	var indexed uint64
+	for iter.First(); iter.Valid(); iter.Next() {
+		hash := common.BytesToHash(iter.Key()[len(headerPrefix):])
+		has, err := blocks.Has(availabilityKey(hash))
+		if err != nil {
+			return fmt.Errorf("checking availability of block %s: %w", hash, err)
+		} else if has {
+			continue
+		}
+
+		availability, err := unindexedAvailability(blocks, hash)
+		if err != nil {
+			return fmt.Errorf("determining availability of block %s: %w", hash, err)
+		}
+
+		key := append([]byte(blockPrefix), availabilityKey(hash)...)
+		err = batch.Put(key, []byte{byte(availability)})
+		if err != nil {
+			return fmt.Errorf("putting availability of block %s: %w", hash, err)
+		}
+		indexed++
+		progress(indexed)
+
+		err = flushMigrationChunk(batch, migrationChunkSize)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setAvailable sets the flags given in the availability of the block hash given,
+// once its data is stored in the database.
+func (bs *BlockState) setAvailable(hash common.Hash, flags BlockAvailability) error {
+	bs.availabilityLock.Lock()
+	defer bs.availabilityLock.Unlock()
+
+	availability, indexed, err := bs.storedAvailability(hash)
+	if err != nil {
+		return err
+	}
+
+	if indexed && availability.Has(flags) {
+		return nil
+	}
+
+	err = bs.db.Put(availabilityKey(hash), []byte{byte(availability | flags)})
+	if err != nil {
+		return fmt.Errorf("setting block availability: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockAvailability_Has(t *testing.T) {
+	t.Parallel()
+
+	availability := HeaderAvailable | JustificationAvailable
+	assert.True(t, availability.Has(HeaderAvailable))
+	assert.True(t, availability.Has(HeaderAvailable|JustificationAvailable))
+	assert.False(t, availability.Has(FullBlockAvailable))
+	assert.True(t, availability.Has(0))
+}
+
+func TestBlockState_GetBlockAvailability(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	header := &types.Header{Number: 1, ParentHash: bs.GenesisHash()}
+	hash := header.Hash()
+
+	availability, err := bs.GetBlockAvailability(hash)
+	require.NoError(t, err)
+	assert.Equal(t, BlockAvailability(0), availability)
+
+	err = bs.SetJustification(hash, []byte{1})
+	require.NoError(t, err)
+	availability, err = bs.GetBlockAvailability(hash)
+	require.NoError(t, err)
+	assert.Equal(t, JustificationAvailable, availability)
+
+	err = bs.SetHeader(header)
+	require.NoError(t, err)
+	err = bs.SetBlockBody(hash, types.NewBody(nil))
+	require.NoError(t, err)
+	availability, err = bs.GetBlockAvailability(hash)
+	require.NoError(t, err)
+	assert.Equal(t, FullBlockAvailable|JustificationAvailable, availability)
+
+	has, err := bs.HasBlockBody(hash)
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = bs.HasJustification(hash)
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestBlockState_GetBlockAvailability_notIndexed(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	header := &types.Header{Number: 1, ParentHash: bs.GenesisHash()}
+	hash := header.Hash()
+
+	// the data of the blocks stored before the index is written without its flags
+	encodedHeader, err := scale.Marshal(*header)
+	require.NoError(t, err)
+	err = bs.db.Put(headerKey(hash), encodedHeader)
+	require.NoError(t, err)
+	err = bs.db.Put(prefixKey(hash, justificationPrefix), []byte{1})
+	require.NoError(t, err)
+
+	availability, err := bs.GetBlockAvailability(hash)
+	require.NoError(t, err)
+	assert.Equal(t, HeaderAvailable|JustificationAvailable, availability)
+
+	err = bs.SetBlockBody(hash, types.NewBody(nil))
+	require.NoError(t, err)
+
+	stored, indexed, err := bs.storedAvailability(hash)
+	require.NoError(t, err)
+	assert.True(t, indexed)
+	assert.Equal(t, FullBlockAvailable|JustificationAvailable, stored)
+}
+
+func TestBlockState_BlockAvailabilityRange(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	chain, _ := AddBlocksToState(t, bs, 3, false)
+
+	err := bs.SetFinalisedHash(chain[1].Hash(), 1, 1)
+	require.NoError(t, err)
+	err = bs.SetJustification(chain[0].Hash(), []byte{1})
+	require.NoError(t, err)
+
+	availabilities, err := bs.BlockAvailabilityRange(1, 5)
+	require.NoError(t, err)
+	expected := []BlockAvailability{
+		FullBlockAvailable | JustificationAvailable,
+		FullBlockAvailable,
+		FullBlockAvailable,
+		0,
+		0,
+	}
+	assert.Equal(t, expected, availabilities)
+
+	_, err = bs.BlockAvailabilityRange(2, 1)
+	assert.ErrorIs(t, err, ErrStartGreaterThanEnd)
+}
+
+func Test_indexBlockAvailability(t *testing.T) {
+	t.Parallel()
+
+	db := NewInMemoryDB(t)
+	blocks := database.NewTable(db, blockPrefix)
+
+	// a block stored before the index with its header and justification
+	notIndexed := common.Hash{1}
+	err := blocks.Put(headerKey(notIndexed), []byte{1})
+	require.NoError(t, err)
+	err = blocks.Put(prefixKey(notIndexed, justificationPrefix), []byte{1})
+	require.NoError(t, err)
+
+	indexed := common.Hash{2}
+	err = blocks.Put(headerKey(indexed), []byte{1})
+	require.NoError(t, err)
+	err = blocks.Put(availabilityKey(indexed), []byte{byte(FullBlockAvailable)})
+	require.NoError(t, err)
+
+	err = runMigrations(db, migrations)
+	require.NoError(t, err)
+
+	for hash, expected := range map[common.Hash]BlockAvailability{
+		notIndexed: HeaderAvailable | JustificationAvailable,
+		indexed:    FullBlockAvailable,
+	} {
+		availability, err := blocks.Get(availabilityKey(hash))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{byte(expected)}, availability)
+	}
+
+	version, err := loadSchemaVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, latestSchemaVersion(migrations), version)
+}
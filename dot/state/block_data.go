@@ -64,7 +64,11 @@ func (bs *BlockState) GetMessageQueue(hash common.Hash) ([]byte, error) {
 
 // HasJustification returns if the db contains a Justification at the given hash
 func (bs *BlockState) HasJustification(hash common.Hash) (bool, error) {
-	return bs.db.Has(prefixKey(hash, justificationPrefix))
+	availability, _, err := bs.storedAvailability(hash)
+	if err != nil {
+		return false, err
+	}
+	return availability.Has(JustificationAvailable), nil
 }
 
 // SetJustification sets a Justification in the database
@@ -74,7 +78,7 @@ func (bs *BlockState) SetJustification(hash common.Hash, data []byte) error {
 		return err
 	}
 
-	return nil
+	return bs.setAvailable(hash, JustificationAvailable)
 }
 
 // GetJustification retrieves a Justification from the database
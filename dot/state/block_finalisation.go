@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -124,6 +125,14 @@ func (bs *BlockState) GetHighestFinalisedHeader() (*types.Header, error) {
 
 // SetFinalisedHash sets the latest finalised block hash
 func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) error {
+	var finalised *events.BlockFinalised
+	// deferred before unlocking, so the event is published once the lock is released
+	defer func() {
+		if finalised != nil {
+			events.Publish(bs.eventBus, *finalised)
+		}
+	}()
+
 	bs.lock.Lock()
 	defer bs.lock.Unlock()
 
@@ -151,19 +160,14 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		return fmt.Errorf("failed to set highest round and set ID: %w", err)
 	}
 
-	if round > 0 {
-		bs.notifyFinalized(hash, round, setID)
-	}
-
 	pruned := bs.bt.Prune(hash)
 	for _, hash := range pruned {
-		blockHeader := bs.unfinalisedBlocks.delete(hash)
-		if blockHeader == nil {
-			continue
-		}
+		hash := hash
+		bs.pins.release(hash, func() { bs.deletePrunedBlock(hash) })
+	}
 
-		bs.tries.delete(blockHeader.StateRoot)
-		logger.Tracef("pruned block number %d with hash %s", blockHeader.Number, hash)
+	if round > 0 {
+		finalised = bs.notifyFinalized(hash, round, setID, pruned)
 	}
 
 	header, err := bs.GetHeader(hash)
@@ -171,6 +175,14 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		return fmt.Errorf("failed to get finalised header, hash: %s, error: %s", hash, err)
 	}
 
+	bs.pruneStorageChanges(header.Number)
+	if bs.storageChangesIndex != nil {
+		err = bs.storageChangesIndex.prune(header.Number)
+		if err != nil {
+			return fmt.Errorf("pruning storage changes index: %w", err)
+		}
+	}
+
 	bs.telemetry.SendMessage(
 		telemetry.NewNotifyFinalized(
 			header.Hash(),
@@ -179,10 +191,13 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	)
 
 	if bs.lastFinalised != hash {
-		err := bs.deleteFromTries(bs.lastFinalised)
-		if err != nil {
-			logger.Debugf("%v", err)
-		}
+		lastFinalised := bs.lastFinalised
+		bs.pins.release(lastFinalised, func() {
+			err := bs.deleteFromTries(lastFinalised)
+			if err != nil {
+				logger.Debugf("%v", err)
+			}
+		})
 	}
 
 	bs.lastFinalised = hash
@@ -194,6 +209,23 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	return nil
 }
 
+// deletePrunedBlock deletes the block pruned with the given hash and its state trie from memory.
+func (bs *BlockState) deletePrunedBlock(hash common.Hash) {
+	blockHeader := bs.unfinalisedBlocks.delete(hash)
+	if blockHeader == nil {
+		return
+	}
+
+	bs.tries.delete(blockHeader.StateRoot)
+	if bs.storageChangesIndex != nil {
+		err := bs.storageChangesIndex.delete(blockHeader.Number, hash)
+		if err != nil {
+			logger.Debugf("%v", err)
+		}
+	}
+	logger.Tracef("pruned block number %d with hash %s", blockHeader.Number, hash)
+}
+
 func (bs *BlockState) deleteFromTries(lastFinalised common.Hash) error {
 	lastFinalisedHeader, err := bs.GetHeader(lastFinalised)
 	if err != nil {
@@ -263,7 +295,8 @@ func (bs *BlockState) handleFinalisedBlock(currentFinalizedHash common.Hash) err
 		// prune all the subchain hashes state tries from memory
 		// but keep the state trie from the current finalized block
 		if currentFinalizedHash != subchainHash {
-			bs.tries.delete(blockHeader.StateRoot)
+			stateRoot := blockHeader.StateRoot
+			bs.pins.release(subchainHash, func() { bs.tries.delete(stateRoot) })
 		}
 
 		logger.Tracef("cleaned out finalised block from memory; block number %d with hash %s",
@@ -7,7 +7,9 @@
 	"errors"
 	"sync"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/google/uuid"
@@ -25,6 +27,164 @@ func (bs *BlockState) GetImportedBlockNotifierChannel() chan *types.Block {
 	return ch
 }
 
+// ImportNotification is the notification of a block imported.
+type ImportNotification struct {
+	Block *types.Block
+	// StorageChanges are the changes of the top level storage keys made by the block.
+	// They are only set for the subscriptions including them, and are nil if the
+	// block was imported without its state being stored.
+	StorageChanges []KeyValue
+}
+
+// ImportNotifications is a subscription to the notifications of the blocks imported.
+type ImportNotifications struct {
+	ch                 chan *ImportNotification
+	withStorageChanges bool
+	blockState         *BlockState
+	once               sync.Once
+}
+
+// SubscribeImportNotifications returns a subscription to the notifications of the blocks
+// imported, including the changes of the storage made by each block if withStorageChanges
+// is true. A notification is dropped if the subscriber does not keep up with the blocks
+// imported, and the subscription must be unsubscribed once it is no longer used.
+func (bs *BlockState) SubscribeImportNotifications(withStorageChanges bool) *ImportNotifications {
+	bs.importedLock.Lock()
+	defer bs.importedLock.Unlock()
+
+	notifications := &ImportNotifications{
+		ch:                 make(chan *ImportNotification, defaultBufferSize),
+		withStorageChanges: withStorageChanges,
+		blockState:         bs,
+	}
+	bs.importSubscriptions[notifications] = struct{}{}
+	return notifications
+}
+
+// Notifications returns the channel the import notifications are sent on.
+func (n *ImportNotifications) Notifications() <-chan *ImportNotification {
+	return n.ch
+}
+
+// Unsubscribe stops the import notifications. It is safe to call more than once.
+func (n *ImportNotifications) Unsubscribe() {
+	n.once.Do(func() {
+		n.blockState.importedLock.Lock()
+		defer n.blockState.importedLock.Unlock()
+		delete(n.blockState.importSubscriptions, n)
+	})
+}
+
+// estimatedBlockSize is the estimated number of bytes of a block held in memory.
+const estimatedBlockSize = 1024
+
+// importedBlockBuffers are the buffers of the imported block channels, which hold the
+// blocks not received yet by the RPC block subscriptions, accounted in the memory budget.
+type importedBlockBuffers struct {
+	blockState *BlockState
+}
+
+// MemoryUsage returns the estimated number of bytes of the buffered imported blocks.
+func (b importedBlockBuffers) MemoryUsage() (usage uint64) {
+	b.blockState.importedLock.RLock()
+	defer b.blockState.importedLock.RUnlock()
+
+	for ch := range b.blockState.imported {
+		usage += uint64(len(ch)) * estimatedBlockSize
+	}
+	return usage
+}
+
+// Shrink drops the oldest buffered imported blocks of each channel, until the estimated
+// number of bytes of the buffered blocks is at most the target given. The subscribers
+// miss the blocks dropped, as if they did not keep up.
+func (b importedBlockBuffers) Shrink(target uint64) {
+	b.blockState.importedLock.RLock()
+	defer b.blockState.importedLock.RUnlock()
+
+	var buffered uint64
+	for ch := range b.blockState.imported {
+		buffered += uint64(len(ch))
+	}
+
+	for ch := range b.blockState.imported {
+		for buffered*estimatedBlockSize > target && dropBlock(ch) {
+			buffered--
+		}
+	}
+}
+
+// dropBlock drops the oldest block of the channel given,
+// returning false if the channel is empty.
+func dropBlock(ch chan *types.Block) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// blockStorageChanges are the storage changes of a block stored.
+type blockStorageChanges struct {
+	number  uint
+	changes []KeyValue
+}
+
+// setStorageChanges keeps the storage changes of the block given until its import
+// is notified, if an import subscription includes the storage changes.
+func (bs *BlockState) setStorageChanges(header *types.Header, changes []KeyValue) {
+	if !bs.hasStorageChangesSubscription() {
+		return
+	}
+
+	bs.storageChangesLock.Lock()
+	defer bs.storageChangesLock.Unlock()
+
+	bs.storageChanges[header.Hash()] = blockStorageChanges{
+		number:  header.Number,
+		changes: changes,
+	}
+}
+
+// takeStorageChanges returns and forgets the storage changes kept for the block hash given.
+func (bs *BlockState) takeStorageChanges(hash common.Hash) []KeyValue {
+	bs.storageChangesLock.Lock()
+	defer bs.storageChangesLock.Unlock()
+
+	blockChanges, ok := bs.storageChanges[hash]
+	if !ok {
+		return nil
+	}
+	delete(bs.storageChanges, hash)
+	return blockChanges.changes
+}
+
+// pruneStorageChanges forgets the storage changes kept for the blocks not imported
+// up to the block number finalised given, since their import cannot be notified.
+func (bs *BlockState) pruneStorageChanges(finalisedNumber uint) {
+	bs.storageChangesLock.Lock()
+	defer bs.storageChangesLock.Unlock()
+
+	for hash, blockChanges := range bs.storageChanges {
+		if blockChanges.number <= finalisedNumber {
+			delete(bs.storageChanges, hash)
+		}
+	}
+}
+
+func (bs *BlockState) hasStorageChangesSubscription() bool {
+	bs.importedLock.RLock()
+	defer bs.importedLock.RUnlock()
+
+	for subscription := range bs.importSubscriptions {
+		if subscription.withStorageChanges {
+			return true
+		}
+	}
+	return false
+}
+
 // GetFinalisedNotifierChannel function to retrieve a finalised block notifier channel
 func (bs *BlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo {
 	bs.finalisedLock.Lock()
@@ -36,6 +196,36 @@ func (bs *BlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 	return ch
 }
 
+// FinalityNotifications is a subscription to the notifications of the blocks finalised.
+type FinalityNotifications struct {
+	ch         chan *types.FinalisationInfo
+	blockState *BlockState
+	once       sync.Once
+}
+
+// SubscribeFinalityNotifications returns a subscription to the notifications of the blocks
+// finalised, carrying their header, justification and the hashes of the forks retracted.
+// A notification is dropped if the subscriber does not keep up with the blocks finalised,
+// and the subscription must be unsubscribed once it is no longer used.
+func (bs *BlockState) SubscribeFinalityNotifications() *FinalityNotifications {
+	return &FinalityNotifications{
+		ch:         bs.GetFinalisedNotifierChannel(),
+		blockState: bs,
+	}
+}
+
+// Notifications returns the channel the finality notifications are sent on.
+func (n *FinalityNotifications) Notifications() <-chan *types.FinalisationInfo {
+	return n.ch
+}
+
+// Unsubscribe stops the finality notifications. It is safe to call more than once.
+func (n *FinalityNotifications) Unsubscribe() {
+	n.once.Do(func() {
+		n.blockState.FreeFinalisedNotifierChannel(n.ch)
+	})
+}
+
 // FreeImportedBlockNotifierChannel to free imported block notifier channel
 func (bs *BlockState) FreeImportedBlockNotifierChannel(ch chan *types.Block) {
 	bs.importedLock.Lock()
@@ -52,10 +242,12 @@ func (bs *BlockState) FreeFinalisedNotifierChannel(ch chan *types.FinalisationIn
 }
 
 func (bs *BlockState) notifyImported(block *types.Block) {
+	storageChanges := bs.takeStorageChanges(block.Header.Hash())
+
 	bs.importedLock.RLock()
 	defer bs.importedLock.RUnlock()
 
-	if len(bs.imported) == 0 {
+	if len(bs.imported) == 0 && len(bs.importSubscriptions) == 0 {
 		return
 	}
 
@@ -68,27 +260,56 @@ func (bs *BlockState) notifyImported(block *types.Block) {
 			}
 		}(ch)
 	}
+
+	notification := &ImportNotification{Block: block}
+	notificationWithChanges := &ImportNotification{Block: block, StorageChanges: storageChanges}
+	for subscription := range bs.importSubscriptions {
+		notification := notification
+		if subscription.withStorageChanges {
+			notification = notificationWithChanges
+		}
+
+		go func(ch chan *ImportNotification) {
+			select {
+			case ch <- notification:
+			default:
+			}
+		}(subscription.ch)
+	}
 }
 
-func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64) {
+// notifyFinalized notifies the finalised block channels of the block finalised, and
+// returns its finalisation info to publish on the event bus, or nil if it has no subscriber.
+// The event must be published once the block state lock is released, since the blocking
+// subscribers may need the block state to receive the previous events.
+func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64,
+	retracted []common.Hash) (event *events.BlockFinalised) {
 	bs.finalisedLock.RLock()
 	defer bs.finalisedLock.RUnlock()
 
-	if len(bs.finalised) == 0 {
-		return
+	hasSubscribers := events.HasSubscribers[events.BlockFinalised](bs.eventBus)
+	if len(bs.finalised) == 0 && !hasSubscribers {
+		return nil
 	}
 
 	header, err := bs.GetHeader(hash)
 	if err != nil {
 		logger.Errorf("failed to get finalised header for hash %s: %s", hash, err)
-		return
+		return nil
+	}
+
+	justification, err := bs.GetJustification(hash)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		logger.Errorf("failed to get justification for finalised block %s: %s", hash, err)
 	}
 
 	logger.Debug("notifying finalised block channels...")
 	info := &types.FinalisationInfo{
-		Header: *header,
-		Round:  round,
-		SetID:  setID,
+		Header:        *header,
+		Round:         round,
+		SetID:         setID,
+		Justification: justification,
+		Retracted:     retracted,
 	}
 
 	for ch := range bs.finalised {
@@ -99,6 +320,11 @@ func (bs *BlockState) notifyFinalized(hash common.Hash, round, setID uint64) {
 			}
 		}(ch)
 	}
+
+	if !hasSubscribers {
+		return nil
+	}
+	return &events.BlockFinalised{Info: info}
 }
 
 func (bs *BlockState) notifyRuntimeUpdated(version runtime.Version) {
@@ -9,7 +9,9 @@
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/stretchr/testify/require"
 )
 
@@ -63,6 +65,159 @@ func TestFinalizedChannel(t *testing.T) {
 	}
 }
 
+func TestBlockState_SubscribeFinalityNotifications(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	notifications := bs.SubscribeFinalityNotifications()
+	require.Len(t, bs.finalised, 1)
+
+	chain, _ := AddBlocksToState(t, bs, 2, false)
+
+	preRuntimeDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 1).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	err = digest.Add(*preRuntimeDigest)
+	require.NoError(t, err)
+	forkBlock := &types.Block{
+		Header: types.Header{
+			ParentHash: bs.GenesisHash(),
+			Number:     1,
+			StateRoot:  trie.EmptyHash,
+			Digest:     digest,
+		},
+		Body: types.Body{},
+	}
+	err = bs.AddBlock(forkBlock)
+	require.NoError(t, err)
+
+	justification := []byte{1, 2, 3}
+	err = bs.SetJustification(chain[1].Hash(), justification)
+	require.NoError(t, err)
+
+	err = bs.SetFinalisedHash(chain[1].Hash(), 1, 0)
+	require.NoError(t, err)
+
+	select {
+	case info := <-notifications.Notifications():
+		expected := &types.FinalisationInfo{
+			Header:        *chain[1],
+			Round:         1,
+			SetID:         0,
+			Justification: justification,
+			Retracted:     []common.Hash{forkBlock.Header.Hash()},
+		}
+		require.Equal(t, expected, info)
+	case <-time.After(testMessageTimeout):
+		t.Fatal("did not receive finality notification")
+	}
+
+	notifications.Unsubscribe()
+	notifications.Unsubscribe()
+	require.Empty(t, bs.finalised)
+}
+
+func TestBlockState_SubscribeImportNotifications(t *testing.T) {
+	ss := newTestStorageState(t)
+	bs := ss.blockState
+
+	withChanges := bs.SubscribeImportNotifications(true)
+	defer withChanges.Unsubscribe()
+	withoutChanges := bs.SubscribeImportNotifications(false)
+	withoutChanges.Unsubscribe()
+	withoutChanges = bs.SubscribeImportNotifications(false)
+	defer withoutChanges.Unsubscribe()
+	require.Len(t, bs.importSubscriptions, 2)
+
+	ts, err := ss.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+	err = ts.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	preRuntimeDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 1).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	err = digest.Add(*preRuntimeDigest)
+	require.NoError(t, err)
+	block := &types.Block{
+		Header: types.Header{
+			ParentHash: bs.GenesisHash(),
+			Number:     1,
+			StateRoot:  ts.MustRoot(),
+			Digest:     digest,
+		},
+		Body: types.Body{},
+	}
+
+	err = ss.StoreTrie(ts, &block.Header)
+	require.NoError(t, err)
+	err = bs.AddBlock(block)
+	require.NoError(t, err)
+
+	expectedWithChanges := &ImportNotification{
+		Block:          block,
+		StorageChanges: []KeyValue{{Key: []byte("key"), Value: []byte("value")}},
+	}
+	expectedWithoutChanges := &ImportNotification{Block: block}
+
+	for _, testCase := range []struct {
+		notifications *ImportNotifications
+		expected      *ImportNotification
+	}{
+		{notifications: withChanges, expected: expectedWithChanges},
+		{notifications: withoutChanges, expected: expectedWithoutChanges},
+	} {
+		select {
+		case notification := <-testCase.notifications.Notifications():
+			require.Equal(t, testCase.expected, notification)
+		case <-time.After(testMessageTimeout):
+			t.Fatal("did not receive import notification")
+		}
+	}
+
+	// the storage changes are only kept until the block import is notified
+	require.Empty(t, bs.storageChanges)
+}
+
+func TestBlockState_pruneStorageChanges(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	notifications := bs.SubscribeImportNotifications(true)
+	defer notifications.Unsubscribe()
+
+	changes := []KeyValue{{Key: []byte("key")}}
+	bs.setStorageChanges(&types.Header{Number: 1}, changes)
+	bs.setStorageChanges(&types.Header{Number: 2}, changes)
+	bs.setStorageChanges(&types.Header{Number: 3}, changes)
+
+	bs.pruneStorageChanges(2)
+
+	expected := map[common.Hash]blockStorageChanges{
+		(&types.Header{Number: 3}).Hash(): {number: 3, changes: changes},
+	}
+	require.Equal(t, expected, bs.storageChanges)
+}
+
+func Test_importedBlockBuffers_Shrink(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	first := bs.GetImportedBlockNotifierChannel()
+	defer bs.FreeImportedBlockNotifierChannel(first)
+	second := bs.GetImportedBlockNotifierChannel()
+	defer bs.FreeImportedBlockNotifierChannel(second)
+
+	for i := uint(1); i <= 3; i++ {
+		first <- &types.Block{Header: types.Header{Number: i}}
+	}
+	second <- &types.Block{}
+
+	buffers := importedBlockBuffers{blockState: bs}
+	require.Equal(t, uint64(4*estimatedBlockSize), buffers.MemoryUsage())
+
+	buffers.Shrink(2 * estimatedBlockSize)
+	require.Equal(t, uint64(2*estimatedBlockSize), buffers.MemoryUsage())
+
+	buffers.Shrink(0)
+	require.Zero(t, buffers.MemoryUsage())
+}
+
 func TestImportChannel_Multi(t *testing.T) {
 	bs := newTestBlockState(t, newTriesEmpty())
 
@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// pinnedBlocks counts the references held on the blocks pinned, and holds back the
+// release of their data from memory, such as their state trie, until they are unpinned.
+// Its zero value is ready to use.
+type pinnedBlocks struct {
+	mutex sync.Mutex
+	// references is the number of references held on each pinned block.
+	references map[common.Hash]uint
+	// releases are the releases of the pinned blocks data held back until they are unpinned.
+	releases map[common.Hash][]func()
+}
+
+func (p *pinnedBlocks) pin(hash common.Hash) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.references == nil {
+		p.references = make(map[common.Hash]uint)
+	}
+	p.references[hash]++
+}
+
+// unpin removes a reference on the block given, running the releases held
+// back for the block once its last reference is removed. It returns false
+// if the block is not pinned.
+func (p *pinnedBlocks) unpin(hash common.Hash) (unpinned bool) {
+	p.mutex.Lock()
+	references, ok := p.references[hash]
+	if !ok {
+		p.mutex.Unlock()
+		return false
+	}
+
+	var releases []func()
+	if references > 1 {
+		p.references[hash] = references - 1
+	} else {
+		delete(p.references, hash)
+		releases = p.releases[hash]
+		delete(p.releases, hash)
+	}
+	p.mutex.Unlock()
+
+	for _, release := range releases {
+		release()
+	}
+	return true
+}
+
+// release runs the release of the data of the block given,
+// or holds it back until the block is unpinned if it is pinned.
+func (p *pinnedBlocks) release(hash common.Hash, release func()) {
+	p.mutex.Lock()
+	if _, pinned := p.references[hash]; pinned {
+		if p.releases == nil {
+			p.releases = make(map[common.Hash][]func())
+		}
+		p.releases[hash] = append(p.releases[hash], release)
+		p.mutex.Unlock()
+		return
+	}
+	p.mutex.Unlock()
+
+	release()
+}
+
+// count returns the number of blocks pinned.
+func (p *pinnedBlocks) count() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.references)
+}
+
+// PinBlock adds a reference on the block with the given hash, preventing the finalisation
+// from pruning the block and its state trie from memory while the block is pinned.
+// A pinned block can still be read once it is pruned from the block tree, until
+// UnpinBlock is called as many times as PinBlock was for it.
+// Pins only hold the in-memory tries, the state stored in the database is never
+// pruned online since only the archive pruner is supported.
+func (bs *BlockState) PinBlock(hash common.Hash) error {
+	// the lock is held so the block cannot be pruned between the check and the pin.
+	bs.lock.RLock()
+	defer bs.lock.RUnlock()
+
+	has, err := bs.HasHeader(hash)
+	if err != nil {
+		return fmt.Errorf("checking header of block %s: %w", hash, err)
+	}
+	if !has {
+		return fmt.Errorf("pinning block %s: %w", hash, database.ErrNotFound)
+	}
+
+	bs.pins.pin(hash)
+	return nil
+}
+
+// UnpinBlock removes a reference added by PinBlock on the block with the given hash,
+// releasing the block pruned while it was pinned once its last reference is removed.
+// It returns false if the block is not pinned.
+func (bs *BlockState) UnpinBlock(hash common.Hash) (unpinned bool) {
+	return bs.pins.unpin(hash)
+}
+
+// PinnedBlocks returns the number of blocks currently pinned.
+func (bs *BlockState) PinnedBlocks() int {
+	return bs.pins.count()
+}
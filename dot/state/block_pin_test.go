@@ -0,0 +1,55 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_PinBlock(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	genesisHash := bs.GenesisHash()
+
+	err := bs.PinBlock(common.Hash{1})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	assert.False(t, bs.UnpinBlock(common.Hash{1}))
+
+	finalised := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), genesisHash)
+	fork := AddBlockToState(t, bs, 1, createSecondaryPlainBABEDigest(t), genesisHash)
+
+	// the fork is pinned twice, by two readers
+	for i := 0; i < 2; i++ {
+		err = bs.PinBlock(fork.Hash())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, bs.PinnedBlocks())
+
+	err = bs.SetFinalisedHash(finalised.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	// the pinned fork is pruned from the block tree but can still be read
+	_, err = bs.bt.GetArrivalTime(fork.Hash())
+	assert.ErrorIs(t, err, blocktree.ErrNodeNotFound)
+	header, err := bs.GetHeader(fork.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, fork, header)
+
+	assert.True(t, bs.UnpinBlock(fork.Hash()))
+	_, err = bs.GetHeader(fork.Hash())
+	require.NoError(t, err)
+
+	// the fork is released once its last reference is removed
+	assert.True(t, bs.UnpinBlock(fork.Hash()))
+	assert.Zero(t, bs.PinnedBlocks())
+	_, err = bs.GetHeader(fork.Hash())
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// GetHeadersInRange returns the headers of the blocks of the canonical chain numbered
+// from start to end included, stopping at the best block if end is greater than its number.
+func (bs *BlockState) GetHeadersInRange(start, end uint) ([]*types.Header, error) {
+	hashes, err := bs.canonicalHashesInRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*types.Header, len(hashes))
+	for i, hash := range hashes {
+		headers[i], err = bs.GetHeader(hash)
+		if err != nil {
+			return nil, fmt.Errorf("getting header of block %d: %w", start+uint(i), err)
+		}
+	}
+	return headers, nil
+}
+
+// GetBlockDatasInRange returns the block data of the blocks of the canonical chain numbered
+// from start to end included, stopping at the best block if end is greater than its number.
+// The header, body and justification of the blocks are set if their flag is in the data
+// requested and they are stored, the other fields of the block data being left empty.
+func (bs *BlockState) GetBlockDatasInRange(start, end uint, requested BlockAvailability) (
+	[]*types.BlockData, error) {
+	hashes, err := bs.canonicalHashesInRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	blockDatas := make([]*types.BlockData, len(hashes))
+	for i, hash := range hashes {
+		blockDatas[i], err = bs.getBlockData(hash, requested)
+		if err != nil {
+			return nil, fmt.Errorf("getting data of block %d: %w", start+uint(i), err)
+		}
+	}
+	return blockDatas, nil
+}
+
+func (bs *BlockState) getBlockData(hash common.Hash, requested BlockAvailability) (
+	blockData *types.BlockData, err error) {
+	blockData = &types.BlockData{Hash: hash}
+
+	if requested.Has(HeaderAvailable) {
+		blockData.Header, err = bs.GetHeader(hash)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("getting header: %w", err)
+		}
+	}
+
+	if requested.Has(BodyAvailable) {
+		blockData.Body, err = bs.GetBlockBody(hash)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("getting body: %w", err)
+		}
+	}
+
+	if requested.Has(JustificationAvailable) {
+		justification, err := bs.GetJustification(hash)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("getting justification: %w", err)
+		}
+		if justification != nil {
+			blockData.Justification = &justification
+		}
+	}
+
+	return blockData, nil
+}
+
+// canonicalHashesInRange returns the hashes of the blocks of the canonical chain numbered
+// from start to end included, stopping at the best block if end is greater than its number.
+// The hashes of the finalised blocks are read with a single iteration over the block
+// number index, and the hashes of the unfinalised blocks from the blocktree.
+func (bs *BlockState) canonicalHashesInRange(start, end uint) ([]common.Hash, error) {
+	if start > end {
+		return nil, fmt.Errorf("%w: start %d is greater than end %d", ErrStartGreaterThanEnd, start, end)
+	}
+
+	hashes, err := bs.finalisedHashesInRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for number := start + uint(len(hashes)); number <= end; number++ {
+		hash, err := bs.bt.GetHashByNumber(number)
+		switch {
+		case errors.Is(err, blocktree.ErrNumGreaterThanHighest):
+			return hashes, nil
+		case errors.Is(err, blocktree.ErrNumLowerThanRoot):
+			// the number is finalised but missing from the block number index
+			return nil, fmt.Errorf("cannot get block %d: %w", number, database.ErrNotFound)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get hash from blocktree: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// finalisedHashesInRange returns the hashes of the finalised blocks numbered from start
+// to end included, stopping before the first number missing from the block number index.
+func (bs *BlockState) finalisedHashesInRange(start, end uint) (hashes []common.Hash, err error) {
+	iter, err := bs.db.NewPrefixIterator(headerHashPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("creating block number index iterator: %w", err)
+	}
+	defer iter.Release()
+
+	for valid := iter.SeekGE(headerHashKey(uint64(start))); valid; valid = iter.Next() {
+		number := uint(binary.BigEndian.Uint64(iter.Key()[len(headerHashPrefix):]))
+		if number > end || number != start+uint(len(hashes)) {
+			break
+		}
+		hashes = append(hashes, common.NewHash(iter.Value()))
+	}
+	return hashes, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_GetHeadersInRange(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	chain, _ := AddBlocksToState(t, bs, 4, false)
+
+	// blocks 1 and 2 are read from the database and blocks 3 and 4 from the blocktree
+	err := bs.SetFinalisedHash(chain[1].Hash(), 1, 1)
+	require.NoError(t, err)
+
+	headers, err := bs.GetHeadersInRange(1, 6)
+	require.NoError(t, err)
+	require.Len(t, headers, 4)
+	for i, header := range headers {
+		assert.Equal(t, chain[i].Hash(), header.Hash())
+	}
+
+	headers, err = bs.GetHeadersInRange(0, 0)
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+	assert.Equal(t, bs.GenesisHash(), headers[0].Hash())
+
+	_, err = bs.GetHeadersInRange(2, 1)
+	assert.ErrorIs(t, err, ErrStartGreaterThanEnd)
+
+	err = bs.db.Del(headerHashKey(1))
+	require.NoError(t, err)
+	_, err = bs.GetHeadersInRange(0, 3)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func TestBlockState_GetBlockDatasInRange(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+	chain, _ := AddBlocksToState(t, bs, 3, false)
+
+	err := bs.SetFinalisedHash(chain[0].Hash(), 1, 1)
+	require.NoError(t, err)
+	err = bs.SetJustification(chain[0].Hash(), []byte{1})
+	require.NoError(t, err)
+
+	blockDatas, err := bs.GetBlockDatasInRange(1, 2, HeaderAvailable|JustificationAvailable)
+	require.NoError(t, err)
+	expected := []*types.BlockData{
+		{Hash: chain[0].Hash(), Header: chain[0], Justification: &[]byte{1}},
+		{Hash: chain[1].Hash(), Header: chain[1]},
+	}
+	assert.Equal(t, expected, blockDatas)
+
+	blockDatas, err = bs.GetBlockDatasInRange(3, 3, BodyAvailable)
+	require.NoError(t, err)
+	expected = []*types.BlockData{
+		{Hash: chain[2].Hash(), Body: types.NewBody([]types.Extrinsic{})},
+	}
+	assert.Equal(t, expected, blockDatas)
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// ForkChoice selects the best chain among the unfinalised chains of the block state. The
+// best block queries, such as BestBlockHash or GetHashByNumber, are answered from the chain
+// it selects, so the blocks are authored and synced on top of it.
+type ForkChoice interface {
+	// BestChain returns the hash of the head of the best chain among
+	// the chain heads given, which contain at least two heads.
+	BestChain(heads []blocktree.ChainHead) common.Hash
+}
+
+// BABEForkChoice is the fork choice of BABE, selecting the longest chain weighted by
+// the number of blocks authored in primary slots, as described by blocktree.MostPrimaryBlocks.
+type BABEForkChoice struct{}
+
+// BestChain returns the head of the chain with the most primary blocks.
+func (BABEForkChoice) BestChain(heads []blocktree.ChainHead) common.Hash {
+	return blocktree.MostPrimaryBlocks(heads)
+}
+
+// SetForkChoice sets the fork choice selecting the best chain, the BABE fork choice being used by default.
+func (bs *BlockState) SetForkChoice(forkChoice ForkChoice) {
+	bs.forkChoice = forkChoice
+	bs.bt.SetChainSelection(forkChoice.BestChain)
+}
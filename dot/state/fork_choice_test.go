@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type forkChoiceFunc func(heads []blocktree.ChainHead) common.Hash
+
+func (f forkChoiceFunc) BestChain(heads []blocktree.ChainHead) common.Hash {
+	return f(heads)
+}
+
+func TestBlockState_SetForkChoice(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	genesisHash := bs.GenesisHash()
+
+	// the secondary block is imported first, so it would be selected
+	// if the primary blocks were not weighted by the fork choice.
+	secondary := AddBlockToState(t, bs, 1, createSecondaryPlainBABEDigest(t), genesisHash)
+	primary := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), genesisHash)
+
+	assert.Equal(t, primary.Hash(), bs.BestBlockHash())
+
+	bs.SetForkChoice(forkChoiceFunc(func(heads []blocktree.ChainHead) common.Hash {
+		require.Len(t, heads, 2)
+		for _, head := range heads {
+			if head.PrimaryBlocks == 0 {
+				return head.Hash
+			}
+		}
+		return common.Hash{}
+	}))
+
+	assert.Equal(t, secondary.Hash(), bs.BestBlockHash())
+	hash, err := bs.GetHashByNumber(1)
+	require.NoError(t, err)
+	assert.Equal(t, secondary.Hash(), hash)
+}
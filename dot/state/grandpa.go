@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -23,6 +24,9 @@
 	errUnfinalizedAncestor     = errors.New("unfinalized ancestor")
 
 	ErrNoNextAuthorityChange = errors.New("no next authority change")
+	// ErrSetNotEnded is returned when the last block of a set id is requested
+	// while the set is still the current set.
+	ErrSetNotEnded = errors.New("authority set not ended")
 )
 
 var (
@@ -43,6 +47,8 @@ type GrandpaState struct {
 	forcedChanges        *orderedPendingChanges
 	scheduledChangeRoots *changeTree
 	telemetry            Telemetry
+
+	ownVotesLock sync.Mutex
 }
 
 // NewGrandpaStateFromGenesis returns a new GrandpaState given the grandpa genesis authorities
@@ -450,49 +456,63 @@ func (s *GrandpaState) GetSetIDChange(setID uint64) (blockNumber uint, err error
 	return common.BytesToUint(num), nil
 }
 
-// GetSetIDByBlockNumber returns the set ID for a given block number
+// GetSetEndBlockNumber returns the number of the last block finalised by the set id given,
+// which is the block whose justification proves the finality of the blocks of the set.
+// It returns ErrSetNotEnded if the set id given is the current set id or a later one.
+func (s *GrandpaState) GetSetEndBlockNumber(setID uint64) (blockNumber uint, err error) {
+	currentSetID, err := s.GetCurrentSetID()
+	if err != nil {
+		return 0, fmt.Errorf("getting current set id: %w", err)
+	} else if setID >= currentSetID {
+		return 0, fmt.Errorf("%w: set id %d, current set id %d", ErrSetNotEnded, setID, currentSetID)
+	}
+
+	// the set id change of the next set is stored at the last block of the set
+	blockNumber, err = s.GetSetIDChange(setID + 1)
+	if err != nil {
+		return 0, fmt.Errorf("getting change of set id %d: %w", setID+1, err)
+	}
+	return blockNumber, nil
+}
+
+// GetSetIDByBlockNumber returns the set ID for a given block number, including the next
+// set ID if its change is scheduled. It searches the last blocks of the ended sets given by
+// GetSetEndBlockNumber, so the set ID of an old block is found without going through every
+// set ID change since then.
 func (s *GrandpaState) GetSetIDByBlockNumber(blockNumber uint) (uint64, error) {
 	curr, err := s.GetCurrentSetID()
 	if err != nil {
 		return 0, err
 	}
 
-	for {
-		changeUpper, err := s.GetSetIDChange(curr + 1)
-		if errors.Is(err, database.ErrNotFound) {
-			if curr == 0 {
-				return 0, nil
-			}
-			curr = curr - 1
-			continue
-		} else if err != nil {
-			return 0, err
-		}
+	// the current set has not ended yet, but its last block is known once the
+	// change to the next set is scheduled
+	scheduledChange, err := s.GetSetIDChange(curr + 1)
+	if err == nil && blockNumber > scheduledChange {
+		return curr + 1, nil
+	} else if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return 0, err
+	}
 
-		changeLower, err := s.GetSetIDChange(curr)
+	// The block numbers of the last blocks of the sets increase with the set ids.
+	// So a block belongs to the highest set id whose previous set ended at a lower
+	// block number.
+	lowest, highest := uint64(0), curr
+	for lowest < highest {
+		middle := lowest + (highest-lowest+1)/2
+		previousSetEnd, err := s.GetSetEndBlockNumber(middle - 1)
 		if err != nil {
-			return 0, err
-		}
-
-		// Set id changes at the last block in the set. So, block (changeLower) at which current
-		// set id was set, does not belong to current set. Thus, all block numbers in given set
-		// would be more than changeLower.
-		// Next set id change happens at the last block of current set. Thus, a block number from
-		// given set could be lower or equal to changeUpper.
-		if blockNumber <= changeUpper && blockNumber > changeLower {
-			return curr, nil
+			return 0, fmt.Errorf("getting end of set id %d: %w", middle-1, err)
 		}
 
-		if blockNumber > changeUpper {
-			return curr + 1, nil
-		}
-
-		curr = curr - 1
-
-		if int(curr) < 0 {
-			return 0, nil
+		if blockNumber > previousSetEnd {
+			lowest = middle
+		} else {
+			highest = middle - 1
 		}
 	}
+
+	return lowest, nil
 }
 
 // SetNextPause sets the next grandpa pause at the given block number
@@ -0,0 +1,105 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var ownVotesKey = []byte("ownvotes") // ownVotesKey -> scale encoded ownVotes
+
+// ownVotes are the votes we cast in a round. Only the votes of the latest round
+// we voted in are kept, since the votes of the rounds before cannot be cast again.
+type ownVotes struct {
+	Round uint64
+	SetID uint64
+	Votes []ownVote
+}
+
+type ownVote struct {
+	Stage byte
+	Vote  types.GrandpaSignedVote
+}
+
+// SetOwnVote persists the vote we cast for the stage of a specific round and set ID,
+// replacing the votes persisted for a previous round.
+func (s *GrandpaState) SetOwnVote(round, setID uint64, stage byte, vote types.GrandpaSignedVote) error {
+	s.ownVotesLock.Lock()
+	defer s.ownVotesLock.Unlock()
+
+	votes, err := s.loadOwnVotes()
+	if err != nil {
+		return err
+	}
+
+	if votes.Round != round || votes.SetID != setID {
+		votes = ownVotes{Round: round, SetID: setID}
+	}
+
+	replaced := false
+	for i := range votes.Votes {
+		if votes.Votes[i].Stage == stage {
+			votes.Votes[i].Vote = vote
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		votes.Votes = append(votes.Votes, ownVote{Stage: stage, Vote: vote})
+	}
+
+	encoded, err := scale.Marshal(votes)
+	if err != nil {
+		return fmt.Errorf("encoding own votes: %w", err)
+	}
+
+	err = s.db.Put(ownVotesKey, encoded)
+	if err != nil {
+		return fmt.Errorf("storing own votes: %w", err)
+	}
+	return nil
+}
+
+// GetOwnVote returns the vote we cast for the stage of a specific
+// round and set ID, or nil if we did not cast any.
+func (s *GrandpaState) GetOwnVote(round, setID uint64, stage byte) (*types.GrandpaSignedVote, error) {
+	s.ownVotesLock.Lock()
+	defer s.ownVotesLock.Unlock()
+
+	votes, err := s.loadOwnVotes()
+	if err != nil {
+		return nil, err
+	}
+
+	if votes.Round != round || votes.SetID != setID {
+		return nil, nil
+	}
+
+	for _, vote := range votes.Votes {
+		if vote.Stage == stage {
+			return &vote.Vote, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *GrandpaState) loadOwnVotes() (votes ownVotes, err error) {
+	encoded, err := s.db.Get(ownVotesKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return votes, nil
+	} else if err != nil {
+		return votes, fmt.Errorf("getting own votes: %w", err)
+	}
+
+	err = scale.Unmarshal(encoded, &votes)
+	if err != nil {
+		return votes, fmt.Errorf("decoding own votes: %w", err)
+	}
+	return votes, nil
+}
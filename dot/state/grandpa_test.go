@@ -107,6 +107,38 @@ func TestGrandpaState_GetSetIDByBlockNumber(t *testing.T) {
 	require.Equal(t, genesisSetID+1, newSetID)
 }
 
+func TestGrandpaState_GetSetIDByBlockNumber_historical(t *testing.T) {
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
+	require.NoError(t, err)
+
+	for _, change := range []uint{10, 20, 30, 40} {
+		err = gs.SetNextChange(testAuths, change)
+		require.NoError(t, err)
+		_, err = gs.IncrementSetID()
+		require.NoError(t, err)
+	}
+	err = gs.SetNextChange(testAuths, 50)
+	require.NoError(t, err)
+
+	testCases := map[uint]uint64{
+		0:  genesisSetID,
+		10: genesisSetID,
+		11: genesisSetID + 1,
+		25: genesisSetID + 2,
+		31: genesisSetID + 3,
+		40: genesisSetID + 3,
+		41: genesisSetID + 4,
+		50: genesisSetID + 4,
+		51: genesisSetID + 5,
+	}
+	for blockNumber, expectedSetID := range testCases {
+		setID, err := gs.GetSetIDByBlockNumber(blockNumber)
+		require.NoError(t, err)
+		require.Equalf(t, expectedSetID, setID, "block number %d", blockNumber)
+	}
+}
+
 func TestGrandpaState_LatestRound(t *testing.T) {
 	db := NewInMemoryDB(t)
 	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
@@ -124,6 +156,67 @@ func TestGrandpaState_LatestRound(t *testing.T) {
 	require.Equal(t, uint64(99), r)
 }
 
+func TestGrandpaState_OwnVotes(t *testing.T) {
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
+	require.NoError(t, err)
+
+	const prevote, precommit = byte(0), byte(1)
+	newVote := func(hash common.Hash, number uint32) types.GrandpaSignedVote {
+		return types.GrandpaSignedVote{
+			Vote:        types.GrandpaVote{Hash: hash, Number: number},
+			AuthorityID: testAuths[0].Key.AsBytes(),
+		}
+	}
+
+	vote, err := gs.GetOwnVote(1, 0, prevote)
+	require.NoError(t, err)
+	require.Nil(t, vote)
+
+	firstPrevote := newVote(common.Hash{1}, 1)
+	err = gs.SetOwnVote(1, 0, prevote, firstPrevote)
+	require.NoError(t, err)
+	firstPrecommit := newVote(common.Hash{2}, 2)
+	err = gs.SetOwnVote(1, 0, precommit, firstPrecommit)
+	require.NoError(t, err)
+
+	// the votes are kept across a restart
+	gs = NewGrandpaState(db, nil, nil)
+
+	vote, err = gs.GetOwnVote(1, 0, prevote)
+	require.NoError(t, err)
+	require.Equal(t, &firstPrevote, vote)
+	vote, err = gs.GetOwnVote(1, 0, precommit)
+	require.NoError(t, err)
+	require.Equal(t, &firstPrecommit, vote)
+
+	vote, err = gs.GetOwnVote(1, 1, prevote)
+	require.NoError(t, err)
+	require.Nil(t, vote)
+
+	replacedPrecommit := newVote(common.Hash{3}, 3)
+	err = gs.SetOwnVote(1, 0, precommit, replacedPrecommit)
+	require.NoError(t, err)
+	vote, err = gs.GetOwnVote(1, 0, precommit)
+	require.NoError(t, err)
+	require.Equal(t, &replacedPrecommit, vote)
+
+	// voting in the next round drops the votes of the previous round
+	secondPrevote := newVote(common.Hash{4}, 4)
+	err = gs.SetOwnVote(2, 0, prevote, secondPrevote)
+	require.NoError(t, err)
+
+	vote, err = gs.GetOwnVote(1, 0, prevote)
+	require.NoError(t, err)
+	require.Nil(t, vote)
+	vote, err = gs.GetOwnVote(2, 0, prevote)
+	require.NoError(t, err)
+	require.Equal(t, &secondPrevote, vote)
+	vote, err = gs.GetOwnVote(2, 0, precommit)
+	require.NoError(t, err)
+	require.Nil(t, vote)
+}
+
 func testBlockState(t *testing.T, db database.Database) *BlockState {
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
@@ -1446,6 +1539,15 @@ func TestApplyScheduledChange(t *testing.T) {
 			blockNumber, err := gs.GetSetIDChange(currentSetID)
 			require.NoError(t, err)
 			require.Equal(t, tt.changeSetIDAt, blockNumber)
+
+			if currentSetID != genesisSetID {
+				endNumber, err := gs.GetSetEndBlockNumber(currentSetID - 1)
+				require.NoError(t, err)
+				require.Equal(t, tt.changeSetIDAt, endNumber)
+			}
+
+			_, err = gs.GetSetEndBlockNumber(currentSetID)
+			require.ErrorIs(t, err, ErrSetNotEnded)
 		})
 	}
 }
@@ -34,7 +34,7 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t trie.
 	}
 
 	// initialise database using data directory
-	db, err := database.LoadDatabase(basepath, s.isMemDB)
+	db, err := s.loadDatabase(basepath, s.isMemDB)
 	if err != nil {
 		return fmt.Errorf("failed to create database: %s", err)
 	}
@@ -104,6 +104,12 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t trie.
 		s.Epoch = epochState
 		s.Grandpa = grandpaState
 		s.Slot = NewSlotState(db)
+		if s.forkChoice != nil {
+			s.Block.SetForkChoice(s.forkChoice)
+		}
+		if s.storageChanges.Enabled {
+			s.Block.SetStorageChangesIndex(db, s.storageChanges.RetainedBlocks)
+		}
 	} else if err = db.Close(); err != nil {
 		return fmt.Errorf("failed to close database: %s", err)
 	}
@@ -152,6 +158,11 @@ func (s *Service) storeInitialValues(data *genesis.Data, t trie.Trie) error {
 		return fmt.Errorf("failed to write genesis data to database: %s", err)
 	}
 
+	// a new database uses the latest storage format and needs no migration
+	if err := storeSchemaVersion(s.db, latestSchemaVersion(migrations)); err != nil {
+		return fmt.Errorf("failed to write schema version to database: %w", err)
+	}
+
 	return nil
 }
 
@@ -16,6 +16,7 @@
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb"
 )
 
 // storagePrefix storage key prefix.
@@ -25,6 +26,8 @@
 // ErrTrieDoesNotExist is returned when attempting to interact with a trie that is not stored in the StorageState
 var ErrTrieDoesNotExist = errors.New("trie with given root does not exist")
 
+var errTrieNotComparable = errors.New("trie cannot be compared")
+
 func errTrieDoesNotExist(hash common.Hash) error {
 	return fmt.Errorf("%w: %s", ErrTrieDoesNotExist, hash)
 }
@@ -40,6 +43,8 @@ type InmemoryStorageState struct {
 	// change notifiers
 	observerListMutex sync.RWMutex
 	observerList      []Observer
+	notifyMutex       sync.Mutex
+	prunerMutex       sync.RWMutex
 	pruner            pruner.Pruner
 }
 
@@ -49,13 +54,27 @@ func NewStorageState(db database.Database, blockState *BlockState,
 	tries *Tries) (*InmemoryStorageState, error) {
 	storageTable := database.NewTable(db, storagePrefix)
 
-	return &InmemoryStorageState{
+	s := &InmemoryStorageState{
 		blockState:   blockState,
 		tries:        tries,
 		db:           storageTable,
 		observerList: []Observer{},
 		pruner:       &pruner.ArchiveNode{},
-	}, nil
+	}
+
+	if blockState != nil {
+		// the runtime code of the finalised blocks is loaded from the storage state
+		blockState.storageState = s
+	}
+	return s, nil
+}
+
+// setPruner replaces the online pruner of the storage state, the journal records of the
+// tries stored from now on being given to the new pruner.
+func (s *InmemoryStorageState) setPruner(p pruner.Pruner) {
+	s.prunerMutex.Lock()
+	defer s.prunerMutex.Unlock()
+	s.pruner = p
 }
 
 // StoreTrie stores the given trie in the StorageState and writes it to the database
@@ -69,7 +88,9 @@ func (s *InmemoryStorageState) StoreTrie(ts *storage.TrieState, header *types.He
 			return fmt.Errorf("getting trie changed node hashes for block hash %s: %w", header.Hash(), err)
 		}
 
+		s.prunerMutex.RLock()
 		err = s.pruner.StoreJournalRecord(deletedNodeHashes, insertedNodeHashes, header.Hash(), int64(header.Number))
+		s.prunerMutex.RUnlock()
 		if err != nil {
 			return fmt.Errorf("storing journal record: %w", err)
 		}
@@ -85,7 +106,21 @@ func (s *InmemoryStorageState) StoreTrie(ts *storage.TrieState, header *types.He
 		}
 	}
 
-	go s.notifyAll(root)
+	tsChanges := ts.Changes()
+	changes := make([]KeyValue, len(tsChanges))
+	for i, change := range tsChanges {
+		changes[i] = KeyValue{Key: change.Key, Value: change.Value}
+	}
+
+	if header != nil {
+		err := s.blockState.indexStorageChanges(header, changes)
+		if err != nil {
+			return fmt.Errorf("indexing storage changes: %w", err)
+		}
+		s.blockState.setStorageChanges(header, changes)
+	}
+
+	go s.notifyAll(root, changes)
 	return nil
 }
 
@@ -117,6 +152,9 @@ func (s *InmemoryStorageState) TrieState(root *common.Hash) (*storage.TrieState,
 	// the modifications?
 	nextTrie := t.(*inmemory_trie.InMemoryTrie).Snapshot()
 	next := storage.NewTrieState(nextTrie)
+	if s.changesListened() {
+		next.RecordChanges()
+	}
 
 	logger.Tracef("returning trie with root %s to be modified", root)
 	return next, nil
@@ -237,15 +275,89 @@ func (s *InmemoryStorageState) Entries(root *common.Hash) (map[string][]byte, er
 	return tr.Entries(), nil
 }
 
+// StorageDiff returns the entries of the trie with the root to differing from the entries of the
+// trie with the root from, as a map of keys to their value at the root to, the value of the keys
+// deleted being nil.
+func (s *InmemoryStorageState) StorageDiff(from, to common.Hash) (map[string][]byte, error) {
+	fromTrie, err := s.loadTrie(&from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTrie, err := s.loadTrie(&to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromInMemoryTrie, ok := fromTrie.(*inmemory_trie.InMemoryTrie)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errTrieNotComparable, fromTrie)
+	}
+	toInMemoryTrie, ok := toTrie.(*inmemory_trie.InMemoryTrie)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errTrieNotComparable, toTrie)
+	}
+	return fromInMemoryTrie.Diff(toInMemoryTrie), nil
+}
+
 // GetKeysWithPrefix returns all that match the given prefix for the given hash
-// (or best block state root if hash is nil) in lexicographic order
+// (or best block state root if hash is nil) in lexicographic order.
+// If the trie is not in memory, only its nodes under the prefix are loaded
+// from the database, instead of loading the whole trie.
 func (s *InmemoryStorageState) GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error) {
+	if root == nil {
+		sr, err := s.blockState.BestBlockStateRoot()
+		if err != nil {
+			return nil, err
+		}
+		root = &sr
+	}
+
+	t := s.tries.get(*root)
+	if t != nil {
+		return t.GetKeysWithPrefix(prefix), nil
+	}
+
+	keys, err := triedb.NewTrieDB(*root, s.db).KeysWithPrefix(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("getting keys with prefix from database at root %s: %w", *root, err)
+	}
+	return keys, nil
+}
+
+// GetKeysWithPrefixPaged returns at most limit keys matching the given prefix for the
+// given hash (or best block state root if hash is nil) in lexicographic order, starting
+// after the startAfter key if it is not nil.
+func (s *InmemoryStorageState) GetKeysWithPrefixPaged(root *common.Hash, prefix, startAfter []byte,
+	limit uint) ([][]byte, error) {
 	tr, err := s.loadTrie(root)
 	if err != nil {
 		return nil, err
 	}
 
-	return tr.GetKeysWithPrefix(prefix), nil
+	return trie.GetKeysWithPrefixPaged(tr, prefix, startAfter, limit), nil
+}
+
+// CountKeysWithPrefix returns the number of keys matching the given prefix for the
+// given hash (or best block state root if hash is nil)
+func (s *InmemoryStorageState) CountKeysWithPrefix(root *common.Hash, prefix []byte) (uint, error) {
+	tr, err := s.loadTrie(root)
+	if err != nil {
+		return 0, err
+	}
+
+	return trie.CountKeysWithPrefix(tr, prefix), nil
+}
+
+// EstimateSizeWithPrefix returns the total size in bytes of the keys and values matching
+// the given prefix for the given hash (or best block state root if hash is nil)
+func (s *InmemoryStorageState) EstimateSizeWithPrefix(root *common.Hash, prefix []byte) (uint, error) {
+	tr, err := s.loadTrie(root)
+	if err != nil {
+		return 0, err
+	}
+
+	return trie.EstimateSizeWithPrefix(tr, prefix), nil
 }
 
 // GetStorageChild returns a child trie, if it exists
@@ -142,6 +142,8 @@ func TestStorage_LoadFromDB(t *testing.T) {
 	prefixKeys, err := storage.GetKeysWithPrefix(&root, []byte("ke"))
 	require.NoError(t, err)
 	require.Equal(t, 2, len(prefixKeys))
+	// Only the nodes under the prefix are loaded from disk
+	require.Nil(t, storage.blockState.tries.get(root))
 
 	storage.blockState.tries.delete(root)
 
@@ -150,6 +152,42 @@ func TestStorage_LoadFromDB(t *testing.T) {
 	require.Equal(t, 5, len(entries))
 }
 
+func TestStorage_StorageDiff(t *testing.T) {
+	storage := newTestStorageState(t)
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	ts.Put([]byte("key1"), []byte("value1"))
+	ts.Put([]byte("key2"), []byte("value2"))
+	from, err := ts.Root()
+	require.NoError(t, err)
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	ts, err = storage.TrieState(&from)
+	require.NoError(t, err)
+	ts.Put([]byte("key1"), []byte("new value1"))
+	err = ts.Delete([]byte("key2"))
+	require.NoError(t, err)
+	ts.Put([]byte("key3"), []byte("value3"))
+	to, err := ts.Root()
+	require.NoError(t, err)
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	// the trie compared is loaded from the database
+	storage.blockState.tries.delete(to)
+
+	diff, err := storage.StorageDiff(from, to)
+	require.NoError(t, err)
+	expected := map[string][]byte{
+		"key1": []byte("new value1"),
+		"key2": nil,
+		"key3": []byte("value3"),
+	}
+	require.Equal(t, expected, diff)
+}
+
 func TestStorage_StoreTrie_NotSyncing(t *testing.T) {
 	storage := newTestStorageState(t)
 	ts, err := storage.TrieState(&trie.EmptyHash)
@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -34,7 +36,12 @@
 // Tries is a thread safe map of root hash
 // to Trie. This structure is only used in combination with in-memory tries
 type Tries struct {
-	rootToTrie    map[common.Hash]trie.Trie
+	rootToTrie map[common.Hash]trie.Trie
+	// roots are the root hashes of the tries in the order they were set.
+	roots []common.Hash
+	// nodesMemory accounts the memory of the nodes of the tries, shared between
+	// the tries snapshot from one another. It is nil unless the memory is accounted.
+	nodesMemory   *inmemory_trie.NodesMemory
 	mapMutex      sync.RWMutex
 	triesGauge    prometheus.Gauge
 	setCounter    prometheus.Counter
@@ -78,12 +85,18 @@ func (t *Tries) softSet(root common.Hash, trie trie.Trie) {
 	t.triesGauge.Inc()
 	t.setCounter.Inc()
 	t.rootToTrie[root] = trie
+	t.roots = append(t.roots, root)
+	t.accountTrie(trie)
 }
 
 func (t *Tries) delete(root common.Hash) {
 	t.mapMutex.Lock()
 	defer t.mapMutex.Unlock()
+	t.unaccountTrie(t.rootToTrie[root])
 	delete(t.rootToTrie, root)
+	if i := slices.Index(t.roots, root); i >= 0 {
+		t.roots = slices.Delete(t.roots, i, i+1)
+	}
 	// Note we use .Set instead of .Dec in case nothing
 	// was deleted since nothing existed at the hash given.
 	t.triesGauge.Set(float64(len(t.rootToTrie)))
@@ -105,3 +118,118 @@ func (t *Tries) len() int {
 	defer t.mapMutex.RUnlock()
 	return len(t.rootToTrie)
 }
+
+// accountMemory starts accounting the memory of the nodes of the tries.
+func (t *Tries) accountMemory() {
+	t.mapMutex.Lock()
+	defer t.mapMutex.Unlock()
+
+	if t.nodesMemory != nil {
+		return
+	}
+
+	t.nodesMemory = inmemory_trie.NewNodesMemory()
+	for _, tr := range t.rootToTrie {
+		t.accountTrie(tr)
+	}
+}
+
+func (t *Tries) accountTrie(tr trie.Trie) {
+	inMemoryTrie, ok := tr.(*inmemory_trie.InMemoryTrie)
+	if !ok || t.nodesMemory == nil {
+		return
+	}
+	t.nodesMemory.Add(inMemoryTrie)
+}
+
+func (t *Tries) unaccountTrie(tr trie.Trie) {
+	inMemoryTrie, ok := tr.(*inmemory_trie.InMemoryTrie)
+	if !ok || t.nodesMemory == nil {
+		return
+	}
+	t.nodesMemory.Remove(inMemoryTrie)
+}
+
+// memoryUsage returns the estimated number of bytes of the nodes of the tries,
+// counting once the nodes shared between tries, or zero if the memory is not accounted.
+func (t *Tries) memoryUsage() uint64 {
+	t.mapMutex.RLock()
+	defer t.mapMutex.RUnlock()
+
+	if t.nodesMemory == nil {
+		return 0
+	}
+	return t.nodesMemory.Usage()
+}
+
+// shrink removes the tries in the order they were set, except the tries at the roots
+// to keep and the empty trie, until the estimated number of bytes of the nodes of the
+// tries is at most the target given. A trie removed is loaded again from the database
+// when it is needed, without sharing its nodes with the tries kept.
+func (t *Tries) shrink(target uint64, keep map[common.Hash]struct{}) {
+	t.mapMutex.Lock()
+	defer t.mapMutex.Unlock()
+
+	if t.nodesMemory == nil {
+		return
+	}
+
+	kept := t.roots[:0]
+	for _, root := range t.roots {
+		_, keepRoot := keep[root]
+		if t.nodesMemory.Usage() <= target || keepRoot || root == trie.EmptyHash {
+			kept = append(kept, root)
+			continue
+		}
+
+		t.unaccountTrie(t.rootToTrie[root])
+		delete(t.rootToTrie, root)
+		t.deleteCounter.Inc()
+	}
+	t.roots = kept
+	t.triesGauge.Set(float64(len(t.rootToTrie)))
+}
+
+// stateTries are the state tries held in memory, accounted in the memory budget.
+// The tries of the highest finalised block and of the block tree leaves are never
+// evicted, since the blocks imported next are most likely built on them, and
+// loading them again from the database would lose the nodes they share.
+type stateTries struct {
+	blockState *BlockState
+}
+
+// MemoryUsage returns the estimated number of bytes of the nodes of the state tries.
+func (s stateTries) MemoryUsage() uint64 {
+	return s.blockState.tries.memoryUsage()
+}
+
+// Shrink evicts the state tries of the blocks other than the highest finalised block
+// and the leaves, oldest first, until the estimated number of bytes of their nodes is
+// at most the target given.
+func (s stateTries) Shrink(target uint64) {
+	keep, err := s.keptRoots()
+	if err != nil {
+		logger.Errorf("cannot shrink the state tries: %s", err)
+		return
+	}
+	s.blockState.tries.shrink(target, keep)
+}
+
+func (s stateTries) keptRoots() (roots map[common.Hash]struct{}, err error) {
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	leaves := s.blockState.Leaves()
+	roots = make(map[common.Hash]struct{}, 1+len(leaves))
+	roots[finalised.StateRoot] = struct{}{}
+	for _, leaf := range leaves {
+		header, err := s.blockState.GetHeader(leaf)
+		if err != nil {
+			return nil, fmt.Errorf("getting header of leaf %s: %w", leaf, err)
+		}
+		roots[header.StateRoot] = struct{}{}
+	}
+	return roots, nil
+}
@@ -6,11 +6,13 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/pkg/trie/node"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -39,6 +41,7 @@ func Test_Tries_SetEmptyTrie(t *testing.T) {
 		rootToTrie: map[common.Hash]trie.Trie{
 			trie.EmptyHash: inmemory_trie.NewEmptyTrie(),
 		},
+		roots:         []common.Hash{trie.EmptyHash},
 		triesGauge:    triesGauge,
 		setCounter:    setCounter,
 		deleteCounter: deleteCounter,
@@ -62,6 +65,7 @@ func Test_Tries_SetTrie(t *testing.T) {
 		rootToTrie: map[common.Hash]trie.Trie{
 			tr.MustHash(): tr,
 		},
+		roots:         []common.Hash{tr.MustHash()},
 		triesGauge:    triesGauge,
 		setCounter:    setCounter,
 		deleteCounter: deleteCounter,
@@ -190,6 +194,136 @@ func Test_Tries_delete(t *testing.T) {
 		})
 	}
 }
+func Test_Tries_memoryUsage(t *testing.T) {
+	t.Parallel()
+
+	parent := inmemory_trie.NewEmptyTrie()
+	for _, key := range []string{"a", "ab", "b"} {
+		err := parent.Put([]byte(key), []byte("value"))
+		require.NoError(t, err)
+	}
+	child := parent.Snapshot()
+	err := child.Put([]byte("b"), []byte("new value"))
+	require.NoError(t, err)
+
+	tries := NewTries()
+	tries.SetTrie(parent)
+	tries.SetTrie(child)
+	assert.Zero(t, tries.memoryUsage())
+
+	tries.accountMemory()
+	expected := inmemory_trie.NewNodesMemory()
+	expected.Add(parent)
+	expected.Add(child)
+	assert.Equal(t, expected.Usage(), tries.memoryUsage())
+
+	tries.delete(child.MustHash())
+	expected.Remove(child)
+	assert.Equal(t, expected.Usage(), tries.memoryUsage())
+}
+
+func Test_Tries_shrink(t *testing.T) {
+	t.Parallel()
+
+	newTrie := func(value byte) *inmemory_trie.InMemoryTrie {
+		return inmemory_trie.NewTrie(&node.Node{
+			PartialKey:   []byte{1},
+			StorageValue: []byte{value},
+		}, nil)
+	}
+	trieUsage := func() uint64 {
+		memory := inmemory_trie.NewNodesMemory()
+		memory.Add(newTrie(0))
+		return memory.Usage()
+	}()
+
+	testCases := map[string]struct {
+		target             uint64
+		keep               map[common.Hash]struct{}
+		expectedRoots      []common.Hash
+		deleteCounterIncs  int
+		triesGaugeSet      float64
+		expectedRootToTrie map[common.Hash]trie.Trie
+	}{
+		"under_target": {
+			target:        3 * trieUsage,
+			expectedRoots: []common.Hash{trie.EmptyHash, {1}, {2}, {3}},
+			triesGaugeSet: 4,
+			expectedRootToTrie: map[common.Hash]trie.Trie{
+				trie.EmptyHash: inmemory_trie.NewEmptyTrie(),
+				{1}:            newTrie(1),
+				{2}:            newTrie(2),
+				{3}:            newTrie(3),
+			},
+		},
+		"oldest_removed_first": {
+			target:            2 * trieUsage,
+			expectedRoots:     []common.Hash{trie.EmptyHash, {2}, {3}},
+			deleteCounterIncs: 1,
+			triesGaugeSet:     3,
+			expectedRootToTrie: map[common.Hash]trie.Trie{
+				trie.EmptyHash: inmemory_trie.NewEmptyTrie(),
+				{2}:            newTrie(2),
+				{3}:            newTrie(3),
+			},
+		},
+		"kept_roots_skipped": {
+			target:            2 * trieUsage,
+			keep:              map[common.Hash]struct{}{{1}: {}},
+			expectedRoots:     []common.Hash{trie.EmptyHash, {1}, {3}},
+			deleteCounterIncs: 1,
+			triesGaugeSet:     3,
+			expectedRootToTrie: map[common.Hash]trie.Trie{
+				trie.EmptyHash: inmemory_trie.NewEmptyTrie(),
+				{1}:            newTrie(1),
+				{3}:            newTrie(3),
+			},
+		},
+		"empty_trie_kept": {
+			target:            0,
+			keep:              map[common.Hash]struct{}{{3}: {}},
+			expectedRoots:     []common.Hash{trie.EmptyHash, {3}},
+			deleteCounterIncs: 2,
+			triesGaugeSet:     2,
+			expectedRootToTrie: map[common.Hash]trie.Trie{
+				trie.EmptyHash: inmemory_trie.NewEmptyTrie(),
+				{3}:            newTrie(3),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			triesGauge := NewMockGauge(ctrl)
+			triesGauge.EXPECT().Set(testCase.triesGaugeSet)
+			deleteCounter := NewMockCounter(ctrl)
+			deleteCounter.EXPECT().Inc().Times(testCase.deleteCounterIncs)
+
+			tries := &Tries{
+				rootToTrie: map[common.Hash]trie.Trie{
+					trie.EmptyHash: inmemory_trie.NewEmptyTrie(),
+					{1}:            newTrie(1),
+					{2}:            newTrie(2),
+					{3}:            newTrie(3),
+				},
+				roots:         []common.Hash{trie.EmptyHash, {1}, {2}, {3}},
+				triesGauge:    triesGauge,
+				deleteCounter: deleteCounter,
+			}
+			tries.accountMemory()
+
+			tries.shrink(testCase.target, testCase.keep)
+
+			assert.Equal(t, testCase.expectedRootToTrie, tries.rootToTrie)
+			assert.Equal(t, testCase.expectedRoots, tries.roots)
+		})
+	}
+}
+
 func Test_Tries_get(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -270,3 +404,26 @@ func Test_Tries_len(t *testing.T) {
 		})
 	}
 }
+
+func Test_stateTries_keptRoots(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	header := &types.Header{
+		Number:     1,
+		StateRoot:  common.Hash{1},
+		Digest:     createPrimaryBABEDigest(t),
+		ParentHash: testGenesisHeader.Hash(),
+	}
+	err := bs.AddBlock(&types.Block{Header: *header, Body: sampleBlockBody})
+	require.NoError(t, err)
+
+	roots, err := stateTries{blockState: bs}.keptRoots()
+	require.NoError(t, err)
+
+	expected := map[common.Hash]struct{}{
+		testGenesisHeader.StateRoot: {},
+		{1}:                         {},
+	}
+	assert.Equal(t, expected, roots)
+}
@@ -21,6 +21,7 @@ type BlockStateDatabase interface {
 	GetPutDeleter
 	Haser
 	NewBatcher
+	PrefixIterable
 }
 
 // GetPutter has methods to get and put key values.
@@ -62,6 +63,11 @@ type NewBatcher interface {
 	NewBatch() database.Batch
 }
 
+// PrefixIterable creates iterators over the keys starting with a prefix.
+type PrefixIterable interface {
+	NewPrefixIterator(prefix []byte) (database.Iterator, error)
+}
+
 // BabeConfigurer returns the babe configuration of the runtime.
 type BabeConfigurer interface {
 	BabeConfiguration() (*types.BabeConfiguration, error)
@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Note: these are using the substrate namespace and labels so
+// the dashboards made for substrate nodes work with gossamer
+var (
+	blockHeightGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "block_height",
+		Help:      "block height info of the chain, by status",
+	}, []string{"status"})
+	finalityLagGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "finality_lag",
+		Help:      "number of blocks the best block is ahead of the last finalised block",
+	})
+)
+
+// updateMetrics updates the chain metrics at each metrics interval, until the service is stopped.
+func (s *Service) updateMetrics(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		case <-ticker.C:
+			err := s.Block.updateChainMetrics()
+			if err != nil {
+				logger.Debugf("updating chain metrics: %s", err)
+			}
+		}
+	}
+}
+
+// updateChainMetrics sets the numbers of the best and finalised blocks, and the finality lag.
+func (bs *BlockState) updateChainMetrics() error {
+	best, err := bs.BestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+
+	finalised, err := bs.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	blockHeightGauge.WithLabelValues("best").Set(float64(best.Number))
+	blockHeightGauge.WithLabelValues("finalized").Set(float64(finalised.Number))
+
+	var lag uint
+	if best.Number > finalised.Number {
+		lag = best.Number - finalised.Number
+	}
+	finalityLagGauge.Set(float64(lag))
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockState_updateChainMetrics(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	AddBlocksToState(t, bs, 5, false)
+
+	finalised, err := bs.GetHashByNumber(2)
+	require.NoError(t, err)
+	err = bs.SetFinalisedHash(finalised, 1, 0)
+	require.NoError(t, err)
+
+	err = bs.updateChainMetrics()
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(5), testutil.ToFloat64(blockHeightGauge.WithLabelValues("best")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(blockHeightGauge.WithLabelValues("finalized")))
+	assert.Equal(t, float64(3), testutil.ToFloat64(finalityLagGauge))
+}
@@ -0,0 +1,166 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+)
+
+// schemaVersionKey -> big endian uint32 version of the storage format of the database
+var schemaVersionKey = []byte("schema_version")
+
+// ErrSchemaVersionTooNew is returned when the database was written by a newer node
+// version, using a storage format this node version does not know.
+var ErrSchemaVersionTooNew = errors.New("database schema version too new")
+
+// migrationProgressInterval is the minimum interval between two progress logs of a migration.
+const migrationProgressInterval = 10 * time.Second
+
+// migrationChunkSize is the number of entries a migration writing many
+// entries writes to its batch before flushing it.
+const migrationChunkSize = 10_000
+
+// migration is a change of the storage format of the database, such as an index
+// addition or an encoding change, applied once to the databases written before it.
+type migration struct {
+	description string
+	// migrate writes the changes of the migration to the batch given, reading the
+	// database as it was before the migration. It reports the number of entries
+	// migrated so far with the progress function given. A migration writing many
+	// entries flushes the batch in chunks with flushMigrationChunk, so it must skip
+	// the entries already migrated when retried after failing.
+	migrate func(db database.Database, batch database.Batch, progress func(migrated uint64)) error
+}
+
+// migrations are the migrations of the database, in order. The migration
+// at index i upgrades the database from the schema version i to i+1, so
+// migrations must only ever be appended to this list.
+var migrations = []migration{
+	{
+		description: "index the availability of the blocks stored before the availability index",
+		migrate:     indexBlockAvailability,
+	},
+}
+
+// latestSchemaVersion returns the schema version of the databases
+// with all the migrations given applied.
+func latestSchemaVersion(migrations []migration) uint32 {
+	return uint32(len(migrations))
+}
+
+// loadSchemaVersion returns the schema version of the database,
+// which is 0 for the databases created before the version was recorded.
+func loadSchemaVersion(db database.Reader) (version uint32, err error) {
+	encoded, err := db.Get(schemaVersionKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("getting schema version: %w", err)
+	}
+
+	if len(encoded) != 4 {
+		return 0, fmt.Errorf("decoding schema version: invalid length %d", len(encoded))
+	}
+	return binary.BigEndian.Uint32(encoded), nil
+}
+
+func storeSchemaVersion(db database.Writer, version uint32) error {
+	encoded := binary.BigEndian.AppendUint32(nil, version)
+	err := db.Put(schemaVersionKey, encoded)
+	if err != nil {
+		return fmt.Errorf("putting schema version: %w", err)
+	}
+	return nil
+}
+
+// runMigrations applies the migrations the database is missing, in order. Each migration is
+// committed along with the schema version it upgrades the database to, so a failed migration
+// leaves the database at the schema version before it, and is retried at the next startup. It returns an error wrapping ErrSchemaVersionTooNew if the database is newer than
+// the migrations given.
+func runMigrations(db database.Database, migrations []migration) error {
+	version, err := loadSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+
+	latest := latestSchemaVersion(migrations)
+	switch {
+	case version > latest:
+		return fmt.Errorf("%w: %d is newer than %d", ErrSchemaVersionTooNew, version, latest)
+	case version == latest:
+		return nil
+	}
+
+	logger.Infof("migrating database from schema version %d to %d...", version, latest)
+	for ; version < latest; version++ {
+		err = runMigration(db, version+1, migrations[version])
+		if err != nil {
+			return fmt.Errorf("migrating database to schema version %d: %w", version+1, err)
+		}
+	}
+	logger.Infof("database migrated to schema version %d", latest)
+	return nil
+}
+
+func runMigration(db database.Database, version uint32, m migration) (err error) {
+	logger.Infof("applying database migration %d: %s", version, m.description)
+	start := time.Now()
+	lastProgress := start
+	progress := func(migrated uint64) {
+		if time.Since(lastProgress) < migrationProgressInterval {
+			return
+		}
+		lastProgress = time.Now()
+		logger.Infof("database migration %d: %d entries migrated in %s",
+			version, migrated, time.Since(start).Round(time.Second))
+	}
+
+	batch := db.NewBatch()
+	defer func() {
+		closeErr := batch.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing batch: %w", closeErr)
+		}
+	}()
+
+	// the batch is dropped without being flushed if the migration fails, rolling
+	// back the changes written by the migration since its last chunk flushed.
+	err = m.migrate(db, batch, progress)
+	if err != nil {
+		return err
+	}
+
+	err = storeSchemaVersion(batch, version)
+	if err != nil {
+		return err
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("committing migration: %w", err)
+	}
+
+	logger.Infof("database migration %d applied in %s", version, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// flushMigrationChunk flushes the batch of a migration once it holds the
+// number of entries given, bounding the memory used by the migration.
+func flushMigrationChunk(batch database.Batch, chunkSize int) error {
+	if batch.ValueSize() < chunkSize {
+		return nil
+	}
+
+	err := batch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing migration chunk: %w", err)
+	}
+	batch.Reset()
+	return nil
+}
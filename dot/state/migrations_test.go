@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_runMigrations(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	schemaVersion := func(version uint32) *uint32 { return &version }
+
+	// putMigration returns a migration putting the key given, with the
+	// value the key had before the migration appended to it.
+	putMigration := func(key string) migration {
+		return migration{
+			description: "put " + key,
+			migrate: func(db database.Database, batch database.Batch, _ func(uint64)) error {
+				previous, err := db.Get([]byte(key))
+				if err != nil && !errors.Is(err, database.ErrNotFound) {
+					return err
+				}
+				return batch.Put([]byte(key), append(previous, 1))
+			},
+		}
+	}
+	failingMigration := migration{
+		description: "failing",
+		migrate: func(_ database.Database, batch database.Batch, _ func(uint64)) error {
+			err := batch.Put([]byte("failed"), []byte{1})
+			if err != nil {
+				return err
+			}
+			return errTest
+		},
+	}
+
+	testCases := map[string]struct {
+		// version is the schema version stored before running the migrations, if any
+		version         *uint32
+		migrations      []migration
+		expectedVersion uint32
+		expectedValues  map[string][]byte
+		errWrapped      error
+		errMessage      string
+	}{
+		"no_migration": {
+			expectedValues: map[string][]byte{"a": nil},
+		},
+		"unversioned_database": {
+			migrations:      []migration{putMigration("a"), putMigration("b")},
+			expectedVersion: 2,
+			expectedValues:  map[string][]byte{"a": {1}, "b": {1}},
+		},
+		"missing_migrations_applied": {
+			version:         schemaVersion(1),
+			migrations:      []migration{putMigration("a"), putMigration("a"), putMigration("a")},
+			expectedVersion: 3,
+			expectedValues:  map[string][]byte{"a": {1, 1}},
+		},
+		"up_to_date": {
+			version:         schemaVersion(1),
+			migrations:      []migration{putMigration("a")},
+			expectedVersion: 1,
+			expectedValues:  map[string][]byte{"a": nil},
+		},
+		"failed_migration_rolled_back": {
+			migrations:      []migration{putMigration("a"), failingMigration, putMigration("b")},
+			expectedVersion: 1,
+			expectedValues:  map[string][]byte{"a": {1}, "failed": nil, "b": nil},
+			errWrapped:      errTest,
+			errMessage:      "migrating database to schema version 2: test error",
+		},
+		"database_too_new": {
+			version:         schemaVersion(2),
+			migrations:      []migration{putMigration("a")},
+			expectedVersion: 2,
+			expectedValues:  map[string][]byte{"a": nil},
+			errWrapped:      ErrSchemaVersionTooNew,
+			errMessage:      "database schema version too new: 2 is newer than 1",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := NewInMemoryDB(t)
+			if testCase.version != nil {
+				err := storeSchemaVersion(db, *testCase.version)
+				require.NoError(t, err)
+			}
+
+			err := runMigrations(db, testCase.migrations)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+
+			version, err := loadSchemaVersion(db)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+
+			for key, expectedValue := range testCase.expectedValues {
+				value, err := db.Get([]byte(key))
+				if expectedValue == nil {
+					assert.ErrorIs(t, err, database.ErrNotFound)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, expectedValue, value)
+			}
+		})
+	}
+}
+
+func Test_flushMigrationChunk(t *testing.T) {
+	t.Parallel()
+
+	db := NewInMemoryDB(t)
+	batch := db.NewBatch()
+	t.Cleanup(func() {
+		err := batch.Close()
+		assert.NoError(t, err)
+	})
+
+	err := batch.Put([]byte("a"), []byte{1})
+	require.NoError(t, err)
+	err = flushMigrationChunk(batch, 2)
+	require.NoError(t, err)
+
+	// the batch is not flushed until it holds a chunk
+	_, err = db.Get([]byte("a"))
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	err = batch.Put([]byte("b"), []byte{2})
+	require.NoError(t, err)
+	err = flushMigrationChunk(batch, 2)
+	require.NoError(t, err)
+
+	value, err := db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+	assert.Zero(t, batch.ValueSize())
+}
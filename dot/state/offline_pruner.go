@@ -119,24 +119,45 @@ func (p *OfflinePruner) SetBloomFilter() (err error) {
 		return fmt.Errorf("not enough block to perform pruning")
 	}
 
-	// loop from latest to last `retainBlockNum` blocks
-	for blockNum := header.Number; blockNum > 0 && blockNum >= latestBlockNum-uint(p.retainBlockNum); {
-		var tr *inmemory_trie.InMemoryTrie
-		loadedTrie, err := p.storageState.LoadFromDB(header.StateRoot)
+	// the retained blocks whose header is not stored, such as the blocks of a gap
+	// left by an interrupted sync, have no state to retain and are skipped.
+	start := uint(1)
+	if latestBlockNum > uint(p.retainBlockNum) {
+		start = latestBlockNum - uint(p.retainBlockNum)
+	}
+	availabilities, err := p.blockState.BlockAvailabilityRange(start, latestBlockNum)
+	if err != nil {
+		return fmt.Errorf("getting availability of blocks %d to %d: %w", start, latestBlockNum, err)
+	}
 
-		tr = loadedTrie.(*inmemory_trie.InMemoryTrie)
+	for i, availability := range availabilities {
+		blockNum := start + uint(i)
+		if !availability.Has(HeaderAvailable) {
+			logger.Warnf("block %d has no header stored, its state cannot be retained", blockNum)
+			continue
+		}
+
+		hash, err := p.blockState.GetHashByNumber(blockNum)
 		if err != nil {
-			return err
+			return fmt.Errorf("getting hash of block %d: %w", blockNum, err)
 		}
 
-		inmemory_trie.PopulateNodeHashes(tr.RootNode(), nodeHashes)
+		header, err := p.blockState.GetHeader(hash)
+		if err != nil {
+			return fmt.Errorf("getting header of block %d: %w", blockNum, err)
+		}
 
-		// get parent header of current block
-		header, err = p.blockState.GetHeader(header.ParentHash)
+		loadedTrie, err := p.storageState.LoadFromDB(header.StateRoot)
 		if err != nil {
 			return err
 		}
-		blockNum = header.Number
+
+		tr, ok := loadedTrie.(*inmemory_trie.InMemoryTrie)
+		if !ok {
+			return fmt.Errorf("unexpected trie type %T for block %d", loadedTrie, blockNum)
+		}
+
+		inmemory_trie.PopulateNodeHashes(tr.RootNode(), nodeHashes)
 	}
 
 	for key := range nodeHashes {
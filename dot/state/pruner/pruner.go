@@ -4,9 +4,15 @@
 package pruner
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
+// ErrInvalidMode is returned when building a pruner for an unknown pruning mode
+var ErrInvalidMode = errors.New("invalid pruning mode")
+
 const (
 	// Archive pruner mode.
 	Archive = Mode("archive")
@@ -37,6 +43,16 @@ type Pruner interface {
 		blockHash common.Hash, blockNum int64) error
 }
 
+// New returns the pruner of the configuration given, an empty mode defaulting to the archive mode.
+func New(config Config) (Pruner, error) {
+	switch config.Mode {
+	case Archive, "":
+		return &ArchiveNode{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMode, config.Mode)
+	}
+}
+
 // ArchiveNode is a no-op since we don't prune nodes in archive mode.
 type ArchiveNode struct{}
 
@@ -0,0 +1,49 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pruner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_New(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config     Config
+		pruner     Pruner
+		errWrapped error
+		errMessage string
+	}{
+		"archive": {
+			config: Config{Mode: Archive, RetainedBlocks: 256},
+			pruner: &ArchiveNode{},
+		},
+		"empty_mode": {
+			pruner: &ArchiveNode{},
+		},
+		"invalid_mode": {
+			config:     Config{Mode: "full"},
+			errWrapped: ErrInvalidMode,
+			errMessage: "invalid pruning mode: full",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pruner, err := New(testCase.config)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				require.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.pruner, pruner)
+		})
+	}
+}
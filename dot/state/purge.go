@@ -0,0 +1,190 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// OffchainStoragePrefix is the database table prefix of the persistent offchain storage
+const OffchainStoragePrefix = "offlinestorage"
+
+// PurgeTarget is a part of the chain data which can be purged on its own
+type PurgeTarget string
+
+const (
+	// PurgeState targets the state tries
+	PurgeState PurgeTarget = "state"
+	// PurgeBlocks targets the block headers, bodies and justifications
+	PurgeBlocks PurgeTarget = "blocks"
+	// PurgeAncillary targets the offchain storage and the slot equivocation records
+	PurgeAncillary PurgeTarget = "ancillary"
+)
+
+var errUnknownPurgeTarget = errors.New("unknown purge target")
+
+// purgeTargetPrefixes are the database table prefixes of each purge target
+var purgeTargetPrefixes = map[PurgeTarget][]string{
+	PurgeState:     {storagePrefix},
+	PurgeBlocks:    {blockPrefix},
+	PurgeAncillary: {OffchainStoragePrefix, slotTablePrefix},
+}
+
+// tablePrefixes are the prefixes of all the database tables. A table prefix can be the
+// start of another one, such as storagePrefix of storageChangesPrefix, so the keys of a
+// table are the keys with its prefix and without the prefix of a longer table.
+var tablePrefixes = []string{
+	storagePrefix, storageChangesPrefix, blockPrefix, epochPrefix,
+	grandpaPrefix, slotTablePrefix, OffchainStoragePrefix,
+}
+
+// headPointerKeys are the keys of the pointers to the chain head, which refer to the
+// blocks and state tries. They are deleted with the blocks or the state tries, so
+// the node does not start from a head whose data is purged.
+var headPointerKeys = [][]byte{
+	common.BestBlockHashKey,
+	common.BlockTreeKey,
+	common.LatestStorageHashKey,
+	common.WorkingStorageHashKey,
+	append([]byte(blockPrefix), highestRoundAndSetIDKey...),
+}
+
+// headPointerPrefixes are the prefixes of the chain head pointers with several keys
+var headPointerPrefixes = [][]byte{
+	append([]byte(blockPrefix), common.FinalizedBlockHashKey...),
+}
+
+// purgeBatchSize is the number of keys deleted per batch
+const purgeBatchSize = 10000
+
+// Purge deletes the chain data of the targets from the database at the basepath.
+// Purging the blocks or the state also deletes the chain head pointers, and the node
+// must then be initialised again. The rest of the database is left untouched.
+func Purge(basepath string, targets ...PurgeTarget) (err error) {
+	for _, target := range targets {
+		if _, ok := purgeTargetPrefixes[target]; !ok {
+			return fmt.Errorf("%w: %s", errUnknownPurgeTarget, target)
+		}
+	}
+
+	db, err := database.LoadDatabase(basepath, false)
+	if err != nil {
+		return fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing database: %w", closeErr)
+		}
+	}()
+
+	purgeHead := false
+	for _, target := range targets {
+		for _, prefix := range purgeTargetPrefixes[target] {
+			deleted, err := deleteTable(db, prefix)
+			if err != nil {
+				return fmt.Errorf("purging %s: %w", target, err)
+			}
+			logger.Infof("purged %d %s entries with prefix %s", deleted, target, prefix)
+		}
+		purgeHead = purgeHead || target == PurgeState || target == PurgeBlocks
+	}
+
+	if purgeHead {
+		err = deleteHeadPointers(db)
+		if err != nil {
+			return fmt.Errorf("purging chain head pointers: %w", err)
+		}
+	}
+
+	return db.Flush()
+}
+
+// deleteHeadPointers deletes the chain head pointers left in the database.
+func deleteHeadPointers(db database.Database) error {
+	for _, key := range headPointerKeys {
+		err := db.Del(key)
+		if err != nil {
+			return fmt.Errorf("deleting 0x%x: %w", key, err)
+		}
+	}
+
+	for _, prefix := range headPointerPrefixes {
+		_, err := deletePrefix(db, prefix, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteTable deletes all the keys of the table with the given prefix and returns
+// the number of keys deleted. Keys of other tables with a longer prefix starting
+// with the given prefix are left untouched.
+func deleteTable(db database.Database, prefix string) (deleted uint64, err error) {
+	var excluded [][]byte
+	for _, tablePrefix := range tablePrefixes {
+		if len(tablePrefix) > len(prefix) && strings.HasPrefix(tablePrefix, prefix) {
+			excluded = append(excluded, []byte(tablePrefix))
+		}
+	}
+	return deletePrefix(db, []byte(prefix), excluded)
+}
+
+// deletePrefix deletes all the keys starting with the prefix, except the keys starting
+// with one of the excluded prefixes, and returns the number of keys deleted.
+func deletePrefix(db database.Database, prefix []byte, excluded [][]byte) (deleted uint64, err error) {
+	iter, err := db.NewPrefixIterator(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("creating iterator: %w", err)
+	}
+	defer iter.Release()
+
+	batch := db.NewBatch()
+	defer batch.Close()
+
+	pending := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		if hasAnyPrefix(iter.Key(), excluded) {
+			continue
+		}
+
+		err = batch.Del(iter.Key())
+		if err != nil {
+			return deleted, err
+		}
+		pending++
+
+		if pending == purgeBatchSize {
+			err = batch.Flush()
+			if err != nil {
+				return deleted, err
+			}
+			batch.Reset()
+			deleted += uint64(pending)
+			pending = 0
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return deleted, err
+	}
+	return deleted + uint64(pending), nil
+}
+
+func hasAnyPrefix(key []byte, prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
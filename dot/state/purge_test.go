@@ -0,0 +1,95 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Purge(t *testing.T) {
+	t.Parallel()
+
+	prefixes := []string{
+		storagePrefix, storageChangesPrefix, blockPrefix,
+		OffchainStoragePrefix, slotTablePrefix, epochPrefix,
+	}
+
+	testCases := map[string]struct {
+		targets    []PurgeTarget
+		purged     []string
+		headPurged bool
+		errWrapped error
+		errMessage string
+	}{
+		"unknown_target": {
+			targets:    []PurgeTarget{PurgeState, "keystore"},
+			errWrapped: errUnknownPurgeTarget,
+			errMessage: "unknown purge target: keystore",
+		},
+		"state": {
+			targets:    []PurgeTarget{PurgeState},
+			purged:     []string{storagePrefix},
+			headPurged: true,
+		},
+		"blocks_and_ancillary": {
+			targets:    []PurgeTarget{PurgeBlocks, PurgeAncillary},
+			purged:     []string{blockPrefix, OffchainStoragePrefix, slotTablePrefix},
+			headPurged: true,
+		},
+		"ancillary": {
+			targets: []PurgeTarget{PurgeAncillary},
+			purged:  []string{OffchainStoragePrefix, slotTablePrefix},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			basepath := t.TempDir()
+			db, err := database.LoadDatabase(basepath, false)
+			require.NoError(t, err)
+			for _, prefix := range prefixes {
+				err = database.NewTable(db, prefix).Put([]byte("key"), []byte("value"))
+				require.NoError(t, err)
+			}
+			err = db.Put(common.BestBlockHashKey, []byte("hash"))
+			require.NoError(t, err)
+			err = db.Close()
+			require.NoError(t, err)
+
+			err = Purge(basepath, testCase.targets...)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+
+			db, err = database.LoadDatabase(basepath, false)
+			require.NoError(t, err)
+			defer db.Close()
+
+			for _, prefix := range prefixes {
+				has, err := database.NewTable(db, prefix).Has([]byte("key"))
+				require.NoError(t, err)
+				purged := false
+				for _, purgedPrefix := range testCase.purged {
+					purged = purged || purgedPrefix == prefix
+				}
+				assert.Equal(t, !purged, has, prefix)
+			}
+
+			if testCase.errWrapped == nil {
+				has, err := db.Has(common.BestBlockHashKey)
+				require.NoError(t, err)
+				assert.Equal(t, !testCase.headPurged, has)
+			}
+		})
+	}
+}
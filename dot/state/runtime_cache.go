@@ -0,0 +1,322 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cachedRuntimesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_runtime_cache",
+		Name:      "instances_total",
+		Help:      "total number of runtime instances of finalised blocks cached",
+	})
+	runtimeCacheHitsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_runtime_cache",
+		Name:      "hits_total",
+		Help:      "total number of runtime instances of finalised blocks found in the cache",
+	})
+	runtimeCacheMissesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_runtime_cache",
+		Name:      "misses_total",
+		Help:      "total number of runtime instances of finalised blocks not found in the cache",
+	})
+	runtimeCacheEvictionsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_runtime_cache",
+		Name:      "evictions_total",
+		Help:      "total number of runtime instances evicted from the cache",
+	})
+)
+
+// maxCachedRuntimes is the maximum number of runtime instances cached,
+// each instance holding its compiled code and its memory.
+const maxCachedRuntimes = 4
+
+// runtimeCache is a least recently used cache of the runtime instances of the finalised
+// blocks no longer in the block tree, keyed by code hash. The least recently used instance
+// is evicted once the cache is full. The runtime instances are reference counted, so an
+// instance evicted or invalidated is only stopped once its last user releases it.
+// The zero value is ready to use.
+type runtimeCache struct {
+	mutex sync.Mutex
+	// entries are the elements of order by code hash
+	entries map[common.Hash]*list.Element
+	// order are the cached runtimes, the most recently used first
+	order *list.List
+	// users are the numbers of users of the runtime instances acquired and not released.
+	users map[runtime.Instance]uint
+	// retired are the runtime instances no longer cached, stopped once released by their users.
+	retired map[runtime.Instance]struct{}
+}
+
+type cachedRuntime struct {
+	codeHash common.Hash
+	instance runtime.Instance
+}
+
+func (c *runtimeCache) init() {
+	if c.entries == nil {
+		c.entries = make(map[common.Hash]*list.Element)
+		c.order = list.New()
+		c.users = make(map[runtime.Instance]uint)
+		c.retired = make(map[runtime.Instance]struct{})
+	}
+}
+
+// get acquires and returns the runtime instance cached for the code hash given, with
+// the function releasing it, or returns a nil instance if there is none.
+func (c *runtimeCache) get(codeHash common.Hash) (instance runtime.Instance, release func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[codeHash]
+	if !ok {
+		runtimeCacheMissesCounter.Inc()
+		return nil, nil
+	}
+
+	runtimeCacheHitsCounter.Inc()
+	c.order.MoveToFront(element)
+	instance = element.Value.(*cachedRuntime).instance
+	return instance, c.acquireLocked(instance)
+}
+
+// add caches and acquires the runtime instance given for the code hash given, evicting
+// the least recently used instance if the cache is full, and returns it with the function
+// releasing it. If an instance of the code is already cached, the instance given is retired
+// and the cached instance is acquired and returned.
+func (c *runtimeCache) add(codeHash common.Hash, instance runtime.Instance) (
+	cached runtime.Instance, release func()) {
+	var stopped []runtime.Instance
+	defer func() { stopAll(stopped) }()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.init()
+
+	element, ok := c.entries[codeHash]
+	if ok {
+		// the code was instantiated concurrently, or the instance is of
+		// a block removed from the block tree enacting a code already cached.
+		if instance != element.Value.(*cachedRuntime).instance {
+			stopped = c.retireLocked(instance, stopped)
+		}
+		c.order.MoveToFront(element)
+		cached = element.Value.(*cachedRuntime).instance
+		return cached, c.acquireLocked(cached)
+	}
+
+	if c.order.Len() >= maxCachedRuntimes {
+		evicted := c.order.Remove(c.order.Back()).(*cachedRuntime)
+		delete(c.entries, evicted.codeHash)
+		stopped = c.retireLocked(evicted.instance, stopped)
+		runtimeCacheEvictionsCounter.Inc()
+	}
+
+	// an instance retired while used can be cached again, such as the instance of a
+	// finalised block shared with a fork and moved back to the cache once it is pruned.
+	delete(c.retired, instance)
+	c.entries[codeHash] = c.order.PushFront(&cachedRuntime{codeHash: codeHash, instance: instance})
+	cachedRuntimesGauge.Set(float64(c.order.Len()))
+	return instance, c.acquireLocked(instance)
+}
+
+// remove removes and returns the runtime instance cached for the code
+// hash given, without stopping it, or returns nil if there is none.
+func (c *runtimeCache) remove(codeHash common.Hash) (instance runtime.Instance) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[codeHash]
+	if !ok {
+		return nil
+	}
+
+	delete(c.entries, codeHash)
+	c.order.Remove(element)
+	cachedRuntimesGauge.Set(float64(c.order.Len()))
+	return element.Value.(*cachedRuntime).instance
+}
+
+// invalidate removes the runtime instance cached for the code hash given, if any,
+// and stops it once it is released by its users.
+func (c *runtimeCache) invalidate(codeHash common.Hash) {
+	instance := c.remove(codeHash)
+	if instance == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	stopped := c.retireLocked(instance, nil)
+	c.mutex.Unlock()
+	stopAll(stopped)
+}
+
+// acquire acquires the runtime instance given, which is not stopped by the cache
+// until the function returned is called to release it.
+func (c *runtimeCache) acquire(instance runtime.Instance) (release func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.acquireLocked(instance)
+}
+
+func (c *runtimeCache) acquireLocked(instance runtime.Instance) (release func()) {
+	c.init()
+	c.users[instance]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mutex.Lock()
+			c.users[instance]--
+			if c.users[instance] > 0 {
+				c.mutex.Unlock()
+				return
+			}
+			delete(c.users, instance)
+			_, retired := c.retired[instance]
+			delete(c.retired, instance)
+			c.mutex.Unlock()
+
+			if retired {
+				instance.Stop()
+			}
+		})
+	}
+}
+
+// retireLocked appends the runtime instance given to the instances to stop given if it has
+// no user, and otherwise marks it to be stopped once released by its last user.
+func (c *runtimeCache) retireLocked(instance runtime.Instance, stopped []runtime.Instance) []runtime.Instance {
+	if c.users[instance] > 0 {
+		c.retired[instance] = struct{}{}
+		return stopped
+	}
+	return append(stopped, instance)
+}
+
+// stopAll stops the runtime instances given, without holding
+// the cache lock while their current execution ends.
+func stopAll(instances []runtime.Instance) {
+	for _, instance := range instances {
+		instance.Stop()
+	}
+}
+
+// cacheRemovedRuntime caches the runtime instance given, no longer used by the blocks of the
+// block tree once a block is finalised, so the finalised blocks enacting its code still use it.
+func (bs *BlockState) cacheRemovedRuntime(instance runtime.Instance) {
+	_, release := bs.runtimeCache.add(instance.GetCodeHash(), instance)
+	release()
+}
+
+// newBlockTree returns a block tree with the root given,
+// caching the runtime instances removed from it.
+func (bs *BlockState) newBlockTree(root *types.Header) *blocktree.BlockTree {
+	bt := blocktree.NewBlockTreeFromRoot(root)
+	bt.SetRemovedRuntimeHandler(bs.cacheRemovedRuntime)
+	return bt
+}
+
+// runtimeStorage is the storage state the code of the finalised blocks is loaded from.
+type runtimeStorage interface {
+	TrieState(root *common.Hash) (*storage.TrieState, error)
+}
+
+// finalisedRuntime acquires and returns a runtime instance of the code of the finalised block
+// with the given hash, which is no longer in the block tree, and the function releasing it.
+// The runtime of the last finalised block is returned if it has the same code, and an instance
+// of the code is cached otherwise.
+func (bs *BlockState) finalisedRuntime(hash common.Hash) (
+	instance runtime.Instance, release func(), err error) {
+	if bs.storageState == nil {
+		return nil, nil, errors.New("no storage state to load the runtime code from")
+	}
+
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	trieState, err := bs.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	code := trieState.LoadCode()
+	if len(code) == 0 {
+		return nil, nil, fmt.Errorf("no runtime code at block %s", hash)
+	}
+
+	codeHash, err := common.Blake2bHash(code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hashing runtime code: %w", err)
+	}
+
+	finalisedHash, err := bs.GetHighestFinalisedHash()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting highest finalised hash: %w", err)
+	}
+
+	finalisedRuntime, err := bs.bt.GetBlockRuntime(finalisedHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting runtime of finalised block: %w", err)
+	} else if finalisedRuntime == nil {
+		return nil, nil, fmt.Errorf("no runtime for finalised block %s", finalisedHash)
+	}
+
+	if finalisedRuntime.GetCodeHash() == codeHash {
+		return finalisedRuntime, bs.runtimeCache.acquire(finalisedRuntime), nil
+	}
+
+	instance, release = bs.runtimeCache.get(codeHash)
+	if instance != nil {
+		return instance, release, nil
+	}
+
+	logger.Debugf("instantiating runtime with code hash %s for finalised block %s", codeHash, hash)
+	instance, err = wazero_runtime.NewInstance(code, runtimeConfig(finalisedRuntime, trieState, codeHash))
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+
+	instance, release = bs.runtimeCache.add(codeHash, instance)
+	return instance, release, nil
+}
+
+// runtimeConfig returns the configuration of a runtime instance of the code with the code
+// hash given, using the keystore, node storage and network of the runtime instance given.
+func runtimeConfig(from runtime.Instance, trieState *storage.TrieState,
+	codeHash common.Hash) wazero_runtime.Config {
+	cfg := wazero_runtime.Config{
+		Storage:     trieState,
+		Keystore:    from.Keystore(),
+		NodeStorage: from.NodeStorage(),
+		Network:     from.NetworkService(),
+		CodeHash:    codeHash,
+	}
+
+	if from.Validator() {
+		cfg.Role = common.AuthorityRole
+	}
+
+	if tracer, ok := from.(runtime.HostFunctionTracer); ok {
+		cfg.HostFunctionTracing = tracer.HostFunctionTracing()
+	}
+	return cfg
+}
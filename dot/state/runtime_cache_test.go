@@ -0,0 +1,153 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_runtimeCache(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	var cache runtimeCache
+	instance, _ := cache.get(common.Hash{1})
+	assert.Nil(t, instance)
+
+	instances := make([]*MockInstance, maxCachedRuntimes+1)
+	for i := range instances {
+		instances[i] = NewMockInstance(ctrl)
+	}
+
+	for i := 0; i < maxCachedRuntimes; i++ {
+		cached, release := cache.add(common.Hash{byte(i)}, instances[i])
+		assert.Equal(t, instances[i], cached)
+		release()
+	}
+
+	// the first instance becomes the most recently used
+	instance, release := cache.get(common.Hash{0})
+	assert.Equal(t, instances[0], instance)
+	release()
+
+	// an instance of a code already cached is stopped
+	duplicate := NewMockInstance(ctrl)
+	duplicate.EXPECT().Stop()
+	cached, release := cache.add(common.Hash{1}, duplicate)
+	assert.Equal(t, instances[1], cached)
+	release()
+
+	// the least recently used instance is evicted once the cache is full,
+	// and is only stopped once released by its users.
+	_, releaseEvicted := cache.get(common.Hash{2})
+	for _, codeHash := range []common.Hash{{3}, {0}, {1}} {
+		_, release := cache.get(codeHash)
+		release()
+	}
+	_, release = cache.add(common.Hash{maxCachedRuntimes}, instances[maxCachedRuntimes])
+	release()
+	instance, _ = cache.get(common.Hash{2})
+	assert.Nil(t, instance)
+	instances[2].EXPECT().Stop()
+	releaseEvicted()
+	// a release function is idempotent
+	releaseEvicted()
+
+	instance, release = cache.get(common.Hash{maxCachedRuntimes})
+	assert.Equal(t, instances[maxCachedRuntimes], instance)
+	release()
+
+	// a removed instance is not stopped
+	assert.Equal(t, instances[3], cache.remove(common.Hash{3}))
+	assert.Nil(t, cache.remove(common.Hash{3}))
+
+	// an instance invalidated is stopped once released by its users
+	_, release = cache.get(common.Hash{0})
+	cache.invalidate(common.Hash{0})
+	instance, _ = cache.get(common.Hash{0})
+	assert.Nil(t, instance)
+	instances[0].EXPECT().Stop()
+	release()
+
+	// an instance not cached is acquired, and is not stopped once released
+	release = cache.acquire(instances[3])
+	release()
+}
+
+func TestBlockState_GetRuntime_finalised(t *testing.T) {
+	t.Parallel()
+
+	storageState := newTestStorageState(t)
+	bs := storageState.blockState
+	ctrl := gomock.NewController(t)
+
+	oldCode, newCode := []byte("old code"), []byte("new code")
+	newCodeHash, err := common.Blake2bHash(newCode)
+	require.NoError(t, err)
+	oldCodeHash, err := common.Blake2bHash(oldCode)
+	require.NoError(t, err)
+
+	// genesis -> old code -> new code -> new code
+	parentHash := bs.GenesisHash()
+	var headers []*types.Header
+	for i, code := range [][]byte{oldCode, newCode, newCode} {
+		tr := inmemory.NewEmptyTrie()
+		tr.Put([]byte(":code"), code)
+		tr.Put([]byte("block"), []byte{byte(i)})
+		stateRoot := trie.V0.MustHash(tr)
+		err = tr.WriteDirty(storageState.db)
+		require.NoError(t, err)
+
+		header := &types.Header{
+			ParentHash: parentHash,
+			Number:     uint(i + 1),
+			StateRoot:  stateRoot,
+			Digest:     createPrimaryBABEDigest(t),
+		}
+		err = bs.AddBlock(&types.Block{Header: *header, Body: types.Body{}})
+		require.NoError(t, err)
+		headers = append(headers, header)
+		parentHash = header.Hash()
+	}
+
+	finalisedRuntime := NewMockInstance(ctrl)
+	finalisedRuntime.EXPECT().GetCodeHash().Return(newCodeHash).AnyTimes()
+	finalisedRuntime.EXPECT().Keystore().AnyTimes()
+	finalisedRuntime.EXPECT().NodeStorage().AnyTimes()
+	finalisedRuntime.EXPECT().NetworkService().AnyTimes()
+	finalisedRuntime.EXPECT().Validator().AnyTimes()
+	bs.StoreRuntime(bs.GenesisHash(), finalisedRuntime)
+
+	err = bs.SetFinalisedHash(headers[2].Hash(), 1, 0)
+	require.NoError(t, err)
+
+	// the finalised block runtime is used for the blocks with the same code
+	instance, err := bs.GetRuntime(headers[1].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, finalisedRuntime, instance)
+
+	// the old code is instantiated, which fails since it is not valid wasm code
+	_, err = bs.GetRuntime(headers[0].Hash())
+	assert.ErrorContains(t, err, "creating runtime instance")
+
+	cachedRuntime := NewMockInstance(ctrl)
+	_, release := bs.runtimeCache.add(oldCodeHash, cachedRuntime)
+	release()
+	instance, release, err = bs.AcquireRuntime(headers[0].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, cachedRuntime, instance)
+
+	// the instance acquired is only stopped once released
+	bs.runtimeCache.invalidate(oldCodeHash)
+	cachedRuntime.EXPECT().Stop()
+	release()
+}
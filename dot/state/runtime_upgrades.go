@@ -0,0 +1,289 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+var runtimeUpgradesKey = []byte("rtupgrades") // runtimeUpgradesKey -> scale encoded []RuntimeUpgrade
+
+// RuntimeUpgrade is a change of the runtime code enacted by a block.
+type RuntimeUpgrade struct {
+	BlockHash   common.Hash
+	BlockNumber uint
+	SpecVersion uint32
+}
+
+// errRuntimeSuperseded is the error of a runtime compiled for a block
+// which got another runtime stored, such as a substituted runtime code.
+var errRuntimeSuperseded = errors.New("runtime superseded")
+
+// pendingRuntime is a runtime instance being compiled in the background,
+// after its code was changed by the block it is pending for.
+type pendingRuntime struct {
+	done     chan struct{}
+	code     []byte
+	cfg      wazero_runtime.Config
+	instance runtime.Instance
+	err      error
+	// superseded is set, with the runtime upgrades lock held, when another
+	// runtime is stored for the block while this one is compiled.
+	superseded bool
+}
+
+// precompileRuntime compiles the runtime code given in the background, so the
+// import of the block changing the code does not wait for it. The runtime is
+// then stored for the block and the upgrade is recorded in the database.
+// Until the compilation is done, GetRuntime waits for it for the block and
+// its descendants.
+func (bs *BlockState) precompileRuntime(hash common.Hash, code []byte, cfg wazero_runtime.Config) {
+	_ = bs.compileRuntime(hash, code, cfg, nil)
+}
+
+// compileRuntime starts compiling the runtime code given for the block hash given,
+// if the runtime pending for the block is the replaced runtime given, and returns
+// the runtime pending for the block.
+func (bs *BlockState) compileRuntime(hash common.Hash, code []byte, cfg wazero_runtime.Config,
+	replaced *pendingRuntime) *pendingRuntime {
+	bs.runtimeUpgradesLock.Lock()
+	defer bs.runtimeUpgradesLock.Unlock()
+
+	if current := bs.pendingRuntimes[hash]; current != replaced {
+		return current
+	}
+
+	pending := &pendingRuntime{
+		done: make(chan struct{}),
+		code: code,
+		cfg:  cfg,
+	}
+	bs.pendingRuntimes[hash] = pending
+
+	go func() {
+		defer close(pending.done)
+
+		pending.instance, pending.err = bs.enactRuntime(hash, pending)
+		switch {
+		case errors.Is(pending.err, blocktree.ErrNodeNotFound):
+			logger.Debugf("discarding runtime of pruned block %s", hash)
+		case errors.Is(pending.err, errRuntimeSuperseded):
+			logger.Debugf("discarding runtime of block %s superseded by another runtime", hash)
+		case pending.err != nil:
+			// A failed compilation is kept pending so GetRuntime compiles it again,
+			// instead of silently using the previous runtime.
+			logger.Criticalf("failed to update runtime code for block %s: %s", hash, pending.err)
+			return
+		}
+
+		bs.runtimeUpgradesLock.Lock()
+		if bs.pendingRuntimes[hash] == pending {
+			delete(bs.pendingRuntimes, hash)
+		}
+		bs.runtimeUpgradesLock.Unlock()
+	}()
+
+	return pending
+}
+
+// enactRuntime instantiates the runtime code pending for the block hash
+// given and stores the runtime instance for the block, unless another
+// runtime was stored for the block in the meantime.
+func (bs *BlockState) enactRuntime(hash common.Hash, pending *pendingRuntime) (
+	instance runtime.Instance, err error) {
+	instance, err = wazero_runtime.NewInstance(pending.code, pending.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+
+	newVersion, err := instance.Version()
+	if err != nil {
+		instance.Stop()
+		return nil, fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	bs.runtimeUpgradesLock.Lock()
+	superseded := pending.superseded
+	stored := !superseded && bs.bt.StoreBlockRuntime(hash, instance)
+	bs.runtimeUpgradesLock.Unlock()
+
+	if superseded {
+		instance.Stop()
+		return nil, fmt.Errorf("%w: for block %s", errRuntimeSuperseded, hash)
+	} else if !stored {
+		// the fork of the block was pruned while the runtime was compiled
+		instance.Stop()
+		return nil, fmt.Errorf("%w: block %s was pruned", blocktree.ErrNodeNotFound, hash)
+	}
+	// an instance of the same code cached for the finalised blocks is no longer needed
+	bs.runtimeCache.invalidate(pending.cfg.CodeHash)
+
+	err = bs.storeRuntimeUpgrade(hash, newVersion.SpecVersion)
+	if err != nil {
+		// The upgrade history is informational only, so the runtime is still used.
+		logger.Errorf("failed to record runtime upgrade for block %s: %s", hash, err)
+	}
+
+	logger.Infof("🔄 runtime upgraded with block %s to spec version %d", hash, newVersion.SpecVersion)
+	go bs.notifyRuntimeUpdated(newVersion)
+	return instance, nil
+}
+
+// shareRuntime stores for the block hash given the runtime instance of the same code
+// enacted by a block of another fork, and records the runtime upgrade of the block.
+func (bs *BlockState) shareRuntime(hash common.Hash, instance runtime.Instance) error {
+	version, err := instance.Version()
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	bs.StoreRuntime(hash, instance)
+
+	err = bs.storeRuntimeUpgrade(hash, version.SpecVersion)
+	if err != nil {
+		// The upgrade history is informational only, so the runtime is still used.
+		logger.Errorf("failed to record runtime upgrade for block %s: %s", hash, err)
+	}
+
+	logger.Infof("🔄 runtime upgraded with block %s to spec version %d, shared with another fork",
+		hash, version.SpecVersion)
+	return nil
+}
+
+// waitPendingRuntime waits for the runtimes being compiled for the block
+// hash given or one of its ancestors. A runtime which failed to compile
+// is compiled again, and the error of its compilation is returned if it
+// fails again.
+func (bs *BlockState) waitPendingRuntime(blockHash common.Hash) error {
+	bs.runtimeUpgradesLock.Lock()
+	pendingRuntimes := make(map[common.Hash]*pendingRuntime, len(bs.pendingRuntimes))
+	for hash, pending := range bs.pendingRuntimes {
+		pendingRuntimes[hash] = pending
+	}
+	bs.runtimeUpgradesLock.Unlock()
+
+	for hash, pending := range pendingRuntimes {
+		if hash != blockHash {
+			isDescendant, err := bs.bt.IsDescendantOf(hash, blockHash)
+			if err != nil || !isDescendant {
+				continue
+			}
+		}
+
+		<-pending.done
+		if !pending.failed() {
+			continue
+		}
+
+		logger.Warnf("compiling again runtime of block %s which failed to compile: %s", hash, pending.err)
+		retry := bs.compileRuntime(hash, pending.code, pending.cfg, pending)
+		if retry == nil {
+			// another runtime was stored for the block in the meantime
+			continue
+		}
+
+		<-retry.done
+		if retry.failed() {
+			return fmt.Errorf("compiling runtime of block %s: %w", hash, retry.err)
+		}
+	}
+
+	return nil
+}
+
+// failed returns true if the runtime failed to compile. It must be
+// called once the compilation is done.
+func (p *pendingRuntime) failed() bool {
+	return p.err != nil &&
+		!errors.Is(p.err, errRuntimeSuperseded) &&
+		!errors.Is(p.err, blocktree.ErrNodeNotFound)
+}
+
+// storeRuntimeUpgrade records in the database the upgrade of
+// the runtime to the spec version given by the block given.
+func (bs *BlockState) storeRuntimeUpgrade(hash common.Hash, specVersion uint32) error {
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+
+	bs.runtimeUpgradesLock.Lock()
+	defer bs.runtimeUpgradesLock.Unlock()
+
+	upgrades, err := bs.loadRuntimeUpgrades()
+	if err != nil {
+		return err
+	}
+
+	for _, upgrade := range upgrades {
+		if upgrade.BlockHash == hash {
+			return nil
+		}
+	}
+
+	upgrades = append(upgrades, RuntimeUpgrade{
+		BlockHash:   hash,
+		BlockNumber: header.Number,
+		SpecVersion: specVersion,
+	})
+
+	encoded, err := scale.Marshal(upgrades)
+	if err != nil {
+		return fmt.Errorf("encoding runtime upgrades: %w", err)
+	}
+
+	return bs.db.Put(runtimeUpgradesKey, encoded)
+}
+
+// GetRuntimeUpgrades returns the runtime upgrades enacted by
+// the blocks of the best chain, ordered by block number.
+func (bs *BlockState) GetRuntimeUpgrades() ([]RuntimeUpgrade, error) {
+	bs.runtimeUpgradesLock.Lock()
+	upgrades, err := bs.loadRuntimeUpgrades()
+	bs.runtimeUpgradesLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Upgrades enacted by blocks of other forks are filtered out
+	canonicalUpgrades := make([]RuntimeUpgrade, 0, len(upgrades))
+	for _, upgrade := range upgrades {
+		hash, err := bs.GetHashByNumber(upgrade.BlockNumber)
+		if err != nil || hash != upgrade.BlockHash {
+			continue
+		}
+		canonicalUpgrades = append(canonicalUpgrades, upgrade)
+	}
+
+	slices.SortFunc(canonicalUpgrades, func(a, b RuntimeUpgrade) int {
+		return cmp.Compare(a.BlockNumber, b.BlockNumber)
+	})
+	return canonicalUpgrades, nil
+}
+
+func (bs *BlockState) loadRuntimeUpgrades() (upgrades []RuntimeUpgrade, err error) {
+	encoded, err := bs.db.Get(runtimeUpgradesKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting runtime upgrades: %w", err)
+	}
+
+	err = scale.Unmarshal(encoded, &upgrades)
+	if err != nil {
+		return nil, fmt.Errorf("decoding runtime upgrades: %w", err)
+	}
+	return upgrades, nil
+}
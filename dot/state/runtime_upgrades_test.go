@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockState_RuntimeUpgrades(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	chain, _ := AddBlocksToState(t, bs, 3, false)
+
+	preRuntimeDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 1).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	err = digest.Add(*preRuntimeDigest)
+	require.NoError(t, err)
+	forkBlock := &types.Block{
+		Header: types.Header{
+			ParentHash: bs.GenesisHash(),
+			Number:     1,
+			StateRoot:  trie.EmptyHash,
+			Digest:     digest,
+		},
+		Body: types.Body{},
+	}
+	err = bs.AddBlock(forkBlock)
+	require.NoError(t, err)
+
+	upgrades, err := bs.GetRuntimeUpgrades()
+	require.NoError(t, err)
+	assert.Empty(t, upgrades)
+
+	err = bs.storeRuntimeUpgrade(chain[2].Hash(), 3)
+	require.NoError(t, err)
+	err = bs.storeRuntimeUpgrade(forkBlock.Header.Hash(), 2)
+	require.NoError(t, err)
+	err = bs.storeRuntimeUpgrade(chain[0].Hash(), 1)
+	require.NoError(t, err)
+	// an upgrade recorded twice for the same block is only kept once
+	err = bs.storeRuntimeUpgrade(chain[0].Hash(), 1)
+	require.NoError(t, err)
+
+	err = bs.storeRuntimeUpgrade(common.Hash{1}, 4)
+	require.ErrorContains(t, err, "getting header")
+
+	expectedUpgrades := []RuntimeUpgrade{
+		{BlockHash: chain[0].Hash(), BlockNumber: 1, SpecVersion: 1},
+		{BlockHash: chain[2].Hash(), BlockNumber: 3, SpecVersion: 3},
+	}
+	upgrades, err = bs.GetRuntimeUpgrades()
+	require.NoError(t, err)
+	assert.Equal(t, expectedUpgrades, upgrades)
+}
+
+func TestBlockState_waitPendingRuntime(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	chain, _ := AddBlocksToState(t, bs, 3, false)
+
+	errTest := errors.New("test error")
+	failed := &pendingRuntime{done: make(chan struct{}), err: errTest}
+	close(failed.done)
+	bs.pendingRuntimes[chain[1].Hash()] = failed
+
+	// the runtime of the block parent is not pending
+	err := bs.waitPendingRuntime(chain[0].Hash())
+	require.NoError(t, err)
+
+	// the failed runtime is compiled again, failing again without code
+	err = bs.waitPendingRuntime(chain[1].Hash())
+	require.ErrorContains(t, err, "creating runtime instance")
+	require.NotErrorIs(t, err, errTest)
+
+	err = bs.waitPendingRuntime(chain[2].Hash())
+	require.ErrorContains(t, err, "creating runtime instance")
+
+	// unknown blocks do not descend from the pending runtime block
+	err = bs.waitPendingRuntime(common.Hash{1})
+	require.NoError(t, err)
+
+	// a runtime stored for the block supersedes the pending runtime
+	ctrl := gomock.NewController(t)
+	substituted := NewMockInstance(ctrl)
+	bs.StoreRuntime(chain[1].Hash(), substituted)
+	assert.Empty(t, bs.pendingRuntimes)
+
+	err = bs.waitPendingRuntime(chain[2].Hash())
+	require.NoError(t, err)
+	instance, err := bs.GetRuntime(chain[2].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, substituted, instance)
+}
+
+func TestBlockState_shareRuntime(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	genesisHash := bs.GenesisHash()
+	ctrl := gomock.NewController(t)
+
+	genesisRuntime := NewMockInstance(ctrl)
+	genesisRuntime.EXPECT().GetCodeHash().Return(common.Hash{}).AnyTimes()
+	bs.StoreRuntime(genesisHash, genesisRuntime)
+
+	// both forks enact the same runtime upgrade
+	upgraded := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), genesisHash)
+	fork := AddBlockToState(t, bs, 1, createSecondaryPlainBABEDigest(t), genesisHash)
+
+	upgradedRuntime := NewMockInstance(ctrl)
+	upgradedRuntime.EXPECT().GetCodeHash().Return(common.Hash{1}).AnyTimes()
+	upgradedRuntime.EXPECT().Version().Return(runtime.Version{SpecVersion: 2}, nil)
+	bs.StoreRuntime(upgraded.Hash(), upgradedRuntime)
+
+	instance := bs.bt.GetRuntimeByCodeHash(common.Hash{1})
+	require.Equal(t, upgradedRuntime, instance)
+	err := bs.shareRuntime(fork.Hash(), instance)
+	require.NoError(t, err)
+
+	instance, err = bs.GetRuntime(fork.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, upgradedRuntime, instance)
+	instance, err = bs.GetRuntime(genesisHash)
+	require.NoError(t, err)
+	assert.Equal(t, genesisRuntime, instance)
+
+	// the shared runtime is not stopped when the fork is pruned,
+	// since the finalised block still uses it.
+	err = bs.SetFinalisedHash(upgraded.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	instance, err = bs.GetRuntime(upgraded.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, upgradedRuntime, instance)
+
+	// the genesis runtime no longer used by the block tree is
+	// cached for the finalised blocks enacting its code.
+	instance, release := bs.runtimeCache.get(common.Hash{})
+	assert.Equal(t, genesisRuntime, instance)
+	release()
+}
@@ -6,13 +6,17 @@
 import (
 	"fmt"
 	"path/filepath"
+	"sync"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/internal/memory"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
@@ -24,6 +28,7 @@
 // Service is the struct that holds storage, block and network states
 type Service struct {
 	dbPath            string
+	dbBackend         database.Backend
 	logLvl            log.Level
 	db                database.Database
 	isMemDB           bool // set to true if using an in-memory database; only used for testing.
@@ -36,9 +41,15 @@ type Service struct {
 	Slot              *SlotState
 	closeCh           chan interface{}
 	genesisBABEConfig *types.BabeConfiguration
+	transactionLimits transaction.PoolLimits
+	forkChoice        ForkChoice
+	storageChanges    StorageChangesConfig
+	metrics           metrics.IntervalConfig
 
-	PrunerCfg pruner.Config
-	Telemetry Telemetry
+	// prunerMutex guards PrunerCfg and the pruner of the storage state once started
+	prunerMutex sync.Mutex
+	PrunerCfg   pruner.Config
+	Telemetry   Telemetry
 
 	// Below are for testing only.
 	BabeThresholdNumerator   uint64
@@ -58,6 +69,15 @@ type Config struct {
 	Telemetry         Telemetry
 	Metrics           metrics.IntervalConfig
 	GenesisBABEConfig *types.BabeConfiguration
+	// DatabaseBackend is the backend of the database, defaulting to the backend
+	// recorded in the database directory
+	DatabaseBackend database.Backend
+	// TransactionPoolLimits are the limits of the transaction pool
+	TransactionPoolLimits transaction.PoolLimits
+	// ForkChoice selects the best chain, defaulting to BABEForkChoice
+	ForkChoice ForkChoice
+	// StorageChanges is the configuration of the index of the storage keys modified by each block
+	StorageChanges StorageChangesConfig
 }
 
 // NewService create a new instance of Service
@@ -66,6 +86,7 @@ func NewService(config Config) *Service {
 
 	return &Service{
 		dbPath:            config.Path,
+		dbBackend:         config.DatabaseBackend,
 		logLvl:            config.LogLevel,
 		db:                nil,
 		isMemDB:           false,
@@ -75,6 +96,10 @@ func NewService(config Config) *Service {
 		PrunerCfg:         config.PrunerCfg,
 		Telemetry:         config.Telemetry,
 		genesisBABEConfig: config.GenesisBABEConfig,
+		transactionLimits: config.TransactionPoolLimits,
+		forkChoice:        config.ForkChoice,
+		storageChanges:    config.StorageChanges,
+		metrics:           config.Metrics,
 	}
 }
 
@@ -85,6 +110,41 @@ func (s *Service) UseMemDB() {
 	s.isMemDB = true
 }
 
+// SetPrunerRetainedBlocks rebuilds the online pruner of the running storage state with the
+// number of blocks to retain given.
+func (s *Service) SetPrunerRetainedBlocks(retainedBlocks uint32) error {
+	s.prunerMutex.Lock()
+	defer s.prunerMutex.Unlock()
+
+	config := s.PrunerCfg
+	config.RetainedBlocks = retainedBlocks
+	p, err := pruner.New(config)
+	if err != nil {
+		return fmt.Errorf("building pruner: %w", err)
+	}
+
+	if s.Storage != nil {
+		s.Storage.setPruner(p)
+	}
+	s.PrunerCfg = config
+
+	if _, archive := p.(*pruner.ArchiveNode); archive {
+		logger.Infof("pruner retained blocks set to %d, unused in archive mode where all blocks are retained",
+			retainedBlocks)
+	} else {
+		logger.Infof("pruner retained blocks set to %d", retainedBlocks)
+	}
+	return nil
+}
+
+// loadDatabase loads the database at the basepath using the configured backend if any
+func (s *Service) loadDatabase(basepath string, inMemory bool) (database.Database, error) {
+	if s.dbBackend == "" {
+		return database.LoadDatabase(basepath, inMemory)
+	}
+	return database.LoadDatabaseBackend(basepath, inMemory, s.dbBackend)
+}
+
 // DB returns the Service's database
 func (s *Service) DB() database.Database {
 	return s.db
@@ -103,7 +163,7 @@ func (s *Service) SetupBase() error {
 	}
 
 	// initialise database
-	db, err := database.LoadDatabase(basepath, false)
+	db, err := s.loadDatabase(basepath, false)
 	if err != nil {
 		return err
 	}
@@ -120,6 +180,11 @@ func (s *Service) Start() (err error) {
 		return nil
 	}
 
+	err = runMigrations(s.db, migrations)
+	if err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
 	tries := NewTries()
 	tries.SetEmptyTrie()
 
@@ -128,6 +193,12 @@ func (s *Service) Start() (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to create block state: %w", err)
 	}
+	if s.forkChoice != nil {
+		s.Block.SetForkChoice(s.forkChoice)
+	}
+	if s.storageChanges.Enabled {
+		s.Block.SetStorageChangesIndex(s.db, s.storageChanges.RetainedBlocks)
+	}
 
 	// retrieve latest header
 	bestHeader, err := s.Block.GetHighestFinalisedHeader()
@@ -144,6 +215,16 @@ func (s *Service) Start() (err error) {
 		return fmt.Errorf("failed to create storage state: %w", err)
 	}
 
+	s.prunerMutex.Lock()
+	onlinePruner, err := pruner.New(s.PrunerCfg)
+	if err == nil {
+		s.Storage.setPruner(onlinePruner)
+	}
+	s.prunerMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("creating online pruner: %w", err)
+	}
+
 	// load current storage state trie into memory
 	_, err = s.Storage.LoadFromDB(stateRoot)
 	if err != nil {
@@ -151,7 +232,7 @@ func (s *Service) Start() (err error) {
 	}
 
 	// create transaction queue
-	s.Transaction = NewTransactionState(s.Telemetry)
+	s.Transaction = NewTransactionState(s.Telemetry, s.transactionLimits)
 
 	// create epoch and slot state
 	s.Slot = NewSlotState(s.db)
@@ -167,9 +248,29 @@ func (s *Service) Start() (err error) {
 		"created state service with head %s, highest number %d and genesis hash %s",
 		s.Block.BestBlockHash(), num, s.Block.genesisHash.String())
 
+	if s.metrics.Publish {
+		go s.updateMetrics(s.metrics.Interval)
+	}
+
 	return nil
 }
 
+// SetMemoryBudget accounts the state tries held in memory and the buffers of the imported
+// block channels, used by the RPC block subscriptions, in the memory budget given.
+// It must be called once started.
+func (s *Service) SetMemoryBudget(budget *memory.Budget) {
+	s.Block.tries.accountMemory()
+	budget.Register("state_tries", stateTries{blockState: s.Block})
+	budget.Register("imported_block_channels", importedBlockBuffers{blockState: s.Block})
+}
+
+// SetEventBus publishes the blocks finalised and the transactions made ready
+// on the event bus given. It must be called once started.
+func (s *Service) SetEventBus(bus *events.Bus) {
+	s.Block.eventBus = bus
+	s.Transaction.eventBus = bus
+}
+
 // Rewind rewinds the chain to the given block number.
 // If the given number of blocks is greater than the chain height, it will rewind to genesis.
 func (s *Service) Rewind(toBlock uint) error {
@@ -187,7 +288,10 @@ func (s *Service) Rewind(toBlock uint) error {
 		return err
 	}
 
-	s.Block.bt = blocktree.NewBlockTreeFromRoot(&root.Header)
+	s.Block.bt = s.Block.newBlockTree(&root.Header)
+	if s.Block.forkChoice != nil {
+		s.Block.SetForkChoice(s.Block.forkChoice)
+	}
 
 	header, err := s.Block.BestBlockHeader()
 	if err != nil {
@@ -271,7 +375,7 @@ func (s *Service) Import(header *types.Header, t trie.Trie,
 	var err error
 	// initialise database using data directory
 	if !s.isMemDB {
-		s.db, err = database.LoadDatabase(s.dbPath, s.isMemDB)
+		s.db, err = s.loadDatabase(s.dbPath, s.isMemDB)
 		if err != nil {
 			return fmt.Errorf("failed to create database: %w", err)
 		}
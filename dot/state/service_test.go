@@ -0,0 +1,32 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SetPrunerRetainedBlocks(t *testing.T) {
+	t.Parallel()
+
+	storage := &InmemoryStorageState{}
+	service := &Service{
+		Storage:   storage,
+		PrunerCfg: pruner.Config{Mode: pruner.Archive, RetainedBlocks: 512},
+	}
+
+	err := service.SetPrunerRetainedBlocks(256)
+	require.NoError(t, err)
+	assert.Equal(t, pruner.Config{Mode: pruner.Archive, RetainedBlocks: 256}, service.PrunerCfg)
+	assert.Equal(t, &pruner.ArchiveNode{}, storage.pruner)
+
+	service.PrunerCfg.Mode = "full"
+	err = service.SetPrunerRetainedBlocks(128)
+	assert.ErrorIs(t, err, pruner.ErrInvalidMode)
+	assert.Equal(t, uint32(256), service.PrunerCfg.RetainedBlocks)
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// storageChangesPrefix is the prefix of the table of the storage changes index, where
+// big endian block number || block hash -> SCALE encoded keys modified by the block, sorted.
+const storageChangesPrefix = "storagechanges"
+
+// StorageChangesConfig is the configuration of the storage changes index.
+type StorageChangesConfig struct {
+	// Enabled enables the indexing of the storage keys modified by each block imported.
+	Enabled bool
+	// RetainedBlocks is the number of finalised blocks the storage changes are retained
+	// for, counting back from the last block finalised. The storage changes of all the
+	// finalised blocks are retained if it is zero.
+	RetainedBlocks uint32
+}
+
+// storageChangesIndex indexes the storage keys modified by each block, outside of the state
+// trie, so the blocks modifying a key are found without comparing their state or executing them.
+// It is used by the state_queryStorage and state_getStorageDiff RPC methods. The storage
+// subscriptions do not use it, since they are notified of the changes recorded when each block
+// is imported, which are the changes indexed.
+type storageChangesIndex struct {
+	db             database.Table
+	retainedBlocks uint32
+}
+
+func newStorageChangesIndex(db database.Database, retainedBlocks uint32) *storageChangesIndex {
+	return &storageChangesIndex{
+		db:             database.NewTable(db, storageChangesPrefix),
+		retainedBlocks: retainedBlocks,
+	}
+}
+
+func storageChangesKey(number uint, hash common.Hash) []byte {
+	key := binary.BigEndian.AppendUint64(nil, uint64(number))
+	return append(key, hash[:]...)
+}
+
+// put indexes the keys modified by the block of the header given.
+func (i *storageChangesIndex) put(header *types.Header, changes []KeyValue) error {
+	keys := make([][]byte, len(changes))
+	for j, change := range changes {
+		keys[j] = change.Key
+	}
+
+	encoded, err := scale.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("encoding keys: %w", err)
+	}
+
+	err = i.db.Put(storageChangesKey(header.Number, header.Hash()), encoded)
+	if err != nil {
+		return fmt.Errorf("putting keys of block %s: %w", header.Hash(), err)
+	}
+	return nil
+}
+
+// get returns the keys modified by the block given, sorted,
+// and false if the storage changes of the block are not indexed.
+func (i *storageChangesIndex) get(number uint, hash common.Hash) (keys [][]byte, indexed bool, err error) {
+	encoded, err := i.db.Get(storageChangesKey(number, hash))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("getting keys of block %s: %w", hash, err)
+	}
+
+	err = scale.Unmarshal(encoded, &keys)
+	if err != nil {
+		return nil, false, fmt.Errorf("decoding keys of block %s: %w", hash, err)
+	}
+	return keys, true, nil
+}
+
+func (i *storageChangesIndex) delete(number uint, hash common.Hash) error {
+	err := i.db.Del(storageChangesKey(number, hash))
+	if err != nil {
+		return fmt.Errorf("deleting keys of block %s: %w", hash, err)
+	}
+	return nil
+}
+
+// prune deletes the storage changes of the blocks no longer retained
+// once the block with the number given is finalised.
+func (i *storageChangesIndex) prune(finalisedNumber uint) error {
+	if i.retainedBlocks == 0 || finalisedNumber < uint(i.retainedBlocks) {
+		return nil
+	}
+	end := binary.BigEndian.AppendUint64(nil, uint64(finalisedNumber-uint(i.retainedBlocks)))
+
+	iter, err := i.db.NewPrefixIterator(nil)
+	if err != nil {
+		return fmt.Errorf("creating storage changes iterator: %w", err)
+	}
+	defer iter.Release()
+
+	batch := i.db.NewBatch()
+	defer batch.Close()
+
+	// the keys are ordered by block number, so the blocks
+	// not retained are the first keys of the index.
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := iter.Key()
+		if bytes.Compare(key, end) >= 0 {
+			break
+		}
+
+		err = batch.Del(bytes.Clone(key))
+		if err != nil {
+			return fmt.Errorf("deleting keys: %w", err)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("flushing batch: %w", err)
+	}
+	return nil
+}
+
+// SetStorageChangesIndex enables the indexing of the storage keys modified by the blocks
+// imported, in the database given, retaining the keys modified by the last finalised blocks
+// given, or by all the finalised blocks if it is zero.
+func (bs *BlockState) SetStorageChangesIndex(db database.Database, retainedBlocks uint32) {
+	bs.storageChangesIndex = newStorageChangesIndex(db, retainedBlocks)
+}
+
+// GetChangedKeys returns the storage keys modified by the block with the given hash,
+// sorted, and false if the storage changes index is disabled or if the block storage
+// changes are not indexed, since they were pruned or the block was imported without
+// the index enabled.
+func (bs *BlockState) GetChangedKeys(hash common.Hash) (keys [][]byte, indexed bool, err error) {
+	if bs.storageChangesIndex == nil {
+		return nil, false, nil
+	}
+
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		return nil, false, fmt.Errorf("getting header: %w", err)
+	}
+
+	return bs.storageChangesIndex.get(header.Number, hash)
+}
+
+// indexStorageChanges indexes the keys modified by the block of the header given,
+// if the storage changes index is enabled.
+func (bs *BlockState) indexStorageChanges(header *types.Header, changes []KeyValue) error {
+	if bs.storageChangesIndex == nil {
+		return nil
+	}
+	return bs.storageChangesIndex.put(header, changes)
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_storageChangesIndex_prune(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		retainedBlocks  uint32
+		finalisedNumber uint
+		indexedNumbers  []uint
+	}{
+		"all_blocks_retained": {
+			finalisedNumber: 5,
+			indexedNumbers:  []uint{1, 2, 3, 4, 5},
+		},
+		"fewer_blocks_than_retained": {
+			retainedBlocks:  10,
+			finalisedNumber: 5,
+			indexedNumbers:  []uint{1, 2, 3, 4, 5},
+		},
+		"blocks_pruned": {
+			retainedBlocks:  2,
+			finalisedNumber: 5,
+			indexedNumbers:  []uint{3, 4, 5},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			index := newStorageChangesIndex(NewInMemoryDB(t), testCase.retainedBlocks)
+
+			headers := make([]*types.Header, 5)
+			for i := range headers {
+				headers[i] = &types.Header{Number: uint(i + 1)}
+				err := index.put(headers[i], []KeyValue{{Key: []byte{byte(i)}}})
+				require.NoError(t, err)
+			}
+
+			err := index.prune(testCase.finalisedNumber)
+			require.NoError(t, err)
+
+			var indexedNumbers []uint
+			for _, header := range headers {
+				keys, indexed, err := index.get(header.Number, header.Hash())
+				require.NoError(t, err)
+				if indexed {
+					assert.Equal(t, [][]byte{{byte(header.Number - 1)}}, keys)
+					indexedNumbers = append(indexedNumbers, header.Number)
+				}
+			}
+			assert.Equal(t, testCase.indexedNumbers, indexedNumbers)
+		})
+	}
+}
+
+func TestBlockState_GetChangedKeys(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	genesisHash := bs.GenesisHash()
+
+	finalised := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), genesisHash)
+	fork := AddBlockToState(t, bs, 1, createSecondaryPlainBABEDigest(t), genesisHash)
+	changes := []KeyValue{
+		{Key: []byte("a"), Value: []byte("value")},
+		{Key: []byte("b")},
+	}
+
+	// the storage changes are not indexed if the index is disabled
+	err := bs.indexStorageChanges(finalised, changes)
+	require.NoError(t, err)
+	_, indexed, err := bs.GetChangedKeys(finalised.Hash())
+	require.NoError(t, err)
+	assert.False(t, indexed)
+
+	db := NewInMemoryDB(t)
+	bs.SetStorageChangesIndex(db, 0)
+
+	_, _, err = bs.GetChangedKeys(common.Hash{1})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	_, indexed, err = bs.GetChangedKeys(finalised.Hash())
+	require.NoError(t, err)
+	assert.False(t, indexed)
+
+	for _, header := range []*types.Header{finalised, fork} {
+		err = bs.indexStorageChanges(header, changes)
+		require.NoError(t, err)
+	}
+
+	keys, indexed, err := bs.GetChangedKeys(finalised.Hash())
+	require.NoError(t, err)
+	assert.True(t, indexed)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, keys)
+
+	// the storage changes of the fork are deleted once it is pruned
+	err = bs.SetFinalisedHash(finalised.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	_, indexed, err = bs.storageChangesIndex.get(fork.Number, fork.Hash())
+	require.NoError(t, err)
+	assert.False(t, indexed)
+	_, indexed, err = bs.GetChangedKeys(finalised.Hash())
+	require.NoError(t, err)
+	assert.True(t, indexed)
+}
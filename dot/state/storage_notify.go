@@ -4,6 +4,7 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
 	"strings"
@@ -44,6 +45,38 @@ type Observer interface {
 	GetFilter() map[string][]byte
 }
 
+// PrefixObserver is an Observer notified of the changes of the keys having one of its
+// prefixes, in addition to the changes of the keys of its filter. Unlike the keys of its
+// filter, the keys having one of its prefixes are not notified when it is registered.
+type PrefixObserver interface {
+	Observer
+	GetPrefixes() [][]byte
+}
+
+// isFiltered returns true if the observer given, with the filter given,
+// is only notified of the changes of some keys of the storage.
+func isFiltered(o Observer, filter map[string][]byte) bool {
+	if len(filter) > 0 {
+		return true
+	}
+	prefixObserver, ok := o.(PrefixObserver)
+	return ok && len(prefixObserver.GetPrefixes()) > 0
+}
+
+func hasPrefix(o Observer, key []byte) bool {
+	prefixObserver, ok := o.(PrefixObserver)
+	if !ok {
+		return false
+	}
+
+	for _, prefix := range prefixObserver.GetPrefixes() {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisterStorageObserver to add abserver to notification list
 func (s *InmemoryStorageState) RegisterStorageObserver(o Observer) {
 	s.observerListMutex.Lock()
@@ -57,6 +90,9 @@ func (s *InmemoryStorageState) RegisterStorageObserver(o Observer) {
 		return
 	}
 	go func() {
+		s.notifyMutex.Lock()
+		defer s.notifyMutex.Unlock()
+
 		if err := s.notifyObserver(sr, o); err != nil {
 			logger.Warnf("failed to notify storage subscriptions: %s", err)
 		}
@@ -71,17 +107,91 @@ func (s *InmemoryStorageState) UnregisterStorageObserver(o Observer) {
 	s.observerList = s.removeFromSlice(s.observerList, o)
 }
 
-func (s *InmemoryStorageState) notifyAll(root common.Hash) {
+// changesListened returns true if the storage changes of the tries stored are used,
+// by a storage observer, an import subscription or the storage changes index.
+func (s *InmemoryStorageState) changesListened() bool {
+	s.observerListMutex.RLock()
+	observed := len(s.observerList) > 0
+	s.observerListMutex.RUnlock()
+	if observed {
+		return true
+	}
+
+	if s.blockState == nil {
+		return false
+	}
+	return s.blockState.storageChangesIndex != nil || s.blockState.hasStorageChangesSubscription()
+}
+
+// notifyAll notifies the observers of the storage changes given,
+// without reading the state trie for each observer.
+func (s *InmemoryStorageState) notifyAll(root common.Hash, changes []KeyValue) {
+	// the notifications are serialised, since they update the values cached in the filters
+	s.notifyMutex.Lock()
+	defer s.notifyMutex.Unlock()
+
 	s.observerListMutex.RLock()
 	defer s.observerListMutex.RUnlock()
 	for _, observer := range s.observerList {
-		err := s.notifyObserver(root, observer)
+		err := notifyObserverChanges(root, changes, observer)
 		if err != nil {
 			logger.Warnf("failed to notify storage subscriptions: %s", err)
 		}
 	}
 }
 
+func notifyObserverChanges(root common.Hash, changes []KeyValue, o Observer) error {
+	subRes := &SubscriptionResult{
+		Hash: root,
+	}
+
+	filter := o.GetFilter()
+	if !isFiltered(o, filter) {
+		// no filter, so send all changes
+		for _, change := range changes {
+			if !bytes.Equal(change.Key, codeKey) {
+				// currently we're ignoring :code since this is a lot of data
+				subRes.Changes = append(subRes.Changes, change)
+			}
+		}
+	} else {
+		// filter changes to include only interested keys
+		filterKeys := make(map[string]string, len(filter))
+		for k := range filter {
+			key, err := common.HexToBytes(k)
+			if err != nil {
+				return fmt.Errorf("failed to convert hex to bytes: %s", err)
+			}
+			filterKeys[string(key)] = k
+		}
+
+		for _, change := range changes {
+			k, ok := filterKeys[string(change.Key)]
+			if !ok {
+				if hasPrefix(o, change.Key) {
+					subRes.Changes = append(subRes.Changes, change)
+				}
+				continue
+			}
+
+			if reflect.DeepEqual(filter[k], change.Value) {
+				continue
+			}
+			subRes.Changes = append(subRes.Changes, change)
+			filter[k] = change.Value
+		}
+	}
+
+	if len(subRes.Changes) > 0 {
+		logger.Tracef("update observer, changes are %v", subRes.Changes)
+		go func() {
+			o.Update(subRes)
+		}()
+	}
+
+	return nil
+}
+
 func (s *InmemoryStorageState) notifyObserver(root common.Hash, o Observer) error {
 	t, err := s.TrieState(&root)
 	if err != nil {
@@ -95,7 +205,8 @@ func (s *InmemoryStorageState) notifyObserver(root common.Hash, o Observer) erro
 	subRes := &SubscriptionResult{
 		Hash: root,
 	}
-	if len(o.GetFilter()) == 0 {
+	filter := o.GetFilter()
+	if !isFiltered(o, filter) {
 		// no filter, so send all changes
 		ent := t.TrieEntries()
 		for k, v := range ent {
@@ -110,7 +221,7 @@ func (s *InmemoryStorageState) notifyObserver(root common.Hash, o Observer) erro
 		}
 	} else {
 		// filter result to include only interested keys
-		for k, cachedValue := range o.GetFilter() {
+		for k, cachedValue := range filter {
 			bytes, err := common.HexToBytes(k)
 			if err != nil {
 				return fmt.Errorf("failed to convert hex to bytes: %s", err)
@@ -122,7 +233,7 @@ func (s *InmemoryStorageState) notifyObserver(root common.Hash, o Observer) erro
 					Value: value,
 				}
 				subRes.Changes = append(subRes.Changes, *kv)
-				o.GetFilter()[k] = value
+				filter[k] = value
 			}
 		}
 	}
@@ -18,9 +18,6 @@ func TestStorageState_RegisterStorageObserver(t *testing.T) {
 
 	ss := newTestStorageState(t)
 
-	ts, err := ss.TrieState(nil)
-	require.NoError(t, err)
-
 	mockfilter := map[string][]byte{}
 	mockobs := NewMockObserver(ctrl)
 
@@ -44,6 +41,10 @@ func TestStorageState_RegisterStorageObserver(t *testing.T) {
 	ss.RegisterStorageObserver(mockobs)
 	defer ss.UnregisterStorageObserver(mockobs)
 
+	// the changes are only recorded by the trie states created once an observer is registered
+	ts, err := ss.TrieState(nil)
+	require.NoError(t, err)
+
 	ts.Put([]byte("mackcom"), []byte("wuz here"))
 	err = ss.StoreTrie(ts, nil)
 	require.NoError(t, err)
@@ -58,8 +59,6 @@ func TestStorageState_RegisterStorageObserver_Multi(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	ss := newTestStorageState(t)
-	ts, err := ss.TrieState(nil)
-	require.NoError(t, err)
 
 	num := 5
 
@@ -75,9 +74,11 @@ func TestStorageState_RegisterStorageObserver_Multi(t *testing.T) {
 
 		mocks = append(mocks, mockobs)
 		ss.RegisterStorageObserver(mockobs)
-		require.NoError(t, err)
 	}
 
+	ts, err := ss.TrieState(nil)
+	require.NoError(t, err)
+
 	key1 := []byte("key1")
 	value1 := []byte("value1")
 
@@ -98,8 +99,6 @@ func TestStorageState_RegisterStorageObserver_Multi_Filter(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	ss := newTestStorageState(t)
-	ts, err := ss.TrieState(nil)
-	require.NoError(t, err)
 
 	key1 := []byte("key1")
 	value1 := []byte("value1")
@@ -120,6 +119,9 @@ func TestStorageState_RegisterStorageObserver_Multi_Filter(t *testing.T) {
 		ss.RegisterStorageObserver(mockobs)
 	}
 
+	ts, err := ss.TrieState(nil)
+	require.NoError(t, err)
+
 	ts.Put(key1, value1)
 	err = ss.StoreTrie(ts, nil)
 	require.NoError(t, err)
@@ -130,3 +132,116 @@ func TestStorageState_RegisterStorageObserver_Multi_Filter(t *testing.T) {
 		ss.UnregisterStorageObserver(observer)
 	}
 }
+
+type testPrefixObserver struct {
+	*MockObserver
+	prefixes [][]byte
+}
+
+func (o *testPrefixObserver) GetPrefixes() [][]byte { return o.prefixes }
+
+func Test_notifyObserverChanges(t *testing.T) {
+	t.Parallel()
+
+	root := common.Hash{1}
+	changes := []KeyValue{
+		{Key: []byte(":code"), Value: []byte("code")},
+		{Key: []byte("changed"), Value: []byte("new")},
+		{Key: []byte("deleted")},
+		{Key: []byte("unchanged"), Value: []byte("same")},
+	}
+
+	testCases := map[string]struct {
+		filter         map[string][]byte
+		prefixes       [][]byte
+		expectedResult *SubscriptionResult
+		expectedFilter map[string][]byte
+	}{
+		"no_filter": {
+			filter: map[string][]byte{},
+			expectedResult: &SubscriptionResult{
+				Hash:    root,
+				Changes: changes[1:],
+			},
+			expectedFilter: map[string][]byte{},
+		},
+		"filter": {
+			filter: map[string][]byte{
+				common.BytesToHex([]byte("changed")):   []byte("old"),
+				common.BytesToHex([]byte("deleted")):   []byte("old"),
+				common.BytesToHex([]byte("unchanged")): []byte("same"),
+				common.BytesToHex([]byte("other")):     []byte("old"),
+			},
+			expectedResult: &SubscriptionResult{
+				Hash:    root,
+				Changes: changes[1:3],
+			},
+			expectedFilter: map[string][]byte{
+				common.BytesToHex([]byte("changed")):   []byte("new"),
+				common.BytesToHex([]byte("deleted")):   nil,
+				common.BytesToHex([]byte("unchanged")): []byte("same"),
+				common.BytesToHex([]byte("other")):     []byte("old"),
+			},
+		},
+		"prefixes": {
+			filter:   map[string][]byte{},
+			prefixes: [][]byte{[]byte("del"), []byte("unch")},
+			expectedResult: &SubscriptionResult{
+				Hash:    root,
+				Changes: changes[2:],
+			},
+			expectedFilter: map[string][]byte{},
+		},
+		"filter_and_prefixes": {
+			filter: map[string][]byte{
+				common.BytesToHex([]byte("changed")): []byte("old"),
+			},
+			prefixes: [][]byte{[]byte("del")},
+			expectedResult: &SubscriptionResult{
+				Hash:    root,
+				Changes: changes[1:3],
+			},
+			expectedFilter: map[string][]byte{
+				common.BytesToHex([]byte("changed")): []byte("new"),
+			},
+		},
+		"no_change_watched": {
+			filter: map[string][]byte{
+				common.BytesToHex([]byte("unchanged")): []byte("same"),
+			},
+			expectedFilter: map[string][]byte{
+				common.BytesToHex([]byte("unchanged")): []byte("same"),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockObserver := NewMockObserver(ctrl)
+			mockObserver.EXPECT().GetFilter().Return(testCase.filter)
+			results := make(chan *SubscriptionResult, 1)
+			if testCase.expectedResult != nil {
+				mockObserver.EXPECT().Update(gomock.Any()).Do(func(result *SubscriptionResult) {
+					results <- result
+				})
+			}
+
+			var observer Observer = mockObserver
+			if testCase.prefixes != nil {
+				observer = &testPrefixObserver{MockObserver: mockObserver, prefixes: testCase.prefixes}
+			}
+
+			err := notifyObserverChanges(root, changes, observer)
+			require.NoError(t, err)
+
+			if testCase.expectedResult != nil {
+				require.Equal(t, testCase.expectedResult, <-results)
+			}
+			require.Equal(t, testCase.expectedFilter, testCase.filter)
+		})
+	}
+}
@@ -50,6 +50,16 @@ func createPrimaryBABEDigest(t testing.TB) types.Digest {
 	return digest
 }
 
+func createSecondaryPlainBABEDigest(t testing.TB) types.Digest {
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 2).ToPreRuntimeDigest()
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	err = digest.Add(*preDigest)
+	require.NoError(t, err)
+	return digest
+}
+
 // branch tree randomly
 type testBranch struct {
 	hash  common.Hash
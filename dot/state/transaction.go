@@ -4,99 +4,198 @@
 package state
 
 import (
+	"errors"
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
-// TransactionState represents the queue of transactions
+// TransactionState represents the pool of transactions, split between the ready
+// transactions which can be included in the next block and the future transactions
+// waiting for the transactions providing their required tags.
 type TransactionState struct {
-	queue *transaction.PriorityQueue
-	pool  *transaction.Pool
+	pool *transaction.TaggedPool
 
 	// notifierChannels are used to notify transaction status. It maps a channel to
 	// hex string of the extrinsic it is supposed to notify about.
 	notifierChannels map[chan transaction.Status]string
 	notifierLock     sync.RWMutex
 
+	// eventBus is the bus the transactions made ready are published on, if any
+	eventBus *events.Bus
+
 	telemetry Telemetry
 }
 
-// NewTransactionState returns a new TransactionState
-func NewTransactionState(telemetry Telemetry) *TransactionState {
+// NewTransactionState returns a new TransactionState with the given pool limits
+func NewTransactionState(telemetry Telemetry, limits transaction.PoolLimits) *TransactionState {
 	return &TransactionState{
-		queue:            transaction.NewPriorityQueue(),
-		pool:             transaction.NewPool(),
+		pool:             transaction.NewTaggedPool(limits),
 		notifierChannels: make(map[chan transaction.Status]string),
 		telemetry:        telemetry,
 	}
 }
 
-// Push pushes a transaction to the queue, ordered by priority
+// Push inserts a transaction in the pool, in the ready queue if the tags it requires
+// are provided and in the future queue otherwise. It returns an error if the
+// transaction is already in the pool, is banned or is rejected by the pool limits.
 func (s *TransactionState) Push(vt *transaction.ValidTransaction) (common.Hash, error) {
-	s.notifyStatus(vt.Extrinsic, transaction.Ready)
-	return s.queue.Push(vt)
+	hash := vt.Extrinsic.Hash()
+	result, err := s.pool.Insert(vt)
+	for _, tx := range result.Evicted {
+		s.notifyStatus(tx.Extrinsic, transaction.Dropped)
+	}
+	if err != nil {
+		return hash, err
+	}
+
+	s.notifyStatus(vt.Extrinsic, result.Status)
+	for _, tx := range result.Promoted {
+		s.notifyStatus(tx.Extrinsic, transaction.Ready)
+	}
+
+	if result.Status == transaction.Ready {
+		events.Publish(s.eventBus, events.TransactionReady{})
+	}
+
+	s.telemetry.SendMessage(
+		telemetry.NewTxpoolImport(uint(s.pool.ReadyLen()), uint(s.pool.FutureLen())),
+	)
+	return hash, nil
 }
 
-// Pop removes and returns the head of the queue
+// Pop removes and returns the ready transaction with the highest priority
 func (s *TransactionState) Pop() *transaction.ValidTransaction {
-	return s.queue.Pop()
+	return s.pool.Pop()
 }
 
-// PopWithTimer returns the next valid transaction from the queue.
+// PopWithTimer returns the next ready transaction from the pool.
 // When the timer expires, it returns `nil`.
 func (s *TransactionState) PopWithTimer(timerCh <-chan time.Time) (transaction *transaction.ValidTransaction) {
-	return s.queue.PopWithTimer(timerCh)
+	return s.pool.PopWithTimer(timerCh)
 }
 
-// Peek returns the head of the queue without removing it
+// Peek returns the next ready transaction without removing it
 func (s *TransactionState) Peek() *transaction.ValidTransaction {
-	return s.queue.Peek()
+	return s.pool.Peek()
 }
 
-// Pending returns the current transactions in the queue and pool
+// Pending returns the current ready and future transactions in the pool
 func (s *TransactionState) Pending() []*transaction.ValidTransaction {
-	return append(s.queue.Pending(), s.pool.Transactions()...)
-}
-
-// PendingInPool returns the current transactions in the pool
-func (s *TransactionState) PendingInPool() []*transaction.ValidTransaction {
-	return s.pool.Transactions()
+	return s.pool.Pending()
 }
 
-// Exists returns true if an extrinsic is already in the pool or queue, false otherwise
+// Exists returns true if an extrinsic is already in the pool, false otherwise
 func (s *TransactionState) Exists(ext types.Extrinsic) bool {
-	hash := ext.Hash()
-	return s.pool.Get(hash) != nil || s.queue.Exists(hash)
+	return s.pool.Exists(ext.Hash())
 }
 
-// RemoveExtrinsic removes an extrinsic from the queue and pool
+// RemoveExtrinsic removes an extrinsic from the pool
 func (s *TransactionState) RemoveExtrinsic(ext types.Extrinsic) {
 	s.pool.Remove(ext.Hash())
-	s.queue.RemoveExtrinsic(ext)
 }
 
-// RemoveExtrinsicFromPool removes an extrinsic from the pool
-func (s *TransactionState) RemoveExtrinsicFromPool(ext types.Extrinsic) {
-	s.pool.Remove(ext.Hash())
+// AddToPool inserts a transaction in the pool, see Push, and returns its hash
+func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Hash {
+	hash, err := s.Push(vt)
+	if err != nil {
+		logger.Tracef("not adding transaction with hash %s to pool: %s", hash, err)
+	}
+	return hash
 }
 
-// AddToPool adds a transaction to the pool
-func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Hash {
-	s.notifyStatus(vt.Extrinsic, transaction.Future)
+// PruneExpired drops the transactions whose longevity ended before the given best
+// block number, which is used as the insertion block number of new transactions.
+func (s *TransactionState) PruneExpired(bestNumber uint) {
+	for _, tx := range s.pool.PruneExpired(bestNumber) {
+		logger.Debugf("dropping expired transaction for extrinsic %s", tx.Extrinsic)
+		s.notifyStatus(tx.Extrinsic, transaction.Dropped)
+	}
+}
 
-	hash := s.pool.Insert(vt)
+// Ban temporarily bans an invalid extrinsic, rejecting it from the pool
+// without having to validate it again.
+func (s *TransactionState) Ban(ext types.Extrinsic) {
+	s.pool.Ban(ext.Hash())
+}
 
-	s.telemetry.SendMessage(
-		telemetry.NewTxpoolImport(uint(s.queue.Len()), uint(s.pool.Len())),
-	)
+// RemoveInvalid bans the transactions with the given extrinsic hashes and removes them,
+// with the transactions depending on them, from the pool. It returns the hashes of the
+// removed transactions.
+func (s *TransactionState) RemoveInvalid(hashes ...common.Hash) (removed []common.Hash) {
+	s.pool.Ban(hashes...)
 
-	return hash
+	for _, tx := range s.pool.RemoveWithDependents(hashes...) {
+		s.notifyStatus(tx.Extrinsic, transaction.Invalid)
+		removed = append(removed, tx.Extrinsic.Hash())
+	}
+	return removed
+}
+
+// IsBanned returns true if the extrinsic is temporarily banned
+func (s *TransactionState) IsBanned(ext types.Extrinsic) bool {
+	return s.pool.IsBanned(ext.Hash())
+}
+
+// Revalidate revalidates every transaction of the pool with the validate function,
+// usually on a new best block, and re-inserts the valid transactions in the ready or
+// future queue according to their updated tags. Transactions failing validation are
+// dropped, and only banned if the runtime reports them as invalid.
+func (s *TransactionState) Revalidate(validate func(ext types.Extrinsic) (*transaction.ValidTransaction, error)) {
+	txs := s.pool.Pending()
+	previousStatuses := make(map[common.Hash]transaction.Status, len(txs))
+	for _, tx := range txs {
+		previousStatuses[tx.Extrinsic.Hash()], _ = s.pool.Status(tx.Extrinsic.Hash())
+	}
+
+	txs = s.pool.Clear()
+	var valid []*transaction.ValidTransaction
+	for _, tx := range txs {
+		vt, err := validate(tx.Extrinsic)
+		var invalidTransaction runtime.InvalidTransaction
+		switch {
+		case errors.As(err, &invalidTransaction):
+			logger.Debugf("dropping invalid transaction for extrinsic %s: %s", tx.Extrinsic, err)
+			s.pool.Ban(tx.Extrinsic.Hash())
+			s.notifyStatus(tx.Extrinsic, transaction.Invalid)
+			continue
+		case err != nil:
+			logger.Debugf("dropping transaction for extrinsic %s failing validation: %s", tx.Extrinsic, err)
+			s.notifyStatus(tx.Extrinsic, transaction.Dropped)
+			continue
+		}
+
+		_, err = s.pool.Insert(vt)
+		if err != nil {
+			s.notifyStatus(tx.Extrinsic, transaction.Dropped)
+			continue
+		}
+		valid = append(valid, vt)
+	}
+
+	becameReady := false
+	for _, tx := range valid {
+		hash := tx.Extrinsic.Hash()
+		status, ok := s.pool.Status(hash)
+		if !ok || status == previousStatuses[hash] {
+			continue
+		}
+
+		// transactions are promoted or demoted between the ready and future queues
+		s.notifyStatus(tx.Extrinsic, status)
+		becameReady = becameReady || status == transaction.Ready
+	}
+
+	if becameReady {
+		events.Publish(s.eventBus, events.TransactionReady{})
+	}
 }
 
 // GetStatusNotifierChannel creates and returns a status notifier channel.
@@ -4,13 +4,14 @@
 package state
 
 import (
-	"math/rand"
+	"errors"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"go.uber.org/mock/gomock"
 
@@ -22,7 +23,7 @@ func TestTransactionState_Pending(t *testing.T) {
 	telemetryMock := NewMockTelemetry(ctrl)
 	telemetryMock.EXPECT().SendMessage(gomock.Any()).Times(5)
 
-	ts := NewTransactionState(telemetryMock)
+	ts := NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
 	txs := []*transaction.ValidTransaction{
 		{
@@ -53,22 +54,15 @@ func TestTransactionState_Pending(t *testing.T) {
 		hashes[i] = h
 	}
 
-	pendingPool := ts.PendingInPool()
-
-	sort.Slice(pendingPool, func(i, j int) bool {
-		return pendingPool[i].Extrinsic[0] < pendingPool[j].Extrinsic[0]
-	})
-	require.Equal(t, pendingPool, txs)
-
 	pending := ts.Pending()
 	sort.Slice(pending, func(i, j int) bool {
 		return pending[i].Extrinsic[0] < pending[j].Extrinsic[0]
 	})
 	require.Equal(t, pending, txs)
 
-	// queue should be empty
+	// transactions without requirements are ready
 	head := ts.Peek()
-	require.Nil(t, head)
+	require.Equal(t, txs[3], head)
 }
 
 func TestTransactionState_NotifierChannels(t *testing.T) {
@@ -76,50 +70,168 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	telemetryMock := NewMockTelemetry(ctrl)
 	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
 
-	ts := NewTransactionState(telemetryMock)
+	ts := NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
-	ext := types.Extrinsic{}
-	notifierChannel := ts.GetStatusNotifierChannel(ext)
-	defer ts.FreeStatusNotifierChannel(notifierChannel)
+	futureTx := transaction.NewValidTransaction(types.Extrinsic{1},
+		transaction.NewValidity(0, [][]byte{{1}}, [][]byte{{2}}, 0, false))
+	readyTx := transaction.NewValidTransaction(types.Extrinsic{2},
+		transaction.NewValidity(0, nil, [][]byte{{1}}, 0, false))
 
-	// number of "future" status updates
-	var futureCount int
-	// number of "ready" status updates
-	var readyCount int
+	futureChannel := ts.GetStatusNotifierChannel(futureTx.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(futureChannel)
+	readyChannel := ts.GetStatusNotifierChannel(readyTx.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(readyChannel)
 
-	rand.Seed(time.Now().UnixNano()) //nolint:staticcheck
+	ts.AddToPool(futureTx)
+	_, err := ts.Push(readyTx)
+	require.NoError(t, err)
 
-	// In practice, one won't see ready and future in this order. This is merely
-	// meant to check that notifier channels work as expected.
-	expectedFutureCount := rand.Intn(10) + 10
-	expectedReadyCount := rand.Intn(5) + 5
+	_, err = ts.Push(readyTx)
+	require.ErrorIs(t, err, transaction.ErrTransactionExists)
 
-	dummyTransactions := make([]*transaction.ValidTransaction, expectedFutureCount)
-
-	for i := 0; i < expectedFutureCount; i++ {
-		dummyTransactions[i] = &transaction.ValidTransaction{
-			Extrinsic: ext,
-			Validity:  transaction.NewValidity(0, [][]byte{{}}, [][]byte{{}}, 0, false),
-		}
+	close(futureChannel)
+	close(readyChannel)
 
-		ts.AddToPool(dummyTransactions[i])
+	var futureStatuses, readyStatuses []transaction.Status
+	for status := range futureChannel {
+		futureStatuses = append(futureStatuses, status)
 	}
-
-	for i := 0; i < expectedReadyCount; i++ {
-		ts.Push(dummyTransactions[i])
+	for status := range readyChannel {
+		readyStatuses = append(readyStatuses, status)
 	}
 
-	close(notifierChannel)
+	// the future transaction is promoted once its required tag is provided
+	require.Equal(t, []transaction.Status{transaction.Future, transaction.Ready}, futureStatuses)
+	require.Equal(t, []transaction.Status{transaction.Ready}, readyStatuses)
+}
+
+func TestTransactionState_Revalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	ts := NewTransactionState(telemetryMock, transaction.PoolLimits{BanDuration: time.Minute})
 
-	for status := range notifierChannel {
-		if status.String() == transaction.Future.String() {
-			futureCount++
+	txs := []*transaction.ValidTransaction{
+		transaction.NewValidTransaction(types.Extrinsic{1},
+			transaction.NewValidity(0, nil, [][]byte{{1}}, 0, false)),
+		transaction.NewValidTransaction(types.Extrinsic{2},
+			transaction.NewValidity(0, [][]byte{{1}}, [][]byte{{2}}, 0, false)),
+		transaction.NewValidTransaction(types.Extrinsic{3},
+			transaction.NewValidity(0, [][]byte{{2}}, [][]byte{{3}}, 0, false)),
+		transaction.NewValidTransaction(types.Extrinsic{4},
+			transaction.NewValidity(0, nil, [][]byte{{5}}, 0, false)),
+	}
+	for _, tx := range txs {
+		_, err := ts.Push(tx)
+		require.NoError(t, err)
+	}
+
+	invalidChannel := ts.GetStatusNotifierChannel(txs[0].Extrinsic)
+	defer ts.FreeStatusNotifierChannel(invalidChannel)
+	demotedChannel := ts.GetStatusNotifierChannel(txs[2].Extrinsic)
+	defer ts.FreeStatusNotifierChannel(demotedChannel)
+
+	invalidTransaction := runtime.NewInvalidTransaction()
+	err := invalidTransaction.SetValue(runtime.Stale{})
+	require.NoError(t, err)
+
+	// the first transaction is included in a block so its dependent transaction
+	// requires nothing, the third transaction now requires an unknown tag and the
+	// validation of the last transaction fails without the runtime reporting it invalid
+	validities := map[string]*transaction.Validity{
+		txs[1].Extrinsic.String(): transaction.NewValidity(0, nil, [][]byte{{2}}, 0, false),
+		txs[2].Extrinsic.String(): transaction.NewValidity(0, [][]byte{{4}}, [][]byte{{3}}, 0, false),
+	}
+	ts.Revalidate(func(ext types.Extrinsic) (*transaction.ValidTransaction, error) {
+		if ext.String() == txs[0].Extrinsic.String() {
+			return nil, invalidTransaction
 		}
-		if status.String() == transaction.Ready.String() {
-			readyCount++
+		validity, ok := validities[ext.String()]
+		if !ok {
+			return nil, errors.New("validation failed")
 		}
+		return transaction.NewValidTransaction(ext, validity), nil
+	})
+
+	close(invalidChannel)
+	close(demotedChannel)
+	require.Equal(t, transaction.Invalid, <-invalidChannel)
+	require.Equal(t, transaction.Future, <-demotedChannel)
+
+	require.False(t, ts.Exists(txs[0].Extrinsic))
+	require.True(t, ts.IsBanned(txs[0].Extrinsic))
+	require.False(t, ts.Exists(txs[3].Extrinsic))
+	require.False(t, ts.IsBanned(txs[3].Extrinsic))
+	require.Equal(t, txs[1].Extrinsic, ts.Pop().Extrinsic)
+	require.Nil(t, ts.Pop())
+	require.True(t, ts.Exists(txs[2].Extrinsic))
+}
+
+func TestTransactionState_PruneExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	ts := NewTransactionState(telemetryMock, transaction.PoolLimits{MaxCount: 1})
+
+	expiring := transaction.NewValidTransaction(types.Extrinsic{1},
+		transaction.NewValidity(1, nil, nil, 1, false))
+	evicting := transaction.NewValidTransaction(types.Extrinsic{2},
+		transaction.NewValidity(2, nil, nil, 0, false))
+
+	expiringChannel := ts.GetStatusNotifierChannel(expiring.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(expiringChannel)
+
+	_, err := ts.Push(expiring)
+	require.NoError(t, err)
+	ts.PruneExpired(1)
+	require.True(t, ts.Exists(expiring.Extrinsic))
+	ts.PruneExpired(2)
+	require.False(t, ts.Exists(expiring.Extrinsic))
+
+	// the lowest priority transaction is evicted from the full pool
+	_, err = ts.Push(expiring)
+	require.NoError(t, err)
+	_, err = ts.Push(evicting)
+	require.NoError(t, err)
+	require.False(t, ts.Exists(expiring.Extrinsic))
+
+	close(expiringChannel)
+	var statuses []transaction.Status
+	for status := range expiringChannel {
+		statuses = append(statuses, status)
 	}
+	require.Equal(t, []transaction.Status{
+		transaction.Ready, transaction.Dropped, transaction.Ready, transaction.Dropped,
+	}, statuses)
+}
+
+func TestTransactionState_RemoveInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	ts := NewTransactionState(telemetryMock, transaction.PoolLimits{BanDuration: time.Minute})
+
+	invalid := transaction.NewValidTransaction(types.Extrinsic{1},
+		transaction.NewValidity(0, nil, [][]byte{{1}}, 0, false))
+	dependent := transaction.NewValidTransaction(types.Extrinsic{2},
+		transaction.NewValidity(0, [][]byte{{1}}, nil, 0, false))
+	for _, tx := range []*transaction.ValidTransaction{invalid, dependent} {
+		_, err := ts.Push(tx)
+		require.NoError(t, err)
+	}
+
+	dependentChannel := ts.GetStatusNotifierChannel(dependent.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(dependentChannel)
+
+	removed := ts.RemoveInvalid(invalid.Extrinsic.Hash())
+	require.Equal(t, []common.Hash{invalid.Extrinsic.Hash(), dependent.Extrinsic.Hash()}, removed)
+	require.Empty(t, ts.Pending())
+	require.True(t, ts.IsBanned(invalid.Extrinsic))
+	require.False(t, ts.IsBanned(dependent.Extrinsic))
 
-	require.Equal(t, expectedFutureCount, futureCount)
-	require.Equal(t, expectedReadyCount, readyCount)
+	close(dependentChannel)
+	require.Equal(t, transaction.Invalid, <-dependentChannel)
 }
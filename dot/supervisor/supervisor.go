@@ -0,0 +1,219 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package supervisor runs the goroutines of the node services, recovering
+// their panics, restarting them with a backoff when they fail and keeping
+// track of their health.
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "supervisor"))
+
+// ErrPanic is wrapped in the failures of the services recovered from a panic.
+var ErrPanic = errors.New("service panicked")
+
+// State is the state of a supervised service.
+type State string
+
+const (
+	// Running is the state of a service running its task.
+	Running State = "running"
+	// Restarting is the state of a failed service waiting for its backoff to restart.
+	Restarting State = "restarting"
+	// Stopped is the state of a service stopped or whose task completed.
+	Stopped State = "stopped"
+	// Failed is the state of a failed service which is not restarted.
+	Failed State = "failed"
+)
+
+// Policy is the restart policy of a supervised service.
+type Policy struct {
+	// Restart is true to restart the service each time it fails.
+	Restart bool
+	// MinBackoff is the delay before restarting the service after a failure.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay before restarting the service, the delay doubling
+	// at each consecutive failure. It is reset once the service ran for MaxBackoff.
+	MaxBackoff time.Duration
+	// MaxRestarts is the maximum number of consecutive restarts after which
+	// the service is left failed, zero for no maximum.
+	MaxRestarts uint
+	// OnRestart is an optional hook called with the failure and the backoff
+	// each time the service is about to be restarted.
+	OnRestart func(err error, backoff time.Duration)
+}
+
+// DefaultPolicy returns the policy restarting a failed service after a second,
+// doubling the delay at each consecutive failure up to a minute.
+func DefaultPolicy() Policy {
+	return Policy{
+		Restart:    true,
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+	}
+}
+
+// Supervisor runs the tasks of the node services and keeps track of their health.
+// A nil supervisor runs the tasks the same way without keeping track of their health.
+type Supervisor struct {
+	mutex    sync.Mutex
+	services map[string]*common.ServiceHealth
+}
+
+// New creates a supervisor.
+func New() *Supervisor {
+	return &Supervisor{
+		services: make(map[string]*common.ServiceHealth),
+	}
+}
+
+// Run runs the task of the service with the name given in the calling goroutine,
+// until the task returns without error or the stop channel is closed. Each time the
+// task fails or panics, it is restarted following the policy given.
+func (s *Supervisor) Run(name string, policy Policy, stop <-chan struct{}, task func() error) {
+	backoff := policy.MinBackoff
+	var restarts uint
+	for {
+		s.setState(name, Running)
+		startedAt := time.Now()
+		err := runTask(task)
+		if err == nil || isClosed(stop) {
+			s.setState(name, Stopped)
+			return
+		}
+
+		s.recordFailure(name, err)
+		if time.Since(startedAt) >= policy.MaxBackoff {
+			backoff = policy.MinBackoff
+			restarts = 0
+		}
+
+		if !policy.Restart || (policy.MaxRestarts > 0 && restarts >= policy.MaxRestarts) {
+			logger.Errorf("service %s failed: %s", name, err)
+			s.setState(name, Failed)
+			return
+		}
+
+		logger.Errorf("service %s failed, restarting in %s: %s", name, backoff, err)
+		s.setState(name, Restarting)
+		if policy.OnRestart != nil {
+			policy.OnRestart(err, backoff)
+		}
+
+		backoffTimer := time.NewTimer(backoff)
+		select {
+		case <-backoffTimer.C:
+		case <-stop:
+			backoffTimer.Stop()
+			s.setState(name, Stopped)
+			return
+		}
+
+		restarts++
+		s.update(name, func(health *common.ServiceHealth) {
+			health.Restarts++
+		})
+		backoff = min(2*backoff, policy.MaxBackoff)
+	}
+}
+
+// Recover recovers the panic of the goroutine it is deferred in, recording it as
+// a failure of the service with the name given. It must be deferred directly, for
+// goroutines of a service which keeps running when one of them fails, such as
+// the stream handlers of the network service.
+func (s *Supervisor) Recover(name string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err := fmt.Errorf("%w: %v", ErrPanic, r)
+	logger.Errorf("service %s recovered from failure: %s\n%s", name, err, debug.Stack())
+	s.recordFailure(name, err)
+}
+
+// Health returns the health of the supervised services, sorted by name.
+func (s *Supervisor) Health() []common.ServiceHealth {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	health := make([]common.ServiceHealth, 0, len(s.services))
+	for _, service := range s.services {
+		health = append(health, *service)
+	}
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].Name < health[j].Name
+	})
+	return health
+}
+
+func (s *Supervisor) setState(name string, state State) {
+	s.update(name, func(health *common.ServiceHealth) {
+		health.State = string(state)
+	})
+}
+
+func (s *Supervisor) recordFailure(name string, err error) {
+	s.update(name, func(health *common.ServiceHealth) {
+		health.Failures++
+		health.LastError = err.Error()
+	})
+}
+
+// update updates the health of the service with the name given, adding
+// the service as running if it is not known yet.
+func (s *Supervisor) update(name string, update func(health *common.ServiceHealth)) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	health, ok := s.services[name]
+	if !ok {
+		health = &common.ServiceHealth{
+			Name:  name,
+			State: string(Running),
+		}
+		s.services[name] = health
+	}
+	update(health)
+}
+
+// runTask runs the task given, returning its panic as an error wrapping ErrPanic.
+func runTask(task func() error) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		err = fmt.Errorf("%w: %v", ErrPanic, r)
+		logger.Debugf("recovered panic: %s\n%s", err, debug.Stack())
+	}()
+	return task()
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package supervisor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisor_Run(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	// newTask returns a task running the steps given in order, a nil step
+	// returning no error, and the last step being repeated.
+	newTask := func(steps ...func() error) func() error {
+		var calls int
+		return func() error {
+			step := steps[min(calls, len(steps)-1)]
+			calls++
+			if step == nil {
+				return nil
+			}
+			return step()
+		}
+	}
+	fail := func() error { return errTest }
+	panics := func() error { panic("test panic") }
+
+	testCases := map[string]struct {
+		policy   Policy
+		task     func() error
+		stop     bool
+		health   common.ServiceHealth
+		backoffs []time.Duration
+	}{
+		"task_completes": {
+			policy: DefaultPolicy(),
+			task:   newTask(nil),
+			health: common.ServiceHealth{
+				Name:  "test",
+				State: string(Stopped),
+			},
+		},
+		"failure_not_restarted": {
+			task: newTask(fail),
+			health: common.ServiceHealth{
+				Name:      "test",
+				State:     string(Failed),
+				Failures:  1,
+				LastError: "test error",
+			},
+		},
+		"panic_restarted": {
+			policy: Policy{
+				Restart:    true,
+				MinBackoff: time.Millisecond,
+				MaxBackoff: time.Hour,
+			},
+			task: newTask(panics, fail, nil),
+			health: common.ServiceHealth{
+				Name:      "test",
+				State:     string(Stopped),
+				Failures:  2,
+				Restarts:  2,
+				LastError: "test error",
+			},
+			backoffs: []time.Duration{time.Millisecond, 2 * time.Millisecond},
+		},
+		"backoff_capped": {
+			policy: Policy{
+				Restart:    true,
+				MinBackoff: time.Millisecond,
+				MaxBackoff: 2 * time.Millisecond,
+			},
+			task: newTask(panics, panics, panics, nil),
+			health: common.ServiceHealth{
+				Name:      "test",
+				State:     string(Stopped),
+				Failures:  3,
+				Restarts:  3,
+				LastError: "service panicked: test panic",
+			},
+			backoffs: []time.Duration{time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond},
+		},
+		"max_restarts_reached": {
+			policy: Policy{
+				Restart:     true,
+				MinBackoff:  time.Millisecond,
+				MaxBackoff:  time.Hour,
+				MaxRestarts: 2,
+			},
+			task: newTask(fail),
+			health: common.ServiceHealth{
+				Name:      "test",
+				State:     string(Failed),
+				Failures:  3,
+				Restarts:  2,
+				LastError: "test error",
+			},
+			backoffs: []time.Duration{time.Millisecond, 2 * time.Millisecond},
+		},
+		"stopped_during_backoff": {
+			policy: Policy{
+				Restart:    true,
+				MinBackoff: time.Hour,
+				MaxBackoff: time.Hour,
+			},
+			task: newTask(fail),
+			stop: true,
+			health: common.ServiceHealth{
+				Name:      "test",
+				State:     string(Stopped),
+				Failures:  1,
+				LastError: "test error",
+			},
+			backoffs: []time.Duration{time.Hour},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			stop := make(chan struct{})
+			var backoffs []time.Duration
+			policy := testCase.policy
+			policy.OnRestart = func(err error, backoff time.Duration) {
+				backoffs = append(backoffs, backoff)
+				if testCase.stop {
+					close(stop)
+				}
+			}
+
+			supervisor := New()
+			supervisor.Run("test", policy, stop, testCase.task)
+
+			assert.Equal(t, []common.ServiceHealth{testCase.health}, supervisor.Health())
+			assert.Equal(t, testCase.backoffs, backoffs)
+		})
+	}
+}
+
+func TestSupervisor_Run_stopped(t *testing.T) {
+	t.Parallel()
+
+	stop := make(chan struct{})
+	supervisor := New()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		supervisor.Run("test", DefaultPolicy(), stop, func() error {
+			<-stop
+			return errors.New("stopping")
+		})
+	}()
+
+	close(stop)
+	<-done
+
+	expected := []common.ServiceHealth{{Name: "test", State: string(Stopped)}}
+	assert.Equal(t, expected, supervisor.Health())
+}
+
+func TestSupervisor_Recover(t *testing.T) {
+	t.Parallel()
+
+	supervisor := New()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer supervisor.Recover("handler")
+			panic("test panic")
+		}()
+	}
+	wg.Wait()
+
+	func() {
+		defer supervisor.Recover("other")
+	}()
+
+	expected := []common.ServiceHealth{{
+		Name:      "handler",
+		State:     string(Running),
+		Failures:  2,
+		LastError: "service panicked: test panic",
+	}}
+	assert.Equal(t, expected, supervisor.Health())
+}
+
+func TestSupervisor_Health(t *testing.T) {
+	t.Parallel()
+
+	supervisor := New()
+	supervisor.Run("sync", Policy{}, nil, func() error { return nil })
+	supervisor.Run("babe", Policy{}, nil, func() error { return errors.New("test error") })
+
+	expected := []common.ServiceHealth{
+		{Name: "babe", State: string(Failed), Failures: 1, LastError: "test error"},
+		{Name: "sync", State: string(Stopped)},
+	}
+	assert.Equal(t, expected, supervisor.Health())
+}
+
+func TestSupervisor_nil(t *testing.T) {
+	t.Parallel()
+
+	var supervisor *Supervisor
+	var calls int
+	policy := Policy{Restart: true, MinBackoff: time.Millisecond, MaxBackoff: time.Hour}
+	supervisor.Run("test", policy, nil, func() error {
+		calls++
+		if calls == 1 {
+			panic("test panic")
+		}
+		return nil
+	})
+	require.Equal(t, 2, calls)
+
+	func() {
+		defer supervisor.Recover("test")
+		panic("test panic")
+	}()
+
+	assert.Nil(t, supervisor.Health())
+}
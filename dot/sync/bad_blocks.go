@@ -0,0 +1,125 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// maxInvalidatedBlocks is the maximum number of invalidated blocks kept, so
+// peers announcing forks of bad blocks cannot grow the set without bound.
+const maxInvalidatedBlocks = 4096
+
+// badBlockSet is the set of the blocks rejected when received from peers.
+type badBlockSet struct {
+	mutex sync.RWMutex
+	// configured are the bad blocks from the genesis or added at runtime.
+	configured map[common.Hash]struct{}
+	// invalidated are the blocks found to be bad, since they descend from
+	// a bad block or are on a fork competing with the finalised chain.
+	invalidated map[common.Hash]struct{}
+	// invalidatedOrder is the order in which blocks were invalidated,
+	// to forget the oldest of them first.
+	invalidatedOrder []common.Hash
+}
+
+func newBadBlockSet(hashes ...common.Hash) *badBlockSet {
+	configured := make(map[common.Hash]struct{}, len(hashes))
+	for _, hash := range hashes {
+		configured[hash] = struct{}{}
+	}
+
+	return &badBlockSet{
+		configured:  configured,
+		invalidated: make(map[common.Hash]struct{}),
+	}
+}
+
+func (s *badBlockSet) add(hash common.Hash) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.configured[hash] = struct{}{}
+}
+
+// invalidate adds the hash given to the bad blocks,
+// without listing it in the configured bad blocks.
+func (s *badBlockSet) invalidate(hash common.Hash) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.addInvalidated(hash)
+}
+
+// addInvalidated adds the hash given to the invalidated blocks, forgetting the
+// oldest invalidated block if there are more than maxInvalidatedBlocks.
+// It must be called with the mutex locked.
+func (s *badBlockSet) addInvalidated(hash common.Hash) {
+	if _, ok := s.invalidated[hash]; ok {
+		return
+	}
+
+	s.invalidated[hash] = struct{}{}
+	s.invalidatedOrder = append(s.invalidatedOrder, hash)
+	if len(s.invalidatedOrder) > maxInvalidatedBlocks {
+		delete(s.invalidated, s.invalidatedOrder[0])
+		s.invalidatedOrder = s.invalidatedOrder[1:]
+	}
+}
+
+// remove removes the hash given from the configured bad blocks. The invalidated
+// blocks are cleared since they may descend from the removed block.
+func (s *badBlockSet) remove(hash common.Hash) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.configured, hash)
+	s.invalidated = make(map[common.Hash]struct{})
+	s.invalidatedOrder = nil
+}
+
+// list returns the configured bad blocks, sorted by hash.
+func (s *badBlockSet) list() (hashes []common.Hash) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	hashes = make([]common.Hash, 0, len(s.configured))
+	for hash := range s.configured {
+		hashes = append(hashes, hash)
+	}
+
+	slices.SortFunc(hashes, func(a, b common.Hash) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return hashes
+}
+
+// isBad returns true if the block hash or its parent hash is a bad block.
+// A block descending from a bad block is invalidated, so its own descendants
+// are found to be bad too.
+func (s *badBlockSet) isBad(hash, parentHash common.Hash) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.contains(hash) {
+		return true
+	}
+
+	if s.contains(parentHash) {
+		s.addInvalidated(hash)
+		return true
+	}
+
+	return false
+}
+
+func (s *badBlockSet) contains(hash common.Hash) bool {
+	_, configured := s.configured[hash]
+	_, invalidated := s.invalidated[hash]
+	return configured || invalidated
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_badBlockSet(t *testing.T) {
+	t.Parallel()
+
+	set := newBadBlockSet(common.Hash{2}, common.Hash{1})
+	assert.Equal(t, []common.Hash{{1}, {2}}, set.list())
+
+	assert.True(t, set.isBad(common.Hash{1}, common.Hash{}))
+	assert.False(t, set.isBad(common.Hash{3}, common.Hash{}))
+
+	// descendants of a bad block are bad blocks
+	assert.True(t, set.isBad(common.Hash{3}, common.Hash{1}))
+	assert.True(t, set.isBad(common.Hash{4}, common.Hash{3}))
+	// but they are not listed
+	assert.Equal(t, []common.Hash{{1}, {2}}, set.list())
+
+	set.invalidate(common.Hash{5})
+	assert.True(t, set.isBad(common.Hash{5}, common.Hash{}))
+
+	set.add(common.Hash{6})
+	assert.Equal(t, []common.Hash{{1}, {2}, {6}}, set.list())
+
+	// removing a bad block clears the invalidated blocks
+	set.remove(common.Hash{1})
+	assert.Equal(t, []common.Hash{{2}, {6}}, set.list())
+	assert.False(t, set.isBad(common.Hash{1}, common.Hash{}))
+	assert.False(t, set.isBad(common.Hash{4}, common.Hash{3}))
+	assert.False(t, set.isBad(common.Hash{5}, common.Hash{}))
+}
+
+func Test_badBlockSet_invalidatedCapacity(t *testing.T) {
+	t.Parallel()
+
+	set := newBadBlockSet()
+	for i := 0; i <= maxInvalidatedBlocks; i++ {
+		set.invalidate(common.Hash{byte(i), byte(i >> 8)})
+	}
+	set.invalidate(common.Hash{1})
+
+	assert.Len(t, set.invalidated, maxInvalidatedBlocks)
+	// the oldest invalidated block is forgotten
+	assert.False(t, set.isBad(common.Hash{}, common.Hash{0xff, 0xff}))
+	assert.True(t, set.isBad(common.Hash{1}, common.Hash{0xff, 0xff}))
+	assert.True(t, set.isBad(common.Hash{0, 0x10}, common.Hash{0xff, 0xff}))
+}
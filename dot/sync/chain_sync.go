@@ -5,6 +5,7 @@
 
 import (
 	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
 	"strings"
@@ -17,6 +18,7 @@
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/exp/slices"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
@@ -40,8 +42,15 @@
 const (
 	networkInitialSync blockOrigin = iota
 	networkBroadcast
+	// networkInitialSyncAncient are the blocks of the initial sync committed
+	// to a justified block of the syncing chain, which are not BABE verified.
+	networkInitialSyncAncient
 )
 
+// batchTelemetryInterval is the minimum interval between two telemetry
+// messages summarising the batches of blocks synced.
+const batchTelemetryInterval = time.Minute
+
 func (s chainSyncState) String() string {
 	switch s {
 	case bootstrap:
@@ -70,9 +79,19 @@ func (s chainSyncState) String() string {
 
 // ChainSync contains the methods used by the high-level service into the `chainSync` module
 type ChainSync interface {
-	start()
+	start() error
 	stop() error
 
+	// setEventBus sets the event bus the blocks finalised and
+	// the peers disconnected are received from, before starting
+	setEventBus(bus *events.Bus)
+
+	// restart restarts syncing after a failure
+	restart() error
+
+	// failures returns the channel the errors failing the syncing are sent on
+	failures() <-chan error
+
 	// called upon receiving a BlockAnnounceHandshake
 	onBlockAnnounceHandshake(p peer.ID, hash common.Hash, number uint) error
 
@@ -109,7 +128,12 @@ type chainSync struct {
 
 	syncMode atomic.Value
 
-	finalisedCh <-chan *types.FinalisationInfo
+	// eventBus is the bus the blocks finalised and the peers disconnected are received from.
+	eventBus *events.Bus
+	// finalised notifies the blocks finalised, below which the pending blocks are removed.
+	finalised *events.Subscription[events.BlockFinalised]
+	// disconnected notifies the peers disconnected, which are removed from the workers.
+	disconnected *events.Subscription[events.PeerDisconnected]
 
 	minPeers     int
 	slotDuration time.Duration
@@ -118,62 +142,134 @@ type chainSync struct {
 	transactionState   TransactionState
 	babeVerifier       BabeVerifier
 	finalityGadget     FinalityGadget
+	justifications     *justificationQueue
 	blockImportHandler BlockImportHandler
 	telemetry          Telemetry
-	badBlocks          []string
+	badBlocks          *badBlockSet
 	requestMaker       network.RequestMaker
 	waitPeersDuration  time.Duration
+	importStats        importStats
+	// tipRequestRacers is the number of peers a single block
+	// request is sent to in tip sync mode, to use the first
+	// valid response received. It is disabled if lower than 2.
+	tipRequestRacers uint
+	// verifyAncientBlocks is set to BABE verify the blocks of the initial
+	// sync which are committed to a justified block of the syncing chain.
+	verifyAncientBlocks bool
+	// checkpoint is the trusted checkpoint the chain is synced to, nil if there is none.
+	checkpoint *Checkpoint
+	// failuresCh receives the error failing the syncing goroutines, for the
+	// sync service to restart the syncing.
+	failuresCh chan error
+	// batchTelemetry enables the telemetry messages summarising the
+	// batches of blocks synced in bootstrap mode.
+	batchTelemetry bool
+	// lastBatchTelemetry is the time the last batch telemetry message was sent.
+	lastBatchTelemetry time.Time
+	// batchSummary accumulates the batches synced since the last batch telemetry message.
+	batchSummary syncBatchSummary
+}
+
+// syncBatchSummary accumulates the batches of blocks synced in bootstrap mode
+// since the last batch telemetry message was sent.
+type syncBatchSummary struct {
+	startBlock   uint
+	endBlock     uint
+	peers        map[peer.ID]struct{}
+	retries      uint
+	retrieveTime time.Duration
+	totalTime    time.Duration
+}
+
+// add adds the batch of blocks given to the summary.
+func (s *syncBatchSummary) add(startBlock, endBlock uint, peers map[peer.ID]struct{}, retries uint,
+	retrieveTime, totalTime time.Duration) {
+	if s.peers == nil {
+		s.startBlock = startBlock
+		s.endBlock = endBlock
+		s.peers = make(map[peer.ID]struct{}, len(peers))
+	}
+	s.startBlock = min(s.startBlock, startBlock)
+	s.endBlock = max(s.endBlock, endBlock)
+	for who := range peers {
+		s.peers[who] = struct{}{}
+	}
+	s.retries += retries
+	s.retrieveTime += retrieveTime
+	s.totalTime += totalTime
 }
 
 type chainSyncConfig struct {
-	bs                 BlockState
-	net                Network
-	requestMaker       network.RequestMaker
-	pendingBlocks      DisjointBlockSet
-	minPeers, maxPeers int
-	slotDuration       time.Duration
-	storageState       StorageState
-	transactionState   TransactionState
-	babeVerifier       BabeVerifier
-	finalityGadget     FinalityGadget
-	blockImportHandler BlockImportHandler
-	telemetry          Telemetry
-	badBlocks          []string
-	waitPeersDuration  time.Duration
+	bs                  BlockState
+	net                 Network
+	requestMaker        network.RequestMaker
+	pendingBlocks       DisjointBlockSet
+	minPeers, maxPeers  int
+	slotDuration        time.Duration
+	storageState        StorageState
+	transactionState    TransactionState
+	babeVerifier        BabeVerifier
+	finalityGadget      FinalityGadget
+	blockImportHandler  BlockImportHandler
+	telemetry           Telemetry
+	badBlocks           *badBlockSet
+	waitPeersDuration   time.Duration
+	tipRequestRacers    uint
+	verifyAncientBlocks bool
+	checkpoint          *Checkpoint
+	workerPool          syncWorkerPoolConfig
+	batchTelemetry      bool
 }
 
 func newChainSync(cfg chainSyncConfig) *chainSync {
 	atomicState := atomic.Value{}
 	atomicState.Store(tip)
+	stopCh := make(chan struct{})
+
+	peerViewSet := newPeerViewSet(cfg.maxPeers)
+	if cfg.checkpoint != nil {
+		// the chain is synced at least up to the checkpoint block
+		peerViewSet.target = cfg.checkpoint.Header.Number
+	}
+
 	return &chainSync{
-		stopCh:             make(chan struct{}),
-		storageState:       cfg.storageState,
-		transactionState:   cfg.transactionState,
-		babeVerifier:       cfg.babeVerifier,
-		finalityGadget:     cfg.finalityGadget,
-		blockImportHandler: cfg.blockImportHandler,
-		telemetry:          cfg.telemetry,
-		blockState:         cfg.bs,
-		network:            cfg.net,
-		peerViewSet:        newPeerViewSet(cfg.maxPeers),
-		pendingBlocks:      cfg.pendingBlocks,
-		syncMode:           atomicState,
-		finalisedCh:        cfg.bs.GetFinalisedNotifierChannel(),
-		minPeers:           cfg.minPeers,
-		slotDuration:       cfg.slotDuration,
-		workerPool:         newSyncWorkerPool(cfg.net, cfg.requestMaker),
-		badBlocks:          cfg.badBlocks,
-		requestMaker:       cfg.requestMaker,
-		waitPeersDuration:  cfg.waitPeersDuration,
+		stopCh:              stopCh,
+		storageState:        cfg.storageState,
+		transactionState:    cfg.transactionState,
+		babeVerifier:        cfg.babeVerifier,
+		finalityGadget:      cfg.finalityGadget,
+		justifications:      newJustificationQueue(cfg.finalityGadget, cfg.net, stopCh),
+		blockImportHandler:  cfg.blockImportHandler,
+		telemetry:           cfg.telemetry,
+		blockState:          cfg.bs,
+		network:             cfg.net,
+		peerViewSet:         peerViewSet,
+		pendingBlocks:       cfg.pendingBlocks,
+		syncMode:            atomicState,
+		minPeers:            cfg.minPeers,
+		slotDuration:        cfg.slotDuration,
+		workerPool:          newSyncWorkerPool(cfg.net, cfg.requestMaker, cfg.workerPool),
+		badBlocks:           cfg.badBlocks,
+		requestMaker:        cfg.requestMaker,
+		waitPeersDuration:   cfg.waitPeersDuration,
+		tipRequestRacers:    cfg.tipRequestRacers,
+		verifyAncientBlocks: cfg.verifyAncientBlocks,
+		checkpoint:          cfg.checkpoint,
+		failuresCh:          make(chan error, 1),
+		batchTelemetry:      cfg.batchTelemetry,
 	}
 }
 
-func (cs *chainSync) waitWorkersAndTarget() {
+func (cs *chainSync) setEventBus(bus *events.Bus) {
+	cs.eventBus = bus
+}
+
+func (cs *chainSync) waitWorkersAndTarget() error {
 	waitPeersTimer := time.NewTimer(cs.waitPeersDuration)
 
 	highestFinalizedHeader, err := cs.blockState.GetHighestFinalisedHeader()
 	if err != nil {
-		panic(fmt.Sprintf("failed to get highest finalised header: %v", err))
+		return fmt.Errorf("getting highest finalised header: %w", err)
 	}
 
 	for {
@@ -182,7 +278,7 @@ func (cs *chainSync) waitWorkersAndTarget() {
 
 		if totalAvailable >= uint(cs.minPeers) &&
 			cs.peerViewSet.getTarget() > 0 {
-			return
+			return nil
 		}
 
 		err := cs.network.BlockAnnounceHandshake(highestFinalizedHeader)
@@ -195,23 +291,90 @@ func (cs *chainSync) waitWorkersAndTarget() {
 			waitPeersTimer.Reset(cs.waitPeersDuration)
 
 		case <-cs.stopCh:
-			return
+			return nil
 		}
 	}
 }
 
-func (cs *chainSync) start() {
+func (cs *chainSync) start() error {
 	// since the default status from sync mode is syncMode(tip)
 	isSyncedGauge.Set(1)
 
+	cs.finalised = events.SubscribeBlocking[events.BlockFinalised](cs.eventBus)
+	cs.wg.Add(1)
+	go cs.pendingBlocks.run(cs.finalised.Events(), cs.stopCh, &cs.wg)
+
+	cs.disconnected = events.Subscribe[events.PeerDisconnected](cs.eventBus)
 	cs.wg.Add(1)
-	go cs.pendingBlocks.run(cs.finalisedCh, cs.stopCh, &cs.wg)
+	go cs.removeDisconnectedPeers()
+
+	cs.wg.Add(1)
+	go cs.justifications.run(&cs.wg)
 
 	// wait until we have a minimal workers in the sync worker pool
-	cs.waitWorkersAndTarget()
+	err := cs.waitWorkersAndTarget()
+	if err != nil {
+		return err
+	}
+
+	cs.wg.Add(1)
+	go cs.repairGaps()
+	return nil
+}
+
+// restart restarts the syncing in tip mode after a failure, waiting for the
+// minimal workers and switching to bootstrap mode if the node is far behind.
+func (cs *chainSync) restart() error {
+	cs.syncMode.Store(tip)
+	isSyncedGauge.Set(1)
+
+	err := cs.waitWorkersAndTarget()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-cs.stopCh:
+		return nil
+	default:
+	}
+
+	bestBlockHeader, err := cs.blockState.BestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+
+	if cs.isBootstrapSync(bestBlockHeader.Number) {
+		cs.switchToBootstrap()
+	}
+	return nil
+}
+
+func (cs *chainSync) failures() <-chan error {
+	return cs.failuresCh
+}
+
+// fail sends the error failing the syncing to the sync service. The error is dropped
+// if a failure is already waiting, since the syncing is restarted only once for both.
+func (cs *chainSync) fail(err error) {
+	select {
+	case cs.failuresCh <- err:
+	default:
+		logger.Errorf("chain sync failure already pending, dropping: %s", err)
+	}
+}
+
+// recoverFailure recovers a panic of the calling syncing goroutine, sending it as a failure.
+func (cs *chainSync) recoverFailure() {
+	if r := recover(); r != nil {
+		cs.fail(fmt.Errorf("%w: %v", errChainSyncPanic, r))
+	}
 }
 
 func (cs *chainSync) stop() error {
+	cs.finalised.Unsubscribe()
+	cs.disconnected.Unsubscribe()
+
 	err := cs.workerPool.stop()
 	if err != nil {
 		return fmt.Errorf("stopping worker poll: %w", err)
@@ -237,6 +400,25 @@ func (cs *chainSync) stop() error {
 	}
 }
 
+// removeDisconnectedPeers removes the peers disconnected from the workers and the
+// peer views, so no block is requested from them until they connect again.
+func (cs *chainSync) removeDisconnectedPeers() {
+	defer cs.wg.Done()
+
+	for {
+		select {
+		case event, ok := <-cs.disconnected.Events():
+			if !ok {
+				return
+			}
+			cs.peerViewSet.remove(event.ID)
+			cs.workerPool.removeWorker(event.ID)
+		case <-cs.stopCh:
+			return
+		}
+	}
+}
+
 func (cs *chainSync) isBootstrapSync(currentBlockNumber uint) bool {
 	syncTarget := cs.peerViewSet.getTarget()
 	return currentBlockNumber+network.MaxBlocksInResponse < syncTarget
@@ -244,9 +426,12 @@ func (cs *chainSync) isBootstrapSync(currentBlockNumber uint) bool {
 
 func (cs *chainSync) bootstrapSync() {
 	defer cs.wg.Done()
+	defer cs.recoverFailure()
+
 	currentBlock, err := cs.blockState.GetHighestFinalisedHeader()
 	if err != nil {
-		panic("cannot find highest finalised header")
+		cs.fail(fmt.Errorf("getting highest finalised header: %w", err))
+		return
 	}
 
 	for {
@@ -271,13 +456,21 @@ func (cs *chainSync) bootstrapSync() {
 
 			currentBlock, err = cs.blockState.BestBlockHeader()
 			if err != nil {
-				logger.Errorf("getting best block header: %v", err)
+				cs.fail(fmt.Errorf("getting best block header: %w", err))
+				return
 			}
 		} else {
 			// we are less than 128 blocks behind the target we can use tip sync
 			cs.syncMode.Store(tip)
 			isSyncedGauge.Set(1)
 			logger.Infof("🔁 switched sync mode to %s", tip.String())
+
+			// peers only serving block requests are not needed anymore, release
+			// their slots so the peerset can rebalance towards gossip peers
+			released := cs.network.ReleaseSyncOnlyPeers(cs.minPeers)
+			if released > 0 {
+				logger.Debugf("released %d sync only peers", released)
+			}
 			return
 		}
 	}
@@ -307,13 +500,21 @@ func (cs *chainSync) onBlockAnnounceHandshake(who peer.ID, bestHash common.Hash,
 	}
 
 	// we are more than 128 blocks behind the head, switch to bootstrap
-	cs.syncMode.Store(bootstrap)
+	cs.switchToBootstrap()
+	return nil
+}
+
+// switchToBootstrap switches the sync mode to bootstrap and starts the bootstrap sync,
+// unless another caller, such as a concurrent block announce handshake, already did.
+func (cs *chainSync) switchToBootstrap() {
+	if !cs.syncMode.CompareAndSwap(tip, bootstrap) {
+		return
+	}
 	isSyncedGauge.Set(0)
 	logger.Infof("🔁 switched sync mode to %s", bootstrap.String())
 
 	cs.wg.Add(1)
 	go cs.bootstrapSync()
-	return nil
 }
 
 func (cs *chainSync) onBlockAnnounce(announced announcedBlock) error {
@@ -364,6 +565,18 @@ func (cs *chainSync) requestAnnouncedBlock(bestBlockHeader *types.Header, announ
 		return fmt.Errorf("getting highest finalized header")
 	}
 
+	isBad, err := cs.isBadBlock(announce.header, highestFinalizedHeader.Number)
+	if err != nil {
+		return fmt.Errorf("checking if announced block is bad: %w", err)
+	} else if isBad {
+		// the announcing peer may not have seen the finalisation yet
+		// so the block is only ignored
+		logger.Debugf("ignoring bad block #%d (%s) announced by %s",
+			announcedNumber, announcedHash, peerWhoAnnounced)
+		cs.pendingBlocks.removeBlock(announcedHash)
+		return nil
+	}
+
 	// if the announced block contains a lower number than our best
 	// block header, let's check if it is greater than our latests
 	// finalized header, if so this block belongs to a fork chain
@@ -390,6 +603,38 @@ func (cs *chainSync) requestAnnouncedBlock(bestBlockHeader *types.Header, announ
 	return nil
 }
 
+// isBadBlock returns true if the block is a bad block, descends from one or is
+// on a fork competing with the finalised chain or the checkpoint. A block found
+// on a competing fork is added to the bad blocks, so its descendants are found
+// to be bad too.
+func (cs *chainSync) isBadBlock(header *types.Header, highestFinalizedNumber uint) (bool, error) {
+	hash := header.Hash()
+	if cs.badBlocks.isBad(hash, header.ParentHash) {
+		return true, nil
+	}
+
+	if cs.checkpoint.conflicts(header) {
+		cs.badBlocks.invalidate(hash)
+		return true, nil
+	}
+
+	if header.Number > highestFinalizedNumber {
+		return false, nil
+	}
+
+	finalizedHash, err := cs.blockState.GetHashByNumber(header.Number)
+	if err != nil {
+		return false, fmt.Errorf("getting finalized block hash: %w", err)
+	}
+
+	if hash == finalizedHash {
+		return false, nil
+	}
+
+	cs.badBlocks.invalidate(hash)
+	return true, nil
+}
+
 func (cs *chainSync) requestChainBlocks(announcedHeader, bestBlockHeader *types.Header,
 	peerWhoAnnounced peer.ID) error {
 	gapLength := uint32(announcedHeader.Number - bestBlockHeader.Number)
@@ -414,8 +659,15 @@ func (cs *chainSync) requestChainBlocks(announcedHeader, bestBlockHeader *types.
 			peerWhoAnnounced, announcedHeader.Number, announcedHeader.Hash().Short())
 	}
 
-	resultsQueue := make(chan *syncTaskResult)
-	err := cs.submitRequest(request, &peerWhoAnnounced, resultsQueue)
+	// buffered for the result of the request submitted, since each result handled
+	// submits at most one request again, so results are never sent blocking.
+	resultsQueue := make(chan *syncTaskResult, 1)
+	var err error
+	if totalBlocks == 1 {
+		err = cs.submitSingleBlockRequest(request, peerWhoAnnounced, resultsQueue)
+	} else {
+		err = cs.submitRequest(request, &peerWhoAnnounced, resultsQueue)
+	}
 	if err != nil {
 		return err
 	}
@@ -455,8 +707,14 @@ func (cs *chainSync) requestForkBlocks(bestBlockHeader, highestFinalizedHeader,
 	logger.Infof("requesting %d fork blocks from peer: %v starting at #%d (%s)",
 		gapLength, peerWhoAnnounced, announcedHeader.Number, announcedHash.Short())
 
-	resultsQueue := make(chan *syncTaskResult)
-	err = cs.submitRequest(request, &peerWhoAnnounced, resultsQueue)
+	// buffered for the result of the request submitted, since each result handled
+	// submits at most one request again, so results are never sent blocking.
+	resultsQueue := make(chan *syncTaskResult, 1)
+	if parentExists {
+		err = cs.submitSingleBlockRequest(request, peerWhoAnnounced, resultsQueue)
+	} else {
+		err = cs.submitRequest(request, &peerWhoAnnounced, resultsQueue)
+	}
 	if err != nil {
 		return err
 	}
@@ -476,39 +734,47 @@ func (cs *chainSync) requestPendingBlocks(highestFinalizedHeader *types.Header)
 	}
 
 	pendingBlocks := cs.pendingBlocks.getBlocks()
+	gapBlocks := make([]*pendingBlock, 0, len(pendingBlocks))
 	for _, pendingBlock := range pendingBlocks {
 		if pendingBlock.number <= highestFinalizedHeader.Number {
 			cs.pendingBlocks.removeBlock(pendingBlock.hash)
 			continue
 		}
 
+		if pendingBlock.header == nil {
+			gapBlocks = append(gapBlocks, pendingBlock)
+			continue
+		}
+
 		parentExists, err := cs.blockState.HasHeader(pendingBlock.header.ParentHash)
 		if err != nil {
 			return fmt.Errorf("getting pending block parent header: %w", err)
 		}
 
 		if parentExists {
-			err := cs.handleReadyBlock(pendingBlock.toBlockData(), networkBroadcast)
+			err := cs.handleReadyBlock(pendingBlock.toBlockData(), networkBroadcast, "")
 			if err != nil {
 				return fmt.Errorf("handling ready block: %w", err)
 			}
 			continue
 		}
 
-		gapLength := pendingBlock.number - highestFinalizedHeader.Number
-		if gapLength > 128 {
-			logger.Warnf("gap of %d blocks, max expected: 128 block", gapLength)
-			gapLength = 128
-		}
+		gapBlocks = append(gapBlocks, pendingBlock)
+	}
 
-		descendingGapRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(pendingBlock.hash),
-			uint32(gapLength), network.BootstrapRequestData, network.Descending)
-		startAtBlock := pendingBlock.number - uint(*descendingGapRequest.Max) + 1
+	gapBlockNumbers := make(map[common.Hash]uint, len(gapBlocks))
+	for _, gapBlock := range gapBlocks {
+		gapBlockNumbers[gapBlock.hash] = gapBlock.number
+	}
+
+	for _, descendingGapRequest := range gapRequests(gapBlocks, highestFinalizedHeader.Number) {
+		startNumber := gapBlockNumbers[descendingGapRequest.StartingBlock.Hash()]
+		startAtBlock := startNumber - uint(*descendingGapRequest.Max) + 1
 
 		// the `requests` in the tip sync are not related necessarily
 		// this is why we need to treat them separately
-		resultsQueue := make(chan *syncTaskResult)
-		err = cs.submitRequest(descendingGapRequest, nil, resultsQueue)
+		resultsQueue := make(chan *syncTaskResult, 1)
+		err := cs.submitRequest(descendingGapRequest, nil, resultsQueue)
 		if err != nil {
 			return err
 		}
@@ -524,13 +790,85 @@ func (cs *chainSync) requestPendingBlocks(highestFinalizedHeader *types.Header)
 	return nil
 }
 
+// gapRequests returns the descending block requests filling the gaps between
+// the highest finalized block and the pending blocks given, whose parents are
+// unknown. Pending blocks forming a contiguous range, linked by their parent
+// hashes, share a single request from the highest block of the range, since
+// it also fills the gap below the others.
+func gapRequests(gapBlocks []*pendingBlock, highestFinalizedNumber uint) (
+	requests []*network.BlockRequestMessage) {
+	gapBlocksByHash := make(map[common.Hash]*pendingBlock, len(gapBlocks))
+	for _, gapBlock := range gapBlocks {
+		gapBlocksByHash[gapBlock.hash] = gapBlock
+	}
+
+	hasGapChild := make(map[common.Hash]struct{}, len(gapBlocks))
+	for _, gapBlock := range gapBlocks {
+		if gapBlock.header == nil {
+			continue
+		}
+		if _, ok := gapBlocksByHash[gapBlock.header.ParentHash]; ok {
+			hasGapChild[gapBlock.header.ParentHash] = struct{}{}
+		}
+	}
+
+	// start from the highest blocks of the ranges, in descending order
+	// of block number so the requests are deterministic
+	sortedGapBlocks := slices.Clone(gapBlocks)
+	slices.SortFunc(sortedGapBlocks, func(a, b *pendingBlock) int {
+		if a.number != b.number {
+			return cmp.Compare(b.number, a.number)
+		}
+		return bytes.Compare(a.hash[:], b.hash[:])
+	})
+
+	covered := make(map[common.Hash]struct{}, len(gapBlocks))
+	for _, highestBlock := range sortedGapBlocks {
+		if _, ok := hasGapChild[highestBlock.hash]; ok {
+			continue
+		}
+
+		gapBlock := highestBlock
+		for gapBlock != nil {
+			if _, ok := covered[gapBlock.hash]; ok {
+				// the rest of the range is filled by the request
+				// of another range sharing its lower blocks
+				break
+			}
+
+			gapLength := gapBlock.number - highestFinalizedNumber
+			if gapLength > 128 {
+				logger.Warnf("gap of %d blocks, max expected: 128 block", gapLength)
+				gapLength = 128
+			}
+
+			request := network.NewBlockRequest(*variadic.MustNewUint32OrHash(gapBlock.hash),
+				uint32(gapLength), network.BootstrapRequestData, network.Descending)
+			requests = append(requests, request)
+
+			// skip the blocks of the range filled by this request
+			lowestRequested := gapBlock.number - uint(gapLength) + 1
+			for gapBlock != nil && gapBlock.number >= lowestRequested {
+				covered[gapBlock.hash] = struct{}{}
+				if gapBlock.header == nil {
+					gapBlock = nil
+					break
+				}
+				gapBlock = gapBlocksByHash[gapBlock.header.ParentHash]
+			}
+		}
+	}
+
+	return requests
+}
+
 func (cs *chainSync) requestMaxBlocksFrom(bestBlockHeader *types.Header, origin blockOrigin) error { //nolint:unparam
 	startRequestAt := bestBlockHeader.Number + 1
 
 	// targetBlockNumber is the virtual target we will request, however
 	// we should bound it to the real target which is collected through
 	// block announces received from other peers
-	targetBlockNumber := startRequestAt + maxRequestsAllowed*128
+	targetBlockNumber := startRequestAt + cs.workerPool.maxRequests*128
 	realTarget := cs.peerViewSet.getTarget()
 
 	if targetBlockNumber > realTarget {
@@ -571,10 +909,34 @@ func (cs *chainSync) submitRequest(
 	return fmt.Errorf("submitting request: %w", errBlockStatePaused)
 }
 
+// submitSingleBlockRequest submits the request of a single announced block. The request
+// is raced to multiple peers if enabled, to minimise the latency to import the block.
+func (cs *chainSync) submitSingleBlockRequest(
+	request *network.BlockRequestMessage,
+	who peer.ID,
+	resultCh chan<- *syncTaskResult,
+) error {
+	if cs.tipRequestRacers < 2 {
+		return cs.submitRequest(request, &who, resultCh)
+	}
+
+	if !cs.blockState.IsPaused() {
+		return cs.workerPool.raceRequest(request, who, cs.tipRequestRacers, resultCh)
+	}
+	return fmt.Errorf("submitting request: %w", errBlockStatePaused)
+}
+
+// submitRequests submits the requests striped across the workers,
+// without asking a peer for blocks beyond its best block.
 func (cs *chainSync) submitRequests(requests []*network.BlockRequestMessage) (
 	resultCh chan *syncTaskResult, err error) {
 	if !cs.blockState.IsPaused() {
-		return cs.workerPool.submitRequests(requests), nil
+		peerViews := cs.peerViewSet.values()
+		peerBests := make(map[peer.ID]uint, len(peerViews))
+		for _, view := range peerViews {
+			peerBests[view.who] = view.number
+		}
+		return cs.workerPool.submitRequests(requests, peerBests), nil
 	}
 	return nil, fmt.Errorf("submitting requests: %w", errBlockStatePaused)
 }
@@ -605,6 +967,10 @@ func (cs *chainSync) showSyncStats(syncBegin time.Time, syncedBlocks int) {
 		finalisedHeader.Hash().Short(),
 		cs.getSyncMode().String(),
 	)
+
+	for _, timings := range cs.importStats.takeSlowest() {
+		logger.Debugf("slowest imports: %s", timings)
+	}
 }
 
 // handleWorkersResults, every time we submit requests to workers they results should be computed here
@@ -616,8 +982,16 @@ func (cs *chainSync) handleWorkersResults(
 	workersResults chan *syncTaskResult, origin blockOrigin, startAtBlock uint, expectedSyncedBlocks uint32) error {
 	startTime := time.Now()
 	syncingChain := make([]*types.BlockData, expectedSyncedBlocks)
+	// the peers each block of the syncing chain was received from
+	senders := make([]peer.ID, expectedSyncedBlocks)
 	// the total numbers of blocks is missing in the syncing chain
 	waitingBlocks := expectedSyncedBlocks
+	// the index in the syncing chain of the next block to import
+	nextToImport := 0
+	// the peers the blocks were received from and the number of
+	// requests submitted again, for the batch telemetry
+	peersUsed := make(map[peer.ID]struct{})
+	var retries uint
 
 taskResultLoop:
 	for waitingBlocks > 0 {
@@ -660,6 +1034,7 @@ func (cs *chainSync) handleWorkersResults(
 				}
 
 				// TODO: avoid the same peer to get the same task
+				retries++
 				err := cs.submitRequest(request, nil, workersResults)
 				if err != nil {
 					return err
@@ -684,6 +1059,7 @@ func (cs *chainSync) handleWorkersResults(
 					}, who)
 				}
 
+				retries++
 				err = cs.submitRequest(taskResult.request, nil, workersResults)
 				if err != nil {
 					return err
@@ -694,6 +1070,7 @@ func (cs *chainSync) handleWorkersResults(
 			isChain := isResponseAChain(response.BlockData)
 			if !isChain {
 				logger.Criticalf("response from %s is not a chain", who)
+				retries++
 				err = cs.submitRequest(taskResult.request, nil, workersResults)
 				if err != nil {
 					return err
@@ -705,6 +1082,7 @@ func (cs *chainSync) handleWorkersResults(
 				startAtBlock, expectedSyncedBlocks)
 			if !grows {
 				logger.Criticalf("response from %s does not grows the ongoing chain", who)
+				retries++
 				err = cs.submitRequest(taskResult.request, nil, workersResults)
 				if err != nil {
 					return err
@@ -713,7 +1091,8 @@ func (cs *chainSync) handleWorkersResults(
 			}
 
 			for _, blockInResponse := range response.BlockData {
-				if slices.Contains(cs.badBlocks, blockInResponse.Hash.String()) {
+				if cs.badBlocks.isBad(blockInResponse.Hash, blockInResponse.Header.ParentHash) ||
+					cs.checkpoint.conflicts(blockInResponse.Header) {
 					logger.Criticalf("%s sent a known bad block: %s (#%d)",
 						who, blockInResponse.Hash.String(), blockInResponse.Number())
 
@@ -723,6 +1102,7 @@ func (cs *chainSync) handleWorkersResults(
 					}, who)
 
 					cs.workerPool.ignorePeerAsWorker(taskResult.who)
+					retries++
 					err = cs.submitRequest(taskResult.request, nil, workersResults)
 					if err != nil {
 						return err
@@ -730,14 +1110,44 @@ func (cs *chainSync) handleWorkersResults(
 					continue taskResultLoop
 				}
 
+				if blockInResponse.Justification != nil {
+					err = cs.finalityGadget.CheckJustification(*blockInResponse.Justification)
+					if err != nil {
+						logger.Criticalf("%s sent a malformed justification for block %s (#%d): %s",
+							who, blockInResponse.Hash.String(), blockInResponse.Number(), err)
+
+						cs.network.ReportPeer(peerset.ReputationChange{
+							Value:  peerset.BadJustificationValue,
+							Reason: peerset.BadJustificationReason,
+						}, who)
+
+						retries++
+						err = cs.submitRequest(taskResult.request, nil, workersResults)
+						if err != nil {
+							return err
+						}
+						continue taskResultLoop
+					}
+				}
+
 				blockExactIndex := blockInResponse.Header.Number - startAtBlock
 				syncingChain[blockExactIndex] = blockInResponse
+				senders[blockExactIndex] = who
 			}
 
+			peersUsed[who] = struct{}{}
+
 			// we need to check if we've filled all positions
 			// otherwise we should wait for more responses
 			waitingBlocks -= uint32(len(response.BlockData))
 
+			// import the blocks received so far at the start of the syncing chain,
+			// so a slow peer does not delay the import of the following blocks
+			nextToImport, err = cs.importSyncingChainPrefix(syncingChain, senders, nextToImport, origin, false)
+			if err != nil {
+				return err
+			}
+
 			// we received a response without the desired amount of blocks
 			// we should include a new request to retrieve the missing blocks
 			if len(response.BlockData) < int(*request.Max) {
@@ -747,7 +1157,7 @@ func (cs *chainSync) handleWorkersResults(
 				startRequestNumber := uint32(lastItem.Header.Number + 1)
 				startAt, err := variadic.NewUint32OrHash(startRequestNumber)
 				if err != nil {
-					panic(err)
+					return fmt.Errorf("creating missing blocks request start: %w", err)
 				}
 
 				taskResult.request = &network.BlockRequestMessage{
@@ -765,23 +1175,118 @@ func (cs *chainSync) handleWorkersResults(
 		}
 	}
 
-	retreiveBlocksSeconds := time.Since(startTime).Seconds()
+	retrieveTime := time.Since(startTime)
 	logger.Infof("🔽 retrieved %d blocks, took: %.2f seconds, starting process...",
-		expectedSyncedBlocks, retreiveBlocksSeconds)
+		expectedSyncedBlocks, retrieveTime.Seconds())
 
 	// response was validated! place into ready block queue
-	for _, bd := range syncingChain {
-		// block is ready to be processed!
-		if err := cs.handleReadyBlock(bd, origin); err != nil {
-			return fmt.Errorf("while handling ready block: %w", err)
-		}
+	_, err := cs.importSyncingChainPrefix(syncingChain, senders, nextToImport, origin, true)
+	if err != nil {
+		return err
 	}
 
 	cs.showSyncStats(startTime, len(syncingChain))
+	cs.sendBatchTelemetry(startAtBlock, startAtBlock+uint(expectedSyncedBlocks)-1,
+		peersUsed, retries, retrieveTime, time.Since(startTime))
 	return nil
 }
 
-func (cs *chainSync) handleReadyBlock(bd *types.BlockData, origin blockOrigin) error {
+// sendBatchTelemetry adds the batch of blocks synced to the batch summary, if the batch
+// telemetry is enabled, and sends the summary once no message was sent in the last interval,
+// so the telemetry servers receive the range synced, the distinct peers used, the retries
+// and the time taken over each interval rather than for every batch.
+func (cs *chainSync) sendBatchTelemetry(startBlock, endBlock uint, peers map[peer.ID]struct{},
+	retries uint, retrieveTime, totalTime time.Duration) {
+	if !cs.batchTelemetry {
+		return
+	}
+
+	cs.batchSummary.add(startBlock, endBlock, peers, retries, retrieveTime, totalTime)
+	if time.Since(cs.lastBatchTelemetry) < batchTelemetryInterval {
+		return
+	}
+	cs.lastBatchTelemetry = time.Now()
+
+	summary := cs.batchSummary
+	cs.batchSummary = syncBatchSummary{}
+	cs.telemetry.SendMessage(telemetry.NewSyncBatch(summary.startBlock, summary.endBlock,
+		len(summary.peers), summary.retries, summary.retrieveTime, summary.totalTime))
+}
+
+// importSyncingChainPrefix handles the contiguous blocks of the syncing chain
+// received from the index given, and returns the index of the next block to import.
+// Unless ancient blocks are verified, the blocks of the initial sync up to the last
+// block with a verified justification or checkpoint block received are committed to it
+// by their hashes, so they are imported without their BABE verification. The blocks
+// following it are only imported, with their BABE verification, once the syncing chain
+// is complete, since a justified block may still be received for them.
+func (cs *chainSync) importSyncingChainPrefix(syncingChain []*types.BlockData, senders []peer.ID,
+	nextToImport int, origin blockOrigin, complete bool) (int, error) {
+	end := nextToImport
+	for end < len(syncingChain) && syncingChain[end] != nil {
+		end++
+	}
+
+	ancientEnd := nextToImport
+	if origin == networkInitialSync && !cs.verifyAncientBlocks {
+		for index := end - 1; index >= nextToImport; index-- {
+			if cs.commitsAncestors(syncingChain[index]) {
+				ancientEnd = index + 1
+				break
+			}
+		}
+
+		if !complete {
+			end = ancientEnd
+		}
+	}
+
+	for ; nextToImport < end; nextToImport++ {
+		blockOrigin := origin
+		if nextToImport < ancientEnd {
+			blockOrigin = networkInitialSyncAncient
+		}
+
+		// block is ready to be processed!
+		err := cs.handleReadyBlock(syncingChain[nextToImport], blockOrigin, senders[nextToImport])
+		if err != nil {
+			return nextToImport, fmt.Errorf("while handling ready block: %w", err)
+		}
+	}
+
+	return nextToImport, nil
+}
+
+// commitsAncestors returns true if the block data given is the checkpoint block, or has
+// a justification which is valid before its import, so its ancestors can be imported
+// without their BABE verification.
+func (cs *chainSync) commitsAncestors(blockData *types.BlockData) bool {
+	if cs.checkpoint.isBlock(blockData) {
+		return true
+	}
+
+	if blockData.Header == nil || !hasJustification(blockData) {
+		return false
+	}
+
+	hash := blockData.Header.Hash()
+	err := cs.finalityGadget.VerifyUnimportedJustification(hash, blockData.Header.Number,
+		*blockData.Justification)
+	if err != nil {
+		logger.Debugf("justification of block #%d (%s) not verified before its import: %s",
+			blockData.Header.Number, hash, err)
+		return false
+	}
+	return true
+}
+
+func hasJustification(blockData *types.BlockData) bool {
+	return blockData.Justification != nil && len(*blockData.Justification) > 0
+}
+
+// handleReadyBlock processes the block data given, received from the peer given
+// if it is known, so the peer is reported if the block justification is invalid.
+func (cs *chainSync) handleReadyBlock(bd *types.BlockData, origin blockOrigin, who peer.ID) error {
 	// if header was not requested, get it from the pending set
 	// if we're expecting headers, validate should ensure we have a header
 	if bd.Header == nil {
@@ -813,7 +1318,7 @@ func (cs *chainSync) handleReadyBlock(bd *types.BlockData, origin blockOrigin) e
 		bd.Header = block.header
 	}
 
-	err := cs.processBlockData(*bd, origin)
+	err := cs.processBlockData(*bd, origin, who)
 	if err != nil {
 		// depending on the error, we might want to save this block for later
 		logger.Errorf("block data processing for block with hash %s failed: %s", bd.Hash, err)
@@ -828,7 +1333,7 @@ func (cs *chainSync) handleReadyBlock(bd *types.BlockData, origin blockOrigin) e
 // returns the index of the last BlockData it handled on success,
 // or the index of the block data that errored on failure.
 // TODO: https://github.com/ChainSafe/gossamer/issues/3468
-func (cs *chainSync) processBlockData(blockData types.BlockData, origin blockOrigin) error {
+func (cs *chainSync) processBlockData(blockData types.BlockData, origin blockOrigin, who peer.ID) error {
 	// while in bootstrap mode we don't need to broadcast block announcements
 	announceImportedBlock := cs.getSyncMode() == tip
 
@@ -840,11 +1345,19 @@ func (cs *chainSync) processBlockData(blockData types.BlockData, origin blockOri
 			}
 		}
 
-		if blockData.Justification != nil && len(*blockData.Justification) > 0 {
-			err := cs.handleJustification(blockData.Header, *blockData.Justification)
-			if err != nil {
-				return fmt.Errorf("handling justification: %w", err)
+		switch {
+		case cs.checkpoint.isBlock(&blockData):
+			if blockData.Body != nil {
+				err := cs.finaliseCheckpoint()
+				if err != nil {
+					return fmt.Errorf("finalising checkpoint: %w", err)
+				}
 			}
+		case cs.checkpoint.isAbove(blockData.Header):
+			// the justification is not verified since the
+			// block is finalised with the checkpoint block
+		case hasJustification(&blockData):
+			cs.justifications.push(blockData.Header, *blockData.Justification, who)
 		}
 	}
 
@@ -856,14 +1369,51 @@ func (cs *chainSync) processBlockData(blockData types.BlockData, origin blockOri
 	return nil
 }
 
+// finaliseCheckpoint finalises the checkpoint block imported with its trusted justification,
+// in the round and authority set the justification was verified for, unless a block at or
+// above the checkpoint block is already finalised.
+func (cs *chainSync) finaliseCheckpoint() error {
+	finalisedHeader, err := cs.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if finalisedHeader.Number >= cs.checkpoint.Header.Number {
+		return nil
+	}
+
+	hash := cs.checkpoint.Header.Hash()
+	err = cs.blockState.SetJustification(hash, cs.checkpoint.Justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	err = cs.blockState.SetFinalisedHash(hash, cs.checkpoint.Round, cs.checkpoint.SetID)
+	if err != nil {
+		return fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	logger.Infof("🔨 finalised checkpoint block #%d (%s), round %d, set id %d",
+		cs.checkpoint.Header.Number, hash, cs.checkpoint.Round, cs.checkpoint.SetID)
+	return nil
+}
+
 func (cs *chainSync) processBlockDataWithHeaderAndBody(blockData types.BlockData,
 	origin blockOrigin, announceImportedBlock bool) (err error) {
+	timings := blockImportTimings{
+		hash:       blockData.Hash,
+		number:     blockData.Header.Number,
+		origin:     origin,
+		extrinsics: len(*blockData.Body),
+	}
 
-	if origin != networkInitialSync {
+	if origin != networkInitialSyncAncient {
+		verificationStart := time.Now()
 		err = cs.babeVerifier.VerifyBlock(blockData.Header)
 		if err != nil {
 			return fmt.Errorf("babe verifying block: %w", err)
 		}
+		timings.verification = time.Since(verificationStart)
 	}
 
 	cs.handleBody(blockData.Body)
@@ -873,11 +1423,12 @@ func (cs *chainSync) processBlockDataWithHeaderAndBody(blockData types.BlockData
 		Body:   *blockData.Body,
 	}
 
-	err = cs.handleBlock(block, announceImportedBlock)
+	err = cs.handleBlock(block, announceImportedBlock, &timings)
 	if err != nil {
 		return fmt.Errorf("handling block: %w", err)
 	}
 
+	cs.importStats.record(timings)
 	return nil
 }
 
@@ -892,23 +1443,10 @@ func (cs *chainSync) handleBody(body *types.Body) {
 	blockSizeGauge.Set(float64(acc))
 }
 
-func (cs *chainSync) handleJustification(header *types.Header, justification []byte) (err error) {
-	headerHash := header.Hash()
-	err = cs.finalityGadget.VerifyBlockJustification(headerHash, justification)
-	if err != nil {
-		return fmt.Errorf("verifying block number %d justification: %w", header.Number, err)
-	}
-
-	err = cs.blockState.SetJustification(headerHash, justification)
-	if err != nil {
-		return fmt.Errorf("setting justification for block number %d: %w", header.Number, err)
-	}
-
-	return nil
-}
-
-// handleHeader handles blocks (header+body) included in BlockResponses
-func (cs *chainSync) handleBlock(block *types.Block, announceImportedBlock bool) error {
+// handleHeader handles blocks (header+body) included in BlockResponses,
+// and sets the durations of their execution and commit in the timings given.
+func (cs *chainSync) handleBlock(block *types.Block, announceImportedBlock bool,
+	timings *blockImportTimings) error {
 	parent, err := cs.blockState.GetHeader(block.Header.ParentHash)
 	if err != nil {
 		return fmt.Errorf("%w: %s", errFailedToGetParent, err)
@@ -922,9 +1460,14 @@ func (cs *chainSync) handleBlock(block *types.Block, announceImportedBlock bool)
 		return err
 	}
 
-	root := ts.MustRoot()
+	root, err := ts.Root()
+	if err != nil {
+		return fmt.Errorf("computing parent state root: %w", err)
+	}
+
 	if !bytes.Equal(parent.StateRoot[:], root[:]) {
-		panic("parent state root does not match snapshot state root")
+		return fmt.Errorf("%w: parent %s and snapshot %s",
+			errParentStateRootMismatch, parent.StateRoot, root)
 	}
 
 	rt, err := cs.blockState.GetRuntime(parent.Hash())
@@ -934,14 +1477,18 @@ func (cs *chainSync) handleBlock(block *types.Block, announceImportedBlock bool)
 
 	rt.SetContextStorage(ts)
 
+	executionStart := time.Now()
 	_, err = rt.ExecuteBlock(block)
 	if err != nil {
 		return fmt.Errorf("failed to execute block %d: %w", block.Header.Number, err)
 	}
+	timings.execution = time.Since(executionStart)
 
+	commitStart := time.Now()
 	if err = cs.blockImportHandler.HandleBlockImport(block, ts, announceImportedBlock); err != nil {
 		return err
 	}
+	timings.commit = time.Since(commitStart)
 
 	blockHash := block.Header.Hash()
 	cs.telemetry.SendMessage(telemetry.NewBlockImport(
@@ -6,10 +6,12 @@
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
@@ -88,7 +90,7 @@ func Test_chainSync_onBlockAnnounce(t *testing.T) {
 					stopCh:        make(chan struct{}),
 					pendingBlocks: pendingBlocks,
 					peerViewSet:   newPeerViewSet(0),
-					workerPool:    newSyncWorkerPool(NewMockNetwork(nil), NewMockRequestMaker(nil)),
+					workerPool:    newSyncWorkerPool(NewMockNetwork(nil), NewMockRequestMaker(nil), syncWorkerPoolConfig{}),
 				}
 			},
 			peerID:              somePeer,
@@ -107,7 +109,7 @@ func Test_chainSync_onBlockAnnounce(t *testing.T) {
 					stopCh:        make(chan struct{}),
 					pendingBlocks: pendingBlocks,
 					peerViewSet:   newPeerViewSet(0),
-					workerPool:    newSyncWorkerPool(NewMockNetwork(nil), NewMockRequestMaker(nil)),
+					workerPool:    newSyncWorkerPool(NewMockNetwork(nil), NewMockRequestMaker(nil), syncWorkerPoolConfig{}),
 				}
 			},
 			peerID:              somePeer,
@@ -129,7 +131,7 @@ func Test_chainSync_onBlockAnnounce(t *testing.T) {
 					pendingBlocks: pendingBlocks,
 					syncMode:      state,
 					peerViewSet:   newPeerViewSet(0),
-					workerPool:    newSyncWorkerPool(NewMockNetwork(nil), NewMockRequestMaker(nil)),
+					workerPool:    newSyncWorkerPool(NewMockNetwork(nil), NewMockRequestMaker(nil), syncWorkerPoolConfig{}),
 				}
 			},
 			peerID:              somePeer,
@@ -157,6 +159,9 @@ func Test_chainSync_onBlockAnnounce(t *testing.T) {
 					GetHighestFinalisedHeader().
 					Return(block2AnnounceHeader, nil).
 					Times(2)
+				blockStateMock.EXPECT().
+					GetHashByNumber(block2AnnounceHeader.Number).
+					Return(block2AnnounceHeader.Hash(), nil)
 
 				expectedRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(block2AnnounceHeader.Hash()),
 					1, network.BootstrapRequestData, network.Descending)
@@ -194,7 +199,7 @@ func Test_chainSync_onBlockAnnounce(t *testing.T) {
 					blockStateMock, babeVerifierMock, storageStateMock, importHandlerMock, telemetryMock,
 					networkBroadcast, announceBlock)
 
-				workerPool := newSyncWorkerPool(networkMock, requestMaker)
+				workerPool := newSyncWorkerPool(networkMock, requestMaker, syncWorkerPoolConfig{})
 				// include the peer who announced the block in the pool
 				workerPool.newPeer(somePeer)
 
@@ -213,6 +218,7 @@ func Test_chainSync_onBlockAnnounce(t *testing.T) {
 					storageState:       storageStateMock,
 					blockImportHandler: importHandlerMock,
 					peerViewSet:        newPeerViewSet(0),
+					badBlocks:          newBadBlockSet(),
 				}
 			},
 			peerID:              somePeer,
@@ -283,6 +289,7 @@ func Test_chainSync_onBlockAnnounceHandshake_tipModeNeedToCatchup(t *testing.T)
 	networkMock.EXPECT().Peers().Return([]common.PeerInfo{}).
 		Times(2)
 	networkMock.EXPECT().AllConnectedPeersIDs().Return([]peer.ID{}).Times(2)
+	networkMock.EXPECT().ReleaseSyncOnlyPeers(0).Return(0)
 
 	firstMockedResponse := createSuccesfullBlockResponse(t, block1AnnounceHeader.Hash(), 2, 128)
 	latestItemFromMockedResponse := firstMockedResponse.BlockData[len(firstMockedResponse.BlockData)-1]
@@ -331,13 +338,14 @@ func Test_chainSync_onBlockAnnounceHandshake_tipModeNeedToCatchup(t *testing.T)
 		peerViewSet:        newPeerViewSet(10),
 		syncMode:           state,
 		pendingBlocks:      newDisjointBlockSet(0),
-		workerPool:         newSyncWorkerPool(networkMock, requestMaker),
+		workerPool:         newSyncWorkerPool(networkMock, requestMaker, syncWorkerPoolConfig{}),
 		network:            networkMock,
 		blockState:         blockStateMock,
 		babeVerifier:       babeVerifierMock,
 		telemetry:          telemetryMock,
 		storageState:       storageStateMock,
 		blockImportHandler: importHandlerMock,
+		badBlocks:          newBadBlockSet(),
 	}
 
 	err := chainSync.onBlockAnnounceHandshake(somePeer, block2AnnounceHeader.Hash(), block2AnnounceHeader.Number)
@@ -365,7 +373,7 @@ func TestChainSync_onBlockAnnounceHandshake_onBootstrapMode(t *testing.T) {
 		"new_peer": {
 			newChainSync: func(t *testing.T, ctrl *gomock.Controller) *chainSync {
 				networkMock := NewMockNetwork(ctrl)
-				workerPool := newSyncWorkerPool(networkMock, NewMockRequestMaker(nil))
+				workerPool := newSyncWorkerPool(networkMock, NewMockRequestMaker(nil), syncWorkerPoolConfig{})
 
 				cs := newChainSyncTest(t, ctrl)
 				cs.syncMode.Store(bootstrap)
@@ -381,7 +389,7 @@ func TestChainSync_onBlockAnnounceHandshake_onBootstrapMode(t *testing.T) {
 		"ignore_peer_should_not_be_included_in_the_workerpoll": {
 			newChainSync: func(t *testing.T, ctrl *gomock.Controller) *chainSync {
 				networkMock := NewMockNetwork(ctrl)
-				workerPool := newSyncWorkerPool(networkMock, NewMockRequestMaker(nil))
+				workerPool := newSyncWorkerPool(networkMock, NewMockRequestMaker(nil), syncWorkerPoolConfig{})
 				workerPool.ignorePeers = map[peer.ID]struct{}{
 					peer.ID("peer-test"): {},
 				}
@@ -399,7 +407,7 @@ func TestChainSync_onBlockAnnounceHandshake_onBootstrapMode(t *testing.T) {
 		"peer_already_exists_in_the_pool": {
 			newChainSync: func(t *testing.T, ctrl *gomock.Controller) *chainSync {
 				networkMock := NewMockNetwork(ctrl)
-				workerPool := newSyncWorkerPool(networkMock, NewMockRequestMaker(nil))
+				workerPool := newSyncWorkerPool(networkMock, NewMockRequestMaker(nil), syncWorkerPoolConfig{})
 				workerPool.workers = map[peer.ID]*syncWorker{
 					peer.ID("peer-test"): {
 						worker: &worker{status: available},
@@ -448,7 +456,6 @@ func newChainSyncTest(t *testing.T, ctrl *gomock.Controller) *chainSync {
 	t.Helper()
 
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 
 	cfg := chainSyncConfig{
 		bs:            mockBlockState,
@@ -492,6 +499,7 @@ func setupChainSyncToBootstrapMode(t *testing.T, blocksAhead uint,
 		storageState:       storageState,
 		blockImportHandler: blockImportHandler,
 		telemetry:          telemetry,
+		badBlocks:          newBadBlockSet(),
 	}
 
 	chainSync := newChainSync(cfg)
@@ -534,7 +542,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithOneWorker(t *testing.T) {
 		})
 
 	mockedBlockState := NewMockBlockState(ctrl)
-	mockedBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockedBlockState.EXPECT().IsPaused().Return(false)
 
 	mockBabeVerifier := NewMockBabeVerifier(ctrl)
@@ -579,7 +586,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithTwoWorkers(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
 		trie.EmptyHash, 0, types.NewDigest())
 
@@ -668,7 +674,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithOneWorkerFailing(t *testing.
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
 	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
 		trie.EmptyHash, 0, types.NewDigest())
@@ -766,7 +771,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithProtocolNotSupported(t *test
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
 	mockBlockState.EXPECT().
 		GetHighestFinalisedHeader().
@@ -871,7 +875,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithNilHeaderInResponse(t *testi
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
 	mockBlockState.EXPECT().
 		GetHighestFinalisedHeader().
@@ -978,7 +981,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithResponseIsNotAChain(t *testi
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
 	mockBlockState.EXPECT().
 		GetHighestFinalisedHeader().
@@ -1082,7 +1084,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithReceivedBadBlock(t *testing.
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
 	mockBlockState.EXPECT().
 		GetHighestFinalisedHeader().
@@ -1179,7 +1180,7 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithReceivedBadBlock(t *testing.
 		mockBlockState, mockNetwork, mockRequestMaker, mockBabeVerifier,
 		mockStorageState, mockImportHandler, mockTelemetry)
 
-	cs.badBlocks = []string{fakeBadBlockHash.String()}
+	cs.badBlocks = newBadBlockSet(fakeBadBlockHash)
 
 	target := cs.peerViewSet.getTarget()
 	require.Equal(t, uint(blocksAhead), target)
@@ -1202,12 +1203,108 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithReceivedBadBlock(t *testing.
 	require.Len(t, cs.workerPool.ignorePeers, 1)
 }
 
+func TestChainSync_BootstrapSync_SuccessfulSync_WithMalformedJustification(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mockBlockState := NewMockBlockState(ctrl)
+	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
+	mockBlockState.EXPECT().
+		GetHighestFinalisedHeader().
+		Return(types.NewEmptyHeader(), nil).
+		Times(1)
+	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
+		trie.EmptyHash, 0, types.NewDigest())
+
+	mockNetwork := NewMockNetwork(ctrl)
+	mockNetwork.EXPECT().Peers().Return([]common.PeerInfo{})
+	mockRequestMaker := NewMockRequestMaker(ctrl)
+
+	mockBabeVerifier := NewMockBabeVerifier(ctrl)
+	mockStorageState := NewMockStorageState(ctrl)
+	mockImportHandler := NewMockBlockImportHandler(ctrl)
+	mockTelemetry := NewMockTelemetry(ctrl)
+	mockFinalityGadget := NewMockFinalityGadget(ctrl)
+
+	// this test expects two workers responding each request with 128 blocks which means
+	// we should import 256 blocks in total
+	blockResponse := createSuccesfullBlockResponse(t, mockedGenesisHeader.Hash(), 1, 256)
+	const announceBlock = false
+
+	worker1Response := &network.BlockResponseMessage{
+		BlockData: blockResponse.BlockData[:128],
+	}
+	ensureSuccessfulBlockImportFlow(t, mockedGenesisHeader, worker1Response.BlockData, mockBlockState,
+		mockBabeVerifier, mockStorageState, mockImportHandler, mockTelemetry, networkInitialSync, announceBlock)
+
+	worker2Response := &network.BlockResponseMessage{
+		BlockData: blockResponse.BlockData[128:],
+	}
+	parent := worker1Response.BlockData[127]
+	ensureSuccessfulBlockImportFlow(t, parent.Header, worker2Response.BlockData, mockBlockState,
+		mockBabeVerifier, mockStorageState, mockImportHandler, mockTelemetry, networkInitialSync, announceBlock)
+
+	// the same blocks as the worker 2 response, the first one carrying a malformed justification
+	malformedJustification := []byte{0x01, 0x02}
+	malformedBlockData := *worker2Response.BlockData[0]
+	malformedBlockData.Justification = &malformedJustification
+	malformedResponse := &network.BlockResponseMessage{
+		BlockData: append([]*types.BlockData{&malformedBlockData}, worker2Response.BlockData[1:]...),
+	}
+
+	mockFinalityGadget.EXPECT().
+		CheckJustification(malformedJustification).
+		Return(errors.New("malformed justification"))
+
+	doBlockRequestCount := atomic.Int32{}
+	mockRequestMaker.EXPECT().
+		Do(gomock.Any(), gomock.Any(), &network.BlockResponseMessage{}).
+		DoAndReturn(func(peerID, _, response any) any {
+			responsePtr := response.(*network.BlockResponseMessage)
+			defer func() { doBlockRequestCount.Add(1) }()
+
+			switch doBlockRequestCount.Load() {
+			case 0:
+				*responsePtr = *worker1Response
+			case 1:
+				*responsePtr = *malformedResponse
+			default:
+				*responsePtr = *worker2Response
+			}
+
+			return nil
+		}).Times(3)
+
+	// the peer sending the malformed justification should be punished
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.BadJustificationValue,
+		Reason: peerset.BadJustificationReason,
+	}, gomock.AssignableToTypeOf(peer.ID("")))
+
+	const blocksAhead = 256
+	cs := setupChainSyncToBootstrapMode(t, blocksAhead,
+		mockBlockState, mockNetwork, mockRequestMaker, mockBabeVerifier,
+		mockStorageState, mockImportHandler, mockTelemetry)
+	cs.finalityGadget = mockFinalityGadget
+
+	target := cs.peerViewSet.getTarget()
+	require.Equal(t, uint(blocksAhead), target)
+
+	cs.workerPool.fromBlockAnnounce(peer.ID("alice"))
+	cs.workerPool.fromBlockAnnounce(peer.ID("bob"))
+
+	err := cs.requestMaxBlocksFrom(mockedGenesisHeader, networkInitialSync)
+	require.NoError(t, err)
+
+	err = cs.workerPool.stop()
+	require.NoError(t, err)
+}
+
 func TestChainSync_BootstrapSync_SucessfulSync_ReceivedPartialBlockData(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockBlockState.EXPECT().IsPaused().Return(false).Times(2)
 	mockBlockState.EXPECT().
 		GetHighestFinalisedHeader().
@@ -1336,8 +1433,8 @@ func ensureSuccessfulBlockImportFlow(t *testing.T, parentHeader *types.Header,
 	t.Helper()
 
 	for idx, blockData := range blocksReceived {
-		if origin != networkInitialSync {
-			mockBabeVerifier.EXPECT().VerifyBlock(blockData.Header).Return(nil)
+		if origin != networkInitialSyncAncient {
+			mockBabeVerifier.EXPECT().VerifyBlock(blockData.Header).Return(nil).AnyTimes()
 		}
 
 		var previousHeader *types.Header
@@ -1732,7 +1829,6 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithInvalidJusticationBlock(t *t
 
 	ctrl := gomock.NewController(t)
 	mockBlockState := NewMockBlockState(ctrl)
-	mockBlockState.EXPECT().GetFinalisedNotifierChannel().Return(make(chan *types.FinalisationInfo))
 	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
 		trie.EmptyHash, 0, types.NewDigest())
 
@@ -1815,3 +1911,666 @@ func TestChainSync_BootstrapSync_SuccessfulSync_WithInvalidJusticationBlock(t *t
 	// peer should be in the ignore list
 	require.Len(t, cs.workerPool.workers, 1)
 }
+
+func Test_gapRequests(t *testing.T) {
+	t.Parallel()
+
+	newPendingBlock := func(number uint, parent *pendingBlock) *pendingBlock {
+		header := &types.Header{Number: number}
+		if parent != nil {
+			header.ParentHash = parent.hash
+		}
+		return &pendingBlock{hash: header.Hash(), number: number, header: header}
+	}
+
+	block10 := newPendingBlock(10, nil)
+	block11 := newPendingBlock(11, block10)
+	block12 := newPendingBlock(12, block11)
+	forkHeader11 := &types.Header{ParentHash: block10.hash, Number: 11, StateRoot: common.Hash{1}}
+	forkBlock11 := &pendingBlock{hash: forkHeader11.Hash(), number: 11, header: forkHeader11}
+	block140 := newPendingBlock(140, block10)
+	announcedBlock := &pendingBlock{hash: common.Hash{1}, number: 20}
+
+	newRequest := func(block *pendingBlock, max uint32) *network.BlockRequestMessage {
+		return network.NewBlockRequest(*variadic.MustNewUint32OrHash(block.hash),
+			max, network.BootstrapRequestData, network.Descending)
+	}
+
+	testCases := map[string]struct {
+		gapBlocks              []*pendingBlock
+		highestFinalizedNumber uint
+		requests               []*network.BlockRequestMessage
+	}{
+		"no_gap_block": {},
+		"unrelated_blocks": {
+			gapBlocks:              []*pendingBlock{block10, announcedBlock},
+			highestFinalizedNumber: 5,
+			requests: []*network.BlockRequestMessage{
+				newRequest(announcedBlock, 15),
+				newRequest(block10, 5),
+			},
+		},
+		"contiguous_range": {
+			gapBlocks:              []*pendingBlock{block10, block12, block11},
+			highestFinalizedNumber: 5,
+			requests: []*network.BlockRequestMessage{
+				newRequest(block12, 7),
+			},
+		},
+		"forks_sharing_ancestors": {
+			gapBlocks:              []*pendingBlock{block10, block11, forkBlock11, block12},
+			highestFinalizedNumber: 5,
+			requests: []*network.BlockRequestMessage{
+				newRequest(block12, 7),
+				newRequest(forkBlock11, 6),
+			},
+		},
+		"range_longer_than_max_request": {
+			gapBlocks:              []*pendingBlock{block10, block140},
+			highestFinalizedNumber: 5,
+			requests: []*network.BlockRequestMessage{
+				newRequest(block140, 128),
+				newRequest(block10, 5),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			requests := gapRequests(testCase.gapBlocks, testCase.highestFinalizedNumber)
+			assert.Equal(t, testCase.requests, requests)
+		})
+	}
+}
+
+func Test_chainSync_isBadBlock(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	finalizedHeader := &types.Header{Number: 2}
+	forkHeader := &types.Header{Number: 2, StateRoot: common.Hash{1}}
+	forkChildHeader := &types.Header{ParentHash: forkHeader.Hash(), Number: 3}
+	checkpointHeader := &types.Header{Number: 3, StateRoot: common.Hash{2}}
+
+	testCases := map[string]struct {
+		header            *types.Header
+		badBlocks         *badBlockSet
+		checkpoint        *Checkpoint
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		isBad             bool
+		errWrapped        error
+	}{
+		"bad_block": {
+			header:    forkHeader,
+			badBlocks: newBadBlockSet(forkHeader.Hash()),
+			isBad:     true,
+		},
+		"descendant_of_bad_block": {
+			header:    forkChildHeader,
+			badBlocks: newBadBlockSet(forkHeader.Hash()),
+			isBad:     true,
+		},
+		"above_finalized_block": {
+			header:    forkChildHeader,
+			badBlocks: newBadBlockSet(),
+		},
+		"checkpoint_block": {
+			header:     checkpointHeader,
+			badBlocks:  newBadBlockSet(),
+			checkpoint: &Checkpoint{Header: checkpointHeader},
+		},
+		"competing_fork_of_checkpoint_block": {
+			header:     forkChildHeader,
+			badBlocks:  newBadBlockSet(),
+			checkpoint: &Checkpoint{Header: checkpointHeader},
+			isBad:      true,
+		},
+		"get_hash_by_number_error": {
+			header:    forkHeader,
+			badBlocks: newBadBlockSet(),
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{}, errTest)
+				return blockState
+			},
+			errWrapped: errTest,
+		},
+		"finalized_block": {
+			header:    finalizedHeader,
+			badBlocks: newBadBlockSet(),
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHashByNumber(uint(2)).Return(finalizedHeader.Hash(), nil)
+				return blockState
+			},
+		},
+		"competing_fork_of_finalized_block": {
+			header:    forkHeader,
+			badBlocks: newBadBlockSet(),
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHashByNumber(uint(2)).Return(finalizedHeader.Hash(), nil)
+				return blockState
+			},
+			isBad: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			cs := &chainSync{badBlocks: testCase.badBlocks, checkpoint: testCase.checkpoint}
+			if testCase.blockStateBuilder != nil {
+				cs.blockState = testCase.blockStateBuilder(ctrl)
+			}
+
+			isBad, err := cs.isBadBlock(testCase.header, finalizedHeader.Number)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.isBad, isBad)
+		})
+	}
+}
+
+func TestChainSync_handleWorkersResults_importsReceivedPrefix(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	mockBlockState := NewMockBlockState(ctrl)
+	mockStorageState := NewMockStorageState(ctrl)
+	mockImportHandler := NewMockBlockImportHandler(ctrl)
+	mockTelemetry := NewMockTelemetry(ctrl)
+	mockNetwork := NewMockNetwork(ctrl)
+
+	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
+		trie.EmptyHash, 0, types.NewDigest())
+	response := createSuccesfullBlockResponse(t, mockedGenesisHeader.Hash(), 1, 4)
+
+	// recorded before the import flow expectations so it is matched first
+	var importedMutex sync.Mutex
+	var imported []uint
+	mockBlockState.EXPECT().CompareAndSetBlockData(gomock.Any()).
+		DoAndReturn(func(blockData *types.BlockData) error {
+			importedMutex.Lock()
+			defer importedMutex.Unlock()
+			imported = append(imported, blockData.Header.Number)
+			return nil
+		}).Times(4)
+	mockBabeVerifier := NewMockBabeVerifier(ctrl)
+	ensureSuccessfulBlockImportFlow(t, mockedGenesisHeader, response.BlockData, mockBlockState,
+		mockBabeVerifier, mockStorageState, mockImportHandler, mockTelemetry,
+		networkInitialSync, false)
+	mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(mockedGenesisHeader, nil)
+	mockNetwork.EXPECT().Peers().Return(nil)
+
+	syncMode := atomic.Value{}
+	syncMode.Store(bootstrap)
+	cs := &chainSync{
+		stopCh:              make(chan struct{}),
+		blockState:          mockBlockState,
+		storageState:        mockStorageState,
+		babeVerifier:        mockBabeVerifier,
+		verifyAncientBlocks: true,
+		blockImportHandler:  mockImportHandler,
+		telemetry:           mockTelemetry,
+		network:             mockNetwork,
+		workerPool:          newSyncWorkerPool(mockNetwork, nil, syncWorkerPoolConfig{}),
+		peerViewSet:         newPeerViewSet(0),
+		pendingBlocks:       newDisjointBlockSet(pendingBlocksLimit),
+		badBlocks:           newBadBlockSet(),
+		syncMode:            syncMode,
+	}
+
+	newResult := func(blockData []*types.BlockData) *syncTaskResult {
+		max := uint32(len(blockData))
+		return &syncTaskResult{
+			who: peer.ID("peer"),
+			request: network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(blockData[0].Header.Number)),
+				max, network.BootstrapRequestData, network.Ascending),
+			response: &network.BlockResponseMessage{BlockData: blockData},
+		}
+	}
+
+	results := make(chan *syncTaskResult)
+	errCh := make(chan error)
+	go func() {
+		errCh <- cs.handleWorkersResults(results, networkInitialSync, 1, 4)
+	}()
+
+	results <- newResult(response.BlockData[:2])
+	results <- newResult(response.BlockData[3:])
+	// the results channel is unbuffered, so the blocks received first
+	// are imported before the next result is received
+	importedMutex.Lock()
+	assert.Equal(t, []uint{1, 2}, imported)
+	importedMutex.Unlock()
+
+	results <- newResult(response.BlockData[2:3])
+	err := <-errCh
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2, 3, 4}, imported)
+}
+
+func TestChainSync_importSyncingChainPrefix_ancientBlocks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	mockBlockState := NewMockBlockState(ctrl)
+	mockBabeVerifier := NewMockBabeVerifier(ctrl)
+	mockStorageState := NewMockStorageState(ctrl)
+	mockImportHandler := NewMockBlockImportHandler(ctrl)
+	mockTelemetry := NewMockTelemetry(ctrl)
+	mockFinalityGadget := NewMockFinalityGadget(ctrl)
+
+	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
+		trie.EmptyHash, 0, types.NewDigest())
+	response := createSuccesfullBlockResponse(t, mockedGenesisHeader.Hash(), 1, 4)
+	justification := []byte{1}
+	response.BlockData[1].Justification = &justification
+	mockFinalityGadget.EXPECT().VerifyUnimportedJustification(response.BlockData[1].Header.Hash(),
+		uint(2), justification).Return(nil)
+
+	// the blocks up to the justified block are committed to it
+	// so they are imported without their BABE verification
+	ensureSuccessfulBlockImportFlow(t, mockedGenesisHeader, response.BlockData[:2], mockBlockState,
+		mockBabeVerifier, mockStorageState, mockImportHandler, mockTelemetry,
+		networkInitialSyncAncient, false)
+	ensureSuccessfulBlockImportFlow(t, response.BlockData[1].Header, response.BlockData[2:], mockBlockState,
+		mockBabeVerifier, mockStorageState, mockImportHandler, mockTelemetry,
+		networkInitialSync, false)
+
+	syncMode := atomic.Value{}
+	syncMode.Store(bootstrap)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	cs := &chainSync{
+		stopCh:             stopCh,
+		blockState:         mockBlockState,
+		storageState:       mockStorageState,
+		babeVerifier:       mockBabeVerifier,
+		finalityGadget:     mockFinalityGadget,
+		blockImportHandler: mockImportHandler,
+		telemetry:          mockTelemetry,
+		pendingBlocks:      newDisjointBlockSet(pendingBlocksLimit),
+		justifications:     newJustificationQueue(nil, nil, stopCh),
+		syncMode:           syncMode,
+	}
+
+	senders := make([]peer.ID, len(response.BlockData))
+	nextToImport, err := cs.importSyncingChainPrefix(response.BlockData, senders, 0, networkInitialSync, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, nextToImport)
+
+	nextToImport, err = cs.importSyncingChainPrefix(response.BlockData, senders, nextToImport,
+		networkInitialSync, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, nextToImport)
+}
+
+func TestChainSync_importSyncingChainPrefix_invalidJustification(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	mockBlockState := NewMockBlockState(ctrl)
+	mockBabeVerifier := NewMockBabeVerifier(ctrl)
+	mockStorageState := NewMockStorageState(ctrl)
+	mockImportHandler := NewMockBlockImportHandler(ctrl)
+	mockTelemetry := NewMockTelemetry(ctrl)
+	mockFinalityGadget := NewMockFinalityGadget(ctrl)
+
+	mockedGenesisHeader := types.NewHeader(common.NewHash([]byte{0}), trie.EmptyHash,
+		trie.EmptyHash, 0, types.NewDigest())
+	response := createSuccesfullBlockResponse(t, mockedGenesisHeader.Hash(), 1, 4)
+	justification := []byte{1}
+	response.BlockData[1].Justification = &justification
+
+	// an invalid justification does not commit the blocks it descends from,
+	// so they are only imported with their BABE verification once the chain is complete
+	errTest := errors.New("test error")
+	mockFinalityGadget.EXPECT().VerifyUnimportedJustification(response.BlockData[1].Header.Hash(),
+		uint(2), justification).Return(errTest).Times(2)
+	ensureSuccessfulBlockImportFlow(t, mockedGenesisHeader, response.BlockData, mockBlockState,
+		mockBabeVerifier, mockStorageState, mockImportHandler, mockTelemetry,
+		networkInitialSync, false)
+
+	syncMode := atomic.Value{}
+	syncMode.Store(bootstrap)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	cs := &chainSync{
+		stopCh:             stopCh,
+		blockState:         mockBlockState,
+		storageState:       mockStorageState,
+		babeVerifier:       mockBabeVerifier,
+		finalityGadget:     mockFinalityGadget,
+		blockImportHandler: mockImportHandler,
+		telemetry:          mockTelemetry,
+		pendingBlocks:      newDisjointBlockSet(pendingBlocksLimit),
+		justifications:     newJustificationQueue(nil, nil, stopCh),
+		syncMode:           syncMode,
+	}
+
+	senders := make([]peer.ID, len(response.BlockData))
+	nextToImport, err := cs.importSyncingChainPrefix(response.BlockData, senders, 0, networkInitialSync, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, nextToImport)
+
+	nextToImport, err = cs.importSyncingChainPrefix(response.BlockData, senders, nextToImport,
+		networkInitialSync, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, nextToImport)
+}
+
+func TestChainSync_bootstrapSync_failure(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	errTest := errors.New("test error")
+	mockBlockState := NewMockBlockState(ctrl)
+	mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(nil, errTest)
+
+	cs := &chainSync{
+		blockState: mockBlockState,
+		failuresCh: make(chan error, 1),
+	}
+
+	cs.wg.Add(1)
+	cs.bootstrapSync()
+
+	err := <-cs.failures()
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "getting highest finalised header: test error")
+}
+
+func TestChainSync_bootstrapSync_panic(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	mockBlockState := NewMockBlockState(ctrl)
+	mockBlockState.EXPECT().GetHighestFinalisedHeader().DoAndReturn(func() (*types.Header, error) {
+		panic("test panic")
+	})
+
+	cs := &chainSync{
+		blockState: mockBlockState,
+		failuresCh: make(chan error, 1),
+	}
+
+	cs.wg.Add(1)
+	cs.bootstrapSync()
+
+	err := <-cs.failures()
+	assert.ErrorIs(t, err, errChainSyncPanic)
+	assert.EqualError(t, err, "chain sync panicked: test panic")
+}
+
+func TestChainSync_fail(t *testing.T) {
+	t.Parallel()
+
+	cs := &chainSync{
+		failuresCh: make(chan error, 1),
+	}
+
+	errFirst := errors.New("first")
+	cs.fail(errFirst)
+	// the second failure is dropped since the first one is pending
+	cs.fail(errors.New("second"))
+
+	assert.Equal(t, errFirst, <-cs.failures())
+	assert.Empty(t, cs.failuresCh)
+}
+
+func TestChainSync_removeDisconnectedPeers(t *testing.T) {
+	t.Parallel()
+
+	bus := events.NewBus()
+	cs := &chainSync{
+		stopCh:       make(chan struct{}),
+		workerPool:   newSyncWorkerPool(nil, nil, syncWorkerPoolConfig{}),
+		peerViewSet:  newPeerViewSet(2),
+		disconnected: events.Subscribe[events.PeerDisconnected](bus),
+	}
+	for _, who := range []peer.ID{"a", "b"} {
+		cs.workerPool.fromBlockAnnounce(who)
+		cs.peerViewSet.update(who, common.Hash{1}, 1)
+	}
+
+	cs.wg.Add(1)
+	go cs.removeDisconnectedPeers()
+
+	events.Publish(bus, events.PeerDisconnected{ID: "a"})
+	assert.Eventually(t, func() bool {
+		return cs.workerPool.totalWorkers() == 1
+	}, time.Second, 10*time.Millisecond)
+	_, ok := cs.peerViewSet.find("a")
+	assert.False(t, ok)
+	_, ok = cs.peerViewSet.find("b")
+	assert.True(t, ok)
+
+	close(cs.stopCh)
+	cs.wg.Wait()
+	cs.disconnected.Unsubscribe()
+	require.NoError(t, cs.workerPool.stop())
+}
+
+func TestChainSync_restart(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockStateBuilder func(ctrl *gomock.Controller) BlockState
+		target            uint
+		expectedSyncMode  chainSyncState
+		errWrapped        error
+		errMessage        string
+	}{
+		"highest_finalised_header_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(nil, errTest)
+				return blockState
+			},
+			expectedSyncMode: tip,
+			errWrapped:       errTest,
+			errMessage:       "getting highest finalised header: test error",
+		},
+		"best_block_header_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(types.NewEmptyHeader(), nil)
+				blockState.EXPECT().BestBlockHeader().Return(nil, errTest)
+				return blockState
+			},
+			target:           1,
+			expectedSyncMode: tip,
+			errWrapped:       errTest,
+			errMessage:       "getting best block header: test error",
+		},
+		"near_target": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(types.NewEmptyHeader(), nil)
+				blockState.EXPECT().BestBlockHeader().Return(types.NewEmptyHeader(), nil)
+				return blockState
+			},
+			target:           1,
+			expectedSyncMode: tip,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			mockNetwork := NewMockNetwork(ctrl)
+			mockNetwork.EXPECT().AllConnectedPeersIDs().Return(nil).AnyTimes()
+
+			peerViewSet := newPeerViewSet(10)
+			peerViewSet.target = testCase.target
+
+			syncMode := atomic.Value{}
+			syncMode.Store(bootstrap)
+			cs := &chainSync{
+				stopCh:      make(chan struct{}),
+				blockState:  testCase.blockStateBuilder(ctrl),
+				network:     mockNetwork,
+				workerPool:  newSyncWorkerPool(mockNetwork, nil, syncWorkerPoolConfig{}),
+				peerViewSet: peerViewSet,
+				syncMode:    syncMode,
+			}
+
+			err := cs.restart()
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expectedSyncMode, cs.getSyncMode())
+		})
+	}
+}
+
+func TestChainSync_switchToBootstrap_alreadyBootstrap(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	syncMode := atomic.Value{}
+	syncMode.Store(bootstrap)
+	cs := &chainSync{
+		// the bootstrap sync would fail the test calling the block state
+		blockState: NewMockBlockState(ctrl),
+		syncMode:   syncMode,
+	}
+
+	cs.switchToBootstrap()
+	cs.wg.Wait()
+
+	assert.Equal(t, bootstrap, cs.getSyncMode())
+}
+
+func TestChainSync_sendBatchTelemetry(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	peerA, peerB := peer.ID("a"), peer.ID("b")
+
+	// no message is sent if the batch telemetry is disabled
+	cs := &chainSync{
+		telemetry: NewMockTelemetry(ctrl),
+	}
+	cs.sendBatchTelemetry(1, 128, map[peer.ID]struct{}{peerA: {}}, 1, time.Second, 2*time.Second)
+
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(telemetry.NewSyncBatch(1, 128, 1, 1, time.Second, 2*time.Second))
+	cs = &chainSync{
+		telemetry:      telemetryMock,
+		batchTelemetry: true,
+	}
+	cs.sendBatchTelemetry(1, 128, map[peer.ID]struct{}{peerA: {}}, 1, time.Second, 2*time.Second)
+
+	// the next batches are accumulated since a message was sent in the last interval
+	cs.sendBatchTelemetry(129, 256, map[peer.ID]struct{}{peerA: {}, peerB: {}}, 2, time.Second, 2*time.Second)
+	cs.sendBatchTelemetry(257, 384, map[peer.ID]struct{}{peerB: {}}, 0, time.Second, 2*time.Second)
+
+	// the batches accumulated are sent once the interval elapsed
+	telemetryMock.EXPECT().SendMessage(telemetry.NewSyncBatch(129, 512, 2, 3, 3*time.Second, 6*time.Second))
+	cs.lastBatchTelemetry = time.Now().Add(-batchTelemetryInterval)
+	cs.sendBatchTelemetry(385, 512, map[peer.ID]struct{}{peerA: {}}, 1, time.Second, 2*time.Second)
+	assert.Equal(t, syncBatchSummary{}, cs.batchSummary)
+}
+
+func TestChainSync_finaliseCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	checkpoint := &Checkpoint{
+		Header:        &types.Header{Number: 10},
+		Justification: []byte{1},
+		Round:         2,
+		SetID:         3,
+	}
+	checkpointHash := checkpoint.Header.Hash()
+
+	testCases := map[string]struct {
+		setupBlockState func(blockState *MockBlockState)
+		errWrapped      error
+		errMessage      string
+	}{
+		"already_finalised": {
+			setupBlockState: func(blockState *MockBlockState) {
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 10}, nil)
+			},
+		},
+		"set_justification_error": {
+			setupBlockState: func(blockState *MockBlockState) {
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 9}, nil)
+				blockState.EXPECT().SetJustification(checkpointHash, []byte{1}).Return(errTest)
+			},
+			errWrapped: errTest,
+			errMessage: "setting justification: test error",
+		},
+		"finalised": {
+			setupBlockState: func(blockState *MockBlockState) {
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 9}, nil)
+				blockState.EXPECT().SetJustification(checkpointHash, []byte{1}).Return(nil)
+				blockState.EXPECT().SetFinalisedHash(checkpointHash, uint64(2), uint64(3)).Return(nil)
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			testCase.setupBlockState(blockState)
+			cs := &chainSync{blockState: blockState, checkpoint: checkpoint}
+
+			err := cs.finaliseCheckpoint()
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func TestChainSync_processBlockData_belowCheckpoint(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	// the justification of a block below the checkpoint is not verified,
+	// since the block is finalised with the checkpoint block
+	justification := []byte{1}
+	blockData := types.BlockData{
+		Hash:          common.Hash{1},
+		Header:        &types.Header{Number: 9},
+		Justification: &justification,
+	}
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().CompareAndSetBlockData(&blockData).Return(nil)
+
+	syncMode := atomic.Value{}
+	syncMode.Store(bootstrap)
+	cs := &chainSync{
+		blockState:     blockState,
+		checkpoint:     &Checkpoint{Header: &types.Header{Number: 10}},
+		justifications: newJustificationQueue(nil, nil, nil),
+		syncMode:       syncMode,
+	}
+
+	err := cs.processBlockData(blockData, networkInitialSync, peer.ID("peer"))
+	require.NoError(t, err)
+	assert.Empty(t, cs.justifications.imports)
+}
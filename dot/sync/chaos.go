@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/exp/slices"
+)
+
+// corruptExtrinsic is the extrinsic appended to the block bodies corrupted.
+var corruptExtrinsic = types.Extrinsic{0xde, 0xad, 0xbe, 0xef}
+
+// injectFaults sets the faults injected in the block responses received by the workers,
+// replacing the faults previously set, and nil stops injecting faults. The faults are
+// only injected in binaries built with the chaos build tag.
+func (s *syncWorkerPool) injectFaults(faults *chaos.Faults) {
+	s.faults.Store(faults)
+}
+
+// faultyRequestMaker injects the faults of the worker pool in the block responses
+// received through the request maker it wraps.
+type faultyRequestMaker struct {
+	network.RequestMaker
+	faults *atomic.Pointer[chaos.Faults]
+}
+
+func (f *faultyRequestMaker) Do(to peer.ID, req network.Message, res network.ResponseMessage) error {
+	err := f.RequestMaker.Do(to, req, res)
+	if err != nil {
+		return err
+	}
+
+	response, ok := res.(*network.BlockResponseMessage)
+	if !ok {
+		return nil
+	}
+
+	faults := f.faults.Load()
+	if faults.CorruptsBlockBody(to) {
+		corruptBlockBodies(response)
+	}
+
+	if faults != nil && faults.JustificationDelay > 0 && carriesJustification(response) {
+		time.Sleep(faults.JustificationDelay)
+	}
+
+	return nil
+}
+
+// corruptBlockBodies appends an extrinsic to the block bodies of the response,
+// so they no longer match the extrinsics root of their block header.
+func corruptBlockBodies(response *network.BlockResponseMessage) {
+	for _, blockData := range response.BlockData {
+		if blockData.Body == nil {
+			continue
+		}
+
+		corrupted := append(slices.Clone(*blockData.Body), corruptExtrinsic)
+		blockData.Body = &corrupted
+	}
+}
+
+// carriesJustification returns true if a block of the response carries a justification.
+func carriesJustification(response *network.BlockResponseMessage) bool {
+	for _, blockData := range response.BlockData {
+		if blockData.Justification != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_faultyRequestMaker_Do(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	who := peer.ID("peerA")
+	justification := []byte{1}
+
+	newResponse := func() *network.BlockResponseMessage {
+		return &network.BlockResponseMessage{
+			BlockData: []*types.BlockData{
+				{Body: &types.Body{{1}}, Justification: &justification},
+				{},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		faults           *chaos.Faults
+		doErr            error
+		errWrapped       error
+		expectedResponse *network.BlockResponseMessage
+		minDuration      time.Duration
+	}{
+		"request_error": {
+			faults:           &chaos.Faults{CorruptBlockBody: chaos.Always},
+			doErr:            errTest,
+			errWrapped:       errTest,
+			expectedResponse: newResponse(),
+		},
+		"no_faults": {
+			expectedResponse: newResponse(),
+		},
+		"corrupt_block_body": {
+			faults: &chaos.Faults{CorruptBlockBody: chaos.Always},
+			expectedResponse: &network.BlockResponseMessage{
+				BlockData: []*types.BlockData{
+					{Body: &types.Body{{1}, corruptExtrinsic}, Justification: &justification},
+					{},
+				},
+			},
+		},
+		"other_peer_corrupted": {
+			faults:           &chaos.Faults{CorruptBlockBody: chaos.Peers("peerB")},
+			expectedResponse: newResponse(),
+		},
+		"justification_delay": {
+			faults:           &chaos.Faults{JustificationDelay: 10 * time.Millisecond},
+			expectedResponse: newResponse(),
+			minDuration:      10 * time.Millisecond,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			response := newResponse()
+			inner := NewMockRequestMaker(ctrl)
+			inner.EXPECT().Do(who, nil, response).Return(testCase.doErr)
+
+			var faults atomic.Pointer[chaos.Faults]
+			faults.Store(testCase.faults)
+			requestMaker := &faultyRequestMaker{RequestMaker: inner, faults: &faults}
+
+			start := time.Now()
+			err := requestMaker.Do(who, nil, response)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.expectedResponse, response)
+			assert.GreaterOrEqual(t, time.Since(start), testCase.minDuration)
+		})
+	}
+}
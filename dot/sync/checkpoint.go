@@ -0,0 +1,41 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+// Checkpoint is a trusted finalised block the chain sync targets. Blocks conflicting
+// with it are rejected, its ancestors are imported without their BABE and justification
+// verifications, and it is finalised with its justification once imported.
+type Checkpoint struct {
+	Header *types.Header
+	// Justification is the verified justification finalising the checkpoint block.
+	Justification []byte
+	// Round and SetID are the GRANDPA round and authority set ID
+	// the checkpoint block is finalised in by its justification.
+	Round uint64
+	SetID uint64
+}
+
+// isAbove returns true if the header given is below the checkpoint block,
+// so it is finalised once the checkpoint block is if it is one of its ancestors.
+// It returns false for a nil checkpoint.
+func (c *Checkpoint) isAbove(header *types.Header) bool {
+	return c != nil && header.Number < c.Header.Number
+}
+
+// conflicts returns true if the header given is at the number of the
+// checkpoint block but is not the checkpoint block.
+// It returns false for a nil checkpoint.
+func (c *Checkpoint) conflicts(header *types.Header) bool {
+	return c != nil && header.Number == c.Header.Number && header.Hash() != c.Header.Hash()
+}
+
+// isBlock returns true if the header of the block data given is the checkpoint block header.
+// It returns false for a nil checkpoint.
+func (c *Checkpoint) isBlock(blockData *types.BlockData) bool {
+	return c != nil && blockData.Header != nil && blockData.Header.Hash() == c.Header.Hash()
+}
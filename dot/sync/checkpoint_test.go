@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Checkpoint(t *testing.T) {
+	t.Parallel()
+
+	checkpointHeader := &types.Header{Number: 10, StateRoot: common.Hash{1}}
+	forkHeader := &types.Header{Number: 10, StateRoot: common.Hash{2}}
+	childHeader := &types.Header{ParentHash: checkpointHeader.Hash(), Number: 11}
+
+	testCases := map[string]struct {
+		checkpoint *Checkpoint
+		header     *types.Header
+		// blockHash is the hash of the block data, the header hash if it is empty
+		blockHash common.Hash
+		conflicts bool
+		isBlock   bool
+	}{
+		"nil_checkpoint": {
+			header: forkHeader,
+		},
+		"checkpoint_block": {
+			checkpoint: &Checkpoint{Header: checkpointHeader},
+			header:     checkpointHeader,
+			isBlock:    true,
+		},
+		"block_at_other_number": {
+			checkpoint: &Checkpoint{Header: checkpointHeader},
+			header:     childHeader,
+		},
+		"competing_fork_block": {
+			checkpoint: &Checkpoint{Header: checkpointHeader},
+			header:     forkHeader,
+			conflicts:  true,
+		},
+		"checkpoint_hash_with_fork_header": {
+			checkpoint: &Checkpoint{Header: checkpointHeader},
+			header:     forkHeader,
+			blockHash:  checkpointHeader.Hash(),
+			conflicts:  true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			blockData := &types.BlockData{Hash: testCase.blockHash, Header: testCase.header}
+			if blockData.Hash.IsEmpty() {
+				blockData.Hash = testCase.header.Hash()
+			}
+			assert.Equal(t, testCase.conflicts, testCase.checkpoint.conflicts(testCase.header))
+			assert.Equal(t, testCase.isBlock, testCase.checkpoint.isBlock(blockData))
+		})
+	}
+}
@@ -5,9 +5,11 @@
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"golang.org/x/exp/maps"
@@ -17,6 +19,13 @@
 	// ttl is the time that a block can stay in this set before being cleared.
 	ttl                 = 10 * time.Minute
 	clearBlocksInterval = time.Minute
+
+	// pendingBlockSize is the estimated number of bytes of a pending block held
+	// in memory, excluding its header, body and justification.
+	pendingBlockSize = 128
+	// pendingHeaderSize is the estimated number of bytes of a block header held
+	// in memory, including its digest.
+	pendingHeaderSize = 512
 )
 
 var (
@@ -27,7 +36,7 @@
 // DisjointBlockSet represents a set of incomplete blocks, or blocks
 // with an unknown parent. it is implemented by *disjointBlockSet
 type DisjointBlockSet interface {
-	run(finalisedCh <-chan *types.FinalisationInfo, stop <-chan struct{}, wg *sync.WaitGroup)
+	run(finalised <-chan events.BlockFinalised, stop <-chan struct{}, wg *sync.WaitGroup)
 	addHashAndNumber(hash common.Hash, number uint) error
 	addHeader(*types.Header) error
 	addBlock(*types.Block) error
@@ -84,6 +93,20 @@ func (b *pendingBlock) toBlockData() *types.BlockData {
 	}
 }
 
+// memoryUsage returns the estimated number of bytes of the pending block held in memory.
+func (b *pendingBlock) memoryUsage() (usage uint64) {
+	usage = pendingBlockSize + uint64(len(b.justification))
+	if b.header != nil {
+		usage += pendingHeaderSize
+	}
+	if b.body != nil {
+		for _, extrinsic := range *b.body {
+			usage += uint64(len(extrinsic))
+		}
+	}
+	return usage
+}
+
 // disjointBlockSet contains a list of incomplete (pending) blocks
 // the header may have empty fields; they may have hash and number only,
 // or they may have all their header fields, or they may be complete.
@@ -114,7 +137,7 @@ func newDisjointBlockSet(limit int) *disjointBlockSet {
 	}
 }
 
-func (s *disjointBlockSet) run(finalisedCh <-chan *types.FinalisationInfo, stop <-chan struct{}, wg *sync.WaitGroup) {
+func (s *disjointBlockSet) run(finalised <-chan events.BlockFinalised, stop <-chan struct{}, wg *sync.WaitGroup) {
 	ticker := time.NewTicker(clearBlocksInterval)
 	defer func() {
 		ticker.Stop()
@@ -125,8 +148,13 @@ func (s *disjointBlockSet) run(finalisedCh <-chan *types.FinalisationInfo, stop
 		select {
 		case <-ticker.C:
 			s.clearBlocks()
-		case finalisedInfo := <-finalisedCh:
-			s.removeLowerBlocks(finalisedInfo.Header.Number)
+		case event, ok := <-finalised:
+			if !ok {
+				// unsubscribed, the blocks are only cleared once expired
+				finalised = nil
+				continue
+			}
+			s.removeLowerBlocks(event.Info.Header.Number)
 		case <-stop:
 			return
 		}
@@ -263,6 +291,42 @@ func (s *disjointBlockSet) removeLowerBlocks(num uint) {
 	}
 }
 
+// MemoryUsage returns the estimated number of bytes of the pending blocks held in memory.
+func (s *disjointBlockSet) MemoryUsage() (usage uint64) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, block := range s.blocks {
+		usage += block.memoryUsage()
+	}
+	return usage
+}
+
+// Shrink removes the pending blocks with the highest numbers, which are the furthest
+// from being imported, until the estimated number of bytes of the pending blocks held
+// in memory is at most the target given.
+func (s *disjointBlockSet) Shrink(target uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	blocks := maps.Values(s.blocks)
+	var usage uint64
+	for _, block := range blocks {
+		usage += block.memoryUsage()
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].number > blocks[j].number
+	})
+	for _, block := range blocks {
+		if usage <= target {
+			return
+		}
+		usage -= block.memoryUsage()
+		s.removeBlockInner(block.hash)
+	}
+}
+
 func (s *disjointBlockSet) hasBlock(hash common.Hash) bool {
 	s.RLock()
 	defer s.RUnlock()
@@ -482,3 +482,57 @@ func Test_disjointBlockSet_size(t *testing.T) {
 		})
 	}
 }
+
+func Test_disjointBlockSet_Shrink(t *testing.T) {
+	t.Parallel()
+
+	newBlocks := func() map[common.Hash]*pendingBlock {
+		return map[common.Hash]*pendingBlock{
+			{1}: {hash: common.Hash{1}, number: 1},
+			{2}: {hash: common.Hash{2}, number: 2, justification: []byte{1, 2}},
+			{3}: {hash: common.Hash{3}, number: 3, header: &types.Header{ParentHash: common.Hash{2}}},
+		}
+	}
+
+	testCases := map[string]struct {
+		target              uint64
+		expectedBlocks      map[common.Hash]*pendingBlock
+		expectedMemoryUsage uint64
+	}{
+		"under_target": {
+			target:              1 << 20,
+			expectedBlocks:      newBlocks(),
+			expectedMemoryUsage: 3*pendingBlockSize + 2 + pendingHeaderSize,
+		},
+		"highest_blocks_removed_first": {
+			target: pendingBlockSize + 2,
+			expectedBlocks: map[common.Hash]*pendingBlock{
+				{1}: {hash: common.Hash{1}, number: 1},
+			},
+			expectedMemoryUsage: pendingBlockSize,
+		},
+		"all_removed": {
+			target:         0,
+			expectedBlocks: map[common.Hash]*pendingBlock{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &disjointBlockSet{
+				blocks: newBlocks(),
+				parentToChildren: map[common.Hash]map[common.Hash]struct{}{
+					{2}: {{3}: {}},
+				},
+			}
+
+			s.Shrink(testCase.target)
+
+			assert.Equal(t, testCase.expectedBlocks, s.blocks)
+			assert.Equal(t, testCase.expectedMemoryUsage, s.MemoryUsage())
+		})
+	}
+}
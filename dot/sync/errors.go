@@ -5,14 +5,17 @@
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
 )
 
 var (
 	errBlockStatePaused = errors.New("blockstate service has been paused")
 
 	// ErrInvalidBlockRequest is returned when an invalid block request is received
-	ErrInvalidBlockRequest     = errors.New("invalid block request")
-	errInvalidRequestDirection = errors.New("invalid request direction")
+	ErrInvalidBlockRequest     = network.ErrInvalidBlockRequest
+	errInvalidRequestDirection = fmt.Errorf("%w: invalid request direction", network.ErrInvalidBlockRequest)
 	errRequestStartTooHigh     = errors.New("request start number is higher than our best block")
 
 	// chainSync errors
@@ -26,4 +29,8 @@
 	errStartAndEndMismatch        = errors.New("request start and end hash are not on the same chain")
 	errFailedToGetDescendant      = errors.New("failed to find descendant block")
 	errAlreadyInDisjointSet       = errors.New("already in disjoint set")
+	errInvalidBlockAnnounce       = errors.New("invalid block announce")
+	errParentStateRootMismatch    = errors.New("parent state root does not match snapshot state root")
+	errChainSyncPanic             = errors.New("chain sync panicked")
+	errBodyExtrinsicsRootMismatch = errors.New("body does not match the extrinsics root of the header")
 )
@@ -0,0 +1,173 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// maxGapRepairBlocks is the number of finalised blocks, up to the highest
+// finalised block, checked for a missing body when the chain sync starts.
+const maxGapRepairBlocks = network.MaxBlocksInResponse
+
+// repairGaps requests and stores the bodies missing for the last finalised blocks of the
+// canonical chain. A block is left without body if the node stops while storing the blocks
+// finalised, after storing its header, and the block requests of peers would not be served.
+func (cs *chainSync) repairGaps() {
+	defer cs.wg.Done()
+
+	err := cs.repairMissingBodies()
+	if err != nil {
+		logger.Warnf("repairing finalised blocks missing their body: %s", err)
+	}
+}
+
+func (cs *chainSync) repairMissingBodies() error {
+	finalised, err := cs.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	} else if finalised.Number == 0 {
+		return nil
+	}
+
+	start := uint(1)
+	if finalised.Number > maxGapRepairBlocks {
+		start = finalised.Number - maxGapRepairBlocks + 1
+	}
+
+	availabilities, err := cs.blockState.BlockAvailabilityRange(start, finalised.Number)
+	if err != nil {
+		return fmt.Errorf("getting availability of blocks %d to %d: %w", start, finalised.Number, err)
+	}
+
+	for _, request := range missingBodyRequests(start, availabilities) {
+		resultCh := make(chan *syncTaskResult, 1)
+		err = cs.submitRequest(request, nil, resultCh)
+		if err != nil {
+			return err
+		}
+
+		var result *syncTaskResult
+		select {
+		case result = <-resultCh:
+		case <-cs.stopCh:
+			return nil
+		}
+
+		if result.err != nil {
+			return fmt.Errorf("requesting bodies from peer %s: %w", result.who, result.err)
+		}
+
+		for _, blockData := range result.response.BlockData {
+			err = cs.storeMissingBody(blockData)
+			if err != nil {
+				return fmt.Errorf("storing body from peer %s: %w", result.who, err)
+			}
+		}
+	}
+	return nil
+}
+
+// missingBodyRequests returns the ascending requests of the bodies of the blocks whose
+// header is stored without their body, given the availabilities of the blocks numbered
+// from the start given.
+func missingBodyRequests(start uint, availabilities []state.BlockAvailability) (
+	requests []*network.BlockRequestMessage) {
+	for i := 0; i < len(availabilities); i++ {
+		if !missesBody(availabilities[i]) {
+			continue
+		}
+
+		first := i
+		for i+1 < len(availabilities) && missesBody(availabilities[i+1]) &&
+			i+1-first < network.MaxBlocksInResponse {
+			i++
+		}
+
+		request := network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(start + uint(first))),
+			uint32(i-first+1), network.RequestedDataBody, network.Ascending)
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func missesBody(availability state.BlockAvailability) bool {
+	return availability.Has(state.HeaderAvailable) && !availability.Has(state.BodyAvailable)
+}
+
+// storeMissingBody stores the body of the block data given, if the block header
+// is stored without its body and the body matches its extrinsics root.
+// The bodies of the blocks not stored, such as the blocks of another chain, are ignored.
+func (cs *chainSync) storeMissingBody(blockData *types.BlockData) error {
+	if blockData.Body == nil {
+		return fmt.Errorf("%w: %s", errNilBodyInResponse, blockData.Hash)
+	}
+
+	hasHeader, err := cs.blockState.HasHeader(blockData.Hash)
+	if err != nil {
+		return fmt.Errorf("checking header of block %s: %w", blockData.Hash, err)
+	} else if !hasHeader {
+		return nil
+	}
+
+	header, err := cs.blockState.GetHeader(blockData.Hash)
+	if err != nil {
+		return fmt.Errorf("getting header of block %s: %w", blockData.Hash, err)
+	}
+
+	matches, err := bodyMatchesExtrinsicsRoot(*blockData.Body, header.ExtrinsicsRoot)
+	if err != nil {
+		return fmt.Errorf("computing extrinsics root of block %s: %w", blockData.Hash, err)
+	} else if !matches {
+		return fmt.Errorf("%w: block #%d (%s)", errBodyExtrinsicsRootMismatch, header.Number, blockData.Hash)
+	}
+
+	err = cs.blockState.SetBlockBody(blockData.Hash, blockData.Body)
+	if err != nil {
+		return fmt.Errorf("setting body of block %s: %w", blockData.Hash, err)
+	}
+
+	logger.Debugf("repaired missing body of block #%d (%s)", header.Number, blockData.Hash)
+	return nil
+}
+
+// bodyMatchesExtrinsicsRoot returns true if the ordered trie root of the extrinsics
+// of the body given is the extrinsics root given, for any of the state trie versions
+// since the version used by the runtime is not known.
+func bodyMatchesExtrinsicsRoot(body types.Body, extrinsicsRoot common.Hash) (bool, error) {
+	extrinsics, err := body.AsEncodedExtrinsics()
+	if err != nil {
+		return false, fmt.Errorf("encoding extrinsics: %w", err)
+	}
+
+	entries := make(trie.Entries, len(extrinsics))
+	for i, extrinsic := range extrinsics {
+		key, err := scale.Marshal(big.NewInt(int64(i)))
+		if err != nil {
+			return false, fmt.Errorf("encoding extrinsic index %d: %w", i, err)
+		}
+		entries[i] = trie.Entry{Key: key, Value: extrinsic}
+	}
+
+	for _, version := range []trie.TrieLayout{trie.V0, trie.V1} {
+		root, err := version.Root(inmemory_trie.NewEmptyTrie(), entries)
+		if err != nil {
+			return false, fmt.Errorf("computing %s root: %w", version, err)
+		}
+		if root == extrinsicsRoot {
+			return true, nil
+		}
+	}
+	return false, nil
+}
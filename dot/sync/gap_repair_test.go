@@ -0,0 +1,202 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_missingBodyRequests(t *testing.T) {
+	t.Parallel()
+
+	const (
+		none   = state.BlockAvailability(0)
+		header = state.HeaderAvailable
+		full   = state.FullBlockAvailable
+	)
+
+	manyMissing := make([]state.BlockAvailability, network.MaxBlocksInResponse+2)
+	for i := range manyMissing {
+		manyMissing[i] = header
+	}
+
+	testCases := map[string]struct {
+		start          uint
+		availabilities []state.BlockAvailability
+		requests       []*network.BlockRequestMessage
+	}{
+		"no_block": {
+			start: 1,
+		},
+		"all_bodies_stored": {
+			start:          1,
+			availabilities: []state.BlockAvailability{full, full, full},
+		},
+		"blocks_not_stored_are_ignored": {
+			start:          1,
+			availabilities: []state.BlockAvailability{full, none, full},
+		},
+		"contiguous_and_separate_missing_bodies": {
+			start:          10,
+			availabilities: []state.BlockAvailability{header, header, full, none, header},
+			requests: []*network.BlockRequestMessage{
+				network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(10)), 2,
+					network.RequestedDataBody, network.Ascending),
+				network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(14)), 1,
+					network.RequestedDataBody, network.Ascending),
+			},
+		},
+		"missing_bodies_split_by_response_size": {
+			start:          1,
+			availabilities: manyMissing,
+			requests: []*network.BlockRequestMessage{
+				network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(1)), network.MaxBlocksInResponse,
+					network.RequestedDataBody, network.Ascending),
+				network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(1 + network.MaxBlocksInResponse)), 2,
+					network.RequestedDataBody, network.Ascending),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			requests := missingBodyRequests(testCase.start, testCase.availabilities)
+			assert.Equal(t, testCase.requests, requests)
+		})
+	}
+}
+
+func Test_bodyMatchesExtrinsicsRoot(t *testing.T) {
+	t.Parallel()
+
+	body := types.NewBody([]types.Extrinsic{{1, 2}, {3}})
+	extrinsics, err := body.AsEncodedExtrinsics()
+	require.NoError(t, err)
+
+	entries := make(trie.Entries, len(extrinsics))
+	for i, extrinsic := range extrinsics {
+		key, err := scale.Marshal(big.NewInt(int64(i)))
+		require.NoError(t, err)
+		entries[i] = trie.Entry{Key: key, Value: extrinsic}
+	}
+	root, err := trie.V0.Root(inmemory_trie.NewEmptyTrie(), entries)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		body           types.Body
+		extrinsicsRoot common.Hash
+		matches        bool
+	}{
+		"empty_body": {
+			body:           types.Body{},
+			extrinsicsRoot: trie.EmptyHash,
+			matches:        true,
+		},
+		"matching_body": {
+			body:           *body,
+			extrinsicsRoot: root,
+			matches:        true,
+		},
+		"body_of_another_block": {
+			body:           *body,
+			extrinsicsRoot: trie.EmptyHash,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			matches, err := bodyMatchesExtrinsicsRoot(testCase.body, testCase.extrinsicsRoot)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.matches, matches)
+		})
+	}
+}
+
+func Test_chainSync_storeMissingBody(t *testing.T) {
+	t.Parallel()
+
+	hash := common.Hash{1}
+	emptyBody := types.NewBody(nil)
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		blockData       *types.BlockData
+		blockStateSetup func(blockState *MockBlockState)
+		errWrapped      error
+	}{
+		"nil_body": {
+			blockData:       &types.BlockData{Hash: hash},
+			blockStateSetup: func(*MockBlockState) {},
+			errWrapped:      errNilBodyInResponse,
+		},
+		"header_not_stored": {
+			blockData: &types.BlockData{Hash: hash, Body: emptyBody},
+			blockStateSetup: func(blockState *MockBlockState) {
+				blockState.EXPECT().HasHeader(hash).Return(false, nil)
+			},
+		},
+		"body_mismatch": {
+			blockData: &types.BlockData{Hash: hash, Body: emptyBody},
+			blockStateSetup: func(blockState *MockBlockState) {
+				blockState.EXPECT().HasHeader(hash).Return(true, nil)
+				blockState.EXPECT().GetHeader(hash).Return(&types.Header{Number: 1}, nil)
+			},
+			errWrapped: errBodyExtrinsicsRootMismatch,
+		},
+		"set_body_error": {
+			blockData: &types.BlockData{Hash: hash, Body: emptyBody},
+			blockStateSetup: func(blockState *MockBlockState) {
+				blockState.EXPECT().HasHeader(hash).Return(true, nil)
+				blockState.EXPECT().GetHeader(hash).
+					Return(&types.Header{Number: 1, ExtrinsicsRoot: trie.EmptyHash}, nil)
+				blockState.EXPECT().SetBlockBody(hash, emptyBody).Return(errTest)
+			},
+			errWrapped: errTest,
+		},
+		"body_stored": {
+			blockData: &types.BlockData{Hash: hash, Body: emptyBody},
+			blockStateSetup: func(blockState *MockBlockState) {
+				blockState.EXPECT().HasHeader(hash).Return(true, nil)
+				blockState.EXPECT().GetHeader(hash).
+					Return(&types.Header{Number: 1, ExtrinsicsRoot: trie.EmptyHash}, nil)
+				blockState.EXPECT().SetBlockBody(hash, emptyBody).Return(nil)
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			testCase.blockStateSetup(blockState)
+			cs := &chainSync{blockState: blockState}
+
+			err := cs.storeMissingBody(testCase.blockData)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+const (
+	// slowBlockImportThreshold is the import duration above
+	// which a block is reported as slow when it is imported.
+	slowBlockImportThreshold = 2 * time.Second
+
+	// slowestBlocksTracked is the number of the slowest blocks
+	// imported kept between two sync stats reports.
+	slowestBlocksTracked = 5
+)
+
+var blockImportPhaseHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "gossamer_sync",
+	Name:      "block_import_phase_seconds",
+	Help:      "duration of the phases of the block imports, by block origin",
+	Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+}, []string{"origin", "phase"})
+
+func (o blockOrigin) String() string {
+	switch o {
+	case networkInitialSync:
+		return "initial_sync"
+	case networkBroadcast:
+		return "broadcast"
+	case networkInitialSyncAncient:
+		return "initial_sync_ancient"
+	default:
+		return "unknown"
+	}
+}
+
+// blockImportTimings are the durations of the phases of a block import.
+type blockImportTimings struct {
+	hash       common.Hash
+	number     uint
+	origin     blockOrigin
+	extrinsics int
+
+	verification time.Duration
+	execution    time.Duration
+	commit       time.Duration
+}
+
+func (t blockImportTimings) total() time.Duration {
+	return t.verification + t.execution + t.commit
+}
+
+func (t blockImportTimings) String() string {
+	return fmt.Sprintf("block #%d (%s) from %s with %d extrinsics took %s "+
+		"(verification: %s, execution: %s, commit: %s)",
+		t.number, t.hash.Short(), t.origin, t.extrinsics, t.total(),
+		t.verification, t.execution, t.commit)
+}
+
+// importStats keeps the slowest blocks imported since the last sync stats report.
+// Its zero value is ready to use.
+type importStats struct {
+	mutex sync.Mutex
+	// slowest is ordered from the slowest block import.
+	slowest []blockImportTimings
+}
+
+// record records the timings of a block import in the block import metrics,
+// and keeps them if the block is one of the slowest imported.
+func (s *importStats) record(timings blockImportTimings) {
+	origin := timings.origin.String()
+	if timings.origin != networkInitialSyncAncient {
+		// ancient blocks from the initial sync are not verified
+		blockImportPhaseHistogram.WithLabelValues(origin, "verification").
+			Observe(timings.verification.Seconds())
+	}
+	blockImportPhaseHistogram.WithLabelValues(origin, "execution").Observe(timings.execution.Seconds())
+	blockImportPhaseHistogram.WithLabelValues(origin, "commit").Observe(timings.commit.Seconds())
+
+	if timings.total() >= slowBlockImportThreshold {
+		logger.Warnf("🐢 slow import of %s", timings)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	index := len(s.slowest)
+	for index > 0 && s.slowest[index-1].total() < timings.total() {
+		index--
+	}
+	if index == slowestBlocksTracked {
+		return
+	}
+
+	s.slowest = append(s.slowest, blockImportTimings{})
+	copy(s.slowest[index+1:], s.slowest[index:])
+	s.slowest[index] = timings
+	if len(s.slowest) > slowestBlocksTracked {
+		s.slowest = s.slowest[:slowestBlocksTracked]
+	}
+}
+
+// takeSlowest returns the slowest blocks imported since its last call,
+// ordered from the slowest block import.
+func (s *importStats) takeSlowest() (slowest []blockImportTimings) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	slowest = s.slowest
+	s.slowest = nil
+	return slowest
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_blockImportTimings_String(t *testing.T) {
+	t.Parallel()
+
+	timings := blockImportTimings{
+		hash:         common.Hash{1},
+		number:       10,
+		origin:       networkBroadcast,
+		extrinsics:   3,
+		verification: time.Millisecond,
+		execution:    2 * time.Second,
+		commit:       10 * time.Millisecond,
+	}
+
+	const expected = "block #10 (0x01000000...00000000) from broadcast with 3 extrinsics took 2.011s " +
+		"(verification: 1ms, execution: 2s, commit: 10ms)"
+	assert.Equal(t, expected, timings.String())
+}
+
+func Test_importStats(t *testing.T) {
+	t.Parallel()
+
+	stats := &importStats{}
+	assert.Empty(t, stats.takeSlowest())
+
+	newTimings := func(number uint, execution time.Duration) blockImportTimings {
+		return blockImportTimings{
+			number:    number,
+			origin:    networkInitialSync,
+			execution: execution,
+		}
+	}
+
+	for number := uint(1); number <= slowestBlocksTracked+2; number++ {
+		stats.record(newTimings(number, time.Duration(number%4)*time.Millisecond))
+	}
+
+	expected := []blockImportTimings{
+		newTimings(3, 3*time.Millisecond),
+		newTimings(7, 3*time.Millisecond),
+		newTimings(2, 2*time.Millisecond),
+		newTimings(6, 2*time.Millisecond),
+		newTimings(1, time.Millisecond),
+	}
+	assert.Equal(t, expected, stats.takeSlowest())
+	assert.Empty(t, stats.takeSlowest())
+}
@@ -8,6 +8,7 @@
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -29,15 +30,21 @@ type BlockState interface {
 	GetMessageQueue(common.Hash) ([]byte, error)
 	GetJustification(common.Hash) ([]byte, error)
 	SetJustification(hash common.Hash, data []byte) error
+	SetFinalisedHash(hash common.Hash, round, setID uint64) error
 	GetHashByNumber(blockNumber uint) (common.Hash, error)
 	GetBlockByHash(common.Hash) (*types.Block, error)
 	GetRuntime(blockHash common.Hash) (runtime runtime.Instance, err error)
 	StoreRuntime(blockHash common.Hash, runtime runtime.Instance)
 	GetHighestFinalisedHeader() (*types.Header, error)
-	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 	GetHeaderByNumber(num uint) (*types.Header, error)
+	GetBlockDatasInRange(start, end uint, requested state.BlockAvailability) ([]*types.BlockData, error)
+	BlockAvailabilityRange(start, end uint) ([]state.BlockAvailability, error)
+	SetBlockBody(hash common.Hash, body *types.Body) error
 	GetAllBlocksAtNumber(num uint) ([]common.Hash, error)
 	IsDescendantOf(parent, child common.Hash) (bool, error)
+	GetBadBlocks() ([]common.Hash, error)
+	AddBadBlock(hash common.Hash) error
+	RemoveBadBlock(hash common.Hash) error
 
 	IsPaused() bool
 	Pause() error
@@ -57,11 +64,15 @@ type TransactionState interface {
 // BabeVerifier deals with BABE block verification
 type BabeVerifier interface {
 	VerifyBlock(header *types.Header) error
+	VerifyBlockAnnounce(header *types.Header) error
 }
 
-// FinalityGadget implements justification verification functionality
+// FinalityGadget implements justification verification functionality.
+// A block justification verified is stored and the block is finalised.
 type FinalityGadget interface {
+	CheckJustification([]byte) error
 	VerifyBlockJustification(common.Hash, []byte) error
+	VerifyUnimportedJustification(hash common.Hash, number uint, justification []byte) error
 }
 
 // BlockImportHandler is the interface for the handler of newly imported blocks
@@ -80,6 +91,10 @@ type Network interface {
 	AllConnectedPeersIDs() []peer.ID
 
 	BlockAnnounceHandshake(*types.Header) error
+
+	// ReleaseSyncOnlyPeers disconnects the peers not gossiping block announces
+	// with the node, down to minPeers connected peers, and returns how many were released.
+	ReleaseSyncOnlyPeers(minPeers int) (released int)
 }
 
 // Telemetry is the telemetry client to send telemetry messages.
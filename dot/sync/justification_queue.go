@@ -0,0 +1,108 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// justificationQueueSize is the number of justifications waiting for their
+// verification above which the block import waits for the queue to be drained.
+const justificationQueueSize = 256
+
+var justificationQueueGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "gossamer_sync",
+	Name:      "justification_queue_length",
+	Help:      "number of justifications waiting for their verification",
+})
+
+// justificationImport is a justification received for a block imported,
+// from the peer who if it is known.
+type justificationImport struct {
+	hash          common.Hash
+	number        uint
+	justification []byte
+	who           peer.ID
+}
+
+// justificationQueue verifies the justifications received with the blocks imported,
+// finalising their blocks, so the block import does not wait for the verification
+// of their signatures.
+// The justifications are imported in the order they are pushed, since a justification
+// can only be verified once the blocks finalised before it are finalised.
+// The peer a justification failing its verification was received from is reported.
+type justificationQueue struct {
+	finalityGadget FinalityGadget
+	network        Network
+	imports        chan justificationImport
+	stop           <-chan struct{}
+}
+
+func newJustificationQueue(finalityGadget FinalityGadget, network Network,
+	stop <-chan struct{}) *justificationQueue {
+	return &justificationQueue{
+		finalityGadget: finalityGadget,
+		network:        network,
+		imports:        make(chan justificationImport, justificationQueueSize),
+		stop:           stop,
+	}
+}
+
+// push queues the justification of the block given, received from the peer who,
+// for its import. The peer is empty for a justification which is not received
+// from the network. It blocks if the queue is full, until the queue is stopped.
+func (q *justificationQueue) push(header *types.Header, justification []byte, who peer.ID) {
+	item := justificationImport{
+		hash:          header.Hash(),
+		number:        header.Number,
+		justification: justification,
+		who:           who,
+	}
+
+	select {
+	case q.imports <- item:
+		justificationQueueGauge.Inc()
+	case <-q.stop:
+	}
+}
+
+func (q *justificationQueue) run(wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		select {
+		case item := <-q.imports:
+			justificationQueueGauge.Dec()
+			err := q.importJustification(item)
+			if err != nil {
+				logger.Errorf("importing justification for block %s: %s", item.hash, err)
+			}
+		case <-q.stop:
+			return
+		}
+	}
+}
+
+func (q *justificationQueue) importJustification(item justificationImport) error {
+	err := q.finalityGadget.VerifyBlockJustification(item.hash, item.justification)
+	if err != nil {
+		if item.who != "" {
+			q.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadJustificationValue,
+				Reason: peerset.BadJustificationReason,
+			}, item.who)
+		}
+		return fmt.Errorf("verifying block number %d justification: %w", item.number, err)
+	}
+	return nil
+}
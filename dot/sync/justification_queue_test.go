@@ -0,0 +1,135 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_justificationQueue(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	finalityGadget := NewMockFinalityGadget(ctrl)
+
+	invalidHeader := &types.Header{Number: 1}
+	validHeader := &types.Header{ParentHash: common.Hash{1}, Number: 2}
+	invalidJustification := []byte{1}
+	validJustification := []byte{2}
+
+	errTest := errors.New("test error")
+	imported := make(chan struct{})
+	gomock.InOrder(
+		finalityGadget.EXPECT().
+			VerifyBlockJustification(invalidHeader.Hash(), invalidJustification).
+			Return(errTest),
+		// a justification failing its verification does not stop the queue
+		finalityGadget.EXPECT().
+			VerifyBlockJustification(validHeader.Hash(), validJustification).
+			DoAndReturn(func(common.Hash, []byte) error {
+				close(imported)
+				return nil
+			}),
+	)
+
+	network := NewMockNetwork(ctrl)
+	network.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.BadJustificationValue,
+		Reason: peerset.BadJustificationReason,
+	}, peer.ID("invalid"))
+
+	stop := make(chan struct{})
+	queue := newJustificationQueue(finalityGadget, network, stop)
+
+	// the justifications are queued without waiting for their verification
+	queue.push(invalidHeader, invalidJustification, peer.ID("invalid"))
+	queue.push(validHeader, validJustification, peer.ID("valid"))
+	assert.Len(t, queue.imports, 2)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go queue.run(&wg)
+
+	<-imported
+	close(stop)
+	wg.Wait()
+
+	// pushing to a stopped queue does not block
+	for i := 0; i <= justificationQueueSize; i++ {
+		queue.push(validHeader, validJustification, peer.ID("valid"))
+	}
+}
+
+func Test_justificationQueue_importJustification(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: 1}
+	justification := []byte{1}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		who        peer.ID
+		verifyErr  error
+		reported   bool
+		errWrapped error
+		errMessage string
+	}{
+		"verification_error": {
+			who:        peer.ID("peer"),
+			verifyErr:  errTest,
+			reported:   true,
+			errWrapped: errTest,
+			errMessage: "verifying block number 1 justification: test error",
+		},
+		"verification_error_without_peer": {
+			verifyErr:  errTest,
+			errWrapped: errTest,
+			errMessage: "verifying block number 1 justification: test error",
+		},
+		"success": {
+			who: peer.ID("peer"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			finalityGadget := NewMockFinalityGadget(ctrl)
+			finalityGadget.EXPECT().VerifyBlockJustification(header.Hash(), justification).
+				Return(testCase.verifyErr)
+			network := NewMockNetwork(ctrl)
+			if testCase.reported {
+				network.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadJustificationValue,
+					Reason: peerset.BadJustificationReason,
+				}, testCase.who)
+			}
+			queue := newJustificationQueue(finalityGadget, network, nil)
+
+			err := queue.importJustification(justificationImport{
+				hash:          header.Hash(),
+				number:        header.Number,
+				justification: justification,
+				who:           testCase.who,
+			})
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -5,8 +5,10 @@
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
@@ -23,18 +25,22 @@ func (s *Service) CreateBlockResponse(req *network.BlockRequestMessage) (*networ
 	}
 }
 
+// maxBlocksInResponse returns the maximum number of blocks of the response to the request given,
+// which is MaxBlocksInResponse unless the request sets a lower non-zero maximum.
+func maxBlocksInResponse(req *network.BlockRequestMessage) uint {
+	if req.Max != nil && *req.Max > 0 && *req.Max < network.MaxBlocksInResponse {
+		return uint(*req.Max)
+	}
+	return network.MaxBlocksInResponse
+}
+
 func (s *Service) handleAscendingRequest(req *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
 	var (
-		max         uint = network.MaxBlocksInResponse
+		max         = maxBlocksInResponse(req)
 		startHash   *common.Hash
 		startNumber uint
 	)
 
-	// determine maximum response size
-	if req.Max != nil && *req.Max < network.MaxBlocksInResponse {
-		max = uint(*req.Max)
-	}
-
 	bestBlockNumber, err := s.blockState.BestBlockNumber()
 	if err != nil {
 		return nil, fmt.Errorf("getting best block for request: %w", err)
@@ -102,14 +108,9 @@ func (s *Service) handleDescendingRequest(req *network.BlockRequestMessage) (*ne
 	var (
 		startHash   *common.Hash
 		startNumber uint
-		max         uint = network.MaxBlocksInResponse
+		max         = maxBlocksInResponse(req)
 	)
 
-	// determine maximum response size
-	if req.Max != nil && *req.Max < network.MaxBlocksInResponse {
-		max = uint(*req.Max)
-	}
-
 	switch startBlock := req.StartingBlock.Value().(type) {
 	case uint32:
 		bestBlockNumber, err := s.blockState.BestBlockNumber()
@@ -138,7 +139,7 @@ func (s *Service) handleDescendingRequest(req *network.BlockRequestMessage) (*ne
 	}
 
 	endNumber := uint(1)
-	if startNumber > max+1 {
+	if startNumber > max {
 		endNumber = startNumber - max + 1
 	}
 
@@ -249,38 +250,60 @@ func (s *Service) checkOrGetDescendantHash(ancestor common.Hash,
 
 func (s *Service) handleAscendingByNumber(start, end uint,
 	requestedData byte) (*network.BlockResponseMessage, error) {
-	var err error
-	data := make([]*types.BlockData, (end-start)+1)
+	builder := newBlockResponseBuilder(end - start + 1)
+	for chunkStart := start; chunkStart <= end; chunkStart += blockResponseChunkSize {
+		chunkEnd := min(chunkStart+blockResponseChunkSize-1, end)
+		data, err := s.getBlockDatasInRange(chunkStart, chunkEnd, requestedData)
+		if err != nil {
+			return nil, err
+		}
 
-	for i := uint(0); start+i <= end; i++ {
-		blockNumber := start + i
-		data[i], err = s.getBlockDataByNumber(blockNumber, requestedData)
+		full, err := builder.add(data)
 		if err != nil {
 			return nil, err
 		}
+
+		// stop once the response is full or the best block is reached
+		if full || uint(len(data)) <= chunkEnd-chunkStart {
+			break
+		}
 	}
 
-	return &network.BlockResponseMessage{
-		BlockData: data,
-	}, nil
+	return builder.response(), nil
 }
 
 func (s *Service) handleDescendingByNumber(start, end uint,
 	requestedData byte) (*network.BlockResponseMessage, error) {
-	var err error
-	data := make([]*types.BlockData, (start-end)+1)
+	if start < end {
+		return &network.BlockResponseMessage{
+			BlockData: []*types.BlockData{},
+		}, nil
+	}
 
-	for i := uint(0); start-i >= end; i++ {
-		blockNumber := start - i
-		data[i], err = s.getBlockDataByNumber(blockNumber, requestedData)
+	builder := newBlockResponseBuilder(start - end + 1)
+	for chunkEnd := start; ; chunkEnd -= blockResponseChunkSize {
+		chunkStart := end
+		if chunkEnd-end >= blockResponseChunkSize {
+			chunkStart = chunkEnd - blockResponseChunkSize + 1
+		}
+
+		data, err := s.getBlockDatasInRange(chunkStart, chunkEnd, requestedData)
 		if err != nil {
 			return nil, err
 		}
+		reverseBlockData(data)
+
+		full, err := builder.add(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if full || chunkStart == end {
+			break
+		}
 	}
 
-	return &network.BlockResponseMessage{
-		BlockData: data,
-	}, nil
+	return builder.response(), nil
 }
 
 func (s *Service) handleChainByHash(ancestor, descendant common.Hash,
@@ -301,32 +324,54 @@ func (s *Service) handleChainByHash(ancestor, descendant common.Hash,
 		}
 	}
 
-	data := make([]*types.BlockData, len(subchain))
+	// respond with the blocks in the direction requested
+	if direction == network.Descending {
+		slices.Reverse(subchain)
+	}
 
-	for i, hash := range subchain {
-		data[i], err = s.getBlockData(hash, requestedData)
+	builder := newBlockResponseBuilder(uint(len(subchain)))
+	for _, hash := range subchain {
+		blockData, err := s.getBlockData(hash, requestedData)
 		if err != nil {
 			return nil, err
 		}
-	}
 
-	// reverse BlockData, if descending request
-	if direction == network.Descending {
-		reverseBlockData(data)
+		full, err := builder.add([]*types.BlockData{blockData})
+		if err != nil {
+			return nil, err
+		}
+
+		if full {
+			break
+		}
 	}
 
-	return &network.BlockResponseMessage{
-		BlockData: data,
-	}, nil
+	return builder.response(), nil
 }
 
-func (s *Service) getBlockDataByNumber(num uint, requestedData byte) (*types.BlockData, error) {
-	hash, err := s.blockState.GetHashByNumber(num)
+// getBlockDatasInRange returns the data requested of the blocks of the canonical chain
+// numbered from start to end included, read from the block state in a single pass.
+func (s *Service) getBlockDatasInRange(start, end uint, requestedData byte) ([]*types.BlockData, error) {
+	var requested state.BlockAvailability
+	if requestedData&network.RequestedDataHeader != 0 {
+		requested |= state.HeaderAvailable
+	}
+	if requestedData&network.RequestedDataBody != 0 {
+		requested |= state.BodyAvailable
+	}
+	if requestedData&network.RequestedDataJustification != 0 {
+		requested |= state.JustificationAvailable
+	}
+
+	data, err := s.blockState.GetBlockDatasInRange(start, end, requested)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("getting blocks from %d to %d: %w", start, end, err)
 	}
 
-	return s.getBlockData(hash, requestedData)
+	for _, blockData := range data {
+		s.setReceiptAndMessageQueue(blockData, requestedData)
+	}
+	return data, nil
 }
 
 func (s *Service) getBlockData(hash common.Hash, requestedData byte) (*types.BlockData, error) {
@@ -353,26 +398,81 @@ func (s *Service) getBlockData(hash common.Hash, requestedData byte) (*types.Blo
 		}
 	}
 
+	s.setReceiptAndMessageQueue(blockData, requestedData)
+
+	if (requestedData&network.RequestedDataJustification)>>4 == 1 {
+		retData, err := s.blockState.GetJustification(hash)
+		if err == nil && retData != nil {
+			blockData.Justification = &retData
+		}
+	}
+
+	return blockData, nil
+}
+
+// setReceiptAndMessageQueue sets the receipt and the message queue of the block data
+// if they are requested and stored.
+func (s *Service) setReceiptAndMessageQueue(blockData *types.BlockData, requestedData byte) {
 	if (requestedData&network.RequestedDataReceipt)>>2 == 1 {
-		retData, err := s.blockState.GetReceipt(hash)
+		retData, err := s.blockState.GetReceipt(blockData.Hash)
 		if err == nil && retData != nil {
 			blockData.Receipt = &retData
 		}
 	}
 
 	if (requestedData&network.RequestedDataMessageQueue)>>3 == 1 {
-		retData, err := s.blockState.GetMessageQueue(hash)
+		retData, err := s.blockState.GetMessageQueue(blockData.Hash)
 		if err == nil && retData != nil {
 			blockData.MessageQueue = &retData
 		}
 	}
+}
 
-	if (requestedData&network.RequestedDataJustification)>>4 == 1 {
-		retData, err := s.blockState.GetJustification(hash)
-		if err == nil && retData != nil {
-			blockData.Justification = &retData
+// blockResponseChunkSize is the number of blocks read at once from the block state to respond
+// to a block request by number, so that no more blocks are read once the response is full.
+const blockResponseChunkSize = 16
+
+// blockResponseBuilder builds a block response within the maximum number of blocks requested
+// and the maximum encoded size of a block response.
+type blockResponseBuilder struct {
+	blockData []*types.BlockData
+	maxBlocks uint
+	size      uint64
+}
+
+func newBlockResponseBuilder(maxBlocks uint) *blockResponseBuilder {
+	return &blockResponseBuilder{
+		blockData: make([]*types.BlockData, 0, maxBlocks),
+		maxBlocks: maxBlocks,
+	}
+}
+
+// add adds the block data given in order to the response, and returns true once the response
+// is full. A block data is not added if it would make the encoded response greater than
+// network.MaxBlockResponseSize, unless it is the first block data of the response.
+func (b *blockResponseBuilder) add(blockData []*types.BlockData) (full bool, err error) {
+	for _, bd := range blockData {
+		if uint(len(b.blockData)) == b.maxBlocks {
+			return true, nil
 		}
+
+		size, err := network.BlockDataEncodedSize(bd)
+		if err != nil {
+			return false, fmt.Errorf("encoding block data %s: %w", bd.Hash, err)
+		}
+
+		if len(b.blockData) > 0 && b.size+size > network.MaxBlockResponseSize {
+			return true, nil
+		}
+
+		b.blockData = append(b.blockData, bd)
+		b.size += size
 	}
+	return uint(len(b.blockData)) == b.maxBlocks, nil
+}
 
-	return blockData, nil
+func (b *blockResponseBuilder) response() *network.BlockResponseMessage {
+	return &network.BlockResponseMessage{
+		BlockData: b.blockData,
+	}
 }
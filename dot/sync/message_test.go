@@ -8,10 +8,12 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/common/variadic"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -40,7 +42,8 @@ type args struct {
 			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
 				mockBlockState := NewMockBlockState(ctrl)
 				mockBlockState.EXPECT().BestBlockNumber().Return(uint(1), nil)
-				mockBlockState.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{1, 2}, nil)
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(1), uint(1), state.BlockAvailability(0)).
+					Return([]*types.BlockData{{Hash: common.Hash{1, 2}}}, nil)
 				return mockBlockState
 			},
 			args: args{req: &network.BlockRequestMessage{
@@ -51,6 +54,70 @@ type args struct {
 				Hash: common.Hash{1, 2},
 			}}},
 		},
+		"ascending_request_range": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(3), nil)
+				requested := state.HeaderAvailable | state.JustificationAvailable
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(2), uint(3), requested).
+					Return([]*types.BlockData{{Hash: common.Hash{2}}, {Hash: common.Hash{3}}}, nil)
+				mockBlockState.EXPECT().GetReceipt(common.Hash{2}).Return([]byte{2}, nil)
+				mockBlockState.EXPECT().GetReceipt(common.Hash{3}).Return(nil, errors.New("not found"))
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				RequestedData: network.RequestedDataHeader | network.RequestedDataReceipt |
+					network.RequestedDataJustification,
+				StartingBlock: *variadic.MustNewUint32OrHash(2),
+				Direction:     network.Ascending,
+			}},
+			want: &network.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Hash: common.Hash{2}, Receipt: &[]byte{2}},
+				{Hash: common.Hash{3}},
+			}},
+		},
+		"ascending_request_range_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(1), nil)
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(1), uint(1), state.BlockAvailability(0)).
+					Return(nil, errors.New("test error"))
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				StartingBlock: *variadic.MustNewUint32OrHash(1),
+				Direction:     network.Ascending,
+			}},
+			err: errors.New("getting blocks from 1 to 1: test error"),
+		},
+		"ascending_request_chunks": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(20), nil)
+				firstChunk := make([]*types.BlockData, blockResponseChunkSize)
+				for i := range firstChunk {
+					firstChunk[i] = &types.BlockData{Hash: common.Hash{byte(i + 1)}}
+				}
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(1), uint(16), state.BlockAvailability(0)).
+					Return(firstChunk, nil)
+				// the best block is reached before the end of the second chunk
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(17), uint(20), state.BlockAvailability(0)).
+					Return([]*types.BlockData{{Hash: common.Hash{17}}}, nil)
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				StartingBlock: *variadic.MustNewUint32OrHash(1),
+				Direction:     network.Ascending,
+				Max:           func() *uint32 { max := uint32(0); return &max }(),
+			}},
+			want: &network.BlockResponseMessage{BlockData: func() []*types.BlockData {
+				data := make([]*types.BlockData, 17)
+				for i := range data {
+					data[i] = &types.BlockData{Hash: common.Hash{byte(i + 1)}}
+				}
+				return data
+			}()},
+		},
 		"ascending_request_start_number_higher": {
 			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
 				mockBlockState := NewMockBlockState(ctrl)
@@ -81,7 +148,8 @@ type args struct {
 			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
 				mockBlockState := NewMockBlockState(ctrl)
 				mockBlockState.EXPECT().BestBlockNumber().Return(uint(1), nil)
-				mockBlockState.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{1, 2}, nil)
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(1), uint(1), state.BlockAvailability(0)).
+					Return([]*types.BlockData{{Hash: common.Hash{1, 2}}}, nil)
 				return mockBlockState
 			},
 			args: args{req: &network.BlockRequestMessage{
@@ -93,6 +161,40 @@ type args struct {
 				Hash: common.Hash{1, 2},
 			}}},
 		},
+		"descending_request_range": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(3), nil)
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(1), uint(3), state.BlockAvailability(0)).
+					Return([]*types.BlockData{{Hash: common.Hash{1}}, {Hash: common.Hash{2}}, {Hash: common.Hash{3}}}, nil)
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				StartingBlock: *variadic.MustNewUint32OrHash(3),
+				Direction:     network.Descending,
+			}},
+			want: &network.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Hash: common.Hash{3}}, {Hash: common.Hash{2}}, {Hash: common.Hash{1}},
+			}},
+		},
+		"descending_request_max": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(5), nil)
+				mockBlockState.EXPECT().GetBlockDatasInRange(uint(2), uint(5), state.BlockAvailability(0)).
+					Return([]*types.BlockData{{Hash: common.Hash{2}}, {Hash: common.Hash{3}},
+						{Hash: common.Hash{4}}, {Hash: common.Hash{5}}}, nil)
+				return mockBlockState
+			},
+			args: args{req: &network.BlockRequestMessage{
+				StartingBlock: *variadic.MustNewUint32OrHash(5),
+				Direction:     network.Descending,
+				Max:           func() *uint32 { max := uint32(4); return &max }(),
+			}},
+			want: &network.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Hash: common.Hash{5}}, {Hash: common.Hash{4}}, {Hash: common.Hash{3}}, {Hash: common.Hash{2}},
+			}},
+		},
 		"ascending_request_startHash": {
 			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
 				mockBlockState := NewMockBlockState(ctrl)
@@ -168,6 +270,63 @@ type args struct {
 	}
 }
 
+func Test_blockResponseBuilder_add(t *testing.T) {
+	t.Parallel()
+
+	// a block data of half the maximum encoded size of a block response
+	halfBody := types.NewBody([]types.Extrinsic{make([]byte, network.MaxBlockResponseSize/2)})
+	halfBlock := &types.BlockData{Hash: common.Hash{1}, Body: halfBody}
+	smallBlock := &types.BlockData{Hash: common.Hash{2}}
+
+	testCases := map[string]struct {
+		maxBlocks uint
+		blockData []*types.BlockData
+		full      bool
+		expected  []*types.BlockData
+	}{
+		"not_full": {
+			maxBlocks: 3,
+			blockData: []*types.BlockData{smallBlock, smallBlock},
+			expected:  []*types.BlockData{smallBlock, smallBlock},
+		},
+		"max_blocks": {
+			maxBlocks: 2,
+			blockData: []*types.BlockData{smallBlock, smallBlock, smallBlock},
+			full:      true,
+			expected:  []*types.BlockData{smallBlock, smallBlock},
+		},
+		"max_size": {
+			maxBlocks: 3,
+			blockData: []*types.BlockData{halfBlock, halfBlock, smallBlock},
+			full:      true,
+			expected:  []*types.BlockData{halfBlock},
+		},
+		"first_block_greater_than_max_size": {
+			maxBlocks: 3,
+			blockData: []*types.BlockData{{
+				Body: types.NewBody([]types.Extrinsic{make([]byte, network.MaxBlockResponseSize)}),
+			}, smallBlock},
+			full: true,
+			expected: []*types.BlockData{{
+				Body: types.NewBody([]types.Extrinsic{make([]byte, network.MaxBlockResponseSize)}),
+			}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := newBlockResponseBuilder(testCase.maxBlocks)
+			full, err := builder.add(testCase.blockData)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.full, full)
+			assert.Equal(t, testCase.expected, builder.response().BlockData)
+		})
+	}
+}
+
 func TestService_checkOrGetDescendantHash(t *testing.T) {
 	t.Parallel()
 
@@ -12,6 +12,7 @@
 import (
 	reflect "reflect"
 
+	events "github.com/ChainSafe/gossamer/dot/events"
 	common "github.com/ChainSafe/gossamer/lib/common"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockChainSync) EXPECT() *MockChainSyncMockRecorder {
 	return m.recorder
 }
 
+// failures mocks base method.
+func (m *MockChainSync) failures() <-chan error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "failures")
+	ret0, _ := ret[0].(<-chan error)
+	return ret0
+}
+
+// failures indicates an expected call of failures.
+func (mr *MockChainSyncMockRecorder) failures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "failures", reflect.TypeOf((*MockChainSync)(nil).failures))
+}
+
 // getHighestBlock mocks base method.
 func (m *MockChainSync) getHighestBlock() (uint, error) {
 	m.ctrl.T.Helper()
@@ -97,10 +112,38 @@ func (mr *MockChainSyncMockRecorder) onBlockAnnounceHandshake(p, hash, number an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "onBlockAnnounceHandshake", reflect.TypeOf((*MockChainSync)(nil).onBlockAnnounceHandshake), p, hash, number)
 }
 
+// restart mocks base method.
+func (m *MockChainSync) restart() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "restart")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// restart indicates an expected call of restart.
+func (mr *MockChainSyncMockRecorder) restart() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "restart", reflect.TypeOf((*MockChainSync)(nil).restart))
+}
+
+// setEventBus mocks base method.
+func (m *MockChainSync) setEventBus(bus *events.Bus) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "setEventBus", bus)
+}
+
+// setEventBus indicates an expected call of setEventBus.
+func (mr *MockChainSyncMockRecorder) setEventBus(bus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setEventBus", reflect.TypeOf((*MockChainSync)(nil).setEventBus), bus)
+}
+
 // start mocks base method.
-func (m *MockChainSync) start() {
+func (m *MockChainSync) start() error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "start")
+	ret := m.ctrl.Call(m, "start")
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // start indicates an expected call of start.
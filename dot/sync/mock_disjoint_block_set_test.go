@@ -13,6 +13,7 @@
 	reflect "reflect"
 	sync0 "sync"
 
+	events "github.com/ChainSafe/gossamer/dot/events"
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
 	gomock "go.uber.org/mock/gomock"
@@ -164,7 +165,7 @@ func (mr *MockDisjointBlockSetMockRecorder) removeLowerBlocks(arg0 any) *gomock.
 }
 
 // run mocks base method.
-func (m *MockDisjointBlockSet) run(arg0 <-chan *types.FinalisationInfo, arg1 <-chan struct{}, arg2 *sync0.WaitGroup) {
+func (m *MockDisjointBlockSet) run(arg0 <-chan events.BlockFinalised, arg1 <-chan struct{}, arg2 *sync0.WaitGroup) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "run", arg0, arg1, arg2)
 }
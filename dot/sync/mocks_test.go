@@ -13,6 +13,7 @@
 	reflect "reflect"
 
 	peerset "github.com/ChainSafe/gossamer/dot/peerset"
+	state "github.com/ChainSafe/gossamer/dot/state"
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
@@ -44,6 +45,20 @@ func (m *MockBlockState) EXPECT() *MockBlockStateMockRecorder {
 	return m.recorder
 }
 
+// AddBadBlock mocks base method.
+func (m *MockBlockState) AddBadBlock(arg0 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBadBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBadBlock indicates an expected call of AddBadBlock.
+func (mr *MockBlockStateMockRecorder) AddBadBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBadBlock", reflect.TypeOf((*MockBlockState)(nil).AddBadBlock), arg0)
+}
+
 // BestBlockHeader mocks base method.
 func (m *MockBlockState) BestBlockHeader() (*types.Header, error) {
 	m.ctrl.T.Helper()
@@ -74,6 +89,21 @@ func (mr *MockBlockStateMockRecorder) BestBlockNumber() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlockNumber", reflect.TypeOf((*MockBlockState)(nil).BestBlockNumber))
 }
 
+// BlockAvailabilityRange mocks base method.
+func (m *MockBlockState) BlockAvailabilityRange(arg0, arg1 uint) ([]state.BlockAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockAvailabilityRange", arg0, arg1)
+	ret0, _ := ret[0].([]state.BlockAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockAvailabilityRange indicates an expected call of BlockAvailabilityRange.
+func (mr *MockBlockStateMockRecorder) BlockAvailabilityRange(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockAvailabilityRange", reflect.TypeOf((*MockBlockState)(nil).BlockAvailabilityRange), arg0, arg1)
+}
+
 // CompareAndSetBlockData mocks base method.
 func (m *MockBlockState) CompareAndSetBlockData(arg0 *types.BlockData) error {
 	m.ctrl.T.Helper()
@@ -103,6 +133,36 @@ func (mr *MockBlockStateMockRecorder) GetAllBlocksAtNumber(arg0 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBlocksAtNumber", reflect.TypeOf((*MockBlockState)(nil).GetAllBlocksAtNumber), arg0)
 }
 
+// GetBadBlocks mocks base method.
+func (m *MockBlockState) GetBadBlocks() ([]common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBadBlocks")
+	ret0, _ := ret[0].([]common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBadBlocks indicates an expected call of GetBadBlocks.
+func (mr *MockBlockStateMockRecorder) GetBadBlocks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBadBlocks", reflect.TypeOf((*MockBlockState)(nil).GetBadBlocks))
+}
+
+// GetBlockDatasInRange mocks base method.
+func (m *MockBlockState) GetBlockDatasInRange(arg0, arg1 uint, arg2 state.BlockAvailability) ([]*types.BlockData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockDatasInRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*types.BlockData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockDatasInRange indicates an expected call of GetBlockDatasInRange.
+func (mr *MockBlockStateMockRecorder) GetBlockDatasInRange(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockDatasInRange", reflect.TypeOf((*MockBlockState)(nil).GetBlockDatasInRange), arg0, arg1, arg2)
+}
+
 // GetBlockBody mocks base method.
 func (m *MockBlockState) GetBlockBody(arg0 common.Hash) (*types.Body, error) {
 	m.ctrl.T.Helper()
@@ -133,20 +193,6 @@ func (mr *MockBlockStateMockRecorder) GetBlockByHash(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockState)(nil).GetBlockByHash), arg0)
 }
 
-// GetFinalisedNotifierChannel mocks base method.
-func (m *MockBlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFinalisedNotifierChannel")
-	ret0, _ := ret[0].(chan *types.FinalisationInfo)
-	return ret0
-}
-
-// GetFinalisedNotifierChannel indicates an expected call of GetFinalisedNotifierChannel.
-func (mr *MockBlockStateMockRecorder) GetFinalisedNotifierChannel() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinalisedNotifierChannel", reflect.TypeOf((*MockBlockState)(nil).GetFinalisedNotifierChannel))
-}
-
 // GetHashByNumber mocks base method.
 func (m *MockBlockState) GetHashByNumber(arg0 uint) (common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +401,48 @@ func (mr *MockBlockStateMockRecorder) RangeInMemory(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeInMemory", reflect.TypeOf((*MockBlockState)(nil).RangeInMemory), arg0, arg1)
 }
 
+// RemoveBadBlock mocks base method.
+func (m *MockBlockState) RemoveBadBlock(arg0 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBadBlock", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBadBlock indicates an expected call of RemoveBadBlock.
+func (mr *MockBlockStateMockRecorder) RemoveBadBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBadBlock", reflect.TypeOf((*MockBlockState)(nil).RemoveBadBlock), arg0)
+}
+
+// SetBlockBody mocks base method.
+func (m *MockBlockState) SetBlockBody(arg0 common.Hash, arg1 *types.Body) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBlockBody", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlockBody indicates an expected call of SetBlockBody.
+func (mr *MockBlockStateMockRecorder) SetBlockBody(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlockBody", reflect.TypeOf((*MockBlockState)(nil).SetBlockBody), arg0, arg1)
+}
+
+// SetFinalisedHash mocks base method.
+func (m *MockBlockState) SetFinalisedHash(arg0 common.Hash, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFinalisedHash", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFinalisedHash indicates an expected call of SetFinalisedHash.
+func (mr *MockBlockStateMockRecorder) SetFinalisedHash(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinalisedHash", reflect.TypeOf((*MockBlockState)(nil).SetFinalisedHash), arg0, arg1, arg2)
+}
+
 // SetJustification mocks base method.
 func (m *MockBlockState) SetJustification(arg0 common.Hash, arg1 []byte) error {
 	m.ctrl.T.Helper()
@@ -515,6 +603,20 @@ func (mr *MockBabeVerifierMockRecorder) VerifyBlock(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlock", reflect.TypeOf((*MockBabeVerifier)(nil).VerifyBlock), arg0)
 }
 
+// VerifyBlockAnnounce mocks base method.
+func (m *MockBabeVerifier) VerifyBlockAnnounce(arg0 *types.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyBlockAnnounce", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyBlockAnnounce indicates an expected call of VerifyBlockAnnounce.
+func (mr *MockBabeVerifierMockRecorder) VerifyBlockAnnounce(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlockAnnounce", reflect.TypeOf((*MockBabeVerifier)(nil).VerifyBlockAnnounce), arg0)
+}
+
 // MockFinalityGadget is a mock of FinalityGadget interface.
 type MockFinalityGadget struct {
 	ctrl     *gomock.Controller
@@ -538,6 +640,20 @@ func (m *MockFinalityGadget) EXPECT() *MockFinalityGadgetMockRecorder {
 	return m.recorder
 }
 
+// CheckJustification mocks base method.
+func (m *MockFinalityGadget) CheckJustification(arg0 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckJustification", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckJustification indicates an expected call of CheckJustification.
+func (mr *MockFinalityGadgetMockRecorder) CheckJustification(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckJustification", reflect.TypeOf((*MockFinalityGadget)(nil).CheckJustification), arg0)
+}
+
 // VerifyBlockJustification mocks base method.
 func (m *MockFinalityGadget) VerifyBlockJustification(arg0 common.Hash, arg1 []byte) error {
 	m.ctrl.T.Helper()
@@ -552,6 +668,20 @@ func (mr *MockFinalityGadgetMockRecorder) VerifyBlockJustification(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlockJustification", reflect.TypeOf((*MockFinalityGadget)(nil).VerifyBlockJustification), arg0, arg1)
 }
 
+// VerifyUnimportedJustification mocks base method.
+func (m *MockFinalityGadget) VerifyUnimportedJustification(arg0 common.Hash, arg1 uint, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUnimportedJustification", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUnimportedJustification indicates an expected call of VerifyUnimportedJustification.
+func (mr *MockFinalityGadgetMockRecorder) VerifyUnimportedJustification(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUnimportedJustification", reflect.TypeOf((*MockFinalityGadget)(nil).VerifyUnimportedJustification), arg0, arg1, arg2)
+}
+
 // MockBlockImportHandler is a mock of BlockImportHandler interface.
 type MockBlockImportHandler struct {
 	ctrl     *gomock.Controller
@@ -654,6 +784,20 @@ func (mr *MockNetworkMockRecorder) Peers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockNetwork)(nil).Peers))
 }
 
+// ReleaseSyncOnlyPeers mocks base method.
+func (m *MockNetwork) ReleaseSyncOnlyPeers(arg0 int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseSyncOnlyPeers", arg0)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ReleaseSyncOnlyPeers indicates an expected call of ReleaseSyncOnlyPeers.
+func (mr *MockNetworkMockRecorder) ReleaseSyncOnlyPeers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSyncOnlyPeers", reflect.TypeOf((*MockNetwork)(nil).ReleaseSyncOnlyPeers), arg0)
+}
+
 // ReportPeer mocks base method.
 func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 	m.ctrl.T.Helper()
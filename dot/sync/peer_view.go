@@ -91,6 +91,15 @@ func (p *peerViewSet) update(peerID peer.ID, hash common.Hash, number uint) {
 	p.view[peerID] = newView
 }
 
+// remove removes the view of the peer given, the target
+// already computed being kept.
+func (p *peerViewSet) remove(peerID peer.ID) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.view, peerID)
+}
+
 func newPeerViewSet(cap int) *peerViewSet {
 	return &peerViewSet{
 		view: make(map[peer.ID]peerView, cap),
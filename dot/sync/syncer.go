@@ -6,14 +6,20 @@
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/internal/memory"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -21,9 +27,20 @@
 
 // Service deals with chain syncing by sending block request messages and watching for responses.
 type Service struct {
-	blockState BlockState
-	chainSync  ChainSync
-	network    Network
+	blockState   BlockState
+	chainSync    ChainSync
+	network      Network
+	babeVerifier BabeVerifier
+	badBlocks    *badBlockSet
+	telemetry    Telemetry
+	// pendingBlocks is the set of the blocks not ready to be imported yet, shared with the chain sync.
+	pendingBlocks *disjointBlockSet
+
+	// restartPolicy is the policy restarting the chain sync after a failure.
+	restartPolicy supervisor.Policy
+	stopCh        chan struct{}
+	supervisor    *supervisor.Supervisor
+	running       sync.WaitGroup
 }
 
 // Pause Pauses the sync service
@@ -46,6 +63,26 @@ type Config struct {
 	Telemetry          Telemetry
 	BadBlocks          []string
 	RequestMaker       network.RequestMaker
+	// TipRequestRacers is the number of peers a single block request is
+	// sent to in tip sync mode, to use the first valid response received.
+	TipRequestRacers uint
+	// VerifyAncientBlocks verifies the BABE authorship of the blocks synced
+	// in bootstrap mode which are committed to a justified block.
+	VerifyAncientBlocks bool
+	// Checkpoint is the trusted checkpoint to sync the chain to, if any.
+	Checkpoint *Checkpoint
+	// MaxRequests is the maximum number of block requests in flight at once,
+	// defaulting to 60 if zero.
+	MaxRequests uint
+	// MaxRequestsPerPeer is the maximum number of block requests in flight
+	// to a single peer, defaulting to 1 if zero.
+	MaxRequestsPerPeer uint
+	// MaxWorkers is the maximum number of peers blocks are requested from,
+	// zero for no limit.
+	MaxWorkers uint
+	// BatchTelemetry enables the telemetry messages summarising
+	// the batches of blocks synced in bootstrap mode.
+	BatchTelemetry bool
 }
 
 // NewService returns a new *sync.Service
@@ -54,41 +91,117 @@ func NewService(cfg *Config) (*Service, error) {
 
 	pendingBlocks := newDisjointBlockSet(pendingBlocksLimit)
 
+	badBlocks, err := cfg.BlockState.GetBadBlocks()
+	if err != nil {
+		return nil, fmt.Errorf("getting bad blocks: %w", err)
+	}
+	for _, badBlock := range cfg.BadBlocks {
+		hash, err := common.HexToHash(badBlock)
+		if err != nil {
+			return nil, fmt.Errorf("parsing bad block %q: %w", badBlock, err)
+		}
+		badBlocks = append(badBlocks, hash)
+	}
+	badBlockSet := newBadBlockSet(badBlocks...)
+
 	csCfg := chainSyncConfig{
-		bs:                 cfg.BlockState,
-		net:                cfg.Network,
-		pendingBlocks:      pendingBlocks,
-		minPeers:           cfg.MinPeers,
-		maxPeers:           cfg.MaxPeers,
-		slotDuration:       cfg.SlotDuration,
-		storageState:       cfg.StorageState,
-		transactionState:   cfg.TransactionState,
-		babeVerifier:       cfg.BabeVerifier,
-		finalityGadget:     cfg.FinalityGadget,
-		blockImportHandler: cfg.BlockImportHandler,
-		telemetry:          cfg.Telemetry,
-		badBlocks:          cfg.BadBlocks,
-		requestMaker:       cfg.RequestMaker,
-		waitPeersDuration:  100 * time.Millisecond,
+		bs:                  cfg.BlockState,
+		net:                 cfg.Network,
+		pendingBlocks:       pendingBlocks,
+		minPeers:            cfg.MinPeers,
+		maxPeers:            cfg.MaxPeers,
+		slotDuration:        cfg.SlotDuration,
+		storageState:        cfg.StorageState,
+		transactionState:    cfg.TransactionState,
+		babeVerifier:        cfg.BabeVerifier,
+		finalityGadget:      cfg.FinalityGadget,
+		blockImportHandler:  cfg.BlockImportHandler,
+		telemetry:           cfg.Telemetry,
+		badBlocks:           badBlockSet,
+		requestMaker:        cfg.RequestMaker,
+		waitPeersDuration:   100 * time.Millisecond,
+		tipRequestRacers:    cfg.TipRequestRacers,
+		verifyAncientBlocks: cfg.VerifyAncientBlocks,
+		checkpoint:          cfg.Checkpoint,
+		workerPool: syncWorkerPoolConfig{
+			maxRequests:        cfg.MaxRequests,
+			maxRequestsPerPeer: cfg.MaxRequestsPerPeer,
+			maxWorkers:         cfg.MaxWorkers,
+		},
+		batchTelemetry: cfg.BatchTelemetry,
 	}
 	chainSync := newChainSync(csCfg)
 
 	return &Service{
-		blockState: cfg.BlockState,
-		chainSync:  chainSync,
-		network:    cfg.Network,
+		blockState:    cfg.BlockState,
+		chainSync:     chainSync,
+		network:       cfg.Network,
+		babeVerifier:  cfg.BabeVerifier,
+		badBlocks:     badBlockSet,
+		telemetry:     cfg.Telemetry,
+		pendingBlocks: pendingBlocks,
+		restartPolicy: supervisor.DefaultPolicy(),
+		stopCh:        make(chan struct{}),
 	}, nil
 }
 
 // Start begins the chainSync and chainProcessor modules. It begins syncing in bootstrap mode
 func (s *Service) Start() error {
-	go s.chainSync.start()
+	s.running.Add(1)
+	go s.superviseChainSync()
 	return nil
 }
 
 // Stop stops the chainSync and chainProcessor modules
 func (s *Service) Stop() error {
-	return s.chainSync.stop()
+	err := s.chainSync.stop()
+	close(s.stopCh)
+	s.running.Wait()
+	return err
+}
+
+// superviseChainSync starts the chain sync and restarts it following the restart
+// policy each time it fails, until the service is stopped.
+func (s *Service) superviseChainSync() {
+	defer s.running.Done()
+
+	policy := s.restartPolicy
+	policy.OnRestart = func(err error, backoff time.Duration) {
+		s.telemetry.SendMessage(telemetry.NewSyncRestart(err.Error(), backoff))
+	}
+
+	start := s.chainSync.start
+	s.supervisor.Run("sync", policy, s.stopCh, func() error {
+		err := start()
+		start = s.chainSync.restart
+		if err != nil {
+			return err
+		}
+
+		select {
+		case err = <-s.chainSync.failures():
+			return err
+		case <-s.stopCh:
+			return nil
+		}
+	})
+}
+
+// SetSupervisor sets the supervisor running the chain sync, before the service is started.
+func (s *Service) SetSupervisor(sv *supervisor.Supervisor) {
+	s.supervisor = sv
+}
+
+// SetEventBus sets the event bus the blocks finalised and the peers
+// disconnected are received from, before the service is started.
+func (s *Service) SetEventBus(bus *events.Bus) {
+	s.chainSync.setEventBus(bus)
+}
+
+// SetMemoryBudget accounts the pending blocks of the chain sync in the memory budget given,
+// which shrinks them under memory pressure.
+func (s *Service) SetMemoryBudget(budget *memory.Budget) {
+	budget.Register("sync_pending_blocks", s.pendingBlocks)
 }
 
 // HandleBlockAnnounceHandshake notifies the `chainSync` module that
@@ -164,6 +277,17 @@ func (s *Service) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnounceMe
 		}
 	}
 
+	// verify the slot claim and the seal of the announced header before requesting
+	// the block, so we do not waste requests on blocks that would not be imported
+	err = s.babeVerifier.VerifyBlockAnnounce(blockAnnounceHeader)
+	if err != nil {
+		s.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadBlockAnnouncementValue,
+			Reason: peerset.BadBlockAnnouncementReason,
+		}, from)
+		return fmt.Errorf("%w: from peer %s: %w", errInvalidBlockAnnounce, from, err)
+	}
+
 	// we assume that if a peer sends us a block announce for a certain block,
 	// that is also has the chain up until and including that block.
 	// this may not be a valid assumption, but perhaps we can assume that
@@ -174,6 +298,36 @@ func (s *Service) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnounceMe
 	})
 }
 
+// AddBadBlock adds the block hash given to the bad blocks rejected when
+// received from peers, and persists it so it is kept after a restart.
+func (s *Service) AddBadBlock(hash common.Hash) error {
+	err := s.blockState.AddBadBlock(hash)
+	if err != nil {
+		return fmt.Errorf("persisting bad block: %w", err)
+	}
+
+	s.badBlocks.add(hash)
+	return nil
+}
+
+// RemoveBadBlock removes the block hash given from the bad blocks.
+// Note a bad block from the genesis is back after a restart.
+func (s *Service) RemoveBadBlock(hash common.Hash) error {
+	err := s.blockState.RemoveBadBlock(hash)
+	if err != nil {
+		return fmt.Errorf("removing persisted bad block: %w", err)
+	}
+
+	s.badBlocks.remove(hash)
+	return nil
+}
+
+// BadBlocks returns the hashes of the bad blocks from the genesis
+// or added at runtime.
+func (s *Service) BadBlocks() []common.Hash {
+	return s.badBlocks.list()
+}
+
 // IsSynced exposes the synced state
 func (s *Service) IsSynced() bool {
 	return s.chainSync.getSyncMode() == tip
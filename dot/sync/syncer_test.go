@@ -7,9 +7,12 @@
 	"errors"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -31,14 +34,37 @@ func TestNewService(t *testing.T) {
 			name: "working_example",
 			cfgBuilder: func(ctrl *gomock.Controller) *Config {
 				blockState := NewMockBlockState(ctrl)
-				blockState.EXPECT().GetFinalisedNotifierChannel().
-					Return(make(chan *types.FinalisationInfo))
+				blockState.EXPECT().GetBadBlocks().Return([]common.Hash{{1}}, nil)
 				return &Config{
 					BlockState: blockState,
+					BadBlocks:  []string{common.Hash{2}.String()},
 				}
 			},
 			want: &Service{},
 		},
+		{
+			name: "get_bad_blocks_error",
+			cfgBuilder: func(ctrl *gomock.Controller) *Config {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetBadBlocks().Return(nil, errors.New("test error"))
+				return &Config{
+					BlockState: blockState,
+				}
+			},
+			err: errors.New("getting bad blocks: test error"),
+		},
+		{
+			name: "invalid_bad_block",
+			cfgBuilder: func(ctrl *gomock.Controller) *Config {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetBadBlocks().Return(nil, nil)
+				return &Config{
+					BlockState: blockState,
+					BadBlocks:  []string{"0xinvalid"},
+				}
+			},
+			err: errors.New(`parsing bad block "0xinvalid": encoding/hex: invalid byte: U+0069 'i'`),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -248,14 +274,45 @@ func TestService_HandleBlockAnnounce(t *testing.T) {
 
 				chainSyncMock.EXPECT().onBlockAnnounce(expectedAnnouncedBlock).Return(nil)
 
+				babeVerifier := NewMockBabeVerifier(ctrl)
+				babeVerifier.EXPECT().VerifyBlockAnnounce(block2AnnounceHeader).Return(nil)
+
 				return &Service{
-					blockState: blockState,
-					chainSync:  chainSyncMock,
+					blockState:   blockState,
+					chainSync:    chainSyncMock,
+					babeVerifier: babeVerifier,
 				}
 			},
 			peerID:              somePeer,
 			blockAnnounceHeader: block2AnnounceHeader,
 		},
+		"number_bigger_than_best_block_number_with_invalid_seal": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().IsPaused().Return(false)
+				bestBlockHeader := &types.Header{Number: 1}
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+
+				babeVerifier := NewMockBabeVerifier(ctrl)
+				babeVerifier.EXPECT().VerifyBlockAnnounce(block2AnnounceHeader).Return(errTest)
+
+				network := NewMockNetwork(ctrl)
+				network.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadBlockAnnouncementValue,
+					Reason: peerset.BadBlockAnnouncementReason,
+				}, somePeer)
+
+				return &Service{
+					blockState:   blockState,
+					network:      network,
+					babeVerifier: babeVerifier,
+				}
+			},
+			peerID:              somePeer,
+			blockAnnounceHeader: block2AnnounceHeader,
+			errWrapped:          errInvalidBlockAnnounce,
+			errMessage:          "invalid block announce: from peer ZiCa: test error",
+		},
 	}
 
 	for name, tt := range testCases {
@@ -354,17 +411,24 @@ func TestService_Start(t *testing.T) {
 
 	chainSync := NewMockChainSync(ctrl)
 	allCalled.Add(1)
-	chainSync.EXPECT().start().DoAndReturn(func() {
+	chainSync.EXPECT().start().DoAndReturn(func() error {
 		allCalled.Done()
+		return nil
 	})
+	chainSync.EXPECT().failures().Return(make(<-chan error))
+	chainSync.EXPECT().stop()
 
-	service := Service{
+	service := &Service{
 		chainSync: chainSync,
+		stopCh:    make(chan struct{}),
 	}
 
 	err := service.Start()
 	allCalled.Wait()
 	assert.NoError(t, err)
+
+	err = service.Stop()
+	assert.NoError(t, err)
 }
 
 func TestService_Stop(t *testing.T) {
@@ -375,12 +439,74 @@ func TestService_Stop(t *testing.T) {
 	chainSync.EXPECT().stop()
 	service := &Service{
 		chainSync: chainSync,
+		stopCh:    make(chan struct{}),
 	}
 
 	err := service.Stop()
 	assert.NoError(t, err)
 }
 
+func TestService_superviseChainSync(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	errStart := errors.New("start error")
+	errRestart := errors.New("restart error")
+	errFailure := errors.New("failure")
+
+	failures := make(chan error, 1)
+	restarted := make(chan struct{})
+
+	chainSync := NewMockChainSync(ctrl)
+	mockTelemetry := NewMockTelemetry(ctrl)
+	gomock.InOrder(
+		chainSync.EXPECT().start().Return(errStart),
+		mockTelemetry.EXPECT().SendMessage(telemetry.NewSyncRestart(errStart.Error(), time.Millisecond)),
+		chainSync.EXPECT().restart().Return(errRestart),
+		mockTelemetry.EXPECT().SendMessage(telemetry.NewSyncRestart(errRestart.Error(), 2*time.Millisecond)),
+		chainSync.EXPECT().restart().DoAndReturn(func() error {
+			failures <- errFailure
+			return nil
+		}),
+		chainSync.EXPECT().failures().Return((<-chan error)(failures)),
+		mockTelemetry.EXPECT().SendMessage(telemetry.NewSyncRestart(errFailure.Error(), 4*time.Millisecond)),
+		chainSync.EXPECT().restart().DoAndReturn(func() error {
+			close(restarted)
+			return nil
+		}),
+		chainSync.EXPECT().failures().Return(make(<-chan error)),
+		chainSync.EXPECT().stop(),
+	)
+
+	service := &Service{
+		chainSync: chainSync,
+		telemetry: mockTelemetry,
+		restartPolicy: supervisor.Policy{
+			Restart:    true,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Minute,
+		},
+		stopCh:     make(chan struct{}),
+		supervisor: supervisor.New(),
+	}
+
+	err := service.Start()
+	require.NoError(t, err)
+
+	<-restarted
+	err = service.Stop()
+	assert.NoError(t, err)
+
+	expectedHealth := []common.ServiceHealth{{
+		Name:      "sync",
+		State:     string(supervisor.Stopped),
+		Failures:  3,
+		Restarts:  3,
+		LastError: errFailure.Error(),
+	}}
+	assert.Equal(t, expectedHealth, service.supervisor.Health())
+}
+
 func Test_reverseBlockData(t *testing.T) {
 	t.Parallel()
 
@@ -433,3 +559,35 @@ func TestService_HighestBlock(t *testing.T) {
 	const expected = uint(2)
 	assert.Equal(t, expected, highestBlock)
 }
+
+func TestService_BadBlocks(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	errTest := errors.New("test error")
+	blockState := NewMockBlockState(ctrl)
+	service := &Service{
+		blockState: blockState,
+		badBlocks:  newBadBlockSet(common.Hash{1}),
+	}
+
+	blockState.EXPECT().AddBadBlock(common.Hash{2}).Return(nil)
+	err := service.AddBadBlock(common.Hash{2})
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{{1}, {2}}, service.BadBlocks())
+
+	blockState.EXPECT().AddBadBlock(common.Hash{3}).Return(errTest)
+	err = service.AddBadBlock(common.Hash{3})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []common.Hash{{1}, {2}}, service.BadBlocks())
+
+	blockState.EXPECT().RemoveBadBlock(common.Hash{1}).Return(nil)
+	err = service.RemoveBadBlock(common.Hash{1})
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{{2}}, service.BadBlocks())
+
+	blockState.EXPECT().RemoveBadBlock(common.Hash{2}).Return(errTest)
+	err = service.RemoveBadBlock(common.Hash{2})
+	assert.ErrorIs(t, err, errTest)
+	assert.Equal(t, []common.Hash{{2}}, service.BadBlocks())
+}
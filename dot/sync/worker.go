@@ -18,25 +18,42 @@ type worker struct {
 	peerID       peer.ID
 	sharedGuard  chan struct{}
 	requestMaker network.RequestMaker
+	// maxRequests is the maximum number of requests the worker has in flight at once.
+	maxRequests uint
 }
 
-func newWorker(pID peer.ID, sharedGuard chan struct{}, network network.RequestMaker) *worker {
+func newWorker(pID peer.ID, sharedGuard chan struct{}, maxRequests uint,
+	network network.RequestMaker) *worker {
 	return &worker{
 		peerID:       pID,
 		sharedGuard:  sharedGuard,
 		requestMaker: network,
+		maxRequests:  maxRequests,
 		status:       available,
 	}
 }
 
+// run executes the tasks of the queue, up to maxRequests at once,
+// until the queue is closed and the tasks in flight are done.
 func (w *worker) run(queue chan *syncTask, wg *sync.WaitGroup) {
+	var inFlight sync.WaitGroup
 	defer func() {
+		inFlight.Wait()
 		logger.Debugf("[STOPPED] worker %s", w.peerID)
 		wg.Done()
 	}()
 
+	peerGuard := make(chan struct{}, max(w.maxRequests, 1))
 	for task := range queue {
-		executeRequest(w.peerID, w.requestMaker, task, w.sharedGuard)
+		peerGuard <- struct{}{}
+		inFlight.Add(1)
+		go func(task *syncTask) {
+			defer func() {
+				<-peerGuard
+				inFlight.Done()
+			}()
+			executeRequest(w.peerID, w.requestMaker, task, w.sharedGuard)
+		}(task)
 	}
 }
 
@@ -48,17 +65,22 @@ func executeRequest(who peer.ID, requestMaker network.RequestMaker,
 
 	sharedGuard <- struct{}{}
 
+	if task.cancelled != nil && task.cancelled() {
+		logger.Debugf("[CANCELLED] worker %s, block request: %s", who, task.request)
+		return
+	}
+
 	request := task.request
 	logger.Debugf("[EXECUTING] worker %s, block request: %s", who, request)
 	response := new(network.BlockResponseMessage)
 	err := requestMaker.Do(who, request, response)
 
-	task.resultCh <- &syncTaskResult{
+	task.sendResult(&syncTaskResult{
 		who:      who,
 		request:  request,
 		response: response,
 		err:      err,
-	}
+	})
 
 	logger.Debugf("[FINISHED] worker %s, err: %s, block data amount: %d", who, err, len(response.BlockData))
 }
@@ -8,11 +8,15 @@
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -22,13 +26,52 @@
 )
 
 const (
-	punishmentBaseTimeout      = 5 * time.Minute
-	maxRequestsAllowed    uint = 60
+	punishmentBaseTimeout = 5 * time.Minute
+	// defaultMaxRequests is the default maximum number of block requests in flight at once.
+	defaultMaxRequests uint = 60
+	// defaultMaxRequestsPerPeer is the default maximum number of block requests in flight to a peer.
+	defaultMaxRequestsPerPeer uint = 1
 )
 
+// syncWorkerPoolConfig holds the concurrency limits of the sync worker pool.
+type syncWorkerPoolConfig struct {
+	// maxRequests is the maximum number of block requests in flight at once.
+	maxRequests uint
+	// maxRequestsPerPeer is the maximum number of block requests in flight to a single peer.
+	maxRequestsPerPeer uint
+	// maxWorkers is the maximum number of workers in the pool, zero for no limit.
+	maxWorkers uint
+}
+
+// withDefaults returns the configuration with its zero limits set to their default.
+func (c syncWorkerPoolConfig) withDefaults() syncWorkerPoolConfig {
+	if c.maxRequests == 0 {
+		c.maxRequests = defaultMaxRequests
+	}
+	if c.maxRequestsPerPeer == 0 {
+		c.maxRequestsPerPeer = defaultMaxRequestsPerPeer
+	}
+	return c
+}
+
 type syncTask struct {
 	request  *network.BlockRequestMessage
 	resultCh chan<- *syncTaskResult
+	// dispatch, if not nil, is called with the result
+	// instead of sending it to the result channel.
+	dispatch func(result *syncTaskResult)
+	// cancelled, if not nil, returns true if the task
+	// should not be executed anymore.
+	cancelled func() bool
+}
+
+// sendResult sends the result of the task to its result channel or dispatches it.
+func (t *syncTask) sendResult(result *syncTaskResult) {
+	if t.dispatch != nil {
+		t.dispatch(result)
+		return
+	}
+	t.resultCh <- result
 }
 
 type syncTaskResult struct {
@@ -38,6 +81,36 @@ type syncTaskResult struct {
 	err      error
 }
 
+func (r *syncTaskResult) copy() *syncTaskResult {
+	resultCopy := *r
+	if r.response != nil {
+		resultCopy.response = &network.BlockResponseMessage{
+			BlockData: slices.Clone(r.response.BlockData),
+		}
+	}
+	return &resultCopy
+}
+
+// blockRequestKey identifies identical block requests.
+type blockRequestKey struct {
+	start         variadic.Uint32OrHash
+	direction     network.SyncDirection
+	max           uint32
+	requestedData byte
+}
+
+func newBlockRequestKey(request *network.BlockRequestMessage) blockRequestKey {
+	key := blockRequestKey{
+		start:         request.StartingBlock,
+		direction:     request.Direction,
+		requestedData: request.RequestedData,
+	}
+	if request.Max != nil {
+		key.max = *request.Max
+	}
+	return key
+}
+
 type syncWorker struct {
 	worker *worker
 	queue  chan *syncTask
@@ -52,16 +125,34 @@ type syncWorkerPool struct {
 	workers      map[peer.ID]*syncWorker
 	ignorePeers  map[peer.ID]struct{}
 
+	maxRequests        uint
+	maxRequestsPerPeer uint
+	maxWorkers         uint
+
 	sharedGuard chan struct{}
+
+	// inFlight are the result channels waiting for the
+	// result of each block request being executed.
+	inFlightMtx sync.Mutex
+	inFlight    map[blockRequestKey][]chan<- *syncTaskResult
+
+	// faults are the faults injected in the block responses, in chaos builds only.
+	faults atomic.Pointer[chaos.Faults]
 }
 
-func newSyncWorkerPool(net Network, requestMaker network.RequestMaker) *syncWorkerPool {
+func newSyncWorkerPool(net Network, requestMaker network.RequestMaker,
+	cfg syncWorkerPoolConfig) *syncWorkerPool {
+	cfg = cfg.withDefaults()
 	swp := &syncWorkerPool{
-		network:      net,
-		requestMaker: requestMaker,
-		workers:      make(map[peer.ID]*syncWorker),
-		ignorePeers:  make(map[peer.ID]struct{}),
-		sharedGuard:  make(chan struct{}, maxRequestsAllowed),
+		network:            net,
+		requestMaker:       requestMaker,
+		workers:            make(map[peer.ID]*syncWorker),
+		ignorePeers:        make(map[peer.ID]struct{}),
+		maxRequests:        cfg.maxRequests,
+		maxRequestsPerPeer: cfg.maxRequestsPerPeer,
+		maxWorkers:         cfg.maxWorkers,
+		sharedGuard:        make(chan struct{}, cfg.maxRequests),
+		inFlight:           make(map[blockRequestKey][]chan<- *syncTaskResult),
 	}
 
 	return swp
@@ -128,8 +219,18 @@ func (s *syncWorkerPool) newPeer(who peer.ID) {
 		return
 	}
 
-	worker := newWorker(who, s.sharedGuard, s.requestMaker)
-	workerQueue := make(chan *syncTask, maxRequestsAllowed)
+	if s.maxWorkers > 0 && uint(len(s.workers)) >= s.maxWorkers {
+		logger.Tracef("potential worker %s not added, the pool is full with %d workers", who, len(s.workers))
+		return
+	}
+
+	requestMaker := s.requestMaker
+	if chaos.Enabled {
+		requestMaker = &faultyRequestMaker{RequestMaker: requestMaker, faults: &s.faults}
+	}
+
+	worker := newWorker(who, s.sharedGuard, s.maxRequestsPerPeer, requestMaker)
+	workerQueue := make(chan *syncTask, s.maxRequests)
 
 	s.wg.Add(1)
 	go worker.run(workerQueue, &s.wg)
@@ -144,14 +245,14 @@ func (s *syncWorkerPool) newPeer(who peer.ID) {
 // submitRequest given a request, the worker pool will get the peer given the peer.ID
 // parameter or if nil the very first available worker or
 // to perform the request, the response will be dispatch in the resultCh.
+// A request identical to one already in flight is not sent again, unless
+// it is bounded to a specific peer, and the result of the request in
+// flight is dispatched in the resultCh too.
+// Results are sent blocking, so the resultCh must be buffered for the
+// results of the requests submitted which are not received yet.
 func (s *syncWorkerPool) submitRequest(request *network.BlockRequestMessage,
 	who *peer.ID, resultCh chan<- *syncTaskResult) {
 
-	task := &syncTask{
-		request:  request,
-		resultCh: resultCh,
-	}
-
 	// if the request is bounded to a specific peer then just
 	// request it and sent through its queue otherwise send
 	// the request in the general queue where all worker are
@@ -161,51 +262,258 @@ func (s *syncWorkerPool) submitRequest(request *network.BlockRequestMessage,
 
 	if who != nil {
 		syncWorker, inMap := s.workers[*who]
-		if inMap {
-			if syncWorker == nil {
-				panic("sync worker should not be nil")
+		if inMap && syncWorker != nil {
+			syncWorker.queue <- &syncTask{
+				request:  request,
+				resultCh: resultCh,
 			}
-			syncWorker.queue <- task
 			return
 		}
 	}
 
+	task := s.trackRequest(request, resultCh)
+	if task == nil {
+		return
+	}
+
 	// if the exact peer is not specified then
 	// randomly select a worker and assign the
 	// task to it, if the amount of workers is
-	var selectedWorkerIdx int
 	workers := maps.Values(s.workers)
-	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(len(workers))))
-	if err != nil {
-		panic(fmt.Errorf("fail to get a random number: %w", err))
-	}
-	selectedWorkerIdx = int(nBig.Int64())
-	selectedWorker := workers[selectedWorkerIdx]
+	selectedWorker := workers[randomIndex(len(workers))]
 	selectedWorker.queue <- task
 }
 
-// submitRequests takes an set of requests and will submit to the pool through submitRequest
-// the response will be dispatch in the resultCh
-func (s *syncWorkerPool) submitRequests(requests []*network.BlockRequestMessage) (resultCh chan *syncTaskResult) {
-	resultCh = make(chan *syncTaskResult, maxRequestsAllowed+1)
+// submitRequests takes an set of requests and stripes them across distinct workers, taking
+// into account the best block numbers reported by their peers given: each request is
+// assigned to the worker with the fewest requests assigned among the peers having the
+// highest block requested. If no peer is known to have it, the request is assigned to
+// the peers with the highest best block number, or to any peer if none is known.
+// The responses will be dispatched in the resultCh.
+func (s *syncWorkerPool) submitRequests(requests []*network.BlockRequestMessage,
+	peerBests map[peer.ID]uint) (resultCh chan *syncTaskResult) {
+	resultCh = make(chan *syncTaskResult, s.maxRequests+1)
 
 	s.mtx.RLock()
 	defer s.mtx.RUnlock()
 
-	allWorkers := maps.Values(s.workers)
-	for idx, request := range requests {
-		workerID := idx % len(allWorkers)
-		syncWorker := allWorkers[workerID]
+	// workers are sorted so the requests are striped deterministically
+	peerIDs := maps.Keys(s.workers)
+	slices.Sort(peerIDs)
+	assigned := make(map[peer.ID]uint, len(peerIDs))
 
-		syncWorker.queue <- &syncTask{
-			request:  request,
-			resultCh: resultCh,
+	for _, request := range requests {
+		task := s.trackRequest(request, resultCh)
+		if task == nil {
+			continue
 		}
+
+		candidates := stripeCandidates(peerIDs, peerBests, request)
+		selected := candidates[0]
+		for _, candidate := range candidates[1:] {
+			if assigned[candidate] < assigned[selected] {
+				selected = candidate
+			}
+		}
+
+		assigned[selected]++
+		s.workers[selected].queue <- task
 	}
 
 	return resultCh
 }
 
+// stripeCandidates returns the peers, from the peers given, which have the highest block
+// of the request given according to their best block numbers. If there is no such peer,
+// it returns the peers with the highest best block number, or all the peers if none of
+// them reported its best block number.
+func stripeCandidates(peerIDs []peer.ID, peerBests map[peer.ID]uint,
+	request *network.BlockRequestMessage) (candidates []peer.ID) {
+	highestRequested, ok := highestRequestedNumber(request)
+	if !ok {
+		return peerIDs
+	}
+
+	var highestBest uint
+	var highestBestPeers []peer.ID
+	for _, peerID := range peerIDs {
+		best, has := peerBests[peerID]
+		if !has {
+			continue
+		}
+
+		if best >= highestRequested {
+			candidates = append(candidates, peerID)
+		}
+
+		switch {
+		case best > highestBest || len(highestBestPeers) == 0:
+			highestBest = best
+			highestBestPeers = []peer.ID{peerID}
+		case best == highestBest:
+			highestBestPeers = append(highestBestPeers, peerID)
+		}
+	}
+
+	switch {
+	case len(candidates) > 0:
+		return candidates
+	case len(highestBestPeers) > 0:
+		return highestBestPeers
+	default:
+		return peerIDs
+	}
+}
+
+// highestRequestedNumber returns the number of the highest block requested by the
+// request given, and false if the request does not start at a block number.
+func highestRequestedNumber(request *network.BlockRequestMessage) (number uint, ok bool) {
+	if !request.StartingBlock.IsUint32() {
+		return 0, false
+	}
+
+	start := uint(request.StartingBlock.Uint32())
+	if request.Direction == network.Descending || request.Max == nil || *request.Max == 0 {
+		return start, true
+	}
+
+	return start + uint(*request.Max) - 1, true
+}
+
+// trackRequest returns the task to execute the request given, or nil if an
+// identical request is already in flight. In both cases, the result of the
+// request in flight is dispatched to the result channel given.
+func (s *syncWorkerPool) trackRequest(request *network.BlockRequestMessage,
+	resultCh chan<- *syncTaskResult) *syncTask {
+	key := newBlockRequestKey(request)
+
+	s.inFlightMtx.Lock()
+	defer s.inFlightMtx.Unlock()
+
+	waiting, inFlight := s.inFlight[key]
+	s.inFlight[key] = append(waiting, resultCh)
+	if inFlight {
+		logger.Debugf("block request already in flight: %s", request)
+		return nil
+	}
+
+	return &syncTask{
+		request: request,
+		dispatch: func(result *syncTaskResult) {
+			s.dispatchResult(key, result)
+		},
+	}
+}
+
+// dispatchResult sends the result of the request in flight
+// with the key given to all the result channels waiting for it.
+func (s *syncWorkerPool) dispatchResult(key blockRequestKey, result *syncTaskResult) {
+	s.inFlightMtx.Lock()
+	waiting := s.inFlight[key]
+	delete(s.inFlight, key)
+	s.inFlightMtx.Unlock()
+
+	// each waiting result channel gets its own result since the result and
+	// its block data are modified when handling it, so the copies are made
+	// before the result is sent to the first result channel.
+	results := make([]*syncTaskResult, len(waiting))
+	for i := range waiting {
+		if i == 0 {
+			results[i] = result
+			continue
+		}
+		results[i] = result.copy()
+	}
+
+	for i, resultCh := range waiting {
+		resultCh <- results[i]
+	}
+}
+
+// raceRequest sends the request to the peer given and to other workers randomly selected,
+// up to the number of racers given, and dispatches in the resultCh the first valid response.
+// The requests not executed yet are then cancelled, and the responses received later
+// are discarded. If no response is valid, the last result received is dispatched.
+// It returns errNoPeers if there is no worker to send the request to, since no
+// result would ever be dispatched.
+func (s *syncWorkerPool) raceRequest(request *network.BlockRequestMessage,
+	who peer.ID, racers uint, resultCh chan<- *syncTaskResult) error {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	if len(s.workers) == 0 {
+		return fmt.Errorf("racing request: %w", errNoPeers)
+	}
+
+	racingWorkers := make([]*syncWorker, 0, racers)
+	if syncWorker, inMap := s.workers[who]; inMap {
+		racingWorkers = append(racingWorkers, syncWorker)
+	}
+
+	otherWorkers := make([]*syncWorker, 0, len(s.workers))
+	for peerID, syncWorker := range s.workers {
+		if peerID != who {
+			otherWorkers = append(otherWorkers, syncWorker)
+		}
+	}
+
+	for len(racingWorkers) < int(racers) && len(otherWorkers) > 0 {
+		selectedWorkerIdx := randomIndex(len(otherWorkers))
+		racingWorkers = append(racingWorkers, otherWorkers[selectedWorkerIdx])
+		otherWorkers = slices.Delete(otherWorkers, selectedWorkerIdx, selectedWorkerIdx+1)
+	}
+
+	race := &requestRace{
+		racers:   len(racingWorkers),
+		resultCh: resultCh,
+	}
+	for _, syncWorker := range racingWorkers {
+		syncWorker.queue <- &syncTask{
+			request:   request,
+			dispatch:  race.dispatch,
+			cancelled: race.isDone,
+		}
+	}
+	return nil
+}
+
+// requestRace is the state of a request sent to multiple
+// peers, to dispatch the first valid response received.
+type requestRace struct {
+	mtx      sync.Mutex
+	racers   int
+	done     bool
+	resultCh chan<- *syncTaskResult
+}
+
+func (r *requestRace) isDone() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.done
+}
+
+func (r *requestRace) dispatch(result *syncTaskResult) {
+	r.mtx.Lock()
+	if r.done {
+		r.mtx.Unlock()
+		return
+	}
+
+	r.racers--
+	valid := result.err == nil && len(result.response.BlockData) > 0 &&
+		validateResponseFields(result.request.RequestedData, result.response.BlockData) == nil
+	if !valid && r.racers > 0 {
+		// wait for the responses of the other racers
+		r.mtx.Unlock()
+		return
+	}
+
+	r.done = true
+	r.mtx.Unlock()
+
+	r.resultCh <- result
+}
+
 func (s *syncWorkerPool) ignorePeerAsWorker(who peer.ID) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
@@ -218,6 +526,19 @@ func (s *syncWorkerPool) ignorePeerAsWorker(who peer.ID) {
 	}
 }
 
+// removeWorker removes the worker of the peer given from the pool,
+// which is added again once the peer is used as a source of blocks.
+func (s *syncWorkerPool) removeWorker(who peer.ID) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	worker, has := s.workers[who]
+	if has {
+		close(worker.queue)
+		delete(s.workers, who)
+	}
+}
+
 // totalWorkers only returns available or busy workers
 func (s *syncWorkerPool) totalWorkers() (total uint) {
 	s.mtx.RLock()
@@ -229,3 +550,14 @@ func (s *syncWorkerPool) totalWorkers() (total uint) {
 
 	return total
 }
+
+// randomIndex returns a random index of a slice of the length given,
+// falling back to the first index if the random source fails.
+func randomIndex(length int) int {
+	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(length)))
+	if err != nil {
+		logger.Warnf("getting a random number: %s", err)
+		return 0
+	}
+	return int(nBig.Int64())
+}
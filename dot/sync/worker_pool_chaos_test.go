@@ -0,0 +1,50 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build chaos
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncWorkerPool_injectFaults(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	who := peer.ID("peerA")
+	request := &network.BlockRequestMessage{}
+
+	requestMaker := NewMockRequestMaker(ctrl)
+	requestMaker.EXPECT().
+		Do(who, request, gomock.AssignableToTypeOf((*network.BlockResponseMessage)(nil))).
+		DoAndReturn(func(_ peer.ID, _ network.Message, res network.ResponseMessage) error {
+			response := res.(*network.BlockResponseMessage)
+			response.BlockData = []*types.BlockData{{Body: &types.Body{}}}
+			return nil
+		})
+
+	workerPool := newSyncWorkerPool(NewMockNetwork(ctrl), requestMaker, syncWorkerPoolConfig{})
+	workerPool.injectFaults(&chaos.Faults{CorruptBlockBody: chaos.Always})
+	workerPool.fromBlockAnnounce(who)
+
+	resultCh := make(chan *syncTaskResult, 1)
+	workerPool.submitRequest(request, &who, resultCh)
+	result := <-resultCh
+
+	err := workerPool.stop()
+	require.NoError(t, err)
+
+	require.NoError(t, result.err)
+	require.Len(t, result.response.BlockData, 1)
+	assert.Equal(t, &types.Body{corruptExtrinsic}, result.response.BlockData[0].Body)
+}
@@ -4,6 +4,7 @@
 package sync
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -31,7 +32,7 @@ func TestSyncWorkerPool_useConnectedPeers(t *testing.T) {
 					AllConnectedPeersIDs().
 					Return([]peer.ID{})
 
-				return newSyncWorkerPool(networkMock, nil)
+				return newSyncWorkerPool(networkMock, nil, syncWorkerPoolConfig{})
 			},
 			exepectedWorkers: []peer.ID{},
 		},
@@ -46,7 +47,7 @@ func TestSyncWorkerPool_useConnectedPeers(t *testing.T) {
 						peer.ID("available-2"),
 						peer.ID("available-3"),
 					})
-				return newSyncWorkerPool(networkMock, nil)
+				return newSyncWorkerPool(networkMock, nil, syncWorkerPoolConfig{})
 			},
 			exepectedWorkers: []peer.ID{
 				peer.ID("available-1"),
@@ -65,7 +66,7 @@ func TestSyncWorkerPool_useConnectedPeers(t *testing.T) {
 						peer.ID("available-2"),
 						peer.ID("available-3"),
 					})
-				workerPool := newSyncWorkerPool(networkMock, nil)
+				workerPool := newSyncWorkerPool(networkMock, nil, syncWorkerPoolConfig{})
 				workerPool.ignorePeers[peer.ID("available-3")] = struct{}{}
 				return workerPool
 			},
@@ -74,6 +75,24 @@ func TestSyncWorkerPool_useConnectedPeers(t *testing.T) {
 				peer.ID("available-2"),
 			},
 		},
+		"max_workers_reached": {
+			setupWorkerPool: func(t *testing.T) *syncWorkerPool {
+				ctrl := gomock.NewController(t)
+				networkMock := NewMockNetwork(ctrl)
+				networkMock.EXPECT().
+					AllConnectedPeersIDs().
+					Return([]peer.ID{
+						peer.ID("available-1"),
+						peer.ID("available-2"),
+						peer.ID("available-3"),
+					})
+				return newSyncWorkerPool(networkMock, nil, syncWorkerPoolConfig{maxWorkers: 2})
+			},
+			exepectedWorkers: []peer.ID{
+				peer.ID("available-1"),
+				peer.ID("available-2"),
+			},
+		},
 		"peer_already_in_workers_set": {
 			setupWorkerPool: func(t *testing.T) *syncWorkerPool {
 				ctrl := gomock.NewController(t)
@@ -85,7 +104,7 @@ func TestSyncWorkerPool_useConnectedPeers(t *testing.T) {
 						peer.ID("available-2"),
 						peer.ID("available-3"),
 					})
-				workerPool := newSyncWorkerPool(networkMock, nil)
+				workerPool := newSyncWorkerPool(networkMock, nil, syncWorkerPoolConfig{})
 				syncWorker := &syncWorker{
 					worker: &worker{},
 					queue:  make(chan *syncTask),
@@ -123,7 +142,7 @@ func TestSyncWorkerPool_listenForRequests_submitRequest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	networkMock := NewMockNetwork(ctrl)
 	requestMakerMock := NewMockRequestMaker(ctrl)
-	workerPool := newSyncWorkerPool(networkMock, requestMakerMock)
+	workerPool := newSyncWorkerPool(networkMock, requestMakerMock, syncWorkerPoolConfig{})
 
 	availablePeer := peer.ID("available-peer")
 	workerPool.newPeer(availablePeer)
@@ -171,7 +190,7 @@ func TestSyncWorkerPool_singleWorker_multipleRequests(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	networkMock := NewMockNetwork(ctrl)
 	requestMakerMock := NewMockRequestMaker(ctrl)
-	workerPool := newSyncWorkerPool(networkMock, requestMakerMock)
+	workerPool := newSyncWorkerPool(networkMock, requestMakerMock, syncWorkerPoolConfig{})
 	defer workerPool.stop()
 
 	availablePeer := peer.ID("available-peer")
@@ -182,7 +201,7 @@ func TestSyncWorkerPool_singleWorker_multipleRequests(t *testing.T) {
 		1, network.BootstrapRequestData, network.Descending)
 
 	secondRequestBlockHash := common.MustHexToHash("0x897646b852a29e5f3668959916a03d6243a3137e91d0cd36870364931030f707")
-	secondBlockRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(firstRequestBlockHash),
+	secondBlockRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(secondRequestBlockHash),
 		1, network.BootstrapRequestData, network.Descending)
 
 	firstMockedBlockResponse := &network.BlockResponseMessage{
@@ -221,7 +240,7 @@ func TestSyncWorkerPool_singleWorker_multipleRequests(t *testing.T) {
 		})
 
 	requestMakerMock.EXPECT().
-		Do(availablePeer, firstBlockRequest, &network.BlockResponseMessage{}).
+		Do(availablePeer, secondBlockRequest, &network.BlockResponseMessage{}).
 		DoAndReturn(func(_, _, response any) any {
 			responsePtr := response.(*network.BlockResponseMessage)
 			*responsePtr = *secondMockedBlockResponse
@@ -229,7 +248,7 @@ func TestSyncWorkerPool_singleWorker_multipleRequests(t *testing.T) {
 		})
 
 	resultCh := workerPool.submitRequests(
-		[]*network.BlockRequestMessage{firstBlockRequest, secondBlockRequest})
+		[]*network.BlockRequestMessage{firstBlockRequest, secondBlockRequest}, nil)
 
 	syncTaskResult := <-resultCh
 	require.NoError(t, syncTaskResult.err)
@@ -245,3 +264,240 @@ func TestSyncWorkerPool_singleWorker_multipleRequests(t *testing.T) {
 
 	require.Equal(t, uint(1), workerPool.totalWorkers())
 }
+
+func TestSyncWorkerPool_submitRequest_duplicateInFlight(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	networkMock := NewMockNetwork(ctrl)
+	requestMakerMock := NewMockRequestMaker(ctrl)
+	workerPool := newSyncWorkerPool(networkMock, requestMakerMock, syncWorkerPoolConfig{})
+
+	availablePeer := peer.ID("available-peer")
+	workerPool.newPeer(availablePeer)
+	defer workerPool.stop()
+
+	blockHash := common.MustHexToHash("0x750646b852a29e5f3668959916a03d6243a3137e91d0cd36870364931030f707")
+	blockRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(blockHash),
+		1, network.BootstrapRequestData, network.Descending)
+	mockedBlockResponse := &network.BlockResponseMessage{
+		BlockData: []*types.BlockData{{Hash: blockHash}},
+	}
+
+	// the request is in flight until it is released
+	release := make(chan struct{})
+	requestMakerMock.EXPECT().
+		Do(availablePeer, blockRequest, &network.BlockResponseMessage{}).
+		DoAndReturn(func(_, _, response any) any {
+			<-release
+			responsePtr := response.(*network.BlockResponseMessage)
+			responsePtr.BlockData = append([]*types.BlockData{}, mockedBlockResponse.BlockData...)
+			return nil
+		}).Times(2)
+
+	firstResultCh := make(chan *syncTaskResult, 1)
+	workerPool.submitRequest(blockRequest, nil, firstResultCh)
+	// an identical request is not sent again
+	duplicateResultCh := make(chan *syncTaskResult, 1)
+	workerPool.submitRequest(blockRequest, nil, duplicateResultCh)
+	// unless it is bounded to a peer
+	boundedResultCh := make(chan *syncTaskResult, 1)
+	workerPool.submitRequest(blockRequest, &availablePeer, boundedResultCh)
+	close(release)
+
+	firstResult := <-firstResultCh
+	require.Equal(t, mockedBlockResponse, firstResult.response)
+	// handling the first result does not modify the results of the other channels
+	firstResult.response.BlockData[0] = nil
+	duplicateResult := <-duplicateResultCh
+	boundedResult := <-boundedResultCh
+
+	for _, result := range []*syncTaskResult{firstResult, duplicateResult, boundedResult} {
+		require.NoError(t, result.err)
+		require.Equal(t, availablePeer, result.who)
+		require.Equal(t, blockRequest, result.request)
+	}
+	require.Equal(t, mockedBlockResponse, duplicateResult.response)
+	require.Equal(t, mockedBlockResponse, boundedResult.response)
+	// each result channel gets its own result
+	require.NotSame(t, firstResult, duplicateResult)
+	require.NotSame(t, firstResult.response, duplicateResult.response)
+
+	// the request is sent again once its result is dispatched
+	secondResultCh := make(chan *syncTaskResult, 1)
+	requestMakerMock.EXPECT().
+		Do(availablePeer, blockRequest, &network.BlockResponseMessage{}).
+		Return(nil)
+	workerPool.submitRequest(blockRequest, nil, secondResultCh)
+	result := <-secondResultCh
+	require.NoError(t, result.err)
+}
+
+func TestSyncWorkerPool_raceRequest(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.MustHexToHash("0x750646b852a29e5f3668959916a03d6243a3137e91d0cd36870364931030f707")
+	blockRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(blockHash),
+		1, network.BootstrapRequestData, network.Descending)
+	mockedBlockResponse := &network.BlockResponseMessage{
+		BlockData: []*types.BlockData{{
+			Hash:          blockHash,
+			Header:        &types.Header{},
+			Body:          &types.Body{},
+			Justification: nil,
+		}},
+	}
+	errTest := errors.New("test error")
+
+	t.Run("first_valid_response", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		requestMakerMock := NewMockRequestMaker(ctrl)
+		workerPool := newSyncWorkerPool(NewMockNetwork(ctrl), requestMakerMock, syncWorkerPoolConfig{})
+
+		slowPeer := peer.ID("slow-peer")
+		failingPeer := peer.ID("failing-peer")
+		fastPeer := peer.ID("fast-peer")
+		for _, who := range []peer.ID{slowPeer, failingPeer, fastPeer} {
+			workerPool.newPeer(who)
+		}
+		defer workerPool.stop()
+
+		// the fast peer responds once the other racers sent their requests
+		slowStarted := make(chan struct{})
+		failingStarted := make(chan struct{})
+		release := make(chan struct{})
+		slowDone := make(chan struct{})
+		requestMakerMock.EXPECT().
+			Do(slowPeer, blockRequest, &network.BlockResponseMessage{}).
+			DoAndReturn(func(_, _, response any) any {
+				defer close(slowDone)
+				close(slowStarted)
+				<-release
+				responsePtr := response.(*network.BlockResponseMessage)
+				*responsePtr = *mockedBlockResponse
+				return nil
+			})
+		requestMakerMock.EXPECT().
+			Do(failingPeer, blockRequest, &network.BlockResponseMessage{}).
+			DoAndReturn(func(_, _, _ any) any {
+				close(failingStarted)
+				return errTest
+			})
+		requestMakerMock.EXPECT().
+			Do(fastPeer, blockRequest, &network.BlockResponseMessage{}).
+			DoAndReturn(func(_, _, response any) any {
+				<-slowStarted
+				<-failingStarted
+				responsePtr := response.(*network.BlockResponseMessage)
+				*responsePtr = *mockedBlockResponse
+				return nil
+			})
+
+		resultCh := make(chan *syncTaskResult, 3)
+		err := workerPool.raceRequest(blockRequest, slowPeer, 3, resultCh)
+		require.NoError(t, err)
+
+		result := <-resultCh
+		require.NoError(t, result.err)
+		require.Equal(t, fastPeer, result.who)
+		require.Equal(t, mockedBlockResponse, result.response)
+
+		// the response received after the first valid one is discarded
+		close(release)
+		<-slowDone
+		require.Never(t, func() bool { return len(resultCh) > 0 },
+			100*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("all_racers_failed", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		requestMakerMock := NewMockRequestMaker(ctrl)
+		workerPool := newSyncWorkerPool(NewMockNetwork(ctrl), requestMakerMock, syncWorkerPoolConfig{})
+
+		peerA := peer.ID("peer-a")
+		peerB := peer.ID("peer-b")
+		workerPool.newPeer(peerA)
+		workerPool.newPeer(peerB)
+		defer workerPool.stop()
+
+		requestMakerMock.EXPECT().
+			Do(gomock.Any(), blockRequest, &network.BlockResponseMessage{}).
+			Return(errTest).Times(2)
+
+		resultCh := make(chan *syncTaskResult, 2)
+		err := workerPool.raceRequest(blockRequest, peerA, 3, resultCh)
+		require.NoError(t, err)
+
+		result := <-resultCh
+		require.ErrorIs(t, result.err, errTest)
+		require.Never(t, func() bool { return len(resultCh) > 0 },
+			100*time.Millisecond, 10*time.Millisecond)
+	})
+
+	t.Run("no_workers", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		workerPool := newSyncWorkerPool(NewMockNetwork(ctrl), NewMockRequestMaker(ctrl), syncWorkerPoolConfig{})
+		defer workerPool.stop()
+
+		resultCh := make(chan *syncTaskResult, 1)
+		err := workerPool.raceRequest(blockRequest, peer.ID("peer"), 3, resultCh)
+		require.ErrorIs(t, err, errNoPeers)
+	})
+}
+
+func Test_stripeCandidates(t *testing.T) {
+	t.Parallel()
+
+	peerIDs := []peer.ID{"a", "b", "c"}
+	ascendingRequest := network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(101)),
+		128, network.BootstrapRequestData, network.Ascending)
+
+	testCases := map[string]struct {
+		peerBests  map[peer.ID]uint
+		request    *network.BlockRequestMessage
+		candidates []peer.ID
+	}{
+		"request_starting_at_hash": {
+			peerBests: map[peer.ID]uint{"a": 1},
+			request: network.NewBlockRequest(*variadic.MustNewUint32OrHash(common.Hash{1}),
+				128, network.BootstrapRequestData, network.Ascending),
+			candidates: peerIDs,
+		},
+		"no_peer_best_known": {
+			request:    ascendingRequest,
+			candidates: peerIDs,
+		},
+		"peers_having_highest_block_requested": {
+			peerBests:  map[peer.ID]uint{"a": 228, "b": 227, "c": 300},
+			request:    ascendingRequest,
+			candidates: []peer.ID{"a", "c"},
+		},
+		"peers_with_highest_best_block": {
+			peerBests:  map[peer.ID]uint{"a": 200, "b": 150, "c": 200},
+			request:    ascendingRequest,
+			candidates: []peer.ID{"a", "c"},
+		},
+		"descending_request": {
+			peerBests: map[peer.ID]uint{"a": 100, "b": 99},
+			request: network.NewBlockRequest(*variadic.MustNewUint32OrHash(uint32(100)),
+				128, network.BootstrapRequestData, network.Descending),
+			candidates: []peer.ID{"a"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			candidates := stripeCandidates(peerIDs, testCase.peerBests, testCase.request)
+			require.Equal(t, testCase.candidates, candidates)
+		})
+	}
+}
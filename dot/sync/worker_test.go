@@ -29,7 +29,7 @@ func TestWorker(t *testing.T) {
 		Return(nil)
 
 	sharedGuard := make(chan struct{}, 1)
-	w := newWorker(peerA, sharedGuard, reqMaker)
+	w := newWorker(peerA, sharedGuard, 1, reqMaker)
 
 	wg := sync.WaitGroup{}
 	queue := make(chan *syncTask, 2)
@@ -59,3 +59,66 @@ func TestWorker(t *testing.T) {
 	close(queue)
 	wg.Wait()
 }
+
+func TestWorker_maxRequests(t *testing.T) {
+	t.Parallel()
+
+	peerA := peer.ID("peerA")
+	ctrl := gomock.NewController(t)
+
+	release := make(chan struct{})
+	reqMaker := NewMockRequestMaker(ctrl)
+	reqMaker.EXPECT().
+		Do(peerA, nil, gomock.AssignableToTypeOf((*network.BlockResponseMessage)(nil))).
+		DoAndReturn(func(_, _, _ any) error {
+			<-release
+			return nil
+		}).
+		Times(3)
+
+	sharedGuard := make(chan struct{}, 3)
+	w := newWorker(peerA, sharedGuard, 2, reqMaker)
+
+	wg := sync.WaitGroup{}
+	queue := make(chan *syncTask, 3)
+
+	wg.Add(1)
+	go w.run(queue, &wg)
+
+	resultCh := make(chan *syncTaskResult, 3)
+	for i := 0; i < 3; i++ {
+		queue <- &syncTask{
+			resultCh: resultCh,
+		}
+	}
+
+	// only two requests are in flight to the peer at once
+	require.Eventually(t, func() bool { return len(sharedGuard) == 2 }, time.Second, 10*time.Millisecond)
+	require.Never(t, func() bool { return len(sharedGuard) > 2 }, 200*time.Millisecond, 10*time.Millisecond)
+
+	close(release)
+	close(queue)
+	wg.Wait()
+	require.Len(t, resultCh, 3)
+}
+
+func TestExecuteRequest_cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	// the request is not sent to the peer
+	reqMaker := NewMockRequestMaker(ctrl)
+
+	sharedGuard := make(chan struct{}, 1)
+	resultCh := make(chan *syncTaskResult, 1)
+	task := &syncTask{
+		request:   &network.BlockRequestMessage{},
+		resultCh:  resultCh,
+		cancelled: func() bool { return true },
+	}
+
+	executeRequest(peer.ID("peerA"), reqMaker, task, sharedGuard)
+
+	require.Empty(t, sharedGuard)
+	require.Empty(t, resultCh)
+}
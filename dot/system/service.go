@@ -5,6 +5,7 @@
 
 import (
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 )
 
@@ -27,11 +28,22 @@ func (s *Service) SystemName() string {
 	return s.systemInfo.SystemName
 }
 
-// ChainType returns the system's chain type
+// defaultChainType is the chain type of the chain specs not defining it, as in Substrate.
+const defaultChainType = "Live"
+
+// ChainType returns the system's chain type defined in the chain spec
 func (s *Service) ChainType() string {
+	if s.genesisData.ChainType == "" {
+		return defaultChainType
+	}
 	return s.genesisData.ChainType
 }
 
+// NodeRole returns the role the node is configured to run as
+func (s *Service) NodeRole() common.NetworkRole {
+	return s.systemInfo.Role
+}
+
 // SystemVersion returns the app version
 func (s *Service) SystemVersion() string {
 	return s.systemInfo.SystemVersion
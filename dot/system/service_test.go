@@ -7,6 +7,7 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +32,19 @@ func TestService_SystemVersion(t *testing.T) {
 	require.Equal(t, "0.0.1", ver)
 }
 
+func TestService_ChainType(t *testing.T) {
+	svc := newTestService()
+	require.Equal(t, "Live", svc.ChainType())
+
+	svc.genesisData.ChainType = "Development"
+	require.Equal(t, "Development", svc.ChainType())
+}
+
+func TestService_NodeRole(t *testing.T) {
+	svc := newTestService()
+	require.Equal(t, common.AuthorityRole, svc.NodeRole())
+}
+
 func TestService_Properties(t *testing.T) {
 	expected := map[string]interface{}(nil)
 
@@ -56,6 +70,7 @@ func newTestService() *Service {
 	sysInfo := &types.SystemInfo{
 		SystemName:    "gossamer",
 		SystemVersion: "0.0.1",
+		Role:          common.AuthorityRole,
 	}
 	genData := &genesis.Data{
 		Name: "gssmr",
@@ -39,6 +39,40 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 		logger: logger,
 	}
 
+	mailer.connections, err = mailer.dial(ctx, conns)
+	if err != nil {
+		return nil, err
+	}
+
+	return mailer, nil
+}
+
+// SetEndpoints replaces the telemetry endpoints messages are sent to,
+// closing the connections to the previous endpoints.
+func (m *Mailer) SetEndpoints(ctx context.Context, conns []*genesis.TelemetryEndpoint) error {
+	connections, err := m.dial(ctx, conns)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	previous := m.connections
+	m.connections = connections
+	m.mutex.Unlock()
+
+	for _, conn := range previous {
+		conn.Lock()
+		err := conn.wsconn.Close()
+		conn.Unlock()
+		if err != nil {
+			m.logger.Debugf("cannot close telemetry connection: %s", err)
+		}
+	}
+	return nil
+}
+
+func (m *Mailer) dial(ctx context.Context, conns []*genesis.TelemetryEndpoint) (
+	connections []*telemetryConnection, err error) {
 	for _, v := range conns {
 		const maxRetries = 3
 
@@ -48,7 +82,7 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 			conn, response, err := websocket.DefaultDialer.DialContext(dialCtx, v.Endpoint, nil)
 			dialCancel()
 			if err != nil {
-				mailer.logger.Debugf("cannot dial telemetry endpoint %s (try %d of %d): %s",
+				m.logger.Debugf("cannot dial telemetry endpoint %s (try %d of %d): %s",
 					v.Endpoint, connAttempts+1, maxRetries, err)
 
 				if ctxErr := ctx.Err(); ctxErr != nil {
@@ -60,10 +94,10 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 
 			err = response.Body.Close()
 			if err != nil {
-				mailer.logger.Warnf("cannot close body of response from %s: %s", v.Endpoint, err)
+				m.logger.Warnf("cannot close body of response from %s: %s", v.Endpoint, err)
 			}
 
-			mailer.connections = append(mailer.connections, &telemetryConnection{
+			connections = append(connections, &telemetryConnection{
 				wsconn:    conn,
 				verbosity: v.Verbosity,
 			})
@@ -71,7 +105,7 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 		}
 	}
 
-	return mailer, nil
+	return connections, nil
 }
 
 // SendMessage sends Message to connected telemetry listeners through messageReceiver
@@ -89,7 +123,11 @@ func (m *Mailer) shipTelemetryMessage(msg json.Marshaler) {
 		return
 	}
 
-	for _, conn := range m.connections {
+	m.mutex.Lock()
+	connections := m.connections
+	m.mutex.Unlock()
+
+	for _, conn := range connections {
 		conn.Lock()
 		defer conn.Unlock()
 
@@ -68,6 +68,8 @@ func TestHandler_SendMulti(t *testing.T) {
 		[]byte(`{"best":"0x07b749b6e20fd5f1159153a2e790235018621dd06072a62bcd25e8576f6ff5e6","height":"32375","msg":"notify.finalized","ts":`),                                                                                                                                                      //nolint:lll
 		[]byte(`{"hash":"0x5814aec3e28527f81f65841e034872f3a30337cf6c33b2d258bba6071e37e27c","number":"1","msg":"prepared_block_for_proposing","ts":`),                                                                                                                                              //nolint:lll
 		[]byte(`{"ready":1,"future":2,"msg":"txpool.import","ts":`),
+		[]byte(`{"reason":"getting best block header: database closed","backoff":"2s","msg":"sync.restart","ts":`),
+		[]byte(`{"start_block":1,"end_block":128,"peers":3,"retries":2,"retrieve_time":"1.5s","total_time":"2s","msg":"sync.batch","ts":`),                                                     //nolint:lll
 		[]byte(`{"authority_id":"authority_id","authority_set_id":"authority_set_id","authorities":"json-stringified-ids-of-authorities","msg":"afg.authority_set","ts`),                       //nolint:lll
 		[]byte(`{"hash":"0x07b749b6e20fd5f1159153a2e790235018621dd06072a62bcd25e8576f6ff5e6","number":"1","msg":"afg.finalized_blocks_up_to","ts":`),                                           //nolint:lll
 		[]byte(`{"target_hash":"0x5814aec3e28527f81f65841e034872f3a30337cf6c33b2d258bba6071e37e27c","target_number":"1","contains_precommits_signed_by":[],"msg":"afg.received_commit","ts":`), //nolint:lll
@@ -90,6 +92,8 @@ func TestHandler_SendMulti(t *testing.T) {
 		NewAfgReceivedPrevote(secondHash, "1", ""),
 		NewNotifyFinalized(firstHash, "32375"),
 		NewPreparedBlockForProposing(secondHash, "1"),
+		NewSyncRestart("getting best block header: database closed", 2*time.Second),
+		NewSyncBatch(1, 128, 3, 2, 1500*time.Millisecond, 2*time.Second),
 	}
 
 	upgrader := websocket.Upgrader{
@@ -355,3 +359,53 @@ func TestTelemetryMarshalMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestMailer_SetEndpoints(t *testing.T) {
+	t.Parallel()
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool { return true },
+	}
+
+	newServer := func(received chan<- []byte) string {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c, err := upgrader.Upgrade(w, r, nil)
+			require.NoError(t, err)
+			defer c.Close() //nolint:errcheck
+
+			for {
+				_, msg, err := c.ReadMessage()
+				if err != nil {
+					return
+				}
+				received <- msg
+			}
+		}))
+		t.Cleanup(srv.Close)
+		return strings.ReplaceAll(srv.URL, "http", "ws")
+	}
+
+	previous := make(chan []byte, 1)
+	updated := make(chan []byte, 1)
+	previousAddr := newServer(previous)
+	updatedAddr := newServer(updated)
+
+	logger := log.New(log.SetWriter(io.Discard))
+	mailer, err := BootstrapMailer(context.Background(),
+		[]*genesis.TelemetryEndpoint{{Endpoint: previousAddr}}, logger)
+	require.NoError(t, err)
+
+	err = mailer.SetEndpoints(context.Background(),
+		[]*genesis.TelemetryEndpoint{{Endpoint: updatedAddr}})
+	require.NoError(t, err)
+
+	mailer.SendMessage(NewTxpoolImport(1, 2))
+
+	select {
+	case msg := <-updated:
+		assert.Contains(t, string(msg), `"msg":"txpool.import"`)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message on updated endpoint")
+	}
+	assert.Empty(t, previous)
+}
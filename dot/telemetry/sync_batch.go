@@ -0,0 +1,53 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package telemetry
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type syncBatchTM SyncBatch
+
+var _ json.Marshaler = (*SyncBatch)(nil)
+
+// SyncBatch holds `sync.batch` telemetry message, which is supposed to be sent
+// periodically while blocks are synced in bootstrap mode, summarising the range of
+// blocks synced over the interval, the distinct peers they were received from,
+// the requests retried and the time taken.
+type SyncBatch struct {
+	StartBlock   uint   `json:"start_block"`
+	EndBlock     uint   `json:"end_block"`
+	Peers        int    `json:"peers"`
+	Retries      uint   `json:"retries"`
+	RetrieveTime string `json:"retrieve_time"`
+	TotalTime    string `json:"total_time"`
+}
+
+// NewSyncBatch creates a new SyncBatch struct
+func NewSyncBatch(startBlock, endBlock uint, peers int, retries uint,
+	retrieveTime, totalTime time.Duration) *SyncBatch {
+	return &SyncBatch{
+		StartBlock:   startBlock,
+		EndBlock:     endBlock,
+		Peers:        peers,
+		Retries:      retries,
+		RetrieveTime: retrieveTime.String(),
+		TotalTime:    totalTime.String(),
+	}
+}
+
+func (sb SyncBatch) MarshalJSON() ([]byte, error) {
+	telemetryData := struct {
+		syncBatchTM
+		MessageType string    `json:"msg"`
+		Timestamp   time.Time `json:"ts"`
+	}{
+		Timestamp:   time.Now(),
+		MessageType: syncBatchMsg,
+		syncBatchTM: syncBatchTM(sb),
+	}
+
+	return json.Marshal(telemetryData)
+}
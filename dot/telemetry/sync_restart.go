@@ -0,0 +1,42 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package telemetry
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type syncRestartTM SyncRestart
+
+var _ json.Marshaler = (*SyncRestart)(nil)
+
+// SyncRestart holds `sync.restart` telemetry message, which is supposed to be
+// sent when the chain sync fails and is restarted after a backoff.
+type SyncRestart struct {
+	Reason  string `json:"reason"`
+	Backoff string `json:"backoff"`
+}
+
+// NewSyncRestart creates a new SyncRestart struct
+func NewSyncRestart(reason string, backoff time.Duration) *SyncRestart {
+	return &SyncRestart{
+		Reason:  reason,
+		Backoff: backoff.String(),
+	}
+}
+
+func (sr SyncRestart) MarshalJSON() ([]byte, error) {
+	telemetryData := struct {
+		syncRestartTM
+		MessageType string    `json:"msg"`
+		Timestamp   time.Time `json:"ts"`
+	}{
+		Timestamp:     time.Now(),
+		MessageType:   syncRestartMsg,
+		syncRestartTM: syncRestartTM(sr),
+	}
+
+	return json.Marshal(telemetryData)
+}
@@ -21,6 +21,9 @@
 
 	preparedBlockForProposingMsg = "prepared_block_for_proposing"
 
+	syncBatchMsg   = "sync.batch"
+	syncRestartMsg = "sync.restart"
+
 	systemConnectedMsg = "system.connected"
 	systemIntervalMsg  = "system.interval"
 
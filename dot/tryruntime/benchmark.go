@@ -0,0 +1,115 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// HostFunctionProfile is the profile of the calls of a host function over the benchmark runs
+type HostFunctionProfile struct {
+	Name  string
+	Calls int
+	Total time.Duration
+}
+
+// BenchmarkResult is the outcome of the benchmark of a runtime method
+type BenchmarkResult struct {
+	Method string
+	Runs   int
+	// Failures is the number of runs the runtime method returned an error for
+	Failures int
+	Total    time.Duration
+	Min      time.Duration
+	Max      time.Duration
+	// HostFunctions are the host functions called, sorted by decreasing total duration
+	HostFunctions []HostFunctionProfile
+}
+
+// Mean returns the mean execution time of the runtime method.
+func (b *BenchmarkResult) Mean() time.Duration {
+	if b.Runs == 0 {
+		return 0
+	}
+	return b.Total / time.Duration(b.Runs)
+}
+
+// BenchmarkRuntime executes the runtime method given the number of runs given, on top
+// of an empty state, with the input returned by the input function for each run.
+// Failing runs are timed and profiled too, and counted as failures in the result.
+func BenchmarkRuntime(code []byte, method string, runs int, input func(run int) []byte) (
+	*BenchmarkResult, error) {
+	instance, err := wazero_runtime.NewInstance(code, wazero_runtime.Config{
+		Storage:             rtstorage.NewTrieState(inmemory.NewEmptyTrie()),
+		Keystore:            keystore.NewGlobalKeystore(),
+		LogLvl:              log.Error,
+		HostFunctionTracing: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	result := &BenchmarkResult{
+		Method: method,
+		Runs:   runs,
+	}
+	profiles := make(map[string]*HostFunctionProfile)
+	for run := 0; run < runs; run++ {
+		data := input(run)
+
+		start := time.Now()
+		_, err := instance.Exec(method, data)
+		elapsed := time.Since(start)
+
+		if err != nil {
+			result.Failures++
+		}
+		result.record(elapsed)
+		profileHostFunctions(profiles, instance.HostFunctionTrace())
+	}
+
+	for _, profile := range profiles {
+		result.HostFunctions = append(result.HostFunctions, *profile)
+	}
+	sort.Slice(result.HostFunctions, func(i, j int) bool {
+		if result.HostFunctions[i].Total == result.HostFunctions[j].Total {
+			return result.HostFunctions[i].Name < result.HostFunctions[j].Name
+		}
+		return result.HostFunctions[i].Total > result.HostFunctions[j].Total
+	})
+
+	return result, nil
+}
+
+func (b *BenchmarkResult) record(elapsed time.Duration) {
+	if b.Total == 0 || elapsed < b.Min {
+		b.Min = elapsed
+	}
+	if elapsed > b.Max {
+		b.Max = elapsed
+	}
+	b.Total += elapsed
+}
+
+func profileHostFunctions(profiles map[string]*HostFunctionProfile, calls []runtime.HostFunctionCall) {
+	for _, call := range calls {
+		profile, ok := profiles[call.Name]
+		if !ok {
+			profile = &HostFunctionProfile{Name: call.Name}
+			profiles[call.Name] = profile
+		}
+		profile.Calls++
+		profile.Total += call.Duration
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BenchmarkResult_record(t *testing.T) {
+	t.Parallel()
+
+	result := &BenchmarkResult{Runs: 3}
+	result.record(2 * time.Second)
+	result.record(time.Second)
+	result.record(3 * time.Second)
+
+	assert.Equal(t, time.Second, result.Min)
+	assert.Equal(t, 3*time.Second, result.Max)
+	assert.Equal(t, 6*time.Second, result.Total)
+	assert.Equal(t, 2*time.Second, result.Mean())
+}
+
+func Test_profileHostFunctions(t *testing.T) {
+	t.Parallel()
+
+	profiles := make(map[string]*HostFunctionProfile)
+	profileHostFunctions(profiles, []runtime.HostFunctionCall{
+		{Name: "a", Duration: time.Second},
+		{Name: "b", Duration: time.Second},
+	})
+	profileHostFunctions(profiles, []runtime.HostFunctionCall{
+		{Name: "a", Duration: 2 * time.Second},
+	})
+
+	expected := map[string]*HostFunctionProfile{
+		"a": {Name: "a", Calls: 2, Total: 3 * time.Second},
+		"b": {Name: "b", Calls: 1, Total: time.Second},
+	}
+	assert.Equal(t, expected, profiles)
+}
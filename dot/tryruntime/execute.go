@@ -0,0 +1,124 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+)
+
+// StorageDiff is the change of a storage value by the execution of a block
+type StorageDiff struct {
+	Key []byte
+	// Before is nil if the key was inserted
+	Before []byte
+	// After is nil if the key was deleted
+	After []byte
+}
+
+// Result is the outcome of the execution of a block
+type Result struct {
+	// StateRoot is the state root after the execution
+	StateRoot common.Hash
+	// ExpectedStateRoot is the state root of the block header
+	ExpectedStateRoot common.Hash
+	// Diff holds the storage changes sorted by key
+	Diff []StorageDiff
+}
+
+// ExecuteBlock executes the block on top of the state of its parent with the given
+// runtime code, or with the code of the parent state if code is empty.
+// The runtime checks the resulting state root at the end of the execution, so
+// a runtime producing a different state fails after applying its changes: the
+// result holds the storage diff in this case too.
+// The tracer, if not nil, is notified of every storage host function call.
+func ExecuteBlock(block *types.Block, parentState *rtstorage.TrieState, code []byte,
+	tracer runtime.StorageTracer) (*Result, error) {
+	if len(code) == 0 {
+		code = parentState.LoadCode()
+		if len(code) == 0 {
+			return nil, fmt.Errorf("cannot find :code in parent state")
+		}
+	}
+
+	instance, err := wazero_runtime.NewInstance(code, wazero_runtime.Config{
+		Storage:       parentState,
+		Keystore:      keystore.NewGlobalKeystore(),
+		LogLvl:        log.Error,
+		StorageTracer: tracer,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	version, err := instance.Version()
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	stateVersion, err := trie.ParseVersion(version.StateVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing state version: %w", err)
+	}
+	parentState.SetVersion(stateVersion)
+
+	before := parentState.TrieEntries()
+
+	_, execErr := instance.ExecuteBlock(block)
+
+	result := &Result{
+		ExpectedStateRoot: block.Header.StateRoot,
+		Diff:              diffEntries(before, parentState.TrieEntries()),
+	}
+
+	result.StateRoot, err = parentState.Root()
+	if err != nil {
+		return nil, fmt.Errorf("computing state root: %w", err)
+	}
+
+	if execErr != nil {
+		return result, fmt.Errorf("executing block: %w", execErr)
+	}
+	return result, nil
+}
+
+func diffEntries(before, after map[string][]byte) (diff []StorageDiff) {
+	for key, beforeValue := range before {
+		afterValue, ok := after[key]
+		if ok && bytes.Equal(beforeValue, afterValue) {
+			continue
+		}
+		diff = append(diff, StorageDiff{
+			Key:    []byte(key),
+			Before: beforeValue,
+			After:  afterValue,
+		})
+	}
+
+	for key, afterValue := range after {
+		if _, ok := before[key]; ok {
+			continue
+		}
+		diff = append(diff, StorageDiff{
+			Key:   []byte(key),
+			After: afterValue,
+		})
+	}
+
+	sort.Slice(diff, func(i, j int) bool {
+		return bytes.Compare(diff[i].Key, diff[j].Key) < 0
+	})
+	return diff
+}
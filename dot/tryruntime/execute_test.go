@@ -0,0 +1,53 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffEntries(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		before map[string][]byte
+		after  map[string][]byte
+		diff   []StorageDiff
+	}{
+		"no_change": {
+			before: map[string][]byte{"a": {1}},
+			after:  map[string][]byte{"a": {1}},
+		},
+		"changes_sorted_by_key": {
+			before: map[string][]byte{
+				"a": {1},
+				"b": {2},
+				"d": {4},
+			},
+			after: map[string][]byte{
+				"a": {1},
+				"b": {3},
+				"c": {5},
+			},
+			diff: []StorageDiff{
+				{Key: []byte("b"), Before: []byte{2}, After: []byte{3}},
+				{Key: []byte("c"), After: []byte{5}},
+				{Key: []byte("d"), Before: []byte{4}},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			diff := diffEntries(testCase.before, testCase.after)
+
+			assert.Equal(t, testCase.diff, diff)
+		})
+	}
+}
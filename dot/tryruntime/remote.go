@@ -0,0 +1,274 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+const (
+	// keysPageSize is the number of keys requested with each state_getKeysPaged call
+	keysPageSize   = 1000
+	requestTimeout = time.Minute
+)
+
+// ErrRPCResponse is returned when the remote node answers a request with an error
+var ErrRPCResponse = errors.New("rpc error response")
+
+type rpcRequest struct {
+	Version string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// RemoteSource fetches blocks and states from a live node over its HTTP RPC endpoint.
+// Child tries are not fetched.
+type RemoteSource struct {
+	endpoint string
+	client   *http.Client
+	nextID   uint64
+}
+
+// NewRemoteSource creates a source reading from the node serving RPC at endpoint
+func NewRemoteSource(endpoint string) *RemoteSource {
+	return &RemoteSource{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Block returns the block with the given hash
+func (s *RemoteSource) Block(hash common.Hash) (*types.Block, error) {
+	var response modules.ChainBlockResponse
+	err := s.call("chain_getBlock", []any{hash.String()}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := headerFromJSON(response.Block.Header)
+	if err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+
+	extrinsics := make([][]byte, len(response.Block.Body))
+	for i, extrinsic := range response.Block.Body {
+		extrinsics[i], err = common.HexToBytes(extrinsic)
+		if err != nil {
+			return nil, fmt.Errorf("decoding extrinsic %d: %w", i, err)
+		}
+	}
+
+	body, err := types.NewBodyFromEncodedBytes(extrinsics)
+	if err != nil {
+		return nil, fmt.Errorf("decoding body: %w", err)
+	}
+
+	return &types.Block{
+		Header: *header,
+		Body:   *body,
+	}, nil
+}
+
+// FinalisedHead returns the hash of the last block finalised by the node
+func (s *RemoteSource) FinalisedHead() (common.Hash, error) {
+	var hash string
+	err := s.call("chain_getFinalizedHead", []any{}, &hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	finalisedHash, err := common.HexToHash(hash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("malformed finalised hash: %w", err)
+	}
+	return finalisedHash, nil
+}
+
+// State returns the state after the block with the given hash.
+// Every key of the state is fetched, which can take a while on large chains.
+func (s *RemoteSource) State(hash common.Hash) (*rtstorage.TrieState, error) {
+	entries, err := s.Entries(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state version is set from the runtime version before execution
+	tr, err := inmemory_trie.LoadFromMap(entries, trie.V0)
+	if err != nil {
+		return nil, fmt.Errorf("loading state: %w", err)
+	}
+
+	return rtstorage.NewTrieState(tr), nil
+}
+
+// Entries returns the entries of the state after the block with the given hash,
+// as a map of hex encoded keys to hex encoded values.
+// Every key of the state is fetched, which can take a while on large chains.
+func (s *RemoteSource) Entries(hash common.Hash) (map[string]string, error) {
+	entries := make(map[string]string)
+	startKey := ""
+	for {
+		params := []any{"0x", keysPageSize, startKey, hash.String()}
+		if startKey == "" {
+			params = []any{"0x", keysPageSize, nil, hash.String()}
+		}
+
+		var keys []string
+		err := s.call("state_getKeysPaged", params, &keys)
+		if err != nil {
+			return nil, err
+		}
+		if len(keys) == 0 {
+			break
+		}
+
+		var changeSets []modules.StorageChangeSetResponse
+		err = s.call("state_queryStorageAt", []any{keys, hash.String()}, &changeSets)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, changeSet := range changeSets {
+			for _, change := range changeSet.Changes {
+				if change[0] == nil || change[1] == nil {
+					continue
+				}
+				entries[*change[0]] = *change[1]
+			}
+		}
+
+		if len(keys) < keysPageSize {
+			break
+		}
+		startKey = keys[len(keys)-1]
+	}
+
+	return entries, nil
+}
+
+func (s *RemoteSource) call(method string, params []any, result any) error {
+	s.nextID++
+	body, err := json.Marshal(rpcRequest{
+		Version: "2.0",
+		ID:      s.nextID,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding %s request: %w", method, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating %s request: %w", method, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := s.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("sending %s request: %w", method, err)
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("reading %s response: %w", method, err)
+	}
+
+	var response rpcResponse
+	err = json.Unmarshal(data, &response)
+	if err != nil {
+		return fmt.Errorf("decoding %s response: %w", method, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%w: %s: %s (code %d)", ErrRPCResponse, method,
+			response.Error.Message, response.Error.Code)
+	}
+
+	err = json.Unmarshal(response.Result, result)
+	if err != nil {
+		return fmt.Errorf("decoding %s result: %w", method, err)
+	}
+	return nil
+}
+
+func headerFromJSON(header modules.ChainBlockHeaderResponse) (*types.Header, error) {
+	parentHash, err := common.HexToHash(header.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("malformed parent hash: %w", err)
+	}
+
+	number, err := strconv.ParseUint(strings.TrimPrefix(header.Number, "0x"), 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed number: %w", err)
+	}
+
+	stateRoot, err := common.HexToHash(header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("malformed state root: %w", err)
+	}
+
+	extrinsicsRoot, err := common.HexToHash(header.ExtrinsicsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("malformed extrinsics root: %w", err)
+	}
+
+	digest := types.NewDigest()
+	for _, log := range header.Digest.Logs {
+		encoded, err := common.HexToBytes(log)
+		if err != nil {
+			return nil, fmt.Errorf("malformed digest item: %w", err)
+		}
+
+		item := types.NewDigestItem()
+		err = scale.Unmarshal(encoded, &item)
+		if err != nil {
+			return nil, fmt.Errorf("decoding digest item: %w", err)
+		}
+
+		value, err := item.Value()
+		if err != nil {
+			return nil, fmt.Errorf("getting digest item value: %w", err)
+		}
+
+		err = digest.Add(value)
+		if err != nil {
+			return nil, fmt.Errorf("adding digest item: %w", err)
+		}
+	}
+
+	return types.NewHeader(parentHash, stateRoot, extrinsicsRoot,
+		uint(number), digest), nil
+}
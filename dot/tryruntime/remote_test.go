@@ -0,0 +1,120 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRPCServer serves the given results by method name
+func newTestRPCServer(t *testing.T, results map[string]any) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request rpcRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+
+		result, ok := results[request.Method]
+		if !ok {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"method not found"}}`,
+				request.ID)
+			return
+		}
+
+		encoded, err := json.Marshal(result)
+		require.NoError(t, err)
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, request.ID, encoded)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteSource_Block(t *testing.T) {
+	t.Parallel()
+
+	digest := types.NewDigest()
+	err := digest.Add(types.PreRuntimeDigest{
+		ConsensusEngineID: types.BabeEngineID,
+		Data:              []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 26, digest)
+	body := types.NewBody([]types.Extrinsic{{4, 5}, {6}})
+	expected := &types.Block{Header: *header, Body: *body}
+
+	headerJSON, err := modules.HeaderToJSON(*header)
+	require.NoError(t, err)
+	encodedExtrinsics, err := body.AsEncodedExtrinsics()
+	require.NoError(t, err)
+	blockJSON := modules.ChainBlockResponse{Block: modules.ChainBlock{Header: headerJSON}}
+	for _, extrinsic := range encodedExtrinsics {
+		blockJSON.Block.Body = append(blockJSON.Block.Body, extrinsic.String())
+	}
+
+	server := newTestRPCServer(t, map[string]any{"chain_getBlock": blockJSON})
+	source := NewRemoteSource(server.URL)
+
+	block, err := source.Block(header.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, expected.Header.Hash(), block.Header.Hash())
+	assert.Equal(t, expected.Body, block.Body)
+}
+
+func TestRemoteSource_State(t *testing.T) {
+	t.Parallel()
+
+	value := "0x01"
+	server := newTestRPCServer(t, map[string]any{
+		"state_getKeysPaged": []string{"0x3a636f6465", "0x0102"},
+		"state_queryStorageAt": []modules.StorageChangeSetResponse{{
+			Changes: [][2]*string{
+				{ptrTo("0x3a636f6465"), &value},
+				{ptrTo("0x0102"), &value},
+			},
+		}},
+	})
+	source := NewRemoteSource(server.URL)
+
+	state, err := source.State(common.Hash{1})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1}, state.LoadCode())
+	assert.Equal(t, []byte{1}, state.Get([]byte{1, 2}))
+}
+
+func TestRemoteSource_FinalisedHead(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRPCServer(t, map[string]any{
+		"chain_getFinalizedHead": common.Hash{1}.String(),
+	})
+	source := NewRemoteSource(server.URL)
+
+	hash, err := source.FinalisedHead()
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{1}, hash)
+}
+
+func TestRemoteSource_errorResponse(t *testing.T) {
+	t.Parallel()
+
+	server := newTestRPCServer(t, nil)
+	source := NewRemoteSource(server.URL)
+
+	_, err := source.Block(common.Hash{1})
+	assert.ErrorIs(t, err, ErrRPCResponse)
+	assert.EqualError(t, err, "rpc error response: chain_getBlock: method not found (code -32601)")
+}
+
+func ptrTo[T any](value T) *T { return &value }
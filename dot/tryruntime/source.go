@@ -0,0 +1,79 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+)
+
+// Source provides the blocks to execute and the states to execute them on
+type Source interface {
+	// Block returns the block with the given hash
+	Block(hash common.Hash) (*types.Block, error)
+	// State returns the state after the block with the given hash
+	State(hash common.Hash) (*rtstorage.TrieState, error)
+}
+
+// LocalSource reads blocks and states from the database of a node
+type LocalSource struct {
+	db           database.Database
+	blockState   *state.BlockState
+	storageState *state.InmemoryStorageState
+}
+
+// NewLocalSource opens the database of the node at basepath.
+// The node must not be running.
+func NewLocalSource(basepath string) (*LocalSource, error) {
+	db, err := database.LoadDatabase(basepath, false)
+	if err != nil {
+		return nil, fmt.Errorf("loading database: %w", err)
+	}
+
+	tries := state.NewTries()
+	tries.SetEmptyTrie()
+
+	blockState, err := state.NewBlockState(db, tries, nil)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("creating block state: %w", err)
+	}
+
+	storageState, err := state.NewStorageState(db, blockState, tries)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("creating storage state: %w", err)
+	}
+
+	return &LocalSource{
+		db:           db,
+		blockState:   blockState,
+		storageState: storageState,
+	}, nil
+}
+
+// Block returns the block with the given hash
+func (s *LocalSource) Block(hash common.Hash) (*types.Block, error) {
+	return s.blockState.GetBlockByHash(hash)
+}
+
+// State returns the state after the block with the given hash
+func (s *LocalSource) State(hash common.Hash) (*rtstorage.TrieState, error) {
+	header, err := s.blockState.GetHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	return s.storageState.TrieState(&header.StateRoot)
+}
+
+// Close closes the database
+func (s *LocalSource) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewLocalSource(t *testing.T) {
+	t.Parallel()
+
+	genesisPath := utils.GetWestendDevRawGenesisPath(t)
+	gen, err := genesis.NewGenesisFromJSONRaw(genesisPath)
+	require.NoError(t, err)
+	genesisTrie, err := runtime.NewTrieFromGenesis(*gen)
+	require.NoError(t, err)
+	stateRoot := trie.V0.MustHash(genesisTrie)
+	genesisHeader := types.NewHeader(common.NewHash([]byte{0}), stateRoot, trie.EmptyHash, 0, types.NewDigest())
+
+	basepath := t.TempDir()
+	stateService := state.NewService(state.Config{
+		Path:      basepath,
+		Telemetry: telemetry.NoopClient{},
+	})
+	err = stateService.Initialise(gen, genesisHeader, genesisTrie)
+	require.NoError(t, err)
+
+	source, err := NewLocalSource(basepath)
+	require.NoError(t, err)
+	defer source.Close()
+
+	block, err := source.Block(genesisHeader.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, genesisHeader.Hash(), block.Header.Hash())
+
+	trieState, err := source.State(genesisHeader.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, stateRoot, trie.V0.MustHash(trieState.Trie()))
+}
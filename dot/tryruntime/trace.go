@@ -0,0 +1,33 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// TraceWriter writes a line for each storage host function call
+type TraceWriter struct {
+	w io.Writer
+}
+
+// NewTraceWriter creates a storage tracer writing to w
+func NewTraceWriter(w io.Writer) *TraceWriter {
+	return &TraceWriter{w: w}
+}
+
+// TraceStorage writes the host function name with its key and value
+func (t *TraceWriter) TraceStorage(hostFunction string, key, value []byte) {
+	line := hostFunction
+	if key != nil {
+		line += " key=" + common.BytesToHex(key)
+	}
+	if value != nil {
+		line += " value=" + common.BytesToHex(value)
+	}
+	_, _ = fmt.Fprintln(t.w, line)
+}
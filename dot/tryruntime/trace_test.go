@@ -0,0 +1,27 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tryruntime
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceWriter_TraceStorage(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	tracer := NewTraceWriter(buffer)
+
+	tracer.TraceStorage("ext_storage_get_version_1", []byte{1}, []byte{2})
+	tracer.TraceStorage("ext_storage_clear_version_1", []byte{3}, nil)
+	tracer.TraceStorage("ext_storage_start_transaction_version_1", nil, nil)
+
+	expected := "ext_storage_get_version_1 key=0x01 value=0x02\n" +
+		"ext_storage_clear_version_1 key=0x03\n" +
+		"ext_storage_start_transaction_version_1\n"
+	assert.Equal(t, expected, buffer.String())
+}
@@ -170,6 +170,12 @@ type FinalisationInfo struct {
 	Header Header
 	Round  uint64
 	SetID  uint64
+	// Justification is the scale encoded justification of the block finalised,
+	// or nil if the block was finalised without a justification stored.
+	Justification []byte
+	// Retracted are the hashes of the unfinalised blocks pruned
+	// since they are not descendants of the block finalised.
+	Retracted []common.Hash
 }
 
 // GrandpaSignedVote represents a signed precommit message for a finalised block
@@ -74,12 +74,18 @@ func (d *InherentData) String() string {
 
 // SetInherent sets a inherent.
 func (d *InherentData) SetInherent(inherentIdentifier InherentIdentifier, value any) error {
+	return d.SetInherentByKey(inherentIdentifier.Bytes(), value)
+}
+
+// SetInherentByKey sets the inherent of the key given to the SCALE encoding of the value given,
+// for the inherents of the chains requiring inherents without an InherentIdentifier.
+func (d *InherentData) SetInherentByKey(key [8]byte, value any) error {
 	data, err := scale.Marshal(value)
 	if err != nil {
 		return err
 	}
 
-	d.Data[inherentIdentifier.Bytes()] = data
+	d.Data[key] = data
 
 	return nil
 }
@@ -3,8 +3,12 @@
 
 package types
 
+import "github.com/ChainSafe/gossamer/lib/common"
+
 // SystemInfo struct to hold system related information
 type SystemInfo struct {
 	SystemName    string
 	SystemVersion string
+	// Role is the role the node is configured to run as.
+	Role common.NetworkRole
 }
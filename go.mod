@@ -24,9 +24,11 @@ require (
 	github.com/ipfs/go-ds-badger2 v0.1.3
 	github.com/jpillora/backoff v1.0.0
 	github.com/jpillora/ipfilter v1.2.9
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/klauspost/compress v1.17.8
 	github.com/libp2p/go-libp2p v0.33.2
 	github.com/libp2p/go-libp2p-kad-dht v0.25.2
+	github.com/linxGnu/grocksdb v1.7.14
 	github.com/minio/sha256-simd v1.0.1
 	github.com/multiformats/go-multiaddr v0.12.3
 	github.com/nanobox-io/golang-scribble v0.0.0-20190309225732-aa3e7c118975
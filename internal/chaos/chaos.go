@@ -0,0 +1,84 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package chaos defines the faults injected in the network and the sync worker
+// pool for chaos testing. The faults are only injected in binaries built with
+// the chaos build tag, and the injection points compile out otherwise.
+package chaos
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ErrFaultInjected is wrapped in the errors returned by the injected faults.
+var ErrFaultInjected = errors.New("fault injected")
+
+// Faults are the faults to inject, each peer selector being called for each
+// request or response involving the peer. A nil selector never selects a peer.
+type Faults struct {
+	// DropPeer selects the peers to disconnect from instead of sending them a request.
+	DropPeer func(who peer.ID) bool
+	// DropResponse selects the peers whose responses are dropped once received.
+	DropResponse func(who peer.ID) bool
+	// CorruptBlockBody selects the peers whose block responses have their block bodies corrupted.
+	CorruptBlockBody func(who peer.ID) bool
+	// JustificationDelay is the delay of the block responses carrying justifications.
+	JustificationDelay time.Duration
+}
+
+// Always selects every peer.
+func Always(peer.ID) bool { return true }
+
+// Peers returns a selector of the peers given.
+func Peers(peerIDs ...peer.ID) func(who peer.ID) bool {
+	selected := make(map[peer.ID]struct{}, len(peerIDs))
+	for _, peerID := range peerIDs {
+		selected[peerID] = struct{}{}
+	}
+
+	return func(who peer.ID) bool {
+		_, ok := selected[who]
+		return ok
+	}
+}
+
+// Randomly returns a selector selecting peers with the probability given,
+// drawn from a pseudo-random source seeded with the seed given, so a chaos
+// test run can be reproduced.
+func Randomly(probability float64, seed int64) func(who peer.ID) bool {
+	var mutex sync.Mutex
+	source := rand.New(rand.NewSource(seed)) //nolint:gosec
+
+	return func(peer.ID) bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return source.Float64() < probability
+	}
+}
+
+// selects returns true if the selector given is not nil and selects the peer given.
+func selects(selector func(who peer.ID) bool, who peer.ID) bool {
+	return selector != nil && selector(who)
+}
+
+// DropsPeer returns true if the faults are not nil and the peer given is to be dropped.
+func (f *Faults) DropsPeer(who peer.ID) bool {
+	return f != nil && selects(f.DropPeer, who)
+}
+
+// DropsResponse returns true if the faults are not nil and the response of the peer
+// given is to be dropped.
+func (f *Faults) DropsResponse(who peer.ID) bool {
+	return f != nil && selects(f.DropResponse, who)
+}
+
+// CorruptsBlockBody returns true if the faults are not nil and the block bodies
+// received from the peer given are to be corrupted.
+func (f *Faults) CorruptsBlockBody(who peer.ID) bool {
+	return f != nil && selects(f.CorruptBlockBody, who)
+}
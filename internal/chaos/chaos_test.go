@@ -0,0 +1,81 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chaos
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Peers(t *testing.T) {
+	t.Parallel()
+
+	selector := Peers("a", "b")
+	assert.True(t, selector("a"))
+	assert.True(t, selector("b"))
+	assert.False(t, selector("c"))
+}
+
+func Test_Randomly(t *testing.T) {
+	t.Parallel()
+
+	draw := func(selector func(who peer.ID) bool) (selected []bool) {
+		selected = make([]bool, 100)
+		for i := range selected {
+			selected[i] = selector("a")
+		}
+		return selected
+	}
+
+	// the same seed gives the same selections
+	assert.Equal(t, draw(Randomly(0.5, 1)), draw(Randomly(0.5, 1)))
+	assert.NotContains(t, draw(Randomly(0, 1)), true)
+	assert.NotContains(t, draw(Randomly(1, 1)), false)
+}
+
+func Test_Faults(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		faults            *Faults
+		dropsPeer         bool
+		dropsResponse     bool
+		corruptsBlockBody bool
+	}{
+		"nil_faults": {},
+		"nil_selectors": {
+			faults: &Faults{},
+		},
+		"all_faults": {
+			faults: &Faults{
+				DropPeer:         Always,
+				DropResponse:     Always,
+				CorruptBlockBody: Always,
+			},
+			dropsPeer:         true,
+			dropsResponse:     true,
+			corruptsBlockBody: true,
+		},
+		"other_peer_selected": {
+			faults: &Faults{
+				DropPeer:         Peers("b"),
+				DropResponse:     Peers("b"),
+				CorruptBlockBody: Peers("b"),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.dropsPeer, testCase.faults.DropsPeer("a"))
+			assert.Equal(t, testCase.dropsResponse, testCase.faults.DropsResponse("a"))
+			assert.Equal(t, testCase.corruptsBlockBody, testCase.faults.CorruptsBlockBody("a"))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build !chaos
+
+package chaos
+
+// Enabled is true when the faults are injected, in binaries built with the chaos build tag.
+const Enabled = false
@@ -0,0 +1,150 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Backend is the name of a database implementation
+type Backend string
+
+const (
+	// PebbleBackend is the Pebble database backend
+	PebbleBackend Backend = "pebble"
+	// RocksDBBackend is the RocksDB database backend
+	RocksDBBackend Backend = "rocksdb"
+	// MemoryBackend keeps databases in memory for the lifetime of the process
+	MemoryBackend Backend = "memory"
+
+	// DefaultBackend is the backend of databases which do not record one
+	DefaultBackend = PebbleBackend
+)
+
+// backendFile is the file recording the backend of a database directory
+const backendFile = "BACKEND"
+
+var (
+	ErrBackendUnavailable = errors.New("database backend not available")
+	ErrBackendMismatch    = errors.New("database backend mismatch")
+	// ErrUnsupportedDatabase is returned for the databases written by older gossamer versions
+	// in a format which cannot be converted, such as the BadgerDB databases.
+	ErrUnsupportedDatabase = errors.New("unsupported database")
+)
+
+// badgerHint is how to recover the node data from a BadgerDB database.
+const badgerHint = "it was written with BadgerDB by an older gossamer version; " +
+	"export the state of its chain head with the state_getPairs RPC method of that version " +
+	"and import it with the import-state command, or remove it with the purge-chain command and resync"
+
+// Opener opens a database of a backend at the given path
+type Opener func(path string, inMemory bool) (Database, error)
+
+var (
+	backendsMutex sync.RWMutex
+	backends      = map[Backend]Opener{
+		PebbleBackend: func(path string, inMemory bool) (Database, error) {
+			return NewPebble(path, inMemory)
+		},
+		MemoryBackend: openMemory,
+	}
+)
+
+// RegisterBackend makes the backend available to Open.
+func RegisterBackend(backend Backend, opener Opener) {
+	backendsMutex.Lock()
+	defer backendsMutex.Unlock()
+	backends[backend] = opener
+}
+
+// Backends returns the available backends.
+func Backends() (available []Backend) {
+	backendsMutex.RLock()
+	defer backendsMutex.RUnlock()
+
+	for backend := range backends {
+		available = append(available, backend)
+	}
+	return available
+}
+
+// Open opens the database at the path using the given backend. The backend is
+// recorded in the directory of databases persisted on disk and opening it with
+// another backend afterwards fails.
+func Open(backend Backend, path string, inMemory bool) (Database, error) {
+	backendsMutex.RLock()
+	opener, ok := backends[backend]
+	backendsMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBackendUnavailable, backend)
+	}
+
+	if inMemory || backend == MemoryBackend {
+		return opener(path, inMemory)
+	}
+
+	recorded, err := DetectBackend(path)
+	if err != nil {
+		return nil, err
+	}
+	if recorded != "" && recorded != backend {
+		return nil, fmt.Errorf("%w: %s uses %s, not %s", ErrBackendMismatch, path, recorded, backend)
+	}
+
+	db, err := opener(path, inMemory)
+	if err != nil {
+		return nil, err
+	}
+
+	if recorded == "" {
+		err = os.WriteFile(filepath.Join(path, backendFile), []byte(backend), 0o600)
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("recording database backend: %w", err)
+		}
+	}
+
+	return db, nil
+}
+
+// DetectBackend returns the backend of the database at the path. Databases
+// created before backends were recorded are Pebble databases, and an empty
+// backend is returned if there is no database at the path. It returns an error
+// wrapping ErrUnsupportedDatabase for the BadgerDB databases of older versions.
+func DetectBackend(path string) (Backend, error) {
+	if hasMemoryDatabase(path) {
+		return MemoryBackend, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(path, backendFile))
+	if err == nil {
+		return Backend(strings.TrimSpace(string(data))), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("reading database backend: %w", err)
+	}
+
+	entries, err := os.ReadDir(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf("reading database directory: %w", err)
+	case len(entries) == 0:
+		return "", nil
+	}
+
+	for _, entry := range entries {
+		// the key registry and value log files are only written by BadgerDB
+		if entry.Name() == "KEYREGISTRY" || strings.HasSuffix(entry.Name(), ".vlog") {
+			return "", fmt.Errorf("%w: %s: %s", ErrUnsupportedDatabase, path, badgerHint)
+		}
+	}
+	return PebbleBackend, nil
+}
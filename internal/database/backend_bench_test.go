@@ -0,0 +1,77 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func benchmarkBackends(b *testing.B, run func(b *testing.B, db Database)) {
+	for _, backend := range Backends() {
+		b.Run(string(backend), func(b *testing.B) {
+			db, err := Open(backend, b.TempDir(), false)
+			require.NoError(b, err)
+			b.Cleanup(func() {
+				err := db.Close()
+				require.NoError(b, err)
+			})
+
+			run(b, db)
+		})
+	}
+}
+
+func Benchmark_Backend_Put(b *testing.B) {
+	value := make([]byte, 128)
+	benchmarkBackends(b, func(b *testing.B, db Database) {
+		key := make([]byte, 8)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			binary.BigEndian.PutUint64(key, uint64(i))
+			err := db.Put(key, value)
+			require.NoError(b, err)
+		}
+	})
+}
+
+func Benchmark_Backend_BatchPut(b *testing.B) {
+	value := make([]byte, 128)
+	benchmarkBackends(b, func(b *testing.B, db Database) {
+		key := make([]byte, 8)
+		batch := db.NewBatch()
+		defer batch.Close()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			binary.BigEndian.PutUint64(key, uint64(i))
+			err := batch.Put(key, value)
+			require.NoError(b, err)
+		}
+		err := batch.Flush()
+		require.NoError(b, err)
+	})
+}
+
+func Benchmark_Backend_Get(b *testing.B) {
+	const entries = 10000
+	value := make([]byte, 128)
+	benchmarkBackends(b, func(b *testing.B, db Database) {
+		key := make([]byte, 8)
+		for i := 0; i < entries; i++ {
+			binary.BigEndian.PutUint64(key, uint64(i))
+			err := db.Put(key, value)
+			require.NoError(b, err)
+		}
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			binary.BigEndian.PutUint64(key, uint64(i%entries))
+			_, err := db.Get(key)
+			require.NoError(b, err)
+		}
+	})
+}
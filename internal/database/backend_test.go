@@ -0,0 +1,133 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Open(t *testing.T) {
+	t.Parallel()
+
+	path := t.TempDir()
+
+	_, err := Open("nope", path, false)
+	assert.ErrorIs(t, err, ErrBackendUnavailable)
+	assert.EqualError(t, err, "database backend not available: nope")
+
+	db, err := Open(PebbleBackend, path, false)
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	backend, err := DetectBackend(path)
+	require.NoError(t, err)
+	assert.Equal(t, PebbleBackend, backend)
+
+	otherPath := t.TempDir()
+	err = os.WriteFile(filepath.Join(otherPath, backendFile), []byte(RocksDBBackend), 0o600)
+	require.NoError(t, err)
+	_, err = Open(PebbleBackend, otherPath, false)
+	assert.ErrorIs(t, err, ErrBackendMismatch)
+	assert.EqualError(t, err, "database backend mismatch: "+otherPath+" uses rocksdb, not pebble")
+}
+
+func Test_DetectBackend(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup      func(t *testing.T, path string)
+		backend    Backend
+		errWrapped error
+	}{
+		"no_directory": {
+			setup: func(t *testing.T, path string) {},
+		},
+		"empty_directory": {
+			setup: func(t *testing.T, path string) {
+				err := os.Mkdir(path, os.ModePerm)
+				require.NoError(t, err)
+			},
+		},
+		"recorded_backend": {
+			setup: func(t *testing.T, path string) {
+				err := os.Mkdir(path, os.ModePerm)
+				require.NoError(t, err)
+				err = os.WriteFile(filepath.Join(path, backendFile), []byte("rocksdb\n"), 0o600)
+				require.NoError(t, err)
+			},
+			backend: RocksDBBackend,
+		},
+		"unrecorded_pebble_database": {
+			setup: func(t *testing.T, path string) {
+				db, err := NewPebble(path, false)
+				require.NoError(t, err)
+				err = db.Close()
+				require.NoError(t, err)
+			},
+			backend: PebbleBackend,
+		},
+		"badger_database": {
+			setup: func(t *testing.T, path string) {
+				err := os.Mkdir(path, os.ModePerm)
+				require.NoError(t, err)
+				for _, name := range []string{"000001.vlog", "KEYREGISTRY", "MANIFEST"} {
+					err = os.WriteFile(filepath.Join(path, name), nil, 0o600)
+					require.NoError(t, err)
+				}
+			},
+			errWrapped: ErrUnsupportedDatabase,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "db")
+			testCase.setup(t, path)
+
+			backend, err := DetectBackend(path)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.backend, backend)
+		})
+	}
+}
+
+func Test_Open_memory(t *testing.T) {
+	t.Parallel()
+
+	basepath := t.TempDir()
+	path := filepath.Join(basepath, DefaultDatabaseDir)
+
+	db, err := Open(MemoryBackend, path, false)
+	require.NoError(t, err)
+	err = db.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	db, err = LoadDatabase(basepath, false)
+	require.NoError(t, err)
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	err = db.Close()
+	require.NoError(t, err)
+
+	err = ClearDatabase(basepath)
+	require.NoError(t, err)
+	backend, err := DetectBackend(path)
+	require.NoError(t, err)
+	assert.Equal(t, Backend(""), backend)
+}
@@ -60,17 +60,36 @@ type Table interface {
 	Path() string
 	NewBatch() Batch
 	NewIterator() (Iterator, error)
+	NewPrefixIterator(prefix []byte) (Iterator, error)
 }
 
 const DefaultDatabaseDir = "db"
 
-// LoadDatabase will return an instance of database based on basepath
+// LoadDatabase will return an instance of database based on basepath, using the
+// backend recorded in the database directory or the DefaultBackend for a new database
 func LoadDatabase(basepath string, inMemory bool) (Database, error) {
 	nodeDatabaseDir := filepath.Join(basepath, DefaultDatabaseDir)
-	return NewPebble(nodeDatabaseDir, inMemory)
+	backend := DefaultBackend
+	if !inMemory {
+		recorded, err := DetectBackend(nodeDatabaseDir)
+		if err != nil {
+			return nil, err
+		}
+		if recorded != "" {
+			backend = recorded
+		}
+	}
+	return Open(backend, nodeDatabaseDir, inMemory)
+}
+
+// LoadDatabaseBackend will return an instance of database based on basepath using the given backend
+func LoadDatabaseBackend(basepath string, inMemory bool, backend Backend) (Database, error) {
+	nodeDatabaseDir := filepath.Join(basepath, DefaultDatabaseDir)
+	return Open(backend, nodeDatabaseDir, inMemory)
 }
 
 func ClearDatabase(basepath string) error {
 	nodeDatabaseDir := filepath.Join(basepath, DefaultDatabaseDir)
+	clearMemoryDatabase(nodeDatabaseDir)
 	return os.RemoveAll(nodeDatabaseDir)
 }
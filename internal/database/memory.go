@@ -0,0 +1,69 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+)
+
+// memoryFileSystems holds the in-memory file systems of the memory backend databases
+// by path, so their content survives closing and reopening them in the same process.
+var memoryFileSystems = struct {
+	sync.Mutex
+	byPath map[string]vfs.FS
+}{byPath: make(map[string]vfs.FS)}
+
+// openMemory opens the in-memory database at the path
+func openMemory(path string, _ bool) (Database, error) {
+	memoryFileSystems.Lock()
+	fs, ok := memoryFileSystems.byPath[memoryKey(path)]
+	if !ok {
+		fs = vfs.NewMem()
+		memoryFileSystems.byPath[memoryKey(path)] = fs
+	}
+	memoryFileSystems.Unlock()
+
+	err := fs.MkdirAll(path, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("creating in-memory directory: %w", err)
+	}
+
+	db, err := pebble.Open(path, &pebble.Options{FS: fs})
+	if err != nil {
+		return nil, fmt.Errorf("opening in-memory pebble db: %w", err)
+	}
+
+	return &PebbleDB{path, db}, nil
+}
+
+// hasMemoryDatabase returns true if a memory backend database exists at the path
+func hasMemoryDatabase(path string) bool {
+	memoryFileSystems.Lock()
+	defer memoryFileSystems.Unlock()
+	_, ok := memoryFileSystems.byPath[memoryKey(path)]
+	return ok
+}
+
+// clearMemoryDatabase removes the memory backend database at the path
+func clearMemoryDatabase(path string) {
+	memoryFileSystems.Lock()
+	defer memoryFileSystems.Unlock()
+	delete(memoryFileSystems.byPath, memoryKey(path))
+}
+
+// memoryKey returns the absolute path if it can be resolved, so relative and
+// absolute paths of a directory share the same database.
+func memoryKey(path string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return absPath
+}
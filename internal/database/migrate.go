@@ -0,0 +1,140 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+var (
+	ErrNoDatabase   = errors.New("no database to migrate")
+	ErrSameBackend  = errors.New("database already uses backend")
+	ErrBackupExists = errors.New("database backup already exists")
+)
+
+// migrateBatchSize is the number of entries written per batch during a migration
+const migrateBatchSize = 10000
+
+// Migrate copies all the key-value pairs of the source database into the
+// destination database and returns the number of pairs copied.
+func Migrate(src, dst Database) (copied uint64, err error) {
+	iter, err := src.NewIterator()
+	if err != nil {
+		return 0, fmt.Errorf("creating source iterator: %w", err)
+	}
+	defer iter.Release()
+
+	batch := dst.NewBatch()
+	defer batch.Close()
+
+	pending := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		err = batch.Put(iter.Key(), iter.Value())
+		if err != nil {
+			return copied, err
+		}
+		pending++
+
+		if pending == migrateBatchSize {
+			err = batch.Flush()
+			if err != nil {
+				return copied, err
+			}
+			batch.Reset()
+			copied += uint64(pending)
+			pending = 0
+			logger.Debugf("migrated %d database entries", copied)
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return copied, err
+	}
+	copied += uint64(pending)
+
+	return copied, dst.Flush()
+}
+
+// MigrateBackend migrates the database at the basepath to the given backend. The
+// migrated database replaces the original one, which is kept next to it with the
+// name of its backend as suffix.
+func MigrateBackend(basepath string, to Backend) (err error) {
+	nodeDatabaseDir := filepath.Join(basepath, DefaultDatabaseDir)
+	from, err := DetectBackend(nodeDatabaseDir)
+	if err != nil {
+		return err
+	}
+
+	switch from {
+	case "":
+		return fmt.Errorf("%w: %s", ErrNoDatabase, nodeDatabaseDir)
+	case to:
+		return fmt.Errorf("%w: %s", ErrSameBackend, to)
+	}
+
+	backupDir := nodeDatabaseDir + "." + string(from)
+	if _, err := os.Stat(backupDir); err == nil {
+		return fmt.Errorf("%w: %s", ErrBackupExists, backupDir)
+	}
+
+	migratedDir := nodeDatabaseDir + "." + string(to)
+	err = os.RemoveAll(migratedDir)
+	if err != nil {
+		return fmt.Errorf("removing previous migration: %w", err)
+	}
+
+	err = migrateDir(nodeDatabaseDir, from, migratedDir, to)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(nodeDatabaseDir, backupDir)
+	if err != nil {
+		return fmt.Errorf("moving original database: %w", err)
+	}
+
+	err = os.Rename(migratedDir, nodeDatabaseDir)
+	if err != nil {
+		return fmt.Errorf("moving migrated database: %w", err)
+	}
+
+	logger.Infof("database migrated from %s to %s, original database kept at %s", from, to, backupDir)
+	return nil
+}
+
+func migrateDir(srcDir string, srcBackend Backend, dstDir string, dstBackend Backend) (err error) {
+	dst, err := Open(dstBackend, dstDir, false)
+	if err != nil {
+		return fmt.Errorf("opening %s database: %w", dstBackend, err)
+	}
+	defer func() {
+		closeErr := dst.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing %s database: %w", dstBackend, closeErr)
+		}
+	}()
+
+	src, err := Open(srcBackend, srcDir, false)
+	if err != nil {
+		return fmt.Errorf("opening %s database: %w", srcBackend, err)
+	}
+	defer func() {
+		closeErr := src.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing %s database: %w", srcBackend, closeErr)
+		}
+	}()
+
+	copied, err := Migrate(src, dst)
+	if err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
+
+	logger.Infof("copied %d database entries", copied)
+	return nil
+}
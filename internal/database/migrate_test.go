@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Migrate(t *testing.T) {
+	t.Parallel()
+
+	src := testNewPebble(t)
+	dst := testNewPebble(t)
+
+	const entries = migrateBatchSize + 1
+	for i := 0; i < entries; i++ {
+		err := src.Put([]byte{byte(i >> 8), byte(i)}, []byte{byte(i)})
+		require.NoError(t, err)
+	}
+
+	copied, err := Migrate(src, dst)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(entries), copied)
+
+	for i := 0; i < entries; i++ {
+		value, err := dst.Get([]byte{byte(i >> 8), byte(i)})
+		require.NoError(t, err)
+		assert.Equal(t, []byte{byte(i)}, value)
+	}
+}
+
+func Test_MigrateBackend(t *testing.T) {
+	t.Parallel()
+
+	const testBackend Backend = "test"
+	RegisterBackend(testBackend, func(path string, inMemory bool) (Database, error) {
+		return NewPebble(path, inMemory)
+	})
+
+	basepath := t.TempDir()
+	err := MigrateBackend(basepath, testBackend)
+	assert.ErrorIs(t, err, ErrNoDatabase)
+
+	db, err := LoadDatabase(basepath, false)
+	require.NoError(t, err)
+	err = db.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	err = MigrateBackend(basepath, PebbleBackend)
+	assert.ErrorIs(t, err, ErrSameBackend)
+
+	err = MigrateBackend(basepath, testBackend)
+	require.NoError(t, err)
+
+	db, err = LoadDatabase(basepath, false)
+	require.NoError(t, err)
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	err = db.Close()
+	require.NoError(t, err)
+
+	backend, err := DetectBackend(filepath.Join(basepath, DefaultDatabaseDir))
+	require.NoError(t, err)
+	assert.Equal(t, testBackend, backend)
+	backend, err = DetectBackend(filepath.Join(basepath, DefaultDatabaseDir+".pebble"))
+	require.NoError(t, err)
+	assert.Equal(t, PebbleBackend, backend)
+}
@@ -649,6 +649,21 @@ func (mr *MockTableMockRecorder) NewIterator() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewIterator", reflect.TypeOf((*MockTable)(nil).NewIterator))
 }
 
+// NewPrefixIterator mocks base method.
+func (m *MockTable) NewPrefixIterator(prefix []byte) (database.Iterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPrefixIterator", prefix)
+	ret0, _ := ret[0].(database.Iterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewPrefixIterator indicates an expected call of NewPrefixIterator.
+func (mr *MockTableMockRecorder) NewPrefixIterator(prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPrefixIterator", reflect.TypeOf((*MockTable)(nil).NewPrefixIterator), prefix)
+}
+
 // Path mocks base method.
 func (m *MockTable) Path() string {
 	m.ctrl.T.Helper()
@@ -14,7 +14,10 @@
 )
 
 var logger = log.NewFromGlobal(log.AddContext("internal", "database"))
-var _ Database = (*PebbleDB)(nil)
+var (
+	_ Database     = (*PebbleDB)(nil)
+	_ Checkpointer = (*PebbleDB)(nil)
+)
 
 var ErrNotFound = pebble.ErrNotFound
 
@@ -101,6 +104,16 @@ func (p *PebbleDB) Close() error {
 	return p.db.Close()
 }
 
+// Checkpoint writes a consistent copy of the database to the directory given, which must not
+// exist, hard linking the immutable files of the database where the file system supports it.
+func (p *PebbleDB) Checkpoint(dir string) error {
+	err := p.db.Checkpoint(dir, pebble.WithFlushedWAL())
+	if err != nil {
+		return fmt.Errorf("checkpointing pebble db: %w", err)
+	}
+	return nil
+}
+
 func (p *PebbleDB) Flush() error {
 	err := p.db.Flush()
 	if err != nil {
@@ -135,20 +148,6 @@ func (p *PebbleDB) NewIterator() (Iterator, error) {
 // keys that contains the prefix
 // more info: https://github.com/ChainSafe/gossamer/pull/3434#discussion_r1291503323
 func (p *PebbleDB) NewPrefixIterator(prefix []byte) (Iterator, error) {
-	keyUpperBound := func(b []byte) []byte {
-		end := make([]byte, len(b))
-		copy(end, b)
-
-		for i := len(end) - 1; i >= 0; i-- {
-			end[i] = end[i] + 1
-			if end[i] != 0 {
-				return end[:i+1]
-			}
-		}
-
-		return nil
-	}
-
 	prefixIterOptions := &pebble.IterOptions{
 		LowerBound: prefix,
 		UpperBound: keyUpperBound(prefix),
@@ -162,3 +161,19 @@ func (p *PebbleDB) NewPrefixIterator(prefix []byte) (Iterator, error) {
 		iter,
 	}, nil
 }
+
+// keyUpperBound returns the smallest key greater than all the keys with the prefix given,
+// or nil if there is none since the prefix only has 0xff bytes.
+func keyUpperBound(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i] = end[i] + 1
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build rocksdb
+
+package database
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/linxGnu/grocksdb"
+)
+
+var (
+	_ Database     = (*RocksDB)(nil)
+	_ Checkpointer = (*RocksDB)(nil)
+)
+
+func init() {
+	RegisterBackend(RocksDBBackend, func(path string, inMemory bool) (Database, error) {
+		// RocksDB has no in memory mode, so the in memory databases use the memory backend
+		if inMemory {
+			return openMemory(path, inMemory)
+		}
+		return NewRocksDB(path)
+	})
+}
+
+// RocksDB is the RocksDB implementation of the Database interface, only
+// available in the binaries built with the rocksdb build tag and linked
+// against the RocksDB library.
+type RocksDB struct {
+	path         string
+	db           *grocksdb.DB
+	options      *grocksdb.Options
+	readOptions  *grocksdb.ReadOptions
+	writeOptions *grocksdb.WriteOptions
+	flushOptions *grocksdb.FlushOptions
+}
+
+// NewRocksDB opens the RocksDB database at the path given, creating it if it does not exist.
+func NewRocksDB(path string) (*RocksDB, error) {
+	if err := os.MkdirAll(path, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	options := grocksdb.NewDefaultOptions()
+	options.SetCreateIfMissing(true)
+
+	db, err := grocksdb.OpenDb(options, path)
+	if err != nil {
+		options.Destroy()
+		return nil, fmt.Errorf("opening rocksdb db: %w", err)
+	}
+
+	flushOptions := grocksdb.NewDefaultFlushOptions()
+	flushOptions.SetWait(true)
+
+	return &RocksDB{
+		path:         path,
+		db:           db,
+		options:      options,
+		readOptions:  grocksdb.NewDefaultReadOptions(),
+		writeOptions: grocksdb.NewDefaultWriteOptions(),
+		flushOptions: flushOptions,
+	}, nil
+}
+
+func (r *RocksDB) Path() string {
+	return r.path
+}
+
+func (r *RocksDB) Put(key, value []byte) error {
+	err := r.db.Put(r.writeOptions, key, value)
+	if err != nil {
+		return fmt.Errorf("writing 0x%x with value 0x%x to database: %w",
+			key, value, err)
+	}
+	return nil
+}
+
+// Get returns a copy of the value of the key given, or ErrNotFound if there is none.
+func (r *RocksDB) Get(key []byte) (value []byte, err error) {
+	slice, err := r.db.Get(r.readOptions, key)
+	if err != nil {
+		return nil, err
+	}
+	defer slice.Free()
+
+	if !slice.Exists() {
+		return nil, ErrNotFound
+	}
+
+	value = make([]byte, slice.Size())
+	copy(value, slice.Data())
+	return value, nil
+}
+
+func (r *RocksDB) Has(key []byte) (exists bool, err error) {
+	slice, err := r.db.Get(r.readOptions, key)
+	if err != nil {
+		return false, err
+	}
+	defer slice.Free()
+
+	return slice.Exists(), nil
+}
+
+func (r *RocksDB) Del(key []byte) error {
+	return r.db.Delete(r.writeOptions, key)
+}
+
+func (r *RocksDB) Close() error {
+	r.db.Close()
+	r.options.Destroy()
+	r.readOptions.Destroy()
+	r.writeOptions.Destroy()
+	r.flushOptions.Destroy()
+	return nil
+}
+
+// Checkpoint writes a consistent copy of the database to the directory given, which must not
+// exist, hard linking the immutable files of the database where the file system supports it.
+func (r *RocksDB) Checkpoint(dir string) error {
+	checkpoint, err := r.db.NewCheckpoint()
+	if err != nil {
+		return fmt.Errorf("creating rocksdb checkpoint: %w", err)
+	}
+	defer checkpoint.Destroy()
+
+	// a zero log size flushes the memtable, so the checkpoint needs no write-ahead log
+	err = checkpoint.CreateCheckpoint(dir, 0)
+	if err != nil {
+		return fmt.Errorf("checkpointing rocksdb db: %w", err)
+	}
+	return nil
+}
+
+func (r *RocksDB) Flush() error {
+	err := r.db.Flush(r.flushOptions)
+	if err != nil {
+		return fmt.Errorf("flushing database: %w", err)
+	}
+
+	return nil
+}
+
+// NewBatch returns an implementation of Batch interface using the
+// internal database
+func (r *RocksDB) NewBatch() Batch {
+	return &rocksDBBatch{
+		db:    r,
+		batch: grocksdb.NewWriteBatch(),
+	}
+}
+
+// NewIterator returns an implementation of Iterator interface using the
+// internal database
+func (r *RocksDB) NewIterator() (Iterator, error) {
+	readOptions := grocksdb.NewDefaultReadOptions()
+	return &rocksDBIterator{
+		iter:        r.db.NewIterator(readOptions),
+		readOptions: readOptions,
+	}, nil
+}
+
+// NewPrefixIterator returns an implementation of Iterator over a specific
+// keys that contains the prefix
+func (r *RocksDB) NewPrefixIterator(prefix []byte) (Iterator, error) {
+	readOptions := grocksdb.NewDefaultReadOptions()
+	readOptions.SetIterateLowerBound(prefix)
+	if upperBound := keyUpperBound(prefix); upperBound != nil {
+		readOptions.SetIterateUpperBound(upperBound)
+	}
+
+	return &rocksDBIterator{
+		iter:        r.db.NewIterator(readOptions),
+		readOptions: readOptions,
+	}, nil
+}
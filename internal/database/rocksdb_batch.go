@@ -0,0 +1,51 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build rocksdb
+
+package database
+
+import (
+	"fmt"
+
+	"github.com/linxGnu/grocksdb"
+)
+
+var _ Batch = (*rocksDBBatch)(nil)
+
+type rocksDBBatch struct {
+	db    *RocksDB
+	batch *grocksdb.WriteBatch
+}
+
+func (rb *rocksDBBatch) Put(key, value []byte) error {
+	rb.batch.Put(key, value)
+	return nil
+}
+
+func (rb *rocksDBBatch) Del(key []byte) error {
+	rb.batch.Delete(key)
+	return nil
+}
+
+func (rb *rocksDBBatch) Flush() error {
+	err := rb.db.db.Write(rb.db.writeOptions, rb.batch)
+	if err != nil {
+		return fmt.Errorf("committing batch: %w", err)
+	}
+
+	return nil
+}
+
+func (rb *rocksDBBatch) ValueSize() int {
+	return rb.batch.Count()
+}
+
+func (rb *rocksDBBatch) Reset() {
+	rb.batch.Clear()
+}
+
+func (rb *rocksDBBatch) Close() error {
+	rb.batch.Destroy()
+	return nil
+}
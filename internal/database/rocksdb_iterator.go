@@ -0,0 +1,59 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build rocksdb
+
+package database
+
+import "github.com/linxGnu/grocksdb"
+
+var _ Iterator = (*rocksDBIterator)(nil)
+
+// rocksDBIterator iterates over the entries of a RocksDB database. The keys and
+// values returned are only valid until the iterator is moved or closed.
+type rocksDBIterator struct {
+	iter *grocksdb.Iterator
+	// readOptions hold the iteration bounds, which must outlive the iterator.
+	readOptions *grocksdb.ReadOptions
+}
+
+func (ri *rocksDBIterator) Valid() bool {
+	return ri.iter.Valid()
+}
+
+func (ri *rocksDBIterator) Next() bool {
+	ri.iter.Next()
+	return ri.iter.Valid()
+}
+
+func (ri *rocksDBIterator) Key() []byte {
+	return ri.iter.Key().Data()
+}
+
+func (ri *rocksDBIterator) Value() []byte {
+	return ri.iter.Value().Data()
+}
+
+func (ri *rocksDBIterator) First() bool {
+	ri.iter.SeekToFirst()
+	return ri.iter.Valid()
+}
+
+func (ri *rocksDBIterator) SeekGE(key []byte) bool {
+	ri.iter.Seek(key)
+	return ri.iter.Valid()
+}
+
+func (ri *rocksDBIterator) Close() error {
+	err := ri.iter.Err()
+	ri.iter.Close()
+	ri.readOptions.Destroy()
+	return err
+}
+
+func (ri *rocksDBIterator) Release() {
+	err := ri.Close()
+	if err != nil {
+		logger.Criticalf("while closing iterator: %s", err)
+	}
+}
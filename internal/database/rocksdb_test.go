@@ -0,0 +1,97 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build rocksdb
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNewRocksDB(t *testing.T) Database {
+	t.Helper()
+
+	db, err := NewRocksDB(t.TempDir())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	return db
+}
+
+func TestRocksDBDatabaseImplementations(t *testing.T) {
+	db := testNewRocksDB(t)
+
+	testPutGetter(t, db)
+	testHasGetter(t, db)
+	testUpdateGetter(t, db)
+	testDelGetter(t, db)
+	testGetPath(t, db)
+}
+
+func TestRocksDBBatch(t *testing.T) {
+	db := testNewRocksDB(t)
+	testBatchPutAndDelete(t, db)
+}
+
+func TestRocksDBIterator(t *testing.T) {
+	db := testNewRocksDB(t)
+	testNextKeyIterator(t, db)
+	testSeekKeyValueIterator(t, db)
+}
+
+func TestRocksDB_NewPrefixIterator(t *testing.T) {
+	db := testNewRocksDB(t)
+
+	for _, key := range []string{"a1", "a2", "a3", "b1", "\xff"} {
+		err := db.Put([]byte(key), []byte("value-"+key))
+		require.NoError(t, err)
+	}
+
+	iter, err := db.NewPrefixIterator([]byte("a"))
+	require.NoError(t, err)
+	defer iter.Release()
+
+	var keys []string
+	for valid := iter.First(); valid; valid = iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	assert.Equal(t, []string{"a1", "a2", "a3"}, keys)
+
+	iter, err = db.NewPrefixIterator([]byte("\xff"))
+	require.NoError(t, err)
+	defer iter.Release()
+
+	require.True(t, iter.First())
+	assert.Equal(t, []byte("value-\xff"), iter.Value())
+	assert.False(t, iter.Next())
+}
+
+func TestRocksDB_Open(t *testing.T) {
+	path := t.TempDir()
+	db, err := Open(RocksDBBackend, path, false)
+	require.NoError(t, err)
+	err = db.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = db.Close()
+	require.NoError(t, err)
+
+	backend, err := DetectBackend(path)
+	require.NoError(t, err)
+	assert.Equal(t, RocksDBBackend, backend)
+
+	db, err = Open(RocksDBBackend, path, false)
+	require.NoError(t, err)
+	defer db.Close()
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
@@ -0,0 +1,257 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSnapshotUnsupported = errors.New("database snapshots not supported")
+	ErrSnapshotExists      = errors.New("database snapshot already exists")
+)
+
+const (
+	// snapshotPrefix is the prefix of the names of the snapshot directories,
+	// followed by the snapshot time in the snapshotTimeFormat.
+	snapshotPrefix     = "snapshot-"
+	snapshotTimeFormat = "20060102T150405Z"
+	// incompleteSnapshotSuffix is the suffix of the directories of the snapshots being written.
+	incompleteSnapshotSuffix = ".tmp"
+)
+
+// Checkpointer is implemented by the databases able to write a consistent copy of themselves.
+type Checkpointer interface {
+	// Checkpoint writes a consistent copy of the database to the directory given, which must not exist.
+	Checkpoint(dir string) error
+}
+
+// Snapshot writes a consistent copy of the database to the directory given, which must not
+// exist, so it can be opened as the database of a node. The databases implementing Checkpointer
+// are checkpointed, which is cheap since their immutable files are hard linked where the file
+// system supports it, and the other databases are copied entry by entry.
+func Snapshot(db Database, dir string) error {
+	backend, err := snapshotBackend(db)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stat(dir)
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrSnapshotExists, dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking snapshot directory: %w", err)
+	}
+
+	checkpointer, ok := db.(Checkpointer)
+	if !ok {
+		return copyToDir(db, dir, backend)
+	}
+
+	err = checkpointer.Checkpoint(dir)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, backendFile), []byte(backend), 0o600)
+	if err != nil {
+		return fmt.Errorf("recording snapshot backend: %w", err)
+	}
+	return nil
+}
+
+// snapshotBackend returns the backend of the database given, returning an error
+// wrapping ErrSnapshotUnsupported if the database is not persisted on disk.
+func snapshotBackend(db Database) (Backend, error) {
+	backend, err := DetectBackend(db.Path())
+	if err != nil {
+		return "", err
+	}
+
+	switch backend {
+	case "", MemoryBackend:
+		return "", fmt.Errorf("%w: %s is not persisted on disk", ErrSnapshotUnsupported, db.Path())
+	}
+	return backend, nil
+}
+
+func copyToDir(db Database, dir string, backend Backend) (err error) {
+	dst, err := Open(backend, dir, false)
+	if err != nil {
+		return fmt.Errorf("opening %s database: %w", backend, err)
+	}
+	defer func() {
+		closeErr := dst.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing %s database: %w", backend, closeErr)
+		}
+	}()
+
+	_, err = Migrate(db, dst)
+	if err != nil {
+		return fmt.Errorf("copying database: %w", err)
+	}
+	return nil
+}
+
+// SnapshotConfig is the configuration of the scheduled snapshots of a database.
+type SnapshotConfig struct {
+	// Dir is the directory the snapshots are written to,
+	// each in a directory named after the snapshot time.
+	Dir string
+	// Interval is the interval between two snapshots.
+	Interval time.Duration
+	// Retained is the number of most recent snapshots retained,
+	// all the snapshots being retained if it is zero.
+	Retained uint32
+}
+
+// Snapshotter snapshots a database at regular intervals, for the node to
+// be recovered quickly from a recent snapshot if the database is lost.
+type Snapshotter struct {
+	db     Database
+	config SnapshotConfig
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSnapshotter creates a new snapshotter of the database given.
+func NewSnapshotter(db Database, config SnapshotConfig) *Snapshotter {
+	return &Snapshotter{
+		db:     db,
+		config: config,
+	}
+}
+
+// Start starts snapshotting the database at each interval, once
+// the incomplete snapshots of a previous run are removed.
+func (s *Snapshotter) Start() error {
+	_, err := snapshotBackend(s.db)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(s.config.Dir, 0o700)
+	if err != nil {
+		return fmt.Errorf("creating snapshot directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(s.config.Dir)
+	if err != nil {
+		return fmt.Errorf("reading snapshot directory: %w", err)
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), snapshotPrefix) &&
+			strings.HasSuffix(entry.Name(), incompleteSnapshotSuffix) {
+			err = os.RemoveAll(filepath.Join(s.config.Dir, entry.Name()))
+			if err != nil {
+				return fmt.Errorf("removing incomplete snapshot: %w", err)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go s.run(ctx)
+
+	logger.Infof("snapshotting database every %s to %s", s.config.Interval, s.config.Dir)
+	return nil
+}
+
+// Stop stops snapshotting the database, waiting for the snapshot being written if any.
+func (s *Snapshotter) Stop() error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+func (s *Snapshotter) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			start := time.Now()
+			path, err := s.snapshot(now)
+			if err != nil {
+				logger.Errorf("snapshotting database: %s", err)
+				continue
+			}
+			logger.Infof("database snapshot written to %s in %s", path, time.Since(start))
+		}
+	}
+}
+
+// snapshot writes the snapshot of the database at the time given, and then removes the
+// oldest snapshots not retained. The snapshot is written to an incomplete snapshot
+// directory first, so an interrupted snapshot is never mistaken for a complete one.
+func (s *Snapshotter) snapshot(now time.Time) (path string, err error) {
+	path = filepath.Join(s.config.Dir, snapshotPrefix+now.UTC().Format(snapshotTimeFormat))
+	incomplete := path + incompleteSnapshotSuffix
+
+	err = Snapshot(s.db, incomplete)
+	if err != nil {
+		_ = os.RemoveAll(incomplete)
+		return "", err
+	}
+
+	err = os.Rename(incomplete, path)
+	if err != nil {
+		return "", fmt.Errorf("completing snapshot: %w", err)
+	}
+
+	err = s.prune()
+	if err != nil {
+		return "", fmt.Errorf("pruning snapshots: %w", err)
+	}
+	return path, nil
+}
+
+// prune removes the oldest complete snapshots beyond the number of snapshots retained.
+func (s *Snapshotter) prune() error {
+	if s.config.Retained == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.config.Dir)
+	if err != nil {
+		return fmt.Errorf("reading snapshot directory: %w", err)
+	}
+
+	var snapshots []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), snapshotPrefix) &&
+			!strings.HasSuffix(entry.Name(), incompleteSnapshotSuffix) {
+			snapshots = append(snapshots, entry.Name())
+		}
+	}
+
+	// the snapshot names sort in the order of their times
+	slices.Sort(snapshots)
+	for len(snapshots) > int(s.config.Retained) {
+		err = os.RemoveAll(filepath.Join(s.config.Dir, snapshots[0]))
+		if err != nil {
+			return fmt.Errorf("removing snapshot %s: %w", snapshots[0], err)
+		}
+		logger.Debugf("removed database snapshot %s", snapshots[0])
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
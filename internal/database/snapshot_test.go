@@ -0,0 +1,117 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copiedDatabase hides the Checkpoint method of the database it embeds.
+type copiedDatabase struct {
+	Database
+}
+
+func Test_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		wrap func(db Database) Database
+	}{
+		"checkpoint": {
+			wrap: func(db Database) Database { return db },
+		},
+		"copy": {
+			wrap: func(db Database) Database { return copiedDatabase{Database: db} },
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := testNewPebble(t)
+			err := db.Put([]byte("key"), []byte("value"))
+			require.NoError(t, err)
+
+			dir := filepath.Join(t.TempDir(), "snapshot")
+			err = Snapshot(testCase.wrap(db), dir)
+			require.NoError(t, err)
+
+			err = Snapshot(testCase.wrap(db), dir)
+			assert.ErrorIs(t, err, ErrSnapshotExists)
+
+			backend, err := DetectBackend(dir)
+			require.NoError(t, err)
+			assert.Equal(t, PebbleBackend, backend)
+
+			snapshot, err := Open(PebbleBackend, dir, false)
+			require.NoError(t, err)
+			value, err := snapshot.Get([]byte("key"))
+			require.NoError(t, err)
+			assert.Equal(t, []byte("value"), value)
+			err = snapshot.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Snapshot_inMemory(t *testing.T) {
+	t.Parallel()
+
+	db, err := NewPebble(filepath.Join(t.TempDir(), "db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	err = Snapshot(db, filepath.Join(t.TempDir(), "snapshot"))
+	assert.ErrorIs(t, err, ErrSnapshotUnsupported)
+}
+
+func Test_Snapshotter(t *testing.T) {
+	t.Parallel()
+
+	db := testNewPebble(t)
+	dir := t.TempDir()
+
+	// an incomplete snapshot of a previous run
+	incomplete := filepath.Join(dir, snapshotPrefix+"20240101T000000Z"+incompleteSnapshotSuffix)
+	err := os.Mkdir(incomplete, 0o700)
+	require.NoError(t, err)
+
+	snapshotter := NewSnapshotter(db, SnapshotConfig{
+		Dir:      dir,
+		Interval: time.Hour,
+		Retained: 2,
+	})
+	err = snapshotter.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := snapshotter.Stop()
+		require.NoError(t, err)
+	})
+	assert.NoDirExists(t, incomplete)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, err := snapshotter.snapshot(start.Add(time.Duration(i) * time.Hour))
+		require.NoError(t, err)
+		paths = append(paths, path)
+	}
+
+	assert.Equal(t, filepath.Join(dir, "snapshot-20240101T000000Z"), paths[0])
+	// the oldest snapshot is not retained
+	assert.NoDirExists(t, paths[0])
+	assert.DirExists(t, paths[1])
+	assert.DirExists(t, paths[2])
+}
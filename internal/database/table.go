@@ -59,3 +59,32 @@ func (t *table) NewBatch() Batch {
 func (t *table) NewIterator() (Iterator, error) {
 	return t.db.NewPrefixIterator(t.prefix)
 }
+
+// NewPrefixIterator returns an iterator over the keys of the table starting with
+// the prefix, the keys iterated and sought being without the table prefix.
+func (t *table) NewPrefixIterator(prefix []byte) (Iterator, error) {
+	tablePrefix := bytes.Join([][]byte{t.prefix, prefix}, nil)
+	iter, err := t.db.NewPrefixIterator(tablePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableIterator{
+		Iterator: iter,
+		prefix:   t.prefix,
+	}, nil
+}
+
+type tableIterator struct {
+	Iterator
+	prefix []byte
+}
+
+func (ti *tableIterator) Key() []byte {
+	return ti.Iterator.Key()[len(ti.prefix):]
+}
+
+func (ti *tableIterator) SeekGE(key []byte) bool {
+	tableItemKey := bytes.Join([][]byte{ti.prefix, key}, nil)
+	return ti.Iterator.SeekGE(tableItemKey)
+}
@@ -0,0 +1,35 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_NewPrefixIterator(t *testing.T) {
+	db := testNewPebble(t)
+	table := NewTable(db, "table")
+
+	for _, key := range []string{"a1", "a2", "a3", "b1"} {
+		err := table.Put([]byte(key), []byte("value-"+key))
+		require.NoError(t, err)
+	}
+	err := db.Put([]byte("a4"), []byte("value-a4"))
+	require.NoError(t, err)
+
+	iter, err := table.NewPrefixIterator([]byte("a"))
+	require.NoError(t, err)
+	defer iter.Release()
+
+	var keys, values []string
+	for valid := iter.SeekGE([]byte("a2")); valid; valid = iter.Next() {
+		keys = append(keys, string(iter.Key()))
+		values = append(values, string(iter.Value()))
+	}
+	assert.Equal(t, []string{"a2", "a3"}, keys)
+	assert.Equal(t, []string{"value-a2", "value-a3"}, values)
+}
@@ -0,0 +1,25 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Clock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	clock := NewClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(6 * time.Second)
+	assert.Equal(t, start.Add(6*time.Second), clock.Now())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(7*time.Second), clock.Now())
+}
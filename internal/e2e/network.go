@@ -0,0 +1,363 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package e2e provides a deterministic test network harness running
+// gossamer nodes in-process, with in-memory databases and a mock clock,
+// connected through libp2p over localhost.
+package e2e
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	westenddev "github.com/ChainSafe/gossamer/chain/westend-dev"
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// defaultTimeout is the time the assertions wait for the nodes to agree.
+	defaultTimeout = time.Minute
+	// pollInterval is the interval the assertions poll the nodes at.
+	pollInterval = 100 * time.Millisecond
+)
+
+// Node is a gossamer node of the test network.
+type Node struct {
+	// Index is the index of the node in the test network.
+	Index int
+	// Config is the configuration the node runs with.
+	Config *cfg.Config
+
+	node     *dot.Node
+	keystore *keystore.GlobalKeystore
+	running  bool
+}
+
+// Network returns the network service of the node.
+func (n *Node) Network() *network.Service {
+	return n.node.ServiceRegistry.Get(&network.Service{}).(*network.Service)
+}
+
+// State returns the state service of the node.
+func (n *Node) State() *state.Service {
+	return n.node.ServiceRegistry.Get(&state.Service{}).(*state.Service)
+}
+
+// Babe returns the BABE service of the node.
+func (n *Node) Babe() *babe.Service {
+	return n.node.ServiceRegistry.Get(&babe.Service{}).(*babe.Service)
+}
+
+// address returns the localhost multiaddress of the node, including its peer ID.
+func (n *Node) address(t *testing.T) string {
+	t.Helper()
+	multiaddrs := n.Network().NetworkState().Multiaddrs
+	require.NotEmpty(t, multiaddrs, "node %d has no listening address", n.Index)
+	return multiaddrs[0].String()
+}
+
+// peerID returns the libp2p peer ID of the node.
+func (n *Node) peerID(t *testing.T) peer.ID {
+	t.Helper()
+	peerID, err := peer.Decode(n.Network().NetworkState().PeerID)
+	require.NoError(t, err)
+	return peerID
+}
+
+// isConnectedTo returns true if the node is connected to the peer given.
+func (n *Node) isConnectedTo(peerID peer.ID) bool {
+	for _, connected := range n.Network().AllConnectedPeersIDs() {
+		if connected == peerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Network is a network of in-process gossamer nodes sharing a mock clock.
+// The first node is the only BABE and GRANDPA authority, authoring blocks
+// on demand only, and every node is connected to every other node.
+type Network struct {
+	// Clock is the mock clock the blocks are authored at.
+	Clock *Clock
+	// Nodes are the nodes of the network, the first one being the authority.
+	Nodes []*Node
+}
+
+// NewNetwork creates and starts a test network of size nodes, all stopped
+// and cleared when the test given completes.
+func NewNetwork(t *testing.T, size int) *Network {
+	t.Helper()
+	require.Greater(t, size, 0, "network size")
+
+	n := &Network{
+		Clock: NewClock(time.Now()),
+		Nodes: make([]*Node, size),
+	}
+
+	genesisPath := utils.GetWestendDevRawGenesisPath(t)
+	for i := range n.Nodes {
+		n.Nodes[i] = newNode(t, i, genesisPath)
+	}
+
+	// registered after the node temporary directories so it runs before they are removed
+	t.Cleanup(n.stop)
+
+	for i := range n.Nodes {
+		n.StartNode(t, i)
+	}
+
+	for i := range n.Nodes {
+		for j := i + 1; j < size; j++ {
+			n.Connect(t, i, j)
+		}
+	}
+
+	n.waitForNodes(t, nil, "connect to each other", func(node *Node) bool {
+		return len(node.Network().Peers()) == size-1
+	})
+
+	return n
+}
+
+// newNode creates the node at the index given, the node at index 0 being
+// the authority of the network.
+func newNode(t *testing.T, index int, genesisPath string) *Node {
+	t.Helper()
+
+	config := westenddev.DefaultConfig()
+	config.Name = fmt.Sprintf("e2e-node-%d", index)
+	config.BasePath = t.TempDir()
+	config.ChainSpec = genesisPath
+	config.LogLevel = "error"
+	config.NoTelemetry = true
+	config.State.DatabaseBackend = string(database.MemoryBackend)
+	config.RPC = &cfg.RPCConfig{}
+	config.Network.ListenAddress = fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", freePort(t))
+	config.Network.PublicIP = "127.0.0.1"
+	config.Network.NoBootstrap = true
+	config.Network.NoMDNS = true
+
+	ks := keystore.NewGlobalKeystore()
+	if index == 0 {
+		config.Core.Role = common.AuthorityRole
+		config.Core.BabeAuthority = true
+		config.Core.GrandpaAuthority = true
+		// instant seal only authors blocks when asked to, at the time of the mock clock
+		config.Core.Dev = true
+
+		sr25519Keyring, err := keystore.NewSr25519Keyring()
+		require.NoError(t, err)
+		err = keystore.LoadKeystore("alice", ks.Babe, sr25519Keyring)
+		require.NoError(t, err)
+		ed25519Keyring, err := keystore.NewEd25519Keyring()
+		require.NoError(t, err)
+		err = keystore.LoadKeystore("alice", ks.Gran, ed25519Keyring)
+		require.NoError(t, err)
+	} else {
+		config.Core.Role = common.FullNodeRole
+		config.Core.BabeAuthority = false
+		config.Core.GrandpaAuthority = false
+	}
+
+	err := dot.InitNode(config)
+	require.NoError(t, err)
+
+	return &Node{
+		Index:    index,
+		Config:   config,
+		keystore: ks,
+	}
+}
+
+// freePort returns a TCP port free on localhost.
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// StartNode starts the node at the index given, from the state it was stopped with.
+func (n *Network) StartNode(t *testing.T, index int) {
+	t.Helper()
+	node := n.Nodes[index]
+	require.False(t, node.running, "node %d is already running", index)
+
+	dotNode, err := dot.NewNode(node.Config, node.keystore)
+	require.NoError(t, err)
+	node.node = dotNode
+
+	if index == 0 {
+		node.Babe().SetClock(n.Clock.Now)
+	}
+
+	dotNode.ServiceRegistry.StartAll()
+	node.running = true
+}
+
+// StopNode stops the node at the index given, keeping its database so it can be restarted.
+func (n *Network) StopNode(t *testing.T, index int) {
+	t.Helper()
+	node := n.Nodes[index]
+	require.True(t, node.running, "node %d is not running", index)
+
+	node.node.ServiceRegistry.StopAll()
+	node.running = false
+}
+
+// stop stops the running nodes and clears their in-memory databases.
+func (n *Network) stop() {
+	for _, node := range n.Nodes {
+		if node == nil {
+			continue
+		}
+
+		if node.running {
+			node.node.ServiceRegistry.StopAll()
+			node.running = false
+		}
+
+		_ = database.ClearDatabase(node.Config.BasePath)
+	}
+}
+
+// Connect connects the running nodes at the indexes given as reserved peers of each other.
+func (n *Network) Connect(t *testing.T, i, j int) {
+	t.Helper()
+	err := n.Nodes[i].Network().AddReservedPeers(n.Nodes[j].address(t))
+	require.NoError(t, err)
+	err = n.Nodes[j].Network().AddReservedPeers(n.Nodes[i].address(t))
+	require.NoError(t, err)
+
+	peerIDI, peerIDJ := n.Nodes[i].peerID(t), n.Nodes[j].peerID(t)
+	require.Eventually(t, func() bool {
+		return n.Nodes[i].isConnectedTo(peerIDJ) && n.Nodes[j].isConnectedTo(peerIDI)
+	}, defaultTimeout, pollInterval, "nodes %d and %d did not connect", i, j)
+}
+
+// Disconnect injects a network fault between the running nodes at the indexes
+// given, making them drop and ban each other.
+func (n *Network) Disconnect(t *testing.T, i, j int) {
+	t.Helper()
+	n.disconnect(t, n.Nodes[i], n.Nodes[j])
+	n.disconnect(t, n.Nodes[j], n.Nodes[i])
+
+	peerIDI, peerIDJ := n.Nodes[i].peerID(t), n.Nodes[j].peerID(t)
+	require.Eventually(t, func() bool {
+		return !n.Nodes[i].isConnectedTo(peerIDJ) && !n.Nodes[j].isConnectedTo(peerIDI)
+	}, defaultTimeout, pollInterval, "nodes %d and %d did not disconnect", i, j)
+}
+
+func (*Network) disconnect(t *testing.T, from, to *Node) {
+	t.Helper()
+	peerID := to.peerID(t)
+	err := from.Network().RemoveReservedPeers(peerID.String())
+	require.NoError(t, err)
+	from.Network().ReportPeer(peerset.ReputationChange{
+		Value:  math.MinInt32,
+		Reason: "e2e fault injection",
+	}, peerID)
+}
+
+// AuthorBlock advances the clock by a slot and authors an empty block on top
+// of the best block of the authority node. It returns the hash of the block.
+func (n *Network) AuthorBlock(t *testing.T) common.Hash {
+	t.Helper()
+	authority := n.Nodes[0]
+
+	slotDuration, err := authority.State().Epoch.GetSlotDuration()
+	require.NoError(t, err)
+	n.Clock.Advance(slotDuration)
+
+	hash, err := authority.Babe().CreateBlock(nil, true, false)
+	require.NoError(t, err)
+	return hash
+}
+
+// AuthorBlocks authors count blocks in a row, returning the hash of the last one.
+func (n *Network) AuthorBlocks(t *testing.T, count int) (last common.Hash) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		last = n.AuthorBlock(t)
+	}
+	return last
+}
+
+// AssertImported waits for the nodes at the indexes given, or all the running
+// nodes if none is given, to import the block with the hash given.
+func (n *Network) AssertImported(t *testing.T, hash common.Hash, indexes ...int) {
+	t.Helper()
+	n.waitForNodes(t, indexes, "import block "+hash.Short(), func(node *Node) bool {
+		has, err := node.State().Block.HasHeader(hash)
+		return err == nil && has
+	})
+}
+
+// AssertFinalised waits for the nodes at the indexes given, or all the running
+// nodes if none is given, to finalise the block with the hash given or one of
+// its descendants.
+func (n *Network) AssertFinalised(t *testing.T, hash common.Hash, indexes ...int) {
+	t.Helper()
+	n.waitForNodes(t, indexes, "finalise block "+hash.Short(), func(node *Node) bool {
+		blockState := node.State().Block
+		header, err := blockState.GetHeader(hash)
+		if err != nil {
+			return false
+		}
+
+		finalised, err := blockState.GetHighestFinalisedHeader()
+		if err != nil || finalised.Number < header.Number {
+			return false
+		}
+
+		finalisedHash, err := blockState.GetHashByNumber(header.Number)
+		return err == nil && finalisedHash == hash
+	})
+}
+
+// waitForNodes waits for the condition given to hold on the nodes at the indexes
+// given, or all the running nodes if none is given, failing the test if it does
+// not within the default timeout.
+func (n *Network) waitForNodes(t *testing.T, indexes []int, description string,
+	condition func(node *Node) bool) {
+	t.Helper()
+
+	nodes := make([]*Node, 0, len(n.Nodes))
+	if len(indexes) == 0 {
+		for _, node := range n.Nodes {
+			if node.running {
+				nodes = append(nodes, node)
+			}
+		}
+	} else {
+		for _, index := range indexes {
+			require.True(t, n.Nodes[index].running, "node %d is not running", index)
+			nodes = append(nodes, n.Nodes[index])
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		for _, node := range nodes {
+			if !condition(node) {
+				return false
+			}
+		}
+		return true
+	}, defaultTimeout, pollInterval, "nodes did not %s", description)
+}
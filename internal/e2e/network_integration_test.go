@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build integration
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork_AuthorAndFinalise(t *testing.T) {
+	network := NewNetwork(t, 3)
+
+	hash := network.AuthorBlocks(t, 3)
+	network.AssertImported(t, hash)
+	network.AssertFinalised(t, hash)
+
+	header, err := network.Nodes[2].State().Block.GetHeader(hash)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), header.Number)
+}
+
+func TestNetwork_Faults(t *testing.T) {
+	network := NewNetwork(t, 3)
+
+	network.Disconnect(t, 0, 2)
+	network.Disconnect(t, 1, 2)
+	network.StopNode(t, 1)
+
+	hash := network.AuthorBlock(t)
+	network.AssertImported(t, hash, 0)
+
+	has, err := network.Nodes[2].State().Block.HasHeader(hash)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	network.StartNode(t, 1)
+	network.Connect(t, 0, 1)
+	next := network.AuthorBlock(t)
+	network.AssertImported(t, next, 0, 1)
+	network.AssertFinalised(t, hash, 0, 1)
+}
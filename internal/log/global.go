@@ -16,6 +16,12 @@ func Patch(options ...Option) {
 	globalLogger.Patch(options...)
 }
 
+// PatchContext patches the loggers created from the global logger
+// having the context value given for the key.
+func PatchContext(key, value string, options ...Option) {
+	globalLogger.PatchContext(key, value, options...)
+}
+
 // Errorf using the global logger, only used in test
 // main runners initialisation error.
 func Errorf(s string, args ...interface{}) {
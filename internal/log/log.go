@@ -4,6 +4,7 @@
 package log
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -24,9 +25,19 @@ func (l *Logger) log(logLevel Level, s string, args ...interface{}) {
 		s = fmt.Sprintf(s, args...)
 	}
 
-	line := time.Now().Format(time.RFC3339) + " " + logLevel.format() + " " + s
-
 	callerString := getCallerString(l.settings.caller)
+
+	line := l.consoleLine(logLevel, s, callerString)
+	if l.settings.format != nil && *l.settings.format == FormatJSON {
+		line = l.jsonLine(logLevel, s, callerString)
+	}
+
+	_, _ = io.WriteString(l.settings.writer, line)
+}
+
+func (l *Logger) consoleLine(logLevel Level, s, callerString string) (line string) {
+	line = time.Now().Format(time.RFC3339) + " " + logLevel.format() + " " + s
+
 	if callerString != "" {
 		line += "\t" + color.HiWhiteString(callerString)
 	}
@@ -41,8 +52,43 @@ func (l *Logger) log(logLevel Level, s string, args ...interface{}) {
 		line += "\t" + strings.Join(keyValues, " ")
 	}
 
-	line += "\n"
-	_, _ = io.WriteString(l.settings.writer, line)
+	return line + "\n"
+}
+
+// jsonLogLine is a log line in the JSON format, with the well-known
+// fields of structured log collectors, such as the ones of zombienet.
+type jsonLogLine struct {
+	Time    string            `json:"time"`
+	Level   string            `json:"level"`
+	Target  string            `json:"target,omitempty"`
+	Message string            `json:"msg"`
+	Caller  string            `json:"caller,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+func (l *Logger) jsonLine(logLevel Level, s, callerString string) (line string) {
+	jsonLine := jsonLogLine{
+		Time:    time.Now().Format(time.RFC3339Nano),
+		Level:   logLevel.String(),
+		Message: s,
+		Caller:  callerString,
+	}
+
+	for _, kvs := range l.settings.context {
+		valuesString := strings.Join(kvs.values, ",")
+		if kvs.key == "pkg" {
+			jsonLine.Target = valuesString
+			continue
+		}
+		if jsonLine.Fields == nil {
+			jsonLine.Fields = make(map[string]string, len(l.settings.context))
+		}
+		jsonLine.Fields[kvs.key] = valuesString
+	}
+
+	// a struct of strings and of a map of strings always encodes
+	encoded, _ := json.Marshal(jsonLine)
+	return string(encoded) + "\n"
 }
 
 // Trace logs with the trce level.
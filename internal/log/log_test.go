@@ -101,6 +101,24 @@ func Test_Logger_log(t *testing.T) {
 			s:           "some words",
 			outputRegex: timePrefixRegex + "TRACE    some words\tkey1=a,b key2=c,d\n$",
 		},
+		"json_format": {
+			logger: &Logger{
+				settings: settings{
+					level:  levelPtr(Trace),
+					format: formatPtr(FormatJSON),
+					caller: newCallerSettings(true, false, false),
+					context: []contextKeyValues{
+						{key: "pkg", values: []string{"sync"}},
+						{key: "key", values: []string{"a", "b"}},
+					},
+				},
+				mutex: new(sync.Mutex),
+			},
+			level: Debug,
+			s:     "some \"words\"",
+			outputRegex: `^{"time":"[^"]+","level":"DEBUG","target":"sync","msg":"some \\"words\\"",` +
+				`"caller":"log_test.go","fields":{"key":"a,b"}}\n$`,
+		},
 	}
 
 	for name, testCase := range testCases {
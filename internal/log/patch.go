@@ -22,3 +22,21 @@ func (l *Logger) patchWithoutLocking(options ...Option) {
 	updatedSettings.mergeWith(newSettings(options))
 	l.settings = updatedSettings
 }
+
+// PatchContext patches the child loggers having the context value
+// given for the key, as well as their own child loggers.
+// This is thread safe.
+func (l *Logger) PatchContext(key, value string, options ...Option) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, child := range l.childs {
+		if !child.settings.hasContext(key, value) {
+			continue
+		}
+		child.patchWithoutLocking(options...)
+		for _, grandChild := range child.childs {
+			grandChild.patchWithoutLocking(options...)
+		}
+	}
+}
@@ -84,3 +84,18 @@ func (s *settings) mergeWith(other settings) {
 		s.context = append(s.context, kvsCopy)
 	}
 }
+
+// hasContext returns true if the settings context has the value for the key.
+func (s *settings) hasContext(key, value string) bool {
+	for _, kvs := range s.context {
+		if kvs.key != key {
+			continue
+		}
+		for _, v := range kvs.values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
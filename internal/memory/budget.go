@@ -0,0 +1,199 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package memory accounts the memory used by the caches and buffers of the node
+// against a memory budget, shrinking them proportionally under memory pressure.
+package memory
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "memory"))
+
+var consumerBytesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "gossamer_memory",
+	Name:      "consumer_bytes",
+	Help:      "estimated number of bytes used by each cache or buffer accounted in the memory budget",
+}, []string{"consumer"})
+
+const (
+	// checkInterval is the interval the memory used by the process is checked at.
+	checkInterval = 5 * time.Second
+	// targetRatio is the ratio of the budget the memory used is brought back
+	// to when it exceeds the budget, to avoid shrinking at every check.
+	targetRatio = 0.9
+)
+
+// Consumer is a cache or buffer whose memory is accounted in the budget.
+type Consumer interface {
+	// MemoryUsage returns the estimated number of bytes used.
+	MemoryUsage() uint64
+	// Shrink evicts entries until the estimated number of bytes used is at most the target given.
+	Shrink(target uint64)
+}
+
+// Budget is the memory budget of the node. Once the memory used by the process
+// exceeds it, every consumer is shrunk by the same proportion of its memory usage
+// to bring the memory used back under the budget.
+// A nil budget accounts nothing and never shrinks its consumers.
+type Budget struct {
+	// limit is the budget in bytes, zero for no budget.
+	limit uint64
+	// memoryUsed returns the number of bytes of memory used by the process.
+	memoryUsed func() uint64
+	interval   time.Duration
+
+	mutex     sync.Mutex
+	consumers map[string]Consumer
+
+	previousLimit int64
+	stop          chan struct{}
+	done          chan struct{}
+}
+
+// NewBudget creates a memory budget of the number of bytes given, zero for no budget.
+func NewBudget(limit uint64) *Budget {
+	return &Budget{
+		limit:      limit,
+		memoryUsed: processMemoryUsed,
+		interval:   checkInterval,
+		consumers:  make(map[string]Consumer),
+	}
+}
+
+// Register accounts the memory of the consumer with the name given in the budget.
+func (b *Budget) Register(name string, consumer Consumer) {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.consumers[name] = consumer
+}
+
+// Usage returns the estimated number of bytes used by each consumer, by consumer name.
+func (b *Budget) Usage() map[string]uint64 {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	usage := make(map[string]uint64, len(b.consumers))
+	for name, consumer := range b.consumers {
+		usage[name] = consumer.MemoryUsage()
+	}
+	return usage
+}
+
+// Start sets the budget as the soft memory limit of the Go runtime, and starts
+// checking the memory used by the process. It does nothing if there is no budget.
+func (b *Budget) Start() error {
+	if b.limit == 0 {
+		return nil
+	}
+
+	if b.limit > math.MaxInt64 {
+		return fmt.Errorf("memory budget %d bytes overflows int64", b.limit)
+	}
+
+	b.previousLimit = debug.SetMemoryLimit(int64(b.limit))
+	b.stop = make(chan struct{})
+	b.done = make(chan struct{})
+	go b.run()
+
+	logger.Infof("memory budget set to %d MiB", b.limit/(1<<20))
+	return nil
+}
+
+// Stop stops checking the memory used by the process and restores the previous
+// soft memory limit of the Go runtime.
+func (b *Budget) Stop() error {
+	if b.limit == 0 || b.stop == nil {
+		return nil
+	}
+
+	close(b.stop)
+	<-b.done
+	debug.SetMemoryLimit(b.previousLimit)
+	return nil
+}
+
+func (b *Budget) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.check()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// check shrinks the consumers proportionally to their memory usage if the memory
+// used by the process exceeds the budget.
+func (b *Budget) check() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	names := make([]string, 0, len(b.consumers))
+	usages := make(map[string]uint64, len(b.consumers))
+	var accounted uint64
+	for name, consumer := range b.consumers {
+		usage := consumer.MemoryUsage()
+		consumerBytesGauge.WithLabelValues(name).Set(float64(usage))
+		names = append(names, name)
+		usages[name] = usage
+		accounted += usage
+	}
+
+	used := b.memoryUsed()
+	if used <= b.limit || accounted == 0 {
+		return
+	}
+
+	excess := used - uint64(targetRatio*float64(b.limit))
+	if excess > accounted {
+		excess = accounted
+	}
+	keepRatio := float64(accounted-excess) / float64(accounted)
+
+	logger.Warnf("memory used %d MiB exceeds the budget of %d MiB, shrinking caches and buffers to %.0f%%",
+		used/(1<<20), b.limit/(1<<20), 100*keepRatio)
+
+	sort.Strings(names)
+	for _, name := range names {
+		target := uint64(keepRatio * float64(usages[name]))
+		b.consumers[name].Shrink(target)
+		logger.Debugf("shrunk %s from %d to %d bytes", name, usages[name], target)
+	}
+}
+
+// processMemoryUsed returns the memory mapped by the Go runtime and not released
+// to the operating system, which is the memory the soft memory limit applies to.
+func processMemoryUsed() uint64 {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/memory/classes/heap/released:bytes"},
+	}
+	metrics.Read(samples)
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConsumer struct {
+	usage  uint64
+	shrunk bool
+}
+
+func (c *testConsumer) MemoryUsage() uint64 { return c.usage }
+
+func (c *testConsumer) Shrink(target uint64) {
+	c.usage = min(c.usage, target)
+	c.shrunk = true
+}
+
+func Test_Budget_check(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		limit          uint64
+		used           uint64
+		usages         map[string]uint64
+		expectedUsages map[string]uint64
+		shrunk         bool
+	}{
+		"under_budget": {
+			limit:          1000,
+			used:           1000,
+			usages:         map[string]uint64{"a": 200, "b": 600},
+			expectedUsages: map[string]uint64{"a": 200, "b": 600},
+		},
+		"shrunk_proportionally": {
+			limit:          1000,
+			used:           1100,
+			usages:         map[string]uint64{"a": 200, "b": 600},
+			expectedUsages: map[string]uint64{"a": 150, "b": 450},
+			shrunk:         true,
+		},
+		"excess_capped_to_accounted": {
+			limit:          1000,
+			used:           5000,
+			usages:         map[string]uint64{"a": 200, "b": 600},
+			expectedUsages: map[string]uint64{"a": 0, "b": 0},
+			shrunk:         true,
+		},
+		"nothing_accounted": {
+			limit:          1000,
+			used:           2000,
+			usages:         map[string]uint64{"a": 0},
+			expectedUsages: map[string]uint64{"a": 0},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			budget := NewBudget(testCase.limit)
+			budget.memoryUsed = func() uint64 { return testCase.used }
+			consumers := make(map[string]*testConsumer, len(testCase.usages))
+			for name, usage := range testCase.usages {
+				consumers[name] = &testConsumer{usage: usage}
+				budget.Register(name, consumers[name])
+			}
+
+			budget.check()
+
+			assert.Equal(t, testCase.expectedUsages, budget.Usage())
+			for _, consumer := range consumers {
+				assert.Equal(t, testCase.shrunk, consumer.shrunk)
+			}
+		})
+	}
+}
+
+func Test_Budget_noBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := NewBudget(0)
+	assert.NoError(t, budget.Start())
+	assert.NoError(t, budget.Stop())
+
+	var nilBudget *Budget
+	nilBudget.Register("a", &testConsumer{})
+	assert.Nil(t, nilBudget.Usage())
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pprof
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"time"
+)
+
+// GCStats are the garbage collection and heap statistics of the process.
+type GCStats struct {
+	NumGC        int64           `json:"numGC"`
+	LastGC       time.Time       `json:"lastGC"`
+	PauseTotal   time.Duration   `json:"pauseTotal"`
+	RecentPauses []time.Duration `json:"recentPauses"`
+	HeapAlloc    uint64          `json:"heapAlloc"`
+	HeapSys      uint64          `json:"heapSys"`
+	HeapObjects  uint64          `json:"heapObjects"`
+	NextGC       uint64          `json:"nextGC"`
+	Goroutines   int             `json:"goroutines"`
+}
+
+// recentPauses is the maximum number of the most recent garbage collection pauses reported.
+const recentPauses = 16
+
+func gcStats(w http.ResponseWriter, _ *http.Request) {
+	var gc debug.GCStats
+	debug.ReadGCStats(&gc)
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	writeJSON(w, GCStats{
+		NumGC:        gc.NumGC,
+		LastGC:       gc.LastGC,
+		PauseTotal:   gc.PauseTotal,
+		RecentPauses: gc.Pause[:min(len(gc.Pause), recentPauses)],
+		HeapAlloc:    memStats.HeapAlloc,
+		HeapSys:      memStats.HeapSys,
+		HeapObjects:  memStats.HeapObjects,
+		NextGC:       memStats.NextGC,
+		Goroutines:   runtime.NumGoroutine(),
+	})
+}
+
+// runtimeMetrics writes the scalar runtime/metrics of the process, by metric name.
+func runtimeMetrics(w http.ResponseWriter, _ *http.Request) {
+	descriptions := metrics.All()
+	samples := make([]metrics.Sample, 0, len(descriptions))
+	for _, description := range descriptions {
+		if description.Kind == metrics.KindFloat64Histogram {
+			continue
+		}
+		samples = append(samples, metrics.Sample{Name: description.Name})
+	}
+	metrics.Read(samples)
+
+	values := make(map[string]any, len(samples))
+	for _, sample := range samples {
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			values[sample.Name] = sample.Value.Uint64()
+		case metrics.KindFloat64:
+			values[sample.Name] = sample.Value.Float64()
+		}
+	}
+	writeJSON(w, values)
+}
+
+// HeapSnapshot is the response of a heap snapshot request.
+type HeapSnapshot struct {
+	Path string `json:"path"`
+}
+
+// heapSnapshot returns a handler writing a heap dump of the process to a new file
+// in the snapshot directory given. The process is paused while the heap is dumped.
+func heapSnapshot(snapshotDir string, logger Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		path, err := writeHeapSnapshot(snapshotDir)
+		if err != nil {
+			logger.Error(fmt.Sprintf("cannot write heap snapshot: %s", err))
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("heap snapshot written to " + path)
+		writeJSON(w, HeapSnapshot{Path: path})
+	})
+}
+
+func writeHeapSnapshot(snapshotDir string) (path string, err error) {
+	const dirPermissions = 0o700
+	err = os.MkdirAll(snapshotDir, dirPermissions)
+	if err != nil {
+		return "", fmt.Errorf("creating snapshot directory: %w", err)
+	}
+
+	path = filepath.Join(snapshotDir, fmt.Sprintf("heap-%d.dump", time.Now().UnixNano()))
+	file, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("creating snapshot file: %w", err)
+	}
+
+	debug.WriteHeapDump(file.Fd())
+
+	err = file.Close()
+	if err != nil {
+		return "", fmt.Errorf("closing snapshot file: %w", err)
+	}
+	return path, nil
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pprof
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_heapSnapshot(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		method string
+		status int
+	}{
+		"method_not_allowed": {
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+		"snapshot_written": {
+			method: http.MethodPost,
+			status: http.StatusOK,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			logger := NewMockLogger(ctrl)
+			snapshotDir := filepath.Join(t.TempDir(), "debug")
+			if testCase.status == http.StatusOK {
+				logger.EXPECT().Info(newRegexMatcher("^heap snapshot written to .+heap-[0-9]+\\.dump$"))
+			}
+
+			request := httptest.NewRequest(testCase.method, "/debug/heap-snapshot", nil)
+			recorder := httptest.NewRecorder()
+
+			heapSnapshot(snapshotDir, logger).ServeHTTP(recorder, request)
+
+			require.Equal(t, testCase.status, recorder.Code)
+			if testCase.status != http.StatusOK {
+				return
+			}
+
+			var snapshot HeapSnapshot
+			err := json.NewDecoder(recorder.Body).Decode(&snapshot)
+			require.NoError(t, err)
+			assert.FileExists(t, snapshot.Path)
+			assert.Equal(t, snapshotDir, filepath.Dir(snapshot.Path))
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package pprof
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"net/http/pprof"
 
@@ -11,8 +12,9 @@
 )
 
 // NewServer creates a new Pprof server which will listen at
-// the address specified.
-func NewServer(address string, logger Logger,
+// the address specified. The requests must be authenticated with
+// the bearer token given, unless it is empty.
+func NewServer(address, authToken, snapshotDir string, logger Logger,
 	options ...httpserver.Option) *httpserver.Server {
 	handler := http.NewServeMux()
 	handler.HandleFunc("/debug/pprof/", pprof.Index)
@@ -24,5 +26,27 @@ func NewServer(address string, logger Logger,
 	handler.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
 	handler.Handle("/debug/pprof/heap", pprof.Handler("heap"))
 	handler.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
-	return httpserver.New("pprof", address, handler, logger, options...)
+	handler.HandleFunc("/debug/gc", gcStats)
+	handler.HandleFunc("/debug/metrics", runtimeMetrics)
+	handler.Handle("/debug/heap-snapshot", heapSnapshot(snapshotDir, logger))
+	return httpserver.New("pprof", address, authenticate(authToken, handler), logger, options...)
+}
+
+// authenticate returns a handler rejecting the requests not authenticated
+// with the bearer token given, or the handler given if the token is empty.
+func authenticate(token string, handler http.Handler) http.Handler {
+	if token == "" {
+		return handler
+	}
+
+	expected := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(authorization, expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
 }
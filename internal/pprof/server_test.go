@@ -7,6 +7,7 @@
 	"context"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -27,7 +28,7 @@ func Test_Server(t *testing.T) {
 	logger.EXPECT().Warn("pprof http server shutting down: context canceled")
 
 	const httpServerShutdownTimeout = 10 * time.Second // 10s in case test worker is slow
-	server := NewServer(address, logger,
+	server := NewServer(address, "", t.TempDir(), logger,
 		httpserver.ShutdownTimeout(httpServerShutdownTimeout))
 	require.NotNil(t, server)
 
@@ -58,6 +59,8 @@ func Test_Server(t *testing.T) {
 		"debug/pprof/goroutine",
 		"debug/pprof/heap",
 		"debug/pprof/threadcreate",
+		"debug/gc",
+		"debug/metrics",
 	}
 
 	type httpResult struct {
@@ -111,3 +114,50 @@ type httpResult struct {
 	cancel()
 	<-done
 }
+
+func Test_authenticate(t *testing.T) {
+	t.Parallel()
+
+	okHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	testCases := map[string]struct {
+		token         string
+		authorization string
+		status        int
+	}{
+		"no_authentication": {
+			status: http.StatusOK,
+		},
+		"authenticated": {
+			token:         "token",
+			authorization: "Bearer token",
+			status:        http.StatusOK,
+		},
+		"missing_token": {
+			token:  "token",
+			status: http.StatusUnauthorized,
+		},
+		"wrong_token": {
+			token:         "token",
+			authorization: "Bearer other",
+			status:        http.StatusUnauthorized,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodGet, "/debug/gc", nil)
+			if testCase.authorization != "" {
+				request.Header.Set("Authorization", testCase.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			authenticate(testCase.token, okHandler).ServeHTTP(recorder, request)
+
+			assert.Equal(t, testCase.status, recorder.Code)
+		})
+	}
+}
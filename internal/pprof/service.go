@@ -21,17 +21,21 @@ type Service struct {
 }
 
 // NewService creates a pprof server service compatible with the
-// dot/service.go interface.
-func NewService(config cfg.PprofConfig, logger Logger) *Service {
+// dot/service.go interface. The requests must be authenticated with
+// the bearer token given, unless it is empty, and the heap snapshots
+// are written to the snapshot directory given.
+func NewService(config cfg.PprofConfig, authToken, snapshotDir string, logger Logger) *Service {
 	settings := Settings{
 		ListeningAddress: config.ListeningAddress,
 		BlockProfileRate: config.BlockProfileRate,
 		MutexProfileRate: config.MutexProfileRate,
+		AuthToken:        authToken,
+		SnapshotDir:      snapshotDir,
 	}
 
 	return &Service{
 		settings: settings,
-		server:   NewServer(settings.ListeningAddress, logger),
+		server:   NewServer(settings.ListeningAddress, authToken, snapshotDir, logger),
 		done:     make(chan error),
 	}
 }
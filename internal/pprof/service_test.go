@@ -21,10 +21,12 @@ func Test_NewService(t *testing.T) {
 	logger := NewMockLogger(ctrl)
 
 	pprofConfig := westenddev.DefaultConfig().Pprof
-	service := NewService(*pprofConfig, logger)
+	service := NewService(*pprofConfig, "token", "snapshots", logger)
 
 	expectedSettings := Settings{
 		ListeningAddress: pprofConfig.ListeningAddress,
+		AuthToken:        "token",
+		SnapshotDir:      "snapshots",
 	}
 	assert.Equal(t, expectedSettings, service.settings)
 	assert.NotNil(t, service.server)
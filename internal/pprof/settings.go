@@ -16,10 +16,19 @@ type Settings struct {
 	// See runtime.SetMutexProfileFraction
 	// Set to 0 to disable profiling.
 	MutexProfileRate int
+	// AuthToken is the bearer token the requests must be
+	// authenticated with, no authentication if empty.
+	AuthToken string
+	// SnapshotDir is the directory the heap snapshots are written to.
+	SnapshotDir string
 }
 
 func (s *Settings) String() string {
+	authentication := "disabled"
+	if s.AuthToken != "" {
+		authentication = "enabled"
+	}
 	return fmt.Sprintf(
-		"listening on %s and setting block profile rate to %d, mutex profile rate to %d",
-		s.ListeningAddress, s.BlockProfileRate, s.MutexProfileRate)
+		"listening on %s and setting block profile rate to %d, mutex profile rate to %d, authentication %s",
+		s.ListeningAddress, s.BlockProfileRate, s.MutexProfileRate, authentication)
 }
@@ -14,7 +14,9 @@ func TestSettings_String(t *testing.T) {
 		ListeningAddress: "localhost:6600",
 		BlockProfileRate: 1,
 		MutexProfileRate: 2,
+		AuthToken:        "token",
 	}
-	expected := "listening on localhost:6600 and setting block profile rate to 1, mutex profile rate to 2"
+	expected := "listening on localhost:6600 and setting block profile rate to 1, " +
+		"mutex profile rate to 2, authentication enabled"
 	assert.Equal(t, expected, settings.String())
 }
@@ -0,0 +1,206 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sysinfo
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+)
+
+const (
+	mebibyte = 1024 * 1024
+	// hashChunkSize is the size of the data hashed at once
+	hashChunkSize = 32 * 1024
+	// verifyMessageSize is the size of the messages signed, as for a hash
+	verifyMessageSize = 32
+	// verifyMessages is the number of messages signed and verified in turn
+	verifyMessages = 64
+	memCopySize    = 64 * mebibyte
+	diskWriteSize  = 64 * mebibyte
+	// diskRndWriteChunkSize is the size of each random write
+	diskRndWriteChunkSize = 4 * 1024
+)
+
+// throughput returns the throughput in MiB/s for the bytes processed in the duration given.
+func throughput(bytes int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		elapsed = time.Nanosecond
+	}
+	return float64(bytes) / mebibyte / elapsed.Seconds()
+}
+
+// benchmarkRepeatedly calls the run function until the duration given has elapsed,
+// at least once, and returns the throughput for the bytes processed at each call.
+func benchmarkRepeatedly(duration time.Duration, bytesPerRun int, run func() error) (float64, error) {
+	var processed int
+	var elapsed time.Duration
+	for elapsed == 0 || elapsed < duration {
+		start := time.Now()
+		err := run()
+		elapsed += time.Since(start)
+		if err != nil {
+			return 0, err
+		}
+		processed += bytesPerRun
+	}
+	return throughput(processed, elapsed), nil
+}
+
+func benchmarkBlake2256(duration time.Duration) (float64, error) {
+	data := make([]byte, hashChunkSize)
+	_, err := rand.Read(data)
+	if err != nil {
+		return 0, fmt.Errorf("generating data: %w", err)
+	}
+
+	return benchmarkRepeatedly(duration, len(data), func() error {
+		_, err := common.Blake2bHash(data)
+		return err
+	})
+}
+
+func benchmarkSr25519Verify(duration time.Duration) (float64, error) {
+	keypair, err := sr25519.GenerateKeypair()
+	if err != nil {
+		return 0, fmt.Errorf("generating keypair: %w", err)
+	}
+	publicKey := keypair.Public()
+
+	messages := make([][]byte, verifyMessages)
+	signatures := make([][]byte, verifyMessages)
+	for i := range messages {
+		messages[i] = make([]byte, verifyMessageSize)
+		_, err = rand.Read(messages[i])
+		if err != nil {
+			return 0, fmt.Errorf("generating message: %w", err)
+		}
+		signatures[i], err = keypair.Sign(messages[i])
+		if err != nil {
+			return 0, fmt.Errorf("signing message: %w", err)
+		}
+	}
+
+	var i int
+	return benchmarkRepeatedly(duration, verifyMessageSize, func() error {
+		ok, err := publicKey.Verify(messages[i], signatures[i])
+		if err != nil {
+			return fmt.Errorf("verifying signature: %w", err)
+		} else if !ok {
+			return fmt.Errorf("invalid signature")
+		}
+		i = (i + 1) % verifyMessages
+		return nil
+	})
+}
+
+func benchmarkMemCopy(size int, duration time.Duration) float64 {
+	source := make([]byte, size)
+	destination := make([]byte, size)
+	// copying cannot fail so the error is always nil
+	result, _ := benchmarkRepeatedly(duration, size, func() error {
+		copy(destination, source)
+		return nil
+	})
+	return result
+}
+
+// benchmarkDiskSeqWrite measures the throughput of writing a file of the size
+// given at once and syncing it to the disk.
+func benchmarkDiskSeqWrite(dir string, size int, duration time.Duration) (float64, error) {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	if err != nil {
+		return 0, fmt.Errorf("generating data: %w", err)
+	}
+
+	file, err := createBenchmarkFile(dir)
+	if err != nil {
+		return 0, err
+	}
+	defer removeBenchmarkFile(file)
+
+	return benchmarkRepeatedly(duration, size, func() error {
+		_, err := file.WriteAt(data, 0)
+		if err != nil {
+			return fmt.Errorf("writing file: %w", err)
+		}
+		return file.Sync()
+	})
+}
+
+// benchmarkDiskRndWrite measures the throughput of writing chunks at random
+// offsets of a file of the size given and syncing it to the disk.
+func benchmarkDiskRndWrite(dir string, size int, duration time.Duration) (float64, error) {
+	chunks := size / diskRndWriteChunkSize
+	if chunks == 0 {
+		return 0, fmt.Errorf("size %d is smaller than the write chunk size %d", size, diskRndWriteChunkSize)
+	}
+
+	data := make([]byte, chunks*diskRndWriteChunkSize)
+	_, err := rand.Read(data)
+	if err != nil {
+		return 0, fmt.Errorf("generating data: %w", err)
+	}
+
+	offsets := make([]int64, chunks)
+	for i := range offsets {
+		chunk, err := rand.Int(rand.Reader, big.NewInt(int64(chunks)))
+		if err != nil {
+			return 0, fmt.Errorf("generating offset: %w", err)
+		}
+		offsets[i] = chunk.Int64() * diskRndWriteChunkSize
+	}
+
+	file, err := createBenchmarkFile(dir)
+	if err != nil {
+		return 0, err
+	}
+	defer removeBenchmarkFile(file)
+
+	// preallocate the file so the random writes do not extend it
+	_, err = file.WriteAt(data, 0)
+	if err != nil {
+		return 0, fmt.Errorf("preallocating file: %w", err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return 0, fmt.Errorf("syncing file: %w", err)
+	}
+
+	return benchmarkRepeatedly(duration, len(data), func() error {
+		for i, offset := range offsets {
+			chunk := data[i*diskRndWriteChunkSize : (i+1)*diskRndWriteChunkSize]
+			_, err := file.WriteAt(chunk, offset)
+			if err != nil {
+				return fmt.Errorf("writing file: %w", err)
+			}
+		}
+		return file.Sync()
+	})
+}
+
+func createBenchmarkFile(dir string) (*os.File, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("creating directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Clean(dir), "disk-benchmark-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating benchmark file: %w", err)
+	}
+	return file, nil
+}
+
+func removeBenchmarkFile(file *os.File) {
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sysinfo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMetricUnknown is returned when benchmarking a metric not known.
+var ErrMetricUnknown = errors.New("metric unknown")
+
+// Metric is a hardware metric measured by the machine benchmark
+type Metric string
+
+const (
+	// Blake2256 is the BLAKE2b-256 hashing throughput, the hasher of the state trie nodes
+	Blake2256 Metric = "BLAKE2-256"
+	// Sr25519Verify is the sr25519 signature verification throughput
+	Sr25519Verify Metric = "SR25519-Verify"
+	// MemCopy is the memory copy bandwidth
+	MemCopy Metric = "Copy"
+	// DiskSeqWrite is the disk sequential write throughput
+	DiskSeqWrite Metric = "Seq Write"
+	// DiskRndWrite is the disk random write throughput
+	DiskRndWrite Metric = "Rnd Write"
+)
+
+// Requirement is the minimum throughput in MiB/s of a metric
+type Requirement struct {
+	Metric  Metric
+	Minimum float64
+}
+
+// ReferenceHardware are the minimum throughputs of the Polkadot reference hardware
+var ReferenceHardware = []Requirement{
+	{Metric: Blake2256, Minimum: 783.27},
+	{Metric: Sr25519Verify, Minimum: 0.547529297},
+	{Metric: MemCopy, Minimum: 11497.87},
+	{Metric: DiskSeqWrite, Minimum: 950},
+	{Metric: DiskRndWrite, Minimum: 420},
+}
+
+// Score is the throughput measured for a metric, compared to its minimum requirement
+type Score struct {
+	Metric Metric
+	// Throughput is the throughput measured in MiB/s
+	Throughput float64
+	Minimum    float64
+}
+
+// Passed returns true if the throughput measured meets the minimum requirement.
+func (s Score) Passed() bool {
+	return s.Throughput >= s.Minimum
+}
+
+func (s Score) String() string {
+	status := "✅ pass"
+	if !s.Passed() {
+		status = "❌ fail"
+	}
+	return fmt.Sprintf("%-15s %10.2f MiB/s (minimum %.2f MiB/s) %s",
+		s.Metric, s.Throughput, s.Minimum, status)
+}
+
+// Benchmark measures each metric of the requirements given for the duration given,
+// writing the disk benchmark files in the directory given, and returns the scores
+// in the order of the requirements.
+func Benchmark(dir string, duration time.Duration, requirements []Requirement) (
+	scores []Score, err error) {
+	scores = make([]Score, len(requirements))
+	for i, requirement := range requirements {
+		var throughput float64
+		switch requirement.Metric {
+		case Blake2256:
+			throughput, err = benchmarkBlake2256(duration)
+		case Sr25519Verify:
+			throughput, err = benchmarkSr25519Verify(duration)
+		case MemCopy:
+			throughput = benchmarkMemCopy(memCopySize, duration)
+		case DiskSeqWrite:
+			throughput, err = benchmarkDiskSeqWrite(dir, diskWriteSize, duration)
+		case DiskRndWrite:
+			throughput, err = benchmarkDiskRndWrite(dir, diskWriteSize, duration)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrMetricUnknown, requirement.Metric)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("benchmarking %s: %w", requirement.Metric, err)
+		}
+
+		scores[i] = Score{
+			Metric:     requirement.Metric,
+			Throughput: throughput,
+			Minimum:    requirement.Minimum,
+		}
+	}
+	return scores, nil
+}
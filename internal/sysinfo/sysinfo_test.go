@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sysinfo
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Benchmark(t *testing.T) {
+	t.Parallel()
+
+	requirements := []Requirement{
+		{Metric: Blake2256, Minimum: 0},
+		{Metric: Sr25519Verify, Minimum: 1e12},
+	}
+
+	scores, err := Benchmark(t.TempDir(), time.Millisecond, requirements)
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+
+	assert.Equal(t, Blake2256, scores[0].Metric)
+	assert.Greater(t, scores[0].Throughput, 0.0)
+	assert.True(t, scores[0].Passed())
+
+	assert.Equal(t, Sr25519Verify, scores[1].Metric)
+	assert.Greater(t, scores[1].Throughput, 0.0)
+	assert.False(t, scores[1].Passed())
+
+	_, err = Benchmark(t.TempDir(), time.Millisecond, []Requirement{{Metric: "unknown"}})
+	assert.ErrorIs(t, err, ErrMetricUnknown)
+}
+
+func Test_Score_String(t *testing.T) {
+	t.Parallel()
+
+	score := Score{Metric: MemCopy, Throughput: 12000, Minimum: 11497.87}
+	assert.Equal(t, "Copy              12000.00 MiB/s (minimum 11497.87 MiB/s) ✅ pass", score.String())
+
+	score.Throughput = 1000
+	assert.Equal(t, "Copy               1000.00 MiB/s (minimum 11497.87 MiB/s) ❌ fail", score.String())
+}
+
+func Test_benchmarkDiskWrites(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	const size = 16 * diskRndWriteChunkSize
+
+	sequential, err := benchmarkDiskSeqWrite(dir, size, time.Millisecond)
+	require.NoError(t, err)
+	assert.Greater(t, sequential, 0.0)
+
+	random, err := benchmarkDiskRndWrite(dir, size, time.Millisecond)
+	require.NoError(t, err)
+	assert.Greater(t, random, 0.0)
+
+	_, err = benchmarkDiskRndWrite(dir, diskRndWriteChunkSize-1, time.Millisecond)
+	assert.EqualError(t, err, "size 4095 is smaller than the write chunk size 4096")
+
+	// benchmark files are removed
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_benchmarkMemCopy(t *testing.T) {
+	t.Parallel()
+
+	assert.Greater(t, benchmarkMemCopy(mebibyte, time.Millisecond), 0.0)
+}
@@ -11,6 +11,8 @@
 	"sync"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/events"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -21,15 +23,31 @@
 
 var logger = log.NewFromGlobal(log.AddContext("pkg", "babe"))
 
+const (
+	// authoringBackoffBias is the number of unfinalised blocks above the maximum
+	// unfinalised depth by which the authoring backoff grows by one slot.
+	authoringBackoffBias = 2
+	// maxAuthoringBackoff is the maximum number of slots the authoring is backed off for.
+	maxAuthoringBackoff = 100
+)
+
 // Service contains the VRF keys for the validator, as well as BABE configuation data
 type Service struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	authority    bool
 	dev          bool
+	instantSeal  bool
 	constants    constants
 	epochHandler *epochHandler
 
+	// maxUnfinalisedDepth is the depth above the finalised block from which
+	// the block authoring is backed off, zero for no backoff.
+	maxUnfinalisedDepth uint
+
+	// inherentDataProviders provide the inherent data of the blocks authored.
+	inherentDataProviders *InherentDataProviders
+
 	// Storage interfaces
 	blockState       BlockState
 	storageState     StorageState
@@ -45,8 +63,21 @@ type Service struct {
 	sync.RWMutex
 	pause chan struct{}
 
-	telemetry Telemetry
-	wg        sync.WaitGroup
+	// sealLock serialises the blocks created by the instant and manual seals
+	sealLock sync.Mutex
+
+	// prewarmed is the parent state and runtime prepared for the next authoring slot
+	prewarmedMutex sync.Mutex
+	prewarmed      *prewarmedParent
+
+	telemetry  Telemetry
+	supervisor *supervisor.Supervisor
+	// eventBus is the bus the instant seal receives the transactions made ready from
+	eventBus *events.Bus
+	wg       sync.WaitGroup
+
+	// timeNow returns the current time the sealed block slots are claimed from.
+	timeNow func() time.Time
 }
 
 // ServiceConfig represents a BABE configuration
@@ -62,6 +93,26 @@ type ServiceConfig struct {
 	IsDev              bool
 	Authority          bool
 	Telemetry          Telemetry
+	// InstantSeal builds blocks as soon as transactions are submitted
+	// instead of authoring blocks in the claimed slots
+	InstantSeal bool
+	// MaxUnfinalisedDepth is the number of unfinalised blocks of the best chain above which
+	// the block authoring is backed off, so the chain does not grow unfinalisable when the
+	// finality stalls. Blocks are then only authored at slot intervals growing with the
+	// number of unfinalised blocks, up to maxAuthoringBackoff slots. Zero for no backoff.
+	MaxUnfinalisedDepth uint
+	// InherentDataProviders provide the inherent data of the blocks authored,
+	// the DefaultInherentDataProviders being used if nil.
+	InherentDataProviders *InherentDataProviders
+}
+
+// inherentDataProviders returns the inherent data providers configured,
+// or the DefaultInherentDataProviders if none is configured.
+func (sc *ServiceConfig) inherentDataProviders() *InherentDataProviders {
+	if sc.InherentDataProviders == nil {
+		return DefaultInherentDataProviders()
+	}
+	return sc.InherentDataProviders
 }
 
 // Validate returns error if config does not contain required attributes
@@ -92,22 +143,26 @@ func (Builder) NewServiceIFace(cfg *ServiceConfig) (service *Service, err error)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	babeService := &Service{
-		ctx:                ctx,
-		cancel:             cancel,
-		blockState:         cfg.BlockState,
-		storageState:       cfg.StorageState,
-		epochState:         cfg.EpochState,
-		keypair:            cfg.Keypair,
-		transactionState:   cfg.TransactionState,
-		pause:              make(chan struct{}),
-		authority:          cfg.Authority,
-		dev:                cfg.IsDev,
-		blockImportHandler: cfg.BlockImportHandler,
+		ctx:                   ctx,
+		cancel:                cancel,
+		blockState:            cfg.BlockState,
+		storageState:          cfg.StorageState,
+		epochState:            cfg.EpochState,
+		keypair:               cfg.Keypair,
+		transactionState:      cfg.TransactionState,
+		pause:                 make(chan struct{}),
+		authority:             cfg.Authority,
+		dev:                   cfg.IsDev,
+		instantSeal:           cfg.InstantSeal,
+		maxUnfinalisedDepth:   cfg.MaxUnfinalisedDepth,
+		inherentDataProviders: cfg.inherentDataProviders(),
+		blockImportHandler:    cfg.BlockImportHandler,
 		constants: constants{
 			slotDuration: slotDuration,
 			epochLength:  cfg.EpochState.GetEpochLength(),
 		},
 		telemetry: cfg.Telemetry,
+		timeNow:   time.Now,
 	}
 
 	logger.Debugf(
@@ -134,22 +189,26 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	babeService := &Service{
-		ctx:                ctx,
-		cancel:             cancel,
-		blockState:         cfg.BlockState,
-		storageState:       cfg.StorageState,
-		epochState:         cfg.EpochState,
-		keypair:            cfg.Keypair,
-		transactionState:   cfg.TransactionState,
-		pause:              make(chan struct{}),
-		authority:          cfg.Authority,
-		dev:                cfg.IsDev,
-		blockImportHandler: cfg.BlockImportHandler,
+		ctx:                   ctx,
+		cancel:                cancel,
+		blockState:            cfg.BlockState,
+		storageState:          cfg.StorageState,
+		epochState:            cfg.EpochState,
+		keypair:               cfg.Keypair,
+		transactionState:      cfg.TransactionState,
+		pause:                 make(chan struct{}),
+		authority:             cfg.Authority,
+		dev:                   cfg.IsDev,
+		instantSeal:           cfg.InstantSeal,
+		maxUnfinalisedDepth:   cfg.MaxUnfinalisedDepth,
+		inherentDataProviders: cfg.inherentDataProviders(),
+		blockImportHandler:    cfg.BlockImportHandler,
 		constants: constants{
 			slotDuration: slotDuration,
 			epochLength:  cfg.EpochState.GetEpochLength(),
 		},
 		telemetry: cfg.Telemetry,
+		timeNow:   time.Now,
 	}
 
 	logger.Debugf(
@@ -166,11 +225,7 @@ func (b *Service) Start() error {
 		return nil
 	}
 
-	b.wg.Add(1)
-	go func() {
-		b.initiate()
-		b.wg.Done()
-	}()
+	b.runSupervised()
 	return nil
 }
 
@@ -184,6 +239,12 @@ func (b *Service) EpochLength() uint64 {
 	return b.constants.epochLength
 }
 
+// InherentDataProviders returns the registry of the inherent data providers of the
+// blocks authored, on which the providers of custom inherents can be registered.
+func (b *Service) InherentDataProviders() *InherentDataProviders {
+	return b.inherentDataProviders
+}
+
 // Pause pauses the service ie. halts block production
 func (b *Service) Pause() error {
 	b.Lock()
@@ -207,11 +268,7 @@ func (b *Service) Resume() error {
 	}
 
 	b.pause = make(chan struct{})
-	b.wg.Add(1)
-	go func() {
-		b.initiate()
-		b.wg.Done()
-	}()
+	b.runSupervised()
 	logger.Debug("service resumed")
 	return nil
 }
@@ -273,13 +330,51 @@ func (b *Service) getAuthorityIndex(Authorities []types.AuthorityRaw) (uint32, e
 	return 0, fmt.Errorf("key not in BABE authority data")
 }
 
-func (b *Service) initiate() {
-	// we should consider better error handling for this - we should
-	// retry to run the engine at some point (maybe the next epoch) if
-	// there's an error.
-	if err := b.runEngine(); err != nil {
-		logger.Criticalf("failed to run block production engine: %s", err)
+// SetSupervisor sets the supervisor restarting the block production when it fails,
+// before the service is started.
+func (b *Service) SetSupervisor(sv *supervisor.Supervisor) {
+	b.supervisor = sv
+}
+
+// SetEventBus sets the event bus the instant seal is notified of the transactions
+// made ready on, before the service is started.
+func (b *Service) SetEventBus(bus *events.Bus) {
+	b.eventBus = bus
+}
+
+// runSupervised runs the block production in a goroutine, restarted by the
+// supervisor when it fails until the service is paused or stopped.
+func (b *Service) runSupervised() {
+	pause := b.pause
+	stop := make(chan struct{})
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		select {
+		case <-b.ctx.Done():
+		case <-pause:
+		}
+		close(stop)
+	}()
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.supervisor.Run("babe", supervisor.DefaultPolicy(), stop, b.initiate)
+	}()
+}
+
+func (b *Service) initiate() error {
+	if b.instantSeal {
+		b.runInstantSeal()
+		return nil
+	}
+
+	err := b.runEngine()
+	if err != nil {
+		return fmt.Errorf("running block production engine: %w", err)
 	}
+	return nil
 }
 
 func (b *Service) initiateAndGetEpochHandler(epoch uint64) (*epochHandler, error) {
@@ -295,11 +390,14 @@ func (b *Service) initiateAndGetEpochHandler(epoch uint64) (*epochHandler, error
 		epochDescriptor,
 		b.constants,
 		b.handleSlot,
+		b.prepareSlot,
 		b.keypair,
 	)
 }
 
 func (b *Service) runEngine() error {
+	defer isAuthorityGauge.Set(0)
+
 	epoch, err := b.epochState.GetCurrentEpoch()
 	if err != nil {
 		return fmt.Errorf("failed to get current epoch: %s", err)
@@ -330,6 +428,8 @@ func (b *Service) handleEpoch(epoch uint64) (next uint64, err error) {
 	if err != nil {
 		return 0, fmt.Errorf("cannot initiate and get epoch handler: %w", err)
 	}
+	epochGauge.Set(float64(epoch))
+	isAuthorityGauge.Set(1)
 
 	nextEpochStarts := b.epochHandler.descriptor.endSlot
 	nextEpochStartTime := getSlotStartTime(nextEpochStarts, b.constants.slotDuration)
@@ -380,6 +480,11 @@ func (b *Service) getParentForBlockAuthoring(slotNum uint64) (*types.Header, err
 		return nil, errNilParentHeader
 	}
 
+	err = b.checkUnfinalisedDepth(parentHeader, slotNum)
+	if err != nil {
+		return nil, err
+	}
+
 	atGenesisBlock := b.blockState.GenesisHash() == parentHeader.Hash()
 	if !atGenesisBlock {
 		bestBlockSlotNum, err := b.blockState.GetSlotForBlock(parentHeader.Hash())
@@ -415,6 +520,43 @@ func (b *Service) getParentForBlockAuthoring(slotNum uint64) (*types.Header, err
 	return parent, nil
 }
 
+// checkUnfinalisedDepth returns errUnfinalisedDepthReached if the block authoring on the
+// best block header given is backed off at the slot given. As in Substrate, once the best
+// block is more than the maximum unfinalised depth above the finalised block, blocks are
+// only authored once a number of slots passed since the slot of the best block. This number
+// grows by one slot every authoringBackoffBias unfinalised blocks, up to maxAuthoringBackoff,
+// so the chain keeps growing slowly while the finality catches up.
+func (b *Service) checkUnfinalisedDepth(bestHeader *types.Header, slotNum uint64) error {
+	if b.maxUnfinalisedDepth == 0 {
+		return nil
+	}
+
+	finalisedHeader, err := b.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if bestHeader.Number <= finalisedHeader.Number+b.maxUnfinalisedDepth {
+		return nil
+	}
+
+	depth := bestHeader.Number - finalisedHeader.Number
+	interval := uint64(min((depth-b.maxUnfinalisedDepth)/authoringBackoffBias, maxAuthoringBackoff))
+	bestBlockSlot, err := b.blockState.GetSlotForBlock(bestHeader.Hash())
+	if err != nil {
+		return fmt.Errorf("getting slot for best block: %w", err)
+	}
+
+	if slotNum > bestBlockSlot+interval {
+		return nil
+	}
+
+	return fmt.Errorf("%w: best block #%d is %d blocks above the finalised block #%d, "+
+		"authoring after slot %d",
+		errUnfinalisedDepthReached, bestHeader.Number, depth,
+		finalisedHeader.Number, bestBlockSlot+interval)
+}
+
 func (b *Service) handleSlot(epoch uint64, slot Slot,
 	authorityIndex uint32,
 	preRuntimeDigest *types.PreRuntimeDigest,
@@ -423,27 +565,34 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 	if err != nil {
 		return fmt.Errorf("could not get parent for claiming slot %d: %w", slot.number, err)
 	}
+
+	// the block authoring is aborted at the slot authoring deadline, and the block
+	// is sealed with the extrinsics applied so far so the slot is not missed.
+	ctx, cancel := context.WithDeadline(b.ctx, slot.authoringDeadline())
+	defer cancel()
+
+	_, err = b.produceBlock(ctx, epoch, slot, parent, authorityIndex, preRuntimeDigest)
+	return err
+}
+
+// produceBlock builds the block of the slot on top of the parent and imports it.
+// No more extrinsics are applied to the block once the context is done.
+func (b *Service) produceBlock(ctx context.Context, epoch uint64, slot Slot, parent *types.Header,
+	authorityIndex uint32, preRuntimeDigest *types.PreRuntimeDigest) (*types.Block, error) {
 	b.storageState.Lock()
 	defer b.storageState.Unlock()
 
 	// set runtime trie before building block
 	// if block building is successful, store the resulting trie in the storage state
-	ts, err := b.storageState.TrieState(&parent.StateRoot)
-	if err != nil || ts == nil {
-		logger.Errorf("failed to get parent trie with parent state root %s: %s", parent.StateRoot, err)
-		return err
-	}
-
-	rt, err := b.blockState.GetRuntime(parent.Hash())
+	ts, rt, err := b.parentStateAndRuntime(slot.number, parent)
 	if err != nil {
-		return err
+		logger.Errorf("failed to get parent state and runtime: %s", err)
+		return nil, err
 	}
 
-	rt.SetContextStorage(ts)
-
-	block, err := b.buildBlock(parent, slot, rt, authorityIndex, preRuntimeDigest)
+	block, err := b.buildBlock(ctx, parent, slot, rt, authorityIndex, preRuntimeDigest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Infof(
@@ -462,10 +611,10 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 
 	if err := b.blockImportHandler.HandleBlockProduced(block, ts); err != nil {
 		logger.Warnf("failed to import built block: %s", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return block, nil
 }
 
 func getCurrentSlot(slotDuration time.Duration) uint64 {
@@ -6,6 +6,7 @@
 package babe
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -180,9 +181,10 @@ func TestService_HandleSlotWithLaggingSlot(t *testing.T) {
 		babeService.blockState,
 		authorityIndex,
 		preRuntimeDigest,
+		babeService.inherentDataProviders,
 	)
 
-	block, err := builder.buildBlock(&genesisHeader, slot, rt)
+	block, err := builder.buildBlock(context.Background(), &genesisHeader, slot, rt)
 	require.NoError(t, err)
 
 	fmt.Println(epochDescriptor.startSlot)
@@ -253,9 +255,10 @@ func TestService_HandleSlotWithSameSlot(t *testing.T) {
 		babeService.blockState,
 		authorityIndex,
 		preRuntimeDigest,
+		babeService.inherentDataProviders,
 	)
 
-	block, err := builder.buildBlock(&genesisHeader, slot, runtime)
+	block, err := builder.buildBlock(context.Background(), &genesisHeader, slot, runtime)
 	require.NoError(t, err)
 
 	// Create new non authority service
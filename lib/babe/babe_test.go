@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_checkUnfinalisedDepth(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	bestHeader := &types.Header{Number: 20}
+
+	testCases := map[string]struct {
+		maxUnfinalisedDepth uint
+		slot                uint64
+		blockStateBuilder   func(ctrl *gomock.Controller) BlockState
+		errWrapped          error
+		errMessage          string
+	}{
+		"no_limit": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState { return nil },
+		},
+		"finalised_header_error": {
+			maxUnfinalisedDepth: 5,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(nil, errTest)
+				return blockState
+			},
+			errWrapped: errTest,
+			errMessage: "getting highest finalised header: test error",
+		},
+		"best_block_within_depth": {
+			maxUnfinalisedDepth: 11,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 10}, nil)
+				return blockState
+			},
+		},
+		"best_block_at_depth": {
+			maxUnfinalisedDepth: 10,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 10}, nil)
+				return blockState
+			},
+		},
+		"best_block_slot_error": {
+			maxUnfinalisedDepth: 4,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 10}, nil)
+				blockState.EXPECT().GetSlotForBlock(bestHeader.Hash()).Return(uint64(0), errTest)
+				return blockState
+			},
+			errWrapped: errTest,
+			errMessage: "getting slot for best block: test error",
+		},
+		"best_block_too_deep_backed_off": {
+			maxUnfinalisedDepth: 4,
+			slot:                103,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 10}, nil)
+				blockState.EXPECT().GetSlotForBlock(bestHeader.Hash()).Return(uint64(100), nil)
+				return blockState
+			},
+			errWrapped: errUnfinalisedDepthReached,
+			errMessage: "maximum unfinalised depth reached: " +
+				"best block #20 is 10 blocks above the finalised block #10, authoring after slot 103",
+		},
+		"best_block_too_deep_authored_after_backoff": {
+			maxUnfinalisedDepth: 4,
+			slot:                104,
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 10}, nil)
+				blockState.EXPECT().GetSlotForBlock(bestHeader.Hash()).Return(uint64(100), nil)
+				return blockState
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := &Service{
+				blockState:          testCase.blockStateBuilder(ctrl),
+				maxUnfinalisedDepth: testCase.maxUnfinalisedDepth,
+			}
+
+			err := service.checkUnfinalisedDepth(bestHeader, testCase.slot)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
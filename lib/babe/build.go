@@ -5,12 +5,12 @@
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/lib/babe/inherents"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/transaction"
@@ -24,7 +24,7 @@
 )
 
 // construct a block for this slot with the given parent
-func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
+func (b *Service) buildBlock(ctx context.Context, parent *types.Header, slot Slot, rt Runtime,
 	authorityIndex uint32, preRuntimeDigest *types.PreRuntimeDigest) (*types.Block, error) {
 	builder := NewBlockBuilder(
 		b.keypair,
@@ -32,13 +32,14 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
 		b.blockState,
 		authorityIndex,
 		preRuntimeDigest,
+		b.inherentDataProviders,
 	)
 
 	// is necessary to enable ethmetrics to be possible register values
 	ethmetrics.Enabled = true
 
 	start := time.Now()
-	block, err := builder.buildBlock(parent, slot, rt)
+	block, err := builder.buildBlock(ctx, parent, slot, rt)
 	if err != nil {
 		builderErrors := ethmetrics.GetOrRegisterCounter(buildBlockErrors, nil)
 		builderErrors.Inc(1)
@@ -50,22 +51,27 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
 	return block, nil
 }
 
-// BlockBuilder builds blocks.
+// BlockBuilder builds blocks, calling the runtime to initialise the block, to create and
+// apply its inherent extrinsics, to apply the extrinsics of the transaction queue and to
+// finalise the block, in this order.
 type BlockBuilder struct {
 	keypair               *sr25519.Keypair
 	transactionState      TransactionState
 	blockState            BlockState
 	currentAuthorityIndex uint32
 	preRuntimeDigest      *types.PreRuntimeDigest
+	inherentDataProviders *InherentDataProviders
 }
 
-// NewBlockBuilder creates a new block builder.
+// NewBlockBuilder creates a new block builder. The inherent data of the blocks built is
+// provided by the providers registered in the inherent data providers given.
 func NewBlockBuilder(
 	kp *sr25519.Keypair,
 	ts TransactionState,
 	bs BlockState,
 	authidx uint32,
 	preRuntimeDigest *types.PreRuntimeDigest,
+	inherentDataProviders *InherentDataProviders,
 ) *BlockBuilder {
 	return &BlockBuilder{
 		keypair:               kp,
@@ -73,23 +79,15 @@ func NewBlockBuilder(
 		blockState:            bs,
 		currentAuthorityIndex: authidx,
 		preRuntimeDigest:      preRuntimeDigest,
+		inherentDataProviders: inherentDataProviders,
 	}
 }
 
-func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (*types.Block, error) {
+func (b *BlockBuilder) buildBlock(ctx context.Context, parent *types.Header, slot Slot, rt Runtime) (
+	*types.Block, error) {
 	logger.Tracef("build block with parent %s and slot: %s", parent, slot)
 
-	// create new block header
-	number := parent.Number + 1
-	digest := types.NewDigest()
-	err := digest.Add(*b.preRuntimeDigest)
-	if err != nil {
-		return nil, err
-	}
-	header := types.NewHeader(parent.Hash(), common.Hash{}, common.Hash{}, number, digest)
-
-	// initialise block header
-	err = rt.InitializeBlock(header)
+	err := b.initialiseBlock(parent, rt)
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +95,7 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	logger.Trace("initialised block")
 
 	// add block inherents
-	inherents, err := buildBlockInherents(slot, rt, parent)
+	inherents, err := buildBlockInherents(slot, rt, parent, b.inherentDataProviders)
 	if err != nil {
 		return nil, fmt.Errorf("cannot build inherents: %s", err)
 	}
@@ -105,12 +103,17 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	logger.Tracef("built block encoded inherents: %v", inherents)
 
 	// add block extrinsics
-	included := b.buildBlockExtrinsics(slot, rt)
+	included := b.buildBlockExtrinsics(ctx, slot, rt)
 
 	logger.Trace("built block extrinsics")
 
+	if errors.Is(ctx.Err(), context.Canceled) {
+		b.addToQueue(included)
+		return nil, fmt.Errorf("building block: %w", ctx.Err())
+	}
+
 	// finalise block
-	header, err = rt.FinalizeBlock()
+	header, err := rt.FinalizeBlock()
 	if err != nil {
 		b.addToQueue(included)
 		return nil, fmt.Errorf("cannot finalise block: %s", err)
@@ -144,6 +147,22 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	return block, nil
 }
 
+// initialiseBlock initialises in the runtime the block built on top of the parent given.
+func (b *BlockBuilder) initialiseBlock(parent *types.Header, rt Runtime) error {
+	digest := types.NewDigest()
+	err := digest.Add(*b.preRuntimeDigest)
+	if err != nil {
+		return fmt.Errorf("adding pre-runtime digest: %w", err)
+	}
+	header := types.NewHeader(parent.Hash(), common.Hash{}, common.Hash{}, parent.Number+1, digest)
+
+	err = rt.InitializeBlock(header)
+	if err != nil {
+		return fmt.Errorf("initialising block: %w", err)
+	}
+	return nil
+}
+
 // buildBlockSeal creates the seal for the block header.
 // the seal consists of the ConsensusEngineID and a signature of the encoded block header.
 func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest, error) {
@@ -169,24 +188,44 @@ func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest,
 }
 
 // buildBlockExtrinsics applies extrinsics to the block. it returns an array of included extrinsics.
-// for each extrinsic in queue, add it to the block, until the slot ends or the block is full.
-// if any extrinsic fails, it returns an empty array and an error.
-func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*transaction.ValidTransaction {
-	var included []*transaction.ValidTransaction
-
-	slotEnd := slot.start.Add(slot.duration * 2 / 3) // reserve last 1/3 of slot for block finalisation
-	timeout := slotEnd.Sub(slot.start)               // timeout relative to the slot start
+// for each extrinsic in queue, add it to the block, until the slot ends, the context is done or
+// the block is full. operational and mandatory extrinsics are popped first, and once normal
+// extrinsics exhaust the block resources, only operational and mandatory extrinsics are applied
+// in the block space reserved to them. extrinsics which may be valid in a later block are pushed
+// back to the queue.
+func (b *BlockBuilder) buildBlockExtrinsics(ctx context.Context, slot Slot,
+	rt ExtrinsicHandler) []*transaction.ValidTransaction {
+	var included, deferred []*transaction.ValidTransaction
+
+	timeout := slot.duration * 2 / 3 // reserve last 1/3 of slot for block finalisation
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		timeout = min(timeout, time.Until(deadline))
+	}
 	slotTimer := time.NewTimer(timeout)
+	defer slotTimer.Stop()
 
+	normalExhausted := false
 	for {
+		if ctx.Err() != nil {
+			logger.Debugf("stopped applying extrinsics after %d extrinsics: %s", len(included), ctx.Err())
+			break
+		}
+
 		txn := b.transactionState.PopWithTimer(slotTimer.C)
 		slotTimerExpired := txn == nil
 		if slotTimerExpired {
 			break
 		}
 
+		if normalExhausted && txn.DispatchClass() == transaction.Normal {
+			// operational extrinsics are popped first, so none is left to apply
+			deferred = append(deferred, txn)
+			break
+		}
+
 		extrinsic := txn.Extrinsic
-		logger.Tracef("build block, applying extrinsic %s", extrinsic)
+		logger.Tracef("build block, applying %s extrinsic %s", txn.DispatchClass(), extrinsic)
 
 		ret, err := rt.ApplyExtrinsic(extrinsic)
 		if err != nil {
@@ -198,24 +237,28 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 		if err != nil {
 			logger.Warnf("error when applying extrinsic %s: %s", extrinsic, err)
 
-			// Failure of the module call dispatching doesn't invalidate the extrinsic.
-			// It is included in the block.
-			if _, ok := err.(*DispatchOutcomeError); !ok {
-				continue
-			}
-
 			// don't drop transactions that may be valid in a later block ie.
 			// run out of gas for this block or have a nonce that may be valid in a later block
 			var e *TransactionValidityError
-			if !errors.As(err, &e) {
+			if errors.As(err, &e) {
+				if errors.Is(e.msg, errExhaustsResources) || errors.Is(e.msg, errInvalidTransaction) {
+					deferred = append(deferred, txn)
+				}
+
+				if errors.Is(e.msg, errExhaustsResources) {
+					if txn.DispatchClass() != transaction.Normal {
+						// the block space reserved to operational extrinsics is full
+						break
+					}
+					normalExhausted = true
+				}
 				continue
 			}
 
-			if errors.Is(e.msg, errExhaustsResources) || errors.Is(e.msg, errInvalidTransaction) {
-				hash, err := b.transactionState.Push(txn)
-				if err != nil {
-					logger.Debugf("failed to re-add transaction with hash %s to queue: %s", hash, err)
-				}
+			// Failure of the module call dispatching doesn't invalidate the extrinsic.
+			// It is included in the block.
+			if _, ok := err.(*DispatchOutcomeError); !ok {
+				continue
 			}
 		}
 
@@ -223,39 +266,19 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 		included = append(included, txn)
 	}
 
+	b.addToQueue(deferred)
 	return included
 }
 
-func buildBlockInherents(slot Slot, rt ExtrinsicHandler, parent *types.Header) ([][]byte, error) {
-	// Setup inherents: add timstap0
-	idata := types.NewInherentData()
-	err := idata.SetInherent(types.Timstap0, uint64(slot.start.UnixMilli()))
+// buildBlockInherents creates the inherent extrinsics of the block from the inherent data
+// of the providers given, and applies them. It returns the inherent extrinsics applied.
+func buildBlockInherents(slot Slot, rt ExtrinsicHandler, parent *types.Header,
+	providers *InherentDataProviders) ([][]byte, error) {
+	idata, err := providers.InherentData(slot, parent)
 	if err != nil {
 		return nil, err
 	}
 
-	// add babeslot
-	err = idata.SetInherent(types.Babeslot, slot.number)
-	if err != nil {
-		return nil, err
-	}
-
-	parachainInherent := inherents.ParachainInherentData{
-		ParentHeader: *parent,
-	}
-
-	// add parachn0 and newheads
-	// for now we can use "empty" values, as we require parachain-specific
-	// logic to actually provide the data.
-
-	if err = idata.SetInherent(types.Parachn0, parachainInherent); err != nil {
-		return nil, fmt.Errorf("setting inherent %q: %w", types.Parachn0, err)
-	}
-
-	if err = idata.SetInherent(types.Newheads, []byte{0}); err != nil {
-		return nil, fmt.Errorf("setting inherent %q: %w", types.Newheads, err)
-	}
-
 	ienc, err := idata.Encode()
 	if err != nil {
 		return nil, err
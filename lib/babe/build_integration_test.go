@@ -123,6 +123,7 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 		babeService.blockState,
 		authorityIndex,
 		preRuntimeDigest,
+		babeService.inherentDataProviders,
 	)
 
 	parentHeader := emptyHeader
@@ -135,7 +136,7 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 	err = rt.InitializeBlock(header)
 	require.NoError(t, err)
 
-	_, err = buildBlockInherents(slot, rt, parentHeader)
+	_, err = buildBlockInherents(slot, rt, parentHeader, DefaultInherentDataProviders())
 	require.NoError(t, err)
 
 	ext := runtime.NewTestExtrinsic(t, rt, emptyHash, parentHeader.Hash(), 0, signature.TestKeyringPairAlice,
@@ -172,7 +173,7 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 	err = rt.InitializeBlock(header2)
 	require.NoError(t, err)
 
-	_, err = buildBlockInherents(slot2, rt, header1)
+	_, err = buildBlockInherents(slot2, rt, header1, DefaultInherentDataProviders())
 	require.NoError(t, err)
 
 	res, err := rt.ApplyExtrinsic(common.MustHexToBytes(ext2))
@@ -0,0 +1,160 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_BlockBuilder_buildBlockExtrinsics(t *testing.T) {
+	t.Parallel()
+
+	newTransaction := func(ext byte, class transaction.DispatchClass) *transaction.ValidTransaction {
+		return &transaction.ValidTransaction{
+			Extrinsic: types.Extrinsic{ext},
+			Validity:  &transaction.Validity{},
+			Class:     class,
+		}
+	}
+	operational := newTransaction(1, transaction.Operational)
+	normal := newTransaction(2, transaction.Normal)
+	otherNormal := newTransaction(3, transaction.Normal)
+
+	applied := []byte{0, 0}
+	badOrigin := []byte{0, 1, 2}
+	invalidPayment := []byte{1, 0, 1}
+	exhaustsResources := []byte{1, 0, 6}
+
+	type appliedTransaction struct {
+		tx     *transaction.ValidTransaction
+		result []byte
+	}
+
+	testCases := map[string]struct {
+		popped          []appliedTransaction
+		slotEnds        bool
+		deadlineReached bool
+		pushed          []*transaction.ValidTransaction
+		included        []*transaction.ValidTransaction
+	}{
+		"applied_until_slot_end": {
+			popped: []appliedTransaction{
+				{tx: operational, result: applied},
+				{tx: normal, result: badOrigin},
+				{tx: otherNormal, result: invalidPayment},
+			},
+			slotEnds: true,
+			included: []*transaction.ValidTransaction{operational, normal},
+		},
+		"normal_exhausts_resources": {
+			popped: []appliedTransaction{
+				{tx: normal, result: exhaustsResources},
+				{tx: operational, result: applied},
+				{tx: otherNormal},
+			},
+			pushed:   []*transaction.ValidTransaction{normal, otherNormal},
+			included: []*transaction.ValidTransaction{operational},
+		},
+		"operational_exhausts_resources": {
+			popped: []appliedTransaction{
+				{tx: normal, result: applied},
+				{tx: operational, result: exhaustsResources},
+			},
+			pushed:   []*transaction.ValidTransaction{operational},
+			included: []*transaction.ValidTransaction{normal},
+		},
+		"authoring_deadline_reached": {
+			deadlineReached: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			transactionState := NewMockTransactionState(ctrl)
+			extrinsicHandler := NewMockExtrinsicHandler(ctrl)
+			for _, popped := range testCase.popped {
+				transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(popped.tx)
+				if popped.result != nil {
+					extrinsicHandler.EXPECT().ApplyExtrinsic(popped.tx.Extrinsic).Return(popped.result, nil)
+				}
+			}
+			if testCase.slotEnds {
+				transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(nil)
+			}
+			for _, tx := range testCase.pushed {
+				transactionState.EXPECT().Push(tx).Return(common.Hash{}, nil)
+			}
+
+			builder := &BlockBuilder{transactionState: transactionState}
+			slot := Slot{start: time.Now(), duration: time.Second}
+			ctx := context.Background()
+			if testCase.deadlineReached {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, slot.start)
+				defer cancel()
+			}
+			included := builder.buildBlockExtrinsics(ctx, slot, extrinsicHandler)
+
+			assert.Equal(t, testCase.included, included)
+		})
+	}
+}
+
+type testInherentDataProvider struct {
+	value uint64
+}
+
+func (p testInherentDataProvider) ProvideInherentData(data *types.InherentData, _ Slot, _ *types.Header) error {
+	return data.SetInherent(types.Babeslot, p.value)
+}
+
+func Test_buildBlockInherents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	slot := Slot{start: time.UnixMilli(1000), duration: time.Second, number: 1}
+	parent := &types.Header{Number: 1}
+
+	// the inherent data given to the runtime is the one of the providers given
+	expectedData := types.NewInherentData()
+	err := expectedData.SetInherent(types.Timstap0, uint64(1000))
+	require.NoError(t, err)
+	err = expectedData.SetInherent(types.Babeslot, uint64(7))
+	require.NoError(t, err)
+	encodedData, err := expectedData.Encode()
+	require.NoError(t, err)
+
+	inherent := []byte{1, 2}
+	encodedInherents, err := scale.Marshal([][]byte{inherent})
+	require.NoError(t, err)
+	encodedInherent, err := scale.Marshal(inherent)
+	require.NoError(t, err)
+
+	rt := NewMockExtrinsicHandler(ctrl)
+	rt.EXPECT().InherentExtrinsics(encodedData).Return(encodedInherents, nil)
+	rt.EXPECT().ApplyExtrinsic(types.Extrinsic(encodedInherent)).Return([]byte{0, 0}, nil)
+
+	providers := NewInherentDataProviders()
+	err = providers.Register("timestamp", TimestampInherentDataProvider{})
+	require.NoError(t, err)
+	err = providers.Register("test", testInherentDataProvider{value: 7})
+	require.NoError(t, err)
+	inherents, err := buildBlockInherents(slot, rt, parent, providers)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{inherent}, inherents)
+}
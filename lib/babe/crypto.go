@@ -39,15 +39,15 @@ func claimPrimarySlot(randomness Randomness,
 ) (*VrfOutputAndProof, error) {
 	transcript := makeTranscript(randomness, slot, epoch)
 
-	out, proof, err := keypair.VrfSign(transcript)
+	sig, err := keypair.SignVrf(transcript)
 	if err != nil {
 		return nil, err
 	}
 
 	logger.Tracef("claimPrimarySlot pub=%s slot=%d epoch=%d output=0x%x proof=0x%x",
-		keypair.Public().Hex(), slot, epoch, out, proof)
+		keypair.Public().Hex(), slot, epoch, sig.Output, sig.Proof)
 
-	ok, err := checkPrimaryThreshold(randomness, slot, epoch, out, threshold, keypair.Public().(*sr25519.PublicKey))
+	ok, err := checkPrimaryThreshold(randomness, slot, epoch, sig.Output, threshold, keypair.Public().(*sr25519.PublicKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to compare with threshold, %w", err)
 	}
@@ -57,26 +57,22 @@ func claimPrimarySlot(randomness Randomness,
 	}
 
 	return &VrfOutputAndProof{
-		output: out,
-		proof:  proof,
+		output: sig.Output,
+		proof:  sig.Proof,
 	}, nil
 }
 
 // checkPrimaryThreshold returns true if the authority was authorized to produce a block in the given slot and epoch
 func checkPrimaryThreshold(randomness Randomness,
 	slot, epoch uint64,
-	output [sr25519.VRFOutputLength]byte,
+	output sr25519.VrfOutput,
 	threshold *scale.Uint128,
 	pub *sr25519.PublicKey,
 ) (bool, error) {
 	t := makeTranscript(randomness, slot, epoch)
-	inout, err := sr25519.AttachInput(output, pub, t)
-	if err != nil {
-		return false, fmt.Errorf("attaching sr25519 input: %w", err)
-	}
 
 	const size = 16
-	res, err := inout.MakeBytes(size, babeVRFPrefix)
+	res, err := output.MakeBytes(pub, t, size, babeVRFPrefix)
 	if err != nil {
 		return false, fmt.Errorf("making sr25519 bytes: %w", err)
 	}
@@ -110,7 +106,7 @@ func claimSecondarySlotVRF(randomness Randomness,
 
 	transcript := makeTranscript(randomness, slot, epoch)
 
-	out, proof, err := keypair.VrfSign(transcript)
+	sig, err := keypair.SignVrf(transcript)
 	if err != nil {
 		return nil, fmt.Errorf("cannot verify transcript: %w", err)
 	}
@@ -118,8 +114,8 @@ func claimSecondarySlotVRF(randomness Randomness,
 	logger.Debugf("claimed secondary slot, for slot number: %d", slot)
 
 	return &VrfOutputAndProof{
-		output: out,
-		proof:  proof,
+		output: sig.Output,
+		proof:  sig.Proof,
 	}, nil
 }
 
@@ -7,6 +7,7 @@
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
@@ -15,6 +16,9 @@
 type handleSlotFunc = func(epoch uint64, slot Slot, authorityIndex uint32,
 	preRuntimeDigest *types.PreRuntimeDigest) error
 
+// prepareSlotFunc is called ahead of an authoring slot, with the slot number.
+type prepareSlotFunc = func(slotNumber uint64)
+
 var errEpochPast = errors.New("cannot run epoch that has already passed")
 
 type epochHandler struct {
@@ -25,10 +29,13 @@ type epochHandler struct {
 	slotToPreRuntimeDigest map[uint64]*types.PreRuntimeDigest
 
 	handleSlot handleSlotFunc
+	// prepareSlot is optional and called one third of a
+	// slot duration before each authoring slot starts.
+	prepareSlot prepareSlotFunc
 }
 
 func newEpochHandler(epochDescriptor *epochDescriptor, constants constants,
-	handleSlot handleSlotFunc, keypair *sr25519.Keypair) (*epochHandler, error) {
+	handleSlot handleSlotFunc, prepareSlot prepareSlotFunc, keypair *sr25519.Keypair) (*epochHandler, error) {
 
 	// determine which slots we'll be authoring in by pre-calculating VRF output
 	slotToPreRuntimeDigest := make(map[uint64]*types.PreRuntimeDigest, constants.epochLength)
@@ -51,6 +58,7 @@ func newEpochHandler(epochDescriptor *epochDescriptor, constants constants,
 		descriptor:             epochDescriptor,
 		constants:              constants,
 		handleSlot:             handleSlot,
+		prepareSlot:            prepareSlot,
 		slotToPreRuntimeDigest: slotToPreRuntimeDigest,
 	}, nil
 }
@@ -80,19 +88,66 @@ func (h *epochHandler) run(ctx context.Context, errCh chan<- error) {
 			return
 		}
 
+		slotGauge.Set(float64(currentSlot.number))
+		nextOwnSlotDistanceGauge.Set(float64(h.nextOwnSlotDistance(currentSlot.number)))
+
 		// check if the slot is an authoring slot otherwise wait for the next slot
 		preRuntimeDigest, has := h.slotToPreRuntimeDigest[currentSlot.number]
-		if !has {
-			continue
+		if has {
+			err = h.handleSlot(
+				h.descriptor.epoch,
+				currentSlot,
+				h.descriptor.data.authorityIndex,
+				preRuntimeDigest)
+			if err != nil {
+				logger.Warnf("failed to handle slot %d: %s", currentSlot.number, err)
+			}
 		}
 
-		err = h.handleSlot(
-			h.descriptor.epoch,
-			currentSlot,
-			h.descriptor.data.authorityIndex,
-			preRuntimeDigest)
+		err = h.prepareNextSlot(ctx, currentSlot.number+1)
 		if err != nil {
-			logger.Warnf("failed to handle slot %d: %s", currentSlot.number, err)
+			errCh <- err
+			return
+		}
+	}
+}
+
+// prepareNextSlot waits until one third of a slot duration before the next slot
+// starts and prepares it, if it is an authoring slot.
+func (h *epochHandler) prepareNextSlot(ctx context.Context, nextSlotNumber uint64) error {
+	if h.prepareSlot == nil {
+		return nil
+	}
+
+	_, has := h.slotToPreRuntimeDigest[nextSlotNumber]
+	if !has {
+		return nil
+	}
+
+	nextSlotStart := getSlotStartTime(nextSlotNumber, h.constants.slotDuration)
+	untilPreparation := time.Until(nextSlotStart) - h.constants.slotDuration/3
+	if untilPreparation > 0 {
+		err := waitUntilNextSlot(ctx, untilPreparation)
+		if err != nil {
+			return fmt.Errorf("waiting to prepare slot: %w", err)
+		}
+	}
+
+	h.prepareSlot(nextSlotNumber)
+	return nil
+}
+
+// nextOwnSlotDistance returns the number of slots from the slot given until the next slot
+// claimed in the epoch, zero if the slot given is claimed, or -1 if there is none left.
+func (h *epochHandler) nextOwnSlotDistance(slotNumber uint64) (distance int64) {
+	distance = -1
+	for ownSlot := range h.slotToPreRuntimeDigest {
+		if ownSlot < slotNumber {
+			continue
+		}
+		if slotDistance := int64(ownSlot - slotNumber); distance == -1 || slotDistance < distance {
+			distance = slotDistance
 		}
 	}
+	return distance
 }
@@ -51,7 +51,7 @@ func TestEpochHandler_run_shouldReturnAfterContextCancel(t *testing.T) {
 		epoch:     1,
 	}
 
-	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, handler, aliceKeyPair)
+	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, handler, nil, aliceKeyPair)
 	require.NoError(t, err)
 	require.Equal(t, epochLength, uint64(len(epochHandler.slotToPreRuntimeDigest)))
 
@@ -103,7 +103,7 @@ func TestEpochHandler_run(t *testing.T) {
 		epoch:     1,
 	}
 
-	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, handler, aliceKeyPair)
+	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, handler, nil, aliceKeyPair)
 	require.NoError(t, err)
 	require.Equal(t, epochLength, uint64(len(epochHandler.slotToPreRuntimeDigest)))
 
@@ -4,6 +4,7 @@
 package babe
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -43,7 +44,7 @@ func TestNewEpochHandler(t *testing.T) {
 		epoch:     1,
 	}
 
-	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, testHandleSlotFunc, keypair)
+	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, testHandleSlotFunc, nil, keypair)
 	require.NoError(t, err)
 	require.Equal(t, 200, len(epochHandler.slotToPreRuntimeDigest))
 	require.Equal(t, uint64(1), epochHandler.descriptor.epoch)
@@ -52,3 +53,70 @@ func TestNewEpochHandler(t *testing.T) {
 	require.Equal(t, epochData, epochHandler.descriptor.data)
 	require.NotNil(t, epochHandler.handleSlot)
 }
+
+func TestEpochHandler_prepareNextSlot(t *testing.T) {
+	t.Parallel()
+
+	const slotDuration = 30 * time.Millisecond
+	nextSlot := getCurrentSlot(slotDuration) + 2
+
+	var preparedSlots []uint64
+	var preparedAt time.Time
+	epochHandler := &epochHandler{
+		constants: constants{slotDuration: slotDuration},
+		slotToPreRuntimeDigest: map[uint64]*types.PreRuntimeDigest{
+			nextSlot:      {},
+			nextSlot + 10: {},
+		},
+		prepareSlot: func(slotNumber uint64) {
+			preparedSlots = append(preparedSlots, slotNumber)
+			preparedAt = time.Now()
+		},
+	}
+
+	// the slot is not an authoring slot
+	err := epochHandler.prepareNextSlot(context.Background(), nextSlot+1)
+	require.NoError(t, err)
+	require.Empty(t, preparedSlots)
+
+	err = epochHandler.prepareNextSlot(context.Background(), nextSlot)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{nextSlot}, preparedSlots)
+	nextSlotStart := getSlotStartTime(nextSlot, slotDuration)
+	require.False(t, preparedAt.Before(nextSlotStart.Add(-slotDuration/3)))
+	require.True(t, preparedAt.Before(nextSlotStart))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = epochHandler.prepareNextSlot(ctx, nextSlot+10)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestEpochHandler_nextOwnSlotDistance(t *testing.T) {
+	t.Parallel()
+
+	epochHandler := &epochHandler{
+		slotToPreRuntimeDigest: map[uint64]*types.PreRuntimeDigest{
+			10: {},
+			15: {},
+		},
+	}
+
+	testCases := map[string]struct {
+		slotNumber uint64
+		distance   int64
+	}{
+		"before_own_slots":  {slotNumber: 5, distance: 5},
+		"own_slot":          {slotNumber: 10, distance: 0},
+		"between_own_slots": {slotNumber: 11, distance: 4},
+		"after_own_slots":   {slotNumber: 16, distance: -1},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, testCase.distance, epochHandler.nextOwnSlotDistance(testCase.slotNumber))
+		})
+	}
+}
@@ -24,6 +24,13 @@
 )
 
 var (
+	// ErrInherentDataProviderRegistered is returned when registering an inherent
+	// data provider under the name of a provider already registered
+	ErrInherentDataProviderRegistered = errors.New("inherent data provider already registered")
+
+	// ErrInherentProvidedTwice is returned when several inherent data providers provide the same inherent
+	ErrInherentProvidedTwice = errors.New("inherent provided twice")
+
 	// ErrAuthIndexOutOfBound is returned when a authority index doesn't exist
 	ErrAuthIndexOutOfBound = errors.New("authority index doesn't exist")
 
@@ -64,6 +71,7 @@
 	ErrThresholdOneIsZero = errors.New("numerator or denominator cannot be 0")
 
 	errNilParentHeader            = errors.New("parent header is nil")
+	errNilParentTrieState         = errors.New("parent trie state is nil")
 	errInvalidResult              = errors.New("invalid error value")
 	errOverPrimarySlotThreshold   = errors.New("cannot claim slot, over primary threshold")
 	errNotOurTurnToPropose        = errors.New("cannot claim slot, not our turn to propose a block")
@@ -73,6 +81,9 @@
 	errNoBABEAuthorityKeyProvided = errors.New("cannot create BABE service as authority; no keypair provided")
 	errLastDigestItemNotSeal      = errors.New("last digest item is not seal")
 	errLaggingSlot                = errors.New("current slot is smaller than slot of best block")
+	errUnfinalisedDepthReached    = errors.New("maximum unfinalised depth reached")
+	errNoTransactionToSeal        = errors.New("no transaction to include in block")
+	errNoSlotToSeal               = errors.New("no slot claimed to seal block")
 	errNoDigest                   = errors.New("no digest provided")
 )
 
@@ -5,6 +5,7 @@
 
 import (
 	"bytes"
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -187,7 +188,7 @@ func createTestService(t *testing.T, cfg ServiceConfig, genesis genesis.Genesis,
 	dbSrv := state.NewService(config)
 	dbSrv.UseMemDB()
 
-	dbSrv.Transaction = state.NewTransactionState(telemetryMock)
+	dbSrv.Transaction = state.NewTransactionState(telemetryMock, transaction.PoolLimits{})
 
 	err := dbSrv.Initialise(&genesis, &genesisHeader, genesisTrie)
 	require.NoError(t, err)
@@ -344,7 +345,7 @@ func createTestBlockWithSlot(t *testing.T, babeService *Service, parent *types.H
 	preRuntimeDigest, err := claimSlot(epochDescriptor.epoch, slot.number, epochDescriptor.data, babeService.keypair)
 	require.NoError(t, err)
 
-	block, err := babeService.buildBlock(parent, slot, rt, epochDescriptor.data.authorityIndex, preRuntimeDigest)
+	block, err := babeService.buildBlock(context.Background(), parent, slot, rt, epochDescriptor.data.authorityIndex, preRuntimeDigest)
 	require.NoError(t, err)
 
 	babeService.blockState.(*state.BlockState).StoreRuntime(block.Header.Hash(), rt)
@@ -0,0 +1,176 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe/inherents"
+)
+
+const (
+	// TimestampInherentDataProviderName is the name of the timestamp inherent data provider.
+	TimestampInherentDataProviderName = "timestamp"
+	// SlotInherentDataProviderName is the name of the BABE slot inherent data provider.
+	SlotInherentDataProviderName = "babe-slot"
+	// ParachainInherentDataProviderName is the name of the parachain inherent data provider.
+	ParachainInherentDataProviderName = "parachain"
+)
+
+// InherentDataProvider provides inherent data to the blocks built, the inherent data
+// being given to the runtime to create the inherent extrinsics of the block.
+type InherentDataProvider interface {
+	// ProvideInherentData sets the inherents of the provider in the inherent data
+	// of the block built in the slot given on top of the parent header given.
+	ProvideInherentData(data *types.InherentData, slot Slot, parent *types.Header) error
+}
+
+// InherentDataProviders is a registry of the inherent data providers of the blocks built,
+// so the chains requiring custom inherents can register their providers without modifying
+// the block builder. It is safe for concurrent use.
+type InherentDataProviders struct {
+	mu        sync.RWMutex
+	providers map[string]InherentDataProvider
+	// names are the names of the providers in their registration order.
+	names []string
+}
+
+// NewInherentDataProviders returns an empty inherent data providers registry.
+func NewInherentDataProviders() *InherentDataProviders {
+	return &InherentDataProviders{
+		providers: make(map[string]InherentDataProvider),
+	}
+}
+
+// DefaultInherentDataProviders returns a registry of the inherent data providers of
+// the blocks built by default: the timestamp, the BABE slot and the parachain inherents.
+func DefaultInherentDataProviders() *InherentDataProviders {
+	providers := NewInherentDataProviders()
+	providers.mustRegister(TimestampInherentDataProviderName, TimestampInherentDataProvider{})
+	providers.mustRegister(SlotInherentDataProviderName, SlotInherentDataProvider{})
+	providers.mustRegister(ParachainInherentDataProviderName, ParachainInherentDataProvider{})
+	return providers
+}
+
+// Register registers the inherent data provider given under the name given. It returns
+// ErrInherentDataProviderRegistered if a provider is already registered under this name.
+func (p *InherentDataProviders) Register(name string, provider InherentDataProvider) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, has := p.providers[name]; has {
+		return fmt.Errorf("%w: %s", ErrInherentDataProviderRegistered, name)
+	}
+
+	p.providers[name] = provider
+	p.names = append(p.names, name)
+	return nil
+}
+
+func (p *InherentDataProviders) mustRegister(name string, provider InherentDataProvider) {
+	err := p.Register(name, provider)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Unregister unregisters the inherent data provider registered under the name given,
+// for example to replace a default provider. It returns false if there is no such provider.
+func (p *InherentDataProviders) Unregister(name string) (unregistered bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, has := p.providers[name]; !has {
+		return false
+	}
+
+	delete(p.providers, name)
+	for i, registered := range p.names {
+		if registered == name {
+			p.names = append(p.names[:i], p.names[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// InherentData returns the inherent data of the block built in the slot given on top of the
+// parent header given, provided by the providers registered in their registration order.
+// It returns ErrInherentProvidedTwice if several providers provide the same inherent.
+func (p *InherentDataProviders) InherentData(slot Slot, parent *types.Header) (*types.InherentData, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	data := types.NewInherentData()
+	providedBy := make(map[[8]byte]string)
+	for _, name := range p.names {
+		providerData := types.NewInherentData()
+		err := p.providers[name].ProvideInherentData(providerData, slot, parent)
+		if err != nil {
+			return nil, fmt.Errorf("providing %s inherent data: %w", name, err)
+		}
+
+		for key, value := range providerData.Data {
+			if otherName, has := providedBy[key]; has {
+				return nil, fmt.Errorf("%w: %q by %s and %s",
+					ErrInherentProvidedTwice, string(key[:]), otherName, name)
+			}
+			providedBy[key] = name
+			data.Data[key] = value
+		}
+	}
+	return data, nil
+}
+
+// TimestampInherentDataProvider provides the timestamp inherent,
+// set to the start of the slot in milliseconds.
+type TimestampInherentDataProvider struct{}
+
+// ProvideInherentData sets the timestamp inherent.
+func (TimestampInherentDataProvider) ProvideInherentData(data *types.InherentData, slot Slot,
+	_ *types.Header) error {
+	err := data.SetInherent(types.Timstap0, uint64(slot.Start().UnixMilli()))
+	if err != nil {
+		return fmt.Errorf("setting inherent %q: %w", types.Timstap0, err)
+	}
+	return nil
+}
+
+// SlotInherentDataProvider provides the BABE slot inherent.
+type SlotInherentDataProvider struct{}
+
+// ProvideInherentData sets the BABE slot inherent.
+func (SlotInherentDataProvider) ProvideInherentData(data *types.InherentData, slot Slot,
+	_ *types.Header) error {
+	err := data.SetInherent(types.Babeslot, slot.Number())
+	if err != nil {
+		return fmt.Errorf("setting inherent %q: %w", types.Babeslot, err)
+	}
+	return nil
+}
+
+// ParachainInherentDataProvider provides the parachain inherents.
+// For now it provides "empty" values, as the parachain-specific
+// logic is required to actually provide the data.
+type ParachainInherentDataProvider struct{}
+
+// ProvideInherentData sets the parachain and the new heads inherents.
+func (ParachainInherentDataProvider) ProvideInherentData(data *types.InherentData, _ Slot,
+	parent *types.Header) error {
+	parachainInherent := inherents.ParachainInherentData{
+		ParentHeader: *parent,
+	}
+	err := data.SetInherent(types.Parachn0, parachainInherent)
+	if err != nil {
+		return fmt.Errorf("setting inherent %q: %w", types.Parachn0, err)
+	}
+
+	err = data.SetInherent(types.Newheads, []byte{0})
+	if err != nil {
+		return fmt.Errorf("setting inherent %q: %w", types.Newheads, err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inherentDataProviderFunc func(data *types.InherentData, slot Slot, parent *types.Header) error
+
+func (f inherentDataProviderFunc) ProvideInherentData(data *types.InherentData, slot Slot,
+	parent *types.Header) error {
+	return f(data, slot, parent)
+}
+
+func TestInherentDataProviders_Register(t *testing.T) {
+	t.Parallel()
+
+	providers := DefaultInherentDataProviders()
+
+	err := providers.Register(TimestampInherentDataProviderName, TimestampInherentDataProvider{})
+	assert.ErrorIs(t, err, ErrInherentDataProviderRegistered)
+	assert.EqualError(t, err, "inherent data provider already registered: timestamp")
+
+	unregistered := providers.Unregister(ParachainInherentDataProviderName)
+	assert.True(t, unregistered)
+	unregistered = providers.Unregister(ParachainInherentDataProviderName)
+	assert.False(t, unregistered)
+	assert.Equal(t, []string{TimestampInherentDataProviderName, SlotInherentDataProviderName}, providers.names)
+}
+
+func TestInherentDataProviders_InherentData(t *testing.T) {
+	t.Parallel()
+
+	slot := Slot{start: time.UnixMilli(1000), duration: time.Second, number: 2}
+	parent := &types.Header{Number: 1}
+	uncles := [8]byte{'u', 'n', 'c', 'l', 'e', 's', '0', '0'}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		providers    map[string]InherentDataProvider
+		expectedData map[[8]byte][]byte
+		errWrapped   error
+		errMessage   string
+	}{
+		"custom_inherent": {
+			providers: map[string]InherentDataProvider{
+				SlotInherentDataProviderName: SlotInherentDataProvider{},
+				"uncles": inherentDataProviderFunc(func(data *types.InherentData, _ Slot, _ *types.Header) error {
+					return data.SetInherentByKey(uncles, []byte{1})
+				}),
+			},
+			expectedData: map[[8]byte][]byte{
+				types.Babeslot.Bytes(): {2, 0, 0, 0, 0, 0, 0, 0},
+				uncles:                 {4, 1},
+			},
+		},
+		"provider_error": {
+			providers: map[string]InherentDataProvider{
+				"failing": inherentDataProviderFunc(func(*types.InherentData, Slot, *types.Header) error {
+					return errTest
+				}),
+			},
+			errWrapped: errTest,
+			errMessage: "providing failing inherent data: test error",
+		},
+		"inherent_provided_twice": {
+			providers: map[string]InherentDataProvider{
+				SlotInherentDataProviderName: SlotInherentDataProvider{},
+				"other-slot": inherentDataProviderFunc(func(data *types.InherentData, _ Slot, _ *types.Header) error {
+					return data.SetInherent(types.Babeslot, uint64(3))
+				}),
+			},
+			errWrapped: ErrInherentProvidedTwice,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			providers := NewInherentDataProviders()
+			for providerName, provider := range testCase.providers {
+				err := providers.Register(providerName, provider)
+				require.NoError(t, err)
+			}
+
+			data, err := providers.InherentData(slot, parent)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			if testCase.errWrapped != nil {
+				return
+			}
+			assert.Equal(t, testCase.expectedData, data.Data)
+		})
+	}
+}
+
+func TestServiceConfig_inherentDataProviders(t *testing.T) {
+	t.Parallel()
+
+	config := &ServiceConfig{}
+	assert.Equal(t, DefaultInherentDataProviders(), config.inherentDataProviders())
+
+	providers := NewInherentDataProviders()
+	config.InherentDataProviders = providers
+	assert.Same(t, providers, config.inherentDataProviders())
+}
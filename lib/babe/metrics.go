@@ -0,0 +1,35 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Note: these are using the substrate namespace so the
+// dashboards made for substrate nodes work with gossamer
+var (
+	epochGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "babe_epoch",
+		Help:      "current epoch of the block production",
+	})
+	slotGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "babe_slot",
+		Help:      "current slot of the block production",
+	})
+	isAuthorityGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "babe_is_authority",
+		Help:      "1 if the node is producing blocks as an authority of the current epoch, 0 otherwise",
+	})
+	nextOwnSlotDistanceGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "substrate",
+		Name:      "babe_next_own_slot_distance",
+		Help: "number of slots until the next slot claimed by the node in the current epoch, " +
+			"-1 if there is none left",
+	})
+)
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/lib/babe (interfaces: BlockState,ImportedBlockNotifierManager,StorageState,TransactionState,EpochState,BlockImportHandler,SlotState)
+// Source: github.com/ChainSafe/gossamer/lib/babe (interfaces: BlockState,StorageState,TransactionState,EpochState,BlockImportHandler,SlotState)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mock_state_test.go -package babe . BlockState,ImportedBlockNotifierManager,StorageState,TransactionState,EpochState,BlockImportHandler,SlotState
+//	mockgen -destination=mock_state_test.go -package babe . BlockState,StorageState,TransactionState,EpochState,BlockImportHandler,SlotState
 //
 
 // Package babe is a generated GoMock package.
@@ -87,18 +87,6 @@ func (mr *MockBlockStateMockRecorder) BestBlockHeader() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BestBlockHeader", reflect.TypeOf((*MockBlockState)(nil).BestBlockHeader))
 }
 
-// FreeImportedBlockNotifierChannel mocks base method.
-func (m *MockBlockState) FreeImportedBlockNotifierChannel(arg0 chan *types.Block) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "FreeImportedBlockNotifierChannel", arg0)
-}
-
-// FreeImportedBlockNotifierChannel indicates an expected call of FreeImportedBlockNotifierChannel.
-func (mr *MockBlockStateMockRecorder) FreeImportedBlockNotifierChannel(arg0 any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreeImportedBlockNotifierChannel", reflect.TypeOf((*MockBlockState)(nil).FreeImportedBlockNotifierChannel), arg0)
-}
-
 // GenesisHash mocks base method.
 func (m *MockBlockState) GenesisHash() common.Hash {
 	m.ctrl.T.Helper()
@@ -173,18 +161,35 @@ func (mr *MockBlockStateMockRecorder) GetHeader(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockBlockState)(nil).GetHeader), arg0)
 }
 
-// GetImportedBlockNotifierChannel mocks base method.
-func (m *MockBlockState) GetImportedBlockNotifierChannel() chan *types.Block {
+// GetHighestFinalisedHeader mocks base method.
+func (m *MockBlockState) GetHighestFinalisedHeader() (*types.Header, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetImportedBlockNotifierChannel")
-	ret0, _ := ret[0].(chan *types.Block)
-	return ret0
+	ret := m.ctrl.Call(m, "GetHighestFinalisedHeader")
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetImportedBlockNotifierChannel indicates an expected call of GetImportedBlockNotifierChannel.
-func (mr *MockBlockStateMockRecorder) GetImportedBlockNotifierChannel() *gomock.Call {
+// GetHighestFinalisedHeader indicates an expected call of GetHighestFinalisedHeader.
+func (mr *MockBlockStateMockRecorder) GetHighestFinalisedHeader() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportedBlockNotifierChannel", reflect.TypeOf((*MockBlockState)(nil).GetImportedBlockNotifierChannel))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).GetHighestFinalisedHeader))
+}
+
+// GetHighestRoundAndSetID mocks base method.
+func (m *MockBlockState) GetHighestRoundAndSetID() (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighestRoundAndSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHighestRoundAndSetID indicates an expected call of GetHighestRoundAndSetID.
+func (mr *MockBlockStateMockRecorder) GetHighestRoundAndSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestRoundAndSetID", reflect.TypeOf((*MockBlockState)(nil).GetHighestRoundAndSetID))
 }
 
 // GetRuntime mocks base method.
@@ -247,65 +252,30 @@ func (mr *MockBlockStateMockRecorder) NumberIsFinalised(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NumberIsFinalised", reflect.TypeOf((*MockBlockState)(nil).NumberIsFinalised), arg0)
 }
 
-// StoreRuntime mocks base method.
-func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 runtime.Instance) {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "StoreRuntime", arg0, arg1)
-}
-
-// StoreRuntime indicates an expected call of StoreRuntime.
-func (mr *MockBlockStateMockRecorder) StoreRuntime(arg0, arg1 any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRuntime", reflect.TypeOf((*MockBlockState)(nil).StoreRuntime), arg0, arg1)
-}
-
-// MockImportedBlockNotifierManager is a mock of ImportedBlockNotifierManager interface.
-type MockImportedBlockNotifierManager struct {
-	ctrl     *gomock.Controller
-	recorder *MockImportedBlockNotifierManagerMockRecorder
-}
-
-// MockImportedBlockNotifierManagerMockRecorder is the mock recorder for MockImportedBlockNotifierManager.
-type MockImportedBlockNotifierManagerMockRecorder struct {
-	mock *MockImportedBlockNotifierManager
-}
-
-// NewMockImportedBlockNotifierManager creates a new mock instance.
-func NewMockImportedBlockNotifierManager(ctrl *gomock.Controller) *MockImportedBlockNotifierManager {
-	mock := &MockImportedBlockNotifierManager{ctrl: ctrl}
-	mock.recorder = &MockImportedBlockNotifierManagerMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockImportedBlockNotifierManager) EXPECT() *MockImportedBlockNotifierManagerMockRecorder {
-	return m.recorder
-}
-
-// FreeImportedBlockNotifierChannel mocks base method.
-func (m *MockImportedBlockNotifierManager) FreeImportedBlockNotifierChannel(arg0 chan *types.Block) {
+// SetFinalisedHash mocks base method.
+func (m *MockBlockState) SetFinalisedHash(arg0 common.Hash, arg1, arg2 uint64) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "FreeImportedBlockNotifierChannel", arg0)
+	ret := m.ctrl.Call(m, "SetFinalisedHash", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FreeImportedBlockNotifierChannel indicates an expected call of FreeImportedBlockNotifierChannel.
-func (mr *MockImportedBlockNotifierManagerMockRecorder) FreeImportedBlockNotifierChannel(arg0 any) *gomock.Call {
+// SetFinalisedHash indicates an expected call of SetFinalisedHash.
+func (mr *MockBlockStateMockRecorder) SetFinalisedHash(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FreeImportedBlockNotifierChannel", reflect.TypeOf((*MockImportedBlockNotifierManager)(nil).FreeImportedBlockNotifierChannel), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinalisedHash", reflect.TypeOf((*MockBlockState)(nil).SetFinalisedHash), arg0, arg1, arg2)
 }
 
-// GetImportedBlockNotifierChannel mocks base method.
-func (m *MockImportedBlockNotifierManager) GetImportedBlockNotifierChannel() chan *types.Block {
+// StoreRuntime mocks base method.
+func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 runtime.Instance) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetImportedBlockNotifierChannel")
-	ret0, _ := ret[0].(chan *types.Block)
-	return ret0
+	m.ctrl.Call(m, "StoreRuntime", arg0, arg1)
 }
 
-// GetImportedBlockNotifierChannel indicates an expected call of GetImportedBlockNotifierChannel.
-func (mr *MockImportedBlockNotifierManagerMockRecorder) GetImportedBlockNotifierChannel() *gomock.Call {
+// StoreRuntime indicates an expected call of StoreRuntime.
+func (mr *MockBlockStateMockRecorder) StoreRuntime(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportedBlockNotifierChannel", reflect.TypeOf((*MockImportedBlockNotifierManager)(nil).GetImportedBlockNotifierChannel))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRuntime", reflect.TypeOf((*MockBlockState)(nil).StoreRuntime), arg0, arg1)
 }
 
 // MockStorageState is a mock of StorageState interface.
@@ -393,6 +363,20 @@ func (m *MockTransactionState) EXPECT() *MockTransactionStateMockRecorder {
 	return m.recorder
 }
 
+// Peek mocks base method.
+func (m *MockTransactionState) Peek() *transaction.ValidTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peek")
+	ret0, _ := ret[0].(*transaction.ValidTransaction)
+	return ret0
+}
+
+// Peek indicates an expected call of Peek.
+func (mr *MockTransactionStateMockRecorder) Peek() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockTransactionState)(nil).Peek))
+}
+
 // PopWithTimer mocks base method.
 func (m *MockTransactionState) PopWithTimer(arg0 <-chan time.Time) *transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@
 package babe
 
 //go:generate mockgen -destination=mock_telemetry_test.go -package $GOPACKAGE . Telemetry
+//go:generate mockgen -destination=mock_extrinsic_handler_test.go -package $GOPACKAGE . ExtrinsicHandler
 //go:generate mockgen -destination=mocks/runtime.go -package mocks github.com/ChainSafe/gossamer/lib/runtime Instance
 //go:generate mockgen -destination=mocks/core.go -package mocks github.com/ChainSafe/gossamer/dot/core Network,BlockImportDigestHandler
-//go:generate mockgen -destination=mock_state_test.go -package $GOPACKAGE . BlockState,ImportedBlockNotifierManager,StorageState,TransactionState,EpochState,BlockImportHandler,SlotState
+//go:generate mockgen -destination=mock_state_test.go -package $GOPACKAGE . BlockState,StorageState,TransactionState,EpochState,BlockImportHandler,SlotState
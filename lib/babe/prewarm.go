@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+)
+
+// prewarmedParent is the state and runtime of the parent block
+// of an authoring slot, loaded before the slot starts.
+type prewarmedParent struct {
+	slot       uint64
+	parentHash common.Hash
+	trieState  *rtstorage.TrieState
+	runtime    runtime.Instance
+}
+
+// prepareSlot loads the trie state and the runtime of the parent block for the authoring
+// slot given, so the block production does not pay their setup cost within the slot.
+// The parent is loaded again when the slot starts if the best block changed meanwhile.
+func (b *Service) prepareSlot(slotNumber uint64) {
+	parent, err := b.getParentForBlockAuthoring(slotNumber)
+	if err != nil {
+		logger.Debugf("cannot prepare authoring slot %d: %s", slotNumber, err)
+		return
+	}
+
+	b.storageState.Lock()
+	defer b.storageState.Unlock()
+
+	ts, rt, err := b.loadParentStateAndRuntime(parent)
+	if err != nil {
+		logger.Debugf("cannot prepare authoring slot %d: %s", slotNumber, err)
+		return
+	}
+
+	b.prewarmedMutex.Lock()
+	defer b.prewarmedMutex.Unlock()
+	b.prewarmed = &prewarmedParent{
+		slot:       slotNumber,
+		parentHash: parent.Hash(),
+		trieState:  ts,
+		runtime:    rt,
+	}
+	logger.Debugf("prepared authoring slot %d on parent block #%d (%s)",
+		slotNumber, parent.Number, parent.Hash())
+}
+
+// parentStateAndRuntime returns the trie state and the runtime of the parent block
+// to author the block of the slot given, using the ones prepared for the slot if the
+// parent did not change. It must be called with the storage state lock held.
+func (b *Service) parentStateAndRuntime(slotNumber uint64, parent *types.Header) (
+	*rtstorage.TrieState, runtime.Instance, error) {
+	b.prewarmedMutex.Lock()
+	prewarmed := b.prewarmed
+	b.prewarmed = nil
+	b.prewarmedMutex.Unlock()
+
+	if prewarmed != nil && prewarmed.slot == slotNumber && prewarmed.parentHash == parent.Hash() {
+		prewarmed.runtime.SetContextStorage(prewarmed.trieState)
+		return prewarmed.trieState, prewarmed.runtime, nil
+	}
+
+	return b.loadParentStateAndRuntime(parent)
+}
+
+func (b *Service) loadParentStateAndRuntime(parent *types.Header) (
+	*rtstorage.TrieState, runtime.Instance, error) {
+	ts, err := b.storageState.TrieState(&parent.StateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting parent trie with state root %s: %w", parent.StateRoot, err)
+	} else if ts == nil {
+		return nil, nil, fmt.Errorf("%w: with state root %s", errNilParentTrieState, parent.StateRoot)
+	}
+
+	rt, err := b.blockState.GetRuntime(parent.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting runtime of parent block: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+	return ts, rt, nil
+}
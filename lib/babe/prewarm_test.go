@@ -0,0 +1,66 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe/mocks"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_prepareSlot(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	parent := &types.Header{
+		Number:    0,
+		StateRoot: common.Hash{1},
+		Digest:    types.NewDigest(),
+	}
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	runtimeInstance := mocks.NewMockInstance(ctrl)
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().BestBlockHeader().Return(parent, nil).Times(2)
+	blockState.EXPECT().GenesisHash().Return(parent.Hash()).Times(2)
+	blockState.EXPECT().GetRuntime(parent.Hash()).Return(runtimeInstance, nil).Times(3)
+
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().Lock().Times(2)
+	storageState.EXPECT().Unlock().Times(2)
+	storageState.EXPECT().TrieState(&parent.StateRoot).Return(trieState, nil).Times(3)
+
+	runtimeInstance.EXPECT().SetContextStorage(trieState).Times(4)
+
+	service := &Service{
+		blockState:   blockState,
+		storageState: storageState,
+	}
+
+	service.prepareSlot(5)
+	require.NotNil(t, service.prewarmed)
+
+	// the parent prepared for the slot is used without being loaded again
+	ts, rt, err := service.parentStateAndRuntime(5, parent)
+	require.NoError(t, err)
+	assert.Same(t, trieState, ts)
+	assert.Same(t, runtimeInstance, rt)
+	assert.Nil(t, service.prewarmed)
+
+	// the parent prepared for another slot is discarded
+	service.prepareSlot(6)
+	ts, rt, err = service.parentStateAndRuntime(7, parent)
+	require.NoError(t, err)
+	assert.Same(t, trieState, ts)
+	assert.Same(t, runtimeInstance, rt)
+	assert.Nil(t, service.prewarmed)
+}
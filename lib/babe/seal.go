@@ -0,0 +1,201 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/events"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// runInstantSeal builds a block as soon as transactions are made ready in the
+// transaction queue, and finalises it, until the service is paused or stopped.
+func (b *Service) runInstantSeal() {
+	logger.Info("instant seal enabled, blocks are built when transactions are submitted")
+
+	ready := events.Subscribe[events.TransactionReady](b.eventBus)
+	defer ready.Unsubscribe()
+
+	for {
+		for b.transactionState.Peek() != nil {
+			_, err := b.CreateBlock(nil, false, true)
+			if err != nil {
+				logger.Warnf("failed to instant seal block: %s", err)
+				// transactions put back in the queue by the failed block
+				// wait for the next transaction made ready
+				drainEvents(ready.Events())
+				break
+			}
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-b.pause:
+			return
+		case <-ready.Events():
+		}
+	}
+}
+
+// drainEvents discards the events already received on the channel given.
+func drainEvents[E any](ch <-chan E) {
+	for {
+		select {
+		case <-ch:
+		default:
+			return
+		}
+	}
+}
+
+// CreateBlock builds a block on top of the parent block, or on top of the best
+// block if parentHash is nil, imports it and returns its hash. It fails if there
+// are no transactions to include in the block unless createEmpty is true, and
+// finalises the block if finalise is true.
+func (b *Service) CreateBlock(parentHash *common.Hash, createEmpty, finalise bool) (common.Hash, error) {
+	if !b.authority {
+		return common.Hash{}, ErrNotAuthority
+	}
+
+	b.sealLock.Lock()
+	defer b.sealLock.Unlock()
+
+	if !createEmpty && b.transactionState.Peek() == nil {
+		return common.Hash{}, errNoTransactionToSeal
+	}
+
+	var parent *types.Header
+	var err error
+	if parentHash == nil {
+		parent, err = b.blockState.BestBlockHeader()
+	} else {
+		parent, err = b.blockState.GetHeader(*parentHash)
+	}
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("getting parent header: %w", err)
+	}
+
+	parent, err = parent.DeepCopy()
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("could not create deep copy of parent header: %w", err)
+	}
+
+	epoch, slotNumber, preRuntimeDigest, authorityIndex, err := b.claimSealSlot(parent)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	// a zero duration only includes the transactions already in the queue
+	// instead of waiting for more during the slot
+	slot := Slot{
+		start:  getSlotStartTime(slotNumber, b.constants.slotDuration),
+		number: slotNumber,
+	}
+	// the sealed blocks have no authoring deadline
+	block, err := b.produceBlock(context.Background(), epoch, slot, parent, authorityIndex, preRuntimeDigest)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	hash := block.Header.Hash()
+	if finalise {
+		err = b.FinalizeBlock(hash)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	return hash, nil
+}
+
+// FinalizeBlock finalises the block with the given hash
+func (b *Service) FinalizeBlock(hash common.Hash) error {
+	round, setID, err := b.blockState.GetHighestRoundAndSetID()
+	if err != nil {
+		return fmt.Errorf("getting highest round and set id: %w", err)
+	}
+
+	err = b.blockState.SetFinalisedHash(hash, round+1, setID)
+	if err != nil {
+		return fmt.Errorf("finalising block %s: %w", hash, err)
+	}
+
+	logger.Infof("finalised block with hash %s", hash)
+	return nil
+}
+
+// SetClock sets the function returning the current time the slots of the instant and
+// manual sealed blocks are claimed from, so a test harness can author blocks with a mock clock.
+func (b *Service) SetClock(now func() time.Time) {
+	b.sealLock.Lock()
+	defer b.sealLock.Unlock()
+	b.timeNow = now
+}
+
+// claimSealSlot claims the first slot on top of the parent which is not before the
+// current slot. The slots of sealed blocks run ahead of time when blocks are sealed
+// faster than the slot duration.
+func (b *Service) claimSealSlot(parent *types.Header) (epoch, slot uint64,
+	preRuntimeDigest *types.PreRuntimeDigest, authorityIndex uint32, err error) {
+	slot = uint64(b.timeNow().UnixNano()) / uint64(b.constants.slotDuration.Nanoseconds())
+
+	var parentEpoch, startSlot uint64
+	atGenesis := parent.Hash() == b.blockState.GenesisHash()
+	if atGenesis {
+		startSlot = slot
+	} else {
+		parentSlot, err := types.GetSlotFromHeader(parent)
+		if err != nil {
+			return 0, 0, nil, 0, fmt.Errorf("getting parent slot: %w", err)
+		}
+		slot = max(slot, parentSlot+1)
+
+		parentEpoch, err = b.epochState.GetEpochForBlock(parent)
+		if err != nil {
+			return 0, 0, nil, 0, fmt.Errorf("getting parent epoch: %w", err)
+		}
+
+		startSlot, err = b.epochState.GetStartSlotForEpoch(parentEpoch, parent.Hash())
+		if err != nil {
+			return 0, 0, nil, 0, fmt.Errorf("getting start slot of epoch %d: %w", parentEpoch, err)
+		}
+	}
+
+	var data *epochData
+	dataEpoch := uint64(0)
+	for end := slot + b.constants.epochLength; slot < end; slot++ {
+		epoch = parentEpoch + (slot-startSlot)/b.constants.epochLength
+
+		// skipped epochs use the epoch data announced for the epoch after the parent one
+		epochToFindData := epoch
+		if !atGenesis && epoch > parentEpoch+1 {
+			epochToFindData = parentEpoch + 1
+		}
+
+		if data == nil || dataEpoch != epochToFindData {
+			data, err = b.getEpochData(epochToFindData, parent)
+			if err != nil {
+				return 0, 0, nil, 0, fmt.Errorf("getting epoch data: %w", err)
+			}
+			dataEpoch = epochToFindData
+		}
+
+		preRuntimeDigest, err = claimSlot(epoch, slot, data, b.keypair)
+		if errors.Is(err, errOverPrimarySlotThreshold) || errors.Is(err, errNotOurTurnToPropose) {
+			continue
+		} else if err != nil {
+			return 0, 0, nil, 0, fmt.Errorf("claiming slot %d: %w", slot, err)
+		}
+
+		return epoch, slot, preRuntimeDigest, data.authorityIndex, nil
+	}
+
+	return 0, 0, nil, 0, fmt.Errorf("%w: in the next %d slots", errNoSlotToSeal, b.constants.epochLength)
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_CreateBlock(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		service     func(ctrl *gomock.Controller) *Service
+		parentHash  *common.Hash
+		createEmpty bool
+		errWrapped  error
+		errMessage  string
+	}{
+		"not_authority": {
+			service: func(*gomock.Controller) *Service {
+				return &Service{}
+			},
+			errWrapped: ErrNotAuthority,
+			errMessage: "node is not an authority",
+		},
+		"no_transaction": {
+			service: func(ctrl *gomock.Controller) *Service {
+				transactionState := NewMockTransactionState(ctrl)
+				transactionState.EXPECT().Peek().Return(nil)
+				return &Service{
+					authority:        true,
+					transactionState: transactionState,
+				}
+			},
+			errWrapped: errNoTransactionToSeal,
+			errMessage: "no transaction to include in block",
+		},
+		"parent_header_error": {
+			service: func(ctrl *gomock.Controller) *Service {
+				transactionState := NewMockTransactionState(ctrl)
+				transactionState.EXPECT().Peek().Return(&transaction.ValidTransaction{})
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(common.Hash{1}).Return(nil, errTest)
+				return &Service{
+					authority:        true,
+					transactionState: transactionState,
+					blockState:       blockState,
+				}
+			},
+			parentHash: &common.Hash{1},
+			errWrapped: errTest,
+			errMessage: "getting parent header: test error",
+		},
+		"best_header_error": {
+			service: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(nil, errTest)
+				return &Service{
+					authority:  true,
+					blockState: blockState,
+				}
+			},
+			createEmpty: true,
+			errWrapped:  errTest,
+			errMessage:  "getting parent header: test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			hash, err := testCase.service(ctrl).CreateBlock(testCase.parentHash, testCase.createEmpty, false)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.EqualError(t, err, testCase.errMessage)
+			assert.Equal(t, common.Hash{}, hash)
+		})
+	}
+}
+
+func TestService_FinalizeBlock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	hash := common.Hash{1}
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHighestRoundAndSetID().Return(uint64(2), uint64(1), nil)
+	blockState.EXPECT().SetFinalisedHash(hash, uint64(3), uint64(1)).Return(nil)
+
+	service := &Service{blockState: blockState}
+	err := service.FinalizeBlock(hash)
+	assert.NoError(t, err)
+}
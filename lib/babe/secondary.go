@@ -70,5 +70,5 @@ func verifySecondarySlotVRF(digest *types.BabeSecondaryVRFPreDigest,
 	}
 
 	t := makeTranscript(randomness, digest.SlotNumber, epoch)
-	return pk.VrfVerify(t, digest.VrfOutput, digest.VrfProof)
+	return pk.VerifyVrf(t, sr25519.VrfSignature{Output: digest.VrfOutput, Proof: digest.VrfProof})
 }
@@ -25,6 +25,7 @@ type BlockState interface {
 	BestBlockHeader() (*types.Header, error)
 	AddBlock(*types.Block) error
 	GetHeader(common.Hash) (*types.Header, error)
+	GetHighestFinalisedHeader() (*types.Header, error)
 	GetBlockByNumber(blockNumber uint) (*types.Block, error)
 	GetBlockHashesBySlot(slot uint64) (blockHashes []common.Hash, err error)
 	GenesisHash() common.Hash
@@ -34,13 +35,8 @@ type BlockState interface {
 	GetRuntime(blockHash common.Hash) (runtime runtime.Instance, err error)
 	StoreRuntime(common.Hash, runtime.Instance)
 	GetBlockByHash(common.Hash) (*types.Block, error)
-	ImportedBlockNotifierManager
-}
-
-// ImportedBlockNotifierManager is the interface for block notification channels
-type ImportedBlockNotifierManager interface {
-	GetImportedBlockNotifierChannel() chan *types.Block
-	FreeImportedBlockNotifierChannel(ch chan *types.Block)
+	GetHighestRoundAndSetID() (uint64, uint64, error)
+	SetFinalisedHash(hash common.Hash, round, setID uint64) error
 }
 
 // StorageState interface for storage state methods
@@ -53,6 +49,7 @@ type StorageState interface {
 type TransactionState interface {
 	Push(vt *transaction.ValidTransaction) (common.Hash, error)
 	PopWithTimer(timerCh <-chan time.Time) (tx *transaction.ValidTransaction)
+	Peek() *transaction.ValidTransaction
 }
 
 // EpochState is the interface for epoch methods
@@ -28,6 +28,12 @@ type Slot struct {
 	number   uint64
 }
 
+// authoringDeadline returns the time after which no more extrinsics are applied to the
+// block authored in the slot, leaving the last third of the slot to seal and propagate it.
+func (s Slot) authoringDeadline() time.Time {
+	return s.start.Add(s.duration - s.duration/3)
+}
+
 // NewSlot returns a new Slot
 func NewSlot(start time.Time, duration time.Duration, number uint64) *Slot {
 	return &Slot{
@@ -37,6 +43,16 @@ func NewSlot(start time.Time, duration time.Duration, number uint64) *Slot {
 	}
 }
 
+// Start returns the time the slot starts at.
+func (s Slot) Start() time.Time {
+	return s.start
+}
+
+// Number returns the number of the slot.
+func (s Slot) Number() uint64 {
+	return s.number
+}
+
 func (s Slot) String() string {
 	return fmt.Sprintf("slot number %d started at %s for a duration of %s",
 		s.number, s.start, s.duration)
@@ -17,6 +17,10 @@
 
 var errEmptyKeyOwnershipProof = errors.New("key ownership proof is nil")
 
+// verifierEpochsRetained is the number of epochs, up to the latest epoch
+// cached, the information needed to verify blocks is kept in memory for.
+const verifierEpochsRetained = 3
+
 // verifierInfo contains the information needed to verify blocks
 // it remains the same for an epoch
 type verifierInfo struct {
@@ -24,6 +28,9 @@ type verifierInfo struct {
 	randomness     Randomness
 	threshold      *scale.Uint128
 	secondarySlots bool
+	// config is the BABE configuration of the epoch, which the next epoch
+	// keeps unless a next config data digest changes it.
+	config types.ConfigData
 }
 
 // onDisabledInfo contains information about an authority that's been disabled at a certain
@@ -128,6 +135,152 @@ func (v *VerificationManager) SetOnDisabled(index uint32, header *types.Header)
 // It checks the next epoch and config data stored in memory only if it cannot retrieve the data from database
 // It returns an error if the block is invalid.
 func (v *VerificationManager) VerifyBlock(header *types.Header) error {
+	verifier, err := v.getVerifier(header)
+	if err != nil {
+		return err
+	}
+
+	if verifier == nil {
+		return nil
+	}
+
+	err = verifier.verifyAuthorshipRight(header)
+	if err != nil {
+		return err
+	}
+
+	v.cacheNextEpochInfo(verifier.epoch, header)
+	return nil
+}
+
+// cacheNextEpochInfo caches the information needed to verify the blocks of the next epoch
+// from the next epoch data digest of the verified block header given, if any, so the headers
+// of the next epoch are verified without fetching the epoch data from the epoch state.
+// The information of the epochs too old to be verified anymore is dropped.
+func (v *VerificationManager) cacheNextEpochInfo(epoch uint64, header *types.Header) {
+	nextEpochData, nextConfigData, err := getNextEpochDigests(header)
+	if err != nil {
+		logger.Debugf("failed to get next epoch digests of block %s: %s", header.Hash(), err)
+		return
+	}
+
+	if nextEpochData == nil {
+		return
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	nextEpoch := epoch + 1
+	if _, has := v.epochInfo[nextEpoch]; has {
+		return
+	}
+
+	info, has := v.epochInfo[epoch]
+	if !has {
+		return
+	}
+
+	config := info.config
+	if nextConfigData != nil {
+		config = *nextConfigData.ToConfigData()
+	}
+
+	threshold, err := CalculateThreshold(config.C1, config.C2, len(nextEpochData.Authorities))
+	if err != nil {
+		logger.Debugf("failed to calculate threshold for epoch %d: %s", nextEpoch, err)
+		return
+	}
+
+	v.epochInfo[nextEpoch] = &verifierInfo{
+		authorities:    nextEpochData.Authorities,
+		randomness:     nextEpochData.Randomness,
+		threshold:      threshold,
+		secondarySlots: config.SecondarySlots > 0,
+		config:         config,
+	}
+
+	for cachedEpoch := range v.epochInfo {
+		if cachedEpoch+verifierEpochsRetained <= nextEpoch {
+			delete(v.epochInfo, cachedEpoch)
+			delete(v.onDisabled, cachedEpoch)
+		}
+	}
+}
+
+// getNextEpochDigests returns the next epoch data and the next config data
+// of the BABE consensus digests of the header given, if any.
+func getNextEpochDigests(header *types.Header) (
+	nextEpochData *types.NextEpochData, nextConfigData *types.NextConfigDataV1, err error) {
+	for _, digestItem := range header.Digest {
+		digestValue, err := digestItem.Value()
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting digest value: %w", err)
+		}
+
+		consensusDigest, ok := digestValue.(types.ConsensusDigest)
+		if !ok || consensusDigest.ConsensusEngineID != types.BabeEngineID {
+			continue
+		}
+
+		babeDigest := types.NewBabeConsensusDigest()
+		err = scale.Unmarshal(consensusDigest.Data, &babeDigest)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decoding babe consensus digest: %w", err)
+		}
+
+		babeDigestValue, err := babeDigest.Value()
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting babe consensus digest value: %w", err)
+		}
+
+		switch value := babeDigestValue.(type) {
+		case types.NextEpochData:
+			nextEpochData = &value
+		case types.VersionedNextConfigData:
+			versionedValue, err := value.Value()
+			if err != nil {
+				return nil, nil, fmt.Errorf("getting next config data value: %w", err)
+			}
+
+			configData, ok := versionedValue.(types.NextConfigDataV1)
+			if !ok {
+				return nil, nil, fmt.Errorf("next config data version not supported: %T", versionedValue)
+			}
+			nextConfigData = &configData
+		}
+	}
+
+	return nextEpochData, nextConfigData, nil
+}
+
+// VerifyBlockAnnounce verifies the slot claim and the seal of an announced block header,
+// which is cheap compared to the verification of the block on import, so peers announcing
+// invalid headers can be punished before the block is requested.
+// Unlike VerifyBlock, it does not check for equivocations, and it does not return an error
+// for a header of an epoch whose data is not known yet, since it cannot be verified yet.
+func (v *VerificationManager) VerifyBlockAnnounce(header *types.Header) error {
+	_, _, err := getAuthorityIndexAndSlot(header)
+	if err != nil {
+		return err
+	}
+
+	verifier, err := v.getVerifier(header)
+	if err != nil {
+		logger.Debugf("cannot verify announced block #%d (%s) yet: %s", header.Number, header.Hash(), err)
+		return nil
+	}
+
+	if verifier == nil {
+		return nil
+	}
+
+	return verifier.verifySlotClaimAndSeal(header)
+}
+
+// getVerifier returns the verifier for the epoch of the block header given.
+// It returns a nil verifier if the verification of the block can be skipped.
+func (v *VerificationManager) getVerifier(header *types.Header) (*verifier, error) {
 	var (
 		info *verifierInfo
 		has  bool
@@ -135,7 +288,7 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 
 	epoch, err := v.epochState.GetEpochForBlock(header)
 	if err != nil {
-		return fmt.Errorf("failed to get epoch for block header: %w", err)
+		return nil, fmt.Errorf("failed to get epoch for block header: %w", err)
 	}
 
 	v.lock.Lock()
@@ -148,14 +301,14 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 			// thus missing the epoch data for previous epochs.
 			skip, skipErr := v.epochState.SkipVerify(header)
 			if skipErr != nil {
-				return fmt.Errorf("failed to check if verification can be skipped: %w", skipErr)
+				return nil, fmt.Errorf("failed to check if verification can be skipped: %w", skipErr)
 			}
 
 			if skip {
-				return nil
+				return nil, nil
 			}
 
-			return fmt.Errorf("failed to get verifier info for block %d: %w", header.Number, err)
+			return nil, fmt.Errorf("failed to get verifier info for block %d: %w", header.Number, err)
 		}
 
 		v.epochInfo[epoch] = info
@@ -164,11 +317,10 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 	v.lock.Unlock()
 	slotDuration, err := v.epochState.GetSlotDuration()
 	if err != nil {
-		return fmt.Errorf("getting current slot duration: %w", err)
+		return nil, fmt.Errorf("getting current slot duration: %w", err)
 	}
 
-	verifier := newVerifier(v.blockState, v.slotState, epoch, info, slotDuration)
-	return verifier.verifyAuthorshipRight(header)
+	return newVerifier(v.blockState, v.slotState, epoch, info, slotDuration), nil
 }
 
 func (v *VerificationManager) getVerifierInfo(epoch uint64, header *types.Header) (*verifierInfo, error) {
@@ -192,6 +344,7 @@ func (v *VerificationManager) getVerifierInfo(epoch uint64, header *types.Header
 		randomness:     epochData.Randomness,
 		threshold:      threshold,
 		secondarySlots: configData.SecondarySlots > 0,
+		config:         *configData,
 	}, nil
 }
 
@@ -224,6 +377,16 @@ func newVerifier(blockState BlockState, slotState SlotState,
 
 // verifyAuthorshipRight verifies that the authority that produced a block was authorized to produce it.
 func (b *verifier) verifyAuthorshipRight(header *types.Header) error {
+	return b.verifyHeader(header, true)
+}
+
+// verifySlotClaimAndSeal verifies the slot claim and the seal of the block header,
+// without checking if its producer equivocated.
+func (b *verifier) verifySlotClaimAndSeal(header *types.Header) error {
+	return b.verifyHeader(header, false)
+}
+
+func (b *verifier) verifyHeader(header *types.Header, checkEquivocation bool) error {
 	// header should have 2 digest items (possibly more in the future)
 	// first item should be pre-digest, second should be seal
 	if len(header.Digest) < 2 {
@@ -321,6 +484,10 @@ func (b *verifier) verifyAuthorshipRight(header *types.Header) error {
 		return ErrBadSignature
 	}
 
+	if !checkEquivocation {
+		return nil
+	}
+
 	equivocated, err := b.verifyBlockEquivocation(header)
 	if err != nil {
 		return fmt.Errorf("could not verify block equivocation: %w", err)
@@ -497,7 +664,7 @@ func (b *verifier) verifyPrimarySlotWinner(authorityIndex uint32,
 		vrfOutput, vrfProof[:])
 
 	t := makeTranscript(b.randomness, slot, b.epoch)
-	return pk.VrfVerify(t, vrfOutput, vrfProof)
+	return pk.VerifyVrf(t, sr25519.VrfSignature{Output: vrfOutput, Proof: vrfProof})
 }
 
 func getAuthorityIndexAndSlot(header *types.Header) (authIdx uint32, slot uint64, err error) {
@@ -1286,6 +1286,10 @@ func TestVerificationManager_getVerifierInfo(t *testing.T) {
 			vm:   vm3,
 			exp: &verifierInfo{
 				threshold: scale.MaxUint128,
+				config: types.ConfigData{
+					C1: 1,
+					C2: 3,
+				},
 			},
 		},
 	}
@@ -1412,6 +1416,102 @@ func TestVerificationManager_VerifyBlock(t *testing.T) {
 	}
 }
 
+func TestVerificationManager_VerifyBlockAnnounce(t *testing.T) {
+	t.Parallel()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	authority := types.NewAuthority(kp.Public(), uint64(1))
+	info := &verifierInfo{
+		authorities:    []types.AuthorityRaw{*authority.ToRaw(), *authority.ToRaw()},
+		threshold:      scale.MaxUint128,
+		secondarySlots: true,
+	}
+
+	preRuntimeDigest, err := types.BabeSecondaryPlainPreDigest{
+		AuthorityIndex: 1,
+		SlotNumber:     1,
+	}.ToPreRuntimeDigest()
+	require.NoError(t, err)
+
+	newSealedHeader := func(t *testing.T, sign bool) *types.Header {
+		header := newTestHeader(t, *preRuntimeDigest)
+		hash := encodeAndHashHeader(t, header)
+		if !sign {
+			hash = common.Hash{1}
+		}
+		signAndAddSeal(t, kp, header, hash[:])
+		return header
+	}
+
+	errTestGetEpochData := errors.New("test get epoch data error")
+
+	testCases := map[string]struct {
+		header         *types.Header
+		epochStateMock func(ctrl *gomock.Controller, header *types.Header) EpochState
+		knownInfo      bool
+		errWrapped     error
+	}{
+		"missing_pre_runtime_digest": {
+			header: types.NewEmptyHeader(),
+			epochStateMock: func(*gomock.Controller, *types.Header) EpochState {
+				return nil
+			},
+			errWrapped: errNoDigest,
+		},
+		"unknown_epoch_data": {
+			header: newSealedHeader(t, false),
+			epochStateMock: func(ctrl *gomock.Controller, header *types.Header) EpochState {
+				epochState := NewMockEpochState(ctrl)
+				epochState.EXPECT().GetEpochForBlock(header).Return(uint64(1), nil)
+				epochState.EXPECT().GetEpochDataRaw(uint64(1), header).Return(nil, errTestGetEpochData)
+				epochState.EXPECT().SkipVerify(header).Return(false, nil)
+				return epochState
+			},
+		},
+		"bad_signature": {
+			header: newSealedHeader(t, false),
+			epochStateMock: func(ctrl *gomock.Controller, header *types.Header) EpochState {
+				epochState := NewMockEpochState(ctrl)
+				epochState.EXPECT().GetEpochForBlock(header).Return(uint64(1), nil)
+				epochState.EXPECT().GetSlotDuration().Return(6*time.Second, nil)
+				return epochState
+			},
+			knownInfo:  true,
+			errWrapped: ErrBadSignature,
+		},
+		"valid_header": {
+			header: newSealedHeader(t, true),
+			epochStateMock: func(ctrl *gomock.Controller, header *types.Header) EpochState {
+				epochState := NewMockEpochState(ctrl)
+				epochState.EXPECT().GetEpochForBlock(header).Return(uint64(1), nil)
+				epochState.EXPECT().GetSlotDuration().Return(6*time.Second, nil)
+				return epochState
+			},
+			knownInfo: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			// the slot state is not used since equivocations are not checked
+			slotState := NewMockSlotState(ctrl)
+			vm := NewVerificationManager(nil, slotState, testCase.epochStateMock(ctrl, testCase.header))
+			if testCase.knownInfo {
+				vm.epochInfo[1] = info
+			}
+
+			err := vm.VerifyBlockAnnounce(testCase.header)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
+
 func TestVerificationManager_SetOnDisabled(t *testing.T) {
 	// Generate keys
 	kp, err := sr25519.GenerateKeypair()
@@ -1575,3 +1675,153 @@ type args struct {
 		})
 	}
 }
+
+func TestVerificationManager_cacheNextEpochInfo(t *testing.T) {
+	t.Parallel()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	authority := types.NewAuthority(kp.Public(), uint64(1))
+
+	nextEpochData := types.NextEpochData{
+		Authorities: []types.AuthorityRaw{*authority.ToRaw()},
+		Randomness:  [types.RandomnessLength]byte{1},
+	}
+	babeConsensusDigestNextEpoch := types.NewBabeConsensusDigest()
+	require.NoError(t, babeConsensusDigestNextEpoch.SetValue(nextEpochData))
+	encNextEpoch, err := scale.Marshal(babeConsensusDigestNextEpoch)
+	require.NoError(t, err)
+
+	versionedNextConfigData := types.NewVersionedNextConfigData()
+	require.NoError(t, versionedNextConfigData.SetValue(types.NextConfigDataV1{
+		C1:             1,
+		C2:             4,
+		SecondarySlots: 1,
+	}))
+	babeConsensusDigestNextConfig := types.NewBabeConsensusDigest()
+	require.NoError(t, babeConsensusDigestNextConfig.SetValue(versionedNextConfigData))
+	encNextConfig, err := scale.Marshal(babeConsensusDigestNextConfig)
+	require.NoError(t, err)
+
+	headerWithNextEpoch := types.NewEmptyHeader()
+	require.NoError(t, headerWithNextEpoch.Digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.BabeEngineID,
+		Data:              encNextEpoch,
+	}))
+
+	headerWithNextConfig := types.NewEmptyHeader()
+	require.NoError(t, headerWithNextConfig.Digest.Add(
+		types.ConsensusDigest{
+			ConsensusEngineID: types.BabeEngineID,
+			Data:              encNextEpoch,
+		},
+		types.ConsensusDigest{
+			ConsensusEngineID: types.BabeEngineID,
+			Data:              encNextConfig,
+		},
+	))
+
+	epochConfig := types.ConfigData{C1: 1, C2: 1}
+	oneQuarterThreshold, err := CalculateThreshold(1, 4, 1)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		epochInfo    map[uint64]*verifierInfo
+		onDisabled   map[uint64]map[uint32][]*onDisabledInfo
+		epoch        uint64
+		header       *types.Header
+		expEpochInfo map[uint64]*verifierInfo
+		expDisabled  map[uint64]map[uint32][]*onDisabledInfo
+	}{
+		"no_next_epoch_digest": {
+			epochInfo:    map[uint64]*verifierInfo{1: {config: epochConfig}},
+			onDisabled:   map[uint64]map[uint32][]*onDisabledInfo{},
+			epoch:        1,
+			header:       types.NewEmptyHeader(),
+			expEpochInfo: map[uint64]*verifierInfo{1: {config: epochConfig}},
+			expDisabled:  map[uint64]map[uint32][]*onDisabledInfo{},
+		},
+		"next_epoch_already_cached": {
+			epochInfo: map[uint64]*verifierInfo{
+				1: {config: epochConfig},
+				2: {},
+			},
+			onDisabled: map[uint64]map[uint32][]*onDisabledInfo{},
+			epoch:      1,
+			header:     headerWithNextEpoch,
+			expEpochInfo: map[uint64]*verifierInfo{
+				1: {config: epochConfig},
+				2: {},
+			},
+			expDisabled: map[uint64]map[uint32][]*onDisabledInfo{},
+		},
+		"epoch_not_cached": {
+			epochInfo:    map[uint64]*verifierInfo{},
+			onDisabled:   map[uint64]map[uint32][]*onDisabledInfo{},
+			epoch:        1,
+			header:       headerWithNextEpoch,
+			expEpochInfo: map[uint64]*verifierInfo{},
+			expDisabled:  map[uint64]map[uint32][]*onDisabledInfo{},
+		},
+		"next_epoch_keeps_config": {
+			epochInfo:  map[uint64]*verifierInfo{1: {config: epochConfig}},
+			onDisabled: map[uint64]map[uint32][]*onDisabledInfo{},
+			epoch:      1,
+			header:     headerWithNextEpoch,
+			expEpochInfo: map[uint64]*verifierInfo{
+				1: {config: epochConfig},
+				2: {
+					authorities: nextEpochData.Authorities,
+					randomness:  nextEpochData.Randomness,
+					threshold:   scale.MaxUint128,
+					config:      epochConfig,
+				},
+			},
+			expDisabled: map[uint64]map[uint32][]*onDisabledInfo{},
+		},
+		"next_epoch_with_config_prunes_old_epochs": {
+			epochInfo: map[uint64]*verifierInfo{
+				0: {},
+				1: {},
+				2: {config: epochConfig},
+			},
+			onDisabled: map[uint64]map[uint32][]*onDisabledInfo{
+				0: {0: {{blockNumber: 1}}},
+				2: {0: {{blockNumber: 2}}},
+			},
+			epoch:  2,
+			header: headerWithNextConfig,
+			expEpochInfo: map[uint64]*verifierInfo{
+				1: {},
+				2: {config: epochConfig},
+				3: {
+					authorities:    nextEpochData.Authorities,
+					randomness:     nextEpochData.Randomness,
+					threshold:      oneQuarterThreshold,
+					secondarySlots: true,
+					config:         types.ConfigData{C1: 1, C2: 4, SecondarySlots: 1},
+				},
+			},
+			expDisabled: map[uint64]map[uint32][]*onDisabledInfo{
+				2: {0: {{blockNumber: 2}}},
+			},
+		},
+	}
+
+	for name, testCase := range tests {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			verificationManager := &VerificationManager{
+				epochInfo:  testCase.epochInfo,
+				onDisabled: testCase.onDisabled,
+			}
+
+			verificationManager.cacheNextEpochInfo(testCase.epoch, testCase.header)
+
+			assert.Equal(t, testCase.expEpochInfo, verificationManager.epochInfo)
+			assert.Equal(t, testCase.expDisabled, verificationManager.onDisabled)
+		})
+	}
+}
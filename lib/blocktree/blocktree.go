@@ -45,6 +45,12 @@ type BlockTree struct {
 	leaves *leafMap
 	sync.RWMutex
 	runtimes *hashToRuntime
+	// selectBest selects the best chain, MostPrimaryBlocks if nil.
+	selectBest ChainSelection
+	// removedRuntime handles the runtime instances no longer used by
+	// the blocks of the tree once a block is finalised, which are
+	// stopped if it is nil.
+	removedRuntime func(instance runtime.Instance)
 }
 
 // NewEmptyBlockTree creates a BlockTree with a nil head
@@ -134,7 +140,7 @@ func (bt *BlockTree) GetHashesAtNumber(number uint) (hashes []common.Hash) {
 		return []common.Hash{}
 	}
 
-	bestLeave := bt.leaves.bestBlock()
+	bestLeave := bt.best()
 	if number > bestLeave.number {
 		return []common.Hash{}
 	}
@@ -273,26 +279,32 @@ func (bt *BlockTree) Prune(finalised Hash) (pruned []Hash) {
 		return pruned
 	}
 
-	// Cleanup in-memory runtimes from the canonical chain.
+	// Cleanup in-memory runtimes of the blocks removed from the tree.
 	// The runtime used in the newly finalised block is kept
-	// instantiated in memory, and all other runtimes are
-	// stopped and removed from memory. Note these are still
-	// accessible through the storage as WASM blob.
-	previousFinalisedBlock := bt.root
-	newCanonicalChainBlocksCount := n.number - previousFinalisedBlock.number
-	if previousFinalisedBlock.number == 0 { // include the genesis block
-		newCanonicalChainBlocksCount++
-	}
-	canonicalChainBlock := n
-	newCanonicalChainBlockHashes := make([]common.Hash, newCanonicalChainBlocksCount)
-	for i := int(newCanonicalChainBlocksCount) - 1; i >= 0; i-- {
-		newCanonicalChainBlockHashes[i] = canonicalChainBlock.hash
-		canonicalChainBlock = canonicalChainBlock.parent
+	// instantiated in memory, as well as the runtimes of its
+	// descendants, and all other runtimes are removed from the
+	// tree and handed to the removed runtime handler, or stopped.
+	// Note these are still accessible through the storage as WASM blob.
+	finalisedRuntime := bt.runtimes.get(n.hash)
+	var removed []Hash
+	for ancestor := n.parent; ancestor != nil; ancestor = ancestor.parent {
+		if finalisedRuntime == nil {
+			finalisedRuntime = bt.runtimes.get(ancestor.hash)
+		}
+		removed = append(removed, ancestor.hash)
 	}
 
-	bt.runtimes.onFinalisation(newCanonicalChainBlockHashes)
-
 	pruned = bt.root.prune(n, nil)
+	removed = append(removed, pruned...)
+	removedRuntimes := bt.runtimes.onFinalisation(n.hash, finalisedRuntime, removed)
+	for _, instance := range removedRuntimes {
+		if bt.removedRuntime != nil {
+			bt.removedRuntime(instance)
+		} else {
+			instance.Stop()
+		}
+	}
+
 	bt.root = n
 	bt.root.parent = nil
 
@@ -331,17 +343,52 @@ func (bt *BlockTree) String() string {
 	return fmt.Sprintf("%s\n%s\n", metadata, tree.Print())
 }
 
+// SetChainSelection sets the fork choice rule selecting the best chain of the block tree.
+func (bt *BlockTree) SetChainSelection(selectBest ChainSelection) {
+	bt.Lock()
+	defer bt.Unlock()
+	bt.selectBest = selectBest
+}
+
+// SetRemovedRuntimeHandler sets the function handling the runtime instances no longer used
+// by the blocks of the tree once a block is finalised, instead of stopping them.
+func (bt *BlockTree) SetRemovedRuntimeHandler(handle func(instance runtime.Instance)) {
+	bt.Lock()
+	defer bt.Unlock()
+	bt.removedRuntime = handle
+}
+
 // best returns the best node in the block tree using the fork choice rule.
 func (bt *BlockTree) best() *node {
-	return bt.leaves.bestBlock()
+	leaves := bt.leaves.nodes()
+	switch len(leaves) {
+	case 0:
+		return nil
+	case 1:
+		return leaves[0]
+	}
+
+	heads := make([]ChainHead, len(leaves))
+	byHash := make(map[Hash]*node, len(leaves))
+	for i, leaf := range leaves {
+		heads[i] = ChainHead{
+			Hash:          leaf.hash,
+			Number:        leaf.number,
+			PrimaryBlocks: uint(leaf.primaryAncestorCount(0)),
+			ArrivalTime:   leaf.arrivalTime,
+		}
+		byHash[leaf.hash] = leaf
+	}
+
+	selectBest := bt.selectBest
+	if selectBest == nil {
+		selectBest = MostPrimaryBlocks
+	}
+	return byHash[selectBest(heads)]
 }
 
 // BestBlockHash returns the hash of the block that is considered "best" based on the
-// fork-choice rule. It returns the head of the chain with the most primary blocks.
-// If there are multiple chains with the same number of primaries, it returns the one
-// with the highest head number.
-// If there are multiple chains with the same number of primaries and the same height,
-// it returns the one with the head block that arrived the earliest.
+// fork-choice rule, MostPrimaryBlocks unless set otherwise with SetChainSelection.
 func (bt *BlockTree) BestBlockHash() Hash {
 	bt.RLock()
 	defer bt.RUnlock()
@@ -462,7 +509,7 @@ func (bt *BlockTree) GetHashByNumber(num uint) (common.Hash, error) {
 	bt.RLock()
 	defer bt.RUnlock()
 
-	best := bt.leaves.bestBlock()
+	best := bt.best()
 	if best.number < num {
 		return common.Hash{}, ErrNumGreaterThanHighest
 	}
@@ -511,7 +558,7 @@ func (bt *BlockTree) DeepCopy() *BlockTree {
 	bt.RLock()
 	defer bt.RUnlock()
 
-	btCopy := &BlockTree{}
+	btCopy := &BlockTree{selectBest: bt.selectBest, removedRuntime: bt.removedRuntime}
 
 	if bt.root == nil {
 		return btCopy
@@ -536,6 +583,26 @@ func (bt *BlockTree) StoreRuntime(hash common.Hash, instance runtime.Instance) {
 	bt.runtimes.set(hash, instance)
 }
 
+// StoreBlockRuntime stores the runtime for the block hash given if the block is still in the
+// block tree, and returns false otherwise, for example if its fork was pruned while the runtime
+// was being instantiated, since the runtime of a block not in the tree is never evicted.
+func (bt *BlockTree) StoreBlockRuntime(hash common.Hash, instance runtime.Instance) (stored bool) {
+	bt.RLock()
+	defer bt.RUnlock()
+
+	if bt.getNode(hash) == nil {
+		return false
+	}
+	bt.runtimes.set(hash, instance)
+	return true
+}
+
+// GetRuntimeByCodeHash returns a runtime instance of the code with the given hash used by
+// a block of the tree, or nil if there is none.
+func (bt *BlockTree) GetRuntimeByCodeHash(codeHash common.Hash) runtime.Instance {
+	return bt.runtimes.getByCodeHash(codeHash)
+}
+
 // GetBlockRuntime returns the runtime corresponding to the given block hash. If there is no instance for
 // the given block hash it will lookup an instance of an ancestor and return it.
 func (bt *BlockTree) GetBlockRuntime(hash common.Hash) (runtime.Instance, error) {
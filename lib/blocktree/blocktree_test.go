@@ -776,6 +776,74 @@ func Test_BlockTree_Prune(t *testing.T) {
 		}
 		assert.Equal(t, expectedHashToRuntime, blockTree.runtimes)
 	})
+
+	t.Run("keep_descendant_runtimes", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		// {0x00} -> {0x01} -> {0x02} -> {0x03}
+		blockTree := buildLinearBlockTree(t, 4)
+		rootRuntime := NewMockInstance(ctrl)
+		blockTree.runtimes.set(common.MustHexToHash("0x00"), rootRuntime)
+		// the runtime upgraded by an unfinalised block is kept
+		// to import its descendants with the upgraded code
+		upgradedRuntime := NewMockInstance(ctrl)
+		blockTree.runtimes.set(common.MustHexToHash("0x03"), upgradedRuntime)
+
+		pruned := blockTree.Prune(common.MustHexToHash("0x02"))
+		assert.Empty(t, pruned)
+
+		expectedHashToRuntime := &hashToRuntime{
+			mapping: map[common.Hash]runtime.Instance{
+				common.MustHexToHash("0x02"): rootRuntime,
+				common.MustHexToHash("0x03"): upgradedRuntime,
+			},
+		}
+		assert.Equal(t, expectedHashToRuntime, blockTree.runtimes)
+
+		instance, err := blockTree.GetBlockRuntime(common.MustHexToHash("0x03"))
+		require.NoError(t, err)
+		assert.Equal(t, upgradedRuntime, instance)
+	})
+
+	t.Run("removed_runtime_handler", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		// {0x00} -> {0x01} -> {0x02}
+		blockTree := buildLinearBlockTree(t, 3)
+		previousRuntime := NewMockInstance(ctrl)
+		blockTree.runtimes.set(common.MustHexToHash("0x00"), previousRuntime)
+		finalisedRuntime := NewMockInstance(ctrl)
+		blockTree.runtimes.set(common.MustHexToHash("0x01"), finalisedRuntime)
+
+		// the runtime removed is handed to the handler instead of being stopped
+		var removed []runtime.Instance
+		blockTree.SetRemovedRuntimeHandler(func(instance runtime.Instance) {
+			removed = append(removed, instance)
+		})
+
+		blockTree.Prune(common.MustHexToHash("0x01"))
+		assert.Equal(t, []runtime.Instance{previousRuntime}, removed)
+	})
+}
+
+func Test_BlockTree_StoreBlockRuntime(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	blockTree := buildLinearBlockTree(t, 2)
+	instance := NewMockInstance(ctrl)
+	instance.EXPECT().GetCodeHash().Return(common.Hash{1}).AnyTimes()
+
+	stored := blockTree.StoreBlockRuntime(common.MustHexToHash("0x01"), instance)
+	assert.True(t, stored)
+	assert.Equal(t, instance, blockTree.GetRuntimeByCodeHash(common.Hash{1}))
+
+	// the block of a pruned fork is no longer in the tree
+	stored = blockTree.StoreBlockRuntime(common.Hash{9}, instance)
+	assert.False(t, stored)
+	assert.Nil(t, blockTree.runtimes.get(common.Hash{9}))
 }
 
 func Test_BlockTree_GetHashByNumber(t *testing.T) {
@@ -1058,3 +1126,33 @@ func BenchmarkBlockTreeSubBlockchain(b *testing.B) {
 	}
 
 }
+
+func Test_BlockTree_SetChainSelection(t *testing.T) {
+	t.Parallel()
+
+	bt := NewEmptyBlockTree()
+	bt.root = &node{
+		hash: common.Hash{0},
+	}
+	primary := &node{hash: common.Hash{1}, parent: bt.root, number: 1, isPrimary: true}
+	secondary := &node{hash: common.Hash{2}, parent: bt.root, number: 1}
+	bt.root.children = []*node{primary, secondary}
+	bt.leaves = newEmptyLeafMap()
+	bt.leaves.store(primary.hash, primary)
+	bt.leaves.store(secondary.hash, secondary)
+	require.Equal(t, primary.hash, bt.BestBlockHash())
+
+	var selectedFrom []ChainHead
+	bt.SetChainSelection(func(heads []ChainHead) common.Hash {
+		selectedFrom = heads
+		return secondary.hash
+	})
+	require.Equal(t, secondary.hash, bt.BestBlockHash())
+	require.ElementsMatch(t, []ChainHead{
+		{Hash: primary.hash, Number: 1, PrimaryBlocks: 1},
+		{Hash: secondary.hash, Number: 1},
+	}, selectedFrom)
+
+	// the chain selection is kept by the copies
+	require.Equal(t, secondary.hash, bt.DeepCopy().BestBlockHash())
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package blocktree
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// ChainHead is the head of a chain of the block tree, a leaf, as seen by the fork choice rule.
+type ChainHead struct {
+	Hash   common.Hash
+	Number uint
+	// PrimaryBlocks is the number of blocks of the chain authored in a primary slot,
+	// not counting the root of the block tree.
+	PrimaryBlocks uint
+	// ArrivalTime is the time the head block was added to the block tree.
+	ArrivalTime time.Time
+}
+
+// ChainSelection is a fork choice rule, returning the hash of the head of the
+// best chain among the chain heads given, which contain at least two heads.
+type ChainSelection func(heads []ChainHead) common.Hash
+
+// MostPrimaryBlocks is the BABE fork choice rule. It returns the head of the chain with the most
+// primary blocks. If there are multiple chains with the same number of primaries, it returns the
+// one with the highest head number. If there are multiple chains with the same number of primaries
+// and the same height, it returns the one with the head block that arrived the earliest, and then
+// the one with the lowest head hash.
+func MostPrimaryBlocks(heads []ChainHead) common.Hash {
+	best := heads[0]
+	for _, head := range heads[1:] {
+		if isBetterHead(head, best) {
+			best = head
+		}
+	}
+	return best.Hash
+}
+
+func isBetterHead(head, than ChainHead) bool {
+	switch {
+	case head.PrimaryBlocks != than.PrimaryBlocks:
+		return head.PrimaryBlocks > than.PrimaryBlocks
+	case head.Number != than.Number:
+		return head.Number > than.Number
+	case !head.ArrivalTime.Equal(than.ArrivalTime):
+		return head.ArrivalTime.Before(than.ArrivalTime)
+	default:
+		// practically, this is very unlikely to happen.
+		return bytes.Compare(head.Hash[:], than.Hash[:]) < 0
+	}
+}
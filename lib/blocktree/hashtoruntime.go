@@ -49,60 +49,53 @@ func (h *hashToRuntime) hashes() (hashes []common.Hash) {
 	return maps.Keys(h.mapping)
 }
 
+// getByCodeHash returns a runtime instance of the code with the given hash, or nil if
+// there is none, so the blocks enacting the same code on different forks share it.
+func (h *hashToRuntime) getByCodeHash(codeHash Hash) (instance runtime.Instance) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for _, instance := range h.mapping {
+		if instance != nil && instance.GetCodeHash() == codeHash {
+			return instance
+		}
+	}
+	return nil
+}
+
 // onFinalisation handles pruning and recording on block finalisation.
-// newCanonicalBlockHashes is the block hashes of the blocks newly finalised.
-// The last element is the finalised block hash.
-func (h *hashToRuntime) onFinalisation(newCanonicalBlockHashes []common.Hash) {
+// The runtime instance given is kept for the finalised block hash given,
+// the runtime instances of the blocks removed from the block tree are
+// deleted, and the instances no longer used by any block are returned.
+// The runtime instances of the descendants of the finalised block are
+// kept, since they may use a different code than the finalised block.
+func (h *hashToRuntime) onFinalisation(finalisedHash Hash, finalisedRuntime runtime.Instance,
+	removedHashes []Hash) (removedRuntimes []runtime.Instance) {
 	h.mutex.Lock()
 	defer h.mutex.Unlock()
 
-	if len(h.mapping) == 0 {
-		logger.Warnf("no runtimes in the mapping")
-		return
+	removed := make(map[runtime.Instance]struct{})
+	for _, hash := range removedHashes {
+		instance, ok := h.mapping[hash]
+		if !ok {
+			continue
+		}
+		delete(h.mapping, hash)
+		if instance != nil {
+			removed[instance] = struct{}{}
+		}
 	}
 
-	defer func() {
-		totalInMemoryRuntimes := len(h.mapping)
-		inMemoryRuntimesGauge.Set(float64(totalInMemoryRuntimes))
-	}()
-
-	finalisedHash := newCanonicalBlockHashes[len(newCanonicalBlockHashes)-1]
-	// if there is only one runtime in the mapping then we should update
-	// its key so we don't need to lookup the entire chain in order to find the ancestry
-	if len(h.mapping) == 1 {
-		uniqueAvailableInstance := maps.Values(h.mapping)[0]
-
-		h.mapping = make(map[Hash]runtime.Instance)
-		h.mapping[finalisedHash] = uniqueAvailableInstance
-		return
+	if finalisedRuntime != nil {
+		h.mapping[finalisedHash] = finalisedRuntime
 	}
 
-	// we procced from backwards since the last element in the newCanonicalBlockHashes
-	// is the finalized one, verifying if there is a runtime instance closest to the finalized
-	// hash. When we find it we clear all the map entries and keeping only the instance found
-	// with the finalised hash as the key
-	lastElementIdx := len(newCanonicalBlockHashes) - 1
-	for idx := lastElementIdx; idx >= 0; idx-- {
-		currentHash := newCanonicalBlockHashes[idx]
-		inMemoryRuntime := h.mapping[currentHash]
-
-		if inMemoryRuntime != nil {
-			// stop all the running instances created by forks keeping
-			// just the closest instance to the finalized block hash
-			stoppedRuntimes := make(map[runtime.Instance]struct{})
-			for _, runtimeToPrune := range h.mapping {
-				if inMemoryRuntime != runtimeToPrune {
-					_, stopped := stoppedRuntimes[runtimeToPrune]
-					if !stopped {
-						runtimeToPrune.Stop()
-						stoppedRuntimes[runtimeToPrune] = struct{}{}
-					}
-				}
-			}
-
-			h.mapping = make(map[Hash]runtime.Instance)
-			h.mapping[finalisedHash] = inMemoryRuntime
-			return
-		}
+	// an instance can be shared by blocks of different forks,
+	// so it is only removed once no block uses it anymore.
+	for _, instance := range h.mapping {
+		delete(removed, instance)
 	}
+
+	inMemoryRuntimesGauge.Set(float64(len(h.mapping)))
+	return maps.Keys(removed)
 }
@@ -181,22 +181,50 @@ func Test_hashToRuntime_delete(t *testing.T) {
 	}
 }
 
+func Test_hashToRuntime_getByCodeHash(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	instance := NewMockInstance(ctrl)
+	instance.EXPECT().GetCodeHash().Return(common.Hash{1}).AnyTimes()
+	otherInstance := NewMockInstance(ctrl)
+	otherInstance.EXPECT().GetCodeHash().Return(common.Hash{2}).AnyTimes()
+
+	htr := &hashToRuntime{
+		mapping: map[Hash]runtime.Instance{
+			{1}: instance,
+			{2}: otherInstance,
+			{3}: otherInstance,
+		},
+	}
+
+	assert.Equal(t, instance, htr.getByCodeHash(common.Hash{1}))
+	assert.Equal(t, otherInstance, htr.getByCodeHash(common.Hash{2}))
+	assert.Nil(t, htr.getByCodeHash(common.Hash{3}))
+}
+
 func Test_hashToRuntime_onFinalisation(t *testing.T) {
 	t.Parallel()
 
 	testCases := map[string]struct {
-		makeParameters          func(ctrl *gomock.Controller) (initial, expected *hashToRuntime)
-		newCanonicalBlockHashes []Hash
+		makeParameters func(ctrl *gomock.Controller) (initial, expected *hashToRuntime,
+			finalisedRuntime runtime.Instance, removedRuntimes []runtime.Instance)
+		finalisedHash Hash
+		removedHashes []Hash
 	}{
 		"new_finalised_runtime_not_found": {
-			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime) {
-				return &hashToRuntime{}, &hashToRuntime{}
+			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime,
+				finalisedRuntime runtime.Instance, removedRuntimes []runtime.Instance) {
+				initial = &hashToRuntime{mapping: map[Hash]runtime.Instance{}}
+				expected = &hashToRuntime{mapping: map[Hash]runtime.Instance{}}
+				return initial, expected, nil, nil
 			},
-			newCanonicalBlockHashes: []Hash{{1}},
+			finalisedHash: Hash{1},
 		},
-		"prune_fork_runtime_with_a_unique_instance": {
-			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime) {
-				finalisedRuntime := NewMockInstance(ctrl)
+		"finalised_runtime_of_an_ancestor": {
+			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime,
+				finalisedRuntime runtime.Instance, removedRuntimes []runtime.Instance) {
+				finalisedRuntime = NewMockInstance(ctrl)
 				initial = &hashToRuntime{
 					mapping: map[Hash]runtime.Instance{
 						{1}: finalisedRuntime,
@@ -209,15 +237,16 @@ func Test_hashToRuntime_onFinalisation(t *testing.T) {
 						{2}: finalisedRuntime,
 					},
 				}
-				return initial, expected
+				return initial, expected, finalisedRuntime, nil
 			},
-			newCanonicalBlockHashes: []Hash{{2}},
+			finalisedHash: Hash{2},
+			removedHashes: []Hash{{1}},
 		},
 		"prune_fork_runtimes_only": {
-			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime) {
-				finalisedRuntime := NewMockInstance(ctrl)
+			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime,
+				finalisedRuntime runtime.Instance, removedRuntimes []runtime.Instance) {
+				finalisedRuntime = NewMockInstance(ctrl)
 				prunedForkRuntime := NewMockInstance(ctrl)
-				prunedForkRuntime.EXPECT().Stop()
 				initial = &hashToRuntime{
 					mapping: map[Hash]runtime.Instance{
 						{1}: finalisedRuntime,
@@ -229,58 +258,62 @@ func Test_hashToRuntime_onFinalisation(t *testing.T) {
 						{1}: finalisedRuntime,
 					},
 				}
-				return initial, expected
+				return initial, expected, finalisedRuntime, []runtime.Instance{prunedForkRuntime}
 			},
-			newCanonicalBlockHashes: []Hash{{1}},
+			finalisedHash: Hash{1},
+			removedHashes: []Hash{{3}},
 		},
-		"new_canonical_block_hash_not_found": {
-			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime) {
-				newFinalisedRuntime := NewMockInstance(ctrl)
+		"keep_runtime_shared_with_finalised_chain": {
+			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime,
+				finalisedRuntime runtime.Instance, removedRuntimes []runtime.Instance) {
+				finalisedRuntime = NewMockInstance(ctrl)
 				initial = &hashToRuntime{
 					mapping: map[Hash]runtime.Instance{
-						// missing {1}
-						{2}: newFinalisedRuntime,
+						{1}: finalisedRuntime,
+						// the fork enacts the same code as the finalised chain
+						{3}: finalisedRuntime,
 					},
 				}
 				expected = &hashToRuntime{
 					mapping: map[Hash]runtime.Instance{
-						{2}: newFinalisedRuntime,
+						{2}: finalisedRuntime,
 					},
 				}
-				return initial, expected
+				return initial, expected, finalisedRuntime, nil
 			},
-			newCanonicalBlockHashes: []Hash{{1}, {2}},
+			finalisedHash: Hash{2},
+			removedHashes: []Hash{{1}, {3}},
 		},
-		"prune_fork_and_canonical_runtimes": {
-			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime) {
-				finalisedRuntime := NewMockInstance(ctrl)
-				unfinalisedRuntime := NewMockInstance(ctrl)
-				newFinalisedRuntime := NewMockInstance(ctrl)
+		"keep_runtimes_of_descendants": {
+			makeParameters: func(ctrl *gomock.Controller) (initial, expected *hashToRuntime,
+				finalisedRuntime runtime.Instance, removedRuntimes []runtime.Instance) {
+				previousRuntime := NewMockInstance(ctrl)
+				finalisedRuntime = NewMockInstance(ctrl)
+				descendantRuntime := NewMockInstance(ctrl)
 				prunedForkRuntime := NewMockInstance(ctrl)
 
-				finalisedRuntime.EXPECT().Stop()
-				unfinalisedRuntime.EXPECT().Stop()
-				prunedForkRuntime.EXPECT().Stop()
-
 				initial = &hashToRuntime{
 					mapping: map[Hash]runtime.Instance{
 						// Previously finalised chain
-						{0}: finalisedRuntime,
+						{0}: previousRuntime,
 						// Newly finalised chain
-						{3}: unfinalisedRuntime,
-						{5}: newFinalisedRuntime,
+						{3}: finalisedRuntime,
+						// Unfinalised descendant upgrading the runtime
+						{7}: descendantRuntime,
 						// Runtimes from forks
 						{100}: prunedForkRuntime,
 					},
 				}
 				expected = &hashToRuntime{
 					mapping: map[Hash]runtime.Instance{
-						{6}: newFinalisedRuntime,
+						{5}: finalisedRuntime,
+						{7}: descendantRuntime,
 					},
 				}
-				return initial, expected
+				return initial, expected, finalisedRuntime, []runtime.Instance{previousRuntime, prunedForkRuntime}
 			},
-			newCanonicalBlockHashes: []Hash{{2}, {3}, {4}, {5}, {6}},
+			finalisedHash: Hash{5},
+			removedHashes: []Hash{{4}, {3}, {2}, {0}, {100}},
 		},
 	}
 
@@ -290,10 +323,11 @@ func Test_hashToRuntime_onFinalisation(t *testing.T) {
 			t.Parallel()
 			ctrl := gomock.NewController(t)
 
-			htr, expectedHtr := testCase.makeParameters(ctrl)
-			htr.onFinalisation(testCase.newCanonicalBlockHashes)
+			htr, expectedHtr, finalisedRuntime, expectedRemoved := testCase.makeParameters(ctrl)
+			removedRuntimes := htr.onFinalisation(testCase.finalisedHash, finalisedRuntime, testCase.removedHashes)
 
 			assert.Equal(t, expectedHtr, htr)
+			assert.ElementsMatch(t, expectedRemoved, removedRuntimes)
 		})
 	}
 }
@@ -4,7 +4,6 @@
 package blocktree
 
 import (
-	"bytes"
 	"fmt"
 	"sync"
 
@@ -55,42 +54,6 @@ func (lm *leafMap) replace(oldNode, newNode *node) {
 	lm.store(newNode.hash, newNode)
 }
 
-// highestLeaf searches the stored leaves to the find the one with the greatest number.
-// If there are two leaves with the same number, choose the one with the earliest arrival time.
-func (lm *leafMap) highestLeaf() *node {
-	lm.RLock()
-	defer lm.RUnlock()
-
-	var max uint
-
-	var deepest *node
-	lm.smap.Range(func(h, n interface{}) bool {
-		node := n.(*node)
-		if node == nil {
-			// this should never happen
-			return true
-		}
-
-		if max < node.number {
-			max = node.number
-			deepest = node
-		} else if max == node.number && node.arrivalTime.Before(deepest.arrivalTime) {
-			deepest = node
-		} else if max == node.number && node.arrivalTime.Equal(deepest.arrivalTime) {
-			// there are two leaf nodes with the same number *and* arrival time, just pick the one
-			// with the lower hash in lexicographical order.
-			// practically, this is very unlikely to happen.
-			if bytes.Compare(node.hash[:], deepest.hash[:]) < 0 {
-				deepest = node
-			}
-		}
-
-		return true
-	})
-
-	return deepest
-}
-
 func (lm *leafMap) toMap() map[common.Hash]*node {
 	lm.RLock()
 	defer lm.RUnlock()
@@ -121,43 +84,3 @@ func (lm *leafMap) nodes() []*node {
 
 	return nodes
 }
-
-func (lm *leafMap) bestBlock() *node {
-	lm.RLock()
-	defer lm.RUnlock()
-
-	// map of primary ancestor count -> *node
-	counts := make(map[int][]*node)
-	highest := 0
-
-	lm.smap.Range(func(_, nn interface{}) bool {
-		n := nn.(*node)
-		count := n.primaryAncestorCount(0)
-		if count > highest {
-			highest = count
-		}
-
-		nodesWithCount, has := counts[count]
-		if !has {
-			counts[count] = []*node{n}
-		} else {
-			counts[count] = append(nodesWithCount, n)
-		}
-
-		return true
-	})
-
-	// there's just one node with the highest amount of primary ancestors,
-	// so let's return it
-	if len(counts[highest]) == 1 {
-		return counts[highest][0]
-	}
-
-	// there are multple with the highest count, run them through `highestLeaf`
-	lm2 := newEmptyLeafMap()
-	for _, node := range counts[highest] {
-		lm2.store(node.hash, node)
-	}
-
-	return lm2.highestLeaf()
-}
@@ -12,6 +12,19 @@ type Health struct {
 	Peers           int
 	IsSyncing       bool
 	ShouldHavePeers bool
+	// Services is the health of the supervised node services, left empty by the network service.
+	Services []ServiceHealth `json:",omitempty"`
+}
+
+// ServiceHealth is the health of a node service run by the supervisor
+type ServiceHealth struct {
+	Name  string
+	State string
+	// Failures is the number of times the service failed or panicked.
+	Failures uint
+	// Restarts is the number of times the service was restarted after a failure.
+	Restarts  uint
+	LastError string `json:",omitempty"`
 }
 
 // NetworkState is network information about host needed for the rpc server and the runtime
@@ -42,4 +55,15 @@ type PeerInfo struct {
 	LightClientRole NetworkRole = 2
 	// AuthorityRole runs the node as a block-producing and finalising node
 	AuthorityRole NetworkRole = 4
+	// RPCNodeRole runs a full node which neither authors blocks nor votes and is tuned
+	// to serve RPC requests. It is announced to peers as a full node.
+	RPCNodeRole NetworkRole = 8
 )
+
+// Announced returns the role advertised to peers in handshakes.
+func (r NetworkRole) Announced() NetworkRole {
+	if r == RPCNodeRole {
+		return FullNodeRole
+	}
+	return r
+}
@@ -0,0 +1,388 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package bls381
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	bls12381 "github.com/kilic/bls12-381"
+	"golang.org/x/crypto/hkdf"
+)
+
+// PrivateKeyLength is the length of a private key, a big endian encoded scalar
+const PrivateKeyLength = 32
+
+// SeedLength is the length of the seed a keypair is generated from
+const SeedLength = 32
+
+const (
+	g1Length     = 48
+	g2Length     = 96
+	scalarLength = 32
+)
+
+// PublicKeyLength is the length of a double public key, the compressed G1 public key
+// followed by the compressed G2 public key
+const PublicKeyLength = g1Length + g2Length
+
+// SignatureLength is the length of a double signature, the compressed G1 signature
+// followed by the proof (c, s) that it shares its discrete logarithm with the G1 public key
+const SignatureLength = g1Length + 2*scalarLength
+
+var (
+	// signatureDST is the domain separation tag of the messages signed, following the
+	// minimal signature size basic scheme of the IETF BLS signature draft
+	signatureDST = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
+	// keyGenSalt is the initial salt of the IETF BLS signature draft key generation
+	keyGenSalt = []byte("BLS-SIG-KEYGEN-SALT-")
+	// proofNonceDST and proofChallengeDST are the domain separation tags of the nonces and
+	// challenges of the Chaum-Pedersen proofs
+	proofNonceDST     = []byte("BLS12381-CHAUM-PEDERSEN-NONCE")
+	proofChallengeDST = []byte("BLS12381-CHAUM-PEDERSEN-CHALLENGE")
+)
+
+var (
+	errZeroPrivateKey       = errors.New("bls381 private key is zero")
+	errMismatchedPublicKeys = errors.New("bls381 G1 and G2 public keys do not share their private key")
+)
+
+// Keypair holds the public and private BLS12-381 keys. Signatures are points of G1 and
+// public keys points of G2, as with the TinyBLS381 keys of Substrate. The public key and
+// the signature are double ones: the public key also holds its G1 point, and the signature
+// a Chaum-Pedersen proof against it, so that signatures can be checked without pairings.
+type Keypair struct {
+	public  *PublicKey
+	private *PrivateKey
+}
+
+// PublicKey is a BLS12-381 double public key
+type PublicKey struct {
+	g1 *bls12381.PointG1
+	g2 *bls12381.PointG2
+}
+
+// PrivateKey is a BLS12-381 private key
+type PrivateKey struct {
+	key *big.Int
+}
+
+// NewKeypairFromSeed returns the keypair generated from the seed given, following the
+// KeyGen procedure of the IETF BLS signature draft.
+func NewKeypairFromSeed(seed []byte) (*Keypair, error) {
+	if len(seed) != SeedLength {
+		return nil, fmt.Errorf("input to create bls381 keypair is not %d bytes", SeedLength)
+	}
+
+	order := bls12381.NewG1().Q()
+	ikm := append(append([]byte{}, seed...), 0)
+	salt := keyGenSalt
+	key := new(big.Int)
+	for key.Sign() == 0 {
+		hashedSalt := sha256.Sum256(salt)
+		salt = hashedSalt[:]
+
+		okm := make([]byte, 48)
+		_, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, []byte{0, 48}), okm)
+		if err != nil {
+			return nil, fmt.Errorf("expanding seed: %w", err)
+		}
+		key.SetBytes(okm).Mod(key, order)
+	}
+
+	return NewKeypairFromPrivate(&PrivateKey{key: key})
+}
+
+// NewKeypairFromPrivate returns the keypair of the private key given
+func NewKeypairFromPrivate(priv *PrivateKey) (*Keypair, error) {
+	pub, err := priv.Public()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Keypair{
+		public:  pub.(*PublicKey),
+		private: priv,
+	}, nil
+}
+
+// NewKeypairFromPrivateKeyString returns a Keypair given a 0x prefixed private key string
+func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
+	privBytes, err := common.HexToBytes(in)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := NewPrivateKey(privBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeypairFromPrivate(priv)
+}
+
+// NewPrivateKey returns the PrivateKey encoded by the 32 bytes given
+func NewPrivateKey(in []byte) (*PrivateKey, error) {
+	if len(in) != PrivateKeyLength {
+		return nil, fmt.Errorf("input to create bls381 private key is not %d bytes", PrivateKeyLength)
+	}
+	priv := new(PrivateKey)
+	err := priv.Decode(in)
+	return priv, err
+}
+
+// NewPublicKey returns the PublicKey of a 144 bytes encoded double public key
+func NewPublicKey(in []byte) (*PublicKey, error) {
+	if len(in) != PublicKeyLength {
+		return nil, fmt.Errorf("input to create bls381 public key is not %d bytes", PublicKeyLength)
+	}
+	pub := new(PublicKey)
+	err := pub.Decode(in)
+	return pub, err
+}
+
+// GenerateKeypair returns a new random bls381 keypair
+func GenerateKeypair() (*Keypair, error) {
+	seed := make([]byte, SeedLength)
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
+// Derive returns the keypair derived from kp along the given junctions, using the encoded
+// private key as seed. Only hard junctions are supported for bls381.
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	if len(junctions) == 0 {
+		return kp, nil
+	}
+
+	seed := kp.private.Encode()
+	for _, junction := range junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("bls381: %w", crypto.ErrSoftDerivationNotSupported)
+		}
+
+		var err error
+		seed, err = crypto.HardDeriveSeed("BLS12381HDKD", seed, junction.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
+// Type returns Bls381Type
+func (*Keypair) Type() crypto.KeyType {
+	return crypto.Bls381Type
+}
+
+// Sign signs the message given
+func (kp *Keypair) Sign(msg []byte) ([]byte, error) {
+	return kp.private.Sign(msg)
+}
+
+// Public returns the public key
+func (kp *Keypair) Public() crypto.PublicKey {
+	return kp.public
+}
+
+// Private returns the private key
+func (kp *Keypair) Private() crypto.PrivateKey {
+	return kp.private
+}
+
+// Verify returns true if the double signature of the message given is valid for the public key.
+// Both the Chaum-Pedersen proof against the G1 public key and the pairing of the signature
+// with the G2 public key are checked.
+func (k *PublicKey) Verify(msg, sig []byte) (bool, error) {
+	if len(sig) != SignatureLength {
+		return false, errors.New("invalid signature length")
+	}
+
+	g1 := bls12381.NewG1()
+	sigPoint, err := g1.FromCompressed(sig[:g1Length])
+	if err != nil {
+		return false, fmt.Errorf("decoding signature: %w", err)
+	}
+
+	order := g1.Q()
+	c := new(big.Int).SetBytes(sig[g1Length : g1Length+scalarLength])
+	s := new(big.Int).SetBytes(sig[g1Length+scalarLength:])
+	if c.Cmp(order) >= 0 || s.Cmp(order) >= 0 {
+		return false, nil
+	}
+
+	hash, err := g1.HashToCurve(msg, signatureDST)
+	if err != nil {
+		return false, fmt.Errorf("hashing message: %w", err)
+	}
+
+	// the commitments of the proof are s*G + c*P for the generator and the message hash
+	commitment := func(base, point *bls12381.PointG1) *bls12381.PointG1 {
+		left, right := g1.New(), g1.New()
+		g1.MulScalarBig(left, base, s)
+		g1.MulScalarBig(right, point, c)
+		return g1.Add(g1.New(), left, right)
+	}
+	challenge := proofChallenge(hash, k.g1, sigPoint,
+		commitment(g1.One(), k.g1), commitment(hash, sigPoint))
+	if challenge.Cmp(c) != 0 {
+		return false, nil
+	}
+
+	engine := bls12381.NewEngine()
+	engine.AddPair(hash, k.g2)
+	engine.AddPairInv(sigPoint, engine.G2.One())
+	return engine.Check(), nil
+}
+
+// Encode returns the compressed G1 public key followed by the compressed G2 public key
+func (k *PublicKey) Encode() []byte {
+	enc := make([]byte, 0, PublicKeyLength)
+	enc = append(enc, bls12381.NewG1().ToCompressed(k.g1)...)
+	return append(enc, bls12381.NewG2().ToCompressed(k.g2)...)
+}
+
+// Decode decodes the double public key given, checking both of its points are of the
+// same private key
+func (k *PublicKey) Decode(in []byte) error {
+	if len(in) != PublicKeyLength {
+		return fmt.Errorf("input to decode bls381 public key is not %d bytes", PublicKeyLength)
+	}
+
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+	g1Key, err := g1.FromCompressed(in[:g1Length])
+	if err != nil {
+		return fmt.Errorf("decoding G1 public key: %w", err)
+	}
+	g2Key, err := g2.FromCompressed(in[g1Length:])
+	if err != nil {
+		return fmt.Errorf("decoding G2 public key: %w", err)
+	}
+	if g1.IsZero(g1Key) || g2.IsZero(g2Key) {
+		return errors.New("bls381 public key is the point at infinity")
+	}
+
+	engine := bls12381.NewEngine()
+	engine.AddPair(g1Key, g2.One())
+	engine.AddPairInv(g1.One(), g2Key)
+	if !engine.Check() {
+		return errMismatchedPublicKeys
+	}
+
+	k.g1, k.g2 = g1Key, g2Key
+	return nil
+}
+
+// Address returns the ss58 address of the public key
+func (k *PublicKey) Address() common.Address {
+	return crypto.PublicKeyToAddress(k)
+}
+
+// Hex returns the 0x prefixed hex encoded public key
+func (k *PublicKey) Hex() string {
+	return "0x" + hex.EncodeToString(k.Encode())
+}
+
+// Sign returns the double signature of the message given: the G1 signature followed by
+// the Chaum-Pedersen proof (c, s) that it shares its discrete logarithm with the G1 public
+// key. The proof nonce is derived from the private key and the message.
+func (pk *PrivateKey) Sign(msg []byte) ([]byte, error) {
+	g1 := bls12381.NewG1()
+	hash, err := g1.HashToCurve(msg, signatureDST)
+	if err != nil {
+		return nil, fmt.Errorf("hashing message: %w", err)
+	}
+
+	sig := g1.New()
+	g1.MulScalarBig(sig, hash, pk.key)
+	pub := g1.New()
+	g1.MulScalarBig(pub, g1.One(), pk.key)
+
+	order := g1.Q()
+	nonce := hashToScalar(proofNonceDST, pk.Encode(), msg)
+	if nonce.Sign() == 0 {
+		return nil, errors.New("bls381 proof nonce is zero")
+	}
+	nonceBase, nonceHash := g1.New(), g1.New()
+	g1.MulScalarBig(nonceBase, g1.One(), nonce)
+	g1.MulScalarBig(nonceHash, hash, nonce)
+
+	c := proofChallenge(hash, pub, sig, nonceBase, nonceHash)
+	// s = nonce - c*key, so that s*G + c*P gives back the nonce commitments
+	s := new(big.Int).Mul(c, pk.key)
+	s.Sub(nonce, s).Mod(s, order)
+
+	out := make([]byte, SignatureLength)
+	copy(out, g1.ToCompressed(sig))
+	c.FillBytes(out[g1Length : g1Length+scalarLength])
+	s.FillBytes(out[g1Length+scalarLength:])
+	return out, nil
+}
+
+// Public returns the double public key of the private key
+func (pk *PrivateKey) Public() (crypto.PublicKey, error) {
+	g1, g2 := bls12381.NewG1(), bls12381.NewG2()
+	pub := &PublicKey{g1: g1.New(), g2: g2.New()}
+	g1.MulScalarBig(pub.g1, g1.One(), pk.key)
+	g2.MulScalarBig(pub.g2, g2.One(), pk.key)
+	return pub, nil
+}
+
+// Encode returns the 32 bytes big endian encoded private key
+func (pk *PrivateKey) Encode() []byte {
+	return pk.key.FillBytes(make([]byte, PrivateKeyLength))
+}
+
+// Decode decodes the 32 bytes big endian encoded private key given
+func (pk *PrivateKey) Decode(in []byte) error {
+	key := new(big.Int).SetBytes(in)
+	if key.Sign() == 0 {
+		return errZeroPrivateKey
+	}
+	if key.Cmp(bls12381.NewG1().Q()) >= 0 {
+		return errors.New("bls381 private key is not lower than the group order")
+	}
+	pk.key = key
+	return nil
+}
+
+// Hex returns the 0x prefixed hex encoded private key
+func (pk *PrivateKey) Hex() string {
+	return "0x" + hex.EncodeToString(pk.Encode())
+}
+
+// proofChallenge returns the Chaum-Pedersen challenge of the proof that the public key and
+// the signature share their discrete logarithm, given the nonce commitments
+func proofChallenge(hash, pub, sig, nonceBase, nonceHash *bls12381.PointG1) *big.Int {
+	g1 := bls12381.NewG1()
+	points := make([][]byte, 0, 5)
+	for _, point := range []*bls12381.PointG1{hash, pub, sig, nonceBase, nonceHash} {
+		points = append(points, g1.ToCompressed(point))
+	}
+	return hashToScalar(proofChallengeDST, points...)
+}
+
+// hashToScalar returns the SHA-512 hash of the inputs given reduced modulo the group order
+func hashToScalar(dst []byte, inputs ...[]byte) *big.Int {
+	h := sha512.New()
+	h.Write(dst)
+	for _, in := range inputs {
+		h.Write(in)
+	}
+	scalar := new(big.Int).SetBytes(h.Sum(nil))
+	return scalar.Mod(scalar, bls12381.NewG1().Q())
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package bls381
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	msg := []byte("borkbork")
+	sig, err := kp.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureLength)
+
+	ok, err := kp.Public().Verify(msg, sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = kp.Public().Verify([]byte("other"), sig)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := GenerateKeypair()
+	require.NoError(t, err)
+	ok, err = other.Public().Verify(msg, sig)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// a valid G1 signature with a proof of another message is rejected
+	otherSig, err := kp.Sign([]byte("other"))
+	require.NoError(t, err)
+	tampered := append(append([]byte{}, sig[:g1Length]...), otherSig[g1Length:]...)
+	ok, err = kp.Public().Verify(msg, tampered)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestPublicKey_Decode(t *testing.T) {
+	t.Parallel()
+
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+	other, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	enc := kp.Public().Encode()
+	require.Len(t, enc, PublicKeyLength)
+
+	mixed := append(append([]byte{}, enc[:g1Length]...), other.Public().Encode()[g1Length:]...)
+	_, err = NewPublicKey(mixed)
+	assert.ErrorIs(t, err, errMismatchedPublicKeys)
+}
+
+func TestNewKeypairFromSeed(t *testing.T) {
+	t.Parallel()
+
+	seed := make([]byte, SeedLength)
+	seed[0] = 1
+
+	kp, err := NewKeypairFromSeed(seed)
+	require.NoError(t, err)
+	again, err := NewKeypairFromSeed(seed)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Public().Encode(), again.Public().Encode())
+	assert.Equal(t, crypto.Bls381Type, kp.Type())
+
+	_, err = NewKeypairFromSeed(seed[:31])
+	assert.EqualError(t, err, "input to create bls381 keypair is not 32 bytes")
+}
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	pub, err := NewPublicKey(kp.Public().Encode())
+	require.NoError(t, err)
+	assert.Equal(t, kp.Public().Hex(), pub.Hex())
+	assert.Len(t, pub.Encode(), PublicKeyLength)
+
+	priv, err := NewPrivateKey(kp.Private().Encode())
+	require.NoError(t, err)
+	assert.Equal(t, kp.Private().Hex(), priv.Hex())
+
+	fromPrivate, err := NewKeypairFromPrivateKeyString(kp.Private().Hex())
+	require.NoError(t, err)
+	assert.Equal(t, kp.Public().Hex(), fromPrivate.Public().Hex())
+
+	_, err = NewPrivateKey(make([]byte, PrivateKeyLength))
+	assert.ErrorIs(t, err, errZeroPrivateKey)
+
+	_, err = NewPublicKey(make([]byte, 33))
+	assert.EqualError(t, err, "input to create bls381 public key is not 144 bytes")
+}
+
+func TestKeypair_Derive(t *testing.T) {
+	t.Parallel()
+
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	same, err := kp.Derive(nil)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Public().Hex(), same.Public().Hex())
+
+	hard, err := crypto.NewDeriveJunction("Alice", true)
+	require.NoError(t, err)
+	derived, err := kp.Derive([]crypto.DeriveJunction{hard})
+	require.NoError(t, err)
+	assert.NotEqual(t, kp.Public().Hex(), derived.Public().Hex())
+
+	soft, err := crypto.NewDeriveJunction("Alice", false)
+	require.NoError(t, err)
+	_, err = kp.Derive([]crypto.DeriveJunction{soft})
+	assert.ErrorIs(t, err, crypto.ErrSoftDerivationNotSupported)
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DevPhrase is the mnemonic used for development keys when a secret URI has no phrase, eg. //Alice
+const DevPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+// JunctionIDLength is the length of a derivation junction chain code
+const JunctionIDLength = 32
+
+var (
+	// ErrSoftDerivationNotSupported is returned when deriving a soft junction for a scheme only supporting hard derivation
+	ErrSoftDerivationNotSupported = errors.New("soft derivation not supported")
+	// ErrInvalidSecretURI is returned when a secret URI cannot be parsed
+	ErrInvalidSecretURI = errors.New("invalid secret URI")
+)
+
+// DeriveJunction is a single step of a key derivation path
+type DeriveJunction struct {
+	ChainCode [JunctionIDLength]byte
+	Hard      bool
+}
+
+// NewDeriveJunction returns the junction for the given path segment.
+// Numeric segments are encoded as little endian u64, other segments are SCALE encoded strings.
+// Encodings longer than 32 bytes are blake2b-256 hashed.
+func NewDeriveJunction(segment string, hard bool) (DeriveJunction, error) {
+	var encoded []byte
+	if n, err := strconv.ParseUint(segment, 10, 64); err == nil {
+		encoded = make([]byte, 8)
+		binary.LittleEndian.PutUint64(encoded, n)
+	} else {
+		encoded, err = scale.Marshal(segment)
+		if err != nil {
+			return DeriveJunction{}, fmt.Errorf("encoding junction %q: %w", segment, err)
+		}
+	}
+
+	junction := DeriveJunction{Hard: hard}
+	if len(encoded) > JunctionIDLength {
+		junction.ChainCode = blake2b.Sum256(encoded)
+	} else {
+		copy(junction.ChainCode[:], encoded)
+	}
+	return junction, nil
+}
+
+// SecretURI is a parsed secret URI of the form `phrase//hard/soft///password`
+type SecretURI struct {
+	Phrase    string
+	Junctions []DeriveJunction
+	Password  string
+}
+
+// ParseSecretURI parses a secret URI, defaulting to the DevPhrase if no phrase is given.
+// The phrase may be a BIP39 mnemonic or a 0x prefixed 32 byte hex seed.
+func ParseSecretURI(suri string) (*SecretURI, error) {
+	parsed := &SecretURI{}
+	if idx := strings.Index(suri, "///"); idx >= 0 {
+		parsed.Password = suri[idx+3:]
+		suri = suri[:idx]
+	}
+
+	idx := strings.Index(suri, "/")
+	if idx < 0 {
+		idx = len(suri)
+	}
+	parsed.Phrase = strings.TrimSpace(suri[:idx])
+	if parsed.Phrase == "" {
+		parsed.Phrase = DevPhrase
+	}
+
+	path := suri[idx:]
+	for path != "" {
+		hard := strings.HasPrefix(path, "//")
+		if hard {
+			path = path[2:]
+		} else {
+			path = path[1:]
+		}
+
+		end := strings.Index(path, "/")
+		if end < 0 {
+			end = len(path)
+		}
+		segment := path[:end]
+		path = path[end:]
+		if segment == "" {
+			return nil, fmt.Errorf("%w: empty derivation junction", ErrInvalidSecretURI)
+		}
+
+		junction, err := NewDeriveJunction(segment, hard)
+		if err != nil {
+			return nil, err
+		}
+		parsed.Junctions = append(parsed.Junctions, junction)
+	}
+
+	return parsed, nil
+}
+
+// HardDeriveSeed derives a child seed from a 32 byte seed using the given hard derivation identifier,
+// eg. Ed25519HDKD or Secp256k1HDKD.
+func HardDeriveSeed(id string, seed []byte, chainCode [JunctionIDLength]byte) ([]byte, error) {
+	encodedID, err := scale.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(encodedID)+len(seed)+JunctionIDLength)
+	data = append(data, encodedID...)
+	data = append(data, seed...)
+	data = append(data, chainCode[:]...)
+	derived := blake2b.Sum256(data)
+	return derived[:], nil
+}
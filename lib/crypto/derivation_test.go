@@ -0,0 +1,81 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretURI(t *testing.T) {
+	t.Parallel()
+
+	alice, err := NewDeriveJunction("Alice", true)
+	require.NoError(t, err)
+	stash, err := NewDeriveJunction("stash", false)
+	require.NoError(t, err)
+	index, err := NewDeriveJunction("1", true)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		suri     string
+		expected *SecretURI
+		errMsg   string
+	}{
+		"dev_phrase": {
+			suri:     "//Alice",
+			expected: &SecretURI{Phrase: DevPhrase, Junctions: []DeriveJunction{alice}},
+		},
+		"phrase_path_and_password": {
+			suri: "some phrase//Alice/stash//1///secret",
+			expected: &SecretURI{
+				Phrase:    "some phrase",
+				Junctions: []DeriveJunction{alice, stash, index},
+				Password:  "secret",
+			},
+		},
+		"seed": {
+			suri:     "0x01",
+			expected: &SecretURI{Phrase: "0x01"},
+		},
+		"empty_junction": {
+			suri:   "some phrase//Alice/",
+			errMsg: "invalid secret URI: empty derivation junction",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			suri, err := ParseSecretURI(testCase.suri)
+			if testCase.errMsg != "" {
+				assert.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, suri)
+		})
+	}
+}
+
+func TestNewDeriveJunction(t *testing.T) {
+	t.Parallel()
+
+	numeric, err := NewDeriveJunction("1", true)
+	require.NoError(t, err)
+	assert.Equal(t, DeriveJunction{ChainCode: [32]byte{1}, Hard: true}, numeric)
+
+	named, err := NewDeriveJunction("Alice", false)
+	require.NoError(t, err)
+	assert.Equal(t, DeriveJunction{ChainCode: [32]byte{20, 'A', 'l', 'i', 'c', 'e'}}, named)
+
+	long, err := NewDeriveJunction("a-junction-name-longer-than-thirty-two-bytes", true)
+	require.NoError(t, err)
+	assert.True(t, long.Hard)
+	assert.NotEqual(t, [32]byte{}, long.ChainCode)
+}
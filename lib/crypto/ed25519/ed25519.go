@@ -10,6 +10,9 @@
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -132,6 +135,24 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	return NewKeypairFromSeed(seed[:32])
 }
 
+// Derive returns the keypair derived from kp along the given junctions.
+// Only hard junctions are supported for ed25519.
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	seed := ed25519.PrivateKey(*kp.private).Seed()
+	for _, junction := range junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("ed25519: %w", crypto.ErrSoftDerivationNotSupported)
+		}
+
+		var err error
+		seed, err = crypto.HardDeriveSeed("Ed25519HDKD", seed, junction.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewKeypairFromSeed(seed)
+}
+
 // GenerateKeypair returns a new ed25519 keypair
 func GenerateKeypair() (*Keypair, error) {
 	buf := make([]byte, SeedLength)
@@ -176,6 +197,54 @@ func Verify(pub *PublicKey, msg, sig []byte) (bool, error) {
 	return ed25519.Verify(ed25519.PublicKey(*pub), msg, sig), nil
 }
 
+// BatchEntry is a signature verified in a batch, with its message and public key.
+type BatchEntry struct {
+	PublicKey *PublicKey
+	Message   []byte
+	Signature []byte
+}
+
+// VerifyBatch verifies the signatures of the entries given, spreading their verification
+// over the CPUs available. It returns true if all the signatures are valid, and stops
+// verifying the signatures left as soon as an invalid signature is found.
+func VerifyBatch(entries []BatchEntry) (ok bool) {
+	workers := min(runtime.GOMAXPROCS(0), len(entries))
+	if workers <= 1 {
+		for _, entry := range entries {
+			valid, _ := Verify(entry.PublicKey, entry.Message, entry.Signature)
+			if !valid {
+				return false
+			}
+		}
+		return true
+	}
+
+	var next atomic.Int64
+	var invalid atomic.Bool
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for !invalid.Load() {
+				index := int(next.Add(1) - 1)
+				if index >= len(entries) {
+					return
+				}
+
+				entry := entries[index]
+				valid, _ := Verify(entry.PublicKey, entry.Message, entry.Signature)
+				if !valid {
+					invalid.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return !invalid.Load()
+}
+
 // Type returns Ed25519Type
 func (*Keypair) Type() crypto.KeyType {
 	return crypto.Ed25519Type
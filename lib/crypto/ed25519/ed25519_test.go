@@ -160,6 +160,71 @@ func TestVerifySignature(t *testing.T) {
 	}
 }
 
+func TestVerifyBatch(t *testing.T) {
+	t.Parallel()
+
+	newEntries := func(t *testing.T, count int) []BatchEntry {
+		t.Helper()
+		entries := make([]BatchEntry, count)
+		for i := range entries {
+			keypair, err := GenerateKeypair()
+			require.NoError(t, err)
+			message := []byte(fmt.Sprintf("message %d", i))
+			signature, err := keypair.Sign(message)
+			require.NoError(t, err)
+			entries[i] = BatchEntry{
+				PublicKey: keypair.public,
+				Message:   message,
+				Signature: signature,
+			}
+		}
+		return entries
+	}
+
+	testCases := map[string]struct {
+		entries func(t *testing.T) []BatchEntry
+		ok      bool
+	}{
+		"no_entry": {
+			entries: func(*testing.T) []BatchEntry { return nil },
+			ok:      true,
+		},
+		"single_valid_signature": {
+			entries: func(t *testing.T) []BatchEntry { return newEntries(t, 1) },
+			ok:      true,
+		},
+		"valid_signatures": {
+			entries: func(t *testing.T) []BatchEntry { return newEntries(t, 100) },
+			ok:      true,
+		},
+		"invalid_signature": {
+			entries: func(t *testing.T) []BatchEntry {
+				entries := newEntries(t, 100)
+				entries[57].Message = []byte("other message")
+				return entries
+			},
+		},
+		"invalid_signature_length": {
+			entries: func(t *testing.T) []BatchEntry {
+				entries := newEntries(t, 100)
+				entries[99].Signature = entries[99].Signature[:SignatureLength-1]
+				return entries
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ok := VerifyBatch(testCase.entries(t))
+
+			require.Equal(t, testCase.ok, ok)
+		})
+	}
+}
+
 func TestPublicKeyFromPrivate(t *testing.T) {
 	// subkey inspect //Alice --scheme ed25519
 	alicePrivateKey := common.MustHexToBytes("0xabf8e5bdbe30c65656c0a3cbd181ff8a56294a69dfedd27982aace4a76909115")
@@ -23,6 +23,9 @@
 // Secp256k1Type secp256k1
 const Secp256k1Type KeyType = "secp256k1"
 
+// Bls381Type bls381
+const Bls381Type KeyType = "bls381"
+
 // UnknownType is used by the GenericKeystore
 const UnknownType KeyType = "unknown"
 
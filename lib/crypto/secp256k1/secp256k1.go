@@ -26,6 +26,9 @@
 // MessageLength is the fixed Message Length
 const MessageLength = 32
 
+// PublicKeyLength is the fixed length of a compressed Public Key
+const PublicKeyLength = 33
+
 // Keypair holds the pub,pk keys
 type Keypair struct {
 	public  *PublicKey
@@ -112,6 +115,16 @@ func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	return priv, err
 }
 
+// NewPublicKey returns a PublicKey for a compressed 33 byte encoded public key
+func NewPublicKey(in []byte) (*PublicKey, error) {
+	if len(in) != PublicKeyLength {
+		return nil, errors.New("input to create secp256k1 public key is not 33 bytes")
+	}
+	pub := new(PublicKey)
+	err := pub.Decode(in)
+	return pub, err
+}
+
 // NewKeypairFromPrivateKeyString returns a Keypair given a 0x prefixed private key string
 func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	privBytes, err := common.HexToBytes(in)
@@ -127,6 +140,29 @@ func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	return NewKeypairFromPrivate(priv)
 }
 
+// Derive returns the keypair derived from kp along the given junctions.
+// Only hard junctions are supported for secp256k1.
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	seed := kp.private.Encode()
+	for _, junction := range junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("secp256k1: %w", crypto.ErrSoftDerivationNotSupported)
+		}
+
+		var err error
+		seed, err = crypto.HardDeriveSeed("Secp256k1HDKD", seed, junction.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	priv, err := NewPrivateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeypairFromPrivate(priv)
+}
+
 // GenerateKeypair will generate a Keypair
 func GenerateKeypair() (*Keypair, error) {
 	priv, err := secp256k1.GenerateKey()
@@ -159,6 +159,34 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	}, nil
 }
 
+// Derive returns the keypair derived from kp along the given junctions.
+// Soft derived secret keys use a random nonce, so while the derived public key matches
+// substrate, signatures made with it are not byte for byte reproducible.
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	key := sr25519.DerivableKey(kp.private.key)
+	for _, junction := range junctions {
+		var (
+			derived *sr25519.ExtendedKey
+			err     error
+		)
+		if junction.Hard {
+			derived, err = sr25519.DeriveKeyHard(key, []byte{}, junction.ChainCode)
+		} else {
+			derived, err = sr25519.DeriveKeySimple(key, []byte{}, junction.ChainCode)
+		}
+		if err != nil {
+			return nil, err
+		}
+		key = derived.Key()
+	}
+
+	secret, ok := key.(*sr25519.SecretKey)
+	if !ok {
+		return nil, fmt.Errorf("derived key is not a secret key: %T", key)
+	}
+	return NewKeypair(secret)
+}
+
 // NewPrivateKey creates a new private key using the input bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
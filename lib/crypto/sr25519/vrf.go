@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sr25519
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gtank/merlin"
+)
+
+// VrfOutput is the output of a VRF evaluation
+type VrfOutput [VRFOutputLength]byte
+
+// VrfProof proves that a VrfOutput was computed using a given key and transcript
+type VrfProof [VRFProofLength]byte
+
+// VrfSignature is a VRF output along with the proof of its correctness
+type VrfSignature struct {
+	Output VrfOutput
+	Proof  VrfProof
+}
+
+// SignVrf evaluates the VRF for the given transcript, returning the output and its proof
+func (kp *Keypair) SignVrf(t *merlin.Transcript) (VrfSignature, error) {
+	if kp.private == nil || kp.private.key == nil {
+		return VrfSignature{}, errors.New("key is nil")
+	}
+
+	out, proof, err := kp.private.VrfSign(t)
+	if err != nil {
+		return VrfSignature{}, err
+	}
+	return VrfSignature{Output: out, Proof: proof}, nil
+}
+
+// VerifyVrf confirms that the VRF signature was produced by the public key for the given transcript
+func (k *PublicKey) VerifyVrf(t *merlin.Transcript, sig VrfSignature) (bool, error) {
+	return k.VrfVerify(t, sig.Output, sig.Proof)
+}
+
+// MakeBytes derives size bytes of randomness from the VRF output, as done when comparing
+// BABE outputs against the slot threshold or computing Sassafras ticket ids.
+// The transcript must be the one the output was computed for.
+func (o VrfOutput) MakeBytes(pub *PublicKey, t *merlin.Transcript, size int, context []byte) ([]byte, error) {
+	inout, err := AttachInput(o, pub, t)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := inout.MakeBytes(size, context)
+	if err != nil {
+		return nil, fmt.Errorf("making bytes: %w", err)
+	}
+	return out, nil
+}
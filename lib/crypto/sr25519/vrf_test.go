@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sr25519
+
+import (
+	"testing"
+
+	"github.com/gtank/merlin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVrfAndVerifyVrf(t *testing.T) {
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	sig, err := kp.SignVrf(merlin.NewTranscript("helloworld"))
+	require.NoError(t, err)
+
+	pub := kp.Public().(*PublicKey)
+	ok, err := pub.VerifyVrf(merlin.NewTranscript("helloworld"), sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = pub.VerifyVrf(merlin.NewTranscript("otherworld"), sig)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestVrfOutput_MakeBytes(t *testing.T) {
+	kp, err := GenerateKeypair()
+	require.NoError(t, err)
+	pub := kp.Public().(*PublicKey)
+
+	sig, err := kp.SignVrf(merlin.NewTranscript("helloworld"))
+	require.NoError(t, err)
+
+	first, err := sig.Output.MakeBytes(pub, merlin.NewTranscript("helloworld"), 16, []byte("context"))
+	require.NoError(t, err)
+	require.Len(t, first, 16)
+
+	second, err := sig.Output.MakeBytes(pub, merlin.NewTranscript("helloworld"), 16, []byte("context"))
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	_, err = sig.Output.MakeBytes(pub, merlin.NewTranscript("helloworld"), 65, []byte("context"))
+	require.EqualError(t, err, "making bytes: invalid size parameter")
+}
@@ -5,11 +5,15 @@
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
+// ErrRuntimeGenesisNotBuilt is returned when converting a runtime genesis to raw without executing the runtime
+var ErrRuntimeGenesisNotBuilt = errors.New("runtime genesis must be built by the runtime genesis builder")
+
 // Genesis stores the data parsed from the genesis configuration file
 type Genesis struct {
 	Name               string                 `json:"name"`
@@ -24,6 +28,7 @@ type Genesis struct {
 	BadBlocks          []string               `json:"badBlocks"`
 	ConsensusEngine    string                 `json:"consensusEngine"`
 	CodeSubstitutes    map[string]string      `json:"codeSubstitutes"`
+	Checkpoint         *Checkpoint            `json:"checkpoint,omitempty"`
 }
 
 // Data defines the genesis file data formatted for trie storage
@@ -39,18 +44,42 @@ type Data struct {
 	BadBlocks          []string
 	ConsensusEngine    string
 	CodeSubstitutes    map[string]string
+	Checkpoint         *Checkpoint
+}
+
+// Checkpoint is a trusted finalised block the node initialises its finality and sync
+// target from, with the GRANDPA justification finalising it and the authority set
+// having signed the justification.
+type Checkpoint struct {
+	// Hash is the hex encoded hash of the checkpoint block.
+	Hash string `json:"hash"`
+	// Header is the hex encoded SCALE header of the checkpoint block.
+	Header string `json:"header"`
+	// SetID is the ID of the GRANDPA authority set having finalised the checkpoint block.
+	SetID       uint64                     `json:"setId"`
+	Authorities []types.AuthorityAsAddress `json:"authorities"`
+	// Justification is the hex encoded GRANDPA justification of the checkpoint block.
+	Justification string `json:"justification"`
 }
 
 // TelemetryEndpoint struct to hold telemetry endpoint information
 type TelemetryEndpoint struct {
-	Endpoint  string `mapstructure:",squash"`
-	Verbosity int    `mapstructure:",squash"`
+	Endpoint  string `mapstructure:"endpoint"`
+	Verbosity int    `mapstructure:"verbosity"`
 }
 
 // Fields stores genesis raw data, and human readable runtime data
 type Fields struct {
-	Raw     map[string]map[string]string `json:"raw,omitempty"`
-	Runtime *Runtime                     `json:"runtime,omitempty"`
+	Raw            map[string]map[string]string `json:"raw,omitempty"`
+	Runtime        *Runtime                     `json:"runtime,omitempty"`
+	RuntimeGenesis *RuntimeGenesis              `json:"runtimeGenesis,omitempty"`
+}
+
+// RuntimeGenesis is a genesis built by the runtime's genesis builder API,
+// from the runtime default genesis config with the patch applied on top.
+type RuntimeGenesis struct {
+	Code  string          `json:"code"`
+	Patch json.RawMessage `json:"patch,omitempty"`
 }
 
 // Runtime is the structure of the genesis runtime field.
@@ -216,6 +245,7 @@ func (g *Genesis) GenesisData() *Data {
 		BadBlocks:          g.BadBlocks,
 		ConsensusEngine:    g.ConsensusEngine,
 		CodeSubstitutes:    g.CodeSubstitutes,
+		Checkpoint:         g.Checkpoint,
 	}
 }
 
@@ -226,15 +256,20 @@ func (g *Genesis) GenesisFields() Fields {
 
 // IsRaw returns whether the genesis is raw or not
 func (g *Genesis) IsRaw() bool {
-	return g.Genesis.Raw != nil || g.Genesis.Runtime == nil
+	return g.Genesis.Raw != nil || (g.Genesis.Runtime == nil && g.Genesis.RuntimeGenesis == nil)
 }
 
-// ToRaw converts a non-raw genesis to a raw genesis
+// ToRaw converts a non-raw genesis to a raw genesis.
+// A genesis given as RuntimeGenesis cannot be converted here since it requires executing the runtime.
 func (g *Genesis) ToRaw() error {
 	if g.IsRaw() {
 		return nil
 	}
 
+	if g.Genesis.Runtime == nil {
+		return ErrRuntimeGenesisNotBuilt
+	}
+
 	grt := g.Genesis.Runtime
 	res, err := buildRawMap(*grt)
 	if err != nil {
@@ -99,3 +99,17 @@ func TestBalancesFieldsUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestGenesis_ToRaw_runtimeGenesis(t *testing.T) {
+	t.Parallel()
+
+	gen := &Genesis{
+		Genesis: Fields{
+			RuntimeGenesis: &RuntimeGenesis{Code: "0x01"},
+		},
+	}
+	require.False(t, gen.IsRaw())
+
+	err := gen.ToRaw()
+	require.ErrorIs(t, err, ErrRuntimeGenesisNotBuilt)
+}
@@ -81,9 +81,12 @@ func NewGenesisFromJSON(file string, authCount int) (*Genesis, error) {
 		trimGenesisAuthority(g, authCount)
 	}
 
-	g.Genesis.Raw = make(map[string]map[string]string)
 	grt := g.Genesis.Runtime
 	if grt == nil {
+		// keep the raw storage of an already raw genesis
+		if g.Genesis.Raw == nil && g.Genesis.RuntimeGenesis == nil {
+			g.Genesis.Raw = make(map[string]map[string]string)
+		}
 		return g, nil
 	}
 
@@ -92,6 +95,7 @@ func NewGenesisFromJSON(file string, authCount int) (*Genesis, error) {
 		return nil, err
 	}
 
+	g.Genesis.Raw = make(map[string]map[string]string)
 	g.Genesis.Raw["top"] = res
 	return g, err
 }
@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/exp/maps"
+)
+
+// trackAuthorityPeer records the peer a valid vote of the authority given was received
+// from, as the peer of the authority, and sets the peers of the authorities known as the
+// validator peers of the network. Only the first peer a vote of an authority is received
+// from is recorded, so the validator peers do not change with the peers relaying its votes.
+func (s *Service) trackAuthorityPeer(authorityID ed25519.PublicKeyBytes, from peer.ID) {
+	if from == "" {
+		return
+	}
+
+	s.authorityPeersLock.Lock()
+	defer s.authorityPeersLock.Unlock()
+
+	if _, has := s.authorityPeers[authorityID]; has {
+		return
+	}
+
+	if s.authorityPeers == nil {
+		s.authorityPeers = make(map[ed25519.PublicKeyBytes]peer.ID)
+	}
+	s.authorityPeers[authorityID] = from
+	s.network.SetValidatorPeers(maps.Values(s.authorityPeers))
+}
+
+// pruneAuthorityPeers forgets the peers of the authorities which are not voters
+// of the set given, and sets the remaining ones as the validator peers of the network.
+func (s *Service) pruneAuthorityPeers(voters []Voter) {
+	s.authorityPeersLock.Lock()
+	defer s.authorityPeersLock.Unlock()
+
+	isVoter := make(map[ed25519.PublicKeyBytes]struct{}, len(voters))
+	for _, voter := range voters {
+		isVoter[voter.Key.AsBytes()] = struct{}{}
+	}
+
+	pruned := false
+	for authorityID := range s.authorityPeers {
+		if _, ok := isVoter[authorityID]; !ok {
+			delete(s.authorityPeers, authorityID)
+			pruned = true
+		}
+	}
+
+	if pruned {
+		s.network.SetValidatorPeers(maps.Values(s.authorityPeers))
+	}
+}
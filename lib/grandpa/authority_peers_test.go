@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_authorityPeers(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	alice := kr.Alice().Public().(*ed25519.PublicKey)
+	bob := kr.Bob().Public().(*ed25519.PublicKey)
+
+	ctrl := gomock.NewController(t)
+	networkMock := NewMockNetwork(ctrl)
+	service := &Service{network: networkMock}
+
+	networkMock.EXPECT().SetValidatorPeers(peer.IDSlice{"a"})
+	service.trackAuthorityPeer(alice.AsBytes(), "a")
+	// the votes of an authority relayed by another peer do not change its peer
+	service.trackAuthorityPeer(alice.AsBytes(), "b")
+	service.trackAuthorityPeer(bob.AsBytes(), "")
+
+	networkMock.EXPECT().SetValidatorPeers(gomock.InAnyOrder(peer.IDSlice{"a", "b"}))
+	service.trackAuthorityPeer(bob.AsBytes(), "b")
+
+	// the peers of the authorities remaining voters are kept
+	service.pruneAuthorityPeers([]types.GrandpaVoter{{Key: *alice}, {Key: *bob}})
+	networkMock.EXPECT().SetValidatorPeers(peer.IDSlice{"b"})
+	service.pruneAuthorityPeers([]types.GrandpaVoter{{Key: *bob}})
+
+	assert.Equal(t, map[ed25519.PublicKeyBytes]peer.ID{bob.AsBytes(): "b"}, service.authorityPeers)
+}
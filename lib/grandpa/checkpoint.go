@@ -0,0 +1,29 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// VerifyCheckpointJustification verifies the justification of a trusted checkpoint block
+// against the authority set given, since the checkpoint block and its authority set are
+// not known by the node until the chain is synced up to the checkpoint.
+// The precommits are only checked to be for the checkpoint block or a block above it,
+// since the blocks descending from the checkpoint are not known either.
+// It returns the round of the justification, the checkpoint block being finalised in it.
+func VerifyCheckpointJustification(hash common.Hash, number uint, justification []byte,
+	setID uint64, voters []types.GrandpaVoter) (round uint64, err error) {
+	fj, err := decodeJustification(justification)
+	if err != nil {
+		return 0, err
+	}
+
+	err = verifyUnimportedJustification(fj, hash, number, setID, voters)
+	if err != nil {
+		return 0, err
+	}
+	return fj.Round, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_VerifyCheckpointJustification(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	keys := kr.Keys[:3]
+
+	voters := make([]types.GrandpaVoter, len(kr.Keys))
+	for i, key := range kr.Keys {
+		voters[i] = types.GrandpaVoter{Key: *key.Public().(*ed25519.PublicKey), ID: uint64(i)}
+	}
+
+	const setID, round = uint64(2), uint64(5)
+	checkpointHash := common.Hash{1}
+	const checkpointNumber = 100
+
+	newSignedJustificationBytes := func(t *testing.T, hash common.Hash, number uint32, precommitNumber uint32,
+		keys []*ed25519.Keypair) []byte {
+		t.Helper()
+		vote := Vote{Hash: hash, Number: precommitNumber}
+		msg, err := scale.Marshal(FullVote{Stage: precommit, Vote: vote, Round: round, SetID: setID})
+		require.NoError(t, err)
+
+		precommits := make([]SignedVote, len(keys))
+		for i, key := range keys {
+			signature, err := key.Sign(msg)
+			require.NoError(t, err)
+			precommits[i] = SignedVote{Vote: vote, AuthorityID: key.Public().(*ed25519.PublicKey).AsBytes()}
+			copy(precommits[i].Signature[:], signature)
+		}
+
+		encoded, err := scale.Marshal(*newJustification(round, hash, number, precommits))
+		require.NoError(t, err)
+		return encoded
+	}
+	newJustificationBytes := func(t *testing.T, hash common.Hash, number uint32, precommitNumber uint32) []byte {
+		t.Helper()
+		return newSignedJustificationBytes(t, hash, number, precommitNumber, keys)
+	}
+
+	testCases := map[string]struct {
+		hash          common.Hash
+		number        uint
+		justification []byte
+		setID         uint64
+		voters        []types.GrandpaVoter
+		errWrapped    error
+	}{
+		"valid_justification": {
+			hash:          checkpointHash,
+			number:        checkpointNumber,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber),
+			setID:         setID,
+			voters:        voters[:3],
+		},
+		"malformed_justification": {
+			hash:          checkpointHash,
+			number:        checkpointNumber,
+			justification: []byte{1, 2, 3},
+			setID:         setID,
+			voters:        voters[:3],
+			errWrapped:    ErrMalformedJustification,
+		},
+		"block_hash_mismatch": {
+			hash:          common.Hash{2},
+			number:        checkpointNumber,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber),
+			setID:         setID,
+			voters:        voters[:3],
+			errWrapped:    ErrJustificationMismatch,
+		},
+		"block_number_mismatch": {
+			hash:          checkpointHash,
+			number:        checkpointNumber + 1,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber),
+			setID:         setID,
+			voters:        voters[:3],
+			errWrapped:    ErrBlockNumbersMismatch,
+		},
+		"precommit_below_checkpoint": {
+			hash:          checkpointHash,
+			number:        checkpointNumber,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber-1),
+			setID:         setID,
+			voters:        voters[:3],
+			errWrapped:    ErrPrecommitBlockMismatch,
+		},
+		"authority_not_in_set": {
+			hash:          checkpointHash,
+			number:        checkpointNumber,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber),
+			setID:         setID,
+			voters:        voters[1:3],
+			errWrapped:    ErrAuthorityNotInSet,
+		},
+		"wrong_set_id": {
+			hash:          checkpointHash,
+			number:        checkpointNumber,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber),
+			setID:         setID + 1,
+			voters:        voters[:3],
+			errWrapped:    ErrInvalidSignature,
+		},
+		"votes_below_supermajority": {
+			hash:          checkpointHash,
+			number:        checkpointNumber,
+			justification: newJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber),
+			setID:         setID,
+			voters:        voters[:5],
+			errWrapped:    ErrMinVotesNotMet,
+		},
+		"repeated_precommit": {
+			hash:   checkpointHash,
+			number: checkpointNumber,
+			justification: newSignedJustificationBytes(t, checkpointHash, checkpointNumber, checkpointNumber,
+				[]*ed25519.Keypair{keys[0], keys[1], keys[1]}),
+			setID:      setID,
+			voters:     voters[:4],
+			errWrapped: ErrMinVotesNotMet,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			justificationRound, err := VerifyCheckpointJustification(testCase.hash, testCase.number,
+				testCase.justification, testCase.setID, testCase.voters)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped == nil {
+				assert.Equal(t, round, justificationRound)
+			}
+		})
+	}
+}
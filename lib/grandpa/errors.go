@@ -52,6 +52,9 @@
 	ErrNoJustification       = errors.New("no justification found for block")
 	ErrJustificationMismatch = errors.New("justification does not correspond to given block hash")
 
+	// ErrMalformedJustification is returned when a justification cannot be decoded
+	ErrMalformedJustification = errors.New("malformed justification")
+
 	ErrBlockHashMismatch = errors.New("block hash does not correspond to given block number")
 
 	ErrBlockNumbersMismatch = errors.New("block numbers mismatch")
@@ -245,7 +245,7 @@ func (h *votingRoundHandler) Run() (err error) {
 				}
 
 				signedpreVote, prevoteMessage, err :=
-					h.grandpaService.createSignedVoteAndVoteMessage(preVote, prevote)
+					h.grandpaService.castVote(preVote, prevote)
 				if err != nil {
 					return fmt.Errorf("creating signed vote: %w", err)
 				}
@@ -267,7 +267,7 @@ func (h *votingRoundHandler) Run() (err error) {
 				}
 
 				signedPreCommit, precommitMessage, err :=
-					h.grandpaService.createSignedVoteAndVoteMessage(preCommit, precommit)
+					h.grandpaService.castVote(preCommit, precommit)
 				if err != nil {
 					return fmt.Errorf("creating signed vote: %w", err)
 				}
@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/require"
+)
+
+func Fuzz_decodeMessage(f *testing.F) {
+	// vote, neighbour packet and catch up request messages
+	f.Add(common.MustHexToBytes("0x004d000000000000006300000000000000017db9db5ed9967b80143100189ba69d9e4deab85ac3570e5df25686cabe32964a7777000036e6eca85489bebbb0f687ca5404748d5aa2ffabee34e3ed272cc7b2f6d0a82c65b99bc7cd90dbc21bb528289ebf96705dbd7d96918d34d815509b4e0e2a030f34602b88f60513f1c805d87ef52896934baf6a662bc37414dbdbf69356b1a691")) //nolint:lll
+	f.Add(common.MustHexToBytes("0x020102000000000000000300000000000000ff000000"))
+	f.Add(common.MustHexToBytes("0x0311000000000000002200000000000000"))
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_, _ = decodeMessage(&ConsensusMessage{Data: in})
+	})
+}
+
+func Fuzz_GrandpaHandshake_Decode(f *testing.F) {
+	f.Add([]byte{byte(common.AuthorityRole)})
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_ = new(GrandpaHandshake).Decode(in)
+	})
+}
+
+func Fuzz_decodeJustification(f *testing.F) {
+	justification := newJustification(1, common.Hash{1}, 2, []SignedVote{{
+		Vote:        Vote{Hash: common.Hash{1}, Number: 2},
+		AuthorityID: [32]byte{3},
+	}})
+	encoded, err := scale.Marshal(*justification)
+	require.NoError(f, err)
+	f.Add(encoded)
+
+	f.Fuzz(func(t *testing.T, in []byte) {
+		_, err := decodeJustification(in)
+		if err != nil {
+			require.ErrorIs(t, err, ErrMalformedJustification)
+		}
+	})
+}
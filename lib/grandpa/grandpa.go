@@ -14,6 +14,7 @@
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/supervisor"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
@@ -79,7 +80,13 @@ type Service struct {
 	// channels for communication with other services
 	finalisedCh chan *types.FinalisationInfo
 
-	telemetry Telemetry
+	// authorityPeers are the peers the votes of the authorities of the current set
+	// were first received from, occupying the validator peer slots of the network.
+	authorityPeers     map[ed25519.PublicKeyBytes]peer.ID
+	authorityPeersLock sync.Mutex
+
+	telemetry  Telemetry
+	supervisor *supervisor.Supervisor
 }
 
 // Config represents a GRANDPA service configuration
@@ -173,16 +180,17 @@ func (s *Service) Start() error {
 
 	s.tracker.start()
 
-	go func() {
-		err := s.initiate()
-		if err != nil {
-			panic(fmt.Sprintf("running grandpa service: %s", err))
-		}
-	}()
+	go s.supervisor.Run("grandpa", supervisor.DefaultPolicy(), s.ctx.Done(), s.initiate)
 
 	return nil
 }
 
+// SetSupervisor sets the supervisor restarting the voting process when it fails,
+// before the service is started.
+func (s *Service) SetSupervisor(sv *supervisor.Supervisor) {
+	s.supervisor = sv
+}
+
 // Stop stops the GRANDPA finality service
 func (s *Service) Stop() error {
 	s.chanLock.Lock()
@@ -234,6 +242,7 @@ func (s *Service) updateAuthorities() error {
 
 	s.state.voters = nextAuthorities
 	s.state.setID = currSetID
+	s.pruneAuthorityPeers(nextAuthorities)
 	// round resets to 1 after a set ID change,
 	// setting to 0 before incrementing indicates
 	// the setID has been increased
@@ -374,7 +383,7 @@ func (s *Service) handleIsPrimary() (bool, error) {
 	}
 
 	// send primary prevote message to network
-	spv, primProposal, err := s.createSignedVoteAndVoteMessage(pv, primaryProposal)
+	spv, primProposal, err := s.castVote(pv, primaryProposal)
 	if err != nil {
 		return false, fmt.Errorf("failed to create primary proposal message: %w", err)
 	}
@@ -1129,6 +1138,7 @@ func (s *Service) handleVoteMessage(from peer.ID, vote *VoteMessage) (err error)
 	if err != nil {
 		return fmt.Errorf("validating vote message: %w", err)
 	}
+	s.trackAuthorityPeer(vote.Message.AuthorityID, from)
 
 	threshold := s.state.threshold() + 1
 	logger.Debugf(
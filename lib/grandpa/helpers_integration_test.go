@@ -75,6 +75,8 @@ func (n *testNetwork) SendMessage(_ peer.ID, _ NotificationsMessage) error {
 	return nil
 }
 
+func (*testNetwork) SetValidatorPeers(_ peer.IDSlice) {}
+
 func (n *testNetwork) SendJustificationRequest(to peer.ID, num uint32) {
 	n.justificationRequest = &testJustificationRequest{
 		to:  to,
@@ -275,6 +275,28 @@ func isDescendantOfHighestFinalisedBlock(blockState BlockState, hash common.Hash
 	return blockState.IsDescendantOf(highestHeader.Hash(), hash)
 }
 
+// isFinalisedBlock returns true if the block with the hash and number given
+// is on the finalised chain, at or below the highest finalised block.
+func isFinalisedBlock(blockState BlockState, hash common.Hash, number uint) (bool, error) {
+	highestHeader, err := blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return false, fmt.Errorf("could not get highest finalised header: %w", err)
+	}
+
+	if number > highestHeader.Number {
+		return false, nil
+	}
+
+	header, err := blockState.GetHeaderByNumber(number)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting finalised header by number: %w", err)
+	}
+
+	return header.Hash() == hash, nil
+}
+
 func (h *MessageHandler) verifyPreVoteJustification(msg *CatchUpResponse) (common.Hash, error) {
 	voters := make(map[ed25519.PublicKeyBytes]map[common.Hash]int, len(msg.PreVoteJustification))
 	eqVotesByHash := make(map[common.Hash]map[ed25519.PublicKeyBytes]struct{})
@@ -399,11 +421,20 @@ func (h *MessageHandler) verifyPreCommitJustification(msg *CatchUpResponse) erro
 	return nil
 }
 
-// VerifyBlockJustification verifies the finality justification for a block, returns scale encoded justification with
-// any extra bytes removed.
+// CheckJustification checks the justification given decodes, without verifying it,
+// so a malformed justification can be rejected when it is received from a peer.
+// It returns an error wrapping ErrMalformedJustification if it does not.
+func (*Service) CheckJustification(justification []byte) error {
+	_, err := decodeJustification(justification)
+	return err
+}
+
+// VerifyBlockJustification verifies the finality justification for a block, and finalises the block
+// with its justification stored, without any extra bytes, if the justification is valid.
+// The justification of a block already finalised, such as an old block fetched during sync,
+// is verified against the historical authority set of the block and only stored.
 func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byte) error {
-	fj := Justification{}
-	err := scale.Unmarshal(justification, &fj)
+	fj, err := decodeJustification(justification)
 	if err != nil {
 		return err
 	}
@@ -432,7 +463,7 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 			return fmt.Errorf("%w, setID=%d and round=%d", errFinalisedBlocksMismatch, setID, fj.Round)
 		}
 
-		return nil
+		return s.setJustification(hash, fj)
 	}
 
 	isDescendant, err := isDescendantOfHighestFinalisedBlock(s.blockState, fj.Commit.Hash)
@@ -440,39 +471,30 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 		return fmt.Errorf("checking if descendant of highest block: %w", err)
 	}
 
+	var alreadyFinalised bool
 	if !isDescendant {
-		return errVoteBlockMismatch
+		alreadyFinalised, err = isFinalisedBlock(s.blockState, hash, uint(fj.Commit.Number))
+		if err != nil {
+			return fmt.Errorf("checking if block is finalised: %w", err)
+		}
+
+		if !alreadyFinalised {
+			return errVoteBlockMismatch
+		}
 	}
 
 	auths, err := s.grandpaState.GetAuthorities(setID)
 	if err != nil {
-		return fmt.Errorf("cannot get authorities for set ID: %w", err)
-	}
-
-	// threshold is two-thirds the number of authorities,
-	// uses the current set of authorities to define the threshold
-	threshold := (2 * len(auths) / 3)
-
-	if len(fj.Commit.Precommits) < threshold {
-		return ErrMinVotesNotMet
-	}
-
-	authPubKeys := make([]AuthData, len(fj.Commit.Precommits))
-	for i, pcj := range fj.Commit.Precommits {
-		authPubKeys[i] = AuthData{AuthorityID: pcj.AuthorityID}
+		return fmt.Errorf("cannot get authorities for set ID %d: %w", setID, err)
 	}
 
-	equivocatoryVoters := getEquivocatoryVoters(authPubKeys)
-
-	var count int
-
 	logger.Debugf(
 		"verifying justification: set id %d, round %d, hash %s, number %d, sig count %d",
 		setID, fj.Round, fj.Commit.Hash, fj.Commit.Number, len(fj.Commit.Precommits))
 
-	for _, just := range fj.Commit.Precommits {
+	err = verifyPrecommits(fj, setID, auths, func(precommit SignedVote) error {
 		// check if vote was for descendant of committed block
-		isDescendant, err := s.blockState.IsDescendantOf(hash, just.Vote.Hash)
+		isDescendant, err := s.blockState.IsDescendantOf(hash, precommit.Vote.Hash)
 		if err != nil {
 			return err
 		}
@@ -480,6 +502,127 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 		if !isDescendant {
 			return ErrPrecommitBlockMismatch
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = verifyBlockHashAgainstBlockNumber(s.blockState, fj.Commit.Hash, uint(fj.Commit.Number))
+	if err != nil {
+		return fmt.Errorf("verifying block hash against block number: %w", err)
+	}
+
+	for _, preCommit := range fj.Commit.Precommits {
+		err := verifyBlockHashAgainstBlockNumber(s.blockState, preCommit.Vote.Hash, uint(preCommit.Vote.Number))
+		if err != nil {
+			return fmt.Errorf("verifying block hash against block number: %w", err)
+		}
+	}
+
+	// the justification is stored before the block is finalised,
+	// so it is sent with the finality notifications of the block.
+	err = s.setJustification(hash, fj)
+	if err != nil {
+		return err
+	}
+
+	if alreadyFinalised {
+		return nil
+	}
+
+	err = s.blockState.SetFinalisedHash(hash, fj.Round, setID)
+	if err != nil {
+		return fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) setJustification(hash common.Hash, justification Justification) error {
+	encoded, err := scale.Marshal(justification)
+	if err != nil {
+		return fmt.Errorf("encoding justification: %w", err)
+	}
+
+	err = s.blockState.SetJustification(hash, encoded)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+	return nil
+}
+
+// VerifyUnimportedJustification verifies the justification of the block given, which is
+// not imported yet, so the import of its ancestors can rely on it. It is verified against
+// the authority set of the block number known so far, and the precommits are only checked
+// to be for the block or a block above it, since its descendants are not known either.
+// The justification is neither stored nor the block finalised, which is left to
+// VerifyBlockJustification once the block is imported.
+func (s *Service) VerifyUnimportedJustification(hash common.Hash, number uint, justification []byte) error {
+	fj, err := decodeJustification(justification)
+	if err != nil {
+		return err
+	}
+
+	setID, err := s.grandpaState.GetSetIDByBlockNumber(number)
+	if err != nil {
+		return fmt.Errorf("cannot get set ID from block number: %w", err)
+	}
+
+	auths, err := s.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return fmt.Errorf("cannot get authorities for set ID %d: %w", setID, err)
+	}
+
+	return verifyUnimportedJustification(fj, hash, number, setID, auths)
+}
+
+// verifyUnimportedJustification verifies the justification given is for the block given,
+// which is not imported, and is signed by a supermajority of the authority set given.
+// The precommits are only checked to be for the block or a block above it.
+func verifyUnimportedJustification(fj Justification, hash common.Hash, number uint,
+	setID uint64, auths []types.GrandpaVoter) error {
+	if hash != fj.Commit.Hash {
+		return fmt.Errorf("%w: justification %s and block hash %s",
+			ErrJustificationMismatch, fj.Commit.Hash.Short(), hash.Short())
+	}
+
+	if uint(fj.Commit.Number) != number {
+		return fmt.Errorf("%w: justification %d and block number %d",
+			ErrBlockNumbersMismatch, fj.Commit.Number, number)
+	}
+
+	return verifyPrecommits(fj, setID, auths, func(precommit SignedVote) error {
+		if uint(precommit.Vote.Number) < number {
+			return ErrPrecommitBlockMismatch
+		}
+		return nil
+	})
+}
+
+// verifyPrecommits verifies the precommits of the justification given are signed by
+// a supermajority of the authorities of the set given, more than two thirds of them.
+// An equivocating authority is counted once, and an authority is not counted twice
+// for the same precommit repeated. The check function given is called on each
+// precommit before its signature is verified.
+func verifyPrecommits(fj Justification, setID uint64, auths []types.GrandpaVoter,
+	check func(precommit SignedVote) error) error {
+	threshold := len(auths) - (len(auths)-1)/3
+
+	if len(fj.Commit.Precommits) < threshold {
+		return ErrMinVotesNotMet
+	}
+
+	voters := make(map[ed25519.PublicKeyBytes]struct{}, len(fj.Commit.Precommits))
+
+	// the signatures are verified together once all the precommits are checked,
+	// since their verification is the most expensive part of the justification verification.
+	signatures := make([]ed25519.BatchEntry, len(fj.Commit.Precommits))
+	for i, just := range fj.Commit.Precommits {
+		err := check(just)
+		if err != nil {
+			return err
+		}
 
 		publicKey, err := ed25519.NewPublicKey(just.AuthorityID[:])
 		if err != nil {
@@ -490,7 +633,6 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 			return ErrAuthorityNotInSet
 		}
 
-		// verify signature for each precommit
 		msg, err := scale.Marshal(FullVote{
 			Stage: precommit,
 			Vote:  just.Vote,
@@ -501,41 +643,20 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 			return err
 		}
 
-		ok, err := publicKey.Verify(msg, just.Signature[:])
-		if err != nil {
-			return err
-		}
-
-		if !ok {
-			return ErrInvalidSignature
-		}
-
-		if _, ok := equivocatoryVoters[just.AuthorityID]; ok {
-			continue
+		signatures[i] = ed25519.BatchEntry{
+			PublicKey: publicKey,
+			Message:   msg,
+			Signature: just.Signature[:],
 		}
-
-		count++
-	}
-
-	if count+len(equivocatoryVoters) < threshold {
-		return ErrMinVotesNotMet
-	}
-
-	err = verifyBlockHashAgainstBlockNumber(s.blockState, fj.Commit.Hash, uint(fj.Commit.Number))
-	if err != nil {
-		return fmt.Errorf("verifying block hash against block number: %w", err)
+		voters[just.AuthorityID] = struct{}{}
 	}
 
-	for _, preCommit := range fj.Commit.Precommits {
-		err := verifyBlockHashAgainstBlockNumber(s.blockState, preCommit.Vote.Hash, uint(preCommit.Vote.Number))
-		if err != nil {
-			return fmt.Errorf("verifying block hash against block number: %w", err)
-		}
+	if !ed25519.VerifyBatch(signatures) {
+		return ErrInvalidSignature
 	}
 
-	err = s.blockState.SetFinalisedHash(hash, fj.Round, setID)
-	if err != nil {
-		return fmt.Errorf("setting finalised hash: %w", err)
+	if len(voters) < threshold {
+		return ErrMinVotesNotMet
 	}
 
 	return nil
@@ -6,7 +6,6 @@
 package grandpa
 
 import (
-	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -836,7 +835,7 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 
 	round := uint64(1)
 	number := uint32(1)
-	precommits := buildTestJustification(t, 2, round, setID, kr, precommit)
+	precommits := buildTestJustification(t, len(auths), round, setID, kr, precommit)
 	just := newJustification(round, testHash, number, precommits)
 	data, err := scale.Marshal(*just)
 	require.NoError(t, err)
@@ -844,7 +843,7 @@ func TestMessageHandler_VerifyBlockJustification(t *testing.T) {
 	require.NoError(t, err)
 
 	// use wrong hash, shouldn't verify
-	precommits = buildTestJustification(t, 2, round+1, setID, kr, precommit)
+	precommits = buildTestJustification(t, len(auths), round+1, setID, kr, precommit)
 	just = newJustification(round+1, testHash, number, precommits)
 	just.Commit.Precommits[0].Vote.Hash = testHeader2.Hash()
 	data, err = scale.Marshal(*just)
@@ -894,7 +893,7 @@ func TestMessageHandler_VerifyBlockJustification_invalid(t *testing.T) {
 	number := uint32(2)
 
 	// use wrong hash, shouldn't verify
-	precommits := buildTestJustification(t, 2, round+1, setID, kr, precommit)
+	precommits := buildTestJustification(t, len(auths), round+1, setID, kr, precommit)
 	just := newJustification(round+1, testHash, number, precommits)
 	just.Commit.Precommits[0].Vote.Hash = genhash
 	data, err := scale.Marshal(*just)
@@ -903,7 +902,7 @@ func TestMessageHandler_VerifyBlockJustification_invalid(t *testing.T) {
 	require.Equal(t, ErrPrecommitBlockMismatch, err)
 
 	// use wrong round, shouldn't verify
-	precommits = buildTestJustification(t, 2, round+1, setID, kr, precommit)
+	precommits = buildTestJustification(t, len(auths), round+1, setID, kr, precommit)
 	just = newJustification(round+2, testHash, number, precommits)
 	data, err = scale.Marshal(*just)
 	require.NoError(t, err)
@@ -1472,7 +1471,7 @@ func TestService_VerifyBlockJustification(t *testing.T) { //nolint
 	kr, err := keystore.NewEd25519Keyring()
 	require.NoError(t, err)
 
-	precommits := buildTestJustification(t, 2, 1, 0, kr, precommit)
+	precommits := buildTestJustification(t, 3, 1, 0, kr, precommit)
 	justification := newJustification(1, testHash, 1, precommits)
 	justificationBytes, err := scale.Marshal(*justification)
 	require.NoError(t, err)
@@ -1504,9 +1503,8 @@ type args struct {
 				hash:          common.Hash{},
 				justification: []byte{1, 2, 3},
 			},
-			want: nil,
-			wantErr: errors.New("decoding struct: unmarshalling field at index 1: decoding struct: unmarshalling" +
-				" field at index 0: EOF"),
+			want:    nil,
+			wantErr: ErrMalformedJustification,
 		},
 		"valid_justification": {
 			fields: fields{
@@ -1515,8 +1513,9 @@ type args struct {
 					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
 					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testHeader, nil)
 					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
-						Return(true, nil).Times(3)
-					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(3)
+						Return(true, nil).Times(4)
+					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(4)
+					mockBlockState.EXPECT().SetJustification(testHash, justificationBytes).Return(nil)
 					mockBlockState.EXPECT().SetFinalisedHash(testHash, uint64(1),
 						uint64(0)).Return(nil)
 					return mockBlockState
@@ -1545,8 +1544,9 @@ type args struct {
 					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
 					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testHeader, nil)
 					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
-						Return(true, nil).Times(3)
-					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(3)
+						Return(true, nil).Times(4)
+					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(4)
+					mockBlockState.EXPECT().SetJustification(testHash, justificationBytes).Return(nil)
 					mockBlockState.EXPECT().SetFinalisedHash(testHash, uint64(1),
 						uint64(0)).Return(nil)
 					return mockBlockState
@@ -1568,6 +1568,61 @@ type args struct {
 			},
 			want: justificationBytes,
 		},
+		"valid_justification_of_finalised_block": {
+			fields: fields{
+				blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+					highestFinalisedHeader := &types.Header{ParentHash: testHash, Number: 2}
+					mockBlockState := NewMockBlockState(ctrl)
+					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
+					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(highestFinalisedHeader, nil).Times(2)
+					mockBlockState.EXPECT().IsDescendantOf(highestFinalisedHeader.Hash(), testHash).Return(false, nil)
+					mockBlockState.EXPECT().GetHeaderByNumber(uint(1)).Return(testHeader, nil)
+					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
+						Return(true, nil).Times(3)
+					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(4)
+					mockBlockState.EXPECT().SetJustification(testHash, justificationBytes).Return(nil)
+					return mockBlockState
+				},
+				grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+					mockGrandpaState := NewMockGrandpaState(ctrl)
+					mockGrandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), nil)
+					mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return([]types.GrandpaVoter{
+						{Key: *kr.Alice().Public().(*ed25519.PublicKey), ID: 1},
+						{Key: *kr.Bob().Public().(*ed25519.PublicKey), ID: 2},
+						{Key: *kr.Charlie().Public().(*ed25519.PublicKey), ID: 3},
+					}, nil)
+					return mockGrandpaState
+				},
+			},
+			args: args{
+				hash:          testHash,
+				justification: justificationBytes,
+			},
+			want: justificationBytes,
+		},
+		"justification_of_block_not_finalised": {
+			fields: fields{
+				blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+					highestFinalisedHeader := &types.Header{ParentHash: testHash, Number: 2}
+					mockBlockState := NewMockBlockState(ctrl)
+					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
+					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(highestFinalisedHeader, nil).Times(2)
+					mockBlockState.EXPECT().IsDescendantOf(highestFinalisedHeader.Hash(), testHash).Return(false, nil)
+					mockBlockState.EXPECT().GetHeaderByNumber(uint(1)).Return(&types.Header{Number: 1}, nil)
+					return mockBlockState
+				},
+				grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+					mockGrandpaState := NewMockGrandpaState(ctrl)
+					mockGrandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), nil)
+					return mockGrandpaState
+				},
+			},
+			args: args{
+				hash:          testHash,
+				justification: justificationBytes,
+			},
+			wantErr: errVoteBlockMismatch,
+		},
 	}
 	for name, tt := range tests {
 		tt := tt
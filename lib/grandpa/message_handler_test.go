@@ -0,0 +1,104 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_VerifyUnimportedJustification(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	keys := kr.Keys[:3]
+
+	voters := make([]types.GrandpaVoter, len(keys))
+	for i, key := range keys {
+		voters[i] = types.GrandpaVoter{Key: *key.Public().(*ed25519.PublicKey), ID: uint64(i)}
+	}
+
+	const setID, round = uint64(2), uint64(5)
+	hash := common.Hash{1}
+	const number = 100
+
+	vote := Vote{Hash: hash, Number: number}
+	msg, err := scale.Marshal(FullVote{Stage: precommit, Vote: vote, Round: round, SetID: setID})
+	require.NoError(t, err)
+
+	precommits := make([]SignedVote, len(keys))
+	for i, key := range keys {
+		signature, err := key.Sign(msg)
+		require.NoError(t, err)
+		precommits[i] = SignedVote{Vote: vote, AuthorityID: key.Public().(*ed25519.PublicKey).AsBytes()}
+		copy(precommits[i].Signature[:], signature)
+	}
+
+	justification, err := scale.Marshal(*newJustification(round, hash, number, precommits))
+	require.NoError(t, err)
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		number            uint
+		grandpaStateSetup func(grandpaState *MockGrandpaState)
+		errWrapped        error
+	}{
+		"valid_justification": {
+			number: number,
+			grandpaStateSetup: func(grandpaState *MockGrandpaState) {
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(number)).Return(setID, nil)
+				grandpaState.EXPECT().GetAuthorities(setID).Return(voters, nil)
+			},
+		},
+		"block_number_mismatch": {
+			number: number + 1,
+			grandpaStateSetup: func(grandpaState *MockGrandpaState) {
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(number+1)).Return(setID, nil)
+				grandpaState.EXPECT().GetAuthorities(setID).Return(voters, nil)
+			},
+			errWrapped: ErrBlockNumbersMismatch,
+		},
+		"set_id_error": {
+			number: number,
+			grandpaStateSetup: func(grandpaState *MockGrandpaState) {
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(number)).Return(uint64(0), errTest)
+			},
+			errWrapped: errTest,
+		},
+		"authority_set_not_known_yet": {
+			number: number,
+			grandpaStateSetup: func(grandpaState *MockGrandpaState) {
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(number)).Return(setID-1, nil)
+				grandpaState.EXPECT().GetAuthorities(setID-1).Return(voters, nil)
+			},
+			errWrapped: ErrInvalidSignature,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			grandpaState := NewMockGrandpaState(ctrl)
+			testCase.grandpaStateSetup(grandpaState)
+			service := &Service{grandpaState: grandpaState}
+
+			err := service.VerifyUnimportedJustification(hash, testCase.number, justification)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
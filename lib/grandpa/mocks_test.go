@@ -431,6 +431,21 @@ func (mr *MockGrandpaStateMockRecorder) GetLatestRound() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestRound", reflect.TypeOf((*MockGrandpaState)(nil).GetLatestRound))
 }
 
+// GetOwnVote mocks base method.
+func (m *MockGrandpaState) GetOwnVote(arg0, arg1 uint64, arg2 byte) (*types.GrandpaSignedVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnVote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*types.GrandpaSignedVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnVote indicates an expected call of GetOwnVote.
+func (mr *MockGrandpaStateMockRecorder) GetOwnVote(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnVote", reflect.TypeOf((*MockGrandpaState)(nil).GetOwnVote), arg0, arg1, arg2)
+}
+
 // GetPrecommits mocks base method.
 func (m *MockGrandpaState) GetPrecommits(arg0, arg1 uint64) ([]types.GrandpaSignedVote, error) {
 	m.ctrl.T.Helper()
@@ -505,6 +520,20 @@ func (mr *MockGrandpaStateMockRecorder) SetLatestRound(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLatestRound", reflect.TypeOf((*MockGrandpaState)(nil).SetLatestRound), arg0)
 }
 
+// SetOwnVote mocks base method.
+func (m *MockGrandpaState) SetOwnVote(arg0, arg1 uint64, arg2 byte, arg3 types.GrandpaSignedVote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOwnVote", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOwnVote indicates an expected call of SetOwnVote.
+func (mr *MockGrandpaStateMockRecorder) SetOwnVote(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOwnVote", reflect.TypeOf((*MockGrandpaState)(nil).SetOwnVote), arg0, arg1, arg2, arg3)
+}
+
 // SetPrecommits mocks base method.
 func (m *MockGrandpaState) SetPrecommits(arg0, arg1 uint64, arg2 []types.GrandpaSignedVote) error {
 	m.ctrl.T.Helper()
@@ -595,3 +624,15 @@ func (mr *MockNetworkMockRecorder) SendMessage(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockNetwork)(nil).SendMessage), arg0, arg1)
 }
+
+// SetValidatorPeers mocks base method.
+func (m *MockNetwork) SetValidatorPeers(arg0 peer.IDSlice) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetValidatorPeers", arg0)
+}
+
+// SetValidatorPeers indicates an expected call of SetValidatorPeers.
+func (mr *MockNetworkMockRecorder) SetValidatorPeers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValidatorPeers", reflect.TypeOf((*MockNetwork)(nil).SetValidatorPeers), arg0)
+}
@@ -40,7 +40,7 @@ func (hs *GrandpaHandshake) Encode() ([]byte, error) {
 
 // Decode the message into a GrandpaHandshake
 func (hs *GrandpaHandshake) Decode(in []byte) error {
-	return scale.Unmarshal(in, hs)
+	return network.UnmarshalMessage(in, hs)
 }
 
 // IsValid return if it is a valid handshake.
@@ -148,7 +148,7 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 // decodeMessage decodes a network-level consensus message into a GRANDPA VoteMessage or CommitMessage
 func decodeMessage(cm *network.ConsensusMessage) (m GrandpaMessage, err error) {
 	msg := newGrandpaMessage()
-	err = scale.Unmarshal(cm.Data, &msg)
+	err = network.UnmarshalMessage(cm.Data, &msg)
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +282,7 @@ func TestPlayGrandpaRound(t *testing.T) {
 					GossipMessage(gomock.Any()).
 					DoAndReturn(serviceNetworkMock(idx, neighbours, equivocatedVoteMessage)).
 					AnyTimes()
+				mockNet.EXPECT().SetValidatorPeers(gomock.Any()).AnyTimes()
 			}
 
 			runfinalisationServices(t, grandpaServices)
@@ -445,6 +446,7 @@ func TestPlayGrandpaRoundMultipleRounds(t *testing.T) {
 				GossipMessage(gomock.Any()).
 				Do(serviceNetworkMock(idx, neighbours)).
 				AnyTimes()
+			mockNet.EXPECT().SetValidatorPeers(gomock.Any()).AnyTimes()
 		}
 
 		// for each grandpa service we should start the finalisation and voting round
@@ -579,6 +581,14 @@ func TestSendingVotesInRightStage(t *testing.T) {
 	mockedGrandpaState.EXPECT().
 		GetPrecommits(uint64(1), uint64(0)).
 		Return([]types.GrandpaSignedVote{}, nil)
+	mockedGrandpaState.EXPECT().
+		GetOwnVote(uint64(1), uint64(0), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+	mockedGrandpaState.EXPECT().
+		SetOwnVote(uint64(1), uint64(0), gomock.Any(), gomock.AssignableToTypeOf(types.GrandpaSignedVote{})).
+		Return(nil).
+		AnyTimes()
 
 	mockedState := NewMockBlockState(ctrl)
 	mockedState.EXPECT().
@@ -658,6 +668,7 @@ func TestSendingVotesInRightStage(t *testing.T) {
 		SendMessage(expectedFinalizedTelemetryMessage)
 
 	mockedNet := NewMockNetwork(ctrl)
+	mockedNet.EXPECT().SetValidatorPeers(gomock.Any()).AnyTimes()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
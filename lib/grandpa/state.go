@@ -50,6 +50,8 @@ type GrandpaState interface {
 	SetPrecommits(round, setID uint64, data []SignedVote) error
 	GetPrevotes(round, setID uint64) ([]SignedVote, error)
 	GetPrecommits(round, setID uint64) ([]SignedVote, error)
+	SetOwnVote(round, setID uint64, stage byte, vote SignedVote) error
+	GetOwnVote(round, setID uint64, stage byte) (*SignedVote, error)
 	NextGrandpaAuthorityChange(bestBlockHash common.Hash, bestBlockNumber uint) (blockHeight uint, err error)
 }
 
@@ -57,6 +59,7 @@ type GrandpaState interface {
 type Network interface {
 	GossipMessage(msg network.NotificationsMessage)
 	SendMessage(to peer.ID, msg NotificationsMessage) error
+	SetValidatorPeers(peers peer.IDSlice)
 	RegisterNotificationsProtocol(sub protocol.ID,
 		messageID network.MessageType,
 		handshakeGetter network.HandshakeGetter,
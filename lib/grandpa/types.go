@@ -7,6 +7,7 @@
 	"bytes"
 	"fmt"
 
+	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
@@ -122,6 +123,17 @@ type Justification struct {
 	Commit Commit
 }
 
+// decodeJustification decodes the SCALE encoded justification given, bounding the
+// allocations to its length since it is received from the network. Any decoding
+// failure, including a panic, is returned wrapping ErrMalformedJustification.
+func decodeJustification(in []byte) (justification Justification, err error) {
+	err = network.UnmarshalMessage(in, &justification)
+	if err != nil {
+		return justification, fmt.Errorf("%w: %w", ErrMalformedJustification, err)
+	}
+	return justification, nil
+}
+
 func newJustification(round uint64, hash common.Hash, number uint32, j []SignedVote) *Justification {
 	return &Justification{
 		Round: round,
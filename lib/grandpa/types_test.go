@@ -110,3 +110,46 @@ func TestJustification_Decode(t *testing.T) {
 		fj.Commit.Hash)
 	require.Equal(t, 199, len(fj.Commit.Precommits))
 }
+
+func Test_decodeJustification(t *testing.T) {
+	t.Parallel()
+
+	data := testdata.Data3b1b0(t)
+
+	testCases := map[string]struct {
+		in         []byte
+		round      uint64
+		errWrapped error
+	}{
+		"network_justification": {
+			in:    data,
+			round: 6971,
+		},
+		"empty": {
+			errWrapped: ErrMalformedJustification,
+		},
+		"truncated": {
+			in:         data[:100],
+			errWrapped: ErrMalformedJustification,
+		},
+		"precommits_length_above_input": {
+			// round, commit hash and number followed by a compact length of 2^30 precommits
+			in: append(make([]byte, 8+32+4),
+				common.MustHexToBytes("0x03000000400000")...),
+			errWrapped: ErrMalformedJustification,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			justification, err := decodeJustification(testCase.in)
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped == nil {
+				require.Equal(t, testCase.round, justification.Round)
+			}
+		})
+	}
+}
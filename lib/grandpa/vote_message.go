@@ -90,6 +90,39 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 	return pc, vm, nil
 }
 
+// castVote signs our vote for the stage of the current round, and persists it before it is
+// sent so that the same vote is sent again if the node restarts during the round. If we
+// already cast a vote for the stage of the current round, that vote is used instead of
+// the vote given, since voting for a different block would be an equivocation.
+func (s *Service) castVote(vote *Vote, stage Subround) (*SignedVote, *VoteMessage, error) {
+	castVote, err := s.grandpaState.GetOwnVote(s.state.round, s.state.setID, byte(stage))
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting own %s vote: %w", stage, err)
+	}
+
+	if castVote != nil {
+		if castVote.Vote != *vote {
+			logger.Infof("casting again %s vote for block %s in round %d, instead of block %s",
+				stage, castVote.Vote.Hash, s.state.round, vote.Hash)
+		}
+		vote = &castVote.Vote
+	}
+
+	signedVote, voteMessage, err := s.createSignedVoteAndVoteMessage(vote, stage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if castVote == nil {
+		err = s.grandpaState.SetOwnVote(s.state.round, s.state.setID, byte(stage), *signedVote)
+		if err != nil {
+			return nil, nil, fmt.Errorf("persisting own %s vote: %w", stage, err)
+		}
+	}
+
+	return signedVote, voteMessage, nil
+}
+
 // validateVoteMessage validates a VoteMessage and adds it to the current votes
 // it returns the resulting vote if validated, error otherwise
 func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, error) {
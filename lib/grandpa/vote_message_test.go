@@ -9,6 +9,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -221,3 +222,98 @@ type args struct {
 		})
 	}
 }
+
+func TestService_castVote(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	castVote := &Vote{Hash: common.Hash{1}, Number: 1}
+	otherVote := &Vote{Hash: common.Hash{2}, Number: 2}
+
+	testCases := map[string]struct {
+		grandpaStateBuilder func(ctrl *gomock.Controller, castVote *SignedVote) GrandpaState
+		vote                *Vote
+		expectedVote        Vote
+		errWrapped          error
+		errMessage          string
+	}{
+		"get_own_vote_error": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller, _ *SignedVote) GrandpaState {
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetOwnVote(uint64(2), uint64(1), byte(prevote)).
+					Return(nil, errTestError)
+				return grandpaState
+			},
+			vote:       castVote,
+			errWrapped: errTestError,
+			errMessage: "getting own prevote vote: test dummy error",
+		},
+		"set_own_vote_error": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller, _ *SignedVote) GrandpaState {
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetOwnVote(uint64(2), uint64(1), byte(prevote)).
+					Return(nil, nil)
+				grandpaState.EXPECT().SetOwnVote(uint64(2), uint64(1), byte(prevote),
+					gomock.AssignableToTypeOf(SignedVote{})).Return(errTestError)
+				return grandpaState
+			},
+			vote:       castVote,
+			errWrapped: errTestError,
+			errMessage: "persisting own prevote vote: test dummy error",
+		},
+		"first_vote_persisted": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller, castVote *SignedVote) GrandpaState {
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetOwnVote(uint64(2), uint64(1), byte(prevote)).
+					Return(nil, nil)
+				grandpaState.EXPECT().SetOwnVote(uint64(2), uint64(1), byte(prevote), *castVote).
+					Return(nil)
+				return grandpaState
+			},
+			vote:         castVote,
+			expectedVote: *castVote,
+		},
+		"vote_cast_before_restart": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller, castVote *SignedVote) GrandpaState {
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetOwnVote(uint64(2), uint64(1), byte(prevote)).
+					Return(castVote, nil)
+				return grandpaState
+			},
+			vote:         otherVote,
+			expectedVote: *castVote,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := &Service{
+				keypair: keypair,
+				state:   &State{setID: 1, round: 2},
+			}
+			expectedSignedVote, _, err := service.createSignedVoteAndVoteMessage(castVote, prevote)
+			require.NoError(t, err)
+			service.grandpaState = testCase.grandpaStateBuilder(ctrl, expectedSignedVote)
+
+			signedVote, voteMessage, err := service.castVote(testCase.vote, prevote)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			// the vote cast again is the same as the vote cast before the restart,
+			// so it cannot be reported as an equivocation.
+			assert.Equal(t, expectedSignedVote, signedVote)
+			assert.Equal(t, testCase.expectedVote, signedVote.Vote)
+			assert.Equal(t, uint64(2), voteMessage.Round)
+			assert.Equal(t, expectedSignedVote.Signature, voteMessage.Message.Signature)
+		})
+	}
+}
@@ -15,6 +15,7 @@
 	"path/filepath"
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/bls381"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
@@ -122,8 +123,12 @@ func EncryptAndWriteToFile(path string, pk crypto.PrivateKey, password []byte) e
 		keytype = crypto.Secp256k1Type
 	}
 
+	if _, ok := pk.(*bls381.PrivateKey); ok {
+		keytype = crypto.Bls381Type
+	}
+
 	if keytype == "" {
-		return errors.New("cannot write key not of type sr25519, ed25519, secp256k1")
+		return errors.New("cannot write key not of type sr25519, ed25519, secp256k1, bls381")
 	}
 
 	keydata := &EncryptedKeystore{
@@ -14,10 +14,13 @@
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/bls381"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/utils"
+
+	"github.com/ChainSafe/go-schnorrkel"
 )
 
 // PrivateKeyToKeypair returns a public, private keypair given a private key
@@ -28,6 +31,8 @@ func PrivateKeyToKeypair(priv crypto.PrivateKey) (kp KeyPair, err error) {
 		kp, err = ed25519.NewKeypairFromPrivate(key)
 	} else if key, ok := priv.(*secp256k1.PrivateKey); ok {
 		kp, err = secp256k1.NewKeypairFromPrivate(key)
+	} else if key, ok := priv.(*bls381.PrivateKey); ok {
+		kp, err = bls381.NewKeypairFromPrivate(key)
 	} else {
 		return nil, errors.New("cannot decode key: invalid key type")
 	}
@@ -43,6 +48,8 @@ func DecodePrivateKey(in []byte, keytype crypto.KeyType) (priv crypto.PrivateKey
 		priv, err = sr25519.NewPrivateKey(in)
 	} else if keytype == crypto.Secp256k1Type {
 		priv, err = secp256k1.NewPrivateKey(in)
+	} else if keytype == crypto.Bls381Type {
+		priv, err = bls381.NewPrivateKey(in)
 	} else {
 		return nil, errors.New("cannot decode key: invalid key type")
 	}
@@ -57,6 +64,15 @@ func DecodeKeyPairFromHex(keystr []byte, keytype crypto.KeyType) (kp KeyPair, er
 		kp, err = sr25519.NewKeypairFromSeed(keystr)
 	case crypto.Ed25519Type:
 		kp, err = ed25519.NewKeypairFromSeed(keystr)
+	case crypto.Secp256k1Type:
+		var priv *secp256k1.PrivateKey
+		priv, err = secp256k1.NewPrivateKey(keystr)
+		if err != nil {
+			return nil, err
+		}
+		kp, err = secp256k1.NewKeypairFromPrivate(priv)
+	case crypto.Bls381Type:
+		kp, err = bls381.NewKeypairFromSeed(keystr)
 	default:
 		return nil, errors.New("cannot decode key: invalid key type")
 	}
@@ -64,6 +80,79 @@ func DecodeKeyPairFromHex(keystr []byte, keytype crypto.KeyType) (kp KeyPair, er
 	return kp, err
 }
 
+// DecodeKeyPairFromSecretURI returns the keypair of the given type described by a secret URI
+// of the form `phrase//hard/soft///password`, following subkey semantics.
+// The phrase is either a BIP39 mnemonic or a 0x prefixed 32 byte hex seed.
+func DecodeKeyPairFromSecretURI(suri string, keytype crypto.KeyType) (kp PublicPrivater, err error) {
+	parsed, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	var seed []byte
+	if strings.HasPrefix(parsed.Phrase, "0x") {
+		seed, err = common.HexToBytes(parsed.Phrase)
+		if err != nil {
+			return nil, fmt.Errorf("decoding seed: %w", err)
+		}
+	} else {
+		mnemonicSeed, err := schnorrkel.SeedFromMnemonic(parsed.Phrase, parsed.Password)
+		if err != nil {
+			return nil, fmt.Errorf("decoding mnemonic: %w", err)
+		}
+		seed = mnemonicSeed[:32]
+	}
+
+	switch keytype {
+	case crypto.Sr25519Type:
+		root, err := sr25519.NewKeypairFromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		derived, err := root.Derive(parsed.Junctions)
+		if err != nil {
+			return nil, err
+		}
+		return derived, nil
+	case crypto.Ed25519Type:
+		root, err := ed25519.NewKeypairFromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		derived, err := root.Derive(parsed.Junctions)
+		if err != nil {
+			return nil, err
+		}
+		return derived, nil
+	case crypto.Secp256k1Type:
+		priv, err := secp256k1.NewPrivateKey(seed)
+		if err != nil {
+			return nil, err
+		}
+		root, err := secp256k1.NewKeypairFromPrivate(priv)
+		if err != nil {
+			return nil, err
+		}
+		derived, err := root.Derive(parsed.Junctions)
+		if err != nil {
+			return nil, err
+		}
+		return derived, nil
+	case crypto.Bls381Type:
+		root, err := bls381.NewKeypairFromSeed(seed)
+		if err != nil {
+			return nil, err
+		}
+		derived, err := root.Derive(parsed.Junctions)
+		if err != nil {
+			return nil, err
+		}
+		return derived, nil
+	default:
+		return nil, errors.New("cannot decode key: invalid key type")
+	}
+}
+
 // GenerateKeypair create a new keypair with the corresponding type and saves
 // it to basepath/keystore/[public key].key in json format encrypted using the
 // specified password and returns the resulting filepath of the new key
@@ -89,6 +178,11 @@ func GenerateKeypair(keytype string, kp PublicPrivater, basepath string, passwor
 			if err != nil {
 				return "", fmt.Errorf("failed to generate secp256k1 keypair: %s", err)
 			}
+		} else if keytype == crypto.Bls381Type {
+			kp, err = bls381.GenerateKeypair()
+			if err != nil {
+				return "", fmt.Errorf("failed to generate bls381 keypair: %s", err)
+			}
 		}
 	}
 
@@ -210,6 +304,11 @@ func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string
 		if err != nil {
 			return "", fmt.Errorf("failed to generate secp256k1 keypair: %s", err)
 		}
+	} else if keytype == crypto.Bls381Type {
+		kp, err = bls381.NewKeypairFromPrivateKeyString(key)
+		if err != nil {
+			return "", fmt.Errorf("failed to import bls381 keypair: %s", err)
+		}
 	}
 
 	return GenerateKeypair(keytype, kp, basepath, password)
@@ -288,10 +387,22 @@ func DetermineKeyType(t string) crypto.KeyType {
 	case "acco", "babe", "para", "asgn",
 		"aura", "imon", "audi", "dumy":
 		return crypto.Sr25519Type
+	case "beef":
+		return crypto.Secp256k1Type
 	}
 	return crypto.UnknownType
 }
 
+// DeterminePublicKeyType returns the crypto.KeyType of the public key given for the key type
+// t. The beef key type holds both ecdsa and bls381 keys, which are told apart by the length
+// of their public keys.
+func DeterminePublicKeyType(t string, pubKey []byte) crypto.KeyType {
+	if Name(t) == BeefName && len(pubKey) == bls381.PublicKeyLength {
+		return crypto.Bls381Type
+	}
+	return DetermineKeyType(t)
+}
+
 // HasKey returns true if given hex encoded public key string is found in keystore, false otherwise, error if there
 // are issues decoding string
 func HasKey(pubKeyStr, keyType string, keystore AddressKeypairGetter) (bool, error) {
@@ -299,7 +410,7 @@ func HasKey(pubKeyStr, keyType string, keystore AddressKeypairGetter) (bool, err
 	if err != nil {
 		return false, err
 	}
-	cKeyType := DetermineKeyType(keyType)
+	cKeyType := DeterminePublicKeyType(keyType, keyBytes)
 
 	var pubKey crypto.PublicKey
 	switch cKeyType {
@@ -307,6 +418,10 @@ func HasKey(pubKeyStr, keyType string, keystore AddressKeypairGetter) (bool, err
 		pubKey, err = sr25519.NewPublicKey(keyBytes)
 	case crypto.Ed25519Type:
 		pubKey, err = ed25519.NewPublicKey(keyBytes)
+	case crypto.Secp256k1Type:
+		pubKey, err = secp256k1.NewPublicKey(keyBytes)
+	case crypto.Bls381Type:
+		pubKey, err = bls381.NewPublicKey(keyBytes)
 	default:
 		err = fmt.Errorf("unknown key type: %s", keyType)
 	}
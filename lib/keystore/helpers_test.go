@@ -14,7 +14,9 @@
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/bls381"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
@@ -51,6 +53,7 @@ func TestLoadKeystore(t *testing.T) {
 	{testType: "imon", expectedType: crypto.Sr25519Type},
 	{testType: "audi", expectedType: crypto.Sr25519Type},
 	{testType: "dumy", expectedType: crypto.Sr25519Type},
+	{testType: "beef", expectedType: crypto.Secp256k1Type},
 	{testType: "xxxx", expectedType: crypto.UnknownType},
 }
 
@@ -394,6 +397,124 @@ func TestDecodeKeyPairFromHex(t *testing.T) {
 	expectedPublic = "0xd3db685ed1f94c195dc3e72803fa3d8549df45381388e313fa8170f0b397895c"
 	require.Equal(t, kp.Public().Hex(), expectedPublic)
 
+	keytype = DetermineKeyType("beef")
+	keyBytes, err = common.HexToBytes("0xcb6df9de1efca7a3998a8ead4e02159d5fa99c3e0d4fd6432667390bb4726854")
+	require.NoError(t, err)
+
+	kp, err = DecodeKeyPairFromHex(keyBytes, keytype)
+	require.NoError(t, err)
+	require.IsType(t, &secp256k1.Keypair{}, kp)
+
+	expectedPublic = "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"
+	require.Equal(t, kp.Public().Hex(), expectedPublic)
+
+	keytype = crypto.Bls381Type
+	kp, err = DecodeKeyPairFromHex(keyBytes, keytype)
+	require.NoError(t, err)
+	require.IsType(t, &bls381.Keypair{}, kp)
+
 	_, err = DecodeKeyPairFromHex(nil, "")
 	require.Error(t, err, "cannot decode key: invalid key type")
 }
+
+func TestDecodeKeyPairFromSecretURI(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		suri      string
+		keytype   crypto.KeyType
+		publicKey string
+		errMsg    string
+	}{
+		"sr25519_alice": {
+			suri:      "//Alice",
+			keytype:   crypto.Sr25519Type,
+			publicKey: "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
+		"sr25519_alice_stash": {
+			suri:      crypto.DevPhrase + "//Alice//stash",
+			keytype:   crypto.Sr25519Type,
+			publicKey: "0xbe5ddb1579b72e84524fc29e78609e3caf42e85aa118ebfe0b0ad404b5bdd25f",
+		},
+		"ed25519_alice": {
+			suri:      "//Alice",
+			keytype:   crypto.Ed25519Type,
+			publicKey: "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee",
+		},
+		"secp256k1_alice": {
+			suri:      "//Alice",
+			keytype:   crypto.Secp256k1Type,
+			publicKey: "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1",
+		},
+		"ed25519_soft_junction": {
+			suri:    "//Alice/soft",
+			keytype: crypto.Ed25519Type,
+			errMsg:  "ed25519: soft derivation not supported",
+		},
+		"invalid_mnemonic": {
+			suri:    "not a valid mnemonic//Alice",
+			keytype: crypto.Sr25519Type,
+			errMsg:  "decoding mnemonic: Invalid mnemonic",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kp, err := DecodeKeyPairFromSecretURI(testCase.suri, testCase.keytype)
+			if testCase.errMsg != "" {
+				require.EqualError(t, err, testCase.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.publicKey, kp.Public().Hex())
+		})
+	}
+}
+
+func TestHasKey_ecdsa(t *testing.T) {
+	t.Parallel()
+
+	kp, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	ks := NewBasicKeystore(BeefName, crypto.Secp256k1Type)
+	require.NoError(t, ks.Insert(kp))
+
+	has, err := HasKey(kp.Public().Hex(), string(BeefName), ks)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	other, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	has, err = HasKey(other.Public().Hex(), string(BeefName), ks)
+	require.NoError(t, err)
+	require.False(t, has)
+}
+
+func TestHasKey_bls381(t *testing.T) {
+	t.Parallel()
+
+	kp, err := bls381.GenerateKeypair()
+	require.NoError(t, err)
+
+	ks := NewGlobalKeystore()
+	beefBls, err := ks.GetKeystoreOfType([]byte(BeefName), crypto.Bls381Type)
+	require.NoError(t, err)
+	require.NoError(t, beefBls.Insert(kp))
+
+	require.Equal(t, crypto.Bls381Type, DeterminePublicKeyType(string(BeefName), kp.Public().Encode()))
+	has, err := HasKey(kp.Public().Hex(), string(BeefName), ks.BeefBls)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	listed := ks.BeefBls.PublicKeys()
+	require.Len(t, listed, 1)
+	require.Equal(t, kp.Public().Hex(), listed[0].Hex())
+	require.Zero(t, ks.Beef.Size())
+
+	_, err = ks.GetKeystoreOfType([]byte(GranName), crypto.Bls381Type)
+	require.ErrorIs(t, err, ErrKeyTypeNotSupported)
+}
@@ -3,7 +3,12 @@
 
 package keystore
 
-import "github.com/ChainSafe/gossamer/lib/crypto"
+import (
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/gtank/merlin"
+)
 
 // KeyPair is a key pair to sign messages and from which
 // the public key and key type can be obtained.
@@ -13,6 +18,13 @@ type KeyPair interface {
 	Typer
 }
 
+// VrfKeyPair is a key pair which can also produce VRF signatures,
+// such as the keys used for BABE slot claims and Sassafras tickets.
+type VrfKeyPair interface {
+	KeyPair
+	SignVrf(t *merlin.Transcript) (sr25519.VrfSignature, error)
+}
+
 // PublicPrivater can return the private or public key
 // from the keypair.
 type PublicPrivater interface {
@@ -5,6 +5,7 @@
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -12,6 +13,10 @@
 
 var (
 	ErrInvalidKeystoreName = errors.New("invalid keystore name")
+	// ErrKeypairNotFound is returned when the keystore does not hold the requested keypair
+	ErrKeypairNotFound = errors.New("keypair not found")
+	// ErrNotVrfKeypair is returned when a keypair cannot produce VRF signatures
+	ErrNotVrfKeypair = errors.New("keypair does not support VRF signing")
 )
 
 // Name represents a defined keystore name
@@ -27,6 +32,7 @@
 	AsgnName Name = "asgn"
 	AudiName Name = "audi"
 	DumyName Name = "dumy"
+	BeefName Name = "beef"
 )
 
 // Keystore provides key management functionality
@@ -68,6 +74,9 @@ type GlobalKeystore struct {
 	Imon Keystore
 	Audi Keystore
 	Dumy Keystore
+	Beef Keystore
+	// BeefBls holds the bls381 BEEFY keys, which share the beef key type with the ecdsa ones
+	BeefBls Keystore
 }
 
 // NewGlobalKeystore returns a new GlobalKeystore
@@ -82,10 +91,24 @@ func NewGlobalKeystore() *GlobalKeystore {
 		Imon: NewBasicKeystore(ImonName, crypto.Sr25519Type),
 		Audi: NewBasicKeystore(AudiName, crypto.Sr25519Type),
 		Dumy: NewGenericKeystore(DumyName),
+		Beef: NewBasicKeystore(BeefName, crypto.Secp256k1Type),
+
+		BeefBls: NewBasicKeystore(BeefName, crypto.Bls381Type),
 	}
 }
 
-// GetKeystore returns a keystore given its name
+// Keystores returns all the keystores set in the GlobalKeystore
+func (k *GlobalKeystore) Keystores() (keystores []Keystore) {
+	for _, ks := range []Keystore{k.Babe, k.Gran, k.Acco, k.Aura, k.Para, k.Asgn, k.Imon, k.Audi, k.Dumy, k.Beef, k.BeefBls} {
+		if ks != nil {
+			keystores = append(keystores, ks)
+		}
+	}
+	return keystores
+}
+
+// GetKeystore returns a keystore given its name. For the beef name, shared by several
+// crypto types, the ecdsa keystore is returned.
 func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 	nameStr := Name(name)
 	switch nameStr {
@@ -107,7 +130,92 @@ func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 		return k.Audi, nil
 	case DumyName:
 		return k.Dumy, nil
+	case BeefName:
+		return k.Beef, nil
 	default:
 		return nil, ErrInvalidKeystoreName
 	}
 }
+
+// GetKeystoreOfType returns the keystore of the given name holding keys of the given type.
+// It returns ErrInvalidKeystoreName if there is no keystore of the name, and
+// ErrKeyTypeNotSupported if none of the keystores of the name holds keys of the type.
+func (k *GlobalKeystore) GetKeystoreOfType(name []byte, typ crypto.KeyType) (Keystore, error) {
+	ks, err := k.GetKeystore(name)
+	if err != nil {
+		return nil, err
+	}
+	if Name(name) == BeefName && typ == crypto.Bls381Type {
+		ks = k.BeefBls
+	}
+
+	if ks == nil {
+		return nil, fmt.Errorf("%w: no %s keystore", ErrKeyTypeNotSupported, name)
+	}
+
+	// generic keystores hold keys of any type
+	if ks.Type() != crypto.UnknownType && ks.Type() != typ {
+		return nil, fmt.Errorf("%w, passed key type: %s, acceptable key type: %s",
+			ErrKeyTypeNotSupported, typ, ks.Type())
+	}
+	return ks, nil
+}
+
+// SetKeystore replaces the keystore matching the name of the given keystore
+func (k *GlobalKeystore) SetKeystore(ks Keystore) error {
+	switch ks.Name() {
+	case BabeName:
+		k.Babe = ks
+	case GranName:
+		k.Gran = ks
+	case AccoName:
+		k.Acco = ks
+	case AuraName:
+		k.Aura = ks
+	case ImonName:
+		k.Imon = ks
+	case ParaName:
+		k.Para = ks
+	case AsgnName:
+		k.Asgn = ks
+	case AudiName:
+		k.Audi = ks
+	case DumyName:
+		k.Dumy = ks
+	case BeefName:
+		if ks.Type() == crypto.Bls381Type {
+			k.BeefBls = ks
+		} else {
+			k.Beef = ks
+		}
+	default:
+		return ErrInvalidKeystoreName
+	}
+	return nil
+}
+
+// VrfKeypairs returns the keypairs of the keystore which can produce VRF signatures
+func VrfKeypairs(ks Keystore) (kps []VrfKeyPair) {
+	for _, kp := range ks.Keypairs() {
+		if vrfKp, ok := kp.(VrfKeyPair); ok {
+			kps = append(kps, vrfKp)
+		}
+	}
+	return kps
+}
+
+// GetVrfKeypair returns the keypair for the given public key if it can produce VRF signatures.
+// It returns ErrKeypairNotFound if the keystore does not hold the key, and ErrNotVrfKeypair if
+// the key cannot be used for VRF signing, eg. because it is held by a remote signer.
+func GetVrfKeypair(ks Keystore, pub crypto.PublicKey) (VrfKeyPair, error) {
+	kp := ks.GetKeypair(pub)
+	if kp == nil {
+		return nil, fmt.Errorf("%w: %s", ErrKeypairNotFound, pub.Hex())
+	}
+
+	vrfKp, ok := kp.(VrfKeyPair)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotVrfKeypair, pub.Hex())
+	}
+	return vrfKp, nil
+}
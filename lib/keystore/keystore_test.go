@@ -0,0 +1,71 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalKeystore_Keystores(t *testing.T) {
+	t.Parallel()
+
+	ks := NewGlobalKeystore()
+	assert.Len(t, ks.Keystores(), 11)
+
+	partial := &GlobalKeystore{Babe: NewBasicKeystore(BabeName, crypto.Sr25519Type)}
+	assert.Equal(t, []Keystore{partial.Babe}, partial.Keystores())
+}
+
+func TestGlobalKeystore_SetKeystore(t *testing.T) {
+	t.Parallel()
+
+	ks := NewGlobalKeystore()
+	remote := NewRemoteKeystore(GranName, crypto.Ed25519Type, nil)
+	err := ks.SetKeystore(remote)
+	require.NoError(t, err)
+	assert.Same(t, remote, ks.Gran)
+
+	err = ks.SetKeystore(NewBasicKeystore("nope", crypto.Sr25519Type))
+	assert.ErrorIs(t, err, ErrInvalidKeystoreName)
+}
+
+func TestVrfKeypairs(t *testing.T) {
+	t.Parallel()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks := NewBasicKeystore(BabeName, crypto.Sr25519Type)
+	require.NoError(t, ks.Insert(kp))
+
+	assert.Equal(t, []VrfKeyPair{kp}, VrfKeypairs(ks))
+
+	vrfKp, err := GetVrfKeypair(ks, kp.Public())
+	require.NoError(t, err)
+	assert.Same(t, kp, vrfKp)
+
+	other, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	_, err = GetVrfKeypair(ks, other.Public())
+	assert.ErrorIs(t, err, ErrKeypairNotFound)
+}
+
+func TestGetVrfKeypair_remote(t *testing.T) {
+	t.Parallel()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	ks := NewRemoteKeystore(ImonName, crypto.Sr25519Type, staticRemoteSigner{kp.Public().Encode()})
+	require.NoError(t, ks.Refresh(context.Background()))
+
+	assert.Empty(t, VrfKeypairs(ks))
+
+	_, err = GetVrfKeypair(ks, kp.Public())
+	assert.ErrorIs(t, err, ErrNotVrfKeypair)
+}
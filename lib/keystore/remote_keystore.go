@@ -0,0 +1,319 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/bls381"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+)
+
+var (
+	// ErrRemoteSignerRequest is returned when a request to the remote signer fails
+	ErrRemoteSignerRequest = errors.New("remote signer request failed")
+	// ErrRemoteKeyInsert is returned when trying to insert a keypair into a remote keystore
+	ErrRemoteKeyInsert = errors.New("cannot insert keys into a remote keystore")
+	// ErrRemoteKeyTypeMismatch is returned when the remote signer returns a key not matching the keystore type
+	ErrRemoteKeyTypeMismatch = errors.New("remote signer returned key of unexpected type")
+)
+
+const defaultRemoteSignerTimeout = 10 * time.Second
+
+// RemoteSigner signs messages with keys held by an external service.
+type RemoteSigner interface {
+	PublicKeys(ctx context.Context, name Name) ([][]byte, error)
+	Sign(ctx context.Context, name Name, pub, msg []byte) ([]byte, error)
+}
+
+// HTTPRemoteSigner is a RemoteSigner talking to a signing service over HTTP.
+// Requests are authenticated using a bearer token and all payloads are JSON,
+// with keys, messages and signatures hex encoded with a 0x prefix.
+//
+// The service is expected to expose:
+//   - GET  <url>/keys/<name>  returning {"publicKeys": ["0x..."]}
+//   - POST <url>/sign         taking {"keyType": "<name>", "publicKey": "0x...", "message": "0x..."}
+//     and returning {"signature": "0x..."}
+//
+// Any status other than 200 OK is treated as a failed request.
+type HTTPRemoteSigner struct {
+	url       string
+	authToken string
+	client    *http.Client
+}
+
+// NewHTTPRemoteSigner returns a new HTTPRemoteSigner for the given base url.
+func NewHTTPRemoteSigner(url, authToken string) *HTTPRemoteSigner {
+	return &HTTPRemoteSigner{
+		url:       strings.TrimSuffix(url, "/"),
+		authToken: authToken,
+		client:    &http.Client{Timeout: defaultRemoteSignerTimeout},
+	}
+}
+
+type remoteKeysResponse struct {
+	PublicKeys []string `json:"publicKeys"`
+}
+
+type remoteSignRequest struct {
+	KeyType   string `json:"keyType"`
+	PublicKey string `json:"publicKey"`
+	Message   string `json:"message"`
+}
+
+type remoteSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// PublicKeys returns the encoded public keys the remote signer holds for the given keystore name.
+func (s *HTTPRemoteSigner) PublicKeys(ctx context.Context, name Name) ([][]byte, error) {
+	var response remoteKeysResponse
+	err := s.do(ctx, http.MethodGet, "/keys/"+string(name), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, len(response.PublicKeys))
+	for i, key := range response.PublicKeys {
+		keys[i], err = common.HexToBytes(key)
+		if err != nil {
+			return nil, fmt.Errorf("decoding public key %q: %w", key, err)
+		}
+	}
+	return keys, nil
+}
+
+// Sign requests a signature of msg by the key pub held in the keystore name.
+func (s *HTTPRemoteSigner) Sign(ctx context.Context, name Name, pub, msg []byte) ([]byte, error) {
+	request := remoteSignRequest{
+		KeyType:   string(name),
+		PublicKey: common.BytesToHex(pub),
+		Message:   common.BytesToHex(msg),
+	}
+
+	var response remoteSignResponse
+	err := s.do(ctx, http.MethodPost, "/sign", request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return common.HexToBytes(response.Signature)
+}
+
+func (s *HTTPRemoteSigner) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.url+path, reader)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrRemoteSignerRequest, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s %s returned status %d", ErrRemoteSignerRequest, method, path, resp.StatusCode)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// RemoteKeystore holds keys of a certain type whose private parts are kept by a RemoteSigner
+type RemoteKeystore struct {
+	name   Name
+	typ    crypto.KeyType
+	signer RemoteSigner
+	keys   []KeyPair // keys in the order returned by the remote signer
+	lock   sync.RWMutex
+}
+
+// NewRemoteKeystore creates a new RemoteKeystore with the given key type backed by the given signer
+func NewRemoteKeystore(name Name, typ crypto.KeyType, signer RemoteSigner) *RemoteKeystore {
+	return &RemoteKeystore{
+		name:   name,
+		typ:    typ,
+		signer: signer,
+	}
+}
+
+// Name returns the keystore's name
+func (ks *RemoteKeystore) Name() Name {
+	return ks.name
+}
+
+// Type returns the keystore's key type
+func (ks *RemoteKeystore) Type() crypto.KeyType {
+	return ks.typ
+}
+
+// Size returns the number of keys in the keystore
+func (ks *RemoteKeystore) Size() int {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	return len(ks.keys)
+}
+
+// Refresh fetches the public keys held by the remote signer and replaces the
+// keys currently known by the keystore.
+func (ks *RemoteKeystore) Refresh(ctx context.Context) error {
+	encodedKeys, err := ks.signer.PublicKeys(ctx, ks.name)
+	if err != nil {
+		return fmt.Errorf("fetching public keys: %w", err)
+	}
+
+	keys := make([]KeyPair, 0, len(encodedKeys))
+	for _, encoded := range encodedKeys {
+		pub, err := decodePublicKey(encoded, ks.typ)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrRemoteKeyTypeMismatch, err)
+		}
+
+		keys = append(keys, &remoteKeypair{
+			name:   ks.name,
+			typ:    ks.typ,
+			pub:    pub,
+			signer: ks.signer,
+		})
+	}
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	ks.keys = keys
+	return nil
+}
+
+// Insert is not supported since private keys never leave the remote signer
+func (*RemoteKeystore) Insert(KeyPair) error {
+	return ErrRemoteKeyInsert
+}
+
+// GetKeypair returns a keypair corresponding to the given public key, or nil if it doesn't exist
+func (ks *RemoteKeystore) GetKeypair(pub crypto.PublicKey) KeyPair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	for _, key := range ks.keys {
+		if bytes.Equal(key.Public().Encode(), pub.Encode()) {
+			return key
+		}
+	}
+	return nil
+}
+
+// GetKeypairFromAddress returns a keypair corresponding to the given address, or nil if it doesn't exist
+func (ks *RemoteKeystore) GetKeypairFromAddress(pub common.Address) KeyPair {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	for _, key := range ks.keys {
+		if key.Public().Address() == pub {
+			return key
+		}
+	}
+	return nil
+}
+
+// PublicKeys returns all public keys in the keystore
+func (ks *RemoteKeystore) PublicKeys() (keys []crypto.PublicKey) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	for _, key := range ks.keys {
+		keys = append(keys, key.Public())
+	}
+	return keys
+}
+
+// Keypairs returns all keypairs in the keystore, in the order returned by the remote signer
+func (ks *RemoteKeystore) Keypairs() (kps []KeyPair) {
+	ks.lock.RLock()
+	defer ks.lock.RUnlock()
+	return append(kps, ks.keys...)
+}
+
+// remoteKeypair is a KeyPair whose signing operations are forwarded to a RemoteSigner
+type remoteKeypair struct {
+	name   Name
+	typ    crypto.KeyType
+	pub    crypto.PublicKey
+	signer RemoteSigner
+}
+
+// Sign signs the message with the remote signer, and verifies the signature returned
+// against the public key of the keypair, so an invalid signature is never used.
+func (kp *remoteKeypair) Sign(msg []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRemoteSignerTimeout)
+	defer cancel()
+	sig, err := kp.signer.Sign(ctx, kp.name, kp.pub.Encode(), msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// ecdsa signatures carry a trailing recovery id which is not verified
+	verified := sig
+	if kp.typ == crypto.Secp256k1Type && len(sig) == secp256k1.SignatureLength+1 {
+		verified = sig[:secp256k1.SignatureLength]
+	}
+
+	ok, err := kp.pub.Verify(msg, verified)
+	if err != nil {
+		return nil, fmt.Errorf("%w: verifying signature: %s", ErrRemoteSignerRequest, err)
+	} else if !ok {
+		return nil, fmt.Errorf("%w: signature does not match public key %s",
+			ErrRemoteSignerRequest, kp.pub.Hex())
+	}
+	return sig, nil
+}
+
+func (kp *remoteKeypair) Public() crypto.PublicKey {
+	return kp.pub
+}
+
+func (kp *remoteKeypair) Type() crypto.KeyType {
+	return kp.typ
+}
+
+// decodePublicKey turns input bytes into a public key based on the specified key type
+func decodePublicKey(in []byte, keytype crypto.KeyType) (crypto.PublicKey, error) {
+	switch keytype {
+	case crypto.Sr25519Type:
+		return sr25519.NewPublicKey(in)
+	case crypto.Ed25519Type:
+		return ed25519.NewPublicKey(in)
+	case crypto.Secp256k1Type:
+		return secp256k1.NewPublicKey(in)
+	case crypto.Bls381Type:
+		return bls381.NewPublicKey(in)
+	default:
+		return nil, fmt.Errorf("cannot decode key: invalid key type %s", keytype)
+	}
+}
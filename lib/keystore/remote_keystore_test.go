@@ -0,0 +1,201 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRemoteSignerServer(t *testing.T, token string, kp *ed25519.Keypair) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/keys/gran", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		err := json.NewEncoder(w).Encode(remoteKeysResponse{
+			PublicKeys: []string{kp.Public().Hex()},
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var request remoteSignRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		msg, err := common.HexToBytes(request.Message)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		sig, err := kp.Sign(msg)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		err = json.NewEncoder(w).Encode(remoteSignResponse{Signature: common.BytesToHex(sig)})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteKeystore(t *testing.T) {
+	t.Parallel()
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	server := newTestRemoteSignerServer(t, "secret", kp)
+
+	ks := NewRemoteKeystore(GranName, crypto.Ed25519Type, NewHTTPRemoteSigner(server.URL, "secret"))
+	err = ks.Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, ks.Size())
+
+	remoteKp := ks.GetKeypairFromAddress(kp.Public().Address())
+	require.NotNil(t, remoteKp)
+	assert.Equal(t, crypto.Ed25519Type, remoteKp.Type())
+	assert.Equal(t, kp.Public().Encode(), remoteKp.Public().Encode())
+
+	msg := []byte("helloworld")
+	sig, err := remoteKp.Sign(msg)
+	require.NoError(t, err)
+	ok, err := kp.Public().Verify(msg, sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	err = ks.Insert(kp)
+	assert.ErrorIs(t, err, ErrRemoteKeyInsert)
+}
+
+func TestRemoteKeystore_Keypairs_order(t *testing.T) {
+	t.Parallel()
+
+	kps := make([]*ed25519.Keypair, 4)
+	publicKeys := make([][]byte, len(kps))
+	for i := range kps {
+		var err error
+		kps[i], err = ed25519.GenerateKeypair()
+		require.NoError(t, err)
+		publicKeys[i] = kps[i].Public().Encode()
+	}
+
+	ks := NewRemoteKeystore(GranName, crypto.Ed25519Type, staticRemoteSigner(publicKeys))
+	err := ks.Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, len(kps), ks.Size())
+
+	for i := 0; i < 3; i++ {
+		remoteKps := ks.Keypairs()
+		for j, kp := range remoteKps {
+			assert.Equal(t, publicKeys[j], kp.Public().Encode())
+		}
+	}
+}
+
+type staticRemoteSigner [][]byte
+
+func (s staticRemoteSigner) PublicKeys(context.Context, Name) ([][]byte, error) {
+	return s, nil
+}
+
+func (staticRemoteSigner) Sign(context.Context, Name, []byte, []byte) ([]byte, error) {
+	return nil, ErrRemoteSignerRequest
+}
+
+func TestRemoteKeystore_unauthorized(t *testing.T) {
+	t.Parallel()
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	server := newTestRemoteSignerServer(t, "secret", kp)
+
+	ks := NewRemoteKeystore(GranName, crypto.Ed25519Type, NewHTTPRemoteSigner(server.URL, "wrong"))
+	err = ks.Refresh(context.Background())
+	assert.ErrorIs(t, err, ErrRemoteSignerRequest)
+	assert.Equal(t, 0, ks.Size())
+}
+
+func TestRemoteKeystore_ecdsa(t *testing.T) {
+	t.Parallel()
+
+	kp, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	ks := NewRemoteKeystore(BeefName, crypto.Secp256k1Type, staticRemoteSigner{kp.Public().Encode()})
+	err = ks.Refresh(context.Background())
+	require.NoError(t, err)
+
+	remoteKp := ks.GetKeypair(kp.Public())
+	require.NotNil(t, remoteKp)
+	assert.Equal(t, crypto.Secp256k1Type, remoteKp.Type())
+
+	ks = NewRemoteKeystore(BeefName, crypto.Secp256k1Type, keypairRemoteSigner{public: kp, signing: kp})
+	err = ks.Refresh(context.Background())
+	require.NoError(t, err)
+
+	msg := common.MustBlake2bHash([]byte("helloworld"))
+	sig, err := ks.GetKeypair(kp.Public()).Sign(msg[:])
+	require.NoError(t, err)
+	assert.Len(t, sig, secp256k1.SignatureLength+1)
+}
+
+func TestRemoteKeystore_invalidSignature(t *testing.T) {
+	t.Parallel()
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	otherKp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	ks := NewRemoteKeystore(GranName, crypto.Ed25519Type, keypairRemoteSigner{public: kp, signing: otherKp})
+	err = ks.Refresh(context.Background())
+	require.NoError(t, err)
+
+	sig, err := ks.GetKeypair(kp.Public()).Sign([]byte("helloworld"))
+	assert.ErrorIs(t, err, ErrRemoteSignerRequest)
+	assert.Nil(t, sig)
+}
+
+// keypairRemoteSigner is a RemoteSigner returning the public key of
+// the public keypair, and signing with the signing keypair.
+type keypairRemoteSigner struct {
+	public  KeyPair
+	signing KeyPair
+}
+
+func (s keypairRemoteSigner) PublicKeys(context.Context, Name) ([][]byte, error) {
+	return [][]byte{s.public.Public().Encode()}, nil
+}
+
+func (s keypairRemoteSigner) Sign(_ context.Context, _ Name, _, msg []byte) ([]byte, error) {
+	return s.signing.Sign(msg)
+}
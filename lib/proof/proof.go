@@ -0,0 +1,102 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package proof verifies the storage proofs returned by the state_getReadProof RPC method
+// against the state root of the block they were generated at, so light client tooling
+// and bridges can read the storage of a chain from a node they do not trust.
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	trieproof "github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+)
+
+var (
+	// ErrBlockMismatch is returned when the header given is not the header
+	// of the block the proof was generated at.
+	ErrBlockMismatch = errors.New("proof block mismatch")
+	// ErrValueMismatch is returned when the value of a key in the proof
+	// does not match the value expected.
+	ErrValueMismatch = errors.New("proof value mismatch")
+)
+
+// ReadProof is a storage proof as returned by the state_getReadProof RPC method.
+type ReadProof struct {
+	// At is the hash of the block the proof was generated at.
+	At common.Hash `json:"at"`
+	// Proof are the hex encoded trie nodes of the proof.
+	Proof []string `json:"proof"`
+}
+
+// EncodedNodes returns the decoded trie nodes of the proof.
+func (p ReadProof) EncodedNodes() (encodedNodes [][]byte, err error) {
+	encodedNodes = make([][]byte, len(p.Proof))
+	for i, hexNode := range p.Proof {
+		encodedNodes[i], err = common.HexToBytes(hexNode)
+		if err != nil {
+			return nil, fmt.Errorf("decoding proof node %d: %w", i, err)
+		}
+	}
+	return encodedNodes, nil
+}
+
+// Values returns the values of the keys given at the block of the header given, which
+// must be the block the proof was generated at. The header must come from a trusted
+// source, such as the headers synced and verified by a light client.
+func (p ReadProof) Values(header *types.Header, keys [][]byte) (values [][]byte, err error) {
+	encodedNodes, err := p.verifiedNodes(header)
+	if err != nil {
+		return nil, err
+	}
+	return Values(encodedNodes, header.StateRoot, keys)
+}
+
+// Verify verifies the key given has the value given at the block of the header given, which
+// must be the block the proof was generated at. A nil value verifies the key has no value,
+// which the proof must prove by covering the full path to the key.
+func (p ReadProof) Verify(header *types.Header, key, value []byte) error {
+	encodedNodes, err := p.verifiedNodes(header)
+	if err != nil {
+		return err
+	}
+
+	values, err := Values(encodedNodes, header.StateRoot, [][]byte{key})
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(values[0], value) {
+		return fmt.Errorf("%w: for key 0x%x: expected 0x%x but got 0x%x",
+			ErrValueMismatch, key, value, values[0])
+	}
+	return nil
+}
+
+func (p ReadProof) verifiedNodes(header *types.Header) (encodedNodes [][]byte, err error) {
+	if hash := header.Hash(); hash != p.At {
+		return nil, fmt.Errorf("%w: proof is at block %s and header is of block %s",
+			ErrBlockMismatch, p.At, hash)
+	}
+	return p.EncodedNodes()
+}
+
+// Values returns the values of the keys given in the trie with the state root given, read from
+// the encoded trie nodes of a storage proof. The value of a key without value is nil, and is only
+// returned if the proof covers the full path to the key: it returns an error wrapping
+// trieproof.ErrIncompleteProof if a node or value on the path to a key is missing from the proof,
+// and an error if the proof does not contain the root node of the trie.
+func Values(encodedNodes [][]byte, stateRoot common.Hash, keys [][]byte) (values [][]byte, err error) {
+	values = make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i], err = trieproof.Read(encodedNodes, stateRoot.ToBytes(), key)
+		if err != nil {
+			return nil, fmt.Errorf("reading key 0x%x: %w", key, err)
+		}
+	}
+	return values, nil
+}
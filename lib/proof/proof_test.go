@@ -0,0 +1,146 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	trieproof "github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestReadProof returns the header of a block with a state trie containing the
+// entries given, and the read proof of the keys given at this block.
+func newTestReadProof(t *testing.T, entries map[string]string, keys [][]byte) (*types.Header, ReadProof) {
+	t.Helper()
+
+	tr := inmemory.NewEmptyTrie()
+	for key, value := range entries {
+		tr.Put([]byte(key), []byte(value))
+	}
+	stateRoot, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	encodedNodes, err := trieproof.Generate(stateRoot.ToBytes(), keys, db)
+	require.NoError(t, err)
+
+	header := &types.Header{Number: 1, StateRoot: stateRoot, Digest: types.NewDigest()}
+	readProof := ReadProof{At: header.Hash()}
+	for _, encodedNode := range encodedNodes {
+		readProof.Proof = append(readProof.Proof, common.BytesToHex(encodedNode))
+	}
+	return header, readProof
+}
+
+func TestReadProof_Values(t *testing.T) {
+	t.Parallel()
+
+	keys := [][]byte{[]byte("key"), []byte("other")}
+	header, readProof := newTestReadProof(t, map[string]string{
+		"key":   "value",
+		"other": "other value",
+	}, keys)
+	forgedHeader, forgedProof := newTestReadProof(t, map[string]string{"key": "forged value"}, keys[:1])
+
+	testCases := map[string]struct {
+		header     *types.Header
+		readProof  ReadProof
+		values     [][]byte
+		errWrapped error
+		errMessage string
+	}{
+		"valid_proof": {
+			header:    header,
+			readProof: readProof,
+			values:    [][]byte{[]byte("value"), []byte("other value")},
+		},
+		"other_block": {
+			header:     forgedHeader,
+			readProof:  readProof,
+			errWrapped: ErrBlockMismatch,
+			errMessage: "proof block mismatch: proof is at block " + readProof.At.String() +
+				" and header is of block " + forgedHeader.Hash().String(),
+		},
+		"proof_of_other_state": {
+			header:     header,
+			readProof:  ReadProof{At: header.Hash(), Proof: forgedProof.Proof},
+			errWrapped: trieproof.ErrRootNodeNotFound,
+		},
+		"unprefixed_hex_node": {
+			header:     header,
+			readProof:  ReadProof{At: header.Hash(), Proof: []string{"01"}},
+			errWrapped: common.ErrNoPrefix,
+			errMessage: "decoding proof node 0: could not byteify non 0x prefixed string: 01",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := testCase.readProof.Values(testCase.header, keys)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.values, values)
+		})
+	}
+}
+
+func TestReadProof_Verify(t *testing.T) {
+	t.Parallel()
+
+	header, readProof := newTestReadProof(t, map[string]string{"key": "value"}, [][]byte{[]byte("key")})
+
+	// the proof is decoded from the JSON response of state_getReadProof
+	encoded, err := json.Marshal(readProof)
+	require.NoError(t, err)
+	var decoded ReadProof
+	err = json.Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+
+	err = decoded.Verify(header, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	err = decoded.Verify(header, []byte("key"), []byte("other value"))
+	assert.ErrorIs(t, err, ErrValueMismatch)
+	assert.EqualError(t, err, "proof value mismatch: for key 0x6b6579: "+
+		"expected 0x6f746865722076616c7565 but got 0x76616c7565")
+}
+
+func TestReadProof_Verify_absence(t *testing.T) {
+	t.Parallel()
+
+	// the values are long enough for their leaves to be referenced by hash
+	// in the branch, and not inlined in it.
+	entries := map[string]string{
+		"key":   "value long enough not to be inlined in its branch",
+		"other": "other value long enough not to be inlined in its branch",
+	}
+	header, readProof := newTestReadProof(t, entries, [][]byte{[]byte("key")})
+
+	err := readProof.Verify(header, []byte("kez"), nil)
+	require.NoError(t, err)
+
+	err = readProof.Verify(header, []byte("other"), nil)
+	assert.ErrorIs(t, err, trieproof.ErrIncompleteProof)
+
+	_, err = readProof.Values(header, [][]byte{[]byte("other")})
+	assert.ErrorIs(t, err, trieproof.ErrIncompleteProof)
+}
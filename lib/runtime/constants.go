@@ -68,4 +68,8 @@
 	TransactionPaymentCallAPIQueryCallInfo = "TransactionPaymentCallApi_query_call_info"
 	// TransactionPaymentCallAPIQueryCallFeeDetails returns call query call fee details
 	TransactionPaymentCallAPIQueryCallFeeDetails = "TransactionPaymentCallApi_query_call_fee_details"
+	// GenesisBuilderCreateDefaultConfig is the runtime API call GenesisBuilder_create_default_config
+	GenesisBuilderCreateDefaultConfig = "GenesisBuilder_create_default_config"
+	// GenesisBuilderBuildConfig is the runtime API call GenesisBuilder_build_config
+	GenesisBuilderBuildConfig = "GenesisBuilder_build_config"
 )
@@ -46,6 +46,8 @@ type Transactional interface {
 type Runtime interface {
 	LoadCode() []byte
 	SetVersion(v trie.TrieLayout)
+	RootWithVersion(v trie.TrieLayout) (common.Hash, error)
+	GetChildRootWithVersion(keyToChild []byte, v trie.TrieLayout) (common.Hash, error)
 }
 
 // Storage runtime interface.
@@ -56,6 +58,12 @@ type Storage interface {
 	Runtime
 }
 
+// StorageTracer is notified of every ext_storage_* host function call
+// with the key and the value read or written, if any
+type StorageTracer interface {
+	TraceStorage(hostFunction string, key, value []byte)
+}
+
 // BasicNetwork interface for functions used by runtime network state function
 type BasicNetwork interface {
 	NetworkState() common.NetworkState
@@ -0,0 +1,52 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// OpaqueNetworkState is the network state of the node as expected by the runtime
+// from ext_offchain_network_state_version_1, for example to send im-online heartbeats.
+// As in Substrate, the peer id and each external address are SCALE encoded bytes
+// the runtime does not decode.
+type OpaqueNetworkState struct {
+	// PeerID is the SCALE encoded bytes of the libp2p peer id.
+	PeerID []byte
+	// ExternalAddresses are the SCALE encoded multiaddresses strings,
+	// without their peer id component.
+	ExternalAddresses [][]byte
+}
+
+// NewOpaqueNetworkState returns the opaque network state for the network state given.
+func NewOpaqueNetworkState(state common.NetworkState) (opaque OpaqueNetworkState, err error) {
+	peerID, err := peer.Decode(state.PeerID)
+	if err != nil {
+		return opaque, fmt.Errorf("decoding peer id: %w", err)
+	}
+
+	opaque.PeerID, err = scale.Marshal([]byte(peerID))
+	if err != nil {
+		return opaque, fmt.Errorf("encoding peer id: %w", err)
+	}
+
+	opaque.ExternalAddresses = make([][]byte, len(state.Multiaddrs))
+	for i, multiaddr := range state.Multiaddrs {
+		transport, _ := peer.SplitAddr(multiaddr)
+		if transport == nil {
+			transport = multiaddr
+		}
+
+		opaque.ExternalAddresses[i], err = scale.Marshal(transport.String())
+		if err != nil {
+			return opaque, fmt.Errorf("encoding multiaddress %s: %w", multiaddr, err)
+		}
+	}
+
+	return opaque, nil
+}
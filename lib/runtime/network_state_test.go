@@ -0,0 +1,66 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewOpaqueNetworkState(t *testing.T) {
+	t.Parallel()
+
+	const peerIDString = "12D3KooWDcCNBqAemRvguPa7rtmsbn2hpgLqAz8KsMMFsF2rdCUP"
+	peerID, err := peer.Decode(peerIDString)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		state      common.NetworkState
+		opaque     OpaqueNetworkState
+		errMessage string
+	}{
+		"invalid_peer_id": {
+			state:      common.NetworkState{PeerID: "invalid"},
+			errMessage: "decoding peer id: failed to parse peer ID: invalid cid: selected encoding not supported",
+		},
+		"peer_id_and_addresses": {
+			state: common.NetworkState{
+				PeerID: peerIDString,
+				Multiaddrs: []ma.Multiaddr{
+					ma.StringCast("/ip4/127.0.0.1/tcp/7001/p2p/" + peerIDString),
+					ma.StringCast("/ip4/10.0.0.1/tcp/7001"),
+				},
+			},
+			opaque: OpaqueNetworkState{
+				PeerID: scale.MustMarshal([]byte(peerID)),
+				ExternalAddresses: [][]byte{
+					scale.MustMarshal("/ip4/127.0.0.1/tcp/7001"),
+					scale.MustMarshal("/ip4/10.0.0.1/tcp/7001"),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opaque, err := NewOpaqueNetworkState(testCase.state)
+
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.opaque, opaque)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storage
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"golang.org/x/exp/maps"
+)
+
+// Change is the change of the value of a top level key of the state trie.
+type Change struct {
+	Key []byte
+	// Value is the new value of the key, or nil if the key was deleted.
+	Value []byte
+}
+
+// RecordChanges starts recording the changes of the top level keys applied to the
+// state trie, returned by Changes. It is only called if the changes are used, since
+// recording the keys deleted by prefix requires listing them.
+func (t *TrieState) RecordChanges() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.recordChanges = true
+}
+
+// Changes returns the changes of the top level keys applied to the state trie, sorted by key,
+// since RecordChanges was called.
+// The changes made in a transaction are only returned once the transaction is committed.
+// The changes of a child trie are returned as the change of its root, stored in the state
+// trie at its child storage key.
+func (t *TrieState) Changes() (changes []Change) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	keys := maps.Keys(t.changes)
+	slices.Sort(keys)
+
+	changes = make([]Change, len(keys))
+	for i, key := range keys {
+		changes[i] = Change{
+			Key:   []byte(key),
+			Value: t.changes[key],
+		}
+	}
+	return changes
+}
+
+// recordChange records the value set for the key given in the state trie,
+// where a nil value records the deletion of the key.
+func (t *TrieState) recordChange(key string, value []byte) {
+	if !t.recordChanges {
+		return
+	}
+	if t.changes == nil {
+		t.changes = make(map[string][]byte)
+	}
+	t.changes[key] = value
+}
+
+// applyDiff applies the storage diff given to the state trie, and records its top level changes
+// and the changes of the roots of the child tries it modifies.
+func (t *TrieState) applyDiff(diff *storageDiff) {
+	if !t.recordChanges {
+		diff.applyToTrie(t.state)
+		return
+	}
+
+	// a key deleted is the key of a child trie if a child trie is stored at this key,
+	// which is only known before the diff is applied.
+	deletedKeys := make([]string, 0, len(diff.deletes))
+	for key := range diff.deletes {
+		child, _ := t.state.GetChild([]byte(key))
+		if child != nil {
+			key = string(childStorageKey([]byte(key)))
+		}
+		deletedKeys = append(deletedKeys, key)
+	}
+
+	diff.applyToTrie(t.state)
+
+	for key, value := range diff.upserts {
+		t.recordChange(key, value)
+	}
+	for _, key := range deletedKeys {
+		t.recordChange(key, nil)
+	}
+	for keyToChild := range diff.childChangeSet {
+		t.recordChildChange([]byte(keyToChild))
+	}
+}
+
+// recordChildChange records the root of the child trie at the key given, stored in the state
+// trie at its child storage key, once the child trie is modified. A nil value records the
+// deletion of the child trie.
+func (t *TrieState) recordChildChange(keyToChild []byte) {
+	if !t.recordChanges {
+		return
+	}
+
+	var root []byte
+	child, err := t.state.GetChild(keyToChild)
+	if err == nil && child != nil {
+		hash, err := child.Hash()
+		if err == nil {
+			root = hash.ToBytes()
+		}
+	}
+	t.recordChange(string(childStorageKey(keyToChild)), root)
+}
+
+// childStorageKey returns the key of the state trie where the root
+// of the child trie at the key given is stored.
+func childStorageKey(keyToChild []byte) []byte {
+	return append(bytes.Clone(inmemory.ChildStorageKeyPrefix), keyToChild...)
+}
+
+// recordDeletedKeys records the deletion of the keys given which are no longer in the
+// state trie, until the number of keys deleted given is recorded.
+func (t *TrieState) recordDeletedKeys(keys [][]byte, deleted uint32) {
+	for _, key := range keys {
+		if deleted == 0 {
+			return
+		}
+		if t.state.Get(key) != nil {
+			continue
+		}
+		t.recordChange(string(key), nil)
+		deleted--
+	}
+}
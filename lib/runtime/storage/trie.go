@@ -13,6 +13,7 @@
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"golang.org/x/exp/maps"
 )
 
@@ -24,6 +25,10 @@ type TrieState struct {
 	mtx          sync.RWMutex
 	state        trie.Trie
 	transactions *list.List
+	// changes are the top level changes applied to the state trie,
+	// with a nil value for a deleted key, recorded if recordChanges is set.
+	changes       map[string][]byte
+	recordChanges bool
 }
 
 // NewTrieState initialises and returns a new TrieState instance
@@ -89,7 +94,8 @@ func (t *TrieState) CommitTransaction() {
 		t.transactions.Back().Prev().Value = t.transactions.Remove(t.transactions.Back())
 	} else {
 		// This is the last transaction so we apply all the changes to our state
-		t.transactions.Remove(t.transactions.Back()).(*storageDiff).applyToTrie(t.state)
+		diff := t.transactions.Remove(t.transactions.Back()).(*storageDiff)
+		t.applyDiff(diff)
 	}
 }
 
@@ -111,9 +117,14 @@ func (t *TrieState) Put(key, value []byte) (err error) {
 	if t.getCurrentTransaction() != nil {
 		t.getCurrentTransaction().upsert(string(key), value)
 		return nil
-	} else {
-		return t.state.Put(key, value)
 	}
+
+	err = t.state.Put(key, value)
+	if err != nil {
+		return err
+	}
+	t.recordChange(string(key), value)
+	return nil
 }
 
 // Get gets a value from the trie
@@ -149,6 +160,38 @@ func (t *TrieState) Root() (common.Hash, error) {
 	return t.state.Hash()
 }
 
+// RootWithVersion returns the root hash of the trie with its values encoded
+// with the state version given.
+func (t *TrieState) RootWithVersion(v trie.TrieLayout) (common.Hash, error) {
+	return rootWithVersion(t.state, v)
+}
+
+// rootWithVersion returns the root hash of the trie given with its values encoded
+// with the state version given. Values are encoded with the version of the trie
+// when they are set, so the root of a trie of another version is computed from
+// its entries, with the roots of its child tries computed the same way.
+func rootWithVersion(t trie.Trie, v trie.TrieLayout) (common.Hash, error) {
+	if t.Version() == v {
+		return t.Hash()
+	}
+
+	entries := t.Entries()
+	for _, key := range t.GetKeysWithPrefix(inmemory.ChildStorageKeyPrefix) {
+		child, err := t.GetChild(key[len(inmemory.ChildStorageKeyPrefix):])
+		if err != nil {
+			return common.EmptyHash, fmt.Errorf("getting child trie: %w", err)
+		}
+
+		childRoot, err := rootWithVersion(child, v)
+		if err != nil {
+			return common.EmptyHash, fmt.Errorf("computing child trie root: %w", err)
+		}
+		entries[string(key)] = childRoot.ToBytes()
+	}
+
+	return v.Root(inmemory.NewEmptyTrie(), trie.NewEntriesFromMap(entries))
+}
+
 // Has returns whether or not a key exists
 func (t *TrieState) Has(key []byte) bool {
 	return t.Get(key) != nil
@@ -164,7 +207,12 @@ func (t *TrieState) Delete(key []byte) (err error) {
 		return nil
 	}
 
-	return t.state.Delete(key)
+	err = t.state.Delete(key)
+	if err != nil {
+		return err
+	}
+	t.recordChange(string(key), nil)
+	return nil
 }
 
 // NextKey returns the next key in the trie in lexicographical order. If it does not exist, it returns nil.
@@ -200,7 +248,16 @@ func (t *TrieState) ClearPrefix(prefix []byte) (err error) {
 		return
 	}
 
-	return t.state.ClearPrefix(prefix)
+	var keys [][]byte
+	if t.recordChanges {
+		keys = t.state.GetKeysWithPrefix(prefix)
+	}
+	err = t.state.ClearPrefix(prefix)
+	if err != nil {
+		return err
+	}
+	t.recordDeletedKeys(keys, uint32(len(keys)))
+	return nil
 }
 
 // ClearPrefixLimit deletes key-value pairs from the trie where the key starts with the given prefix till limit reached
@@ -215,7 +272,16 @@ func (t *TrieState) ClearPrefixLimit(prefix []byte, limit uint32) (
 		return deleted, allDeleted, nil
 	}
 
-	return t.state.ClearPrefixLimit(prefix, limit)
+	var keys [][]byte
+	if t.recordChanges {
+		keys = t.state.GetKeysWithPrefix(prefix)
+	}
+	deleted, allDeleted, err = t.state.ClearPrefixLimit(prefix, limit)
+	if err != nil {
+		return deleted, allDeleted, err
+	}
+	t.recordDeletedKeys(keys, deleted)
+	return deleted, allDeleted, nil
 }
 
 // TrieEntries returns every key-value pair in the trie
@@ -253,7 +319,12 @@ func (t *TrieState) SetChildStorage(keyToChild, key, value []byte) error {
 		return nil
 	}
 
-	return t.state.PutIntoChild(keyToChild, key, value)
+	err := t.state.PutIntoChild(keyToChild, key, value)
+	if err != nil {
+		return err
+	}
+	t.recordChildChange(keyToChild)
+	return nil
 }
 
 func (t *TrieState) GetChildRoot(keyToChild []byte) (common.Hash, error) {
@@ -268,6 +339,20 @@ func (t *TrieState) GetChildRoot(keyToChild []byte) (common.Hash, error) {
 	return child.Hash()
 }
 
+// GetChildRootWithVersion returns the root hash of a child trie with its values
+// encoded with the state version given.
+func (t *TrieState) GetChildRootWithVersion(keyToChild []byte, v trie.TrieLayout) (common.Hash, error) {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	child, err := t.state.GetChild(keyToChild)
+	if err != nil {
+		return common.EmptyHash, err
+	}
+
+	return rootWithVersion(child, v)
+}
+
 // GetChildStorage returns a value from a child trie
 func (t *TrieState) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
 	t.mtx.RLock()
@@ -294,7 +379,12 @@ func (t *TrieState) DeleteChild(keyToChild []byte) (err error) {
 		return nil
 	}
 
-	return t.state.DeleteChild(keyToChild)
+	err = t.state.DeleteChild(keyToChild)
+	if err != nil {
+		return err
+	}
+	t.recordChildChange(keyToChild)
+	return nil
 }
 
 // DeleteChildLimit deletes up to limit of database entries by lexicographic order.
@@ -339,6 +429,7 @@ func (t *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (
 		if err != nil {
 			return 0, false, fmt.Errorf("deleting child trie: %w", err)
 		}
+		t.recordChildChange(key)
 
 		return qtyEntries, true, nil
 	}
@@ -362,6 +453,7 @@ func (t *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (
 			break
 		}
 	}
+	t.recordChildChange(key)
 
 	allDeleted = deleted == qtyEntries
 	return deleted, allDeleted, nil
@@ -379,7 +471,12 @@ func (t *TrieState) ClearChildStorage(keyToChild, key []byte) error {
 		return nil
 	}
 
-	return t.state.ClearFromChild(keyToChild, key)
+	err := t.state.ClearFromChild(keyToChild, key)
+	if err != nil {
+		return err
+	}
+	t.recordChildChange(keyToChild)
+	return nil
 }
 
 // ClearPrefixInChild clears all the keys from the child trie that have the given prefix
@@ -410,6 +507,7 @@ func (t *TrieState) ClearPrefixInChild(keyToChild, prefix []byte) error {
 	if err != nil {
 		return fmt.Errorf("clearing prefix in child trie located at key 0x%x: %w", keyToChild, err)
 	}
+	t.recordChildChange(keyToChild)
 
 	return nil
 }
@@ -434,7 +532,12 @@ func (t *TrieState) ClearPrefixInChildWithLimit(keyToChild, prefix []byte, limit
 		return 0, false, err
 	}
 
-	return child.ClearPrefixLimit(prefix, limit)
+	deleted, allDeleted, err := child.ClearPrefixLimit(prefix, limit)
+	if err != nil {
+		return deleted, allDeleted, err
+	}
+	t.recordChildChange(keyToChild)
+	return deleted, allDeleted, nil
 }
 
 // GetChildNextKey returns the next lexicographical larger key from child storage. If it does not exist, it returns nil.
@@ -4,11 +4,13 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/stretchr/testify/require"
 )
@@ -384,6 +386,34 @@ func TestTrieState_Root(t *testing.T) {
 	require.Equal(t, expected, ts.MustRoot())
 }
 
+func TestTrieState_RootWithVersion(t *testing.T) {
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	keyToChild := []byte("child")
+	largeValue := bytes.Repeat([]byte{1}, 40)
+
+	require.NoError(t, ts.Put([]byte("key"), largeValue))
+	require.NoError(t, ts.SetChildStorage(keyToChild, []byte("key"), largeValue))
+
+	root, err := ts.RootWithVersion(trie.V0)
+	require.NoError(t, err)
+	require.Equal(t, ts.MustRoot(), root)
+
+	v1Trie := inmemory_trie.NewEmptyTrie()
+	v1Trie.SetVersion(trie.V1)
+	require.NoError(t, v1Trie.Put([]byte("key"), largeValue))
+	require.NoError(t, v1Trie.PutIntoChild(keyToChild, []byte("key"), largeValue))
+
+	root, err = ts.RootWithVersion(trie.V1)
+	require.NoError(t, err)
+	require.Equal(t, v1Trie.MustHash(), root)
+
+	v1Child, err := v1Trie.GetChild(keyToChild)
+	require.NoError(t, err)
+	childRoot, err := ts.GetChildRootWithVersion(keyToChild, trie.V1)
+	require.NoError(t, err)
+	require.Equal(t, v1Child.MustHash(), childRoot)
+}
+
 func TestTrieState_ChildRoot(t *testing.T) {
 	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
 
@@ -500,3 +530,91 @@ func TestTrieState_NestedTransactions(t *testing.T) {
 		})
 	}
 }
+
+func TestTrieState_Changes(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, ts.Put([]byte("unrecorded"), []byte("a")))
+	require.NoError(t, ts.ClearPrefix([]byte("unrecorded")))
+	require.Empty(t, ts.Changes())
+
+	ts.RecordChanges()
+	require.NoError(t, ts.Put([]byte("deleted"), []byte("a")))
+	require.NoError(t, ts.Put([]byte("prefix1"), []byte("b")))
+	require.NoError(t, ts.Put([]byte("prefix2"), []byte("c")))
+	require.NoError(t, ts.Put([]byte("updated"), []byte("d")))
+	require.NoError(t, ts.Delete([]byte("deleted")))
+	require.NoError(t, ts.ClearPrefix([]byte("prefix")))
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("updated"), []byte("e")))
+	require.NoError(t, ts.Put([]byte("transaction"), []byte("f")))
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("rolled back"), []byte("g")))
+	ts.RollbackTransaction()
+
+	// the changes of a transaction are only recorded once it is committed
+	require.Equal(t, []byte("d"), ts.Changes()[3].Value)
+	ts.CommitTransaction()
+
+	expected := []Change{
+		{Key: []byte("deleted")},
+		{Key: []byte("prefix1")},
+		{Key: []byte("prefix2")},
+		{Key: []byte("transaction"), Value: []byte("f")},
+		{Key: []byte("updated"), Value: []byte("e")},
+	}
+	require.Equal(t, expected, ts.Changes())
+}
+
+func TestTrieState_Changes_clearPrefixLimit(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	for _, key := range []string{"prefix1", "prefix2", "prefix3"} {
+		require.NoError(t, ts.Put([]byte(key), []byte("a")))
+	}
+	ts.RecordChanges()
+
+	deleted, allDeleted, err := ts.ClearPrefixLimit([]byte("prefix"), 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), deleted)
+	require.False(t, allDeleted)
+
+	// only the keys deleted are recorded
+	changes := ts.Changes()
+	require.Len(t, changes, 2)
+	for _, change := range changes {
+		require.Nil(t, ts.Get(change.Key))
+		require.Nil(t, change.Value)
+	}
+}
+
+func TestTrieState_Changes_childTries(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, ts.SetChildStorage([]byte("deleted"), []byte("key"), []byte("a")))
+	ts.RecordChanges()
+
+	require.NoError(t, ts.SetChildStorage([]byte("child"), []byte("key"), []byte("b")))
+
+	ts.StartTransaction()
+	require.NoError(t, ts.SetChildStorage([]byte("transaction"), []byte("key"), []byte("c")))
+	require.NoError(t, ts.DeleteChild([]byte("deleted")))
+	ts.CommitTransaction()
+
+	childRoot := func(keyToChild string) []byte {
+		root, err := ts.GetChildRoot([]byte(keyToChild))
+		require.NoError(t, err)
+		return root.ToBytes()
+	}
+	expected := []Change{
+		{Key: []byte(":child_storage:default:child"), Value: childRoot("child")},
+		{Key: []byte(":child_storage:default:deleted")},
+		{Key: []byte(":child_storage:default:transaction"), Value: childRoot("transaction")},
+	}
+	require.Equal(t, expected, ts.Changes())
+}
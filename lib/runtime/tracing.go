@@ -0,0 +1,40 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+)
+
+// HostFunctionCall is a host function call made by the runtime.
+type HostFunctionCall struct {
+	Name string
+	// ArgSizes are the sizes in bytes of the arguments, being the span size
+	// for the pointer-size i64 arguments and the value size otherwise.
+	ArgSizes []uint32
+	// ResultSize is the span size of a pointer-size i64 result, or the size
+	// of the result value otherwise, and zero for no result.
+	ResultSize uint32
+	Duration   time.Duration
+	// Aborted is true if the host function panicked or the call was aborted.
+	Aborted bool
+}
+
+func (h HostFunctionCall) String() string {
+	if h.Aborted {
+		return fmt.Sprintf("%s(arg sizes %v) aborted after %s", h.Name, h.ArgSizes, h.Duration)
+	}
+	return fmt.Sprintf("%s(arg sizes %v) returned %d bytes in %s",
+		h.Name, h.ArgSizes, h.ResultSize, h.Duration)
+}
+
+// HostFunctionTracer is implemented by the runtime instances able to trace
+// the host function calls made during their runtime calls.
+type HostFunctionTracer interface {
+	// HostFunctionTracing returns true if the host function calls are traced.
+	HostFunctionTracing() bool
+	// HostFunctionTrace returns the host function calls made during the last runtime call.
+	HostFunctionTrace() []HostFunctionCall
+}
@@ -62,4 +62,5 @@ type Context struct {
 	SigVerifier     *crypto.SignatureVerifier
 	OffchainHTTPSet *offchain.HTTPSet
 	Version         *Version
+	StorageTracer   StorageTracer
 }
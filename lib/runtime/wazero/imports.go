@@ -1259,7 +1259,7 @@ func ext_default_child_storage_root_version_1(
 
 //export ext_default_child_storage_root_version_2
 func ext_default_child_storage_root_version_2(ctx context.Context, m api.Module, childStorageKey uint64,
-	version uint32) (ptrSize uint64) { //skipcq: RVV-B0012
+	version uint32) (ptrSize uint64) {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
 		panic("nil runtime context")
@@ -1267,7 +1267,13 @@ func ext_default_child_storage_root_version_2(ctx context.Context, m api.Module,
 	storage := rtCtx.Storage
 	key := read(m, childStorageKey)
 
-	childRoot, err := storage.GetChildRoot(key)
+	stateVersion, err := trie.ParseVersion(uint8(version))
+	if err != nil {
+		logger.Errorf("failed parsing state version: %s", err)
+		return mustWrite(m, rtCtx.Allocator, emptyByteVectorEncoded)
+	}
+
+	childRoot, err := storage.GetChildRootWithVersion(key, stateVersion)
 	if err != nil {
 		logger.Errorf("failed to encode child root: %s", err)
 		return mustWrite(m, rtCtx.Allocator, emptyByteVectorEncoded)
@@ -1792,19 +1798,27 @@ func ext_offchain_network_state_version_1(ctx context.Context, m api.Module) uin
 		panic("nil runtime context")
 	}
 
-	if rtCtx.Network == nil {
-		return 0
-	}
+	// expected to return Result<OpaqueNetworkState, ()>
+	res := scale.NewResult(runtime.OpaqueNetworkState{}, nil)
 
-	// expected to return Result<OpaqueNetworkState, ()
-
-	nsEnc, err := scale.Marshal(rtCtx.Network.NetworkState())
+	err := res.Set(scale.Err, nil)
 	if err != nil {
-		logger.Errorf("failed at encoding network state: %s", err)
-		return 0
+		panic(err)
 	}
 
-	ret, err := write(m, rtCtx.Allocator, nsEnc)
+	if rtCtx.Network != nil {
+		networkState, err := runtime.NewOpaqueNetworkState(rtCtx.Network.NetworkState())
+		if err != nil {
+			logger.Errorf("failed to get network state: %s", err)
+		} else {
+			err = res.Set(scale.OK, networkState)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	ret, err := write(m, rtCtx.Allocator, scale.MustMarshal(res))
 	if err != nil {
 		panic(err)
 	}
@@ -2020,6 +2034,14 @@ func storageAppend(storage runtime.Storage, key, valueToAppend []byte) (err erro
 	return nil
 }
 
+// traceStorage notifies the storage tracer of the runtime context, if any,
+// of a storage host function call
+func traceStorage(rtCtx *runtime.Context, hostFunction string, key, value []byte) {
+	if rtCtx.StorageTracer != nil {
+		rtCtx.StorageTracer.TraceStorage(hostFunction, key, value)
+	}
+}
+
 func ext_storage_append_version_1(ctx context.Context, m api.Module, keySpan, valueSpan uint64) {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
@@ -2040,6 +2062,7 @@ func ext_storage_append_version_1(ctx context.Context, m api.Module, keySpan, va
 	if err != nil {
 		logger.Errorf("failed appending to storage: %s", err)
 	}
+	traceStorage(rtCtx, "ext_storage_append_version_1", key, cp)
 }
 
 // Always returns `None`. This function exists for compatibility reasons.
@@ -2073,6 +2096,7 @@ func ext_storage_clear_version_1(ctx context.Context, m api.Module, keySpan uint
 	if err != nil {
 		panic(err)
 	}
+	traceStorage(rtCtx, "ext_storage_clear_version_1", key, nil)
 }
 
 func ext_storage_clear_prefix_version_1(ctx context.Context, m api.Module, prefixSpan uint64) {
@@ -2089,6 +2113,7 @@ func ext_storage_clear_prefix_version_1(ctx context.Context, m api.Module, prefi
 	if err != nil {
 		panic(err)
 	}
+	traceStorage(rtCtx, "ext_storage_clear_prefix_version_1", prefix, nil)
 }
 
 // toKillStorageResultEnum encodes the `allRemoved` flag and
@@ -2142,6 +2167,7 @@ func ext_storage_clear_prefix_version_2(ctx context.Context, m api.Module, prefi
 		logger.Errorf("failed to clear prefix limit: %s", err)
 		panic(err)
 	}
+	traceStorage(rtCtx, "ext_storage_clear_prefix_version_2", prefix, nil)
 
 	encBytes, err := toKillStorageResultEnum(all, numRemoved)
 	if err != nil {
@@ -2169,6 +2195,7 @@ func ext_storage_exists_version_1(ctx context.Context, m api.Module, keySpan uin
 	logger.Debugf("key: 0x%x", key)
 
 	value := storage.Get(key)
+	traceStorage(rtCtx, "ext_storage_exists_version_1", key, value)
 	if value != nil {
 		return 1
 	}
@@ -2188,6 +2215,7 @@ func ext_storage_get_version_1(ctx context.Context, m api.Module, keySpan uint64
 
 	value := storage.Get(key)
 	logger.Debugf("value: 0x%x", value)
+	traceStorage(rtCtx, "ext_storage_get_version_1", key, value)
 
 	var encodedOption []byte
 	if value != nil {
@@ -2212,6 +2240,7 @@ func ext_storage_next_key_version_1(ctx context.Context, m api.Module, keySpan u
 	logger.Debugf(
 		"key: 0x%x; next key 0x%x",
 		key, next)
+	traceStorage(rtCtx, "ext_storage_next_key_version_1", key, next)
 
 	var encodedOption []byte
 	if len(next) == 0 {
@@ -2235,6 +2264,7 @@ func ext_storage_read_version_1(ctx context.Context, m api.Module, keySpan, valu
 	logger.Debugf(
 		"key 0x%x has value 0x%x",
 		key, value)
+	traceStorage(rtCtx, "ext_storage_read_version_1", key, value)
 
 	if value == nil {
 		return mustWrite(m, rtCtx.Allocator, noneEncoded)
@@ -2279,6 +2309,7 @@ func ext_storage_root_version_1(ctx context.Context, m api.Module) uint64 {
 	}
 
 	logger.Debugf("root hash is: %s", root)
+	traceStorage(rtCtx, "ext_storage_root_version_1", nil, root[:])
 
 	rootSpan, err := write(m, rtCtx.Allocator, root[:])
 	if err != nil {
@@ -2288,18 +2319,26 @@ func ext_storage_root_version_1(ctx context.Context, m api.Module) uint64 {
 	return rootSpan
 }
 
-func ext_storage_root_version_2(ctx context.Context, m api.Module, _ uint32) uint64 { //skipcq: RVV-B0012
+func ext_storage_root_version_2(ctx context.Context, m api.Module, version uint32) uint64 {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
 		panic("nil runtime context")
 	}
 	storage := rtCtx.Storage
 
-	root, err := storage.Root()
+	stateVersion, err := trie.ParseVersion(uint8(version))
+	if err != nil {
+		logger.Errorf("failed parsing state version: %s", err)
+		panic(err)
+	}
+
+	root, err := storage.RootWithVersion(stateVersion)
 	if err != nil {
 		logger.Errorf("failed to get storage root: %s", err)
 		panic(err)
 	}
+	logger.Debugf("root hash for state version %s is: %s", stateVersion, root)
+	traceStorage(rtCtx, "ext_storage_root_version_2", nil, root[:])
 
 	rootSpan, err := write(m, rtCtx.Allocator, root[:])
 	if err != nil {
@@ -2329,6 +2368,7 @@ func ext_storage_set_version_1(ctx context.Context, m api.Module, keySpan, value
 	if err != nil {
 		panic(err)
 	}
+	traceStorage(rtCtx, "ext_storage_set_version_1", key, cp)
 }
 
 func ext_storage_start_transaction_version_1(ctx context.Context, _ api.Module) {
@@ -2337,6 +2377,7 @@ func ext_storage_start_transaction_version_1(ctx context.Context, _ api.Module)
 		panic("nil runtime context")
 	}
 	rtCtx.Storage.StartTransaction()
+	traceStorage(rtCtx, "ext_storage_start_transaction_version_1", nil, nil)
 }
 
 func ext_storage_rollback_transaction_version_1(ctx context.Context, _ api.Module) {
@@ -2345,6 +2386,7 @@ func ext_storage_rollback_transaction_version_1(ctx context.Context, _ api.Modul
 		panic("nil runtime context")
 	}
 	rtCtx.Storage.RollbackTransaction()
+	traceStorage(rtCtx, "ext_storage_rollback_transaction_version_1", nil, nil)
 }
 
 func ext_storage_commit_transaction_version_1(ctx context.Context, _ api.Module) {
@@ -2353,6 +2395,7 @@ func ext_storage_commit_transaction_version_1(ctx context.Context, _ api.Module)
 		panic("nil runtime context")
 	}
 	rtCtx.Storage.CommitTransaction()
+	traceStorage(rtCtx, "ext_storage_commit_transaction_version_1", nil, nil)
 }
 
 func ext_allocator_free_version_1(ctx context.Context, m api.Module, addr uint32) {
@@ -2026,6 +2026,42 @@ func Test_ext_storage_get_version_1(t *testing.T) {
 	require.Equal(t, testvalue, *value)
 }
 
+type storageTrace struct {
+	hostFunction string
+	key, value   []byte
+}
+
+type storageTraceRecorder struct {
+	traces []storageTrace
+}
+
+func (r *storageTraceRecorder) TraceStorage(hostFunction string, key, value []byte) {
+	r.traces = append(r.traces, storageTrace{hostFunction: hostFunction, key: key, value: value})
+}
+
+func Test_ext_storage_get_version_1_traced(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+	recorder := &storageTraceRecorder{}
+	inst.Context.StorageTracer = recorder
+
+	testkey := []byte("noot")
+	testvalue := []byte{1, 2}
+	inst.Context.Storage.Put(testkey, testvalue)
+
+	enc, err := scale.Marshal(testkey)
+	require.NoError(t, err)
+
+	_, err = inst.Exec("rtm_ext_storage_get_version_1", enc)
+	require.NoError(t, err)
+
+	expected := []storageTrace{{
+		hostFunction: "ext_storage_get_version_1",
+		key:          testkey,
+		value:        testvalue,
+	}}
+	require.Equal(t, expected, recorder.traces)
+}
+
 func Test_ext_storage_exists_version_1(t *testing.T) {
 	testCases := map[string]struct {
 		key    []byte
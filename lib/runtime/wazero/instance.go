@@ -25,6 +25,7 @@
 	"github.com/klauspost/compress/zstd"
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
 )
 
 // Name represents the name of the interpreter
@@ -35,6 +36,7 @@
 const runtimeContextKey = contextKey("runtime.Context")
 
 var _ runtime.Instance = &Instance{}
+var _ runtime.HostFunctionTracer = &Instance{}
 
 // Instance backed by wazero.Runtime
 type Instance struct {
@@ -43,6 +45,8 @@ type Instance struct {
 	Context  *runtime.Context
 	codeHash common.Hash
 	heapBase uint32
+	// tracer traces the host function calls, and is nil if tracing is disabled.
+	tracer *hostFunctionTracer
 	sync.Mutex
 }
 
@@ -57,6 +61,9 @@ type Config struct {
 	Transaction    runtime.TransactionState
 	CodeHash       common.Hash
 	DefaultVersion *runtime.Version
+	StorageTracer  runtime.StorageTracer
+	// HostFunctionTracing traces the host function calls of each runtime call.
+	HostFunctionTracing bool
 }
 
 func decompressWasm(code []byte) ([]byte, error) {
@@ -108,6 +115,14 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 	ctx := context.Background()
 	rt := wazero.NewRuntime(ctx)
 
+	// function listeners are bound to the host functions when the host module is compiled
+	hostModuleCtx := ctx
+	var tracer *hostFunctionTracer
+	if cfg.HostFunctionTracing {
+		tracer = new(hostFunctionTracer)
+		hostModuleCtx = context.WithValue(ctx, experimental.FunctionListenerFactoryKey{}, tracer)
+	}
+
 	_, err = rt.NewHostModuleBuilder("env").
 		// values from newer kusama/polkadot runtimes
 		ExportMemory("memory", 23).
@@ -393,7 +408,7 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 		NewFunctionBuilder().
 		WithFunc(ext_crypto_ecdsa_generate_version_1).
 		Export("ext_crypto_ecdsa_generate_version_1").
-		Instantiate(ctx)
+		Instantiate(hostModuleCtx)
 
 	if err != nil {
 		return nil, err
@@ -433,9 +448,11 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 			Transaction:     cfg.Transaction,
 			SigVerifier:     crypto.NewSignatureVerifier(logger),
 			OffchainHTTPSet: offchain.NewHTTPSet(),
+			StorageTracer:   cfg.StorageTracer,
 		},
 		Module:   mod,
 		codeHash: cfg.CodeHash,
+		tracer:   tracer,
 	}
 
 	if cfg.DefaultVersion == nil {
@@ -456,9 +473,15 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 
 var ErrExportFunctionNotFound = errors.New("export function not found")
 
+// ErrGenesisBuilder is returned when the runtime fails to build the genesis config
+var ErrGenesisBuilder = errors.New("genesis builder failed")
+
 func (i *Instance) Exec(function string, data []byte) (result []byte, err error) {
 	i.Lock()
 	i.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(i.heapBase)
+	if i.tracer != nil {
+		i.tracer.reset()
+	}
 
 	defer func() {
 		i.Context.Allocator = nil
@@ -491,6 +514,11 @@ func (i *Instance) Exec(function string, data []byte) (result []byte, err error)
 	ctx := context.WithValue(context.Background(), runtimeContextKey, i.Context)
 	values, err := runtimeFunc.Call(ctx, api.EncodeU32(inputPtr), api.EncodeU32(dataLength))
 	if err != nil {
+		if i.tracer != nil {
+			for _, call := range i.tracer.trace() {
+				logger.Debugf("host function call of failed runtime call %s: %s", function, call)
+			}
+		}
 		return nil, fmt.Errorf("running runtime function: %w", err)
 	}
 	if len(values) == 0 {
@@ -780,6 +808,50 @@ func (in *Instance) QueryCallFeeDetails(ext []byte) (*types.FeeDetails, error) {
 	return dispatchInfo, nil
 }
 
+// GenesisBuilderCreateDefaultConfig returns the JSON encoded default genesis config of the runtime
+func (in *Instance) GenesisBuilderCreateDefaultConfig() ([]byte, error) {
+	resBytes, err := in.Exec(runtime.GenesisBuilderCreateDefaultConfig, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	var config []byte
+	err = scale.Unmarshal(resBytes, &config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding default genesis config: %w", err)
+	}
+	return config, nil
+}
+
+// GenesisBuilderBuildConfig builds the genesis storage from the given JSON encoded genesis config,
+// writing it to the storage of the instance.
+func (in *Instance) GenesisBuilderBuildConfig(config []byte) error {
+	encConfig, err := scale.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encoding genesis config: %w", err)
+	}
+
+	resBytes, err := in.Exec(runtime.GenesisBuilderBuildConfig, encConfig)
+	if err != nil {
+		return err
+	}
+
+	// the runtime returns a Result<(), RuntimeString>
+	if len(resBytes) == 0 {
+		return fmt.Errorf("%w: empty result", ErrGenesisBuilder)
+	}
+	if resBytes[0] == 0 {
+		return nil
+	}
+
+	var reason string
+	err = scale.Unmarshal(resBytes[1:], &reason)
+	if err != nil {
+		return fmt.Errorf("decoding genesis builder error: %w", err)
+	}
+	return fmt.Errorf("%w: %s", ErrGenesisBuilder, reason)
+}
+
 // CheckInherents checks inherents in the block verification process.
 // TODO: use this in block verification process (#1873)
 func (*Instance) CheckInherents() {}
@@ -857,6 +929,20 @@ func (in *Instance) NodeStorage() runtime.NodeStorage {
 	return in.Context.NodeStorage
 }
 
+// HostFunctionTracing returns true if the host function calls are traced.
+func (in *Instance) HostFunctionTracing() bool {
+	return in.tracer != nil
+}
+
+// HostFunctionTrace returns the host function calls made during the last
+// runtime call, or nil if the host function calls are not traced.
+func (in *Instance) HostFunctionTrace() []runtime.HostFunctionCall {
+	if in.tracer == nil {
+		return nil
+	}
+	return in.tracer.trace()
+}
+
 // NetworkService to get referernce to runtime network service
 func (in *Instance) NetworkService() runtime.BasicNetwork {
 	return in.Context.Network
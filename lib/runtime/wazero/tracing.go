@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+// hostFunctionTracer traces the host function calls made during a runtime call,
+// logging their entry and exit. It is set as the function listener factory of
+// the host module, so only host functions are traced.
+type hostFunctionTracer struct {
+	mutex sync.Mutex
+	calls []runtime.HostFunctionCall
+}
+
+// NewFunctionListener returns a listener for the host function given,
+// and nil for functions defined in wasm.
+func (t *hostFunctionTracer) NewFunctionListener(definition api.FunctionDefinition) experimental.FunctionListener {
+	if definition.GoFunction() == nil {
+		return nil
+	}
+	return &hostFunctionListener{tracer: t}
+}
+
+// reset clears the calls traced, before a new runtime call.
+func (t *hostFunctionTracer) reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.calls = nil
+}
+
+func (t *hostFunctionTracer) record(call runtime.HostFunctionCall) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.calls = append(t.calls, call)
+}
+
+// trace returns a copy of the calls traced since the last reset.
+func (t *hostFunctionTracer) trace() []runtime.HostFunctionCall {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]runtime.HostFunctionCall(nil), t.calls...)
+}
+
+// hostFunctionListener traces the calls of a single host function.
+// Host functions do not call back into the runtime, so a call of
+// the function always exits before the next one enters.
+type hostFunctionListener struct {
+	tracer   *hostFunctionTracer
+	start    time.Time
+	argSizes []uint32
+}
+
+func (l *hostFunctionListener) Before(_ context.Context, _ api.Module, definition api.FunctionDefinition,
+	params []uint64, _ experimental.StackIterator) {
+	l.argSizes = valueSizes(definition.ParamTypes(), params)
+	logger.Tracef("→ entering host function %s with arg sizes %v", definition.Name(), l.argSizes)
+	l.start = time.Now()
+}
+
+func (l *hostFunctionListener) After(_ context.Context, _ api.Module, definition api.FunctionDefinition,
+	results []uint64) {
+	call := runtime.HostFunctionCall{
+		Name:     definition.Name(),
+		ArgSizes: l.argSizes,
+		Duration: time.Since(l.start),
+	}
+	if resultSizes := valueSizes(definition.ResultTypes(), results); len(resultSizes) > 0 {
+		call.ResultSize = resultSizes[0]
+	}
+	logger.Tracef("← exiting %s", call)
+	l.tracer.record(call)
+}
+
+func (l *hostFunctionListener) Abort(_ context.Context, _ api.Module, definition api.FunctionDefinition,
+	err error) {
+	call := runtime.HostFunctionCall{
+		Name:     definition.Name(),
+		ArgSizes: l.argSizes,
+		Duration: time.Since(l.start),
+		Aborted:  true,
+	}
+	logger.Tracef("← %s: %s", call, err)
+	l.tracer.record(call)
+}
+
+// valueSizes returns the sizes of the values given, being the span size for
+// i64 values, which are pointer-size spans for almost all host functions,
+// and the value type size otherwise.
+func valueSizes(types []api.ValueType, values []uint64) (sizes []uint32) {
+	sizes = make([]uint32, len(values))
+	for i, value := range values {
+		switch types[i] {
+		case api.ValueTypeI64:
+			_, size := splitPointerSize(value)
+			sizes[i] = uint32(size)
+		case api.ValueTypeI32, api.ValueTypeF32:
+			sizes[i] = 4
+		default:
+			sizes[i] = 8
+		}
+	}
+	return sizes
+}
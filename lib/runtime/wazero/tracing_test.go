@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+func Test_hostFunctionTracer(t *testing.T) {
+	t.Parallel()
+
+	// wasm module exporting run(i64) i64 calling the imported env.f(i64) i64
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+		0x01, 0x06, 0x01, 0x60, 0x01, 0x7e, 0x01, 0x7e, // type section
+		0x02, 0x09, 0x01, 0x03, 'e', 'n', 'v', 0x01, 'f', 0x00, 0x00, // import section
+		0x03, 0x02, 0x01, 0x00, // function section
+		0x07, 0x07, 0x01, 0x03, 'r', 'u', 'n', 0x00, 0x01, // export section
+		0x0a, 0x08, 0x01, 0x06, 0x00, 0x20, 0x00, 0x10, 0x00, 0x0b, // code section
+	}
+
+	ctx := context.Background()
+	rt := wazero.NewRuntime(ctx)
+	t.Cleanup(func() {
+		err := rt.Close(ctx)
+		require.NoError(t, err)
+	})
+
+	tracer := new(hostFunctionTracer)
+	hostModuleCtx := context.WithValue(ctx, experimental.FunctionListenerFactoryKey{}, tracer)
+	_, err := rt.NewHostModuleBuilder("env").
+		NewFunctionBuilder().
+		WithFunc(func(span uint64) uint64 {
+			if span == 0 {
+				panic("empty span")
+			}
+			return newPointerSize(0, 5)
+		}).
+		Export("f").
+		Instantiate(hostModuleCtx)
+	require.NoError(t, err)
+
+	mod, err := rt.Instantiate(ctx, code)
+	require.NoError(t, err)
+	run := mod.ExportedFunction("run")
+
+	_, err = run.Call(ctx, newPointerSize(0, 3))
+	require.NoError(t, err)
+
+	trace := tracer.trace()
+	require.Len(t, trace, 1)
+	assert.Equal(t, "f", trace[0].Name)
+	assert.Equal(t, []uint32{3}, trace[0].ArgSizes)
+	assert.Equal(t, uint32(5), trace[0].ResultSize)
+	assert.False(t, trace[0].Aborted)
+
+	tracer.reset()
+	_, err = run.Call(ctx, 0)
+	require.Error(t, err)
+
+	trace = tracer.trace()
+	require.Len(t, trace, 1)
+	assert.Equal(t, runtime.HostFunctionCall{
+		Name:     "f",
+		ArgSizes: []uint32{0},
+		Duration: trace[0].Duration,
+		Aborted:  true,
+	}, trace[0])
+}
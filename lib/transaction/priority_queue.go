@@ -6,6 +6,7 @@
 import (
 	"container/heap"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -31,6 +32,10 @@ type Item struct {
 
 	priority uint64 // The priority of the item in the queue.
 
+	// operational is true for operational and mandatory transactions, which are
+	// popped before the normal transactions regardless of their priority.
+	operational bool
+
 	// The order is an monotonically increasing sequence and is used to differentiate between `Item`
 	// having the same priority value.
 	order uint64
@@ -45,6 +50,9 @@ type Item struct {
 func (pq priorityQueue) Len() int { return len(pq) } //skipcq: GO-W1029
 
 func (pq priorityQueue) Less(i, j int) bool { //skipcq: GO-W1029
+	if pq[i].operational != pq[j].operational {
+		return pq[i].operational
+	}
 	// For Item having same priority value we compare them based on their insertion order(FIFO).
 	if pq[i].priority == pq[j].priority {
 		return pq[i].order < pq[j].order
@@ -132,6 +140,8 @@ func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
 		hash:     hash,
 		order:    spq.currOrder,
 		priority: txn.Validity.Priority,
+
+		operational: txn.DispatchClass() != Normal,
 	}
 	spq.currOrder++
 	heap.Push(&spq.pq, item)
@@ -201,14 +211,18 @@ func (spq *PriorityQueue) Peek() *ValidTransaction {
 	return spq.pq[0].data
 }
 
-// Pending returns all the transactions currently in the queue
+// Pending returns all the transactions currently in the queue, in the order they are popped
 func (spq *PriorityQueue) Pending() []*ValidTransaction {
 	spq.Lock()
 	defer spq.Unlock()
 
+	items := make(priorityQueue, spq.pq.Len())
+	copy(items, spq.pq)
+	sort.Slice(items, items.Less)
+
 	var txns []*ValidTransaction
-	for idx := 0; idx < spq.pq.Len(); idx++ {
-		txns = append(txns, spq.pq[idx].data)
+	for _, item := range items {
+		txns = append(txns, item.data)
 	}
 	return txns
 }
@@ -0,0 +1,659 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// ErrPoolFull is returned when a transaction cannot be inserted in the pool
+	// because it has the lowest priority of a full pool
+	ErrPoolFull = errors.New("transaction pool is full")
+	// ErrSenderLimitReached is returned when the sender of a transaction already
+	// has the maximum number of transactions in the pool
+	ErrSenderLimitReached = errors.New("sender transaction limit reached")
+	// ErrTransactionBanned is returned when trying to insert a temporarily banned transaction
+	ErrTransactionBanned = errors.New("transaction is temporarily banned")
+)
+
+var (
+	transactionFutureGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_state_transaction",
+		Name:      "pool_total",
+		Help:      "total number of transactions in future pool",
+	})
+	transactionBytesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_state_transaction",
+		Name:      "pool_bytes",
+		Help:      "total size in bytes of the transactions in pool",
+	})
+	transactionBannedGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_state_transaction",
+		Name:      "banned_total",
+		Help:      "total number of temporarily banned transactions",
+	})
+	transactionEvictedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_transaction",
+		Name:      "evicted_total",
+		Help:      "total number of transactions evicted from the full pool",
+	})
+	transactionExpiredCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_transaction",
+		Name:      "expired_total",
+		Help:      "total number of transactions dropped from pool after their longevity",
+	})
+)
+
+// PoolLimits are the limits of a TaggedPool, zero values disable a limit
+type PoolLimits struct {
+	// MaxCount is the maximum number of transactions in the pool
+	MaxCount int
+	// MaxBytes is the maximum total size of the transactions in the pool
+	MaxBytes int
+	// MaxPerSender is the maximum number of signed transactions of a sender in the pool
+	MaxPerSender int
+	// BanDuration is how long banned transactions are rejected by the pool
+	BanDuration time.Duration
+}
+
+// InsertResult is the result of the insertion of a transaction in a TaggedPool
+type InsertResult struct {
+	// Status is the status of the inserted transaction, Ready or Future
+	Status Status
+	// Promoted are the future transactions made ready by the inserted transaction
+	Promoted []*ValidTransaction
+	// Evicted are the transactions dropped to keep the pool within its limits
+	Evicted []*ValidTransaction
+}
+
+// provider is the pool transaction providing a tag
+type provider struct {
+	hash common.Hash
+	// popped is true once the transaction is popped to be included in a block
+	popped bool
+}
+
+// entry holds the pool bookkeeping of a transaction
+type entry struct {
+	tx     *ValidTransaction
+	sender string
+	// order is the insertion order of the transaction in the pool
+	order uint64
+	// validTill is the last block number the transaction is valid at
+	validTill uint
+}
+
+// TaggedPool is a transaction pool ordering transactions by the tags they require
+// and provide, as returned by the TaggedTransactionQueue runtime API. The tags
+// required by a transaction are the ones not provided on chain, eg. the previous
+// nonces of its sender.
+// A transaction is ready once each tag it requires is provided by another ready
+// transaction, and is held in the future queue otherwise. Ready transactions are
+// popped by priority, operational and mandatory transactions first, but never
+// before the transactions providing their tags.
+// When the pool is over its limits, the future transactions are evicted first,
+// then the ready transactions, both normal transactions first, by lowest priority
+// and newest first.
+type TaggedPool struct {
+	mu     sync.Mutex
+	limits PoolLimits
+	// ready holds the ready transactions whose required transactions are popped
+	ready *PriorityQueue
+	// blocked holds the ready transactions waiting for a required transaction to be popped
+	blocked   map[common.Hash]*ValidTransaction
+	future    map[common.Hash]*ValidTransaction
+	providers map[string]*provider
+
+	entries    map[common.Hash]*entry
+	senders    map[string]int
+	bytes      int
+	nextOrder  uint64
+	bestNumber uint
+	// banned maps banned transaction hashes to the end of their ban
+	banned map[common.Hash]time.Time
+
+	pollInterval time.Duration
+}
+
+// NewTaggedPool returns a new empty TaggedPool with the given limits
+func NewTaggedPool(limits PoolLimits) *TaggedPool {
+	return &TaggedPool{
+		limits:       limits,
+		ready:        NewPriorityQueue(),
+		blocked:      make(map[common.Hash]*ValidTransaction),
+		future:       make(map[common.Hash]*ValidTransaction),
+		providers:    make(map[string]*provider),
+		entries:      make(map[common.Hash]*entry),
+		senders:      make(map[string]int),
+		banned:       make(map[common.Hash]time.Time),
+		pollInterval: 10 * time.Millisecond,
+	}
+}
+
+// Insert inserts a transaction in the ready or the future queue, depending on its
+// required tags. It returns the status of the transaction, the future transactions
+// which became ready since their required tags are provided by the transaction and
+// the transactions evicted to keep the pool within its limits.
+// ErrPoolFull is returned, with the transactions evicted before it, if the inserted
+// transaction is itself evicted, in which case the transactions it promoted are moved
+// back to the future queue.
+func (tp *TaggedPool) Insert(tx *ValidTransaction) (result InsertResult, err error) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	hash := tx.Extrinsic.Hash()
+	if tp.exists(hash) {
+		return result, ErrTransactionExists
+	}
+
+	if tp.isBanned(hash) {
+		return result, ErrTransactionBanned
+	}
+
+	sender := signer(tx.Extrinsic)
+	if tp.limits.MaxPerSender > 0 && sender != "" && tp.senders[sender] >= tp.limits.MaxPerSender {
+		return result, ErrSenderLimitReached
+	}
+
+	tp.entries[hash] = &entry{
+		tx:        tx,
+		sender:    sender,
+		order:     tp.nextOrder,
+		validTill: validTill(tp.bestNumber, tx.Validity.Longevity),
+	}
+	tp.nextOrder++
+	if sender != "" {
+		tp.senders[sender]++
+	}
+	tp.bytes += len(tx.Extrinsic)
+
+	result.Status = tp.insert(hash, tx)
+	if result.Status == Ready {
+		result.Promoted = tp.promote()
+	}
+
+	for tp.overLimits() {
+		victim := tp.worst()
+		tp.remove(victim.Extrinsic.Hash())
+		transactionEvictedCounter.Inc()
+
+		if victim == tx {
+			tp.demote(result.Promoted)
+			result.Promoted = nil
+			tp.updateGauges()
+			return result, ErrPoolFull
+		}
+		result.Evicted = append(result.Evicted, victim)
+	}
+
+	tp.updateGauges()
+	return result, nil
+}
+
+// validTill returns the last block number a transaction with the given longevity
+// inserted at the given block number is valid at. A zero longevity never expires.
+func validTill(blockNumber uint, longevity uint64) uint {
+	if longevity == 0 || longevity > uint64(math.MaxUint-blockNumber) {
+		return math.MaxUint
+	}
+	return blockNumber + uint(longevity)
+}
+
+// signer returns the encoded address of the signer of the extrinsic, or an empty
+// string if the extrinsic is not signed or cannot be decoded
+func signer(ext types.Extrinsic) string {
+	decoded := &ctypes.Extrinsic{}
+	decoder := cscale.NewDecoder(bytes.NewReader(ext))
+	err := decoder.Decode(decoded)
+	if err != nil || !decoded.IsSigned() {
+		return ""
+	}
+
+	var encoded bytes.Buffer
+	err = decoded.Signature.Signer.Encode(*cscale.NewEncoder(&encoded))
+	if err != nil {
+		return ""
+	}
+	return encoded.String()
+}
+
+func (tp *TaggedPool) insert(hash common.Hash, tx *ValidTransaction) Status {
+	if !tp.requirementsProvided(tx, false) {
+		tp.future[hash] = tx
+		return Future
+	}
+
+	for _, tag := range tx.Validity.Provides {
+		tp.providers[string(tag)] = &provider{hash: hash}
+	}
+
+	if tp.requirementsProvided(tx, true) {
+		// the error is only returned for transactions already in the queue
+		_, _ = tp.ready.Push(tx)
+	} else {
+		tp.blocked[hash] = tx
+	}
+	return Ready
+}
+
+// promote moves the future transactions whose required tags are provided to the
+// ready queue, until no more future transaction can be promoted
+func (tp *TaggedPool) promote() (promoted []*ValidTransaction) {
+	for {
+		promotedBefore := len(promoted)
+		for hash, tx := range tp.future {
+			if !tp.requirementsProvided(tx, false) {
+				continue
+			}
+
+			delete(tp.future, hash)
+			tp.insert(hash, tx)
+			promoted = append(promoted, tx)
+		}
+
+		if len(promoted) == promotedBefore {
+			return promoted
+		}
+	}
+}
+
+// demote moves the promoted transactions still in the pool whose required tags are
+// no longer provided back to the future queue, until no more transaction can be demoted
+func (tp *TaggedPool) demote(promoted []*ValidTransaction) {
+	for demoted := true; demoted; {
+		demoted = false
+		for _, tx := range promoted {
+			hash := tx.Extrinsic.Hash()
+			_, inPool := tp.entries[hash]
+			_, isFuture := tp.future[hash]
+			if !inPool || isFuture || tp.requirementsProvided(tx, false) {
+				continue
+			}
+
+			delete(tp.blocked, hash)
+			tp.ready.RemoveExtrinsic(tx.Extrinsic)
+			for _, tag := range tx.Validity.Provides {
+				provider, ok := tp.providers[string(tag)]
+				if ok && provider.hash == hash {
+					delete(tp.providers, string(tag))
+				}
+			}
+			tp.future[hash] = tx
+			demoted = true
+		}
+	}
+}
+
+// requirementsProvided returns true if each tag required by the transaction is
+// provided by a transaction of the pool, which is popped if popped is true.
+func (tp *TaggedPool) requirementsProvided(tx *ValidTransaction, popped bool) bool {
+	for _, tag := range tx.Validity.Requires {
+		provider, ok := tp.providers[string(tag)]
+		if !ok || (popped && !provider.popped) {
+			return false
+		}
+	}
+	return true
+}
+
+func (tp *TaggedPool) overLimits() bool {
+	count := len(tp.entries)
+	return count > 0 &&
+		((tp.limits.MaxCount > 0 && count > tp.limits.MaxCount) ||
+			(tp.limits.MaxBytes > 0 && tp.bytes > tp.limits.MaxBytes))
+}
+
+// worst returns the transaction to evict first from the pool: the future
+// transaction, or else the ready transaction, of the normal class, or else of the
+// operational or mandatory class, with the lowest priority and inserted last.
+func (tp *TaggedPool) worst() (worst *ValidTransaction) {
+	var worstEntry *entry
+	for _, e := range tp.entries {
+		_, isFuture := tp.future[e.tx.Extrinsic.Hash()]
+		if worstEntry != nil {
+			_, worstIsFuture := tp.future[worstEntry.tx.Extrinsic.Hash()]
+			switch {
+			case isFuture != worstIsFuture:
+				if !isFuture {
+					continue
+				}
+			case (e.tx.DispatchClass() == Normal) != (worstEntry.tx.DispatchClass() == Normal):
+				if e.tx.DispatchClass() != Normal {
+					continue
+				}
+			case e.tx.Validity.Priority != worstEntry.tx.Validity.Priority:
+				if e.tx.Validity.Priority > worstEntry.tx.Validity.Priority {
+					continue
+				}
+			case e.order < worstEntry.order:
+				continue
+			}
+		}
+		worstEntry = e
+	}
+	return worstEntry.tx
+}
+
+// Pop removes and returns the ready transaction with the highest priority, or nil
+// if there is no ready transaction
+func (tp *TaggedPool) Pop() *ValidTransaction {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	tx := tp.ready.Pop()
+	if tx == nil {
+		return nil
+	}
+
+	hash := tx.Extrinsic.Hash()
+	tp.forget(hash)
+	for _, tag := range tx.Validity.Provides {
+		provider, ok := tp.providers[string(tag)]
+		if ok && provider.hash == hash {
+			provider.popped = true
+		}
+	}
+
+	for blockedHash, blockedTx := range tp.blocked {
+		if tp.requirementsProvided(blockedTx, true) {
+			delete(tp.blocked, blockedHash)
+			_, _ = tp.ready.Push(blockedTx)
+		}
+	}
+
+	tp.updateGauges()
+	return tx
+}
+
+// PopWithTimer returns the next ready transaction, waiting for one until the timer expires.
+// When the timer expires, it returns `nil`.
+func (tp *TaggedPool) PopWithTimer(timerCh <-chan time.Time) *ValidTransaction {
+	tx := tp.Pop()
+	if tx != nil {
+		return tx
+	}
+
+	pollTicker := time.NewTicker(tp.pollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-timerCh:
+			return nil
+		case <-pollTicker.C:
+		}
+
+		tx := tp.Pop()
+		if tx != nil {
+			return tx
+		}
+	}
+}
+
+// Peek returns the next ready transaction without removing it from the pool
+func (tp *TaggedPool) Peek() *ValidTransaction {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return tp.ready.Peek()
+}
+
+// Remove removes the transaction with the given extrinsic hash from the pool
+func (tp *TaggedPool) Remove(hash common.Hash) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	tp.remove(hash)
+	tp.updateGauges()
+}
+
+// RemoveWithDependents removes the transactions with the given extrinsic hashes from
+// the pool, with the transactions requiring a tag they provide, recursively. It returns
+// the removed transactions.
+func (tp *TaggedPool) RemoveWithDependents(hashes ...common.Hash) (removed []*ValidTransaction) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	for len(hashes) > 0 {
+		hash := hashes[0]
+		hashes = hashes[1:]
+
+		e, ok := tp.entries[hash]
+		if !ok {
+			continue
+		}
+
+		tp.remove(hash)
+		removed = append(removed, e.tx)
+		for dependentHash, dependent := range tp.entries {
+			if requiresAny(dependent.tx, e.tx.Validity.Provides) {
+				hashes = append(hashes, dependentHash)
+			}
+		}
+	}
+
+	tp.updateGauges()
+	return removed
+}
+
+// requiresAny returns true if the transaction requires any of the given tags
+func requiresAny(tx *ValidTransaction, tags [][]byte) bool {
+	for _, required := range tx.Validity.Requires {
+		for _, tag := range tags {
+			if bytes.Equal(required, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (tp *TaggedPool) remove(hash common.Hash) {
+	e, ok := tp.entries[hash]
+	if !ok {
+		return
+	}
+
+	tp.forget(hash)
+	delete(tp.future, hash)
+	delete(tp.blocked, hash)
+	tp.ready.RemoveExtrinsic(e.tx.Extrinsic)
+
+	for _, tag := range e.tx.Validity.Provides {
+		provider, ok := tp.providers[string(tag)]
+		if ok && provider.hash == hash {
+			delete(tp.providers, string(tag))
+		}
+	}
+}
+
+// forget removes the bookkeeping entry of a transaction leaving the pool
+func (tp *TaggedPool) forget(hash common.Hash) {
+	e, ok := tp.entries[hash]
+	if !ok {
+		return
+	}
+
+	delete(tp.entries, hash)
+	tp.bytes -= len(e.tx.Extrinsic)
+	if e.sender == "" {
+		return
+	}
+
+	tp.senders[e.sender]--
+	if tp.senders[e.sender] == 0 {
+		delete(tp.senders, e.sender)
+	}
+}
+
+// PruneExpired sets the best block number and removes and returns the transactions
+// whose longevity ended before it
+func (tp *TaggedPool) PruneExpired(bestNumber uint) (expired []*ValidTransaction) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	tp.bestNumber = bestNumber
+	for hash, e := range tp.entries {
+		if e.validTill >= bestNumber {
+			continue
+		}
+
+		expired = append(expired, e.tx)
+		tp.remove(hash)
+		transactionExpiredCounter.Inc()
+	}
+
+	tp.updateGauges()
+	return expired
+}
+
+// Ban bans the transactions with the given extrinsic hashes from the pool for the
+// ban duration of the pool limits
+func (tp *TaggedPool) Ban(hashes ...common.Hash) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if tp.limits.BanDuration <= 0 {
+		return
+	}
+
+	now := time.Now()
+	for hash, end := range tp.banned {
+		if !now.Before(end) {
+			delete(tp.banned, hash)
+		}
+	}
+
+	for _, hash := range hashes {
+		tp.banned[hash] = now.Add(tp.limits.BanDuration)
+	}
+	transactionBannedGauge.Set(float64(len(tp.banned)))
+}
+
+// IsBanned returns true if the transaction with the given extrinsic hash is banned
+func (tp *TaggedPool) IsBanned(hash common.Hash) bool {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return tp.isBanned(hash)
+}
+
+func (tp *TaggedPool) isBanned(hash common.Hash) bool {
+	end, ok := tp.banned[hash]
+	if !ok {
+		return false
+	}
+
+	if !time.Now().Before(end) {
+		delete(tp.banned, hash)
+		transactionBannedGauge.Set(float64(len(tp.banned)))
+		return false
+	}
+	return true
+}
+
+// Exists returns true if the transaction with the given extrinsic hash is in the pool
+func (tp *TaggedPool) Exists(hash common.Hash) bool {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return tp.exists(hash)
+}
+
+func (tp *TaggedPool) exists(hash common.Hash) bool {
+	_, ok := tp.entries[hash]
+	return ok
+}
+
+// Status returns the status of the transaction with the given extrinsic hash, which
+// is either Ready or Future, and false if the transaction is not in the pool
+func (tp *TaggedPool) Status(hash common.Hash) (status Status, ok bool) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if _, ok := tp.future[hash]; ok {
+		return Future, true
+	}
+	return Ready, tp.exists(hash)
+}
+
+// Pending returns the ready transactions followed by the future transactions
+func (tp *TaggedPool) Pending() []*ValidTransaction {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return tp.pending()
+}
+
+func (tp *TaggedPool) pending() []*ValidTransaction {
+	txs := tp.ready.Pending()
+	for _, tx := range tp.blocked {
+		txs = append(txs, tx)
+	}
+	for _, tx := range tp.future {
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// Clear removes and returns every transaction of the pool, with the tags provided
+// by the popped transactions. Banned transactions stay banned.
+func (tp *TaggedPool) Clear() []*ValidTransaction {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	txs := tp.pending()
+	for _, tx := range tp.ready.Pending() {
+		tp.ready.RemoveExtrinsic(tx.Extrinsic)
+	}
+	tp.blocked = make(map[common.Hash]*ValidTransaction)
+	tp.future = make(map[common.Hash]*ValidTransaction)
+	tp.providers = make(map[string]*provider)
+	tp.entries = make(map[common.Hash]*entry)
+	tp.senders = make(map[string]int)
+	tp.bytes = 0
+	tp.updateGauges()
+	return txs
+}
+
+func (tp *TaggedPool) updateGauges() {
+	transactionFutureGauge.Set(float64(len(tp.future)))
+	transactionBytesGauge.Set(float64(tp.bytes))
+}
+
+// Len returns the number of transactions in the pool
+func (tp *TaggedPool) Len() int {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return len(tp.entries)
+}
+
+// ReadyLen returns the number of ready transactions in the pool
+func (tp *TaggedPool) ReadyLen() int {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return tp.ready.Len() + len(tp.blocked)
+}
+
+// FutureLen returns the number of future transactions in the pool
+func (tp *TaggedPool) FutureLen() int {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	return len(tp.future)
+}
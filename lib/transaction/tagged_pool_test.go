@@ -0,0 +1,414 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaggedTransaction(ext byte, priority uint64, requires, provides [][]byte) *ValidTransaction {
+	return NewValidTransaction([]byte{ext}, NewValidity(priority, requires, provides, 0, false))
+}
+
+func newOperationalTransaction(ext byte, priority uint64) *ValidTransaction {
+	tx := newTaggedTransaction(ext, priority, nil, nil)
+	tx.Class = Operational
+	return tx
+}
+
+// newSignedExtrinsic returns a scale encoded signed extrinsic of the given signer
+func newSignedExtrinsic(t *testing.T, signer byte, nonce uint64) types.Extrinsic {
+	t.Helper()
+
+	address, err := ctypes.NewMultiAddressFromAccountID(bytes.Repeat([]byte{signer}, 32))
+	require.NoError(t, err)
+
+	extrinsic := ctypes.Extrinsic{
+		Version: ctypes.ExtrinsicBitSigned | ctypes.ExtrinsicVersion4,
+		Signature: ctypes.ExtrinsicSignatureV4{
+			Signer:    address,
+			Signature: ctypes.MultiSignature{IsSr25519: true},
+			Era:       ctypes.ExtrinsicEra{IsImmortalEra: true},
+			Nonce:     ctypes.NewUCompactFromUInt(nonce),
+			Tip:       ctypes.NewUCompactFromUInt(0),
+		},
+		Method: ctypes.Call{CallIndex: ctypes.CallIndex{SectionIndex: 4}},
+	}
+
+	var encoded bytes.Buffer
+	err = cscale.NewEncoder(&encoded).Encode(extrinsic)
+	require.NoError(t, err)
+	return encoded.Bytes()
+}
+
+func TestTaggedPool_Insert(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+
+	first := newTaggedTransaction(1, 0, nil, [][]byte{{1}})
+	second := newTaggedTransaction(2, 0, [][]byte{{1}}, [][]byte{{2}})
+	third := newTaggedTransaction(3, 0, [][]byte{{2}}, [][]byte{{3}})
+
+	result, err := pool.Insert(third)
+	require.NoError(t, err)
+	assert.Equal(t, Future, result.Status)
+	assert.Empty(t, result.Promoted)
+
+	result, err = pool.Insert(second)
+	require.NoError(t, err)
+	assert.Equal(t, Future, result.Status)
+	assert.Empty(t, result.Promoted)
+
+	result, err = pool.Insert(first)
+	require.NoError(t, err)
+	assert.Equal(t, Ready, result.Status)
+	assert.ElementsMatch(t, []*ValidTransaction{second, third}, result.Promoted)
+
+	_, err = pool.Insert(first)
+	assert.ErrorIs(t, err, ErrTransactionExists)
+
+	assert.Equal(t, 3, pool.ReadyLen())
+	assert.Equal(t, 0, pool.FutureLen())
+}
+
+func TestTaggedPool_Pop(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+
+	// the dependent transaction has a higher priority but is popped last
+	low := newTaggedTransaction(1, 1, nil, [][]byte{{1}})
+	dependent := newTaggedTransaction(2, 10, [][]byte{{1}}, nil)
+	other := newTaggedTransaction(3, 5, nil, nil)
+
+	for _, tx := range []*ValidTransaction{dependent, low, other} {
+		_, err := pool.Insert(tx)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, other, pool.Peek())
+	assert.Equal(t, other, pool.Pop())
+	assert.Equal(t, low, pool.Pop())
+	assert.Equal(t, dependent, pool.Pop())
+	assert.Nil(t, pool.Pop())
+	assert.Equal(t, 0, pool.Len())
+
+	// requirements provided by popped transactions are satisfied
+	next := newTaggedTransaction(4, 0, [][]byte{{1}}, nil)
+	result, err := pool.Insert(next)
+	require.NoError(t, err)
+	assert.Equal(t, Ready, result.Status)
+	assert.Equal(t, next, pool.Pop())
+}
+
+func TestTaggedPool_Pop_operationalFirst(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+
+	normal := newTaggedTransaction(1, 100, nil, nil)
+	operational := newOperationalTransaction(2, 1)
+	mandatory := newTaggedTransaction(3, 0, nil, nil)
+	mandatory.Class = Mandatory
+
+	for _, tx := range []*ValidTransaction{normal, operational, mandatory} {
+		_, err := pool.Insert(tx)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, operational, pool.Pop())
+	assert.Equal(t, mandatory, pool.Pop())
+	assert.Equal(t, normal, pool.Pop())
+}
+
+func TestTaggedPool_Remove(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+
+	provider := newTaggedTransaction(1, 0, nil, [][]byte{{1}})
+	dependent := newTaggedTransaction(2, 0, [][]byte{{1}}, nil)
+	_, err := pool.Insert(provider)
+	require.NoError(t, err)
+	_, err = pool.Insert(dependent)
+	require.NoError(t, err)
+
+	pool.Remove(provider.Extrinsic.Hash())
+	assert.False(t, pool.Exists(provider.Extrinsic.Hash()))
+	assert.True(t, pool.Exists(dependent.Extrinsic.Hash()))
+
+	status, ok := pool.Status(dependent.Extrinsic.Hash())
+	assert.True(t, ok)
+	assert.Equal(t, Ready, status)
+
+	_, ok = pool.Status(provider.Extrinsic.Hash())
+	assert.False(t, ok)
+}
+
+func TestTaggedPool_RemoveWithDependents(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+
+	first := newTaggedTransaction(1, 0, nil, [][]byte{{1}})
+	second := newTaggedTransaction(2, 0, [][]byte{{1}}, [][]byte{{2}})
+	third := newTaggedTransaction(3, 0, [][]byte{{2}}, nil)
+	other := newTaggedTransaction(4, 0, nil, nil)
+
+	for _, tx := range []*ValidTransaction{first, second, third, other} {
+		_, err := pool.Insert(tx)
+		require.NoError(t, err)
+	}
+
+	removed := pool.RemoveWithDependents(second.Extrinsic.Hash(), common.Hash{1})
+	assert.Equal(t, []*ValidTransaction{second, third}, removed)
+	assert.ElementsMatch(t, []*ValidTransaction{first, other}, pool.Pending())
+}
+
+func TestTaggedPool_Clear(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+
+	txs := []*ValidTransaction{
+		newTaggedTransaction(1, 0, nil, [][]byte{{1}}),
+		newTaggedTransaction(2, 0, [][]byte{{1}}, nil),
+		newTaggedTransaction(3, 0, [][]byte{{3}}, nil),
+	}
+	for _, tx := range txs {
+		_, err := pool.Insert(tx)
+		require.NoError(t, err)
+	}
+
+	assert.ElementsMatch(t, txs, pool.Pending())
+	assert.ElementsMatch(t, txs, pool.Clear())
+	assert.Empty(t, pool.Pending())
+	assert.Nil(t, pool.Peek())
+
+	result, err := pool.Insert(txs[1])
+	require.NoError(t, err)
+	assert.Equal(t, Future, result.Status)
+}
+
+func TestTaggedPool_PopWithTimer(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+	pool.pollInterval = time.Millisecond
+
+	timer := time.NewTimer(50 * time.Millisecond)
+	defer timer.Stop()
+	assert.Nil(t, pool.PopWithTimer(timer.C))
+
+	tx := newTaggedTransaction(1, 0, nil, nil)
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		_, _ = pool.Insert(tx)
+	}()
+
+	timer = time.NewTimer(time.Second)
+	defer timer.Stop()
+	assert.Equal(t, tx, pool.PopWithTimer(timer.C))
+}
+
+func TestTaggedPool_Limits(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		limits          PoolLimits
+		inserted        []*ValidTransaction
+		tx              *ValidTransaction
+		expectedEvicted []*ValidTransaction
+		errSentinel     error
+		expectedLen     int
+	}{
+		"evicts_future_first": {
+			limits: PoolLimits{MaxCount: 2},
+			inserted: []*ValidTransaction{
+				newTaggedTransaction(1, 0, nil, nil),
+				newTaggedTransaction(2, 10, [][]byte{{1}}, nil),
+			},
+			tx:              newTaggedTransaction(3, 1, nil, nil),
+			expectedEvicted: []*ValidTransaction{newTaggedTransaction(2, 10, [][]byte{{1}}, nil)},
+			expectedLen:     2,
+		},
+		"evicts_lowest_priority": {
+			limits: PoolLimits{MaxCount: 2},
+			inserted: []*ValidTransaction{
+				newTaggedTransaction(1, 5, nil, nil),
+				newTaggedTransaction(2, 1, nil, nil),
+			},
+			tx:              newTaggedTransaction(3, 3, nil, nil),
+			expectedEvicted: []*ValidTransaction{newTaggedTransaction(2, 1, nil, nil)},
+			expectedLen:     2,
+		},
+		"evicts_normal_before_operational": {
+			limits: PoolLimits{MaxCount: 2},
+			inserted: []*ValidTransaction{
+				newOperationalTransaction(1, 1),
+				newTaggedTransaction(2, 5, nil, nil),
+			},
+			tx:              newOperationalTransaction(3, 0),
+			expectedEvicted: []*ValidTransaction{newTaggedTransaction(2, 5, nil, nil)},
+			expectedLen:     2,
+		},
+		"rejects_lowest_priority": {
+			limits: PoolLimits{MaxCount: 2},
+			inserted: []*ValidTransaction{
+				newTaggedTransaction(1, 5, nil, nil),
+				newTaggedTransaction(2, 1, nil, nil),
+			},
+			tx:          newTaggedTransaction(3, 1, nil, nil),
+			errSentinel: ErrPoolFull,
+			expectedLen: 2,
+		},
+		"bytes_limit": {
+			limits: PoolLimits{MaxBytes: 2},
+			inserted: []*ValidTransaction{
+				newTaggedTransaction(1, 1, nil, nil),
+				newTaggedTransaction(2, 2, nil, nil),
+			},
+			tx:              newTaggedTransaction(3, 3, nil, nil),
+			expectedEvicted: []*ValidTransaction{newTaggedTransaction(1, 1, nil, nil)},
+			expectedLen:     2,
+		},
+		"sender_limit": {
+			limits: PoolLimits{MaxPerSender: 1},
+			inserted: []*ValidTransaction{
+				NewValidTransaction(newSignedExtrinsic(t, 1, 0), &Validity{}),
+				NewValidTransaction(newSignedExtrinsic(t, 2, 0), &Validity{}),
+			},
+			tx:          NewValidTransaction(newSignedExtrinsic(t, 1, 1), &Validity{}),
+			errSentinel: ErrSenderLimitReached,
+			expectedLen: 2,
+		},
+		"unsigned_transactions_have_no_sender_limit": {
+			limits: PoolLimits{MaxPerSender: 1},
+			inserted: []*ValidTransaction{
+				newTaggedTransaction(1, 0, nil, nil),
+			},
+			tx:          newTaggedTransaction(2, 0, nil, nil),
+			expectedLen: 2,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pool := NewTaggedPool(testCase.limits)
+			for _, tx := range testCase.inserted {
+				_, err := pool.Insert(tx)
+				require.NoError(t, err)
+			}
+
+			result, err := pool.Insert(testCase.tx)
+			assert.ErrorIs(t, err, testCase.errSentinel)
+			assert.Equal(t, testCase.expectedEvicted, result.Evicted)
+			assert.Equal(t, testCase.expectedLen, pool.Len())
+		})
+	}
+}
+
+func TestTaggedPool_Insert_evictedRollsBackPromotions(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{MaxCount: 2})
+	promoted := newTaggedTransaction(1, 5, [][]byte{{1}}, [][]byte{{2}})
+	dependent := newTaggedTransaction(2, 5, [][]byte{{2}}, nil)
+	for _, tx := range []*ValidTransaction{promoted, dependent} {
+		_, err := pool.Insert(tx)
+		require.NoError(t, err)
+	}
+
+	// the transaction providing the tag required by the future transactions
+	// has the lowest priority, so it is evicted once they are promoted
+	result, err := pool.Insert(newTaggedTransaction(3, 0, nil, [][]byte{{1}}))
+	assert.ErrorIs(t, err, ErrPoolFull)
+	assert.Empty(t, result.Promoted)
+	assert.Equal(t, 2, pool.FutureLen())
+	assert.Equal(t, 0, pool.ReadyLen())
+
+	for _, tx := range []*ValidTransaction{promoted, dependent} {
+		status, ok := pool.Status(tx.Extrinsic.Hash())
+		assert.True(t, ok)
+		assert.Equal(t, Future, status)
+	}
+}
+
+func TestTaggedPool_Insert_classQueriedLazily(t *testing.T) {
+	t.Parallel()
+
+	queries := 0
+	future := newTaggedTransaction(1, 0, [][]byte{{1}}, nil)
+	future.SetClassQuery(func() DispatchClass {
+		queries++
+		return Operational
+	})
+
+	pool := NewTaggedPool(PoolLimits{})
+	_, err := pool.Insert(future)
+	require.NoError(t, err)
+	assert.Equal(t, 0, queries)
+
+	_, err = pool.Insert(newTaggedTransaction(2, 0, nil, [][]byte{{1}}))
+	require.NoError(t, err)
+	assert.Equal(t, Operational, future.DispatchClass())
+	assert.Equal(t, Operational, future.DispatchClass())
+	assert.Equal(t, 1, queries)
+}
+
+func TestTaggedPool_PruneExpired(t *testing.T) {
+	t.Parallel()
+
+	pool := NewTaggedPool(PoolLimits{})
+	pool.PruneExpired(10)
+
+	shortLived := NewValidTransaction([]byte{1}, &Validity{Longevity: 2})
+	immortal := NewValidTransaction([]byte{2}, &Validity{})
+	for _, tx := range []*ValidTransaction{shortLived, immortal} {
+		_, err := pool.Insert(tx)
+		require.NoError(t, err)
+	}
+
+	assert.Empty(t, pool.PruneExpired(12))
+	assert.Equal(t, []*ValidTransaction{shortLived}, pool.PruneExpired(13))
+	assert.Equal(t, []*ValidTransaction{immortal}, pool.Pending())
+}
+
+func TestTaggedPool_Ban(t *testing.T) {
+	t.Parallel()
+
+	tx := newTaggedTransaction(1, 0, nil, nil)
+	hash := tx.Extrinsic.Hash()
+
+	pool := NewTaggedPool(PoolLimits{BanDuration: time.Minute})
+	pool.Ban(hash)
+	assert.True(t, pool.IsBanned(hash))
+	_, err := pool.Insert(tx)
+	assert.ErrorIs(t, err, ErrTransactionBanned)
+
+	pool = NewTaggedPool(PoolLimits{BanDuration: time.Nanosecond})
+	pool.Ban(hash)
+	time.Sleep(time.Millisecond)
+	assert.False(t, pool.IsBanned(hash))
+	_, err = pool.Insert(tx)
+	assert.NoError(t, err)
+
+	// bans are disabled without ban duration
+	pool = NewTaggedPool(PoolLimits{})
+	pool.Ban(hash)
+	assert.False(t, pool.IsBanned(hash))
+}
@@ -28,10 +28,43 @@ func NewValidity(priority uint64, requires, provides [][]byte, longevity uint64,
 	}
 }
 
+// DispatchClass is the dispatch class of an extrinsic, see
+// https://github.com/paritytech/polkadot-sdk/blob/master/substrate/frame/support/src/dispatch.rs
+type DispatchClass int
+
+const (
+	// Normal is the class of the regular user extrinsics
+	Normal DispatchClass = iota
+	// Operational is the class of the extrinsics operating the network, eg. council
+	// emergency calls, which may use the block space reserved to them
+	Operational
+	// Mandatory is the class of the extrinsics which are always included in a block,
+	// eg. inherents
+	Mandatory
+)
+
+// String returns the string representation of the dispatch class
+func (c DispatchClass) String() string {
+	switch c {
+	case Normal:
+		return "normal"
+	case Operational:
+		return "operational"
+	case Mandatory:
+		return "mandatory"
+	}
+	return "unknown"
+}
+
 // ValidTransaction struct
 type ValidTransaction struct {
 	Extrinsic types.Extrinsic
 	Validity  *Validity
+	// Class is the dispatch class of the extrinsic, operational and mandatory
+	// transactions are popped from the pool before normal transactions
+	Class DispatchClass
+	// classQuery queries the dispatch class of the extrinsic the first time it is needed
+	classQuery func() DispatchClass
 }
 
 // NewValidTransaction returns ValidTransaction
@@ -42,6 +75,22 @@ func NewValidTransaction(extrinsic types.Extrinsic, validity *Validity) *ValidTr
 	}
 }
 
+// SetClassQuery sets the function querying the dispatch class of the extrinsic. The
+// query is deferred until the class is needed to order the transaction.
+func (vt *ValidTransaction) SetClassQuery(query func() DispatchClass) {
+	vt.classQuery = query
+}
+
+// DispatchClass returns the dispatch class of the extrinsic, querying it first if
+// it is not known yet.
+func (vt *ValidTransaction) DispatchClass() DispatchClass {
+	if vt.classQuery != nil {
+		vt.Class = vt.classQuery()
+		vt.classQuery = nil
+	}
+	return vt.Class
+}
+
 // // StatusNotification represents information about a transaction status update.
 // type StatusNotification struct {
 // 	Ext                types.Extrinsic
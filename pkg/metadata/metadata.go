@@ -0,0 +1,216 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package metadata decodes the runtime metadata returned by Metadata_metadata
+// into typed structures, and computes the storage keys of its storage items.
+package metadata
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// Magic is the "meta" prefix of encoded runtime metadata
+var Magic = [4]byte{'m', 'e', 't', 'a'}
+
+var (
+	ErrInvalidMagic       = errors.New("invalid metadata magic number")
+	ErrUnsupportedVersion = errors.New("unsupported metadata version")
+	ErrPalletNotFound     = errors.New("pallet not found")
+	ErrStorageNotFound    = errors.New("storage item not found")
+	ErrTooManyKeys        = errors.New("too many storage keys")
+	ErrTypeNotFound       = errors.New("type not found")
+)
+
+// Metadata is the runtime metadata V14 or V15. The fields introduced by V15 are left
+// empty for V14 metadata.
+type Metadata struct {
+	Version   uint8
+	Types     []PortableType
+	Pallets   []Pallet
+	Extrinsic Extrinsic
+	// Type is the type id of the runtime
+	Type       uint
+	APIs       []RuntimeAPI
+	OuterEnums OuterEnums
+	Custom     map[string]CustomValue
+}
+
+// Extrinsic describes the format of the extrinsics. Type is only set for V14 metadata,
+// and the address, call, signature and extra types are only set for V15 metadata.
+type Extrinsic struct {
+	Version          uint8
+	Type             uint
+	AddressType      uint
+	CallType         uint
+	SignatureType    uint
+	ExtraType        uint
+	SignedExtensions []SignedExtension
+}
+
+// SignedExtension describes a signed extension of the extrinsics
+type SignedExtension struct {
+	Identifier       string
+	Type             uint
+	AdditionalSigned uint
+}
+
+// RuntimeAPI describes a runtime API and its methods
+type RuntimeAPI struct {
+	Name    string
+	Methods []RuntimeAPIMethod
+	Docs    []string
+}
+
+// RuntimeAPIMethod describes a method of a runtime API
+type RuntimeAPIMethod struct {
+	Name   string
+	Inputs []RuntimeAPIMethodParam
+	Output uint
+	Docs   []string
+}
+
+// RuntimeAPIMethodParam describes a parameter of a runtime API method
+type RuntimeAPIMethodParam struct {
+	Name string
+	Type uint
+}
+
+// OuterEnums holds the type ids of the runtime call, event and error enums
+type OuterEnums struct {
+	CallType  uint
+	EventType uint
+	ErrorType uint
+}
+
+// CustomValue is a custom value of the metadata, encoded as the given type
+type CustomValue struct {
+	Type  uint
+	Value []byte
+}
+
+type metadataV14 struct {
+	Types     []PortableType
+	Pallets   []palletV14
+	Extrinsic extrinsicV14
+	Type      uint
+}
+
+type extrinsicV14 struct {
+	Type             uint
+	Version          uint8
+	SignedExtensions []SignedExtension
+}
+
+type metadataV15 struct {
+	Types      []PortableType
+	Pallets    []Pallet
+	Extrinsic  extrinsicV15
+	Type       uint
+	APIs       []RuntimeAPI
+	OuterEnums OuterEnums
+	Custom     map[string]CustomValue
+}
+
+type extrinsicV15 struct {
+	Version          uint8
+	AddressType      uint
+	CallType         uint
+	SignatureType    uint
+	ExtraType        uint
+	SignedExtensions []SignedExtension
+}
+
+// Decode decodes runtime metadata prefixed with its magic number and version. The
+// Metadata_metadata runtime call returns this encoding wrapped in a byte array.
+func Decode(data []byte) (*Metadata, error) {
+	if len(data) < len(Magic)+1 || !bytes.Equal(data[:len(Magic)], Magic[:]) {
+		return nil, ErrInvalidMagic
+	}
+
+	version := data[len(Magic)]
+	data = data[len(Magic)+1:]
+	switch version {
+	case 14:
+		var v14 metadataV14
+		err := scale.Unmarshal(data, &v14)
+		if err != nil {
+			return nil, fmt.Errorf("decoding metadata v14: %w", err)
+		}
+		return v14.toMetadata(), nil
+	case 15:
+		var v15 metadataV15
+		err := scale.Unmarshal(data, &v15)
+		if err != nil {
+			return nil, fmt.Errorf("decoding metadata v15: %w", err)
+		}
+		return v15.toMetadata(), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+}
+
+func (m metadataV14) toMetadata() *Metadata {
+	pallets := make([]Pallet, len(m.Pallets))
+	for i, pallet := range m.Pallets {
+		pallets[i] = pallet.toPallet()
+	}
+	return &Metadata{
+		Version: 14,
+		Types:   m.Types,
+		Pallets: pallets,
+		Extrinsic: Extrinsic{
+			Version:          m.Extrinsic.Version,
+			Type:             m.Extrinsic.Type,
+			SignedExtensions: m.Extrinsic.SignedExtensions,
+		},
+		Type: m.Type,
+	}
+}
+
+func (m metadataV15) toMetadata() *Metadata {
+	return &Metadata{
+		Version: 15,
+		Types:   m.Types,
+		Pallets: m.Pallets,
+		Extrinsic: Extrinsic{
+			Version:          m.Extrinsic.Version,
+			AddressType:      m.Extrinsic.AddressType,
+			CallType:         m.Extrinsic.CallType,
+			SignatureType:    m.Extrinsic.SignatureType,
+			ExtraType:        m.Extrinsic.ExtraType,
+			SignedExtensions: m.Extrinsic.SignedExtensions,
+		},
+		Type:       m.Type,
+		APIs:       m.APIs,
+		OuterEnums: m.OuterEnums,
+		Custom:     m.Custom,
+	}
+}
+
+// Pallet returns the pallet with the given name
+func (m *Metadata) Pallet(name string) (*Pallet, error) {
+	for i := range m.Pallets {
+		if m.Pallets[i].Name == name {
+			return &m.Pallets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPalletNotFound, name)
+}
+
+// TypeByID returns the type with the given id from the type registry
+func (m *Metadata) TypeByID(id uint) (*Type, error) {
+	// type ids are usually the indices of the registry
+	if id < uint(len(m.Types)) && m.Types[id].ID == id {
+		return &m.Types[id].Type, nil
+	}
+	for i := range m.Types {
+		if m.Types[i].ID == id {
+			return &m.Types[i].Type, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrTypeNotFound, id)
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTypes() []PortableType {
+	accountIDName := "AccountId32"
+	return []PortableType{
+		{ID: 0, Type: Type{Def: NewTypeDef(U32)}},
+		{ID: 1, Type: Type{Def: NewTypeDef(TypeDefArray{Len: 32, Type: 2})}},
+		{ID: 2, Type: Type{Def: NewTypeDef(U8)}},
+		{ID: 3, Type: Type{
+			Path: []string{"sp_core", "crypto", "AccountId32"},
+			Def: NewTypeDef(TypeDefComposite{
+				Fields: []Field{{Type: 1, TypeName: &accountIDName}},
+			}),
+		}},
+		{ID: 4, Type: Type{
+			Path:   []string{"Option"},
+			Params: []TypeParameter{{Name: "T", Type: new(uint)}},
+			Def: NewTypeDef(TypeDefVariant{Variants: []Variant{
+				{Name: "None", Index: 0},
+				{Name: "Some", Fields: []Field{{Type: 0}}, Index: 1},
+			}}),
+		}},
+		{ID: 5, Type: Type{Def: NewTypeDef(TypeDefSequence{Type: 2})}},
+		{ID: 6, Type: Type{Def: NewTypeDef(TypeDefTuple{0, 3})}},
+		{ID: 7, Type: Type{Def: NewTypeDef(TypeDefCompact{Type: 0})}},
+		{ID: 8, Type: Type{Def: NewTypeDef(TypeDefBitSequence{BitStoreType: 2, BitOrderType: 6})}},
+	}
+}
+
+func newTestPallets() []Pallet {
+	return []Pallet{
+		{
+			Name: "System",
+			Storage: &PalletStorage{
+				Prefix: "System",
+				Entries: []StorageEntry{
+					{
+						Name:     "Account",
+						Modifier: Default,
+						Type: NewStorageEntryType(StorageEntryTypeMap{
+							Hashers: []StorageHasher{Blake2_128Concat},
+							Key:     3,
+							Value:   0,
+						}),
+						Default: []byte{0, 0, 0, 0},
+						Docs:    []string{" The full account information for a particular account ID."},
+					},
+					{
+						Name:     "Number",
+						Modifier: Default,
+						Type:     NewStorageEntryType(StorageEntryTypePlain(0)),
+						Default:  []byte{0, 0, 0, 0},
+					},
+				},
+			},
+			Calls:     &PalletType{Type: 4},
+			Constants: []PalletConstant{{Name: "SS58Prefix", Type: 0, Value: []byte{42, 0, 0, 0}}},
+			Index:     0,
+		},
+		{
+			Name:  "Timestamp",
+			Event: &PalletType{Type: 6},
+			Error: &PalletType{Type: 7},
+			Index: 3,
+		},
+	}
+}
+
+func encodeMetadata(t *testing.T, version uint8, metadata any) []byte {
+	t.Helper()
+	encoded, err := scale.Marshal(metadata)
+	require.NoError(t, err)
+	return append(append(Magic[:], version), encoded...)
+}
+
+func Test_Decode(t *testing.T) {
+	t.Parallel()
+
+	types := newTestTypes()
+	pallets := newTestPallets()
+	palletsV14 := make([]palletV14, len(pallets))
+	for i, pallet := range pallets {
+		palletsV14[i] = palletV14{
+			Name:      pallet.Name,
+			Storage:   pallet.Storage,
+			Calls:     pallet.Calls,
+			Event:     pallet.Event,
+			Constants: pallet.Constants,
+			Error:     pallet.Error,
+			Index:     pallet.Index,
+		}
+	}
+	signedExtensions := []SignedExtension{{Identifier: "CheckNonce", Type: 7}}
+
+	v15Pallets := newTestPallets()
+	v15Pallets[0].Docs = []string{" The System pallet."}
+
+	testCases := map[string]struct {
+		data       []byte
+		metadata   *Metadata
+		errWrapped error
+		errMessage string
+	}{
+		"invalid_magic": {
+			data:       []byte{'a', 't', 'e', 'm', 14},
+			errWrapped: ErrInvalidMagic,
+			errMessage: "invalid metadata magic number",
+		},
+		"unsupported_version": {
+			data:       []byte{'m', 'e', 't', 'a', 13},
+			errWrapped: ErrUnsupportedVersion,
+			errMessage: "unsupported metadata version: 13",
+		},
+		"v14": {
+			data: encodeMetadata(t, 14, metadataV14{
+				Types:   types,
+				Pallets: palletsV14,
+				Extrinsic: extrinsicV14{
+					Type:             5,
+					Version:          4,
+					SignedExtensions: signedExtensions,
+				},
+				Type: 6,
+			}),
+			metadata: &Metadata{
+				Version: 14,
+				Types:   types,
+				Pallets: pallets,
+				Extrinsic: Extrinsic{
+					Version:          4,
+					Type:             5,
+					SignedExtensions: signedExtensions,
+				},
+				Type: 6,
+			},
+		},
+		"v15": {
+			data: encodeMetadata(t, 15, metadataV15{
+				Types:   types,
+				Pallets: v15Pallets,
+				Extrinsic: extrinsicV15{
+					Version:          4,
+					AddressType:      3,
+					CallType:         4,
+					SignatureType:    1,
+					ExtraType:        6,
+					SignedExtensions: signedExtensions,
+				},
+				Type: 6,
+				APIs: []RuntimeAPI{{
+					Name: "Core",
+					Methods: []RuntimeAPIMethod{{
+						Name:   "version",
+						Output: 5,
+					}},
+				}},
+				OuterEnums: OuterEnums{CallType: 4, EventType: 6, ErrorType: 7},
+				Custom:     map[string]CustomValue{"answer": {Type: 0, Value: []byte{42, 0, 0, 0}}},
+			}),
+			metadata: &Metadata{
+				Version: 15,
+				Types:   types,
+				Pallets: v15Pallets,
+				Extrinsic: Extrinsic{
+					Version:          4,
+					AddressType:      3,
+					CallType:         4,
+					SignatureType:    1,
+					ExtraType:        6,
+					SignedExtensions: signedExtensions,
+				},
+				Type: 6,
+				APIs: []RuntimeAPI{{
+					Name: "Core",
+					Methods: []RuntimeAPIMethod{{
+						Name:   "version",
+						Output: 5,
+					}},
+				}},
+				OuterEnums: OuterEnums{CallType: 4, EventType: 6, ErrorType: 7},
+				Custom:     map[string]CustomValue{"answer": {Type: 0, Value: []byte{42, 0, 0, 0}}},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			metadata, err := Decode(testCase.data)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.metadata, metadata)
+		})
+	}
+}
+
+func Test_Metadata_TypeByID(t *testing.T) {
+	t.Parallel()
+
+	metadata := &Metadata{Types: newTestTypes()}
+
+	typ, err := metadata.TypeByID(3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sp_core", "crypto", "AccountId32"}, typ.Path)
+
+	_, err = metadata.TypeByID(9)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metadata
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// Pallet describes a pallet of the runtime. Docs are only set for V15 metadata.
+type Pallet struct {
+	Name      string
+	Storage   *PalletStorage
+	Calls     *PalletType
+	Event     *PalletType
+	Constants []PalletConstant
+	Error     *PalletType
+	Index     uint8
+	Docs      []string
+}
+
+type palletV14 struct {
+	Name      string
+	Storage   *PalletStorage
+	Calls     *PalletType
+	Event     *PalletType
+	Constants []PalletConstant
+	Error     *PalletType
+	Index     uint8
+}
+
+func (p palletV14) toPallet() Pallet {
+	return Pallet{
+		Name:      p.Name,
+		Storage:   p.Storage,
+		Calls:     p.Calls,
+		Event:     p.Event,
+		Constants: p.Constants,
+		Error:     p.Error,
+		Index:     p.Index,
+	}
+}
+
+// PalletType holds the type id of the calls, events or errors of a pallet
+type PalletType struct {
+	Type uint
+}
+
+// PalletConstant is a constant of a pallet, encoded as the given type
+type PalletConstant struct {
+	Name  string
+	Type  uint
+	Value []byte
+	Docs  []string
+}
+
+// PalletStorage describes the storage items of a pallet
+type PalletStorage struct {
+	Prefix  string
+	Entries []StorageEntry
+}
+
+// StorageEntry describes a storage item
+type StorageEntry struct {
+	Name     string
+	Modifier StorageEntryModifier
+	Type     StorageEntryType
+	Default  []byte
+	Docs     []string
+}
+
+// StorageEntryModifier tells if a storage item returns its default value when absent
+type StorageEntryModifier uint8
+
+const (
+	Optional StorageEntryModifier = iota
+	Default
+)
+
+// StorageHasher is the hasher of a storage map key
+type StorageHasher uint8
+
+const (
+	Blake2_128 StorageHasher = iota
+	Blake2_256
+	Blake2_128Concat
+	Twox128
+	Twox256
+	Twox64Concat
+	Identity
+)
+
+// StorageEntryTypePlain is a storage value of the given type
+type StorageEntryTypePlain uint
+
+// StorageEntryTypeMap is a storage map, with a hasher per key of the key type
+type StorageEntryTypeMap struct {
+	Hashers []StorageHasher
+	Key     uint
+	Value   uint
+}
+
+// StorageEntryTypeValues is the type constraint of the StorageEntryType values
+type StorageEntryTypeValues interface {
+	StorageEntryTypePlain | StorageEntryTypeMap
+}
+
+// StorageEntryType is the type of a storage item
+type StorageEntryType struct {
+	inner any
+}
+
+func setStorageEntryType[Value StorageEntryTypeValues](mvdt *StorageEntryType, value Value) {
+	mvdt.inner = value
+}
+
+// NewStorageEntryType returns a StorageEntryType holding the given value
+func NewStorageEntryType[Value StorageEntryTypeValues](value Value) StorageEntryType {
+	entryType := StorageEntryType{}
+	setStorageEntryType[Value](&entryType, value)
+	return entryType
+}
+
+// SetValue sets the value of the StorageEntryType
+func (mvdt *StorageEntryType) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case StorageEntryTypePlain:
+		setStorageEntryType(mvdt, value)
+		return
+	case StorageEntryTypeMap:
+		setStorageEntryType(mvdt, value)
+		return
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+// IndexValue returns the index and value of the StorageEntryType
+func (mvdt StorageEntryType) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case StorageEntryTypePlain:
+		return 0, mvdt.inner, nil
+	case StorageEntryTypeMap:
+		return 1, mvdt.inner, nil
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+// Value returns the value of the StorageEntryType
+func (mvdt StorageEntryType) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+
+// ValueAt returns a zero value of the StorageEntryType value at the given index
+func (mvdt StorageEntryType) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 0:
+		return StorageEntryTypePlain(0), nil
+	case 1:
+		return StorageEntryTypeMap{}, nil
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+// StorageEntry returns the storage item of the pallet with the given name
+func (p *Pallet) StorageEntry(name string) (*StorageEntry, error) {
+	if p.Storage == nil {
+		return nil, fmt.Errorf("%w: %s has no storage", ErrStorageNotFound, p.Name)
+	}
+	for i := range p.Storage.Entries {
+		if p.Storage.Entries[i].Name == name {
+			return &p.Storage.Entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s.%s", ErrStorageNotFound, p.Name, name)
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metadata
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// StorageKey returns the storage key of the storage item of the given pallet. The
+// keys of a storage map are SCALE encoded and hashed with the hashers of the item,
+// and fewer keys than hashers give the prefix of the map entries with these keys.
+func (m *Metadata) StorageKey(pallet, item string, keys ...any) ([]byte, error) {
+	p, err := m.Pallet(pallet)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := p.StorageEntry(item)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashers []StorageHasher
+	entryType, err := entry.Type.Value()
+	if err != nil {
+		return nil, fmt.Errorf("getting storage entry type: %w", err)
+	}
+	if mapType, ok := entryType.(StorageEntryTypeMap); ok {
+		hashers = mapType.Hashers
+	}
+	if len(keys) > len(hashers) {
+		return nil, fmt.Errorf("%w: %d keys for %s.%s with %d hashers",
+			ErrTooManyKeys, len(keys), pallet, item, len(hashers))
+	}
+
+	key, err := StoragePrefix(p.Storage.Prefix, entry.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, k := range keys {
+		encoded, err := scale.Marshal(k)
+		if err != nil {
+			return nil, fmt.Errorf("encoding key %d: %w", i, err)
+		}
+
+		hashed, err := hashers[i].Hash(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("hashing key %d: %w", i, err)
+		}
+		key = append(key, hashed...)
+	}
+	return key, nil
+}
+
+// StoragePrefix returns the storage key prefix of a storage item, which is the
+// twox128 hash of the pallet prefix followed by the twox128 hash of the item name.
+func StoragePrefix(palletPrefix, item string) ([]byte, error) {
+	palletHash, err := common.Twox128Hash([]byte(palletPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("hashing pallet prefix: %w", err)
+	}
+
+	itemHash, err := common.Twox128Hash([]byte(item))
+	if err != nil {
+		return nil, fmt.Errorf("hashing item name: %w", err)
+	}
+	return append(palletHash, itemHash...), nil
+}
+
+// Hash hashes an encoded storage map key. The concat hashers append the key to its hash.
+func (h StorageHasher) Hash(key []byte) ([]byte, error) {
+	switch h {
+	case Blake2_128:
+		return common.Blake2b128(key)
+	case Blake2_256:
+		hash, err := common.Blake2bHash(key)
+		return hash[:], err
+	case Blake2_128Concat:
+		hash, err := common.Blake2b128(key)
+		if err != nil {
+			return nil, err
+		}
+		return append(hash, key...), nil
+	case Twox128:
+		return common.Twox128Hash(key)
+	case Twox256:
+		hash, err := common.Twox256(key)
+		return hash[:], err
+	case Twox64Concat:
+		hash, err := common.Twox64(key)
+		if err != nil {
+			return nil, err
+		}
+		return append(hash, key...), nil
+	case Identity:
+		return append([]byte(nil), key...), nil
+	default:
+		return nil, fmt.Errorf("unknown storage hasher: %d", h)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Metadata_StorageKey(t *testing.T) {
+	t.Parallel()
+
+	accountID := [32]byte{1, 2, 3}
+	accountIDHash, err := common.Blake2b128(accountID[:])
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		pallet     string
+		item       string
+		keys       []any
+		key        []byte
+		errWrapped error
+		errMessage string
+	}{
+		"plain": {
+			pallet: "System",
+			item:   "Number",
+			key:    common.MustHexToBytes("0x26aa394eea5630e07c48ae0c9558cef702a5c1b19ab7a04f536c519aca4983ac"),
+		},
+		"map": {
+			pallet: "System",
+			item:   "Account",
+			keys:   []any{accountID},
+			key: append(append(
+				common.MustHexToBytes("0x26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886371da9"),
+				accountIDHash...), accountID[:]...),
+		},
+		"map_prefix": {
+			pallet: "System",
+			item:   "Account",
+			key:    common.MustHexToBytes("0x26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886371da9"),
+		},
+		"too_many_keys": {
+			pallet:     "System",
+			item:       "Number",
+			keys:       []any{uint32(1)},
+			errWrapped: ErrTooManyKeys,
+			errMessage: "too many storage keys: 1 keys for System.Number with 0 hashers",
+		},
+		"pallet_not_found": {
+			pallet:     "Balances",
+			item:       "Account",
+			errWrapped: ErrPalletNotFound,
+			errMessage: "pallet not found: Balances",
+		},
+		"pallet_without_storage": {
+			pallet:     "Timestamp",
+			item:       "Now",
+			errWrapped: ErrStorageNotFound,
+			errMessage: "storage item not found: Timestamp has no storage",
+		},
+		"item_not_found": {
+			pallet:     "System",
+			item:       "Events",
+			errWrapped: ErrStorageNotFound,
+			errMessage: "storage item not found: System.Events",
+		},
+	}
+
+	metadata := &Metadata{Pallets: newTestPallets()}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := metadata.StorageKey(testCase.pallet, testCase.item, testCase.keys...)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.key, key)
+		})
+	}
+}
+
+func Test_StorageHasher_Hash(t *testing.T) {
+	t.Parallel()
+
+	key := []byte{1, 2, 3, 4}
+	blake2b128, err := common.Blake2b128(key)
+	require.NoError(t, err)
+	blake2b256, err := common.Blake2bHash(key)
+	require.NoError(t, err)
+	twox64, err := common.Twox64(key)
+	require.NoError(t, err)
+	twox128, err := common.Twox128Hash(key)
+	require.NoError(t, err)
+	twox256, err := common.Twox256(key)
+	require.NoError(t, err)
+
+	testCases := map[StorageHasher][]byte{
+		Blake2_128:       blake2b128,
+		Blake2_256:       blake2b256[:],
+		Blake2_128Concat: append(blake2b128, key...),
+		Twox128:          twox128,
+		Twox256:          twox256[:],
+		Twox64Concat:     append(twox64, key...),
+		Identity:         key,
+	}
+
+	for hasher, expected := range testCases {
+		hashed, err := hasher.Hash(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, hashed)
+	}
+
+	_, err = StorageHasher(7).Hash(key)
+	assert.EqualError(t, err, "unknown storage hasher: 7")
+}
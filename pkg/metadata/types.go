@@ -0,0 +1,208 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metadata
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// PortableType is a type of the type registry with its id
+type PortableType struct {
+	ID   uint
+	Type Type
+}
+
+// Type describes a type of the runtime
+type Type struct {
+	Path   []string
+	Params []TypeParameter
+	Def    TypeDef
+	Docs   []string
+}
+
+// TypeParameter is a generic parameter of a type. Type is nil if the parameter is unused.
+type TypeParameter struct {
+	Name string
+	Type *uint
+}
+
+// Field is a field of a composite type or of a variant
+type Field struct {
+	Name     *string
+	Type     uint
+	TypeName *string
+	Docs     []string
+}
+
+// Variant is a variant of an enum type
+type Variant struct {
+	Name   string
+	Fields []Field
+	Index  uint8
+	Docs   []string
+}
+
+// TypeDefComposite is a struct or tuple struct type
+type TypeDefComposite struct {
+	Fields []Field
+}
+
+// TypeDefVariant is an enum type
+type TypeDefVariant struct {
+	Variants []Variant
+}
+
+// TypeDefSequence is a vector type
+type TypeDefSequence struct {
+	Type uint
+}
+
+// TypeDefArray is a fixed length array type
+type TypeDefArray struct {
+	Len  uint32
+	Type uint
+}
+
+// TypeDefTuple is a tuple type
+type TypeDefTuple []uint
+
+// TypeDefPrimitive is a primitive type
+type TypeDefPrimitive uint8
+
+const (
+	Bool TypeDefPrimitive = iota
+	Char
+	Str
+	U8
+	U16
+	U32
+	U64
+	U128
+	U256
+	I8
+	I16
+	I32
+	I64
+	I128
+	I256
+)
+
+// TypeDefCompact is the compact encoding of a type
+type TypeDefCompact struct {
+	Type uint
+}
+
+// TypeDefBitSequence is a bit vector type
+type TypeDefBitSequence struct {
+	BitStoreType uint
+	BitOrderType uint
+}
+
+// TypeDefValues is the type constraint of the TypeDef values
+type TypeDefValues interface {
+	TypeDefComposite | TypeDefVariant | TypeDefSequence | TypeDefArray | TypeDefTuple |
+		TypeDefPrimitive | TypeDefCompact | TypeDefBitSequence
+}
+
+// TypeDef is the definition of a type
+type TypeDef struct {
+	inner any
+}
+
+func setTypeDef[Value TypeDefValues](mvdt *TypeDef, value Value) {
+	mvdt.inner = value
+}
+
+// NewTypeDef returns a TypeDef holding the given value
+func NewTypeDef[Value TypeDefValues](value Value) TypeDef {
+	def := TypeDef{}
+	setTypeDef[Value](&def, value)
+	return def
+}
+
+// SetValue sets the value of the TypeDef
+func (mvdt *TypeDef) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case TypeDefComposite:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefVariant:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefSequence:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefArray:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefTuple:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefPrimitive:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefCompact:
+		setTypeDef(mvdt, value)
+		return
+	case TypeDefBitSequence:
+		setTypeDef(mvdt, value)
+		return
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+// IndexValue returns the index and value of the TypeDef
+func (mvdt TypeDef) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case TypeDefComposite:
+		return 0, mvdt.inner, nil
+	case TypeDefVariant:
+		return 1, mvdt.inner, nil
+	case TypeDefSequence:
+		return 2, mvdt.inner, nil
+	case TypeDefArray:
+		return 3, mvdt.inner, nil
+	case TypeDefTuple:
+		return 4, mvdt.inner, nil
+	case TypeDefPrimitive:
+		return 5, mvdt.inner, nil
+	case TypeDefCompact:
+		return 6, mvdt.inner, nil
+	case TypeDefBitSequence:
+		return 7, mvdt.inner, nil
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+// Value returns the value of the TypeDef
+func (mvdt TypeDef) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+
+// ValueAt returns a zero value of the TypeDef value at the given index
+func (mvdt TypeDef) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 0:
+		return TypeDefComposite{}, nil
+	case 1:
+		return TypeDefVariant{}, nil
+	case 2:
+		return TypeDefSequence{}, nil
+	case 3:
+		return TypeDefArray{}, nil
+	case 4:
+		return TypeDefTuple{}, nil
+	case 5:
+		return TypeDefPrimitive(0), nil
+	case 6:
+		return TypeDefCompact{}, nil
+	case 7:
+		return TypeDefBitSequence{}, nil
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
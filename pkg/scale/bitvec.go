@@ -0,0 +1,65 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+// BitVec is a vector of bits, encoded like the bitvec crate's BitVec<u8, Lsb0>
+// used for availability bitfields: the compact number of bits, followed by the
+// bits packed into bytes, least significant bit first.
+type BitVec struct {
+	bits []bool
+}
+
+// NewBitVec returns a BitVec holding a copy of the given bits
+func NewBitVec(bits []bool) BitVec {
+	return BitVec{bits: append([]bool(nil), bits...)}
+}
+
+// NewBitVecFromBytes returns a BitVec of the given number of bits unpacked from
+// bytes, least significant bit first. Missing bytes are taken as zero.
+func NewBitVecFromBytes(b []byte, length uint) BitVec {
+	bits := make([]bool, length)
+	for i := range bits {
+		if i/8 < len(b) {
+			bits[i] = b[i/8]&(1<<(i%8)) != 0
+		}
+	}
+	return BitVec{bits: bits}
+}
+
+// Len returns the number of bits
+func (bv BitVec) Len() uint {
+	return uint(len(bv.bits))
+}
+
+// Get returns the bit at index i, or false if i is out of range
+func (bv BitVec) Get(i uint) bool {
+	if i >= bv.Len() {
+		return false
+	}
+	return bv.bits[i]
+}
+
+// Set sets the bit at index i, growing the BitVec if i is out of range
+func (bv *BitVec) Set(i uint, value bool) {
+	if i >= bv.Len() {
+		bv.bits = append(bv.bits, make([]bool, i+1-bv.Len())...)
+	}
+	bv.bits[i] = value
+}
+
+// Bits returns a copy of the bits
+func (bv BitVec) Bits() []bool {
+	return append([]bool(nil), bv.bits...)
+}
+
+// Bytes returns the bits packed into bytes, least significant bit first
+func (bv BitVec) Bytes() []byte {
+	b := make([]byte, (len(bv.bits)+7)/8)
+	for i, bit := range bv.bits {
+		if bit {
+			b[i/8] |= 1 << (i % 8)
+		}
+	}
+	return b
+}
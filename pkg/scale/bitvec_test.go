@@ -0,0 +1,75 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BitVec(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		bits    []bool
+		encoded []byte
+	}{
+		"empty": {
+			encoded: []byte{0},
+		},
+		"single_byte": {
+			bits:    []bool{true, false, true, true, false},
+			encoded: []byte{5 << 2, 0b0000_1101},
+		},
+		"two_bytes": {
+			bits:    []bool{false, false, false, false, false, false, false, false, true, true},
+			encoded: []byte{10 << 2, 0, 0b0000_0011},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bv := NewBitVec(testCase.bits)
+			encoded, err := Marshal(bv)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoded, encoded)
+
+			var decoded BitVec
+			err = Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, bv.Len(), decoded.Len())
+			assert.Equal(t, bv.Bytes(), decoded.Bytes())
+		})
+	}
+}
+
+func Test_BitVec_SetGet(t *testing.T) {
+	t.Parallel()
+
+	var bv BitVec
+	bv.Set(9, true)
+	assert.Equal(t, uint(10), bv.Len())
+	assert.True(t, bv.Get(9))
+	assert.False(t, bv.Get(0))
+	assert.False(t, bv.Get(10))
+	assert.Equal(t, []byte{0, 0b0000_0010}, bv.Bytes())
+}
+
+func Test_BitVec_decodeErrors(t *testing.T) {
+	t.Parallel()
+
+	var bv BitVec
+	err := Unmarshal([]byte{9 << 2, 0xff}, &bv)
+	assert.ErrorContains(t, err, "unexpected EOF")
+
+	decoder := NewDecoder(bytes.NewReader([]byte{0x01, 0x01, 0xff}), WithMaxAllocation(32))
+	err = decoder.Decode(&bv)
+	assert.ErrorIs(t, err, ErrMaxAllocationExceeded)
+}
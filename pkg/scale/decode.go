@@ -81,11 +81,32 @@ type Unmarshaler interface {
 	UnmarshalSCALE(io.Reader) error
 }
 
-// Decoder is used to decode from an io.Reader
+// Decoder is used to decode from an io.Reader. The input is read as values are
+// decoded, so a stream can be decoded one value at a time without buffering it.
 type Decoder struct {
 	decodeState
 }
 
+// DecoderOption is an option limiting the resources used by a Decoder
+type DecoderOption func(*decodeState)
+
+// WithMaxDepth limits the nesting depth of the values decoded by the Decoder, to guard
+// against maliciously nested inputs. Zero means no limit.
+func WithMaxDepth(maxDepth uint) DecoderOption {
+	return func(ds *decodeState) {
+		ds.maxDepth = maxDepth
+	}
+}
+
+// WithMaxAllocation limits the number of bytes allocated by the Decoder for the byte
+// arrays, strings, slices and maps of each decoded value, to guard against malicious
+// length prefixes. Zero means no limit.
+func WithMaxAllocation(maxAllocation uint) DecoderOption {
+	return func(ds *decodeState) {
+		ds.maxAllocation = maxAllocation
+	}
+}
+
 // Decode accepts a pointer to a destination and decodes into supplied destination
 func (d *Decoder) Decode(dst interface{}) (err error) {
 	dstv := reflect.ValueOf(dst)
@@ -94,6 +115,8 @@ func (d *Decoder) Decode(dst interface{}) (err error) {
 		return
 	}
 
+	d.depth = 0
+	d.allocated = 0
 	err = d.unmarshal(indirect(dstv))
 	if err != nil {
 		return
@@ -101,19 +124,89 @@ func (d *Decoder) Decode(dst interface{}) (err error) {
 	return nil
 }
 
+// DecodeLength decodes a compact encoded length, such as the length prefix of a vector.
+// The elements of a large vector can then be decoded one at a time with Decode.
+func (d *Decoder) DecodeLength() (length uint, err error) {
+	return d.decodeLength()
+}
+
+// DecodeBytesTo decodes a byte array and copies its bytes to the writer as they are
+// read, without allocating the byte array. It returns the number of bytes copied.
+func (d *Decoder) DecodeBytesTo(w io.Writer) (n int64, err error) {
+	length, err := d.decodeLength()
+	if err != nil {
+		return 0, fmt.Errorf("decoding length: %w", err)
+	}
+
+	// bytes length in encoded as Compact<u32>, so it can't be more than math.MaxUint32
+	if length > math.MaxUint32 {
+		return 0, fmt.Errorf("byte array length %d exceeds max value of uint32", length)
+	}
+
+	n, err = io.CopyN(w, d.Reader, int64(length))
+	if err != nil {
+		return n, fmt.Errorf("copying bytes: %w", err)
+	}
+	return n, nil
+}
+
 // NewDecoder is constructor for Decoder
-func NewDecoder(r io.Reader) (d *Decoder) {
+func NewDecoder(r io.Reader, options ...DecoderOption) (d *Decoder) {
 	d = &Decoder{
-		decodeState{r},
+		decodeState{Reader: r},
+	}
+	for _, option := range options {
+		option(&d.decodeState)
 	}
 	return
 }
 
 type decodeState struct {
 	io.Reader
+	maxDepth      uint
+	maxAllocation uint
+	// depth is the nesting depth of the value being decoded
+	depth uint
+	// allocated is the number of bytes allocated for the value being decoded
+	allocated uint
+}
+
+// allocate accounts for the allocation of count elements of the given type, and returns
+// an error if it exceeds the maximum allocation of the decoder
+func (ds *decodeState) allocate(count uint, elemType reflect.Type) error {
+	if ds.maxAllocation == 0 {
+		return nil
+	}
+
+	// zero sized elements are accounted for a byte so their number is limited
+	elemSize := max(uint(elemType.Size()), 1)
+	available := (ds.maxAllocation - ds.allocated) / elemSize
+	if count > available {
+		return fmt.Errorf("%w: %d elements of %d bytes with %d of %d bytes allocated",
+			ErrMaxAllocationExceeded, count, elemSize, ds.allocated, ds.maxAllocation)
+	}
+
+	ds.allocated += count * elemSize
+	return nil
+}
+
+// remaining returns the remaining bytes of the input for error messages, if the input
+// is buffered, so a stream is not read until its end
+func (ds *decodeState) remaining() []byte {
+	buffer, ok := ds.Reader.(*bytes.Buffer)
+	if !ok {
+		return nil
+	}
+	return buffer.Bytes()
 }
 
 func (ds *decodeState) unmarshal(dstv reflect.Value) (err error) {
+	ds.depth++
+	defer func() { ds.depth-- }()
+	if ds.maxDepth > 0 && ds.depth > ds.maxDepth {
+		return fmt.Errorf("%w: %d", ErrMaxDepthExceeded, ds.maxDepth)
+	}
+
 	unmarshalerType := reflect.TypeOf((*Unmarshaler)(nil)).Elem()
 	if dstv.CanAddr() && dstv.Addr().Type().Implements(unmarshalerType) {
 		methodVal := dstv.Addr().MethodByName("UnmarshalSCALE")
@@ -141,6 +234,8 @@ func (ds *decodeState) unmarshal(dstv reflect.Value) (err error) {
 		err = ds.decodeBigInt(dstv)
 	case *Uint128:
 		err = ds.decodeUint128(dstv)
+	case BitVec:
+		err = ds.decodeBitVec(dstv)
 	case int, uint:
 		err = ds.decodeUint(dstv)
 	case int8, uint8, int16, uint16, int32, uint32, int64, uint64:
@@ -263,8 +358,8 @@ func (ds *decodeState) decodeCustomPrimitive(dstv reflect.Value) (err error) {
 }
 
 func (ds *decodeState) ReadByte() (byte, error) {
-	b := make([]byte, 1)        // make buffer
-	_, err := ds.Reader.Read(b) // read what's in the Decoder's underlying buffer to our new buffer b
+	b := make([]byte, 1)                // make buffer
+	_, err := io.ReadFull(ds.Reader, b) // read what's in the Decoder's underlying reader to our new buffer b
 	return b[0], err
 }
 
@@ -301,8 +396,7 @@ func (ds *decodeState) decodeResult(dstv reflect.Value) (err error) {
 		}
 		dstv.Set(reflect.ValueOf(res))
 	default:
-		bytes, _ := io.ReadAll(ds.Reader)
-		err = fmt.Errorf("%w: value: %v, bytes: %v", ErrUnsupportedResult, rb, bytes)
+		err = fmt.Errorf("%w: value: %v, bytes: %v", ErrUnsupportedResult, rb, ds.remaining())
 	}
 	return
 }
@@ -334,8 +428,7 @@ func (ds *decodeState) decodePointer(dstv reflect.Value) (err error) {
 			dstv.Set(tempElem)
 		}
 	default:
-		bytes, _ := io.ReadAll(ds.Reader)
-		err = fmt.Errorf("%w: value: %v, bytes: %v", errUnsupportedOption, rb, bytes)
+		err = fmt.Errorf("%w: value: %v, bytes: %v", errUnsupportedOption, rb, ds.remaining())
 	}
 	return
 }
@@ -369,6 +462,10 @@ func (ds *decodeState) decodeSlice(dstv reflect.Value) (err error) {
 		return
 	}
 	in := dstv.Interface()
+	err = ds.allocate(l, reflect.TypeOf(in).Elem())
+	if err != nil {
+		return
+	}
 	temp := reflect.New(reflect.ValueOf(in).Type())
 	for i := uint(0); i < l; i++ {
 		tempElemType := reflect.TypeOf(in).Elem()
@@ -405,6 +502,18 @@ func (ds *decodeState) decodeMap(dstv reflect.Value) (err error) {
 		return fmt.Errorf("decoding length: %w", err)
 	}
 	in := dstv.Interface()
+	err = ds.allocate(numberOfTuples, reflect.TypeOf(in).Key())
+	if err != nil {
+		return err
+	}
+	err = ds.allocate(numberOfTuples, reflect.TypeOf(in).Elem())
+	if err != nil {
+		return err
+	}
+
+	if dstv.IsNil() {
+		dstv.Set(reflect.MakeMap(dstv.Type()))
+	}
 
 	for i := uint(0); i < numberOfTuples; i++ {
 		tempKeyType := reflect.TypeOf(in).Key()
@@ -511,7 +620,7 @@ func (ds *decodeState) decodeUint(dstv reflect.Value) (err error) {
 		// 0b10: four-byte mode: upper six bits and the following three bytes are the LE encoding
 		// of the value (valid only for values (2**14)-(2**30-1)).
 		buf := make([]byte, 3)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return fmt.Errorf("reading bytes: %w", err)
 		}
@@ -524,8 +633,11 @@ func (ds *decodeState) decodeUint(dstv reflect.Value) (err error) {
 		// The value is contained, LE encoded, in the bytes following. The final (most significant)
 		// byte must be non-zero. Valid only for values (2**30)-(2**536-1).
 		byteLen := (prefix >> 2) + 4
+		if byteLen != 4 && byteLen != 8 {
+			return fmt.Errorf("%w: %d", ErrCompactUintPrefixUnknown, prefix)
+		}
 		buf := make([]byte, byteLen)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return fmt.Errorf("reading bytes: %w", err)
 		}
@@ -586,10 +698,15 @@ func (ds *decodeState) decodeBytes(dstv reflect.Value) (err error) {
 		return fmt.Errorf("byte array length %d exceeds max value of uint32", length)
 	}
 
+	err = ds.allocate(length, reflect.TypeOf(byte(0)))
+	if err != nil {
+		return
+	}
+
 	b := make([]byte, length)
 
 	if length > 0 {
-		_, err = ds.Read(b)
+		_, err = io.ReadFull(ds.Reader, b)
 		if err != nil {
 			return
 		}
@@ -601,6 +718,33 @@ func (ds *decodeState) decodeBytes(dstv reflect.Value) (err error) {
 	return
 }
 
+// decodeBitVec is used to decode with a destination of BitVec type
+func (ds *decodeState) decodeBitVec(dstv reflect.Value) (err error) {
+	length, err := ds.decodeLength()
+	if err != nil {
+		return
+	}
+
+	// the number of bits is encoded as Compact<u32>, so it can't be more than math.MaxUint32
+	if length > math.MaxUint32 {
+		return fmt.Errorf("bit vector length %d exceeds max value of uint32", length)
+	}
+
+	err = ds.allocate(length, reflect.TypeOf(false))
+	if err != nil {
+		return
+	}
+
+	b := make([]byte, (length+7)/8)
+	_, err = io.ReadFull(ds.Reader, b)
+	if err != nil {
+		return
+	}
+
+	dstv.Set(reflect.ValueOf(NewBitVecFromBytes(b, length)))
+	return
+}
+
 // decodeSmallInt is used in the decodeUint and decodeBigInt functions when the mode is <= 2
 // need to pass in the first byte, since we assume it's already been read
 func (ds *decodeState) decodeSmallInt(firstByte, mode byte) (out int64, err error) {
@@ -616,7 +760,7 @@ func (ds *decodeState) decodeSmallInt(firstByte, mode byte) (out int64, err erro
 		out = int64(binary.LittleEndian.Uint16([]byte{firstByte, buf}) >> 2)
 	case 2:
 		buf := make([]byte, 3)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			break
 		}
@@ -651,7 +795,7 @@ func (ds *decodeState) decodeBigInt(dstv reflect.Value) (err error) {
 		byteLen := uint(topSixBits) + 4
 
 		buf := make([]byte, byteLen)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			err = fmt.Errorf("reading bytes: %w", err)
 			break
@@ -684,42 +828,42 @@ func (ds *decodeState) decodeFixedWidthInt(dstv reflect.Value) (err error) {
 		out = b
 	case int16:
 		buf := make([]byte, 2)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return
 		}
 		out = int16(binary.LittleEndian.Uint16(buf))
 	case uint16:
 		buf := make([]byte, 2)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return
 		}
 		out = binary.LittleEndian.Uint16(buf)
 	case int32:
 		buf := make([]byte, 4)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return
 		}
 		out = int32(binary.LittleEndian.Uint32(buf))
 	case uint32:
 		buf := make([]byte, 4)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return
 		}
 		out = binary.LittleEndian.Uint32(buf)
 	case int64:
 		buf := make([]byte, 8)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return
 		}
 		out = int64(binary.LittleEndian.Uint64(buf))
 	case uint64:
 		buf := make([]byte, 8)
-		_, err = ds.Read(buf)
+		_, err = io.ReadFull(ds.Reader, buf)
 		if err != nil {
 			return
 		}
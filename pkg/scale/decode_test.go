@@ -11,10 +11,12 @@
 	"math/big"
 	"reflect"
 	"testing"
+	"testing/iotest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_decodeState_decodeFixedWidthInt(t *testing.T) {
@@ -254,6 +256,17 @@ func Test_decodeState_decodeMap(t *testing.T) {
 	}
 }
 
+func Test_decodeState_decodeMap_nilMap(t *testing.T) {
+	t.Parallel()
+
+	var output struct {
+		Map map[int8][]byte
+	}
+	err := Unmarshal([]byte{4, 2, 8, 1, 2}, &output)
+	require.NoError(t, err)
+	assert.Equal(t, map[int8][]byte{2: {1, 2}}, output.Map)
+}
+
 func Test_unmarshal_optionality(t *testing.T) {
 	var ptrTests tests
 	for _, t := range append(tests{}, allTests...) {
@@ -404,6 +417,94 @@ func Test_Decoder_Decode_MultipleCalls(t *testing.T) {
 	}
 }
 
+func Test_Decoder_stream(t *testing.T) {
+	t.Parallel()
+
+	values := []uint32{1, 2, 3}
+	data := []byte{1, 2, 3, 4, 5}
+	encoded := append(MustMarshal(values), MustMarshal(data)...)
+
+	// the reader returns a byte at a time as a network stream may
+	d := NewDecoder(iotest.OneByteReader(bytes.NewReader(encoded)))
+
+	length, err := d.DecodeLength()
+	require.NoError(t, err)
+	require.Equal(t, uint(len(values)), length)
+	for _, value := range values {
+		var decoded uint32
+		err = d.Decode(&decoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded)
+	}
+
+	var copied bytes.Buffer
+	n, err := d.DecodeBytesTo(&copied)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.Equal(t, data, copied.Bytes())
+
+	_, err = d.DecodeBytesTo(&copied)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func Test_Decoder_limits(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		A *uint8
+		B []uint16
+	}
+
+	testCases := map[string]struct {
+		options     []DecoderOption
+		encoded     []byte
+		dst         any
+		errSentinel error
+	}{
+		"within_limits": {
+			options: []DecoderOption{WithMaxDepth(3), WithMaxAllocation(4)},
+			encoded: MustMarshal(nested{A: new(uint8), B: []uint16{1, 2}}),
+			dst:     &nested{},
+		},
+		"max_depth_exceeded": {
+			options:     []DecoderOption{WithMaxDepth(2)},
+			encoded:     MustMarshal(nested{A: new(uint8)}),
+			dst:         &nested{},
+			errSentinel: ErrMaxDepthExceeded,
+		},
+		"slice_allocation_exceeded": {
+			options:     []DecoderOption{WithMaxAllocation(4)},
+			encoded:     MustMarshal(nested{B: []uint16{1, 2, 3}}),
+			dst:         &nested{},
+			errSentinel: ErrMaxAllocationExceeded,
+		},
+		"bytes_allocation_exceeded": {
+			// the length prefix of a 1GiB byte array not followed by its bytes
+			options:     []DecoderOption{WithMaxAllocation(1 << 20)},
+			encoded:     MustMarshal(uint(1 << 30)),
+			dst:         new([]byte),
+			errSentinel: ErrMaxAllocationExceeded,
+		},
+		"map_allocation_exceeded": {
+			options:     []DecoderOption{WithMaxAllocation(8)},
+			encoded:     MustMarshal(map[uint32]uint32{1: 1, 2: 2}),
+			dst:         &map[uint32]uint32{},
+			errSentinel: ErrMaxAllocationExceeded,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			d := NewDecoder(bytes.NewReader(testCase.encoded), testCase.options...)
+			err := d.Decode(testCase.dst)
+			assert.ErrorIs(t, err, testCase.errSentinel)
+		})
+	}
+}
+
 func Test_decodeState_decodeUint(t *testing.T) {
 	t.Parallel()
 	decodeUint32Tests := tests{
@@ -8,6 +8,7 @@
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"reflect"
 )
@@ -95,6 +96,8 @@ func (es *encodeState) marshal(in interface{}) (err error) {
 		err = es.encodeBigInt(in)
 	case *Uint128:
 		err = es.encodeUint128(in)
+	case BitVec:
+		err = es.encodeBitVec(in)
 	case []byte:
 		err = es.encodeBytes(in)
 	case string:
@@ -421,6 +424,21 @@ func (es *encodeState) encodeUint(i uint) (err error) {
 	return
 }
 
+// encodeBitVec encodes the compact number of bits of a BitVec followed by its packed bits
+func (es *encodeState) encodeBitVec(bv BitVec) (err error) {
+	// the number of bits is encoded as Compact<u32>, so it can't be more than math.MaxUint32
+	if bv.Len() > math.MaxUint32 {
+		return fmt.Errorf("bit vector length %d exceeds max value of uint32", bv.Len())
+	}
+
+	err = es.encodeUint(bv.Len())
+	if err != nil {
+		return
+	}
+	_, err = es.Write(bv.Bytes())
+	return
+}
+
 // encodeUint128 encodes a Uint128
 func (es *encodeState) encodeUint128(i *Uint128) (err error) {
 	if i == nil {
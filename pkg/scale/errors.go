@@ -21,4 +21,7 @@
 	ErrVaryingDataTypeNotSet           = errors.New("varying data type not set")
 	ErrUnsupportedCustomPrimitive      = errors.New("unsupported type for custom primitive")
 	ErrInvalidScaleIndex               = errors.New("invalid scale index")
+	ErrMaxDepthExceeded                = errors.New("maximum nesting depth exceeded")
+	ErrMaxAllocationExceeded           = errors.New("maximum allocation exceeded")
+	ErrPerThingOutOfRange              = errors.New("fixed-point fraction out of range")
 )
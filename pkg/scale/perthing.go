@@ -0,0 +1,82 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+)
+
+// Percent is a fixed-point fraction in parts per hundred, like sp_arithmetic::Percent
+type Percent uint8
+
+// Permill is a fixed-point fraction in parts per million, like sp_arithmetic::Permill
+type Permill uint32
+
+// Perbill is a fixed-point fraction in parts per billion, like sp_arithmetic::Perbill
+type Perbill uint32
+
+// Perquintill is a fixed-point fraction in parts per quintillion, like sp_arithmetic::Perquintill
+type Perquintill uint64
+
+// Accuracy returns the number of parts representing one
+func (Percent) Accuracy() uint64 { return 100 }
+
+// Accuracy returns the number of parts representing one
+func (Permill) Accuracy() uint64 { return 1_000_000 }
+
+// Accuracy returns the number of parts representing one
+func (Perbill) Accuracy() uint64 { return 1_000_000_000 }
+
+// Accuracy returns the number of parts representing one
+func (Perquintill) Accuracy() uint64 { return 1_000_000_000_000_000_000 }
+
+// PerThing is a fixed-point fraction type. Without a wrapper, a PerThing is encoded
+// as its fixed width number of parts.
+type PerThing interface {
+	Percent | Permill | Perbill | Perquintill
+	Accuracy() uint64
+}
+
+// Compact wraps a PerThing to encode its number of parts as a compact integer,
+// like Compact<Perbill> in staking data.
+type Compact[T PerThing] struct {
+	Value T
+}
+
+// MarshalSCALE encodes the number of parts as a compact integer. The parts of a
+// Perquintill can take up to 8 bytes in big-integer mode.
+func (c Compact[T]) MarshalSCALE() ([]byte, error) {
+	if uint64(c.Value) > c.Value.Accuracy() {
+		return nil, fmt.Errorf("%w: %d parts of %T", ErrPerThingOutOfRange, c.Value, c.Value)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	es := encodeState{Writer: buf}
+	err := es.encodeBigInt(new(big.Int).SetUint64(uint64(c.Value)))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalSCALE decodes a compact integer number of parts
+func (c *Compact[T]) UnmarshalSCALE(r io.Reader) error {
+	ds := decodeState{Reader: r}
+	parts := new(big.Int)
+	err := ds.decodeBigInt(reflect.ValueOf(&parts).Elem())
+	if err != nil {
+		return err
+	}
+
+	var value T
+	if !parts.IsUint64() || parts.Uint64() > value.Accuracy() {
+		return fmt.Errorf("%w: %s parts of %T", ErrPerThingOutOfRange, parts, value)
+	}
+	c.Value = T(parts.Uint64())
+	return nil
+}
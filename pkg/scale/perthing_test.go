@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PerThing_fixedWidth(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := Marshal(Perbill(500_000_000))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x65, 0xcd, 0x1d}, encoded)
+
+	var decoded Perbill
+	err = Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, Perbill(500_000_000), decoded)
+}
+
+func Test_Compact(t *testing.T) {
+	t.Parallel()
+
+	type staking struct {
+		Commission Compact[Perbill]
+		Slash      Compact[Percent]
+	}
+
+	value := staking{
+		Commission: Compact[Perbill]{Value: 100_000_000},
+		Slash:      Compact[Percent]{Value: 10},
+	}
+	encoded, err := Marshal(value)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02, 0x84, 0xd7, 0x17, 10 << 2}, encoded)
+
+	var decoded staking
+	err = Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, value, decoded)
+}
+
+func Test_Compact_outOfRange(t *testing.T) {
+	t.Parallel()
+
+	_, err := Marshal(Compact[Percent]{Value: 101})
+	assert.ErrorIs(t, err, ErrPerThingOutOfRange)
+
+	var decoded Compact[Percent]
+	err = Unmarshal([]byte{0x95, 0x01}, &decoded)
+	assert.ErrorIs(t, err, ErrPerThingOutOfRange)
+
+	var decodedPerquintill Compact[Perquintill]
+	err = Unmarshal([]byte{0x17, 0, 0, 0, 0, 0, 0, 0, 0, 0x01}, &decodedPerquintill)
+	assert.ErrorIs(t, err, ErrPerThingOutOfRange)
+}
+
+func Test_Compact_Perquintill(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value   Perquintill
+		encoded []byte
+	}{
+		"single_byte_mode": {
+			value:   1<<6 - 1,
+			encoded: []byte{0xfc},
+		},
+		"two_bytes_mode": {
+			value:   1 << 6,
+			encoded: []byte{0x01, 0x01},
+		},
+		"four_bytes_mode": {
+			value:   1 << 14,
+			encoded: []byte{0x02, 0x00, 0x01, 0x00},
+		},
+		"big_integer_mode_4_bytes": {
+			value:   1 << 30,
+			encoded: []byte{0x03, 0x00, 0x00, 0x00, 0x40},
+		},
+		"big_integer_mode_5_bytes": {
+			value:   1 << 32,
+			encoded: []byte{0x07, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+		"big_integer_mode_7_bytes": {
+			value:   1<<56 - 1,
+			encoded: []byte{0x0f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		},
+		"big_integer_mode_8_bytes": {
+			value:   1 << 56,
+			encoded: []byte{0x13, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01},
+		},
+		"one": {
+			value:   1_000_000_000_000_000_000,
+			encoded: []byte{0x13, 0x00, 0x00, 0x64, 0xa7, 0xb3, 0xb6, 0xe0, 0x0d},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := Marshal(Compact[Perquintill]{Value: testCase.value})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoded, encoded)
+
+			var decoded Compact[Perquintill]
+			err = Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.value, decoded.Value)
+		})
+	}
+}
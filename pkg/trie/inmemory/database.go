@@ -243,7 +243,7 @@ func getFromDBAtNode(db db.DBGetter, n *node.Node, key []byte) (
 	value []byte, err error) {
 	if n.Kind() == node.Leaf {
 		if bytes.Equal(n.PartialKey, key) {
-			return n.StorageValue, nil
+			return getStorageValueFromDB(db, n)
 		}
 		return nil, nil
 	}
@@ -251,7 +251,7 @@ func getFromDBAtNode(db db.DBGetter, n *node.Node, key []byte) (
 	branch := n
 	// Key is equal to the key of this branch or is empty
 	if len(key) == 0 || bytes.Equal(branch.PartialKey, key) {
-		return branch.StorageValue, nil
+		return getStorageValueFromDB(db, branch)
 	}
 
 	commonPrefixLength := lenCommonPrefix(branch.PartialKey, key)
@@ -294,6 +294,22 @@ func getFromDBAtNode(db db.DBGetter, n *node.Node, key []byte) (
 	// Note: do not wrap error since it's called recursively.
 }
 
+// getStorageValueFromDB returns the storage value of the node, getting it from
+// the database if the node has a hashed value, where it is stored prefixed with
+// the partial key of the node.
+func getStorageValueFromDB(db db.DBGetter, n *node.Node) (value []byte, err error) {
+	if !n.IsHashedValue {
+		return n.StorageValue, nil
+	}
+
+	prefixedKey := bytes.Join([][]byte{n.PartialKey, n.StorageValue}, nil)
+	value, err = db.Get(prefixedKey)
+	if err != nil {
+		return nil, fmt.Errorf("getting hashed value 0x%x from database: %w", n.StorageValue, err)
+	}
+	return value, nil
+}
+
 // WriteDirty writes all dirty nodes to the database and sets them to clean
 func (t *InMemoryTrie) WriteDirty(db db.NewBatcher) error {
 	batch := db.NewBatch()
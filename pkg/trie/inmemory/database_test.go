@@ -4,6 +4,7 @@
 package inmemory
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/internal/database"
@@ -288,6 +289,34 @@ func Test_GetFromDB(t *testing.T) {
 	}
 }
 
+func Test_GetFromDB_hashedValues(t *testing.T) {
+	t.Parallel()
+
+	keyValues := map[string][]byte{
+		"no":      bytes.Repeat([]byte{1}, 40),
+		"not":     []byte("short"),
+		"nothing": bytes.Repeat([]byte{2}, 33),
+	}
+	tr := NewEmptyTrie()
+	tr.SetVersion(trie.V1)
+	for key, value := range keyValues {
+		err := tr.Put([]byte(key), value)
+		require.NoError(t, err)
+	}
+
+	db := newTestDB(t)
+	err := tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	root := trie.V1.MustHash(tr)
+
+	for keyString, expectedValue := range keyValues {
+		value, err := GetFromDB(db, root, []byte(keyString))
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	}
+}
+
 func Test_GetFromDB_EmptyHash(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,104 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"bytes"
+
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+// Diff returns the entries of the other trie given differing from the entries of the trie,
+// as a map of keys encoded in Little Endian to their value in the other trie, the value of
+// the keys deleted in the other trie being nil. The subtries with the same Merkle value in
+// both tries are skipped, so the cost of the diff grows with the number of differences
+// rather than with the size of the tries. The entries of the child tries are not compared,
+// only the child trie roots stored in the main trie.
+func (t *InMemoryTrie) Diff(other *InMemoryTrie) (changes map[string][]byte) {
+	changes = make(map[string][]byte)
+	t.diff(other, nil, t.root, other.root, changes)
+	return changes
+}
+
+// diff sets in changes the entries differing between the subtrie of the trie rooted at
+// the node a and the subtrie of the other trie rooted at the node b, both nodes being at
+// the key prefix given.
+func (t *InMemoryTrie) diff(other *InMemoryTrie, prefix []byte, a, b *node.Node,
+	changes map[string][]byte) {
+	switch {
+	case a == nil && b == nil:
+		return
+	case sameSubtrie(a, b):
+		return
+	case a == nil || b == nil || !bytes.Equal(a.PartialKey, b.PartialKey):
+		// the subtries are shaped differently, so all their entries are compared.
+		entries := make(map[string][]byte)
+		t.buildEntriesMap(a, prefix, entries)
+		otherEntries := make(map[string][]byte)
+		other.buildEntriesMap(b, prefix, otherEntries)
+		diffEntries(entries, otherEntries, changes)
+		return
+	}
+
+	fullKey := concatenateSlices(prefix, a.PartialKey)
+	if a.StorageValue != nil || b.StorageValue != nil {
+		keyLE := codec.NibblesToKeyLE(fullKey)
+		value, otherValue := t.Get(keyLE), other.Get(keyLE)
+		if !equalValues(value, otherValue) {
+			changes[string(keyLE)] = otherValue
+		}
+	}
+
+	for i := 0; i < node.ChildrenCapacity; i++ {
+		childPrefix := concatenateSlices(fullKey, intToByteSlice(i))
+		t.diff(other, childPrefix, child(a, i), child(b, i), changes)
+	}
+}
+
+// sameSubtrie returns true if the nodes given are known to be the roots of the same
+// subtrie, without calculating their Merkle value if it is not already calculated.
+func sameSubtrie(a, b *node.Node) bool {
+	if a == b {
+		return true
+	}
+	return a != nil && b != nil &&
+		!a.Dirty && !b.Dirty &&
+		len(a.MerkleValue) > 0 &&
+		bytes.Equal(a.MerkleValue, b.MerkleValue)
+}
+
+func child(n *node.Node, index int) *node.Node {
+	if n == nil || index >= len(n.Children) {
+		return nil
+	}
+	return n.Children[index]
+}
+
+// diffEntries sets in changes the entries of other differing from the entries
+// given, the value of the keys not in other being nil.
+func diffEntries(entries, other, changes map[string][]byte) {
+	for key, value := range entries {
+		otherValue, ok := other[key]
+		if !ok {
+			changes[key] = nil
+			continue
+		}
+		if !equalValues(value, otherValue) {
+			changes[key] = otherValue
+		}
+	}
+
+	for key, otherValue := range other {
+		if _, ok := entries[key]; !ok {
+			changes[key] = otherValue
+		}
+	}
+}
+
+// equalValues returns true if the values given are equal, a nil value
+// meaning the key has no value and being different from an empty value.
+func equalValues(a, b []byte) bool {
+	return (a == nil) == (b == nil) && bytes.Equal(a, b)
+}
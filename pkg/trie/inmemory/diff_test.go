@@ -0,0 +1,110 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InMemoryTrie_Diff(t *testing.T) {
+	t.Parallel()
+
+	base := map[string][]byte{
+		"a":       {1},
+		"ab":      {2},
+		"abc":     {3},
+		"b":       {4},
+		"bcd":     {5},
+		"c":       {},
+		"longkey": {6},
+	}
+
+	testCases := map[string]struct {
+		puts    map[string][]byte
+		deletes []string
+		changes map[string][]byte
+	}{
+		"same_entries": {
+			changes: map[string][]byte{},
+		},
+		"values_changed": {
+			puts: map[string][]byte{
+				"ab": {9},
+				"c":  {9},
+			},
+			changes: map[string][]byte{
+				"ab": {9},
+				"c":  {9},
+			},
+		},
+		"value_emptied": {
+			puts:    map[string][]byte{"b": {}},
+			changes: map[string][]byte{"b": {}},
+		},
+		"keys_added_and_deleted": {
+			puts:    map[string][]byte{"abd": {7}, "d": {8}},
+			deletes: []string{"bcd", "longkey"},
+			changes: map[string][]byte{
+				"abd":     {7},
+				"d":       {8},
+				"bcd":     nil,
+				"longkey": nil,
+			},
+		},
+		"branch_turned_to_leaf": {
+			deletes: []string{"ab", "abc"},
+			changes: map[string][]byte{
+				"ab":  nil,
+				"abc": nil,
+			},
+		},
+		"leaf_split": {
+			puts:    map[string][]byte{"longer": {9}},
+			changes: map[string][]byte{"longer": {9}},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := NewEmptyTrie()
+			for key, value := range base {
+				err := tr.Put([]byte(key), value)
+				require.NoError(t, err)
+			}
+			// the Merkle values of the nodes are calculated, so the
+			// subtries shared by both tries are skipped by the diff.
+			_, err := trie.V0.Hash(tr)
+			require.NoError(t, err)
+
+			other := tr.Snapshot()
+			for key, value := range testCase.puts {
+				err := other.Put([]byte(key), value)
+				require.NoError(t, err)
+			}
+			for _, key := range testCase.deletes {
+				err := other.Delete([]byte(key))
+				require.NoError(t, err)
+			}
+			_, err = trie.V0.Hash(other)
+			require.NoError(t, err)
+
+			changes := tr.Diff(other)
+			assert.Equal(t, testCase.changes, changes)
+
+			// the diff of the other trie with the trie reverts the changes
+			reverted := other.Diff(tr)
+			assert.Len(t, reverted, len(testCase.changes))
+			for key := range testCase.changes {
+				assert.Equal(t, base[key], reverted[key])
+			}
+		})
+	}
+}
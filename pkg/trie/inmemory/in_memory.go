@@ -56,6 +56,11 @@ func (t *InMemoryTrie) SetVersion(v trie.TrieLayout) {
 	t.version = v
 }
 
+// Version returns the state trie version used to encode the values set.
+func (t *InMemoryTrie) Version() trie.TrieLayout {
+	return t.version
+}
+
 // Equal is to compare one trie with other, this method will ignore the shared db instance
 func (t *InMemoryTrie) Equal(other *InMemoryTrie) bool {
 	if t == nil && other == nil {
@@ -720,9 +725,7 @@ func getKeysWithPrefixFromBranch(parent *node.Node, prefix, key []byte,
 		return addAllKeys(parent, prefix, keysLE)
 	}
 
-	noPossiblePrefixedKeys :=
-		len(parent.PartialKey) > len(key) &&
-			!bytes.HasPrefix(parent.PartialKey, key)
+	noPossiblePrefixedKeys := !bytes.HasPrefix(key, parent.PartialKey)
 	if noPossiblePrefixedKeys {
 		return keysLE
 	}
@@ -796,22 +799,14 @@ func retrieve(db db.DBGetter, parent *node.Node, key []byte) (value []byte) {
 
 func retrieveFromLeaf(db db.DBGetter, leaf *node.Node, key []byte) (value []byte) {
 	if bytes.Equal(leaf.PartialKey, key) {
-		if leaf.IsHashedValue {
-			// We get the node
-			value, err := db.Get(leaf.StorageValue)
-			if err != nil {
-				panic(fmt.Sprintf("retrieving value from leaf %s", err.Error()))
-			}
-			return value
-		}
-		return leaf.StorageValue
+		return retrieveStorageValue(db, leaf)
 	}
 	return nil
 }
 
 func retrieveFromBranch(db db.DBGetter, branch *node.Node, key []byte) (value []byte) {
 	if len(key) == 0 || bytes.Equal(branch.PartialKey, key) {
-		return branch.StorageValue
+		return retrieveStorageValue(db, branch)
 	}
 
 	if len(branch.PartialKey) > len(key) && bytes.HasPrefix(branch.PartialKey, key) {
@@ -825,6 +820,20 @@ func retrieveFromBranch(db db.DBGetter, branch *node.Node, key []byte) (value []
 	return retrieve(db, child, childKey)
 }
 
+// retrieveStorageValue returns the storage value of the node, getting it
+// from the database using its hash if the node has a hashed value.
+func retrieveStorageValue(db db.DBGetter, n *node.Node) (value []byte) {
+	if !n.IsHashedValue {
+		return n.StorageValue
+	}
+
+	value, err := db.Get(n.StorageValue)
+	if err != nil {
+		panic(fmt.Sprintf("retrieving hashed value from %s: %s", n.Kind(), err))
+	}
+	return value
+}
+
 // ClearPrefixLimit deletes the keys having the prefix given in little
 // Endian format for up to `limit` keys. It returns the number of deleted
 // keys and a boolean indicating if all keys with the prefix were deleted
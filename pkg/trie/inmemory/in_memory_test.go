@@ -1834,6 +1834,38 @@ func Test_Trie_GetKeysWithPrefix(t *testing.T) {
 	}
 }
 
+func Test_Trie_PrefixIterator(t *testing.T) {
+	t.Parallel()
+
+	tr := NewEmptyTrie()
+	keys := [][]byte{
+		{0x01}, {0x01, 0x02}, {0x01, 0x02, 0x03}, {0x01, 0x02, 0x10},
+		{0x01, 0x20}, {0x02}, {0x12, 0x34},
+	}
+	for _, key := range keys {
+		err := tr.Put(key, []byte{1})
+		require.NoError(t, err)
+	}
+
+	prefix := []byte{0x01}
+	expectedKeys := tr.GetKeysWithPrefix(prefix)
+	require.Len(t, expectedKeys, 5)
+
+	var pagedKeys [][]byte
+	var startAfter []byte
+	for {
+		page := trie.GetKeysWithPrefixPaged(tr, prefix, startAfter, 2)
+		if len(page) == 0 {
+			break
+		}
+		pagedKeys = append(pagedKeys, page...)
+		startAfter = page[len(page)-1]
+	}
+	assert.Equal(t, expectedKeys, pagedKeys)
+	assert.Equal(t, uint(5), trie.CountKeysWithPrefix(tr, prefix))
+	assert.Equal(t, uint(len(keys)), trie.CountKeysWithPrefix(tr, nil))
+}
+
 func Test_getKeysWithPrefix(t *testing.T) {
 	t.Parallel()
 
@@ -1893,6 +1925,32 @@ func Test_getKeysWithPrefix(t *testing.T) {
 			keys:         [][]byte{{1}, {2}},
 			expectedKeys: [][]byte{{1}, {2}},
 		},
+		"search_key_same_length_as_branch_key_with_no_full_common_prefix": {
+			parent: &node.Node{
+				PartialKey:  []byte{1, 2, 3},
+				Descendants: 2,
+				Children: padRightChildren([]*node.Node{
+					{PartialKey: []byte{4}, StorageValue: []byte{1}},
+					{PartialKey: []byte{5}, StorageValue: []byte{1}},
+				}),
+			},
+			key:          []byte{1, 2, 4},
+			keys:         [][]byte{{1}, {2}},
+			expectedKeys: [][]byte{{1}, {2}},
+		},
+		"search_key_longer_than_branch_key_with_no_full_common_prefix": {
+			parent: &node.Node{
+				PartialKey:  []byte{1, 2, 3},
+				Descendants: 2,
+				Children: padRightChildren([]*node.Node{
+					{PartialKey: []byte{4}, StorageValue: []byte{1}},
+					{PartialKey: []byte{5}, StorageValue: []byte{1}},
+				}),
+			},
+			key:          []byte{1, 3, 3, 0},
+			keys:         [][]byte{{1}, {2}},
+			expectedKeys: [][]byte{{1}, {2}},
+		},
 		"common_prefix_smaller_tan_search_key": {
 			parent: &node.Node{
 				PartialKey:  []byte{1, 2},
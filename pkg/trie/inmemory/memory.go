@@ -0,0 +1,102 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"unsafe"
+
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+// maxMerkleValueSize is the maximum number of bytes of the Merkle value cached in a node.
+const maxMerkleValueSize = 32
+
+// NodesMemory accounts the memory of the nodes of in-memory tries. The tries snapshot
+// from one another share their unchanged nodes, so each node is counted once no matter
+// how many of the accounted tries reference it. The tries accounted must not be modified
+// until they are removed, and NodesMemory is not safe for concurrent use.
+type NodesMemory struct {
+	// references is the number of accounted tries and nodes referencing each node.
+	references map[*node.Node]uint32
+	usage      uint64
+}
+
+// NewNodesMemory creates an accountant of the memory of the nodes of in-memory tries.
+func NewNodesMemory() *NodesMemory {
+	return &NodesMemory{
+		references: make(map[*node.Node]uint32),
+	}
+}
+
+// Usage returns the estimated number of bytes of the nodes of the accounted tries.
+func (m *NodesMemory) Usage() uint64 {
+	return m.usage
+}
+
+// Add accounts the nodes of the trie and of its child tries, counting
+// only the nodes not referenced yet by the tries already accounted.
+func (m *NodesMemory) Add(t *InMemoryTrie) {
+	m.reference(t.root)
+	for _, childTrie := range t.childTries {
+		m.reference(childTrie.root)
+	}
+}
+
+// Remove stops accounting the nodes of the trie and of its child tries, returning
+// the estimated number of bytes of the nodes no other accounted trie references,
+// which is the memory the trie holds on its own.
+func (m *NodesMemory) Remove(t *InMemoryTrie) (freed uint64) {
+	freed = m.dereference(t.root)
+	for _, childTrie := range t.childTries {
+		freed += m.dereference(childTrie.root)
+	}
+	m.usage -= min(freed, m.usage)
+	return freed
+}
+
+func (m *NodesMemory) reference(n *node.Node) {
+	if n == nil {
+		return
+	}
+
+	m.references[n]++
+	if m.references[n] > 1 {
+		// the descendants are already referenced by the node
+		return
+	}
+
+	m.usage += nodeMemoryUsage(n)
+	for _, child := range n.Children {
+		m.reference(child)
+	}
+}
+
+func (m *NodesMemory) dereference(n *node.Node) (freed uint64) {
+	references, ok := m.references[n]
+	if !ok {
+		return 0
+	} else if references > 1 {
+		m.references[n] = references - 1
+		return 0
+	}
+
+	delete(m.references, n)
+	freed = nodeMemoryUsage(n)
+	for _, child := range n.Children {
+		freed += m.dereference(child)
+	}
+	return freed
+}
+
+// nodeMemoryUsage returns the estimated number of bytes of the node held in memory.
+// The Merkle value is counted at its maximum size since it is only cached once the
+// node is hashed, so the estimate stays the same from when the node is added to
+// when it is removed.
+func nodeMemoryUsage(n *node.Node) uint64 {
+	return uint64(unsafe.Sizeof(*n)) +
+		uint64(len(n.PartialKey)) +
+		uint64(len(n.StorageValue)) +
+		maxMerkleValueSize +
+		uint64(len(n.Children))*uint64(unsafe.Sizeof(n))
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NodesMemory(t *testing.T) {
+	t.Parallel()
+
+	trie := NewEmptyTrie()
+	for _, key := range []string{"a", "ab", "abc", "b", "bc"} {
+		err := trie.Put([]byte(key), []byte("value"))
+		require.NoError(t, err)
+	}
+
+	snapshot := trie.Snapshot()
+	err := snapshot.Put([]byte("bc"), []byte("new value"))
+	require.NoError(t, err)
+
+	memory := NewNodesMemory()
+	memory.Add(trie)
+	trieUsage := memory.Usage()
+	require.NotZero(t, trieUsage)
+
+	// the snapshot only adds the nodes copied on write
+	memory.Add(snapshot)
+	bothUsage := memory.Usage()
+	assert.Greater(t, bothUsage, trieUsage)
+	assert.Less(t, bothUsage, 2*trieUsage)
+
+	freed := memory.Remove(snapshot)
+	assert.Equal(t, bothUsage-trieUsage, freed)
+	assert.Equal(t, trieUsage, memory.Usage())
+
+	freed = memory.Remove(trie)
+	assert.Equal(t, trieUsage, freed)
+	assert.Zero(t, memory.Usage())
+	assert.Empty(t, memory.references)
+}
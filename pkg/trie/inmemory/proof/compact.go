@@ -0,0 +1,274 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+// escapeCompactHeader prefixes the compact encoding of a node whose hashed
+// storage value is attached to the node encoding, as in Substrate's sp-trie.
+const escapeCompactHeader = 0b0000_0001
+
+var (
+	ErrIncompleteCompactProof  = errors.New("compact proof is missing omitted nodes")
+	ErrExtraneousCompactProof  = errors.New("compact proof has extraneous nodes")
+	ErrCompactProofRootInvalid = errors.New("compact proof root hash does not match")
+)
+
+// KeyValue is a full key and its storage value, to verify against a proof.
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+// GenerateCompact generates a compact proof for the trie corresponding to the root
+// hash given and for the slice of (Little Endian) full keys given, compatible with
+// the compact proof format of Substrate's sp-trie. The node encodings are ordered
+// depth first, each child node of the proof being omitted from its parent encoding,
+// and the hashed storage values of the keys given are attached to their node.
+func GenerateCompact(rootHash []byte, fullKeys [][]byte, database db.DBGetter) (
+	encodedProofNodes [][]byte, err error) {
+	trie := inmemory.NewEmptyTrie()
+	if err := trie.Load(database, common.BytesToHash(rootHash)); err != nil {
+		return nil, fmt.Errorf("loading trie: %w", err)
+	}
+	rootNode := trie.RootNode()
+
+	inProof := make(map[*node.Node]struct{})
+	attachedValue := make(map[*node.Node]struct{})
+	for _, fullKey := range fullKeys {
+		path, err := walkPath(rootNode, codec.KeyLEToNibbles(fullKey))
+		if err != nil {
+			return nil, fmt.Errorf("walking to node at key 0x%x: %w", fullKey, err)
+		}
+
+		for i, pathNode := range path {
+			if i > 0 {
+				merkleValue, err := pathNode.CalculateMerkleValue()
+				if err != nil {
+					return nil, fmt.Errorf("calculating Merkle value: %w", err)
+				}
+				if len(merkleValue) < common.HashLength {
+					// inlined in the encoding of its parent
+					continue
+				}
+			}
+			inProof[pathNode] = struct{}{}
+		}
+
+		if len(path) > 0 && path[len(path)-1].MustBeHashed {
+			attachedValue[path[len(path)-1]] = struct{}{}
+		}
+	}
+
+	if len(inProof) == 0 {
+		return nil, nil
+	}
+	return encodeCompact(rootNode, inProof, attachedValue, encodedProofNodes)
+}
+
+// walkPath returns the nodes from the root node given to the node at the full key
+// given, or nil if the trie is empty and the key is empty.
+func walkPath(root *node.Node, fullKey []byte) (path []*node.Node, err error) {
+	current := root
+	for {
+		if current == nil {
+			if len(fullKey) == 0 && len(path) == 0 {
+				return nil, nil
+			}
+			return nil, ErrKeyNotFound
+		}
+		path = append(path, current)
+
+		if len(fullKey) == 0 || bytes.Equal(current.PartialKey, fullKey) {
+			return path, nil
+		}
+
+		if current.Kind() == node.Leaf || len(fullKey) <= len(current.PartialKey) {
+			return nil, ErrKeyNotFound
+		}
+
+		commonLength := lenCommonPrefix(current.PartialKey, fullKey)
+		if commonLength < len(current.PartialKey) {
+			return nil, ErrKeyNotFound
+		}
+		current = current.Children[fullKey[commonLength]]
+		fullKey = fullKey[commonLength+1:]
+	}
+}
+
+// encodeCompact appends the compact encodings of the node given and of its
+// descendant nodes in the proof to the encoded proof nodes given, depth first.
+func encodeCompact(n *node.Node, inProof, attachedValue map[*node.Node]struct{},
+	encodedProofNodes [][]byte) ([][]byte, error) {
+	compactNode := *n
+	if n.Kind() == node.Branch {
+		compactNode.Children = make([]*node.Node, node.ChildrenCapacity)
+		for i, child := range n.Children {
+			_, omitted := inProof[child]
+			if child != nil && omitted {
+				child = &node.Node{MerkleValue: []byte{}}
+			}
+			compactNode.Children[i] = child
+		}
+	}
+
+	// Note we do not use sync.Pool buffers since we would have
+	// to copy it so it persists in encodedProofNodes.
+	encodingBuffer := bytes.NewBuffer(nil)
+	if _, ok := attachedValue[n]; ok {
+		compactNode.MustBeHashed = false
+		encodingBuffer.WriteByte(escapeCompactHeader)
+	}
+	err := compactNode.Encode(encodingBuffer)
+	if err != nil {
+		return nil, fmt.Errorf("encoding node: %w", err)
+	}
+	encodedProofNodes = append(encodedProofNodes, encodingBuffer.Bytes())
+
+	for _, child := range n.Children {
+		if _, ok := inProof[child]; child == nil || !ok {
+			continue
+		}
+		encodedProofNodes, err = encodeCompact(child, inProof, attachedValue, encodedProofNodes)
+		if err != nil {
+			return nil, err // note: do not wrap since this is recursive
+		}
+	}
+	return encodedProofNodes, nil
+}
+
+// VerifyCompact verifies the given keys and values belong to the trie with the
+// root hash given, using the compact proof given. Values are only compared if
+// they are not empty. A nil error is returned on success.
+func VerifyCompact(encodedProofNodes [][]byte, rootHash []byte, keyValues []KeyValue) (err error) {
+	proofTrie, err := buildCompactTrie(encodedProofNodes, rootHash)
+	if err != nil {
+		return fmt.Errorf("building trie from compact proof: %w", err)
+	}
+
+	for _, keyValue := range keyValues {
+		err = verifyValue(proofTrie, rootHash, keyValue.Key, keyValue.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadCompact returns the value of the key in the trie with the given root hash,
+// using the compact proof given. A nil value is only returned if the proof proves
+// the key is absent from the trie, and ErrIncompleteProof is returned if a node or
+// hashed value on the path to the key is missing from the proof.
+func ReadCompact(encodedProofNodes [][]byte, rootHash, key []byte) (value []byte, err error) {
+	root, err := decodeCompactRoot(encodedProofNodes, rootHash)
+	if err != nil {
+		return nil, fmt.Errorf("building trie from compact proof: %w", err)
+	}
+
+	// Nodes and values not included in the compact proof are only referenced
+	// by their hash digest, so they cannot be resolved.
+	resolveNode := func(merkleValue []byte) (*node.Node, error) {
+		return nil, fmt.Errorf("%w: node with hash digest 0x%x",
+			ErrIncompleteProof, merkleValue)
+	}
+	resolveValue := func(hash []byte) ([]byte, error) {
+		return nil, fmt.Errorf("%w: value with hash 0x%x", ErrIncompleteProof, hash)
+	}
+
+	return readPath(root, codec.KeyLEToNibbles(key), resolveNode, resolveValue)
+}
+
+// buildCompactTrie decodes the compact proof given into a partial trie, and checks
+// its root hash matches the root hash given.
+func buildCompactTrie(encodedProofNodes [][]byte, rootHash []byte) (t trie.Trie, err error) {
+	root, err := decodeCompactRoot(encodedProofNodes, rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return inmemory.NewTrie(root, db.NewEmptyMemoryDB()), nil
+}
+
+// decodeCompactRoot decodes the compact proof given into its root node, and checks
+// its root hash matches the root hash given.
+func decodeCompactRoot(encodedProofNodes [][]byte, rootHash []byte) (root *node.Node, err error) {
+	if len(encodedProofNodes) == 0 {
+		return nil, fmt.Errorf("%w: for Merkle root hash 0x%x",
+			ErrEmptyProof, rootHash)
+	}
+
+	var index int
+	root, err = decodeCompact(encodedProofNodes, &index)
+	if err != nil {
+		return nil, err
+	}
+	if index != len(encodedProofNodes) {
+		return nil, fmt.Errorf("%w: %d nodes decoded out of %d",
+			ErrExtraneousCompactProof, index, len(encodedProofNodes))
+	}
+
+	rootMerkleValue, err := root.CalculateRootMerkleValue()
+	if err != nil {
+		return nil, fmt.Errorf("calculating root hash: %w", err)
+	}
+	if !bytes.Equal(rootMerkleValue, rootHash) {
+		return nil, fmt.Errorf("%w: expected 0x%x but got 0x%x",
+			ErrCompactProofRootInvalid, rootHash, rootMerkleValue)
+	}
+
+	return root, nil
+}
+
+// decodeCompact is a recursive function decoding the compact encoding at the index
+// given and the encodings of its omitted descendant nodes, which follow it depth first.
+func decodeCompact(encodedProofNodes [][]byte, index *int) (n *node.Node, err error) {
+	if *index >= len(encodedProofNodes) {
+		return nil, fmt.Errorf("%w: after %d nodes", ErrIncompleteCompactProof, *index)
+	}
+	encoding := encodedProofNodes[*index]
+	*index++
+
+	valueAttached := len(encoding) > 0 && encoding[0] == escapeCompactHeader
+	if valueAttached {
+		encoding = encoding[1:]
+	}
+
+	n, err = node.DecodeCompact(bytes.NewReader(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding node %d: %w", *index-1, err)
+	}
+	if n == nil {
+		return nil, fmt.Errorf("decoding node %d: empty node", *index-1)
+	}
+	// The attached value is hashed in the node encoding
+	n.MustBeHashed = valueAttached
+	n.Dirty = true
+
+	for i, child := range n.Children {
+		omitted := child != nil && child.MerkleValue != nil && len(child.MerkleValue) == 0
+		if !omitted {
+			continue
+		}
+
+		child, err = decodeCompact(encodedProofNodes, index)
+		if err != nil {
+			return nil, err // do not wrap error since this is recursive
+		}
+		n.Children[i] = child
+		n.Descendants += child.Descendants
+	}
+
+	return n, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompactTestTrie(t *testing.T, version trie.TrieLayout, keyValues []KeyValue) (
+	rootHash []byte, db database.Database) {
+	t.Helper()
+
+	tr := inmemory.NewEmptyTrie()
+	tr.SetVersion(version)
+	for _, keyValue := range keyValues {
+		err := tr.Put(keyValue.Key, keyValue.Value)
+		require.NoError(t, err)
+	}
+
+	hash, err := version.Hash(tr)
+	require.NoError(t, err)
+
+	db, err = database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	return hash.ToBytes(), db
+}
+
+func Test_GenerateCompact_VerifyCompact(t *testing.T) {
+	t.Parallel()
+
+	keyValues := []KeyValue{
+		{Key: []byte("cat"), Value: []byte("meow")},
+		{Key: []byte("catapulta"), Value: []byte("launch")},
+		{Key: []byte("catapora"), Value: bytes.Repeat([]byte{1}, 40)},
+		{Key: []byte("dog"), Value: bytes.Repeat([]byte{2}, 64)},
+		{Key: []byte("doguinho"), Value: []byte("puppy")},
+	}
+
+	for _, version := range []trie.TrieLayout{trie.V0, trie.V1} {
+		version := version
+		t.Run(version.String(), func(t *testing.T) {
+			t.Parallel()
+
+			rootHash, db := newCompactTestTrie(t, version, keyValues)
+
+			for _, keyValue := range keyValues {
+				proof, err := GenerateCompact(rootHash, [][]byte{keyValue.Key}, db)
+				require.NoError(t, err)
+
+				err = VerifyCompact(proof, rootHash, []KeyValue{keyValue})
+				require.NoError(t, err)
+
+				value, err := ReadCompact(proof, rootHash, keyValue.Key)
+				require.NoError(t, err)
+				assert.Equal(t, keyValue.Value, value)
+			}
+
+			fullKeys := make([][]byte, len(keyValues))
+			for i, keyValue := range keyValues {
+				fullKeys[i] = keyValue.Key
+			}
+			proof, err := GenerateCompact(rootHash, fullKeys, db)
+			require.NoError(t, err)
+
+			err = VerifyCompact(proof, rootHash, keyValues)
+			require.NoError(t, err)
+
+			regularProof, err := Generate(rootHash, fullKeys, db)
+			require.NoError(t, err)
+			// The compact proof attaches the values hashed in version 1
+			// to their node instead of adding them as separate entries.
+			hashedValues := 0
+			if version == trie.V1 {
+				hashedValues = 2
+			}
+			assert.Len(t, proof, len(regularProof)-hashedValues)
+		})
+	}
+}
+
+func Test_GenerateCompact_encoding(t *testing.T) {
+	t.Parallel()
+
+	largeValue := generateBytes(t, 40)
+	rootHash, db := newCompactTestTrie(t, trie.V1, []KeyValue{
+		{Key: []byte{0x01}, Value: largeValue},
+		{Key: []byte{0x02}, Value: []byte{3}},
+	})
+
+	proof, err := GenerateCompact(rootHash, [][]byte{{0x01}}, db)
+	require.NoError(t, err)
+
+	// The leaf with the hashed value is omitted from the root branch
+	// encoding and follows it with its value attached.
+	leafWithValue := node.Node{StorageValue: largeValue}
+	rootWithOmittedChild := node.Node{
+		PartialKey: []byte{0},
+		Children: padRightChildren([]*node.Node{
+			nil,
+			{MerkleValue: []byte{}},
+			{StorageValue: []byte{3}},
+		}),
+	}
+	expected := [][]byte{
+		encodeNode(t, rootWithOmittedChild),
+		append([]byte{escapeCompactHeader}, encodeNode(t, leafWithValue)...),
+	}
+	assert.Equal(t, expected, proof)
+}
+
+func Test_VerifyCompact_errors(t *testing.T) {
+	t.Parallel()
+
+	keyValues := make([]KeyValue, 4)
+	for i := range keyValues {
+		keyValues[i] = KeyValue{
+			Key:   []byte(fmt.Sprintf("key%d", i)),
+			Value: bytes.Repeat([]byte{byte(i)}, 40),
+		}
+	}
+	rootHash, db := newCompactTestTrie(t, trie.V0, keyValues)
+
+	proof, err := GenerateCompact(rootHash, [][]byte{keyValues[0].Key, keyValues[3].Key}, db)
+	require.NoError(t, err)
+	require.Greater(t, len(proof), 1)
+
+	testCases := map[string]struct {
+		proof      [][]byte
+		rootHash   []byte
+		keyValues  []KeyValue
+		errWrapped error
+	}{
+		"empty_proof": {
+			rootHash:   rootHash,
+			errWrapped: ErrEmptyProof,
+		},
+		"missing_node": {
+			proof:      proof[:len(proof)-1],
+			rootHash:   rootHash,
+			errWrapped: ErrIncompleteCompactProof,
+		},
+		"extraneous_node": {
+			proof:      append(append([][]byte{}, proof...), proof[len(proof)-1]),
+			rootHash:   rootHash,
+			errWrapped: ErrExtraneousCompactProof,
+		},
+		"wrong_root_hash": {
+			proof:      proof,
+			rootHash:   make([]byte, 32),
+			errWrapped: ErrCompactProofRootInvalid,
+		},
+		"key_not_in_proof": {
+			proof:      proof,
+			rootHash:   rootHash,
+			keyValues:  []KeyValue{keyValues[1]},
+			errWrapped: ErrKeyNotFoundInProofTrie,
+		},
+		"value_mismatch": {
+			proof:      proof,
+			rootHash:   rootHash,
+			keyValues:  []KeyValue{{Key: keyValues[0].Key, Value: keyValues[3].Value}},
+			errWrapped: ErrValueMismatchProofTrie,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := VerifyCompact(testCase.proof, testCase.rootHash, testCase.keyValues)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
+
+func Test_ReadCompact_absence(t *testing.T) {
+	t.Parallel()
+
+	keyValues := []KeyValue{
+		{Key: []byte("cat"), Value: bytes.Repeat([]byte{1}, 41)},
+		{Key: []byte("cow"), Value: bytes.Repeat([]byte{2}, 41)},
+		{Key: []byte("dog"), Value: bytes.Repeat([]byte{3}, 41)},
+	}
+	rootHash, db := newCompactTestTrie(t, trie.V1, keyValues)
+
+	proof, err := GenerateCompact(rootHash, [][]byte{[]byte("dog")}, db)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		key        []byte
+		value      []byte
+		errWrapped error
+	}{
+		"proven_value": {
+			key:   []byte("dog"),
+			value: keyValues[2].Value,
+		},
+		"proven_absent": {
+			key: []byte("eel"),
+		},
+		"node_not_in_proof": {
+			key:        []byte("cat"),
+			errWrapped: ErrIncompleteProof,
+		},
+		"absence_not_proven": {
+			key:        []byte("cod"),
+			errWrapped: ErrIncompleteProof,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := ReadCompact(proof, rootHash, testCase.key)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.value, value)
+		})
+	}
+}
@@ -89,7 +89,7 @@ func walkRoot(root *node.Node, fullKey []byte) (
 
 	nodeFound := len(fullKey) == 0 || bytes.Equal(root.PartialKey, fullKey)
 	if nodeFound {
-		return encodedProofNodes, nil
+		return appendHashedValue(encodedProofNodes, root), nil
 	}
 
 	if root.Kind() == node.Leaf && !nodeFound {
@@ -141,7 +141,7 @@ func walk(parent *node.Node, fullKey []byte) (
 
 	nodeFound := len(fullKey) == 0 || bytes.Equal(parent.PartialKey, fullKey)
 	if nodeFound {
-		return encodedProofNodes, nil
+		return appendHashedValue(encodedProofNodes, parent), nil
 	}
 
 	if parent.Kind() == node.Leaf && !nodeFound {
@@ -166,6 +166,16 @@ func walk(parent *node.Node, fullKey []byte) (
 	return encodedProofNodes, nil
 }
 
+// appendHashedValue appends the storage value of the node to the encoded proof
+// nodes if it is hashed in the node encoding (state trie version 1), since the
+// value is then stored out of the node and is needed to read the value from the proof.
+func appendHashedValue(encodedProofNodes [][]byte, n *node.Node) [][]byte {
+	if !n.MustBeHashed {
+		return encodedProofNodes
+	}
+	return append(encodedProofNodes, n.StorageValue)
+}
+
 // lenCommonPrefix returns the length of the
 // common prefix between two byte slices.
 func lenCommonPrefix(a, b []byte) (length int) {
@@ -4,6 +4,7 @@
 package proof
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"testing"
@@ -52,6 +53,139 @@ func Test_Generate_Verify(t *testing.T) {
 	}
 }
 
+func Test_Generate_Verify_V1(t *testing.T) {
+	t.Parallel()
+
+	// "cat" is a branch with a hashed value
+	entries := map[string][]byte{
+		"cat":       append(make([]byte, 35), 3),
+		"catapulta": make([]byte, 40),
+		"catapora":  append(make([]byte, 32), 1),
+		"dog":       []byte("woof"),
+	}
+
+	tr := inmemory.NewEmptyTrie()
+	tr.SetVersion(trie.V1)
+	for key, value := range entries {
+		tr.Put([]byte(key), value)
+	}
+
+	rootHash, err := trie.V1.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	for key, value := range entries {
+		fullKeys := [][]byte{[]byte(key)}
+		proof, err := Generate(rootHash.ToBytes(), fullKeys, db)
+		require.NoError(t, err)
+
+		err = Verify(proof, rootHash.ToBytes(), []byte(key), value)
+		require.NoError(t, err)
+	}
+
+	fullKeys := make([][]byte, 0, len(entries))
+	for key := range entries {
+		fullKeys = append(fullKeys, []byte(key))
+	}
+	proof, err := Generate(rootHash.ToBytes(), fullKeys, db)
+	require.NoError(t, err)
+
+	for key, value := range entries {
+		err = Verify(proof, rootHash.ToBytes(), []byte(key), value)
+		require.NoError(t, err)
+	}
+}
+
+func Test_Generate_Read(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory.NewEmptyTrie()
+	tr.Put([]byte("cat"), []byte("meow"))
+	tr.Put([]byte("catapulta"), []byte("launch"))
+	tr.Put([]byte("dog"), []byte("woof"))
+
+	rootHash, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	fullKeys := [][]byte{[]byte("cat"), []byte("dog")}
+	proof, err := Generate(rootHash.ToBytes(), fullKeys, db)
+	require.NoError(t, err)
+
+	value, err := Read(proof, rootHash.ToBytes(), []byte("cat"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("meow"), value)
+
+	value, err = Read(proof, rootHash.ToBytes(), []byte("dog"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("woof"), value)
+
+	_, err = Read(nil, rootHash.ToBytes(), []byte("cat"))
+	require.ErrorIs(t, err, ErrEmptyProof)
+}
+
+func Test_Read_absence(t *testing.T) {
+	t.Parallel()
+
+	// values are large enough for the nodes to be hashed in their parent
+	// encoding, and for the storage values to be hashed in a V1 trie.
+	entries := map[string][]byte{
+		"cat":       append(make([]byte, 40), 1),
+		"catapulta": append(make([]byte, 40), 2),
+		"dog":       append(make([]byte, 40), 3),
+	}
+
+	tr := inmemory.NewEmptyTrie()
+	tr.SetVersion(trie.V1)
+	for key, value := range entries {
+		tr.Put([]byte(key), value)
+	}
+
+	rootHash, err := trie.V1.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	proof, err := Generate(rootHash.ToBytes(), [][]byte{[]byte("dog")}, db)
+	require.NoError(t, err)
+
+	value, err := Read(proof, rootHash.ToBytes(), []byte("dog"))
+	require.NoError(t, err)
+	require.Equal(t, entries["dog"], value)
+
+	// the root node proves no key starts with the nibbles 0x65
+	value, err = Read(proof, rootHash.ToBytes(), []byte("eel"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// the path to "cat" and "cow" goes through a node missing from the proof
+	_, err = Read(proof, rootHash.ToBytes(), []byte("cat"))
+	require.ErrorIs(t, err, ErrIncompleteProof)
+	_, err = Read(proof, rootHash.ToBytes(), []byte("cow"))
+	require.ErrorIs(t, err, ErrIncompleteProof)
+
+	// the hashed value of "dog" is missing from the proof
+	var proofWithoutValue [][]byte
+	for _, encoded := range proof {
+		if !bytes.Equal(encoded, entries["dog"]) {
+			proofWithoutValue = append(proofWithoutValue, encoded)
+		}
+	}
+	_, err = Read(proofWithoutValue, rootHash.ToBytes(), []byte("dog"))
+	require.ErrorIs(t, err, ErrIncompleteProof)
+}
+
 func TestParachainHeaderStateProof(t *testing.T) {
 	stateRoot, err := hex.DecodeString("3b903e9947f26c4455f213b648661d0ef9b30018da7fa7be76bb5af2f5f75735")
 	require.NoError(t, err)
@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
 	"github.com/ChainSafe/gossamer/pkg/trie/db"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/pkg/trie/node"
@@ -42,6 +43,12 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 		return fmt.Errorf("building trie from proof encoded nodes: %w", err)
 	}
 
+	return verifyValue(proofTrie, rootHash, key, value)
+}
+
+// verifyValue verifies the key is in the proof trie given, and that its value
+// matches the value given if it is not empty.
+func verifyValue(proofTrie trie.Trie, rootHash, key, value []byte) (err error) {
 	proofTrieValue := proofTrie.Get(key)
 	if proofTrieValue == nil {
 		return fmt.Errorf("%w: %s in proof trie for root hash 0x%x",
@@ -57,19 +64,128 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 	return nil
 }
 
+// Read returns the value of the key in the trie with the given root hash, using the encoded
+// proof nodes given. A nil value is only returned if the proof nodes prove the key is absent
+// from the trie, and ErrIncompleteProof is returned if a node or hashed value on the path
+// to the key is missing from the proof nodes.
+func Read(encodedProofNodes [][]byte, rootHash, key []byte) (value []byte, err error) {
+	proofDB, err := db.NewMemoryDBFromProof(encodedProofNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	root, digestToEncoding, err := decodeRoot(encodedProofNodes, rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveNode := func(merkleValue []byte) (*node.Node, error) {
+		encoding, ok := digestToEncoding[string(merkleValue)]
+		if !ok {
+			return nil, fmt.Errorf("%w: node with hash digest 0x%x",
+				ErrIncompleteProof, merkleValue)
+		}
+
+		decoded, err := node.Decode(bytes.NewReader(encoding))
+		if err != nil {
+			return nil, fmt.Errorf("decoding child node for hash digest 0x%x: %w",
+				merkleValue, err)
+		}
+		return decoded, nil
+	}
+
+	resolveValue := func(hash []byte) ([]byte, error) {
+		value, err := proofDB.Get(hash)
+		if err != nil {
+			return nil, fmt.Errorf("getting hashed value 0x%x: %w", hash, err)
+		} else if value == nil {
+			return nil, fmt.Errorf("%w: value with hash 0x%x", ErrIncompleteProof, hash)
+		}
+		return value, nil
+	}
+
+	return readPath(root, codec.KeyLEToNibbles(key), resolveNode, resolveValue)
+}
+
+// readPath walks the proven nodes from the root node given along the nibbles of
+// a full key, and returns the value of the key, or nil if the nodes walked prove
+// the key is absent. Child nodes only referenced by their hash digest and hashed
+// values are obtained with the resolve functions given.
+func readPath(root *node.Node, nibbles []byte,
+	resolveNode func(merkleValue []byte) (*node.Node, error),
+	resolveValue func(hash []byte) ([]byte, error)) (value []byte, err error) {
+	current := root
+	for {
+		if !bytes.HasPrefix(nibbles, current.PartialKey) {
+			return nil, nil
+		}
+		nibbles = nibbles[len(current.PartialKey):]
+
+		if len(nibbles) == 0 {
+			if current.IsHashedValue {
+				return resolveValue(current.StorageValue)
+			}
+			return current.StorageValue, nil
+		}
+
+		if current.Kind() != node.Branch {
+			return nil, nil
+		}
+
+		child := current.Children[nibbles[0]]
+		nibbles = nibbles[1:]
+		if child == nil {
+			return nil, nil
+		}
+
+		if isHashReference(child) {
+			child, err = resolveNode(child.MerkleValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+		current = child
+	}
+}
+
+// isHashReference returns true if the node given is a child node decoded from the
+// encoding of its parent as its hash digest only, and not as an inlined node.
+func isHashReference(n *node.Node) bool {
+	return len(n.MerkleValue) == common.HashLength && n.PartialKey == nil &&
+		n.StorageValue == nil && n.Children == nil
+}
+
 var (
 	ErrEmptyProof       = errors.New("proof slice empty")
 	ErrRootNodeNotFound = errors.New("root node not found in proof")
+	ErrIncompleteProof  = errors.New("proof does not cover the full key path")
 )
 
 // buildTrie sets a partial trie based on the proof slice of encoded nodes.
 func buildTrie(encodedProofNodes [][]byte, rootHash []byte, db db.Database) (t trie.Trie, err error) {
+	root, digestToEncoding, err := decodeRoot(encodedProofNodes, rootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadProof(digestToEncoding, root)
+	if err != nil {
+		return nil, fmt.Errorf("loading proof: %w", err)
+	}
+
+	return inmemory.NewTrie(root, db), nil
+}
+
+// decodeRoot decodes the root node of the proof slice of encoded nodes, and returns
+// the other encoded nodes in a mapping from their encoding hash digest to their encoding.
+func decodeRoot(encodedProofNodes [][]byte, rootHash []byte) (
+	root *node.Node, digestToEncoding map[string][]byte, err error) {
 	if len(encodedProofNodes) == 0 {
-		return nil, fmt.Errorf("%w: for Merkle root hash 0x%x",
+		return nil, nil, fmt.Errorf("%w: for Merkle root hash 0x%x",
 			ErrEmptyProof, rootHash)
 	}
 
-	digestToEncoding := make(map[string][]byte, len(encodedProofNodes))
+	digestToEncoding = make(map[string][]byte, len(encodedProofNodes))
 
 	// note we can use a buffer from the pool since
 	// the calculated root hash digest is not used after
@@ -82,7 +198,6 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte, db db.Database) (t t
 	// 2. It stores other encoded nodes in a mapping from their encoding digest to
 	//    their encoding. They are only decoded later if the root or one of its
 	//    descendant nodes reference their hash digest.
-	var root *node.Node
 	for _, encodedProofNode := range encodedProofNodes {
 		// Note all encoded proof nodes are one of the following:
 		// - trie root node
@@ -94,7 +209,7 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte, db db.Database) (t t
 		buffer.Reset()
 		err = node.MerkleValueRoot(encodedProofNode, buffer)
 		if err != nil {
-			return nil, fmt.Errorf("calculating node hash: %w", err)
+			return nil, nil, fmt.Errorf("calculating node hash: %w", err)
 		}
 		digest := buffer.Bytes()
 
@@ -107,7 +222,7 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte, db db.Database) (t t
 
 		root, err = node.Decode(bytes.NewReader(encodedProofNode))
 		if err != nil {
-			return nil, fmt.Errorf("decoding root node: %w", err)
+			return nil, nil, fmt.Errorf("decoding root node: %w", err)
 		}
 		// The built proof trie is not used with a database, but just in case
 		// it becomes used with a database in the future, we set the dirty flag
@@ -121,16 +236,11 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte, db db.Database) (t t
 			hashDigestHex := common.BytesToHex([]byte(hashDigestString))
 			proofHashDigests = append(proofHashDigests, hashDigestHex)
 		}
-		return nil, fmt.Errorf("%w: for root hash 0x%x in proof hash digests %s",
+		return nil, nil, fmt.Errorf("%w: for root hash 0x%x in proof hash digests %s",
 			ErrRootNodeNotFound, rootHash, strings.Join(proofHashDigests, ", "))
 	}
 
-	err = loadProof(digestToEncoding, root)
-	if err != nil {
-		return nil, fmt.Errorf("loading proof: %w", err)
-	}
-
-	return inmemory.NewTrie(root, db), nil
+	return root, digestToEncoding, nil
 }
 
 // loadProof is a recursive function that will create all the trie paths based
@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package trie
+
+import "bytes"
+
+// KeyIterator is the trie interface required by PrefixIterator
+type KeyIterator interface {
+	KVStoreRead
+	NextKey(key []byte) []byte
+}
+
+// PrefixIterator iterates over the keys of a trie having a prefix, in lexicographic
+// order. Keys are looked up one at a time, so they are never all held in memory.
+type PrefixIterator struct {
+	trie       KeyIterator
+	prefix     []byte
+	startAfter []byte
+	cursor     []byte
+	done       bool
+}
+
+// NewPrefixIterator returns an iterator over the keys of the trie given having the
+// prefix given. If startAfter is not nil, only the keys after it are iterated over,
+// so a previous iteration can be resumed from its last key.
+func NewPrefixIterator(trie KeyIterator, prefix, startAfter []byte) *PrefixIterator {
+	return &PrefixIterator{
+		trie:       trie,
+		prefix:     prefix,
+		startAfter: startAfter,
+	}
+}
+
+// NextKey returns the next key with the prefix, or nil once all keys are iterated over.
+func (i *PrefixIterator) NextKey() (key []byte) {
+	if i.done {
+		return nil
+	}
+
+	switch {
+	case i.cursor != nil:
+		key = i.trie.NextKey(i.cursor)
+	case i.startAfter == nil || bytes.Compare(i.startAfter, i.prefix) < 0:
+		// the prefix itself is the first key with the prefix
+		key = i.prefix
+		if i.trie.Get(key) == nil {
+			key = i.trie.NextKey(key)
+		}
+	default:
+		key = i.trie.NextKey(i.startAfter)
+	}
+
+	if key == nil || !bytes.HasPrefix(key, i.prefix) {
+		i.done = true
+		return nil
+	}
+	i.cursor = key
+	return key
+}
+
+// Next returns the next key with the prefix and its value, or nil once all keys are
+// iterated over.
+func (i *PrefixIterator) Next() (key, value []byte) {
+	key = i.NextKey()
+	if key == nil {
+		return nil, nil
+	}
+	return key, i.trie.Get(key)
+}
+
+// GetKeysWithPrefixPaged returns at most limit keys of the trie having the prefix
+// given, starting after the startAfter key if it is not nil.
+func GetKeysWithPrefixPaged(trie KeyIterator, prefix, startAfter []byte, limit uint) (keys [][]byte) {
+	iterator := NewPrefixIterator(trie, prefix, startAfter)
+	for uint(len(keys)) < limit {
+		key := iterator.NextKey()
+		if key == nil {
+			break
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// CountKeysWithPrefix returns the number of keys of the trie having the prefix given.
+func CountKeysWithPrefix(trie KeyIterator, prefix []byte) (count uint) {
+	iterator := NewPrefixIterator(trie, prefix, nil)
+	for iterator.NextKey() != nil {
+		count++
+	}
+	return count
+}
+
+// EstimateSizeWithPrefix returns the total size in bytes of the keys and values of
+// the trie having the prefix given. It does not account for the trie nodes overhead.
+func EstimateSizeWithPrefix(trie KeyIterator, prefix []byte) (size uint) {
+	iterator := NewPrefixIterator(trie, prefix, nil)
+	for {
+		key, value := iterator.Next()
+		if key == nil {
+			return size
+		}
+		size += uint(len(key) + len(value))
+	}
+}
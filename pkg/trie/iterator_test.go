@@ -0,0 +1,123 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package trie
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sortedKeyValues is a KeyIterator over key values kept in lexicographic order
+type sortedKeyValues struct {
+	keys   [][]byte
+	values map[string][]byte
+}
+
+func newSortedKeyValues(keyValues map[string]string) *sortedKeyValues {
+	s := &sortedKeyValues{values: make(map[string][]byte, len(keyValues))}
+	for key, value := range keyValues {
+		s.keys = append(s.keys, []byte(key))
+		s.values[key] = []byte(value)
+	}
+	sort.Slice(s.keys, func(i, j int) bool {
+		return bytes.Compare(s.keys[i], s.keys[j]) < 0
+	})
+	return s
+}
+
+func (s *sortedKeyValues) Get(key []byte) []byte {
+	return s.values[string(key)]
+}
+
+func (s *sortedKeyValues) NextKey(key []byte) []byte {
+	for _, k := range s.keys {
+		if bytes.Compare(k, key) > 0 {
+			return k
+		}
+	}
+	return nil
+}
+
+func Test_GetKeysWithPrefixPaged(t *testing.T) {
+	t.Parallel()
+
+	trie := newSortedKeyValues(map[string]string{
+		"a":   "0",
+		"ab":  "1",
+		"abc": "2",
+		"abd": "3",
+		"b":   "4",
+	})
+
+	testCases := map[string]struct {
+		prefix     []byte
+		startAfter []byte
+		limit      uint
+		keys       [][]byte
+	}{
+		"all_keys": {
+			limit: 10,
+			keys:  [][]byte{[]byte("a"), []byte("ab"), []byte("abc"), []byte("abd"), []byte("b")},
+		},
+		"prefix_included": {
+			prefix: []byte("ab"),
+			limit:  10,
+			keys:   [][]byte{[]byte("ab"), []byte("abc"), []byte("abd")},
+		},
+		"limited": {
+			prefix: []byte("a"),
+			limit:  2,
+			keys:   [][]byte{[]byte("a"), []byte("ab")},
+		},
+		"start_after_key": {
+			prefix:     []byte("a"),
+			startAfter: []byte("ab"),
+			limit:      10,
+			keys:       [][]byte{[]byte("abc"), []byte("abd")},
+		},
+		"start_after_before_prefix": {
+			prefix:     []byte("ab"),
+			startAfter: []byte("a"),
+			limit:      10,
+			keys:       [][]byte{[]byte("ab"), []byte("abc"), []byte("abd")},
+		},
+		"start_after_all_keys": {
+			prefix:     []byte("a"),
+			startAfter: []byte("abd"),
+			limit:      10,
+		},
+		"no_key_with_prefix": {
+			prefix: []byte("c"),
+			limit:  10,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			keys := GetKeysWithPrefixPaged(trie, testCase.prefix, testCase.startAfter, testCase.limit)
+			assert.Equal(t, testCase.keys, keys)
+		})
+	}
+}
+
+func Test_CountKeysWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	trie := newSortedKeyValues(map[string]string{
+		"a":   "0",
+		"ab":  "1",
+		"abc": "22",
+		"b":   "3",
+	})
+
+	assert.Equal(t, uint(3), CountKeysWithPrefix(trie, []byte("a")))
+	assert.Equal(t, uint(0), CountKeysWithPrefix(trie, []byte("c")))
+	assert.Equal(t, uint(3+5), EstimateSizeWithPrefix(trie, []byte("ab")))
+}
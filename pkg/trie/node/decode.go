@@ -34,6 +34,17 @@
 // For branch decoding, see the comments on decodeBranch.
 // For leaf decoding, see the comments on decodeLeaf.
 func Decode(reader io.Reader) (n *Node, err error) {
+	return decode(reader, false)
+}
+
+// DecodeCompact decodes a node from its encoding in a compact proof, where the
+// children encoded after their parent in the proof are replaced by empty child
+// references. These omitted children are decoded with an empty non-nil Merkle value.
+func DecodeCompact(reader io.Reader) (n *Node, err error) {
+	return decode(reader, true)
+}
+
+func decode(reader io.Reader, compact bool) (n *Node, err error) {
 	variant, partialKeyLength, err := decodeHeader(reader)
 	if err != nil {
 		return nil, fmt.Errorf("decoding header: %w", err)
@@ -49,7 +60,7 @@ func Decode(reader io.Reader) (n *Node, err error) {
 		}
 		return n, nil
 	case branchVariant, branchWithValueVariant, branchWithHashedValueVariant:
-		n, err = decodeBranch(reader, variant, partialKeyLength)
+		n, err = decodeBranch(reader, variant, partialKeyLength, compact)
 		if err != nil {
 			return nil, fmt.Errorf("cannot decode branch: %w", err)
 		}
@@ -65,7 +76,8 @@ func Decode(reader io.Reader) (n *Node, err error) {
 // reconstructing the child nodes from the encoding. This function instead stubs where the
 // children are known to be with an empty leaf. The children nodes hashes are then used to
 // find other storage values using the persistent database.
-func decodeBranch(reader io.Reader, variant variant, partialKeyLength uint16) (
+// If compact is true, empty child references are decoded as omitted children.
+func decodeBranch(reader io.Reader, variant variant, partialKeyLength uint16, compact bool) (
 	node *Node, err error) {
 	node = &Node{
 		Children: make([]*Node, ChildrenCapacity),
@@ -116,7 +128,8 @@ func decodeBranch(reader io.Reader, variant variant, partialKeyLength uint16) (
 		childNode := &Node{
 			MerkleValue: hash,
 		}
-		if len(hash) < hashLength {
+		omittedChild := compact && len(hash) == 0
+		if !omittedChild && len(hash) < hashLength {
 			// Handle inlined nodes
 			reader = bytes.NewReader(hash)
 			childNode, err = Decode(reader)
@@ -179,6 +179,7 @@ func Test_decodeBranch(t *testing.T) {
 		reader           io.Reader
 		nodeVariant      variant
 		partialKeyLength uint16
+		compact          bool
 		branch           *Node
 		errWrapped       error
 		errMessage       string
@@ -235,6 +236,29 @@ func Test_decodeBranch(t *testing.T) {
 				Descendants: 1,
 			},
 		},
+		"compact_branch_with_omitted_child": {
+			reader: bytes.NewBuffer(
+				concatByteSlices([][]byte{
+					{9},    // key data
+					{0, 4}, // children bitmap
+					{0},    // omitted child
+				}),
+			),
+			nodeVariant:      branchVariant,
+			partialKeyLength: 1,
+			compact:          true,
+			branch: &Node{
+				PartialKey: []byte{9},
+				Children: padRightChildren([]*Node{
+					nil, nil, nil, nil, nil,
+					nil, nil, nil, nil, nil,
+					{
+						MerkleValue: []byte{},
+					},
+				}),
+				Descendants: 1,
+			},
+		},
 		"value_decoding_error_for_branch_with_value_variant": {
 			reader: bytes.NewBuffer(
 				concatByteSlices([][]byte{
@@ -333,7 +357,7 @@ func Test_decodeBranch(t *testing.T) {
 			t.Parallel()
 
 			branch, err := decodeBranch(testCase.reader,
-				testCase.nodeVariant, testCase.partialKeyLength)
+				testCase.nodeVariant, testCase.partialKeyLength, testCase.compact)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if err != nil {
@@ -21,7 +21,7 @@ func (n *Node) Encode(buffer Buffer) (err error) {
 		return err
 	}
 
-	err = encodeHeader(n, n.MustBeHashed, buffer)
+	err = encodeHeader(n, n.MustBeHashed || n.IsHashedValue, buffer)
 	if err != nil {
 		return fmt.Errorf("cannot encode header: %w", err)
 	}
@@ -57,6 +57,11 @@ func (n *Node) Encode(buffer Buffer) (err error) {
 			if err != nil {
 				return fmt.Errorf("writing hashed storage value: %w", err)
 			}
+		case n.IsHashedValue:
+			_, err = buffer.Write(n.StorageValue)
+			if err != nil {
+				return fmt.Errorf("writing hashed storage value: %w", err)
+			}
 		default:
 			encoder := scale.NewEncoder(buffer)
 			err = encoder.Encode(n.StorageValue)
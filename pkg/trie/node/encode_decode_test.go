@@ -124,7 +124,7 @@ func Test_Branch_Encode_Decode(t *testing.T) {
 			nodeVariant, partialKeyLength, err := decodeHeader(buffer)
 			require.NoError(t, err)
 
-			resultBranch, err := decodeBranch(buffer, nodeVariant, partialKeyLength)
+			resultBranch, err := decodeBranch(buffer, nodeVariant, partialKeyLength, false)
 			require.NoError(t, err)
 
 			assert.Equal(t, testCase.branchDecoded, resultBranch)
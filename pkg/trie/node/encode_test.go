@@ -131,6 +131,20 @@ func Test_Node_Encode(t *testing.T) {
 				{written: hashedLargeValue},
 			},
 		},
+		"leaf_with_hashed_value_success": {
+			node: &Node{
+				PartialKey:    []byte{1, 2, 3},
+				StorageValue:  hashedLargeValue,
+				IsHashedValue: true,
+			},
+			writes: []writeCall{
+				{
+					written: []byte{leafWithHashedValueVariant.bits | 3},
+				},
+				{written: []byte{0x01, 0x23}},
+				{written: hashedLargeValue},
+			},
+		},
 		"leaf_with_value_gt_max_fail": {
 			node: &Node{
 				PartialKey:    []byte{1, 2, 3},
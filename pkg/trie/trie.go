@@ -56,6 +56,7 @@ type TrieDeltas interface {
 
 type Versioned interface {
 	SetVersion(TrieLayout)
+	Version() TrieLayout
 }
 
 type Hashable interface {
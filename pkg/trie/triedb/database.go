@@ -0,0 +1,138 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package triedb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	trienode "github.com/ChainSafe/gossamer/pkg/trie/node"
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb/codec"
+)
+
+// WriteDirty writes all the nodes modified in memory to the database,
+// and releases them so they are loaded from the database from now on.
+func (t *TrieDB) WriteDirty(db db.NewBatcher) error {
+	switch root := t.root.(type) {
+	case nil:
+		t.rootHash = trie.EmptyHash
+		return nil
+	case *node:
+		batch := db.NewBatch()
+		rootHash, err := t.hashRoot(root, batch.Put)
+		if err != nil {
+			batch.Reset()
+			return err
+		}
+
+		err = batch.Flush()
+		if err != nil {
+			return fmt.Errorf("flushing batch: %w", err)
+		}
+
+		t.rootHash = rootHash
+		t.root = newPersistedHash(rootHash.ToBytes())
+		return nil
+	default:
+		return nil
+	}
+}
+
+// hashRoot returns the root hash of the trie rooted at the node given.
+// If the put function is not nil, it is called with the encoding of each
+// node modified in memory, and with each of their values to be hashed,
+// keyed as they should be stored in the database.
+func (t *TrieDB) hashRoot(root *node, put func(key, value []byte) error) (
+	rootHash common.Hash, err error) {
+	merkleValue, err := t.encode(root, true, put)
+	if err != nil {
+		return rootHash, err
+	}
+	return common.BytesToHash(merkleValue), nil
+}
+
+// encode encodes the node given and returns its Merkle value,
+// encoding first its children modified in memory.
+func (t *TrieDB) encode(n *node, isRoot bool, put func(key, value []byte) error) (
+	merkleValue []byte, err error) {
+	encodable := &trienode.Node{
+		PartialKey: n.partialKey,
+		Dirty:      true,
+	}
+
+	switch value := n.value.(type) {
+	case codec.InlineValue:
+		encodable.StorageValue = value.Data
+		encodable.MustBeHashed = t.version == trie.V1 && len(value.Data) > trie.V1.MaxInlineValue()
+	case codec.HashedValue:
+		encodable.StorageValue = value.Data
+		encodable.IsHashedValue = true
+	}
+
+	if count, _ := n.numChildren(); count > 0 {
+		encodable.Children = make([]*trienode.Node, codec.ChildrenCapacity)
+	}
+	for i, handle := range n.children {
+		var childMerkleValue []byte
+		switch child := handle.(type) {
+		case nil:
+			continue
+		case persisted:
+			switch merkleValue := child.merkleValue.(type) {
+			case codec.InlineNode:
+				childMerkleValue = merkleValue.Data
+			case codec.HashedNode:
+				childMerkleValue = merkleValue.Data
+			}
+		case *node:
+			childMerkleValue, err = t.encode(child, false, put)
+			if err != nil {
+				return nil, fmt.Errorf("encoding child at index %d: %w", i, err)
+			}
+		}
+		// The child Merkle value is already computed, so
+		// it is used as is to encode the node.
+		encodable.Children[i] = &trienode.Node{MerkleValue: childMerkleValue}
+	}
+
+	var encoding []byte
+	if isRoot {
+		encoding, merkleValue, err = encodable.EncodeAndHashRoot()
+	} else {
+		encoding, merkleValue, err = encodable.EncodeAndHash()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoding and hashing node: %w", err)
+	}
+
+	if put == nil {
+		return merkleValue, nil
+	}
+
+	if encodable.MustBeHashed {
+		hashedValue := common.MustBlake2bHash(encodable.StorageValue)
+		prefixedKey := bytes.Join([][]byte{n.partialKey, hashedValue[:]}, nil)
+		err = put(prefixedKey, encodable.StorageValue)
+		if err != nil {
+			return nil, fmt.Errorf("putting hashed storage value in database: %w", err)
+		}
+	}
+
+	if len(merkleValue) < common.HashLength {
+		// Merkle value is the node encoding which is less than 32 bytes.
+		// That means this node encoding is inlined in its parent node encoding,
+		// and so it is not needed to write it in the database.
+		return merkleValue, nil
+	}
+
+	err = put(merkleValue, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("putting encoding of node with node hash 0x%x in database: %w",
+			merkleValue, err)
+	}
+	return merkleValue, nil
+}
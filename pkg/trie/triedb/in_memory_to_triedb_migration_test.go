@@ -7,6 +7,7 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/stretchr/testify/assert"
@@ -57,7 +58,7 @@ func TestTrieDB_Get(t *testing.T) {
 func TestTrieDB_Lookup(t *testing.T) {
 	t.Run("root_not_exists_in_db", func(t *testing.T) {
 		db := newTestDB(t)
-		trieDB := NewTrieDB(trie.EmptyHash, db)
+		trieDB := NewTrieDB(common.Hash{1}, db)
 
 		value, err := trieDB.lookup([]byte("test"))
 		assert.Nil(t, value)
@@ -3,14 +3,138 @@
 
 package triedb
 
+import (
+	"bytes"
+
+	nibbles "github.com/ChainSafe/gossamer/pkg/trie/codec"
+)
+
 // Entries returns all the key-value pairs in the trie as a map of keys to values
 // where the keys are encoded in Little Endian.
+// Note all the nodes of the trie are loaded from the database.
 func (t *TrieDB) Entries() (keyValueMap map[string][]byte) {
-	panic("not implemented yet")
+	keyValueMap = make(map[string][]byte)
+	root, err := t.resolve(t.root)
+	if err != nil || root == nil {
+		return keyValueMap
+	}
+
+	_, err = t.appendEntries(root, nil, nil, keyValueMap)
+	if err != nil {
+		return nil
+	}
+	return keyValueMap
 }
 
 // NextKey returns the next key in the trie in lexicographic order.
 // It returns nil if no next key is found.
 func (t *TrieDB) NextKey(key []byte) []byte {
-	panic("not implemented yet")
+	nextKey, err := t.nextKey(t.root, nil, nibbles.KeyLEToNibbles(key))
+	if err != nil || nextKey == nil {
+		return nil
+	}
+	return nibbles.NibblesToKeyLE(nextKey)
+}
+
+// nextKey returns the smallest full key in nibbles of the subtrie referenced
+// by the handle which is strictly bigger than the search key given in nibbles.
+// The path argument is the full key in nibbles of the subtrie parent.
+func (t *TrieDB) nextKey(handle nodeHandle, path, searchKey []byte) ([]byte, error) {
+	n, err := t.resolve(handle)
+	if err != nil || n == nil {
+		return nil, err
+	}
+
+	fullKey := bytes.Join([][]byte{path, n.partialKey}, nil)
+	if !bytes.HasPrefix(searchKey, fullKey) {
+		if bytes.Compare(fullKey, searchKey) < 0 {
+			// All the keys of the subtrie are smaller than the search key
+			return nil, nil
+		}
+		return t.firstKey(n, fullKey)
+	}
+
+	// The search key is in the subtrie, so only the children from the search
+	// key child index are bigger than it, and the node key is not.
+	startIndex := 0
+	if len(searchKey) > len(fullKey) {
+		childIndex := searchKey[len(fullKey)]
+		childPath := append(fullKey[:len(fullKey):len(fullKey)], childIndex)
+		nextKey, err := t.nextKey(n.children[childIndex], childPath, searchKey)
+		if err != nil || nextKey != nil {
+			return nextKey, err
+		}
+		startIndex = int(childIndex) + 1
+	}
+
+	return t.firstChildKey(n, fullKey, startIndex)
+}
+
+// firstKey returns the smallest full key in nibbles of the subtrie
+// rooted at the node given, which has the full key given in nibbles.
+func (t *TrieDB) firstKey(n *node, fullKey []byte) ([]byte, error) {
+	if n.value != nil {
+		return fullKey, nil
+	}
+	return t.firstChildKey(n, fullKey, 0)
+}
+
+// firstChildKey returns the smallest full key in nibbles of the children
+// of the node given from the start index given.
+func (t *TrieDB) firstChildKey(n *node, fullKey []byte, startIndex int) ([]byte, error) {
+	for i := startIndex; i < len(n.children); i++ {
+		child, err := t.resolve(n.children[i])
+		if err != nil {
+			return nil, err
+		} else if child == nil {
+			continue
+		}
+
+		childFullKey := make([]byte, 0, len(fullKey)+1+len(child.partialKey))
+		childFullKey = append(childFullKey, fullKey...)
+		childFullKey = append(childFullKey, byte(i))
+		childFullKey = append(childFullKey, child.partialKey...)
+		key, err := t.firstKey(child, childFullKey)
+		if err != nil || key != nil {
+			return key, err
+		}
+	}
+	return nil, nil
+}
+
+// appendEntries appends the keys in little Endian format of the subtrie
+// rooted at the node given in lexicographic order, and sets their values
+// in the entries map if it is not nil.
+// The path argument is the full key in nibbles of the node parent.
+func (t *TrieDB) appendEntries(n *node, path []byte, keysLE [][]byte,
+	entries map[string][]byte) ([][]byte, error) {
+	fullKey := bytes.Join([][]byte{path, n.partialKey}, nil)
+	if n.value != nil {
+		keyLE := nibbles.NibblesToKeyLE(fullKey)
+		keysLE = append(keysLE, keyLE)
+
+		if entries != nil {
+			value, err := t.loadValue(n.partialKey, n.value)
+			if err != nil {
+				return nil, err
+			}
+			entries[string(keyLE)] = value
+		}
+	}
+
+	for i, handle := range n.children {
+		child, err := t.resolve(handle)
+		if err != nil {
+			return nil, err
+		} else if child == nil {
+			continue
+		}
+
+		childPath := append(fullKey[:len(fullKey):len(fullKey)], byte(i))
+		keysLE, err = t.appendEntries(child, childPath, keysLE, entries)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keysLE, nil
 }
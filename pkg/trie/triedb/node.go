@@ -0,0 +1,114 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package triedb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/pkg/trie/triedb/codec"
+)
+
+// nodeHandle is a reference to a trie node, which is either
+// a node persisted in the database or a node modified in memory.
+type nodeHandle interface {
+	isNodeHandle()
+}
+
+// persisted is a handle to a node not loaded from the database yet.
+// Its Merkle value is either the hash of the node encoding to look up
+// in the database, or the node encoding itself if it is inlined.
+type persisted struct {
+	merkleValue codec.MerkleValue
+}
+
+// node is a trie node held in memory. Nodes are never modified
+// once created, so that tries sharing nodes can be modified
+// independently, copying on write only the nodes on the path
+// to the modified key.
+type node struct {
+	// partialKey is the partial key of the node in nibbles.
+	partialKey []byte
+	// value is nil for a branch without value.
+	value    codec.NodeValue
+	children [codec.ChildrenCapacity]nodeHandle
+}
+
+func (persisted) isNodeHandle() {}
+func (*node) isNodeHandle()     {}
+
+func newPersistedHash(hash []byte) persisted {
+	return persisted{merkleValue: codec.NewHashedNode(hash)}
+}
+
+// numChildren returns the number of children of the node
+// and the index of the last of them.
+func (n *node) numChildren() (count int, lastIndex byte) {
+	for i, child := range n.children {
+		if child != nil {
+			count++
+			lastIndex = byte(i)
+		}
+	}
+	return count, lastIndex
+}
+
+// copy returns a shallow copy of the node, sharing its children.
+func (n *node) copy() *node {
+	nodeCopy := *n
+	return &nodeCopy
+}
+
+// resolve returns the node the handle given references,
+// loading and decoding it from the database if needed.
+// It returns a nil node for a nil handle.
+func (t *TrieDB) resolve(handle nodeHandle) (*node, error) {
+	switch h := handle.(type) {
+	case nil:
+		return nil, nil
+	case *node:
+		return h, nil
+	case persisted:
+		switch merkleValue := h.merkleValue.(type) {
+		case codec.InlineNode:
+			return decodeNode(merkleValue.Data)
+		case codec.HashedNode:
+			encoding, err := t.db.Get(merkleValue.Data)
+			if err != nil || encoding == nil {
+				return nil, fmt.Errorf("%w: node with hash 0x%x", ErrIncompleteDB, merkleValue.Data)
+			}
+			return decodeNode(encoding)
+		default:
+			panic(fmt.Sprintf("unknown merkle value type %T", h.merkleValue))
+		}
+	default:
+		panic(fmt.Sprintf("unknown node handle type %T", handle))
+	}
+}
+
+// decodeNode decodes the node encoding given, keeping its children
+// as persisted handles so they are only loaded when needed.
+func decodeNode(encoding []byte) (*node, error) {
+	decoded, err := codec.Decode(bytes.NewReader(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding node: %w", err)
+	}
+
+	switch n := decoded.(type) {
+	case codec.Empty:
+		return nil, nil
+	case codec.Leaf:
+		return &node{partialKey: n.PartialKey, value: n.Value}, nil
+	case codec.Branch:
+		branch := &node{partialKey: n.PartialKey, value: n.Value}
+		for i, child := range n.Children {
+			if child != nil {
+				branch.children[i] = persisted{merkleValue: child}
+			}
+		}
+		return branch, nil
+	default:
+		panic(fmt.Sprintf("unknown decoded node type %T", decoded))
+	}
+}
@@ -18,25 +18,75 @@
 var ErrIncompleteDB = errors.New("incomplete database")
 
 // TrieDB is a DB-backed patricia merkle trie implementation
-// using lazy loading to fetch nodes
+// using lazy loading to fetch nodes.
+// Modifications are kept in memory, copying on write the nodes
+// on the path to the modified keys, until they are written to
+// the database with WriteDirty.
 type TrieDB struct {
 	rootHash common.Hash
+	root     nodeHandle
 	db       db.DBGetter
+	version  trie.TrieLayout
 }
 
 // NewTrieDB creates a new TrieDB using the given root and db
 func NewTrieDB(rootHash common.Hash, db db.DBGetter) *TrieDB {
+	if rootHash == trie.EmptyHash {
+		// The empty trie root node is not stored in the database
+		return NewEmptyTrieDB(db)
+	}
+
 	return &TrieDB{
 		rootHash: rootHash,
+		root:     newPersistedHash(rootHash.ToBytes()),
+		db:       db,
+		version:  trie.V0,
+	}
+}
+
+// NewEmptyTrieDB creates a new empty TrieDB using the given db
+func NewEmptyTrieDB(db db.DBGetter) *TrieDB {
+	return &TrieDB{
+		rootHash: trie.EmptyHash,
 		db:       db,
+		version:  trie.V0,
 	}
 }
 
+// SetVersion sets the state trie version used to encode modified nodes.
+func (t *TrieDB) SetVersion(v trie.TrieLayout) {
+	if v < t.version {
+		panic("cannot regress trie version")
+	}
+
+	t.version = v
+}
+
+// Version returns the state trie version used to encode modified nodes.
+func (t *TrieDB) Version() trie.TrieLayout {
+	return t.version
+}
+
+// Snapshot returns a copy of the trie sharing its nodes.
+// Since nodes are copied on write, the copy and the trie
+// can then be modified independently, for example to execute
+// a block on top of the state of its parent.
+func (t *TrieDB) Snapshot() *TrieDB {
+	trieCopy := *t
+	return &trieCopy
+}
+
 // Hash returns the hashed root of the trie.
 func (t *TrieDB) Hash() (common.Hash, error) {
-	// This is trivial since it is a read only trie, but will change when we
-	// support writes
-	return t.rootHash, nil
+	switch root := t.root.(type) {
+	case nil:
+		return trie.EmptyHash, nil
+	case *node:
+		return t.hashRoot(root, nil)
+	default:
+		// The root is not modified since it was loaded
+		return t.rootHash, nil
+	}
 }
 
 // MustHash returns the hashed root of the trie.
@@ -61,98 +111,99 @@ func (t *TrieDB) Get(key []byte) []byte {
 	return val
 }
 
+// Put inserts a value into the trie at the
+// key specified in little Endian format.
+func (t *TrieDB) Put(key, value []byte) error {
+	if value == nil {
+		// A nil value would be mistaken for a branch without value
+		value = []byte{}
+	}
+
+	root, err := t.insert(t.root, nibbles.KeyLEToNibbles(key), value)
+	if err != nil {
+		return err
+	}
+
+	t.root = root
+	return nil
+}
+
+// Delete removes the node of the trie with the key
+// matching the key given in little Endian format.
+// If no node is found at this key, nothing is deleted.
+func (t *TrieDB) Delete(key []byte) error {
+	root, deleted, err := t.delete(t.root, nibbles.KeyLEToNibbles(key))
+	if err != nil {
+		return err
+	}
+
+	if deleted {
+		t.root = root
+	}
+	return nil
+}
+
 // GetKeysWithPrefix returns all keys in little Endian
 // format from nodes in the trie that have the given little
 // Endian formatted prefix in their key.
+// It panics if a node cannot be loaded from the database,
+// use KeysWithPrefix to handle the error instead.
 func (t *TrieDB) GetKeysWithPrefix(prefix []byte) (keysLE [][]byte) {
-	panic("not implemented yet")
+	keysLE, err := t.KeysWithPrefix(prefix)
+	if err != nil {
+		panic(err)
+	}
+	return keysLE
+}
+
+// KeysWithPrefix returns all keys in little Endian
+// format from nodes in the trie that have the given little
+// Endian formatted prefix in their key, loading from the
+// database only the nodes under the prefix.
+func (t *TrieDB) KeysWithPrefix(prefix []byte) (keysLE [][]byte, err error) {
+	return t.getKeysWithPrefix(t.root, nil, nibbles.KeyLEToNibbles(prefix), nil)
 }
 
 // Internal methods
 
 func (t *TrieDB) lookup(key []byte) ([]byte, error) {
 	keyNibbles := nibbles.KeyLEToNibbles(key)
-	return t.lookupWithoutCache(keyNibbles)
+	return t.lookupAt(t.root, keyNibbles)
 }
 
-// lookupWithoutCache traverse nodes loading then from DB until reach the one
-// we are looking for.
-func (t *TrieDB) lookupWithoutCache(nibbleKey []byte) ([]byte, error) {
-	// Start from root node and going downwards
-	partialKey := nibbleKey
-	hash := t.rootHash[:]
-
-	// Iterates through non inlined nodes
+// lookupAt traverses nodes, loading them from the DB if they are not
+// modified in memory, until it reaches the one we are looking for.
+func (t *TrieDB) lookupAt(handle nodeHandle, partialKey []byte) ([]byte, error) {
 	for {
-		// Get node from DB
-		nodeData, err := t.db.Get(hash)
-
+		n, err := t.resolve(handle)
 		if err != nil {
-			return nil, ErrIncompleteDB
+			return nil, err
 		}
 
-	InlinedChildrenIterator:
-		for {
-			// Decode node
-			reader := bytes.NewReader(nodeData)
-			decodedNode, err := codec.Decode(reader)
-			if err != nil {
-				return nil, err
-			}
-
-			var nextNode codec.MerkleValue
+		// This could happen if for some reason one branch has a hashed
+		// child node that points to a node that doesn't share the prefix
+		// we are expecting
+		if n == nil || !bytes.HasPrefix(partialKey, n.partialKey) {
+			return nil, nil
+		}
 
-			switch n := decodedNode.(type) {
-			case codec.Empty:
+		// We are in the node we were looking for
+		if len(partialKey) == len(n.partialKey) {
+			if n.value == nil {
 				return nil, nil
-			case codec.Leaf:
-				// We are in the node we were looking for
-				if bytes.Equal(partialKey, n.PartialKey) {
-					return t.loadValue(partialKey, n.Value)
-				}
-				return nil, nil
-			case codec.Branch:
-				nodePartialKey := n.PartialKey
-
-				// This is unusual but could happen if for some reason one
-				// branch has a hashed child node that points to a node that
-				// doesn't share the prefix we are expecting
-				if !bytes.HasPrefix(partialKey, nodePartialKey) {
-					return nil, nil
-				}
-
-				// We are in the node we were looking for
-				if bytes.Equal(partialKey, nodePartialKey) {
-					if n.Value != nil {
-						return t.loadValue(partialKey, n.Value)
-					}
-					return nil, nil
-				}
-
-				// This is not the node we were looking for but it might be in
-				// one of its children
-				childIdx := int(partialKey[len(nodePartialKey)])
-				nextNode = n.Children[childIdx]
-				if nextNode == nil {
-					return nil, nil
-				}
-
-				// Advance the partial key consuming the part we already checked
-				partialKey = partialKey[len(nodePartialKey)+1:]
 			}
+			return t.loadValue(n.partialKey, n.value)
+		}
 
-			// Next node could be inlined or hashed (pointer to a node)
-			// https://spec.polkadot.network/chap-state#defn-merkle-value
-			switch merkleValue := nextNode.(type) {
-			case codec.HashedNode:
-				// If it's hashed we set the hash to look for it in next loop
-				hash = merkleValue.Data
-				break InlinedChildrenIterator
-			case codec.InlineNode:
-				// If it is inlined we just need to decode it in the next loop
-				nodeData = merkleValue.Data
-			}
+		// This is not the node we were looking for but it might be in
+		// one of its children
+		handle = n.children[partialKey[len(n.partialKey)]]
+		if handle == nil {
+			return nil, nil
 		}
+
+		// Advance the partial key consuming the part we already checked
+		partialKey = partialKey[len(n.partialKey)+1:]
 	}
 }
 
@@ -170,4 +221,188 @@ func (t *TrieDB) loadValue(prefix []byte, value codec.NodeValue) ([]byte, error)
 	}
 }
 
-var _ trie.TrieRead = (*TrieDB)(nil)
+// insert inserts the value at the key given in nibbles in the subtrie
+// referenced by the handle, and returns the new root of the subtrie.
+// Nodes on the path to the key are copied so the subtrie is unchanged.
+func (t *TrieDB) insert(handle nodeHandle, key, value []byte) (*node, error) {
+	n, err := t.resolve(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == nil {
+		return &node{partialKey: key, value: codec.NewInlineValue(value)}, nil
+	}
+
+	commonPrefixLength := lenCommonPrefix(n.partialKey, key)
+	if commonPrefixLength == len(n.partialKey) {
+		newNode := n.copy()
+		if len(key) == len(n.partialKey) {
+			newNode.value = codec.NewInlineValue(value)
+			return newNode, nil
+		}
+
+		childIndex := key[commonPrefixLength]
+		child, err := t.insert(n.children[childIndex], key[commonPrefixLength+1:], value)
+		if err != nil {
+			return nil, err
+		}
+		newNode.children[childIndex] = child
+		return newNode, nil
+	}
+
+	// The node partial key and the key diverge, so a new branch
+	// is needed with the common prefix as its partial key.
+	branch := &node{partialKey: key[:commonPrefixLength]}
+	movedNode, err := t.withPartialKey(n, n.partialKey[commonPrefixLength+1:])
+	if err != nil {
+		return nil, err
+	}
+	branch.children[n.partialKey[commonPrefixLength]] = movedNode
+
+	if commonPrefixLength == len(key) {
+		branch.value = codec.NewInlineValue(value)
+	} else {
+		branch.children[key[commonPrefixLength]] = &node{
+			partialKey: key[commonPrefixLength+1:],
+			value:      codec.NewInlineValue(value),
+		}
+	}
+	return branch, nil
+}
+
+// delete removes the value at the key given in nibbles from the subtrie
+// referenced by the handle, and returns the new root of the subtrie
+// and whether a value was deleted.
+func (t *TrieDB) delete(handle nodeHandle, key []byte) (
+	newHandle nodeHandle, deleted bool, err error) {
+	n, err := t.resolve(handle)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if n == nil || !bytes.HasPrefix(key, n.partialKey) {
+		return handle, false, nil
+	}
+
+	newNode := n.copy()
+	if len(key) == len(n.partialKey) {
+		if n.value == nil {
+			return handle, false, nil
+		}
+		newNode.value = nil
+	} else {
+		childIndex := key[len(n.partialKey)]
+		child, deleted, err := t.delete(n.children[childIndex], key[len(n.partialKey)+1:])
+		if err != nil {
+			return nil, false, err
+		} else if !deleted {
+			return handle, false, nil
+		}
+		newNode.children[childIndex] = child
+	}
+
+	fixedNode, err := t.fix(newNode)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if fixedNode == nil {
+		// Return an untyped nil so the handle compares to nil
+		return nil, true, nil
+	}
+	return fixedNode, true, nil
+}
+
+// fix restores the node invariants after a deletion: a node without value
+// has no reason to exist without children, and is merged with its child
+// if it has a single one.
+func (t *TrieDB) fix(n *node) (*node, error) {
+	if n.value != nil {
+		return n, nil
+	}
+
+	count, childIndex := n.numChildren()
+	switch count {
+	case 0:
+		return nil, nil
+	case 1:
+		child, err := t.resolve(n.children[childIndex])
+		if err != nil {
+			return nil, err
+		}
+
+		partialKey := make([]byte, 0, len(n.partialKey)+1+len(child.partialKey))
+		partialKey = append(partialKey, n.partialKey...)
+		partialKey = append(partialKey, childIndex)
+		partialKey = append(partialKey, child.partialKey...)
+		return t.withPartialKey(child, partialKey)
+	default:
+		return n, nil
+	}
+}
+
+// withPartialKey returns a copy of the node with the partial key given.
+// Since hashed values are stored in the database prefixed with the partial
+// key of their node, a hashed value is loaded to be written again with the
+// new partial key.
+func (t *TrieDB) withPartialKey(n *node, partialKey []byte) (*node, error) {
+	newNode := n.copy()
+	newNode.partialKey = partialKey
+
+	if hashedValue, ok := n.value.(codec.HashedValue); ok {
+		value, err := t.loadValue(n.partialKey, hashedValue)
+		if err != nil {
+			return nil, err
+		}
+		newNode.value = codec.NewInlineValue(value)
+	}
+	return newNode, nil
+}
+
+// getKeysWithPrefix appends the keys in little Endian format of the subtrie
+// referenced by the handle which have the prefix given in nibbles.
+// The path argument is the full key in nibbles of the subtrie parent.
+func (t *TrieDB) getKeysWithPrefix(handle nodeHandle, path, prefix []byte,
+	keysLE [][]byte) ([][]byte, error) {
+	n, err := t.resolve(handle)
+	if err != nil || n == nil {
+		return keysLE, err
+	}
+
+	switch {
+	case len(prefix) <= len(n.partialKey):
+		if !bytes.HasPrefix(n.partialKey, prefix) {
+			return keysLE, nil
+		}
+		return t.appendEntries(n, path, keysLE, nil)
+	case bytes.HasPrefix(prefix, n.partialKey):
+		childIndex := prefix[len(n.partialKey)]
+		childPath := makeChildPath(path, n.partialKey, childIndex)
+		return t.getKeysWithPrefix(n.children[childIndex], childPath,
+			prefix[len(n.partialKey)+1:], keysLE)
+	default:
+		return keysLE, nil
+	}
+}
+
+func lenCommonPrefix(a, b []byte) (length int) {
+	maxLength := min(len(a), len(b))
+	for length < maxLength && a[length] == b[length] {
+		length++
+	}
+	return length
+}
+
+func makeChildPath(path, partialKey []byte, childIndex byte) []byte {
+	childPath := make([]byte, 0, len(path)+len(partialKey)+1)
+	childPath = append(childPath, path...)
+	childPath = append(childPath, partialKey...)
+	return append(childPath, childIndex)
+}
+
+var (
+	_ trie.TrieRead     = (*TrieDB)(nil)
+	_ trie.KVStoreWrite = (*TrieDB)(nil)
+	_ trie.Versioned    = (*TrieDB)(nil)
+)
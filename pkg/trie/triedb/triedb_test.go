@@ -0,0 +1,174 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package triedb
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntries() map[string][]byte {
+	return map[string][]byte{
+		"no":           []byte("short"),
+		"not":          make([]byte, 40),
+		"nothing":      []byte{},
+		"notification": append(make([]byte, 39), 1),
+		"test":         []byte("value"),
+		"testing":      make([]byte, 33),
+	}
+}
+
+func Test_TrieDB_PutDelete(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []trie.TrieLayout{trie.V0, trie.V1} {
+		version := version
+		t.Run(version.String(), func(t *testing.T) {
+			t.Parallel()
+
+			inMemoryTrie := inmemory.NewEmptyTrie()
+			inMemoryTrie.SetVersion(version)
+			trieDB := NewEmptyTrieDB(newTestDB(t))
+			trieDB.SetVersion(version)
+
+			for k, v := range testEntries() {
+				require.NoError(t, inMemoryTrie.Put([]byte(k), v))
+				require.NoError(t, trieDB.Put([]byte(k), v))
+			}
+			assert.Equal(t, inMemoryTrie.MustHash(), trieDB.MustHash())
+
+			for _, k := range []string{"not", "test", "missing", "no"} {
+				require.NoError(t, inMemoryTrie.Delete([]byte(k)))
+				require.NoError(t, trieDB.Delete([]byte(k)))
+				assert.Equal(t, inMemoryTrie.MustHash(), trieDB.MustHash())
+			}
+			assert.Equal(t, inMemoryTrie.Entries(), trieDB.Entries())
+
+			for k := range testEntries() {
+				require.NoError(t, trieDB.Delete([]byte(k)))
+			}
+			assert.Equal(t, trie.EmptyHash, trieDB.MustHash())
+		})
+	}
+}
+
+func Test_TrieDB_WriteDirty(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []trie.TrieLayout{trie.V0, trie.V1} {
+		version := version
+		t.Run(version.String(), func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDB(t)
+			inMemoryTrie := inmemory.NewEmptyTrie()
+			inMemoryTrie.SetVersion(version)
+			trieDB := NewEmptyTrieDB(db)
+			trieDB.SetVersion(version)
+
+			for k, v := range testEntries() {
+				require.NoError(t, inMemoryTrie.Put([]byte(k), v))
+				require.NoError(t, trieDB.Put([]byte(k), v))
+			}
+
+			err := trieDB.WriteDirty(db)
+			require.NoError(t, err)
+			root := inMemoryTrie.MustHash()
+			assert.Equal(t, root, trieDB.MustHash())
+
+			// Nodes are lazily loaded from the database
+			loaded := NewTrieDB(root, db)
+			loaded.SetVersion(version)
+			assert.Equal(t, inMemoryTrie.Entries(), loaded.Entries())
+			assert.Equal(t, inMemoryTrie.GetKeysWithPrefix([]byte("not")),
+				loaded.GetKeysWithPrefix([]byte("not")))
+			for _, key := range []string{"", "no", "not", "nothing", "o", "test", "testing"} {
+				assert.Equal(t, inMemoryTrie.NextKey([]byte(key)), loaded.NextKey([]byte(key)), key)
+			}
+
+			// Modifying the loaded trie moves persisted nodes with hashed values
+			for _, key := range []string{"nothing", "test", "no"} {
+				require.NoError(t, inMemoryTrie.Delete([]byte(key)))
+				require.NoError(t, loaded.Delete([]byte(key)))
+			}
+			require.NoError(t, inMemoryTrie.Put([]byte("notary"), make([]byte, 35)))
+			require.NoError(t, loaded.Put([]byte("notary"), make([]byte, 35)))
+			assert.Equal(t, inMemoryTrie.MustHash(), loaded.MustHash())
+
+			err = loaded.WriteDirty(db)
+			require.NoError(t, err)
+			reloaded := NewTrieDB(inMemoryTrie.MustHash(), db)
+			assert.Equal(t, inMemoryTrie.Entries(), reloaded.Entries())
+		})
+	}
+}
+
+func Test_TrieDB_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	trieDB := NewEmptyTrieDB(db)
+	for k, v := range testEntries() {
+		require.NoError(t, trieDB.Put([]byte(k), v))
+	}
+	require.NoError(t, trieDB.WriteDirty(db))
+	root := trieDB.MustHash()
+
+	snapshot := trieDB.Snapshot()
+	require.NoError(t, snapshot.Put([]byte("nothing"), []byte("new")))
+	require.NoError(t, snapshot.Delete([]byte("test")))
+
+	modified := snapshot.Snapshot()
+	require.NoError(t, modified.Put([]byte("other"), []byte("value")))
+
+	assert.Equal(t, root, trieDB.MustHash())
+	assert.Equal(t, []byte{}, trieDB.Get([]byte("nothing")))
+	assert.Equal(t, []byte("value"), trieDB.Get([]byte("test")))
+
+	assert.NotEqual(t, root, snapshot.MustHash())
+	assert.Equal(t, []byte("new"), snapshot.Get([]byte("nothing")))
+	assert.Nil(t, snapshot.Get([]byte("test")))
+	assert.Nil(t, snapshot.Get([]byte("other")))
+
+	assert.Equal(t, []byte("value"), modified.Get([]byte("other")))
+}
+
+func Test_NewTrieDB_emptyRoot(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	trieDB := NewTrieDB(trie.EmptyHash, db)
+	assert.Nil(t, trieDB.Get([]byte("test")))
+
+	err := trieDB.Put([]byte("test"), []byte("value"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), trieDB.Get([]byte("test")))
+}
+
+func Test_TrieDB_KeysWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	trieDB := NewEmptyTrieDB(db)
+	for k, v := range testEntries() {
+		require.NoError(t, trieDB.Put([]byte(k), v))
+	}
+	require.NoError(t, trieDB.WriteDirty(db))
+
+	keys, err := NewTrieDB(trieDB.MustHash(), db).KeysWithPrefix([]byte("test"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("test"), []byte("testing")}, keys)
+
+	keys, err = NewTrieDB(common.Hash{1}, db).KeysWithPrefix([]byte("test"))
+	assert.ErrorIs(t, err, ErrIncompleteDB)
+	assert.Nil(t, keys)
+	assert.Panics(t, func() {
+		NewTrieDB(common.Hash{1}, db).GetKeysWithPrefix([]byte("test"))
+	})
+}
@@ -127,6 +127,13 @@ func TestStableNetworkRPC(t *testing.T) { //nolint:tparallel
 				var response modules.SystemHealthResponse
 				fetchWithTimeoutFromEndpoint(t, endpoint, "system_health", &response)
 
+				// the node services are supervised since the node started
+				require.NotEmpty(t, response.Services)
+				for _, service := range response.Services {
+					assert.Equal(t, "running", service.State, service.Name)
+				}
+				response.Services = nil
+
 				expectedResponse := modules.SystemHealthResponse{
 					Peers:           len(nodes) - 1,
 					IsSyncing:       false,
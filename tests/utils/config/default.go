@@ -64,7 +64,11 @@ func Default() cfg.Config {
 				"system", "author", "chain", "state", "rpc",
 				"grandpa", "offchain", "childstate", "syncstate", "payment"},
 		},
-		State:  &cfg.StateConfig{},
+		State: &cfg.StateConfig{},
+		Sync: &cfg.SyncConfig{
+			MaxRequests:        cfg.DefaultSyncMaxRequests,
+			MaxRequestsPerPeer: cfg.DefaultSyncMaxRequestsPerPeer,
+		},
 		Pprof:  &cfg.PprofConfig{},
 		System: &cfg.SystemConfig{},
 	}
@@ -19,6 +19,8 @@ func ParseNetworkRole(r common.NetworkRole) string {
 		return cfg.LightNode.String()
 	case common.AuthorityRole:
 		return cfg.AuthorityNode.String()
+	case common.RPCNodeRole:
+		return cfg.RPCNode.String()
 	default:
 		return "Unknown"
 	}